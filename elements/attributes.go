@@ -0,0 +1,414 @@
+package elements
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type attributeKind uint8
+
+const (
+	attributeString attributeKind = iota
+	// attributeTrusted values skip the contextual escapers.
+	attributeTrusted
+	// attributeBool attributes are rendered bare, and repeat their name as
+	// value in XML.
+	attributeBool
+	// attributeEmpty is an empty value rendered bare, it is used by the empty
+	// choices such as hidden="" or popover="".
+	attributeEmpty
+	attributeInt
+	attributeFloat
+	attributeDelimited
+	attributeKeyValue
+	// attributeNonce stands for the nonce of the render context.
+	attributeNonce
+)
+
+// attribute is an entry of the attribute store of an element. The field
+// holding the value depends on the kind.
+type attribute struct {
+	name  string
+	kind  attributeKind
+	str   string
+	num   int
+	float float64
+	// precision is the precision hint of a float, zero when there is none.
+	precision int
+	delimited *delimitedBuilder[string]
+	keyValue  *keyValueBuilder
+	// typed converts the value of choice and rune attributes to the type of
+	// their setter, see AttrValue.
+	typed func(string) any
+}
+
+// appendValue appends the attribute value to dst, without any escaping. Floats
+// are rounded to the smallest positive precision of the renderer and the
+// attribute.
+func (a *attribute) appendValue(dst []byte, precision int) []byte {
+	switch a.kind {
+	case attributeString, attributeTrusted:
+		return append(dst, a.str...)
+	case attributeInt:
+		return strconv.AppendInt(dst, int64(a.num), 10)
+	case attributeFloat:
+		if a.precision > 0 && (precision <= 0 || a.precision < precision) {
+			precision = a.precision
+		}
+		return appendNumber(dst, a.float, precision)
+	case attributeDelimited:
+		return a.delimited.appendTo(dst)
+	case attributeKeyValue:
+		return a.keyValue.appendTo(dst)
+	}
+	return dst
+}
+
+// attributeIndex returns the position of name in the attribute store, which
+// is sorted by name, and whether it is set.
+func (e *Element) attributeIndex(name string) (int, bool) {
+	return slices.BinarySearchFunc(e.attributes, name, func(a attribute, name string) int {
+		return strings.Compare(a.name, name)
+	})
+}
+
+func (e *Element) getAttribute(name string) *attribute {
+	if i, ok := e.attributeIndex(name); ok {
+		return &e.attributes[i]
+	}
+	return nil
+}
+
+// setAttribute sets a, replacing any value of another kind set under the
+// same name.
+func (e *Element) setAttribute(a attribute) {
+	e.checkWritable()
+	i, ok := e.attributeIndex(a.name)
+	if ok {
+		e.attributes[i] = a
+		return
+	}
+	e.attributes = slices.Insert(e.attributes, i, a)
+}
+
+func (e *Element) removeAttribute(name string) {
+	e.checkWritable()
+	if i, ok := e.attributeIndex(name); ok {
+		e.attributes = slices.Delete(e.attributes, i, i+1)
+	}
+}
+
+func (e *Element) setStringAttribute(name, value string) {
+	e.setAttribute(attribute{name: name, kind: attributeString, str: value})
+}
+
+// setChoiceAttribute sets an enumerated attribute, the empty choice is
+// rendered bare. typed converts the value to the choice type of the setter.
+func (e *Element) setChoiceAttribute(name, value string, typed func(string) any) {
+	if value == "" {
+		e.setAttribute(attribute{name: name, kind: attributeEmpty, typed: typed})
+		return
+	}
+	e.setAttribute(attribute{name: name, kind: attributeString, str: value, typed: typed})
+}
+
+// choiceValue converts the value of a choice attribute to its type T.
+func choiceValue[T ~string](value string) any {
+	return T(value)
+}
+
+func (e *Element) setRuneAttribute(name string, r rune) {
+	e.setAttribute(attribute{name: name, kind: attributeString, str: string(r), typed: runeValue})
+}
+
+func runeValue(value string) any {
+	r, _ := utf8.DecodeRuneInString(value)
+	return r
+}
+
+func (e *Element) setIntAttribute(name string, value int) {
+	e.setAttribute(attribute{name: name, kind: attributeInt, num: value})
+}
+
+var ErrInvalidNumber = errors.New("invalid number")
+
+func (e *Element) setFloatAttribute(name string, value float64, precision int) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		e.setErr(fmt.Errorf("%w: %s=%v", ErrInvalidNumber, name, value))
+		return
+	}
+	e.setAttribute(attribute{name: name, kind: attributeFloat, float: value, precision: precision})
+}
+
+func (e *Element) setBoolAttribute(name string) {
+	e.setAttribute(attribute{name: name, kind: attributeBool})
+}
+
+// delimitedAttribute returns the builder of a delimited attribute, creating
+// it if needed. A string value already set under the same name is split into
+// the initial values.
+func (e *Element) delimitedAttribute(name, delimiter string) *delimitedBuilder[string] {
+	e.checkWritable()
+	a := e.getAttribute(name)
+	if a != nil && a.kind == attributeDelimited {
+		return a.delimited
+	}
+	ds := newDelimitedBuilder[string](delimiter)
+	if a != nil && (a.kind == attributeString || a.kind == attributeTrusted) && a.str != "" {
+		ds.Add(strings.Split(a.str, delimiter)...)
+	}
+	e.setAttribute(attribute{name: name, kind: attributeDelimited, delimited: ds})
+	return ds
+}
+
+func (e *Element) removeDelimitedValues(name string, values ...string) {
+	e.checkWritable()
+	if a := e.getAttribute(name); a != nil && a.kind == attributeDelimited {
+		a.delimited.Remove(values...)
+	}
+}
+
+// keyValueAttribute returns the builder of a key-value attribute, creating it
+// if needed.
+func (e *Element) keyValueAttribute(name, keyPairDelimiter, entryDelimiter string) *keyValueBuilder {
+	e.checkWritable()
+	if a := e.getAttribute(name); a != nil && a.kind == attributeKeyValue {
+		return a.keyValue
+	}
+	kv := newKVBuilder(keyPairDelimiter, entryDelimiter)
+	e.setAttribute(attribute{name: name, kind: attributeKeyValue, keyValue: kv})
+	return kv
+}
+
+func (e *Element) removeKeyValues(name string, keys ...string) {
+	e.checkWritable()
+	if a := e.getAttribute(name); a != nil && a.kind == attributeKeyValue {
+		a.keyValue.Remove(keys...)
+	}
+}
+
+// renderAttributes writes the attributes in name order, along with the
+// nonce of the render context and the namespace declarations of XML namespace
+// roots.
+func (e *Element) renderAttributes(rw *renderWriter) error {
+	var declarations [3]attribute
+	extra := declarations[:0]
+	if e.takesNonce() {
+		extra = append(extra, attribute{name: "nonce", kind: attributeNonce})
+	}
+	if rw.renderer.XML && rw.isNamespaceRoot() {
+		if e.getAttribute("xmlns") == nil {
+			extra = append(extra, attribute{name: "xmlns", kind: attributeTrusted, str: namespaceURIs[e.namespace]})
+		}
+		if e.namespace == namespaceSVG && e.getAttribute("xmlns:xlink") == nil && usesXLink(e) {
+			extra = append(extra, attribute{name: "xmlns:xlink", kind: attributeTrusted, str: xlinkNamespaceURI})
+		}
+	}
+
+	attrs := e.attributes
+	for len(attrs) > 0 || len(extra) > 0 {
+		if len(extra) > 0 && (len(attrs) == 0 || extra[0].name < attrs[0].name) {
+			if extra[0].kind == attributeNonce {
+				if err := rw.renderNonce(e); err != nil {
+					return err
+				}
+			} else {
+				e.renderAttribute(rw, &extra[0])
+			}
+			extra = extra[1:]
+			continue
+		}
+		e.renderAttribute(rw, &attrs[0])
+		attrs = attrs[1:]
+	}
+	return nil
+}
+
+func (e *Element) renderAttribute(rw *renderWriter, a *attribute) {
+	xml := rw.renderer.XML
+
+	rw.Write(space)
+	rw.WriteString(a.name)
+
+	switch a.kind {
+	case attributeBool:
+		if xml {
+			// XML has no boolean attributes, they repeat their name.
+			rw.Write(equal)
+			rw.Write(doubleQuotes)
+			rw.writeAttributeValue(append(rw.scratch[:0], a.name...))
+			rw.Write(doubleQuotes)
+		}
+		return
+	case attributeEmpty:
+		if xml {
+			rw.Write(equal)
+			rw.Write(doubleQuotes)
+			rw.Write(doubleQuotes)
+		}
+		return
+	}
+
+	trusted := a.kind == attributeTrusted
+	var value []byte
+	if rw.renderer.Contextual && !trusted {
+		c := attributeEscapeContext(string(e.tag), a.name)
+		switch {
+		case a.kind == attributeKeyValue && c == escapeContextCSS:
+			value = a.keyValue.appendFiltered(rw.scratch[:0], filterCSSDeclaration)
+		case c != escapeContextNone:
+			value = a.appendValue(rw.scratch[:0], rw.renderer.Precision)
+			value = append(value[:0], escapeAttributeContext(c, string(value))...)
+		default:
+			value = a.appendValue(rw.scratch[:0], rw.renderer.Precision)
+		}
+	} else {
+		value = a.appendValue(rw.scratch[:0], rw.renderer.Precision)
+	}
+	rw.scratch = value[:0]
+	if a.name == "style" && len(value) > 0 {
+		rw.hashStyleAttribute(value)
+	}
+
+	if rw.minify() {
+		// Empty values are the same as no value.
+		if len(value) == 0 {
+			return
+		}
+		rw.Write(equal)
+		if needsQuotes(value) {
+			rw.Write(doubleQuotes)
+			rw.writeAttributeValue(value)
+			rw.Write(doubleQuotes)
+		} else {
+			rw.writeAttributeValue(value)
+		}
+		return
+	}
+
+	rw.Write(equal)
+	rw.Write(doubleQuotes)
+	rw.writeAttributeValue(value)
+	rw.Write(doubleQuotes)
+}
+
+// writeAttributeValue writes value with the characters that are significant
+// inside a quoted attribute value escaped.
+func (rw *renderWriter) writeAttributeValue(value []byte) {
+	last := 0
+	for i, c := range value {
+		var escaped string
+		switch c {
+		case '&':
+			escaped = "&amp;"
+		case '"':
+			escaped = "&#34;"
+		case '<':
+			escaped = "&lt;"
+		case '>':
+			escaped = "&gt;"
+		default:
+			continue
+		}
+		rw.Write(value[last:i])
+		rw.WriteString(escaped)
+		last = i + 1
+	}
+	rw.Write(value[last:])
+}
+
+func (d *delimitedBuilder[T]) appendTo(dst []byte) []byte {
+	for i := range d.values {
+		if i > 0 {
+			dst = append(dst, d.delimiter...)
+		}
+		// A pointer is boxed without allocating.
+		switch v := any(&d.values[i]).(type) {
+		case *string:
+			dst = append(dst, *v...)
+		default:
+			dst = fmt.Append(dst, d.values[i])
+		}
+	}
+	return dst
+}
+
+func (d *keyValueBuilder) appendTo(dst []byte) []byte {
+	for i, kv := range d.values {
+		if i > 0 {
+			dst = append(dst, d.entryDelimiter...)
+		}
+		dst = append(dst, kv.Key...)
+		dst = append(dst, d.keyPairDelimiter...)
+		dst = append(dst, kv.Value...)
+	}
+	return dst
+}
+
+// appendFiltered appends the pairs accepted by filter, with the key and value
+// it returns.
+func (d *keyValueBuilder) appendFiltered(dst []byte, filter func(key, value string) (string, string, bool)) []byte {
+	first := true
+	for _, kv := range d.values {
+		key, value, ok := filter(kv.Key, kv.Value)
+		if !ok {
+			continue
+		}
+		if !first {
+			dst = append(dst, d.entryDelimiter...)
+		}
+		first = false
+		dst = append(dst, key...)
+		dst = append(dst, d.keyPairDelimiter...)
+		dst = append(dst, value...)
+	}
+	return dst
+}
+
+// appendNumber appends f as a valid floating-point number, in decimal
+// notation. When precision is positive, f is rounded to at most precision
+// decimal places.
+func appendNumber(dst []byte, f float64, precision int) []byte {
+	if f == 0 {
+		// Drop the sign of negative zero.
+		f = 0
+	}
+	if precision <= 0 {
+		return strconv.AppendFloat(dst, f, 'f', -1, 64)
+	}
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, f, 'f', precision, 64)
+	n := len(bytes.TrimRight(dst[start:], "0"))
+	if dst[start+n-1] == '.' {
+		n--
+	}
+	dst = dst[:start+n]
+	if string(dst[start:]) == "-0" {
+		dst = append(dst[:start], '0')
+	}
+	return dst
+}
+
+// hasAttributePrefix reports whether the name of an attribute of e starts
+// with prefix.
+func (e *Element) hasAttributePrefix(prefix string) bool {
+	for i := range e.attributes {
+		if strings.HasPrefix(e.attributes[i].name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsQuotes reports whether an attribute value has to be quoted in minify
+// mode. The quote and angle brackets are escaped as character references so
+// they never require quotes.
+func needsQuotes(value []byte) bool {
+	return bytes.ContainsAny(value, " \t\n\f\r'=`")
+}
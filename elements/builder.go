@@ -1,12 +1,13 @@
 package elements
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"html"
 	"io"
-
-	"github.com/igrmk/treemap/v2"
-	"github.com/valyala/bytebufferpool"
+	"strings"
+	"sync/atomic"
 )
 
 var (
@@ -24,23 +25,67 @@ type ElementRenderer interface {
 
 type ElementRendererFunc func() ElementRenderer
 
+// ContextRenderer is implemented by the elements and groups, which pass the
+// context down to their children. Rendering stops with the error of the
+// context once it is done.
+type ContextRenderer interface {
+	ElementRenderer
+	RenderContext(ctx context.Context, w io.Writer) error
+}
+
+type ContextElementRendererFunc func(ctx context.Context) ElementRenderer
+
+type namespace uint8
+
+const (
+	namespaceHTML namespace = iota
+	namespaceSVG
+	namespaceMathML
+)
+
+// Element is an element of the tree, the generated types embed it.
+//
+// Rendering never modifies the tree and keeps its state in the render pass:
+// a tree may be rendered from many goroutines at once, provided nothing
+// modifies it meanwhile. Freezing a tree before sharing it makes sure of
+// that, as the setters of frozen elements modify copies instead.
 type Element struct {
-	tag              []byte
-	isSelfClosing    bool
-	intAttributes    *treemap.TreeMap[string, int]
-	floatAttributes  *treemap.TreeMap[string, float64]
-	stringAttributes *treemap.TreeMap[string, string]
-	delimitedStrings *treemap.TreeMap[string, *delimitedBuilder[string]]
-	keyValueStrings  *treemap.TreeMap[string, *keyValueBuilder]
-	boolAttributes   *treemap.TreeMap[string, struct{}]
-	descendants      []ElementRenderer
+	tag           []byte
+	namespace     namespace
+	isSelfClosing bool
+	err           error
+	// attributes is sorted by name.
+	attributes  []attribute
+	descendants []ElementRenderer
+	// original is the element of the tree a render-time copy is made from.
+	original *Element
+	frozen   bool
+}
+
+var ErrInvalidAttributeName = errors.New("invalid attribute name")
+
+// setErr records the first builder error on the element, it is returned by
+// Render.
+func (e *Element) setErr(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+func (e *Element) checkAttributeName(name string) bool {
+	if !isValidAttributeName(name) {
+		e.setErr(fmt.Errorf("%w: %q", ErrInvalidAttributeName, name))
+		return false
+	}
+	return true
 }
 
 func (e *Element) Attr(name string, value string) *Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
+	e = e.writable()
+	if !e.checkAttributeName(name) {
+		return e
 	}
-	e.stringAttributes.Set(name, value)
+	e.setStringAttribute(name, value)
 	return e
 }
 
@@ -48,122 +93,210 @@ func (e *Element) Attrs(attrs ...string) *Element {
 	if len(attrs)%2 != 0 {
 		panic("attrs must be a multiple of 2")
 	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
+	e = e.writable()
 	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
+		e.Attr(attrs[i], attrs[i+1])
 	}
 	return e
 }
 
 func (e *Element) AttrsMap(attrs map[string]string) *Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
+	e = e.writable()
 	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
+		e.Attr(k, v)
+	}
+	return e
+}
+
+// RawAttr sets an attribute whose value is trusted. The value is escaped as an
+// attribute value but never passes through the contextual escapers, which
+// makes it the way to set event handler code or javascript: URLs.
+func (e *Element) RawAttr(name string, value string) *Element {
+	e = e.writable()
+	if !e.checkAttributeName(name) {
+		return e
+	}
+	e.setAttribute(attribute{name: name, kind: attributeTrusted, str: value})
+	return e
+}
+
+func (e *Element) BoolAttr(name string) *Element {
+	e = e.writable()
+	if !e.checkAttributeName(name) {
+		return e
 	}
+	e.setBoolAttribute(name)
+	return e
+}
+
+// RemoveAttr removes the attribute, whatever its type.
+func (e *Element) RemoveAttr(name string) *Element {
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *Element) Render(w io.Writer) error {
-	w.Write(openBracket)
-	w.Write(e.tag)
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
 
-	finalKeys := treemap.New[string, string]()
+	if transformers := rw.renderer.Transformers; len(transformers) > 0 {
+		e = e.transform(rw.context(), transformers)
+	}
+	rw.stack = append(rw.stack, e)
+	err := e.render(rw)
+	rw.stack = rw.stack[:len(rw.stack)-1]
+	return err
+}
 
-	if e.intAttributes != nil && e.intAttributes.Len() > 0 {
-		for it := e.intAttributes.Iterator(); it.Valid(); it.Next() {
-			k, v := it.Key(), it.Value()
-			finalKeys.Set(k, fmt.Sprint(v))
-		}
+func (e *Element) id() (string, bool) {
+	a := e.getAttribute("id")
+	if a == nil || a.kind != attributeString {
+		return "", false
 	}
+	return a.str, true
+}
 
-	if e.floatAttributes != nil && e.floatAttributes.Len() > 0 {
-		for it := e.floatAttributes.Iterator(); it.Valid(); it.Next() {
-			k, v := it.Key(), it.Value()
-			finalKeys.Set(k, fmt.Sprint(v))
-		}
+func (e *Element) RenderContext(ctx context.Context, w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
 	}
+	defer rw.withContext(ctx)()
+	return e.Render(rw)
+}
 
-	if e.stringAttributes != nil && e.stringAttributes.Len() > 0 {
-		for it := e.stringAttributes.Iterator(); it.Valid(); it.Next() {
-			k, v := it.Key(), it.Value()
-			finalKeys.Set(k, v)
-		}
+// baseElement gives access to the Element embedded in the generated types.
+func (e *Element) baseElement() *Element {
+	return e
+}
+
+func (e *Element) render(rw *renderWriter) error {
+	f := rw.following
+	rw.following = following{}
+
+	if e.err != nil {
+		return rw.fail(e.err)
 	}
+	if err := rw.ctxErr(); err != nil {
+		return rw.fail(err)
+	}
+	minify := rw.minify()
+	xml := rw.renderer.XML
 
-	if e.delimitedStrings != nil && e.delimitedStrings.Len() > 0 {
-		for it := e.delimitedStrings.Iterator(); it.Valid(); it.Next() {
-			k, v := it.Key(), it.Value()
-			buf := bytebufferpool.Get()
-			if err := v.Render(buf); err != nil {
-				return err
-			}
-			finalKeys.Set(k, buf.String())
-			bytebufferpool.Put(buf)
-		}
+	rw.Write(openBracket)
+	rw.Write(e.tag)
+	if err := e.renderAttributes(rw); err != nil {
+		return rw.fail(err)
 	}
 
-	if e.keyValueStrings != nil && e.keyValueStrings.Len() > 0 {
-		for it := e.keyValueStrings.Iterator(); it.Valid(); it.Next() {
-			k, v := it.Key(), it.Value()
-			buf := bytebufferpool.Get()
-			if err := v.Render(buf); err != nil {
-				return err
-			}
-			finalKeys.Set(k, buf.String())
-			bytebufferpool.Put(buf)
+	if xml && (e.isSelfClosing || !hasChildren(e.descendants)) {
+		rw.Write(slash)
+		rw.Write(closeBracket)
+		if rw.err != nil {
+			return rw.fail(rw.err)
 		}
+		return nil
 	}
 
-	if e.boolAttributes != nil && e.boolAttributes.Len() > 0 {
-		for it := e.boolAttributes.Iterator(); it.Valid(); it.Next() {
-			finalKeys.Set(it.Key(), "")
-		}
+	rw.Write(closeBracket)
+	if rw.err != nil {
+		return rw.fail(rw.err)
 	}
 
-	if finalKeys.Len() > 0 {
-		for it := finalKeys.Iterator(); it.Valid(); it.Next() {
-			k, v := it.Key(), it.Value()
+	if e.isSelfClosing {
+		return nil
+	}
 
-			w.Write(space)
-			w.Write([]byte(k))
+	rawText := rw.rawText
+	rw.rawText = rawTextEscapeContext(e.tag)
+	hashesBody := e.hashesBody()
+	if hashesBody {
+		rw.beginBody()
+	}
+	err := e.renderChildren(rw)
+	if hashesBody {
+		rw.endBody(e)
+	}
+	rw.rawText = rawText
+	if err != nil {
+		return rw.fail(err)
+	}
 
-			if v != "" {
-				w.Write(equal)
-				w.Write(doubleQuotes)
-				w.Write(fmt.Append(nil, v))
-				w.Write(doubleQuotes)
-			}
+	if minify {
+		var parent *Element
+		if len(rw.stack) > 1 {
+			parent = rw.stack[len(rw.stack)-2]
+		}
+		if canOmitEndTag(e, parent, f) {
+			return nil
 		}
 	}
 
-	if e.isSelfClosing {
-		w.Write(closeBracket)
-		return nil
+	rw.Write(openBracket)
+	rw.Write(slash)
+	rw.Write(e.tag)
+	rw.Write(closeBracket)
+	if rw.err != nil {
+		return rw.fail(rw.err)
 	}
 
-	w.Write(closeBracket)
+	return nil
+}
+
+func (e *Element) renderChildren(rw *renderWriter) error {
+	if rw.renderer.Minify {
+		// Whitespace is collapsed in XML mode too, so the elements preserving
+		// it are tracked in both modes.
+		preserveSpace := rw.preserveSpace
+		rw.preserveSpace = preserveSpace || whitespacePreservingTags[string(e.tag)]
+		defer func() { rw.preserveSpace = preserveSpace }()
+		if rw.minify() {
+			return rw.renderMinified(e.descendants)
+		}
+	}
+
+	if rw.renderer.Indent != "" && !rw.compact {
+		if isBlockContainer(e) && allBlockLevel(e, e.descendants) {
+			n, err := rw.renderIndented(e.descendants)
+			if err == nil && n > 0 {
+				rw.newline()
+			}
+			return err
+		}
+
+		// Inline content is rendered as is, all the way down.
+		rw.compact = true
+		defer func() { rw.compact = false }()
+	}
 
 	for _, d := range e.descendants {
 		if d == nil {
 			continue
 		}
-		if err := d.Render(w); err != nil {
+		if err := d.Render(rw); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	w.Write(openBracket)
-	w.Write(slash)
-	w.Write(e.tag)
-	w.Write(closeBracket)
+// RenderIndent renders the element with its block-level descendants indented
+// with indent, see Renderer.Indent.
+func (e *Element) RenderIndent(w io.Writer, indent string) error {
+	return (&Renderer{Indent: indent}).Render(w, e)
+}
 
-	return nil
+// RenderMinified renders the element in minify mode, see Renderer.Minify.
+func (e *Element) RenderMinified(w io.Writer) error {
+	return (&Renderer{Minify: true}).Render(w, e)
+}
+
+// RenderXML renders the element as well-formed XML, see Renderer.XML.
+func (e *Element) RenderXML(w io.Writer) error {
+	return (&Renderer{XML: true}).Render(w, e)
 }
 
 type delimitedBuilder[T comparable] struct {
@@ -200,22 +333,6 @@ func (d *delimitedBuilder[T]) Remove(values ...T) *delimitedBuilder[T] {
 	return d
 }
 
-func (d *delimitedBuilder[T]) Render(w io.Writer) error {
-	for i, v := range d.values {
-		b := fmt.Append(nil, v)
-		if _, err := w.Write(b); err != nil {
-			return err
-		}
-
-		if i < len(d.values)-1 {
-			if _, err := w.Write([]byte(d.delimiter)); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
 type keyValue struct {
 	Key   string
 	Value string
@@ -261,46 +378,114 @@ func (d *keyValueBuilder) Remove(keys ...string) *keyValueBuilder {
 	return d
 }
 
-func (d *keyValueBuilder) Render(w io.Writer) error {
-	for i, kv := range d.values {
-		if _, err := w.Write([]byte(kv.Key)); err != nil {
-			return err
-		}
-		if _, err := w.Write([]byte(d.keyPairDelimiter)); err != nil {
-			return err
-		}
-		if _, err := w.Write([]byte(kv.Value)); err != nil {
-			return err
-		}
+// TextContent is a text node. Text is written as is, unless the node comes
+// from Text or Textf and safe text mode is enabled when it is rendered.
+type TextContent struct {
+	Text string
+	mode textMode
+	// format and args are kept by Textf to escape the arguments in safe text
+	// mode.
+	format string
+	args   []any
+}
 
-		if i < len(d.values)-1 {
-			if _, err := w.Write([]byte(d.entryDelimiter)); err != nil {
-				return err
-			}
+type textMode uint8
+
+const (
+	textRaw textMode = iota
+	textEscaped
+	textFormatted
+)
+
+func (tc *TextContent) Render(w io.Writer) error {
+	text := tc.Text
+	rw, ok := w.(*renderWriter)
+	if tc.mode != textRaw && safeText.Load() {
+		switch {
+		case ok && rw.rawText != escapeContextNone:
+			// The text of script and style is not HTML, it is not escaped but
+			// kept from ending the element.
+			text = neutralizeRawText(text)
+		case tc.mode == textFormatted:
+			text = tc.formatEscaped()
+		default:
+			text = html.EscapeString(text)
 		}
 	}
-	return nil
+	if ok && rw.collapseSpace() {
+		text = collapseWhitespace(text)
+	}
+	_, err := io.WriteString(w, text)
+	return err
 }
 
-type TextContent string
+var safeText atomic.Bool
 
-func (tc *TextContent) Render(w io.Writer) error {
-	_, err := w.Write([]byte(*tc))
-	return err
+// SetSafeText enables or disables the safe text mode. In safe text mode Text
+// and the Text methods of the elements HTML-escape the text, and Textf only
+// escapes its arguments. In script and style elements the text is not
+// escaped, but the sequences ending the element or starting a comment are
+// broken with a backslash. Raw and Rawf write text as is in both modes. The
+// mode is checked when rendering, so it applies to trees built before the
+// call too.
+func SetSafeText(enabled bool) {
+	safeText.Store(enabled)
+}
+
+// SafeText reports whether the safe text mode is enabled.
+func SafeText() bool {
+	return safeText.Load()
 }
 
 func Text(text string) *TextContent {
-	return (*TextContent)(&text)
+	return &TextContent{Text: text, mode: textEscaped}
 }
 
 func Textf(format string, args ...any) *TextContent {
-	return Text(fmt.Sprintf(format, args...))
+	return &TextContent{Text: fmt.Sprintf(format, args...), mode: textFormatted, format: format, args: args}
+}
+
+// formatEscaped formats the text of Textf with its arguments HTML-escaped.
+func (tc *TextContent) formatEscaped() string {
+	escaped := make([]any, len(tc.args))
+	for i, escape := range formattedArgs(tc.format, len(tc.args)) {
+		escaped[i] = tc.args[i]
+		if escape {
+			escaped[i] = escapedArg{tc.args[i]}
+		}
+	}
+	return fmt.Sprintf(tc.format, escaped...)
+}
+
+// Raw returns text written as is, in safe text mode too. It must not hold
+// untrusted input.
+func Raw(text string) *TextContent {
+	return &TextContent{Text: text}
+}
+
+func Rawf(format string, args ...any) *TextContent {
+	return Raw(fmt.Sprintf(format, args...))
 }
 
 type EscapedContent string
 
 func (ec *EscapedContent) Render(w io.Writer) error {
-	_, err := w.Write([]byte(html.EscapeString(string(*ec))))
+	text := string(*ec)
+	rw, ok := w.(*renderWriter)
+	if ok && rw.collapseSpace() {
+		text = collapseWhitespace(text)
+	}
+	if ok && rw.renderer.Contextual {
+		switch rw.rawText {
+		case escapeContextJS:
+			_, err := io.WriteString(w, jsStringLiteral(text))
+			return err
+		case escapeContextCSS:
+			_, err := io.WriteString(w, cssEscape(text))
+			return err
+		}
+	}
+	_, err := io.WriteString(w, html.EscapeString(text))
 	return err
 }
 
@@ -312,6 +497,125 @@ func Escapedf(format string, args ...any) *EscapedContent {
 	return Escaped(fmt.Sprintf(format, args...))
 }
 
+type DoctypeContent string
+
+func (dc *DoctypeContent) Render(w io.Writer) error {
+	for _, s := range [...]string{"<!DOCTYPE ", string(*dc), ">"} {
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var htmlDoctype = DoctypeContent("html")
+
+// Doctype returns the HTML doctype, <!DOCTYPE html>.
+func Doctype() *DoctypeContent {
+	name := "html"
+	return (*DoctypeContent)(&name)
+}
+
+type CommentContent string
+
+func (cc *CommentContent) Render(w io.Writer) error {
+	_, err := io.WriteString(w, "<!--"+escapeComment(string(*cc))+"-->")
+	return err
+}
+
+// Comment returns a comment node. Hyphens are spaced out where they would end
+// the comment early or make it invalid, so the text never contains "--".
+func Comment(text string) *CommentContent {
+	return (*CommentContent)(&text)
+}
+
+func Commentf(format string, args ...any) *CommentContent {
+	return Comment(fmt.Sprintf(format, args...))
+}
+
+type CDATAContent string
+
+func (cc *CDATAContent) Render(w io.Writer) error {
+	text := strings.ReplaceAll(string(*cc), "]]>", "]]]]><![CDATA[>")
+	_, err := io.WriteString(w, "<![CDATA["+text+"]]>")
+	return err
+}
+
+// CDATA returns a CDATA section, for use in XML output and in SVG and MathML
+// content. Occurrences of "]]>" are split across two sections.
+func CDATA(text string) *CDATAContent {
+	return (*CDATAContent)(&text)
+}
+
+type ProcessingInstructionContent struct {
+	Target string
+	Data   string
+}
+
+var ErrInvalidProcessingInstruction = errors.New("invalid processing instruction")
+
+func (pi *ProcessingInstructionContent) Render(w io.Writer) error {
+	if !isValidPITarget(pi.Target) {
+		return fmt.Errorf("%w: invalid target %q", ErrInvalidProcessingInstruction, pi.Target)
+	}
+	if strings.Contains(pi.Data, "?>") {
+		return fmt.Errorf("%w: data contains \"?>\"", ErrInvalidProcessingInstruction)
+	}
+	text := "<?" + pi.Target
+	if pi.Data != "" {
+		text += " " + pi.Data
+	}
+	_, err := io.WriteString(w, text+"?>")
+	return err
+}
+
+func ProcessingInstruction(target, data string) *ProcessingInstructionContent {
+	return &ProcessingInstructionContent{
+		Target: target,
+		Data:   data,
+	}
+}
+
+// XMLDeclaration returns the <?xml version="1.0" encoding="UTF-8"?>
+// declaration that starts standalone XML documents such as SVG files.
+func XMLDeclaration() *ProcessingInstructionContent {
+	return &ProcessingInstructionContent{
+		Target: "xml",
+		Data:   `version="1.0" encoding="UTF-8"`,
+	}
+}
+
+// DocumentContent is the root of an HTML document, it always starts with the
+// doctype.
+type DocumentContent struct {
+	Children []ElementRenderer
+}
+
+func (d *DocumentContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+
+	if rw.minify() || rw.renderer.Indent != "" {
+		// The doctype takes part in the layout of the top level.
+		children := make([]ElementRenderer, 0, len(d.Children)+1)
+		children = append(children, &htmlDoctype)
+		children = append(children, d.Children...)
+		return rw.renderTopLevel(children)
+	}
+	if err := htmlDoctype.Render(rw); err != nil {
+		return err
+	}
+	return rw.renderTopLevel(d.Children)
+}
+
+func Document(children ...ElementRenderer) *DocumentContent {
+	return &DocumentContent{
+		Children: children,
+	}
+}
+
 type Grouper struct {
 	Children []ElementRenderer
 }
@@ -321,15 +625,50 @@ func (g *Grouper) Render(w io.Writer) error {
 		return nil
 	}
 
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
 	for _, child := range g.Children {
-		if err := child.Render(w); err != nil {
-			return fmt.Errorf("failed to build element: %w", err)
+		if child == nil {
+			continue
+		}
+		if err := rw.ctxErr(); err != nil {
+			return rw.fail(err)
+		}
+		if err := child.Render(rw); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (g *Grouper) RenderContext(ctx context.Context, w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	defer rw.withContext(ctx)()
+	return g.Render(rw)
+}
+
+// RenderIndent renders the children with their block-level descendants
+// indented with indent, see Renderer.Indent.
+func (g *Grouper) RenderIndent(w io.Writer, indent string) error {
+	return (&Renderer{Indent: indent}).Render(w, g)
+}
+
+// RenderMinified renders the children in minify mode, see Renderer.Minify.
+func (g *Grouper) RenderMinified(w io.Writer) error {
+	return (&Renderer{Minify: true}).Render(w, g)
+}
+
+// RenderXML renders the children as well-formed XML, see Renderer.XML.
+func (g *Grouper) RenderXML(w io.Writer) error {
+	return (&Renderer{XML: true}).Render(w, g)
+}
+
 func Group(children ...ElementRenderer) *Grouper {
 	return &Grouper{
 		Children: children,
@@ -401,6 +740,44 @@ func DynIf(condition bool, childrenFuncs ...ElementRendererFunc) *Grouper {
 	return nil
 }
 
+// contextGroup calls its functions with the render context when it is
+// rendered.
+type contextGroup struct {
+	childrenFuncs []ContextElementRendererFunc
+}
+
+func (cg *contextGroup) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	ctx := rw.context()
+	children := make([]ElementRenderer, 0, len(cg.childrenFuncs))
+	for _, childFunc := range cg.childrenFuncs {
+		child := childFunc(ctx)
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return Group(children...).Render(rw)
+}
+
+// DynGroupContext is DynGroup with callbacks receiving the render context.
+// They are called at render time, each time the group is rendered.
+func DynGroupContext(childrenFuncs ...ContextElementRendererFunc) ElementRenderer {
+	return &contextGroup{
+		childrenFuncs: childrenFuncs,
+	}
+}
+
+// DynIfContext is DynIf with callbacks receiving the render context.
+func DynIfContext(condition bool, childrenFuncs ...ContextElementRendererFunc) ElementRenderer {
+	if condition {
+		return DynGroupContext(childrenFuncs...)
+	}
+	return nil
+}
+
 func DynTern(condition bool, trueChildren, falseChildren ElementRendererFunc) ElementRenderer {
 	if condition {
 		return trueChildren()
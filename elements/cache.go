@@ -0,0 +1,463 @@
+package elements
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// Cache stores rendered fragments. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the fragment stored under key, if it has not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores a fragment under key. A zero ttl never expires.
+	Set(key string, value []byte, ttl time.Duration, tags []string)
+	// Invalidate removes the fragments stored with any of the tags.
+	Invalidate(tags ...string)
+}
+
+// DefaultCache is used by the renderers without a Cache.
+var DefaultCache Cache = NewLRUCache(1024)
+
+// CachedContent renders its content once and replays the bytes from the
+// cache until they expire or are invalidated.
+type CachedContent struct {
+	key  string
+	ttl  time.Duration
+	tags []string
+	fn   ElementRendererFunc
+}
+
+// Cached returns a fragment stored under key for ttl. fn is only called when
+// the fragment is not in the cache. The key is shared by every fragment
+// rendered with the same options, so it must identify the content. The nonce
+// of the render context, see WithNonce, is written anew whenever the fragment
+// is. Renderers with Transformers but no TransformersKey call fn
+// on every render.
+func Cached(key string, ttl time.Duration, fn ElementRendererFunc) *CachedContent {
+	return &CachedContent{
+		key: key,
+		ttl: ttl,
+		fn:  fn,
+	}
+}
+
+// Tags adds tags to the fragment, invalidating any of them removes it from
+// the cache.
+func (c *CachedContent) Tags(tags ...string) *CachedContent {
+	c.tags = append(c.tags, tags...)
+	return c
+}
+
+func (c *CachedContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	if rw.compiler != nil || !rw.renderer.replayable() {
+		// Compiled content is rendered once already, and unidentified
+		// transformers are applied anew.
+		return c.render(rw)
+	}
+
+	cache := rw.renderer.Cache
+	if cache == nil {
+		cache = DefaultCache
+	}
+	key := rw.cacheKey(c.key)
+	hashes := rw.cspHashes()
+	if hashes != nil {
+		return c.renderHashed(rw, cache, key, hashes)
+	}
+	if b, ok := cache.Get(key); ok && rw.writeFragment(b) {
+		return rw.err
+	}
+
+	stored, err := c.record(rw)
+	if err != nil {
+		return err
+	}
+	cache.Set(key, stored, c.ttl, c.tags)
+	rw.writeFragment(stored)
+	return rw.err
+}
+
+// renderHashed renders the fragment collecting CSP hashes. The hashes of the
+// fragment are stored along with it, under a key of their own, and replayed
+// with it.
+func (c *CachedContent) renderHashed(rw *renderWriter, cache Cache, key string, hashes *CSPHashes) error {
+	key += "\x00h" + hashes.cacheKey()
+	hashesKey := key + "\x00sources"
+	if b, ok := cache.Get(key); ok {
+		if sources, ok := cache.Get(hashesKey); ok && rw.writeFragment(b) {
+			hashes.merge(sources)
+			return rw.err
+		}
+	}
+
+	fragment := hashes.fork()
+	restore := rw.withContext(WithCSPHashes(rw.ctx, fragment))
+	stored, err := c.record(rw)
+	restore()
+	if err != nil {
+		return err
+	}
+	sources := fragment.marshal()
+	hashes.merge(sources)
+	cache.Set(key, stored, c.ttl, c.tags)
+	cache.Set(hashesKey, sources, c.ttl, c.tags)
+	rw.writeFragment(stored)
+	return rw.err
+}
+
+// record renders the fragment into the form it is stored in, see recording.
+func (c *CachedContent) record(rw *renderWriter) ([]byte, error) {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+	out := rw.w
+	rw.w = buf
+	r, stop := rw.startRecording()
+	err := c.render(rw)
+	stop()
+	rw.w = out
+	if err != nil {
+		return nil, err
+	}
+	return r.encode(buf.B), nil
+}
+
+func (c *CachedContent) render(rw *renderWriter) error {
+	child := c.fn()
+	if child == nil {
+		return nil
+	}
+	rw.following = following{}
+	return child.Render(rw)
+}
+
+// recording collects the IDs a cached fragment allocates with UseID and the
+// nonce attributes it renders. They change with every render pass, so the
+// fragment is stored with placeholders instead and its IDs and nonces are
+// written anew whenever it is. The stored form is the prefixes of the IDs,
+// the positions of the placeholders and the bytes without them.
+type recording struct {
+	prefixes []string
+	nonce    bool
+}
+
+// placeholderToken starts the placeholders of the recordings. It is made of
+// letters and digits, which every escaper leaves as is.
+var placeholderToken = func() []byte {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return []byte("zSpecklesz" + hex.EncodeToString(b))
+}()
+
+// startRecording makes UseID return placeholders, until the returned
+// function is called.
+func (rw *renderWriter) startRecording() (*recording, func()) {
+	rw.context()
+	ids := rw.ids
+	prev := ids.recording
+	r := &recording{}
+	ids.recording = r
+	return r, func() { ids.recording = prev }
+}
+
+// useID returns the placeholder of an ID. They are numbered from 1, 0 stands
+// for the nonce attribute.
+func (r *recording) useID(prefix string) string {
+	r.prefixes = append(r.prefixes, prefix)
+	return string(placeholderToken) + strconv.Itoa(len(r.prefixes)) + "z"
+}
+
+func (r *recording) useNonce() string {
+	r.nonce = true
+	return string(placeholderToken) + "0z"
+}
+
+// encode returns the stored form of the fragment rendered into b.
+func (r *recording) encode(b []byte) []byte {
+	out := binary.AppendUvarint(nil, uint64(len(r.prefixes)))
+	for _, prefix := range r.prefixes {
+		out = binary.AppendUvarint(out, uint64(len(prefix)))
+		out = append(out, prefix...)
+	}
+	var marks, body []byte
+	n := 0
+	for len(r.prefixes) > 0 || r.nonce {
+		i := bytes.Index(b, placeholderToken)
+		if i < 0 {
+			break
+		}
+		rest := b[i+len(placeholderToken):]
+		end := bytes.IndexByte(rest, 'z')
+		index, err := strconv.Atoi(string(rest[:max(end, 0)]))
+		if end <= 0 || err != nil || index > len(r.prefixes) || (index == 0 && !r.nonce) {
+			body = append(body, b[:i+len(placeholderToken)]...)
+			b = rest
+			continue
+		}
+		body = append(body, b[:i]...)
+		marks = binary.AppendUvarint(marks, uint64(len(body)))
+		marks = binary.AppendUvarint(marks, uint64(index))
+		n++
+		b = rest[end+1:]
+	}
+	out = binary.AppendUvarint(out, uint64(n))
+	out = append(out, marks...)
+	out = append(out, body...)
+	return append(out, b...)
+}
+
+// writeFragment writes a fragment stored by a recording, allocating its IDs
+// and writing the nonce of the render. It reports false for data that is not
+// a stored fragment, which is then rendered again.
+func (rw *renderWriter) writeFragment(b []byte) bool {
+	prefixes, marks, body, ok := decodeFragment(b)
+	if !ok {
+		return false
+	}
+	ids := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		ids[i] = UseID(rw.context(), prefix)
+	}
+	nonce, _ := rw.nonce()
+	last := 0
+	for _, m := range marks {
+		rw.Write(body[last:m.offset])
+		if m.index == 0 {
+			rw.writeNonce(nonce)
+		} else {
+			io.WriteString(rw, ids[m.index-1])
+		}
+		last = m.offset
+	}
+	rw.Write(body[last:])
+	return true
+}
+
+// fragmentMark is the position of a placeholder in a stored fragment.
+type fragmentMark struct {
+	offset, index int
+}
+
+func decodeFragment(b []byte) (prefixes []string, marks []fragmentMark, body []byte, ok bool) {
+	count, b, ok := readUvarint(b)
+	if !ok || count > uint64(len(b)) {
+		return nil, nil, nil, false
+	}
+	for range count {
+		var size uint64
+		if size, b, ok = readUvarint(b); !ok || size > uint64(len(b)) {
+			return nil, nil, nil, false
+		}
+		prefixes = append(prefixes, string(b[:size]))
+		b = b[size:]
+	}
+	n, b, ok := readUvarint(b)
+	if !ok || n > uint64(len(b)) {
+		return nil, nil, nil, false
+	}
+	for range n {
+		var offset, index uint64
+		if offset, b, ok = readUvarint(b); !ok {
+			return nil, nil, nil, false
+		}
+		if index, b, ok = readUvarint(b); !ok || index > count {
+			return nil, nil, nil, false
+		}
+		marks = append(marks, fragmentMark{offset: int(offset), index: int(index)})
+	}
+	last := 0
+	for _, m := range marks {
+		if m.offset < last || m.offset > len(b) {
+			return nil, nil, nil, false
+		}
+		last = m.offset
+	}
+	return prefixes, marks, b, true
+}
+
+func readUvarint(b []byte) (uint64, []byte, bool) {
+	v, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, b, false
+	}
+	return v, b[n:], true
+}
+
+// cacheKey qualifies key with the options and the state of the render pass
+// the output depends on, safe text mode included.
+func (rw *renderWriter) cacheKey(key string) string {
+	r := rw.renderer
+	_, hasNonce := rw.nonce()
+	safe := safeText.Load()
+	if !r.Contextual && r.Indent == "" && !r.Minify && !r.XML && r.Precision <= 0 &&
+		!hasNonce && len(r.Transformers) == 0 && !safe && !r.RequireNonce {
+		return key
+	}
+	b := append([]byte(key), 0)
+	if safe {
+		b = append(b, 's')
+	}
+	if r.RequireNonce {
+		// Fragments rendered without the check could replay inline scripts
+		// lacking a nonce.
+		b = append(b, 'r')
+	}
+	if len(r.Transformers) > 0 {
+		b = append(b, 't')
+		b = strconv.AppendQuote(b, r.TransformersKey)
+	}
+	if hasNonce {
+		// The nonce itself is written when the fragment is.
+		b = append(b, 'n')
+	}
+	if r.Precision > 0 {
+		b = append(b, 'p')
+		b = strconv.AppendInt(b, int64(r.Precision), 10)
+	}
+	if r.Contextual {
+		b = append(b, 'c')
+		b = strconv.AppendInt(b, int64(rw.rawText), 10)
+	}
+	if r.Indent != "" {
+		b = append(b, 'i')
+		b = strconv.AppendQuote(b, r.Indent)
+		b = strconv.AppendInt(b, int64(rw.depth), 10)
+		b = strconv.AppendBool(b, rw.compact)
+	}
+	if r.Minify {
+		b = append(b, 'm')
+		b = strconv.AppendBool(b, rw.preserveSpace)
+	}
+	if r.XML {
+		b = append(b, 'x')
+		if n := len(rw.stack); n > 0 {
+			b = strconv.AppendInt(b, int64(rw.stack[n-1].namespace), 10)
+		}
+	}
+	return string(b)
+}
+
+// LRUCache is an in-memory Cache holding up to a number of fragments, the
+// least recently used ones are evicted first.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  *list.List
+	keys     map[string]*list.Element
+	tags     map[string]map[string]struct{}
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+	tags    []string
+}
+
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+	return &LRUCache{
+		capacity: capacity,
+		entries:  list.New(),
+		keys:     make(map[string]*list.Element),
+		tags:     make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.keys[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.entries.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration, tags []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.keys[key]; ok {
+		c.remove(el)
+	}
+	entry := &lruEntry{
+		key:   key,
+		value: value,
+		tags:  append([]string(nil), tags...),
+	}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	c.keys[key] = c.entries.PushFront(entry)
+	for _, tag := range entry.tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	for c.entries.Len() > c.capacity {
+		c.remove(c.entries.Back())
+	}
+}
+
+func (c *LRUCache) Invalidate(tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			c.remove(c.keys[key])
+		}
+	}
+}
+
+// Delete removes the fragment stored under key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.keys[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Len returns the number of fragments in the cache, expired ones included.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+func (c *LRUCache) remove(el *list.Element) {
+	entry := c.entries.Remove(el).(*lruEntry)
+	delete(c.keys, entry.key)
+	for _, tag := range entry.tags {
+		delete(c.tags[tag], entry.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package elements
+
+// Clone returns a deep copy of e, which can be modified without affecting e.
+// The attributes and the children are copied all the way down, except for the
+// children other than elements, groups and documents, such as text, which
+// are shared. The copy is not frozen.
+func (e *Element) Clone() *Element {
+	c := e.CloneShallow()
+	for i, child := range c.descendants {
+		c.descendants[i] = cloneNode(child)
+	}
+	return c
+}
+
+// CloneShallow returns a copy of e with attributes and a list of children of
+// its own, the children themselves being shared with e. The copy is not
+// frozen.
+func (e *Element) CloneShallow() *Element {
+	return e.shallowCopy()
+}
+
+// Freeze makes e and the elements below it immutable: their setters leave
+// them untouched and return a modified copy instead, which is not frozen. It
+// is meant for base elements to derive variants from, and for trees shared
+// between goroutines. The Children of groups and documents are not protected
+// and must not be modified.
+func (e *Element) Freeze() *Element {
+	Inspect(e, func(node ElementRenderer) bool {
+		el, ok := node.(interface{ baseElement() *Element })
+		if !ok {
+			return true
+		}
+		b := el.baseElement()
+		if b.frozen {
+			// The elements below a frozen element are frozen already.
+			return false
+		}
+		b.frozen = true
+		return true
+	})
+	return e
+}
+
+// Frozen reports whether e is frozen, see Freeze.
+func (e *Element) Frozen() bool {
+	return e.frozen
+}
+
+// checkWritable panics if e is frozen, the setters modify a copy of frozen
+// elements.
+func (e *Element) checkWritable() {
+	if e.frozen {
+		panic("elements: frozen element modified")
+	}
+}
+
+// writable returns e, or the copy to modify when it is frozen.
+func (e *Element) writable() *Element {
+	if e.frozen {
+		return e.shallowCopy()
+	}
+	return e
+}
+
+func (e *Element) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+func (g *Grouper) cloneNode() ElementRenderer {
+	if g == nil {
+		return g
+	}
+	return &Grouper{Children: cloneNodes(g.Children)}
+}
+
+func (d *DocumentContent) cloneNode() ElementRenderer {
+	if d == nil {
+		return d
+	}
+	return &DocumentContent{Children: cloneNodes(d.Children)}
+}
+
+// cloneNode returns a deep copy of node, node itself when it is immutable.
+func cloneNode(node ElementRenderer) ElementRenderer {
+	if c, ok := node.(interface{ cloneNode() ElementRenderer }); ok {
+		return c.cloneNode()
+	}
+	return node
+}
+
+func cloneNodes(nodes []ElementRenderer) []ElementRenderer {
+	if nodes == nil {
+		return nil
+	}
+	c := make([]ElementRenderer, len(nodes))
+	for i, node := range nodes {
+		c[i] = cloneNode(node)
+	}
+	return c
+}
@@ -0,0 +1,264 @@
+package elements
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+)
+
+// HoleContent is a placeholder for content provided at render time, with
+// FillHoles or WithHoles. A hole without content renders nothing.
+type HoleContent struct {
+	Name string
+}
+
+func (s *HoleContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	return rw.renderHole(s.Name)
+}
+
+func Hole(name string) *HoleContent {
+	return &HoleContent{
+		Name: name,
+	}
+}
+
+type holesKey struct{}
+
+// WithHoles returns a context filling the holes rendered with it.
+func WithHoles(ctx context.Context, holes map[string]ElementRenderer) context.Context {
+	return context.WithValue(ctx, holesKey{}, holes)
+}
+
+type filledContent struct {
+	root  ElementRenderer
+	holes map[string]ElementRenderer
+}
+
+func (f *filledContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	holes := rw.holes
+	rw.holes = f.holes
+	defer func() { rw.holes = holes }()
+	return rw.renderRoot(f.root)
+}
+
+// FillHoles returns root with its holes filled from holes. Holes missing
+// from the map are looked up in the render context.
+func FillHoles(root ElementRenderer, holes map[string]ElementRenderer) ElementRenderer {
+	return &filledContent{
+		root:  root,
+		holes: holes,
+	}
+}
+
+func (rw *renderWriter) renderHole(name string) error {
+	if rw.compiler != nil {
+		rw.compiler.addHole(rw, name)
+		return nil
+	}
+	content, ok := rw.holes[name]
+	if !ok {
+		holes, _ := rw.context().Value(holesKey{}).(map[string]ElementRenderer)
+		content = holes[name]
+	}
+	if content == nil {
+		return nil
+	}
+	return content.Render(rw)
+}
+
+// CompiledContent is a tree rendered once into bytes, except for its holes.
+// Rendering it writes the bytes and renders the content of the holes.
+type CompiledContent struct {
+	root     ElementRenderer
+	renderer Renderer
+	chunks   []byte
+	holes    []compiledHole
+	// safeText is the safe text mode the tree was compiled in.
+	safeText bool
+	// styleAttributes holds the values of the style attributes to hash.
+	styleAttributes []string
+}
+
+// compiledHole is the position of a hole in the chunks, along with the state
+// of the render pass at that point. Marks are holes standing for the render
+// time parts of the element on top of the stack, other than content.
+type compiledHole struct {
+	offset        int
+	name          string
+	mark          compiledMark
+	stack         []*Element
+	rawText       escapeContext
+	depth         int
+	compact       bool
+	preserveSpace bool
+}
+
+type compiler struct {
+	buf             *bytes.Buffer
+	holes           []compiledHole
+	styleAttributes []string
+}
+
+func (c *compiler) addHole(rw *renderWriter, name string) {
+	c.holes = append(c.holes, compiledHole{
+		offset:        c.buf.Len(),
+		name:          name,
+		stack:         slices.Clone(rw.stack),
+		rawText:       rw.rawText,
+		depth:         rw.depth,
+		compact:       rw.compact,
+		preserveSpace: rw.preserveSpace,
+	})
+}
+
+type compiledMark uint8
+
+const (
+	markNone compiledMark = iota
+	// markNonce stands for the nonce attribute.
+	markNonce
+	// markBodyStart and markBodyEnd surround a script or style body to hash.
+	markBodyStart
+	markBodyEnd
+)
+
+func (c *compiler) addMark(rw *renderWriter, mark compiledMark) {
+	c.holes = append(c.holes, compiledHole{
+		offset: c.buf.Len(),
+		mark:   mark,
+		stack:  slices.Clone(rw.stack),
+	})
+}
+
+// Compile renders root with the options of the renderer, except for its
+// holes. Everything else is rendered once: dynamic content such as the
+// callbacks of DynGroupContext must go in holes. The compiled bytes are only
+// reused by renderers with the same options, see Renderer.TransformersKey for
+// the renderers using transformers.
+func (r *Renderer) Compile(root ElementRenderer) (*CompiledContent, error) {
+	var buf bytes.Buffer
+	c := &compiler{buf: &buf}
+	safe := safeText.Load()
+	rw := newRenderWriter(&buf, r)
+	defer rw.release()
+	rw.compiler = c
+	if root != nil {
+		if err := rw.renderRoot(root); err != nil {
+			return nil, err
+		}
+	}
+	return &CompiledContent{
+		root:            root,
+		renderer:        *r,
+		chunks:          buf.Bytes(),
+		holes:           c.holes,
+		safeText:        safe,
+		styleAttributes: c.styleAttributes,
+	}, nil
+}
+
+// Compile renders root with the default options, see Renderer.Compile.
+func Compile(root ElementRenderer) (*CompiledContent, error) {
+	return defaultRenderer.Compile(root)
+}
+
+// Static is like Compile but panics if root fails to render. It is meant for
+// package-level templates.
+func Static(root ElementRenderer) *CompiledContent {
+	c, err := Compile(root)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (c *CompiledContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	if !c.reusable(rw) {
+		if c.root == nil {
+			return nil
+		}
+		return rw.renderRoot(c.root)
+	}
+
+	if len(c.styleAttributes) > 0 {
+		if h := rw.cspHashes(); h != nil {
+			for _, v := range c.styleAttributes {
+				h.add(cspStyleAttribute, []byte(v))
+			}
+		}
+	}
+	last := 0
+	for i := range c.holes {
+		h := &c.holes[i]
+		rw.Write(c.chunks[last:h.offset])
+		last = h.offset
+		if err := h.render(rw); err != nil {
+			return err
+		}
+	}
+	rw.Write(c.chunks[last:])
+	return rw.err
+}
+
+// Fill returns the compiled content with its holes filled from holes, see
+// FillHoles.
+func (c *CompiledContent) Fill(holes map[string]ElementRenderer) ElementRenderer {
+	return FillHoles(c, holes)
+}
+
+// reusable reports whether the compiled bytes are what rendering the tree
+// would produce, the options and the safe text mode being the same. Otherwise
+// the tree is rendered as usual. Indentation, XML namespace declarations and
+// minification depend on the position in the document, so in these modes the
+// bytes are only used at the top level.
+func (c *CompiledContent) reusable(rw *renderWriter) bool {
+	if !c.renderer.sameOutput(rw.renderer) || c.safeText != safeText.Load() {
+		return false
+	}
+	r := &c.renderer
+	return len(rw.stack) == 0 || (r.Indent == "" && !r.XML && !r.Minify)
+}
+
+func (h *compiledHole) render(rw *renderWriter) error {
+	if h.mark != markNone {
+		return h.renderMark(rw)
+	}
+	stack, rawText, depth, compact, preserveSpace := rw.stack, rw.rawText, rw.depth, rw.compact, rw.preserveSpace
+	rw.stack = append(rw.stack, h.stack...)
+	rw.rawText, rw.depth, rw.compact, rw.preserveSpace = h.rawText, h.depth, h.compact, h.preserveSpace
+	rw.following = following{}
+	err := rw.renderHole(h.name)
+	rw.stack, rw.rawText, rw.depth, rw.compact, rw.preserveSpace = stack, rawText, depth, compact, preserveSpace
+	return err
+}
+
+func (h *compiledHole) renderMark(rw *renderWriter) error {
+	e := h.stack[len(h.stack)-1]
+	switch h.mark {
+	case markNonce:
+		stack := rw.stack
+		rw.stack = append(rw.stack, h.stack...)
+		defer func() { rw.stack = stack }()
+		if err := rw.renderNonce(e); err != nil {
+			return rw.fail(err)
+		}
+	case markBodyStart:
+		rw.beginBody()
+	case markBodyEnd:
+		rw.endBody(e)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package elements
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Slots holds the content passed to a component by slot name. The children
+// of the component are in the default slot, named "".
+type Slots map[string]ElementRenderer
+
+// Get returns the content of the slot, or the defaults when it is not set.
+func (s Slots) Get(name string, defaults ...ElementRenderer) ElementRenderer {
+	if content, ok := s[name]; ok && content != nil {
+		return content
+	}
+	return Group(defaults...)
+}
+
+// Has reports whether the slot is set.
+func (s Slots) Has(name string) bool {
+	return s[name] != nil
+}
+
+// Children returns the content of the default slot.
+func (s Slots) Children() ElementRenderer {
+	return s.Get("")
+}
+
+// Component is a reusable tree built from typed props and slots.
+type Component[P any] struct {
+	render func(ctx context.Context, props P, slots Slots) ElementRenderer
+}
+
+// NewComponent returns a component rendered by render. It is called each
+// time an instance is rendered, the forwarded attributes are set on a copy of
+// the root element it returns so the tree may be shared.
+func NewComponent[P any](render func(props P, slots Slots) ElementRenderer) *Component[P] {
+	return &Component[P]{
+		render: func(_ context.Context, props P, slots Slots) ElementRenderer {
+			return render(props, slots)
+		},
+	}
+}
+
+// NewComponentContext is NewComponent with render receiving the render
+// context, for use with UseID.
+func NewComponentContext[P any](render func(ctx context.Context, props P, slots Slots) ElementRenderer) *Component[P] {
+	return &Component[P]{
+		render: render,
+	}
+}
+
+// New returns an instance of the component, with children in the default
+// slot.
+func (c *Component[P]) New(props P, children ...ElementRenderer) *ComponentContent {
+	cc := &ComponentContent{
+		render: func(ctx context.Context, slots Slots) ElementRenderer { return c.render(ctx, props, slots) },
+		slots:  Slots{},
+	}
+	if len(children) > 0 {
+		cc.slots[""] = Group(children...)
+	}
+	return cc
+}
+
+var ErrNoRootElement = errors.New("component root is not an element")
+
+// ComponentContent is an instance of a component.
+type ComponentContent struct {
+	render func(ctx context.Context, slots Slots) ElementRenderer
+	slots  Slots
+	// forwarded holds the attributes set on the instance, which are set on
+	// the root element of the component.
+	forwarded Element
+}
+
+// Slot sets the content of a slot.
+func (cc *ComponentContent) Slot(name string, content ...ElementRenderer) *ComponentContent {
+	cc.slots[name] = Group(content...)
+	return cc
+}
+
+// Children adds content to the default slot.
+func (cc *ComponentContent) Children(children ...ElementRenderer) *ComponentContent {
+	if g, ok := cc.slots[""].(*Grouper); ok {
+		g.Children = append(g.Children, children...)
+		return cc
+	}
+	return cc.Slot("", children...)
+}
+
+// Attr forwards an attribute to the root element of the component, replacing
+// the value set by the component.
+func (cc *ComponentContent) Attr(name, value string) *ComponentContent {
+	cc.forwarded.Attr(name, value)
+	return cc
+}
+
+func (cc *ComponentContent) Attrs(attrs ...string) *ComponentContent {
+	cc.forwarded.Attrs(attrs...)
+	return cc
+}
+
+func (cc *ComponentContent) BoolAttr(name string) *ComponentContent {
+	cc.forwarded.BoolAttr(name)
+	return cc
+}
+
+// Class forwards classes to the root element of the component, in addition
+// to the classes set by the component.
+func (cc *ComponentContent) Class(classes ...string) *ComponentContent {
+	for _, class := range classes {
+		cc.forwarded.delimitedAttribute("class", " ").Add(strings.Fields(class)...)
+	}
+	return cc
+}
+
+func (cc *ComponentContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	if cc.forwarded.err != nil {
+		return rw.fail(cc.forwarded.err)
+	}
+
+	root := cc.render(rw.context(), cc.slots)
+	if len(cc.forwarded.attributes) > 0 {
+		el, ok := root.(interface{ baseElement() *Element })
+		if !ok {
+			return rw.fail(ErrNoRootElement)
+		}
+		e := el.baseElement().shallowCopy()
+		e.forward(&cc.forwarded)
+		return e.Render(rw)
+	}
+	if root == nil {
+		return nil
+	}
+	return root.Render(rw)
+}
+
+// forward sets the attributes of from on e. Classes are added to the classes
+// of e.
+func (e *Element) forward(from *Element) {
+	for _, a := range from.attributes {
+		if a.kind != attributeDelimited {
+			e.setAttribute(a)
+			continue
+		}
+		ds := e.delimitedAttribute(a.name, a.delimited.delimiter)
+		for _, v := range a.delimited.values {
+			if !slices.Contains(ds.values, v) {
+				ds.Add(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package elements
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+var ErrMissingNonce = errors.New("inline script without nonce")
+
+type nonceKey struct{}
+
+// WithNonce returns a context whose nonce is stamped on the script, style and
+// link elements rendered with it, unless they have a nonce already.
+func WithNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, nonceKey{}, nonce)
+}
+
+// NonceFromContext returns the nonce set with WithNonce.
+func NonceFromContext(ctx context.Context) (string, bool) {
+	nonce, ok := ctx.Value(nonceKey{}).(string)
+	return nonce, ok && nonce != ""
+}
+
+// takesNonce reports whether the nonce of the render context is stamped on e.
+func (e *Element) takesNonce() bool {
+	switch string(e.tag) {
+	case "script", "style", "link":
+		return e.namespace != namespaceMathML && e.getAttribute("nonce") == nil
+	}
+	return false
+}
+
+// isInlineScript reports whether e is a script element without a src.
+func (e *Element) isInlineScript() bool {
+	return string(e.tag) == "script" && e.getAttribute("src") == nil && e.getAttribute("href") == nil
+}
+
+func (rw *renderWriter) nonce() (string, bool) {
+	if rw.ctx == nil {
+		return "", false
+	}
+	return NonceFromContext(rw.ctx)
+}
+
+// renderNonce writes the nonce attribute of e, which lacks one. While
+// compiling, the nonce is left to the render pass, like a hole.
+func (rw *renderWriter) renderNonce(e *Element) error {
+	if rw.compiler != nil {
+		rw.compiler.addMark(rw, markNonce)
+		return nil
+	}
+	nonce, ok := rw.nonce()
+	if !ok {
+		if rw.renderer.RequireNonce && e.isInlineScript() {
+			return ErrMissingNonce
+		}
+		return nil
+	}
+	rw.writeNonce(nonce)
+	return nil
+}
+
+// writeNonce writes the nonce attribute. While a cached fragment is recorded,
+// a placeholder is written instead, see recording.
+func (rw *renderWriter) writeNonce(nonce string) {
+	if rw.ids != nil && rw.ids.recording != nil {
+		rw.WriteString(rw.ids.recording.useNonce())
+		return
+	}
+	value := append(rw.scratch[:0], nonce...)
+	rw.scratch = value[:0]
+	rw.WriteString(" nonce=")
+	if rw.minify() && !needsQuotes(value) {
+		rw.writeAttributeValue(value)
+		return
+	}
+	rw.Write(doubleQuotes)
+	rw.writeAttributeValue(value)
+	rw.Write(doubleQuotes)
+}
+
+// CSPHashes collects the hashes of the inline scripts and styles, and of the
+// style attributes, rendered with it, see WithCSPHashes. It is safe for
+// concurrent use.
+type CSPHashes struct {
+	algorithms []crypto.Hash
+
+	mu      sync.Mutex
+	sources [cspKinds][]string
+	seen    map[string]struct{}
+}
+
+type cspKind uint8
+
+const (
+	cspScript cspKind = iota
+	cspStyle
+	cspStyleAttribute
+	cspKinds
+)
+
+var cspAlgorithms = map[crypto.Hash]string{
+	crypto.SHA256: "sha256",
+	crypto.SHA384: "sha384",
+	crypto.SHA512: "sha512",
+}
+
+// NewCSPHashes returns a collector hashing with the algorithms, which are
+// SHA256, SHA384 or SHA512, SHA256 when there is none. It panics on any other
+// algorithm.
+func NewCSPHashes(algorithms ...crypto.Hash) *CSPHashes {
+	if len(algorithms) == 0 {
+		algorithms = []crypto.Hash{crypto.SHA256}
+	}
+	for _, a := range algorithms {
+		if _, ok := cspAlgorithms[a]; !ok {
+			panic(fmt.Sprintf("elements: unsupported CSP hash algorithm %v", a))
+		}
+	}
+	return &CSPHashes{
+		algorithms: slices.Clone(algorithms),
+		seen:       map[string]struct{}{},
+	}
+}
+
+type cspHashesKey struct{}
+
+// WithCSPHashes returns a context collecting in h the hashes of the inline
+// scripts and styles rendered with it. The bodies of HTML raw text elements
+// are hashed as written, the bodies of SVG elements or XML documents and the
+// style attributes as the text the browser decodes. Compiled and cached
+// content is hashed too, HTML templates are not.
+func WithCSPHashes(ctx context.Context, h *CSPHashes) context.Context {
+	return context.WithValue(ctx, cspHashesKey{}, h)
+}
+
+// ScriptSources returns the hash sources of the inline scripts, in render
+// order.
+func (h *CSPHashes) ScriptSources() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.sources[cspScript])
+}
+
+// StyleSources returns the hash sources of the inline styles, followed by
+// 'unsafe-hashes' and the hash sources of the style attributes if there are
+// any.
+func (h *CSPHashes) StyleSources() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sources := slices.Clone(h.sources[cspStyle])
+	if attributes := h.sources[cspStyleAttribute]; len(attributes) > 0 {
+		sources = append(sources, "'unsafe-hashes'")
+		sources = append(sources, attributes...)
+	}
+	return sources
+}
+
+// Header returns the script-src and style-src directives of a
+// Content-Security-Policy header allowing the hashed content, such as
+// "script-src 'sha256-…'; style-src 'sha256-…'". A directive without sources
+// is left out.
+func (h *CSPHashes) Header() string {
+	var directives []string
+	if sources := h.ScriptSources(); len(sources) > 0 {
+		directives = append(directives, "script-src "+strings.Join(sources, " "))
+	}
+	if sources := h.StyleSources(); len(sources) > 0 {
+		directives = append(directives, "style-src "+strings.Join(sources, " "))
+	}
+	return strings.Join(directives, "; ")
+}
+
+// add hashes content with each algorithm.
+func (h *CSPHashes) add(kind cspKind, content []byte) {
+	for _, a := range h.algorithms {
+		hash := a.New()
+		hash.Write(content)
+		h.addSource(kind, "'"+cspAlgorithms[a]+"-"+base64.StdEncoding.EncodeToString(hash.Sum(nil))+"'")
+	}
+}
+
+func (h *CSPHashes) addSource(kind cspKind, source string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := string(rune('0'+kind)) + source
+	if _, ok := h.seen[key]; ok {
+		return
+	}
+	h.seen[key] = struct{}{}
+	h.sources[kind] = append(h.sources[kind], source)
+}
+
+// fork returns an empty collector with the same algorithms.
+func (h *CSPHashes) fork() *CSPHashes {
+	return &CSPHashes{
+		algorithms: h.algorithms,
+		seen:       map[string]struct{}{},
+	}
+}
+
+// cacheKey identifies the algorithms in cache keys.
+func (h *CSPHashes) cacheKey() string {
+	var b []byte
+	for _, a := range h.algorithms {
+		b = strconv.AppendInt(b, int64(a), 10)
+		b = append(b, ',')
+	}
+	return string(b)
+}
+
+// marshal encodes the sources of h, one per line prefixed with their kind.
+func (h *CSPHashes) marshal() []byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var b []byte
+	for kind, sources := range h.sources {
+		for _, s := range sources {
+			b = append(b, byte('0'+kind))
+			b = append(b, s...)
+			b = append(b, '\n')
+		}
+	}
+	return b
+}
+
+// merge adds the sources encoded by marshal.
+func (h *CSPHashes) merge(b []byte) {
+	for line := range bytes.Lines(b) {
+		line = bytes.TrimSuffix(line, []byte("\n"))
+		if len(line) < 2 || line[0] < '0' || line[0] >= '0'+byte(cspKinds) {
+			continue
+		}
+		h.addSource(cspKind(line[0]-'0'), string(line[1:]))
+	}
+}
+
+func (rw *renderWriter) cspHashes() *CSPHashes {
+	if rw.ctx == nil {
+		return nil
+	}
+	h, _ := rw.ctx.Value(cspHashesKey{}).(*CSPHashes)
+	return h
+}
+
+// hashesBody reports whether the body of e is hashed, e being an inline script
+// or a style element.
+func (e *Element) hashesBody() bool {
+	if e.namespace == namespaceMathML || !hasChildren(e.descendants) {
+		return false
+	}
+	return string(e.tag) == "style" || e.isInlineScript()
+}
+
+// beginBody starts recording the body of a script or style, while compiling it
+// marks where the body starts instead.
+func (rw *renderWriter) beginBody() {
+	if rw.compiler != nil {
+		rw.compiler.addMark(rw, markBodyStart)
+		return
+	}
+	if rw.cspHashes() == nil {
+		return
+	}
+	rw.body = bytebufferpool.Get()
+	rw.bodyOut = rw.w
+	rw.w = io.MultiWriter(rw.bodyOut, rw.body)
+}
+
+// endBody hashes the recorded body of e.
+func (rw *renderWriter) endBody(e *Element) {
+	if rw.compiler != nil {
+		rw.compiler.addMark(rw, markBodyEnd)
+		return
+	}
+	if rw.body == nil {
+		return
+	}
+	kind := cspScript
+	if string(e.tag) == "style" {
+		kind = cspStyle
+	}
+	if h := rw.cspHashes(); h != nil {
+		body := rw.body.B
+		if rw.renderer.XML || e.namespace != namespaceHTML {
+			// Outside of HTML raw text, the body is parsed like any text: the
+			// browser hashes it with its character references decoded.
+			body = []byte(html.UnescapeString(string(body)))
+		}
+		h.add(kind, body)
+	}
+	rw.w = rw.bodyOut
+	bytebufferpool.Put(rw.body)
+	rw.body, rw.bodyOut = nil, nil
+}
+
+// hashStyleAttribute hashes the value of a style attribute.
+func (rw *renderWriter) hashStyleAttribute(value []byte) {
+	if rw.compiler != nil {
+		rw.compiler.styleAttributes = append(rw.compiler.styleAttributes, string(value))
+		return
+	}
+	if h := rw.cspHashes(); h != nil {
+		h.add(cspStyleAttribute, value)
+	}
+}
@@ -0,0 +1,488 @@
+package elements
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isValidAttributeName reports whether name matches the attribute name grammar
+// of the HTML Living Standard: one or more characters other than controls,
+// U+0020 SPACE, U+0022 ("), U+0027 ('), U+003E (>), U+002F (/), U+003D (=),
+// and noncharacters.
+func isValidAttributeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == utf8.RuneError:
+			if _, size := utf8.DecodeRuneInString(name[i:]); size == 1 {
+				return false
+			}
+		case r <= 0x1f, r >= 0x7f && r <= 0x9f:
+			return false
+		case r == ' ', r == '"', r == '\'', r == '>', r == '/', r == '=':
+			return false
+		case isNoncharacter(r):
+			return false
+		}
+	}
+	return true
+}
+
+func isNoncharacter(r rune) bool {
+	return (r >= 0xfdd0 && r <= 0xfdef) || r&0xfffe == 0xfffe
+}
+
+// escapeContext is the context an attribute value or text is rendered into
+// when contextual escaping is enabled.
+type escapeContext uint8
+
+const (
+	escapeContextNone escapeContext = iota
+	escapeContextURL
+	escapeContextURLList
+	escapeContextSrcset
+	escapeContextSrcdoc
+	escapeContextCSS
+	escapeContextJS
+)
+
+// Replacement values for content rejected by the contextual escapers. They are
+// harmless where they end up and easy to search for.
+const (
+	unsafeURL = "about:invalid#zSpecklesz"
+	unsafeCSS = "zSpecklesz"
+)
+
+// attributeEscapeContext returns the escape context of the attribute key on
+// the element with the given tag, based on the generated attribute metadata.
+func attributeEscapeContext(tag, key string) escapeContext {
+	key = strings.ToLower(key)
+	if c, ok := elementAttributeContexts[tag][key]; ok {
+		return c
+	}
+	if c, ok := globalAttributeContexts[key]; ok {
+		return c
+	}
+	if len(key) > 2 && strings.HasPrefix(key, "on") {
+		return escapeContextJS
+	}
+	return escapeContextNone
+}
+
+// rawTextEscapeContext returns the escape context of the text content of the
+// element with the given tag.
+func rawTextEscapeContext(tag []byte) escapeContext {
+	switch string(tag) {
+	case "script":
+		return escapeContextJS
+	case "style":
+		return escapeContextCSS
+	}
+	return escapeContextNone
+}
+
+// escapeAttributeContext applies the contextual escaper of c to an attribute
+// value. The result still has to be escaped as an attribute value.
+func escapeAttributeContext(c escapeContext, value string) string {
+	switch c {
+	case escapeContextURL:
+		return filterURL(value)
+	case escapeContextURLList:
+		return filterURLList(value)
+	case escapeContextSrcset:
+		return filterSrcset(value)
+	case escapeContextSrcdoc:
+		return html.EscapeString(value)
+	case escapeContextCSS:
+		return filterCSSDeclarations(value)
+	case escapeContextJS:
+		return jsStringLiteral(value)
+	}
+	return value
+}
+
+// filterURL replaces URLs with a scheme other than http, https, mailto or tel
+// and percent-encodes the characters that are not valid in a URL.
+func filterURL(s string) string {
+	if i := strings.IndexByte(s, ':'); i >= 0 && !strings.ContainsAny(s[:i], "/?#") {
+		switch strings.ToLower(strings.TrimSpace(s[:i])) {
+		case "http", "https", "mailto", "tel":
+		default:
+			return unsafeURL
+		}
+	}
+	return normalizeURL(s)
+}
+
+func normalizeURL(s string) string {
+	var b strings.Builder
+	written := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isURLByte(c) {
+			continue
+		}
+		if written == 0 {
+			b.Grow(len(s) + 16)
+		}
+		b.WriteString(s[written:i])
+		fmt.Fprintf(&b, "%%%02X", c)
+		written = i + 1
+	}
+	if written == 0 {
+		return s
+	}
+	b.WriteString(s[written:])
+	return b.String()
+}
+
+// isURLByte reports whether c may appear in a normalized URL: the unreserved
+// and reserved characters of RFC 3986 and the percent sign of existing escapes.
+func isURLByte(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("-._~!#$&*+,/:;=?@[]%", c) >= 0
+}
+
+// filterURLList filters each URL of a space or comma separated list.
+func filterURLList(s string) string {
+	var b strings.Builder
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && !isURLListSeparator(s[i]) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			b.WriteString(filterURL(s[start:i]))
+			start = -1
+		}
+		if i < len(s) {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func isURLListSeparator(c byte) bool {
+	return c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// filterSrcset filters the URL of each image candidate of a srcset value. A
+// candidate with a descriptor that is not a width or density is rejected.
+func filterSrcset(s string) string {
+	candidates := strings.Split(s, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		url := filterURL(fields[0])
+		for _, descriptor := range fields[1:] {
+			if !isSrcsetDescriptor(descriptor) {
+				url = unsafeURL
+			}
+		}
+		fields[0] = url
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ",")
+}
+
+func isSrcsetDescriptor(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	switch s[len(s)-1] {
+	case 'w', 'x':
+	default:
+		return false
+	}
+	for i := 0; i < len(s)-1; i++ {
+		if (s[i] < '0' || s[i] > '9') && s[i] != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// filterCSSDeclaration checks a single inline style declaration. Properties
+// that are not identifiers are rejected, values that could break out of the
+// declaration or load script are replaced.
+func filterCSSDeclaration(property, value string) (string, string, bool) {
+	if !isCSSIdentifier(property) {
+		return "", "", false
+	}
+	if !isSafeCSSValue(value) {
+		return property, unsafeCSS, true
+	}
+	return property, value, true
+}
+
+// filterCSSDeclarations checks the declarations of a style attribute set as a
+// string, the way key-value styles are checked one declaration at a time.
+// Declarations without a property are dropped.
+func filterCSSDeclarations(s string) string {
+	var b strings.Builder
+	for _, declaration := range strings.Split(s, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		property, value, ok = filterCSSDeclaration(strings.TrimSpace(property), strings.TrimSpace(value))
+		if !ok {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(property)
+		b.WriteByte(':')
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+func isCSSIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', c == '_', c == '-', c >= 0x80:
+		case '0' <= c && c <= '9':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isSafeCSSValue(s string) bool {
+	if strings.ContainsAny(s, "\"'`;{}<>\\@\x00") || strings.Contains(s, "/*") {
+		return false
+	}
+	lower := strings.ToLower(s)
+	for _, keyword := range []string{"expression", "javascript:", "-moz-binding", "behavior"} {
+		if strings.Contains(lower, keyword) {
+			return false
+		}
+	}
+	for rest := lower; ; {
+		i := strings.Index(rest, "url(")
+		if i < 0 {
+			break
+		}
+		rest = rest[i+len("url("):]
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return false
+		}
+		if filterURL(strings.TrimSpace(rest[:end])) == unsafeURL {
+			return false
+		}
+		rest = rest[end:]
+	}
+	return true
+}
+
+// jsStringLiteral quotes s as a JavaScript string literal that is safe to embed
+// in a script element or an event handler attribute.
+func jsStringLiteral(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '"', '\'', '`', '<', '>', '&', '=', '/', '\u2028', '\u2029':
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// cssEscape escapes s so it can be placed in a style element, as part of an
+// identifier or a string.
+func cssEscape(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '-', r == '_', r == ' ', r >= 0x80:
+			b.WriteRune(r)
+		default:
+			fmt.Fprintf(&b, `\%X `, r)
+		}
+	}
+	return b.String()
+}
+
+// neutralizeRawText keeps text from ending the script or style element it is
+// placed in: "</script", "</style" and "<!--", in any case, get a backslash
+// after their "<", which strings of JavaScript and CSS read the same.
+func neutralizeRawText(s string) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '<' {
+			continue
+		}
+		rest := s[i+1:]
+		if hasPrefixFold(rest, "/script") || hasPrefixFold(rest, "/style") || strings.HasPrefix(rest, "!--") {
+			b.WriteString(s[last : i+1])
+			b.WriteByte('\\')
+			last = i + 1
+		}
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// escapedArg HTML-escapes the formatted value of an argument of Textf.
+type escapedArg struct {
+	value any
+}
+
+func (a escapedArg) Format(f fmt.State, verb rune) {
+	io.WriteString(f, html.EscapeString(fmt.Sprintf(fmt.FormatString(f, verb), a.value)))
+}
+
+// formattedArgs reports which of the n arguments of format are formatted by
+// a verb, and can be wrapped in an escapedArg. Widths and precisions given as
+// arguments must stay integers, and fmt prints %T and %p without calling
+// Format.
+func formattedArgs(format string, n int) []bool {
+	formatted := make([]bool, n)
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		argIndex := func() {
+			if i < len(format) && format[i] == '[' {
+				if end := strings.IndexByte(format[i:], ']'); end > 0 {
+					if k, err := strconv.Atoi(format[i+1 : i+end]); err == nil {
+						arg = k - 1
+					}
+					i += end + 1
+				}
+			}
+		}
+		number := func() {
+			argIndex()
+			if i < len(format) && format[i] == '*' {
+				arg++
+				i++
+				return
+			}
+			for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+				i++
+			}
+		}
+
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		number()
+		if i < len(format) && format[i] == '.' {
+			i++
+			number()
+		}
+		argIndex()
+		if i >= len(format) {
+			break
+		}
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		if verb == '%' {
+			continue
+		}
+		if arg >= 0 && arg < n && verb != 'T' && verb != 'p' {
+			formatted[arg] = true
+		}
+		arg++
+	}
+	return formatted
+}
+
+// escapeComment spaces out the hyphens of a comment text that would end the
+// comment or make it invalid: "--" anywhere, a leading ">" or "->" and a
+// trailing "-".
+func escapeComment(s string) string {
+	if !strings.Contains(s, "-") && !strings.HasPrefix(s, ">") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	if strings.HasPrefix(s, ">") || strings.HasPrefix(s, "->") {
+		b.WriteByte(' ')
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '-' && i > 0 && s[i-1] == '-' {
+			b.WriteByte(' ')
+		}
+		b.WriteByte(s[i])
+	}
+	if strings.HasSuffix(s, "-") {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// isValidPITarget reports whether target is an XML name other than the
+// reserved "xml" in any case, which is only allowed for the XML declaration.
+func isValidPITarget(target string) bool {
+	if target == "xml" {
+		return true
+	}
+	if target == "" || strings.EqualFold(target, "xml") {
+		return false
+	}
+	for i, r := range target {
+		switch {
+		case r == ':' || r == '_' || unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <a> element (or anchor element), with its href Attribute, creates a
@@ -24,18 +22,81 @@ type AElement struct {
 // with the tag "a" during rendering.
 func A(children ...ElementRenderer) *AElement {
 	e := NewElement("a", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &AElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *AElement) Clone() *AElement {
+	return &AElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *AElement) CloneShallow() *AElement {
+	return &AElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *AElement) Freeze() *AElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *AElement) writable() *AElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *AElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *AElement) choiceType(name string) func(string) any {
+	switch name {
+	case "referrerpolicy":
+		return choiceValue[AReferrerpolicyChoice]
+	case "target":
+		return choiceValue[ATargetChoice]
+	case "autocapitalize":
+		return choiceValue[AAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[AContenteditableChoice]
+	case "dir":
+		return choiceValue[ADirChoice]
+	case "draggable":
+		return choiceValue[ADraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[AEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[AHiddenChoice]
+	case "inputmode":
+		return choiceValue[AInputmodeChoice]
+	case "popover":
+		return choiceValue[APopoverChoice]
+	case "spellcheck":
+		return choiceValue[ASpellcheckChoice]
+	case "translate":
+		return choiceValue[ATranslateChoice]
+	}
+	return nil
+}
+
 func (e *AElement) Children(children ...ElementRenderer) *AElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *AElement) IfChildren(condition bool, children ...ElementRenderer) *AElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +104,30 @@ func (e *AElement) IfChildren(condition bool, children ...ElementRenderer) *AEle
 
 func (e *AElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *AElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *AElement) BoolAttr(name string) *AElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *AElement) BoolAttrRemove(name string) *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *AElement) IfBoolAttr(condition bool, name string) *AElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +138,40 @@ func (e *AElement) BoolAttrf(format string, args ...any) *AElement {
 
 func (e *AElement) IfBoolAttrf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *AElement) BoolAttrs(names ...string) *AElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *AElement) IfBoolAttrs(condition bool, names ...string) *AElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *AElement) Attr(name, value string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *AElement) RawAttr(name, value string) *AElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *AElement) IfAttr(condition bool, name, value string) *AElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +182,52 @@ func (e *AElement) Attrf(name, format string, args ...any) *AElement {
 
 func (e *AElement) IfAttrf(condition bool, name, format string, args ...any) *AElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *AElement) Attrs(attrs ...string) *AElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *AElement) IfAttrs(condition bool, attrs ...string) *AElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *AElement) AttrsMap(attrs map[string]string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *AElement) IfAttrsMap(condition bool, attrs map[string]string) *AElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *AElement) Text(text string) *AElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *AElement) Textf(format string, args ...any) *AElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *AElement) IfText(condition bool, text string) *AElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +235,28 @@ func (e *AElement) IfText(condition bool, text string) *AElement {
 
 func (e *AElement) IfTextf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *AElement) Raw(text string) *AElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *AElement) Escaped(text string) *AElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *AElement) IfEscaped(condition bool, text string) *AElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +268,7 @@ func (e *AElement) Escapedf(format string, args ...any) *AElement {
 
 func (e *AElement) IfEscapedf(condition bool, format string, args ...any) *AElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +277,8 @@ func (e *AElement) IfEscapedf(condition bool, format string, args ...any) *AElem
 // Causes the browser to treat the linked URL as a download. Can be used with or
 // without a filename
 func (e *AElement) Download(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("download", s)
+	e = e.writable()
+	e.setStringAttribute("download", s)
 	return e
 }
 
@@ -229,7 +292,7 @@ func (e *AElement) Downloadf(format string, args ...any) *AElement {
 // without a filename
 func (e *AElement) IfDownload(condition bool, s string) *AElement {
 	if condition {
-		e.Download(s)
+		e = e.Download(s)
 	}
 	return e
 }
@@ -238,7 +301,7 @@ func (e *AElement) IfDownload(condition bool, s string) *AElement {
 // without a filename
 func (e *AElement) IfDownloadf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Download(fmt.Sprintf(format, args...))
+		e = e.Download(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -247,20 +310,16 @@ func (e *AElement) IfDownloadf(condition bool, format string, args ...any) *AEle
 // without a filename
 // Remove the attribute Download from the element.
 func (e *AElement) DownloadRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("download")
+	e = e.writable()
+	e.removeAttribute("download")
 	return e
 }
 
 // The URL that the hyperlink points to. Links are not restricted to HTTP-based
 // URLs — they can use any URL scheme supported by browsers
 func (e *AElement) Href(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -274,7 +333,7 @@ func (e *AElement) Hreff(format string, args ...any) *AElement {
 // URLs — they can use any URL scheme supported by browsers
 func (e *AElement) IfHref(condition bool, s string) *AElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -283,7 +342,7 @@ func (e *AElement) IfHref(condition bool, s string) *AElement {
 // URLs — they can use any URL scheme supported by browsers
 func (e *AElement) IfHreff(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -292,10 +351,8 @@ func (e *AElement) IfHreff(condition bool, format string, args ...any) *AElement
 // URLs — they can use any URL scheme supported by browsers
 // Remove the attribute Href from the element.
 func (e *AElement) HrefRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
@@ -303,10 +360,8 @@ func (e *AElement) HrefRemove() *AElement {
 // values are determined by BCP47 for HTML5 and by RFC1766 for HTML 4. Use this
 // Attribute only if the href attribute is present
 func (e *AElement) Hreflang(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hreflang", s)
+	e = e.writable()
+	e.setStringAttribute("hreflang", s)
 	return e
 }
 
@@ -322,7 +377,7 @@ func (e *AElement) Hreflangf(format string, args ...any) *AElement {
 // Attribute only if the href attribute is present
 func (e *AElement) IfHreflang(condition bool, s string) *AElement {
 	if condition {
-		e.Hreflang(s)
+		e = e.Hreflang(s)
 	}
 	return e
 }
@@ -332,7 +387,7 @@ func (e *AElement) IfHreflang(condition bool, s string) *AElement {
 // Attribute only if the href attribute is present
 func (e *AElement) IfHreflangf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Hreflang(fmt.Sprintf(format, args...))
+		e = e.Hreflang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -342,26 +397,17 @@ func (e *AElement) IfHreflangf(condition bool, format string, args ...any) *AEle
 // Attribute only if the href attribute is present
 // Remove the attribute Hreflang from the element.
 func (e *AElement) HreflangRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hreflang")
+	e = e.writable()
+	e.removeAttribute("hreflang")
 	return e
 }
 
 // A space-separated list of URLs. When the link is followed, the browser will
 // send POST requests with the body PING to the URLs. Typically for tracking.
 func (e *AElement) Ping(s string) *AElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("ping")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("ping", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("ping", ",").Add(values...)
 	return e
 }
 
@@ -369,7 +415,7 @@ func (e *AElement) Ping(s string) *AElement {
 // send POST requests with the body PING to the URLs. Typically for tracking.
 func (e *AElement) IfPing(condition bool, s string) *AElement {
 	if condition {
-		e.Ping(s)
+		e = e.Ping(s)
 	}
 	return e
 }
@@ -378,24 +424,16 @@ func (e *AElement) IfPing(condition bool, s string) *AElement {
 // send POST requests with the body PING to the URLs. Typically for tracking.
 // Remove the values from the attribute Ping in the element.
 func (e *AElement) PingRemove(s ...string) *AElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("ping")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("ping", s...)
 	return e
 }
 
 // Specifies which referrer to send when fetching the resource. See
 // Referrer-Policy for possible values and their effects.
 func (e *AElement) Referrerpolicy(c AReferrerpolicyChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[AReferrerpolicyChoice])
 	return e
 }
 
@@ -429,10 +467,8 @@ const (
 // Referrer-Policy for possible values and their effects.
 // Remove the attribute Referrerpolicy from the element.
 func (e *AElement) ReferrerpolicyRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
@@ -442,16 +478,9 @@ func (e *AElement) ReferrerpolicyRemove() *AElement {
 // document author. The default relationship, if no other is given, is void. Use
 // this Attribute only if the href attribute is present.
 func (e *AElement) Rel(s string) *AElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("rel")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("rel", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("rel", " ").Add(values...)
 	return e
 }
 
@@ -462,7 +491,7 @@ func (e *AElement) Rel(s string) *AElement {
 // this Attribute only if the href attribute is present.
 func (e *AElement) IfRel(condition bool, s string) *AElement {
 	if condition {
-		e.Rel(s)
+		e = e.Rel(s)
 	}
 	return e
 }
@@ -474,14 +503,8 @@ func (e *AElement) IfRel(condition bool, s string) *AElement {
 // this Attribute only if the href attribute is present.
 // Remove the values from the attribute Rel in the element.
 func (e *AElement) RelRemove(s ...string) *AElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("rel")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("rel", s...)
 	return e
 }
 
@@ -489,10 +512,8 @@ func (e *AElement) RelRemove(s ...string) *AElement {
 // browsing context: a tab, window, or <iframe>. The following keywords have
 // special meanings:
 func (e *AElement) Target(c ATargetChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("target", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("target", string(c), choiceValue[ATargetChoice])
 	return e
 }
 
@@ -519,19 +540,15 @@ const (
 // special meanings:
 // Remove the attribute Target from the element.
 func (e *AElement) TargetRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("target")
+	e = e.writable()
+	e.removeAttribute("target")
 	return e
 }
 
 // Hints at the linked URL's format with a MIME type. No built-in functionality.
 func (e *AElement) Type(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -543,7 +560,7 @@ func (e *AElement) Typef(format string, args ...any) *AElement {
 // Hints at the linked URL's format with a MIME type. No built-in functionality.
 func (e *AElement) IfType(condition bool, s string) *AElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -551,7 +568,7 @@ func (e *AElement) IfType(condition bool, s string) *AElement {
 // Hints at the linked URL's format with a MIME type. No built-in functionality.
 func (e *AElement) IfTypef(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -559,10 +576,8 @@ func (e *AElement) IfTypef(condition bool, format string, args ...any) *AElement
 // Hints at the linked URL's format with a MIME type. No built-in functionality.
 // Remove the attribute Type from the element.
 func (e *AElement) TypeRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -571,10 +586,8 @@ func (e *AElement) TypeRemove() *AElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *AElement) Accesskey(r rune) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -584,7 +597,7 @@ func (e *AElement) Accesskey(r rune) *AElement {
 // can be generated by the keyboard).
 func (e *AElement) IfAccesskey(condition bool, r rune) *AElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -595,10 +608,8 @@ func (e *AElement) IfAccesskey(condition bool, r rune) *AElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *AElement) AccesskeyRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -614,10 +625,8 @@ func (e *AElement) AccesskeyRemove() *AElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *AElement) Autocapitalize(c AAutocapitalizeChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[AAutocapitalizeChoice])
 	return e
 }
 
@@ -651,10 +660,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *AElement) AutocapitalizeRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -673,10 +680,8 @@ func (e *AElement) AutocapitalizeRemove() *AElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AElement) Autofocus() *AElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -696,7 +701,7 @@ func (e *AElement) Autofocus() *AElement {
 // created by the preceding content.
 func (e *AElement) IfAutofocus(condition bool) *AElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -717,10 +722,8 @@ func (e *AElement) IfAutofocus(condition bool) *AElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AElement) AutofocusRemove() *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -740,10 +743,8 @@ func (e *AElement) AutofocusRemove() *AElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AElement) AutofocusIfRemove() *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -752,16 +753,9 @@ func (e *AElement) AutofocusIfRemove() *AElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *AElement) Class(s string) *AElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -771,7 +765,7 @@ func (e *AElement) Class(s string) *AElement {
 // document.getElementsByClassName.
 func (e *AElement) IfClass(condition bool, s string) *AElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -782,14 +776,8 @@ func (e *AElement) IfClass(condition bool, s string) *AElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *AElement) ClassRemove(s ...string) *AElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -797,10 +785,8 @@ func (e *AElement) ClassRemove(s ...string) *AElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *AElement) Contenteditable(c AContenteditableChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[AContenteditableChoice])
 	return e
 }
 
@@ -823,10 +809,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *AElement) ContenteditableRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -844,10 +828,8 @@ func (e *AElement) ContenteditableRemove() *AElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *AElement) Dir(c ADirChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ADirChoice])
 	return e
 }
 
@@ -881,10 +863,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *AElement) DirRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -892,10 +872,8 @@ func (e *AElement) DirRemove() *AElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *AElement) Draggable(c ADraggableChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ADraggableChoice])
 	return e
 }
 
@@ -921,20 +899,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *AElement) DraggableRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *AElement) Enterkeyhint(c AEnterkeyhintChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[AEnterkeyhintChoice])
 	return e
 }
 
@@ -964,10 +938,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *AElement) EnterkeyhintRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -989,16 +961,9 @@ func (e *AElement) EnterkeyhintRemove() *AElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *AElement) Exportparts(s string) *AElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1021,7 +986,7 @@ func (e *AElement) Exportparts(s string) *AElement {
 // the current structure.
 func (e *AElement) IfExportparts(condition bool, s string) *AElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1045,14 +1010,8 @@ func (e *AElement) IfExportparts(condition bool, s string) *AElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *AElement) ExportpartsRemove(s ...string) *AElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1069,10 +1028,8 @@ func (e *AElement) ExportpartsRemove(s ...string) *AElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *AElement) Hidden(c AHiddenChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[AHiddenChoice])
 	return e
 }
 
@@ -1108,10 +1065,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *AElement) HiddenRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1119,10 +1074,8 @@ func (e *AElement) HiddenRemove() *AElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AElement) ID(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1138,7 +1091,7 @@ func (e *AElement) IDf(format string, args ...any) *AElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AElement) IfID(condition bool, s string) *AElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1148,7 +1101,7 @@ func (e *AElement) IfID(condition bool, s string) *AElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AElement) IfIDf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1158,10 +1111,8 @@ func (e *AElement) IfIDf(condition bool, format string, args ...any) *AElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *AElement) IDRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1176,10 +1127,8 @@ func (e *AElement) IDRemove() *AElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AElement) Inert() *AElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1195,7 +1144,7 @@ func (e *AElement) Inert() *AElement {
 // excluding them from the accessibility tree.
 func (e *AElement) IfInert(condition bool) *AElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1212,10 +1161,8 @@ func (e *AElement) IfInert(condition bool) *AElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AElement) InertRemove() *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1231,10 +1178,8 @@ func (e *AElement) InertRemove() *AElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AElement) InertIfRemove() *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1248,10 +1193,8 @@ func (e *AElement) InertIfRemove() *AElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *AElement) Inputmode(c AInputmodeChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[AInputmodeChoice])
 	return e
 }
 
@@ -1302,10 +1245,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *AElement) InputmodeRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1315,10 +1256,8 @@ func (e *AElement) InputmodeRemove() *AElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *AElement) Is(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1338,7 +1277,7 @@ func (e *AElement) Isf(format string, args ...any) *AElement {
 // and extends the element type it is being applied to.
 func (e *AElement) IfIs(condition bool, s string) *AElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1350,7 +1289,7 @@ func (e *AElement) IfIs(condition bool, s string) *AElement {
 // and extends the element type it is being applied to.
 func (e *AElement) IfIsf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1362,10 +1301,8 @@ func (e *AElement) IfIsf(condition bool, format string, args ...any) *AElement {
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *AElement) IsRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1381,10 +1318,8 @@ func (e *AElement) IsRemove() *AElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *AElement) Itemid(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1416,7 +1351,7 @@ func (e *AElement) Itemidf(format string, args ...any) *AElement {
 // how items with the same identifier are handled.
 func (e *AElement) IfItemid(condition bool, s string) *AElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1434,7 +1369,7 @@ func (e *AElement) IfItemid(condition bool, s string) *AElement {
 // how items with the same identifier are handled.
 func (e *AElement) IfItemidf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1452,10 +1387,8 @@ func (e *AElement) IfItemidf(condition bool, format string, args ...any) *AEleme
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *AElement) ItemidRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1467,10 +1400,8 @@ func (e *AElement) ItemidRemove() *AElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *AElement) Itemprop(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1494,7 +1425,7 @@ func (e *AElement) Itempropf(format string, args ...any) *AElement {
 // <track>, and <video>.
 func (e *AElement) IfItemprop(condition bool, s string) *AElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1508,7 +1439,7 @@ func (e *AElement) IfItemprop(condition bool, s string) *AElement {
 // <track>, and <video>.
 func (e *AElement) IfItempropf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1522,10 +1453,8 @@ func (e *AElement) IfItempropf(condition bool, format string, args ...any) *AEle
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *AElement) ItempropRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1535,10 +1464,8 @@ func (e *AElement) ItempropRemove() *AElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *AElement) Itemref(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1558,7 +1485,7 @@ func (e *AElement) Itemreff(format string, args ...any) *AElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AElement) IfItemref(condition bool, s string) *AElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1570,7 +1497,7 @@ func (e *AElement) IfItemref(condition bool, s string) *AElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AElement) IfItemreff(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1582,10 +1509,8 @@ func (e *AElement) IfItemreff(condition bool, format string, args ...any) *AElem
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *AElement) ItemrefRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1597,10 +1522,8 @@ func (e *AElement) ItemrefRemove() *AElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AElement) Itemscope() *AElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1613,7 +1536,7 @@ func (e *AElement) Itemscope() *AElement {
 // <object>, <source>, <track>, and <video>.
 func (e *AElement) IfItemscope(condition bool) *AElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1627,10 +1550,8 @@ func (e *AElement) IfItemscope(condition bool) *AElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AElement) ItemscopeRemove() *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1643,10 +1564,8 @@ func (e *AElement) ItemscopeRemove() *AElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AElement) ItemscopeIfRemove() *AElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1658,10 +1577,8 @@ func (e *AElement) ItemscopeIfRemove() *AElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *AElement) Itemtype(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1685,7 +1602,7 @@ func (e *AElement) Itemtypef(format string, args ...any) *AElement {
 // <video>.
 func (e *AElement) IfItemtype(condition bool, s string) *AElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1699,7 +1616,7 @@ func (e *AElement) IfItemtype(condition bool, s string) *AElement {
 // <video>.
 func (e *AElement) IfItemtypef(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1713,10 +1630,8 @@ func (e *AElement) IfItemtypef(condition bool, format string, args ...any) *AEle
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *AElement) ItemtypeRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1726,10 +1641,8 @@ func (e *AElement) ItemtypeRemove() *AElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AElement) Lang(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1749,7 +1662,7 @@ func (e *AElement) Langf(format string, args ...any) *AElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AElement) IfLang(condition bool, s string) *AElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1761,7 +1674,7 @@ func (e *AElement) IfLang(condition bool, s string) *AElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AElement) IfLangf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1773,10 +1686,8 @@ func (e *AElement) IfLangf(condition bool, format string, args ...any) *AElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *AElement) LangRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1786,10 +1697,8 @@ func (e *AElement) LangRemove() *AElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *AElement) Nonce(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1809,7 +1718,7 @@ func (e *AElement) Noncef(format string, args ...any) *AElement {
 // execute.
 func (e *AElement) IfNonce(condition bool, s string) *AElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1821,7 +1730,7 @@ func (e *AElement) IfNonce(condition bool, s string) *AElement {
 // execute.
 func (e *AElement) IfNoncef(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1833,10 +1742,8 @@ func (e *AElement) IfNoncef(condition bool, format string, args ...any) *AElemen
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *AElement) NonceRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1844,16 +1751,9 @@ func (e *AElement) NonceRemove() *AElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *AElement) Part(s string) *AElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1862,7 +1762,7 @@ func (e *AElement) Part(s string) *AElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *AElement) IfPart(condition bool, s string) *AElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1872,14 +1772,8 @@ func (e *AElement) IfPart(condition bool, s string) *AElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *AElement) PartRemove(s ...string) *AElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1890,10 +1784,8 @@ func (e *AElement) PartRemove(s ...string) *AElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *AElement) Popover(c APopoverChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[APopoverChoice])
 	return e
 }
 
@@ -1921,10 +1813,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *AElement) PopoverRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1933,10 +1823,8 @@ func (e *AElement) PopoverRemove() *AElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *AElement) Role(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1954,7 +1842,7 @@ func (e *AElement) Rolef(format string, args ...any) *AElement {
 // role of an element.
 func (e *AElement) IfRole(condition bool, s string) *AElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1965,7 +1853,7 @@ func (e *AElement) IfRole(condition bool, s string) *AElement {
 // role of an element.
 func (e *AElement) IfRolef(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1976,10 +1864,8 @@ func (e *AElement) IfRolef(condition bool, format string, args ...any) *AElement
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *AElement) RoleRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1988,10 +1874,8 @@ func (e *AElement) RoleRemove() *AElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *AElement) Slot(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2009,7 +1893,7 @@ func (e *AElement) Slotf(format string, args ...any) *AElement {
 // value.
 func (e *AElement) IfSlot(condition bool, s string) *AElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2020,7 +1904,7 @@ func (e *AElement) IfSlot(condition bool, s string) *AElement {
 // value.
 func (e *AElement) IfSlotf(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2031,10 +1915,8 @@ func (e *AElement) IfSlotf(condition bool, format string, args ...any) *AElement
 // value.
 // Remove the attribute Slot from the element.
 func (e *AElement) SlotRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2050,10 +1932,8 @@ func (e *AElement) SlotRemove() *AElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *AElement) Spellcheck(c ASpellcheckChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ASpellcheckChoice])
 	return e
 }
 
@@ -2081,10 +1961,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *AElement) SpellcheckRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2094,14 +1972,8 @@ func (e *AElement) StylePairs(pairs ...string) *AElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2116,14 +1988,8 @@ func (e *AElement) StylePairs(pairs ...string) *AElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AElement) Style(s string) *AElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2131,7 +1997,7 @@ func (e *AElement) Style(s string) *AElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2140,7 +2006,7 @@ func (e *AElement) Style(s string) *AElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *AElement) IfStyle(condition bool, s string) *AElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2148,15 +2014,7 @@ func (e *AElement) IfStyle(condition bool, s string) *AElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AElement) StyleAdd(k string, v string) *AElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2170,7 +2028,7 @@ func (e *AElement) StyleAddf(k string, format string, args ...any) *AElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *AElement) IfStyleAdd(condition bool, k string, v string) *AElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2179,7 +2037,7 @@ func (e *AElement) IfStyleAdd(condition bool, k string, v string) *AElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *AElement) IfStyleAddf(condition bool, k string, format string, args ...any) *AElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2188,21 +2046,15 @@ func (e *AElement) IfStyleAddf(condition bool, k string, format string, args ...
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *AElement) StyleMap(m map[string]string) *AElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2211,14 +2063,8 @@ func (e *AElement) StyleMap(m map[string]string) *AElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *AElement) StyleRemove(keys ...string) *AElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2236,10 +2082,8 @@ func (e *AElement) StyleRemove(keys ...string) *AElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *AElement) Tabindex(i int) *AElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2258,7 +2102,7 @@ func (e *AElement) Tabindex(i int) *AElement {
 // their relative position in the document.
 func (e *AElement) IfTabindex(condition bool, i int) *AElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2278,10 +2122,8 @@ func (e *AElement) IfTabindex(condition bool, i int) *AElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *AElement) TabindexRemove() *AElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2302,10 +2144,8 @@ func (e *AElement) TabindexRemove() *AElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *AElement) Title(s string) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2347,7 +2187,7 @@ func (e *AElement) Titlef(format string, args ...any) *AElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *AElement) IfTitle(condition bool, s string) *AElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2370,7 +2210,7 @@ func (e *AElement) IfTitle(condition bool, s string) *AElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *AElement) IfTitlef(condition bool, format string, args ...any) *AElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2393,10 +2233,8 @@ func (e *AElement) IfTitlef(condition bool, format string, args ...any) *AElemen
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *AElement) TitleRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2405,10 +2243,8 @@ func (e *AElement) TitleRemove() *AElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *AElement) Translate(c ATranslateChoice) *AElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ATranslateChoice])
 	return e
 }
 
@@ -2429,9 +2265,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *AElement) TranslateRemove() *AElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML Abbreviation element (<abbr>) represents an abbreviation or acronym;
@@ -23,18 +21,77 @@ type AbbrElement struct {
 // with the tag "abbr" during rendering.
 func Abbr(children ...ElementRenderer) *AbbrElement {
 	e := NewElement("abbr", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &AbbrElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *AbbrElement) Clone() *AbbrElement {
+	return &AbbrElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *AbbrElement) CloneShallow() *AbbrElement {
+	return &AbbrElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *AbbrElement) Freeze() *AbbrElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *AbbrElement) writable() *AbbrElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *AbbrElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *AbbrElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[AbbrAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[AbbrContenteditableChoice]
+	case "dir":
+		return choiceValue[AbbrDirChoice]
+	case "draggable":
+		return choiceValue[AbbrDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[AbbrEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[AbbrHiddenChoice]
+	case "inputmode":
+		return choiceValue[AbbrInputmodeChoice]
+	case "popover":
+		return choiceValue[AbbrPopoverChoice]
+	case "spellcheck":
+		return choiceValue[AbbrSpellcheckChoice]
+	case "translate":
+		return choiceValue[AbbrTranslateChoice]
+	}
+	return nil
+}
+
 func (e *AbbrElement) Children(children ...ElementRenderer) *AbbrElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *AbbrElement) IfChildren(condition bool, children ...ElementRenderer) *AbbrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *AbbrElement) IfChildren(condition bool, children ...ElementRenderer) *A
 
 func (e *AbbrElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *AbbrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *AbbrElement) BoolAttr(name string) *AbbrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *AbbrElement) BoolAttrRemove(name string) *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *AbbrElement) IfBoolAttr(condition bool, name string) *AbbrElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *AbbrElement) BoolAttrf(format string, args ...any) *AbbrElement {
 
 func (e *AbbrElement) IfBoolAttrf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *AbbrElement) BoolAttrs(names ...string) *AbbrElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *AbbrElement) IfBoolAttrs(condition bool, names ...string) *AbbrElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *AbbrElement) Attr(name, value string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *AbbrElement) RawAttr(name, value string) *AbbrElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *AbbrElement) IfAttr(condition bool, name, value string) *AbbrElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *AbbrElement) Attrf(name, format string, args ...any) *AbbrElement {
 
 func (e *AbbrElement) IfAttrf(condition bool, name, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *AbbrElement) Attrs(attrs ...string) *AbbrElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *AbbrElement) IfAttrs(condition bool, attrs ...string) *AbbrElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *AbbrElement) AttrsMap(attrs map[string]string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *AbbrElement) IfAttrsMap(condition bool, attrs map[string]string) *AbbrElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *AbbrElement) Text(text string) *AbbrElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *AbbrElement) Textf(format string, args ...any) *AbbrElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *AbbrElement) IfText(condition bool, text string) *AbbrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *AbbrElement) IfText(condition bool, text string) *AbbrElement {
 
 func (e *AbbrElement) IfTextf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *AbbrElement) Raw(text string) *AbbrElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *AbbrElement) Escaped(text string) *AbbrElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *AbbrElement) IfEscaped(condition bool, text string) *AbbrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *AbbrElement) Escapedf(format string, args ...any) *AbbrElement {
 
 func (e *AbbrElement) IfEscapedf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +272,8 @@ func (e *AbbrElement) IfEscapedf(condition bool, format string, args ...any) *Ab
 // Contains a string that represents the full term or expansion of the
 // abbreviation or acronym, as defined by the abbr element.
 func (e *AbbrElement) Title(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -228,7 +287,7 @@ func (e *AbbrElement) Titlef(format string, args ...any) *AbbrElement {
 // abbreviation or acronym, as defined by the abbr element.
 func (e *AbbrElement) IfTitle(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -237,7 +296,7 @@ func (e *AbbrElement) IfTitle(condition bool, s string) *AbbrElement {
 // abbreviation or acronym, as defined by the abbr element.
 func (e *AbbrElement) IfTitlef(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -246,10 +305,8 @@ func (e *AbbrElement) IfTitlef(condition bool, format string, args ...any) *Abbr
 // abbreviation or acronym, as defined by the abbr element.
 // Remove the attribute Title from the element.
 func (e *AbbrElement) TitleRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -258,10 +315,8 @@ func (e *AbbrElement) TitleRemove() *AbbrElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *AbbrElement) Accesskey(r rune) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -271,7 +326,7 @@ func (e *AbbrElement) Accesskey(r rune) *AbbrElement {
 // can be generated by the keyboard).
 func (e *AbbrElement) IfAccesskey(condition bool, r rune) *AbbrElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -282,10 +337,8 @@ func (e *AbbrElement) IfAccesskey(condition bool, r rune) *AbbrElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *AbbrElement) AccesskeyRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -301,10 +354,8 @@ func (e *AbbrElement) AccesskeyRemove() *AbbrElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *AbbrElement) Autocapitalize(c AbbrAutocapitalizeChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[AbbrAutocapitalizeChoice])
 	return e
 }
 
@@ -338,10 +389,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *AbbrElement) AutocapitalizeRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -360,10 +409,8 @@ func (e *AbbrElement) AutocapitalizeRemove() *AbbrElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AbbrElement) Autofocus() *AbbrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -383,7 +430,7 @@ func (e *AbbrElement) Autofocus() *AbbrElement {
 // created by the preceding content.
 func (e *AbbrElement) IfAutofocus(condition bool) *AbbrElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -404,10 +451,8 @@ func (e *AbbrElement) IfAutofocus(condition bool) *AbbrElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AbbrElement) AutofocusRemove() *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -427,10 +472,8 @@ func (e *AbbrElement) AutofocusRemove() *AbbrElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AbbrElement) AutofocusIfRemove() *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -439,16 +482,9 @@ func (e *AbbrElement) AutofocusIfRemove() *AbbrElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *AbbrElement) Class(s string) *AbbrElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -458,7 +494,7 @@ func (e *AbbrElement) Class(s string) *AbbrElement {
 // document.getElementsByClassName.
 func (e *AbbrElement) IfClass(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -469,14 +505,8 @@ func (e *AbbrElement) IfClass(condition bool, s string) *AbbrElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *AbbrElement) ClassRemove(s ...string) *AbbrElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -484,10 +514,8 @@ func (e *AbbrElement) ClassRemove(s ...string) *AbbrElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *AbbrElement) Contenteditable(c AbbrContenteditableChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[AbbrContenteditableChoice])
 	return e
 }
 
@@ -510,10 +538,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *AbbrElement) ContenteditableRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -531,10 +557,8 @@ func (e *AbbrElement) ContenteditableRemove() *AbbrElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *AbbrElement) Dir(c AbbrDirChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[AbbrDirChoice])
 	return e
 }
 
@@ -568,10 +592,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *AbbrElement) DirRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -579,10 +601,8 @@ func (e *AbbrElement) DirRemove() *AbbrElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *AbbrElement) Draggable(c AbbrDraggableChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[AbbrDraggableChoice])
 	return e
 }
 
@@ -608,20 +628,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *AbbrElement) DraggableRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *AbbrElement) Enterkeyhint(c AbbrEnterkeyhintChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[AbbrEnterkeyhintChoice])
 	return e
 }
 
@@ -651,10 +667,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *AbbrElement) EnterkeyhintRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -676,16 +690,9 @@ func (e *AbbrElement) EnterkeyhintRemove() *AbbrElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *AbbrElement) Exportparts(s string) *AbbrElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -708,7 +715,7 @@ func (e *AbbrElement) Exportparts(s string) *AbbrElement {
 // the current structure.
 func (e *AbbrElement) IfExportparts(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -732,14 +739,8 @@ func (e *AbbrElement) IfExportparts(condition bool, s string) *AbbrElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *AbbrElement) ExportpartsRemove(s ...string) *AbbrElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -756,10 +757,8 @@ func (e *AbbrElement) ExportpartsRemove(s ...string) *AbbrElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *AbbrElement) Hidden(c AbbrHiddenChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[AbbrHiddenChoice])
 	return e
 }
 
@@ -795,10 +794,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *AbbrElement) HiddenRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -806,10 +803,8 @@ func (e *AbbrElement) HiddenRemove() *AbbrElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AbbrElement) ID(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -825,7 +820,7 @@ func (e *AbbrElement) IDf(format string, args ...any) *AbbrElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AbbrElement) IfID(condition bool, s string) *AbbrElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -835,7 +830,7 @@ func (e *AbbrElement) IfID(condition bool, s string) *AbbrElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AbbrElement) IfIDf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -845,10 +840,8 @@ func (e *AbbrElement) IfIDf(condition bool, format string, args ...any) *AbbrEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *AbbrElement) IDRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -863,10 +856,8 @@ func (e *AbbrElement) IDRemove() *AbbrElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AbbrElement) Inert() *AbbrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -882,7 +873,7 @@ func (e *AbbrElement) Inert() *AbbrElement {
 // excluding them from the accessibility tree.
 func (e *AbbrElement) IfInert(condition bool) *AbbrElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -899,10 +890,8 @@ func (e *AbbrElement) IfInert(condition bool) *AbbrElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AbbrElement) InertRemove() *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -918,10 +907,8 @@ func (e *AbbrElement) InertRemove() *AbbrElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AbbrElement) InertIfRemove() *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -935,10 +922,8 @@ func (e *AbbrElement) InertIfRemove() *AbbrElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *AbbrElement) Inputmode(c AbbrInputmodeChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[AbbrInputmodeChoice])
 	return e
 }
 
@@ -989,10 +974,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *AbbrElement) InputmodeRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1002,10 +985,8 @@ func (e *AbbrElement) InputmodeRemove() *AbbrElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *AbbrElement) Is(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1025,7 +1006,7 @@ func (e *AbbrElement) Isf(format string, args ...any) *AbbrElement {
 // and extends the element type it is being applied to.
 func (e *AbbrElement) IfIs(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1037,7 +1018,7 @@ func (e *AbbrElement) IfIs(condition bool, s string) *AbbrElement {
 // and extends the element type it is being applied to.
 func (e *AbbrElement) IfIsf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1049,10 +1030,8 @@ func (e *AbbrElement) IfIsf(condition bool, format string, args ...any) *AbbrEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *AbbrElement) IsRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1068,10 +1047,8 @@ func (e *AbbrElement) IsRemove() *AbbrElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *AbbrElement) Itemid(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1103,7 +1080,7 @@ func (e *AbbrElement) Itemidf(format string, args ...any) *AbbrElement {
 // how items with the same identifier are handled.
 func (e *AbbrElement) IfItemid(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1121,7 +1098,7 @@ func (e *AbbrElement) IfItemid(condition bool, s string) *AbbrElement {
 // how items with the same identifier are handled.
 func (e *AbbrElement) IfItemidf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1139,10 +1116,8 @@ func (e *AbbrElement) IfItemidf(condition bool, format string, args ...any) *Abb
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *AbbrElement) ItemidRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1154,10 +1129,8 @@ func (e *AbbrElement) ItemidRemove() *AbbrElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *AbbrElement) Itemprop(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1181,7 +1154,7 @@ func (e *AbbrElement) Itempropf(format string, args ...any) *AbbrElement {
 // <track>, and <video>.
 func (e *AbbrElement) IfItemprop(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1195,7 +1168,7 @@ func (e *AbbrElement) IfItemprop(condition bool, s string) *AbbrElement {
 // <track>, and <video>.
 func (e *AbbrElement) IfItempropf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1209,10 +1182,8 @@ func (e *AbbrElement) IfItempropf(condition bool, format string, args ...any) *A
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *AbbrElement) ItempropRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1222,10 +1193,8 @@ func (e *AbbrElement) ItempropRemove() *AbbrElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *AbbrElement) Itemref(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1245,7 +1214,7 @@ func (e *AbbrElement) Itemreff(format string, args ...any) *AbbrElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AbbrElement) IfItemref(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1257,7 +1226,7 @@ func (e *AbbrElement) IfItemref(condition bool, s string) *AbbrElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AbbrElement) IfItemreff(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1269,10 +1238,8 @@ func (e *AbbrElement) IfItemreff(condition bool, format string, args ...any) *Ab
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *AbbrElement) ItemrefRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1284,10 +1251,8 @@ func (e *AbbrElement) ItemrefRemove() *AbbrElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AbbrElement) Itemscope() *AbbrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1300,7 +1265,7 @@ func (e *AbbrElement) Itemscope() *AbbrElement {
 // <object>, <source>, <track>, and <video>.
 func (e *AbbrElement) IfItemscope(condition bool) *AbbrElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1314,10 +1279,8 @@ func (e *AbbrElement) IfItemscope(condition bool) *AbbrElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AbbrElement) ItemscopeRemove() *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1330,10 +1293,8 @@ func (e *AbbrElement) ItemscopeRemove() *AbbrElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AbbrElement) ItemscopeIfRemove() *AbbrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1345,10 +1306,8 @@ func (e *AbbrElement) ItemscopeIfRemove() *AbbrElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *AbbrElement) Itemtype(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1372,7 +1331,7 @@ func (e *AbbrElement) Itemtypef(format string, args ...any) *AbbrElement {
 // <video>.
 func (e *AbbrElement) IfItemtype(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1386,7 +1345,7 @@ func (e *AbbrElement) IfItemtype(condition bool, s string) *AbbrElement {
 // <video>.
 func (e *AbbrElement) IfItemtypef(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1400,10 +1359,8 @@ func (e *AbbrElement) IfItemtypef(condition bool, format string, args ...any) *A
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *AbbrElement) ItemtypeRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1413,10 +1370,8 @@ func (e *AbbrElement) ItemtypeRemove() *AbbrElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AbbrElement) Lang(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1436,7 +1391,7 @@ func (e *AbbrElement) Langf(format string, args ...any) *AbbrElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AbbrElement) IfLang(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1448,7 +1403,7 @@ func (e *AbbrElement) IfLang(condition bool, s string) *AbbrElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AbbrElement) IfLangf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1460,10 +1415,8 @@ func (e *AbbrElement) IfLangf(condition bool, format string, args ...any) *AbbrE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *AbbrElement) LangRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1473,10 +1426,8 @@ func (e *AbbrElement) LangRemove() *AbbrElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *AbbrElement) Nonce(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1496,7 +1447,7 @@ func (e *AbbrElement) Noncef(format string, args ...any) *AbbrElement {
 // execute.
 func (e *AbbrElement) IfNonce(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1508,7 +1459,7 @@ func (e *AbbrElement) IfNonce(condition bool, s string) *AbbrElement {
 // execute.
 func (e *AbbrElement) IfNoncef(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1520,10 +1471,8 @@ func (e *AbbrElement) IfNoncef(condition bool, format string, args ...any) *Abbr
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *AbbrElement) NonceRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1531,16 +1480,9 @@ func (e *AbbrElement) NonceRemove() *AbbrElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *AbbrElement) Part(s string) *AbbrElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1549,7 +1491,7 @@ func (e *AbbrElement) Part(s string) *AbbrElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *AbbrElement) IfPart(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1559,14 +1501,8 @@ func (e *AbbrElement) IfPart(condition bool, s string) *AbbrElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *AbbrElement) PartRemove(s ...string) *AbbrElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1577,10 +1513,8 @@ func (e *AbbrElement) PartRemove(s ...string) *AbbrElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *AbbrElement) Popover(c AbbrPopoverChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[AbbrPopoverChoice])
 	return e
 }
 
@@ -1608,10 +1542,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *AbbrElement) PopoverRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1620,10 +1552,8 @@ func (e *AbbrElement) PopoverRemove() *AbbrElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *AbbrElement) Role(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1641,7 +1571,7 @@ func (e *AbbrElement) Rolef(format string, args ...any) *AbbrElement {
 // role of an element.
 func (e *AbbrElement) IfRole(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1652,7 +1582,7 @@ func (e *AbbrElement) IfRole(condition bool, s string) *AbbrElement {
 // role of an element.
 func (e *AbbrElement) IfRolef(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1663,10 +1593,8 @@ func (e *AbbrElement) IfRolef(condition bool, format string, args ...any) *AbbrE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *AbbrElement) RoleRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1675,10 +1603,8 @@ func (e *AbbrElement) RoleRemove() *AbbrElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *AbbrElement) Slot(s string) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1696,7 +1622,7 @@ func (e *AbbrElement) Slotf(format string, args ...any) *AbbrElement {
 // value.
 func (e *AbbrElement) IfSlot(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1707,7 +1633,7 @@ func (e *AbbrElement) IfSlot(condition bool, s string) *AbbrElement {
 // value.
 func (e *AbbrElement) IfSlotf(condition bool, format string, args ...any) *AbbrElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1718,10 +1644,8 @@ func (e *AbbrElement) IfSlotf(condition bool, format string, args ...any) *AbbrE
 // value.
 // Remove the attribute Slot from the element.
 func (e *AbbrElement) SlotRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1737,10 +1661,8 @@ func (e *AbbrElement) SlotRemove() *AbbrElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *AbbrElement) Spellcheck(c AbbrSpellcheckChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[AbbrSpellcheckChoice])
 	return e
 }
 
@@ -1768,10 +1690,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *AbbrElement) SpellcheckRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1781,14 +1701,8 @@ func (e *AbbrElement) StylePairs(pairs ...string) *AbbrElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1803,14 +1717,8 @@ func (e *AbbrElement) StylePairs(pairs ...string) *AbbrElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AbbrElement) Style(s string) *AbbrElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1818,7 +1726,7 @@ func (e *AbbrElement) Style(s string) *AbbrElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1827,7 +1735,7 @@ func (e *AbbrElement) Style(s string) *AbbrElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *AbbrElement) IfStyle(condition bool, s string) *AbbrElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1835,15 +1743,7 @@ func (e *AbbrElement) IfStyle(condition bool, s string) *AbbrElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AbbrElement) StyleAdd(k string, v string) *AbbrElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1857,7 +1757,7 @@ func (e *AbbrElement) StyleAddf(k string, format string, args ...any) *AbbrEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *AbbrElement) IfStyleAdd(condition bool, k string, v string) *AbbrElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1866,7 +1766,7 @@ func (e *AbbrElement) IfStyleAdd(condition bool, k string, v string) *AbbrElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *AbbrElement) IfStyleAddf(condition bool, k string, format string, args ...any) *AbbrElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1875,21 +1775,15 @@ func (e *AbbrElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *AbbrElement) StyleMap(m map[string]string) *AbbrElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1898,14 +1792,8 @@ func (e *AbbrElement) StyleMap(m map[string]string) *AbbrElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *AbbrElement) StyleRemove(keys ...string) *AbbrElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1923,10 +1811,8 @@ func (e *AbbrElement) StyleRemove(keys ...string) *AbbrElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *AbbrElement) Tabindex(i int) *AbbrElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1945,7 +1831,7 @@ func (e *AbbrElement) Tabindex(i int) *AbbrElement {
 // their relative position in the document.
 func (e *AbbrElement) IfTabindex(condition bool, i int) *AbbrElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1965,10 +1851,8 @@ func (e *AbbrElement) IfTabindex(condition bool, i int) *AbbrElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *AbbrElement) TabindexRemove() *AbbrElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1977,10 +1861,8 @@ func (e *AbbrElement) TabindexRemove() *AbbrElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *AbbrElement) Translate(c AbbrTranslateChoice) *AbbrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[AbbrTranslateChoice])
 	return e
 }
 
@@ -2001,9 +1883,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *AbbrElement) TranslateRemove() *AbbrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <area> element defines an area inside an image map that has
@@ -23,18 +21,83 @@ type AreaElement struct {
 // with the tag "area" during rendering.
 func Area() *AreaElement {
 	e := NewElement("area")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &AreaElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *AreaElement) Clone() *AreaElement {
+	return &AreaElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *AreaElement) CloneShallow() *AreaElement {
+	return &AreaElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *AreaElement) Freeze() *AreaElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *AreaElement) writable() *AreaElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *AreaElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *AreaElement) choiceType(name string) func(string) any {
+	switch name {
+	case "referrerpolicy":
+		return choiceValue[AreaReferrerpolicyChoice]
+	case "shape":
+		return choiceValue[AreaShapeChoice]
+	case "target":
+		return choiceValue[AreaTargetChoice]
+	case "autocapitalize":
+		return choiceValue[AreaAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[AreaContenteditableChoice]
+	case "dir":
+		return choiceValue[AreaDirChoice]
+	case "draggable":
+		return choiceValue[AreaDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[AreaEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[AreaHiddenChoice]
+	case "inputmode":
+		return choiceValue[AreaInputmodeChoice]
+	case "popover":
+		return choiceValue[AreaPopoverChoice]
+	case "spellcheck":
+		return choiceValue[AreaSpellcheckChoice]
+	case "translate":
+		return choiceValue[AreaTranslateChoice]
+	}
+	return nil
+}
+
 func (e *AreaElement) Children(children ...ElementRenderer) *AreaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *AreaElement) IfChildren(condition bool, children ...ElementRenderer) *AreaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +105,30 @@ func (e *AreaElement) IfChildren(condition bool, children ...ElementRenderer) *A
 
 func (e *AreaElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *AreaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *AreaElement) BoolAttr(name string) *AreaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *AreaElement) BoolAttrRemove(name string) *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *AreaElement) IfBoolAttr(condition bool, name string) *AreaElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +139,40 @@ func (e *AreaElement) BoolAttrf(format string, args ...any) *AreaElement {
 
 func (e *AreaElement) IfBoolAttrf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *AreaElement) BoolAttrs(names ...string) *AreaElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *AreaElement) IfBoolAttrs(condition bool, names ...string) *AreaElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *AreaElement) Attr(name, value string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *AreaElement) RawAttr(name, value string) *AreaElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *AreaElement) IfAttr(condition bool, name, value string) *AreaElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +183,52 @@ func (e *AreaElement) Attrf(name, format string, args ...any) *AreaElement {
 
 func (e *AreaElement) IfAttrf(condition bool, name, format string, args ...any) *AreaElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *AreaElement) Attrs(attrs ...string) *AreaElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *AreaElement) IfAttrs(condition bool, attrs ...string) *AreaElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *AreaElement) AttrsMap(attrs map[string]string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *AreaElement) IfAttrsMap(condition bool, attrs map[string]string) *AreaElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *AreaElement) Text(text string) *AreaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *AreaElement) Textf(format string, args ...any) *AreaElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *AreaElement) IfText(condition bool, text string) *AreaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +236,28 @@ func (e *AreaElement) IfText(condition bool, text string) *AreaElement {
 
 func (e *AreaElement) IfTextf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *AreaElement) Raw(text string) *AreaElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *AreaElement) Escaped(text string) *AreaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *AreaElement) IfEscaped(condition bool, text string) *AreaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +269,7 @@ func (e *AreaElement) Escapedf(format string, args ...any) *AreaElement {
 
 func (e *AreaElement) IfEscapedf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +277,8 @@ func (e *AreaElement) IfEscapedf(condition bool, format string, args ...any) *Ar
 
 // Alternative text in case an image can't be displayed
 func (e *AreaElement) Alt(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("alt", s)
+	e = e.writable()
+	e.setStringAttribute("alt", s)
 	return e
 }
 
@@ -225,7 +290,7 @@ func (e *AreaElement) Altf(format string, args ...any) *AreaElement {
 // Alternative text in case an image can't be displayed
 func (e *AreaElement) IfAlt(condition bool, s string) *AreaElement {
 	if condition {
-		e.Alt(s)
+		e = e.Alt(s)
 	}
 	return e
 }
@@ -233,7 +298,7 @@ func (e *AreaElement) IfAlt(condition bool, s string) *AreaElement {
 // Alternative text in case an image can't be displayed
 func (e *AreaElement) IfAltf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Alt(fmt.Sprintf(format, args...))
+		e = e.Alt(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,32 +306,23 @@ func (e *AreaElement) IfAltf(condition bool, format string, args ...any) *AreaEl
 // Alternative text in case an image can't be displayed
 // Remove the attribute Alt from the element.
 func (e *AreaElement) AltRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("alt")
+	e = e.writable()
+	e.removeAttribute("alt")
 	return e
 }
 
 // Coordinates for the shape to be created in an image map
 func (e *AreaElement) Coords(s string) *AreaElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("coords")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("coords", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("coords", ",").Add(values...)
 	return e
 }
 
 // Coordinates for the shape to be created in an image map
 func (e *AreaElement) IfCoords(condition bool, s string) *AreaElement {
 	if condition {
-		e.Coords(s)
+		e = e.Coords(s)
 	}
 	return e
 }
@@ -274,24 +330,16 @@ func (e *AreaElement) IfCoords(condition bool, s string) *AreaElement {
 // Coordinates for the shape to be created in an image map
 // Remove the values from the attribute Coords in the element.
 func (e *AreaElement) CoordsRemove(s ...string) *AreaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("coords")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("coords", s...)
 	return e
 }
 
 // Causes the browser to download the resource instead of navigating to it. Can
 // be used with or without a value
 func (e *AreaElement) Download(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("download", s)
+	e = e.writable()
+	e.setStringAttribute("download", s)
 	return e
 }
 
@@ -305,7 +353,7 @@ func (e *AreaElement) Downloadf(format string, args ...any) *AreaElement {
 // be used with or without a value
 func (e *AreaElement) IfDownload(condition bool, s string) *AreaElement {
 	if condition {
-		e.Download(s)
+		e = e.Download(s)
 	}
 	return e
 }
@@ -314,7 +362,7 @@ func (e *AreaElement) IfDownload(condition bool, s string) *AreaElement {
 // be used with or without a value
 func (e *AreaElement) IfDownloadf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Download(fmt.Sprintf(format, args...))
+		e = e.Download(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -323,19 +371,15 @@ func (e *AreaElement) IfDownloadf(condition bool, format string, args ...any) *A
 // be used with or without a value
 // Remove the attribute Download from the element.
 func (e *AreaElement) DownloadRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("download")
+	e = e.writable()
+	e.removeAttribute("download")
 	return e
 }
 
 // The URL of a linked resource
 func (e *AreaElement) Href(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -347,7 +391,7 @@ func (e *AreaElement) Hreff(format string, args ...any) *AreaElement {
 // The URL of a linked resource
 func (e *AreaElement) IfHref(condition bool, s string) *AreaElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -355,7 +399,7 @@ func (e *AreaElement) IfHref(condition bool, s string) *AreaElement {
 // The URL of a linked resource
 func (e *AreaElement) IfHreff(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -363,10 +407,8 @@ func (e *AreaElement) IfHreff(condition bool, format string, args ...any) *AreaE
 // The URL of a linked resource
 // Remove the attribute Href from the element.
 func (e *AreaElement) HrefRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
@@ -374,16 +416,9 @@ func (e *AreaElement) HrefRemove() *AreaElement {
 // the body PING will be sent by the browser (in the background). Typically used
 // for tracking.
 func (e *AreaElement) Ping(s string) *AreaElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("ping")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("ping", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("ping", ",").Add(values...)
 	return e
 }
 
@@ -392,7 +427,7 @@ func (e *AreaElement) Ping(s string) *AreaElement {
 // for tracking.
 func (e *AreaElement) IfPing(condition bool, s string) *AreaElement {
 	if condition {
-		e.Ping(s)
+		e = e.Ping(s)
 	}
 	return e
 }
@@ -402,24 +437,16 @@ func (e *AreaElement) IfPing(condition bool, s string) *AreaElement {
 // for tracking.
 // Remove the values from the attribute Ping in the element.
 func (e *AreaElement) PingRemove(s ...string) *AreaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("ping")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("ping", s...)
 	return e
 }
 
 // Specifies which referrer to send when fetching the resource. See
 // Referrer-Policy for possible values and their effects.
 func (e *AreaElement) Referrerpolicy(c AreaReferrerpolicyChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[AreaReferrerpolicyChoice])
 	return e
 }
 
@@ -453,10 +480,8 @@ const (
 // Referrer-Policy for possible values and their effects.
 // Remove the attribute Referrerpolicy from the element.
 func (e *AreaElement) ReferrerpolicyRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
@@ -466,16 +491,9 @@ func (e *AreaElement) ReferrerpolicyRemove() *AreaElement {
 // document author. The default relationship, if no other is given, is void. Use
 // this Attribute only if the href attribute is present.
 func (e *AreaElement) Rel(s string) *AreaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("rel")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("rel", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("rel", " ").Add(values...)
 	return e
 }
 
@@ -486,7 +504,7 @@ func (e *AreaElement) Rel(s string) *AreaElement {
 // this Attribute only if the href attribute is present.
 func (e *AreaElement) IfRel(condition bool, s string) *AreaElement {
 	if condition {
-		e.Rel(s)
+		e = e.Rel(s)
 	}
 	return e
 }
@@ -498,23 +516,15 @@ func (e *AreaElement) IfRel(condition bool, s string) *AreaElement {
 // this Attribute only if the href attribute is present.
 // Remove the values from the attribute Rel in the element.
 func (e *AreaElement) RelRemove(s ...string) *AreaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("rel")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("rel", s...)
 	return e
 }
 
 // The kind of shape to be created in an image map
 func (e *AreaElement) Shape(c AreaShapeChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("shape", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("shape", string(c), choiceValue[AreaShapeChoice])
 	return e
 }
 
@@ -534,10 +544,8 @@ const (
 // The kind of shape to be created in an image map
 // Remove the attribute Shape from the element.
 func (e *AreaElement) ShapeRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("shape")
+	e = e.writable()
+	e.removeAttribute("shape")
 	return e
 }
 
@@ -545,10 +553,8 @@ func (e *AreaElement) ShapeRemove() *AreaElement {
 // browsing context: a tab, window, or <iframe>. The following keywords have
 // special meanings:
 func (e *AreaElement) Target(c AreaTargetChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("target", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("target", string(c), choiceValue[AreaTargetChoice])
 	return e
 }
 
@@ -575,10 +581,8 @@ const (
 // special meanings:
 // Remove the attribute Target from the element.
 func (e *AreaElement) TargetRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("target")
+	e = e.writable()
+	e.removeAttribute("target")
 	return e
 }
 
@@ -587,10 +591,8 @@ func (e *AreaElement) TargetRemove() *AreaElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *AreaElement) Accesskey(r rune) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -600,7 +602,7 @@ func (e *AreaElement) Accesskey(r rune) *AreaElement {
 // can be generated by the keyboard).
 func (e *AreaElement) IfAccesskey(condition bool, r rune) *AreaElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -611,10 +613,8 @@ func (e *AreaElement) IfAccesskey(condition bool, r rune) *AreaElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *AreaElement) AccesskeyRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -630,10 +630,8 @@ func (e *AreaElement) AccesskeyRemove() *AreaElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *AreaElement) Autocapitalize(c AreaAutocapitalizeChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[AreaAutocapitalizeChoice])
 	return e
 }
 
@@ -667,10 +665,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *AreaElement) AutocapitalizeRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -689,10 +685,8 @@ func (e *AreaElement) AutocapitalizeRemove() *AreaElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AreaElement) Autofocus() *AreaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -712,7 +706,7 @@ func (e *AreaElement) Autofocus() *AreaElement {
 // created by the preceding content.
 func (e *AreaElement) IfAutofocus(condition bool) *AreaElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -733,10 +727,8 @@ func (e *AreaElement) IfAutofocus(condition bool) *AreaElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AreaElement) AutofocusRemove() *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -756,10 +748,8 @@ func (e *AreaElement) AutofocusRemove() *AreaElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AreaElement) AutofocusIfRemove() *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -768,16 +758,9 @@ func (e *AreaElement) AutofocusIfRemove() *AreaElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *AreaElement) Class(s string) *AreaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -787,7 +770,7 @@ func (e *AreaElement) Class(s string) *AreaElement {
 // document.getElementsByClassName.
 func (e *AreaElement) IfClass(condition bool, s string) *AreaElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -798,14 +781,8 @@ func (e *AreaElement) IfClass(condition bool, s string) *AreaElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *AreaElement) ClassRemove(s ...string) *AreaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -813,10 +790,8 @@ func (e *AreaElement) ClassRemove(s ...string) *AreaElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *AreaElement) Contenteditable(c AreaContenteditableChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[AreaContenteditableChoice])
 	return e
 }
 
@@ -839,10 +814,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *AreaElement) ContenteditableRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -860,10 +833,8 @@ func (e *AreaElement) ContenteditableRemove() *AreaElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *AreaElement) Dir(c AreaDirChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[AreaDirChoice])
 	return e
 }
 
@@ -897,10 +868,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *AreaElement) DirRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -908,10 +877,8 @@ func (e *AreaElement) DirRemove() *AreaElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *AreaElement) Draggable(c AreaDraggableChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[AreaDraggableChoice])
 	return e
 }
 
@@ -937,20 +904,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *AreaElement) DraggableRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *AreaElement) Enterkeyhint(c AreaEnterkeyhintChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[AreaEnterkeyhintChoice])
 	return e
 }
 
@@ -980,10 +943,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *AreaElement) EnterkeyhintRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1005,16 +966,9 @@ func (e *AreaElement) EnterkeyhintRemove() *AreaElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *AreaElement) Exportparts(s string) *AreaElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1037,7 +991,7 @@ func (e *AreaElement) Exportparts(s string) *AreaElement {
 // the current structure.
 func (e *AreaElement) IfExportparts(condition bool, s string) *AreaElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1061,14 +1015,8 @@ func (e *AreaElement) IfExportparts(condition bool, s string) *AreaElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *AreaElement) ExportpartsRemove(s ...string) *AreaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1085,10 +1033,8 @@ func (e *AreaElement) ExportpartsRemove(s ...string) *AreaElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *AreaElement) Hidden(c AreaHiddenChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[AreaHiddenChoice])
 	return e
 }
 
@@ -1124,10 +1070,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *AreaElement) HiddenRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1135,10 +1079,8 @@ func (e *AreaElement) HiddenRemove() *AreaElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AreaElement) ID(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1154,7 +1096,7 @@ func (e *AreaElement) IDf(format string, args ...any) *AreaElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AreaElement) IfID(condition bool, s string) *AreaElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1164,7 +1106,7 @@ func (e *AreaElement) IfID(condition bool, s string) *AreaElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AreaElement) IfIDf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1174,10 +1116,8 @@ func (e *AreaElement) IfIDf(condition bool, format string, args ...any) *AreaEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *AreaElement) IDRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1192,10 +1132,8 @@ func (e *AreaElement) IDRemove() *AreaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AreaElement) Inert() *AreaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1211,7 +1149,7 @@ func (e *AreaElement) Inert() *AreaElement {
 // excluding them from the accessibility tree.
 func (e *AreaElement) IfInert(condition bool) *AreaElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1228,10 +1166,8 @@ func (e *AreaElement) IfInert(condition bool) *AreaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AreaElement) InertRemove() *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1247,10 +1183,8 @@ func (e *AreaElement) InertRemove() *AreaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AreaElement) InertIfRemove() *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1264,10 +1198,8 @@ func (e *AreaElement) InertIfRemove() *AreaElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *AreaElement) Inputmode(c AreaInputmodeChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[AreaInputmodeChoice])
 	return e
 }
 
@@ -1318,10 +1250,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *AreaElement) InputmodeRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1331,10 +1261,8 @@ func (e *AreaElement) InputmodeRemove() *AreaElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *AreaElement) Is(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1354,7 +1282,7 @@ func (e *AreaElement) Isf(format string, args ...any) *AreaElement {
 // and extends the element type it is being applied to.
 func (e *AreaElement) IfIs(condition bool, s string) *AreaElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1366,7 +1294,7 @@ func (e *AreaElement) IfIs(condition bool, s string) *AreaElement {
 // and extends the element type it is being applied to.
 func (e *AreaElement) IfIsf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1378,10 +1306,8 @@ func (e *AreaElement) IfIsf(condition bool, format string, args ...any) *AreaEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *AreaElement) IsRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1397,10 +1323,8 @@ func (e *AreaElement) IsRemove() *AreaElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *AreaElement) Itemid(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1432,7 +1356,7 @@ func (e *AreaElement) Itemidf(format string, args ...any) *AreaElement {
 // how items with the same identifier are handled.
 func (e *AreaElement) IfItemid(condition bool, s string) *AreaElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1450,7 +1374,7 @@ func (e *AreaElement) IfItemid(condition bool, s string) *AreaElement {
 // how items with the same identifier are handled.
 func (e *AreaElement) IfItemidf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1468,10 +1392,8 @@ func (e *AreaElement) IfItemidf(condition bool, format string, args ...any) *Are
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *AreaElement) ItemidRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1483,10 +1405,8 @@ func (e *AreaElement) ItemidRemove() *AreaElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *AreaElement) Itemprop(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1510,7 +1430,7 @@ func (e *AreaElement) Itempropf(format string, args ...any) *AreaElement {
 // <track>, and <video>.
 func (e *AreaElement) IfItemprop(condition bool, s string) *AreaElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1524,7 +1444,7 @@ func (e *AreaElement) IfItemprop(condition bool, s string) *AreaElement {
 // <track>, and <video>.
 func (e *AreaElement) IfItempropf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1538,10 +1458,8 @@ func (e *AreaElement) IfItempropf(condition bool, format string, args ...any) *A
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *AreaElement) ItempropRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1551,10 +1469,8 @@ func (e *AreaElement) ItempropRemove() *AreaElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *AreaElement) Itemref(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1574,7 +1490,7 @@ func (e *AreaElement) Itemreff(format string, args ...any) *AreaElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AreaElement) IfItemref(condition bool, s string) *AreaElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1586,7 +1502,7 @@ func (e *AreaElement) IfItemref(condition bool, s string) *AreaElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AreaElement) IfItemreff(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1598,10 +1514,8 @@ func (e *AreaElement) IfItemreff(condition bool, format string, args ...any) *Ar
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *AreaElement) ItemrefRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1613,10 +1527,8 @@ func (e *AreaElement) ItemrefRemove() *AreaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AreaElement) Itemscope() *AreaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1629,7 +1541,7 @@ func (e *AreaElement) Itemscope() *AreaElement {
 // <object>, <source>, <track>, and <video>.
 func (e *AreaElement) IfItemscope(condition bool) *AreaElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1643,10 +1555,8 @@ func (e *AreaElement) IfItemscope(condition bool) *AreaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AreaElement) ItemscopeRemove() *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1659,10 +1569,8 @@ func (e *AreaElement) ItemscopeRemove() *AreaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AreaElement) ItemscopeIfRemove() *AreaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1674,10 +1582,8 @@ func (e *AreaElement) ItemscopeIfRemove() *AreaElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *AreaElement) Itemtype(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1701,7 +1607,7 @@ func (e *AreaElement) Itemtypef(format string, args ...any) *AreaElement {
 // <video>.
 func (e *AreaElement) IfItemtype(condition bool, s string) *AreaElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1715,7 +1621,7 @@ func (e *AreaElement) IfItemtype(condition bool, s string) *AreaElement {
 // <video>.
 func (e *AreaElement) IfItemtypef(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1729,10 +1635,8 @@ func (e *AreaElement) IfItemtypef(condition bool, format string, args ...any) *A
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *AreaElement) ItemtypeRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1742,10 +1646,8 @@ func (e *AreaElement) ItemtypeRemove() *AreaElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AreaElement) Lang(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1765,7 +1667,7 @@ func (e *AreaElement) Langf(format string, args ...any) *AreaElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AreaElement) IfLang(condition bool, s string) *AreaElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1777,7 +1679,7 @@ func (e *AreaElement) IfLang(condition bool, s string) *AreaElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AreaElement) IfLangf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1789,10 +1691,8 @@ func (e *AreaElement) IfLangf(condition bool, format string, args ...any) *AreaE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *AreaElement) LangRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1802,10 +1702,8 @@ func (e *AreaElement) LangRemove() *AreaElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *AreaElement) Nonce(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1825,7 +1723,7 @@ func (e *AreaElement) Noncef(format string, args ...any) *AreaElement {
 // execute.
 func (e *AreaElement) IfNonce(condition bool, s string) *AreaElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1837,7 +1735,7 @@ func (e *AreaElement) IfNonce(condition bool, s string) *AreaElement {
 // execute.
 func (e *AreaElement) IfNoncef(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1849,10 +1747,8 @@ func (e *AreaElement) IfNoncef(condition bool, format string, args ...any) *Area
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *AreaElement) NonceRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1860,16 +1756,9 @@ func (e *AreaElement) NonceRemove() *AreaElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *AreaElement) Part(s string) *AreaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1878,7 +1767,7 @@ func (e *AreaElement) Part(s string) *AreaElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *AreaElement) IfPart(condition bool, s string) *AreaElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1888,14 +1777,8 @@ func (e *AreaElement) IfPart(condition bool, s string) *AreaElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *AreaElement) PartRemove(s ...string) *AreaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1906,10 +1789,8 @@ func (e *AreaElement) PartRemove(s ...string) *AreaElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *AreaElement) Popover(c AreaPopoverChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[AreaPopoverChoice])
 	return e
 }
 
@@ -1937,10 +1818,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *AreaElement) PopoverRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1949,10 +1828,8 @@ func (e *AreaElement) PopoverRemove() *AreaElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *AreaElement) Role(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1970,7 +1847,7 @@ func (e *AreaElement) Rolef(format string, args ...any) *AreaElement {
 // role of an element.
 func (e *AreaElement) IfRole(condition bool, s string) *AreaElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1981,7 +1858,7 @@ func (e *AreaElement) IfRole(condition bool, s string) *AreaElement {
 // role of an element.
 func (e *AreaElement) IfRolef(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1992,10 +1869,8 @@ func (e *AreaElement) IfRolef(condition bool, format string, args ...any) *AreaE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *AreaElement) RoleRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2004,10 +1879,8 @@ func (e *AreaElement) RoleRemove() *AreaElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *AreaElement) Slot(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2025,7 +1898,7 @@ func (e *AreaElement) Slotf(format string, args ...any) *AreaElement {
 // value.
 func (e *AreaElement) IfSlot(condition bool, s string) *AreaElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2036,7 +1909,7 @@ func (e *AreaElement) IfSlot(condition bool, s string) *AreaElement {
 // value.
 func (e *AreaElement) IfSlotf(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2047,10 +1920,8 @@ func (e *AreaElement) IfSlotf(condition bool, format string, args ...any) *AreaE
 // value.
 // Remove the attribute Slot from the element.
 func (e *AreaElement) SlotRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2066,10 +1937,8 @@ func (e *AreaElement) SlotRemove() *AreaElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *AreaElement) Spellcheck(c AreaSpellcheckChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[AreaSpellcheckChoice])
 	return e
 }
 
@@ -2097,10 +1966,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *AreaElement) SpellcheckRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2110,14 +1977,8 @@ func (e *AreaElement) StylePairs(pairs ...string) *AreaElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2132,14 +1993,8 @@ func (e *AreaElement) StylePairs(pairs ...string) *AreaElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AreaElement) Style(s string) *AreaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2147,7 +2002,7 @@ func (e *AreaElement) Style(s string) *AreaElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2156,7 +2011,7 @@ func (e *AreaElement) Style(s string) *AreaElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *AreaElement) IfStyle(condition bool, s string) *AreaElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2164,15 +2019,7 @@ func (e *AreaElement) IfStyle(condition bool, s string) *AreaElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AreaElement) StyleAdd(k string, v string) *AreaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2186,7 +2033,7 @@ func (e *AreaElement) StyleAddf(k string, format string, args ...any) *AreaEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *AreaElement) IfStyleAdd(condition bool, k string, v string) *AreaElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2195,7 +2042,7 @@ func (e *AreaElement) IfStyleAdd(condition bool, k string, v string) *AreaElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *AreaElement) IfStyleAddf(condition bool, k string, format string, args ...any) *AreaElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2204,21 +2051,15 @@ func (e *AreaElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *AreaElement) StyleMap(m map[string]string) *AreaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2227,14 +2068,8 @@ func (e *AreaElement) StyleMap(m map[string]string) *AreaElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *AreaElement) StyleRemove(keys ...string) *AreaElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2252,10 +2087,8 @@ func (e *AreaElement) StyleRemove(keys ...string) *AreaElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *AreaElement) Tabindex(i int) *AreaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2274,7 +2107,7 @@ func (e *AreaElement) Tabindex(i int) *AreaElement {
 // their relative position in the document.
 func (e *AreaElement) IfTabindex(condition bool, i int) *AreaElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2294,10 +2127,8 @@ func (e *AreaElement) IfTabindex(condition bool, i int) *AreaElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *AreaElement) TabindexRemove() *AreaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2318,10 +2149,8 @@ func (e *AreaElement) TabindexRemove() *AreaElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *AreaElement) Title(s string) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2363,7 +2192,7 @@ func (e *AreaElement) Titlef(format string, args ...any) *AreaElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *AreaElement) IfTitle(condition bool, s string) *AreaElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2386,7 +2215,7 @@ func (e *AreaElement) IfTitle(condition bool, s string) *AreaElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *AreaElement) IfTitlef(condition bool, format string, args ...any) *AreaElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2409,10 +2238,8 @@ func (e *AreaElement) IfTitlef(condition bool, format string, args ...any) *Area
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *AreaElement) TitleRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2421,10 +2248,8 @@ func (e *AreaElement) TitleRemove() *AreaElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *AreaElement) Translate(c AreaTranslateChoice) *AreaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[AreaTranslateChoice])
 	return e
 }
 
@@ -2445,9 +2270,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *AreaElement) TranslateRemove() *AreaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <audio> element is used to embed sound content in documents. It may
@@ -24,18 +22,79 @@ type AudioElement struct {
 // with the tag "audio" during rendering.
 func Audio(children ...ElementRenderer) *AudioElement {
 	e := NewElement("audio", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &AudioElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *AudioElement) Clone() *AudioElement {
+	return &AudioElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *AudioElement) CloneShallow() *AudioElement {
+	return &AudioElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *AudioElement) Freeze() *AudioElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *AudioElement) writable() *AudioElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *AudioElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *AudioElement) choiceType(name string) func(string) any {
+	switch name {
+	case "preload":
+		return choiceValue[AudioPreloadChoice]
+	case "autocapitalize":
+		return choiceValue[AudioAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[AudioContenteditableChoice]
+	case "dir":
+		return choiceValue[AudioDirChoice]
+	case "draggable":
+		return choiceValue[AudioDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[AudioEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[AudioHiddenChoice]
+	case "inputmode":
+		return choiceValue[AudioInputmodeChoice]
+	case "popover":
+		return choiceValue[AudioPopoverChoice]
+	case "spellcheck":
+		return choiceValue[AudioSpellcheckChoice]
+	case "translate":
+		return choiceValue[AudioTranslateChoice]
+	}
+	return nil
+}
+
 func (e *AudioElement) Children(children ...ElementRenderer) *AudioElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *AudioElement) IfChildren(condition bool, children ...ElementRenderer) *AudioElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +102,30 @@ func (e *AudioElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *AudioElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *AudioElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *AudioElement) BoolAttr(name string) *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *AudioElement) BoolAttrRemove(name string) *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *AudioElement) IfBoolAttr(condition bool, name string) *AudioElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +136,40 @@ func (e *AudioElement) BoolAttrf(format string, args ...any) *AudioElement {
 
 func (e *AudioElement) IfBoolAttrf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *AudioElement) BoolAttrs(names ...string) *AudioElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *AudioElement) IfBoolAttrs(condition bool, names ...string) *AudioElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *AudioElement) Attr(name, value string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *AudioElement) RawAttr(name, value string) *AudioElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *AudioElement) IfAttr(condition bool, name, value string) *AudioElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +180,52 @@ func (e *AudioElement) Attrf(name, format string, args ...any) *AudioElement {
 
 func (e *AudioElement) IfAttrf(condition bool, name, format string, args ...any) *AudioElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *AudioElement) Attrs(attrs ...string) *AudioElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *AudioElement) IfAttrs(condition bool, attrs ...string) *AudioElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *AudioElement) AttrsMap(attrs map[string]string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *AudioElement) IfAttrsMap(condition bool, attrs map[string]string) *AudioElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *AudioElement) Text(text string) *AudioElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *AudioElement) Textf(format string, args ...any) *AudioElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *AudioElement) IfText(condition bool, text string) *AudioElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +233,28 @@ func (e *AudioElement) IfText(condition bool, text string) *AudioElement {
 
 func (e *AudioElement) IfTextf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *AudioElement) Raw(text string) *AudioElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *AudioElement) Escaped(text string) *AudioElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *AudioElement) IfEscaped(condition bool, text string) *AudioElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +266,7 @@ func (e *AudioElement) Escapedf(format string, args ...any) *AudioElement {
 
 func (e *AudioElement) IfEscapedf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -213,10 +276,8 @@ func (e *AudioElement) IfEscapedf(condition bool, format string, args ...any) *A
 // will automatically begin playback as soon as it can do so, without waiting
 // for the entire audio file to finish downloading.
 func (e *AudioElement) Autoplay() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autoplay", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autoplay")
 	return e
 }
 
@@ -225,7 +286,7 @@ func (e *AudioElement) Autoplay() *AudioElement {
 // for the entire audio file to finish downloading.
 func (e *AudioElement) IfAutoplay(condition bool) *AudioElement {
 	if condition {
-		e.Autoplay()
+		e = e.Autoplay()
 	}
 	return e
 }
@@ -235,10 +296,8 @@ func (e *AudioElement) IfAutoplay(condition bool) *AudioElement {
 // will automatically begin playback as soon as it can do so, without waiting
 // for the entire audio file to finish downloading.
 func (e *AudioElement) AutoplayRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autoplay")
+	e = e.writable()
+	e.removeAttribute("autoplay")
 	return e
 }
 
@@ -247,10 +306,8 @@ func (e *AudioElement) AutoplayRemove() *AudioElement {
 // will automatically begin playback as soon as it can do so, without waiting
 // for the entire audio file to finish downloading.
 func (e *AudioElement) AutoplayIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autoplay")
+	e = e.writable()
+	e.removeAttribute("autoplay")
 	return e
 }
 
@@ -258,10 +315,8 @@ func (e *AudioElement) AutoplayIfRemove() *AudioElement {
 // user to control audio playback, including volume, seeking, and pause/resume
 // playback.
 func (e *AudioElement) Controls() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("controls", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("controls")
 	return e
 }
 
@@ -270,7 +325,7 @@ func (e *AudioElement) Controls() *AudioElement {
 // playback.
 func (e *AudioElement) IfControls(condition bool) *AudioElement {
 	if condition {
-		e.Controls()
+		e = e.Controls()
 	}
 	return e
 }
@@ -280,10 +335,8 @@ func (e *AudioElement) IfControls(condition bool) *AudioElement {
 // user to control audio playback, including volume, seeking, and pause/resume
 // playback.
 func (e *AudioElement) ControlsRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("controls")
+	e = e.writable()
+	e.removeAttribute("controls")
 	return e
 }
 
@@ -292,20 +345,16 @@ func (e *AudioElement) ControlsRemove() *AudioElement {
 // user to control audio playback, including volume, seeking, and pause/resume
 // playback.
 func (e *AudioElement) ControlsIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("controls")
+	e = e.writable()
+	e.removeAttribute("controls")
 	return e
 }
 
 // A Boolean Attribute; if specified, the audio player will automatically seek
 // back to the start upon reaching the end of the audio.
 func (e *AudioElement) Loop() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("loop", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("loop")
 	return e
 }
 
@@ -313,7 +362,7 @@ func (e *AudioElement) Loop() *AudioElement {
 // back to the start upon reaching the end of the audio.
 func (e *AudioElement) IfLoop(condition bool) *AudioElement {
 	if condition {
-		e.Loop()
+		e = e.Loop()
 	}
 	return e
 }
@@ -322,10 +371,8 @@ func (e *AudioElement) IfLoop(condition bool) *AudioElement {
 // A Boolean Attribute; if specified, the audio player will automatically seek
 // back to the start upon reaching the end of the audio.
 func (e *AudioElement) LoopRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("loop")
+	e = e.writable()
+	e.removeAttribute("loop")
 	return e
 }
 
@@ -333,10 +380,8 @@ func (e *AudioElement) LoopRemove() *AudioElement {
 // A Boolean Attribute; if specified, the audio player will automatically seek
 // back to the start upon reaching the end of the audio.
 func (e *AudioElement) LoopIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("loop")
+	e = e.writable()
+	e.removeAttribute("loop")
 	return e
 }
 
@@ -344,10 +389,8 @@ func (e *AudioElement) LoopIfRemove() *AudioElement {
 // silenced. Its default value is false, meaning that the audio will be played
 // when the <audio> element is loaded.
 func (e *AudioElement) Muted() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("muted", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("muted")
 	return e
 }
 
@@ -356,7 +399,7 @@ func (e *AudioElement) Muted() *AudioElement {
 // when the <audio> element is loaded.
 func (e *AudioElement) IfMuted(condition bool) *AudioElement {
 	if condition {
-		e.Muted()
+		e = e.Muted()
 	}
 	return e
 }
@@ -366,10 +409,8 @@ func (e *AudioElement) IfMuted(condition bool) *AudioElement {
 // silenced. Its default value is false, meaning that the audio will be played
 // when the <audio> element is loaded.
 func (e *AudioElement) MutedRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("muted")
+	e = e.writable()
+	e.removeAttribute("muted")
 	return e
 }
 
@@ -378,10 +419,8 @@ func (e *AudioElement) MutedRemove() *AudioElement {
 // silenced. Its default value is false, meaning that the audio will be played
 // when the <audio> element is loaded.
 func (e *AudioElement) MutedIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("muted")
+	e = e.writable()
+	e.removeAttribute("muted")
 	return e
 }
 
@@ -389,10 +428,8 @@ func (e *AudioElement) MutedIfRemove() *AudioElement {
 // what the author thinks will lead to the best user experience. It may have one
 // of the following values:
 func (e *AudioElement) Preload(c AudioPreloadChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("preload", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("preload", string(c), choiceValue[AudioPreloadChoice])
 	return e
 }
 
@@ -416,19 +453,15 @@ const (
 // of the following values:
 // Remove the attribute Preload from the element.
 func (e *AudioElement) PreloadRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("preload")
+	e = e.writable()
+	e.removeAttribute("preload")
 	return e
 }
 
 // The URL of the embeddable content.
 func (e *AudioElement) Src(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -440,7 +473,7 @@ func (e *AudioElement) Srcf(format string, args ...any) *AudioElement {
 // The URL of the embeddable content.
 func (e *AudioElement) IfSrc(condition bool, s string) *AudioElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -448,7 +481,7 @@ func (e *AudioElement) IfSrc(condition bool, s string) *AudioElement {
 // The URL of the embeddable content.
 func (e *AudioElement) IfSrcf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -456,10 +489,8 @@ func (e *AudioElement) IfSrcf(condition bool, format string, args ...any) *Audio
 // The URL of the embeddable content.
 // Remove the attribute Src from the element.
 func (e *AudioElement) SrcRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
@@ -468,10 +499,8 @@ func (e *AudioElement) SrcRemove() *AudioElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *AudioElement) Accesskey(r rune) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -481,7 +510,7 @@ func (e *AudioElement) Accesskey(r rune) *AudioElement {
 // can be generated by the keyboard).
 func (e *AudioElement) IfAccesskey(condition bool, r rune) *AudioElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -492,10 +521,8 @@ func (e *AudioElement) IfAccesskey(condition bool, r rune) *AudioElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *AudioElement) AccesskeyRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -511,10 +538,8 @@ func (e *AudioElement) AccesskeyRemove() *AudioElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *AudioElement) Autocapitalize(c AudioAutocapitalizeChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[AudioAutocapitalizeChoice])
 	return e
 }
 
@@ -548,10 +573,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *AudioElement) AutocapitalizeRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -570,10 +593,8 @@ func (e *AudioElement) AutocapitalizeRemove() *AudioElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AudioElement) Autofocus() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -593,7 +614,7 @@ func (e *AudioElement) Autofocus() *AudioElement {
 // created by the preceding content.
 func (e *AudioElement) IfAutofocus(condition bool) *AudioElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -614,10 +635,8 @@ func (e *AudioElement) IfAutofocus(condition bool) *AudioElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AudioElement) AutofocusRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -637,10 +656,8 @@ func (e *AudioElement) AutofocusRemove() *AudioElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *AudioElement) AutofocusIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -649,16 +666,9 @@ func (e *AudioElement) AutofocusIfRemove() *AudioElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *AudioElement) Class(s string) *AudioElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -668,7 +678,7 @@ func (e *AudioElement) Class(s string) *AudioElement {
 // document.getElementsByClassName.
 func (e *AudioElement) IfClass(condition bool, s string) *AudioElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -679,14 +689,8 @@ func (e *AudioElement) IfClass(condition bool, s string) *AudioElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *AudioElement) ClassRemove(s ...string) *AudioElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -694,10 +698,8 @@ func (e *AudioElement) ClassRemove(s ...string) *AudioElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *AudioElement) Contenteditable(c AudioContenteditableChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[AudioContenteditableChoice])
 	return e
 }
 
@@ -720,10 +722,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *AudioElement) ContenteditableRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -741,10 +741,8 @@ func (e *AudioElement) ContenteditableRemove() *AudioElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *AudioElement) Dir(c AudioDirChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[AudioDirChoice])
 	return e
 }
 
@@ -778,10 +776,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *AudioElement) DirRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -789,10 +785,8 @@ func (e *AudioElement) DirRemove() *AudioElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *AudioElement) Draggable(c AudioDraggableChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[AudioDraggableChoice])
 	return e
 }
 
@@ -818,20 +812,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *AudioElement) DraggableRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *AudioElement) Enterkeyhint(c AudioEnterkeyhintChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[AudioEnterkeyhintChoice])
 	return e
 }
 
@@ -861,10 +851,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *AudioElement) EnterkeyhintRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -886,16 +874,9 @@ func (e *AudioElement) EnterkeyhintRemove() *AudioElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *AudioElement) Exportparts(s string) *AudioElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -918,7 +899,7 @@ func (e *AudioElement) Exportparts(s string) *AudioElement {
 // the current structure.
 func (e *AudioElement) IfExportparts(condition bool, s string) *AudioElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -942,14 +923,8 @@ func (e *AudioElement) IfExportparts(condition bool, s string) *AudioElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *AudioElement) ExportpartsRemove(s ...string) *AudioElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -966,10 +941,8 @@ func (e *AudioElement) ExportpartsRemove(s ...string) *AudioElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *AudioElement) Hidden(c AudioHiddenChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[AudioHiddenChoice])
 	return e
 }
 
@@ -1005,10 +978,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *AudioElement) HiddenRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1016,10 +987,8 @@ func (e *AudioElement) HiddenRemove() *AudioElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AudioElement) ID(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1035,7 +1004,7 @@ func (e *AudioElement) IDf(format string, args ...any) *AudioElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AudioElement) IfID(condition bool, s string) *AudioElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1045,7 +1014,7 @@ func (e *AudioElement) IfID(condition bool, s string) *AudioElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *AudioElement) IfIDf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1055,10 +1024,8 @@ func (e *AudioElement) IfIDf(condition bool, format string, args ...any) *AudioE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *AudioElement) IDRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1073,10 +1040,8 @@ func (e *AudioElement) IDRemove() *AudioElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AudioElement) Inert() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1092,7 +1057,7 @@ func (e *AudioElement) Inert() *AudioElement {
 // excluding them from the accessibility tree.
 func (e *AudioElement) IfInert(condition bool) *AudioElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1109,10 +1074,8 @@ func (e *AudioElement) IfInert(condition bool) *AudioElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AudioElement) InertRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1128,10 +1091,8 @@ func (e *AudioElement) InertRemove() *AudioElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *AudioElement) InertIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1145,10 +1106,8 @@ func (e *AudioElement) InertIfRemove() *AudioElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *AudioElement) Inputmode(c AudioInputmodeChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[AudioInputmodeChoice])
 	return e
 }
 
@@ -1199,10 +1158,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *AudioElement) InputmodeRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1212,10 +1169,8 @@ func (e *AudioElement) InputmodeRemove() *AudioElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *AudioElement) Is(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1235,7 +1190,7 @@ func (e *AudioElement) Isf(format string, args ...any) *AudioElement {
 // and extends the element type it is being applied to.
 func (e *AudioElement) IfIs(condition bool, s string) *AudioElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1247,7 +1202,7 @@ func (e *AudioElement) IfIs(condition bool, s string) *AudioElement {
 // and extends the element type it is being applied to.
 func (e *AudioElement) IfIsf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1259,10 +1214,8 @@ func (e *AudioElement) IfIsf(condition bool, format string, args ...any) *AudioE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *AudioElement) IsRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1278,10 +1231,8 @@ func (e *AudioElement) IsRemove() *AudioElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *AudioElement) Itemid(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1313,7 +1264,7 @@ func (e *AudioElement) Itemidf(format string, args ...any) *AudioElement {
 // how items with the same identifier are handled.
 func (e *AudioElement) IfItemid(condition bool, s string) *AudioElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1331,7 +1282,7 @@ func (e *AudioElement) IfItemid(condition bool, s string) *AudioElement {
 // how items with the same identifier are handled.
 func (e *AudioElement) IfItemidf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1349,10 +1300,8 @@ func (e *AudioElement) IfItemidf(condition bool, format string, args ...any) *Au
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *AudioElement) ItemidRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1364,10 +1313,8 @@ func (e *AudioElement) ItemidRemove() *AudioElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *AudioElement) Itemprop(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1391,7 +1338,7 @@ func (e *AudioElement) Itempropf(format string, args ...any) *AudioElement {
 // <track>, and <video>.
 func (e *AudioElement) IfItemprop(condition bool, s string) *AudioElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1405,7 +1352,7 @@ func (e *AudioElement) IfItemprop(condition bool, s string) *AudioElement {
 // <track>, and <video>.
 func (e *AudioElement) IfItempropf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1419,10 +1366,8 @@ func (e *AudioElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *AudioElement) ItempropRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1432,10 +1377,8 @@ func (e *AudioElement) ItempropRemove() *AudioElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *AudioElement) Itemref(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1455,7 +1398,7 @@ func (e *AudioElement) Itemreff(format string, args ...any) *AudioElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AudioElement) IfItemref(condition bool, s string) *AudioElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1467,7 +1410,7 @@ func (e *AudioElement) IfItemref(condition bool, s string) *AudioElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *AudioElement) IfItemreff(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1479,10 +1422,8 @@ func (e *AudioElement) IfItemreff(condition bool, format string, args ...any) *A
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *AudioElement) ItemrefRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1494,10 +1435,8 @@ func (e *AudioElement) ItemrefRemove() *AudioElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AudioElement) Itemscope() *AudioElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1510,7 +1449,7 @@ func (e *AudioElement) Itemscope() *AudioElement {
 // <object>, <source>, <track>, and <video>.
 func (e *AudioElement) IfItemscope(condition bool) *AudioElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1524,10 +1463,8 @@ func (e *AudioElement) IfItemscope(condition bool) *AudioElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AudioElement) ItemscopeRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1540,10 +1477,8 @@ func (e *AudioElement) ItemscopeRemove() *AudioElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *AudioElement) ItemscopeIfRemove() *AudioElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1555,10 +1490,8 @@ func (e *AudioElement) ItemscopeIfRemove() *AudioElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *AudioElement) Itemtype(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1582,7 +1515,7 @@ func (e *AudioElement) Itemtypef(format string, args ...any) *AudioElement {
 // <video>.
 func (e *AudioElement) IfItemtype(condition bool, s string) *AudioElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1596,7 +1529,7 @@ func (e *AudioElement) IfItemtype(condition bool, s string) *AudioElement {
 // <video>.
 func (e *AudioElement) IfItemtypef(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1610,10 +1543,8 @@ func (e *AudioElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *AudioElement) ItemtypeRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1623,10 +1554,8 @@ func (e *AudioElement) ItemtypeRemove() *AudioElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AudioElement) Lang(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1646,7 +1575,7 @@ func (e *AudioElement) Langf(format string, args ...any) *AudioElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AudioElement) IfLang(condition bool, s string) *AudioElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1658,7 +1587,7 @@ func (e *AudioElement) IfLang(condition bool, s string) *AudioElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *AudioElement) IfLangf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1670,10 +1599,8 @@ func (e *AudioElement) IfLangf(condition bool, format string, args ...any) *Audi
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *AudioElement) LangRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1683,10 +1610,8 @@ func (e *AudioElement) LangRemove() *AudioElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *AudioElement) Nonce(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1706,7 +1631,7 @@ func (e *AudioElement) Noncef(format string, args ...any) *AudioElement {
 // execute.
 func (e *AudioElement) IfNonce(condition bool, s string) *AudioElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1718,7 +1643,7 @@ func (e *AudioElement) IfNonce(condition bool, s string) *AudioElement {
 // execute.
 func (e *AudioElement) IfNoncef(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1730,10 +1655,8 @@ func (e *AudioElement) IfNoncef(condition bool, format string, args ...any) *Aud
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *AudioElement) NonceRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1741,16 +1664,9 @@ func (e *AudioElement) NonceRemove() *AudioElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *AudioElement) Part(s string) *AudioElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1759,7 +1675,7 @@ func (e *AudioElement) Part(s string) *AudioElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *AudioElement) IfPart(condition bool, s string) *AudioElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1769,14 +1685,8 @@ func (e *AudioElement) IfPart(condition bool, s string) *AudioElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *AudioElement) PartRemove(s ...string) *AudioElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1787,10 +1697,8 @@ func (e *AudioElement) PartRemove(s ...string) *AudioElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *AudioElement) Popover(c AudioPopoverChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[AudioPopoverChoice])
 	return e
 }
 
@@ -1818,10 +1726,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *AudioElement) PopoverRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1830,10 +1736,8 @@ func (e *AudioElement) PopoverRemove() *AudioElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *AudioElement) Role(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1851,7 +1755,7 @@ func (e *AudioElement) Rolef(format string, args ...any) *AudioElement {
 // role of an element.
 func (e *AudioElement) IfRole(condition bool, s string) *AudioElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1862,7 +1766,7 @@ func (e *AudioElement) IfRole(condition bool, s string) *AudioElement {
 // role of an element.
 func (e *AudioElement) IfRolef(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1873,10 +1777,8 @@ func (e *AudioElement) IfRolef(condition bool, format string, args ...any) *Audi
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *AudioElement) RoleRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1885,10 +1787,8 @@ func (e *AudioElement) RoleRemove() *AudioElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *AudioElement) Slot(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1906,7 +1806,7 @@ func (e *AudioElement) Slotf(format string, args ...any) *AudioElement {
 // value.
 func (e *AudioElement) IfSlot(condition bool, s string) *AudioElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1917,7 +1817,7 @@ func (e *AudioElement) IfSlot(condition bool, s string) *AudioElement {
 // value.
 func (e *AudioElement) IfSlotf(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1928,10 +1828,8 @@ func (e *AudioElement) IfSlotf(condition bool, format string, args ...any) *Audi
 // value.
 // Remove the attribute Slot from the element.
 func (e *AudioElement) SlotRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1947,10 +1845,8 @@ func (e *AudioElement) SlotRemove() *AudioElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *AudioElement) Spellcheck(c AudioSpellcheckChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[AudioSpellcheckChoice])
 	return e
 }
 
@@ -1978,10 +1874,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *AudioElement) SpellcheckRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1991,14 +1885,8 @@ func (e *AudioElement) StylePairs(pairs ...string) *AudioElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2013,14 +1901,8 @@ func (e *AudioElement) StylePairs(pairs ...string) *AudioElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AudioElement) Style(s string) *AudioElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2028,7 +1910,7 @@ func (e *AudioElement) Style(s string) *AudioElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2037,7 +1919,7 @@ func (e *AudioElement) Style(s string) *AudioElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *AudioElement) IfStyle(condition bool, s string) *AudioElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2045,15 +1927,7 @@ func (e *AudioElement) IfStyle(condition bool, s string) *AudioElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *AudioElement) StyleAdd(k string, v string) *AudioElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2067,7 +1941,7 @@ func (e *AudioElement) StyleAddf(k string, format string, args ...any) *AudioEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *AudioElement) IfStyleAdd(condition bool, k string, v string) *AudioElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2076,7 +1950,7 @@ func (e *AudioElement) IfStyleAdd(condition bool, k string, v string) *AudioElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *AudioElement) IfStyleAddf(condition bool, k string, format string, args ...any) *AudioElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2085,21 +1959,15 @@ func (e *AudioElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *AudioElement) StyleMap(m map[string]string) *AudioElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2108,14 +1976,8 @@ func (e *AudioElement) StyleMap(m map[string]string) *AudioElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *AudioElement) StyleRemove(keys ...string) *AudioElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2133,10 +1995,8 @@ func (e *AudioElement) StyleRemove(keys ...string) *AudioElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *AudioElement) Tabindex(i int) *AudioElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2155,7 +2015,7 @@ func (e *AudioElement) Tabindex(i int) *AudioElement {
 // their relative position in the document.
 func (e *AudioElement) IfTabindex(condition bool, i int) *AudioElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2175,10 +2035,8 @@ func (e *AudioElement) IfTabindex(condition bool, i int) *AudioElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *AudioElement) TabindexRemove() *AudioElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2199,10 +2057,8 @@ func (e *AudioElement) TabindexRemove() *AudioElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *AudioElement) Title(s string) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2244,7 +2100,7 @@ func (e *AudioElement) Titlef(format string, args ...any) *AudioElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *AudioElement) IfTitle(condition bool, s string) *AudioElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2267,7 +2123,7 @@ func (e *AudioElement) IfTitle(condition bool, s string) *AudioElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *AudioElement) IfTitlef(condition bool, format string, args ...any) *AudioElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2290,10 +2146,8 @@ func (e *AudioElement) IfTitlef(condition bool, format string, args ...any) *Aud
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *AudioElement) TitleRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2302,10 +2156,8 @@ func (e *AudioElement) TitleRemove() *AudioElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *AudioElement) Translate(c AudioTranslateChoice) *AudioElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[AudioTranslateChoice])
 	return e
 }
 
@@ -2326,9 +2178,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *AudioElement) TranslateRemove() *AudioElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML Bring Attention To element (<b>) is used to draw the reader's
@@ -27,18 +25,77 @@ type BElement struct {
 // with the tag "b" during rendering.
 func B(children ...ElementRenderer) *BElement {
 	e := NewElement("b", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &BElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *BElement) Clone() *BElement {
+	return &BElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *BElement) CloneShallow() *BElement {
+	return &BElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *BElement) Freeze() *BElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *BElement) writable() *BElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *BElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *BElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[BAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[BContenteditableChoice]
+	case "dir":
+		return choiceValue[BDirChoice]
+	case "draggable":
+		return choiceValue[BDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[BEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[BHiddenChoice]
+	case "inputmode":
+		return choiceValue[BInputmodeChoice]
+	case "popover":
+		return choiceValue[BPopoverChoice]
+	case "spellcheck":
+		return choiceValue[BSpellcheckChoice]
+	case "translate":
+		return choiceValue[BTranslateChoice]
+	}
+	return nil
+}
+
 func (e *BElement) Children(children ...ElementRenderer) *BElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *BElement) IfChildren(condition bool, children ...ElementRenderer) *BElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -46,32 +103,30 @@ func (e *BElement) IfChildren(condition bool, children ...ElementRenderer) *BEle
 
 func (e *BElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *BElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *BElement) BoolAttr(name string) *BElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *BElement) BoolAttrRemove(name string) *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *BElement) IfBoolAttr(condition bool, name string) *BElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -82,36 +137,40 @@ func (e *BElement) BoolAttrf(format string, args ...any) *BElement {
 
 func (e *BElement) IfBoolAttrf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *BElement) BoolAttrs(names ...string) *BElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *BElement) IfBoolAttrs(condition bool, names ...string) *BElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *BElement) Attr(name, value string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *BElement) RawAttr(name, value string) *BElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *BElement) IfAttr(condition bool, name, value string) *BElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -122,61 +181,52 @@ func (e *BElement) Attrf(name, format string, args ...any) *BElement {
 
 func (e *BElement) IfAttrf(condition bool, name, format string, args ...any) *BElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *BElement) Attrs(attrs ...string) *BElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *BElement) IfAttrs(condition bool, attrs ...string) *BElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *BElement) AttrsMap(attrs map[string]string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *BElement) IfAttrsMap(condition bool, attrs map[string]string) *BElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *BElement) Text(text string) *BElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *BElement) Textf(format string, args ...any) *BElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *BElement) IfText(condition bool, text string) *BElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -184,18 +234,28 @@ func (e *BElement) IfText(condition bool, text string) *BElement {
 
 func (e *BElement) IfTextf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *BElement) Raw(text string) *BElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *BElement) Escaped(text string) *BElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *BElement) IfEscaped(condition bool, text string) *BElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -207,6 +267,7 @@ func (e *BElement) Escapedf(format string, args ...any) *BElement {
 
 func (e *BElement) IfEscapedf(condition bool, format string, args ...any) *BElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -217,10 +278,8 @@ func (e *BElement) IfEscapedf(condition bool, format string, args ...any) *BElem
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *BElement) Accesskey(r rune) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -230,7 +289,7 @@ func (e *BElement) Accesskey(r rune) *BElement {
 // can be generated by the keyboard).
 func (e *BElement) IfAccesskey(condition bool, r rune) *BElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -241,10 +300,8 @@ func (e *BElement) IfAccesskey(condition bool, r rune) *BElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *BElement) AccesskeyRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -260,10 +317,8 @@ func (e *BElement) AccesskeyRemove() *BElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *BElement) Autocapitalize(c BAutocapitalizeChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[BAutocapitalizeChoice])
 	return e
 }
 
@@ -297,10 +352,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *BElement) AutocapitalizeRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -319,10 +372,8 @@ func (e *BElement) AutocapitalizeRemove() *BElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BElement) Autofocus() *BElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -342,7 +393,7 @@ func (e *BElement) Autofocus() *BElement {
 // created by the preceding content.
 func (e *BElement) IfAutofocus(condition bool) *BElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -363,10 +414,8 @@ func (e *BElement) IfAutofocus(condition bool) *BElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BElement) AutofocusRemove() *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -386,10 +435,8 @@ func (e *BElement) AutofocusRemove() *BElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BElement) AutofocusIfRemove() *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -398,16 +445,9 @@ func (e *BElement) AutofocusIfRemove() *BElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *BElement) Class(s string) *BElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -417,7 +457,7 @@ func (e *BElement) Class(s string) *BElement {
 // document.getElementsByClassName.
 func (e *BElement) IfClass(condition bool, s string) *BElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -428,14 +468,8 @@ func (e *BElement) IfClass(condition bool, s string) *BElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *BElement) ClassRemove(s ...string) *BElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -443,10 +477,8 @@ func (e *BElement) ClassRemove(s ...string) *BElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *BElement) Contenteditable(c BContenteditableChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[BContenteditableChoice])
 	return e
 }
 
@@ -469,10 +501,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *BElement) ContenteditableRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -490,10 +520,8 @@ func (e *BElement) ContenteditableRemove() *BElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *BElement) Dir(c BDirChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[BDirChoice])
 	return e
 }
 
@@ -527,10 +555,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *BElement) DirRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -538,10 +564,8 @@ func (e *BElement) DirRemove() *BElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *BElement) Draggable(c BDraggableChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[BDraggableChoice])
 	return e
 }
 
@@ -567,20 +591,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *BElement) DraggableRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *BElement) Enterkeyhint(c BEnterkeyhintChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[BEnterkeyhintChoice])
 	return e
 }
 
@@ -610,10 +630,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *BElement) EnterkeyhintRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -635,16 +653,9 @@ func (e *BElement) EnterkeyhintRemove() *BElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *BElement) Exportparts(s string) *BElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -667,7 +678,7 @@ func (e *BElement) Exportparts(s string) *BElement {
 // the current structure.
 func (e *BElement) IfExportparts(condition bool, s string) *BElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -691,14 +702,8 @@ func (e *BElement) IfExportparts(condition bool, s string) *BElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *BElement) ExportpartsRemove(s ...string) *BElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -715,10 +720,8 @@ func (e *BElement) ExportpartsRemove(s ...string) *BElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *BElement) Hidden(c BHiddenChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[BHiddenChoice])
 	return e
 }
 
@@ -754,10 +757,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *BElement) HiddenRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -765,10 +766,8 @@ func (e *BElement) HiddenRemove() *BElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BElement) ID(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -784,7 +783,7 @@ func (e *BElement) IDf(format string, args ...any) *BElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BElement) IfID(condition bool, s string) *BElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -794,7 +793,7 @@ func (e *BElement) IfID(condition bool, s string) *BElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BElement) IfIDf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -804,10 +803,8 @@ func (e *BElement) IfIDf(condition bool, format string, args ...any) *BElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *BElement) IDRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -822,10 +819,8 @@ func (e *BElement) IDRemove() *BElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BElement) Inert() *BElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -841,7 +836,7 @@ func (e *BElement) Inert() *BElement {
 // excluding them from the accessibility tree.
 func (e *BElement) IfInert(condition bool) *BElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -858,10 +853,8 @@ func (e *BElement) IfInert(condition bool) *BElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BElement) InertRemove() *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -877,10 +870,8 @@ func (e *BElement) InertRemove() *BElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BElement) InertIfRemove() *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -894,10 +885,8 @@ func (e *BElement) InertIfRemove() *BElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *BElement) Inputmode(c BInputmodeChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[BInputmodeChoice])
 	return e
 }
 
@@ -948,10 +937,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *BElement) InputmodeRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -961,10 +948,8 @@ func (e *BElement) InputmodeRemove() *BElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *BElement) Is(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -984,7 +969,7 @@ func (e *BElement) Isf(format string, args ...any) *BElement {
 // and extends the element type it is being applied to.
 func (e *BElement) IfIs(condition bool, s string) *BElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -996,7 +981,7 @@ func (e *BElement) IfIs(condition bool, s string) *BElement {
 // and extends the element type it is being applied to.
 func (e *BElement) IfIsf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1008,10 +993,8 @@ func (e *BElement) IfIsf(condition bool, format string, args ...any) *BElement {
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *BElement) IsRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1027,10 +1010,8 @@ func (e *BElement) IsRemove() *BElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *BElement) Itemid(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1062,7 +1043,7 @@ func (e *BElement) Itemidf(format string, args ...any) *BElement {
 // how items with the same identifier are handled.
 func (e *BElement) IfItemid(condition bool, s string) *BElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1080,7 +1061,7 @@ func (e *BElement) IfItemid(condition bool, s string) *BElement {
 // how items with the same identifier are handled.
 func (e *BElement) IfItemidf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1098,10 +1079,8 @@ func (e *BElement) IfItemidf(condition bool, format string, args ...any) *BEleme
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *BElement) ItemidRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1113,10 +1092,8 @@ func (e *BElement) ItemidRemove() *BElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *BElement) Itemprop(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1140,7 +1117,7 @@ func (e *BElement) Itempropf(format string, args ...any) *BElement {
 // <track>, and <video>.
 func (e *BElement) IfItemprop(condition bool, s string) *BElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1154,7 +1131,7 @@ func (e *BElement) IfItemprop(condition bool, s string) *BElement {
 // <track>, and <video>.
 func (e *BElement) IfItempropf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1168,10 +1145,8 @@ func (e *BElement) IfItempropf(condition bool, format string, args ...any) *BEle
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *BElement) ItempropRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1181,10 +1156,8 @@ func (e *BElement) ItempropRemove() *BElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *BElement) Itemref(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1204,7 +1177,7 @@ func (e *BElement) Itemreff(format string, args ...any) *BElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BElement) IfItemref(condition bool, s string) *BElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1216,7 +1189,7 @@ func (e *BElement) IfItemref(condition bool, s string) *BElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BElement) IfItemreff(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1228,10 +1201,8 @@ func (e *BElement) IfItemreff(condition bool, format string, args ...any) *BElem
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *BElement) ItemrefRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1243,10 +1214,8 @@ func (e *BElement) ItemrefRemove() *BElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BElement) Itemscope() *BElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1259,7 +1228,7 @@ func (e *BElement) Itemscope() *BElement {
 // <object>, <source>, <track>, and <video>.
 func (e *BElement) IfItemscope(condition bool) *BElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1273,10 +1242,8 @@ func (e *BElement) IfItemscope(condition bool) *BElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BElement) ItemscopeRemove() *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1289,10 +1256,8 @@ func (e *BElement) ItemscopeRemove() *BElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BElement) ItemscopeIfRemove() *BElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1304,10 +1269,8 @@ func (e *BElement) ItemscopeIfRemove() *BElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *BElement) Itemtype(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1331,7 +1294,7 @@ func (e *BElement) Itemtypef(format string, args ...any) *BElement {
 // <video>.
 func (e *BElement) IfItemtype(condition bool, s string) *BElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1345,7 +1308,7 @@ func (e *BElement) IfItemtype(condition bool, s string) *BElement {
 // <video>.
 func (e *BElement) IfItemtypef(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1359,10 +1322,8 @@ func (e *BElement) IfItemtypef(condition bool, format string, args ...any) *BEle
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *BElement) ItemtypeRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1372,10 +1333,8 @@ func (e *BElement) ItemtypeRemove() *BElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BElement) Lang(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1395,7 +1354,7 @@ func (e *BElement) Langf(format string, args ...any) *BElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BElement) IfLang(condition bool, s string) *BElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1407,7 +1366,7 @@ func (e *BElement) IfLang(condition bool, s string) *BElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BElement) IfLangf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1419,10 +1378,8 @@ func (e *BElement) IfLangf(condition bool, format string, args ...any) *BElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *BElement) LangRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1432,10 +1389,8 @@ func (e *BElement) LangRemove() *BElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *BElement) Nonce(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1455,7 +1410,7 @@ func (e *BElement) Noncef(format string, args ...any) *BElement {
 // execute.
 func (e *BElement) IfNonce(condition bool, s string) *BElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1467,7 +1422,7 @@ func (e *BElement) IfNonce(condition bool, s string) *BElement {
 // execute.
 func (e *BElement) IfNoncef(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1479,10 +1434,8 @@ func (e *BElement) IfNoncef(condition bool, format string, args ...any) *BElemen
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *BElement) NonceRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1490,16 +1443,9 @@ func (e *BElement) NonceRemove() *BElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *BElement) Part(s string) *BElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1508,7 +1454,7 @@ func (e *BElement) Part(s string) *BElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *BElement) IfPart(condition bool, s string) *BElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1518,14 +1464,8 @@ func (e *BElement) IfPart(condition bool, s string) *BElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *BElement) PartRemove(s ...string) *BElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1536,10 +1476,8 @@ func (e *BElement) PartRemove(s ...string) *BElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *BElement) Popover(c BPopoverChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[BPopoverChoice])
 	return e
 }
 
@@ -1567,10 +1505,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *BElement) PopoverRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1579,10 +1515,8 @@ func (e *BElement) PopoverRemove() *BElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *BElement) Role(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1600,7 +1534,7 @@ func (e *BElement) Rolef(format string, args ...any) *BElement {
 // role of an element.
 func (e *BElement) IfRole(condition bool, s string) *BElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1611,7 +1545,7 @@ func (e *BElement) IfRole(condition bool, s string) *BElement {
 // role of an element.
 func (e *BElement) IfRolef(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1622,10 +1556,8 @@ func (e *BElement) IfRolef(condition bool, format string, args ...any) *BElement
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *BElement) RoleRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1634,10 +1566,8 @@ func (e *BElement) RoleRemove() *BElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *BElement) Slot(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1655,7 +1585,7 @@ func (e *BElement) Slotf(format string, args ...any) *BElement {
 // value.
 func (e *BElement) IfSlot(condition bool, s string) *BElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1666,7 +1596,7 @@ func (e *BElement) IfSlot(condition bool, s string) *BElement {
 // value.
 func (e *BElement) IfSlotf(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1677,10 +1607,8 @@ func (e *BElement) IfSlotf(condition bool, format string, args ...any) *BElement
 // value.
 // Remove the attribute Slot from the element.
 func (e *BElement) SlotRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1696,10 +1624,8 @@ func (e *BElement) SlotRemove() *BElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *BElement) Spellcheck(c BSpellcheckChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[BSpellcheckChoice])
 	return e
 }
 
@@ -1727,10 +1653,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *BElement) SpellcheckRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1740,14 +1664,8 @@ func (e *BElement) StylePairs(pairs ...string) *BElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1762,14 +1680,8 @@ func (e *BElement) StylePairs(pairs ...string) *BElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BElement) Style(s string) *BElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1777,7 +1689,7 @@ func (e *BElement) Style(s string) *BElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1786,7 +1698,7 @@ func (e *BElement) Style(s string) *BElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BElement) IfStyle(condition bool, s string) *BElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1794,15 +1706,7 @@ func (e *BElement) IfStyle(condition bool, s string) *BElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BElement) StyleAdd(k string, v string) *BElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1816,7 +1720,7 @@ func (e *BElement) StyleAddf(k string, format string, args ...any) *BElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BElement) IfStyleAdd(condition bool, k string, v string) *BElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1825,7 +1729,7 @@ func (e *BElement) IfStyleAdd(condition bool, k string, v string) *BElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BElement) IfStyleAddf(condition bool, k string, format string, args ...any) *BElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1834,21 +1738,15 @@ func (e *BElement) IfStyleAddf(condition bool, k string, format string, args ...
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *BElement) StyleMap(m map[string]string) *BElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1857,14 +1755,8 @@ func (e *BElement) StyleMap(m map[string]string) *BElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *BElement) StyleRemove(keys ...string) *BElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1882,10 +1774,8 @@ func (e *BElement) StyleRemove(keys ...string) *BElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *BElement) Tabindex(i int) *BElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1904,7 +1794,7 @@ func (e *BElement) Tabindex(i int) *BElement {
 // their relative position in the document.
 func (e *BElement) IfTabindex(condition bool, i int) *BElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1924,10 +1814,8 @@ func (e *BElement) IfTabindex(condition bool, i int) *BElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *BElement) TabindexRemove() *BElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1948,10 +1836,8 @@ func (e *BElement) TabindexRemove() *BElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *BElement) Title(s string) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1993,7 +1879,7 @@ func (e *BElement) Titlef(format string, args ...any) *BElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BElement) IfTitle(condition bool, s string) *BElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2016,7 +1902,7 @@ func (e *BElement) IfTitle(condition bool, s string) *BElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BElement) IfTitlef(condition bool, format string, args ...any) *BElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2039,10 +1925,8 @@ func (e *BElement) IfTitlef(condition bool, format string, args ...any) *BElemen
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *BElement) TitleRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2051,10 +1935,8 @@ func (e *BElement) TitleRemove() *BElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *BElement) Translate(c BTranslateChoice) *BElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[BTranslateChoice])
 	return e
 }
 
@@ -2075,9 +1957,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *BElement) TranslateRemove() *BElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <base> element specifies the base URL to use for all relative URLs
@@ -22,18 +20,77 @@ type BaseElement struct {
 // with the tag "base" during rendering.
 func Base() *BaseElement {
 	e := NewElement("base")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &BaseElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *BaseElement) Clone() *BaseElement {
+	return &BaseElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *BaseElement) CloneShallow() *BaseElement {
+	return &BaseElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *BaseElement) Freeze() *BaseElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *BaseElement) writable() *BaseElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *BaseElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *BaseElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[BaseAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[BaseContenteditableChoice]
+	case "dir":
+		return choiceValue[BaseDirChoice]
+	case "draggable":
+		return choiceValue[BaseDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[BaseEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[BaseHiddenChoice]
+	case "inputmode":
+		return choiceValue[BaseInputmodeChoice]
+	case "popover":
+		return choiceValue[BasePopoverChoice]
+	case "spellcheck":
+		return choiceValue[BaseSpellcheckChoice]
+	case "translate":
+		return choiceValue[BaseTranslateChoice]
+	}
+	return nil
+}
+
 func (e *BaseElement) Children(children ...ElementRenderer) *BaseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *BaseElement) IfChildren(condition bool, children ...ElementRenderer) *BaseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *BaseElement) IfChildren(condition bool, children ...ElementRenderer) *B
 
 func (e *BaseElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *BaseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *BaseElement) BoolAttr(name string) *BaseElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *BaseElement) BoolAttrRemove(name string) *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *BaseElement) IfBoolAttr(condition bool, name string) *BaseElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *BaseElement) BoolAttrf(format string, args ...any) *BaseElement {
 
 func (e *BaseElement) IfBoolAttrf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *BaseElement) BoolAttrs(names ...string) *BaseElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *BaseElement) IfBoolAttrs(condition bool, names ...string) *BaseElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *BaseElement) Attr(name, value string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *BaseElement) RawAttr(name, value string) *BaseElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *BaseElement) IfAttr(condition bool, name, value string) *BaseElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *BaseElement) Attrf(name, format string, args ...any) *BaseElement {
 
 func (e *BaseElement) IfAttrf(condition bool, name, format string, args ...any) *BaseElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *BaseElement) Attrs(attrs ...string) *BaseElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *BaseElement) IfAttrs(condition bool, attrs ...string) *BaseElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *BaseElement) AttrsMap(attrs map[string]string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *BaseElement) IfAttrsMap(condition bool, attrs map[string]string) *BaseElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *BaseElement) Text(text string) *BaseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *BaseElement) Textf(format string, args ...any) *BaseElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *BaseElement) IfText(condition bool, text string) *BaseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *BaseElement) IfText(condition bool, text string) *BaseElement {
 
 func (e *BaseElement) IfTextf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *BaseElement) Raw(text string) *BaseElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *BaseElement) Escaped(text string) *BaseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *BaseElement) IfEscaped(condition bool, text string) *BaseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *BaseElement) Escapedf(format string, args ...any) *BaseElement {
 
 func (e *BaseElement) IfEscapedf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +270,8 @@ func (e *BaseElement) IfEscapedf(condition bool, format string, args ...any) *Ba
 
 // Specifies the base URL to use for all relative URLs in the page.
 func (e *BaseElement) Href(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -224,7 +283,7 @@ func (e *BaseElement) Hreff(format string, args ...any) *BaseElement {
 // Specifies the base URL to use for all relative URLs in the page.
 func (e *BaseElement) IfHref(condition bool, s string) *BaseElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -232,7 +291,7 @@ func (e *BaseElement) IfHref(condition bool, s string) *BaseElement {
 // Specifies the base URL to use for all relative URLs in the page.
 func (e *BaseElement) IfHreff(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +299,15 @@ func (e *BaseElement) IfHreff(condition bool, format string, args ...any) *BaseE
 // Specifies the base URL to use for all relative URLs in the page.
 // Remove the attribute Href from the element.
 func (e *BaseElement) HrefRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies the default target Attribute to use for the page.
 func (e *BaseElement) Target(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("target", s)
+	e = e.writable()
+	e.setStringAttribute("target", s)
 	return e
 }
 
@@ -264,7 +319,7 @@ func (e *BaseElement) Targetf(format string, args ...any) *BaseElement {
 // Specifies the default target Attribute to use for the page.
 func (e *BaseElement) IfTarget(condition bool, s string) *BaseElement {
 	if condition {
-		e.Target(s)
+		e = e.Target(s)
 	}
 	return e
 }
@@ -272,7 +327,7 @@ func (e *BaseElement) IfTarget(condition bool, s string) *BaseElement {
 // Specifies the default target Attribute to use for the page.
 func (e *BaseElement) IfTargetf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Target(fmt.Sprintf(format, args...))
+		e = e.Target(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,10 +335,8 @@ func (e *BaseElement) IfTargetf(condition bool, format string, args ...any) *Bas
 // Specifies the default target Attribute to use for the page.
 // Remove the attribute Target from the element.
 func (e *BaseElement) TargetRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("target")
+	e = e.writable()
+	e.removeAttribute("target")
 	return e
 }
 
@@ -292,10 +345,8 @@ func (e *BaseElement) TargetRemove() *BaseElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *BaseElement) Accesskey(r rune) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -305,7 +356,7 @@ func (e *BaseElement) Accesskey(r rune) *BaseElement {
 // can be generated by the keyboard).
 func (e *BaseElement) IfAccesskey(condition bool, r rune) *BaseElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -316,10 +367,8 @@ func (e *BaseElement) IfAccesskey(condition bool, r rune) *BaseElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *BaseElement) AccesskeyRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -335,10 +384,8 @@ func (e *BaseElement) AccesskeyRemove() *BaseElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *BaseElement) Autocapitalize(c BaseAutocapitalizeChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[BaseAutocapitalizeChoice])
 	return e
 }
 
@@ -372,10 +419,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *BaseElement) AutocapitalizeRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -394,10 +439,8 @@ func (e *BaseElement) AutocapitalizeRemove() *BaseElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BaseElement) Autofocus() *BaseElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -417,7 +460,7 @@ func (e *BaseElement) Autofocus() *BaseElement {
 // created by the preceding content.
 func (e *BaseElement) IfAutofocus(condition bool) *BaseElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -438,10 +481,8 @@ func (e *BaseElement) IfAutofocus(condition bool) *BaseElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BaseElement) AutofocusRemove() *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -461,10 +502,8 @@ func (e *BaseElement) AutofocusRemove() *BaseElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BaseElement) AutofocusIfRemove() *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -473,16 +512,9 @@ func (e *BaseElement) AutofocusIfRemove() *BaseElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *BaseElement) Class(s string) *BaseElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -492,7 +524,7 @@ func (e *BaseElement) Class(s string) *BaseElement {
 // document.getElementsByClassName.
 func (e *BaseElement) IfClass(condition bool, s string) *BaseElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -503,14 +535,8 @@ func (e *BaseElement) IfClass(condition bool, s string) *BaseElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *BaseElement) ClassRemove(s ...string) *BaseElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -518,10 +544,8 @@ func (e *BaseElement) ClassRemove(s ...string) *BaseElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *BaseElement) Contenteditable(c BaseContenteditableChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[BaseContenteditableChoice])
 	return e
 }
 
@@ -544,10 +568,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *BaseElement) ContenteditableRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -565,10 +587,8 @@ func (e *BaseElement) ContenteditableRemove() *BaseElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *BaseElement) Dir(c BaseDirChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[BaseDirChoice])
 	return e
 }
 
@@ -602,10 +622,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *BaseElement) DirRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -613,10 +631,8 @@ func (e *BaseElement) DirRemove() *BaseElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *BaseElement) Draggable(c BaseDraggableChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[BaseDraggableChoice])
 	return e
 }
 
@@ -642,20 +658,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *BaseElement) DraggableRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *BaseElement) Enterkeyhint(c BaseEnterkeyhintChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[BaseEnterkeyhintChoice])
 	return e
 }
 
@@ -685,10 +697,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *BaseElement) EnterkeyhintRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -710,16 +720,9 @@ func (e *BaseElement) EnterkeyhintRemove() *BaseElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *BaseElement) Exportparts(s string) *BaseElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -742,7 +745,7 @@ func (e *BaseElement) Exportparts(s string) *BaseElement {
 // the current structure.
 func (e *BaseElement) IfExportparts(condition bool, s string) *BaseElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -766,14 +769,8 @@ func (e *BaseElement) IfExportparts(condition bool, s string) *BaseElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *BaseElement) ExportpartsRemove(s ...string) *BaseElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -790,10 +787,8 @@ func (e *BaseElement) ExportpartsRemove(s ...string) *BaseElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *BaseElement) Hidden(c BaseHiddenChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[BaseHiddenChoice])
 	return e
 }
 
@@ -829,10 +824,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *BaseElement) HiddenRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -840,10 +833,8 @@ func (e *BaseElement) HiddenRemove() *BaseElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BaseElement) ID(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -859,7 +850,7 @@ func (e *BaseElement) IDf(format string, args ...any) *BaseElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BaseElement) IfID(condition bool, s string) *BaseElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -869,7 +860,7 @@ func (e *BaseElement) IfID(condition bool, s string) *BaseElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BaseElement) IfIDf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -879,10 +870,8 @@ func (e *BaseElement) IfIDf(condition bool, format string, args ...any) *BaseEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *BaseElement) IDRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -897,10 +886,8 @@ func (e *BaseElement) IDRemove() *BaseElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BaseElement) Inert() *BaseElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -916,7 +903,7 @@ func (e *BaseElement) Inert() *BaseElement {
 // excluding them from the accessibility tree.
 func (e *BaseElement) IfInert(condition bool) *BaseElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -933,10 +920,8 @@ func (e *BaseElement) IfInert(condition bool) *BaseElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BaseElement) InertRemove() *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -952,10 +937,8 @@ func (e *BaseElement) InertRemove() *BaseElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BaseElement) InertIfRemove() *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -969,10 +952,8 @@ func (e *BaseElement) InertIfRemove() *BaseElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *BaseElement) Inputmode(c BaseInputmodeChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[BaseInputmodeChoice])
 	return e
 }
 
@@ -1023,10 +1004,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *BaseElement) InputmodeRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1036,10 +1015,8 @@ func (e *BaseElement) InputmodeRemove() *BaseElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *BaseElement) Is(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1059,7 +1036,7 @@ func (e *BaseElement) Isf(format string, args ...any) *BaseElement {
 // and extends the element type it is being applied to.
 func (e *BaseElement) IfIs(condition bool, s string) *BaseElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1071,7 +1048,7 @@ func (e *BaseElement) IfIs(condition bool, s string) *BaseElement {
 // and extends the element type it is being applied to.
 func (e *BaseElement) IfIsf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1083,10 +1060,8 @@ func (e *BaseElement) IfIsf(condition bool, format string, args ...any) *BaseEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *BaseElement) IsRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1102,10 +1077,8 @@ func (e *BaseElement) IsRemove() *BaseElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *BaseElement) Itemid(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1137,7 +1110,7 @@ func (e *BaseElement) Itemidf(format string, args ...any) *BaseElement {
 // how items with the same identifier are handled.
 func (e *BaseElement) IfItemid(condition bool, s string) *BaseElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1155,7 +1128,7 @@ func (e *BaseElement) IfItemid(condition bool, s string) *BaseElement {
 // how items with the same identifier are handled.
 func (e *BaseElement) IfItemidf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1173,10 +1146,8 @@ func (e *BaseElement) IfItemidf(condition bool, format string, args ...any) *Bas
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *BaseElement) ItemidRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1188,10 +1159,8 @@ func (e *BaseElement) ItemidRemove() *BaseElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *BaseElement) Itemprop(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1215,7 +1184,7 @@ func (e *BaseElement) Itempropf(format string, args ...any) *BaseElement {
 // <track>, and <video>.
 func (e *BaseElement) IfItemprop(condition bool, s string) *BaseElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1229,7 +1198,7 @@ func (e *BaseElement) IfItemprop(condition bool, s string) *BaseElement {
 // <track>, and <video>.
 func (e *BaseElement) IfItempropf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1243,10 +1212,8 @@ func (e *BaseElement) IfItempropf(condition bool, format string, args ...any) *B
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *BaseElement) ItempropRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1256,10 +1223,8 @@ func (e *BaseElement) ItempropRemove() *BaseElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *BaseElement) Itemref(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1279,7 +1244,7 @@ func (e *BaseElement) Itemreff(format string, args ...any) *BaseElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BaseElement) IfItemref(condition bool, s string) *BaseElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1291,7 +1256,7 @@ func (e *BaseElement) IfItemref(condition bool, s string) *BaseElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BaseElement) IfItemreff(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1303,10 +1268,8 @@ func (e *BaseElement) IfItemreff(condition bool, format string, args ...any) *Ba
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *BaseElement) ItemrefRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1318,10 +1281,8 @@ func (e *BaseElement) ItemrefRemove() *BaseElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BaseElement) Itemscope() *BaseElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1334,7 +1295,7 @@ func (e *BaseElement) Itemscope() *BaseElement {
 // <object>, <source>, <track>, and <video>.
 func (e *BaseElement) IfItemscope(condition bool) *BaseElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1348,10 +1309,8 @@ func (e *BaseElement) IfItemscope(condition bool) *BaseElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BaseElement) ItemscopeRemove() *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1364,10 +1323,8 @@ func (e *BaseElement) ItemscopeRemove() *BaseElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BaseElement) ItemscopeIfRemove() *BaseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1379,10 +1336,8 @@ func (e *BaseElement) ItemscopeIfRemove() *BaseElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *BaseElement) Itemtype(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1406,7 +1361,7 @@ func (e *BaseElement) Itemtypef(format string, args ...any) *BaseElement {
 // <video>.
 func (e *BaseElement) IfItemtype(condition bool, s string) *BaseElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1420,7 +1375,7 @@ func (e *BaseElement) IfItemtype(condition bool, s string) *BaseElement {
 // <video>.
 func (e *BaseElement) IfItemtypef(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1434,10 +1389,8 @@ func (e *BaseElement) IfItemtypef(condition bool, format string, args ...any) *B
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *BaseElement) ItemtypeRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1447,10 +1400,8 @@ func (e *BaseElement) ItemtypeRemove() *BaseElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BaseElement) Lang(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1470,7 +1421,7 @@ func (e *BaseElement) Langf(format string, args ...any) *BaseElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BaseElement) IfLang(condition bool, s string) *BaseElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1482,7 +1433,7 @@ func (e *BaseElement) IfLang(condition bool, s string) *BaseElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BaseElement) IfLangf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1494,10 +1445,8 @@ func (e *BaseElement) IfLangf(condition bool, format string, args ...any) *BaseE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *BaseElement) LangRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1507,10 +1456,8 @@ func (e *BaseElement) LangRemove() *BaseElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *BaseElement) Nonce(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1530,7 +1477,7 @@ func (e *BaseElement) Noncef(format string, args ...any) *BaseElement {
 // execute.
 func (e *BaseElement) IfNonce(condition bool, s string) *BaseElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1542,7 +1489,7 @@ func (e *BaseElement) IfNonce(condition bool, s string) *BaseElement {
 // execute.
 func (e *BaseElement) IfNoncef(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1554,10 +1501,8 @@ func (e *BaseElement) IfNoncef(condition bool, format string, args ...any) *Base
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *BaseElement) NonceRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1565,16 +1510,9 @@ func (e *BaseElement) NonceRemove() *BaseElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *BaseElement) Part(s string) *BaseElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1583,7 +1521,7 @@ func (e *BaseElement) Part(s string) *BaseElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *BaseElement) IfPart(condition bool, s string) *BaseElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1593,14 +1531,8 @@ func (e *BaseElement) IfPart(condition bool, s string) *BaseElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *BaseElement) PartRemove(s ...string) *BaseElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1611,10 +1543,8 @@ func (e *BaseElement) PartRemove(s ...string) *BaseElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *BaseElement) Popover(c BasePopoverChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[BasePopoverChoice])
 	return e
 }
 
@@ -1642,10 +1572,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *BaseElement) PopoverRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1654,10 +1582,8 @@ func (e *BaseElement) PopoverRemove() *BaseElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *BaseElement) Role(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1675,7 +1601,7 @@ func (e *BaseElement) Rolef(format string, args ...any) *BaseElement {
 // role of an element.
 func (e *BaseElement) IfRole(condition bool, s string) *BaseElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1686,7 +1612,7 @@ func (e *BaseElement) IfRole(condition bool, s string) *BaseElement {
 // role of an element.
 func (e *BaseElement) IfRolef(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1697,10 +1623,8 @@ func (e *BaseElement) IfRolef(condition bool, format string, args ...any) *BaseE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *BaseElement) RoleRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1709,10 +1633,8 @@ func (e *BaseElement) RoleRemove() *BaseElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *BaseElement) Slot(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1730,7 +1652,7 @@ func (e *BaseElement) Slotf(format string, args ...any) *BaseElement {
 // value.
 func (e *BaseElement) IfSlot(condition bool, s string) *BaseElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1741,7 +1663,7 @@ func (e *BaseElement) IfSlot(condition bool, s string) *BaseElement {
 // value.
 func (e *BaseElement) IfSlotf(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1752,10 +1674,8 @@ func (e *BaseElement) IfSlotf(condition bool, format string, args ...any) *BaseE
 // value.
 // Remove the attribute Slot from the element.
 func (e *BaseElement) SlotRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1771,10 +1691,8 @@ func (e *BaseElement) SlotRemove() *BaseElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *BaseElement) Spellcheck(c BaseSpellcheckChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[BaseSpellcheckChoice])
 	return e
 }
 
@@ -1802,10 +1720,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *BaseElement) SpellcheckRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1815,14 +1731,8 @@ func (e *BaseElement) StylePairs(pairs ...string) *BaseElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1837,14 +1747,8 @@ func (e *BaseElement) StylePairs(pairs ...string) *BaseElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BaseElement) Style(s string) *BaseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1852,7 +1756,7 @@ func (e *BaseElement) Style(s string) *BaseElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1861,7 +1765,7 @@ func (e *BaseElement) Style(s string) *BaseElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BaseElement) IfStyle(condition bool, s string) *BaseElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1869,15 +1773,7 @@ func (e *BaseElement) IfStyle(condition bool, s string) *BaseElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BaseElement) StyleAdd(k string, v string) *BaseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1891,7 +1787,7 @@ func (e *BaseElement) StyleAddf(k string, format string, args ...any) *BaseEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *BaseElement) IfStyleAdd(condition bool, k string, v string) *BaseElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1900,7 +1796,7 @@ func (e *BaseElement) IfStyleAdd(condition bool, k string, v string) *BaseElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *BaseElement) IfStyleAddf(condition bool, k string, format string, args ...any) *BaseElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1909,21 +1805,15 @@ func (e *BaseElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *BaseElement) StyleMap(m map[string]string) *BaseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1932,14 +1822,8 @@ func (e *BaseElement) StyleMap(m map[string]string) *BaseElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *BaseElement) StyleRemove(keys ...string) *BaseElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1957,10 +1841,8 @@ func (e *BaseElement) StyleRemove(keys ...string) *BaseElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *BaseElement) Tabindex(i int) *BaseElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1979,7 +1861,7 @@ func (e *BaseElement) Tabindex(i int) *BaseElement {
 // their relative position in the document.
 func (e *BaseElement) IfTabindex(condition bool, i int) *BaseElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1999,10 +1881,8 @@ func (e *BaseElement) IfTabindex(condition bool, i int) *BaseElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *BaseElement) TabindexRemove() *BaseElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2023,10 +1903,8 @@ func (e *BaseElement) TabindexRemove() *BaseElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *BaseElement) Title(s string) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2068,7 +1946,7 @@ func (e *BaseElement) Titlef(format string, args ...any) *BaseElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BaseElement) IfTitle(condition bool, s string) *BaseElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2091,7 +1969,7 @@ func (e *BaseElement) IfTitle(condition bool, s string) *BaseElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BaseElement) IfTitlef(condition bool, format string, args ...any) *BaseElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2114,10 +1992,8 @@ func (e *BaseElement) IfTitlef(condition bool, format string, args ...any) *Base
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *BaseElement) TitleRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2126,10 +2002,8 @@ func (e *BaseElement) TitleRemove() *BaseElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *BaseElement) Translate(c BaseTranslateChoice) *BaseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[BaseTranslateChoice])
 	return e
 }
 
@@ -2150,9 +2024,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *BaseElement) TranslateRemove() *BaseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
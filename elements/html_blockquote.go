@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <blockquote> Element (or HTML Block Quotation Element) indicates
@@ -25,18 +23,77 @@ type BlockquoteElement struct {
 // with the tag "blockquote" during rendering.
 func Blockquote(children ...ElementRenderer) *BlockquoteElement {
 	e := NewElement("blockquote", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &BlockquoteElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *BlockquoteElement) Clone() *BlockquoteElement {
+	return &BlockquoteElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *BlockquoteElement) CloneShallow() *BlockquoteElement {
+	return &BlockquoteElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *BlockquoteElement) Freeze() *BlockquoteElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *BlockquoteElement) writable() *BlockquoteElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *BlockquoteElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *BlockquoteElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[BlockquoteAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[BlockquoteContenteditableChoice]
+	case "dir":
+		return choiceValue[BlockquoteDirChoice]
+	case "draggable":
+		return choiceValue[BlockquoteDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[BlockquoteEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[BlockquoteHiddenChoice]
+	case "inputmode":
+		return choiceValue[BlockquoteInputmodeChoice]
+	case "popover":
+		return choiceValue[BlockquotePopoverChoice]
+	case "spellcheck":
+		return choiceValue[BlockquoteSpellcheckChoice]
+	case "translate":
+		return choiceValue[BlockquoteTranslateChoice]
+	}
+	return nil
+}
+
 func (e *BlockquoteElement) Children(children ...ElementRenderer) *BlockquoteElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *BlockquoteElement) IfChildren(condition bool, children ...ElementRenderer) *BlockquoteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -44,32 +101,30 @@ func (e *BlockquoteElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *BlockquoteElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *BlockquoteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) BoolAttr(name string) *BlockquoteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *BlockquoteElement) BoolAttrRemove(name string) *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *BlockquoteElement) IfBoolAttr(condition bool, name string) *BlockquoteElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -80,36 +135,40 @@ func (e *BlockquoteElement) BoolAttrf(format string, args ...any) *BlockquoteEle
 
 func (e *BlockquoteElement) IfBoolAttrf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) BoolAttrs(names ...string) *BlockquoteElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) IfBoolAttrs(condition bool, names ...string) *BlockquoteElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) Attr(name, value string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *BlockquoteElement) RawAttr(name, value string) *BlockquoteElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *BlockquoteElement) IfAttr(condition bool, name, value string) *BlockquoteElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -120,61 +179,52 @@ func (e *BlockquoteElement) Attrf(name, format string, args ...any) *BlockquoteE
 
 func (e *BlockquoteElement) IfAttrf(condition bool, name, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) Attrs(attrs ...string) *BlockquoteElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *BlockquoteElement) IfAttrs(condition bool, attrs ...string) *BlockquoteElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) AttrsMap(attrs map[string]string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *BlockquoteElement) IfAttrsMap(condition bool, attrs map[string]string) *BlockquoteElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *BlockquoteElement) Text(text string) *BlockquoteElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *BlockquoteElement) Textf(format string, args ...any) *BlockquoteElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *BlockquoteElement) IfText(condition bool, text string) *BlockquoteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -182,18 +232,28 @@ func (e *BlockquoteElement) IfText(condition bool, text string) *BlockquoteEleme
 
 func (e *BlockquoteElement) IfTextf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *BlockquoteElement) Raw(text string) *BlockquoteElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *BlockquoteElement) Escaped(text string) *BlockquoteElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *BlockquoteElement) IfEscaped(condition bool, text string) *BlockquoteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -205,6 +265,7 @@ func (e *BlockquoteElement) Escapedf(format string, args ...any) *BlockquoteElem
 
 func (e *BlockquoteElement) IfEscapedf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +273,8 @@ func (e *BlockquoteElement) IfEscapedf(condition bool, format string, args ...an
 
 // Contains a URI which points to the source of the quote or change.
 func (e *BlockquoteElement) Cite(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("cite", s)
+	e = e.writable()
+	e.setStringAttribute("cite", s)
 	return e
 }
 
@@ -227,7 +286,7 @@ func (e *BlockquoteElement) Citef(format string, args ...any) *BlockquoteElement
 // Contains a URI which points to the source of the quote or change.
 func (e *BlockquoteElement) IfCite(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Cite(s)
+		e = e.Cite(s)
 	}
 	return e
 }
@@ -235,7 +294,7 @@ func (e *BlockquoteElement) IfCite(condition bool, s string) *BlockquoteElement
 // Contains a URI which points to the source of the quote or change.
 func (e *BlockquoteElement) IfCitef(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Cite(fmt.Sprintf(format, args...))
+		e = e.Cite(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -243,10 +302,8 @@ func (e *BlockquoteElement) IfCitef(condition bool, format string, args ...any)
 // Contains a URI which points to the source of the quote or change.
 // Remove the attribute Cite from the element.
 func (e *BlockquoteElement) CiteRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("cite")
+	e = e.writable()
+	e.removeAttribute("cite")
 	return e
 }
 
@@ -255,10 +312,8 @@ func (e *BlockquoteElement) CiteRemove() *BlockquoteElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *BlockquoteElement) Accesskey(r rune) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -268,7 +323,7 @@ func (e *BlockquoteElement) Accesskey(r rune) *BlockquoteElement {
 // can be generated by the keyboard).
 func (e *BlockquoteElement) IfAccesskey(condition bool, r rune) *BlockquoteElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -279,10 +334,8 @@ func (e *BlockquoteElement) IfAccesskey(condition bool, r rune) *BlockquoteEleme
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *BlockquoteElement) AccesskeyRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -298,10 +351,8 @@ func (e *BlockquoteElement) AccesskeyRemove() *BlockquoteElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *BlockquoteElement) Autocapitalize(c BlockquoteAutocapitalizeChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[BlockquoteAutocapitalizeChoice])
 	return e
 }
 
@@ -335,10 +386,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *BlockquoteElement) AutocapitalizeRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -357,10 +406,8 @@ func (e *BlockquoteElement) AutocapitalizeRemove() *BlockquoteElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BlockquoteElement) Autofocus() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -380,7 +427,7 @@ func (e *BlockquoteElement) Autofocus() *BlockquoteElement {
 // created by the preceding content.
 func (e *BlockquoteElement) IfAutofocus(condition bool) *BlockquoteElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -401,10 +448,8 @@ func (e *BlockquoteElement) IfAutofocus(condition bool) *BlockquoteElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BlockquoteElement) AutofocusRemove() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -424,10 +469,8 @@ func (e *BlockquoteElement) AutofocusRemove() *BlockquoteElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BlockquoteElement) AutofocusIfRemove() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -436,16 +479,9 @@ func (e *BlockquoteElement) AutofocusIfRemove() *BlockquoteElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *BlockquoteElement) Class(s string) *BlockquoteElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -455,7 +491,7 @@ func (e *BlockquoteElement) Class(s string) *BlockquoteElement {
 // document.getElementsByClassName.
 func (e *BlockquoteElement) IfClass(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -466,14 +502,8 @@ func (e *BlockquoteElement) IfClass(condition bool, s string) *BlockquoteElement
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *BlockquoteElement) ClassRemove(s ...string) *BlockquoteElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -481,10 +511,8 @@ func (e *BlockquoteElement) ClassRemove(s ...string) *BlockquoteElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *BlockquoteElement) Contenteditable(c BlockquoteContenteditableChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[BlockquoteContenteditableChoice])
 	return e
 }
 
@@ -507,10 +535,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *BlockquoteElement) ContenteditableRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -528,10 +554,8 @@ func (e *BlockquoteElement) ContenteditableRemove() *BlockquoteElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *BlockquoteElement) Dir(c BlockquoteDirChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[BlockquoteDirChoice])
 	return e
 }
 
@@ -565,10 +589,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *BlockquoteElement) DirRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -576,10 +598,8 @@ func (e *BlockquoteElement) DirRemove() *BlockquoteElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *BlockquoteElement) Draggable(c BlockquoteDraggableChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[BlockquoteDraggableChoice])
 	return e
 }
 
@@ -605,20 +625,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *BlockquoteElement) DraggableRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *BlockquoteElement) Enterkeyhint(c BlockquoteEnterkeyhintChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[BlockquoteEnterkeyhintChoice])
 	return e
 }
 
@@ -648,10 +664,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *BlockquoteElement) EnterkeyhintRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -673,16 +687,9 @@ func (e *BlockquoteElement) EnterkeyhintRemove() *BlockquoteElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *BlockquoteElement) Exportparts(s string) *BlockquoteElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -705,7 +712,7 @@ func (e *BlockquoteElement) Exportparts(s string) *BlockquoteElement {
 // the current structure.
 func (e *BlockquoteElement) IfExportparts(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -729,14 +736,8 @@ func (e *BlockquoteElement) IfExportparts(condition bool, s string) *BlockquoteE
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *BlockquoteElement) ExportpartsRemove(s ...string) *BlockquoteElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -753,10 +754,8 @@ func (e *BlockquoteElement) ExportpartsRemove(s ...string) *BlockquoteElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *BlockquoteElement) Hidden(c BlockquoteHiddenChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[BlockquoteHiddenChoice])
 	return e
 }
 
@@ -792,10 +791,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *BlockquoteElement) HiddenRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -803,10 +800,8 @@ func (e *BlockquoteElement) HiddenRemove() *BlockquoteElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BlockquoteElement) ID(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -822,7 +817,7 @@ func (e *BlockquoteElement) IDf(format string, args ...any) *BlockquoteElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BlockquoteElement) IfID(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -832,7 +827,7 @@ func (e *BlockquoteElement) IfID(condition bool, s string) *BlockquoteElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BlockquoteElement) IfIDf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -842,10 +837,8 @@ func (e *BlockquoteElement) IfIDf(condition bool, format string, args ...any) *B
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *BlockquoteElement) IDRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -860,10 +853,8 @@ func (e *BlockquoteElement) IDRemove() *BlockquoteElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BlockquoteElement) Inert() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -879,7 +870,7 @@ func (e *BlockquoteElement) Inert() *BlockquoteElement {
 // excluding them from the accessibility tree.
 func (e *BlockquoteElement) IfInert(condition bool) *BlockquoteElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -896,10 +887,8 @@ func (e *BlockquoteElement) IfInert(condition bool) *BlockquoteElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BlockquoteElement) InertRemove() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -915,10 +904,8 @@ func (e *BlockquoteElement) InertRemove() *BlockquoteElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BlockquoteElement) InertIfRemove() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -932,10 +919,8 @@ func (e *BlockquoteElement) InertIfRemove() *BlockquoteElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *BlockquoteElement) Inputmode(c BlockquoteInputmodeChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[BlockquoteInputmodeChoice])
 	return e
 }
 
@@ -986,10 +971,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *BlockquoteElement) InputmodeRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -999,10 +982,8 @@ func (e *BlockquoteElement) InputmodeRemove() *BlockquoteElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *BlockquoteElement) Is(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1022,7 +1003,7 @@ func (e *BlockquoteElement) Isf(format string, args ...any) *BlockquoteElement {
 // and extends the element type it is being applied to.
 func (e *BlockquoteElement) IfIs(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1034,7 +1015,7 @@ func (e *BlockquoteElement) IfIs(condition bool, s string) *BlockquoteElement {
 // and extends the element type it is being applied to.
 func (e *BlockquoteElement) IfIsf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1046,10 +1027,8 @@ func (e *BlockquoteElement) IfIsf(condition bool, format string, args ...any) *B
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *BlockquoteElement) IsRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1065,10 +1044,8 @@ func (e *BlockquoteElement) IsRemove() *BlockquoteElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *BlockquoteElement) Itemid(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1100,7 +1077,7 @@ func (e *BlockquoteElement) Itemidf(format string, args ...any) *BlockquoteEleme
 // how items with the same identifier are handled.
 func (e *BlockquoteElement) IfItemid(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1118,7 +1095,7 @@ func (e *BlockquoteElement) IfItemid(condition bool, s string) *BlockquoteElemen
 // how items with the same identifier are handled.
 func (e *BlockquoteElement) IfItemidf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1136,10 +1113,8 @@ func (e *BlockquoteElement) IfItemidf(condition bool, format string, args ...any
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *BlockquoteElement) ItemidRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1151,10 +1126,8 @@ func (e *BlockquoteElement) ItemidRemove() *BlockquoteElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *BlockquoteElement) Itemprop(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1178,7 +1151,7 @@ func (e *BlockquoteElement) Itempropf(format string, args ...any) *BlockquoteEle
 // <track>, and <video>.
 func (e *BlockquoteElement) IfItemprop(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1192,7 +1165,7 @@ func (e *BlockquoteElement) IfItemprop(condition bool, s string) *BlockquoteElem
 // <track>, and <video>.
 func (e *BlockquoteElement) IfItempropf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1206,10 +1179,8 @@ func (e *BlockquoteElement) IfItempropf(condition bool, format string, args ...a
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *BlockquoteElement) ItempropRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1219,10 +1190,8 @@ func (e *BlockquoteElement) ItempropRemove() *BlockquoteElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *BlockquoteElement) Itemref(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1242,7 +1211,7 @@ func (e *BlockquoteElement) Itemreff(format string, args ...any) *BlockquoteElem
 // specified on elements that have an itemscope attribute specified.
 func (e *BlockquoteElement) IfItemref(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1254,7 +1223,7 @@ func (e *BlockquoteElement) IfItemref(condition bool, s string) *BlockquoteEleme
 // specified on elements that have an itemscope attribute specified.
 func (e *BlockquoteElement) IfItemreff(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1266,10 +1235,8 @@ func (e *BlockquoteElement) IfItemreff(condition bool, format string, args ...an
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *BlockquoteElement) ItemrefRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1281,10 +1248,8 @@ func (e *BlockquoteElement) ItemrefRemove() *BlockquoteElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BlockquoteElement) Itemscope() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1297,7 +1262,7 @@ func (e *BlockquoteElement) Itemscope() *BlockquoteElement {
 // <object>, <source>, <track>, and <video>.
 func (e *BlockquoteElement) IfItemscope(condition bool) *BlockquoteElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1311,10 +1276,8 @@ func (e *BlockquoteElement) IfItemscope(condition bool) *BlockquoteElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BlockquoteElement) ItemscopeRemove() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1327,10 +1290,8 @@ func (e *BlockquoteElement) ItemscopeRemove() *BlockquoteElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BlockquoteElement) ItemscopeIfRemove() *BlockquoteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1342,10 +1303,8 @@ func (e *BlockquoteElement) ItemscopeIfRemove() *BlockquoteElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *BlockquoteElement) Itemtype(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1369,7 +1328,7 @@ func (e *BlockquoteElement) Itemtypef(format string, args ...any) *BlockquoteEle
 // <video>.
 func (e *BlockquoteElement) IfItemtype(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1383,7 +1342,7 @@ func (e *BlockquoteElement) IfItemtype(condition bool, s string) *BlockquoteElem
 // <video>.
 func (e *BlockquoteElement) IfItemtypef(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1397,10 +1356,8 @@ func (e *BlockquoteElement) IfItemtypef(condition bool, format string, args ...a
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *BlockquoteElement) ItemtypeRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1410,10 +1367,8 @@ func (e *BlockquoteElement) ItemtypeRemove() *BlockquoteElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BlockquoteElement) Lang(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1433,7 +1388,7 @@ func (e *BlockquoteElement) Langf(format string, args ...any) *BlockquoteElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BlockquoteElement) IfLang(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1445,7 +1400,7 @@ func (e *BlockquoteElement) IfLang(condition bool, s string) *BlockquoteElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BlockquoteElement) IfLangf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1457,10 +1412,8 @@ func (e *BlockquoteElement) IfLangf(condition bool, format string, args ...any)
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *BlockquoteElement) LangRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1470,10 +1423,8 @@ func (e *BlockquoteElement) LangRemove() *BlockquoteElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *BlockquoteElement) Nonce(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1493,7 +1444,7 @@ func (e *BlockquoteElement) Noncef(format string, args ...any) *BlockquoteElemen
 // execute.
 func (e *BlockquoteElement) IfNonce(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1505,7 +1456,7 @@ func (e *BlockquoteElement) IfNonce(condition bool, s string) *BlockquoteElement
 // execute.
 func (e *BlockquoteElement) IfNoncef(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1517,10 +1468,8 @@ func (e *BlockquoteElement) IfNoncef(condition bool, format string, args ...any)
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *BlockquoteElement) NonceRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1528,16 +1477,9 @@ func (e *BlockquoteElement) NonceRemove() *BlockquoteElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *BlockquoteElement) Part(s string) *BlockquoteElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1546,7 +1488,7 @@ func (e *BlockquoteElement) Part(s string) *BlockquoteElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *BlockquoteElement) IfPart(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1556,14 +1498,8 @@ func (e *BlockquoteElement) IfPart(condition bool, s string) *BlockquoteElement
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *BlockquoteElement) PartRemove(s ...string) *BlockquoteElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1574,10 +1510,8 @@ func (e *BlockquoteElement) PartRemove(s ...string) *BlockquoteElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *BlockquoteElement) Popover(c BlockquotePopoverChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[BlockquotePopoverChoice])
 	return e
 }
 
@@ -1605,10 +1539,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *BlockquoteElement) PopoverRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1617,10 +1549,8 @@ func (e *BlockquoteElement) PopoverRemove() *BlockquoteElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *BlockquoteElement) Role(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1638,7 +1568,7 @@ func (e *BlockquoteElement) Rolef(format string, args ...any) *BlockquoteElement
 // role of an element.
 func (e *BlockquoteElement) IfRole(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1649,7 +1579,7 @@ func (e *BlockquoteElement) IfRole(condition bool, s string) *BlockquoteElement
 // role of an element.
 func (e *BlockquoteElement) IfRolef(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1660,10 +1590,8 @@ func (e *BlockquoteElement) IfRolef(condition bool, format string, args ...any)
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *BlockquoteElement) RoleRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1672,10 +1600,8 @@ func (e *BlockquoteElement) RoleRemove() *BlockquoteElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *BlockquoteElement) Slot(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1693,7 +1619,7 @@ func (e *BlockquoteElement) Slotf(format string, args ...any) *BlockquoteElement
 // value.
 func (e *BlockquoteElement) IfSlot(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1704,7 +1630,7 @@ func (e *BlockquoteElement) IfSlot(condition bool, s string) *BlockquoteElement
 // value.
 func (e *BlockquoteElement) IfSlotf(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1715,10 +1641,8 @@ func (e *BlockquoteElement) IfSlotf(condition bool, format string, args ...any)
 // value.
 // Remove the attribute Slot from the element.
 func (e *BlockquoteElement) SlotRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1734,10 +1658,8 @@ func (e *BlockquoteElement) SlotRemove() *BlockquoteElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *BlockquoteElement) Spellcheck(c BlockquoteSpellcheckChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[BlockquoteSpellcheckChoice])
 	return e
 }
 
@@ -1765,10 +1687,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *BlockquoteElement) SpellcheckRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1778,14 +1698,8 @@ func (e *BlockquoteElement) StylePairs(pairs ...string) *BlockquoteElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1800,14 +1714,8 @@ func (e *BlockquoteElement) StylePairs(pairs ...string) *BlockquoteElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BlockquoteElement) Style(s string) *BlockquoteElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1815,7 +1723,7 @@ func (e *BlockquoteElement) Style(s string) *BlockquoteElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1824,7 +1732,7 @@ func (e *BlockquoteElement) Style(s string) *BlockquoteElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BlockquoteElement) IfStyle(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1832,15 +1740,7 @@ func (e *BlockquoteElement) IfStyle(condition bool, s string) *BlockquoteElement
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BlockquoteElement) StyleAdd(k string, v string) *BlockquoteElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1854,7 +1754,7 @@ func (e *BlockquoteElement) StyleAddf(k string, format string, args ...any) *Blo
 // color, font, size, and more. Styles are written in CSS.
 func (e *BlockquoteElement) IfStyleAdd(condition bool, k string, v string) *BlockquoteElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1863,7 +1763,7 @@ func (e *BlockquoteElement) IfStyleAdd(condition bool, k string, v string) *Bloc
 // color, font, size, and more. Styles are written in CSS.
 func (e *BlockquoteElement) IfStyleAddf(condition bool, k string, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1872,21 +1772,15 @@ func (e *BlockquoteElement) IfStyleAddf(condition bool, k string, format string,
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *BlockquoteElement) StyleMap(m map[string]string) *BlockquoteElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1895,14 +1789,8 @@ func (e *BlockquoteElement) StyleMap(m map[string]string) *BlockquoteElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *BlockquoteElement) StyleRemove(keys ...string) *BlockquoteElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1920,10 +1808,8 @@ func (e *BlockquoteElement) StyleRemove(keys ...string) *BlockquoteElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *BlockquoteElement) Tabindex(i int) *BlockquoteElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1942,7 +1828,7 @@ func (e *BlockquoteElement) Tabindex(i int) *BlockquoteElement {
 // their relative position in the document.
 func (e *BlockquoteElement) IfTabindex(condition bool, i int) *BlockquoteElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1962,10 +1848,8 @@ func (e *BlockquoteElement) IfTabindex(condition bool, i int) *BlockquoteElement
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *BlockquoteElement) TabindexRemove() *BlockquoteElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1986,10 +1870,8 @@ func (e *BlockquoteElement) TabindexRemove() *BlockquoteElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *BlockquoteElement) Title(s string) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2031,7 +1913,7 @@ func (e *BlockquoteElement) Titlef(format string, args ...any) *BlockquoteElemen
 // element, this is not good practice. Use a <label> instead.
 func (e *BlockquoteElement) IfTitle(condition bool, s string) *BlockquoteElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2054,7 +1936,7 @@ func (e *BlockquoteElement) IfTitle(condition bool, s string) *BlockquoteElement
 // element, this is not good practice. Use a <label> instead.
 func (e *BlockquoteElement) IfTitlef(condition bool, format string, args ...any) *BlockquoteElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2077,10 +1959,8 @@ func (e *BlockquoteElement) IfTitlef(condition bool, format string, args ...any)
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *BlockquoteElement) TitleRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2089,10 +1969,8 @@ func (e *BlockquoteElement) TitleRemove() *BlockquoteElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *BlockquoteElement) Translate(c BlockquoteTranslateChoice) *BlockquoteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[BlockquoteTranslateChoice])
 	return e
 }
 
@@ -2113,9 +1991,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *BlockquoteElement) TranslateRemove() *BlockquoteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <body> Element represents the content of an HTML document. There can
@@ -22,18 +20,77 @@ type BodyElement struct {
 // with the tag "body" during rendering.
 func Body(children ...ElementRenderer) *BodyElement {
 	e := NewElement("body", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &BodyElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *BodyElement) Clone() *BodyElement {
+	return &BodyElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *BodyElement) CloneShallow() *BodyElement {
+	return &BodyElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *BodyElement) Freeze() *BodyElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *BodyElement) writable() *BodyElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *BodyElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *BodyElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[BodyAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[BodyContenteditableChoice]
+	case "dir":
+		return choiceValue[BodyDirChoice]
+	case "draggable":
+		return choiceValue[BodyDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[BodyEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[BodyHiddenChoice]
+	case "inputmode":
+		return choiceValue[BodyInputmodeChoice]
+	case "popover":
+		return choiceValue[BodyPopoverChoice]
+	case "spellcheck":
+		return choiceValue[BodySpellcheckChoice]
+	case "translate":
+		return choiceValue[BodyTranslateChoice]
+	}
+	return nil
+}
+
 func (e *BodyElement) Children(children ...ElementRenderer) *BodyElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *BodyElement) IfChildren(condition bool, children ...ElementRenderer) *BodyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *BodyElement) IfChildren(condition bool, children ...ElementRenderer) *B
 
 func (e *BodyElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *BodyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *BodyElement) BoolAttr(name string) *BodyElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *BodyElement) BoolAttrRemove(name string) *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *BodyElement) IfBoolAttr(condition bool, name string) *BodyElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *BodyElement) BoolAttrf(format string, args ...any) *BodyElement {
 
 func (e *BodyElement) IfBoolAttrf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *BodyElement) BoolAttrs(names ...string) *BodyElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *BodyElement) IfBoolAttrs(condition bool, names ...string) *BodyElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *BodyElement) Attr(name, value string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *BodyElement) RawAttr(name, value string) *BodyElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *BodyElement) IfAttr(condition bool, name, value string) *BodyElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *BodyElement) Attrf(name, format string, args ...any) *BodyElement {
 
 func (e *BodyElement) IfAttrf(condition bool, name, format string, args ...any) *BodyElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *BodyElement) Attrs(attrs ...string) *BodyElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *BodyElement) IfAttrs(condition bool, attrs ...string) *BodyElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *BodyElement) AttrsMap(attrs map[string]string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *BodyElement) IfAttrsMap(condition bool, attrs map[string]string) *BodyElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *BodyElement) Text(text string) *BodyElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *BodyElement) Textf(format string, args ...any) *BodyElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *BodyElement) IfText(condition bool, text string) *BodyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *BodyElement) IfText(condition bool, text string) *BodyElement {
 
 func (e *BodyElement) IfTextf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *BodyElement) Raw(text string) *BodyElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *BodyElement) Escaped(text string) *BodyElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *BodyElement) IfEscaped(condition bool, text string) *BodyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *BodyElement) Escapedf(format string, args ...any) *BodyElement {
 
 func (e *BodyElement) IfEscapedf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +273,8 @@ func (e *BodyElement) IfEscapedf(condition bool, format string, args ...any) *Bo
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *BodyElement) Accesskey(r rune) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *BodyElement) Accesskey(r rune) *BodyElement {
 // can be generated by the keyboard).
 func (e *BodyElement) IfAccesskey(condition bool, r rune) *BodyElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -236,10 +295,8 @@ func (e *BodyElement) IfAccesskey(condition bool, r rune) *BodyElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *BodyElement) AccesskeyRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -255,10 +312,8 @@ func (e *BodyElement) AccesskeyRemove() *BodyElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *BodyElement) Autocapitalize(c BodyAutocapitalizeChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[BodyAutocapitalizeChoice])
 	return e
 }
 
@@ -292,10 +347,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *BodyElement) AutocapitalizeRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -314,10 +367,8 @@ func (e *BodyElement) AutocapitalizeRemove() *BodyElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BodyElement) Autofocus() *BodyElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -337,7 +388,7 @@ func (e *BodyElement) Autofocus() *BodyElement {
 // created by the preceding content.
 func (e *BodyElement) IfAutofocus(condition bool) *BodyElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -358,10 +409,8 @@ func (e *BodyElement) IfAutofocus(condition bool) *BodyElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BodyElement) AutofocusRemove() *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -381,10 +430,8 @@ func (e *BodyElement) AutofocusRemove() *BodyElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BodyElement) AutofocusIfRemove() *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -393,16 +440,9 @@ func (e *BodyElement) AutofocusIfRemove() *BodyElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *BodyElement) Class(s string) *BodyElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -412,7 +452,7 @@ func (e *BodyElement) Class(s string) *BodyElement {
 // document.getElementsByClassName.
 func (e *BodyElement) IfClass(condition bool, s string) *BodyElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -423,14 +463,8 @@ func (e *BodyElement) IfClass(condition bool, s string) *BodyElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *BodyElement) ClassRemove(s ...string) *BodyElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,10 +472,8 @@ func (e *BodyElement) ClassRemove(s ...string) *BodyElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *BodyElement) Contenteditable(c BodyContenteditableChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[BodyContenteditableChoice])
 	return e
 }
 
@@ -464,10 +496,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *BodyElement) ContenteditableRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -485,10 +515,8 @@ func (e *BodyElement) ContenteditableRemove() *BodyElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *BodyElement) Dir(c BodyDirChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[BodyDirChoice])
 	return e
 }
 
@@ -522,10 +550,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *BodyElement) DirRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -533,10 +559,8 @@ func (e *BodyElement) DirRemove() *BodyElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *BodyElement) Draggable(c BodyDraggableChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[BodyDraggableChoice])
 	return e
 }
 
@@ -562,20 +586,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *BodyElement) DraggableRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *BodyElement) Enterkeyhint(c BodyEnterkeyhintChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[BodyEnterkeyhintChoice])
 	return e
 }
 
@@ -605,10 +625,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *BodyElement) EnterkeyhintRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -630,16 +648,9 @@ func (e *BodyElement) EnterkeyhintRemove() *BodyElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *BodyElement) Exportparts(s string) *BodyElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -662,7 +673,7 @@ func (e *BodyElement) Exportparts(s string) *BodyElement {
 // the current structure.
 func (e *BodyElement) IfExportparts(condition bool, s string) *BodyElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -686,14 +697,8 @@ func (e *BodyElement) IfExportparts(condition bool, s string) *BodyElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *BodyElement) ExportpartsRemove(s ...string) *BodyElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -710,10 +715,8 @@ func (e *BodyElement) ExportpartsRemove(s ...string) *BodyElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *BodyElement) Hidden(c BodyHiddenChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[BodyHiddenChoice])
 	return e
 }
 
@@ -749,10 +752,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *BodyElement) HiddenRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -760,10 +761,8 @@ func (e *BodyElement) HiddenRemove() *BodyElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BodyElement) ID(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -779,7 +778,7 @@ func (e *BodyElement) IDf(format string, args ...any) *BodyElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BodyElement) IfID(condition bool, s string) *BodyElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -789,7 +788,7 @@ func (e *BodyElement) IfID(condition bool, s string) *BodyElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BodyElement) IfIDf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -799,10 +798,8 @@ func (e *BodyElement) IfIDf(condition bool, format string, args ...any) *BodyEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *BodyElement) IDRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -817,10 +814,8 @@ func (e *BodyElement) IDRemove() *BodyElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BodyElement) Inert() *BodyElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -836,7 +831,7 @@ func (e *BodyElement) Inert() *BodyElement {
 // excluding them from the accessibility tree.
 func (e *BodyElement) IfInert(condition bool) *BodyElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -853,10 +848,8 @@ func (e *BodyElement) IfInert(condition bool) *BodyElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BodyElement) InertRemove() *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -872,10 +865,8 @@ func (e *BodyElement) InertRemove() *BodyElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BodyElement) InertIfRemove() *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -889,10 +880,8 @@ func (e *BodyElement) InertIfRemove() *BodyElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *BodyElement) Inputmode(c BodyInputmodeChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[BodyInputmodeChoice])
 	return e
 }
 
@@ -943,10 +932,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *BodyElement) InputmodeRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -956,10 +943,8 @@ func (e *BodyElement) InputmodeRemove() *BodyElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *BodyElement) Is(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -979,7 +964,7 @@ func (e *BodyElement) Isf(format string, args ...any) *BodyElement {
 // and extends the element type it is being applied to.
 func (e *BodyElement) IfIs(condition bool, s string) *BodyElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -991,7 +976,7 @@ func (e *BodyElement) IfIs(condition bool, s string) *BodyElement {
 // and extends the element type it is being applied to.
 func (e *BodyElement) IfIsf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1003,10 +988,8 @@ func (e *BodyElement) IfIsf(condition bool, format string, args ...any) *BodyEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *BodyElement) IsRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1022,10 +1005,8 @@ func (e *BodyElement) IsRemove() *BodyElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *BodyElement) Itemid(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1057,7 +1038,7 @@ func (e *BodyElement) Itemidf(format string, args ...any) *BodyElement {
 // how items with the same identifier are handled.
 func (e *BodyElement) IfItemid(condition bool, s string) *BodyElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1075,7 +1056,7 @@ func (e *BodyElement) IfItemid(condition bool, s string) *BodyElement {
 // how items with the same identifier are handled.
 func (e *BodyElement) IfItemidf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1093,10 +1074,8 @@ func (e *BodyElement) IfItemidf(condition bool, format string, args ...any) *Bod
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *BodyElement) ItemidRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1108,10 +1087,8 @@ func (e *BodyElement) ItemidRemove() *BodyElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *BodyElement) Itemprop(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1135,7 +1112,7 @@ func (e *BodyElement) Itempropf(format string, args ...any) *BodyElement {
 // <track>, and <video>.
 func (e *BodyElement) IfItemprop(condition bool, s string) *BodyElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1149,7 +1126,7 @@ func (e *BodyElement) IfItemprop(condition bool, s string) *BodyElement {
 // <track>, and <video>.
 func (e *BodyElement) IfItempropf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1163,10 +1140,8 @@ func (e *BodyElement) IfItempropf(condition bool, format string, args ...any) *B
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *BodyElement) ItempropRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1176,10 +1151,8 @@ func (e *BodyElement) ItempropRemove() *BodyElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *BodyElement) Itemref(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1199,7 +1172,7 @@ func (e *BodyElement) Itemreff(format string, args ...any) *BodyElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BodyElement) IfItemref(condition bool, s string) *BodyElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1211,7 +1184,7 @@ func (e *BodyElement) IfItemref(condition bool, s string) *BodyElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BodyElement) IfItemreff(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1223,10 +1196,8 @@ func (e *BodyElement) IfItemreff(condition bool, format string, args ...any) *Bo
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *BodyElement) ItemrefRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1238,10 +1209,8 @@ func (e *BodyElement) ItemrefRemove() *BodyElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BodyElement) Itemscope() *BodyElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1254,7 +1223,7 @@ func (e *BodyElement) Itemscope() *BodyElement {
 // <object>, <source>, <track>, and <video>.
 func (e *BodyElement) IfItemscope(condition bool) *BodyElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1268,10 +1237,8 @@ func (e *BodyElement) IfItemscope(condition bool) *BodyElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BodyElement) ItemscopeRemove() *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1284,10 +1251,8 @@ func (e *BodyElement) ItemscopeRemove() *BodyElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BodyElement) ItemscopeIfRemove() *BodyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1299,10 +1264,8 @@ func (e *BodyElement) ItemscopeIfRemove() *BodyElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *BodyElement) Itemtype(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1326,7 +1289,7 @@ func (e *BodyElement) Itemtypef(format string, args ...any) *BodyElement {
 // <video>.
 func (e *BodyElement) IfItemtype(condition bool, s string) *BodyElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1340,7 +1303,7 @@ func (e *BodyElement) IfItemtype(condition bool, s string) *BodyElement {
 // <video>.
 func (e *BodyElement) IfItemtypef(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1354,10 +1317,8 @@ func (e *BodyElement) IfItemtypef(condition bool, format string, args ...any) *B
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *BodyElement) ItemtypeRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1367,10 +1328,8 @@ func (e *BodyElement) ItemtypeRemove() *BodyElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BodyElement) Lang(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1390,7 +1349,7 @@ func (e *BodyElement) Langf(format string, args ...any) *BodyElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BodyElement) IfLang(condition bool, s string) *BodyElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1402,7 +1361,7 @@ func (e *BodyElement) IfLang(condition bool, s string) *BodyElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BodyElement) IfLangf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1414,10 +1373,8 @@ func (e *BodyElement) IfLangf(condition bool, format string, args ...any) *BodyE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *BodyElement) LangRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1427,10 +1384,8 @@ func (e *BodyElement) LangRemove() *BodyElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *BodyElement) Nonce(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1450,7 +1405,7 @@ func (e *BodyElement) Noncef(format string, args ...any) *BodyElement {
 // execute.
 func (e *BodyElement) IfNonce(condition bool, s string) *BodyElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1462,7 +1417,7 @@ func (e *BodyElement) IfNonce(condition bool, s string) *BodyElement {
 // execute.
 func (e *BodyElement) IfNoncef(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1474,10 +1429,8 @@ func (e *BodyElement) IfNoncef(condition bool, format string, args ...any) *Body
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *BodyElement) NonceRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1485,16 +1438,9 @@ func (e *BodyElement) NonceRemove() *BodyElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *BodyElement) Part(s string) *BodyElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1503,7 +1449,7 @@ func (e *BodyElement) Part(s string) *BodyElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *BodyElement) IfPart(condition bool, s string) *BodyElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1513,14 +1459,8 @@ func (e *BodyElement) IfPart(condition bool, s string) *BodyElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *BodyElement) PartRemove(s ...string) *BodyElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1531,10 +1471,8 @@ func (e *BodyElement) PartRemove(s ...string) *BodyElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *BodyElement) Popover(c BodyPopoverChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[BodyPopoverChoice])
 	return e
 }
 
@@ -1562,10 +1500,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *BodyElement) PopoverRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1574,10 +1510,8 @@ func (e *BodyElement) PopoverRemove() *BodyElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *BodyElement) Role(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1595,7 +1529,7 @@ func (e *BodyElement) Rolef(format string, args ...any) *BodyElement {
 // role of an element.
 func (e *BodyElement) IfRole(condition bool, s string) *BodyElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1606,7 +1540,7 @@ func (e *BodyElement) IfRole(condition bool, s string) *BodyElement {
 // role of an element.
 func (e *BodyElement) IfRolef(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1617,10 +1551,8 @@ func (e *BodyElement) IfRolef(condition bool, format string, args ...any) *BodyE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *BodyElement) RoleRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1629,10 +1561,8 @@ func (e *BodyElement) RoleRemove() *BodyElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *BodyElement) Slot(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1650,7 +1580,7 @@ func (e *BodyElement) Slotf(format string, args ...any) *BodyElement {
 // value.
 func (e *BodyElement) IfSlot(condition bool, s string) *BodyElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1661,7 +1591,7 @@ func (e *BodyElement) IfSlot(condition bool, s string) *BodyElement {
 // value.
 func (e *BodyElement) IfSlotf(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1672,10 +1602,8 @@ func (e *BodyElement) IfSlotf(condition bool, format string, args ...any) *BodyE
 // value.
 // Remove the attribute Slot from the element.
 func (e *BodyElement) SlotRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1691,10 +1619,8 @@ func (e *BodyElement) SlotRemove() *BodyElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *BodyElement) Spellcheck(c BodySpellcheckChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[BodySpellcheckChoice])
 	return e
 }
 
@@ -1722,10 +1648,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *BodyElement) SpellcheckRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1735,14 +1659,8 @@ func (e *BodyElement) StylePairs(pairs ...string) *BodyElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1757,14 +1675,8 @@ func (e *BodyElement) StylePairs(pairs ...string) *BodyElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BodyElement) Style(s string) *BodyElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1772,7 +1684,7 @@ func (e *BodyElement) Style(s string) *BodyElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1781,7 +1693,7 @@ func (e *BodyElement) Style(s string) *BodyElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BodyElement) IfStyle(condition bool, s string) *BodyElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1789,15 +1701,7 @@ func (e *BodyElement) IfStyle(condition bool, s string) *BodyElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BodyElement) StyleAdd(k string, v string) *BodyElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1811,7 +1715,7 @@ func (e *BodyElement) StyleAddf(k string, format string, args ...any) *BodyEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *BodyElement) IfStyleAdd(condition bool, k string, v string) *BodyElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1820,7 +1724,7 @@ func (e *BodyElement) IfStyleAdd(condition bool, k string, v string) *BodyElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *BodyElement) IfStyleAddf(condition bool, k string, format string, args ...any) *BodyElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1829,21 +1733,15 @@ func (e *BodyElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *BodyElement) StyleMap(m map[string]string) *BodyElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1852,14 +1750,8 @@ func (e *BodyElement) StyleMap(m map[string]string) *BodyElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *BodyElement) StyleRemove(keys ...string) *BodyElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1877,10 +1769,8 @@ func (e *BodyElement) StyleRemove(keys ...string) *BodyElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *BodyElement) Tabindex(i int) *BodyElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1899,7 +1789,7 @@ func (e *BodyElement) Tabindex(i int) *BodyElement {
 // their relative position in the document.
 func (e *BodyElement) IfTabindex(condition bool, i int) *BodyElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1919,10 +1809,8 @@ func (e *BodyElement) IfTabindex(condition bool, i int) *BodyElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *BodyElement) TabindexRemove() *BodyElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1943,10 +1831,8 @@ func (e *BodyElement) TabindexRemove() *BodyElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *BodyElement) Title(s string) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1988,7 +1874,7 @@ func (e *BodyElement) Titlef(format string, args ...any) *BodyElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BodyElement) IfTitle(condition bool, s string) *BodyElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2011,7 +1897,7 @@ func (e *BodyElement) IfTitle(condition bool, s string) *BodyElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BodyElement) IfTitlef(condition bool, format string, args ...any) *BodyElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2034,10 +1920,8 @@ func (e *BodyElement) IfTitlef(condition bool, format string, args ...any) *Body
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *BodyElement) TitleRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2046,10 +1930,8 @@ func (e *BodyElement) TitleRemove() *BodyElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *BodyElement) Translate(c BodyTranslateChoice) *BodyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[BodyTranslateChoice])
 	return e
 }
 
@@ -2070,9 +1952,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *BodyElement) TranslateRemove() *BodyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <br> element produces a line break in text (carriage-return). It is
@@ -23,18 +21,77 @@ type BrElement struct {
 // with the tag "br" during rendering.
 func Br() *BrElement {
 	e := NewElement("br")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &BrElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *BrElement) Clone() *BrElement {
+	return &BrElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *BrElement) CloneShallow() *BrElement {
+	return &BrElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *BrElement) Freeze() *BrElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *BrElement) writable() *BrElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *BrElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *BrElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[BrAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[BrContenteditableChoice]
+	case "dir":
+		return choiceValue[BrDirChoice]
+	case "draggable":
+		return choiceValue[BrDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[BrEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[BrHiddenChoice]
+	case "inputmode":
+		return choiceValue[BrInputmodeChoice]
+	case "popover":
+		return choiceValue[BrPopoverChoice]
+	case "spellcheck":
+		return choiceValue[BrSpellcheckChoice]
+	case "translate":
+		return choiceValue[BrTranslateChoice]
+	}
+	return nil
+}
+
 func (e *BrElement) Children(children ...ElementRenderer) *BrElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *BrElement) IfChildren(condition bool, children ...ElementRenderer) *BrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *BrElement) IfChildren(condition bool, children ...ElementRenderer) *BrE
 
 func (e *BrElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *BrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *BrElement) BoolAttr(name string) *BrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *BrElement) BoolAttrRemove(name string) *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *BrElement) IfBoolAttr(condition bool, name string) *BrElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *BrElement) BoolAttrf(format string, args ...any) *BrElement {
 
 func (e *BrElement) IfBoolAttrf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *BrElement) BoolAttrs(names ...string) *BrElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *BrElement) IfBoolAttrs(condition bool, names ...string) *BrElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *BrElement) Attr(name, value string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *BrElement) RawAttr(name, value string) *BrElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *BrElement) IfAttr(condition bool, name, value string) *BrElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *BrElement) Attrf(name, format string, args ...any) *BrElement {
 
 func (e *BrElement) IfAttrf(condition bool, name, format string, args ...any) *BrElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *BrElement) Attrs(attrs ...string) *BrElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *BrElement) IfAttrs(condition bool, attrs ...string) *BrElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *BrElement) AttrsMap(attrs map[string]string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *BrElement) IfAttrsMap(condition bool, attrs map[string]string) *BrElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *BrElement) Text(text string) *BrElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *BrElement) Textf(format string, args ...any) *BrElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *BrElement) IfText(condition bool, text string) *BrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *BrElement) IfText(condition bool, text string) *BrElement {
 
 func (e *BrElement) IfTextf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *BrElement) Raw(text string) *BrElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *BrElement) Escaped(text string) *BrElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *BrElement) IfEscaped(condition bool, text string) *BrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *BrElement) Escapedf(format string, args ...any) *BrElement {
 
 func (e *BrElement) IfEscapedf(condition bool, format string, args ...any) *BrElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -213,10 +274,8 @@ func (e *BrElement) IfEscapedf(condition bool, format string, args ...any) *BrEl
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *BrElement) Accesskey(r rune) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -226,7 +285,7 @@ func (e *BrElement) Accesskey(r rune) *BrElement {
 // can be generated by the keyboard).
 func (e *BrElement) IfAccesskey(condition bool, r rune) *BrElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -237,10 +296,8 @@ func (e *BrElement) IfAccesskey(condition bool, r rune) *BrElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *BrElement) AccesskeyRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -256,10 +313,8 @@ func (e *BrElement) AccesskeyRemove() *BrElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *BrElement) Autocapitalize(c BrAutocapitalizeChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[BrAutocapitalizeChoice])
 	return e
 }
 
@@ -293,10 +348,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *BrElement) AutocapitalizeRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -315,10 +368,8 @@ func (e *BrElement) AutocapitalizeRemove() *BrElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BrElement) Autofocus() *BrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -338,7 +389,7 @@ func (e *BrElement) Autofocus() *BrElement {
 // created by the preceding content.
 func (e *BrElement) IfAutofocus(condition bool) *BrElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -359,10 +410,8 @@ func (e *BrElement) IfAutofocus(condition bool) *BrElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BrElement) AutofocusRemove() *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -382,10 +431,8 @@ func (e *BrElement) AutofocusRemove() *BrElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *BrElement) AutofocusIfRemove() *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -394,16 +441,9 @@ func (e *BrElement) AutofocusIfRemove() *BrElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *BrElement) Class(s string) *BrElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +453,7 @@ func (e *BrElement) Class(s string) *BrElement {
 // document.getElementsByClassName.
 func (e *BrElement) IfClass(condition bool, s string) *BrElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -424,14 +464,8 @@ func (e *BrElement) IfClass(condition bool, s string) *BrElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *BrElement) ClassRemove(s ...string) *BrElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -439,10 +473,8 @@ func (e *BrElement) ClassRemove(s ...string) *BrElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *BrElement) Contenteditable(c BrContenteditableChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[BrContenteditableChoice])
 	return e
 }
 
@@ -465,10 +497,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *BrElement) ContenteditableRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -486,10 +516,8 @@ func (e *BrElement) ContenteditableRemove() *BrElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *BrElement) Dir(c BrDirChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[BrDirChoice])
 	return e
 }
 
@@ -523,10 +551,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *BrElement) DirRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -534,10 +560,8 @@ func (e *BrElement) DirRemove() *BrElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *BrElement) Draggable(c BrDraggableChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[BrDraggableChoice])
 	return e
 }
 
@@ -563,20 +587,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *BrElement) DraggableRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *BrElement) Enterkeyhint(c BrEnterkeyhintChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[BrEnterkeyhintChoice])
 	return e
 }
 
@@ -606,10 +626,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *BrElement) EnterkeyhintRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -631,16 +649,9 @@ func (e *BrElement) EnterkeyhintRemove() *BrElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *BrElement) Exportparts(s string) *BrElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -663,7 +674,7 @@ func (e *BrElement) Exportparts(s string) *BrElement {
 // the current structure.
 func (e *BrElement) IfExportparts(condition bool, s string) *BrElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -687,14 +698,8 @@ func (e *BrElement) IfExportparts(condition bool, s string) *BrElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *BrElement) ExportpartsRemove(s ...string) *BrElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -711,10 +716,8 @@ func (e *BrElement) ExportpartsRemove(s ...string) *BrElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *BrElement) Hidden(c BrHiddenChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[BrHiddenChoice])
 	return e
 }
 
@@ -750,10 +753,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *BrElement) HiddenRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -761,10 +762,8 @@ func (e *BrElement) HiddenRemove() *BrElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BrElement) ID(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -780,7 +779,7 @@ func (e *BrElement) IDf(format string, args ...any) *BrElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BrElement) IfID(condition bool, s string) *BrElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -790,7 +789,7 @@ func (e *BrElement) IfID(condition bool, s string) *BrElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *BrElement) IfIDf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -800,10 +799,8 @@ func (e *BrElement) IfIDf(condition bool, format string, args ...any) *BrElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *BrElement) IDRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -818,10 +815,8 @@ func (e *BrElement) IDRemove() *BrElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BrElement) Inert() *BrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -837,7 +832,7 @@ func (e *BrElement) Inert() *BrElement {
 // excluding them from the accessibility tree.
 func (e *BrElement) IfInert(condition bool) *BrElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -854,10 +849,8 @@ func (e *BrElement) IfInert(condition bool) *BrElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BrElement) InertRemove() *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -873,10 +866,8 @@ func (e *BrElement) InertRemove() *BrElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *BrElement) InertIfRemove() *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -890,10 +881,8 @@ func (e *BrElement) InertIfRemove() *BrElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *BrElement) Inputmode(c BrInputmodeChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[BrInputmodeChoice])
 	return e
 }
 
@@ -944,10 +933,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *BrElement) InputmodeRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -957,10 +944,8 @@ func (e *BrElement) InputmodeRemove() *BrElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *BrElement) Is(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -980,7 +965,7 @@ func (e *BrElement) Isf(format string, args ...any) *BrElement {
 // and extends the element type it is being applied to.
 func (e *BrElement) IfIs(condition bool, s string) *BrElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -992,7 +977,7 @@ func (e *BrElement) IfIs(condition bool, s string) *BrElement {
 // and extends the element type it is being applied to.
 func (e *BrElement) IfIsf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1004,10 +989,8 @@ func (e *BrElement) IfIsf(condition bool, format string, args ...any) *BrElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *BrElement) IsRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1023,10 +1006,8 @@ func (e *BrElement) IsRemove() *BrElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *BrElement) Itemid(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1058,7 +1039,7 @@ func (e *BrElement) Itemidf(format string, args ...any) *BrElement {
 // how items with the same identifier are handled.
 func (e *BrElement) IfItemid(condition bool, s string) *BrElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1076,7 +1057,7 @@ func (e *BrElement) IfItemid(condition bool, s string) *BrElement {
 // how items with the same identifier are handled.
 func (e *BrElement) IfItemidf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1094,10 +1075,8 @@ func (e *BrElement) IfItemidf(condition bool, format string, args ...any) *BrEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *BrElement) ItemidRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1109,10 +1088,8 @@ func (e *BrElement) ItemidRemove() *BrElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *BrElement) Itemprop(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1136,7 +1113,7 @@ func (e *BrElement) Itempropf(format string, args ...any) *BrElement {
 // <track>, and <video>.
 func (e *BrElement) IfItemprop(condition bool, s string) *BrElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1150,7 +1127,7 @@ func (e *BrElement) IfItemprop(condition bool, s string) *BrElement {
 // <track>, and <video>.
 func (e *BrElement) IfItempropf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1164,10 +1141,8 @@ func (e *BrElement) IfItempropf(condition bool, format string, args ...any) *BrE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *BrElement) ItempropRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1177,10 +1152,8 @@ func (e *BrElement) ItempropRemove() *BrElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *BrElement) Itemref(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1200,7 +1173,7 @@ func (e *BrElement) Itemreff(format string, args ...any) *BrElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BrElement) IfItemref(condition bool, s string) *BrElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1212,7 +1185,7 @@ func (e *BrElement) IfItemref(condition bool, s string) *BrElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *BrElement) IfItemreff(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1224,10 +1197,8 @@ func (e *BrElement) IfItemreff(condition bool, format string, args ...any) *BrEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *BrElement) ItemrefRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1239,10 +1210,8 @@ func (e *BrElement) ItemrefRemove() *BrElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BrElement) Itemscope() *BrElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1255,7 +1224,7 @@ func (e *BrElement) Itemscope() *BrElement {
 // <object>, <source>, <track>, and <video>.
 func (e *BrElement) IfItemscope(condition bool) *BrElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1269,10 +1238,8 @@ func (e *BrElement) IfItemscope(condition bool) *BrElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BrElement) ItemscopeRemove() *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1285,10 +1252,8 @@ func (e *BrElement) ItemscopeRemove() *BrElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *BrElement) ItemscopeIfRemove() *BrElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1300,10 +1265,8 @@ func (e *BrElement) ItemscopeIfRemove() *BrElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *BrElement) Itemtype(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1327,7 +1290,7 @@ func (e *BrElement) Itemtypef(format string, args ...any) *BrElement {
 // <video>.
 func (e *BrElement) IfItemtype(condition bool, s string) *BrElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1341,7 +1304,7 @@ func (e *BrElement) IfItemtype(condition bool, s string) *BrElement {
 // <video>.
 func (e *BrElement) IfItemtypef(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1355,10 +1318,8 @@ func (e *BrElement) IfItemtypef(condition bool, format string, args ...any) *BrE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *BrElement) ItemtypeRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1368,10 +1329,8 @@ func (e *BrElement) ItemtypeRemove() *BrElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BrElement) Lang(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1391,7 +1350,7 @@ func (e *BrElement) Langf(format string, args ...any) *BrElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BrElement) IfLang(condition bool, s string) *BrElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1403,7 +1362,7 @@ func (e *BrElement) IfLang(condition bool, s string) *BrElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *BrElement) IfLangf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1415,10 +1374,8 @@ func (e *BrElement) IfLangf(condition bool, format string, args ...any) *BrEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *BrElement) LangRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1428,10 +1385,8 @@ func (e *BrElement) LangRemove() *BrElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *BrElement) Nonce(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1451,7 +1406,7 @@ func (e *BrElement) Noncef(format string, args ...any) *BrElement {
 // execute.
 func (e *BrElement) IfNonce(condition bool, s string) *BrElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1463,7 +1418,7 @@ func (e *BrElement) IfNonce(condition bool, s string) *BrElement {
 // execute.
 func (e *BrElement) IfNoncef(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1475,10 +1430,8 @@ func (e *BrElement) IfNoncef(condition bool, format string, args ...any) *BrElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *BrElement) NonceRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1486,16 +1439,9 @@ func (e *BrElement) NonceRemove() *BrElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *BrElement) Part(s string) *BrElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1504,7 +1450,7 @@ func (e *BrElement) Part(s string) *BrElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *BrElement) IfPart(condition bool, s string) *BrElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1514,14 +1460,8 @@ func (e *BrElement) IfPart(condition bool, s string) *BrElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *BrElement) PartRemove(s ...string) *BrElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1532,10 +1472,8 @@ func (e *BrElement) PartRemove(s ...string) *BrElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *BrElement) Popover(c BrPopoverChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[BrPopoverChoice])
 	return e
 }
 
@@ -1563,10 +1501,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *BrElement) PopoverRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1575,10 +1511,8 @@ func (e *BrElement) PopoverRemove() *BrElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *BrElement) Role(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1596,7 +1530,7 @@ func (e *BrElement) Rolef(format string, args ...any) *BrElement {
 // role of an element.
 func (e *BrElement) IfRole(condition bool, s string) *BrElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1607,7 +1541,7 @@ func (e *BrElement) IfRole(condition bool, s string) *BrElement {
 // role of an element.
 func (e *BrElement) IfRolef(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1618,10 +1552,8 @@ func (e *BrElement) IfRolef(condition bool, format string, args ...any) *BrEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *BrElement) RoleRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1630,10 +1562,8 @@ func (e *BrElement) RoleRemove() *BrElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *BrElement) Slot(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1651,7 +1581,7 @@ func (e *BrElement) Slotf(format string, args ...any) *BrElement {
 // value.
 func (e *BrElement) IfSlot(condition bool, s string) *BrElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1662,7 +1592,7 @@ func (e *BrElement) IfSlot(condition bool, s string) *BrElement {
 // value.
 func (e *BrElement) IfSlotf(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1673,10 +1603,8 @@ func (e *BrElement) IfSlotf(condition bool, format string, args ...any) *BrEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *BrElement) SlotRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1692,10 +1620,8 @@ func (e *BrElement) SlotRemove() *BrElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *BrElement) Spellcheck(c BrSpellcheckChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[BrSpellcheckChoice])
 	return e
 }
 
@@ -1723,10 +1649,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *BrElement) SpellcheckRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1736,14 +1660,8 @@ func (e *BrElement) StylePairs(pairs ...string) *BrElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1758,14 +1676,8 @@ func (e *BrElement) StylePairs(pairs ...string) *BrElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BrElement) Style(s string) *BrElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1773,7 +1685,7 @@ func (e *BrElement) Style(s string) *BrElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1782,7 +1694,7 @@ func (e *BrElement) Style(s string) *BrElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BrElement) IfStyle(condition bool, s string) *BrElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1790,15 +1702,7 @@ func (e *BrElement) IfStyle(condition bool, s string) *BrElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *BrElement) StyleAdd(k string, v string) *BrElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1812,7 +1716,7 @@ func (e *BrElement) StyleAddf(k string, format string, args ...any) *BrElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BrElement) IfStyleAdd(condition bool, k string, v string) *BrElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1821,7 +1725,7 @@ func (e *BrElement) IfStyleAdd(condition bool, k string, v string) *BrElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *BrElement) IfStyleAddf(condition bool, k string, format string, args ...any) *BrElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1830,21 +1734,15 @@ func (e *BrElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *BrElement) StyleMap(m map[string]string) *BrElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1853,14 +1751,8 @@ func (e *BrElement) StyleMap(m map[string]string) *BrElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *BrElement) StyleRemove(keys ...string) *BrElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1878,10 +1770,8 @@ func (e *BrElement) StyleRemove(keys ...string) *BrElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *BrElement) Tabindex(i int) *BrElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1900,7 +1790,7 @@ func (e *BrElement) Tabindex(i int) *BrElement {
 // their relative position in the document.
 func (e *BrElement) IfTabindex(condition bool, i int) *BrElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1920,10 +1810,8 @@ func (e *BrElement) IfTabindex(condition bool, i int) *BrElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *BrElement) TabindexRemove() *BrElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1944,10 +1832,8 @@ func (e *BrElement) TabindexRemove() *BrElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *BrElement) Title(s string) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1989,7 +1875,7 @@ func (e *BrElement) Titlef(format string, args ...any) *BrElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BrElement) IfTitle(condition bool, s string) *BrElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2012,7 +1898,7 @@ func (e *BrElement) IfTitle(condition bool, s string) *BrElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *BrElement) IfTitlef(condition bool, format string, args ...any) *BrElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2035,10 +1921,8 @@ func (e *BrElement) IfTitlef(condition bool, format string, args ...any) *BrElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *BrElement) TitleRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2047,10 +1931,8 @@ func (e *BrElement) TitleRemove() *BrElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *BrElement) Translate(c BrTranslateChoice) *BrElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[BrTranslateChoice])
 	return e
 }
 
@@ -2071,9 +1953,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *BrElement) TranslateRemove() *BrElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <button> element represents a clickable button, used to submit forms
@@ -22,18 +20,87 @@ type ButtonElement struct {
 // with the tag "button" during rendering.
 func Button(children ...ElementRenderer) *ButtonElement {
 	e := NewElement("button", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &ButtonElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ButtonElement) Clone() *ButtonElement {
+	return &ButtonElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ButtonElement) CloneShallow() *ButtonElement {
+	return &ButtonElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ButtonElement) Freeze() *ButtonElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ButtonElement) writable() *ButtonElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ButtonElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ButtonElement) choiceType(name string) func(string) any {
+	switch name {
+	case "formenctype":
+		return choiceValue[ButtonFormenctypeChoice]
+	case "formmethod":
+		return choiceValue[ButtonFormmethodChoice]
+	case "formtarget":
+		return choiceValue[ButtonFormtargetChoice]
+	case "popovertargetaction":
+		return choiceValue[ButtonPopovertargetactionChoice]
+	case "type":
+		return choiceValue[ButtonTypeChoice]
+	case "autocapitalize":
+		return choiceValue[ButtonAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ButtonContenteditableChoice]
+	case "dir":
+		return choiceValue[ButtonDirChoice]
+	case "draggable":
+		return choiceValue[ButtonDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ButtonEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ButtonHiddenChoice]
+	case "inputmode":
+		return choiceValue[ButtonInputmodeChoice]
+	case "popover":
+		return choiceValue[ButtonPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ButtonSpellcheckChoice]
+	case "translate":
+		return choiceValue[ButtonTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ButtonElement) Children(children ...ElementRenderer) *ButtonElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ButtonElement) IfChildren(condition bool, children ...ElementRenderer) *ButtonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +108,30 @@ func (e *ButtonElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *ButtonElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ButtonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ButtonElement) BoolAttr(name string) *ButtonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ButtonElement) BoolAttrRemove(name string) *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ButtonElement) IfBoolAttr(condition bool, name string) *ButtonElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +142,40 @@ func (e *ButtonElement) BoolAttrf(format string, args ...any) *ButtonElement {
 
 func (e *ButtonElement) IfBoolAttrf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ButtonElement) BoolAttrs(names ...string) *ButtonElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ButtonElement) IfBoolAttrs(condition bool, names ...string) *ButtonElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ButtonElement) Attr(name, value string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ButtonElement) RawAttr(name, value string) *ButtonElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ButtonElement) IfAttr(condition bool, name, value string) *ButtonElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +186,52 @@ func (e *ButtonElement) Attrf(name, format string, args ...any) *ButtonElement {
 
 func (e *ButtonElement) IfAttrf(condition bool, name, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ButtonElement) Attrs(attrs ...string) *ButtonElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ButtonElement) IfAttrs(condition bool, attrs ...string) *ButtonElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ButtonElement) AttrsMap(attrs map[string]string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ButtonElement) IfAttrsMap(condition bool, attrs map[string]string) *ButtonElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ButtonElement) Text(text string) *ButtonElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ButtonElement) Textf(format string, args ...any) *ButtonElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ButtonElement) IfText(condition bool, text string) *ButtonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +239,28 @@ func (e *ButtonElement) IfText(condition bool, text string) *ButtonElement {
 
 func (e *ButtonElement) IfTextf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ButtonElement) Raw(text string) *ButtonElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ButtonElement) Escaped(text string) *ButtonElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ButtonElement) IfEscaped(condition bool, text string) *ButtonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +272,7 @@ func (e *ButtonElement) Escapedf(format string, args ...any) *ButtonElement {
 
 func (e *ButtonElement) IfEscapedf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +281,8 @@ func (e *ButtonElement) IfEscapedf(condition bool, format string, args ...any) *
 // A Boolean Attribute which, if present, indicates that the button should be
 // focused as soon as the page is loaded.
 func (e *ButtonElement) Autofocus() *ButtonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -221,7 +290,7 @@ func (e *ButtonElement) Autofocus() *ButtonElement {
 // focused as soon as the page is loaded.
 func (e *ButtonElement) IfAutofocus(condition bool) *ButtonElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -230,10 +299,8 @@ func (e *ButtonElement) IfAutofocus(condition bool) *ButtonElement {
 // A Boolean Attribute which, if present, indicates that the button should be
 // focused as soon as the page is loaded.
 func (e *ButtonElement) AutofocusRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -241,26 +308,22 @@ func (e *ButtonElement) AutofocusRemove() *ButtonElement {
 // A Boolean Attribute which, if present, indicates that the button should be
 // focused as soon as the page is loaded.
 func (e *ButtonElement) AutofocusIfRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
 // A Boolean Attribute which is present if the button is disabled.
 func (e *ButtonElement) Disabled() *ButtonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
 // A Boolean Attribute which is present if the button is disabled.
 func (e *ButtonElement) IfDisabled(condition bool) *ButtonElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -268,29 +331,23 @@ func (e *ButtonElement) IfDisabled(condition bool) *ButtonElement {
 // Remove the attribute Disabled from the element.
 // A Boolean Attribute which is present if the button is disabled.
 func (e *ButtonElement) DisabledRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Remove the attribute Disabled from the element.
 // A Boolean Attribute which is present if the button is disabled.
 func (e *ButtonElement) DisabledIfRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // The id of the form with which to associate the element.
 func (e *ButtonElement) Form(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -302,7 +359,7 @@ func (e *ButtonElement) Formf(format string, args ...any) *ButtonElement {
 // The id of the form with which to associate the element.
 func (e *ButtonElement) IfForm(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -310,7 +367,7 @@ func (e *ButtonElement) IfForm(condition bool, s string) *ButtonElement {
 // The id of the form with which to associate the element.
 func (e *ButtonElement) IfFormf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -318,20 +375,16 @@ func (e *ButtonElement) IfFormf(condition bool, format string, args ...any) *But
 // The id of the form with which to associate the element.
 // Remove the attribute Form from the element.
 func (e *ButtonElement) FormRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // The URI of a program that processes the information submitted by the button.
 // If specified, it overrides the action Attribute of the button's form owner.
 func (e *ButtonElement) Formaction(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formaction", s)
+	e = e.writable()
+	e.setStringAttribute("formaction", s)
 	return e
 }
 
@@ -345,7 +398,7 @@ func (e *ButtonElement) Formactionf(format string, args ...any) *ButtonElement {
 // If specified, it overrides the action Attribute of the button's form owner.
 func (e *ButtonElement) IfFormaction(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Formaction(s)
+		e = e.Formaction(s)
 	}
 	return e
 }
@@ -354,7 +407,7 @@ func (e *ButtonElement) IfFormaction(condition bool, s string) *ButtonElement {
 // If specified, it overrides the action Attribute of the button's form owner.
 func (e *ButtonElement) IfFormactionf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Formaction(fmt.Sprintf(format, args...))
+		e = e.Formaction(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -363,20 +416,16 @@ func (e *ButtonElement) IfFormactionf(condition bool, format string, args ...any
 // If specified, it overrides the action Attribute of the button's form owner.
 // Remove the attribute Formaction from the element.
 func (e *ButtonElement) FormactionRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formaction")
+	e = e.writable()
+	e.removeAttribute("formaction")
 	return e
 }
 
 // If the button is a submit button, this Attribute specifies the type of
 // content that is used to submit the form to the server.
 func (e *ButtonElement) Formenctype(c ButtonFormenctypeChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formenctype", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("formenctype", string(c), choiceValue[ButtonFormenctypeChoice])
 	return e
 }
 
@@ -396,20 +445,16 @@ const (
 // content that is used to submit the form to the server.
 // Remove the attribute Formenctype from the element.
 func (e *ButtonElement) FormenctypeRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formenctype")
+	e = e.writable()
+	e.removeAttribute("formenctype")
 	return e
 }
 
 // If the button is a submit button, this Attribute specifies the HTTP method
 // that the browser uses to submit the form. Possible values are:
 func (e *ButtonElement) Formmethod(c ButtonFormmethodChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formmethod", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("formmethod", string(c), choiceValue[ButtonFormmethodChoice])
 	return e
 }
 
@@ -431,10 +476,8 @@ const (
 // that the browser uses to submit the form. Possible values are:
 // Remove the attribute Formmethod from the element.
 func (e *ButtonElement) FormmethodRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formmethod")
+	e = e.writable()
+	e.removeAttribute("formmethod")
 	return e
 }
 
@@ -442,10 +485,8 @@ func (e *ButtonElement) FormmethodRemove() *ButtonElement {
 // form is not to be validated when it is submitted. If this attribute is
 // specified, it overrides the novalidate attribute of the button's form owner.
 func (e *ButtonElement) Formnovalidate() *ButtonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("formnovalidate", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("formnovalidate")
 	return e
 }
 
@@ -454,7 +495,7 @@ func (e *ButtonElement) Formnovalidate() *ButtonElement {
 // specified, it overrides the novalidate attribute of the button's form owner.
 func (e *ButtonElement) IfFormnovalidate(condition bool) *ButtonElement {
 	if condition {
-		e.Formnovalidate()
+		e = e.Formnovalidate()
 	}
 	return e
 }
@@ -464,10 +505,8 @@ func (e *ButtonElement) IfFormnovalidate(condition bool) *ButtonElement {
 // form is not to be validated when it is submitted. If this attribute is
 // specified, it overrides the novalidate attribute of the button's form owner.
 func (e *ButtonElement) FormnovalidateRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("formnovalidate")
+	e = e.writable()
+	e.removeAttribute("formnovalidate")
 	return e
 }
 
@@ -476,10 +515,8 @@ func (e *ButtonElement) FormnovalidateRemove() *ButtonElement {
 // form is not to be validated when it is submitted. If this attribute is
 // specified, it overrides the novalidate attribute of the button's form owner.
 func (e *ButtonElement) FormnovalidateIfRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("formnovalidate")
+	e = e.writable()
+	e.removeAttribute("formnovalidate")
 	return e
 }
 
@@ -490,10 +527,8 @@ func (e *ButtonElement) FormnovalidateIfRemove() *ButtonElement {
 // the target attribute of the button's form owner. The following keywords have
 // special meanings:
 func (e *ButtonElement) Formtarget(c ButtonFormtargetChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formtarget", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("formtarget", string(c), choiceValue[ButtonFormtargetChoice])
 	return e
 }
 
@@ -522,19 +557,15 @@ const (
 // special meanings:
 // Remove the attribute Formtarget from the element.
 func (e *ButtonElement) FormtargetRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formtarget")
+	e = e.writable()
+	e.removeAttribute("formtarget")
 	return e
 }
 
 // The name of the button, which is submitted with the form data.
 func (e *ButtonElement) Name(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -546,7 +577,7 @@ func (e *ButtonElement) Namef(format string, args ...any) *ButtonElement {
 // The name of the button, which is submitted with the form data.
 func (e *ButtonElement) IfName(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -554,7 +585,7 @@ func (e *ButtonElement) IfName(condition bool, s string) *ButtonElement {
 // The name of the button, which is submitted with the form data.
 func (e *ButtonElement) IfNamef(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -562,10 +593,8 @@ func (e *ButtonElement) IfNamef(condition bool, format string, args ...any) *But
 // The name of the button, which is submitted with the form data.
 // Remove the attribute Name from the element.
 func (e *ButtonElement) NameRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -573,10 +602,8 @@ func (e *ButtonElement) NameRemove() *ButtonElement {
 // popover element to control as its value. See the Popover API landing page for
 // more details.
 func (e *ButtonElement) Popovertarget(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popovertarget", s)
+	e = e.writable()
+	e.setStringAttribute("popovertarget", s)
 	return e
 }
 
@@ -592,7 +619,7 @@ func (e *ButtonElement) Popovertargetf(format string, args ...any) *ButtonElemen
 // more details.
 func (e *ButtonElement) IfPopovertarget(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Popovertarget(s)
+		e = e.Popovertarget(s)
 	}
 	return e
 }
@@ -602,7 +629,7 @@ func (e *ButtonElement) IfPopovertarget(condition bool, s string) *ButtonElement
 // more details.
 func (e *ButtonElement) IfPopovertargetf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Popovertarget(fmt.Sprintf(format, args...))
+		e = e.Popovertarget(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -612,20 +639,16 @@ func (e *ButtonElement) IfPopovertargetf(condition bool, format string, args ...
 // more details.
 // Remove the attribute Popovertarget from the element.
 func (e *ButtonElement) PopovertargetRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popovertarget")
+	e = e.writable()
+	e.removeAttribute("popovertarget")
 	return e
 }
 
 // Specifies the action to take when the popover control button is clicked.
 // Possible values are:
 func (e *ButtonElement) Popovertargetaction(c ButtonPopovertargetactionChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popovertargetaction", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popovertargetaction", string(c), choiceValue[ButtonPopovertargetactionChoice])
 	return e
 }
 
@@ -644,19 +667,15 @@ const (
 // Possible values are:
 // Remove the attribute Popovertargetaction from the element.
 func (e *ButtonElement) PopovertargetactionRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popovertargetaction")
+	e = e.writable()
+	e.removeAttribute("popovertargetaction")
 	return e
 }
 
 // The type of the button. Possible values are:
 func (e *ButtonElement) Type(c ButtonTypeChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[ButtonTypeChoice])
 	return e
 }
 
@@ -678,20 +697,16 @@ const (
 // The type of the button. Possible values are:
 // Remove the attribute Type from the element.
 func (e *ButtonElement) TypeRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // The initial value of the button. This Attribute is optional except when the
 // value of the type attribute is radio or checkbox.
 func (e *ButtonElement) Value(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("value", s)
+	e = e.writable()
+	e.setStringAttribute("value", s)
 	return e
 }
 
@@ -705,7 +720,7 @@ func (e *ButtonElement) Valuef(format string, args ...any) *ButtonElement {
 // value of the type attribute is radio or checkbox.
 func (e *ButtonElement) IfValue(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Value(s)
+		e = e.Value(s)
 	}
 	return e
 }
@@ -714,7 +729,7 @@ func (e *ButtonElement) IfValue(condition bool, s string) *ButtonElement {
 // value of the type attribute is radio or checkbox.
 func (e *ButtonElement) IfValuef(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Value(fmt.Sprintf(format, args...))
+		e = e.Value(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -723,10 +738,8 @@ func (e *ButtonElement) IfValuef(condition bool, format string, args ...any) *Bu
 // value of the type attribute is radio or checkbox.
 // Remove the attribute Value from the element.
 func (e *ButtonElement) ValueRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("value")
+	e = e.writable()
+	e.removeAttribute("value")
 	return e
 }
 
@@ -735,10 +748,8 @@ func (e *ButtonElement) ValueRemove() *ButtonElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ButtonElement) Accesskey(r rune) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -748,7 +759,7 @@ func (e *ButtonElement) Accesskey(r rune) *ButtonElement {
 // can be generated by the keyboard).
 func (e *ButtonElement) IfAccesskey(condition bool, r rune) *ButtonElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -759,10 +770,8 @@ func (e *ButtonElement) IfAccesskey(condition bool, r rune) *ButtonElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ButtonElement) AccesskeyRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -778,10 +787,8 @@ func (e *ButtonElement) AccesskeyRemove() *ButtonElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ButtonElement) Autocapitalize(c ButtonAutocapitalizeChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ButtonAutocapitalizeChoice])
 	return e
 }
 
@@ -815,10 +822,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ButtonElement) AutocapitalizeRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -827,16 +832,9 @@ func (e *ButtonElement) AutocapitalizeRemove() *ButtonElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ButtonElement) Class(s string) *ButtonElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -846,7 +844,7 @@ func (e *ButtonElement) Class(s string) *ButtonElement {
 // document.getElementsByClassName.
 func (e *ButtonElement) IfClass(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -857,14 +855,8 @@ func (e *ButtonElement) IfClass(condition bool, s string) *ButtonElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ButtonElement) ClassRemove(s ...string) *ButtonElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -872,10 +864,8 @@ func (e *ButtonElement) ClassRemove(s ...string) *ButtonElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ButtonElement) Contenteditable(c ButtonContenteditableChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ButtonContenteditableChoice])
 	return e
 }
 
@@ -898,10 +888,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ButtonElement) ContenteditableRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -919,10 +907,8 @@ func (e *ButtonElement) ContenteditableRemove() *ButtonElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ButtonElement) Dir(c ButtonDirChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ButtonDirChoice])
 	return e
 }
 
@@ -956,10 +942,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ButtonElement) DirRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -967,10 +951,8 @@ func (e *ButtonElement) DirRemove() *ButtonElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ButtonElement) Draggable(c ButtonDraggableChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ButtonDraggableChoice])
 	return e
 }
 
@@ -996,20 +978,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ButtonElement) DraggableRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ButtonElement) Enterkeyhint(c ButtonEnterkeyhintChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ButtonEnterkeyhintChoice])
 	return e
 }
 
@@ -1039,10 +1017,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ButtonElement) EnterkeyhintRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1064,16 +1040,9 @@ func (e *ButtonElement) EnterkeyhintRemove() *ButtonElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ButtonElement) Exportparts(s string) *ButtonElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1096,7 +1065,7 @@ func (e *ButtonElement) Exportparts(s string) *ButtonElement {
 // the current structure.
 func (e *ButtonElement) IfExportparts(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1120,14 +1089,8 @@ func (e *ButtonElement) IfExportparts(condition bool, s string) *ButtonElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ButtonElement) ExportpartsRemove(s ...string) *ButtonElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1144,10 +1107,8 @@ func (e *ButtonElement) ExportpartsRemove(s ...string) *ButtonElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ButtonElement) Hidden(c ButtonHiddenChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ButtonHiddenChoice])
 	return e
 }
 
@@ -1183,10 +1144,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ButtonElement) HiddenRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1194,10 +1153,8 @@ func (e *ButtonElement) HiddenRemove() *ButtonElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ButtonElement) ID(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1213,7 +1170,7 @@ func (e *ButtonElement) IDf(format string, args ...any) *ButtonElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ButtonElement) IfID(condition bool, s string) *ButtonElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1223,7 +1180,7 @@ func (e *ButtonElement) IfID(condition bool, s string) *ButtonElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ButtonElement) IfIDf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1233,10 +1190,8 @@ func (e *ButtonElement) IfIDf(condition bool, format string, args ...any) *Butto
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ButtonElement) IDRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1251,10 +1206,8 @@ func (e *ButtonElement) IDRemove() *ButtonElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ButtonElement) Inert() *ButtonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1270,7 +1223,7 @@ func (e *ButtonElement) Inert() *ButtonElement {
 // excluding them from the accessibility tree.
 func (e *ButtonElement) IfInert(condition bool) *ButtonElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1287,10 +1240,8 @@ func (e *ButtonElement) IfInert(condition bool) *ButtonElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ButtonElement) InertRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1306,10 +1257,8 @@ func (e *ButtonElement) InertRemove() *ButtonElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ButtonElement) InertIfRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1323,10 +1272,8 @@ func (e *ButtonElement) InertIfRemove() *ButtonElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ButtonElement) Inputmode(c ButtonInputmodeChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ButtonInputmodeChoice])
 	return e
 }
 
@@ -1377,10 +1324,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ButtonElement) InputmodeRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1390,10 +1335,8 @@ func (e *ButtonElement) InputmodeRemove() *ButtonElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ButtonElement) Is(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1413,7 +1356,7 @@ func (e *ButtonElement) Isf(format string, args ...any) *ButtonElement {
 // and extends the element type it is being applied to.
 func (e *ButtonElement) IfIs(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1425,7 +1368,7 @@ func (e *ButtonElement) IfIs(condition bool, s string) *ButtonElement {
 // and extends the element type it is being applied to.
 func (e *ButtonElement) IfIsf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1437,10 +1380,8 @@ func (e *ButtonElement) IfIsf(condition bool, format string, args ...any) *Butto
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ButtonElement) IsRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1456,10 +1397,8 @@ func (e *ButtonElement) IsRemove() *ButtonElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ButtonElement) Itemid(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1491,7 +1430,7 @@ func (e *ButtonElement) Itemidf(format string, args ...any) *ButtonElement {
 // how items with the same identifier are handled.
 func (e *ButtonElement) IfItemid(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1509,7 +1448,7 @@ func (e *ButtonElement) IfItemid(condition bool, s string) *ButtonElement {
 // how items with the same identifier are handled.
 func (e *ButtonElement) IfItemidf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1527,10 +1466,8 @@ func (e *ButtonElement) IfItemidf(condition bool, format string, args ...any) *B
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ButtonElement) ItemidRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1542,10 +1479,8 @@ func (e *ButtonElement) ItemidRemove() *ButtonElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ButtonElement) Itemprop(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1569,7 +1504,7 @@ func (e *ButtonElement) Itempropf(format string, args ...any) *ButtonElement {
 // <track>, and <video>.
 func (e *ButtonElement) IfItemprop(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1583,7 +1518,7 @@ func (e *ButtonElement) IfItemprop(condition bool, s string) *ButtonElement {
 // <track>, and <video>.
 func (e *ButtonElement) IfItempropf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1597,10 +1532,8 @@ func (e *ButtonElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ButtonElement) ItempropRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1610,10 +1543,8 @@ func (e *ButtonElement) ItempropRemove() *ButtonElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ButtonElement) Itemref(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1633,7 +1564,7 @@ func (e *ButtonElement) Itemreff(format string, args ...any) *ButtonElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ButtonElement) IfItemref(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1645,7 +1576,7 @@ func (e *ButtonElement) IfItemref(condition bool, s string) *ButtonElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ButtonElement) IfItemreff(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1657,10 +1588,8 @@ func (e *ButtonElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ButtonElement) ItemrefRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1672,10 +1601,8 @@ func (e *ButtonElement) ItemrefRemove() *ButtonElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ButtonElement) Itemscope() *ButtonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1688,7 +1615,7 @@ func (e *ButtonElement) Itemscope() *ButtonElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ButtonElement) IfItemscope(condition bool) *ButtonElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1702,10 +1629,8 @@ func (e *ButtonElement) IfItemscope(condition bool) *ButtonElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ButtonElement) ItemscopeRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1718,10 +1643,8 @@ func (e *ButtonElement) ItemscopeRemove() *ButtonElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ButtonElement) ItemscopeIfRemove() *ButtonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1733,10 +1656,8 @@ func (e *ButtonElement) ItemscopeIfRemove() *ButtonElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ButtonElement) Itemtype(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1760,7 +1681,7 @@ func (e *ButtonElement) Itemtypef(format string, args ...any) *ButtonElement {
 // <video>.
 func (e *ButtonElement) IfItemtype(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1774,7 +1695,7 @@ func (e *ButtonElement) IfItemtype(condition bool, s string) *ButtonElement {
 // <video>.
 func (e *ButtonElement) IfItemtypef(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1788,10 +1709,8 @@ func (e *ButtonElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ButtonElement) ItemtypeRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1801,10 +1720,8 @@ func (e *ButtonElement) ItemtypeRemove() *ButtonElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ButtonElement) Lang(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1824,7 +1741,7 @@ func (e *ButtonElement) Langf(format string, args ...any) *ButtonElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ButtonElement) IfLang(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1836,7 +1753,7 @@ func (e *ButtonElement) IfLang(condition bool, s string) *ButtonElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ButtonElement) IfLangf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1848,10 +1765,8 @@ func (e *ButtonElement) IfLangf(condition bool, format string, args ...any) *But
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ButtonElement) LangRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1861,10 +1776,8 @@ func (e *ButtonElement) LangRemove() *ButtonElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ButtonElement) Nonce(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1884,7 +1797,7 @@ func (e *ButtonElement) Noncef(format string, args ...any) *ButtonElement {
 // execute.
 func (e *ButtonElement) IfNonce(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1896,7 +1809,7 @@ func (e *ButtonElement) IfNonce(condition bool, s string) *ButtonElement {
 // execute.
 func (e *ButtonElement) IfNoncef(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1908,10 +1821,8 @@ func (e *ButtonElement) IfNoncef(condition bool, format string, args ...any) *Bu
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ButtonElement) NonceRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1919,16 +1830,9 @@ func (e *ButtonElement) NonceRemove() *ButtonElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ButtonElement) Part(s string) *ButtonElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1937,7 +1841,7 @@ func (e *ButtonElement) Part(s string) *ButtonElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ButtonElement) IfPart(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1947,14 +1851,8 @@ func (e *ButtonElement) IfPart(condition bool, s string) *ButtonElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ButtonElement) PartRemove(s ...string) *ButtonElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1965,10 +1863,8 @@ func (e *ButtonElement) PartRemove(s ...string) *ButtonElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ButtonElement) Popover(c ButtonPopoverChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ButtonPopoverChoice])
 	return e
 }
 
@@ -1996,10 +1892,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ButtonElement) PopoverRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -2008,10 +1902,8 @@ func (e *ButtonElement) PopoverRemove() *ButtonElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ButtonElement) Role(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -2029,7 +1921,7 @@ func (e *ButtonElement) Rolef(format string, args ...any) *ButtonElement {
 // role of an element.
 func (e *ButtonElement) IfRole(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -2040,7 +1932,7 @@ func (e *ButtonElement) IfRole(condition bool, s string) *ButtonElement {
 // role of an element.
 func (e *ButtonElement) IfRolef(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2051,10 +1943,8 @@ func (e *ButtonElement) IfRolef(condition bool, format string, args ...any) *But
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ButtonElement) RoleRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2063,10 +1953,8 @@ func (e *ButtonElement) RoleRemove() *ButtonElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ButtonElement) Slot(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2084,7 +1972,7 @@ func (e *ButtonElement) Slotf(format string, args ...any) *ButtonElement {
 // value.
 func (e *ButtonElement) IfSlot(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2095,7 +1983,7 @@ func (e *ButtonElement) IfSlot(condition bool, s string) *ButtonElement {
 // value.
 func (e *ButtonElement) IfSlotf(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2106,10 +1994,8 @@ func (e *ButtonElement) IfSlotf(condition bool, format string, args ...any) *But
 // value.
 // Remove the attribute Slot from the element.
 func (e *ButtonElement) SlotRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2125,10 +2011,8 @@ func (e *ButtonElement) SlotRemove() *ButtonElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ButtonElement) Spellcheck(c ButtonSpellcheckChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ButtonSpellcheckChoice])
 	return e
 }
 
@@ -2156,10 +2040,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ButtonElement) SpellcheckRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2169,14 +2051,8 @@ func (e *ButtonElement) StylePairs(pairs ...string) *ButtonElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2191,14 +2067,8 @@ func (e *ButtonElement) StylePairs(pairs ...string) *ButtonElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ButtonElement) Style(s string) *ButtonElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2206,7 +2076,7 @@ func (e *ButtonElement) Style(s string) *ButtonElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2215,7 +2085,7 @@ func (e *ButtonElement) Style(s string) *ButtonElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ButtonElement) IfStyle(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2223,15 +2093,7 @@ func (e *ButtonElement) IfStyle(condition bool, s string) *ButtonElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ButtonElement) StyleAdd(k string, v string) *ButtonElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2245,7 +2107,7 @@ func (e *ButtonElement) StyleAddf(k string, format string, args ...any) *ButtonE
 // color, font, size, and more. Styles are written in CSS.
 func (e *ButtonElement) IfStyleAdd(condition bool, k string, v string) *ButtonElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2254,7 +2116,7 @@ func (e *ButtonElement) IfStyleAdd(condition bool, k string, v string) *ButtonEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *ButtonElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ButtonElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2263,21 +2125,15 @@ func (e *ButtonElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ButtonElement) StyleMap(m map[string]string) *ButtonElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2286,14 +2142,8 @@ func (e *ButtonElement) StyleMap(m map[string]string) *ButtonElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ButtonElement) StyleRemove(keys ...string) *ButtonElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2311,10 +2161,8 @@ func (e *ButtonElement) StyleRemove(keys ...string) *ButtonElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ButtonElement) Tabindex(i int) *ButtonElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2333,7 +2181,7 @@ func (e *ButtonElement) Tabindex(i int) *ButtonElement {
 // their relative position in the document.
 func (e *ButtonElement) IfTabindex(condition bool, i int) *ButtonElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2353,10 +2201,8 @@ func (e *ButtonElement) IfTabindex(condition bool, i int) *ButtonElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ButtonElement) TabindexRemove() *ButtonElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2377,10 +2223,8 @@ func (e *ButtonElement) TabindexRemove() *ButtonElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ButtonElement) Title(s string) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2422,7 +2266,7 @@ func (e *ButtonElement) Titlef(format string, args ...any) *ButtonElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ButtonElement) IfTitle(condition bool, s string) *ButtonElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2445,7 +2289,7 @@ func (e *ButtonElement) IfTitle(condition bool, s string) *ButtonElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ButtonElement) IfTitlef(condition bool, format string, args ...any) *ButtonElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2468,10 +2312,8 @@ func (e *ButtonElement) IfTitlef(condition bool, format string, args ...any) *Bu
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ButtonElement) TitleRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2480,10 +2322,8 @@ func (e *ButtonElement) TitleRemove() *ButtonElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ButtonElement) Translate(c ButtonTranslateChoice) *ButtonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ButtonTranslateChoice])
 	return e
 }
 
@@ -2504,9 +2344,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ButtonElement) TranslateRemove() *ButtonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
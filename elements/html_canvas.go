@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <canvas> element is used to draw graphics, on the fly, via scripting
@@ -23,18 +21,77 @@ type CanvasElement struct {
 // with the tag "canvas" during rendering.
 func Canvas(children ...ElementRenderer) *CanvasElement {
 	e := NewElement("canvas", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &CanvasElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *CanvasElement) Clone() *CanvasElement {
+	return &CanvasElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *CanvasElement) CloneShallow() *CanvasElement {
+	return &CanvasElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *CanvasElement) Freeze() *CanvasElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *CanvasElement) writable() *CanvasElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *CanvasElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *CanvasElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[CanvasAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[CanvasContenteditableChoice]
+	case "dir":
+		return choiceValue[CanvasDirChoice]
+	case "draggable":
+		return choiceValue[CanvasDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[CanvasEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[CanvasHiddenChoice]
+	case "inputmode":
+		return choiceValue[CanvasInputmodeChoice]
+	case "popover":
+		return choiceValue[CanvasPopoverChoice]
+	case "spellcheck":
+		return choiceValue[CanvasSpellcheckChoice]
+	case "translate":
+		return choiceValue[CanvasTranslateChoice]
+	}
+	return nil
+}
+
 func (e *CanvasElement) Children(children ...ElementRenderer) *CanvasElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *CanvasElement) IfChildren(condition bool, children ...ElementRenderer) *CanvasElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *CanvasElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *CanvasElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *CanvasElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *CanvasElement) BoolAttr(name string) *CanvasElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *CanvasElement) BoolAttrRemove(name string) *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *CanvasElement) IfBoolAttr(condition bool, name string) *CanvasElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *CanvasElement) BoolAttrf(format string, args ...any) *CanvasElement {
 
 func (e *CanvasElement) IfBoolAttrf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *CanvasElement) BoolAttrs(names ...string) *CanvasElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *CanvasElement) IfBoolAttrs(condition bool, names ...string) *CanvasElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *CanvasElement) Attr(name, value string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *CanvasElement) RawAttr(name, value string) *CanvasElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *CanvasElement) IfAttr(condition bool, name, value string) *CanvasElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *CanvasElement) Attrf(name, format string, args ...any) *CanvasElement {
 
 func (e *CanvasElement) IfAttrf(condition bool, name, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *CanvasElement) Attrs(attrs ...string) *CanvasElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *CanvasElement) IfAttrs(condition bool, attrs ...string) *CanvasElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *CanvasElement) AttrsMap(attrs map[string]string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *CanvasElement) IfAttrsMap(condition bool, attrs map[string]string) *CanvasElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *CanvasElement) Text(text string) *CanvasElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *CanvasElement) Textf(format string, args ...any) *CanvasElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *CanvasElement) IfText(condition bool, text string) *CanvasElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *CanvasElement) IfText(condition bool, text string) *CanvasElement {
 
 func (e *CanvasElement) IfTextf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *CanvasElement) Raw(text string) *CanvasElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *CanvasElement) Escaped(text string) *CanvasElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *CanvasElement) IfEscaped(condition bool, text string) *CanvasElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *CanvasElement) Escapedf(format string, args ...any) *CanvasElement {
 
 func (e *CanvasElement) IfEscapedf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +271,15 @@ func (e *CanvasElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // The height of the coordinate space in CSS pixels.
 func (e *CanvasElement) Height(i int) *CanvasElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // The height of the coordinate space in CSS pixels.
 func (e *CanvasElement) IfHeight(condition bool, i int) *CanvasElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -228,26 +287,22 @@ func (e *CanvasElement) IfHeight(condition bool, i int) *CanvasElement {
 // The height of the coordinate space in CSS pixels.
 // Remove the attribute Height from the element.
 func (e *CanvasElement) HeightRemove() *CanvasElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // The width of the coordinate space in CSS pixels.
 func (e *CanvasElement) Width(i int) *CanvasElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // The width of the coordinate space in CSS pixels.
 func (e *CanvasElement) IfWidth(condition bool, i int) *CanvasElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -255,10 +310,8 @@ func (e *CanvasElement) IfWidth(condition bool, i int) *CanvasElement {
 // The width of the coordinate space in CSS pixels.
 // Remove the attribute Width from the element.
 func (e *CanvasElement) WidthRemove() *CanvasElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -267,10 +320,8 @@ func (e *CanvasElement) WidthRemove() *CanvasElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *CanvasElement) Accesskey(r rune) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -280,7 +331,7 @@ func (e *CanvasElement) Accesskey(r rune) *CanvasElement {
 // can be generated by the keyboard).
 func (e *CanvasElement) IfAccesskey(condition bool, r rune) *CanvasElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -291,10 +342,8 @@ func (e *CanvasElement) IfAccesskey(condition bool, r rune) *CanvasElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *CanvasElement) AccesskeyRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -310,10 +359,8 @@ func (e *CanvasElement) AccesskeyRemove() *CanvasElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *CanvasElement) Autocapitalize(c CanvasAutocapitalizeChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[CanvasAutocapitalizeChoice])
 	return e
 }
 
@@ -347,10 +394,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *CanvasElement) AutocapitalizeRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -369,10 +414,8 @@ func (e *CanvasElement) AutocapitalizeRemove() *CanvasElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *CanvasElement) Autofocus() *CanvasElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -392,7 +435,7 @@ func (e *CanvasElement) Autofocus() *CanvasElement {
 // created by the preceding content.
 func (e *CanvasElement) IfAutofocus(condition bool) *CanvasElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -413,10 +456,8 @@ func (e *CanvasElement) IfAutofocus(condition bool) *CanvasElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *CanvasElement) AutofocusRemove() *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -436,10 +477,8 @@ func (e *CanvasElement) AutofocusRemove() *CanvasElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *CanvasElement) AutofocusIfRemove() *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -448,16 +487,9 @@ func (e *CanvasElement) AutofocusIfRemove() *CanvasElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *CanvasElement) Class(s string) *CanvasElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -467,7 +499,7 @@ func (e *CanvasElement) Class(s string) *CanvasElement {
 // document.getElementsByClassName.
 func (e *CanvasElement) IfClass(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -478,14 +510,8 @@ func (e *CanvasElement) IfClass(condition bool, s string) *CanvasElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *CanvasElement) ClassRemove(s ...string) *CanvasElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -493,10 +519,8 @@ func (e *CanvasElement) ClassRemove(s ...string) *CanvasElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *CanvasElement) Contenteditable(c CanvasContenteditableChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[CanvasContenteditableChoice])
 	return e
 }
 
@@ -519,10 +543,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *CanvasElement) ContenteditableRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -540,10 +562,8 @@ func (e *CanvasElement) ContenteditableRemove() *CanvasElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *CanvasElement) Dir(c CanvasDirChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[CanvasDirChoice])
 	return e
 }
 
@@ -577,10 +597,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *CanvasElement) DirRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -588,10 +606,8 @@ func (e *CanvasElement) DirRemove() *CanvasElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *CanvasElement) Draggable(c CanvasDraggableChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[CanvasDraggableChoice])
 	return e
 }
 
@@ -617,20 +633,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *CanvasElement) DraggableRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *CanvasElement) Enterkeyhint(c CanvasEnterkeyhintChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[CanvasEnterkeyhintChoice])
 	return e
 }
 
@@ -660,10 +672,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *CanvasElement) EnterkeyhintRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -685,16 +695,9 @@ func (e *CanvasElement) EnterkeyhintRemove() *CanvasElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *CanvasElement) Exportparts(s string) *CanvasElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -717,7 +720,7 @@ func (e *CanvasElement) Exportparts(s string) *CanvasElement {
 // the current structure.
 func (e *CanvasElement) IfExportparts(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -741,14 +744,8 @@ func (e *CanvasElement) IfExportparts(condition bool, s string) *CanvasElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *CanvasElement) ExportpartsRemove(s ...string) *CanvasElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -765,10 +762,8 @@ func (e *CanvasElement) ExportpartsRemove(s ...string) *CanvasElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *CanvasElement) Hidden(c CanvasHiddenChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[CanvasHiddenChoice])
 	return e
 }
 
@@ -804,10 +799,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *CanvasElement) HiddenRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -815,10 +808,8 @@ func (e *CanvasElement) HiddenRemove() *CanvasElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *CanvasElement) ID(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -834,7 +825,7 @@ func (e *CanvasElement) IDf(format string, args ...any) *CanvasElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *CanvasElement) IfID(condition bool, s string) *CanvasElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -844,7 +835,7 @@ func (e *CanvasElement) IfID(condition bool, s string) *CanvasElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *CanvasElement) IfIDf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -854,10 +845,8 @@ func (e *CanvasElement) IfIDf(condition bool, format string, args ...any) *Canva
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *CanvasElement) IDRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -872,10 +861,8 @@ func (e *CanvasElement) IDRemove() *CanvasElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *CanvasElement) Inert() *CanvasElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -891,7 +878,7 @@ func (e *CanvasElement) Inert() *CanvasElement {
 // excluding them from the accessibility tree.
 func (e *CanvasElement) IfInert(condition bool) *CanvasElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -908,10 +895,8 @@ func (e *CanvasElement) IfInert(condition bool) *CanvasElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *CanvasElement) InertRemove() *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -927,10 +912,8 @@ func (e *CanvasElement) InertRemove() *CanvasElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *CanvasElement) InertIfRemove() *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -944,10 +927,8 @@ func (e *CanvasElement) InertIfRemove() *CanvasElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *CanvasElement) Inputmode(c CanvasInputmodeChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[CanvasInputmodeChoice])
 	return e
 }
 
@@ -998,10 +979,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *CanvasElement) InputmodeRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1011,10 +990,8 @@ func (e *CanvasElement) InputmodeRemove() *CanvasElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *CanvasElement) Is(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1034,7 +1011,7 @@ func (e *CanvasElement) Isf(format string, args ...any) *CanvasElement {
 // and extends the element type it is being applied to.
 func (e *CanvasElement) IfIs(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1046,7 +1023,7 @@ func (e *CanvasElement) IfIs(condition bool, s string) *CanvasElement {
 // and extends the element type it is being applied to.
 func (e *CanvasElement) IfIsf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1058,10 +1035,8 @@ func (e *CanvasElement) IfIsf(condition bool, format string, args ...any) *Canva
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *CanvasElement) IsRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1077,10 +1052,8 @@ func (e *CanvasElement) IsRemove() *CanvasElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *CanvasElement) Itemid(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1112,7 +1085,7 @@ func (e *CanvasElement) Itemidf(format string, args ...any) *CanvasElement {
 // how items with the same identifier are handled.
 func (e *CanvasElement) IfItemid(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1130,7 +1103,7 @@ func (e *CanvasElement) IfItemid(condition bool, s string) *CanvasElement {
 // how items with the same identifier are handled.
 func (e *CanvasElement) IfItemidf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1148,10 +1121,8 @@ func (e *CanvasElement) IfItemidf(condition bool, format string, args ...any) *C
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *CanvasElement) ItemidRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1163,10 +1134,8 @@ func (e *CanvasElement) ItemidRemove() *CanvasElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *CanvasElement) Itemprop(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1190,7 +1159,7 @@ func (e *CanvasElement) Itempropf(format string, args ...any) *CanvasElement {
 // <track>, and <video>.
 func (e *CanvasElement) IfItemprop(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1204,7 +1173,7 @@ func (e *CanvasElement) IfItemprop(condition bool, s string) *CanvasElement {
 // <track>, and <video>.
 func (e *CanvasElement) IfItempropf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1218,10 +1187,8 @@ func (e *CanvasElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *CanvasElement) ItempropRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1231,10 +1198,8 @@ func (e *CanvasElement) ItempropRemove() *CanvasElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *CanvasElement) Itemref(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1254,7 +1219,7 @@ func (e *CanvasElement) Itemreff(format string, args ...any) *CanvasElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *CanvasElement) IfItemref(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1266,7 +1231,7 @@ func (e *CanvasElement) IfItemref(condition bool, s string) *CanvasElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *CanvasElement) IfItemreff(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1278,10 +1243,8 @@ func (e *CanvasElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *CanvasElement) ItemrefRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1293,10 +1256,8 @@ func (e *CanvasElement) ItemrefRemove() *CanvasElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *CanvasElement) Itemscope() *CanvasElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1309,7 +1270,7 @@ func (e *CanvasElement) Itemscope() *CanvasElement {
 // <object>, <source>, <track>, and <video>.
 func (e *CanvasElement) IfItemscope(condition bool) *CanvasElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1323,10 +1284,8 @@ func (e *CanvasElement) IfItemscope(condition bool) *CanvasElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *CanvasElement) ItemscopeRemove() *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1339,10 +1298,8 @@ func (e *CanvasElement) ItemscopeRemove() *CanvasElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *CanvasElement) ItemscopeIfRemove() *CanvasElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1354,10 +1311,8 @@ func (e *CanvasElement) ItemscopeIfRemove() *CanvasElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *CanvasElement) Itemtype(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1381,7 +1336,7 @@ func (e *CanvasElement) Itemtypef(format string, args ...any) *CanvasElement {
 // <video>.
 func (e *CanvasElement) IfItemtype(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1395,7 +1350,7 @@ func (e *CanvasElement) IfItemtype(condition bool, s string) *CanvasElement {
 // <video>.
 func (e *CanvasElement) IfItemtypef(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1409,10 +1364,8 @@ func (e *CanvasElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *CanvasElement) ItemtypeRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1422,10 +1375,8 @@ func (e *CanvasElement) ItemtypeRemove() *CanvasElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *CanvasElement) Lang(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1445,7 +1396,7 @@ func (e *CanvasElement) Langf(format string, args ...any) *CanvasElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *CanvasElement) IfLang(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1457,7 +1408,7 @@ func (e *CanvasElement) IfLang(condition bool, s string) *CanvasElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *CanvasElement) IfLangf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1469,10 +1420,8 @@ func (e *CanvasElement) IfLangf(condition bool, format string, args ...any) *Can
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *CanvasElement) LangRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1482,10 +1431,8 @@ func (e *CanvasElement) LangRemove() *CanvasElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *CanvasElement) Nonce(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1505,7 +1452,7 @@ func (e *CanvasElement) Noncef(format string, args ...any) *CanvasElement {
 // execute.
 func (e *CanvasElement) IfNonce(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1517,7 +1464,7 @@ func (e *CanvasElement) IfNonce(condition bool, s string) *CanvasElement {
 // execute.
 func (e *CanvasElement) IfNoncef(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1529,10 +1476,8 @@ func (e *CanvasElement) IfNoncef(condition bool, format string, args ...any) *Ca
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *CanvasElement) NonceRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1540,16 +1485,9 @@ func (e *CanvasElement) NonceRemove() *CanvasElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *CanvasElement) Part(s string) *CanvasElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1558,7 +1496,7 @@ func (e *CanvasElement) Part(s string) *CanvasElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *CanvasElement) IfPart(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1568,14 +1506,8 @@ func (e *CanvasElement) IfPart(condition bool, s string) *CanvasElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *CanvasElement) PartRemove(s ...string) *CanvasElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1586,10 +1518,8 @@ func (e *CanvasElement) PartRemove(s ...string) *CanvasElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *CanvasElement) Popover(c CanvasPopoverChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[CanvasPopoverChoice])
 	return e
 }
 
@@ -1617,10 +1547,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *CanvasElement) PopoverRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1629,10 +1557,8 @@ func (e *CanvasElement) PopoverRemove() *CanvasElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *CanvasElement) Role(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1650,7 +1576,7 @@ func (e *CanvasElement) Rolef(format string, args ...any) *CanvasElement {
 // role of an element.
 func (e *CanvasElement) IfRole(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1661,7 +1587,7 @@ func (e *CanvasElement) IfRole(condition bool, s string) *CanvasElement {
 // role of an element.
 func (e *CanvasElement) IfRolef(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1672,10 +1598,8 @@ func (e *CanvasElement) IfRolef(condition bool, format string, args ...any) *Can
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *CanvasElement) RoleRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1684,10 +1608,8 @@ func (e *CanvasElement) RoleRemove() *CanvasElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *CanvasElement) Slot(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1705,7 +1627,7 @@ func (e *CanvasElement) Slotf(format string, args ...any) *CanvasElement {
 // value.
 func (e *CanvasElement) IfSlot(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1716,7 +1638,7 @@ func (e *CanvasElement) IfSlot(condition bool, s string) *CanvasElement {
 // value.
 func (e *CanvasElement) IfSlotf(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1727,10 +1649,8 @@ func (e *CanvasElement) IfSlotf(condition bool, format string, args ...any) *Can
 // value.
 // Remove the attribute Slot from the element.
 func (e *CanvasElement) SlotRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1746,10 +1666,8 @@ func (e *CanvasElement) SlotRemove() *CanvasElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *CanvasElement) Spellcheck(c CanvasSpellcheckChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[CanvasSpellcheckChoice])
 	return e
 }
 
@@ -1777,10 +1695,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *CanvasElement) SpellcheckRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1790,14 +1706,8 @@ func (e *CanvasElement) StylePairs(pairs ...string) *CanvasElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1812,14 +1722,8 @@ func (e *CanvasElement) StylePairs(pairs ...string) *CanvasElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *CanvasElement) Style(s string) *CanvasElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1827,7 +1731,7 @@ func (e *CanvasElement) Style(s string) *CanvasElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1836,7 +1740,7 @@ func (e *CanvasElement) Style(s string) *CanvasElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *CanvasElement) IfStyle(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1844,15 +1748,7 @@ func (e *CanvasElement) IfStyle(condition bool, s string) *CanvasElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *CanvasElement) StyleAdd(k string, v string) *CanvasElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1866,7 +1762,7 @@ func (e *CanvasElement) StyleAddf(k string, format string, args ...any) *CanvasE
 // color, font, size, and more. Styles are written in CSS.
 func (e *CanvasElement) IfStyleAdd(condition bool, k string, v string) *CanvasElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1875,7 +1771,7 @@ func (e *CanvasElement) IfStyleAdd(condition bool, k string, v string) *CanvasEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *CanvasElement) IfStyleAddf(condition bool, k string, format string, args ...any) *CanvasElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1884,21 +1780,15 @@ func (e *CanvasElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *CanvasElement) StyleMap(m map[string]string) *CanvasElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1907,14 +1797,8 @@ func (e *CanvasElement) StyleMap(m map[string]string) *CanvasElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *CanvasElement) StyleRemove(keys ...string) *CanvasElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1932,10 +1816,8 @@ func (e *CanvasElement) StyleRemove(keys ...string) *CanvasElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *CanvasElement) Tabindex(i int) *CanvasElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1954,7 +1836,7 @@ func (e *CanvasElement) Tabindex(i int) *CanvasElement {
 // their relative position in the document.
 func (e *CanvasElement) IfTabindex(condition bool, i int) *CanvasElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1974,10 +1856,8 @@ func (e *CanvasElement) IfTabindex(condition bool, i int) *CanvasElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *CanvasElement) TabindexRemove() *CanvasElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1998,10 +1878,8 @@ func (e *CanvasElement) TabindexRemove() *CanvasElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *CanvasElement) Title(s string) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2043,7 +1921,7 @@ func (e *CanvasElement) Titlef(format string, args ...any) *CanvasElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *CanvasElement) IfTitle(condition bool, s string) *CanvasElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2066,7 +1944,7 @@ func (e *CanvasElement) IfTitle(condition bool, s string) *CanvasElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *CanvasElement) IfTitlef(condition bool, format string, args ...any) *CanvasElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2089,10 +1967,8 @@ func (e *CanvasElement) IfTitlef(condition bool, format string, args ...any) *Ca
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *CanvasElement) TitleRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2101,10 +1977,8 @@ func (e *CanvasElement) TitleRemove() *CanvasElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *CanvasElement) Translate(c CanvasTranslateChoice) *CanvasElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[CanvasTranslateChoice])
 	return e
 }
 
@@ -2125,9 +1999,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *CanvasElement) TranslateRemove() *CanvasElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML Citation element (<cite>) is used to describe a reference to a cited
@@ -24,18 +22,77 @@ type CiteElement struct {
 // with the tag "cite" during rendering.
 func Cite(children ...ElementRenderer) *CiteElement {
 	e := NewElement("cite", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &CiteElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *CiteElement) Clone() *CiteElement {
+	return &CiteElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *CiteElement) CloneShallow() *CiteElement {
+	return &CiteElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *CiteElement) Freeze() *CiteElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *CiteElement) writable() *CiteElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *CiteElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *CiteElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[CiteAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[CiteContenteditableChoice]
+	case "dir":
+		return choiceValue[CiteDirChoice]
+	case "draggable":
+		return choiceValue[CiteDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[CiteEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[CiteHiddenChoice]
+	case "inputmode":
+		return choiceValue[CiteInputmodeChoice]
+	case "popover":
+		return choiceValue[CitePopoverChoice]
+	case "spellcheck":
+		return choiceValue[CiteSpellcheckChoice]
+	case "translate":
+		return choiceValue[CiteTranslateChoice]
+	}
+	return nil
+}
+
 func (e *CiteElement) Children(children ...ElementRenderer) *CiteElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *CiteElement) IfChildren(condition bool, children ...ElementRenderer) *CiteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +100,30 @@ func (e *CiteElement) IfChildren(condition bool, children ...ElementRenderer) *C
 
 func (e *CiteElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *CiteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *CiteElement) BoolAttr(name string) *CiteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *CiteElement) BoolAttrRemove(name string) *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *CiteElement) IfBoolAttr(condition bool, name string) *CiteElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +134,40 @@ func (e *CiteElement) BoolAttrf(format string, args ...any) *CiteElement {
 
 func (e *CiteElement) IfBoolAttrf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *CiteElement) BoolAttrs(names ...string) *CiteElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *CiteElement) IfBoolAttrs(condition bool, names ...string) *CiteElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *CiteElement) Attr(name, value string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *CiteElement) RawAttr(name, value string) *CiteElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *CiteElement) IfAttr(condition bool, name, value string) *CiteElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +178,52 @@ func (e *CiteElement) Attrf(name, format string, args ...any) *CiteElement {
 
 func (e *CiteElement) IfAttrf(condition bool, name, format string, args ...any) *CiteElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *CiteElement) Attrs(attrs ...string) *CiteElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *CiteElement) IfAttrs(condition bool, attrs ...string) *CiteElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *CiteElement) AttrsMap(attrs map[string]string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *CiteElement) IfAttrsMap(condition bool, attrs map[string]string) *CiteElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *CiteElement) Text(text string) *CiteElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *CiteElement) Textf(format string, args ...any) *CiteElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *CiteElement) IfText(condition bool, text string) *CiteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +231,28 @@ func (e *CiteElement) IfText(condition bool, text string) *CiteElement {
 
 func (e *CiteElement) IfTextf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *CiteElement) Raw(text string) *CiteElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *CiteElement) Escaped(text string) *CiteElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *CiteElement) IfEscaped(condition bool, text string) *CiteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +264,7 @@ func (e *CiteElement) Escapedf(format string, args ...any) *CiteElement {
 
 func (e *CiteElement) IfEscapedf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -214,10 +275,8 @@ func (e *CiteElement) IfEscapedf(condition bool, format string, args ...any) *Ci
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *CiteElement) Accesskey(r rune) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -227,7 +286,7 @@ func (e *CiteElement) Accesskey(r rune) *CiteElement {
 // can be generated by the keyboard).
 func (e *CiteElement) IfAccesskey(condition bool, r rune) *CiteElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -238,10 +297,8 @@ func (e *CiteElement) IfAccesskey(condition bool, r rune) *CiteElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *CiteElement) AccesskeyRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -257,10 +314,8 @@ func (e *CiteElement) AccesskeyRemove() *CiteElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *CiteElement) Autocapitalize(c CiteAutocapitalizeChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[CiteAutocapitalizeChoice])
 	return e
 }
 
@@ -294,10 +349,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *CiteElement) AutocapitalizeRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -316,10 +369,8 @@ func (e *CiteElement) AutocapitalizeRemove() *CiteElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *CiteElement) Autofocus() *CiteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -339,7 +390,7 @@ func (e *CiteElement) Autofocus() *CiteElement {
 // created by the preceding content.
 func (e *CiteElement) IfAutofocus(condition bool) *CiteElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -360,10 +411,8 @@ func (e *CiteElement) IfAutofocus(condition bool) *CiteElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *CiteElement) AutofocusRemove() *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -383,10 +432,8 @@ func (e *CiteElement) AutofocusRemove() *CiteElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *CiteElement) AutofocusIfRemove() *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -395,16 +442,9 @@ func (e *CiteElement) AutofocusIfRemove() *CiteElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *CiteElement) Class(s string) *CiteElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -414,7 +454,7 @@ func (e *CiteElement) Class(s string) *CiteElement {
 // document.getElementsByClassName.
 func (e *CiteElement) IfClass(condition bool, s string) *CiteElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -425,14 +465,8 @@ func (e *CiteElement) IfClass(condition bool, s string) *CiteElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *CiteElement) ClassRemove(s ...string) *CiteElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -440,10 +474,8 @@ func (e *CiteElement) ClassRemove(s ...string) *CiteElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *CiteElement) Contenteditable(c CiteContenteditableChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[CiteContenteditableChoice])
 	return e
 }
 
@@ -466,10 +498,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *CiteElement) ContenteditableRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -487,10 +517,8 @@ func (e *CiteElement) ContenteditableRemove() *CiteElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *CiteElement) Dir(c CiteDirChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[CiteDirChoice])
 	return e
 }
 
@@ -524,10 +552,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *CiteElement) DirRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -535,10 +561,8 @@ func (e *CiteElement) DirRemove() *CiteElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *CiteElement) Draggable(c CiteDraggableChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[CiteDraggableChoice])
 	return e
 }
 
@@ -564,20 +588,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *CiteElement) DraggableRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *CiteElement) Enterkeyhint(c CiteEnterkeyhintChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[CiteEnterkeyhintChoice])
 	return e
 }
 
@@ -607,10 +627,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *CiteElement) EnterkeyhintRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -632,16 +650,9 @@ func (e *CiteElement) EnterkeyhintRemove() *CiteElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *CiteElement) Exportparts(s string) *CiteElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -664,7 +675,7 @@ func (e *CiteElement) Exportparts(s string) *CiteElement {
 // the current structure.
 func (e *CiteElement) IfExportparts(condition bool, s string) *CiteElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -688,14 +699,8 @@ func (e *CiteElement) IfExportparts(condition bool, s string) *CiteElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *CiteElement) ExportpartsRemove(s ...string) *CiteElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -712,10 +717,8 @@ func (e *CiteElement) ExportpartsRemove(s ...string) *CiteElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *CiteElement) Hidden(c CiteHiddenChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[CiteHiddenChoice])
 	return e
 }
 
@@ -751,10 +754,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *CiteElement) HiddenRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -762,10 +763,8 @@ func (e *CiteElement) HiddenRemove() *CiteElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *CiteElement) ID(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -781,7 +780,7 @@ func (e *CiteElement) IDf(format string, args ...any) *CiteElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *CiteElement) IfID(condition bool, s string) *CiteElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -791,7 +790,7 @@ func (e *CiteElement) IfID(condition bool, s string) *CiteElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *CiteElement) IfIDf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -801,10 +800,8 @@ func (e *CiteElement) IfIDf(condition bool, format string, args ...any) *CiteEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *CiteElement) IDRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -819,10 +816,8 @@ func (e *CiteElement) IDRemove() *CiteElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *CiteElement) Inert() *CiteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -838,7 +833,7 @@ func (e *CiteElement) Inert() *CiteElement {
 // excluding them from the accessibility tree.
 func (e *CiteElement) IfInert(condition bool) *CiteElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -855,10 +850,8 @@ func (e *CiteElement) IfInert(condition bool) *CiteElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *CiteElement) InertRemove() *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -874,10 +867,8 @@ func (e *CiteElement) InertRemove() *CiteElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *CiteElement) InertIfRemove() *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -891,10 +882,8 @@ func (e *CiteElement) InertIfRemove() *CiteElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *CiteElement) Inputmode(c CiteInputmodeChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[CiteInputmodeChoice])
 	return e
 }
 
@@ -945,10 +934,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *CiteElement) InputmodeRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -958,10 +945,8 @@ func (e *CiteElement) InputmodeRemove() *CiteElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *CiteElement) Is(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -981,7 +966,7 @@ func (e *CiteElement) Isf(format string, args ...any) *CiteElement {
 // and extends the element type it is being applied to.
 func (e *CiteElement) IfIs(condition bool, s string) *CiteElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -993,7 +978,7 @@ func (e *CiteElement) IfIs(condition bool, s string) *CiteElement {
 // and extends the element type it is being applied to.
 func (e *CiteElement) IfIsf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1005,10 +990,8 @@ func (e *CiteElement) IfIsf(condition bool, format string, args ...any) *CiteEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *CiteElement) IsRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1024,10 +1007,8 @@ func (e *CiteElement) IsRemove() *CiteElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *CiteElement) Itemid(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1059,7 +1040,7 @@ func (e *CiteElement) Itemidf(format string, args ...any) *CiteElement {
 // how items with the same identifier are handled.
 func (e *CiteElement) IfItemid(condition bool, s string) *CiteElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1077,7 +1058,7 @@ func (e *CiteElement) IfItemid(condition bool, s string) *CiteElement {
 // how items with the same identifier are handled.
 func (e *CiteElement) IfItemidf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1095,10 +1076,8 @@ func (e *CiteElement) IfItemidf(condition bool, format string, args ...any) *Cit
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *CiteElement) ItemidRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1110,10 +1089,8 @@ func (e *CiteElement) ItemidRemove() *CiteElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *CiteElement) Itemprop(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1137,7 +1114,7 @@ func (e *CiteElement) Itempropf(format string, args ...any) *CiteElement {
 // <track>, and <video>.
 func (e *CiteElement) IfItemprop(condition bool, s string) *CiteElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1151,7 +1128,7 @@ func (e *CiteElement) IfItemprop(condition bool, s string) *CiteElement {
 // <track>, and <video>.
 func (e *CiteElement) IfItempropf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1165,10 +1142,8 @@ func (e *CiteElement) IfItempropf(condition bool, format string, args ...any) *C
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *CiteElement) ItempropRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1178,10 +1153,8 @@ func (e *CiteElement) ItempropRemove() *CiteElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *CiteElement) Itemref(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1201,7 +1174,7 @@ func (e *CiteElement) Itemreff(format string, args ...any) *CiteElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *CiteElement) IfItemref(condition bool, s string) *CiteElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1213,7 +1186,7 @@ func (e *CiteElement) IfItemref(condition bool, s string) *CiteElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *CiteElement) IfItemreff(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1225,10 +1198,8 @@ func (e *CiteElement) IfItemreff(condition bool, format string, args ...any) *Ci
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *CiteElement) ItemrefRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1240,10 +1211,8 @@ func (e *CiteElement) ItemrefRemove() *CiteElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *CiteElement) Itemscope() *CiteElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1256,7 +1225,7 @@ func (e *CiteElement) Itemscope() *CiteElement {
 // <object>, <source>, <track>, and <video>.
 func (e *CiteElement) IfItemscope(condition bool) *CiteElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1270,10 +1239,8 @@ func (e *CiteElement) IfItemscope(condition bool) *CiteElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *CiteElement) ItemscopeRemove() *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1286,10 +1253,8 @@ func (e *CiteElement) ItemscopeRemove() *CiteElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *CiteElement) ItemscopeIfRemove() *CiteElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1301,10 +1266,8 @@ func (e *CiteElement) ItemscopeIfRemove() *CiteElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *CiteElement) Itemtype(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1328,7 +1291,7 @@ func (e *CiteElement) Itemtypef(format string, args ...any) *CiteElement {
 // <video>.
 func (e *CiteElement) IfItemtype(condition bool, s string) *CiteElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1342,7 +1305,7 @@ func (e *CiteElement) IfItemtype(condition bool, s string) *CiteElement {
 // <video>.
 func (e *CiteElement) IfItemtypef(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1356,10 +1319,8 @@ func (e *CiteElement) IfItemtypef(condition bool, format string, args ...any) *C
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *CiteElement) ItemtypeRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1369,10 +1330,8 @@ func (e *CiteElement) ItemtypeRemove() *CiteElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *CiteElement) Lang(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1392,7 +1351,7 @@ func (e *CiteElement) Langf(format string, args ...any) *CiteElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *CiteElement) IfLang(condition bool, s string) *CiteElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1404,7 +1363,7 @@ func (e *CiteElement) IfLang(condition bool, s string) *CiteElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *CiteElement) IfLangf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1416,10 +1375,8 @@ func (e *CiteElement) IfLangf(condition bool, format string, args ...any) *CiteE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *CiteElement) LangRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1429,10 +1386,8 @@ func (e *CiteElement) LangRemove() *CiteElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *CiteElement) Nonce(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1452,7 +1407,7 @@ func (e *CiteElement) Noncef(format string, args ...any) *CiteElement {
 // execute.
 func (e *CiteElement) IfNonce(condition bool, s string) *CiteElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1464,7 +1419,7 @@ func (e *CiteElement) IfNonce(condition bool, s string) *CiteElement {
 // execute.
 func (e *CiteElement) IfNoncef(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1476,10 +1431,8 @@ func (e *CiteElement) IfNoncef(condition bool, format string, args ...any) *Cite
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *CiteElement) NonceRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1487,16 +1440,9 @@ func (e *CiteElement) NonceRemove() *CiteElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *CiteElement) Part(s string) *CiteElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1505,7 +1451,7 @@ func (e *CiteElement) Part(s string) *CiteElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *CiteElement) IfPart(condition bool, s string) *CiteElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1515,14 +1461,8 @@ func (e *CiteElement) IfPart(condition bool, s string) *CiteElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *CiteElement) PartRemove(s ...string) *CiteElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1533,10 +1473,8 @@ func (e *CiteElement) PartRemove(s ...string) *CiteElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *CiteElement) Popover(c CitePopoverChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[CitePopoverChoice])
 	return e
 }
 
@@ -1564,10 +1502,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *CiteElement) PopoverRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1576,10 +1512,8 @@ func (e *CiteElement) PopoverRemove() *CiteElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *CiteElement) Role(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1597,7 +1531,7 @@ func (e *CiteElement) Rolef(format string, args ...any) *CiteElement {
 // role of an element.
 func (e *CiteElement) IfRole(condition bool, s string) *CiteElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1608,7 +1542,7 @@ func (e *CiteElement) IfRole(condition bool, s string) *CiteElement {
 // role of an element.
 func (e *CiteElement) IfRolef(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1619,10 +1553,8 @@ func (e *CiteElement) IfRolef(condition bool, format string, args ...any) *CiteE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *CiteElement) RoleRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1631,10 +1563,8 @@ func (e *CiteElement) RoleRemove() *CiteElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *CiteElement) Slot(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1652,7 +1582,7 @@ func (e *CiteElement) Slotf(format string, args ...any) *CiteElement {
 // value.
 func (e *CiteElement) IfSlot(condition bool, s string) *CiteElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1663,7 +1593,7 @@ func (e *CiteElement) IfSlot(condition bool, s string) *CiteElement {
 // value.
 func (e *CiteElement) IfSlotf(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1674,10 +1604,8 @@ func (e *CiteElement) IfSlotf(condition bool, format string, args ...any) *CiteE
 // value.
 // Remove the attribute Slot from the element.
 func (e *CiteElement) SlotRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1693,10 +1621,8 @@ func (e *CiteElement) SlotRemove() *CiteElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *CiteElement) Spellcheck(c CiteSpellcheckChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[CiteSpellcheckChoice])
 	return e
 }
 
@@ -1724,10 +1650,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *CiteElement) SpellcheckRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1737,14 +1661,8 @@ func (e *CiteElement) StylePairs(pairs ...string) *CiteElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1759,14 +1677,8 @@ func (e *CiteElement) StylePairs(pairs ...string) *CiteElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *CiteElement) Style(s string) *CiteElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1774,7 +1686,7 @@ func (e *CiteElement) Style(s string) *CiteElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1783,7 +1695,7 @@ func (e *CiteElement) Style(s string) *CiteElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *CiteElement) IfStyle(condition bool, s string) *CiteElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1791,15 +1703,7 @@ func (e *CiteElement) IfStyle(condition bool, s string) *CiteElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *CiteElement) StyleAdd(k string, v string) *CiteElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1813,7 +1717,7 @@ func (e *CiteElement) StyleAddf(k string, format string, args ...any) *CiteEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *CiteElement) IfStyleAdd(condition bool, k string, v string) *CiteElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1822,7 +1726,7 @@ func (e *CiteElement) IfStyleAdd(condition bool, k string, v string) *CiteElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *CiteElement) IfStyleAddf(condition bool, k string, format string, args ...any) *CiteElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1831,21 +1735,15 @@ func (e *CiteElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *CiteElement) StyleMap(m map[string]string) *CiteElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1854,14 +1752,8 @@ func (e *CiteElement) StyleMap(m map[string]string) *CiteElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *CiteElement) StyleRemove(keys ...string) *CiteElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1879,10 +1771,8 @@ func (e *CiteElement) StyleRemove(keys ...string) *CiteElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *CiteElement) Tabindex(i int) *CiteElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1901,7 +1791,7 @@ func (e *CiteElement) Tabindex(i int) *CiteElement {
 // their relative position in the document.
 func (e *CiteElement) IfTabindex(condition bool, i int) *CiteElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1921,10 +1811,8 @@ func (e *CiteElement) IfTabindex(condition bool, i int) *CiteElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *CiteElement) TabindexRemove() *CiteElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1945,10 +1833,8 @@ func (e *CiteElement) TabindexRemove() *CiteElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *CiteElement) Title(s string) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1990,7 +1876,7 @@ func (e *CiteElement) Titlef(format string, args ...any) *CiteElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *CiteElement) IfTitle(condition bool, s string) *CiteElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2013,7 +1899,7 @@ func (e *CiteElement) IfTitle(condition bool, s string) *CiteElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *CiteElement) IfTitlef(condition bool, format string, args ...any) *CiteElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2036,10 +1922,8 @@ func (e *CiteElement) IfTitlef(condition bool, format string, args ...any) *Cite
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *CiteElement) TitleRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2048,10 +1932,8 @@ func (e *CiteElement) TitleRemove() *CiteElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *CiteElement) Translate(c CiteTranslateChoice) *CiteElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[CiteTranslateChoice])
 	return e
 }
 
@@ -2072,9 +1954,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *CiteElement) TranslateRemove() *CiteElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
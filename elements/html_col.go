@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <col> element defines a column within a table and is used for
@@ -23,18 +21,77 @@ type ColElement struct {
 // with the tag "col" during rendering.
 func Col() *ColElement {
 	e := NewElement("col")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &ColElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ColElement) Clone() *ColElement {
+	return &ColElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ColElement) CloneShallow() *ColElement {
+	return &ColElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ColElement) Freeze() *ColElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ColElement) writable() *ColElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ColElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ColElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[ColAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ColContenteditableChoice]
+	case "dir":
+		return choiceValue[ColDirChoice]
+	case "draggable":
+		return choiceValue[ColDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ColEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ColHiddenChoice]
+	case "inputmode":
+		return choiceValue[ColInputmodeChoice]
+	case "popover":
+		return choiceValue[ColPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ColSpellcheckChoice]
+	case "translate":
+		return choiceValue[ColTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ColElement) Children(children ...ElementRenderer) *ColElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ColElement) IfChildren(condition bool, children ...ElementRenderer) *ColElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *ColElement) IfChildren(condition bool, children ...ElementRenderer) *Co
 
 func (e *ColElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ColElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ColElement) BoolAttr(name string) *ColElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ColElement) BoolAttrRemove(name string) *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ColElement) IfBoolAttr(condition bool, name string) *ColElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *ColElement) BoolAttrf(format string, args ...any) *ColElement {
 
 func (e *ColElement) IfBoolAttrf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ColElement) BoolAttrs(names ...string) *ColElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ColElement) IfBoolAttrs(condition bool, names ...string) *ColElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ColElement) Attr(name, value string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ColElement) RawAttr(name, value string) *ColElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ColElement) IfAttr(condition bool, name, value string) *ColElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *ColElement) Attrf(name, format string, args ...any) *ColElement {
 
 func (e *ColElement) IfAttrf(condition bool, name, format string, args ...any) *ColElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ColElement) Attrs(attrs ...string) *ColElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ColElement) IfAttrs(condition bool, attrs ...string) *ColElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ColElement) AttrsMap(attrs map[string]string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ColElement) IfAttrsMap(condition bool, attrs map[string]string) *ColElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ColElement) Text(text string) *ColElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ColElement) Textf(format string, args ...any) *ColElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ColElement) IfText(condition bool, text string) *ColElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *ColElement) IfText(condition bool, text string) *ColElement {
 
 func (e *ColElement) IfTextf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ColElement) Raw(text string) *ColElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ColElement) Escaped(text string) *ColElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ColElement) IfEscaped(condition bool, text string) *ColElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *ColElement) Escapedf(format string, args ...any) *ColElement {
 
 func (e *ColElement) IfEscapedf(condition bool, format string, args ...any) *ColElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +271,15 @@ func (e *ColElement) IfEscapedf(condition bool, format string, args ...any) *Col
 
 // How many columns this column element spans.
 func (e *ColElement) Span(i int) *ColElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("span", i)
+	e = e.writable()
+	e.setIntAttribute("span", i)
 	return e
 }
 
 // How many columns this column element spans.
 func (e *ColElement) IfSpan(condition bool, i int) *ColElement {
 	if condition {
-		e.Span(i)
+		e = e.Span(i)
 	}
 	return e
 }
@@ -228,10 +287,8 @@ func (e *ColElement) IfSpan(condition bool, i int) *ColElement {
 // How many columns this column element spans.
 // Remove the attribute Span from the element.
 func (e *ColElement) SpanRemove() *ColElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("span")
+	e = e.writable()
+	e.removeAttribute("span")
 	return e
 }
 
@@ -240,10 +297,8 @@ func (e *ColElement) SpanRemove() *ColElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ColElement) Accesskey(r rune) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -253,7 +308,7 @@ func (e *ColElement) Accesskey(r rune) *ColElement {
 // can be generated by the keyboard).
 func (e *ColElement) IfAccesskey(condition bool, r rune) *ColElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -264,10 +319,8 @@ func (e *ColElement) IfAccesskey(condition bool, r rune) *ColElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ColElement) AccesskeyRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -283,10 +336,8 @@ func (e *ColElement) AccesskeyRemove() *ColElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ColElement) Autocapitalize(c ColAutocapitalizeChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ColAutocapitalizeChoice])
 	return e
 }
 
@@ -320,10 +371,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ColElement) AutocapitalizeRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -342,10 +391,8 @@ func (e *ColElement) AutocapitalizeRemove() *ColElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ColElement) Autofocus() *ColElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -365,7 +412,7 @@ func (e *ColElement) Autofocus() *ColElement {
 // created by the preceding content.
 func (e *ColElement) IfAutofocus(condition bool) *ColElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -386,10 +433,8 @@ func (e *ColElement) IfAutofocus(condition bool) *ColElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ColElement) AutofocusRemove() *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -409,10 +454,8 @@ func (e *ColElement) AutofocusRemove() *ColElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ColElement) AutofocusIfRemove() *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -421,16 +464,9 @@ func (e *ColElement) AutofocusIfRemove() *ColElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ColElement) Class(s string) *ColElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -440,7 +476,7 @@ func (e *ColElement) Class(s string) *ColElement {
 // document.getElementsByClassName.
 func (e *ColElement) IfClass(condition bool, s string) *ColElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -451,14 +487,8 @@ func (e *ColElement) IfClass(condition bool, s string) *ColElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ColElement) ClassRemove(s ...string) *ColElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -466,10 +496,8 @@ func (e *ColElement) ClassRemove(s ...string) *ColElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ColElement) Contenteditable(c ColContenteditableChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ColContenteditableChoice])
 	return e
 }
 
@@ -492,10 +520,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ColElement) ContenteditableRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -513,10 +539,8 @@ func (e *ColElement) ContenteditableRemove() *ColElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ColElement) Dir(c ColDirChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ColDirChoice])
 	return e
 }
 
@@ -550,10 +574,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ColElement) DirRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -561,10 +583,8 @@ func (e *ColElement) DirRemove() *ColElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ColElement) Draggable(c ColDraggableChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ColDraggableChoice])
 	return e
 }
 
@@ -590,20 +610,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ColElement) DraggableRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ColElement) Enterkeyhint(c ColEnterkeyhintChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ColEnterkeyhintChoice])
 	return e
 }
 
@@ -633,10 +649,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ColElement) EnterkeyhintRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -658,16 +672,9 @@ func (e *ColElement) EnterkeyhintRemove() *ColElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ColElement) Exportparts(s string) *ColElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -690,7 +697,7 @@ func (e *ColElement) Exportparts(s string) *ColElement {
 // the current structure.
 func (e *ColElement) IfExportparts(condition bool, s string) *ColElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -714,14 +721,8 @@ func (e *ColElement) IfExportparts(condition bool, s string) *ColElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ColElement) ExportpartsRemove(s ...string) *ColElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -738,10 +739,8 @@ func (e *ColElement) ExportpartsRemove(s ...string) *ColElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ColElement) Hidden(c ColHiddenChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ColHiddenChoice])
 	return e
 }
 
@@ -777,10 +776,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ColElement) HiddenRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -788,10 +785,8 @@ func (e *ColElement) HiddenRemove() *ColElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ColElement) ID(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -807,7 +802,7 @@ func (e *ColElement) IDf(format string, args ...any) *ColElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ColElement) IfID(condition bool, s string) *ColElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -817,7 +812,7 @@ func (e *ColElement) IfID(condition bool, s string) *ColElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ColElement) IfIDf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -827,10 +822,8 @@ func (e *ColElement) IfIDf(condition bool, format string, args ...any) *ColEleme
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ColElement) IDRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -845,10 +838,8 @@ func (e *ColElement) IDRemove() *ColElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ColElement) Inert() *ColElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -864,7 +855,7 @@ func (e *ColElement) Inert() *ColElement {
 // excluding them from the accessibility tree.
 func (e *ColElement) IfInert(condition bool) *ColElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -881,10 +872,8 @@ func (e *ColElement) IfInert(condition bool) *ColElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ColElement) InertRemove() *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -900,10 +889,8 @@ func (e *ColElement) InertRemove() *ColElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ColElement) InertIfRemove() *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -917,10 +904,8 @@ func (e *ColElement) InertIfRemove() *ColElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ColElement) Inputmode(c ColInputmodeChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ColInputmodeChoice])
 	return e
 }
 
@@ -971,10 +956,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ColElement) InputmodeRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -984,10 +967,8 @@ func (e *ColElement) InputmodeRemove() *ColElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ColElement) Is(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1007,7 +988,7 @@ func (e *ColElement) Isf(format string, args ...any) *ColElement {
 // and extends the element type it is being applied to.
 func (e *ColElement) IfIs(condition bool, s string) *ColElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1019,7 +1000,7 @@ func (e *ColElement) IfIs(condition bool, s string) *ColElement {
 // and extends the element type it is being applied to.
 func (e *ColElement) IfIsf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1031,10 +1012,8 @@ func (e *ColElement) IfIsf(condition bool, format string, args ...any) *ColEleme
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ColElement) IsRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1050,10 +1029,8 @@ func (e *ColElement) IsRemove() *ColElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ColElement) Itemid(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1085,7 +1062,7 @@ func (e *ColElement) Itemidf(format string, args ...any) *ColElement {
 // how items with the same identifier are handled.
 func (e *ColElement) IfItemid(condition bool, s string) *ColElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1103,7 +1080,7 @@ func (e *ColElement) IfItemid(condition bool, s string) *ColElement {
 // how items with the same identifier are handled.
 func (e *ColElement) IfItemidf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1121,10 +1098,8 @@ func (e *ColElement) IfItemidf(condition bool, format string, args ...any) *ColE
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ColElement) ItemidRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1136,10 +1111,8 @@ func (e *ColElement) ItemidRemove() *ColElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ColElement) Itemprop(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1163,7 +1136,7 @@ func (e *ColElement) Itempropf(format string, args ...any) *ColElement {
 // <track>, and <video>.
 func (e *ColElement) IfItemprop(condition bool, s string) *ColElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1177,7 +1150,7 @@ func (e *ColElement) IfItemprop(condition bool, s string) *ColElement {
 // <track>, and <video>.
 func (e *ColElement) IfItempropf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1191,10 +1164,8 @@ func (e *ColElement) IfItempropf(condition bool, format string, args ...any) *Co
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ColElement) ItempropRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1204,10 +1175,8 @@ func (e *ColElement) ItempropRemove() *ColElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ColElement) Itemref(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1227,7 +1196,7 @@ func (e *ColElement) Itemreff(format string, args ...any) *ColElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ColElement) IfItemref(condition bool, s string) *ColElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1239,7 +1208,7 @@ func (e *ColElement) IfItemref(condition bool, s string) *ColElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ColElement) IfItemreff(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1251,10 +1220,8 @@ func (e *ColElement) IfItemreff(condition bool, format string, args ...any) *Col
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ColElement) ItemrefRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1266,10 +1233,8 @@ func (e *ColElement) ItemrefRemove() *ColElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ColElement) Itemscope() *ColElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1282,7 +1247,7 @@ func (e *ColElement) Itemscope() *ColElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ColElement) IfItemscope(condition bool) *ColElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1296,10 +1261,8 @@ func (e *ColElement) IfItemscope(condition bool) *ColElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ColElement) ItemscopeRemove() *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1312,10 +1275,8 @@ func (e *ColElement) ItemscopeRemove() *ColElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ColElement) ItemscopeIfRemove() *ColElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1327,10 +1288,8 @@ func (e *ColElement) ItemscopeIfRemove() *ColElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ColElement) Itemtype(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1354,7 +1313,7 @@ func (e *ColElement) Itemtypef(format string, args ...any) *ColElement {
 // <video>.
 func (e *ColElement) IfItemtype(condition bool, s string) *ColElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1368,7 +1327,7 @@ func (e *ColElement) IfItemtype(condition bool, s string) *ColElement {
 // <video>.
 func (e *ColElement) IfItemtypef(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1382,10 +1341,8 @@ func (e *ColElement) IfItemtypef(condition bool, format string, args ...any) *Co
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ColElement) ItemtypeRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1395,10 +1352,8 @@ func (e *ColElement) ItemtypeRemove() *ColElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ColElement) Lang(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1418,7 +1373,7 @@ func (e *ColElement) Langf(format string, args ...any) *ColElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ColElement) IfLang(condition bool, s string) *ColElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1430,7 +1385,7 @@ func (e *ColElement) IfLang(condition bool, s string) *ColElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ColElement) IfLangf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1442,10 +1397,8 @@ func (e *ColElement) IfLangf(condition bool, format string, args ...any) *ColEle
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ColElement) LangRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1455,10 +1408,8 @@ func (e *ColElement) LangRemove() *ColElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ColElement) Nonce(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1478,7 +1429,7 @@ func (e *ColElement) Noncef(format string, args ...any) *ColElement {
 // execute.
 func (e *ColElement) IfNonce(condition bool, s string) *ColElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1490,7 +1441,7 @@ func (e *ColElement) IfNonce(condition bool, s string) *ColElement {
 // execute.
 func (e *ColElement) IfNoncef(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1502,10 +1453,8 @@ func (e *ColElement) IfNoncef(condition bool, format string, args ...any) *ColEl
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ColElement) NonceRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1513,16 +1462,9 @@ func (e *ColElement) NonceRemove() *ColElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ColElement) Part(s string) *ColElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1531,7 +1473,7 @@ func (e *ColElement) Part(s string) *ColElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ColElement) IfPart(condition bool, s string) *ColElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1541,14 +1483,8 @@ func (e *ColElement) IfPart(condition bool, s string) *ColElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ColElement) PartRemove(s ...string) *ColElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1559,10 +1495,8 @@ func (e *ColElement) PartRemove(s ...string) *ColElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ColElement) Popover(c ColPopoverChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ColPopoverChoice])
 	return e
 }
 
@@ -1590,10 +1524,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ColElement) PopoverRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1602,10 +1534,8 @@ func (e *ColElement) PopoverRemove() *ColElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ColElement) Role(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1623,7 +1553,7 @@ func (e *ColElement) Rolef(format string, args ...any) *ColElement {
 // role of an element.
 func (e *ColElement) IfRole(condition bool, s string) *ColElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1634,7 +1564,7 @@ func (e *ColElement) IfRole(condition bool, s string) *ColElement {
 // role of an element.
 func (e *ColElement) IfRolef(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1645,10 +1575,8 @@ func (e *ColElement) IfRolef(condition bool, format string, args ...any) *ColEle
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ColElement) RoleRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1657,10 +1585,8 @@ func (e *ColElement) RoleRemove() *ColElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ColElement) Slot(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1678,7 +1604,7 @@ func (e *ColElement) Slotf(format string, args ...any) *ColElement {
 // value.
 func (e *ColElement) IfSlot(condition bool, s string) *ColElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1689,7 +1615,7 @@ func (e *ColElement) IfSlot(condition bool, s string) *ColElement {
 // value.
 func (e *ColElement) IfSlotf(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1700,10 +1626,8 @@ func (e *ColElement) IfSlotf(condition bool, format string, args ...any) *ColEle
 // value.
 // Remove the attribute Slot from the element.
 func (e *ColElement) SlotRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1719,10 +1643,8 @@ func (e *ColElement) SlotRemove() *ColElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ColElement) Spellcheck(c ColSpellcheckChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ColSpellcheckChoice])
 	return e
 }
 
@@ -1750,10 +1672,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ColElement) SpellcheckRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1763,14 +1683,8 @@ func (e *ColElement) StylePairs(pairs ...string) *ColElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1785,14 +1699,8 @@ func (e *ColElement) StylePairs(pairs ...string) *ColElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ColElement) Style(s string) *ColElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1800,7 +1708,7 @@ func (e *ColElement) Style(s string) *ColElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1809,7 +1717,7 @@ func (e *ColElement) Style(s string) *ColElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ColElement) IfStyle(condition bool, s string) *ColElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1817,15 +1725,7 @@ func (e *ColElement) IfStyle(condition bool, s string) *ColElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ColElement) StyleAdd(k string, v string) *ColElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1839,7 +1739,7 @@ func (e *ColElement) StyleAddf(k string, format string, args ...any) *ColElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *ColElement) IfStyleAdd(condition bool, k string, v string) *ColElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1848,7 +1748,7 @@ func (e *ColElement) IfStyleAdd(condition bool, k string, v string) *ColElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *ColElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ColElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1857,21 +1757,15 @@ func (e *ColElement) IfStyleAddf(condition bool, k string, format string, args .
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ColElement) StyleMap(m map[string]string) *ColElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1880,14 +1774,8 @@ func (e *ColElement) StyleMap(m map[string]string) *ColElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ColElement) StyleRemove(keys ...string) *ColElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1905,10 +1793,8 @@ func (e *ColElement) StyleRemove(keys ...string) *ColElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ColElement) Tabindex(i int) *ColElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1927,7 +1813,7 @@ func (e *ColElement) Tabindex(i int) *ColElement {
 // their relative position in the document.
 func (e *ColElement) IfTabindex(condition bool, i int) *ColElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1947,10 +1833,8 @@ func (e *ColElement) IfTabindex(condition bool, i int) *ColElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ColElement) TabindexRemove() *ColElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1971,10 +1855,8 @@ func (e *ColElement) TabindexRemove() *ColElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ColElement) Title(s string) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2016,7 +1898,7 @@ func (e *ColElement) Titlef(format string, args ...any) *ColElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ColElement) IfTitle(condition bool, s string) *ColElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2039,7 +1921,7 @@ func (e *ColElement) IfTitle(condition bool, s string) *ColElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ColElement) IfTitlef(condition bool, format string, args ...any) *ColElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2062,10 +1944,8 @@ func (e *ColElement) IfTitlef(condition bool, format string, args ...any) *ColEl
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ColElement) TitleRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2074,10 +1954,8 @@ func (e *ColElement) TitleRemove() *ColElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ColElement) Translate(c ColTranslateChoice) *ColElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ColTranslateChoice])
 	return e
 }
 
@@ -2098,9 +1976,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ColElement) TranslateRemove() *ColElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
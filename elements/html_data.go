@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <data> element links a given content with a machine-readable
@@ -23,18 +21,77 @@ type DataElement struct {
 // with the tag "data" during rendering.
 func Data(children ...ElementRenderer) *DataElement {
 	e := NewElement("data", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &DataElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *DataElement) Clone() *DataElement {
+	return &DataElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *DataElement) CloneShallow() *DataElement {
+	return &DataElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *DataElement) Freeze() *DataElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *DataElement) writable() *DataElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *DataElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *DataElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[DataAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[DataContenteditableChoice]
+	case "dir":
+		return choiceValue[DataDirChoice]
+	case "draggable":
+		return choiceValue[DataDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[DataEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[DataHiddenChoice]
+	case "inputmode":
+		return choiceValue[DataInputmodeChoice]
+	case "popover":
+		return choiceValue[DataPopoverChoice]
+	case "spellcheck":
+		return choiceValue[DataSpellcheckChoice]
+	case "translate":
+		return choiceValue[DataTranslateChoice]
+	}
+	return nil
+}
+
 func (e *DataElement) Children(children ...ElementRenderer) *DataElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *DataElement) IfChildren(condition bool, children ...ElementRenderer) *DataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *DataElement) IfChildren(condition bool, children ...ElementRenderer) *D
 
 func (e *DataElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *DataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *DataElement) BoolAttr(name string) *DataElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *DataElement) BoolAttrRemove(name string) *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *DataElement) IfBoolAttr(condition bool, name string) *DataElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *DataElement) BoolAttrf(format string, args ...any) *DataElement {
 
 func (e *DataElement) IfBoolAttrf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *DataElement) BoolAttrs(names ...string) *DataElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *DataElement) IfBoolAttrs(condition bool, names ...string) *DataElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *DataElement) Attr(name, value string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *DataElement) RawAttr(name, value string) *DataElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *DataElement) IfAttr(condition bool, name, value string) *DataElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *DataElement) Attrf(name, format string, args ...any) *DataElement {
 
 func (e *DataElement) IfAttrf(condition bool, name, format string, args ...any) *DataElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *DataElement) Attrs(attrs ...string) *DataElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *DataElement) IfAttrs(condition bool, attrs ...string) *DataElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *DataElement) AttrsMap(attrs map[string]string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *DataElement) IfAttrsMap(condition bool, attrs map[string]string) *DataElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *DataElement) Text(text string) *DataElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *DataElement) Textf(format string, args ...any) *DataElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *DataElement) IfText(condition bool, text string) *DataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *DataElement) IfText(condition bool, text string) *DataElement {
 
 func (e *DataElement) IfTextf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *DataElement) Raw(text string) *DataElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *DataElement) Escaped(text string) *DataElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *DataElement) IfEscaped(condition bool, text string) *DataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *DataElement) Escapedf(format string, args ...any) *DataElement {
 
 func (e *DataElement) IfEscapedf(condition bool, format string, args ...any) *DataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +271,8 @@ func (e *DataElement) IfEscapedf(condition bool, format string, args ...any) *Da
 
 // The machine-readable translation of the content of the element.
 func (e *DataElement) Value(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("value", s)
+	e = e.writable()
+	e.setStringAttribute("value", s)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *DataElement) Valuef(format string, args ...any) *DataElement {
 // The machine-readable translation of the content of the element.
 func (e *DataElement) IfValue(condition bool, s string) *DataElement {
 	if condition {
-		e.Value(s)
+		e = e.Value(s)
 	}
 	return e
 }
@@ -233,7 +292,7 @@ func (e *DataElement) IfValue(condition bool, s string) *DataElement {
 // The machine-readable translation of the content of the element.
 func (e *DataElement) IfValuef(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Value(fmt.Sprintf(format, args...))
+		e = e.Value(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,10 +300,8 @@ func (e *DataElement) IfValuef(condition bool, format string, args ...any) *Data
 // The machine-readable translation of the content of the element.
 // Remove the attribute Value from the element.
 func (e *DataElement) ValueRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("value")
+	e = e.writable()
+	e.removeAttribute("value")
 	return e
 }
 
@@ -253,10 +310,8 @@ func (e *DataElement) ValueRemove() *DataElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *DataElement) Accesskey(r rune) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -266,7 +321,7 @@ func (e *DataElement) Accesskey(r rune) *DataElement {
 // can be generated by the keyboard).
 func (e *DataElement) IfAccesskey(condition bool, r rune) *DataElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -277,10 +332,8 @@ func (e *DataElement) IfAccesskey(condition bool, r rune) *DataElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *DataElement) AccesskeyRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -296,10 +349,8 @@ func (e *DataElement) AccesskeyRemove() *DataElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *DataElement) Autocapitalize(c DataAutocapitalizeChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[DataAutocapitalizeChoice])
 	return e
 }
 
@@ -333,10 +384,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *DataElement) AutocapitalizeRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -355,10 +404,8 @@ func (e *DataElement) AutocapitalizeRemove() *DataElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DataElement) Autofocus() *DataElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -378,7 +425,7 @@ func (e *DataElement) Autofocus() *DataElement {
 // created by the preceding content.
 func (e *DataElement) IfAutofocus(condition bool) *DataElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -399,10 +446,8 @@ func (e *DataElement) IfAutofocus(condition bool) *DataElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DataElement) AutofocusRemove() *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -422,10 +467,8 @@ func (e *DataElement) AutofocusRemove() *DataElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DataElement) AutofocusIfRemove() *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -434,16 +477,9 @@ func (e *DataElement) AutofocusIfRemove() *DataElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *DataElement) Class(s string) *DataElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -453,7 +489,7 @@ func (e *DataElement) Class(s string) *DataElement {
 // document.getElementsByClassName.
 func (e *DataElement) IfClass(condition bool, s string) *DataElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -464,14 +500,8 @@ func (e *DataElement) IfClass(condition bool, s string) *DataElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *DataElement) ClassRemove(s ...string) *DataElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -479,10 +509,8 @@ func (e *DataElement) ClassRemove(s ...string) *DataElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *DataElement) Contenteditable(c DataContenteditableChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[DataContenteditableChoice])
 	return e
 }
 
@@ -505,10 +533,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *DataElement) ContenteditableRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -526,10 +552,8 @@ func (e *DataElement) ContenteditableRemove() *DataElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *DataElement) Dir(c DataDirChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[DataDirChoice])
 	return e
 }
 
@@ -563,10 +587,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *DataElement) DirRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -574,10 +596,8 @@ func (e *DataElement) DirRemove() *DataElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *DataElement) Draggable(c DataDraggableChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[DataDraggableChoice])
 	return e
 }
 
@@ -603,20 +623,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *DataElement) DraggableRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *DataElement) Enterkeyhint(c DataEnterkeyhintChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[DataEnterkeyhintChoice])
 	return e
 }
 
@@ -646,10 +662,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *DataElement) EnterkeyhintRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -671,16 +685,9 @@ func (e *DataElement) EnterkeyhintRemove() *DataElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *DataElement) Exportparts(s string) *DataElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -703,7 +710,7 @@ func (e *DataElement) Exportparts(s string) *DataElement {
 // the current structure.
 func (e *DataElement) IfExportparts(condition bool, s string) *DataElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -727,14 +734,8 @@ func (e *DataElement) IfExportparts(condition bool, s string) *DataElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *DataElement) ExportpartsRemove(s ...string) *DataElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -751,10 +752,8 @@ func (e *DataElement) ExportpartsRemove(s ...string) *DataElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *DataElement) Hidden(c DataHiddenChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[DataHiddenChoice])
 	return e
 }
 
@@ -790,10 +789,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *DataElement) HiddenRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -801,10 +798,8 @@ func (e *DataElement) HiddenRemove() *DataElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DataElement) ID(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -820,7 +815,7 @@ func (e *DataElement) IDf(format string, args ...any) *DataElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DataElement) IfID(condition bool, s string) *DataElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -830,7 +825,7 @@ func (e *DataElement) IfID(condition bool, s string) *DataElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DataElement) IfIDf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -840,10 +835,8 @@ func (e *DataElement) IfIDf(condition bool, format string, args ...any) *DataEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *DataElement) IDRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -858,10 +851,8 @@ func (e *DataElement) IDRemove() *DataElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DataElement) Inert() *DataElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -877,7 +868,7 @@ func (e *DataElement) Inert() *DataElement {
 // excluding them from the accessibility tree.
 func (e *DataElement) IfInert(condition bool) *DataElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -894,10 +885,8 @@ func (e *DataElement) IfInert(condition bool) *DataElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DataElement) InertRemove() *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -913,10 +902,8 @@ func (e *DataElement) InertRemove() *DataElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DataElement) InertIfRemove() *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -930,10 +917,8 @@ func (e *DataElement) InertIfRemove() *DataElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *DataElement) Inputmode(c DataInputmodeChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[DataInputmodeChoice])
 	return e
 }
 
@@ -984,10 +969,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *DataElement) InputmodeRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -997,10 +980,8 @@ func (e *DataElement) InputmodeRemove() *DataElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *DataElement) Is(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1020,7 +1001,7 @@ func (e *DataElement) Isf(format string, args ...any) *DataElement {
 // and extends the element type it is being applied to.
 func (e *DataElement) IfIs(condition bool, s string) *DataElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1032,7 +1013,7 @@ func (e *DataElement) IfIs(condition bool, s string) *DataElement {
 // and extends the element type it is being applied to.
 func (e *DataElement) IfIsf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1044,10 +1025,8 @@ func (e *DataElement) IfIsf(condition bool, format string, args ...any) *DataEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *DataElement) IsRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1063,10 +1042,8 @@ func (e *DataElement) IsRemove() *DataElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *DataElement) Itemid(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1098,7 +1075,7 @@ func (e *DataElement) Itemidf(format string, args ...any) *DataElement {
 // how items with the same identifier are handled.
 func (e *DataElement) IfItemid(condition bool, s string) *DataElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1116,7 +1093,7 @@ func (e *DataElement) IfItemid(condition bool, s string) *DataElement {
 // how items with the same identifier are handled.
 func (e *DataElement) IfItemidf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1134,10 +1111,8 @@ func (e *DataElement) IfItemidf(condition bool, format string, args ...any) *Dat
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *DataElement) ItemidRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1149,10 +1124,8 @@ func (e *DataElement) ItemidRemove() *DataElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *DataElement) Itemprop(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1176,7 +1149,7 @@ func (e *DataElement) Itempropf(format string, args ...any) *DataElement {
 // <track>, and <video>.
 func (e *DataElement) IfItemprop(condition bool, s string) *DataElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1190,7 +1163,7 @@ func (e *DataElement) IfItemprop(condition bool, s string) *DataElement {
 // <track>, and <video>.
 func (e *DataElement) IfItempropf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1204,10 +1177,8 @@ func (e *DataElement) IfItempropf(condition bool, format string, args ...any) *D
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *DataElement) ItempropRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1217,10 +1188,8 @@ func (e *DataElement) ItempropRemove() *DataElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *DataElement) Itemref(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1240,7 +1209,7 @@ func (e *DataElement) Itemreff(format string, args ...any) *DataElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DataElement) IfItemref(condition bool, s string) *DataElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1252,7 +1221,7 @@ func (e *DataElement) IfItemref(condition bool, s string) *DataElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DataElement) IfItemreff(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1264,10 +1233,8 @@ func (e *DataElement) IfItemreff(condition bool, format string, args ...any) *Da
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *DataElement) ItemrefRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1279,10 +1246,8 @@ func (e *DataElement) ItemrefRemove() *DataElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DataElement) Itemscope() *DataElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1295,7 +1260,7 @@ func (e *DataElement) Itemscope() *DataElement {
 // <object>, <source>, <track>, and <video>.
 func (e *DataElement) IfItemscope(condition bool) *DataElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1309,10 +1274,8 @@ func (e *DataElement) IfItemscope(condition bool) *DataElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DataElement) ItemscopeRemove() *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1325,10 +1288,8 @@ func (e *DataElement) ItemscopeRemove() *DataElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DataElement) ItemscopeIfRemove() *DataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1340,10 +1301,8 @@ func (e *DataElement) ItemscopeIfRemove() *DataElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *DataElement) Itemtype(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1367,7 +1326,7 @@ func (e *DataElement) Itemtypef(format string, args ...any) *DataElement {
 // <video>.
 func (e *DataElement) IfItemtype(condition bool, s string) *DataElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1381,7 +1340,7 @@ func (e *DataElement) IfItemtype(condition bool, s string) *DataElement {
 // <video>.
 func (e *DataElement) IfItemtypef(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1395,10 +1354,8 @@ func (e *DataElement) IfItemtypef(condition bool, format string, args ...any) *D
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *DataElement) ItemtypeRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1408,10 +1365,8 @@ func (e *DataElement) ItemtypeRemove() *DataElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DataElement) Lang(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1431,7 +1386,7 @@ func (e *DataElement) Langf(format string, args ...any) *DataElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DataElement) IfLang(condition bool, s string) *DataElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1443,7 +1398,7 @@ func (e *DataElement) IfLang(condition bool, s string) *DataElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DataElement) IfLangf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1455,10 +1410,8 @@ func (e *DataElement) IfLangf(condition bool, format string, args ...any) *DataE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *DataElement) LangRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1468,10 +1421,8 @@ func (e *DataElement) LangRemove() *DataElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *DataElement) Nonce(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1491,7 +1442,7 @@ func (e *DataElement) Noncef(format string, args ...any) *DataElement {
 // execute.
 func (e *DataElement) IfNonce(condition bool, s string) *DataElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1503,7 +1454,7 @@ func (e *DataElement) IfNonce(condition bool, s string) *DataElement {
 // execute.
 func (e *DataElement) IfNoncef(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1515,10 +1466,8 @@ func (e *DataElement) IfNoncef(condition bool, format string, args ...any) *Data
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *DataElement) NonceRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1526,16 +1475,9 @@ func (e *DataElement) NonceRemove() *DataElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *DataElement) Part(s string) *DataElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1544,7 +1486,7 @@ func (e *DataElement) Part(s string) *DataElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *DataElement) IfPart(condition bool, s string) *DataElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1554,14 +1496,8 @@ func (e *DataElement) IfPart(condition bool, s string) *DataElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *DataElement) PartRemove(s ...string) *DataElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1572,10 +1508,8 @@ func (e *DataElement) PartRemove(s ...string) *DataElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *DataElement) Popover(c DataPopoverChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[DataPopoverChoice])
 	return e
 }
 
@@ -1603,10 +1537,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *DataElement) PopoverRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1615,10 +1547,8 @@ func (e *DataElement) PopoverRemove() *DataElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *DataElement) Role(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1636,7 +1566,7 @@ func (e *DataElement) Rolef(format string, args ...any) *DataElement {
 // role of an element.
 func (e *DataElement) IfRole(condition bool, s string) *DataElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1647,7 +1577,7 @@ func (e *DataElement) IfRole(condition bool, s string) *DataElement {
 // role of an element.
 func (e *DataElement) IfRolef(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1658,10 +1588,8 @@ func (e *DataElement) IfRolef(condition bool, format string, args ...any) *DataE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *DataElement) RoleRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1670,10 +1598,8 @@ func (e *DataElement) RoleRemove() *DataElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *DataElement) Slot(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1691,7 +1617,7 @@ func (e *DataElement) Slotf(format string, args ...any) *DataElement {
 // value.
 func (e *DataElement) IfSlot(condition bool, s string) *DataElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1702,7 +1628,7 @@ func (e *DataElement) IfSlot(condition bool, s string) *DataElement {
 // value.
 func (e *DataElement) IfSlotf(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1713,10 +1639,8 @@ func (e *DataElement) IfSlotf(condition bool, format string, args ...any) *DataE
 // value.
 // Remove the attribute Slot from the element.
 func (e *DataElement) SlotRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1732,10 +1656,8 @@ func (e *DataElement) SlotRemove() *DataElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *DataElement) Spellcheck(c DataSpellcheckChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[DataSpellcheckChoice])
 	return e
 }
 
@@ -1763,10 +1685,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *DataElement) SpellcheckRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1776,14 +1696,8 @@ func (e *DataElement) StylePairs(pairs ...string) *DataElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1798,14 +1712,8 @@ func (e *DataElement) StylePairs(pairs ...string) *DataElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DataElement) Style(s string) *DataElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1813,7 +1721,7 @@ func (e *DataElement) Style(s string) *DataElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1822,7 +1730,7 @@ func (e *DataElement) Style(s string) *DataElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *DataElement) IfStyle(condition bool, s string) *DataElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1830,15 +1738,7 @@ func (e *DataElement) IfStyle(condition bool, s string) *DataElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DataElement) StyleAdd(k string, v string) *DataElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1852,7 +1752,7 @@ func (e *DataElement) StyleAddf(k string, format string, args ...any) *DataEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *DataElement) IfStyleAdd(condition bool, k string, v string) *DataElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1861,7 +1761,7 @@ func (e *DataElement) IfStyleAdd(condition bool, k string, v string) *DataElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *DataElement) IfStyleAddf(condition bool, k string, format string, args ...any) *DataElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1870,21 +1770,15 @@ func (e *DataElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *DataElement) StyleMap(m map[string]string) *DataElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1893,14 +1787,8 @@ func (e *DataElement) StyleMap(m map[string]string) *DataElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *DataElement) StyleRemove(keys ...string) *DataElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1918,10 +1806,8 @@ func (e *DataElement) StyleRemove(keys ...string) *DataElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *DataElement) Tabindex(i int) *DataElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1940,7 +1826,7 @@ func (e *DataElement) Tabindex(i int) *DataElement {
 // their relative position in the document.
 func (e *DataElement) IfTabindex(condition bool, i int) *DataElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1960,10 +1846,8 @@ func (e *DataElement) IfTabindex(condition bool, i int) *DataElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *DataElement) TabindexRemove() *DataElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1984,10 +1868,8 @@ func (e *DataElement) TabindexRemove() *DataElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *DataElement) Title(s string) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2029,7 +1911,7 @@ func (e *DataElement) Titlef(format string, args ...any) *DataElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DataElement) IfTitle(condition bool, s string) *DataElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2052,7 +1934,7 @@ func (e *DataElement) IfTitle(condition bool, s string) *DataElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DataElement) IfTitlef(condition bool, format string, args ...any) *DataElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2075,10 +1957,8 @@ func (e *DataElement) IfTitlef(condition bool, format string, args ...any) *Data
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *DataElement) TitleRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2087,10 +1967,8 @@ func (e *DataElement) TitleRemove() *DataElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *DataElement) Translate(c DataTranslateChoice) *DataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[DataTranslateChoice])
 	return e
 }
 
@@ -2111,9 +1989,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *DataElement) TranslateRemove() *DataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
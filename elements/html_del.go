@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <del> element represents a range of text that has been deleted from
@@ -24,18 +22,77 @@ type DelElement struct {
 // with the tag "del" during rendering.
 func Del(children ...ElementRenderer) *DelElement {
 	e := NewElement("del", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &DelElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *DelElement) Clone() *DelElement {
+	return &DelElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *DelElement) CloneShallow() *DelElement {
+	return &DelElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *DelElement) Freeze() *DelElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *DelElement) writable() *DelElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *DelElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *DelElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[DelAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[DelContenteditableChoice]
+	case "dir":
+		return choiceValue[DelDirChoice]
+	case "draggable":
+		return choiceValue[DelDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[DelEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[DelHiddenChoice]
+	case "inputmode":
+		return choiceValue[DelInputmodeChoice]
+	case "popover":
+		return choiceValue[DelPopoverChoice]
+	case "spellcheck":
+		return choiceValue[DelSpellcheckChoice]
+	case "translate":
+		return choiceValue[DelTranslateChoice]
+	}
+	return nil
+}
+
 func (e *DelElement) Children(children ...ElementRenderer) *DelElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *DelElement) IfChildren(condition bool, children ...ElementRenderer) *DelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +100,30 @@ func (e *DelElement) IfChildren(condition bool, children ...ElementRenderer) *De
 
 func (e *DelElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *DelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *DelElement) BoolAttr(name string) *DelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *DelElement) BoolAttrRemove(name string) *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *DelElement) IfBoolAttr(condition bool, name string) *DelElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +134,40 @@ func (e *DelElement) BoolAttrf(format string, args ...any) *DelElement {
 
 func (e *DelElement) IfBoolAttrf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *DelElement) BoolAttrs(names ...string) *DelElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *DelElement) IfBoolAttrs(condition bool, names ...string) *DelElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *DelElement) Attr(name, value string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *DelElement) RawAttr(name, value string) *DelElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *DelElement) IfAttr(condition bool, name, value string) *DelElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +178,52 @@ func (e *DelElement) Attrf(name, format string, args ...any) *DelElement {
 
 func (e *DelElement) IfAttrf(condition bool, name, format string, args ...any) *DelElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *DelElement) Attrs(attrs ...string) *DelElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *DelElement) IfAttrs(condition bool, attrs ...string) *DelElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *DelElement) AttrsMap(attrs map[string]string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *DelElement) IfAttrsMap(condition bool, attrs map[string]string) *DelElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *DelElement) Text(text string) *DelElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *DelElement) Textf(format string, args ...any) *DelElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *DelElement) IfText(condition bool, text string) *DelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +231,28 @@ func (e *DelElement) IfText(condition bool, text string) *DelElement {
 
 func (e *DelElement) IfTextf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *DelElement) Raw(text string) *DelElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *DelElement) Escaped(text string) *DelElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *DelElement) IfEscaped(condition bool, text string) *DelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +264,7 @@ func (e *DelElement) Escapedf(format string, args ...any) *DelElement {
 
 func (e *DelElement) IfEscapedf(condition bool, format string, args ...any) *DelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +272,8 @@ func (e *DelElement) IfEscapedf(condition bool, format string, args ...any) *Del
 
 // Contains a URI which points to the source of the quote or change.
 func (e *DelElement) Cite(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("cite", s)
+	e = e.writable()
+	e.setStringAttribute("cite", s)
 	return e
 }
 
@@ -226,7 +285,7 @@ func (e *DelElement) Citef(format string, args ...any) *DelElement {
 // Contains a URI which points to the source of the quote or change.
 func (e *DelElement) IfCite(condition bool, s string) *DelElement {
 	if condition {
-		e.Cite(s)
+		e = e.Cite(s)
 	}
 	return e
 }
@@ -234,7 +293,7 @@ func (e *DelElement) IfCite(condition bool, s string) *DelElement {
 // Contains a URI which points to the source of the quote or change.
 func (e *DelElement) IfCitef(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Cite(fmt.Sprintf(format, args...))
+		e = e.Cite(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -242,19 +301,15 @@ func (e *DelElement) IfCitef(condition bool, format string, args ...any) *DelEle
 // Contains a URI which points to the source of the quote or change.
 // Remove the attribute Cite from the element.
 func (e *DelElement) CiteRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("cite")
+	e = e.writable()
+	e.removeAttribute("cite")
 	return e
 }
 
 // Indicates the date and time associated with the element.
 func (e *DelElement) Datetime(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("datetime", s)
+	e = e.writable()
+	e.setStringAttribute("datetime", s)
 	return e
 }
 
@@ -266,7 +321,7 @@ func (e *DelElement) Datetimef(format string, args ...any) *DelElement {
 // Indicates the date and time associated with the element.
 func (e *DelElement) IfDatetime(condition bool, s string) *DelElement {
 	if condition {
-		e.Datetime(s)
+		e = e.Datetime(s)
 	}
 	return e
 }
@@ -274,7 +329,7 @@ func (e *DelElement) IfDatetime(condition bool, s string) *DelElement {
 // Indicates the date and time associated with the element.
 func (e *DelElement) IfDatetimef(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Datetime(fmt.Sprintf(format, args...))
+		e = e.Datetime(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -282,10 +337,8 @@ func (e *DelElement) IfDatetimef(condition bool, format string, args ...any) *De
 // Indicates the date and time associated with the element.
 // Remove the attribute Datetime from the element.
 func (e *DelElement) DatetimeRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("datetime")
+	e = e.writable()
+	e.removeAttribute("datetime")
 	return e
 }
 
@@ -294,10 +347,8 @@ func (e *DelElement) DatetimeRemove() *DelElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *DelElement) Accesskey(r rune) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -307,7 +358,7 @@ func (e *DelElement) Accesskey(r rune) *DelElement {
 // can be generated by the keyboard).
 func (e *DelElement) IfAccesskey(condition bool, r rune) *DelElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -318,10 +369,8 @@ func (e *DelElement) IfAccesskey(condition bool, r rune) *DelElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *DelElement) AccesskeyRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -337,10 +386,8 @@ func (e *DelElement) AccesskeyRemove() *DelElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *DelElement) Autocapitalize(c DelAutocapitalizeChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[DelAutocapitalizeChoice])
 	return e
 }
 
@@ -374,10 +421,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *DelElement) AutocapitalizeRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -396,10 +441,8 @@ func (e *DelElement) AutocapitalizeRemove() *DelElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DelElement) Autofocus() *DelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -419,7 +462,7 @@ func (e *DelElement) Autofocus() *DelElement {
 // created by the preceding content.
 func (e *DelElement) IfAutofocus(condition bool) *DelElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -440,10 +483,8 @@ func (e *DelElement) IfAutofocus(condition bool) *DelElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DelElement) AutofocusRemove() *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -463,10 +504,8 @@ func (e *DelElement) AutofocusRemove() *DelElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DelElement) AutofocusIfRemove() *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -475,16 +514,9 @@ func (e *DelElement) AutofocusIfRemove() *DelElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *DelElement) Class(s string) *DelElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -494,7 +526,7 @@ func (e *DelElement) Class(s string) *DelElement {
 // document.getElementsByClassName.
 func (e *DelElement) IfClass(condition bool, s string) *DelElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -505,14 +537,8 @@ func (e *DelElement) IfClass(condition bool, s string) *DelElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *DelElement) ClassRemove(s ...string) *DelElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -520,10 +546,8 @@ func (e *DelElement) ClassRemove(s ...string) *DelElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *DelElement) Contenteditable(c DelContenteditableChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[DelContenteditableChoice])
 	return e
 }
 
@@ -546,10 +570,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *DelElement) ContenteditableRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -567,10 +589,8 @@ func (e *DelElement) ContenteditableRemove() *DelElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *DelElement) Dir(c DelDirChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[DelDirChoice])
 	return e
 }
 
@@ -604,10 +624,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *DelElement) DirRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -615,10 +633,8 @@ func (e *DelElement) DirRemove() *DelElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *DelElement) Draggable(c DelDraggableChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[DelDraggableChoice])
 	return e
 }
 
@@ -644,20 +660,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *DelElement) DraggableRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *DelElement) Enterkeyhint(c DelEnterkeyhintChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[DelEnterkeyhintChoice])
 	return e
 }
 
@@ -687,10 +699,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *DelElement) EnterkeyhintRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -712,16 +722,9 @@ func (e *DelElement) EnterkeyhintRemove() *DelElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *DelElement) Exportparts(s string) *DelElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -744,7 +747,7 @@ func (e *DelElement) Exportparts(s string) *DelElement {
 // the current structure.
 func (e *DelElement) IfExportparts(condition bool, s string) *DelElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -768,14 +771,8 @@ func (e *DelElement) IfExportparts(condition bool, s string) *DelElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *DelElement) ExportpartsRemove(s ...string) *DelElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -792,10 +789,8 @@ func (e *DelElement) ExportpartsRemove(s ...string) *DelElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *DelElement) Hidden(c DelHiddenChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[DelHiddenChoice])
 	return e
 }
 
@@ -831,10 +826,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *DelElement) HiddenRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -842,10 +835,8 @@ func (e *DelElement) HiddenRemove() *DelElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DelElement) ID(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -861,7 +852,7 @@ func (e *DelElement) IDf(format string, args ...any) *DelElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DelElement) IfID(condition bool, s string) *DelElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -871,7 +862,7 @@ func (e *DelElement) IfID(condition bool, s string) *DelElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DelElement) IfIDf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -881,10 +872,8 @@ func (e *DelElement) IfIDf(condition bool, format string, args ...any) *DelEleme
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *DelElement) IDRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -899,10 +888,8 @@ func (e *DelElement) IDRemove() *DelElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DelElement) Inert() *DelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -918,7 +905,7 @@ func (e *DelElement) Inert() *DelElement {
 // excluding them from the accessibility tree.
 func (e *DelElement) IfInert(condition bool) *DelElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -935,10 +922,8 @@ func (e *DelElement) IfInert(condition bool) *DelElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DelElement) InertRemove() *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -954,10 +939,8 @@ func (e *DelElement) InertRemove() *DelElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DelElement) InertIfRemove() *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -971,10 +954,8 @@ func (e *DelElement) InertIfRemove() *DelElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *DelElement) Inputmode(c DelInputmodeChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[DelInputmodeChoice])
 	return e
 }
 
@@ -1025,10 +1006,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *DelElement) InputmodeRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1038,10 +1017,8 @@ func (e *DelElement) InputmodeRemove() *DelElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *DelElement) Is(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1061,7 +1038,7 @@ func (e *DelElement) Isf(format string, args ...any) *DelElement {
 // and extends the element type it is being applied to.
 func (e *DelElement) IfIs(condition bool, s string) *DelElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1073,7 +1050,7 @@ func (e *DelElement) IfIs(condition bool, s string) *DelElement {
 // and extends the element type it is being applied to.
 func (e *DelElement) IfIsf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1085,10 +1062,8 @@ func (e *DelElement) IfIsf(condition bool, format string, args ...any) *DelEleme
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *DelElement) IsRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1104,10 +1079,8 @@ func (e *DelElement) IsRemove() *DelElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *DelElement) Itemid(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1139,7 +1112,7 @@ func (e *DelElement) Itemidf(format string, args ...any) *DelElement {
 // how items with the same identifier are handled.
 func (e *DelElement) IfItemid(condition bool, s string) *DelElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1157,7 +1130,7 @@ func (e *DelElement) IfItemid(condition bool, s string) *DelElement {
 // how items with the same identifier are handled.
 func (e *DelElement) IfItemidf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1175,10 +1148,8 @@ func (e *DelElement) IfItemidf(condition bool, format string, args ...any) *DelE
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *DelElement) ItemidRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1190,10 +1161,8 @@ func (e *DelElement) ItemidRemove() *DelElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *DelElement) Itemprop(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1217,7 +1186,7 @@ func (e *DelElement) Itempropf(format string, args ...any) *DelElement {
 // <track>, and <video>.
 func (e *DelElement) IfItemprop(condition bool, s string) *DelElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1231,7 +1200,7 @@ func (e *DelElement) IfItemprop(condition bool, s string) *DelElement {
 // <track>, and <video>.
 func (e *DelElement) IfItempropf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1245,10 +1214,8 @@ func (e *DelElement) IfItempropf(condition bool, format string, args ...any) *De
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *DelElement) ItempropRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1258,10 +1225,8 @@ func (e *DelElement) ItempropRemove() *DelElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *DelElement) Itemref(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1281,7 +1246,7 @@ func (e *DelElement) Itemreff(format string, args ...any) *DelElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DelElement) IfItemref(condition bool, s string) *DelElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1293,7 +1258,7 @@ func (e *DelElement) IfItemref(condition bool, s string) *DelElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DelElement) IfItemreff(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1305,10 +1270,8 @@ func (e *DelElement) IfItemreff(condition bool, format string, args ...any) *Del
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *DelElement) ItemrefRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1320,10 +1283,8 @@ func (e *DelElement) ItemrefRemove() *DelElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DelElement) Itemscope() *DelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1336,7 +1297,7 @@ func (e *DelElement) Itemscope() *DelElement {
 // <object>, <source>, <track>, and <video>.
 func (e *DelElement) IfItemscope(condition bool) *DelElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1350,10 +1311,8 @@ func (e *DelElement) IfItemscope(condition bool) *DelElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DelElement) ItemscopeRemove() *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1366,10 +1325,8 @@ func (e *DelElement) ItemscopeRemove() *DelElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DelElement) ItemscopeIfRemove() *DelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1381,10 +1338,8 @@ func (e *DelElement) ItemscopeIfRemove() *DelElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *DelElement) Itemtype(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1408,7 +1363,7 @@ func (e *DelElement) Itemtypef(format string, args ...any) *DelElement {
 // <video>.
 func (e *DelElement) IfItemtype(condition bool, s string) *DelElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1422,7 +1377,7 @@ func (e *DelElement) IfItemtype(condition bool, s string) *DelElement {
 // <video>.
 func (e *DelElement) IfItemtypef(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1436,10 +1391,8 @@ func (e *DelElement) IfItemtypef(condition bool, format string, args ...any) *De
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *DelElement) ItemtypeRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1449,10 +1402,8 @@ func (e *DelElement) ItemtypeRemove() *DelElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DelElement) Lang(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1472,7 +1423,7 @@ func (e *DelElement) Langf(format string, args ...any) *DelElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DelElement) IfLang(condition bool, s string) *DelElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1484,7 +1435,7 @@ func (e *DelElement) IfLang(condition bool, s string) *DelElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DelElement) IfLangf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1496,10 +1447,8 @@ func (e *DelElement) IfLangf(condition bool, format string, args ...any) *DelEle
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *DelElement) LangRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1509,10 +1458,8 @@ func (e *DelElement) LangRemove() *DelElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *DelElement) Nonce(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1532,7 +1479,7 @@ func (e *DelElement) Noncef(format string, args ...any) *DelElement {
 // execute.
 func (e *DelElement) IfNonce(condition bool, s string) *DelElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1544,7 +1491,7 @@ func (e *DelElement) IfNonce(condition bool, s string) *DelElement {
 // execute.
 func (e *DelElement) IfNoncef(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1556,10 +1503,8 @@ func (e *DelElement) IfNoncef(condition bool, format string, args ...any) *DelEl
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *DelElement) NonceRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1567,16 +1512,9 @@ func (e *DelElement) NonceRemove() *DelElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *DelElement) Part(s string) *DelElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1585,7 +1523,7 @@ func (e *DelElement) Part(s string) *DelElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *DelElement) IfPart(condition bool, s string) *DelElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1595,14 +1533,8 @@ func (e *DelElement) IfPart(condition bool, s string) *DelElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *DelElement) PartRemove(s ...string) *DelElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1613,10 +1545,8 @@ func (e *DelElement) PartRemove(s ...string) *DelElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *DelElement) Popover(c DelPopoverChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[DelPopoverChoice])
 	return e
 }
 
@@ -1644,10 +1574,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *DelElement) PopoverRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1656,10 +1584,8 @@ func (e *DelElement) PopoverRemove() *DelElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *DelElement) Role(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1677,7 +1603,7 @@ func (e *DelElement) Rolef(format string, args ...any) *DelElement {
 // role of an element.
 func (e *DelElement) IfRole(condition bool, s string) *DelElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1688,7 +1614,7 @@ func (e *DelElement) IfRole(condition bool, s string) *DelElement {
 // role of an element.
 func (e *DelElement) IfRolef(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1699,10 +1625,8 @@ func (e *DelElement) IfRolef(condition bool, format string, args ...any) *DelEle
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *DelElement) RoleRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1711,10 +1635,8 @@ func (e *DelElement) RoleRemove() *DelElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *DelElement) Slot(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1732,7 +1654,7 @@ func (e *DelElement) Slotf(format string, args ...any) *DelElement {
 // value.
 func (e *DelElement) IfSlot(condition bool, s string) *DelElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1743,7 +1665,7 @@ func (e *DelElement) IfSlot(condition bool, s string) *DelElement {
 // value.
 func (e *DelElement) IfSlotf(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1754,10 +1676,8 @@ func (e *DelElement) IfSlotf(condition bool, format string, args ...any) *DelEle
 // value.
 // Remove the attribute Slot from the element.
 func (e *DelElement) SlotRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1773,10 +1693,8 @@ func (e *DelElement) SlotRemove() *DelElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *DelElement) Spellcheck(c DelSpellcheckChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[DelSpellcheckChoice])
 	return e
 }
 
@@ -1804,10 +1722,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *DelElement) SpellcheckRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1817,14 +1733,8 @@ func (e *DelElement) StylePairs(pairs ...string) *DelElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1839,14 +1749,8 @@ func (e *DelElement) StylePairs(pairs ...string) *DelElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DelElement) Style(s string) *DelElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1854,7 +1758,7 @@ func (e *DelElement) Style(s string) *DelElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1863,7 +1767,7 @@ func (e *DelElement) Style(s string) *DelElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *DelElement) IfStyle(condition bool, s string) *DelElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1871,15 +1775,7 @@ func (e *DelElement) IfStyle(condition bool, s string) *DelElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DelElement) StyleAdd(k string, v string) *DelElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1893,7 +1789,7 @@ func (e *DelElement) StyleAddf(k string, format string, args ...any) *DelElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *DelElement) IfStyleAdd(condition bool, k string, v string) *DelElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1902,7 +1798,7 @@ func (e *DelElement) IfStyleAdd(condition bool, k string, v string) *DelElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *DelElement) IfStyleAddf(condition bool, k string, format string, args ...any) *DelElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1911,21 +1807,15 @@ func (e *DelElement) IfStyleAddf(condition bool, k string, format string, args .
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *DelElement) StyleMap(m map[string]string) *DelElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1934,14 +1824,8 @@ func (e *DelElement) StyleMap(m map[string]string) *DelElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *DelElement) StyleRemove(keys ...string) *DelElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1959,10 +1843,8 @@ func (e *DelElement) StyleRemove(keys ...string) *DelElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *DelElement) Tabindex(i int) *DelElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1981,7 +1863,7 @@ func (e *DelElement) Tabindex(i int) *DelElement {
 // their relative position in the document.
 func (e *DelElement) IfTabindex(condition bool, i int) *DelElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2001,10 +1883,8 @@ func (e *DelElement) IfTabindex(condition bool, i int) *DelElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *DelElement) TabindexRemove() *DelElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2025,10 +1905,8 @@ func (e *DelElement) TabindexRemove() *DelElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *DelElement) Title(s string) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2070,7 +1948,7 @@ func (e *DelElement) Titlef(format string, args ...any) *DelElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DelElement) IfTitle(condition bool, s string) *DelElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2093,7 +1971,7 @@ func (e *DelElement) IfTitle(condition bool, s string) *DelElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DelElement) IfTitlef(condition bool, format string, args ...any) *DelElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2116,10 +1994,8 @@ func (e *DelElement) IfTitlef(condition bool, format string, args ...any) *DelEl
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *DelElement) TitleRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2128,10 +2004,8 @@ func (e *DelElement) TitleRemove() *DelElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *DelElement) Translate(c DelTranslateChoice) *DelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[DelTranslateChoice])
 	return e
 }
 
@@ -2152,9 +2026,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *DelElement) TranslateRemove() *DelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
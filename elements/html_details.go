@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <details> element is used as a disclosure widget from which the user
@@ -22,18 +20,77 @@ type DetailsElement struct {
 // with the tag "details" during rendering.
 func Details(children ...ElementRenderer) *DetailsElement {
 	e := NewElement("details", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &DetailsElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *DetailsElement) Clone() *DetailsElement {
+	return &DetailsElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *DetailsElement) CloneShallow() *DetailsElement {
+	return &DetailsElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *DetailsElement) Freeze() *DetailsElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *DetailsElement) writable() *DetailsElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *DetailsElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *DetailsElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[DetailsAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[DetailsContenteditableChoice]
+	case "dir":
+		return choiceValue[DetailsDirChoice]
+	case "draggable":
+		return choiceValue[DetailsDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[DetailsEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[DetailsHiddenChoice]
+	case "inputmode":
+		return choiceValue[DetailsInputmodeChoice]
+	case "popover":
+		return choiceValue[DetailsPopoverChoice]
+	case "spellcheck":
+		return choiceValue[DetailsSpellcheckChoice]
+	case "translate":
+		return choiceValue[DetailsTranslateChoice]
+	}
+	return nil
+}
+
 func (e *DetailsElement) Children(children ...ElementRenderer) *DetailsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *DetailsElement) IfChildren(condition bool, children ...ElementRenderer) *DetailsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *DetailsElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *DetailsElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *DetailsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *DetailsElement) BoolAttr(name string) *DetailsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *DetailsElement) BoolAttrRemove(name string) *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *DetailsElement) IfBoolAttr(condition bool, name string) *DetailsElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *DetailsElement) BoolAttrf(format string, args ...any) *DetailsElement {
 
 func (e *DetailsElement) IfBoolAttrf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *DetailsElement) BoolAttrs(names ...string) *DetailsElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *DetailsElement) IfBoolAttrs(condition bool, names ...string) *DetailsElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *DetailsElement) Attr(name, value string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *DetailsElement) RawAttr(name, value string) *DetailsElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *DetailsElement) IfAttr(condition bool, name, value string) *DetailsElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *DetailsElement) Attrf(name, format string, args ...any) *DetailsElement
 
 func (e *DetailsElement) IfAttrf(condition bool, name, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *DetailsElement) Attrs(attrs ...string) *DetailsElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *DetailsElement) IfAttrs(condition bool, attrs ...string) *DetailsElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *DetailsElement) AttrsMap(attrs map[string]string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *DetailsElement) IfAttrsMap(condition bool, attrs map[string]string) *DetailsElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *DetailsElement) Text(text string) *DetailsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *DetailsElement) Textf(format string, args ...any) *DetailsElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *DetailsElement) IfText(condition bool, text string) *DetailsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *DetailsElement) IfText(condition bool, text string) *DetailsElement {
 
 func (e *DetailsElement) IfTextf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *DetailsElement) Raw(text string) *DetailsElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *DetailsElement) Escaped(text string) *DetailsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *DetailsElement) IfEscaped(condition bool, text string) *DetailsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *DetailsElement) Escapedf(format string, args ...any) *DetailsElement {
 
 func (e *DetailsElement) IfEscapedf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,17 +270,15 @@ func (e *DetailsElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Indicates whether the details will be shown on page load.
 func (e *DetailsElement) Open() *DetailsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("open", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("open")
 	return e
 }
 
 // Indicates whether the details will be shown on page load.
 func (e *DetailsElement) IfOpen(condition bool) *DetailsElement {
 	if condition {
-		e.Open()
+		e = e.Open()
 	}
 	return e
 }
@@ -227,20 +286,16 @@ func (e *DetailsElement) IfOpen(condition bool) *DetailsElement {
 // Remove the attribute Open from the element.
 // Indicates whether the details will be shown on page load.
 func (e *DetailsElement) OpenRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("open")
+	e = e.writable()
+	e.removeAttribute("open")
 	return e
 }
 
 // Remove the attribute Open from the element.
 // Indicates whether the details will be shown on page load.
 func (e *DetailsElement) OpenIfRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("open")
+	e = e.writable()
+	e.removeAttribute("open")
 	return e
 }
 
@@ -249,10 +304,8 @@ func (e *DetailsElement) OpenIfRemove() *DetailsElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *DetailsElement) Accesskey(r rune) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -262,7 +315,7 @@ func (e *DetailsElement) Accesskey(r rune) *DetailsElement {
 // can be generated by the keyboard).
 func (e *DetailsElement) IfAccesskey(condition bool, r rune) *DetailsElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -273,10 +326,8 @@ func (e *DetailsElement) IfAccesskey(condition bool, r rune) *DetailsElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *DetailsElement) AccesskeyRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -292,10 +343,8 @@ func (e *DetailsElement) AccesskeyRemove() *DetailsElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *DetailsElement) Autocapitalize(c DetailsAutocapitalizeChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[DetailsAutocapitalizeChoice])
 	return e
 }
 
@@ -329,10 +378,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *DetailsElement) AutocapitalizeRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -351,10 +398,8 @@ func (e *DetailsElement) AutocapitalizeRemove() *DetailsElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DetailsElement) Autofocus() *DetailsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -374,7 +419,7 @@ func (e *DetailsElement) Autofocus() *DetailsElement {
 // created by the preceding content.
 func (e *DetailsElement) IfAutofocus(condition bool) *DetailsElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -395,10 +440,8 @@ func (e *DetailsElement) IfAutofocus(condition bool) *DetailsElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DetailsElement) AutofocusRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -418,10 +461,8 @@ func (e *DetailsElement) AutofocusRemove() *DetailsElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DetailsElement) AutofocusIfRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -430,16 +471,9 @@ func (e *DetailsElement) AutofocusIfRemove() *DetailsElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *DetailsElement) Class(s string) *DetailsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -449,7 +483,7 @@ func (e *DetailsElement) Class(s string) *DetailsElement {
 // document.getElementsByClassName.
 func (e *DetailsElement) IfClass(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -460,14 +494,8 @@ func (e *DetailsElement) IfClass(condition bool, s string) *DetailsElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *DetailsElement) ClassRemove(s ...string) *DetailsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -475,10 +503,8 @@ func (e *DetailsElement) ClassRemove(s ...string) *DetailsElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *DetailsElement) Contenteditable(c DetailsContenteditableChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[DetailsContenteditableChoice])
 	return e
 }
 
@@ -501,10 +527,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *DetailsElement) ContenteditableRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -522,10 +546,8 @@ func (e *DetailsElement) ContenteditableRemove() *DetailsElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *DetailsElement) Dir(c DetailsDirChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[DetailsDirChoice])
 	return e
 }
 
@@ -559,10 +581,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *DetailsElement) DirRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -570,10 +590,8 @@ func (e *DetailsElement) DirRemove() *DetailsElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *DetailsElement) Draggable(c DetailsDraggableChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[DetailsDraggableChoice])
 	return e
 }
 
@@ -599,20 +617,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *DetailsElement) DraggableRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *DetailsElement) Enterkeyhint(c DetailsEnterkeyhintChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[DetailsEnterkeyhintChoice])
 	return e
 }
 
@@ -642,10 +656,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *DetailsElement) EnterkeyhintRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -667,16 +679,9 @@ func (e *DetailsElement) EnterkeyhintRemove() *DetailsElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *DetailsElement) Exportparts(s string) *DetailsElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -699,7 +704,7 @@ func (e *DetailsElement) Exportparts(s string) *DetailsElement {
 // the current structure.
 func (e *DetailsElement) IfExportparts(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -723,14 +728,8 @@ func (e *DetailsElement) IfExportparts(condition bool, s string) *DetailsElement
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *DetailsElement) ExportpartsRemove(s ...string) *DetailsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -747,10 +746,8 @@ func (e *DetailsElement) ExportpartsRemove(s ...string) *DetailsElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *DetailsElement) Hidden(c DetailsHiddenChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[DetailsHiddenChoice])
 	return e
 }
 
@@ -786,10 +783,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *DetailsElement) HiddenRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -797,10 +792,8 @@ func (e *DetailsElement) HiddenRemove() *DetailsElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DetailsElement) ID(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -816,7 +809,7 @@ func (e *DetailsElement) IDf(format string, args ...any) *DetailsElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DetailsElement) IfID(condition bool, s string) *DetailsElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -826,7 +819,7 @@ func (e *DetailsElement) IfID(condition bool, s string) *DetailsElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DetailsElement) IfIDf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -836,10 +829,8 @@ func (e *DetailsElement) IfIDf(condition bool, format string, args ...any) *Deta
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *DetailsElement) IDRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -854,10 +845,8 @@ func (e *DetailsElement) IDRemove() *DetailsElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DetailsElement) Inert() *DetailsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -873,7 +862,7 @@ func (e *DetailsElement) Inert() *DetailsElement {
 // excluding them from the accessibility tree.
 func (e *DetailsElement) IfInert(condition bool) *DetailsElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -890,10 +879,8 @@ func (e *DetailsElement) IfInert(condition bool) *DetailsElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DetailsElement) InertRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -909,10 +896,8 @@ func (e *DetailsElement) InertRemove() *DetailsElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DetailsElement) InertIfRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -926,10 +911,8 @@ func (e *DetailsElement) InertIfRemove() *DetailsElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *DetailsElement) Inputmode(c DetailsInputmodeChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[DetailsInputmodeChoice])
 	return e
 }
 
@@ -980,10 +963,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *DetailsElement) InputmodeRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -993,10 +974,8 @@ func (e *DetailsElement) InputmodeRemove() *DetailsElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *DetailsElement) Is(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1016,7 +995,7 @@ func (e *DetailsElement) Isf(format string, args ...any) *DetailsElement {
 // and extends the element type it is being applied to.
 func (e *DetailsElement) IfIs(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1028,7 +1007,7 @@ func (e *DetailsElement) IfIs(condition bool, s string) *DetailsElement {
 // and extends the element type it is being applied to.
 func (e *DetailsElement) IfIsf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1040,10 +1019,8 @@ func (e *DetailsElement) IfIsf(condition bool, format string, args ...any) *Deta
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *DetailsElement) IsRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1059,10 +1036,8 @@ func (e *DetailsElement) IsRemove() *DetailsElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *DetailsElement) Itemid(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1094,7 +1069,7 @@ func (e *DetailsElement) Itemidf(format string, args ...any) *DetailsElement {
 // how items with the same identifier are handled.
 func (e *DetailsElement) IfItemid(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1112,7 +1087,7 @@ func (e *DetailsElement) IfItemid(condition bool, s string) *DetailsElement {
 // how items with the same identifier are handled.
 func (e *DetailsElement) IfItemidf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1130,10 +1105,8 @@ func (e *DetailsElement) IfItemidf(condition bool, format string, args ...any) *
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *DetailsElement) ItemidRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1145,10 +1118,8 @@ func (e *DetailsElement) ItemidRemove() *DetailsElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *DetailsElement) Itemprop(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1172,7 +1143,7 @@ func (e *DetailsElement) Itempropf(format string, args ...any) *DetailsElement {
 // <track>, and <video>.
 func (e *DetailsElement) IfItemprop(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1186,7 +1157,7 @@ func (e *DetailsElement) IfItemprop(condition bool, s string) *DetailsElement {
 // <track>, and <video>.
 func (e *DetailsElement) IfItempropf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1200,10 +1171,8 @@ func (e *DetailsElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *DetailsElement) ItempropRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1213,10 +1182,8 @@ func (e *DetailsElement) ItempropRemove() *DetailsElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *DetailsElement) Itemref(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1236,7 +1203,7 @@ func (e *DetailsElement) Itemreff(format string, args ...any) *DetailsElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DetailsElement) IfItemref(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1248,7 +1215,7 @@ func (e *DetailsElement) IfItemref(condition bool, s string) *DetailsElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DetailsElement) IfItemreff(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1260,10 +1227,8 @@ func (e *DetailsElement) IfItemreff(condition bool, format string, args ...any)
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *DetailsElement) ItemrefRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1275,10 +1240,8 @@ func (e *DetailsElement) ItemrefRemove() *DetailsElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DetailsElement) Itemscope() *DetailsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1291,7 +1254,7 @@ func (e *DetailsElement) Itemscope() *DetailsElement {
 // <object>, <source>, <track>, and <video>.
 func (e *DetailsElement) IfItemscope(condition bool) *DetailsElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1305,10 +1268,8 @@ func (e *DetailsElement) IfItemscope(condition bool) *DetailsElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DetailsElement) ItemscopeRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1321,10 +1282,8 @@ func (e *DetailsElement) ItemscopeRemove() *DetailsElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DetailsElement) ItemscopeIfRemove() *DetailsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1336,10 +1295,8 @@ func (e *DetailsElement) ItemscopeIfRemove() *DetailsElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *DetailsElement) Itemtype(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1363,7 +1320,7 @@ func (e *DetailsElement) Itemtypef(format string, args ...any) *DetailsElement {
 // <video>.
 func (e *DetailsElement) IfItemtype(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1377,7 +1334,7 @@ func (e *DetailsElement) IfItemtype(condition bool, s string) *DetailsElement {
 // <video>.
 func (e *DetailsElement) IfItemtypef(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1391,10 +1348,8 @@ func (e *DetailsElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *DetailsElement) ItemtypeRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1404,10 +1359,8 @@ func (e *DetailsElement) ItemtypeRemove() *DetailsElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DetailsElement) Lang(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1427,7 +1380,7 @@ func (e *DetailsElement) Langf(format string, args ...any) *DetailsElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DetailsElement) IfLang(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1439,7 +1392,7 @@ func (e *DetailsElement) IfLang(condition bool, s string) *DetailsElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DetailsElement) IfLangf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1451,10 +1404,8 @@ func (e *DetailsElement) IfLangf(condition bool, format string, args ...any) *De
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *DetailsElement) LangRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1464,10 +1415,8 @@ func (e *DetailsElement) LangRemove() *DetailsElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *DetailsElement) Nonce(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1487,7 +1436,7 @@ func (e *DetailsElement) Noncef(format string, args ...any) *DetailsElement {
 // execute.
 func (e *DetailsElement) IfNonce(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1499,7 +1448,7 @@ func (e *DetailsElement) IfNonce(condition bool, s string) *DetailsElement {
 // execute.
 func (e *DetailsElement) IfNoncef(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1511,10 +1460,8 @@ func (e *DetailsElement) IfNoncef(condition bool, format string, args ...any) *D
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *DetailsElement) NonceRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1522,16 +1469,9 @@ func (e *DetailsElement) NonceRemove() *DetailsElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *DetailsElement) Part(s string) *DetailsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1540,7 +1480,7 @@ func (e *DetailsElement) Part(s string) *DetailsElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *DetailsElement) IfPart(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1550,14 +1490,8 @@ func (e *DetailsElement) IfPart(condition bool, s string) *DetailsElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *DetailsElement) PartRemove(s ...string) *DetailsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1568,10 +1502,8 @@ func (e *DetailsElement) PartRemove(s ...string) *DetailsElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *DetailsElement) Popover(c DetailsPopoverChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[DetailsPopoverChoice])
 	return e
 }
 
@@ -1599,10 +1531,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *DetailsElement) PopoverRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1611,10 +1541,8 @@ func (e *DetailsElement) PopoverRemove() *DetailsElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *DetailsElement) Role(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1632,7 +1560,7 @@ func (e *DetailsElement) Rolef(format string, args ...any) *DetailsElement {
 // role of an element.
 func (e *DetailsElement) IfRole(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1643,7 +1571,7 @@ func (e *DetailsElement) IfRole(condition bool, s string) *DetailsElement {
 // role of an element.
 func (e *DetailsElement) IfRolef(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1654,10 +1582,8 @@ func (e *DetailsElement) IfRolef(condition bool, format string, args ...any) *De
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *DetailsElement) RoleRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1666,10 +1592,8 @@ func (e *DetailsElement) RoleRemove() *DetailsElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *DetailsElement) Slot(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1687,7 +1611,7 @@ func (e *DetailsElement) Slotf(format string, args ...any) *DetailsElement {
 // value.
 func (e *DetailsElement) IfSlot(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1698,7 +1622,7 @@ func (e *DetailsElement) IfSlot(condition bool, s string) *DetailsElement {
 // value.
 func (e *DetailsElement) IfSlotf(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1709,10 +1633,8 @@ func (e *DetailsElement) IfSlotf(condition bool, format string, args ...any) *De
 // value.
 // Remove the attribute Slot from the element.
 func (e *DetailsElement) SlotRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1728,10 +1650,8 @@ func (e *DetailsElement) SlotRemove() *DetailsElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *DetailsElement) Spellcheck(c DetailsSpellcheckChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[DetailsSpellcheckChoice])
 	return e
 }
 
@@ -1759,10 +1679,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *DetailsElement) SpellcheckRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1772,14 +1690,8 @@ func (e *DetailsElement) StylePairs(pairs ...string) *DetailsElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1794,14 +1706,8 @@ func (e *DetailsElement) StylePairs(pairs ...string) *DetailsElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DetailsElement) Style(s string) *DetailsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1809,7 +1715,7 @@ func (e *DetailsElement) Style(s string) *DetailsElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1818,7 +1724,7 @@ func (e *DetailsElement) Style(s string) *DetailsElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *DetailsElement) IfStyle(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1826,15 +1732,7 @@ func (e *DetailsElement) IfStyle(condition bool, s string) *DetailsElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DetailsElement) StyleAdd(k string, v string) *DetailsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1848,7 +1746,7 @@ func (e *DetailsElement) StyleAddf(k string, format string, args ...any) *Detail
 // color, font, size, and more. Styles are written in CSS.
 func (e *DetailsElement) IfStyleAdd(condition bool, k string, v string) *DetailsElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1857,7 +1755,7 @@ func (e *DetailsElement) IfStyleAdd(condition bool, k string, v string) *Details
 // color, font, size, and more. Styles are written in CSS.
 func (e *DetailsElement) IfStyleAddf(condition bool, k string, format string, args ...any) *DetailsElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1866,21 +1764,15 @@ func (e *DetailsElement) IfStyleAddf(condition bool, k string, format string, ar
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *DetailsElement) StyleMap(m map[string]string) *DetailsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1889,14 +1781,8 @@ func (e *DetailsElement) StyleMap(m map[string]string) *DetailsElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *DetailsElement) StyleRemove(keys ...string) *DetailsElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1914,10 +1800,8 @@ func (e *DetailsElement) StyleRemove(keys ...string) *DetailsElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *DetailsElement) Tabindex(i int) *DetailsElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1936,7 +1820,7 @@ func (e *DetailsElement) Tabindex(i int) *DetailsElement {
 // their relative position in the document.
 func (e *DetailsElement) IfTabindex(condition bool, i int) *DetailsElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1956,10 +1840,8 @@ func (e *DetailsElement) IfTabindex(condition bool, i int) *DetailsElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *DetailsElement) TabindexRemove() *DetailsElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1980,10 +1862,8 @@ func (e *DetailsElement) TabindexRemove() *DetailsElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *DetailsElement) Title(s string) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2025,7 +1905,7 @@ func (e *DetailsElement) Titlef(format string, args ...any) *DetailsElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DetailsElement) IfTitle(condition bool, s string) *DetailsElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2048,7 +1928,7 @@ func (e *DetailsElement) IfTitle(condition bool, s string) *DetailsElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DetailsElement) IfTitlef(condition bool, format string, args ...any) *DetailsElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2071,10 +1951,8 @@ func (e *DetailsElement) IfTitlef(condition bool, format string, args ...any) *D
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *DetailsElement) TitleRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2083,10 +1961,8 @@ func (e *DetailsElement) TitleRemove() *DetailsElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *DetailsElement) Translate(c DetailsTranslateChoice) *DetailsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[DetailsTranslateChoice])
 	return e
 }
 
@@ -2107,9 +1983,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *DetailsElement) TranslateRemove() *DetailsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
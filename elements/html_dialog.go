@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <dialog> element represents a dialog box or other interactive
@@ -23,18 +21,77 @@ type DialogElement struct {
 // with the tag "dialog" during rendering.
 func Dialog(children ...ElementRenderer) *DialogElement {
 	e := NewElement("dialog", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &DialogElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *DialogElement) Clone() *DialogElement {
+	return &DialogElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *DialogElement) CloneShallow() *DialogElement {
+	return &DialogElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *DialogElement) Freeze() *DialogElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *DialogElement) writable() *DialogElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *DialogElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *DialogElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[DialogAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[DialogContenteditableChoice]
+	case "dir":
+		return choiceValue[DialogDirChoice]
+	case "draggable":
+		return choiceValue[DialogDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[DialogEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[DialogHiddenChoice]
+	case "inputmode":
+		return choiceValue[DialogInputmodeChoice]
+	case "popover":
+		return choiceValue[DialogPopoverChoice]
+	case "spellcheck":
+		return choiceValue[DialogSpellcheckChoice]
+	case "translate":
+		return choiceValue[DialogTranslateChoice]
+	}
+	return nil
+}
+
 func (e *DialogElement) Children(children ...ElementRenderer) *DialogElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *DialogElement) IfChildren(condition bool, children ...ElementRenderer) *DialogElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *DialogElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *DialogElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *DialogElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *DialogElement) BoolAttr(name string) *DialogElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *DialogElement) BoolAttrRemove(name string) *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *DialogElement) IfBoolAttr(condition bool, name string) *DialogElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *DialogElement) BoolAttrf(format string, args ...any) *DialogElement {
 
 func (e *DialogElement) IfBoolAttrf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *DialogElement) BoolAttrs(names ...string) *DialogElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *DialogElement) IfBoolAttrs(condition bool, names ...string) *DialogElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *DialogElement) Attr(name, value string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *DialogElement) RawAttr(name, value string) *DialogElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *DialogElement) IfAttr(condition bool, name, value string) *DialogElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *DialogElement) Attrf(name, format string, args ...any) *DialogElement {
 
 func (e *DialogElement) IfAttrf(condition bool, name, format string, args ...any) *DialogElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *DialogElement) Attrs(attrs ...string) *DialogElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *DialogElement) IfAttrs(condition bool, attrs ...string) *DialogElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *DialogElement) AttrsMap(attrs map[string]string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *DialogElement) IfAttrsMap(condition bool, attrs map[string]string) *DialogElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *DialogElement) Text(text string) *DialogElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *DialogElement) Textf(format string, args ...any) *DialogElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *DialogElement) IfText(condition bool, text string) *DialogElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *DialogElement) IfText(condition bool, text string) *DialogElement {
 
 func (e *DialogElement) IfTextf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *DialogElement) Raw(text string) *DialogElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *DialogElement) Escaped(text string) *DialogElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *DialogElement) IfEscaped(condition bool, text string) *DialogElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *DialogElement) Escapedf(format string, args ...any) *DialogElement {
 
 func (e *DialogElement) IfEscapedf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +271,15 @@ func (e *DialogElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Indicates whether the dialog is showing.
 func (e *DialogElement) Open() *DialogElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("open", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("open")
 	return e
 }
 
 // Indicates whether the dialog is showing.
 func (e *DialogElement) IfOpen(condition bool) *DialogElement {
 	if condition {
-		e.Open()
+		e = e.Open()
 	}
 	return e
 }
@@ -228,20 +287,16 @@ func (e *DialogElement) IfOpen(condition bool) *DialogElement {
 // Remove the attribute Open from the element.
 // Indicates whether the dialog is showing.
 func (e *DialogElement) OpenRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("open")
+	e = e.writable()
+	e.removeAttribute("open")
 	return e
 }
 
 // Remove the attribute Open from the element.
 // Indicates whether the dialog is showing.
 func (e *DialogElement) OpenIfRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("open")
+	e = e.writable()
+	e.removeAttribute("open")
 	return e
 }
 
@@ -250,10 +305,8 @@ func (e *DialogElement) OpenIfRemove() *DialogElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *DialogElement) Accesskey(r rune) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -263,7 +316,7 @@ func (e *DialogElement) Accesskey(r rune) *DialogElement {
 // can be generated by the keyboard).
 func (e *DialogElement) IfAccesskey(condition bool, r rune) *DialogElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -274,10 +327,8 @@ func (e *DialogElement) IfAccesskey(condition bool, r rune) *DialogElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *DialogElement) AccesskeyRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -293,10 +344,8 @@ func (e *DialogElement) AccesskeyRemove() *DialogElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *DialogElement) Autocapitalize(c DialogAutocapitalizeChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[DialogAutocapitalizeChoice])
 	return e
 }
 
@@ -330,10 +379,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *DialogElement) AutocapitalizeRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -352,10 +399,8 @@ func (e *DialogElement) AutocapitalizeRemove() *DialogElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DialogElement) Autofocus() *DialogElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -375,7 +420,7 @@ func (e *DialogElement) Autofocus() *DialogElement {
 // created by the preceding content.
 func (e *DialogElement) IfAutofocus(condition bool) *DialogElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -396,10 +441,8 @@ func (e *DialogElement) IfAutofocus(condition bool) *DialogElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DialogElement) AutofocusRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -419,10 +462,8 @@ func (e *DialogElement) AutofocusRemove() *DialogElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *DialogElement) AutofocusIfRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -431,16 +472,9 @@ func (e *DialogElement) AutofocusIfRemove() *DialogElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *DialogElement) Class(s string) *DialogElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -450,7 +484,7 @@ func (e *DialogElement) Class(s string) *DialogElement {
 // document.getElementsByClassName.
 func (e *DialogElement) IfClass(condition bool, s string) *DialogElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -461,14 +495,8 @@ func (e *DialogElement) IfClass(condition bool, s string) *DialogElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *DialogElement) ClassRemove(s ...string) *DialogElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -476,10 +504,8 @@ func (e *DialogElement) ClassRemove(s ...string) *DialogElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *DialogElement) Contenteditable(c DialogContenteditableChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[DialogContenteditableChoice])
 	return e
 }
 
@@ -502,10 +528,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *DialogElement) ContenteditableRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -523,10 +547,8 @@ func (e *DialogElement) ContenteditableRemove() *DialogElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *DialogElement) Dir(c DialogDirChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[DialogDirChoice])
 	return e
 }
 
@@ -560,10 +582,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *DialogElement) DirRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -571,10 +591,8 @@ func (e *DialogElement) DirRemove() *DialogElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *DialogElement) Draggable(c DialogDraggableChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[DialogDraggableChoice])
 	return e
 }
 
@@ -600,20 +618,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *DialogElement) DraggableRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *DialogElement) Enterkeyhint(c DialogEnterkeyhintChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[DialogEnterkeyhintChoice])
 	return e
 }
 
@@ -643,10 +657,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *DialogElement) EnterkeyhintRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -668,16 +680,9 @@ func (e *DialogElement) EnterkeyhintRemove() *DialogElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *DialogElement) Exportparts(s string) *DialogElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -700,7 +705,7 @@ func (e *DialogElement) Exportparts(s string) *DialogElement {
 // the current structure.
 func (e *DialogElement) IfExportparts(condition bool, s string) *DialogElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -724,14 +729,8 @@ func (e *DialogElement) IfExportparts(condition bool, s string) *DialogElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *DialogElement) ExportpartsRemove(s ...string) *DialogElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -748,10 +747,8 @@ func (e *DialogElement) ExportpartsRemove(s ...string) *DialogElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *DialogElement) Hidden(c DialogHiddenChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[DialogHiddenChoice])
 	return e
 }
 
@@ -787,10 +784,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *DialogElement) HiddenRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -798,10 +793,8 @@ func (e *DialogElement) HiddenRemove() *DialogElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DialogElement) ID(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -817,7 +810,7 @@ func (e *DialogElement) IDf(format string, args ...any) *DialogElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DialogElement) IfID(condition bool, s string) *DialogElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -827,7 +820,7 @@ func (e *DialogElement) IfID(condition bool, s string) *DialogElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *DialogElement) IfIDf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -837,10 +830,8 @@ func (e *DialogElement) IfIDf(condition bool, format string, args ...any) *Dialo
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *DialogElement) IDRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -855,10 +846,8 @@ func (e *DialogElement) IDRemove() *DialogElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DialogElement) Inert() *DialogElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -874,7 +863,7 @@ func (e *DialogElement) Inert() *DialogElement {
 // excluding them from the accessibility tree.
 func (e *DialogElement) IfInert(condition bool) *DialogElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -891,10 +880,8 @@ func (e *DialogElement) IfInert(condition bool) *DialogElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DialogElement) InertRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -910,10 +897,8 @@ func (e *DialogElement) InertRemove() *DialogElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *DialogElement) InertIfRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -927,10 +912,8 @@ func (e *DialogElement) InertIfRemove() *DialogElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *DialogElement) Inputmode(c DialogInputmodeChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[DialogInputmodeChoice])
 	return e
 }
 
@@ -981,10 +964,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *DialogElement) InputmodeRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -994,10 +975,8 @@ func (e *DialogElement) InputmodeRemove() *DialogElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *DialogElement) Is(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1017,7 +996,7 @@ func (e *DialogElement) Isf(format string, args ...any) *DialogElement {
 // and extends the element type it is being applied to.
 func (e *DialogElement) IfIs(condition bool, s string) *DialogElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1029,7 +1008,7 @@ func (e *DialogElement) IfIs(condition bool, s string) *DialogElement {
 // and extends the element type it is being applied to.
 func (e *DialogElement) IfIsf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1041,10 +1020,8 @@ func (e *DialogElement) IfIsf(condition bool, format string, args ...any) *Dialo
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *DialogElement) IsRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1060,10 +1037,8 @@ func (e *DialogElement) IsRemove() *DialogElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *DialogElement) Itemid(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1095,7 +1070,7 @@ func (e *DialogElement) Itemidf(format string, args ...any) *DialogElement {
 // how items with the same identifier are handled.
 func (e *DialogElement) IfItemid(condition bool, s string) *DialogElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1113,7 +1088,7 @@ func (e *DialogElement) IfItemid(condition bool, s string) *DialogElement {
 // how items with the same identifier are handled.
 func (e *DialogElement) IfItemidf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1131,10 +1106,8 @@ func (e *DialogElement) IfItemidf(condition bool, format string, args ...any) *D
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *DialogElement) ItemidRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1146,10 +1119,8 @@ func (e *DialogElement) ItemidRemove() *DialogElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *DialogElement) Itemprop(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1173,7 +1144,7 @@ func (e *DialogElement) Itempropf(format string, args ...any) *DialogElement {
 // <track>, and <video>.
 func (e *DialogElement) IfItemprop(condition bool, s string) *DialogElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1187,7 +1158,7 @@ func (e *DialogElement) IfItemprop(condition bool, s string) *DialogElement {
 // <track>, and <video>.
 func (e *DialogElement) IfItempropf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1201,10 +1172,8 @@ func (e *DialogElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *DialogElement) ItempropRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1214,10 +1183,8 @@ func (e *DialogElement) ItempropRemove() *DialogElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *DialogElement) Itemref(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1237,7 +1204,7 @@ func (e *DialogElement) Itemreff(format string, args ...any) *DialogElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DialogElement) IfItemref(condition bool, s string) *DialogElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1249,7 +1216,7 @@ func (e *DialogElement) IfItemref(condition bool, s string) *DialogElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *DialogElement) IfItemreff(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1261,10 +1228,8 @@ func (e *DialogElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *DialogElement) ItemrefRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1276,10 +1241,8 @@ func (e *DialogElement) ItemrefRemove() *DialogElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DialogElement) Itemscope() *DialogElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1292,7 +1255,7 @@ func (e *DialogElement) Itemscope() *DialogElement {
 // <object>, <source>, <track>, and <video>.
 func (e *DialogElement) IfItemscope(condition bool) *DialogElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1306,10 +1269,8 @@ func (e *DialogElement) IfItemscope(condition bool) *DialogElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DialogElement) ItemscopeRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1322,10 +1283,8 @@ func (e *DialogElement) ItemscopeRemove() *DialogElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *DialogElement) ItemscopeIfRemove() *DialogElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1337,10 +1296,8 @@ func (e *DialogElement) ItemscopeIfRemove() *DialogElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *DialogElement) Itemtype(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1364,7 +1321,7 @@ func (e *DialogElement) Itemtypef(format string, args ...any) *DialogElement {
 // <video>.
 func (e *DialogElement) IfItemtype(condition bool, s string) *DialogElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1378,7 +1335,7 @@ func (e *DialogElement) IfItemtype(condition bool, s string) *DialogElement {
 // <video>.
 func (e *DialogElement) IfItemtypef(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1392,10 +1349,8 @@ func (e *DialogElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *DialogElement) ItemtypeRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1405,10 +1360,8 @@ func (e *DialogElement) ItemtypeRemove() *DialogElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DialogElement) Lang(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1428,7 +1381,7 @@ func (e *DialogElement) Langf(format string, args ...any) *DialogElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DialogElement) IfLang(condition bool, s string) *DialogElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1440,7 +1393,7 @@ func (e *DialogElement) IfLang(condition bool, s string) *DialogElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *DialogElement) IfLangf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1452,10 +1405,8 @@ func (e *DialogElement) IfLangf(condition bool, format string, args ...any) *Dia
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *DialogElement) LangRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1465,10 +1416,8 @@ func (e *DialogElement) LangRemove() *DialogElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *DialogElement) Nonce(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1488,7 +1437,7 @@ func (e *DialogElement) Noncef(format string, args ...any) *DialogElement {
 // execute.
 func (e *DialogElement) IfNonce(condition bool, s string) *DialogElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1500,7 +1449,7 @@ func (e *DialogElement) IfNonce(condition bool, s string) *DialogElement {
 // execute.
 func (e *DialogElement) IfNoncef(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1512,10 +1461,8 @@ func (e *DialogElement) IfNoncef(condition bool, format string, args ...any) *Di
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *DialogElement) NonceRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1523,16 +1470,9 @@ func (e *DialogElement) NonceRemove() *DialogElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *DialogElement) Part(s string) *DialogElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1541,7 +1481,7 @@ func (e *DialogElement) Part(s string) *DialogElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *DialogElement) IfPart(condition bool, s string) *DialogElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1551,14 +1491,8 @@ func (e *DialogElement) IfPart(condition bool, s string) *DialogElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *DialogElement) PartRemove(s ...string) *DialogElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1569,10 +1503,8 @@ func (e *DialogElement) PartRemove(s ...string) *DialogElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *DialogElement) Popover(c DialogPopoverChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[DialogPopoverChoice])
 	return e
 }
 
@@ -1600,10 +1532,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *DialogElement) PopoverRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1612,10 +1542,8 @@ func (e *DialogElement) PopoverRemove() *DialogElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *DialogElement) Role(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1633,7 +1561,7 @@ func (e *DialogElement) Rolef(format string, args ...any) *DialogElement {
 // role of an element.
 func (e *DialogElement) IfRole(condition bool, s string) *DialogElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1644,7 +1572,7 @@ func (e *DialogElement) IfRole(condition bool, s string) *DialogElement {
 // role of an element.
 func (e *DialogElement) IfRolef(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1655,10 +1583,8 @@ func (e *DialogElement) IfRolef(condition bool, format string, args ...any) *Dia
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *DialogElement) RoleRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1667,10 +1593,8 @@ func (e *DialogElement) RoleRemove() *DialogElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *DialogElement) Slot(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1688,7 +1612,7 @@ func (e *DialogElement) Slotf(format string, args ...any) *DialogElement {
 // value.
 func (e *DialogElement) IfSlot(condition bool, s string) *DialogElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1699,7 +1623,7 @@ func (e *DialogElement) IfSlot(condition bool, s string) *DialogElement {
 // value.
 func (e *DialogElement) IfSlotf(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1710,10 +1634,8 @@ func (e *DialogElement) IfSlotf(condition bool, format string, args ...any) *Dia
 // value.
 // Remove the attribute Slot from the element.
 func (e *DialogElement) SlotRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1729,10 +1651,8 @@ func (e *DialogElement) SlotRemove() *DialogElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *DialogElement) Spellcheck(c DialogSpellcheckChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[DialogSpellcheckChoice])
 	return e
 }
 
@@ -1760,10 +1680,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *DialogElement) SpellcheckRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1773,14 +1691,8 @@ func (e *DialogElement) StylePairs(pairs ...string) *DialogElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1795,14 +1707,8 @@ func (e *DialogElement) StylePairs(pairs ...string) *DialogElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DialogElement) Style(s string) *DialogElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1810,7 +1716,7 @@ func (e *DialogElement) Style(s string) *DialogElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1819,7 +1725,7 @@ func (e *DialogElement) Style(s string) *DialogElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *DialogElement) IfStyle(condition bool, s string) *DialogElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1827,15 +1733,7 @@ func (e *DialogElement) IfStyle(condition bool, s string) *DialogElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *DialogElement) StyleAdd(k string, v string) *DialogElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1849,7 +1747,7 @@ func (e *DialogElement) StyleAddf(k string, format string, args ...any) *DialogE
 // color, font, size, and more. Styles are written in CSS.
 func (e *DialogElement) IfStyleAdd(condition bool, k string, v string) *DialogElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1858,7 +1756,7 @@ func (e *DialogElement) IfStyleAdd(condition bool, k string, v string) *DialogEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *DialogElement) IfStyleAddf(condition bool, k string, format string, args ...any) *DialogElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1867,21 +1765,15 @@ func (e *DialogElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *DialogElement) StyleMap(m map[string]string) *DialogElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1890,14 +1782,8 @@ func (e *DialogElement) StyleMap(m map[string]string) *DialogElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *DialogElement) StyleRemove(keys ...string) *DialogElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1915,10 +1801,8 @@ func (e *DialogElement) StyleRemove(keys ...string) *DialogElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *DialogElement) Tabindex(i int) *DialogElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1937,7 +1821,7 @@ func (e *DialogElement) Tabindex(i int) *DialogElement {
 // their relative position in the document.
 func (e *DialogElement) IfTabindex(condition bool, i int) *DialogElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1957,10 +1841,8 @@ func (e *DialogElement) IfTabindex(condition bool, i int) *DialogElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *DialogElement) TabindexRemove() *DialogElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1981,10 +1863,8 @@ func (e *DialogElement) TabindexRemove() *DialogElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *DialogElement) Title(s string) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2026,7 +1906,7 @@ func (e *DialogElement) Titlef(format string, args ...any) *DialogElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DialogElement) IfTitle(condition bool, s string) *DialogElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2049,7 +1929,7 @@ func (e *DialogElement) IfTitle(condition bool, s string) *DialogElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *DialogElement) IfTitlef(condition bool, format string, args ...any) *DialogElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2072,10 +1952,8 @@ func (e *DialogElement) IfTitlef(condition bool, format string, args ...any) *Di
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *DialogElement) TitleRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2084,10 +1962,8 @@ func (e *DialogElement) TitleRemove() *DialogElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *DialogElement) Translate(c DialogTranslateChoice) *DialogElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[DialogTranslateChoice])
 	return e
 }
 
@@ -2108,9 +1984,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *DialogElement) TranslateRemove() *DialogElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
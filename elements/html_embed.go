@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <embed> element embeds external content at the specified point in
@@ -23,18 +21,77 @@ type EmbedElement struct {
 // with the tag "embed" during rendering.
 func Embed() *EmbedElement {
 	e := NewElement("embed")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &EmbedElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *EmbedElement) Clone() *EmbedElement {
+	return &EmbedElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *EmbedElement) CloneShallow() *EmbedElement {
+	return &EmbedElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *EmbedElement) Freeze() *EmbedElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *EmbedElement) writable() *EmbedElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *EmbedElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *EmbedElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[EmbedAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[EmbedContenteditableChoice]
+	case "dir":
+		return choiceValue[EmbedDirChoice]
+	case "draggable":
+		return choiceValue[EmbedDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[EmbedEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[EmbedHiddenChoice]
+	case "inputmode":
+		return choiceValue[EmbedInputmodeChoice]
+	case "popover":
+		return choiceValue[EmbedPopoverChoice]
+	case "spellcheck":
+		return choiceValue[EmbedSpellcheckChoice]
+	case "translate":
+		return choiceValue[EmbedTranslateChoice]
+	}
+	return nil
+}
+
 func (e *EmbedElement) Children(children ...ElementRenderer) *EmbedElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *EmbedElement) IfChildren(condition bool, children ...ElementRenderer) *EmbedElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *EmbedElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *EmbedElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *EmbedElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *EmbedElement) BoolAttr(name string) *EmbedElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *EmbedElement) BoolAttrRemove(name string) *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *EmbedElement) IfBoolAttr(condition bool, name string) *EmbedElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *EmbedElement) BoolAttrf(format string, args ...any) *EmbedElement {
 
 func (e *EmbedElement) IfBoolAttrf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *EmbedElement) BoolAttrs(names ...string) *EmbedElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *EmbedElement) IfBoolAttrs(condition bool, names ...string) *EmbedElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *EmbedElement) Attr(name, value string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *EmbedElement) RawAttr(name, value string) *EmbedElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *EmbedElement) IfAttr(condition bool, name, value string) *EmbedElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *EmbedElement) Attrf(name, format string, args ...any) *EmbedElement {
 
 func (e *EmbedElement) IfAttrf(condition bool, name, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *EmbedElement) Attrs(attrs ...string) *EmbedElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *EmbedElement) IfAttrs(condition bool, attrs ...string) *EmbedElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *EmbedElement) AttrsMap(attrs map[string]string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *EmbedElement) IfAttrsMap(condition bool, attrs map[string]string) *EmbedElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *EmbedElement) Text(text string) *EmbedElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *EmbedElement) Textf(format string, args ...any) *EmbedElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *EmbedElement) IfText(condition bool, text string) *EmbedElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *EmbedElement) IfText(condition bool, text string) *EmbedElement {
 
 func (e *EmbedElement) IfTextf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *EmbedElement) Raw(text string) *EmbedElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *EmbedElement) Escaped(text string) *EmbedElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *EmbedElement) IfEscaped(condition bool, text string) *EmbedElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *EmbedElement) Escapedf(format string, args ...any) *EmbedElement {
 
 func (e *EmbedElement) IfEscapedf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +271,15 @@ func (e *EmbedElement) IfEscapedf(condition bool, format string, args ...any) *E
 
 // The height of the embedded content.
 func (e *EmbedElement) Height(i int) *EmbedElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // The height of the embedded content.
 func (e *EmbedElement) IfHeight(condition bool, i int) *EmbedElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -228,19 +287,15 @@ func (e *EmbedElement) IfHeight(condition bool, i int) *EmbedElement {
 // The height of the embedded content.
 // Remove the attribute Height from the element.
 func (e *EmbedElement) HeightRemove() *EmbedElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // The URL of the resource being embedded.
 func (e *EmbedElement) Src(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -252,7 +307,7 @@ func (e *EmbedElement) Srcf(format string, args ...any) *EmbedElement {
 // The URL of the resource being embedded.
 func (e *EmbedElement) IfSrc(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -260,7 +315,7 @@ func (e *EmbedElement) IfSrc(condition bool, s string) *EmbedElement {
 // The URL of the resource being embedded.
 func (e *EmbedElement) IfSrcf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -268,19 +323,15 @@ func (e *EmbedElement) IfSrcf(condition bool, format string, args ...any) *Embed
 // The URL of the resource being embedded.
 // Remove the attribute Src from the element.
 func (e *EmbedElement) SrcRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // The type of content being embedded.
 func (e *EmbedElement) Type(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -292,7 +343,7 @@ func (e *EmbedElement) Typef(format string, args ...any) *EmbedElement {
 // The type of content being embedded.
 func (e *EmbedElement) IfType(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -300,7 +351,7 @@ func (e *EmbedElement) IfType(condition bool, s string) *EmbedElement {
 // The type of content being embedded.
 func (e *EmbedElement) IfTypef(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -308,26 +359,22 @@ func (e *EmbedElement) IfTypef(condition bool, format string, args ...any) *Embe
 // The type of content being embedded.
 // Remove the attribute Type from the element.
 func (e *EmbedElement) TypeRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // The width of the embedded content.
 func (e *EmbedElement) Width(i int) *EmbedElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // The width of the embedded content.
 func (e *EmbedElement) IfWidth(condition bool, i int) *EmbedElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -335,10 +382,8 @@ func (e *EmbedElement) IfWidth(condition bool, i int) *EmbedElement {
 // The width of the embedded content.
 // Remove the attribute Width from the element.
 func (e *EmbedElement) WidthRemove() *EmbedElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -347,10 +392,8 @@ func (e *EmbedElement) WidthRemove() *EmbedElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *EmbedElement) Accesskey(r rune) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -360,7 +403,7 @@ func (e *EmbedElement) Accesskey(r rune) *EmbedElement {
 // can be generated by the keyboard).
 func (e *EmbedElement) IfAccesskey(condition bool, r rune) *EmbedElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -371,10 +414,8 @@ func (e *EmbedElement) IfAccesskey(condition bool, r rune) *EmbedElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *EmbedElement) AccesskeyRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -390,10 +431,8 @@ func (e *EmbedElement) AccesskeyRemove() *EmbedElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *EmbedElement) Autocapitalize(c EmbedAutocapitalizeChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[EmbedAutocapitalizeChoice])
 	return e
 }
 
@@ -427,10 +466,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *EmbedElement) AutocapitalizeRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -449,10 +486,8 @@ func (e *EmbedElement) AutocapitalizeRemove() *EmbedElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *EmbedElement) Autofocus() *EmbedElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -472,7 +507,7 @@ func (e *EmbedElement) Autofocus() *EmbedElement {
 // created by the preceding content.
 func (e *EmbedElement) IfAutofocus(condition bool) *EmbedElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -493,10 +528,8 @@ func (e *EmbedElement) IfAutofocus(condition bool) *EmbedElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *EmbedElement) AutofocusRemove() *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -516,10 +549,8 @@ func (e *EmbedElement) AutofocusRemove() *EmbedElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *EmbedElement) AutofocusIfRemove() *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -528,16 +559,9 @@ func (e *EmbedElement) AutofocusIfRemove() *EmbedElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *EmbedElement) Class(s string) *EmbedElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -547,7 +571,7 @@ func (e *EmbedElement) Class(s string) *EmbedElement {
 // document.getElementsByClassName.
 func (e *EmbedElement) IfClass(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -558,14 +582,8 @@ func (e *EmbedElement) IfClass(condition bool, s string) *EmbedElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *EmbedElement) ClassRemove(s ...string) *EmbedElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -573,10 +591,8 @@ func (e *EmbedElement) ClassRemove(s ...string) *EmbedElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *EmbedElement) Contenteditable(c EmbedContenteditableChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[EmbedContenteditableChoice])
 	return e
 }
 
@@ -599,10 +615,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *EmbedElement) ContenteditableRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -620,10 +634,8 @@ func (e *EmbedElement) ContenteditableRemove() *EmbedElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *EmbedElement) Dir(c EmbedDirChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[EmbedDirChoice])
 	return e
 }
 
@@ -657,10 +669,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *EmbedElement) DirRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -668,10 +678,8 @@ func (e *EmbedElement) DirRemove() *EmbedElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *EmbedElement) Draggable(c EmbedDraggableChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[EmbedDraggableChoice])
 	return e
 }
 
@@ -697,20 +705,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *EmbedElement) DraggableRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *EmbedElement) Enterkeyhint(c EmbedEnterkeyhintChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[EmbedEnterkeyhintChoice])
 	return e
 }
 
@@ -740,10 +744,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *EmbedElement) EnterkeyhintRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -765,16 +767,9 @@ func (e *EmbedElement) EnterkeyhintRemove() *EmbedElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *EmbedElement) Exportparts(s string) *EmbedElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -797,7 +792,7 @@ func (e *EmbedElement) Exportparts(s string) *EmbedElement {
 // the current structure.
 func (e *EmbedElement) IfExportparts(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -821,14 +816,8 @@ func (e *EmbedElement) IfExportparts(condition bool, s string) *EmbedElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *EmbedElement) ExportpartsRemove(s ...string) *EmbedElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -845,10 +834,8 @@ func (e *EmbedElement) ExportpartsRemove(s ...string) *EmbedElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *EmbedElement) Hidden(c EmbedHiddenChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[EmbedHiddenChoice])
 	return e
 }
 
@@ -884,10 +871,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *EmbedElement) HiddenRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -895,10 +880,8 @@ func (e *EmbedElement) HiddenRemove() *EmbedElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *EmbedElement) ID(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -914,7 +897,7 @@ func (e *EmbedElement) IDf(format string, args ...any) *EmbedElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *EmbedElement) IfID(condition bool, s string) *EmbedElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -924,7 +907,7 @@ func (e *EmbedElement) IfID(condition bool, s string) *EmbedElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *EmbedElement) IfIDf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -934,10 +917,8 @@ func (e *EmbedElement) IfIDf(condition bool, format string, args ...any) *EmbedE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *EmbedElement) IDRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -952,10 +933,8 @@ func (e *EmbedElement) IDRemove() *EmbedElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *EmbedElement) Inert() *EmbedElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -971,7 +950,7 @@ func (e *EmbedElement) Inert() *EmbedElement {
 // excluding them from the accessibility tree.
 func (e *EmbedElement) IfInert(condition bool) *EmbedElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -988,10 +967,8 @@ func (e *EmbedElement) IfInert(condition bool) *EmbedElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *EmbedElement) InertRemove() *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1007,10 +984,8 @@ func (e *EmbedElement) InertRemove() *EmbedElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *EmbedElement) InertIfRemove() *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1024,10 +999,8 @@ func (e *EmbedElement) InertIfRemove() *EmbedElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *EmbedElement) Inputmode(c EmbedInputmodeChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[EmbedInputmodeChoice])
 	return e
 }
 
@@ -1078,10 +1051,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *EmbedElement) InputmodeRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1091,10 +1062,8 @@ func (e *EmbedElement) InputmodeRemove() *EmbedElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *EmbedElement) Is(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1114,7 +1083,7 @@ func (e *EmbedElement) Isf(format string, args ...any) *EmbedElement {
 // and extends the element type it is being applied to.
 func (e *EmbedElement) IfIs(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1126,7 +1095,7 @@ func (e *EmbedElement) IfIs(condition bool, s string) *EmbedElement {
 // and extends the element type it is being applied to.
 func (e *EmbedElement) IfIsf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1138,10 +1107,8 @@ func (e *EmbedElement) IfIsf(condition bool, format string, args ...any) *EmbedE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *EmbedElement) IsRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1157,10 +1124,8 @@ func (e *EmbedElement) IsRemove() *EmbedElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *EmbedElement) Itemid(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1192,7 +1157,7 @@ func (e *EmbedElement) Itemidf(format string, args ...any) *EmbedElement {
 // how items with the same identifier are handled.
 func (e *EmbedElement) IfItemid(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1210,7 +1175,7 @@ func (e *EmbedElement) IfItemid(condition bool, s string) *EmbedElement {
 // how items with the same identifier are handled.
 func (e *EmbedElement) IfItemidf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1228,10 +1193,8 @@ func (e *EmbedElement) IfItemidf(condition bool, format string, args ...any) *Em
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *EmbedElement) ItemidRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1243,10 +1206,8 @@ func (e *EmbedElement) ItemidRemove() *EmbedElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *EmbedElement) Itemprop(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1270,7 +1231,7 @@ func (e *EmbedElement) Itempropf(format string, args ...any) *EmbedElement {
 // <track>, and <video>.
 func (e *EmbedElement) IfItemprop(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1284,7 +1245,7 @@ func (e *EmbedElement) IfItemprop(condition bool, s string) *EmbedElement {
 // <track>, and <video>.
 func (e *EmbedElement) IfItempropf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1298,10 +1259,8 @@ func (e *EmbedElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *EmbedElement) ItempropRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1311,10 +1270,8 @@ func (e *EmbedElement) ItempropRemove() *EmbedElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *EmbedElement) Itemref(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1334,7 +1291,7 @@ func (e *EmbedElement) Itemreff(format string, args ...any) *EmbedElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *EmbedElement) IfItemref(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1346,7 +1303,7 @@ func (e *EmbedElement) IfItemref(condition bool, s string) *EmbedElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *EmbedElement) IfItemreff(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1358,10 +1315,8 @@ func (e *EmbedElement) IfItemreff(condition bool, format string, args ...any) *E
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *EmbedElement) ItemrefRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1373,10 +1328,8 @@ func (e *EmbedElement) ItemrefRemove() *EmbedElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *EmbedElement) Itemscope() *EmbedElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1389,7 +1342,7 @@ func (e *EmbedElement) Itemscope() *EmbedElement {
 // <object>, <source>, <track>, and <video>.
 func (e *EmbedElement) IfItemscope(condition bool) *EmbedElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1403,10 +1356,8 @@ func (e *EmbedElement) IfItemscope(condition bool) *EmbedElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *EmbedElement) ItemscopeRemove() *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1419,10 +1370,8 @@ func (e *EmbedElement) ItemscopeRemove() *EmbedElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *EmbedElement) ItemscopeIfRemove() *EmbedElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1434,10 +1383,8 @@ func (e *EmbedElement) ItemscopeIfRemove() *EmbedElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *EmbedElement) Itemtype(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1461,7 +1408,7 @@ func (e *EmbedElement) Itemtypef(format string, args ...any) *EmbedElement {
 // <video>.
 func (e *EmbedElement) IfItemtype(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1475,7 +1422,7 @@ func (e *EmbedElement) IfItemtype(condition bool, s string) *EmbedElement {
 // <video>.
 func (e *EmbedElement) IfItemtypef(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1489,10 +1436,8 @@ func (e *EmbedElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *EmbedElement) ItemtypeRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1502,10 +1447,8 @@ func (e *EmbedElement) ItemtypeRemove() *EmbedElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *EmbedElement) Lang(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1525,7 +1468,7 @@ func (e *EmbedElement) Langf(format string, args ...any) *EmbedElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *EmbedElement) IfLang(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1537,7 +1480,7 @@ func (e *EmbedElement) IfLang(condition bool, s string) *EmbedElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *EmbedElement) IfLangf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1549,10 +1492,8 @@ func (e *EmbedElement) IfLangf(condition bool, format string, args ...any) *Embe
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *EmbedElement) LangRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1562,10 +1503,8 @@ func (e *EmbedElement) LangRemove() *EmbedElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *EmbedElement) Nonce(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1585,7 +1524,7 @@ func (e *EmbedElement) Noncef(format string, args ...any) *EmbedElement {
 // execute.
 func (e *EmbedElement) IfNonce(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1597,7 +1536,7 @@ func (e *EmbedElement) IfNonce(condition bool, s string) *EmbedElement {
 // execute.
 func (e *EmbedElement) IfNoncef(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1609,10 +1548,8 @@ func (e *EmbedElement) IfNoncef(condition bool, format string, args ...any) *Emb
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *EmbedElement) NonceRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1620,16 +1557,9 @@ func (e *EmbedElement) NonceRemove() *EmbedElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *EmbedElement) Part(s string) *EmbedElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1638,7 +1568,7 @@ func (e *EmbedElement) Part(s string) *EmbedElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *EmbedElement) IfPart(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1648,14 +1578,8 @@ func (e *EmbedElement) IfPart(condition bool, s string) *EmbedElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *EmbedElement) PartRemove(s ...string) *EmbedElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1666,10 +1590,8 @@ func (e *EmbedElement) PartRemove(s ...string) *EmbedElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *EmbedElement) Popover(c EmbedPopoverChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[EmbedPopoverChoice])
 	return e
 }
 
@@ -1697,10 +1619,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *EmbedElement) PopoverRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1709,10 +1629,8 @@ func (e *EmbedElement) PopoverRemove() *EmbedElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *EmbedElement) Role(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1730,7 +1648,7 @@ func (e *EmbedElement) Rolef(format string, args ...any) *EmbedElement {
 // role of an element.
 func (e *EmbedElement) IfRole(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1741,7 +1659,7 @@ func (e *EmbedElement) IfRole(condition bool, s string) *EmbedElement {
 // role of an element.
 func (e *EmbedElement) IfRolef(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1752,10 +1670,8 @@ func (e *EmbedElement) IfRolef(condition bool, format string, args ...any) *Embe
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *EmbedElement) RoleRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1764,10 +1680,8 @@ func (e *EmbedElement) RoleRemove() *EmbedElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *EmbedElement) Slot(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1785,7 +1699,7 @@ func (e *EmbedElement) Slotf(format string, args ...any) *EmbedElement {
 // value.
 func (e *EmbedElement) IfSlot(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1796,7 +1710,7 @@ func (e *EmbedElement) IfSlot(condition bool, s string) *EmbedElement {
 // value.
 func (e *EmbedElement) IfSlotf(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1807,10 +1721,8 @@ func (e *EmbedElement) IfSlotf(condition bool, format string, args ...any) *Embe
 // value.
 // Remove the attribute Slot from the element.
 func (e *EmbedElement) SlotRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1826,10 +1738,8 @@ func (e *EmbedElement) SlotRemove() *EmbedElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *EmbedElement) Spellcheck(c EmbedSpellcheckChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[EmbedSpellcheckChoice])
 	return e
 }
 
@@ -1857,10 +1767,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *EmbedElement) SpellcheckRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1870,14 +1778,8 @@ func (e *EmbedElement) StylePairs(pairs ...string) *EmbedElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1892,14 +1794,8 @@ func (e *EmbedElement) StylePairs(pairs ...string) *EmbedElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *EmbedElement) Style(s string) *EmbedElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1907,7 +1803,7 @@ func (e *EmbedElement) Style(s string) *EmbedElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1916,7 +1812,7 @@ func (e *EmbedElement) Style(s string) *EmbedElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *EmbedElement) IfStyle(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1924,15 +1820,7 @@ func (e *EmbedElement) IfStyle(condition bool, s string) *EmbedElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *EmbedElement) StyleAdd(k string, v string) *EmbedElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1946,7 +1834,7 @@ func (e *EmbedElement) StyleAddf(k string, format string, args ...any) *EmbedEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *EmbedElement) IfStyleAdd(condition bool, k string, v string) *EmbedElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1955,7 +1843,7 @@ func (e *EmbedElement) IfStyleAdd(condition bool, k string, v string) *EmbedElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *EmbedElement) IfStyleAddf(condition bool, k string, format string, args ...any) *EmbedElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1964,21 +1852,15 @@ func (e *EmbedElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *EmbedElement) StyleMap(m map[string]string) *EmbedElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1987,14 +1869,8 @@ func (e *EmbedElement) StyleMap(m map[string]string) *EmbedElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *EmbedElement) StyleRemove(keys ...string) *EmbedElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2012,10 +1888,8 @@ func (e *EmbedElement) StyleRemove(keys ...string) *EmbedElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *EmbedElement) Tabindex(i int) *EmbedElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2034,7 +1908,7 @@ func (e *EmbedElement) Tabindex(i int) *EmbedElement {
 // their relative position in the document.
 func (e *EmbedElement) IfTabindex(condition bool, i int) *EmbedElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2054,10 +1928,8 @@ func (e *EmbedElement) IfTabindex(condition bool, i int) *EmbedElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *EmbedElement) TabindexRemove() *EmbedElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2078,10 +1950,8 @@ func (e *EmbedElement) TabindexRemove() *EmbedElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *EmbedElement) Title(s string) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2123,7 +1993,7 @@ func (e *EmbedElement) Titlef(format string, args ...any) *EmbedElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *EmbedElement) IfTitle(condition bool, s string) *EmbedElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2146,7 +2016,7 @@ func (e *EmbedElement) IfTitle(condition bool, s string) *EmbedElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *EmbedElement) IfTitlef(condition bool, format string, args ...any) *EmbedElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2169,10 +2039,8 @@ func (e *EmbedElement) IfTitlef(condition bool, format string, args ...any) *Emb
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *EmbedElement) TitleRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2181,10 +2049,8 @@ func (e *EmbedElement) TitleRemove() *EmbedElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *EmbedElement) Translate(c EmbedTranslateChoice) *EmbedElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[EmbedTranslateChoice])
 	return e
 }
 
@@ -2205,9 +2071,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *EmbedElement) TranslateRemove() *EmbedElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
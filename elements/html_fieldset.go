@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <fieldset> element is used to group several controls as well as
@@ -22,18 +20,77 @@ type FieldsetElement struct {
 // with the tag "fieldset" during rendering.
 func Fieldset(children ...ElementRenderer) *FieldsetElement {
 	e := NewElement("fieldset", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &FieldsetElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *FieldsetElement) Clone() *FieldsetElement {
+	return &FieldsetElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *FieldsetElement) CloneShallow() *FieldsetElement {
+	return &FieldsetElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *FieldsetElement) Freeze() *FieldsetElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *FieldsetElement) writable() *FieldsetElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *FieldsetElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *FieldsetElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[FieldsetAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[FieldsetContenteditableChoice]
+	case "dir":
+		return choiceValue[FieldsetDirChoice]
+	case "draggable":
+		return choiceValue[FieldsetDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[FieldsetEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[FieldsetHiddenChoice]
+	case "inputmode":
+		return choiceValue[FieldsetInputmodeChoice]
+	case "popover":
+		return choiceValue[FieldsetPopoverChoice]
+	case "spellcheck":
+		return choiceValue[FieldsetSpellcheckChoice]
+	case "translate":
+		return choiceValue[FieldsetTranslateChoice]
+	}
+	return nil
+}
+
 func (e *FieldsetElement) Children(children ...ElementRenderer) *FieldsetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *FieldsetElement) IfChildren(condition bool, children ...ElementRenderer) *FieldsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *FieldsetElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *FieldsetElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *FieldsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *FieldsetElement) BoolAttr(name string) *FieldsetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *FieldsetElement) BoolAttrRemove(name string) *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *FieldsetElement) IfBoolAttr(condition bool, name string) *FieldsetElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *FieldsetElement) BoolAttrf(format string, args ...any) *FieldsetElement
 
 func (e *FieldsetElement) IfBoolAttrf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *FieldsetElement) BoolAttrs(names ...string) *FieldsetElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *FieldsetElement) IfBoolAttrs(condition bool, names ...string) *FieldsetElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *FieldsetElement) Attr(name, value string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *FieldsetElement) RawAttr(name, value string) *FieldsetElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *FieldsetElement) IfAttr(condition bool, name, value string) *FieldsetElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *FieldsetElement) Attrf(name, format string, args ...any) *FieldsetEleme
 
 func (e *FieldsetElement) IfAttrf(condition bool, name, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *FieldsetElement) Attrs(attrs ...string) *FieldsetElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *FieldsetElement) IfAttrs(condition bool, attrs ...string) *FieldsetElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *FieldsetElement) AttrsMap(attrs map[string]string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *FieldsetElement) IfAttrsMap(condition bool, attrs map[string]string) *FieldsetElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *FieldsetElement) Text(text string) *FieldsetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *FieldsetElement) Textf(format string, args ...any) *FieldsetElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *FieldsetElement) IfText(condition bool, text string) *FieldsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *FieldsetElement) IfText(condition bool, text string) *FieldsetElement {
 
 func (e *FieldsetElement) IfTextf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *FieldsetElement) Raw(text string) *FieldsetElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *FieldsetElement) Escaped(text string) *FieldsetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *FieldsetElement) IfEscaped(condition bool, text string) *FieldsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *FieldsetElement) Escapedf(format string, args ...any) *FieldsetElement
 
 func (e *FieldsetElement) IfEscapedf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -214,10 +275,8 @@ func (e *FieldsetElement) IfEscapedf(condition bool, format string, args ...any)
 // controls grayed out. Note that form elements inside the <legend> element
 // won't be disabled.
 func (e *FieldsetElement) Disabled() *FieldsetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
@@ -229,7 +288,7 @@ func (e *FieldsetElement) Disabled() *FieldsetElement {
 // won't be disabled.
 func (e *FieldsetElement) IfDisabled(condition bool) *FieldsetElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -242,10 +301,8 @@ func (e *FieldsetElement) IfDisabled(condition bool) *FieldsetElement {
 // controls grayed out. Note that form elements inside the <legend> element
 // won't be disabled.
 func (e *FieldsetElement) DisabledRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
@@ -257,10 +314,8 @@ func (e *FieldsetElement) DisabledRemove() *FieldsetElement {
 // controls grayed out. Note that form elements inside the <legend> element
 // won't be disabled.
 func (e *FieldsetElement) DisabledIfRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
@@ -271,10 +326,8 @@ func (e *FieldsetElement) DisabledIfRemove() *FieldsetElement {
 // form attribute directly on those elements. You can check which elements are
 // associated with a form via JavaScript, using HTMLFormElement.elements.
 func (e *FieldsetElement) Form(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -296,7 +349,7 @@ func (e *FieldsetElement) Formf(format string, args ...any) *FieldsetElement {
 // associated with a form via JavaScript, using HTMLFormElement.elements.
 func (e *FieldsetElement) IfForm(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -309,7 +362,7 @@ func (e *FieldsetElement) IfForm(condition bool, s string) *FieldsetElement {
 // associated with a form via JavaScript, using HTMLFormElement.elements.
 func (e *FieldsetElement) IfFormf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -322,19 +375,15 @@ func (e *FieldsetElement) IfFormf(condition bool, format string, args ...any) *F
 // associated with a form via JavaScript, using HTMLFormElement.elements.
 // Remove the attribute Form from the element.
 func (e *FieldsetElement) FormRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // The name associated with the group.
 func (e *FieldsetElement) Name(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -346,7 +395,7 @@ func (e *FieldsetElement) Namef(format string, args ...any) *FieldsetElement {
 // The name associated with the group.
 func (e *FieldsetElement) IfName(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -354,7 +403,7 @@ func (e *FieldsetElement) IfName(condition bool, s string) *FieldsetElement {
 // The name associated with the group.
 func (e *FieldsetElement) IfNamef(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -362,10 +411,8 @@ func (e *FieldsetElement) IfNamef(condition bool, format string, args ...any) *F
 // The name associated with the group.
 // Remove the attribute Name from the element.
 func (e *FieldsetElement) NameRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -374,10 +421,8 @@ func (e *FieldsetElement) NameRemove() *FieldsetElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *FieldsetElement) Accesskey(r rune) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -387,7 +432,7 @@ func (e *FieldsetElement) Accesskey(r rune) *FieldsetElement {
 // can be generated by the keyboard).
 func (e *FieldsetElement) IfAccesskey(condition bool, r rune) *FieldsetElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -398,10 +443,8 @@ func (e *FieldsetElement) IfAccesskey(condition bool, r rune) *FieldsetElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *FieldsetElement) AccesskeyRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -417,10 +460,8 @@ func (e *FieldsetElement) AccesskeyRemove() *FieldsetElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *FieldsetElement) Autocapitalize(c FieldsetAutocapitalizeChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[FieldsetAutocapitalizeChoice])
 	return e
 }
 
@@ -454,10 +495,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *FieldsetElement) AutocapitalizeRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -476,10 +515,8 @@ func (e *FieldsetElement) AutocapitalizeRemove() *FieldsetElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FieldsetElement) Autofocus() *FieldsetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -499,7 +536,7 @@ func (e *FieldsetElement) Autofocus() *FieldsetElement {
 // created by the preceding content.
 func (e *FieldsetElement) IfAutofocus(condition bool) *FieldsetElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -520,10 +557,8 @@ func (e *FieldsetElement) IfAutofocus(condition bool) *FieldsetElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FieldsetElement) AutofocusRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -543,10 +578,8 @@ func (e *FieldsetElement) AutofocusRemove() *FieldsetElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FieldsetElement) AutofocusIfRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -555,16 +588,9 @@ func (e *FieldsetElement) AutofocusIfRemove() *FieldsetElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *FieldsetElement) Class(s string) *FieldsetElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -574,7 +600,7 @@ func (e *FieldsetElement) Class(s string) *FieldsetElement {
 // document.getElementsByClassName.
 func (e *FieldsetElement) IfClass(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -585,14 +611,8 @@ func (e *FieldsetElement) IfClass(condition bool, s string) *FieldsetElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *FieldsetElement) ClassRemove(s ...string) *FieldsetElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -600,10 +620,8 @@ func (e *FieldsetElement) ClassRemove(s ...string) *FieldsetElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *FieldsetElement) Contenteditable(c FieldsetContenteditableChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[FieldsetContenteditableChoice])
 	return e
 }
 
@@ -626,10 +644,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *FieldsetElement) ContenteditableRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -647,10 +663,8 @@ func (e *FieldsetElement) ContenteditableRemove() *FieldsetElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *FieldsetElement) Dir(c FieldsetDirChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[FieldsetDirChoice])
 	return e
 }
 
@@ -684,10 +698,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *FieldsetElement) DirRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -695,10 +707,8 @@ func (e *FieldsetElement) DirRemove() *FieldsetElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *FieldsetElement) Draggable(c FieldsetDraggableChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[FieldsetDraggableChoice])
 	return e
 }
 
@@ -724,20 +734,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *FieldsetElement) DraggableRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *FieldsetElement) Enterkeyhint(c FieldsetEnterkeyhintChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[FieldsetEnterkeyhintChoice])
 	return e
 }
 
@@ -767,10 +773,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *FieldsetElement) EnterkeyhintRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -792,16 +796,9 @@ func (e *FieldsetElement) EnterkeyhintRemove() *FieldsetElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *FieldsetElement) Exportparts(s string) *FieldsetElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -824,7 +821,7 @@ func (e *FieldsetElement) Exportparts(s string) *FieldsetElement {
 // the current structure.
 func (e *FieldsetElement) IfExportparts(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -848,14 +845,8 @@ func (e *FieldsetElement) IfExportparts(condition bool, s string) *FieldsetEleme
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *FieldsetElement) ExportpartsRemove(s ...string) *FieldsetElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -872,10 +863,8 @@ func (e *FieldsetElement) ExportpartsRemove(s ...string) *FieldsetElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *FieldsetElement) Hidden(c FieldsetHiddenChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[FieldsetHiddenChoice])
 	return e
 }
 
@@ -911,10 +900,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *FieldsetElement) HiddenRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -922,10 +909,8 @@ func (e *FieldsetElement) HiddenRemove() *FieldsetElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FieldsetElement) ID(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -941,7 +926,7 @@ func (e *FieldsetElement) IDf(format string, args ...any) *FieldsetElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FieldsetElement) IfID(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -951,7 +936,7 @@ func (e *FieldsetElement) IfID(condition bool, s string) *FieldsetElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FieldsetElement) IfIDf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -961,10 +946,8 @@ func (e *FieldsetElement) IfIDf(condition bool, format string, args ...any) *Fie
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *FieldsetElement) IDRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -979,10 +962,8 @@ func (e *FieldsetElement) IDRemove() *FieldsetElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FieldsetElement) Inert() *FieldsetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -998,7 +979,7 @@ func (e *FieldsetElement) Inert() *FieldsetElement {
 // excluding them from the accessibility tree.
 func (e *FieldsetElement) IfInert(condition bool) *FieldsetElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1015,10 +996,8 @@ func (e *FieldsetElement) IfInert(condition bool) *FieldsetElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FieldsetElement) InertRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1034,10 +1013,8 @@ func (e *FieldsetElement) InertRemove() *FieldsetElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FieldsetElement) InertIfRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1051,10 +1028,8 @@ func (e *FieldsetElement) InertIfRemove() *FieldsetElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *FieldsetElement) Inputmode(c FieldsetInputmodeChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[FieldsetInputmodeChoice])
 	return e
 }
 
@@ -1105,10 +1080,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *FieldsetElement) InputmodeRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1118,10 +1091,8 @@ func (e *FieldsetElement) InputmodeRemove() *FieldsetElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *FieldsetElement) Is(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1141,7 +1112,7 @@ func (e *FieldsetElement) Isf(format string, args ...any) *FieldsetElement {
 // and extends the element type it is being applied to.
 func (e *FieldsetElement) IfIs(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1153,7 +1124,7 @@ func (e *FieldsetElement) IfIs(condition bool, s string) *FieldsetElement {
 // and extends the element type it is being applied to.
 func (e *FieldsetElement) IfIsf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1165,10 +1136,8 @@ func (e *FieldsetElement) IfIsf(condition bool, format string, args ...any) *Fie
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *FieldsetElement) IsRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1184,10 +1153,8 @@ func (e *FieldsetElement) IsRemove() *FieldsetElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *FieldsetElement) Itemid(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1219,7 +1186,7 @@ func (e *FieldsetElement) Itemidf(format string, args ...any) *FieldsetElement {
 // how items with the same identifier are handled.
 func (e *FieldsetElement) IfItemid(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1237,7 +1204,7 @@ func (e *FieldsetElement) IfItemid(condition bool, s string) *FieldsetElement {
 // how items with the same identifier are handled.
 func (e *FieldsetElement) IfItemidf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1255,10 +1222,8 @@ func (e *FieldsetElement) IfItemidf(condition bool, format string, args ...any)
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *FieldsetElement) ItemidRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1270,10 +1235,8 @@ func (e *FieldsetElement) ItemidRemove() *FieldsetElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *FieldsetElement) Itemprop(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1297,7 +1260,7 @@ func (e *FieldsetElement) Itempropf(format string, args ...any) *FieldsetElement
 // <track>, and <video>.
 func (e *FieldsetElement) IfItemprop(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1311,7 +1274,7 @@ func (e *FieldsetElement) IfItemprop(condition bool, s string) *FieldsetElement
 // <track>, and <video>.
 func (e *FieldsetElement) IfItempropf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1325,10 +1288,8 @@ func (e *FieldsetElement) IfItempropf(condition bool, format string, args ...any
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *FieldsetElement) ItempropRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1338,10 +1299,8 @@ func (e *FieldsetElement) ItempropRemove() *FieldsetElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *FieldsetElement) Itemref(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1361,7 +1320,7 @@ func (e *FieldsetElement) Itemreff(format string, args ...any) *FieldsetElement
 // specified on elements that have an itemscope attribute specified.
 func (e *FieldsetElement) IfItemref(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1373,7 +1332,7 @@ func (e *FieldsetElement) IfItemref(condition bool, s string) *FieldsetElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *FieldsetElement) IfItemreff(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1385,10 +1344,8 @@ func (e *FieldsetElement) IfItemreff(condition bool, format string, args ...any)
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *FieldsetElement) ItemrefRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1400,10 +1357,8 @@ func (e *FieldsetElement) ItemrefRemove() *FieldsetElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FieldsetElement) Itemscope() *FieldsetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1416,7 +1371,7 @@ func (e *FieldsetElement) Itemscope() *FieldsetElement {
 // <object>, <source>, <track>, and <video>.
 func (e *FieldsetElement) IfItemscope(condition bool) *FieldsetElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1430,10 +1385,8 @@ func (e *FieldsetElement) IfItemscope(condition bool) *FieldsetElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FieldsetElement) ItemscopeRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1446,10 +1399,8 @@ func (e *FieldsetElement) ItemscopeRemove() *FieldsetElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FieldsetElement) ItemscopeIfRemove() *FieldsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1461,10 +1412,8 @@ func (e *FieldsetElement) ItemscopeIfRemove() *FieldsetElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *FieldsetElement) Itemtype(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1488,7 +1437,7 @@ func (e *FieldsetElement) Itemtypef(format string, args ...any) *FieldsetElement
 // <video>.
 func (e *FieldsetElement) IfItemtype(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1502,7 +1451,7 @@ func (e *FieldsetElement) IfItemtype(condition bool, s string) *FieldsetElement
 // <video>.
 func (e *FieldsetElement) IfItemtypef(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1516,10 +1465,8 @@ func (e *FieldsetElement) IfItemtypef(condition bool, format string, args ...any
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *FieldsetElement) ItemtypeRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1529,10 +1476,8 @@ func (e *FieldsetElement) ItemtypeRemove() *FieldsetElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FieldsetElement) Lang(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1552,7 +1497,7 @@ func (e *FieldsetElement) Langf(format string, args ...any) *FieldsetElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FieldsetElement) IfLang(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1564,7 +1509,7 @@ func (e *FieldsetElement) IfLang(condition bool, s string) *FieldsetElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FieldsetElement) IfLangf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1576,10 +1521,8 @@ func (e *FieldsetElement) IfLangf(condition bool, format string, args ...any) *F
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *FieldsetElement) LangRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1589,10 +1532,8 @@ func (e *FieldsetElement) LangRemove() *FieldsetElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *FieldsetElement) Nonce(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1612,7 +1553,7 @@ func (e *FieldsetElement) Noncef(format string, args ...any) *FieldsetElement {
 // execute.
 func (e *FieldsetElement) IfNonce(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1624,7 +1565,7 @@ func (e *FieldsetElement) IfNonce(condition bool, s string) *FieldsetElement {
 // execute.
 func (e *FieldsetElement) IfNoncef(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1636,10 +1577,8 @@ func (e *FieldsetElement) IfNoncef(condition bool, format string, args ...any) *
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *FieldsetElement) NonceRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1647,16 +1586,9 @@ func (e *FieldsetElement) NonceRemove() *FieldsetElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *FieldsetElement) Part(s string) *FieldsetElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1665,7 +1597,7 @@ func (e *FieldsetElement) Part(s string) *FieldsetElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *FieldsetElement) IfPart(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1675,14 +1607,8 @@ func (e *FieldsetElement) IfPart(condition bool, s string) *FieldsetElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *FieldsetElement) PartRemove(s ...string) *FieldsetElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1693,10 +1619,8 @@ func (e *FieldsetElement) PartRemove(s ...string) *FieldsetElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *FieldsetElement) Popover(c FieldsetPopoverChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[FieldsetPopoverChoice])
 	return e
 }
 
@@ -1724,10 +1648,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *FieldsetElement) PopoverRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1736,10 +1658,8 @@ func (e *FieldsetElement) PopoverRemove() *FieldsetElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *FieldsetElement) Role(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1757,7 +1677,7 @@ func (e *FieldsetElement) Rolef(format string, args ...any) *FieldsetElement {
 // role of an element.
 func (e *FieldsetElement) IfRole(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1768,7 +1688,7 @@ func (e *FieldsetElement) IfRole(condition bool, s string) *FieldsetElement {
 // role of an element.
 func (e *FieldsetElement) IfRolef(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1779,10 +1699,8 @@ func (e *FieldsetElement) IfRolef(condition bool, format string, args ...any) *F
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *FieldsetElement) RoleRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1791,10 +1709,8 @@ func (e *FieldsetElement) RoleRemove() *FieldsetElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *FieldsetElement) Slot(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1812,7 +1728,7 @@ func (e *FieldsetElement) Slotf(format string, args ...any) *FieldsetElement {
 // value.
 func (e *FieldsetElement) IfSlot(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1823,7 +1739,7 @@ func (e *FieldsetElement) IfSlot(condition bool, s string) *FieldsetElement {
 // value.
 func (e *FieldsetElement) IfSlotf(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1834,10 +1750,8 @@ func (e *FieldsetElement) IfSlotf(condition bool, format string, args ...any) *F
 // value.
 // Remove the attribute Slot from the element.
 func (e *FieldsetElement) SlotRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1853,10 +1767,8 @@ func (e *FieldsetElement) SlotRemove() *FieldsetElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *FieldsetElement) Spellcheck(c FieldsetSpellcheckChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[FieldsetSpellcheckChoice])
 	return e
 }
 
@@ -1884,10 +1796,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *FieldsetElement) SpellcheckRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1897,14 +1807,8 @@ func (e *FieldsetElement) StylePairs(pairs ...string) *FieldsetElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1919,14 +1823,8 @@ func (e *FieldsetElement) StylePairs(pairs ...string) *FieldsetElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *FieldsetElement) Style(s string) *FieldsetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1934,7 +1832,7 @@ func (e *FieldsetElement) Style(s string) *FieldsetElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1943,7 +1841,7 @@ func (e *FieldsetElement) Style(s string) *FieldsetElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *FieldsetElement) IfStyle(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1951,15 +1849,7 @@ func (e *FieldsetElement) IfStyle(condition bool, s string) *FieldsetElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *FieldsetElement) StyleAdd(k string, v string) *FieldsetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1973,7 +1863,7 @@ func (e *FieldsetElement) StyleAddf(k string, format string, args ...any) *Field
 // color, font, size, and more. Styles are written in CSS.
 func (e *FieldsetElement) IfStyleAdd(condition bool, k string, v string) *FieldsetElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1982,7 +1872,7 @@ func (e *FieldsetElement) IfStyleAdd(condition bool, k string, v string) *Fields
 // color, font, size, and more. Styles are written in CSS.
 func (e *FieldsetElement) IfStyleAddf(condition bool, k string, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1991,21 +1881,15 @@ func (e *FieldsetElement) IfStyleAddf(condition bool, k string, format string, a
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *FieldsetElement) StyleMap(m map[string]string) *FieldsetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2014,14 +1898,8 @@ func (e *FieldsetElement) StyleMap(m map[string]string) *FieldsetElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *FieldsetElement) StyleRemove(keys ...string) *FieldsetElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2039,10 +1917,8 @@ func (e *FieldsetElement) StyleRemove(keys ...string) *FieldsetElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *FieldsetElement) Tabindex(i int) *FieldsetElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2061,7 +1937,7 @@ func (e *FieldsetElement) Tabindex(i int) *FieldsetElement {
 // their relative position in the document.
 func (e *FieldsetElement) IfTabindex(condition bool, i int) *FieldsetElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2081,10 +1957,8 @@ func (e *FieldsetElement) IfTabindex(condition bool, i int) *FieldsetElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *FieldsetElement) TabindexRemove() *FieldsetElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2105,10 +1979,8 @@ func (e *FieldsetElement) TabindexRemove() *FieldsetElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *FieldsetElement) Title(s string) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2150,7 +2022,7 @@ func (e *FieldsetElement) Titlef(format string, args ...any) *FieldsetElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *FieldsetElement) IfTitle(condition bool, s string) *FieldsetElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2173,7 +2045,7 @@ func (e *FieldsetElement) IfTitle(condition bool, s string) *FieldsetElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *FieldsetElement) IfTitlef(condition bool, format string, args ...any) *FieldsetElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2196,10 +2068,8 @@ func (e *FieldsetElement) IfTitlef(condition bool, format string, args ...any) *
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *FieldsetElement) TitleRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2208,10 +2078,8 @@ func (e *FieldsetElement) TitleRemove() *FieldsetElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *FieldsetElement) Translate(c FieldsetTranslateChoice) *FieldsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[FieldsetTranslateChoice])
 	return e
 }
 
@@ -2232,9 +2100,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *FieldsetElement) TranslateRemove() *FieldsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <footer> element represents a footer for its nearest sectioning
@@ -23,18 +21,77 @@ type FooterElement struct {
 // with the tag "footer" during rendering.
 func Footer(children ...ElementRenderer) *FooterElement {
 	e := NewElement("footer", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &FooterElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *FooterElement) Clone() *FooterElement {
+	return &FooterElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *FooterElement) CloneShallow() *FooterElement {
+	return &FooterElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *FooterElement) Freeze() *FooterElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *FooterElement) writable() *FooterElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *FooterElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *FooterElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[FooterAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[FooterContenteditableChoice]
+	case "dir":
+		return choiceValue[FooterDirChoice]
+	case "draggable":
+		return choiceValue[FooterDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[FooterEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[FooterHiddenChoice]
+	case "inputmode":
+		return choiceValue[FooterInputmodeChoice]
+	case "popover":
+		return choiceValue[FooterPopoverChoice]
+	case "spellcheck":
+		return choiceValue[FooterSpellcheckChoice]
+	case "translate":
+		return choiceValue[FooterTranslateChoice]
+	}
+	return nil
+}
+
 func (e *FooterElement) Children(children ...ElementRenderer) *FooterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *FooterElement) IfChildren(condition bool, children ...ElementRenderer) *FooterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *FooterElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *FooterElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *FooterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *FooterElement) BoolAttr(name string) *FooterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *FooterElement) BoolAttrRemove(name string) *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *FooterElement) IfBoolAttr(condition bool, name string) *FooterElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *FooterElement) BoolAttrf(format string, args ...any) *FooterElement {
 
 func (e *FooterElement) IfBoolAttrf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *FooterElement) BoolAttrs(names ...string) *FooterElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *FooterElement) IfBoolAttrs(condition bool, names ...string) *FooterElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *FooterElement) Attr(name, value string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *FooterElement) RawAttr(name, value string) *FooterElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *FooterElement) IfAttr(condition bool, name, value string) *FooterElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *FooterElement) Attrf(name, format string, args ...any) *FooterElement {
 
 func (e *FooterElement) IfAttrf(condition bool, name, format string, args ...any) *FooterElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *FooterElement) Attrs(attrs ...string) *FooterElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *FooterElement) IfAttrs(condition bool, attrs ...string) *FooterElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *FooterElement) AttrsMap(attrs map[string]string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *FooterElement) IfAttrsMap(condition bool, attrs map[string]string) *FooterElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *FooterElement) Text(text string) *FooterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *FooterElement) Textf(format string, args ...any) *FooterElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *FooterElement) IfText(condition bool, text string) *FooterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *FooterElement) IfText(condition bool, text string) *FooterElement {
 
 func (e *FooterElement) IfTextf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *FooterElement) Raw(text string) *FooterElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *FooterElement) Escaped(text string) *FooterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *FooterElement) IfEscaped(condition bool, text string) *FooterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *FooterElement) Escapedf(format string, args ...any) *FooterElement {
 
 func (e *FooterElement) IfEscapedf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -213,10 +274,8 @@ func (e *FooterElement) IfEscapedf(condition bool, format string, args ...any) *
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *FooterElement) Accesskey(r rune) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -226,7 +285,7 @@ func (e *FooterElement) Accesskey(r rune) *FooterElement {
 // can be generated by the keyboard).
 func (e *FooterElement) IfAccesskey(condition bool, r rune) *FooterElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -237,10 +296,8 @@ func (e *FooterElement) IfAccesskey(condition bool, r rune) *FooterElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *FooterElement) AccesskeyRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -256,10 +313,8 @@ func (e *FooterElement) AccesskeyRemove() *FooterElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *FooterElement) Autocapitalize(c FooterAutocapitalizeChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[FooterAutocapitalizeChoice])
 	return e
 }
 
@@ -293,10 +348,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *FooterElement) AutocapitalizeRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -315,10 +368,8 @@ func (e *FooterElement) AutocapitalizeRemove() *FooterElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FooterElement) Autofocus() *FooterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -338,7 +389,7 @@ func (e *FooterElement) Autofocus() *FooterElement {
 // created by the preceding content.
 func (e *FooterElement) IfAutofocus(condition bool) *FooterElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -359,10 +410,8 @@ func (e *FooterElement) IfAutofocus(condition bool) *FooterElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FooterElement) AutofocusRemove() *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -382,10 +431,8 @@ func (e *FooterElement) AutofocusRemove() *FooterElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FooterElement) AutofocusIfRemove() *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -394,16 +441,9 @@ func (e *FooterElement) AutofocusIfRemove() *FooterElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *FooterElement) Class(s string) *FooterElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +453,7 @@ func (e *FooterElement) Class(s string) *FooterElement {
 // document.getElementsByClassName.
 func (e *FooterElement) IfClass(condition bool, s string) *FooterElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -424,14 +464,8 @@ func (e *FooterElement) IfClass(condition bool, s string) *FooterElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *FooterElement) ClassRemove(s ...string) *FooterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -439,10 +473,8 @@ func (e *FooterElement) ClassRemove(s ...string) *FooterElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *FooterElement) Contenteditable(c FooterContenteditableChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[FooterContenteditableChoice])
 	return e
 }
 
@@ -465,10 +497,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *FooterElement) ContenteditableRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -486,10 +516,8 @@ func (e *FooterElement) ContenteditableRemove() *FooterElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *FooterElement) Dir(c FooterDirChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[FooterDirChoice])
 	return e
 }
 
@@ -523,10 +551,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *FooterElement) DirRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -534,10 +560,8 @@ func (e *FooterElement) DirRemove() *FooterElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *FooterElement) Draggable(c FooterDraggableChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[FooterDraggableChoice])
 	return e
 }
 
@@ -563,20 +587,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *FooterElement) DraggableRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *FooterElement) Enterkeyhint(c FooterEnterkeyhintChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[FooterEnterkeyhintChoice])
 	return e
 }
 
@@ -606,10 +626,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *FooterElement) EnterkeyhintRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -631,16 +649,9 @@ func (e *FooterElement) EnterkeyhintRemove() *FooterElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *FooterElement) Exportparts(s string) *FooterElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -663,7 +674,7 @@ func (e *FooterElement) Exportparts(s string) *FooterElement {
 // the current structure.
 func (e *FooterElement) IfExportparts(condition bool, s string) *FooterElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -687,14 +698,8 @@ func (e *FooterElement) IfExportparts(condition bool, s string) *FooterElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *FooterElement) ExportpartsRemove(s ...string) *FooterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -711,10 +716,8 @@ func (e *FooterElement) ExportpartsRemove(s ...string) *FooterElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *FooterElement) Hidden(c FooterHiddenChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[FooterHiddenChoice])
 	return e
 }
 
@@ -750,10 +753,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *FooterElement) HiddenRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -761,10 +762,8 @@ func (e *FooterElement) HiddenRemove() *FooterElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FooterElement) ID(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -780,7 +779,7 @@ func (e *FooterElement) IDf(format string, args ...any) *FooterElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FooterElement) IfID(condition bool, s string) *FooterElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -790,7 +789,7 @@ func (e *FooterElement) IfID(condition bool, s string) *FooterElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FooterElement) IfIDf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -800,10 +799,8 @@ func (e *FooterElement) IfIDf(condition bool, format string, args ...any) *Foote
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *FooterElement) IDRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -818,10 +815,8 @@ func (e *FooterElement) IDRemove() *FooterElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FooterElement) Inert() *FooterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -837,7 +832,7 @@ func (e *FooterElement) Inert() *FooterElement {
 // excluding them from the accessibility tree.
 func (e *FooterElement) IfInert(condition bool) *FooterElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -854,10 +849,8 @@ func (e *FooterElement) IfInert(condition bool) *FooterElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FooterElement) InertRemove() *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -873,10 +866,8 @@ func (e *FooterElement) InertRemove() *FooterElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FooterElement) InertIfRemove() *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -890,10 +881,8 @@ func (e *FooterElement) InertIfRemove() *FooterElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *FooterElement) Inputmode(c FooterInputmodeChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[FooterInputmodeChoice])
 	return e
 }
 
@@ -944,10 +933,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *FooterElement) InputmodeRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -957,10 +944,8 @@ func (e *FooterElement) InputmodeRemove() *FooterElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *FooterElement) Is(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -980,7 +965,7 @@ func (e *FooterElement) Isf(format string, args ...any) *FooterElement {
 // and extends the element type it is being applied to.
 func (e *FooterElement) IfIs(condition bool, s string) *FooterElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -992,7 +977,7 @@ func (e *FooterElement) IfIs(condition bool, s string) *FooterElement {
 // and extends the element type it is being applied to.
 func (e *FooterElement) IfIsf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1004,10 +989,8 @@ func (e *FooterElement) IfIsf(condition bool, format string, args ...any) *Foote
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *FooterElement) IsRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1023,10 +1006,8 @@ func (e *FooterElement) IsRemove() *FooterElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *FooterElement) Itemid(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1058,7 +1039,7 @@ func (e *FooterElement) Itemidf(format string, args ...any) *FooterElement {
 // how items with the same identifier are handled.
 func (e *FooterElement) IfItemid(condition bool, s string) *FooterElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1076,7 +1057,7 @@ func (e *FooterElement) IfItemid(condition bool, s string) *FooterElement {
 // how items with the same identifier are handled.
 func (e *FooterElement) IfItemidf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1094,10 +1075,8 @@ func (e *FooterElement) IfItemidf(condition bool, format string, args ...any) *F
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *FooterElement) ItemidRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1109,10 +1088,8 @@ func (e *FooterElement) ItemidRemove() *FooterElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *FooterElement) Itemprop(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1136,7 +1113,7 @@ func (e *FooterElement) Itempropf(format string, args ...any) *FooterElement {
 // <track>, and <video>.
 func (e *FooterElement) IfItemprop(condition bool, s string) *FooterElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1150,7 +1127,7 @@ func (e *FooterElement) IfItemprop(condition bool, s string) *FooterElement {
 // <track>, and <video>.
 func (e *FooterElement) IfItempropf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1164,10 +1141,8 @@ func (e *FooterElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *FooterElement) ItempropRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1177,10 +1152,8 @@ func (e *FooterElement) ItempropRemove() *FooterElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *FooterElement) Itemref(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1200,7 +1173,7 @@ func (e *FooterElement) Itemreff(format string, args ...any) *FooterElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *FooterElement) IfItemref(condition bool, s string) *FooterElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1212,7 +1185,7 @@ func (e *FooterElement) IfItemref(condition bool, s string) *FooterElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *FooterElement) IfItemreff(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1224,10 +1197,8 @@ func (e *FooterElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *FooterElement) ItemrefRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1239,10 +1210,8 @@ func (e *FooterElement) ItemrefRemove() *FooterElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FooterElement) Itemscope() *FooterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1255,7 +1224,7 @@ func (e *FooterElement) Itemscope() *FooterElement {
 // <object>, <source>, <track>, and <video>.
 func (e *FooterElement) IfItemscope(condition bool) *FooterElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1269,10 +1238,8 @@ func (e *FooterElement) IfItemscope(condition bool) *FooterElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FooterElement) ItemscopeRemove() *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1285,10 +1252,8 @@ func (e *FooterElement) ItemscopeRemove() *FooterElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FooterElement) ItemscopeIfRemove() *FooterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1300,10 +1265,8 @@ func (e *FooterElement) ItemscopeIfRemove() *FooterElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *FooterElement) Itemtype(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1327,7 +1290,7 @@ func (e *FooterElement) Itemtypef(format string, args ...any) *FooterElement {
 // <video>.
 func (e *FooterElement) IfItemtype(condition bool, s string) *FooterElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1341,7 +1304,7 @@ func (e *FooterElement) IfItemtype(condition bool, s string) *FooterElement {
 // <video>.
 func (e *FooterElement) IfItemtypef(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1355,10 +1318,8 @@ func (e *FooterElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *FooterElement) ItemtypeRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1368,10 +1329,8 @@ func (e *FooterElement) ItemtypeRemove() *FooterElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FooterElement) Lang(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1391,7 +1350,7 @@ func (e *FooterElement) Langf(format string, args ...any) *FooterElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FooterElement) IfLang(condition bool, s string) *FooterElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1403,7 +1362,7 @@ func (e *FooterElement) IfLang(condition bool, s string) *FooterElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FooterElement) IfLangf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1415,10 +1374,8 @@ func (e *FooterElement) IfLangf(condition bool, format string, args ...any) *Foo
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *FooterElement) LangRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1428,10 +1385,8 @@ func (e *FooterElement) LangRemove() *FooterElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *FooterElement) Nonce(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1451,7 +1406,7 @@ func (e *FooterElement) Noncef(format string, args ...any) *FooterElement {
 // execute.
 func (e *FooterElement) IfNonce(condition bool, s string) *FooterElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1463,7 +1418,7 @@ func (e *FooterElement) IfNonce(condition bool, s string) *FooterElement {
 // execute.
 func (e *FooterElement) IfNoncef(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1475,10 +1430,8 @@ func (e *FooterElement) IfNoncef(condition bool, format string, args ...any) *Fo
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *FooterElement) NonceRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1486,16 +1439,9 @@ func (e *FooterElement) NonceRemove() *FooterElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *FooterElement) Part(s string) *FooterElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1504,7 +1450,7 @@ func (e *FooterElement) Part(s string) *FooterElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *FooterElement) IfPart(condition bool, s string) *FooterElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1514,14 +1460,8 @@ func (e *FooterElement) IfPart(condition bool, s string) *FooterElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *FooterElement) PartRemove(s ...string) *FooterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1532,10 +1472,8 @@ func (e *FooterElement) PartRemove(s ...string) *FooterElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *FooterElement) Popover(c FooterPopoverChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[FooterPopoverChoice])
 	return e
 }
 
@@ -1563,10 +1501,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *FooterElement) PopoverRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1575,10 +1511,8 @@ func (e *FooterElement) PopoverRemove() *FooterElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *FooterElement) Role(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1596,7 +1530,7 @@ func (e *FooterElement) Rolef(format string, args ...any) *FooterElement {
 // role of an element.
 func (e *FooterElement) IfRole(condition bool, s string) *FooterElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1607,7 +1541,7 @@ func (e *FooterElement) IfRole(condition bool, s string) *FooterElement {
 // role of an element.
 func (e *FooterElement) IfRolef(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1618,10 +1552,8 @@ func (e *FooterElement) IfRolef(condition bool, format string, args ...any) *Foo
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *FooterElement) RoleRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1630,10 +1562,8 @@ func (e *FooterElement) RoleRemove() *FooterElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *FooterElement) Slot(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1651,7 +1581,7 @@ func (e *FooterElement) Slotf(format string, args ...any) *FooterElement {
 // value.
 func (e *FooterElement) IfSlot(condition bool, s string) *FooterElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1662,7 +1592,7 @@ func (e *FooterElement) IfSlot(condition bool, s string) *FooterElement {
 // value.
 func (e *FooterElement) IfSlotf(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1673,10 +1603,8 @@ func (e *FooterElement) IfSlotf(condition bool, format string, args ...any) *Foo
 // value.
 // Remove the attribute Slot from the element.
 func (e *FooterElement) SlotRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1692,10 +1620,8 @@ func (e *FooterElement) SlotRemove() *FooterElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *FooterElement) Spellcheck(c FooterSpellcheckChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[FooterSpellcheckChoice])
 	return e
 }
 
@@ -1723,10 +1649,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *FooterElement) SpellcheckRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1736,14 +1660,8 @@ func (e *FooterElement) StylePairs(pairs ...string) *FooterElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1758,14 +1676,8 @@ func (e *FooterElement) StylePairs(pairs ...string) *FooterElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *FooterElement) Style(s string) *FooterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1773,7 +1685,7 @@ func (e *FooterElement) Style(s string) *FooterElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1782,7 +1694,7 @@ func (e *FooterElement) Style(s string) *FooterElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *FooterElement) IfStyle(condition bool, s string) *FooterElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1790,15 +1702,7 @@ func (e *FooterElement) IfStyle(condition bool, s string) *FooterElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *FooterElement) StyleAdd(k string, v string) *FooterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1812,7 +1716,7 @@ func (e *FooterElement) StyleAddf(k string, format string, args ...any) *FooterE
 // color, font, size, and more. Styles are written in CSS.
 func (e *FooterElement) IfStyleAdd(condition bool, k string, v string) *FooterElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1821,7 +1725,7 @@ func (e *FooterElement) IfStyleAdd(condition bool, k string, v string) *FooterEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *FooterElement) IfStyleAddf(condition bool, k string, format string, args ...any) *FooterElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1830,21 +1734,15 @@ func (e *FooterElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *FooterElement) StyleMap(m map[string]string) *FooterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1853,14 +1751,8 @@ func (e *FooterElement) StyleMap(m map[string]string) *FooterElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *FooterElement) StyleRemove(keys ...string) *FooterElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1878,10 +1770,8 @@ func (e *FooterElement) StyleRemove(keys ...string) *FooterElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *FooterElement) Tabindex(i int) *FooterElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1900,7 +1790,7 @@ func (e *FooterElement) Tabindex(i int) *FooterElement {
 // their relative position in the document.
 func (e *FooterElement) IfTabindex(condition bool, i int) *FooterElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1920,10 +1810,8 @@ func (e *FooterElement) IfTabindex(condition bool, i int) *FooterElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *FooterElement) TabindexRemove() *FooterElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1944,10 +1832,8 @@ func (e *FooterElement) TabindexRemove() *FooterElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *FooterElement) Title(s string) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1989,7 +1875,7 @@ func (e *FooterElement) Titlef(format string, args ...any) *FooterElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *FooterElement) IfTitle(condition bool, s string) *FooterElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2012,7 +1898,7 @@ func (e *FooterElement) IfTitle(condition bool, s string) *FooterElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *FooterElement) IfTitlef(condition bool, format string, args ...any) *FooterElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2035,10 +1921,8 @@ func (e *FooterElement) IfTitlef(condition bool, format string, args ...any) *Fo
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *FooterElement) TitleRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2047,10 +1931,8 @@ func (e *FooterElement) TitleRemove() *FooterElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *FooterElement) Translate(c FooterTranslateChoice) *FooterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[FooterTranslateChoice])
 	return e
 }
 
@@ -2071,9 +1953,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *FooterElement) TranslateRemove() *FooterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <form> element represents a document section containing interactive
@@ -22,18 +20,85 @@ type FormElement struct {
 // with the tag "form" during rendering.
 func Form(children ...ElementRenderer) *FormElement {
 	e := NewElement("form", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &FormElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *FormElement) Clone() *FormElement {
+	return &FormElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *FormElement) CloneShallow() *FormElement {
+	return &FormElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *FormElement) Freeze() *FormElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *FormElement) writable() *FormElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *FormElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *FormElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocomplete":
+		return choiceValue[FormAutocompleteChoice]
+	case "enctype":
+		return choiceValue[FormEnctypeChoice]
+	case "method":
+		return choiceValue[FormMethodChoice]
+	case "target":
+		return choiceValue[FormTargetChoice]
+	case "autocapitalize":
+		return choiceValue[FormAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[FormContenteditableChoice]
+	case "dir":
+		return choiceValue[FormDirChoice]
+	case "draggable":
+		return choiceValue[FormDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[FormEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[FormHiddenChoice]
+	case "inputmode":
+		return choiceValue[FormInputmodeChoice]
+	case "popover":
+		return choiceValue[FormPopoverChoice]
+	case "spellcheck":
+		return choiceValue[FormSpellcheckChoice]
+	case "translate":
+		return choiceValue[FormTranslateChoice]
+	}
+	return nil
+}
+
 func (e *FormElement) Children(children ...ElementRenderer) *FormElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *FormElement) IfChildren(condition bool, children ...ElementRenderer) *FormElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +106,30 @@ func (e *FormElement) IfChildren(condition bool, children ...ElementRenderer) *F
 
 func (e *FormElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *FormElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *FormElement) BoolAttr(name string) *FormElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *FormElement) BoolAttrRemove(name string) *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *FormElement) IfBoolAttr(condition bool, name string) *FormElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +140,40 @@ func (e *FormElement) BoolAttrf(format string, args ...any) *FormElement {
 
 func (e *FormElement) IfBoolAttrf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *FormElement) BoolAttrs(names ...string) *FormElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *FormElement) IfBoolAttrs(condition bool, names ...string) *FormElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *FormElement) Attr(name, value string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *FormElement) RawAttr(name, value string) *FormElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *FormElement) IfAttr(condition bool, name, value string) *FormElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +184,52 @@ func (e *FormElement) Attrf(name, format string, args ...any) *FormElement {
 
 func (e *FormElement) IfAttrf(condition bool, name, format string, args ...any) *FormElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *FormElement) Attrs(attrs ...string) *FormElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *FormElement) IfAttrs(condition bool, attrs ...string) *FormElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *FormElement) AttrsMap(attrs map[string]string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *FormElement) IfAttrsMap(condition bool, attrs map[string]string) *FormElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *FormElement) Text(text string) *FormElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *FormElement) Textf(format string, args ...any) *FormElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *FormElement) IfText(condition bool, text string) *FormElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +237,28 @@ func (e *FormElement) IfText(condition bool, text string) *FormElement {
 
 func (e *FormElement) IfTextf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *FormElement) Raw(text string) *FormElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *FormElement) Escaped(text string) *FormElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *FormElement) IfEscaped(condition bool, text string) *FormElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +270,7 @@ func (e *FormElement) Escapedf(format string, args ...any) *FormElement {
 
 func (e *FormElement) IfEscapedf(condition bool, format string, args ...any) *FormElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +279,8 @@ func (e *FormElement) IfEscapedf(condition bool, format string, args ...any) *Fo
 // Specifies the character encodings that are to be used for the form
 // submission.
 func (e *FormElement) AcceptCharset(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accept-charset", s)
+	e = e.writable()
+	e.setStringAttribute("accept-charset", s)
 	return e
 }
 
@@ -227,7 +294,7 @@ func (e *FormElement) AcceptCharsetf(format string, args ...any) *FormElement {
 // submission.
 func (e *FormElement) IfAcceptCharset(condition bool, s string) *FormElement {
 	if condition {
-		e.AcceptCharset(s)
+		e = e.AcceptCharset(s)
 	}
 	return e
 }
@@ -236,7 +303,7 @@ func (e *FormElement) IfAcceptCharset(condition bool, s string) *FormElement {
 // submission.
 func (e *FormElement) IfAcceptCharsetf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.AcceptCharset(fmt.Sprintf(format, args...))
+		e = e.AcceptCharset(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -245,20 +312,16 @@ func (e *FormElement) IfAcceptCharsetf(condition bool, format string, args ...an
 // submission.
 // Remove the attribute AcceptCharset from the element.
 func (e *FormElement) AcceptCharsetRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accept-charset")
+	e = e.writable()
+	e.removeAttribute("accept-charset")
 	return e
 }
 
 // Specifies where to send the form-data when a form is submitted. Only for
 // type="submit" and type="image".
 func (e *FormElement) Action(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("action", s)
+	e = e.writable()
+	e.setStringAttribute("action", s)
 	return e
 }
 
@@ -272,7 +335,7 @@ func (e *FormElement) Actionf(format string, args ...any) *FormElement {
 // type="submit" and type="image".
 func (e *FormElement) IfAction(condition bool, s string) *FormElement {
 	if condition {
-		e.Action(s)
+		e = e.Action(s)
 	}
 	return e
 }
@@ -281,7 +344,7 @@ func (e *FormElement) IfAction(condition bool, s string) *FormElement {
 // type="submit" and type="image".
 func (e *FormElement) IfActionf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Action(fmt.Sprintf(format, args...))
+		e = e.Action(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -290,20 +353,16 @@ func (e *FormElement) IfActionf(condition bool, format string, args ...any) *For
 // type="submit" and type="image".
 // Remove the attribute Action from the element.
 func (e *FormElement) ActionRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("action")
+	e = e.writable()
+	e.removeAttribute("action")
 	return e
 }
 
 // Indicates whether controls in this form can by default have their values
 // automatically completed by the browser.
 func (e *FormElement) Autocomplete(c FormAutocompleteChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocomplete", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocomplete", string(c), choiceValue[FormAutocompleteChoice])
 	return e
 }
 
@@ -320,19 +379,15 @@ const (
 // automatically completed by the browser.
 // Remove the attribute Autocomplete from the element.
 func (e *FormElement) AutocompleteRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocomplete")
+	e = e.writable()
+	e.removeAttribute("autocomplete")
 	return e
 }
 
 // Defines the content type of the form data when the method is POST.
 func (e *FormElement) Enctype(c FormEnctypeChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enctype", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enctype", string(c), choiceValue[FormEnctypeChoice])
 	return e
 }
 
@@ -351,20 +406,16 @@ const (
 // Defines the content type of the form data when the method is POST.
 // Remove the attribute Enctype from the element.
 func (e *FormElement) EnctypeRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enctype")
+	e = e.writable()
+	e.removeAttribute("enctype")
 	return e
 }
 
 // Defines which HTTP method to use when submitting the form. Can be GET
 // (default) or POST.
 func (e *FormElement) Method(c FormMethodChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("method", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("method", string(c), choiceValue[FormMethodChoice])
 	return e
 }
 
@@ -381,20 +432,16 @@ const (
 // (default) or POST.
 // Remove the attribute Method from the element.
 func (e *FormElement) MethodRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("method")
+	e = e.writable()
+	e.removeAttribute("method")
 	return e
 }
 
 // Name of the element. For example used by the server to identify the fields in
 // form submits.
 func (e *FormElement) Name(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -408,7 +455,7 @@ func (e *FormElement) Namef(format string, args ...any) *FormElement {
 // form submits.
 func (e *FormElement) IfName(condition bool, s string) *FormElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -417,7 +464,7 @@ func (e *FormElement) IfName(condition bool, s string) *FormElement {
 // form submits.
 func (e *FormElement) IfNamef(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -426,10 +473,8 @@ func (e *FormElement) IfNamef(condition bool, format string, args ...any) *FormE
 // form submits.
 // Remove the attribute Name from the element.
 func (e *FormElement) NameRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -439,10 +484,8 @@ func (e *FormElement) NameRemove() *FormElement {
 // attribute on a <button>, <input type="submit">, or <input type="image">
 // element belonging to the form.
 func (e *FormElement) Novalidate() *FormElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("novalidate", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("novalidate")
 	return e
 }
 
@@ -453,7 +496,7 @@ func (e *FormElement) Novalidate() *FormElement {
 // element belonging to the form.
 func (e *FormElement) IfNovalidate(condition bool) *FormElement {
 	if condition {
-		e.Novalidate()
+		e = e.Novalidate()
 	}
 	return e
 }
@@ -465,10 +508,8 @@ func (e *FormElement) IfNovalidate(condition bool) *FormElement {
 // attribute on a <button>, <input type="submit">, or <input type="image">
 // element belonging to the form.
 func (e *FormElement) NovalidateRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("novalidate")
+	e = e.writable()
+	e.removeAttribute("novalidate")
 	return e
 }
 
@@ -479,19 +520,15 @@ func (e *FormElement) NovalidateRemove() *FormElement {
 // attribute on a <button>, <input type="submit">, or <input type="image">
 // element belonging to the form.
 func (e *FormElement) NovalidateIfRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("novalidate")
+	e = e.writable()
+	e.removeAttribute("novalidate")
 	return e
 }
 
 // Indicates where to display the response after submitting the form.
 func (e *FormElement) Target(c FormTargetChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("target", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("target", string(c), choiceValue[FormTargetChoice])
 	return e
 }
 
@@ -515,10 +552,8 @@ const (
 // Indicates where to display the response after submitting the form.
 // Remove the attribute Target from the element.
 func (e *FormElement) TargetRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("target")
+	e = e.writable()
+	e.removeAttribute("target")
 	return e
 }
 
@@ -527,10 +562,8 @@ func (e *FormElement) TargetRemove() *FormElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *FormElement) Accesskey(r rune) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -540,7 +573,7 @@ func (e *FormElement) Accesskey(r rune) *FormElement {
 // can be generated by the keyboard).
 func (e *FormElement) IfAccesskey(condition bool, r rune) *FormElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -551,10 +584,8 @@ func (e *FormElement) IfAccesskey(condition bool, r rune) *FormElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *FormElement) AccesskeyRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -570,10 +601,8 @@ func (e *FormElement) AccesskeyRemove() *FormElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *FormElement) Autocapitalize(c FormAutocapitalizeChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[FormAutocapitalizeChoice])
 	return e
 }
 
@@ -607,10 +636,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *FormElement) AutocapitalizeRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -629,10 +656,8 @@ func (e *FormElement) AutocapitalizeRemove() *FormElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FormElement) Autofocus() *FormElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -652,7 +677,7 @@ func (e *FormElement) Autofocus() *FormElement {
 // created by the preceding content.
 func (e *FormElement) IfAutofocus(condition bool) *FormElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -673,10 +698,8 @@ func (e *FormElement) IfAutofocus(condition bool) *FormElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FormElement) AutofocusRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -696,10 +719,8 @@ func (e *FormElement) AutofocusRemove() *FormElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *FormElement) AutofocusIfRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -708,16 +729,9 @@ func (e *FormElement) AutofocusIfRemove() *FormElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *FormElement) Class(s string) *FormElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -727,7 +741,7 @@ func (e *FormElement) Class(s string) *FormElement {
 // document.getElementsByClassName.
 func (e *FormElement) IfClass(condition bool, s string) *FormElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -738,14 +752,8 @@ func (e *FormElement) IfClass(condition bool, s string) *FormElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *FormElement) ClassRemove(s ...string) *FormElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -753,10 +761,8 @@ func (e *FormElement) ClassRemove(s ...string) *FormElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *FormElement) Contenteditable(c FormContenteditableChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[FormContenteditableChoice])
 	return e
 }
 
@@ -779,10 +785,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *FormElement) ContenteditableRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -800,10 +804,8 @@ func (e *FormElement) ContenteditableRemove() *FormElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *FormElement) Dir(c FormDirChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[FormDirChoice])
 	return e
 }
 
@@ -837,10 +839,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *FormElement) DirRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -848,10 +848,8 @@ func (e *FormElement) DirRemove() *FormElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *FormElement) Draggable(c FormDraggableChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[FormDraggableChoice])
 	return e
 }
 
@@ -877,20 +875,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *FormElement) DraggableRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *FormElement) Enterkeyhint(c FormEnterkeyhintChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[FormEnterkeyhintChoice])
 	return e
 }
 
@@ -920,10 +914,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *FormElement) EnterkeyhintRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -945,16 +937,9 @@ func (e *FormElement) EnterkeyhintRemove() *FormElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *FormElement) Exportparts(s string) *FormElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -977,7 +962,7 @@ func (e *FormElement) Exportparts(s string) *FormElement {
 // the current structure.
 func (e *FormElement) IfExportparts(condition bool, s string) *FormElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1001,14 +986,8 @@ func (e *FormElement) IfExportparts(condition bool, s string) *FormElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *FormElement) ExportpartsRemove(s ...string) *FormElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1025,10 +1004,8 @@ func (e *FormElement) ExportpartsRemove(s ...string) *FormElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *FormElement) Hidden(c FormHiddenChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[FormHiddenChoice])
 	return e
 }
 
@@ -1064,10 +1041,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *FormElement) HiddenRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1075,10 +1050,8 @@ func (e *FormElement) HiddenRemove() *FormElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FormElement) ID(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1094,7 +1067,7 @@ func (e *FormElement) IDf(format string, args ...any) *FormElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FormElement) IfID(condition bool, s string) *FormElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1104,7 +1077,7 @@ func (e *FormElement) IfID(condition bool, s string) *FormElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *FormElement) IfIDf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1114,10 +1087,8 @@ func (e *FormElement) IfIDf(condition bool, format string, args ...any) *FormEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *FormElement) IDRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1132,10 +1103,8 @@ func (e *FormElement) IDRemove() *FormElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FormElement) Inert() *FormElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1151,7 +1120,7 @@ func (e *FormElement) Inert() *FormElement {
 // excluding them from the accessibility tree.
 func (e *FormElement) IfInert(condition bool) *FormElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1168,10 +1137,8 @@ func (e *FormElement) IfInert(condition bool) *FormElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FormElement) InertRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1187,10 +1154,8 @@ func (e *FormElement) InertRemove() *FormElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *FormElement) InertIfRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1204,10 +1169,8 @@ func (e *FormElement) InertIfRemove() *FormElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *FormElement) Inputmode(c FormInputmodeChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[FormInputmodeChoice])
 	return e
 }
 
@@ -1258,10 +1221,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *FormElement) InputmodeRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1271,10 +1232,8 @@ func (e *FormElement) InputmodeRemove() *FormElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *FormElement) Is(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1294,7 +1253,7 @@ func (e *FormElement) Isf(format string, args ...any) *FormElement {
 // and extends the element type it is being applied to.
 func (e *FormElement) IfIs(condition bool, s string) *FormElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1306,7 +1265,7 @@ func (e *FormElement) IfIs(condition bool, s string) *FormElement {
 // and extends the element type it is being applied to.
 func (e *FormElement) IfIsf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1318,10 +1277,8 @@ func (e *FormElement) IfIsf(condition bool, format string, args ...any) *FormEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *FormElement) IsRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1337,10 +1294,8 @@ func (e *FormElement) IsRemove() *FormElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *FormElement) Itemid(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1372,7 +1327,7 @@ func (e *FormElement) Itemidf(format string, args ...any) *FormElement {
 // how items with the same identifier are handled.
 func (e *FormElement) IfItemid(condition bool, s string) *FormElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1390,7 +1345,7 @@ func (e *FormElement) IfItemid(condition bool, s string) *FormElement {
 // how items with the same identifier are handled.
 func (e *FormElement) IfItemidf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1408,10 +1363,8 @@ func (e *FormElement) IfItemidf(condition bool, format string, args ...any) *For
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *FormElement) ItemidRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1423,10 +1376,8 @@ func (e *FormElement) ItemidRemove() *FormElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *FormElement) Itemprop(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1450,7 +1401,7 @@ func (e *FormElement) Itempropf(format string, args ...any) *FormElement {
 // <track>, and <video>.
 func (e *FormElement) IfItemprop(condition bool, s string) *FormElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1464,7 +1415,7 @@ func (e *FormElement) IfItemprop(condition bool, s string) *FormElement {
 // <track>, and <video>.
 func (e *FormElement) IfItempropf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1478,10 +1429,8 @@ func (e *FormElement) IfItempropf(condition bool, format string, args ...any) *F
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *FormElement) ItempropRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1491,10 +1440,8 @@ func (e *FormElement) ItempropRemove() *FormElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *FormElement) Itemref(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1514,7 +1461,7 @@ func (e *FormElement) Itemreff(format string, args ...any) *FormElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *FormElement) IfItemref(condition bool, s string) *FormElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1526,7 +1473,7 @@ func (e *FormElement) IfItemref(condition bool, s string) *FormElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *FormElement) IfItemreff(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1538,10 +1485,8 @@ func (e *FormElement) IfItemreff(condition bool, format string, args ...any) *Fo
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *FormElement) ItemrefRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1553,10 +1498,8 @@ func (e *FormElement) ItemrefRemove() *FormElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FormElement) Itemscope() *FormElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1569,7 +1512,7 @@ func (e *FormElement) Itemscope() *FormElement {
 // <object>, <source>, <track>, and <video>.
 func (e *FormElement) IfItemscope(condition bool) *FormElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1583,10 +1526,8 @@ func (e *FormElement) IfItemscope(condition bool) *FormElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FormElement) ItemscopeRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1599,10 +1540,8 @@ func (e *FormElement) ItemscopeRemove() *FormElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *FormElement) ItemscopeIfRemove() *FormElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1614,10 +1553,8 @@ func (e *FormElement) ItemscopeIfRemove() *FormElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *FormElement) Itemtype(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1641,7 +1578,7 @@ func (e *FormElement) Itemtypef(format string, args ...any) *FormElement {
 // <video>.
 func (e *FormElement) IfItemtype(condition bool, s string) *FormElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1655,7 +1592,7 @@ func (e *FormElement) IfItemtype(condition bool, s string) *FormElement {
 // <video>.
 func (e *FormElement) IfItemtypef(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1669,10 +1606,8 @@ func (e *FormElement) IfItemtypef(condition bool, format string, args ...any) *F
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *FormElement) ItemtypeRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1682,10 +1617,8 @@ func (e *FormElement) ItemtypeRemove() *FormElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FormElement) Lang(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1705,7 +1638,7 @@ func (e *FormElement) Langf(format string, args ...any) *FormElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FormElement) IfLang(condition bool, s string) *FormElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1717,7 +1650,7 @@ func (e *FormElement) IfLang(condition bool, s string) *FormElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *FormElement) IfLangf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1729,10 +1662,8 @@ func (e *FormElement) IfLangf(condition bool, format string, args ...any) *FormE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *FormElement) LangRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1742,10 +1673,8 @@ func (e *FormElement) LangRemove() *FormElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *FormElement) Nonce(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1765,7 +1694,7 @@ func (e *FormElement) Noncef(format string, args ...any) *FormElement {
 // execute.
 func (e *FormElement) IfNonce(condition bool, s string) *FormElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1777,7 +1706,7 @@ func (e *FormElement) IfNonce(condition bool, s string) *FormElement {
 // execute.
 func (e *FormElement) IfNoncef(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1789,10 +1718,8 @@ func (e *FormElement) IfNoncef(condition bool, format string, args ...any) *Form
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *FormElement) NonceRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1800,16 +1727,9 @@ func (e *FormElement) NonceRemove() *FormElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *FormElement) Part(s string) *FormElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1818,7 +1738,7 @@ func (e *FormElement) Part(s string) *FormElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *FormElement) IfPart(condition bool, s string) *FormElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1828,14 +1748,8 @@ func (e *FormElement) IfPart(condition bool, s string) *FormElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *FormElement) PartRemove(s ...string) *FormElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1846,10 +1760,8 @@ func (e *FormElement) PartRemove(s ...string) *FormElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *FormElement) Popover(c FormPopoverChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[FormPopoverChoice])
 	return e
 }
 
@@ -1877,10 +1789,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *FormElement) PopoverRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1889,10 +1799,8 @@ func (e *FormElement) PopoverRemove() *FormElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *FormElement) Role(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1910,7 +1818,7 @@ func (e *FormElement) Rolef(format string, args ...any) *FormElement {
 // role of an element.
 func (e *FormElement) IfRole(condition bool, s string) *FormElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1921,7 +1829,7 @@ func (e *FormElement) IfRole(condition bool, s string) *FormElement {
 // role of an element.
 func (e *FormElement) IfRolef(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1932,10 +1840,8 @@ func (e *FormElement) IfRolef(condition bool, format string, args ...any) *FormE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *FormElement) RoleRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1944,10 +1850,8 @@ func (e *FormElement) RoleRemove() *FormElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *FormElement) Slot(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1965,7 +1869,7 @@ func (e *FormElement) Slotf(format string, args ...any) *FormElement {
 // value.
 func (e *FormElement) IfSlot(condition bool, s string) *FormElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1976,7 +1880,7 @@ func (e *FormElement) IfSlot(condition bool, s string) *FormElement {
 // value.
 func (e *FormElement) IfSlotf(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1987,10 +1891,8 @@ func (e *FormElement) IfSlotf(condition bool, format string, args ...any) *FormE
 // value.
 // Remove the attribute Slot from the element.
 func (e *FormElement) SlotRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2006,10 +1908,8 @@ func (e *FormElement) SlotRemove() *FormElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *FormElement) Spellcheck(c FormSpellcheckChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[FormSpellcheckChoice])
 	return e
 }
 
@@ -2037,10 +1937,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *FormElement) SpellcheckRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2050,14 +1948,8 @@ func (e *FormElement) StylePairs(pairs ...string) *FormElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2072,14 +1964,8 @@ func (e *FormElement) StylePairs(pairs ...string) *FormElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *FormElement) Style(s string) *FormElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2087,7 +1973,7 @@ func (e *FormElement) Style(s string) *FormElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2096,7 +1982,7 @@ func (e *FormElement) Style(s string) *FormElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *FormElement) IfStyle(condition bool, s string) *FormElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2104,15 +1990,7 @@ func (e *FormElement) IfStyle(condition bool, s string) *FormElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *FormElement) StyleAdd(k string, v string) *FormElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2126,7 +2004,7 @@ func (e *FormElement) StyleAddf(k string, format string, args ...any) *FormEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *FormElement) IfStyleAdd(condition bool, k string, v string) *FormElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2135,7 +2013,7 @@ func (e *FormElement) IfStyleAdd(condition bool, k string, v string) *FormElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *FormElement) IfStyleAddf(condition bool, k string, format string, args ...any) *FormElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2144,21 +2022,15 @@ func (e *FormElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *FormElement) StyleMap(m map[string]string) *FormElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2167,14 +2039,8 @@ func (e *FormElement) StyleMap(m map[string]string) *FormElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *FormElement) StyleRemove(keys ...string) *FormElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2192,10 +2058,8 @@ func (e *FormElement) StyleRemove(keys ...string) *FormElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *FormElement) Tabindex(i int) *FormElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2214,7 +2078,7 @@ func (e *FormElement) Tabindex(i int) *FormElement {
 // their relative position in the document.
 func (e *FormElement) IfTabindex(condition bool, i int) *FormElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2234,10 +2098,8 @@ func (e *FormElement) IfTabindex(condition bool, i int) *FormElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *FormElement) TabindexRemove() *FormElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2258,10 +2120,8 @@ func (e *FormElement) TabindexRemove() *FormElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *FormElement) Title(s string) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2303,7 +2163,7 @@ func (e *FormElement) Titlef(format string, args ...any) *FormElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *FormElement) IfTitle(condition bool, s string) *FormElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2326,7 +2186,7 @@ func (e *FormElement) IfTitle(condition bool, s string) *FormElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *FormElement) IfTitlef(condition bool, format string, args ...any) *FormElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2349,10 +2209,8 @@ func (e *FormElement) IfTitlef(condition bool, format string, args ...any) *Form
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *FormElement) TitleRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2361,10 +2219,8 @@ func (e *FormElement) TitleRemove() *FormElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *FormElement) Translate(c FormTranslateChoice) *FormElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[FormTranslateChoice])
 	return e
 }
 
@@ -2385,9 +2241,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *FormElement) TranslateRemove() *FormElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
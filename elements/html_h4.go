@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <h1>–<h6> elements represent six levels of section headings. <h1>
@@ -22,18 +20,77 @@ type H4Element struct {
 // with the tag "h4" during rendering.
 func H4(children ...ElementRenderer) *H4Element {
 	e := NewElement("h4", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &H4Element{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *H4Element) Clone() *H4Element {
+	return &H4Element{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *H4Element) CloneShallow() *H4Element {
+	return &H4Element{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *H4Element) Freeze() *H4Element {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *H4Element) writable() *H4Element {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *H4Element) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *H4Element) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[H4AutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[H4ContenteditableChoice]
+	case "dir":
+		return choiceValue[H4DirChoice]
+	case "draggable":
+		return choiceValue[H4DraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[H4EnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[H4HiddenChoice]
+	case "inputmode":
+		return choiceValue[H4InputmodeChoice]
+	case "popover":
+		return choiceValue[H4PopoverChoice]
+	case "spellcheck":
+		return choiceValue[H4SpellcheckChoice]
+	case "translate":
+		return choiceValue[H4TranslateChoice]
+	}
+	return nil
+}
+
 func (e *H4Element) Children(children ...ElementRenderer) *H4Element {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *H4Element) IfChildren(condition bool, children ...ElementRenderer) *H4Element {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *H4Element) IfChildren(condition bool, children ...ElementRenderer) *H4E
 
 func (e *H4Element) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *H4Element {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *H4Element) BoolAttr(name string) *H4Element {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *H4Element) BoolAttrRemove(name string) *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *H4Element) IfBoolAttr(condition bool, name string) *H4Element {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *H4Element) BoolAttrf(format string, args ...any) *H4Element {
 
 func (e *H4Element) IfBoolAttrf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *H4Element) BoolAttrs(names ...string) *H4Element {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *H4Element) IfBoolAttrs(condition bool, names ...string) *H4Element {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *H4Element) Attr(name, value string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *H4Element) RawAttr(name, value string) *H4Element {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *H4Element) IfAttr(condition bool, name, value string) *H4Element {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *H4Element) Attrf(name, format string, args ...any) *H4Element {
 
 func (e *H4Element) IfAttrf(condition bool, name, format string, args ...any) *H4Element {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *H4Element) Attrs(attrs ...string) *H4Element {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *H4Element) IfAttrs(condition bool, attrs ...string) *H4Element {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *H4Element) AttrsMap(attrs map[string]string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *H4Element) IfAttrsMap(condition bool, attrs map[string]string) *H4Element {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *H4Element) Text(text string) *H4Element {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *H4Element) Textf(format string, args ...any) *H4Element {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *H4Element) IfText(condition bool, text string) *H4Element {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *H4Element) IfText(condition bool, text string) *H4Element {
 
 func (e *H4Element) IfTextf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *H4Element) Raw(text string) *H4Element {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *H4Element) Escaped(text string) *H4Element {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *H4Element) IfEscaped(condition bool, text string) *H4Element {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *H4Element) Escapedf(format string, args ...any) *H4Element {
 
 func (e *H4Element) IfEscapedf(condition bool, format string, args ...any) *H4Element {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +273,8 @@ func (e *H4Element) IfEscapedf(condition bool, format string, args ...any) *H4El
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *H4Element) Accesskey(r rune) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *H4Element) Accesskey(r rune) *H4Element {
 // can be generated by the keyboard).
 func (e *H4Element) IfAccesskey(condition bool, r rune) *H4Element {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -236,10 +295,8 @@ func (e *H4Element) IfAccesskey(condition bool, r rune) *H4Element {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *H4Element) AccesskeyRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -255,10 +312,8 @@ func (e *H4Element) AccesskeyRemove() *H4Element {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *H4Element) Autocapitalize(c H4AutocapitalizeChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[H4AutocapitalizeChoice])
 	return e
 }
 
@@ -292,10 +347,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *H4Element) AutocapitalizeRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -314,10 +367,8 @@ func (e *H4Element) AutocapitalizeRemove() *H4Element {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *H4Element) Autofocus() *H4Element {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -337,7 +388,7 @@ func (e *H4Element) Autofocus() *H4Element {
 // created by the preceding content.
 func (e *H4Element) IfAutofocus(condition bool) *H4Element {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -358,10 +409,8 @@ func (e *H4Element) IfAutofocus(condition bool) *H4Element {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *H4Element) AutofocusRemove() *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -381,10 +430,8 @@ func (e *H4Element) AutofocusRemove() *H4Element {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *H4Element) AutofocusIfRemove() *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -393,16 +440,9 @@ func (e *H4Element) AutofocusIfRemove() *H4Element {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *H4Element) Class(s string) *H4Element {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -412,7 +452,7 @@ func (e *H4Element) Class(s string) *H4Element {
 // document.getElementsByClassName.
 func (e *H4Element) IfClass(condition bool, s string) *H4Element {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -423,14 +463,8 @@ func (e *H4Element) IfClass(condition bool, s string) *H4Element {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *H4Element) ClassRemove(s ...string) *H4Element {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,10 +472,8 @@ func (e *H4Element) ClassRemove(s ...string) *H4Element {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *H4Element) Contenteditable(c H4ContenteditableChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[H4ContenteditableChoice])
 	return e
 }
 
@@ -464,10 +496,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *H4Element) ContenteditableRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -485,10 +515,8 @@ func (e *H4Element) ContenteditableRemove() *H4Element {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *H4Element) Dir(c H4DirChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[H4DirChoice])
 	return e
 }
 
@@ -522,10 +550,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *H4Element) DirRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -533,10 +559,8 @@ func (e *H4Element) DirRemove() *H4Element {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *H4Element) Draggable(c H4DraggableChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[H4DraggableChoice])
 	return e
 }
 
@@ -562,20 +586,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *H4Element) DraggableRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *H4Element) Enterkeyhint(c H4EnterkeyhintChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[H4EnterkeyhintChoice])
 	return e
 }
 
@@ -605,10 +625,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *H4Element) EnterkeyhintRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -630,16 +648,9 @@ func (e *H4Element) EnterkeyhintRemove() *H4Element {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *H4Element) Exportparts(s string) *H4Element {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -662,7 +673,7 @@ func (e *H4Element) Exportparts(s string) *H4Element {
 // the current structure.
 func (e *H4Element) IfExportparts(condition bool, s string) *H4Element {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -686,14 +697,8 @@ func (e *H4Element) IfExportparts(condition bool, s string) *H4Element {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *H4Element) ExportpartsRemove(s ...string) *H4Element {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -710,10 +715,8 @@ func (e *H4Element) ExportpartsRemove(s ...string) *H4Element {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *H4Element) Hidden(c H4HiddenChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[H4HiddenChoice])
 	return e
 }
 
@@ -749,10 +752,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *H4Element) HiddenRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -760,10 +761,8 @@ func (e *H4Element) HiddenRemove() *H4Element {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *H4Element) ID(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -779,7 +778,7 @@ func (e *H4Element) IDf(format string, args ...any) *H4Element {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *H4Element) IfID(condition bool, s string) *H4Element {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -789,7 +788,7 @@ func (e *H4Element) IfID(condition bool, s string) *H4Element {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *H4Element) IfIDf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -799,10 +798,8 @@ func (e *H4Element) IfIDf(condition bool, format string, args ...any) *H4Element
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *H4Element) IDRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -817,10 +814,8 @@ func (e *H4Element) IDRemove() *H4Element {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *H4Element) Inert() *H4Element {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -836,7 +831,7 @@ func (e *H4Element) Inert() *H4Element {
 // excluding them from the accessibility tree.
 func (e *H4Element) IfInert(condition bool) *H4Element {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -853,10 +848,8 @@ func (e *H4Element) IfInert(condition bool) *H4Element {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *H4Element) InertRemove() *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -872,10 +865,8 @@ func (e *H4Element) InertRemove() *H4Element {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *H4Element) InertIfRemove() *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -889,10 +880,8 @@ func (e *H4Element) InertIfRemove() *H4Element {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *H4Element) Inputmode(c H4InputmodeChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[H4InputmodeChoice])
 	return e
 }
 
@@ -943,10 +932,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *H4Element) InputmodeRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -956,10 +943,8 @@ func (e *H4Element) InputmodeRemove() *H4Element {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *H4Element) Is(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -979,7 +964,7 @@ func (e *H4Element) Isf(format string, args ...any) *H4Element {
 // and extends the element type it is being applied to.
 func (e *H4Element) IfIs(condition bool, s string) *H4Element {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -991,7 +976,7 @@ func (e *H4Element) IfIs(condition bool, s string) *H4Element {
 // and extends the element type it is being applied to.
 func (e *H4Element) IfIsf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1003,10 +988,8 @@ func (e *H4Element) IfIsf(condition bool, format string, args ...any) *H4Element
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *H4Element) IsRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1022,10 +1005,8 @@ func (e *H4Element) IsRemove() *H4Element {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *H4Element) Itemid(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1057,7 +1038,7 @@ func (e *H4Element) Itemidf(format string, args ...any) *H4Element {
 // how items with the same identifier are handled.
 func (e *H4Element) IfItemid(condition bool, s string) *H4Element {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1075,7 +1056,7 @@ func (e *H4Element) IfItemid(condition bool, s string) *H4Element {
 // how items with the same identifier are handled.
 func (e *H4Element) IfItemidf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1093,10 +1074,8 @@ func (e *H4Element) IfItemidf(condition bool, format string, args ...any) *H4Ele
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *H4Element) ItemidRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1108,10 +1087,8 @@ func (e *H4Element) ItemidRemove() *H4Element {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *H4Element) Itemprop(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1135,7 +1112,7 @@ func (e *H4Element) Itempropf(format string, args ...any) *H4Element {
 // <track>, and <video>.
 func (e *H4Element) IfItemprop(condition bool, s string) *H4Element {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1149,7 +1126,7 @@ func (e *H4Element) IfItemprop(condition bool, s string) *H4Element {
 // <track>, and <video>.
 func (e *H4Element) IfItempropf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1163,10 +1140,8 @@ func (e *H4Element) IfItempropf(condition bool, format string, args ...any) *H4E
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *H4Element) ItempropRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1176,10 +1151,8 @@ func (e *H4Element) ItempropRemove() *H4Element {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *H4Element) Itemref(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1199,7 +1172,7 @@ func (e *H4Element) Itemreff(format string, args ...any) *H4Element {
 // specified on elements that have an itemscope attribute specified.
 func (e *H4Element) IfItemref(condition bool, s string) *H4Element {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1211,7 +1184,7 @@ func (e *H4Element) IfItemref(condition bool, s string) *H4Element {
 // specified on elements that have an itemscope attribute specified.
 func (e *H4Element) IfItemreff(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1223,10 +1196,8 @@ func (e *H4Element) IfItemreff(condition bool, format string, args ...any) *H4El
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *H4Element) ItemrefRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1238,10 +1209,8 @@ func (e *H4Element) ItemrefRemove() *H4Element {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *H4Element) Itemscope() *H4Element {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1254,7 +1223,7 @@ func (e *H4Element) Itemscope() *H4Element {
 // <object>, <source>, <track>, and <video>.
 func (e *H4Element) IfItemscope(condition bool) *H4Element {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1268,10 +1237,8 @@ func (e *H4Element) IfItemscope(condition bool) *H4Element {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *H4Element) ItemscopeRemove() *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1284,10 +1251,8 @@ func (e *H4Element) ItemscopeRemove() *H4Element {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *H4Element) ItemscopeIfRemove() *H4Element {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1299,10 +1264,8 @@ func (e *H4Element) ItemscopeIfRemove() *H4Element {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *H4Element) Itemtype(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1326,7 +1289,7 @@ func (e *H4Element) Itemtypef(format string, args ...any) *H4Element {
 // <video>.
 func (e *H4Element) IfItemtype(condition bool, s string) *H4Element {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1340,7 +1303,7 @@ func (e *H4Element) IfItemtype(condition bool, s string) *H4Element {
 // <video>.
 func (e *H4Element) IfItemtypef(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1354,10 +1317,8 @@ func (e *H4Element) IfItemtypef(condition bool, format string, args ...any) *H4E
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *H4Element) ItemtypeRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1367,10 +1328,8 @@ func (e *H4Element) ItemtypeRemove() *H4Element {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *H4Element) Lang(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1390,7 +1349,7 @@ func (e *H4Element) Langf(format string, args ...any) *H4Element {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *H4Element) IfLang(condition bool, s string) *H4Element {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1402,7 +1361,7 @@ func (e *H4Element) IfLang(condition bool, s string) *H4Element {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *H4Element) IfLangf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1414,10 +1373,8 @@ func (e *H4Element) IfLangf(condition bool, format string, args ...any) *H4Eleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *H4Element) LangRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1427,10 +1384,8 @@ func (e *H4Element) LangRemove() *H4Element {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *H4Element) Nonce(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1450,7 +1405,7 @@ func (e *H4Element) Noncef(format string, args ...any) *H4Element {
 // execute.
 func (e *H4Element) IfNonce(condition bool, s string) *H4Element {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1462,7 +1417,7 @@ func (e *H4Element) IfNonce(condition bool, s string) *H4Element {
 // execute.
 func (e *H4Element) IfNoncef(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1474,10 +1429,8 @@ func (e *H4Element) IfNoncef(condition bool, format string, args ...any) *H4Elem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *H4Element) NonceRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1485,16 +1438,9 @@ func (e *H4Element) NonceRemove() *H4Element {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *H4Element) Part(s string) *H4Element {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1503,7 +1449,7 @@ func (e *H4Element) Part(s string) *H4Element {
 // in a shadow tree via the ::part pseudo-element.
 func (e *H4Element) IfPart(condition bool, s string) *H4Element {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1513,14 +1459,8 @@ func (e *H4Element) IfPart(condition bool, s string) *H4Element {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *H4Element) PartRemove(s ...string) *H4Element {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1531,10 +1471,8 @@ func (e *H4Element) PartRemove(s ...string) *H4Element {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *H4Element) Popover(c H4PopoverChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[H4PopoverChoice])
 	return e
 }
 
@@ -1562,10 +1500,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *H4Element) PopoverRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1574,10 +1510,8 @@ func (e *H4Element) PopoverRemove() *H4Element {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *H4Element) Role(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1595,7 +1529,7 @@ func (e *H4Element) Rolef(format string, args ...any) *H4Element {
 // role of an element.
 func (e *H4Element) IfRole(condition bool, s string) *H4Element {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1606,7 +1540,7 @@ func (e *H4Element) IfRole(condition bool, s string) *H4Element {
 // role of an element.
 func (e *H4Element) IfRolef(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1617,10 +1551,8 @@ func (e *H4Element) IfRolef(condition bool, format string, args ...any) *H4Eleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *H4Element) RoleRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1629,10 +1561,8 @@ func (e *H4Element) RoleRemove() *H4Element {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *H4Element) Slot(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1650,7 +1580,7 @@ func (e *H4Element) Slotf(format string, args ...any) *H4Element {
 // value.
 func (e *H4Element) IfSlot(condition bool, s string) *H4Element {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1661,7 +1591,7 @@ func (e *H4Element) IfSlot(condition bool, s string) *H4Element {
 // value.
 func (e *H4Element) IfSlotf(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1672,10 +1602,8 @@ func (e *H4Element) IfSlotf(condition bool, format string, args ...any) *H4Eleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *H4Element) SlotRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1691,10 +1619,8 @@ func (e *H4Element) SlotRemove() *H4Element {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *H4Element) Spellcheck(c H4SpellcheckChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[H4SpellcheckChoice])
 	return e
 }
 
@@ -1722,10 +1648,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *H4Element) SpellcheckRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1735,14 +1659,8 @@ func (e *H4Element) StylePairs(pairs ...string) *H4Element {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1757,14 +1675,8 @@ func (e *H4Element) StylePairs(pairs ...string) *H4Element {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *H4Element) Style(s string) *H4Element {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1772,7 +1684,7 @@ func (e *H4Element) Style(s string) *H4Element {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1781,7 +1693,7 @@ func (e *H4Element) Style(s string) *H4Element {
 // color, font, size, and more. Styles are written in CSS.
 func (e *H4Element) IfStyle(condition bool, s string) *H4Element {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1789,15 +1701,7 @@ func (e *H4Element) IfStyle(condition bool, s string) *H4Element {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *H4Element) StyleAdd(k string, v string) *H4Element {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1811,7 +1715,7 @@ func (e *H4Element) StyleAddf(k string, format string, args ...any) *H4Element {
 // color, font, size, and more. Styles are written in CSS.
 func (e *H4Element) IfStyleAdd(condition bool, k string, v string) *H4Element {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1820,7 +1724,7 @@ func (e *H4Element) IfStyleAdd(condition bool, k string, v string) *H4Element {
 // color, font, size, and more. Styles are written in CSS.
 func (e *H4Element) IfStyleAddf(condition bool, k string, format string, args ...any) *H4Element {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1829,21 +1733,15 @@ func (e *H4Element) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *H4Element) StyleMap(m map[string]string) *H4Element {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1852,14 +1750,8 @@ func (e *H4Element) StyleMap(m map[string]string) *H4Element {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *H4Element) StyleRemove(keys ...string) *H4Element {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1877,10 +1769,8 @@ func (e *H4Element) StyleRemove(keys ...string) *H4Element {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *H4Element) Tabindex(i int) *H4Element {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1899,7 +1789,7 @@ func (e *H4Element) Tabindex(i int) *H4Element {
 // their relative position in the document.
 func (e *H4Element) IfTabindex(condition bool, i int) *H4Element {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1919,10 +1809,8 @@ func (e *H4Element) IfTabindex(condition bool, i int) *H4Element {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *H4Element) TabindexRemove() *H4Element {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1943,10 +1831,8 @@ func (e *H4Element) TabindexRemove() *H4Element {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *H4Element) Title(s string) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1988,7 +1874,7 @@ func (e *H4Element) Titlef(format string, args ...any) *H4Element {
 // element, this is not good practice. Use a <label> instead.
 func (e *H4Element) IfTitle(condition bool, s string) *H4Element {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2011,7 +1897,7 @@ func (e *H4Element) IfTitle(condition bool, s string) *H4Element {
 // element, this is not good practice. Use a <label> instead.
 func (e *H4Element) IfTitlef(condition bool, format string, args ...any) *H4Element {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2034,10 +1920,8 @@ func (e *H4Element) IfTitlef(condition bool, format string, args ...any) *H4Elem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *H4Element) TitleRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2046,10 +1930,8 @@ func (e *H4Element) TitleRemove() *H4Element {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *H4Element) Translate(c H4TranslateChoice) *H4Element {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[H4TranslateChoice])
 	return e
 }
 
@@ -2070,9 +1952,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *H4Element) TranslateRemove() *H4Element {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <hgroup> element represents a multi-level heading for a section of a
@@ -22,18 +20,77 @@ type HgroupElement struct {
 // with the tag "hgroup" during rendering.
 func Hgroup(children ...ElementRenderer) *HgroupElement {
 	e := NewElement("hgroup", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &HgroupElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *HgroupElement) Clone() *HgroupElement {
+	return &HgroupElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *HgroupElement) CloneShallow() *HgroupElement {
+	return &HgroupElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *HgroupElement) Freeze() *HgroupElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *HgroupElement) writable() *HgroupElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *HgroupElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *HgroupElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[HgroupAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[HgroupContenteditableChoice]
+	case "dir":
+		return choiceValue[HgroupDirChoice]
+	case "draggable":
+		return choiceValue[HgroupDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[HgroupEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[HgroupHiddenChoice]
+	case "inputmode":
+		return choiceValue[HgroupInputmodeChoice]
+	case "popover":
+		return choiceValue[HgroupPopoverChoice]
+	case "spellcheck":
+		return choiceValue[HgroupSpellcheckChoice]
+	case "translate":
+		return choiceValue[HgroupTranslateChoice]
+	}
+	return nil
+}
+
 func (e *HgroupElement) Children(children ...ElementRenderer) *HgroupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *HgroupElement) IfChildren(condition bool, children ...ElementRenderer) *HgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *HgroupElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *HgroupElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *HgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *HgroupElement) BoolAttr(name string) *HgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *HgroupElement) BoolAttrRemove(name string) *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *HgroupElement) IfBoolAttr(condition bool, name string) *HgroupElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *HgroupElement) BoolAttrf(format string, args ...any) *HgroupElement {
 
 func (e *HgroupElement) IfBoolAttrf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *HgroupElement) BoolAttrs(names ...string) *HgroupElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *HgroupElement) IfBoolAttrs(condition bool, names ...string) *HgroupElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *HgroupElement) Attr(name, value string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *HgroupElement) RawAttr(name, value string) *HgroupElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *HgroupElement) IfAttr(condition bool, name, value string) *HgroupElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *HgroupElement) Attrf(name, format string, args ...any) *HgroupElement {
 
 func (e *HgroupElement) IfAttrf(condition bool, name, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *HgroupElement) Attrs(attrs ...string) *HgroupElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *HgroupElement) IfAttrs(condition bool, attrs ...string) *HgroupElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *HgroupElement) AttrsMap(attrs map[string]string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *HgroupElement) IfAttrsMap(condition bool, attrs map[string]string) *HgroupElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *HgroupElement) Text(text string) *HgroupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *HgroupElement) Textf(format string, args ...any) *HgroupElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *HgroupElement) IfText(condition bool, text string) *HgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *HgroupElement) IfText(condition bool, text string) *HgroupElement {
 
 func (e *HgroupElement) IfTextf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *HgroupElement) Raw(text string) *HgroupElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *HgroupElement) Escaped(text string) *HgroupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *HgroupElement) IfEscaped(condition bool, text string) *HgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *HgroupElement) Escapedf(format string, args ...any) *HgroupElement {
 
 func (e *HgroupElement) IfEscapedf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +273,8 @@ func (e *HgroupElement) IfEscapedf(condition bool, format string, args ...any) *
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *HgroupElement) Accesskey(r rune) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *HgroupElement) Accesskey(r rune) *HgroupElement {
 // can be generated by the keyboard).
 func (e *HgroupElement) IfAccesskey(condition bool, r rune) *HgroupElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -236,10 +295,8 @@ func (e *HgroupElement) IfAccesskey(condition bool, r rune) *HgroupElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *HgroupElement) AccesskeyRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -255,10 +312,8 @@ func (e *HgroupElement) AccesskeyRemove() *HgroupElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *HgroupElement) Autocapitalize(c HgroupAutocapitalizeChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[HgroupAutocapitalizeChoice])
 	return e
 }
 
@@ -292,10 +347,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *HgroupElement) AutocapitalizeRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -314,10 +367,8 @@ func (e *HgroupElement) AutocapitalizeRemove() *HgroupElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *HgroupElement) Autofocus() *HgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -337,7 +388,7 @@ func (e *HgroupElement) Autofocus() *HgroupElement {
 // created by the preceding content.
 func (e *HgroupElement) IfAutofocus(condition bool) *HgroupElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -358,10 +409,8 @@ func (e *HgroupElement) IfAutofocus(condition bool) *HgroupElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *HgroupElement) AutofocusRemove() *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -381,10 +430,8 @@ func (e *HgroupElement) AutofocusRemove() *HgroupElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *HgroupElement) AutofocusIfRemove() *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -393,16 +440,9 @@ func (e *HgroupElement) AutofocusIfRemove() *HgroupElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *HgroupElement) Class(s string) *HgroupElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -412,7 +452,7 @@ func (e *HgroupElement) Class(s string) *HgroupElement {
 // document.getElementsByClassName.
 func (e *HgroupElement) IfClass(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -423,14 +463,8 @@ func (e *HgroupElement) IfClass(condition bool, s string) *HgroupElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *HgroupElement) ClassRemove(s ...string) *HgroupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,10 +472,8 @@ func (e *HgroupElement) ClassRemove(s ...string) *HgroupElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *HgroupElement) Contenteditable(c HgroupContenteditableChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[HgroupContenteditableChoice])
 	return e
 }
 
@@ -464,10 +496,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *HgroupElement) ContenteditableRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -485,10 +515,8 @@ func (e *HgroupElement) ContenteditableRemove() *HgroupElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *HgroupElement) Dir(c HgroupDirChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[HgroupDirChoice])
 	return e
 }
 
@@ -522,10 +550,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *HgroupElement) DirRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -533,10 +559,8 @@ func (e *HgroupElement) DirRemove() *HgroupElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *HgroupElement) Draggable(c HgroupDraggableChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[HgroupDraggableChoice])
 	return e
 }
 
@@ -562,20 +586,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *HgroupElement) DraggableRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *HgroupElement) Enterkeyhint(c HgroupEnterkeyhintChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[HgroupEnterkeyhintChoice])
 	return e
 }
 
@@ -605,10 +625,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *HgroupElement) EnterkeyhintRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -630,16 +648,9 @@ func (e *HgroupElement) EnterkeyhintRemove() *HgroupElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *HgroupElement) Exportparts(s string) *HgroupElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -662,7 +673,7 @@ func (e *HgroupElement) Exportparts(s string) *HgroupElement {
 // the current structure.
 func (e *HgroupElement) IfExportparts(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -686,14 +697,8 @@ func (e *HgroupElement) IfExportparts(condition bool, s string) *HgroupElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *HgroupElement) ExportpartsRemove(s ...string) *HgroupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -710,10 +715,8 @@ func (e *HgroupElement) ExportpartsRemove(s ...string) *HgroupElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *HgroupElement) Hidden(c HgroupHiddenChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[HgroupHiddenChoice])
 	return e
 }
 
@@ -749,10 +752,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *HgroupElement) HiddenRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -760,10 +761,8 @@ func (e *HgroupElement) HiddenRemove() *HgroupElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *HgroupElement) ID(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -779,7 +778,7 @@ func (e *HgroupElement) IDf(format string, args ...any) *HgroupElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *HgroupElement) IfID(condition bool, s string) *HgroupElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -789,7 +788,7 @@ func (e *HgroupElement) IfID(condition bool, s string) *HgroupElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *HgroupElement) IfIDf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -799,10 +798,8 @@ func (e *HgroupElement) IfIDf(condition bool, format string, args ...any) *Hgrou
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *HgroupElement) IDRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -817,10 +814,8 @@ func (e *HgroupElement) IDRemove() *HgroupElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *HgroupElement) Inert() *HgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -836,7 +831,7 @@ func (e *HgroupElement) Inert() *HgroupElement {
 // excluding them from the accessibility tree.
 func (e *HgroupElement) IfInert(condition bool) *HgroupElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -853,10 +848,8 @@ func (e *HgroupElement) IfInert(condition bool) *HgroupElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *HgroupElement) InertRemove() *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -872,10 +865,8 @@ func (e *HgroupElement) InertRemove() *HgroupElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *HgroupElement) InertIfRemove() *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -889,10 +880,8 @@ func (e *HgroupElement) InertIfRemove() *HgroupElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *HgroupElement) Inputmode(c HgroupInputmodeChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[HgroupInputmodeChoice])
 	return e
 }
 
@@ -943,10 +932,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *HgroupElement) InputmodeRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -956,10 +943,8 @@ func (e *HgroupElement) InputmodeRemove() *HgroupElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *HgroupElement) Is(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -979,7 +964,7 @@ func (e *HgroupElement) Isf(format string, args ...any) *HgroupElement {
 // and extends the element type it is being applied to.
 func (e *HgroupElement) IfIs(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -991,7 +976,7 @@ func (e *HgroupElement) IfIs(condition bool, s string) *HgroupElement {
 // and extends the element type it is being applied to.
 func (e *HgroupElement) IfIsf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1003,10 +988,8 @@ func (e *HgroupElement) IfIsf(condition bool, format string, args ...any) *Hgrou
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *HgroupElement) IsRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1022,10 +1005,8 @@ func (e *HgroupElement) IsRemove() *HgroupElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *HgroupElement) Itemid(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1057,7 +1038,7 @@ func (e *HgroupElement) Itemidf(format string, args ...any) *HgroupElement {
 // how items with the same identifier are handled.
 func (e *HgroupElement) IfItemid(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1075,7 +1056,7 @@ func (e *HgroupElement) IfItemid(condition bool, s string) *HgroupElement {
 // how items with the same identifier are handled.
 func (e *HgroupElement) IfItemidf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1093,10 +1074,8 @@ func (e *HgroupElement) IfItemidf(condition bool, format string, args ...any) *H
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *HgroupElement) ItemidRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1108,10 +1087,8 @@ func (e *HgroupElement) ItemidRemove() *HgroupElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *HgroupElement) Itemprop(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1135,7 +1112,7 @@ func (e *HgroupElement) Itempropf(format string, args ...any) *HgroupElement {
 // <track>, and <video>.
 func (e *HgroupElement) IfItemprop(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1149,7 +1126,7 @@ func (e *HgroupElement) IfItemprop(condition bool, s string) *HgroupElement {
 // <track>, and <video>.
 func (e *HgroupElement) IfItempropf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1163,10 +1140,8 @@ func (e *HgroupElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *HgroupElement) ItempropRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1176,10 +1151,8 @@ func (e *HgroupElement) ItempropRemove() *HgroupElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *HgroupElement) Itemref(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1199,7 +1172,7 @@ func (e *HgroupElement) Itemreff(format string, args ...any) *HgroupElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *HgroupElement) IfItemref(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1211,7 +1184,7 @@ func (e *HgroupElement) IfItemref(condition bool, s string) *HgroupElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *HgroupElement) IfItemreff(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1223,10 +1196,8 @@ func (e *HgroupElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *HgroupElement) ItemrefRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1238,10 +1209,8 @@ func (e *HgroupElement) ItemrefRemove() *HgroupElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *HgroupElement) Itemscope() *HgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1254,7 +1223,7 @@ func (e *HgroupElement) Itemscope() *HgroupElement {
 // <object>, <source>, <track>, and <video>.
 func (e *HgroupElement) IfItemscope(condition bool) *HgroupElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1268,10 +1237,8 @@ func (e *HgroupElement) IfItemscope(condition bool) *HgroupElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *HgroupElement) ItemscopeRemove() *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1284,10 +1251,8 @@ func (e *HgroupElement) ItemscopeRemove() *HgroupElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *HgroupElement) ItemscopeIfRemove() *HgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1299,10 +1264,8 @@ func (e *HgroupElement) ItemscopeIfRemove() *HgroupElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *HgroupElement) Itemtype(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1326,7 +1289,7 @@ func (e *HgroupElement) Itemtypef(format string, args ...any) *HgroupElement {
 // <video>.
 func (e *HgroupElement) IfItemtype(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1340,7 +1303,7 @@ func (e *HgroupElement) IfItemtype(condition bool, s string) *HgroupElement {
 // <video>.
 func (e *HgroupElement) IfItemtypef(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1354,10 +1317,8 @@ func (e *HgroupElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *HgroupElement) ItemtypeRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1367,10 +1328,8 @@ func (e *HgroupElement) ItemtypeRemove() *HgroupElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *HgroupElement) Lang(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1390,7 +1349,7 @@ func (e *HgroupElement) Langf(format string, args ...any) *HgroupElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *HgroupElement) IfLang(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1402,7 +1361,7 @@ func (e *HgroupElement) IfLang(condition bool, s string) *HgroupElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *HgroupElement) IfLangf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1414,10 +1373,8 @@ func (e *HgroupElement) IfLangf(condition bool, format string, args ...any) *Hgr
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *HgroupElement) LangRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1427,10 +1384,8 @@ func (e *HgroupElement) LangRemove() *HgroupElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *HgroupElement) Nonce(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1450,7 +1405,7 @@ func (e *HgroupElement) Noncef(format string, args ...any) *HgroupElement {
 // execute.
 func (e *HgroupElement) IfNonce(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1462,7 +1417,7 @@ func (e *HgroupElement) IfNonce(condition bool, s string) *HgroupElement {
 // execute.
 func (e *HgroupElement) IfNoncef(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1474,10 +1429,8 @@ func (e *HgroupElement) IfNoncef(condition bool, format string, args ...any) *Hg
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *HgroupElement) NonceRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1485,16 +1438,9 @@ func (e *HgroupElement) NonceRemove() *HgroupElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *HgroupElement) Part(s string) *HgroupElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1503,7 +1449,7 @@ func (e *HgroupElement) Part(s string) *HgroupElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *HgroupElement) IfPart(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1513,14 +1459,8 @@ func (e *HgroupElement) IfPart(condition bool, s string) *HgroupElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *HgroupElement) PartRemove(s ...string) *HgroupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1531,10 +1471,8 @@ func (e *HgroupElement) PartRemove(s ...string) *HgroupElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *HgroupElement) Popover(c HgroupPopoverChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[HgroupPopoverChoice])
 	return e
 }
 
@@ -1562,10 +1500,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *HgroupElement) PopoverRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1574,10 +1510,8 @@ func (e *HgroupElement) PopoverRemove() *HgroupElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *HgroupElement) Role(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1595,7 +1529,7 @@ func (e *HgroupElement) Rolef(format string, args ...any) *HgroupElement {
 // role of an element.
 func (e *HgroupElement) IfRole(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1606,7 +1540,7 @@ func (e *HgroupElement) IfRole(condition bool, s string) *HgroupElement {
 // role of an element.
 func (e *HgroupElement) IfRolef(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1617,10 +1551,8 @@ func (e *HgroupElement) IfRolef(condition bool, format string, args ...any) *Hgr
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *HgroupElement) RoleRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1629,10 +1561,8 @@ func (e *HgroupElement) RoleRemove() *HgroupElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *HgroupElement) Slot(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1650,7 +1580,7 @@ func (e *HgroupElement) Slotf(format string, args ...any) *HgroupElement {
 // value.
 func (e *HgroupElement) IfSlot(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1661,7 +1591,7 @@ func (e *HgroupElement) IfSlot(condition bool, s string) *HgroupElement {
 // value.
 func (e *HgroupElement) IfSlotf(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1672,10 +1602,8 @@ func (e *HgroupElement) IfSlotf(condition bool, format string, args ...any) *Hgr
 // value.
 // Remove the attribute Slot from the element.
 func (e *HgroupElement) SlotRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1691,10 +1619,8 @@ func (e *HgroupElement) SlotRemove() *HgroupElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *HgroupElement) Spellcheck(c HgroupSpellcheckChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[HgroupSpellcheckChoice])
 	return e
 }
 
@@ -1722,10 +1648,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *HgroupElement) SpellcheckRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1735,14 +1659,8 @@ func (e *HgroupElement) StylePairs(pairs ...string) *HgroupElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1757,14 +1675,8 @@ func (e *HgroupElement) StylePairs(pairs ...string) *HgroupElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *HgroupElement) Style(s string) *HgroupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1772,7 +1684,7 @@ func (e *HgroupElement) Style(s string) *HgroupElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1781,7 +1693,7 @@ func (e *HgroupElement) Style(s string) *HgroupElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *HgroupElement) IfStyle(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1789,15 +1701,7 @@ func (e *HgroupElement) IfStyle(condition bool, s string) *HgroupElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *HgroupElement) StyleAdd(k string, v string) *HgroupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1811,7 +1715,7 @@ func (e *HgroupElement) StyleAddf(k string, format string, args ...any) *HgroupE
 // color, font, size, and more. Styles are written in CSS.
 func (e *HgroupElement) IfStyleAdd(condition bool, k string, v string) *HgroupElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1820,7 +1724,7 @@ func (e *HgroupElement) IfStyleAdd(condition bool, k string, v string) *HgroupEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *HgroupElement) IfStyleAddf(condition bool, k string, format string, args ...any) *HgroupElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1829,21 +1733,15 @@ func (e *HgroupElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *HgroupElement) StyleMap(m map[string]string) *HgroupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1852,14 +1750,8 @@ func (e *HgroupElement) StyleMap(m map[string]string) *HgroupElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *HgroupElement) StyleRemove(keys ...string) *HgroupElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1877,10 +1769,8 @@ func (e *HgroupElement) StyleRemove(keys ...string) *HgroupElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *HgroupElement) Tabindex(i int) *HgroupElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1899,7 +1789,7 @@ func (e *HgroupElement) Tabindex(i int) *HgroupElement {
 // their relative position in the document.
 func (e *HgroupElement) IfTabindex(condition bool, i int) *HgroupElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1919,10 +1809,8 @@ func (e *HgroupElement) IfTabindex(condition bool, i int) *HgroupElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *HgroupElement) TabindexRemove() *HgroupElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1943,10 +1831,8 @@ func (e *HgroupElement) TabindexRemove() *HgroupElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *HgroupElement) Title(s string) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1988,7 +1874,7 @@ func (e *HgroupElement) Titlef(format string, args ...any) *HgroupElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *HgroupElement) IfTitle(condition bool, s string) *HgroupElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2011,7 +1897,7 @@ func (e *HgroupElement) IfTitle(condition bool, s string) *HgroupElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *HgroupElement) IfTitlef(condition bool, format string, args ...any) *HgroupElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2034,10 +1920,8 @@ func (e *HgroupElement) IfTitlef(condition bool, format string, args ...any) *Hg
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *HgroupElement) TitleRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2046,10 +1930,8 @@ func (e *HgroupElement) TitleRemove() *HgroupElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *HgroupElement) Translate(c HgroupTranslateChoice) *HgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[HgroupTranslateChoice])
 	return e
 }
 
@@ -2070,9 +1952,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *HgroupElement) TranslateRemove() *HgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
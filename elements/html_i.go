@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML Bring Attention To element (<b>) is used to draw the reader's
@@ -27,18 +25,77 @@ type IElement struct {
 // with the tag "i" during rendering.
 func I(children ...ElementRenderer) *IElement {
 	e := NewElement("i", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &IElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *IElement) Clone() *IElement {
+	return &IElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *IElement) CloneShallow() *IElement {
+	return &IElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *IElement) Freeze() *IElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *IElement) writable() *IElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *IElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *IElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[IAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[IContenteditableChoice]
+	case "dir":
+		return choiceValue[IDirChoice]
+	case "draggable":
+		return choiceValue[IDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[IEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[IHiddenChoice]
+	case "inputmode":
+		return choiceValue[IInputmodeChoice]
+	case "popover":
+		return choiceValue[IPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ISpellcheckChoice]
+	case "translate":
+		return choiceValue[ITranslateChoice]
+	}
+	return nil
+}
+
 func (e *IElement) Children(children ...ElementRenderer) *IElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *IElement) IfChildren(condition bool, children ...ElementRenderer) *IElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -46,32 +103,30 @@ func (e *IElement) IfChildren(condition bool, children ...ElementRenderer) *IEle
 
 func (e *IElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *IElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *IElement) BoolAttr(name string) *IElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *IElement) BoolAttrRemove(name string) *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *IElement) IfBoolAttr(condition bool, name string) *IElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -82,36 +137,40 @@ func (e *IElement) BoolAttrf(format string, args ...any) *IElement {
 
 func (e *IElement) IfBoolAttrf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *IElement) BoolAttrs(names ...string) *IElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *IElement) IfBoolAttrs(condition bool, names ...string) *IElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *IElement) Attr(name, value string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *IElement) RawAttr(name, value string) *IElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *IElement) IfAttr(condition bool, name, value string) *IElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -122,61 +181,52 @@ func (e *IElement) Attrf(name, format string, args ...any) *IElement {
 
 func (e *IElement) IfAttrf(condition bool, name, format string, args ...any) *IElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *IElement) Attrs(attrs ...string) *IElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *IElement) IfAttrs(condition bool, attrs ...string) *IElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *IElement) AttrsMap(attrs map[string]string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *IElement) IfAttrsMap(condition bool, attrs map[string]string) *IElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *IElement) Text(text string) *IElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *IElement) Textf(format string, args ...any) *IElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *IElement) IfText(condition bool, text string) *IElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -184,18 +234,28 @@ func (e *IElement) IfText(condition bool, text string) *IElement {
 
 func (e *IElement) IfTextf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *IElement) Raw(text string) *IElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *IElement) Escaped(text string) *IElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *IElement) IfEscaped(condition bool, text string) *IElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -207,6 +267,7 @@ func (e *IElement) Escapedf(format string, args ...any) *IElement {
 
 func (e *IElement) IfEscapedf(condition bool, format string, args ...any) *IElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -217,10 +278,8 @@ func (e *IElement) IfEscapedf(condition bool, format string, args ...any) *IElem
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *IElement) Accesskey(r rune) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -230,7 +289,7 @@ func (e *IElement) Accesskey(r rune) *IElement {
 // can be generated by the keyboard).
 func (e *IElement) IfAccesskey(condition bool, r rune) *IElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -241,10 +300,8 @@ func (e *IElement) IfAccesskey(condition bool, r rune) *IElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *IElement) AccesskeyRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -260,10 +317,8 @@ func (e *IElement) AccesskeyRemove() *IElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *IElement) Autocapitalize(c IAutocapitalizeChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[IAutocapitalizeChoice])
 	return e
 }
 
@@ -297,10 +352,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *IElement) AutocapitalizeRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -319,10 +372,8 @@ func (e *IElement) AutocapitalizeRemove() *IElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *IElement) Autofocus() *IElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -342,7 +393,7 @@ func (e *IElement) Autofocus() *IElement {
 // created by the preceding content.
 func (e *IElement) IfAutofocus(condition bool) *IElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -363,10 +414,8 @@ func (e *IElement) IfAutofocus(condition bool) *IElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *IElement) AutofocusRemove() *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -386,10 +435,8 @@ func (e *IElement) AutofocusRemove() *IElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *IElement) AutofocusIfRemove() *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -398,16 +445,9 @@ func (e *IElement) AutofocusIfRemove() *IElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *IElement) Class(s string) *IElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -417,7 +457,7 @@ func (e *IElement) Class(s string) *IElement {
 // document.getElementsByClassName.
 func (e *IElement) IfClass(condition bool, s string) *IElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -428,14 +468,8 @@ func (e *IElement) IfClass(condition bool, s string) *IElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *IElement) ClassRemove(s ...string) *IElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -443,10 +477,8 @@ func (e *IElement) ClassRemove(s ...string) *IElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *IElement) Contenteditable(c IContenteditableChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[IContenteditableChoice])
 	return e
 }
 
@@ -469,10 +501,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *IElement) ContenteditableRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -490,10 +520,8 @@ func (e *IElement) ContenteditableRemove() *IElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *IElement) Dir(c IDirChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[IDirChoice])
 	return e
 }
 
@@ -527,10 +555,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *IElement) DirRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -538,10 +564,8 @@ func (e *IElement) DirRemove() *IElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *IElement) Draggable(c IDraggableChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[IDraggableChoice])
 	return e
 }
 
@@ -567,20 +591,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *IElement) DraggableRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *IElement) Enterkeyhint(c IEnterkeyhintChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[IEnterkeyhintChoice])
 	return e
 }
 
@@ -610,10 +630,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *IElement) EnterkeyhintRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -635,16 +653,9 @@ func (e *IElement) EnterkeyhintRemove() *IElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *IElement) Exportparts(s string) *IElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -667,7 +678,7 @@ func (e *IElement) Exportparts(s string) *IElement {
 // the current structure.
 func (e *IElement) IfExportparts(condition bool, s string) *IElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -691,14 +702,8 @@ func (e *IElement) IfExportparts(condition bool, s string) *IElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *IElement) ExportpartsRemove(s ...string) *IElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -715,10 +720,8 @@ func (e *IElement) ExportpartsRemove(s ...string) *IElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *IElement) Hidden(c IHiddenChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[IHiddenChoice])
 	return e
 }
 
@@ -754,10 +757,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *IElement) HiddenRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -765,10 +766,8 @@ func (e *IElement) HiddenRemove() *IElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *IElement) ID(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -784,7 +783,7 @@ func (e *IElement) IDf(format string, args ...any) *IElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *IElement) IfID(condition bool, s string) *IElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -794,7 +793,7 @@ func (e *IElement) IfID(condition bool, s string) *IElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *IElement) IfIDf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -804,10 +803,8 @@ func (e *IElement) IfIDf(condition bool, format string, args ...any) *IElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *IElement) IDRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -822,10 +819,8 @@ func (e *IElement) IDRemove() *IElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *IElement) Inert() *IElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -841,7 +836,7 @@ func (e *IElement) Inert() *IElement {
 // excluding them from the accessibility tree.
 func (e *IElement) IfInert(condition bool) *IElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -858,10 +853,8 @@ func (e *IElement) IfInert(condition bool) *IElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *IElement) InertRemove() *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -877,10 +870,8 @@ func (e *IElement) InertRemove() *IElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *IElement) InertIfRemove() *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -894,10 +885,8 @@ func (e *IElement) InertIfRemove() *IElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *IElement) Inputmode(c IInputmodeChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[IInputmodeChoice])
 	return e
 }
 
@@ -948,10 +937,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *IElement) InputmodeRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -961,10 +948,8 @@ func (e *IElement) InputmodeRemove() *IElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *IElement) Is(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -984,7 +969,7 @@ func (e *IElement) Isf(format string, args ...any) *IElement {
 // and extends the element type it is being applied to.
 func (e *IElement) IfIs(condition bool, s string) *IElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -996,7 +981,7 @@ func (e *IElement) IfIs(condition bool, s string) *IElement {
 // and extends the element type it is being applied to.
 func (e *IElement) IfIsf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1008,10 +993,8 @@ func (e *IElement) IfIsf(condition bool, format string, args ...any) *IElement {
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *IElement) IsRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1027,10 +1010,8 @@ func (e *IElement) IsRemove() *IElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *IElement) Itemid(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1062,7 +1043,7 @@ func (e *IElement) Itemidf(format string, args ...any) *IElement {
 // how items with the same identifier are handled.
 func (e *IElement) IfItemid(condition bool, s string) *IElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1080,7 +1061,7 @@ func (e *IElement) IfItemid(condition bool, s string) *IElement {
 // how items with the same identifier are handled.
 func (e *IElement) IfItemidf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1098,10 +1079,8 @@ func (e *IElement) IfItemidf(condition bool, format string, args ...any) *IEleme
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *IElement) ItemidRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1113,10 +1092,8 @@ func (e *IElement) ItemidRemove() *IElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *IElement) Itemprop(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1140,7 +1117,7 @@ func (e *IElement) Itempropf(format string, args ...any) *IElement {
 // <track>, and <video>.
 func (e *IElement) IfItemprop(condition bool, s string) *IElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1154,7 +1131,7 @@ func (e *IElement) IfItemprop(condition bool, s string) *IElement {
 // <track>, and <video>.
 func (e *IElement) IfItempropf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1168,10 +1145,8 @@ func (e *IElement) IfItempropf(condition bool, format string, args ...any) *IEle
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *IElement) ItempropRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1181,10 +1156,8 @@ func (e *IElement) ItempropRemove() *IElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *IElement) Itemref(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1204,7 +1177,7 @@ func (e *IElement) Itemreff(format string, args ...any) *IElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *IElement) IfItemref(condition bool, s string) *IElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1216,7 +1189,7 @@ func (e *IElement) IfItemref(condition bool, s string) *IElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *IElement) IfItemreff(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1228,10 +1201,8 @@ func (e *IElement) IfItemreff(condition bool, format string, args ...any) *IElem
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *IElement) ItemrefRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1243,10 +1214,8 @@ func (e *IElement) ItemrefRemove() *IElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *IElement) Itemscope() *IElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1259,7 +1228,7 @@ func (e *IElement) Itemscope() *IElement {
 // <object>, <source>, <track>, and <video>.
 func (e *IElement) IfItemscope(condition bool) *IElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1273,10 +1242,8 @@ func (e *IElement) IfItemscope(condition bool) *IElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *IElement) ItemscopeRemove() *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1289,10 +1256,8 @@ func (e *IElement) ItemscopeRemove() *IElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *IElement) ItemscopeIfRemove() *IElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1304,10 +1269,8 @@ func (e *IElement) ItemscopeIfRemove() *IElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *IElement) Itemtype(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1331,7 +1294,7 @@ func (e *IElement) Itemtypef(format string, args ...any) *IElement {
 // <video>.
 func (e *IElement) IfItemtype(condition bool, s string) *IElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1345,7 +1308,7 @@ func (e *IElement) IfItemtype(condition bool, s string) *IElement {
 // <video>.
 func (e *IElement) IfItemtypef(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1359,10 +1322,8 @@ func (e *IElement) IfItemtypef(condition bool, format string, args ...any) *IEle
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *IElement) ItemtypeRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1372,10 +1333,8 @@ func (e *IElement) ItemtypeRemove() *IElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *IElement) Lang(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1395,7 +1354,7 @@ func (e *IElement) Langf(format string, args ...any) *IElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *IElement) IfLang(condition bool, s string) *IElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1407,7 +1366,7 @@ func (e *IElement) IfLang(condition bool, s string) *IElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *IElement) IfLangf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1419,10 +1378,8 @@ func (e *IElement) IfLangf(condition bool, format string, args ...any) *IElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *IElement) LangRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1432,10 +1389,8 @@ func (e *IElement) LangRemove() *IElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *IElement) Nonce(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1455,7 +1410,7 @@ func (e *IElement) Noncef(format string, args ...any) *IElement {
 // execute.
 func (e *IElement) IfNonce(condition bool, s string) *IElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1467,7 +1422,7 @@ func (e *IElement) IfNonce(condition bool, s string) *IElement {
 // execute.
 func (e *IElement) IfNoncef(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1479,10 +1434,8 @@ func (e *IElement) IfNoncef(condition bool, format string, args ...any) *IElemen
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *IElement) NonceRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1490,16 +1443,9 @@ func (e *IElement) NonceRemove() *IElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *IElement) Part(s string) *IElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1508,7 +1454,7 @@ func (e *IElement) Part(s string) *IElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *IElement) IfPart(condition bool, s string) *IElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1518,14 +1464,8 @@ func (e *IElement) IfPart(condition bool, s string) *IElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *IElement) PartRemove(s ...string) *IElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1536,10 +1476,8 @@ func (e *IElement) PartRemove(s ...string) *IElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *IElement) Popover(c IPopoverChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[IPopoverChoice])
 	return e
 }
 
@@ -1567,10 +1505,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *IElement) PopoverRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1579,10 +1515,8 @@ func (e *IElement) PopoverRemove() *IElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *IElement) Role(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1600,7 +1534,7 @@ func (e *IElement) Rolef(format string, args ...any) *IElement {
 // role of an element.
 func (e *IElement) IfRole(condition bool, s string) *IElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1611,7 +1545,7 @@ func (e *IElement) IfRole(condition bool, s string) *IElement {
 // role of an element.
 func (e *IElement) IfRolef(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1622,10 +1556,8 @@ func (e *IElement) IfRolef(condition bool, format string, args ...any) *IElement
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *IElement) RoleRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1634,10 +1566,8 @@ func (e *IElement) RoleRemove() *IElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *IElement) Slot(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1655,7 +1585,7 @@ func (e *IElement) Slotf(format string, args ...any) *IElement {
 // value.
 func (e *IElement) IfSlot(condition bool, s string) *IElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1666,7 +1596,7 @@ func (e *IElement) IfSlot(condition bool, s string) *IElement {
 // value.
 func (e *IElement) IfSlotf(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1677,10 +1607,8 @@ func (e *IElement) IfSlotf(condition bool, format string, args ...any) *IElement
 // value.
 // Remove the attribute Slot from the element.
 func (e *IElement) SlotRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1696,10 +1624,8 @@ func (e *IElement) SlotRemove() *IElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *IElement) Spellcheck(c ISpellcheckChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ISpellcheckChoice])
 	return e
 }
 
@@ -1727,10 +1653,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *IElement) SpellcheckRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1740,14 +1664,8 @@ func (e *IElement) StylePairs(pairs ...string) *IElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1762,14 +1680,8 @@ func (e *IElement) StylePairs(pairs ...string) *IElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *IElement) Style(s string) *IElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1777,7 +1689,7 @@ func (e *IElement) Style(s string) *IElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1786,7 +1698,7 @@ func (e *IElement) Style(s string) *IElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *IElement) IfStyle(condition bool, s string) *IElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1794,15 +1706,7 @@ func (e *IElement) IfStyle(condition bool, s string) *IElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *IElement) StyleAdd(k string, v string) *IElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1816,7 +1720,7 @@ func (e *IElement) StyleAddf(k string, format string, args ...any) *IElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *IElement) IfStyleAdd(condition bool, k string, v string) *IElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1825,7 +1729,7 @@ func (e *IElement) IfStyleAdd(condition bool, k string, v string) *IElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *IElement) IfStyleAddf(condition bool, k string, format string, args ...any) *IElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1834,21 +1738,15 @@ func (e *IElement) IfStyleAddf(condition bool, k string, format string, args ...
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *IElement) StyleMap(m map[string]string) *IElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1857,14 +1755,8 @@ func (e *IElement) StyleMap(m map[string]string) *IElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *IElement) StyleRemove(keys ...string) *IElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1882,10 +1774,8 @@ func (e *IElement) StyleRemove(keys ...string) *IElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *IElement) Tabindex(i int) *IElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1904,7 +1794,7 @@ func (e *IElement) Tabindex(i int) *IElement {
 // their relative position in the document.
 func (e *IElement) IfTabindex(condition bool, i int) *IElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1924,10 +1814,8 @@ func (e *IElement) IfTabindex(condition bool, i int) *IElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *IElement) TabindexRemove() *IElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1948,10 +1836,8 @@ func (e *IElement) TabindexRemove() *IElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *IElement) Title(s string) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1993,7 +1879,7 @@ func (e *IElement) Titlef(format string, args ...any) *IElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *IElement) IfTitle(condition bool, s string) *IElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2016,7 +1902,7 @@ func (e *IElement) IfTitle(condition bool, s string) *IElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *IElement) IfTitlef(condition bool, format string, args ...any) *IElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2039,10 +1925,8 @@ func (e *IElement) IfTitlef(condition bool, format string, args ...any) *IElemen
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *IElement) TitleRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2051,10 +1935,8 @@ func (e *IElement) TitleRemove() *IElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *IElement) Translate(c ITranslateChoice) *IElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ITranslateChoice])
 	return e
 }
 
@@ -2075,9 +1957,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *IElement) TranslateRemove() *IElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML Inline Frame element (<iframe>) represents a nested browsing
@@ -22,18 +20,81 @@ type IframeElement struct {
 // with the tag "iframe" during rendering.
 func Iframe(children ...ElementRenderer) *IframeElement {
 	e := NewElement("iframe", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &IframeElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *IframeElement) Clone() *IframeElement {
+	return &IframeElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *IframeElement) CloneShallow() *IframeElement {
+	return &IframeElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *IframeElement) Freeze() *IframeElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *IframeElement) writable() *IframeElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *IframeElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *IframeElement) choiceType(name string) func(string) any {
+	switch name {
+	case "referrerpolicy":
+		return choiceValue[IframeReferrerpolicyChoice]
+	case "sandbox":
+		return choiceValue[IframeSandboxChoice]
+	case "autocapitalize":
+		return choiceValue[IframeAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[IframeContenteditableChoice]
+	case "dir":
+		return choiceValue[IframeDirChoice]
+	case "draggable":
+		return choiceValue[IframeDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[IframeEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[IframeHiddenChoice]
+	case "inputmode":
+		return choiceValue[IframeInputmodeChoice]
+	case "popover":
+		return choiceValue[IframePopoverChoice]
+	case "spellcheck":
+		return choiceValue[IframeSpellcheckChoice]
+	case "translate":
+		return choiceValue[IframeTranslateChoice]
+	}
+	return nil
+}
+
 func (e *IframeElement) Children(children ...ElementRenderer) *IframeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *IframeElement) IfChildren(condition bool, children ...ElementRenderer) *IframeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +102,30 @@ func (e *IframeElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *IframeElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *IframeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *IframeElement) BoolAttr(name string) *IframeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *IframeElement) BoolAttrRemove(name string) *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *IframeElement) IfBoolAttr(condition bool, name string) *IframeElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +136,40 @@ func (e *IframeElement) BoolAttrf(format string, args ...any) *IframeElement {
 
 func (e *IframeElement) IfBoolAttrf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *IframeElement) BoolAttrs(names ...string) *IframeElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *IframeElement) IfBoolAttrs(condition bool, names ...string) *IframeElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *IframeElement) Attr(name, value string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *IframeElement) RawAttr(name, value string) *IframeElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *IframeElement) IfAttr(condition bool, name, value string) *IframeElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +180,52 @@ func (e *IframeElement) Attrf(name, format string, args ...any) *IframeElement {
 
 func (e *IframeElement) IfAttrf(condition bool, name, format string, args ...any) *IframeElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *IframeElement) Attrs(attrs ...string) *IframeElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *IframeElement) IfAttrs(condition bool, attrs ...string) *IframeElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *IframeElement) AttrsMap(attrs map[string]string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *IframeElement) IfAttrsMap(condition bool, attrs map[string]string) *IframeElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *IframeElement) Text(text string) *IframeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *IframeElement) Textf(format string, args ...any) *IframeElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *IframeElement) IfText(condition bool, text string) *IframeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +233,28 @@ func (e *IframeElement) IfText(condition bool, text string) *IframeElement {
 
 func (e *IframeElement) IfTextf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *IframeElement) Raw(text string) *IframeElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *IframeElement) Escaped(text string) *IframeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *IframeElement) IfEscaped(condition bool, text string) *IframeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +266,7 @@ func (e *IframeElement) Escapedf(format string, args ...any) *IframeElement {
 
 func (e *IframeElement) IfEscapedf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,23 +274,16 @@ func (e *IframeElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Allows the iframe's contents to be treated as being from a different origin.
 func (e *IframeElement) Allow(s string) *IframeElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("allow")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("allow", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("allow", ",").Add(values...)
 	return e
 }
 
 // Allows the iframe's contents to be treated as being from a different origin.
 func (e *IframeElement) IfAllow(condition bool, s string) *IframeElement {
 	if condition {
-		e.Allow(s)
+		e = e.Allow(s)
 	}
 	return e
 }
@@ -233,24 +291,16 @@ func (e *IframeElement) IfAllow(condition bool, s string) *IframeElement {
 // Allows the iframe's contents to be treated as being from a different origin.
 // Remove the values from the attribute Allow in the element.
 func (e *IframeElement) AllowRemove(s ...string) *IframeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("allow")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("allow", s...)
 	return e
 }
 
 // Allows the iframe to be placed into full screen mode, and iframes are also
 // allowed to use the Fullscreen API.
 func (e *IframeElement) Allowfullscreen() *IframeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("allowfullscreen", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("allowfullscreen")
 	return e
 }
 
@@ -258,7 +308,7 @@ func (e *IframeElement) Allowfullscreen() *IframeElement {
 // allowed to use the Fullscreen API.
 func (e *IframeElement) IfAllowfullscreen(condition bool) *IframeElement {
 	if condition {
-		e.Allowfullscreen()
+		e = e.Allowfullscreen()
 	}
 	return e
 }
@@ -267,10 +317,8 @@ func (e *IframeElement) IfAllowfullscreen(condition bool) *IframeElement {
 // Allows the iframe to be placed into full screen mode, and iframes are also
 // allowed to use the Fullscreen API.
 func (e *IframeElement) AllowfullscreenRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("allowfullscreen")
+	e = e.writable()
+	e.removeAttribute("allowfullscreen")
 	return e
 }
 
@@ -278,20 +326,16 @@ func (e *IframeElement) AllowfullscreenRemove() *IframeElement {
 // Allows the iframe to be placed into full screen mode, and iframes are also
 // allowed to use the Fullscreen API.
 func (e *IframeElement) AllowfullscreenIfRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("allowfullscreen")
+	e = e.writable()
+	e.removeAttribute("allowfullscreen")
 	return e
 }
 
 // Allows the iframe to use the PaymentRequest interface to make payment
 // requests via the browser.
 func (e *IframeElement) Allowpaymentrequest() *IframeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("allowpaymentrequest", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("allowpaymentrequest")
 	return e
 }
 
@@ -299,7 +343,7 @@ func (e *IframeElement) Allowpaymentrequest() *IframeElement {
 // requests via the browser.
 func (e *IframeElement) IfAllowpaymentrequest(condition bool) *IframeElement {
 	if condition {
-		e.Allowpaymentrequest()
+		e = e.Allowpaymentrequest()
 	}
 	return e
 }
@@ -308,10 +352,8 @@ func (e *IframeElement) IfAllowpaymentrequest(condition bool) *IframeElement {
 // Allows the iframe to use the PaymentRequest interface to make payment
 // requests via the browser.
 func (e *IframeElement) AllowpaymentrequestRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("allowpaymentrequest")
+	e = e.writable()
+	e.removeAttribute("allowpaymentrequest")
 	return e
 }
 
@@ -319,26 +361,22 @@ func (e *IframeElement) AllowpaymentrequestRemove() *IframeElement {
 // Allows the iframe to use the PaymentRequest interface to make payment
 // requests via the browser.
 func (e *IframeElement) AllowpaymentrequestIfRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("allowpaymentrequest")
+	e = e.writable()
+	e.removeAttribute("allowpaymentrequest")
 	return e
 }
 
 // The height of the frame in CSS pixels.
 func (e *IframeElement) Height(i int) *IframeElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // The height of the frame in CSS pixels.
 func (e *IframeElement) IfHeight(condition bool, i int) *IframeElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -346,19 +384,15 @@ func (e *IframeElement) IfHeight(condition bool, i int) *IframeElement {
 // The height of the frame in CSS pixels.
 // Remove the attribute Height from the element.
 func (e *IframeElement) HeightRemove() *IframeElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // The name of the frame.
 func (e *IframeElement) Name(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -370,7 +404,7 @@ func (e *IframeElement) Namef(format string, args ...any) *IframeElement {
 // The name of the frame.
 func (e *IframeElement) IfName(condition bool, s string) *IframeElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -378,7 +412,7 @@ func (e *IframeElement) IfName(condition bool, s string) *IframeElement {
 // The name of the frame.
 func (e *IframeElement) IfNamef(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -386,20 +420,16 @@ func (e *IframeElement) IfNamef(condition bool, format string, args ...any) *Ifr
 // The name of the frame.
 // Remove the attribute Name from the element.
 func (e *IframeElement) NameRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
 // Specifies which referrer to send when fetching the resource. See
 // Referrer-Policy for possible values and their effects.
 func (e *IframeElement) Referrerpolicy(c IframeReferrerpolicyChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[IframeReferrerpolicyChoice])
 	return e
 }
 
@@ -433,19 +463,15 @@ const (
 // Referrer-Policy for possible values and their effects.
 // Remove the attribute Referrerpolicy from the element.
 func (e *IframeElement) ReferrerpolicyRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
 // Enables a set of extra restrictions on any content hosted by the iframe.
 func (e *IframeElement) Sandbox(c IframeSandboxChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("sandbox", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("sandbox", string(c), choiceValue[IframeSandboxChoice])
 	return e
 }
 
@@ -480,19 +506,15 @@ const (
 // Enables a set of extra restrictions on any content hosted by the iframe.
 // Remove the attribute Sandbox from the element.
 func (e *IframeElement) SandboxRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("sandbox")
+	e = e.writable()
+	e.removeAttribute("sandbox")
 	return e
 }
 
 // The URL of the page to embed.
 func (e *IframeElement) Src(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -504,7 +526,7 @@ func (e *IframeElement) Srcf(format string, args ...any) *IframeElement {
 // The URL of the page to embed.
 func (e *IframeElement) IfSrc(condition bool, s string) *IframeElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -512,7 +534,7 @@ func (e *IframeElement) IfSrc(condition bool, s string) *IframeElement {
 // The URL of the page to embed.
 func (e *IframeElement) IfSrcf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -520,19 +542,15 @@ func (e *IframeElement) IfSrcf(condition bool, format string, args ...any) *Ifra
 // The URL of the page to embed.
 // Remove the attribute Src from the element.
 func (e *IframeElement) SrcRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // A document to render in the iframe.
 func (e *IframeElement) Srcdoc(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("srcdoc", s)
+	e = e.writable()
+	e.setStringAttribute("srcdoc", s)
 	return e
 }
 
@@ -544,7 +562,7 @@ func (e *IframeElement) Srcdocf(format string, args ...any) *IframeElement {
 // A document to render in the iframe.
 func (e *IframeElement) IfSrcdoc(condition bool, s string) *IframeElement {
 	if condition {
-		e.Srcdoc(s)
+		e = e.Srcdoc(s)
 	}
 	return e
 }
@@ -552,7 +570,7 @@ func (e *IframeElement) IfSrcdoc(condition bool, s string) *IframeElement {
 // A document to render in the iframe.
 func (e *IframeElement) IfSrcdocf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Srcdoc(fmt.Sprintf(format, args...))
+		e = e.Srcdoc(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -560,26 +578,22 @@ func (e *IframeElement) IfSrcdocf(condition bool, format string, args ...any) *I
 // A document to render in the iframe.
 // Remove the attribute Srcdoc from the element.
 func (e *IframeElement) SrcdocRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("srcdoc")
+	e = e.writable()
+	e.removeAttribute("srcdoc")
 	return e
 }
 
 // The width of the frame in CSS pixels.
 func (e *IframeElement) Width(i int) *IframeElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // The width of the frame in CSS pixels.
 func (e *IframeElement) IfWidth(condition bool, i int) *IframeElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -587,10 +601,8 @@ func (e *IframeElement) IfWidth(condition bool, i int) *IframeElement {
 // The width of the frame in CSS pixels.
 // Remove the attribute Width from the element.
 func (e *IframeElement) WidthRemove() *IframeElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -599,10 +611,8 @@ func (e *IframeElement) WidthRemove() *IframeElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *IframeElement) Accesskey(r rune) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -612,7 +622,7 @@ func (e *IframeElement) Accesskey(r rune) *IframeElement {
 // can be generated by the keyboard).
 func (e *IframeElement) IfAccesskey(condition bool, r rune) *IframeElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -623,10 +633,8 @@ func (e *IframeElement) IfAccesskey(condition bool, r rune) *IframeElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *IframeElement) AccesskeyRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -642,10 +650,8 @@ func (e *IframeElement) AccesskeyRemove() *IframeElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *IframeElement) Autocapitalize(c IframeAutocapitalizeChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[IframeAutocapitalizeChoice])
 	return e
 }
 
@@ -679,10 +685,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *IframeElement) AutocapitalizeRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -701,10 +705,8 @@ func (e *IframeElement) AutocapitalizeRemove() *IframeElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *IframeElement) Autofocus() *IframeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -724,7 +726,7 @@ func (e *IframeElement) Autofocus() *IframeElement {
 // created by the preceding content.
 func (e *IframeElement) IfAutofocus(condition bool) *IframeElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -745,10 +747,8 @@ func (e *IframeElement) IfAutofocus(condition bool) *IframeElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *IframeElement) AutofocusRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -768,10 +768,8 @@ func (e *IframeElement) AutofocusRemove() *IframeElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *IframeElement) AutofocusIfRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -780,16 +778,9 @@ func (e *IframeElement) AutofocusIfRemove() *IframeElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *IframeElement) Class(s string) *IframeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -799,7 +790,7 @@ func (e *IframeElement) Class(s string) *IframeElement {
 // document.getElementsByClassName.
 func (e *IframeElement) IfClass(condition bool, s string) *IframeElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -810,14 +801,8 @@ func (e *IframeElement) IfClass(condition bool, s string) *IframeElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *IframeElement) ClassRemove(s ...string) *IframeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -825,10 +810,8 @@ func (e *IframeElement) ClassRemove(s ...string) *IframeElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *IframeElement) Contenteditable(c IframeContenteditableChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[IframeContenteditableChoice])
 	return e
 }
 
@@ -851,10 +834,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *IframeElement) ContenteditableRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -872,10 +853,8 @@ func (e *IframeElement) ContenteditableRemove() *IframeElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *IframeElement) Dir(c IframeDirChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[IframeDirChoice])
 	return e
 }
 
@@ -909,10 +888,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *IframeElement) DirRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -920,10 +897,8 @@ func (e *IframeElement) DirRemove() *IframeElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *IframeElement) Draggable(c IframeDraggableChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[IframeDraggableChoice])
 	return e
 }
 
@@ -949,20 +924,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *IframeElement) DraggableRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *IframeElement) Enterkeyhint(c IframeEnterkeyhintChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[IframeEnterkeyhintChoice])
 	return e
 }
 
@@ -992,10 +963,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *IframeElement) EnterkeyhintRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1017,16 +986,9 @@ func (e *IframeElement) EnterkeyhintRemove() *IframeElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *IframeElement) Exportparts(s string) *IframeElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1049,7 +1011,7 @@ func (e *IframeElement) Exportparts(s string) *IframeElement {
 // the current structure.
 func (e *IframeElement) IfExportparts(condition bool, s string) *IframeElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1073,14 +1035,8 @@ func (e *IframeElement) IfExportparts(condition bool, s string) *IframeElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *IframeElement) ExportpartsRemove(s ...string) *IframeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1097,10 +1053,8 @@ func (e *IframeElement) ExportpartsRemove(s ...string) *IframeElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *IframeElement) Hidden(c IframeHiddenChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[IframeHiddenChoice])
 	return e
 }
 
@@ -1136,10 +1090,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *IframeElement) HiddenRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1147,10 +1099,8 @@ func (e *IframeElement) HiddenRemove() *IframeElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *IframeElement) ID(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1166,7 +1116,7 @@ func (e *IframeElement) IDf(format string, args ...any) *IframeElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *IframeElement) IfID(condition bool, s string) *IframeElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1176,7 +1126,7 @@ func (e *IframeElement) IfID(condition bool, s string) *IframeElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *IframeElement) IfIDf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1186,10 +1136,8 @@ func (e *IframeElement) IfIDf(condition bool, format string, args ...any) *Ifram
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *IframeElement) IDRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1204,10 +1152,8 @@ func (e *IframeElement) IDRemove() *IframeElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *IframeElement) Inert() *IframeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1223,7 +1169,7 @@ func (e *IframeElement) Inert() *IframeElement {
 // excluding them from the accessibility tree.
 func (e *IframeElement) IfInert(condition bool) *IframeElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1240,10 +1186,8 @@ func (e *IframeElement) IfInert(condition bool) *IframeElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *IframeElement) InertRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1259,10 +1203,8 @@ func (e *IframeElement) InertRemove() *IframeElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *IframeElement) InertIfRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1276,10 +1218,8 @@ func (e *IframeElement) InertIfRemove() *IframeElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *IframeElement) Inputmode(c IframeInputmodeChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[IframeInputmodeChoice])
 	return e
 }
 
@@ -1330,10 +1270,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *IframeElement) InputmodeRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1343,10 +1281,8 @@ func (e *IframeElement) InputmodeRemove() *IframeElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *IframeElement) Is(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1366,7 +1302,7 @@ func (e *IframeElement) Isf(format string, args ...any) *IframeElement {
 // and extends the element type it is being applied to.
 func (e *IframeElement) IfIs(condition bool, s string) *IframeElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1378,7 +1314,7 @@ func (e *IframeElement) IfIs(condition bool, s string) *IframeElement {
 // and extends the element type it is being applied to.
 func (e *IframeElement) IfIsf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1390,10 +1326,8 @@ func (e *IframeElement) IfIsf(condition bool, format string, args ...any) *Ifram
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *IframeElement) IsRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1409,10 +1343,8 @@ func (e *IframeElement) IsRemove() *IframeElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *IframeElement) Itemid(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1444,7 +1376,7 @@ func (e *IframeElement) Itemidf(format string, args ...any) *IframeElement {
 // how items with the same identifier are handled.
 func (e *IframeElement) IfItemid(condition bool, s string) *IframeElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1462,7 +1394,7 @@ func (e *IframeElement) IfItemid(condition bool, s string) *IframeElement {
 // how items with the same identifier are handled.
 func (e *IframeElement) IfItemidf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1480,10 +1412,8 @@ func (e *IframeElement) IfItemidf(condition bool, format string, args ...any) *I
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *IframeElement) ItemidRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1495,10 +1425,8 @@ func (e *IframeElement) ItemidRemove() *IframeElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *IframeElement) Itemprop(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1522,7 +1450,7 @@ func (e *IframeElement) Itempropf(format string, args ...any) *IframeElement {
 // <track>, and <video>.
 func (e *IframeElement) IfItemprop(condition bool, s string) *IframeElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1536,7 +1464,7 @@ func (e *IframeElement) IfItemprop(condition bool, s string) *IframeElement {
 // <track>, and <video>.
 func (e *IframeElement) IfItempropf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1550,10 +1478,8 @@ func (e *IframeElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *IframeElement) ItempropRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1563,10 +1489,8 @@ func (e *IframeElement) ItempropRemove() *IframeElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *IframeElement) Itemref(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1586,7 +1510,7 @@ func (e *IframeElement) Itemreff(format string, args ...any) *IframeElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *IframeElement) IfItemref(condition bool, s string) *IframeElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1598,7 +1522,7 @@ func (e *IframeElement) IfItemref(condition bool, s string) *IframeElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *IframeElement) IfItemreff(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1610,10 +1534,8 @@ func (e *IframeElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *IframeElement) ItemrefRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1625,10 +1547,8 @@ func (e *IframeElement) ItemrefRemove() *IframeElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *IframeElement) Itemscope() *IframeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1641,7 +1561,7 @@ func (e *IframeElement) Itemscope() *IframeElement {
 // <object>, <source>, <track>, and <video>.
 func (e *IframeElement) IfItemscope(condition bool) *IframeElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1655,10 +1575,8 @@ func (e *IframeElement) IfItemscope(condition bool) *IframeElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *IframeElement) ItemscopeRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1671,10 +1589,8 @@ func (e *IframeElement) ItemscopeRemove() *IframeElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *IframeElement) ItemscopeIfRemove() *IframeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1686,10 +1602,8 @@ func (e *IframeElement) ItemscopeIfRemove() *IframeElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *IframeElement) Itemtype(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1713,7 +1627,7 @@ func (e *IframeElement) Itemtypef(format string, args ...any) *IframeElement {
 // <video>.
 func (e *IframeElement) IfItemtype(condition bool, s string) *IframeElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1727,7 +1641,7 @@ func (e *IframeElement) IfItemtype(condition bool, s string) *IframeElement {
 // <video>.
 func (e *IframeElement) IfItemtypef(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1741,10 +1655,8 @@ func (e *IframeElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *IframeElement) ItemtypeRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1754,10 +1666,8 @@ func (e *IframeElement) ItemtypeRemove() *IframeElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *IframeElement) Lang(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1777,7 +1687,7 @@ func (e *IframeElement) Langf(format string, args ...any) *IframeElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *IframeElement) IfLang(condition bool, s string) *IframeElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1789,7 +1699,7 @@ func (e *IframeElement) IfLang(condition bool, s string) *IframeElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *IframeElement) IfLangf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1801,10 +1711,8 @@ func (e *IframeElement) IfLangf(condition bool, format string, args ...any) *Ifr
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *IframeElement) LangRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1814,10 +1722,8 @@ func (e *IframeElement) LangRemove() *IframeElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *IframeElement) Nonce(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1837,7 +1743,7 @@ func (e *IframeElement) Noncef(format string, args ...any) *IframeElement {
 // execute.
 func (e *IframeElement) IfNonce(condition bool, s string) *IframeElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1849,7 +1755,7 @@ func (e *IframeElement) IfNonce(condition bool, s string) *IframeElement {
 // execute.
 func (e *IframeElement) IfNoncef(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1861,10 +1767,8 @@ func (e *IframeElement) IfNoncef(condition bool, format string, args ...any) *If
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *IframeElement) NonceRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1872,16 +1776,9 @@ func (e *IframeElement) NonceRemove() *IframeElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *IframeElement) Part(s string) *IframeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1890,7 +1787,7 @@ func (e *IframeElement) Part(s string) *IframeElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *IframeElement) IfPart(condition bool, s string) *IframeElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1900,14 +1797,8 @@ func (e *IframeElement) IfPart(condition bool, s string) *IframeElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *IframeElement) PartRemove(s ...string) *IframeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1918,10 +1809,8 @@ func (e *IframeElement) PartRemove(s ...string) *IframeElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *IframeElement) Popover(c IframePopoverChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[IframePopoverChoice])
 	return e
 }
 
@@ -1949,10 +1838,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *IframeElement) PopoverRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1961,10 +1848,8 @@ func (e *IframeElement) PopoverRemove() *IframeElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *IframeElement) Role(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1982,7 +1867,7 @@ func (e *IframeElement) Rolef(format string, args ...any) *IframeElement {
 // role of an element.
 func (e *IframeElement) IfRole(condition bool, s string) *IframeElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1993,7 +1878,7 @@ func (e *IframeElement) IfRole(condition bool, s string) *IframeElement {
 // role of an element.
 func (e *IframeElement) IfRolef(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2004,10 +1889,8 @@ func (e *IframeElement) IfRolef(condition bool, format string, args ...any) *Ifr
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *IframeElement) RoleRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2016,10 +1899,8 @@ func (e *IframeElement) RoleRemove() *IframeElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *IframeElement) Slot(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2037,7 +1918,7 @@ func (e *IframeElement) Slotf(format string, args ...any) *IframeElement {
 // value.
 func (e *IframeElement) IfSlot(condition bool, s string) *IframeElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2048,7 +1929,7 @@ func (e *IframeElement) IfSlot(condition bool, s string) *IframeElement {
 // value.
 func (e *IframeElement) IfSlotf(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2059,10 +1940,8 @@ func (e *IframeElement) IfSlotf(condition bool, format string, args ...any) *Ifr
 // value.
 // Remove the attribute Slot from the element.
 func (e *IframeElement) SlotRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2078,10 +1957,8 @@ func (e *IframeElement) SlotRemove() *IframeElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *IframeElement) Spellcheck(c IframeSpellcheckChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[IframeSpellcheckChoice])
 	return e
 }
 
@@ -2109,10 +1986,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *IframeElement) SpellcheckRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2122,14 +1997,8 @@ func (e *IframeElement) StylePairs(pairs ...string) *IframeElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2144,14 +2013,8 @@ func (e *IframeElement) StylePairs(pairs ...string) *IframeElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *IframeElement) Style(s string) *IframeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2159,7 +2022,7 @@ func (e *IframeElement) Style(s string) *IframeElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2168,7 +2031,7 @@ func (e *IframeElement) Style(s string) *IframeElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *IframeElement) IfStyle(condition bool, s string) *IframeElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2176,15 +2039,7 @@ func (e *IframeElement) IfStyle(condition bool, s string) *IframeElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *IframeElement) StyleAdd(k string, v string) *IframeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2198,7 +2053,7 @@ func (e *IframeElement) StyleAddf(k string, format string, args ...any) *IframeE
 // color, font, size, and more. Styles are written in CSS.
 func (e *IframeElement) IfStyleAdd(condition bool, k string, v string) *IframeElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2207,7 +2062,7 @@ func (e *IframeElement) IfStyleAdd(condition bool, k string, v string) *IframeEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *IframeElement) IfStyleAddf(condition bool, k string, format string, args ...any) *IframeElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2216,21 +2071,15 @@ func (e *IframeElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *IframeElement) StyleMap(m map[string]string) *IframeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2239,14 +2088,8 @@ func (e *IframeElement) StyleMap(m map[string]string) *IframeElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *IframeElement) StyleRemove(keys ...string) *IframeElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2264,10 +2107,8 @@ func (e *IframeElement) StyleRemove(keys ...string) *IframeElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *IframeElement) Tabindex(i int) *IframeElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2286,7 +2127,7 @@ func (e *IframeElement) Tabindex(i int) *IframeElement {
 // their relative position in the document.
 func (e *IframeElement) IfTabindex(condition bool, i int) *IframeElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2306,10 +2147,8 @@ func (e *IframeElement) IfTabindex(condition bool, i int) *IframeElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *IframeElement) TabindexRemove() *IframeElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2330,10 +2169,8 @@ func (e *IframeElement) TabindexRemove() *IframeElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *IframeElement) Title(s string) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2375,7 +2212,7 @@ func (e *IframeElement) Titlef(format string, args ...any) *IframeElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *IframeElement) IfTitle(condition bool, s string) *IframeElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2398,7 +2235,7 @@ func (e *IframeElement) IfTitle(condition bool, s string) *IframeElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *IframeElement) IfTitlef(condition bool, format string, args ...any) *IframeElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2421,10 +2258,8 @@ func (e *IframeElement) IfTitlef(condition bool, format string, args ...any) *If
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *IframeElement) TitleRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2433,10 +2268,8 @@ func (e *IframeElement) TitleRemove() *IframeElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *IframeElement) Translate(c IframeTranslateChoice) *IframeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[IframeTranslateChoice])
 	return e
 }
 
@@ -2457,9 +2290,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *IframeElement) TranslateRemove() *IframeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
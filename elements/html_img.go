@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <img> element embeds an image into the document.
@@ -21,18 +19,87 @@ type ImgElement struct {
 // with the tag "img" during rendering.
 func Img() *ImgElement {
 	e := NewElement("img")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &ImgElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ImgElement) Clone() *ImgElement {
+	return &ImgElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ImgElement) CloneShallow() *ImgElement {
+	return &ImgElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ImgElement) Freeze() *ImgElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ImgElement) writable() *ImgElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ImgElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ImgElement) choiceType(name string) func(string) any {
+	switch name {
+	case "crossorigin":
+		return choiceValue[ImgCrossoriginChoice]
+	case "decoding":
+		return choiceValue[ImgDecodingChoice]
+	case "fetchpriority":
+		return choiceValue[ImgFetchpriorityChoice]
+	case "loading":
+		return choiceValue[ImgLoadingChoice]
+	case "referrerpolicy":
+		return choiceValue[ImgReferrerpolicyChoice]
+	case "autocapitalize":
+		return choiceValue[ImgAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ImgContenteditableChoice]
+	case "dir":
+		return choiceValue[ImgDirChoice]
+	case "draggable":
+		return choiceValue[ImgDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ImgEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ImgHiddenChoice]
+	case "inputmode":
+		return choiceValue[ImgInputmodeChoice]
+	case "popover":
+		return choiceValue[ImgPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ImgSpellcheckChoice]
+	case "translate":
+		return choiceValue[ImgTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ImgElement) Children(children ...ElementRenderer) *ImgElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ImgElement) IfChildren(condition bool, children ...ElementRenderer) *ImgElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +107,30 @@ func (e *ImgElement) IfChildren(condition bool, children ...ElementRenderer) *Im
 
 func (e *ImgElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ImgElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ImgElement) BoolAttr(name string) *ImgElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ImgElement) BoolAttrRemove(name string) *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ImgElement) IfBoolAttr(condition bool, name string) *ImgElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +141,40 @@ func (e *ImgElement) BoolAttrf(format string, args ...any) *ImgElement {
 
 func (e *ImgElement) IfBoolAttrf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ImgElement) BoolAttrs(names ...string) *ImgElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ImgElement) IfBoolAttrs(condition bool, names ...string) *ImgElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ImgElement) Attr(name, value string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ImgElement) RawAttr(name, value string) *ImgElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ImgElement) IfAttr(condition bool, name, value string) *ImgElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +185,52 @@ func (e *ImgElement) Attrf(name, format string, args ...any) *ImgElement {
 
 func (e *ImgElement) IfAttrf(condition bool, name, format string, args ...any) *ImgElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ImgElement) Attrs(attrs ...string) *ImgElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ImgElement) IfAttrs(condition bool, attrs ...string) *ImgElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ImgElement) AttrsMap(attrs map[string]string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ImgElement) IfAttrsMap(condition bool, attrs map[string]string) *ImgElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ImgElement) Text(text string) *ImgElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ImgElement) Textf(format string, args ...any) *ImgElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ImgElement) IfText(condition bool, text string) *ImgElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +238,28 @@ func (e *ImgElement) IfText(condition bool, text string) *ImgElement {
 
 func (e *ImgElement) IfTextf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ImgElement) Raw(text string) *ImgElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ImgElement) Escaped(text string) *ImgElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ImgElement) IfEscaped(condition bool, text string) *ImgElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +271,7 @@ func (e *ImgElement) Escapedf(format string, args ...any) *ImgElement {
 
 func (e *ImgElement) IfEscapedf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,10 +279,8 @@ func (e *ImgElement) IfEscapedf(condition bool, format string, args ...any) *Img
 
 // The text alternative of the element's content.
 func (e *ImgElement) Alt(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("alt", s)
+	e = e.writable()
+	e.setStringAttribute("alt", s)
 	return e
 }
 
@@ -223,7 +292,7 @@ func (e *ImgElement) Altf(format string, args ...any) *ImgElement {
 // The text alternative of the element's content.
 func (e *ImgElement) IfAlt(condition bool, s string) *ImgElement {
 	if condition {
-		e.Alt(s)
+		e = e.Alt(s)
 	}
 	return e
 }
@@ -231,7 +300,7 @@ func (e *ImgElement) IfAlt(condition bool, s string) *ImgElement {
 // The text alternative of the element's content.
 func (e *ImgElement) IfAltf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Alt(fmt.Sprintf(format, args...))
+		e = e.Alt(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -239,10 +308,8 @@ func (e *ImgElement) IfAltf(condition bool, format string, args ...any) *ImgElem
 // The text alternative of the element's content.
 // Remove the attribute Alt from the element.
 func (e *ImgElement) AltRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("alt")
+	e = e.writable()
+	e.removeAttribute("alt")
 	return e
 }
 
@@ -250,10 +317,8 @@ func (e *ImgElement) AltRemove() *ImgElement {
 // attribute must not be specified on an element that does not have an alt
 // attribute, or whose alt attribute's value is the empty string.
 func (e *ImgElement) Controls() *ImgElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("controls", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("controls")
 	return e
 }
 
@@ -262,7 +327,7 @@ func (e *ImgElement) Controls() *ImgElement {
 // attribute, or whose alt attribute's value is the empty string.
 func (e *ImgElement) IfControls(condition bool) *ImgElement {
 	if condition {
-		e.Controls()
+		e = e.Controls()
 	}
 	return e
 }
@@ -272,10 +337,8 @@ func (e *ImgElement) IfControls(condition bool) *ImgElement {
 // attribute must not be specified on an element that does not have an alt
 // attribute, or whose alt attribute's value is the empty string.
 func (e *ImgElement) ControlsRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("controls")
+	e = e.writable()
+	e.removeAttribute("controls")
 	return e
 }
 
@@ -284,19 +347,15 @@ func (e *ImgElement) ControlsRemove() *ImgElement {
 // attribute must not be specified on an element that does not have an alt
 // attribute, or whose alt attribute's value is the empty string.
 func (e *ImgElement) ControlsIfRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("controls")
+	e = e.writable()
+	e.removeAttribute("controls")
 	return e
 }
 
 // How the element handles crossorigin requests.
 func (e *ImgElement) Crossorigin(c ImgCrossoriginChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("crossorigin", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("crossorigin", string(c), choiceValue[ImgCrossoriginChoice])
 	return e
 }
 
@@ -314,10 +373,8 @@ const (
 // How the element handles crossorigin requests.
 // Remove the attribute Crossorigin from the element.
 func (e *ImgElement) CrossoriginRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("crossorigin")
+	e = e.writable()
+	e.removeAttribute("crossorigin")
 	return e
 }
 
@@ -327,10 +384,8 @@ func (e *ImgElement) CrossoriginRemove() *ImgElement {
 // other DOM content first and then decode the image and present it later
 // (async).
 func (e *ImgElement) Decoding(c ImgDecodingChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("decoding", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("decoding", string(c), choiceValue[ImgDecodingChoice])
 	return e
 }
 
@@ -355,19 +410,15 @@ const (
 // (async).
 // Remove the attribute Decoding from the element.
 func (e *ImgElement) DecodingRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("decoding")
+	e = e.writable()
+	e.removeAttribute("decoding")
 	return e
 }
 
 // Provides a hint of the relative priority to use when fetching the image.
 func (e *ImgElement) Fetchpriority(c ImgFetchpriorityChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fetchpriority", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("fetchpriority", string(c), choiceValue[ImgFetchpriorityChoice])
 	return e
 }
 
@@ -386,26 +437,22 @@ const (
 // Provides a hint of the relative priority to use when fetching the image.
 // Remove the attribute Fetchpriority from the element.
 func (e *ImgElement) FetchpriorityRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fetchpriority")
+	e = e.writable()
+	e.removeAttribute("fetchpriority")
 	return e
 }
 
 // The height of the image in pixels.
 func (e *ImgElement) Height(i int) *ImgElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // The height of the image in pixels.
 func (e *ImgElement) IfHeight(condition bool, i int) *ImgElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -413,26 +460,22 @@ func (e *ImgElement) IfHeight(condition bool, i int) *ImgElement {
 // The height of the image in pixels.
 // Remove the attribute Height from the element.
 func (e *ImgElement) HeightRemove() *ImgElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // Indicates that the image is part of a server-side image map.
 func (e *ImgElement) Ismap() *ImgElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("ismap", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("ismap")
 	return e
 }
 
 // Indicates that the image is part of a server-side image map.
 func (e *ImgElement) IfIsmap(condition bool) *ImgElement {
 	if condition {
-		e.Ismap()
+		e = e.Ismap()
 	}
 	return e
 }
@@ -440,29 +483,23 @@ func (e *ImgElement) IfIsmap(condition bool) *ImgElement {
 // Remove the attribute Ismap from the element.
 // Indicates that the image is part of a server-side image map.
 func (e *ImgElement) IsmapRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("ismap")
+	e = e.writable()
+	e.removeAttribute("ismap")
 	return e
 }
 
 // Remove the attribute Ismap from the element.
 // Indicates that the image is part of a server-side image map.
 func (e *ImgElement) IsmapIfRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("ismap")
+	e = e.writable()
+	e.removeAttribute("ismap")
 	return e
 }
 
 // Indicates how the browser should load the image.
 func (e *ImgElement) Loading(c ImgLoadingChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("loading", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("loading", string(c), choiceValue[ImgLoadingChoice])
 	return e
 }
 
@@ -481,20 +518,16 @@ const (
 // Indicates how the browser should load the image.
 // Remove the attribute Loading from the element.
 func (e *ImgElement) LoadingRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("loading")
+	e = e.writable()
+	e.removeAttribute("loading")
 	return e
 }
 
 // Specifies which referrer to send when fetching the resource. See
 // Referrer-Policy for possible values and their effects.
 func (e *ImgElement) Referrerpolicy(c ImgReferrerpolicyChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[ImgReferrerpolicyChoice])
 	return e
 }
 
@@ -528,19 +561,15 @@ const (
 // Referrer-Policy for possible values and their effects.
 // Remove the attribute Referrerpolicy from the element.
 func (e *ImgElement) ReferrerpolicyRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
 // A set of source sizes.
 func (e *ImgElement) Sizes(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("sizes", s)
+	e = e.writable()
+	e.setStringAttribute("sizes", s)
 	return e
 }
 
@@ -552,7 +581,7 @@ func (e *ImgElement) Sizesf(format string, args ...any) *ImgElement {
 // A set of source sizes.
 func (e *ImgElement) IfSizes(condition bool, s string) *ImgElement {
 	if condition {
-		e.Sizes(s)
+		e = e.Sizes(s)
 	}
 	return e
 }
@@ -560,7 +589,7 @@ func (e *ImgElement) IfSizes(condition bool, s string) *ImgElement {
 // A set of source sizes.
 func (e *ImgElement) IfSizesf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Sizes(fmt.Sprintf(format, args...))
+		e = e.Sizes(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -568,19 +597,15 @@ func (e *ImgElement) IfSizesf(condition bool, format string, args ...any) *ImgEl
 // A set of source sizes.
 // Remove the attribute Sizes from the element.
 func (e *ImgElement) SizesRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("sizes")
+	e = e.writable()
+	e.removeAttribute("sizes")
 	return e
 }
 
 // The image URL.
 func (e *ImgElement) Src(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -592,7 +617,7 @@ func (e *ImgElement) Srcf(format string, args ...any) *ImgElement {
 // The image URL.
 func (e *ImgElement) IfSrc(condition bool, s string) *ImgElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -600,7 +625,7 @@ func (e *ImgElement) IfSrc(condition bool, s string) *ImgElement {
 // The image URL.
 func (e *ImgElement) IfSrcf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -608,20 +633,16 @@ func (e *ImgElement) IfSrcf(condition bool, format string, args ...any) *ImgElem
 // The image URL.
 // Remove the attribute Src from the element.
 func (e *ImgElement) SrcRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // A list of one or more strings separated by commas indicating a set of
 // possible image sources for the user agent to use.
 func (e *ImgElement) Srcset(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("srcset", s)
+	e = e.writable()
+	e.setStringAttribute("srcset", s)
 	return e
 }
 
@@ -635,7 +656,7 @@ func (e *ImgElement) Srcsetf(format string, args ...any) *ImgElement {
 // possible image sources for the user agent to use.
 func (e *ImgElement) IfSrcset(condition bool, s string) *ImgElement {
 	if condition {
-		e.Srcset(s)
+		e = e.Srcset(s)
 	}
 	return e
 }
@@ -644,7 +665,7 @@ func (e *ImgElement) IfSrcset(condition bool, s string) *ImgElement {
 // possible image sources for the user agent to use.
 func (e *ImgElement) IfSrcsetf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Srcset(fmt.Sprintf(format, args...))
+		e = e.Srcset(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -653,19 +674,15 @@ func (e *ImgElement) IfSrcsetf(condition bool, format string, args ...any) *ImgE
 // possible image sources for the user agent to use.
 // Remove the attribute Srcset from the element.
 func (e *ImgElement) SrcsetRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("srcset")
+	e = e.writable()
+	e.removeAttribute("srcset")
 	return e
 }
 
 // The <map> element id to use as an image map.
 func (e *ImgElement) Usemap(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("usemap", s)
+	e = e.writable()
+	e.setStringAttribute("usemap", s)
 	return e
 }
 
@@ -677,7 +694,7 @@ func (e *ImgElement) Usemapf(format string, args ...any) *ImgElement {
 // The <map> element id to use as an image map.
 func (e *ImgElement) IfUsemap(condition bool, s string) *ImgElement {
 	if condition {
-		e.Usemap(s)
+		e = e.Usemap(s)
 	}
 	return e
 }
@@ -685,7 +702,7 @@ func (e *ImgElement) IfUsemap(condition bool, s string) *ImgElement {
 // The <map> element id to use as an image map.
 func (e *ImgElement) IfUsemapf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Usemap(fmt.Sprintf(format, args...))
+		e = e.Usemap(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -693,26 +710,22 @@ func (e *ImgElement) IfUsemapf(condition bool, format string, args ...any) *ImgE
 // The <map> element id to use as an image map.
 // Remove the attribute Usemap from the element.
 func (e *ImgElement) UsemapRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("usemap")
+	e = e.writable()
+	e.removeAttribute("usemap")
 	return e
 }
 
 // The width of the image in pixels.
 func (e *ImgElement) Width(i int) *ImgElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // The width of the image in pixels.
 func (e *ImgElement) IfWidth(condition bool, i int) *ImgElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -720,10 +733,8 @@ func (e *ImgElement) IfWidth(condition bool, i int) *ImgElement {
 // The width of the image in pixels.
 // Remove the attribute Width from the element.
 func (e *ImgElement) WidthRemove() *ImgElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -732,10 +743,8 @@ func (e *ImgElement) WidthRemove() *ImgElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ImgElement) Accesskey(r rune) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -745,7 +754,7 @@ func (e *ImgElement) Accesskey(r rune) *ImgElement {
 // can be generated by the keyboard).
 func (e *ImgElement) IfAccesskey(condition bool, r rune) *ImgElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -756,10 +765,8 @@ func (e *ImgElement) IfAccesskey(condition bool, r rune) *ImgElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ImgElement) AccesskeyRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -775,10 +782,8 @@ func (e *ImgElement) AccesskeyRemove() *ImgElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ImgElement) Autocapitalize(c ImgAutocapitalizeChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ImgAutocapitalizeChoice])
 	return e
 }
 
@@ -812,10 +817,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ImgElement) AutocapitalizeRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -834,10 +837,8 @@ func (e *ImgElement) AutocapitalizeRemove() *ImgElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ImgElement) Autofocus() *ImgElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -857,7 +858,7 @@ func (e *ImgElement) Autofocus() *ImgElement {
 // created by the preceding content.
 func (e *ImgElement) IfAutofocus(condition bool) *ImgElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -878,10 +879,8 @@ func (e *ImgElement) IfAutofocus(condition bool) *ImgElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ImgElement) AutofocusRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -901,10 +900,8 @@ func (e *ImgElement) AutofocusRemove() *ImgElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ImgElement) AutofocusIfRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -913,16 +910,9 @@ func (e *ImgElement) AutofocusIfRemove() *ImgElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ImgElement) Class(s string) *ImgElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -932,7 +922,7 @@ func (e *ImgElement) Class(s string) *ImgElement {
 // document.getElementsByClassName.
 func (e *ImgElement) IfClass(condition bool, s string) *ImgElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -943,14 +933,8 @@ func (e *ImgElement) IfClass(condition bool, s string) *ImgElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ImgElement) ClassRemove(s ...string) *ImgElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -958,10 +942,8 @@ func (e *ImgElement) ClassRemove(s ...string) *ImgElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ImgElement) Contenteditable(c ImgContenteditableChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ImgContenteditableChoice])
 	return e
 }
 
@@ -984,10 +966,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ImgElement) ContenteditableRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -1005,10 +985,8 @@ func (e *ImgElement) ContenteditableRemove() *ImgElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ImgElement) Dir(c ImgDirChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ImgDirChoice])
 	return e
 }
 
@@ -1042,10 +1020,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ImgElement) DirRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -1053,10 +1029,8 @@ func (e *ImgElement) DirRemove() *ImgElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ImgElement) Draggable(c ImgDraggableChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ImgDraggableChoice])
 	return e
 }
 
@@ -1082,20 +1056,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ImgElement) DraggableRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ImgElement) Enterkeyhint(c ImgEnterkeyhintChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ImgEnterkeyhintChoice])
 	return e
 }
 
@@ -1125,10 +1095,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ImgElement) EnterkeyhintRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1150,16 +1118,9 @@ func (e *ImgElement) EnterkeyhintRemove() *ImgElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ImgElement) Exportparts(s string) *ImgElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1182,7 +1143,7 @@ func (e *ImgElement) Exportparts(s string) *ImgElement {
 // the current structure.
 func (e *ImgElement) IfExportparts(condition bool, s string) *ImgElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1206,14 +1167,8 @@ func (e *ImgElement) IfExportparts(condition bool, s string) *ImgElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ImgElement) ExportpartsRemove(s ...string) *ImgElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1230,10 +1185,8 @@ func (e *ImgElement) ExportpartsRemove(s ...string) *ImgElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ImgElement) Hidden(c ImgHiddenChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ImgHiddenChoice])
 	return e
 }
 
@@ -1269,10 +1222,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ImgElement) HiddenRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1280,10 +1231,8 @@ func (e *ImgElement) HiddenRemove() *ImgElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ImgElement) ID(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1299,7 +1248,7 @@ func (e *ImgElement) IDf(format string, args ...any) *ImgElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ImgElement) IfID(condition bool, s string) *ImgElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1309,7 +1258,7 @@ func (e *ImgElement) IfID(condition bool, s string) *ImgElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ImgElement) IfIDf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1319,10 +1268,8 @@ func (e *ImgElement) IfIDf(condition bool, format string, args ...any) *ImgEleme
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ImgElement) IDRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1337,10 +1284,8 @@ func (e *ImgElement) IDRemove() *ImgElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ImgElement) Inert() *ImgElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1356,7 +1301,7 @@ func (e *ImgElement) Inert() *ImgElement {
 // excluding them from the accessibility tree.
 func (e *ImgElement) IfInert(condition bool) *ImgElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1373,10 +1318,8 @@ func (e *ImgElement) IfInert(condition bool) *ImgElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ImgElement) InertRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1392,10 +1335,8 @@ func (e *ImgElement) InertRemove() *ImgElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ImgElement) InertIfRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1409,10 +1350,8 @@ func (e *ImgElement) InertIfRemove() *ImgElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ImgElement) Inputmode(c ImgInputmodeChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ImgInputmodeChoice])
 	return e
 }
 
@@ -1463,10 +1402,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ImgElement) InputmodeRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1476,10 +1413,8 @@ func (e *ImgElement) InputmodeRemove() *ImgElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ImgElement) Is(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1499,7 +1434,7 @@ func (e *ImgElement) Isf(format string, args ...any) *ImgElement {
 // and extends the element type it is being applied to.
 func (e *ImgElement) IfIs(condition bool, s string) *ImgElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1511,7 +1446,7 @@ func (e *ImgElement) IfIs(condition bool, s string) *ImgElement {
 // and extends the element type it is being applied to.
 func (e *ImgElement) IfIsf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1523,10 +1458,8 @@ func (e *ImgElement) IfIsf(condition bool, format string, args ...any) *ImgEleme
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ImgElement) IsRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1542,10 +1475,8 @@ func (e *ImgElement) IsRemove() *ImgElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ImgElement) Itemid(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1577,7 +1508,7 @@ func (e *ImgElement) Itemidf(format string, args ...any) *ImgElement {
 // how items with the same identifier are handled.
 func (e *ImgElement) IfItemid(condition bool, s string) *ImgElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1595,7 +1526,7 @@ func (e *ImgElement) IfItemid(condition bool, s string) *ImgElement {
 // how items with the same identifier are handled.
 func (e *ImgElement) IfItemidf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1613,10 +1544,8 @@ func (e *ImgElement) IfItemidf(condition bool, format string, args ...any) *ImgE
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ImgElement) ItemidRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1628,10 +1557,8 @@ func (e *ImgElement) ItemidRemove() *ImgElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ImgElement) Itemprop(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1655,7 +1582,7 @@ func (e *ImgElement) Itempropf(format string, args ...any) *ImgElement {
 // <track>, and <video>.
 func (e *ImgElement) IfItemprop(condition bool, s string) *ImgElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1669,7 +1596,7 @@ func (e *ImgElement) IfItemprop(condition bool, s string) *ImgElement {
 // <track>, and <video>.
 func (e *ImgElement) IfItempropf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1683,10 +1610,8 @@ func (e *ImgElement) IfItempropf(condition bool, format string, args ...any) *Im
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ImgElement) ItempropRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1696,10 +1621,8 @@ func (e *ImgElement) ItempropRemove() *ImgElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ImgElement) Itemref(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1719,7 +1642,7 @@ func (e *ImgElement) Itemreff(format string, args ...any) *ImgElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ImgElement) IfItemref(condition bool, s string) *ImgElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1731,7 +1654,7 @@ func (e *ImgElement) IfItemref(condition bool, s string) *ImgElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ImgElement) IfItemreff(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1743,10 +1666,8 @@ func (e *ImgElement) IfItemreff(condition bool, format string, args ...any) *Img
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ImgElement) ItemrefRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1758,10 +1679,8 @@ func (e *ImgElement) ItemrefRemove() *ImgElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ImgElement) Itemscope() *ImgElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1774,7 +1693,7 @@ func (e *ImgElement) Itemscope() *ImgElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ImgElement) IfItemscope(condition bool) *ImgElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1788,10 +1707,8 @@ func (e *ImgElement) IfItemscope(condition bool) *ImgElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ImgElement) ItemscopeRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1804,10 +1721,8 @@ func (e *ImgElement) ItemscopeRemove() *ImgElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ImgElement) ItemscopeIfRemove() *ImgElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1819,10 +1734,8 @@ func (e *ImgElement) ItemscopeIfRemove() *ImgElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ImgElement) Itemtype(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1846,7 +1759,7 @@ func (e *ImgElement) Itemtypef(format string, args ...any) *ImgElement {
 // <video>.
 func (e *ImgElement) IfItemtype(condition bool, s string) *ImgElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1860,7 +1773,7 @@ func (e *ImgElement) IfItemtype(condition bool, s string) *ImgElement {
 // <video>.
 func (e *ImgElement) IfItemtypef(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1874,10 +1787,8 @@ func (e *ImgElement) IfItemtypef(condition bool, format string, args ...any) *Im
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ImgElement) ItemtypeRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1887,10 +1798,8 @@ func (e *ImgElement) ItemtypeRemove() *ImgElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ImgElement) Lang(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1910,7 +1819,7 @@ func (e *ImgElement) Langf(format string, args ...any) *ImgElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ImgElement) IfLang(condition bool, s string) *ImgElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1922,7 +1831,7 @@ func (e *ImgElement) IfLang(condition bool, s string) *ImgElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ImgElement) IfLangf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1934,10 +1843,8 @@ func (e *ImgElement) IfLangf(condition bool, format string, args ...any) *ImgEle
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ImgElement) LangRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1947,10 +1854,8 @@ func (e *ImgElement) LangRemove() *ImgElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ImgElement) Nonce(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1970,7 +1875,7 @@ func (e *ImgElement) Noncef(format string, args ...any) *ImgElement {
 // execute.
 func (e *ImgElement) IfNonce(condition bool, s string) *ImgElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1982,7 +1887,7 @@ func (e *ImgElement) IfNonce(condition bool, s string) *ImgElement {
 // execute.
 func (e *ImgElement) IfNoncef(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1994,10 +1899,8 @@ func (e *ImgElement) IfNoncef(condition bool, format string, args ...any) *ImgEl
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ImgElement) NonceRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -2005,16 +1908,9 @@ func (e *ImgElement) NonceRemove() *ImgElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ImgElement) Part(s string) *ImgElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -2023,7 +1919,7 @@ func (e *ImgElement) Part(s string) *ImgElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ImgElement) IfPart(condition bool, s string) *ImgElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -2033,14 +1929,8 @@ func (e *ImgElement) IfPart(condition bool, s string) *ImgElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ImgElement) PartRemove(s ...string) *ImgElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -2051,10 +1941,8 @@ func (e *ImgElement) PartRemove(s ...string) *ImgElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ImgElement) Popover(c ImgPopoverChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ImgPopoverChoice])
 	return e
 }
 
@@ -2082,10 +1970,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ImgElement) PopoverRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -2094,10 +1980,8 @@ func (e *ImgElement) PopoverRemove() *ImgElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ImgElement) Role(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -2115,7 +1999,7 @@ func (e *ImgElement) Rolef(format string, args ...any) *ImgElement {
 // role of an element.
 func (e *ImgElement) IfRole(condition bool, s string) *ImgElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -2126,7 +2010,7 @@ func (e *ImgElement) IfRole(condition bool, s string) *ImgElement {
 // role of an element.
 func (e *ImgElement) IfRolef(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2137,10 +2021,8 @@ func (e *ImgElement) IfRolef(condition bool, format string, args ...any) *ImgEle
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ImgElement) RoleRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2149,10 +2031,8 @@ func (e *ImgElement) RoleRemove() *ImgElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ImgElement) Slot(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2170,7 +2050,7 @@ func (e *ImgElement) Slotf(format string, args ...any) *ImgElement {
 // value.
 func (e *ImgElement) IfSlot(condition bool, s string) *ImgElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2181,7 +2061,7 @@ func (e *ImgElement) IfSlot(condition bool, s string) *ImgElement {
 // value.
 func (e *ImgElement) IfSlotf(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2192,10 +2072,8 @@ func (e *ImgElement) IfSlotf(condition bool, format string, args ...any) *ImgEle
 // value.
 // Remove the attribute Slot from the element.
 func (e *ImgElement) SlotRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2211,10 +2089,8 @@ func (e *ImgElement) SlotRemove() *ImgElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ImgElement) Spellcheck(c ImgSpellcheckChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ImgSpellcheckChoice])
 	return e
 }
 
@@ -2242,10 +2118,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ImgElement) SpellcheckRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2255,14 +2129,8 @@ func (e *ImgElement) StylePairs(pairs ...string) *ImgElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2277,14 +2145,8 @@ func (e *ImgElement) StylePairs(pairs ...string) *ImgElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ImgElement) Style(s string) *ImgElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2292,7 +2154,7 @@ func (e *ImgElement) Style(s string) *ImgElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2301,7 +2163,7 @@ func (e *ImgElement) Style(s string) *ImgElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ImgElement) IfStyle(condition bool, s string) *ImgElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2309,15 +2171,7 @@ func (e *ImgElement) IfStyle(condition bool, s string) *ImgElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ImgElement) StyleAdd(k string, v string) *ImgElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2331,7 +2185,7 @@ func (e *ImgElement) StyleAddf(k string, format string, args ...any) *ImgElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *ImgElement) IfStyleAdd(condition bool, k string, v string) *ImgElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2340,7 +2194,7 @@ func (e *ImgElement) IfStyleAdd(condition bool, k string, v string) *ImgElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *ImgElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ImgElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2349,21 +2203,15 @@ func (e *ImgElement) IfStyleAddf(condition bool, k string, format string, args .
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ImgElement) StyleMap(m map[string]string) *ImgElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2372,14 +2220,8 @@ func (e *ImgElement) StyleMap(m map[string]string) *ImgElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ImgElement) StyleRemove(keys ...string) *ImgElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2397,10 +2239,8 @@ func (e *ImgElement) StyleRemove(keys ...string) *ImgElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ImgElement) Tabindex(i int) *ImgElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2419,7 +2259,7 @@ func (e *ImgElement) Tabindex(i int) *ImgElement {
 // their relative position in the document.
 func (e *ImgElement) IfTabindex(condition bool, i int) *ImgElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2439,10 +2279,8 @@ func (e *ImgElement) IfTabindex(condition bool, i int) *ImgElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ImgElement) TabindexRemove() *ImgElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2463,10 +2301,8 @@ func (e *ImgElement) TabindexRemove() *ImgElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ImgElement) Title(s string) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2508,7 +2344,7 @@ func (e *ImgElement) Titlef(format string, args ...any) *ImgElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ImgElement) IfTitle(condition bool, s string) *ImgElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2531,7 +2367,7 @@ func (e *ImgElement) IfTitle(condition bool, s string) *ImgElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ImgElement) IfTitlef(condition bool, format string, args ...any) *ImgElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2554,10 +2390,8 @@ func (e *ImgElement) IfTitlef(condition bool, format string, args ...any) *ImgEl
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ImgElement) TitleRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2566,10 +2400,8 @@ func (e *ImgElement) TitleRemove() *ImgElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ImgElement) Translate(c ImgTranslateChoice) *ImgElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ImgTranslateChoice])
 	return e
 }
 
@@ -2590,9 +2422,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ImgElement) TranslateRemove() *ImgElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <input> element is used to create interactive controls for web-based
@@ -24,18 +22,87 @@ type InputElement struct {
 // with the tag "input" during rendering.
 func Input() *InputElement {
 	e := NewElement("input")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &InputElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *InputElement) Clone() *InputElement {
+	return &InputElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *InputElement) CloneShallow() *InputElement {
+	return &InputElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *InputElement) Freeze() *InputElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *InputElement) writable() *InputElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *InputElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *InputElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocomplete":
+		return choiceValue[InputAutocompleteChoice]
+	case "formenctype":
+		return choiceValue[InputFormenctypeChoice]
+	case "formmethod":
+		return choiceValue[InputFormmethodChoice]
+	case "formtarget":
+		return choiceValue[InputFormtargetChoice]
+	case "type":
+		return choiceValue[InputTypeChoice]
+	case "autocapitalize":
+		return choiceValue[InputAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[InputContenteditableChoice]
+	case "dir":
+		return choiceValue[InputDirChoice]
+	case "draggable":
+		return choiceValue[InputDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[InputEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[InputHiddenChoice]
+	case "inputmode":
+		return choiceValue[InputInputmodeChoice]
+	case "popover":
+		return choiceValue[InputPopoverChoice]
+	case "spellcheck":
+		return choiceValue[InputSpellcheckChoice]
+	case "translate":
+		return choiceValue[InputTranslateChoice]
+	}
+	return nil
+}
+
 func (e *InputElement) Children(children ...ElementRenderer) *InputElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *InputElement) IfChildren(condition bool, children ...ElementRenderer) *InputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +110,30 @@ func (e *InputElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *InputElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *InputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *InputElement) BoolAttr(name string) *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *InputElement) BoolAttrRemove(name string) *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *InputElement) IfBoolAttr(condition bool, name string) *InputElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +144,40 @@ func (e *InputElement) BoolAttrf(format string, args ...any) *InputElement {
 
 func (e *InputElement) IfBoolAttrf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *InputElement) BoolAttrs(names ...string) *InputElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *InputElement) IfBoolAttrs(condition bool, names ...string) *InputElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *InputElement) Attr(name, value string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *InputElement) RawAttr(name, value string) *InputElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *InputElement) IfAttr(condition bool, name, value string) *InputElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +188,52 @@ func (e *InputElement) Attrf(name, format string, args ...any) *InputElement {
 
 func (e *InputElement) IfAttrf(condition bool, name, format string, args ...any) *InputElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *InputElement) Attrs(attrs ...string) *InputElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *InputElement) IfAttrs(condition bool, attrs ...string) *InputElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *InputElement) AttrsMap(attrs map[string]string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *InputElement) IfAttrsMap(condition bool, attrs map[string]string) *InputElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *InputElement) Text(text string) *InputElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *InputElement) Textf(format string, args ...any) *InputElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *InputElement) IfText(condition bool, text string) *InputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +241,28 @@ func (e *InputElement) IfText(condition bool, text string) *InputElement {
 
 func (e *InputElement) IfTextf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *InputElement) Raw(text string) *InputElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *InputElement) Escaped(text string) *InputElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *InputElement) IfEscaped(condition bool, text string) *InputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +274,7 @@ func (e *InputElement) Escapedf(format string, args ...any) *InputElement {
 
 func (e *InputElement) IfEscapedf(condition bool, format string, args ...any) *InputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +282,8 @@ func (e *InputElement) IfEscapedf(condition bool, format string, args ...any) *I
 
 // Hint for expected file type in file upload controls.
 func (e *InputElement) Accept(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accept", s)
+	e = e.writable()
+	e.setStringAttribute("accept", s)
 	return e
 }
 
@@ -226,7 +295,7 @@ func (e *InputElement) Acceptf(format string, args ...any) *InputElement {
 // Hint for expected file type in file upload controls.
 func (e *InputElement) IfAccept(condition bool, s string) *InputElement {
 	if condition {
-		e.Accept(s)
+		e = e.Accept(s)
 	}
 	return e
 }
@@ -234,7 +303,7 @@ func (e *InputElement) IfAccept(condition bool, s string) *InputElement {
 // Hint for expected file type in file upload controls.
 func (e *InputElement) IfAcceptf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Accept(fmt.Sprintf(format, args...))
+		e = e.Accept(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -242,19 +311,15 @@ func (e *InputElement) IfAcceptf(condition bool, format string, args ...any) *In
 // Hint for expected file type in file upload controls.
 // Remove the attribute Accept from the element.
 func (e *InputElement) AcceptRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accept")
+	e = e.writable()
+	e.removeAttribute("accept")
 	return e
 }
 
 // Alternative text in case an image can't be displayed.
 func (e *InputElement) Alt(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("alt", s)
+	e = e.writable()
+	e.setStringAttribute("alt", s)
 	return e
 }
 
@@ -266,7 +331,7 @@ func (e *InputElement) Altf(format string, args ...any) *InputElement {
 // Alternative text in case an image can't be displayed.
 func (e *InputElement) IfAlt(condition bool, s string) *InputElement {
 	if condition {
-		e.Alt(s)
+		e = e.Alt(s)
 	}
 	return e
 }
@@ -274,7 +339,7 @@ func (e *InputElement) IfAlt(condition bool, s string) *InputElement {
 // Alternative text in case an image can't be displayed.
 func (e *InputElement) IfAltf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Alt(fmt.Sprintf(format, args...))
+		e = e.Alt(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -282,19 +347,15 @@ func (e *InputElement) IfAltf(condition bool, format string, args ...any) *Input
 // Alternative text in case an image can't be displayed.
 // Remove the attribute Alt from the element.
 func (e *InputElement) AltRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("alt")
+	e = e.writable()
+	e.removeAttribute("alt")
 	return e
 }
 
 // Hint for form autofill feature.
 func (e *InputElement) Autocomplete(c InputAutocompleteChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocomplete", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocomplete", string(c), choiceValue[InputAutocompleteChoice])
 	return e
 }
 
@@ -310,26 +371,22 @@ const (
 // Hint for form autofill feature.
 // Remove the attribute Autocomplete from the element.
 func (e *InputElement) AutocompleteRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocomplete")
+	e = e.writable()
+	e.removeAttribute("autocomplete")
 	return e
 }
 
 // Automatically focus the form control when the page is loaded.
 func (e *InputElement) Autofocus() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
 // Automatically focus the form control when the page is loaded.
 func (e *InputElement) IfAutofocus(condition bool) *InputElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -337,36 +394,30 @@ func (e *InputElement) IfAutofocus(condition bool) *InputElement {
 // Remove the attribute Autofocus from the element.
 // Automatically focus the form control when the page is loaded.
 func (e *InputElement) AutofocusRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
 // Remove the attribute Autofocus from the element.
 // Automatically focus the form control when the page is loaded.
 func (e *InputElement) AutofocusIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
 // Whether the command or control is checked.
 func (e *InputElement) Checked() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("checked", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("checked")
 	return e
 }
 
 // Whether the command or control is checked.
 func (e *InputElement) IfChecked(condition bool) *InputElement {
 	if condition {
-		e.Checked()
+		e = e.Checked()
 	}
 	return e
 }
@@ -374,30 +425,24 @@ func (e *InputElement) IfChecked(condition bool) *InputElement {
 // Remove the attribute Checked from the element.
 // Whether the command or control is checked.
 func (e *InputElement) CheckedRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("checked")
+	e = e.writable()
+	e.removeAttribute("checked")
 	return e
 }
 
 // Remove the attribute Checked from the element.
 // Whether the command or control is checked.
 func (e *InputElement) CheckedIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("checked")
+	e = e.writable()
+	e.removeAttribute("checked")
 	return e
 }
 
 // Name of form field to use for sending the element's directionality in form
 // submission.
 func (e *InputElement) Dirname(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dirname", s)
+	e = e.writable()
+	e.setStringAttribute("dirname", s)
 	return e
 }
 
@@ -411,7 +456,7 @@ func (e *InputElement) Dirnamef(format string, args ...any) *InputElement {
 // submission.
 func (e *InputElement) IfDirname(condition bool, s string) *InputElement {
 	if condition {
-		e.Dirname(s)
+		e = e.Dirname(s)
 	}
 	return e
 }
@@ -420,7 +465,7 @@ func (e *InputElement) IfDirname(condition bool, s string) *InputElement {
 // submission.
 func (e *InputElement) IfDirnamef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Dirname(fmt.Sprintf(format, args...))
+		e = e.Dirname(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -429,26 +474,22 @@ func (e *InputElement) IfDirnamef(condition bool, format string, args ...any) *I
 // submission.
 // Remove the attribute Dirname from the element.
 func (e *InputElement) DirnameRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dirname")
+	e = e.writable()
+	e.removeAttribute("dirname")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *InputElement) Disabled() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *InputElement) IfDisabled(condition bool) *InputElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -456,29 +497,23 @@ func (e *InputElement) IfDisabled(condition bool) *InputElement {
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *InputElement) DisabledRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *InputElement) DisabledIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Associates the control with a form element.
 func (e *InputElement) Form(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -490,7 +525,7 @@ func (e *InputElement) Formf(format string, args ...any) *InputElement {
 // Associates the control with a form element.
 func (e *InputElement) IfForm(condition bool, s string) *InputElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -498,7 +533,7 @@ func (e *InputElement) IfForm(condition bool, s string) *InputElement {
 // Associates the control with a form element.
 func (e *InputElement) IfFormf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -506,19 +541,15 @@ func (e *InputElement) IfFormf(condition bool, format string, args ...any) *Inpu
 // Associates the control with a form element.
 // Remove the attribute Form from the element.
 func (e *InputElement) FormRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // URL to use for form submission.
 func (e *InputElement) Formaction(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formaction", s)
+	e = e.writable()
+	e.setStringAttribute("formaction", s)
 	return e
 }
 
@@ -530,7 +561,7 @@ func (e *InputElement) Formactionf(format string, args ...any) *InputElement {
 // URL to use for form submission.
 func (e *InputElement) IfFormaction(condition bool, s string) *InputElement {
 	if condition {
-		e.Formaction(s)
+		e = e.Formaction(s)
 	}
 	return e
 }
@@ -538,7 +569,7 @@ func (e *InputElement) IfFormaction(condition bool, s string) *InputElement {
 // URL to use for form submission.
 func (e *InputElement) IfFormactionf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Formaction(fmt.Sprintf(format, args...))
+		e = e.Formaction(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -546,19 +577,15 @@ func (e *InputElement) IfFormactionf(condition bool, format string, args ...any)
 // URL to use for form submission.
 // Remove the attribute Formaction from the element.
 func (e *InputElement) FormactionRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formaction")
+	e = e.writable()
+	e.removeAttribute("formaction")
 	return e
 }
 
 // Form data set encoding type to use for form submission.
 func (e *InputElement) Formenctype(c InputFormenctypeChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formenctype", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("formenctype", string(c), choiceValue[InputFormenctypeChoice])
 	return e
 }
 
@@ -577,19 +604,15 @@ const (
 // Form data set encoding type to use for form submission.
 // Remove the attribute Formenctype from the element.
 func (e *InputElement) FormenctypeRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formenctype")
+	e = e.writable()
+	e.removeAttribute("formenctype")
 	return e
 }
 
 // HTTP method to use for form submission.
 func (e *InputElement) Formmethod(c InputFormmethodChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formmethod", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("formmethod", string(c), choiceValue[InputFormmethodChoice])
 	return e
 }
 
@@ -610,26 +633,22 @@ const (
 // HTTP method to use for form submission.
 // Remove the attribute Formmethod from the element.
 func (e *InputElement) FormmethodRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formmethod")
+	e = e.writable()
+	e.removeAttribute("formmethod")
 	return e
 }
 
 // Bypass form control validation for form submission.
 func (e *InputElement) Formnovalidate() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("formnovalidate", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("formnovalidate")
 	return e
 }
 
 // Bypass form control validation for form submission.
 func (e *InputElement) IfFormnovalidate(condition bool) *InputElement {
 	if condition {
-		e.Formnovalidate()
+		e = e.Formnovalidate()
 	}
 	return e
 }
@@ -637,29 +656,23 @@ func (e *InputElement) IfFormnovalidate(condition bool) *InputElement {
 // Remove the attribute Formnovalidate from the element.
 // Bypass form control validation for form submission.
 func (e *InputElement) FormnovalidateRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("formnovalidate")
+	e = e.writable()
+	e.removeAttribute("formnovalidate")
 	return e
 }
 
 // Remove the attribute Formnovalidate from the element.
 // Bypass form control validation for form submission.
 func (e *InputElement) FormnovalidateIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("formnovalidate")
+	e = e.writable()
+	e.removeAttribute("formnovalidate")
 	return e
 }
 
 // Browsing context for form submission.
 func (e *InputElement) Formtarget(c InputFormtargetChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("formtarget", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("formtarget", string(c), choiceValue[InputFormtargetChoice])
 	return e
 }
 
@@ -683,26 +696,22 @@ const (
 // Browsing context for form submission.
 // Remove the attribute Formtarget from the element.
 func (e *InputElement) FormtargetRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("formtarget")
+	e = e.writable()
+	e.removeAttribute("formtarget")
 	return e
 }
 
 // Height of the image in pixels.
 func (e *InputElement) Height(i int) *InputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // Height of the image in pixels.
 func (e *InputElement) IfHeight(condition bool, i int) *InputElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -710,19 +719,15 @@ func (e *InputElement) IfHeight(condition bool, i int) *InputElement {
 // Height of the image in pixels.
 // Remove the attribute Height from the element.
 func (e *InputElement) HeightRemove() *InputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // Identifies a list of pre-defined options to suggest to the user.
 func (e *InputElement) List(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("list", s)
+	e = e.writable()
+	e.setStringAttribute("list", s)
 	return e
 }
 
@@ -734,7 +739,7 @@ func (e *InputElement) Listf(format string, args ...any) *InputElement {
 // Identifies a list of pre-defined options to suggest to the user.
 func (e *InputElement) IfList(condition bool, s string) *InputElement {
 	if condition {
-		e.List(s)
+		e = e.List(s)
 	}
 	return e
 }
@@ -742,7 +747,7 @@ func (e *InputElement) IfList(condition bool, s string) *InputElement {
 // Identifies a list of pre-defined options to suggest to the user.
 func (e *InputElement) IfListf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.List(fmt.Sprintf(format, args...))
+		e = e.List(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -750,19 +755,15 @@ func (e *InputElement) IfListf(condition bool, format string, args ...any) *Inpu
 // Identifies a list of pre-defined options to suggest to the user.
 // Remove the attribute List from the element.
 func (e *InputElement) ListRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("list")
+	e = e.writable()
+	e.removeAttribute("list")
 	return e
 }
 
 // maximum value of an <input> element with type="number" or type="range".
 func (e *InputElement) MaxStr(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("max", s)
+	e = e.writable()
+	e.setStringAttribute("max", s)
 	return e
 }
 
@@ -774,7 +775,7 @@ func (e *InputElement) MaxStrf(format string, args ...any) *InputElement {
 // maximum value of an <input> element with type="number" or type="range".
 func (e *InputElement) IfMaxStr(condition bool, s string) *InputElement {
 	if condition {
-		e.MaxStr(s)
+		e = e.MaxStr(s)
 	}
 	return e
 }
@@ -782,7 +783,7 @@ func (e *InputElement) IfMaxStr(condition bool, s string) *InputElement {
 // maximum value of an <input> element with type="number" or type="range".
 func (e *InputElement) IfMaxStrf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.MaxStr(fmt.Sprintf(format, args...))
+		e = e.MaxStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -790,10 +791,8 @@ func (e *InputElement) IfMaxStrf(condition bool, format string, args ...any) *In
 // maximum value of an <input> element with type="number" or type="range".
 // Remove the attribute MaxStr from the element.
 func (e *InputElement) MaxStrRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("max")
+	e = e.writable()
+	e.removeAttribute("max")
 	return e
 }
 
@@ -803,10 +802,8 @@ func (e *InputElement) MaxStrRemove() *InputElement {
 // maximum length. This value must also be greater than or equal to the value of
 // minlength.
 func (e *InputElement) Maxlength(i int) *InputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("maxlength", i)
+	e = e.writable()
+	e.setIntAttribute("maxlength", i)
 	return e
 }
 
@@ -817,7 +814,7 @@ func (e *InputElement) Maxlength(i int) *InputElement {
 // minlength.
 func (e *InputElement) IfMaxlength(condition bool, i int) *InputElement {
 	if condition {
-		e.Maxlength(i)
+		e = e.Maxlength(i)
 	}
 	return e
 }
@@ -829,19 +826,15 @@ func (e *InputElement) IfMaxlength(condition bool, i int) *InputElement {
 // minlength.
 // Remove the attribute Maxlength from the element.
 func (e *InputElement) MaxlengthRemove() *InputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("maxlength")
+	e = e.writable()
+	e.removeAttribute("maxlength")
 	return e
 }
 
 // the minimum value of an <input> element with type="number" or type="range".
 func (e *InputElement) MinStr(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("min", s)
+	e = e.writable()
+	e.setStringAttribute("min", s)
 	return e
 }
 
@@ -853,7 +846,7 @@ func (e *InputElement) MinStrf(format string, args ...any) *InputElement {
 // the minimum value of an <input> element with type="number" or type="range".
 func (e *InputElement) IfMinStr(condition bool, s string) *InputElement {
 	if condition {
-		e.MinStr(s)
+		e = e.MinStr(s)
 	}
 	return e
 }
@@ -861,7 +854,7 @@ func (e *InputElement) IfMinStr(condition bool, s string) *InputElement {
 // the minimum value of an <input> element with type="number" or type="range".
 func (e *InputElement) IfMinStrf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.MinStr(fmt.Sprintf(format, args...))
+		e = e.MinStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -869,10 +862,8 @@ func (e *InputElement) IfMinStrf(condition bool, format string, args ...any) *In
 // the minimum value of an <input> element with type="number" or type="range".
 // Remove the attribute MinStr from the element.
 func (e *InputElement) MinStrRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("min")
+	e = e.writable()
+	e.removeAttribute("min")
 	return e
 }
 
@@ -882,10 +873,8 @@ func (e *InputElement) MinStrRemove() *InputElement {
 // <input> or <textarea> has no minimum length. This value must also be less
 // than or equal to the value of maxlength.
 func (e *InputElement) Minlength(i int) *InputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("minlength", i)
+	e = e.writable()
+	e.setIntAttribute("minlength", i)
 	return e
 }
 
@@ -896,7 +885,7 @@ func (e *InputElement) Minlength(i int) *InputElement {
 // than or equal to the value of maxlength.
 func (e *InputElement) IfMinlength(condition bool, i int) *InputElement {
 	if condition {
-		e.Minlength(i)
+		e = e.Minlength(i)
 	}
 	return e
 }
@@ -908,26 +897,22 @@ func (e *InputElement) IfMinlength(condition bool, i int) *InputElement {
 // than or equal to the value of maxlength.
 // Remove the attribute Minlength from the element.
 func (e *InputElement) MinlengthRemove() *InputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("minlength")
+	e = e.writable()
+	e.removeAttribute("minlength")
 	return e
 }
 
 // Whether to allow multiple values.
 func (e *InputElement) Multiple() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("multiple", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("multiple")
 	return e
 }
 
 // Whether to allow multiple values.
 func (e *InputElement) IfMultiple(condition bool) *InputElement {
 	if condition {
-		e.Multiple()
+		e = e.Multiple()
 	}
 	return e
 }
@@ -935,29 +920,23 @@ func (e *InputElement) IfMultiple(condition bool) *InputElement {
 // Remove the attribute Multiple from the element.
 // Whether to allow multiple values.
 func (e *InputElement) MultipleRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("multiple")
+	e = e.writable()
+	e.removeAttribute("multiple")
 	return e
 }
 
 // Remove the attribute Multiple from the element.
 // Whether to allow multiple values.
 func (e *InputElement) MultipleIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("multiple")
+	e = e.writable()
+	e.removeAttribute("multiple")
 	return e
 }
 
 // Name of the element to use for form submission and in the form.elements API.
 func (e *InputElement) Name(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -969,7 +948,7 @@ func (e *InputElement) Namef(format string, args ...any) *InputElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *InputElement) IfName(condition bool, s string) *InputElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -977,7 +956,7 @@ func (e *InputElement) IfName(condition bool, s string) *InputElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *InputElement) IfNamef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -985,19 +964,15 @@ func (e *InputElement) IfNamef(condition bool, format string, args ...any) *Inpu
 // Name of the element to use for form submission and in the form.elements API.
 // Remove the attribute Name from the element.
 func (e *InputElement) NameRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
 // Pattern to be matched by the form control's value.
 func (e *InputElement) Pattern(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("pattern", s)
+	e = e.writable()
+	e.setStringAttribute("pattern", s)
 	return e
 }
 
@@ -1009,7 +984,7 @@ func (e *InputElement) Patternf(format string, args ...any) *InputElement {
 // Pattern to be matched by the form control's value.
 func (e *InputElement) IfPattern(condition bool, s string) *InputElement {
 	if condition {
-		e.Pattern(s)
+		e = e.Pattern(s)
 	}
 	return e
 }
@@ -1017,7 +992,7 @@ func (e *InputElement) IfPattern(condition bool, s string) *InputElement {
 // Pattern to be matched by the form control's value.
 func (e *InputElement) IfPatternf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Pattern(fmt.Sprintf(format, args...))
+		e = e.Pattern(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1025,19 +1000,15 @@ func (e *InputElement) IfPatternf(condition bool, format string, args ...any) *I
 // Pattern to be matched by the form control's value.
 // Remove the attribute Pattern from the element.
 func (e *InputElement) PatternRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("pattern")
+	e = e.writable()
+	e.removeAttribute("pattern")
 	return e
 }
 
 // User-visible label to be placed within the form control.
 func (e *InputElement) Placeholder(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("placeholder", s)
+	e = e.writable()
+	e.setStringAttribute("placeholder", s)
 	return e
 }
 
@@ -1049,7 +1020,7 @@ func (e *InputElement) Placeholderf(format string, args ...any) *InputElement {
 // User-visible label to be placed within the form control.
 func (e *InputElement) IfPlaceholder(condition bool, s string) *InputElement {
 	if condition {
-		e.Placeholder(s)
+		e = e.Placeholder(s)
 	}
 	return e
 }
@@ -1057,7 +1028,7 @@ func (e *InputElement) IfPlaceholder(condition bool, s string) *InputElement {
 // User-visible label to be placed within the form control.
 func (e *InputElement) IfPlaceholderf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Placeholder(fmt.Sprintf(format, args...))
+		e = e.Placeholder(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1065,26 +1036,22 @@ func (e *InputElement) IfPlaceholderf(condition bool, format string, args ...any
 // User-visible label to be placed within the form control.
 // Remove the attribute Placeholder from the element.
 func (e *InputElement) PlaceholderRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("placeholder")
+	e = e.writable()
+	e.removeAttribute("placeholder")
 	return e
 }
 
 // Whether to allow the value to be edited by the user.
 func (e *InputElement) Readonly() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("readonly", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("readonly")
 	return e
 }
 
 // Whether to allow the value to be edited by the user.
 func (e *InputElement) IfReadonly(condition bool) *InputElement {
 	if condition {
-		e.Readonly()
+		e = e.Readonly()
 	}
 	return e
 }
@@ -1092,36 +1059,30 @@ func (e *InputElement) IfReadonly(condition bool) *InputElement {
 // Remove the attribute Readonly from the element.
 // Whether to allow the value to be edited by the user.
 func (e *InputElement) ReadonlyRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("readonly")
+	e = e.writable()
+	e.removeAttribute("readonly")
 	return e
 }
 
 // Remove the attribute Readonly from the element.
 // Whether to allow the value to be edited by the user.
 func (e *InputElement) ReadonlyIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("readonly")
+	e = e.writable()
+	e.removeAttribute("readonly")
 	return e
 }
 
 // Whether the control is required for form submission.
 func (e *InputElement) Required() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("required", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("required")
 	return e
 }
 
 // Whether the control is required for form submission.
 func (e *InputElement) IfRequired(condition bool) *InputElement {
 	if condition {
-		e.Required()
+		e = e.Required()
 	}
 	return e
 }
@@ -1129,36 +1090,30 @@ func (e *InputElement) IfRequired(condition bool) *InputElement {
 // Remove the attribute Required from the element.
 // Whether the control is required for form submission.
 func (e *InputElement) RequiredRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("required")
+	e = e.writable()
+	e.removeAttribute("required")
 	return e
 }
 
 // Remove the attribute Required from the element.
 // Whether the control is required for form submission.
 func (e *InputElement) RequiredIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("required")
+	e = e.writable()
+	e.removeAttribute("required")
 	return e
 }
 
 // Size of the control.
 func (e *InputElement) Size(i int) *InputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("size", i)
+	e = e.writable()
+	e.setIntAttribute("size", i)
 	return e
 }
 
 // Size of the control.
 func (e *InputElement) IfSize(condition bool, i int) *InputElement {
 	if condition {
-		e.Size(i)
+		e = e.Size(i)
 	}
 	return e
 }
@@ -1166,19 +1121,15 @@ func (e *InputElement) IfSize(condition bool, i int) *InputElement {
 // Size of the control.
 // Remove the attribute Size from the element.
 func (e *InputElement) SizeRemove() *InputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("size")
+	e = e.writable()
+	e.removeAttribute("size")
 	return e
 }
 
 // Address of the resource.
 func (e *InputElement) Src(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -1190,7 +1141,7 @@ func (e *InputElement) Srcf(format string, args ...any) *InputElement {
 // Address of the resource.
 func (e *InputElement) IfSrc(condition bool, s string) *InputElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -1198,7 +1149,7 @@ func (e *InputElement) IfSrc(condition bool, s string) *InputElement {
 // Address of the resource.
 func (e *InputElement) IfSrcf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1206,19 +1157,15 @@ func (e *InputElement) IfSrcf(condition bool, format string, args ...any) *Input
 // Address of the resource.
 // Remove the attribute Src from the element.
 func (e *InputElement) SrcRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // Granularity to be matched by the form control's value.
 func (e *InputElement) Step(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("step", s)
+	e = e.writable()
+	e.setStringAttribute("step", s)
 	return e
 }
 
@@ -1230,7 +1177,7 @@ func (e *InputElement) Stepf(format string, args ...any) *InputElement {
 // Granularity to be matched by the form control's value.
 func (e *InputElement) IfStep(condition bool, s string) *InputElement {
 	if condition {
-		e.Step(s)
+		e = e.Step(s)
 	}
 	return e
 }
@@ -1238,7 +1185,7 @@ func (e *InputElement) IfStep(condition bool, s string) *InputElement {
 // Granularity to be matched by the form control's value.
 func (e *InputElement) IfStepf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Step(fmt.Sprintf(format, args...))
+		e = e.Step(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1246,19 +1193,15 @@ func (e *InputElement) IfStepf(condition bool, format string, args ...any) *Inpu
 // Granularity to be matched by the form control's value.
 // Remove the attribute Step from the element.
 func (e *InputElement) StepRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("step")
+	e = e.writable()
+	e.removeAttribute("step")
 	return e
 }
 
 // Type of form control.
 func (e *InputElement) Type(c InputTypeChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[InputTypeChoice])
 	return e
 }
 
@@ -1323,19 +1266,15 @@ const (
 // Type of form control.
 // Remove the attribute Type from the element.
 func (e *InputElement) TypeRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Value of the form control.
 func (e *InputElement) Value(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("value", s)
+	e = e.writable()
+	e.setStringAttribute("value", s)
 	return e
 }
 
@@ -1347,7 +1286,7 @@ func (e *InputElement) Valuef(format string, args ...any) *InputElement {
 // Value of the form control.
 func (e *InputElement) IfValue(condition bool, s string) *InputElement {
 	if condition {
-		e.Value(s)
+		e = e.Value(s)
 	}
 	return e
 }
@@ -1355,7 +1294,7 @@ func (e *InputElement) IfValue(condition bool, s string) *InputElement {
 // Value of the form control.
 func (e *InputElement) IfValuef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Value(fmt.Sprintf(format, args...))
+		e = e.Value(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1363,26 +1302,22 @@ func (e *InputElement) IfValuef(condition bool, format string, args ...any) *Inp
 // Value of the form control.
 // Remove the attribute Value from the element.
 func (e *InputElement) ValueRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("value")
+	e = e.writable()
+	e.removeAttribute("value")
 	return e
 }
 
 // Width of the image in pixels.
 func (e *InputElement) Width(i int) *InputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // Width of the image in pixels.
 func (e *InputElement) IfWidth(condition bool, i int) *InputElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -1390,10 +1325,8 @@ func (e *InputElement) IfWidth(condition bool, i int) *InputElement {
 // Width of the image in pixels.
 // Remove the attribute Width from the element.
 func (e *InputElement) WidthRemove() *InputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -1402,10 +1335,8 @@ func (e *InputElement) WidthRemove() *InputElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *InputElement) Accesskey(r rune) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -1415,7 +1346,7 @@ func (e *InputElement) Accesskey(r rune) *InputElement {
 // can be generated by the keyboard).
 func (e *InputElement) IfAccesskey(condition bool, r rune) *InputElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -1426,10 +1357,8 @@ func (e *InputElement) IfAccesskey(condition bool, r rune) *InputElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *InputElement) AccesskeyRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -1445,10 +1374,8 @@ func (e *InputElement) AccesskeyRemove() *InputElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *InputElement) Autocapitalize(c InputAutocapitalizeChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[InputAutocapitalizeChoice])
 	return e
 }
 
@@ -1482,10 +1409,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *InputElement) AutocapitalizeRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -1494,16 +1419,9 @@ func (e *InputElement) AutocapitalizeRemove() *InputElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *InputElement) Class(s string) *InputElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -1513,7 +1431,7 @@ func (e *InputElement) Class(s string) *InputElement {
 // document.getElementsByClassName.
 func (e *InputElement) IfClass(condition bool, s string) *InputElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -1524,14 +1442,8 @@ func (e *InputElement) IfClass(condition bool, s string) *InputElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *InputElement) ClassRemove(s ...string) *InputElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -1539,10 +1451,8 @@ func (e *InputElement) ClassRemove(s ...string) *InputElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *InputElement) Contenteditable(c InputContenteditableChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[InputContenteditableChoice])
 	return e
 }
 
@@ -1565,10 +1475,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *InputElement) ContenteditableRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -1586,10 +1494,8 @@ func (e *InputElement) ContenteditableRemove() *InputElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *InputElement) Dir(c InputDirChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[InputDirChoice])
 	return e
 }
 
@@ -1623,10 +1529,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *InputElement) DirRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -1634,10 +1538,8 @@ func (e *InputElement) DirRemove() *InputElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *InputElement) Draggable(c InputDraggableChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[InputDraggableChoice])
 	return e
 }
 
@@ -1663,20 +1565,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *InputElement) DraggableRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *InputElement) Enterkeyhint(c InputEnterkeyhintChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[InputEnterkeyhintChoice])
 	return e
 }
 
@@ -1706,10 +1604,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *InputElement) EnterkeyhintRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1731,16 +1627,9 @@ func (e *InputElement) EnterkeyhintRemove() *InputElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *InputElement) Exportparts(s string) *InputElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1763,7 +1652,7 @@ func (e *InputElement) Exportparts(s string) *InputElement {
 // the current structure.
 func (e *InputElement) IfExportparts(condition bool, s string) *InputElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1787,14 +1676,8 @@ func (e *InputElement) IfExportparts(condition bool, s string) *InputElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *InputElement) ExportpartsRemove(s ...string) *InputElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1811,10 +1694,8 @@ func (e *InputElement) ExportpartsRemove(s ...string) *InputElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *InputElement) Hidden(c InputHiddenChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[InputHiddenChoice])
 	return e
 }
 
@@ -1850,10 +1731,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *InputElement) HiddenRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1861,10 +1740,8 @@ func (e *InputElement) HiddenRemove() *InputElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *InputElement) ID(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1880,7 +1757,7 @@ func (e *InputElement) IDf(format string, args ...any) *InputElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *InputElement) IfID(condition bool, s string) *InputElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1890,7 +1767,7 @@ func (e *InputElement) IfID(condition bool, s string) *InputElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *InputElement) IfIDf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1900,10 +1777,8 @@ func (e *InputElement) IfIDf(condition bool, format string, args ...any) *InputE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *InputElement) IDRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1918,10 +1793,8 @@ func (e *InputElement) IDRemove() *InputElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *InputElement) Inert() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1937,7 +1810,7 @@ func (e *InputElement) Inert() *InputElement {
 // excluding them from the accessibility tree.
 func (e *InputElement) IfInert(condition bool) *InputElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1954,10 +1827,8 @@ func (e *InputElement) IfInert(condition bool) *InputElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *InputElement) InertRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1973,10 +1844,8 @@ func (e *InputElement) InertRemove() *InputElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *InputElement) InertIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1990,10 +1859,8 @@ func (e *InputElement) InertIfRemove() *InputElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *InputElement) Inputmode(c InputInputmodeChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[InputInputmodeChoice])
 	return e
 }
 
@@ -2044,10 +1911,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *InputElement) InputmodeRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -2057,10 +1922,8 @@ func (e *InputElement) InputmodeRemove() *InputElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *InputElement) Is(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -2080,7 +1943,7 @@ func (e *InputElement) Isf(format string, args ...any) *InputElement {
 // and extends the element type it is being applied to.
 func (e *InputElement) IfIs(condition bool, s string) *InputElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -2092,7 +1955,7 @@ func (e *InputElement) IfIs(condition bool, s string) *InputElement {
 // and extends the element type it is being applied to.
 func (e *InputElement) IfIsf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2104,10 +1967,8 @@ func (e *InputElement) IfIsf(condition bool, format string, args ...any) *InputE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *InputElement) IsRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -2123,10 +1984,8 @@ func (e *InputElement) IsRemove() *InputElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *InputElement) Itemid(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -2158,7 +2017,7 @@ func (e *InputElement) Itemidf(format string, args ...any) *InputElement {
 // how items with the same identifier are handled.
 func (e *InputElement) IfItemid(condition bool, s string) *InputElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -2176,7 +2035,7 @@ func (e *InputElement) IfItemid(condition bool, s string) *InputElement {
 // how items with the same identifier are handled.
 func (e *InputElement) IfItemidf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2194,10 +2053,8 @@ func (e *InputElement) IfItemidf(condition bool, format string, args ...any) *In
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *InputElement) ItemidRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -2209,10 +2066,8 @@ func (e *InputElement) ItemidRemove() *InputElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *InputElement) Itemprop(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -2236,7 +2091,7 @@ func (e *InputElement) Itempropf(format string, args ...any) *InputElement {
 // <track>, and <video>.
 func (e *InputElement) IfItemprop(condition bool, s string) *InputElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -2250,7 +2105,7 @@ func (e *InputElement) IfItemprop(condition bool, s string) *InputElement {
 // <track>, and <video>.
 func (e *InputElement) IfItempropf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2264,10 +2119,8 @@ func (e *InputElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *InputElement) ItempropRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -2277,10 +2130,8 @@ func (e *InputElement) ItempropRemove() *InputElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *InputElement) Itemref(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -2300,7 +2151,7 @@ func (e *InputElement) Itemreff(format string, args ...any) *InputElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *InputElement) IfItemref(condition bool, s string) *InputElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -2312,7 +2163,7 @@ func (e *InputElement) IfItemref(condition bool, s string) *InputElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *InputElement) IfItemreff(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2324,10 +2175,8 @@ func (e *InputElement) IfItemreff(condition bool, format string, args ...any) *I
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *InputElement) ItemrefRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -2339,10 +2188,8 @@ func (e *InputElement) ItemrefRemove() *InputElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *InputElement) Itemscope() *InputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -2355,7 +2202,7 @@ func (e *InputElement) Itemscope() *InputElement {
 // <object>, <source>, <track>, and <video>.
 func (e *InputElement) IfItemscope(condition bool) *InputElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -2369,10 +2216,8 @@ func (e *InputElement) IfItemscope(condition bool) *InputElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *InputElement) ItemscopeRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -2385,10 +2230,8 @@ func (e *InputElement) ItemscopeRemove() *InputElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *InputElement) ItemscopeIfRemove() *InputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -2400,10 +2243,8 @@ func (e *InputElement) ItemscopeIfRemove() *InputElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *InputElement) Itemtype(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -2427,7 +2268,7 @@ func (e *InputElement) Itemtypef(format string, args ...any) *InputElement {
 // <video>.
 func (e *InputElement) IfItemtype(condition bool, s string) *InputElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -2441,7 +2282,7 @@ func (e *InputElement) IfItemtype(condition bool, s string) *InputElement {
 // <video>.
 func (e *InputElement) IfItemtypef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2455,10 +2296,8 @@ func (e *InputElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *InputElement) ItemtypeRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -2468,10 +2307,8 @@ func (e *InputElement) ItemtypeRemove() *InputElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *InputElement) Lang(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -2491,7 +2328,7 @@ func (e *InputElement) Langf(format string, args ...any) *InputElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *InputElement) IfLang(condition bool, s string) *InputElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -2503,7 +2340,7 @@ func (e *InputElement) IfLang(condition bool, s string) *InputElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *InputElement) IfLangf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2515,10 +2352,8 @@ func (e *InputElement) IfLangf(condition bool, format string, args ...any) *Inpu
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *InputElement) LangRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -2528,10 +2363,8 @@ func (e *InputElement) LangRemove() *InputElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *InputElement) Nonce(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -2551,7 +2384,7 @@ func (e *InputElement) Noncef(format string, args ...any) *InputElement {
 // execute.
 func (e *InputElement) IfNonce(condition bool, s string) *InputElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -2563,7 +2396,7 @@ func (e *InputElement) IfNonce(condition bool, s string) *InputElement {
 // execute.
 func (e *InputElement) IfNoncef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2575,10 +2408,8 @@ func (e *InputElement) IfNoncef(condition bool, format string, args ...any) *Inp
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *InputElement) NonceRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -2586,16 +2417,9 @@ func (e *InputElement) NonceRemove() *InputElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *InputElement) Part(s string) *InputElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -2604,7 +2428,7 @@ func (e *InputElement) Part(s string) *InputElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *InputElement) IfPart(condition bool, s string) *InputElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -2614,14 +2438,8 @@ func (e *InputElement) IfPart(condition bool, s string) *InputElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *InputElement) PartRemove(s ...string) *InputElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -2632,10 +2450,8 @@ func (e *InputElement) PartRemove(s ...string) *InputElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *InputElement) Popover(c InputPopoverChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[InputPopoverChoice])
 	return e
 }
 
@@ -2663,10 +2479,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *InputElement) PopoverRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -2675,10 +2489,8 @@ func (e *InputElement) PopoverRemove() *InputElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *InputElement) Role(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -2696,7 +2508,7 @@ func (e *InputElement) Rolef(format string, args ...any) *InputElement {
 // role of an element.
 func (e *InputElement) IfRole(condition bool, s string) *InputElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -2707,7 +2519,7 @@ func (e *InputElement) IfRole(condition bool, s string) *InputElement {
 // role of an element.
 func (e *InputElement) IfRolef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2718,10 +2530,8 @@ func (e *InputElement) IfRolef(condition bool, format string, args ...any) *Inpu
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *InputElement) RoleRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2730,10 +2540,8 @@ func (e *InputElement) RoleRemove() *InputElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *InputElement) Slot(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2751,7 +2559,7 @@ func (e *InputElement) Slotf(format string, args ...any) *InputElement {
 // value.
 func (e *InputElement) IfSlot(condition bool, s string) *InputElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2762,7 +2570,7 @@ func (e *InputElement) IfSlot(condition bool, s string) *InputElement {
 // value.
 func (e *InputElement) IfSlotf(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2773,10 +2581,8 @@ func (e *InputElement) IfSlotf(condition bool, format string, args ...any) *Inpu
 // value.
 // Remove the attribute Slot from the element.
 func (e *InputElement) SlotRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2792,10 +2598,8 @@ func (e *InputElement) SlotRemove() *InputElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *InputElement) Spellcheck(c InputSpellcheckChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[InputSpellcheckChoice])
 	return e
 }
 
@@ -2823,10 +2627,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *InputElement) SpellcheckRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2836,14 +2638,8 @@ func (e *InputElement) StylePairs(pairs ...string) *InputElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2858,14 +2654,8 @@ func (e *InputElement) StylePairs(pairs ...string) *InputElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *InputElement) Style(s string) *InputElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2873,7 +2663,7 @@ func (e *InputElement) Style(s string) *InputElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2882,7 +2672,7 @@ func (e *InputElement) Style(s string) *InputElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *InputElement) IfStyle(condition bool, s string) *InputElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2890,15 +2680,7 @@ func (e *InputElement) IfStyle(condition bool, s string) *InputElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *InputElement) StyleAdd(k string, v string) *InputElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2912,7 +2694,7 @@ func (e *InputElement) StyleAddf(k string, format string, args ...any) *InputEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *InputElement) IfStyleAdd(condition bool, k string, v string) *InputElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2921,7 +2703,7 @@ func (e *InputElement) IfStyleAdd(condition bool, k string, v string) *InputElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *InputElement) IfStyleAddf(condition bool, k string, format string, args ...any) *InputElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2930,21 +2712,15 @@ func (e *InputElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *InputElement) StyleMap(m map[string]string) *InputElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2953,14 +2729,8 @@ func (e *InputElement) StyleMap(m map[string]string) *InputElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *InputElement) StyleRemove(keys ...string) *InputElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2978,10 +2748,8 @@ func (e *InputElement) StyleRemove(keys ...string) *InputElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *InputElement) Tabindex(i int) *InputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -3000,7 +2768,7 @@ func (e *InputElement) Tabindex(i int) *InputElement {
 // their relative position in the document.
 func (e *InputElement) IfTabindex(condition bool, i int) *InputElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -3020,10 +2788,8 @@ func (e *InputElement) IfTabindex(condition bool, i int) *InputElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *InputElement) TabindexRemove() *InputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -3044,10 +2810,8 @@ func (e *InputElement) TabindexRemove() *InputElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *InputElement) Title(s string) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -3089,7 +2853,7 @@ func (e *InputElement) Titlef(format string, args ...any) *InputElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *InputElement) IfTitle(condition bool, s string) *InputElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -3112,7 +2876,7 @@ func (e *InputElement) IfTitle(condition bool, s string) *InputElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *InputElement) IfTitlef(condition bool, format string, args ...any) *InputElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -3135,10 +2899,8 @@ func (e *InputElement) IfTitlef(condition bool, format string, args ...any) *Inp
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *InputElement) TitleRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -3147,10 +2909,8 @@ func (e *InputElement) TitleRemove() *InputElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *InputElement) Translate(c InputTranslateChoice) *InputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[InputTranslateChoice])
 	return e
 }
 
@@ -3171,9 +2931,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *InputElement) TranslateRemove() *InputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <ins> element represents a range of text that has been added to a
@@ -22,18 +20,77 @@ type InsElement struct {
 // with the tag "ins" during rendering.
 func Ins(children ...ElementRenderer) *InsElement {
 	e := NewElement("ins", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &InsElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *InsElement) Clone() *InsElement {
+	return &InsElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *InsElement) CloneShallow() *InsElement {
+	return &InsElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *InsElement) Freeze() *InsElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *InsElement) writable() *InsElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *InsElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *InsElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[InsAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[InsContenteditableChoice]
+	case "dir":
+		return choiceValue[InsDirChoice]
+	case "draggable":
+		return choiceValue[InsDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[InsEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[InsHiddenChoice]
+	case "inputmode":
+		return choiceValue[InsInputmodeChoice]
+	case "popover":
+		return choiceValue[InsPopoverChoice]
+	case "spellcheck":
+		return choiceValue[InsSpellcheckChoice]
+	case "translate":
+		return choiceValue[InsTranslateChoice]
+	}
+	return nil
+}
+
 func (e *InsElement) Children(children ...ElementRenderer) *InsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *InsElement) IfChildren(condition bool, children ...ElementRenderer) *InsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *InsElement) IfChildren(condition bool, children ...ElementRenderer) *In
 
 func (e *InsElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *InsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *InsElement) BoolAttr(name string) *InsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *InsElement) BoolAttrRemove(name string) *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *InsElement) IfBoolAttr(condition bool, name string) *InsElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *InsElement) BoolAttrf(format string, args ...any) *InsElement {
 
 func (e *InsElement) IfBoolAttrf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *InsElement) BoolAttrs(names ...string) *InsElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *InsElement) IfBoolAttrs(condition bool, names ...string) *InsElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *InsElement) Attr(name, value string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *InsElement) RawAttr(name, value string) *InsElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *InsElement) IfAttr(condition bool, name, value string) *InsElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *InsElement) Attrf(name, format string, args ...any) *InsElement {
 
 func (e *InsElement) IfAttrf(condition bool, name, format string, args ...any) *InsElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *InsElement) Attrs(attrs ...string) *InsElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *InsElement) IfAttrs(condition bool, attrs ...string) *InsElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *InsElement) AttrsMap(attrs map[string]string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *InsElement) IfAttrsMap(condition bool, attrs map[string]string) *InsElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *InsElement) Text(text string) *InsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *InsElement) Textf(format string, args ...any) *InsElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *InsElement) IfText(condition bool, text string) *InsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *InsElement) IfText(condition bool, text string) *InsElement {
 
 func (e *InsElement) IfTextf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *InsElement) Raw(text string) *InsElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *InsElement) Escaped(text string) *InsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *InsElement) IfEscaped(condition bool, text string) *InsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *InsElement) Escapedf(format string, args ...any) *InsElement {
 
 func (e *InsElement) IfEscapedf(condition bool, format string, args ...any) *InsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +270,8 @@ func (e *InsElement) IfEscapedf(condition bool, format string, args ...any) *Ins
 
 // Contains a URI which points to the source of the quote or change.
 func (e *InsElement) Cite(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("cite", s)
+	e = e.writable()
+	e.setStringAttribute("cite", s)
 	return e
 }
 
@@ -224,7 +283,7 @@ func (e *InsElement) Citef(format string, args ...any) *InsElement {
 // Contains a URI which points to the source of the quote or change.
 func (e *InsElement) IfCite(condition bool, s string) *InsElement {
 	if condition {
-		e.Cite(s)
+		e = e.Cite(s)
 	}
 	return e
 }
@@ -232,7 +291,7 @@ func (e *InsElement) IfCite(condition bool, s string) *InsElement {
 // Contains a URI which points to the source of the quote or change.
 func (e *InsElement) IfCitef(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Cite(fmt.Sprintf(format, args...))
+		e = e.Cite(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +299,15 @@ func (e *InsElement) IfCitef(condition bool, format string, args ...any) *InsEle
 // Contains a URI which points to the source of the quote or change.
 // Remove the attribute Cite from the element.
 func (e *InsElement) CiteRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("cite")
+	e = e.writable()
+	e.removeAttribute("cite")
 	return e
 }
 
 // Indicates the date and time associated with the element.
 func (e *InsElement) Datetime(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("datetime", s)
+	e = e.writable()
+	e.setStringAttribute("datetime", s)
 	return e
 }
 
@@ -264,7 +319,7 @@ func (e *InsElement) Datetimef(format string, args ...any) *InsElement {
 // Indicates the date and time associated with the element.
 func (e *InsElement) IfDatetime(condition bool, s string) *InsElement {
 	if condition {
-		e.Datetime(s)
+		e = e.Datetime(s)
 	}
 	return e
 }
@@ -272,7 +327,7 @@ func (e *InsElement) IfDatetime(condition bool, s string) *InsElement {
 // Indicates the date and time associated with the element.
 func (e *InsElement) IfDatetimef(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Datetime(fmt.Sprintf(format, args...))
+		e = e.Datetime(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,10 +335,8 @@ func (e *InsElement) IfDatetimef(condition bool, format string, args ...any) *In
 // Indicates the date and time associated with the element.
 // Remove the attribute Datetime from the element.
 func (e *InsElement) DatetimeRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("datetime")
+	e = e.writable()
+	e.removeAttribute("datetime")
 	return e
 }
 
@@ -292,10 +345,8 @@ func (e *InsElement) DatetimeRemove() *InsElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *InsElement) Accesskey(r rune) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -305,7 +356,7 @@ func (e *InsElement) Accesskey(r rune) *InsElement {
 // can be generated by the keyboard).
 func (e *InsElement) IfAccesskey(condition bool, r rune) *InsElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -316,10 +367,8 @@ func (e *InsElement) IfAccesskey(condition bool, r rune) *InsElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *InsElement) AccesskeyRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -335,10 +384,8 @@ func (e *InsElement) AccesskeyRemove() *InsElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *InsElement) Autocapitalize(c InsAutocapitalizeChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[InsAutocapitalizeChoice])
 	return e
 }
 
@@ -372,10 +419,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *InsElement) AutocapitalizeRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -394,10 +439,8 @@ func (e *InsElement) AutocapitalizeRemove() *InsElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *InsElement) Autofocus() *InsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -417,7 +460,7 @@ func (e *InsElement) Autofocus() *InsElement {
 // created by the preceding content.
 func (e *InsElement) IfAutofocus(condition bool) *InsElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -438,10 +481,8 @@ func (e *InsElement) IfAutofocus(condition bool) *InsElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *InsElement) AutofocusRemove() *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -461,10 +502,8 @@ func (e *InsElement) AutofocusRemove() *InsElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *InsElement) AutofocusIfRemove() *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -473,16 +512,9 @@ func (e *InsElement) AutofocusIfRemove() *InsElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *InsElement) Class(s string) *InsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -492,7 +524,7 @@ func (e *InsElement) Class(s string) *InsElement {
 // document.getElementsByClassName.
 func (e *InsElement) IfClass(condition bool, s string) *InsElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -503,14 +535,8 @@ func (e *InsElement) IfClass(condition bool, s string) *InsElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *InsElement) ClassRemove(s ...string) *InsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -518,10 +544,8 @@ func (e *InsElement) ClassRemove(s ...string) *InsElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *InsElement) Contenteditable(c InsContenteditableChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[InsContenteditableChoice])
 	return e
 }
 
@@ -544,10 +568,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *InsElement) ContenteditableRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -565,10 +587,8 @@ func (e *InsElement) ContenteditableRemove() *InsElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *InsElement) Dir(c InsDirChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[InsDirChoice])
 	return e
 }
 
@@ -602,10 +622,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *InsElement) DirRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -613,10 +631,8 @@ func (e *InsElement) DirRemove() *InsElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *InsElement) Draggable(c InsDraggableChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[InsDraggableChoice])
 	return e
 }
 
@@ -642,20 +658,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *InsElement) DraggableRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *InsElement) Enterkeyhint(c InsEnterkeyhintChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[InsEnterkeyhintChoice])
 	return e
 }
 
@@ -685,10 +697,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *InsElement) EnterkeyhintRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -710,16 +720,9 @@ func (e *InsElement) EnterkeyhintRemove() *InsElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *InsElement) Exportparts(s string) *InsElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -742,7 +745,7 @@ func (e *InsElement) Exportparts(s string) *InsElement {
 // the current structure.
 func (e *InsElement) IfExportparts(condition bool, s string) *InsElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -766,14 +769,8 @@ func (e *InsElement) IfExportparts(condition bool, s string) *InsElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *InsElement) ExportpartsRemove(s ...string) *InsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -790,10 +787,8 @@ func (e *InsElement) ExportpartsRemove(s ...string) *InsElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *InsElement) Hidden(c InsHiddenChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[InsHiddenChoice])
 	return e
 }
 
@@ -829,10 +824,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *InsElement) HiddenRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -840,10 +833,8 @@ func (e *InsElement) HiddenRemove() *InsElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *InsElement) ID(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -859,7 +850,7 @@ func (e *InsElement) IDf(format string, args ...any) *InsElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *InsElement) IfID(condition bool, s string) *InsElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -869,7 +860,7 @@ func (e *InsElement) IfID(condition bool, s string) *InsElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *InsElement) IfIDf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -879,10 +870,8 @@ func (e *InsElement) IfIDf(condition bool, format string, args ...any) *InsEleme
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *InsElement) IDRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -897,10 +886,8 @@ func (e *InsElement) IDRemove() *InsElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *InsElement) Inert() *InsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -916,7 +903,7 @@ func (e *InsElement) Inert() *InsElement {
 // excluding them from the accessibility tree.
 func (e *InsElement) IfInert(condition bool) *InsElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -933,10 +920,8 @@ func (e *InsElement) IfInert(condition bool) *InsElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *InsElement) InertRemove() *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -952,10 +937,8 @@ func (e *InsElement) InertRemove() *InsElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *InsElement) InertIfRemove() *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -969,10 +952,8 @@ func (e *InsElement) InertIfRemove() *InsElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *InsElement) Inputmode(c InsInputmodeChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[InsInputmodeChoice])
 	return e
 }
 
@@ -1023,10 +1004,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *InsElement) InputmodeRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1036,10 +1015,8 @@ func (e *InsElement) InputmodeRemove() *InsElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *InsElement) Is(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1059,7 +1036,7 @@ func (e *InsElement) Isf(format string, args ...any) *InsElement {
 // and extends the element type it is being applied to.
 func (e *InsElement) IfIs(condition bool, s string) *InsElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1071,7 +1048,7 @@ func (e *InsElement) IfIs(condition bool, s string) *InsElement {
 // and extends the element type it is being applied to.
 func (e *InsElement) IfIsf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1083,10 +1060,8 @@ func (e *InsElement) IfIsf(condition bool, format string, args ...any) *InsEleme
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *InsElement) IsRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1102,10 +1077,8 @@ func (e *InsElement) IsRemove() *InsElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *InsElement) Itemid(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1137,7 +1110,7 @@ func (e *InsElement) Itemidf(format string, args ...any) *InsElement {
 // how items with the same identifier are handled.
 func (e *InsElement) IfItemid(condition bool, s string) *InsElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1155,7 +1128,7 @@ func (e *InsElement) IfItemid(condition bool, s string) *InsElement {
 // how items with the same identifier are handled.
 func (e *InsElement) IfItemidf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1173,10 +1146,8 @@ func (e *InsElement) IfItemidf(condition bool, format string, args ...any) *InsE
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *InsElement) ItemidRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1188,10 +1159,8 @@ func (e *InsElement) ItemidRemove() *InsElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *InsElement) Itemprop(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1215,7 +1184,7 @@ func (e *InsElement) Itempropf(format string, args ...any) *InsElement {
 // <track>, and <video>.
 func (e *InsElement) IfItemprop(condition bool, s string) *InsElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1229,7 +1198,7 @@ func (e *InsElement) IfItemprop(condition bool, s string) *InsElement {
 // <track>, and <video>.
 func (e *InsElement) IfItempropf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1243,10 +1212,8 @@ func (e *InsElement) IfItempropf(condition bool, format string, args ...any) *In
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *InsElement) ItempropRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1256,10 +1223,8 @@ func (e *InsElement) ItempropRemove() *InsElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *InsElement) Itemref(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1279,7 +1244,7 @@ func (e *InsElement) Itemreff(format string, args ...any) *InsElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *InsElement) IfItemref(condition bool, s string) *InsElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1291,7 +1256,7 @@ func (e *InsElement) IfItemref(condition bool, s string) *InsElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *InsElement) IfItemreff(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1303,10 +1268,8 @@ func (e *InsElement) IfItemreff(condition bool, format string, args ...any) *Ins
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *InsElement) ItemrefRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1318,10 +1281,8 @@ func (e *InsElement) ItemrefRemove() *InsElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *InsElement) Itemscope() *InsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1334,7 +1295,7 @@ func (e *InsElement) Itemscope() *InsElement {
 // <object>, <source>, <track>, and <video>.
 func (e *InsElement) IfItemscope(condition bool) *InsElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1348,10 +1309,8 @@ func (e *InsElement) IfItemscope(condition bool) *InsElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *InsElement) ItemscopeRemove() *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1364,10 +1323,8 @@ func (e *InsElement) ItemscopeRemove() *InsElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *InsElement) ItemscopeIfRemove() *InsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1379,10 +1336,8 @@ func (e *InsElement) ItemscopeIfRemove() *InsElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *InsElement) Itemtype(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1406,7 +1361,7 @@ func (e *InsElement) Itemtypef(format string, args ...any) *InsElement {
 // <video>.
 func (e *InsElement) IfItemtype(condition bool, s string) *InsElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1420,7 +1375,7 @@ func (e *InsElement) IfItemtype(condition bool, s string) *InsElement {
 // <video>.
 func (e *InsElement) IfItemtypef(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1434,10 +1389,8 @@ func (e *InsElement) IfItemtypef(condition bool, format string, args ...any) *In
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *InsElement) ItemtypeRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1447,10 +1400,8 @@ func (e *InsElement) ItemtypeRemove() *InsElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *InsElement) Lang(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1470,7 +1421,7 @@ func (e *InsElement) Langf(format string, args ...any) *InsElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *InsElement) IfLang(condition bool, s string) *InsElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1482,7 +1433,7 @@ func (e *InsElement) IfLang(condition bool, s string) *InsElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *InsElement) IfLangf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1494,10 +1445,8 @@ func (e *InsElement) IfLangf(condition bool, format string, args ...any) *InsEle
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *InsElement) LangRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1507,10 +1456,8 @@ func (e *InsElement) LangRemove() *InsElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *InsElement) Nonce(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1530,7 +1477,7 @@ func (e *InsElement) Noncef(format string, args ...any) *InsElement {
 // execute.
 func (e *InsElement) IfNonce(condition bool, s string) *InsElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1542,7 +1489,7 @@ func (e *InsElement) IfNonce(condition bool, s string) *InsElement {
 // execute.
 func (e *InsElement) IfNoncef(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1554,10 +1501,8 @@ func (e *InsElement) IfNoncef(condition bool, format string, args ...any) *InsEl
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *InsElement) NonceRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1565,16 +1510,9 @@ func (e *InsElement) NonceRemove() *InsElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *InsElement) Part(s string) *InsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1583,7 +1521,7 @@ func (e *InsElement) Part(s string) *InsElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *InsElement) IfPart(condition bool, s string) *InsElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1593,14 +1531,8 @@ func (e *InsElement) IfPart(condition bool, s string) *InsElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *InsElement) PartRemove(s ...string) *InsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1611,10 +1543,8 @@ func (e *InsElement) PartRemove(s ...string) *InsElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *InsElement) Popover(c InsPopoverChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[InsPopoverChoice])
 	return e
 }
 
@@ -1642,10 +1572,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *InsElement) PopoverRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1654,10 +1582,8 @@ func (e *InsElement) PopoverRemove() *InsElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *InsElement) Role(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1675,7 +1601,7 @@ func (e *InsElement) Rolef(format string, args ...any) *InsElement {
 // role of an element.
 func (e *InsElement) IfRole(condition bool, s string) *InsElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1686,7 +1612,7 @@ func (e *InsElement) IfRole(condition bool, s string) *InsElement {
 // role of an element.
 func (e *InsElement) IfRolef(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1697,10 +1623,8 @@ func (e *InsElement) IfRolef(condition bool, format string, args ...any) *InsEle
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *InsElement) RoleRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1709,10 +1633,8 @@ func (e *InsElement) RoleRemove() *InsElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *InsElement) Slot(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1730,7 +1652,7 @@ func (e *InsElement) Slotf(format string, args ...any) *InsElement {
 // value.
 func (e *InsElement) IfSlot(condition bool, s string) *InsElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1741,7 +1663,7 @@ func (e *InsElement) IfSlot(condition bool, s string) *InsElement {
 // value.
 func (e *InsElement) IfSlotf(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1752,10 +1674,8 @@ func (e *InsElement) IfSlotf(condition bool, format string, args ...any) *InsEle
 // value.
 // Remove the attribute Slot from the element.
 func (e *InsElement) SlotRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1771,10 +1691,8 @@ func (e *InsElement) SlotRemove() *InsElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *InsElement) Spellcheck(c InsSpellcheckChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[InsSpellcheckChoice])
 	return e
 }
 
@@ -1802,10 +1720,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *InsElement) SpellcheckRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1815,14 +1731,8 @@ func (e *InsElement) StylePairs(pairs ...string) *InsElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1837,14 +1747,8 @@ func (e *InsElement) StylePairs(pairs ...string) *InsElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *InsElement) Style(s string) *InsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1852,7 +1756,7 @@ func (e *InsElement) Style(s string) *InsElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1861,7 +1765,7 @@ func (e *InsElement) Style(s string) *InsElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *InsElement) IfStyle(condition bool, s string) *InsElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1869,15 +1773,7 @@ func (e *InsElement) IfStyle(condition bool, s string) *InsElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *InsElement) StyleAdd(k string, v string) *InsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1891,7 +1787,7 @@ func (e *InsElement) StyleAddf(k string, format string, args ...any) *InsElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *InsElement) IfStyleAdd(condition bool, k string, v string) *InsElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1900,7 +1796,7 @@ func (e *InsElement) IfStyleAdd(condition bool, k string, v string) *InsElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *InsElement) IfStyleAddf(condition bool, k string, format string, args ...any) *InsElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1909,21 +1805,15 @@ func (e *InsElement) IfStyleAddf(condition bool, k string, format string, args .
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *InsElement) StyleMap(m map[string]string) *InsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1932,14 +1822,8 @@ func (e *InsElement) StyleMap(m map[string]string) *InsElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *InsElement) StyleRemove(keys ...string) *InsElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1957,10 +1841,8 @@ func (e *InsElement) StyleRemove(keys ...string) *InsElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *InsElement) Tabindex(i int) *InsElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1979,7 +1861,7 @@ func (e *InsElement) Tabindex(i int) *InsElement {
 // their relative position in the document.
 func (e *InsElement) IfTabindex(condition bool, i int) *InsElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1999,10 +1881,8 @@ func (e *InsElement) IfTabindex(condition bool, i int) *InsElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *InsElement) TabindexRemove() *InsElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2023,10 +1903,8 @@ func (e *InsElement) TabindexRemove() *InsElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *InsElement) Title(s string) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2068,7 +1946,7 @@ func (e *InsElement) Titlef(format string, args ...any) *InsElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *InsElement) IfTitle(condition bool, s string) *InsElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2091,7 +1969,7 @@ func (e *InsElement) IfTitle(condition bool, s string) *InsElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *InsElement) IfTitlef(condition bool, format string, args ...any) *InsElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2114,10 +1992,8 @@ func (e *InsElement) IfTitlef(condition bool, format string, args ...any) *InsEl
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *InsElement) TitleRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2126,10 +2002,8 @@ func (e *InsElement) TitleRemove() *InsElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *InsElement) Translate(c InsTranslateChoice) *InsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[InsTranslateChoice])
 	return e
 }
 
@@ -2150,9 +2024,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *InsElement) TranslateRemove() *InsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <label> element represents a caption for an item in a user
@@ -22,18 +20,77 @@ type LabelElement struct {
 // with the tag "label" during rendering.
 func Label(children ...ElementRenderer) *LabelElement {
 	e := NewElement("label", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &LabelElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *LabelElement) Clone() *LabelElement {
+	return &LabelElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *LabelElement) CloneShallow() *LabelElement {
+	return &LabelElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *LabelElement) Freeze() *LabelElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *LabelElement) writable() *LabelElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *LabelElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *LabelElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[LabelAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[LabelContenteditableChoice]
+	case "dir":
+		return choiceValue[LabelDirChoice]
+	case "draggable":
+		return choiceValue[LabelDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[LabelEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[LabelHiddenChoice]
+	case "inputmode":
+		return choiceValue[LabelInputmodeChoice]
+	case "popover":
+		return choiceValue[LabelPopoverChoice]
+	case "spellcheck":
+		return choiceValue[LabelSpellcheckChoice]
+	case "translate":
+		return choiceValue[LabelTranslateChoice]
+	}
+	return nil
+}
+
 func (e *LabelElement) Children(children ...ElementRenderer) *LabelElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *LabelElement) IfChildren(condition bool, children ...ElementRenderer) *LabelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *LabelElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *LabelElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *LabelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *LabelElement) BoolAttr(name string) *LabelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *LabelElement) BoolAttrRemove(name string) *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *LabelElement) IfBoolAttr(condition bool, name string) *LabelElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *LabelElement) BoolAttrf(format string, args ...any) *LabelElement {
 
 func (e *LabelElement) IfBoolAttrf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *LabelElement) BoolAttrs(names ...string) *LabelElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *LabelElement) IfBoolAttrs(condition bool, names ...string) *LabelElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *LabelElement) Attr(name, value string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *LabelElement) RawAttr(name, value string) *LabelElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *LabelElement) IfAttr(condition bool, name, value string) *LabelElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *LabelElement) Attrf(name, format string, args ...any) *LabelElement {
 
 func (e *LabelElement) IfAttrf(condition bool, name, format string, args ...any) *LabelElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *LabelElement) Attrs(attrs ...string) *LabelElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *LabelElement) IfAttrs(condition bool, attrs ...string) *LabelElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *LabelElement) AttrsMap(attrs map[string]string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *LabelElement) IfAttrsMap(condition bool, attrs map[string]string) *LabelElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *LabelElement) Text(text string) *LabelElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *LabelElement) Textf(format string, args ...any) *LabelElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *LabelElement) IfText(condition bool, text string) *LabelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *LabelElement) IfText(condition bool, text string) *LabelElement {
 
 func (e *LabelElement) IfTextf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *LabelElement) Raw(text string) *LabelElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *LabelElement) Escaped(text string) *LabelElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *LabelElement) IfEscaped(condition bool, text string) *LabelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *LabelElement) Escapedf(format string, args ...any) *LabelElement {
 
 func (e *LabelElement) IfEscapedf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +270,8 @@ func (e *LabelElement) IfEscapedf(condition bool, format string, args ...any) *L
 
 // Describes elements which belongs to this one.
 func (e *LabelElement) For(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("for", s)
+	e = e.writable()
+	e.setStringAttribute("for", s)
 	return e
 }
 
@@ -224,7 +283,7 @@ func (e *LabelElement) Forf(format string, args ...any) *LabelElement {
 // Describes elements which belongs to this one.
 func (e *LabelElement) IfFor(condition bool, s string) *LabelElement {
 	if condition {
-		e.For(s)
+		e = e.For(s)
 	}
 	return e
 }
@@ -232,7 +291,7 @@ func (e *LabelElement) IfFor(condition bool, s string) *LabelElement {
 // Describes elements which belongs to this one.
 func (e *LabelElement) IfForf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.For(fmt.Sprintf(format, args...))
+		e = e.For(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +299,15 @@ func (e *LabelElement) IfForf(condition bool, format string, args ...any) *Label
 // Describes elements which belongs to this one.
 // Remove the attribute For from the element.
 func (e *LabelElement) ForRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("for")
+	e = e.writable()
+	e.removeAttribute("for")
 	return e
 }
 
 // Specifies the form that is the owner of the element.
 func (e *LabelElement) Form(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -264,7 +319,7 @@ func (e *LabelElement) Formf(format string, args ...any) *LabelElement {
 // Specifies the form that is the owner of the element.
 func (e *LabelElement) IfForm(condition bool, s string) *LabelElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -272,7 +327,7 @@ func (e *LabelElement) IfForm(condition bool, s string) *LabelElement {
 // Specifies the form that is the owner of the element.
 func (e *LabelElement) IfFormf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,10 +335,8 @@ func (e *LabelElement) IfFormf(condition bool, format string, args ...any) *Labe
 // Specifies the form that is the owner of the element.
 // Remove the attribute Form from the element.
 func (e *LabelElement) FormRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
@@ -292,10 +345,8 @@ func (e *LabelElement) FormRemove() *LabelElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *LabelElement) Accesskey(r rune) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -305,7 +356,7 @@ func (e *LabelElement) Accesskey(r rune) *LabelElement {
 // can be generated by the keyboard).
 func (e *LabelElement) IfAccesskey(condition bool, r rune) *LabelElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -316,10 +367,8 @@ func (e *LabelElement) IfAccesskey(condition bool, r rune) *LabelElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *LabelElement) AccesskeyRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -335,10 +384,8 @@ func (e *LabelElement) AccesskeyRemove() *LabelElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *LabelElement) Autocapitalize(c LabelAutocapitalizeChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[LabelAutocapitalizeChoice])
 	return e
 }
 
@@ -372,10 +419,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *LabelElement) AutocapitalizeRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -394,10 +439,8 @@ func (e *LabelElement) AutocapitalizeRemove() *LabelElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LabelElement) Autofocus() *LabelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -417,7 +460,7 @@ func (e *LabelElement) Autofocus() *LabelElement {
 // created by the preceding content.
 func (e *LabelElement) IfAutofocus(condition bool) *LabelElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -438,10 +481,8 @@ func (e *LabelElement) IfAutofocus(condition bool) *LabelElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LabelElement) AutofocusRemove() *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -461,10 +502,8 @@ func (e *LabelElement) AutofocusRemove() *LabelElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LabelElement) AutofocusIfRemove() *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -473,16 +512,9 @@ func (e *LabelElement) AutofocusIfRemove() *LabelElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *LabelElement) Class(s string) *LabelElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -492,7 +524,7 @@ func (e *LabelElement) Class(s string) *LabelElement {
 // document.getElementsByClassName.
 func (e *LabelElement) IfClass(condition bool, s string) *LabelElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -503,14 +535,8 @@ func (e *LabelElement) IfClass(condition bool, s string) *LabelElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *LabelElement) ClassRemove(s ...string) *LabelElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -518,10 +544,8 @@ func (e *LabelElement) ClassRemove(s ...string) *LabelElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *LabelElement) Contenteditable(c LabelContenteditableChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[LabelContenteditableChoice])
 	return e
 }
 
@@ -544,10 +568,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *LabelElement) ContenteditableRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -565,10 +587,8 @@ func (e *LabelElement) ContenteditableRemove() *LabelElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *LabelElement) Dir(c LabelDirChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[LabelDirChoice])
 	return e
 }
 
@@ -602,10 +622,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *LabelElement) DirRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -613,10 +631,8 @@ func (e *LabelElement) DirRemove() *LabelElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *LabelElement) Draggable(c LabelDraggableChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[LabelDraggableChoice])
 	return e
 }
 
@@ -642,20 +658,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *LabelElement) DraggableRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *LabelElement) Enterkeyhint(c LabelEnterkeyhintChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[LabelEnterkeyhintChoice])
 	return e
 }
 
@@ -685,10 +697,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *LabelElement) EnterkeyhintRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -710,16 +720,9 @@ func (e *LabelElement) EnterkeyhintRemove() *LabelElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *LabelElement) Exportparts(s string) *LabelElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -742,7 +745,7 @@ func (e *LabelElement) Exportparts(s string) *LabelElement {
 // the current structure.
 func (e *LabelElement) IfExportparts(condition bool, s string) *LabelElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -766,14 +769,8 @@ func (e *LabelElement) IfExportparts(condition bool, s string) *LabelElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *LabelElement) ExportpartsRemove(s ...string) *LabelElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -790,10 +787,8 @@ func (e *LabelElement) ExportpartsRemove(s ...string) *LabelElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *LabelElement) Hidden(c LabelHiddenChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[LabelHiddenChoice])
 	return e
 }
 
@@ -829,10 +824,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *LabelElement) HiddenRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -840,10 +833,8 @@ func (e *LabelElement) HiddenRemove() *LabelElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LabelElement) ID(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -859,7 +850,7 @@ func (e *LabelElement) IDf(format string, args ...any) *LabelElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LabelElement) IfID(condition bool, s string) *LabelElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -869,7 +860,7 @@ func (e *LabelElement) IfID(condition bool, s string) *LabelElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LabelElement) IfIDf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -879,10 +870,8 @@ func (e *LabelElement) IfIDf(condition bool, format string, args ...any) *LabelE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *LabelElement) IDRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -897,10 +886,8 @@ func (e *LabelElement) IDRemove() *LabelElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LabelElement) Inert() *LabelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -916,7 +903,7 @@ func (e *LabelElement) Inert() *LabelElement {
 // excluding them from the accessibility tree.
 func (e *LabelElement) IfInert(condition bool) *LabelElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -933,10 +920,8 @@ func (e *LabelElement) IfInert(condition bool) *LabelElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LabelElement) InertRemove() *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -952,10 +937,8 @@ func (e *LabelElement) InertRemove() *LabelElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LabelElement) InertIfRemove() *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -969,10 +952,8 @@ func (e *LabelElement) InertIfRemove() *LabelElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *LabelElement) Inputmode(c LabelInputmodeChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[LabelInputmodeChoice])
 	return e
 }
 
@@ -1023,10 +1004,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *LabelElement) InputmodeRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1036,10 +1015,8 @@ func (e *LabelElement) InputmodeRemove() *LabelElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *LabelElement) Is(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1059,7 +1036,7 @@ func (e *LabelElement) Isf(format string, args ...any) *LabelElement {
 // and extends the element type it is being applied to.
 func (e *LabelElement) IfIs(condition bool, s string) *LabelElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1071,7 +1048,7 @@ func (e *LabelElement) IfIs(condition bool, s string) *LabelElement {
 // and extends the element type it is being applied to.
 func (e *LabelElement) IfIsf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1083,10 +1060,8 @@ func (e *LabelElement) IfIsf(condition bool, format string, args ...any) *LabelE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *LabelElement) IsRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1102,10 +1077,8 @@ func (e *LabelElement) IsRemove() *LabelElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *LabelElement) Itemid(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1137,7 +1110,7 @@ func (e *LabelElement) Itemidf(format string, args ...any) *LabelElement {
 // how items with the same identifier are handled.
 func (e *LabelElement) IfItemid(condition bool, s string) *LabelElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1155,7 +1128,7 @@ func (e *LabelElement) IfItemid(condition bool, s string) *LabelElement {
 // how items with the same identifier are handled.
 func (e *LabelElement) IfItemidf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1173,10 +1146,8 @@ func (e *LabelElement) IfItemidf(condition bool, format string, args ...any) *La
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *LabelElement) ItemidRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1188,10 +1159,8 @@ func (e *LabelElement) ItemidRemove() *LabelElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *LabelElement) Itemprop(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1215,7 +1184,7 @@ func (e *LabelElement) Itempropf(format string, args ...any) *LabelElement {
 // <track>, and <video>.
 func (e *LabelElement) IfItemprop(condition bool, s string) *LabelElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1229,7 +1198,7 @@ func (e *LabelElement) IfItemprop(condition bool, s string) *LabelElement {
 // <track>, and <video>.
 func (e *LabelElement) IfItempropf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1243,10 +1212,8 @@ func (e *LabelElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *LabelElement) ItempropRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1256,10 +1223,8 @@ func (e *LabelElement) ItempropRemove() *LabelElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *LabelElement) Itemref(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1279,7 +1244,7 @@ func (e *LabelElement) Itemreff(format string, args ...any) *LabelElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LabelElement) IfItemref(condition bool, s string) *LabelElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1291,7 +1256,7 @@ func (e *LabelElement) IfItemref(condition bool, s string) *LabelElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LabelElement) IfItemreff(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1303,10 +1268,8 @@ func (e *LabelElement) IfItemreff(condition bool, format string, args ...any) *L
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *LabelElement) ItemrefRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1318,10 +1281,8 @@ func (e *LabelElement) ItemrefRemove() *LabelElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LabelElement) Itemscope() *LabelElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1334,7 +1295,7 @@ func (e *LabelElement) Itemscope() *LabelElement {
 // <object>, <source>, <track>, and <video>.
 func (e *LabelElement) IfItemscope(condition bool) *LabelElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1348,10 +1309,8 @@ func (e *LabelElement) IfItemscope(condition bool) *LabelElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LabelElement) ItemscopeRemove() *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1364,10 +1323,8 @@ func (e *LabelElement) ItemscopeRemove() *LabelElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LabelElement) ItemscopeIfRemove() *LabelElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1379,10 +1336,8 @@ func (e *LabelElement) ItemscopeIfRemove() *LabelElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *LabelElement) Itemtype(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1406,7 +1361,7 @@ func (e *LabelElement) Itemtypef(format string, args ...any) *LabelElement {
 // <video>.
 func (e *LabelElement) IfItemtype(condition bool, s string) *LabelElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1420,7 +1375,7 @@ func (e *LabelElement) IfItemtype(condition bool, s string) *LabelElement {
 // <video>.
 func (e *LabelElement) IfItemtypef(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1434,10 +1389,8 @@ func (e *LabelElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *LabelElement) ItemtypeRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1447,10 +1400,8 @@ func (e *LabelElement) ItemtypeRemove() *LabelElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LabelElement) Lang(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1470,7 +1421,7 @@ func (e *LabelElement) Langf(format string, args ...any) *LabelElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LabelElement) IfLang(condition bool, s string) *LabelElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1482,7 +1433,7 @@ func (e *LabelElement) IfLang(condition bool, s string) *LabelElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LabelElement) IfLangf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1494,10 +1445,8 @@ func (e *LabelElement) IfLangf(condition bool, format string, args ...any) *Labe
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *LabelElement) LangRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1507,10 +1456,8 @@ func (e *LabelElement) LangRemove() *LabelElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *LabelElement) Nonce(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1530,7 +1477,7 @@ func (e *LabelElement) Noncef(format string, args ...any) *LabelElement {
 // execute.
 func (e *LabelElement) IfNonce(condition bool, s string) *LabelElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1542,7 +1489,7 @@ func (e *LabelElement) IfNonce(condition bool, s string) *LabelElement {
 // execute.
 func (e *LabelElement) IfNoncef(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1554,10 +1501,8 @@ func (e *LabelElement) IfNoncef(condition bool, format string, args ...any) *Lab
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *LabelElement) NonceRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1565,16 +1510,9 @@ func (e *LabelElement) NonceRemove() *LabelElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *LabelElement) Part(s string) *LabelElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1583,7 +1521,7 @@ func (e *LabelElement) Part(s string) *LabelElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *LabelElement) IfPart(condition bool, s string) *LabelElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1593,14 +1531,8 @@ func (e *LabelElement) IfPart(condition bool, s string) *LabelElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *LabelElement) PartRemove(s ...string) *LabelElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1611,10 +1543,8 @@ func (e *LabelElement) PartRemove(s ...string) *LabelElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *LabelElement) Popover(c LabelPopoverChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[LabelPopoverChoice])
 	return e
 }
 
@@ -1642,10 +1572,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *LabelElement) PopoverRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1654,10 +1582,8 @@ func (e *LabelElement) PopoverRemove() *LabelElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *LabelElement) Role(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1675,7 +1601,7 @@ func (e *LabelElement) Rolef(format string, args ...any) *LabelElement {
 // role of an element.
 func (e *LabelElement) IfRole(condition bool, s string) *LabelElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1686,7 +1612,7 @@ func (e *LabelElement) IfRole(condition bool, s string) *LabelElement {
 // role of an element.
 func (e *LabelElement) IfRolef(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1697,10 +1623,8 @@ func (e *LabelElement) IfRolef(condition bool, format string, args ...any) *Labe
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *LabelElement) RoleRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1709,10 +1633,8 @@ func (e *LabelElement) RoleRemove() *LabelElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *LabelElement) Slot(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1730,7 +1652,7 @@ func (e *LabelElement) Slotf(format string, args ...any) *LabelElement {
 // value.
 func (e *LabelElement) IfSlot(condition bool, s string) *LabelElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1741,7 +1663,7 @@ func (e *LabelElement) IfSlot(condition bool, s string) *LabelElement {
 // value.
 func (e *LabelElement) IfSlotf(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1752,10 +1674,8 @@ func (e *LabelElement) IfSlotf(condition bool, format string, args ...any) *Labe
 // value.
 // Remove the attribute Slot from the element.
 func (e *LabelElement) SlotRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1771,10 +1691,8 @@ func (e *LabelElement) SlotRemove() *LabelElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *LabelElement) Spellcheck(c LabelSpellcheckChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[LabelSpellcheckChoice])
 	return e
 }
 
@@ -1802,10 +1720,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *LabelElement) SpellcheckRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1815,14 +1731,8 @@ func (e *LabelElement) StylePairs(pairs ...string) *LabelElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1837,14 +1747,8 @@ func (e *LabelElement) StylePairs(pairs ...string) *LabelElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LabelElement) Style(s string) *LabelElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1852,7 +1756,7 @@ func (e *LabelElement) Style(s string) *LabelElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1861,7 +1765,7 @@ func (e *LabelElement) Style(s string) *LabelElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *LabelElement) IfStyle(condition bool, s string) *LabelElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1869,15 +1773,7 @@ func (e *LabelElement) IfStyle(condition bool, s string) *LabelElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LabelElement) StyleAdd(k string, v string) *LabelElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1891,7 +1787,7 @@ func (e *LabelElement) StyleAddf(k string, format string, args ...any) *LabelEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *LabelElement) IfStyleAdd(condition bool, k string, v string) *LabelElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1900,7 +1796,7 @@ func (e *LabelElement) IfStyleAdd(condition bool, k string, v string) *LabelElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *LabelElement) IfStyleAddf(condition bool, k string, format string, args ...any) *LabelElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1909,21 +1805,15 @@ func (e *LabelElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *LabelElement) StyleMap(m map[string]string) *LabelElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1932,14 +1822,8 @@ func (e *LabelElement) StyleMap(m map[string]string) *LabelElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *LabelElement) StyleRemove(keys ...string) *LabelElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1957,10 +1841,8 @@ func (e *LabelElement) StyleRemove(keys ...string) *LabelElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *LabelElement) Tabindex(i int) *LabelElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1979,7 +1861,7 @@ func (e *LabelElement) Tabindex(i int) *LabelElement {
 // their relative position in the document.
 func (e *LabelElement) IfTabindex(condition bool, i int) *LabelElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1999,10 +1881,8 @@ func (e *LabelElement) IfTabindex(condition bool, i int) *LabelElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *LabelElement) TabindexRemove() *LabelElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2023,10 +1903,8 @@ func (e *LabelElement) TabindexRemove() *LabelElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *LabelElement) Title(s string) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2068,7 +1946,7 @@ func (e *LabelElement) Titlef(format string, args ...any) *LabelElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LabelElement) IfTitle(condition bool, s string) *LabelElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2091,7 +1969,7 @@ func (e *LabelElement) IfTitle(condition bool, s string) *LabelElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LabelElement) IfTitlef(condition bool, format string, args ...any) *LabelElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2114,10 +1992,8 @@ func (e *LabelElement) IfTitlef(condition bool, format string, args ...any) *Lab
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *LabelElement) TitleRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2126,10 +2002,8 @@ func (e *LabelElement) TitleRemove() *LabelElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *LabelElement) Translate(c LabelTranslateChoice) *LabelElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[LabelTranslateChoice])
 	return e
 }
 
@@ -2150,9 +2024,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *LabelElement) TranslateRemove() *LabelElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
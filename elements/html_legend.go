@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <legend> element represents a caption for the content of its parent
@@ -22,18 +20,77 @@ type LegendElement struct {
 // with the tag "legend" during rendering.
 func Legend(children ...ElementRenderer) *LegendElement {
 	e := NewElement("legend", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &LegendElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *LegendElement) Clone() *LegendElement {
+	return &LegendElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *LegendElement) CloneShallow() *LegendElement {
+	return &LegendElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *LegendElement) Freeze() *LegendElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *LegendElement) writable() *LegendElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *LegendElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *LegendElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[LegendAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[LegendContenteditableChoice]
+	case "dir":
+		return choiceValue[LegendDirChoice]
+	case "draggable":
+		return choiceValue[LegendDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[LegendEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[LegendHiddenChoice]
+	case "inputmode":
+		return choiceValue[LegendInputmodeChoice]
+	case "popover":
+		return choiceValue[LegendPopoverChoice]
+	case "spellcheck":
+		return choiceValue[LegendSpellcheckChoice]
+	case "translate":
+		return choiceValue[LegendTranslateChoice]
+	}
+	return nil
+}
+
 func (e *LegendElement) Children(children ...ElementRenderer) *LegendElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *LegendElement) IfChildren(condition bool, children ...ElementRenderer) *LegendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *LegendElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *LegendElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *LegendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *LegendElement) BoolAttr(name string) *LegendElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *LegendElement) BoolAttrRemove(name string) *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *LegendElement) IfBoolAttr(condition bool, name string) *LegendElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *LegendElement) BoolAttrf(format string, args ...any) *LegendElement {
 
 func (e *LegendElement) IfBoolAttrf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *LegendElement) BoolAttrs(names ...string) *LegendElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *LegendElement) IfBoolAttrs(condition bool, names ...string) *LegendElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *LegendElement) Attr(name, value string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *LegendElement) RawAttr(name, value string) *LegendElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *LegendElement) IfAttr(condition bool, name, value string) *LegendElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *LegendElement) Attrf(name, format string, args ...any) *LegendElement {
 
 func (e *LegendElement) IfAttrf(condition bool, name, format string, args ...any) *LegendElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *LegendElement) Attrs(attrs ...string) *LegendElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *LegendElement) IfAttrs(condition bool, attrs ...string) *LegendElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *LegendElement) AttrsMap(attrs map[string]string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *LegendElement) IfAttrsMap(condition bool, attrs map[string]string) *LegendElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *LegendElement) Text(text string) *LegendElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *LegendElement) Textf(format string, args ...any) *LegendElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *LegendElement) IfText(condition bool, text string) *LegendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *LegendElement) IfText(condition bool, text string) *LegendElement {
 
 func (e *LegendElement) IfTextf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *LegendElement) Raw(text string) *LegendElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *LegendElement) Escaped(text string) *LegendElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *LegendElement) IfEscaped(condition bool, text string) *LegendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *LegendElement) Escapedf(format string, args ...any) *LegendElement {
 
 func (e *LegendElement) IfEscapedf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +273,8 @@ func (e *LegendElement) IfEscapedf(condition bool, format string, args ...any) *
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *LegendElement) Accesskey(r rune) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *LegendElement) Accesskey(r rune) *LegendElement {
 // can be generated by the keyboard).
 func (e *LegendElement) IfAccesskey(condition bool, r rune) *LegendElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -236,10 +295,8 @@ func (e *LegendElement) IfAccesskey(condition bool, r rune) *LegendElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *LegendElement) AccesskeyRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -255,10 +312,8 @@ func (e *LegendElement) AccesskeyRemove() *LegendElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *LegendElement) Autocapitalize(c LegendAutocapitalizeChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[LegendAutocapitalizeChoice])
 	return e
 }
 
@@ -292,10 +347,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *LegendElement) AutocapitalizeRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -314,10 +367,8 @@ func (e *LegendElement) AutocapitalizeRemove() *LegendElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LegendElement) Autofocus() *LegendElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -337,7 +388,7 @@ func (e *LegendElement) Autofocus() *LegendElement {
 // created by the preceding content.
 func (e *LegendElement) IfAutofocus(condition bool) *LegendElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -358,10 +409,8 @@ func (e *LegendElement) IfAutofocus(condition bool) *LegendElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LegendElement) AutofocusRemove() *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -381,10 +430,8 @@ func (e *LegendElement) AutofocusRemove() *LegendElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LegendElement) AutofocusIfRemove() *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -393,16 +440,9 @@ func (e *LegendElement) AutofocusIfRemove() *LegendElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *LegendElement) Class(s string) *LegendElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -412,7 +452,7 @@ func (e *LegendElement) Class(s string) *LegendElement {
 // document.getElementsByClassName.
 func (e *LegendElement) IfClass(condition bool, s string) *LegendElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -423,14 +463,8 @@ func (e *LegendElement) IfClass(condition bool, s string) *LegendElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *LegendElement) ClassRemove(s ...string) *LegendElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,10 +472,8 @@ func (e *LegendElement) ClassRemove(s ...string) *LegendElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *LegendElement) Contenteditable(c LegendContenteditableChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[LegendContenteditableChoice])
 	return e
 }
 
@@ -464,10 +496,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *LegendElement) ContenteditableRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -485,10 +515,8 @@ func (e *LegendElement) ContenteditableRemove() *LegendElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *LegendElement) Dir(c LegendDirChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[LegendDirChoice])
 	return e
 }
 
@@ -522,10 +550,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *LegendElement) DirRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -533,10 +559,8 @@ func (e *LegendElement) DirRemove() *LegendElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *LegendElement) Draggable(c LegendDraggableChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[LegendDraggableChoice])
 	return e
 }
 
@@ -562,20 +586,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *LegendElement) DraggableRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *LegendElement) Enterkeyhint(c LegendEnterkeyhintChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[LegendEnterkeyhintChoice])
 	return e
 }
 
@@ -605,10 +625,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *LegendElement) EnterkeyhintRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -630,16 +648,9 @@ func (e *LegendElement) EnterkeyhintRemove() *LegendElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *LegendElement) Exportparts(s string) *LegendElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -662,7 +673,7 @@ func (e *LegendElement) Exportparts(s string) *LegendElement {
 // the current structure.
 func (e *LegendElement) IfExportparts(condition bool, s string) *LegendElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -686,14 +697,8 @@ func (e *LegendElement) IfExportparts(condition bool, s string) *LegendElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *LegendElement) ExportpartsRemove(s ...string) *LegendElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -710,10 +715,8 @@ func (e *LegendElement) ExportpartsRemove(s ...string) *LegendElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *LegendElement) Hidden(c LegendHiddenChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[LegendHiddenChoice])
 	return e
 }
 
@@ -749,10 +752,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *LegendElement) HiddenRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -760,10 +761,8 @@ func (e *LegendElement) HiddenRemove() *LegendElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LegendElement) ID(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -779,7 +778,7 @@ func (e *LegendElement) IDf(format string, args ...any) *LegendElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LegendElement) IfID(condition bool, s string) *LegendElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -789,7 +788,7 @@ func (e *LegendElement) IfID(condition bool, s string) *LegendElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LegendElement) IfIDf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -799,10 +798,8 @@ func (e *LegendElement) IfIDf(condition bool, format string, args ...any) *Legen
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *LegendElement) IDRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -817,10 +814,8 @@ func (e *LegendElement) IDRemove() *LegendElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LegendElement) Inert() *LegendElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -836,7 +831,7 @@ func (e *LegendElement) Inert() *LegendElement {
 // excluding them from the accessibility tree.
 func (e *LegendElement) IfInert(condition bool) *LegendElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -853,10 +848,8 @@ func (e *LegendElement) IfInert(condition bool) *LegendElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LegendElement) InertRemove() *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -872,10 +865,8 @@ func (e *LegendElement) InertRemove() *LegendElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LegendElement) InertIfRemove() *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -889,10 +880,8 @@ func (e *LegendElement) InertIfRemove() *LegendElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *LegendElement) Inputmode(c LegendInputmodeChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[LegendInputmodeChoice])
 	return e
 }
 
@@ -943,10 +932,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *LegendElement) InputmodeRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -956,10 +943,8 @@ func (e *LegendElement) InputmodeRemove() *LegendElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *LegendElement) Is(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -979,7 +964,7 @@ func (e *LegendElement) Isf(format string, args ...any) *LegendElement {
 // and extends the element type it is being applied to.
 func (e *LegendElement) IfIs(condition bool, s string) *LegendElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -991,7 +976,7 @@ func (e *LegendElement) IfIs(condition bool, s string) *LegendElement {
 // and extends the element type it is being applied to.
 func (e *LegendElement) IfIsf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1003,10 +988,8 @@ func (e *LegendElement) IfIsf(condition bool, format string, args ...any) *Legen
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *LegendElement) IsRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1022,10 +1005,8 @@ func (e *LegendElement) IsRemove() *LegendElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *LegendElement) Itemid(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1057,7 +1038,7 @@ func (e *LegendElement) Itemidf(format string, args ...any) *LegendElement {
 // how items with the same identifier are handled.
 func (e *LegendElement) IfItemid(condition bool, s string) *LegendElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1075,7 +1056,7 @@ func (e *LegendElement) IfItemid(condition bool, s string) *LegendElement {
 // how items with the same identifier are handled.
 func (e *LegendElement) IfItemidf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1093,10 +1074,8 @@ func (e *LegendElement) IfItemidf(condition bool, format string, args ...any) *L
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *LegendElement) ItemidRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1108,10 +1087,8 @@ func (e *LegendElement) ItemidRemove() *LegendElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *LegendElement) Itemprop(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1135,7 +1112,7 @@ func (e *LegendElement) Itempropf(format string, args ...any) *LegendElement {
 // <track>, and <video>.
 func (e *LegendElement) IfItemprop(condition bool, s string) *LegendElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1149,7 +1126,7 @@ func (e *LegendElement) IfItemprop(condition bool, s string) *LegendElement {
 // <track>, and <video>.
 func (e *LegendElement) IfItempropf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1163,10 +1140,8 @@ func (e *LegendElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *LegendElement) ItempropRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1176,10 +1151,8 @@ func (e *LegendElement) ItempropRemove() *LegendElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *LegendElement) Itemref(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1199,7 +1172,7 @@ func (e *LegendElement) Itemreff(format string, args ...any) *LegendElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LegendElement) IfItemref(condition bool, s string) *LegendElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1211,7 +1184,7 @@ func (e *LegendElement) IfItemref(condition bool, s string) *LegendElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LegendElement) IfItemreff(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1223,10 +1196,8 @@ func (e *LegendElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *LegendElement) ItemrefRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1238,10 +1209,8 @@ func (e *LegendElement) ItemrefRemove() *LegendElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LegendElement) Itemscope() *LegendElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1254,7 +1223,7 @@ func (e *LegendElement) Itemscope() *LegendElement {
 // <object>, <source>, <track>, and <video>.
 func (e *LegendElement) IfItemscope(condition bool) *LegendElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1268,10 +1237,8 @@ func (e *LegendElement) IfItemscope(condition bool) *LegendElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LegendElement) ItemscopeRemove() *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1284,10 +1251,8 @@ func (e *LegendElement) ItemscopeRemove() *LegendElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LegendElement) ItemscopeIfRemove() *LegendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1299,10 +1264,8 @@ func (e *LegendElement) ItemscopeIfRemove() *LegendElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *LegendElement) Itemtype(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1326,7 +1289,7 @@ func (e *LegendElement) Itemtypef(format string, args ...any) *LegendElement {
 // <video>.
 func (e *LegendElement) IfItemtype(condition bool, s string) *LegendElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1340,7 +1303,7 @@ func (e *LegendElement) IfItemtype(condition bool, s string) *LegendElement {
 // <video>.
 func (e *LegendElement) IfItemtypef(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1354,10 +1317,8 @@ func (e *LegendElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *LegendElement) ItemtypeRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1367,10 +1328,8 @@ func (e *LegendElement) ItemtypeRemove() *LegendElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LegendElement) Lang(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1390,7 +1349,7 @@ func (e *LegendElement) Langf(format string, args ...any) *LegendElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LegendElement) IfLang(condition bool, s string) *LegendElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1402,7 +1361,7 @@ func (e *LegendElement) IfLang(condition bool, s string) *LegendElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LegendElement) IfLangf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1414,10 +1373,8 @@ func (e *LegendElement) IfLangf(condition bool, format string, args ...any) *Leg
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *LegendElement) LangRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1427,10 +1384,8 @@ func (e *LegendElement) LangRemove() *LegendElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *LegendElement) Nonce(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1450,7 +1405,7 @@ func (e *LegendElement) Noncef(format string, args ...any) *LegendElement {
 // execute.
 func (e *LegendElement) IfNonce(condition bool, s string) *LegendElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1462,7 +1417,7 @@ func (e *LegendElement) IfNonce(condition bool, s string) *LegendElement {
 // execute.
 func (e *LegendElement) IfNoncef(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1474,10 +1429,8 @@ func (e *LegendElement) IfNoncef(condition bool, format string, args ...any) *Le
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *LegendElement) NonceRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1485,16 +1438,9 @@ func (e *LegendElement) NonceRemove() *LegendElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *LegendElement) Part(s string) *LegendElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1503,7 +1449,7 @@ func (e *LegendElement) Part(s string) *LegendElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *LegendElement) IfPart(condition bool, s string) *LegendElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1513,14 +1459,8 @@ func (e *LegendElement) IfPart(condition bool, s string) *LegendElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *LegendElement) PartRemove(s ...string) *LegendElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1531,10 +1471,8 @@ func (e *LegendElement) PartRemove(s ...string) *LegendElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *LegendElement) Popover(c LegendPopoverChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[LegendPopoverChoice])
 	return e
 }
 
@@ -1562,10 +1500,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *LegendElement) PopoverRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1574,10 +1510,8 @@ func (e *LegendElement) PopoverRemove() *LegendElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *LegendElement) Role(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1595,7 +1529,7 @@ func (e *LegendElement) Rolef(format string, args ...any) *LegendElement {
 // role of an element.
 func (e *LegendElement) IfRole(condition bool, s string) *LegendElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1606,7 +1540,7 @@ func (e *LegendElement) IfRole(condition bool, s string) *LegendElement {
 // role of an element.
 func (e *LegendElement) IfRolef(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1617,10 +1551,8 @@ func (e *LegendElement) IfRolef(condition bool, format string, args ...any) *Leg
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *LegendElement) RoleRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1629,10 +1561,8 @@ func (e *LegendElement) RoleRemove() *LegendElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *LegendElement) Slot(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1650,7 +1580,7 @@ func (e *LegendElement) Slotf(format string, args ...any) *LegendElement {
 // value.
 func (e *LegendElement) IfSlot(condition bool, s string) *LegendElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1661,7 +1591,7 @@ func (e *LegendElement) IfSlot(condition bool, s string) *LegendElement {
 // value.
 func (e *LegendElement) IfSlotf(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1672,10 +1602,8 @@ func (e *LegendElement) IfSlotf(condition bool, format string, args ...any) *Leg
 // value.
 // Remove the attribute Slot from the element.
 func (e *LegendElement) SlotRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1691,10 +1619,8 @@ func (e *LegendElement) SlotRemove() *LegendElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *LegendElement) Spellcheck(c LegendSpellcheckChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[LegendSpellcheckChoice])
 	return e
 }
 
@@ -1722,10 +1648,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *LegendElement) SpellcheckRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1735,14 +1659,8 @@ func (e *LegendElement) StylePairs(pairs ...string) *LegendElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1757,14 +1675,8 @@ func (e *LegendElement) StylePairs(pairs ...string) *LegendElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LegendElement) Style(s string) *LegendElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1772,7 +1684,7 @@ func (e *LegendElement) Style(s string) *LegendElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1781,7 +1693,7 @@ func (e *LegendElement) Style(s string) *LegendElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *LegendElement) IfStyle(condition bool, s string) *LegendElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1789,15 +1701,7 @@ func (e *LegendElement) IfStyle(condition bool, s string) *LegendElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LegendElement) StyleAdd(k string, v string) *LegendElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1811,7 +1715,7 @@ func (e *LegendElement) StyleAddf(k string, format string, args ...any) *LegendE
 // color, font, size, and more. Styles are written in CSS.
 func (e *LegendElement) IfStyleAdd(condition bool, k string, v string) *LegendElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1820,7 +1724,7 @@ func (e *LegendElement) IfStyleAdd(condition bool, k string, v string) *LegendEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *LegendElement) IfStyleAddf(condition bool, k string, format string, args ...any) *LegendElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1829,21 +1733,15 @@ func (e *LegendElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *LegendElement) StyleMap(m map[string]string) *LegendElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1852,14 +1750,8 @@ func (e *LegendElement) StyleMap(m map[string]string) *LegendElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *LegendElement) StyleRemove(keys ...string) *LegendElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1877,10 +1769,8 @@ func (e *LegendElement) StyleRemove(keys ...string) *LegendElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *LegendElement) Tabindex(i int) *LegendElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1899,7 +1789,7 @@ func (e *LegendElement) Tabindex(i int) *LegendElement {
 // their relative position in the document.
 func (e *LegendElement) IfTabindex(condition bool, i int) *LegendElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1919,10 +1809,8 @@ func (e *LegendElement) IfTabindex(condition bool, i int) *LegendElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *LegendElement) TabindexRemove() *LegendElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1943,10 +1831,8 @@ func (e *LegendElement) TabindexRemove() *LegendElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *LegendElement) Title(s string) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1988,7 +1874,7 @@ func (e *LegendElement) Titlef(format string, args ...any) *LegendElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LegendElement) IfTitle(condition bool, s string) *LegendElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2011,7 +1897,7 @@ func (e *LegendElement) IfTitle(condition bool, s string) *LegendElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LegendElement) IfTitlef(condition bool, format string, args ...any) *LegendElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2034,10 +1920,8 @@ func (e *LegendElement) IfTitlef(condition bool, format string, args ...any) *Le
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *LegendElement) TitleRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2046,10 +1930,8 @@ func (e *LegendElement) TitleRemove() *LegendElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *LegendElement) Translate(c LegendTranslateChoice) *LegendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[LegendTranslateChoice])
 	return e
 }
 
@@ -2070,9 +1952,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *LegendElement) TranslateRemove() *LegendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
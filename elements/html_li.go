@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <li> element is used to represent an item in a list.
@@ -21,18 +19,77 @@ type LiElement struct {
 // with the tag "li" during rendering.
 func Li(children ...ElementRenderer) *LiElement {
 	e := NewElement("li", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &LiElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *LiElement) Clone() *LiElement {
+	return &LiElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *LiElement) CloneShallow() *LiElement {
+	return &LiElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *LiElement) Freeze() *LiElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *LiElement) writable() *LiElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *LiElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *LiElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[LiAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[LiContenteditableChoice]
+	case "dir":
+		return choiceValue[LiDirChoice]
+	case "draggable":
+		return choiceValue[LiDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[LiEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[LiHiddenChoice]
+	case "inputmode":
+		return choiceValue[LiInputmodeChoice]
+	case "popover":
+		return choiceValue[LiPopoverChoice]
+	case "spellcheck":
+		return choiceValue[LiSpellcheckChoice]
+	case "translate":
+		return choiceValue[LiTranslateChoice]
+	}
+	return nil
+}
+
 func (e *LiElement) Children(children ...ElementRenderer) *LiElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *LiElement) IfChildren(condition bool, children ...ElementRenderer) *LiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +97,30 @@ func (e *LiElement) IfChildren(condition bool, children ...ElementRenderer) *LiE
 
 func (e *LiElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *LiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *LiElement) BoolAttr(name string) *LiElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *LiElement) BoolAttrRemove(name string) *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *LiElement) IfBoolAttr(condition bool, name string) *LiElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +131,40 @@ func (e *LiElement) BoolAttrf(format string, args ...any) *LiElement {
 
 func (e *LiElement) IfBoolAttrf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *LiElement) BoolAttrs(names ...string) *LiElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *LiElement) IfBoolAttrs(condition bool, names ...string) *LiElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *LiElement) Attr(name, value string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *LiElement) RawAttr(name, value string) *LiElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *LiElement) IfAttr(condition bool, name, value string) *LiElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +175,52 @@ func (e *LiElement) Attrf(name, format string, args ...any) *LiElement {
 
 func (e *LiElement) IfAttrf(condition bool, name, format string, args ...any) *LiElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *LiElement) Attrs(attrs ...string) *LiElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *LiElement) IfAttrs(condition bool, attrs ...string) *LiElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *LiElement) AttrsMap(attrs map[string]string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *LiElement) IfAttrsMap(condition bool, attrs map[string]string) *LiElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *LiElement) Text(text string) *LiElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *LiElement) Textf(format string, args ...any) *LiElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *LiElement) IfText(condition bool, text string) *LiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +228,28 @@ func (e *LiElement) IfText(condition bool, text string) *LiElement {
 
 func (e *LiElement) IfTextf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *LiElement) Raw(text string) *LiElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *LiElement) Escaped(text string) *LiElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *LiElement) IfEscaped(condition bool, text string) *LiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +261,7 @@ func (e *LiElement) Escapedf(format string, args ...any) *LiElement {
 
 func (e *LiElement) IfEscapedf(condition bool, format string, args ...any) *LiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,17 +269,15 @@ func (e *LiElement) IfEscapedf(condition bool, format string, args ...any) *LiEl
 
 // Ordinal value of the list item.
 func (e *LiElement) Value(i int) *LiElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("value", i)
+	e = e.writable()
+	e.setIntAttribute("value", i)
 	return e
 }
 
 // Ordinal value of the list item.
 func (e *LiElement) IfValue(condition bool, i int) *LiElement {
 	if condition {
-		e.Value(i)
+		e = e.Value(i)
 	}
 	return e
 }
@@ -226,10 +285,8 @@ func (e *LiElement) IfValue(condition bool, i int) *LiElement {
 // Ordinal value of the list item.
 // Remove the attribute Value from the element.
 func (e *LiElement) ValueRemove() *LiElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("value")
+	e = e.writable()
+	e.removeAttribute("value")
 	return e
 }
 
@@ -238,10 +295,8 @@ func (e *LiElement) ValueRemove() *LiElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *LiElement) Accesskey(r rune) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -251,7 +306,7 @@ func (e *LiElement) Accesskey(r rune) *LiElement {
 // can be generated by the keyboard).
 func (e *LiElement) IfAccesskey(condition bool, r rune) *LiElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -262,10 +317,8 @@ func (e *LiElement) IfAccesskey(condition bool, r rune) *LiElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *LiElement) AccesskeyRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -281,10 +334,8 @@ func (e *LiElement) AccesskeyRemove() *LiElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *LiElement) Autocapitalize(c LiAutocapitalizeChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[LiAutocapitalizeChoice])
 	return e
 }
 
@@ -318,10 +369,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *LiElement) AutocapitalizeRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -340,10 +389,8 @@ func (e *LiElement) AutocapitalizeRemove() *LiElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LiElement) Autofocus() *LiElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -363,7 +410,7 @@ func (e *LiElement) Autofocus() *LiElement {
 // created by the preceding content.
 func (e *LiElement) IfAutofocus(condition bool) *LiElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -384,10 +431,8 @@ func (e *LiElement) IfAutofocus(condition bool) *LiElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LiElement) AutofocusRemove() *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -407,10 +452,8 @@ func (e *LiElement) AutofocusRemove() *LiElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LiElement) AutofocusIfRemove() *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -419,16 +462,9 @@ func (e *LiElement) AutofocusIfRemove() *LiElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *LiElement) Class(s string) *LiElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -438,7 +474,7 @@ func (e *LiElement) Class(s string) *LiElement {
 // document.getElementsByClassName.
 func (e *LiElement) IfClass(condition bool, s string) *LiElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -449,14 +485,8 @@ func (e *LiElement) IfClass(condition bool, s string) *LiElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *LiElement) ClassRemove(s ...string) *LiElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -464,10 +494,8 @@ func (e *LiElement) ClassRemove(s ...string) *LiElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *LiElement) Contenteditable(c LiContenteditableChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[LiContenteditableChoice])
 	return e
 }
 
@@ -490,10 +518,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *LiElement) ContenteditableRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -511,10 +537,8 @@ func (e *LiElement) ContenteditableRemove() *LiElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *LiElement) Dir(c LiDirChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[LiDirChoice])
 	return e
 }
 
@@ -548,10 +572,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *LiElement) DirRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -559,10 +581,8 @@ func (e *LiElement) DirRemove() *LiElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *LiElement) Draggable(c LiDraggableChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[LiDraggableChoice])
 	return e
 }
 
@@ -588,20 +608,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *LiElement) DraggableRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *LiElement) Enterkeyhint(c LiEnterkeyhintChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[LiEnterkeyhintChoice])
 	return e
 }
 
@@ -631,10 +647,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *LiElement) EnterkeyhintRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -656,16 +670,9 @@ func (e *LiElement) EnterkeyhintRemove() *LiElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *LiElement) Exportparts(s string) *LiElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -688,7 +695,7 @@ func (e *LiElement) Exportparts(s string) *LiElement {
 // the current structure.
 func (e *LiElement) IfExportparts(condition bool, s string) *LiElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -712,14 +719,8 @@ func (e *LiElement) IfExportparts(condition bool, s string) *LiElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *LiElement) ExportpartsRemove(s ...string) *LiElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -736,10 +737,8 @@ func (e *LiElement) ExportpartsRemove(s ...string) *LiElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *LiElement) Hidden(c LiHiddenChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[LiHiddenChoice])
 	return e
 }
 
@@ -775,10 +774,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *LiElement) HiddenRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -786,10 +783,8 @@ func (e *LiElement) HiddenRemove() *LiElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LiElement) ID(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -805,7 +800,7 @@ func (e *LiElement) IDf(format string, args ...any) *LiElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LiElement) IfID(condition bool, s string) *LiElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -815,7 +810,7 @@ func (e *LiElement) IfID(condition bool, s string) *LiElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LiElement) IfIDf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -825,10 +820,8 @@ func (e *LiElement) IfIDf(condition bool, format string, args ...any) *LiElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *LiElement) IDRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -843,10 +836,8 @@ func (e *LiElement) IDRemove() *LiElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LiElement) Inert() *LiElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -862,7 +853,7 @@ func (e *LiElement) Inert() *LiElement {
 // excluding them from the accessibility tree.
 func (e *LiElement) IfInert(condition bool) *LiElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -879,10 +870,8 @@ func (e *LiElement) IfInert(condition bool) *LiElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LiElement) InertRemove() *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -898,10 +887,8 @@ func (e *LiElement) InertRemove() *LiElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LiElement) InertIfRemove() *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -915,10 +902,8 @@ func (e *LiElement) InertIfRemove() *LiElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *LiElement) Inputmode(c LiInputmodeChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[LiInputmodeChoice])
 	return e
 }
 
@@ -969,10 +954,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *LiElement) InputmodeRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -982,10 +965,8 @@ func (e *LiElement) InputmodeRemove() *LiElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *LiElement) Is(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1005,7 +986,7 @@ func (e *LiElement) Isf(format string, args ...any) *LiElement {
 // and extends the element type it is being applied to.
 func (e *LiElement) IfIs(condition bool, s string) *LiElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1017,7 +998,7 @@ func (e *LiElement) IfIs(condition bool, s string) *LiElement {
 // and extends the element type it is being applied to.
 func (e *LiElement) IfIsf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1029,10 +1010,8 @@ func (e *LiElement) IfIsf(condition bool, format string, args ...any) *LiElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *LiElement) IsRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1048,10 +1027,8 @@ func (e *LiElement) IsRemove() *LiElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *LiElement) Itemid(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1083,7 +1060,7 @@ func (e *LiElement) Itemidf(format string, args ...any) *LiElement {
 // how items with the same identifier are handled.
 func (e *LiElement) IfItemid(condition bool, s string) *LiElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1101,7 +1078,7 @@ func (e *LiElement) IfItemid(condition bool, s string) *LiElement {
 // how items with the same identifier are handled.
 func (e *LiElement) IfItemidf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1119,10 +1096,8 @@ func (e *LiElement) IfItemidf(condition bool, format string, args ...any) *LiEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *LiElement) ItemidRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1134,10 +1109,8 @@ func (e *LiElement) ItemidRemove() *LiElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *LiElement) Itemprop(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1161,7 +1134,7 @@ func (e *LiElement) Itempropf(format string, args ...any) *LiElement {
 // <track>, and <video>.
 func (e *LiElement) IfItemprop(condition bool, s string) *LiElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1175,7 +1148,7 @@ func (e *LiElement) IfItemprop(condition bool, s string) *LiElement {
 // <track>, and <video>.
 func (e *LiElement) IfItempropf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1189,10 +1162,8 @@ func (e *LiElement) IfItempropf(condition bool, format string, args ...any) *LiE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *LiElement) ItempropRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1202,10 +1173,8 @@ func (e *LiElement) ItempropRemove() *LiElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *LiElement) Itemref(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1225,7 +1194,7 @@ func (e *LiElement) Itemreff(format string, args ...any) *LiElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LiElement) IfItemref(condition bool, s string) *LiElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1237,7 +1206,7 @@ func (e *LiElement) IfItemref(condition bool, s string) *LiElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LiElement) IfItemreff(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1249,10 +1218,8 @@ func (e *LiElement) IfItemreff(condition bool, format string, args ...any) *LiEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *LiElement) ItemrefRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1264,10 +1231,8 @@ func (e *LiElement) ItemrefRemove() *LiElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LiElement) Itemscope() *LiElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1280,7 +1245,7 @@ func (e *LiElement) Itemscope() *LiElement {
 // <object>, <source>, <track>, and <video>.
 func (e *LiElement) IfItemscope(condition bool) *LiElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1294,10 +1259,8 @@ func (e *LiElement) IfItemscope(condition bool) *LiElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LiElement) ItemscopeRemove() *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1310,10 +1273,8 @@ func (e *LiElement) ItemscopeRemove() *LiElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LiElement) ItemscopeIfRemove() *LiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1325,10 +1286,8 @@ func (e *LiElement) ItemscopeIfRemove() *LiElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *LiElement) Itemtype(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1352,7 +1311,7 @@ func (e *LiElement) Itemtypef(format string, args ...any) *LiElement {
 // <video>.
 func (e *LiElement) IfItemtype(condition bool, s string) *LiElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1366,7 +1325,7 @@ func (e *LiElement) IfItemtype(condition bool, s string) *LiElement {
 // <video>.
 func (e *LiElement) IfItemtypef(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1380,10 +1339,8 @@ func (e *LiElement) IfItemtypef(condition bool, format string, args ...any) *LiE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *LiElement) ItemtypeRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1393,10 +1350,8 @@ func (e *LiElement) ItemtypeRemove() *LiElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LiElement) Lang(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1416,7 +1371,7 @@ func (e *LiElement) Langf(format string, args ...any) *LiElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LiElement) IfLang(condition bool, s string) *LiElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1428,7 +1383,7 @@ func (e *LiElement) IfLang(condition bool, s string) *LiElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LiElement) IfLangf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1440,10 +1395,8 @@ func (e *LiElement) IfLangf(condition bool, format string, args ...any) *LiEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *LiElement) LangRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1453,10 +1406,8 @@ func (e *LiElement) LangRemove() *LiElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *LiElement) Nonce(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1476,7 +1427,7 @@ func (e *LiElement) Noncef(format string, args ...any) *LiElement {
 // execute.
 func (e *LiElement) IfNonce(condition bool, s string) *LiElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1488,7 +1439,7 @@ func (e *LiElement) IfNonce(condition bool, s string) *LiElement {
 // execute.
 func (e *LiElement) IfNoncef(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1500,10 +1451,8 @@ func (e *LiElement) IfNoncef(condition bool, format string, args ...any) *LiElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *LiElement) NonceRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1511,16 +1460,9 @@ func (e *LiElement) NonceRemove() *LiElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *LiElement) Part(s string) *LiElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1529,7 +1471,7 @@ func (e *LiElement) Part(s string) *LiElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *LiElement) IfPart(condition bool, s string) *LiElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1539,14 +1481,8 @@ func (e *LiElement) IfPart(condition bool, s string) *LiElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *LiElement) PartRemove(s ...string) *LiElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1557,10 +1493,8 @@ func (e *LiElement) PartRemove(s ...string) *LiElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *LiElement) Popover(c LiPopoverChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[LiPopoverChoice])
 	return e
 }
 
@@ -1588,10 +1522,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *LiElement) PopoverRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1600,10 +1532,8 @@ func (e *LiElement) PopoverRemove() *LiElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *LiElement) Role(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1621,7 +1551,7 @@ func (e *LiElement) Rolef(format string, args ...any) *LiElement {
 // role of an element.
 func (e *LiElement) IfRole(condition bool, s string) *LiElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1632,7 +1562,7 @@ func (e *LiElement) IfRole(condition bool, s string) *LiElement {
 // role of an element.
 func (e *LiElement) IfRolef(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1643,10 +1573,8 @@ func (e *LiElement) IfRolef(condition bool, format string, args ...any) *LiEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *LiElement) RoleRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1655,10 +1583,8 @@ func (e *LiElement) RoleRemove() *LiElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *LiElement) Slot(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1676,7 +1602,7 @@ func (e *LiElement) Slotf(format string, args ...any) *LiElement {
 // value.
 func (e *LiElement) IfSlot(condition bool, s string) *LiElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1687,7 +1613,7 @@ func (e *LiElement) IfSlot(condition bool, s string) *LiElement {
 // value.
 func (e *LiElement) IfSlotf(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1698,10 +1624,8 @@ func (e *LiElement) IfSlotf(condition bool, format string, args ...any) *LiEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *LiElement) SlotRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1717,10 +1641,8 @@ func (e *LiElement) SlotRemove() *LiElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *LiElement) Spellcheck(c LiSpellcheckChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[LiSpellcheckChoice])
 	return e
 }
 
@@ -1748,10 +1670,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *LiElement) SpellcheckRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1761,14 +1681,8 @@ func (e *LiElement) StylePairs(pairs ...string) *LiElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1783,14 +1697,8 @@ func (e *LiElement) StylePairs(pairs ...string) *LiElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LiElement) Style(s string) *LiElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1798,7 +1706,7 @@ func (e *LiElement) Style(s string) *LiElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1807,7 +1715,7 @@ func (e *LiElement) Style(s string) *LiElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *LiElement) IfStyle(condition bool, s string) *LiElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1815,15 +1723,7 @@ func (e *LiElement) IfStyle(condition bool, s string) *LiElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LiElement) StyleAdd(k string, v string) *LiElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1837,7 +1737,7 @@ func (e *LiElement) StyleAddf(k string, format string, args ...any) *LiElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *LiElement) IfStyleAdd(condition bool, k string, v string) *LiElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1846,7 +1746,7 @@ func (e *LiElement) IfStyleAdd(condition bool, k string, v string) *LiElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *LiElement) IfStyleAddf(condition bool, k string, format string, args ...any) *LiElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1855,21 +1755,15 @@ func (e *LiElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *LiElement) StyleMap(m map[string]string) *LiElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1878,14 +1772,8 @@ func (e *LiElement) StyleMap(m map[string]string) *LiElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *LiElement) StyleRemove(keys ...string) *LiElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1903,10 +1791,8 @@ func (e *LiElement) StyleRemove(keys ...string) *LiElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *LiElement) Tabindex(i int) *LiElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1925,7 +1811,7 @@ func (e *LiElement) Tabindex(i int) *LiElement {
 // their relative position in the document.
 func (e *LiElement) IfTabindex(condition bool, i int) *LiElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1945,10 +1831,8 @@ func (e *LiElement) IfTabindex(condition bool, i int) *LiElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *LiElement) TabindexRemove() *LiElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1969,10 +1853,8 @@ func (e *LiElement) TabindexRemove() *LiElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *LiElement) Title(s string) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2014,7 +1896,7 @@ func (e *LiElement) Titlef(format string, args ...any) *LiElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LiElement) IfTitle(condition bool, s string) *LiElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2037,7 +1919,7 @@ func (e *LiElement) IfTitle(condition bool, s string) *LiElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LiElement) IfTitlef(condition bool, format string, args ...any) *LiElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2060,10 +1942,8 @@ func (e *LiElement) IfTitlef(condition bool, format string, args ...any) *LiElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *LiElement) TitleRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2072,10 +1952,8 @@ func (e *LiElement) TitleRemove() *LiElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *LiElement) Translate(c LiTranslateChoice) *LiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[LiTranslateChoice])
 	return e
 }
 
@@ -2096,9 +1974,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *LiElement) TranslateRemove() *LiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML External Resource Link element (<link>) specifies relationships
@@ -25,18 +23,83 @@ type LinkElement struct {
 // with the tag "link" during rendering.
 func Link() *LinkElement {
 	e := NewElement("link")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &LinkElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *LinkElement) Clone() *LinkElement {
+	return &LinkElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *LinkElement) CloneShallow() *LinkElement {
+	return &LinkElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *LinkElement) Freeze() *LinkElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *LinkElement) writable() *LinkElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *LinkElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *LinkElement) choiceType(name string) func(string) any {
+	switch name {
+	case "as":
+		return choiceValue[LinkAsChoice]
+	case "crossorigin":
+		return choiceValue[LinkCrossoriginChoice]
+	case "referrerpolicy":
+		return choiceValue[LinkReferrerpolicyChoice]
+	case "autocapitalize":
+		return choiceValue[LinkAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[LinkContenteditableChoice]
+	case "dir":
+		return choiceValue[LinkDirChoice]
+	case "draggable":
+		return choiceValue[LinkDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[LinkEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[LinkHiddenChoice]
+	case "inputmode":
+		return choiceValue[LinkInputmodeChoice]
+	case "popover":
+		return choiceValue[LinkPopoverChoice]
+	case "spellcheck":
+		return choiceValue[LinkSpellcheckChoice]
+	case "translate":
+		return choiceValue[LinkTranslateChoice]
+	}
+	return nil
+}
+
 func (e *LinkElement) Children(children ...ElementRenderer) *LinkElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *LinkElement) IfChildren(condition bool, children ...ElementRenderer) *LinkElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -44,32 +107,30 @@ func (e *LinkElement) IfChildren(condition bool, children ...ElementRenderer) *L
 
 func (e *LinkElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *LinkElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *LinkElement) BoolAttr(name string) *LinkElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *LinkElement) BoolAttrRemove(name string) *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *LinkElement) IfBoolAttr(condition bool, name string) *LinkElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -80,36 +141,40 @@ func (e *LinkElement) BoolAttrf(format string, args ...any) *LinkElement {
 
 func (e *LinkElement) IfBoolAttrf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *LinkElement) BoolAttrs(names ...string) *LinkElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *LinkElement) IfBoolAttrs(condition bool, names ...string) *LinkElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *LinkElement) Attr(name, value string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *LinkElement) RawAttr(name, value string) *LinkElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *LinkElement) IfAttr(condition bool, name, value string) *LinkElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -120,61 +185,52 @@ func (e *LinkElement) Attrf(name, format string, args ...any) *LinkElement {
 
 func (e *LinkElement) IfAttrf(condition bool, name, format string, args ...any) *LinkElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *LinkElement) Attrs(attrs ...string) *LinkElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *LinkElement) IfAttrs(condition bool, attrs ...string) *LinkElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *LinkElement) AttrsMap(attrs map[string]string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *LinkElement) IfAttrsMap(condition bool, attrs map[string]string) *LinkElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *LinkElement) Text(text string) *LinkElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *LinkElement) Textf(format string, args ...any) *LinkElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *LinkElement) IfText(condition bool, text string) *LinkElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -182,18 +238,28 @@ func (e *LinkElement) IfText(condition bool, text string) *LinkElement {
 
 func (e *LinkElement) IfTextf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *LinkElement) Raw(text string) *LinkElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *LinkElement) Escaped(text string) *LinkElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *LinkElement) IfEscaped(condition bool, text string) *LinkElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -205,6 +271,7 @@ func (e *LinkElement) Escapedf(format string, args ...any) *LinkElement {
 
 func (e *LinkElement) IfEscapedf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +279,8 @@ func (e *LinkElement) IfEscapedf(condition bool, format string, args ...any) *Li
 
 // Indicates the type of content being loaded by the link target.
 func (e *LinkElement) As(c LinkAsChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("as", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("as", string(c), choiceValue[LinkAsChoice])
 	return e
 }
 
@@ -262,19 +327,15 @@ const (
 // Indicates the type of content being loaded by the link target.
 // Remove the attribute As from the element.
 func (e *LinkElement) AsRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("as")
+	e = e.writable()
+	e.removeAttribute("as")
 	return e
 }
 
 // How the element handles crossorigin requests.
 func (e *LinkElement) Crossorigin(c LinkCrossoriginChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("crossorigin", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("crossorigin", string(c), choiceValue[LinkCrossoriginChoice])
 	return e
 }
 
@@ -292,19 +353,15 @@ const (
 // How the element handles crossorigin requests.
 // Remove the attribute Crossorigin from the element.
 func (e *LinkElement) CrossoriginRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("crossorigin")
+	e = e.writable()
+	e.removeAttribute("crossorigin")
 	return e
 }
 
 // Address of the hyperlink.
 func (e *LinkElement) Href(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -316,7 +373,7 @@ func (e *LinkElement) Hreff(format string, args ...any) *LinkElement {
 // Address of the hyperlink.
 func (e *LinkElement) IfHref(condition bool, s string) *LinkElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -324,7 +381,7 @@ func (e *LinkElement) IfHref(condition bool, s string) *LinkElement {
 // Address of the hyperlink.
 func (e *LinkElement) IfHreff(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -332,19 +389,15 @@ func (e *LinkElement) IfHreff(condition bool, format string, args ...any) *LinkE
 // Address of the hyperlink.
 // Remove the attribute Href from the element.
 func (e *LinkElement) HrefRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies the language of the linked resource.
 func (e *LinkElement) Hreflang(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hreflang", s)
+	e = e.writable()
+	e.setStringAttribute("hreflang", s)
 	return e
 }
 
@@ -356,7 +409,7 @@ func (e *LinkElement) Hreflangf(format string, args ...any) *LinkElement {
 // Specifies the language of the linked resource.
 func (e *LinkElement) IfHreflang(condition bool, s string) *LinkElement {
 	if condition {
-		e.Hreflang(s)
+		e = e.Hreflang(s)
 	}
 	return e
 }
@@ -364,7 +417,7 @@ func (e *LinkElement) IfHreflang(condition bool, s string) *LinkElement {
 // Specifies the language of the linked resource.
 func (e *LinkElement) IfHreflangf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Hreflang(fmt.Sprintf(format, args...))
+		e = e.Hreflang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -372,19 +425,15 @@ func (e *LinkElement) IfHreflangf(condition bool, format string, args ...any) *L
 // Specifies the language of the linked resource.
 // Remove the attribute Hreflang from the element.
 func (e *LinkElement) HreflangRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hreflang")
+	e = e.writable()
+	e.removeAttribute("hreflang")
 	return e
 }
 
 // Security Feature that allows browsers to verify what they fetch.
 func (e *LinkElement) Integrity(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("integrity", s)
+	e = e.writable()
+	e.setStringAttribute("integrity", s)
 	return e
 }
 
@@ -396,7 +445,7 @@ func (e *LinkElement) Integrityf(format string, args ...any) *LinkElement {
 // Security Feature that allows browsers to verify what they fetch.
 func (e *LinkElement) IfIntegrity(condition bool, s string) *LinkElement {
 	if condition {
-		e.Integrity(s)
+		e = e.Integrity(s)
 	}
 	return e
 }
@@ -404,7 +453,7 @@ func (e *LinkElement) IfIntegrity(condition bool, s string) *LinkElement {
 // Security Feature that allows browsers to verify what they fetch.
 func (e *LinkElement) IfIntegrityf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Integrity(fmt.Sprintf(format, args...))
+		e = e.Integrity(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -412,19 +461,15 @@ func (e *LinkElement) IfIntegrityf(condition bool, format string, args ...any) *
 // Security Feature that allows browsers to verify what they fetch.
 // Remove the attribute Integrity from the element.
 func (e *LinkElement) IntegrityRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("integrity")
+	e = e.writable()
+	e.removeAttribute("integrity")
 	return e
 }
 
 // Specifies a hint of the media for which the linked resource was designed.
 func (e *LinkElement) Media(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("media", s)
+	e = e.writable()
+	e.setStringAttribute("media", s)
 	return e
 }
 
@@ -436,7 +481,7 @@ func (e *LinkElement) Mediaf(format string, args ...any) *LinkElement {
 // Specifies a hint of the media for which the linked resource was designed.
 func (e *LinkElement) IfMedia(condition bool, s string) *LinkElement {
 	if condition {
-		e.Media(s)
+		e = e.Media(s)
 	}
 	return e
 }
@@ -444,7 +489,7 @@ func (e *LinkElement) IfMedia(condition bool, s string) *LinkElement {
 // Specifies a hint of the media for which the linked resource was designed.
 func (e *LinkElement) IfMediaf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Media(fmt.Sprintf(format, args...))
+		e = e.Media(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -452,20 +497,16 @@ func (e *LinkElement) IfMediaf(condition bool, format string, args ...any) *Link
 // Specifies a hint of the media for which the linked resource was designed.
 // Remove the attribute Media from the element.
 func (e *LinkElement) MediaRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("media")
+	e = e.writable()
+	e.removeAttribute("media")
 	return e
 }
 
 // Specifies which referrer to send when fetching the resource. See
 // Referrer-Policy for possible values and their effects.
 func (e *LinkElement) Referrerpolicy(c LinkReferrerpolicyChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[LinkReferrerpolicyChoice])
 	return e
 }
 
@@ -499,19 +540,15 @@ const (
 // Referrer-Policy for possible values and their effects.
 // Remove the attribute Referrerpolicy from the element.
 func (e *LinkElement) ReferrerpolicyRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
 // Specifies the relationship of the target object to the link object.
 func (e *LinkElement) Rel(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("rel", s)
+	e = e.writable()
+	e.setStringAttribute("rel", s)
 	return e
 }
 
@@ -523,7 +560,7 @@ func (e *LinkElement) Relf(format string, args ...any) *LinkElement {
 // Specifies the relationship of the target object to the link object.
 func (e *LinkElement) IfRel(condition bool, s string) *LinkElement {
 	if condition {
-		e.Rel(s)
+		e = e.Rel(s)
 	}
 	return e
 }
@@ -531,7 +568,7 @@ func (e *LinkElement) IfRel(condition bool, s string) *LinkElement {
 // Specifies the relationship of the target object to the link object.
 func (e *LinkElement) IfRelf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Rel(fmt.Sprintf(format, args...))
+		e = e.Rel(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -539,19 +576,15 @@ func (e *LinkElement) IfRelf(condition bool, format string, args ...any) *LinkEl
 // Specifies the relationship of the target object to the link object.
 // Remove the attribute Rel from the element.
 func (e *LinkElement) RelRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("rel")
+	e = e.writable()
+	e.removeAttribute("rel")
 	return e
 }
 
 // Sizes of the icons (for rel="icon").
 func (e *LinkElement) Sizes(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("sizes", s)
+	e = e.writable()
+	e.setStringAttribute("sizes", s)
 	return e
 }
 
@@ -563,7 +596,7 @@ func (e *LinkElement) Sizesf(format string, args ...any) *LinkElement {
 // Sizes of the icons (for rel="icon").
 func (e *LinkElement) IfSizes(condition bool, s string) *LinkElement {
 	if condition {
-		e.Sizes(s)
+		e = e.Sizes(s)
 	}
 	return e
 }
@@ -571,7 +604,7 @@ func (e *LinkElement) IfSizes(condition bool, s string) *LinkElement {
 // Sizes of the icons (for rel="icon").
 func (e *LinkElement) IfSizesf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Sizes(fmt.Sprintf(format, args...))
+		e = e.Sizes(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -579,19 +612,15 @@ func (e *LinkElement) IfSizesf(condition bool, format string, args ...any) *Link
 // Sizes of the icons (for rel="icon").
 // Remove the attribute Sizes from the element.
 func (e *LinkElement) SizesRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("sizes")
+	e = e.writable()
+	e.removeAttribute("sizes")
 	return e
 }
 
 // Specifies the MIME type of the linked resource.
 func (e *LinkElement) Type(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -603,7 +632,7 @@ func (e *LinkElement) Typef(format string, args ...any) *LinkElement {
 // Specifies the MIME type of the linked resource.
 func (e *LinkElement) IfType(condition bool, s string) *LinkElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -611,7 +640,7 @@ func (e *LinkElement) IfType(condition bool, s string) *LinkElement {
 // Specifies the MIME type of the linked resource.
 func (e *LinkElement) IfTypef(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -619,10 +648,8 @@ func (e *LinkElement) IfTypef(condition bool, format string, args ...any) *LinkE
 // Specifies the MIME type of the linked resource.
 // Remove the attribute Type from the element.
 func (e *LinkElement) TypeRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -631,10 +658,8 @@ func (e *LinkElement) TypeRemove() *LinkElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *LinkElement) Accesskey(r rune) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -644,7 +669,7 @@ func (e *LinkElement) Accesskey(r rune) *LinkElement {
 // can be generated by the keyboard).
 func (e *LinkElement) IfAccesskey(condition bool, r rune) *LinkElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -655,10 +680,8 @@ func (e *LinkElement) IfAccesskey(condition bool, r rune) *LinkElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *LinkElement) AccesskeyRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -674,10 +697,8 @@ func (e *LinkElement) AccesskeyRemove() *LinkElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *LinkElement) Autocapitalize(c LinkAutocapitalizeChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[LinkAutocapitalizeChoice])
 	return e
 }
 
@@ -711,10 +732,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *LinkElement) AutocapitalizeRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -733,10 +752,8 @@ func (e *LinkElement) AutocapitalizeRemove() *LinkElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LinkElement) Autofocus() *LinkElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -756,7 +773,7 @@ func (e *LinkElement) Autofocus() *LinkElement {
 // created by the preceding content.
 func (e *LinkElement) IfAutofocus(condition bool) *LinkElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -777,10 +794,8 @@ func (e *LinkElement) IfAutofocus(condition bool) *LinkElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LinkElement) AutofocusRemove() *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -800,10 +815,8 @@ func (e *LinkElement) AutofocusRemove() *LinkElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *LinkElement) AutofocusIfRemove() *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -812,16 +825,9 @@ func (e *LinkElement) AutofocusIfRemove() *LinkElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *LinkElement) Class(s string) *LinkElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -831,7 +837,7 @@ func (e *LinkElement) Class(s string) *LinkElement {
 // document.getElementsByClassName.
 func (e *LinkElement) IfClass(condition bool, s string) *LinkElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -842,14 +848,8 @@ func (e *LinkElement) IfClass(condition bool, s string) *LinkElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *LinkElement) ClassRemove(s ...string) *LinkElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -857,10 +857,8 @@ func (e *LinkElement) ClassRemove(s ...string) *LinkElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *LinkElement) Contenteditable(c LinkContenteditableChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[LinkContenteditableChoice])
 	return e
 }
 
@@ -883,10 +881,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *LinkElement) ContenteditableRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -904,10 +900,8 @@ func (e *LinkElement) ContenteditableRemove() *LinkElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *LinkElement) Dir(c LinkDirChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[LinkDirChoice])
 	return e
 }
 
@@ -941,10 +935,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *LinkElement) DirRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -952,10 +944,8 @@ func (e *LinkElement) DirRemove() *LinkElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *LinkElement) Draggable(c LinkDraggableChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[LinkDraggableChoice])
 	return e
 }
 
@@ -981,20 +971,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *LinkElement) DraggableRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *LinkElement) Enterkeyhint(c LinkEnterkeyhintChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[LinkEnterkeyhintChoice])
 	return e
 }
 
@@ -1024,10 +1010,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *LinkElement) EnterkeyhintRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1049,16 +1033,9 @@ func (e *LinkElement) EnterkeyhintRemove() *LinkElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *LinkElement) Exportparts(s string) *LinkElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1081,7 +1058,7 @@ func (e *LinkElement) Exportparts(s string) *LinkElement {
 // the current structure.
 func (e *LinkElement) IfExportparts(condition bool, s string) *LinkElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1105,14 +1082,8 @@ func (e *LinkElement) IfExportparts(condition bool, s string) *LinkElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *LinkElement) ExportpartsRemove(s ...string) *LinkElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1129,10 +1100,8 @@ func (e *LinkElement) ExportpartsRemove(s ...string) *LinkElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *LinkElement) Hidden(c LinkHiddenChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[LinkHiddenChoice])
 	return e
 }
 
@@ -1168,10 +1137,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *LinkElement) HiddenRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1179,10 +1146,8 @@ func (e *LinkElement) HiddenRemove() *LinkElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LinkElement) ID(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1198,7 +1163,7 @@ func (e *LinkElement) IDf(format string, args ...any) *LinkElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LinkElement) IfID(condition bool, s string) *LinkElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1208,7 +1173,7 @@ func (e *LinkElement) IfID(condition bool, s string) *LinkElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *LinkElement) IfIDf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1218,10 +1183,8 @@ func (e *LinkElement) IfIDf(condition bool, format string, args ...any) *LinkEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *LinkElement) IDRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1236,10 +1199,8 @@ func (e *LinkElement) IDRemove() *LinkElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LinkElement) Inert() *LinkElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1255,7 +1216,7 @@ func (e *LinkElement) Inert() *LinkElement {
 // excluding them from the accessibility tree.
 func (e *LinkElement) IfInert(condition bool) *LinkElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1272,10 +1233,8 @@ func (e *LinkElement) IfInert(condition bool) *LinkElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LinkElement) InertRemove() *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1291,10 +1250,8 @@ func (e *LinkElement) InertRemove() *LinkElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *LinkElement) InertIfRemove() *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1308,10 +1265,8 @@ func (e *LinkElement) InertIfRemove() *LinkElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *LinkElement) Inputmode(c LinkInputmodeChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[LinkInputmodeChoice])
 	return e
 }
 
@@ -1362,10 +1317,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *LinkElement) InputmodeRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1375,10 +1328,8 @@ func (e *LinkElement) InputmodeRemove() *LinkElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *LinkElement) Is(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1398,7 +1349,7 @@ func (e *LinkElement) Isf(format string, args ...any) *LinkElement {
 // and extends the element type it is being applied to.
 func (e *LinkElement) IfIs(condition bool, s string) *LinkElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1410,7 +1361,7 @@ func (e *LinkElement) IfIs(condition bool, s string) *LinkElement {
 // and extends the element type it is being applied to.
 func (e *LinkElement) IfIsf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1422,10 +1373,8 @@ func (e *LinkElement) IfIsf(condition bool, format string, args ...any) *LinkEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *LinkElement) IsRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1441,10 +1390,8 @@ func (e *LinkElement) IsRemove() *LinkElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *LinkElement) Itemid(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1476,7 +1423,7 @@ func (e *LinkElement) Itemidf(format string, args ...any) *LinkElement {
 // how items with the same identifier are handled.
 func (e *LinkElement) IfItemid(condition bool, s string) *LinkElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1494,7 +1441,7 @@ func (e *LinkElement) IfItemid(condition bool, s string) *LinkElement {
 // how items with the same identifier are handled.
 func (e *LinkElement) IfItemidf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1512,10 +1459,8 @@ func (e *LinkElement) IfItemidf(condition bool, format string, args ...any) *Lin
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *LinkElement) ItemidRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1527,10 +1472,8 @@ func (e *LinkElement) ItemidRemove() *LinkElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *LinkElement) Itemprop(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1554,7 +1497,7 @@ func (e *LinkElement) Itempropf(format string, args ...any) *LinkElement {
 // <track>, and <video>.
 func (e *LinkElement) IfItemprop(condition bool, s string) *LinkElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1568,7 +1511,7 @@ func (e *LinkElement) IfItemprop(condition bool, s string) *LinkElement {
 // <track>, and <video>.
 func (e *LinkElement) IfItempropf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1582,10 +1525,8 @@ func (e *LinkElement) IfItempropf(condition bool, format string, args ...any) *L
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *LinkElement) ItempropRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1595,10 +1536,8 @@ func (e *LinkElement) ItempropRemove() *LinkElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *LinkElement) Itemref(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1618,7 +1557,7 @@ func (e *LinkElement) Itemreff(format string, args ...any) *LinkElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LinkElement) IfItemref(condition bool, s string) *LinkElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1630,7 +1569,7 @@ func (e *LinkElement) IfItemref(condition bool, s string) *LinkElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *LinkElement) IfItemreff(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1642,10 +1581,8 @@ func (e *LinkElement) IfItemreff(condition bool, format string, args ...any) *Li
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *LinkElement) ItemrefRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1657,10 +1594,8 @@ func (e *LinkElement) ItemrefRemove() *LinkElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LinkElement) Itemscope() *LinkElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1673,7 +1608,7 @@ func (e *LinkElement) Itemscope() *LinkElement {
 // <object>, <source>, <track>, and <video>.
 func (e *LinkElement) IfItemscope(condition bool) *LinkElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1687,10 +1622,8 @@ func (e *LinkElement) IfItemscope(condition bool) *LinkElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LinkElement) ItemscopeRemove() *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1703,10 +1636,8 @@ func (e *LinkElement) ItemscopeRemove() *LinkElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *LinkElement) ItemscopeIfRemove() *LinkElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1718,10 +1649,8 @@ func (e *LinkElement) ItemscopeIfRemove() *LinkElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *LinkElement) Itemtype(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1745,7 +1674,7 @@ func (e *LinkElement) Itemtypef(format string, args ...any) *LinkElement {
 // <video>.
 func (e *LinkElement) IfItemtype(condition bool, s string) *LinkElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1759,7 +1688,7 @@ func (e *LinkElement) IfItemtype(condition bool, s string) *LinkElement {
 // <video>.
 func (e *LinkElement) IfItemtypef(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1773,10 +1702,8 @@ func (e *LinkElement) IfItemtypef(condition bool, format string, args ...any) *L
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *LinkElement) ItemtypeRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1786,10 +1713,8 @@ func (e *LinkElement) ItemtypeRemove() *LinkElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LinkElement) Lang(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1809,7 +1734,7 @@ func (e *LinkElement) Langf(format string, args ...any) *LinkElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LinkElement) IfLang(condition bool, s string) *LinkElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1821,7 +1746,7 @@ func (e *LinkElement) IfLang(condition bool, s string) *LinkElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *LinkElement) IfLangf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1833,10 +1758,8 @@ func (e *LinkElement) IfLangf(condition bool, format string, args ...any) *LinkE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *LinkElement) LangRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1846,10 +1769,8 @@ func (e *LinkElement) LangRemove() *LinkElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *LinkElement) Nonce(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1869,7 +1790,7 @@ func (e *LinkElement) Noncef(format string, args ...any) *LinkElement {
 // execute.
 func (e *LinkElement) IfNonce(condition bool, s string) *LinkElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1881,7 +1802,7 @@ func (e *LinkElement) IfNonce(condition bool, s string) *LinkElement {
 // execute.
 func (e *LinkElement) IfNoncef(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1893,10 +1814,8 @@ func (e *LinkElement) IfNoncef(condition bool, format string, args ...any) *Link
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *LinkElement) NonceRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1904,16 +1823,9 @@ func (e *LinkElement) NonceRemove() *LinkElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *LinkElement) Part(s string) *LinkElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1922,7 +1834,7 @@ func (e *LinkElement) Part(s string) *LinkElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *LinkElement) IfPart(condition bool, s string) *LinkElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1932,14 +1844,8 @@ func (e *LinkElement) IfPart(condition bool, s string) *LinkElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *LinkElement) PartRemove(s ...string) *LinkElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1950,10 +1856,8 @@ func (e *LinkElement) PartRemove(s ...string) *LinkElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *LinkElement) Popover(c LinkPopoverChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[LinkPopoverChoice])
 	return e
 }
 
@@ -1981,10 +1885,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *LinkElement) PopoverRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1993,10 +1895,8 @@ func (e *LinkElement) PopoverRemove() *LinkElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *LinkElement) Role(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -2014,7 +1914,7 @@ func (e *LinkElement) Rolef(format string, args ...any) *LinkElement {
 // role of an element.
 func (e *LinkElement) IfRole(condition bool, s string) *LinkElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -2025,7 +1925,7 @@ func (e *LinkElement) IfRole(condition bool, s string) *LinkElement {
 // role of an element.
 func (e *LinkElement) IfRolef(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2036,10 +1936,8 @@ func (e *LinkElement) IfRolef(condition bool, format string, args ...any) *LinkE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *LinkElement) RoleRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2048,10 +1946,8 @@ func (e *LinkElement) RoleRemove() *LinkElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *LinkElement) Slot(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2069,7 +1965,7 @@ func (e *LinkElement) Slotf(format string, args ...any) *LinkElement {
 // value.
 func (e *LinkElement) IfSlot(condition bool, s string) *LinkElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2080,7 +1976,7 @@ func (e *LinkElement) IfSlot(condition bool, s string) *LinkElement {
 // value.
 func (e *LinkElement) IfSlotf(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2091,10 +1987,8 @@ func (e *LinkElement) IfSlotf(condition bool, format string, args ...any) *LinkE
 // value.
 // Remove the attribute Slot from the element.
 func (e *LinkElement) SlotRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2110,10 +2004,8 @@ func (e *LinkElement) SlotRemove() *LinkElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *LinkElement) Spellcheck(c LinkSpellcheckChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[LinkSpellcheckChoice])
 	return e
 }
 
@@ -2141,10 +2033,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *LinkElement) SpellcheckRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2154,14 +2044,8 @@ func (e *LinkElement) StylePairs(pairs ...string) *LinkElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2176,14 +2060,8 @@ func (e *LinkElement) StylePairs(pairs ...string) *LinkElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LinkElement) Style(s string) *LinkElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2191,7 +2069,7 @@ func (e *LinkElement) Style(s string) *LinkElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2200,7 +2078,7 @@ func (e *LinkElement) Style(s string) *LinkElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *LinkElement) IfStyle(condition bool, s string) *LinkElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2208,15 +2086,7 @@ func (e *LinkElement) IfStyle(condition bool, s string) *LinkElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *LinkElement) StyleAdd(k string, v string) *LinkElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2230,7 +2100,7 @@ func (e *LinkElement) StyleAddf(k string, format string, args ...any) *LinkEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *LinkElement) IfStyleAdd(condition bool, k string, v string) *LinkElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2239,7 +2109,7 @@ func (e *LinkElement) IfStyleAdd(condition bool, k string, v string) *LinkElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *LinkElement) IfStyleAddf(condition bool, k string, format string, args ...any) *LinkElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2248,21 +2118,15 @@ func (e *LinkElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *LinkElement) StyleMap(m map[string]string) *LinkElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2271,14 +2135,8 @@ func (e *LinkElement) StyleMap(m map[string]string) *LinkElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *LinkElement) StyleRemove(keys ...string) *LinkElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2296,10 +2154,8 @@ func (e *LinkElement) StyleRemove(keys ...string) *LinkElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *LinkElement) Tabindex(i int) *LinkElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2318,7 +2174,7 @@ func (e *LinkElement) Tabindex(i int) *LinkElement {
 // their relative position in the document.
 func (e *LinkElement) IfTabindex(condition bool, i int) *LinkElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2338,10 +2194,8 @@ func (e *LinkElement) IfTabindex(condition bool, i int) *LinkElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *LinkElement) TabindexRemove() *LinkElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2362,10 +2216,8 @@ func (e *LinkElement) TabindexRemove() *LinkElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *LinkElement) Title(s string) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2407,7 +2259,7 @@ func (e *LinkElement) Titlef(format string, args ...any) *LinkElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LinkElement) IfTitle(condition bool, s string) *LinkElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2430,7 +2282,7 @@ func (e *LinkElement) IfTitle(condition bool, s string) *LinkElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *LinkElement) IfTitlef(condition bool, format string, args ...any) *LinkElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2453,10 +2305,8 @@ func (e *LinkElement) IfTitlef(condition bool, format string, args ...any) *Link
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *LinkElement) TitleRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2465,10 +2315,8 @@ func (e *LinkElement) TitleRemove() *LinkElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *LinkElement) Translate(c LinkTranslateChoice) *LinkElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[LinkTranslateChoice])
 	return e
 }
 
@@ -2489,9 +2337,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *LinkElement) TranslateRemove() *LinkElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <map> element is used with <area> elements to define an image map (a
@@ -22,18 +20,77 @@ type MapElement struct {
 // with the tag "map" during rendering.
 func Map(children ...ElementRenderer) *MapElement {
 	e := NewElement("map", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MapElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MapElement) Clone() *MapElement {
+	return &MapElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MapElement) CloneShallow() *MapElement {
+	return &MapElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MapElement) Freeze() *MapElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MapElement) writable() *MapElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MapElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MapElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[MapAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[MapContenteditableChoice]
+	case "dir":
+		return choiceValue[MapDirChoice]
+	case "draggable":
+		return choiceValue[MapDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[MapEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[MapHiddenChoice]
+	case "inputmode":
+		return choiceValue[MapInputmodeChoice]
+	case "popover":
+		return choiceValue[MapPopoverChoice]
+	case "spellcheck":
+		return choiceValue[MapSpellcheckChoice]
+	case "translate":
+		return choiceValue[MapTranslateChoice]
+	}
+	return nil
+}
+
 func (e *MapElement) Children(children ...ElementRenderer) *MapElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MapElement) IfChildren(condition bool, children ...ElementRenderer) *MapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *MapElement) IfChildren(condition bool, children ...ElementRenderer) *Ma
 
 func (e *MapElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MapElement) BoolAttr(name string) *MapElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MapElement) BoolAttrRemove(name string) *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MapElement) IfBoolAttr(condition bool, name string) *MapElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *MapElement) BoolAttrf(format string, args ...any) *MapElement {
 
 func (e *MapElement) IfBoolAttrf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MapElement) BoolAttrs(names ...string) *MapElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MapElement) IfBoolAttrs(condition bool, names ...string) *MapElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MapElement) Attr(name, value string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MapElement) RawAttr(name, value string) *MapElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MapElement) IfAttr(condition bool, name, value string) *MapElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *MapElement) Attrf(name, format string, args ...any) *MapElement {
 
 func (e *MapElement) IfAttrf(condition bool, name, format string, args ...any) *MapElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MapElement) Attrs(attrs ...string) *MapElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MapElement) IfAttrs(condition bool, attrs ...string) *MapElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MapElement) AttrsMap(attrs map[string]string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MapElement) IfAttrsMap(condition bool, attrs map[string]string) *MapElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MapElement) Text(text string) *MapElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MapElement) Textf(format string, args ...any) *MapElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MapElement) IfText(condition bool, text string) *MapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *MapElement) IfText(condition bool, text string) *MapElement {
 
 func (e *MapElement) IfTextf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MapElement) Raw(text string) *MapElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MapElement) Escaped(text string) *MapElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MapElement) IfEscaped(condition bool, text string) *MapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *MapElement) Escapedf(format string, args ...any) *MapElement {
 
 func (e *MapElement) IfEscapedf(condition bool, format string, args ...any) *MapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +270,8 @@ func (e *MapElement) IfEscapedf(condition bool, format string, args ...any) *Map
 
 // Name of the element to use as a target.
 func (e *MapElement) Name(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -224,7 +283,7 @@ func (e *MapElement) Namef(format string, args ...any) *MapElement {
 // Name of the element to use as a target.
 func (e *MapElement) IfName(condition bool, s string) *MapElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -232,7 +291,7 @@ func (e *MapElement) IfName(condition bool, s string) *MapElement {
 // Name of the element to use as a target.
 func (e *MapElement) IfNamef(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,10 +299,8 @@ func (e *MapElement) IfNamef(condition bool, format string, args ...any) *MapEle
 // Name of the element to use as a target.
 // Remove the attribute Name from the element.
 func (e *MapElement) NameRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -252,10 +309,8 @@ func (e *MapElement) NameRemove() *MapElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *MapElement) Accesskey(r rune) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -265,7 +320,7 @@ func (e *MapElement) Accesskey(r rune) *MapElement {
 // can be generated by the keyboard).
 func (e *MapElement) IfAccesskey(condition bool, r rune) *MapElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -276,10 +331,8 @@ func (e *MapElement) IfAccesskey(condition bool, r rune) *MapElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *MapElement) AccesskeyRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -295,10 +348,8 @@ func (e *MapElement) AccesskeyRemove() *MapElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *MapElement) Autocapitalize(c MapAutocapitalizeChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[MapAutocapitalizeChoice])
 	return e
 }
 
@@ -332,10 +383,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *MapElement) AutocapitalizeRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -354,10 +403,8 @@ func (e *MapElement) AutocapitalizeRemove() *MapElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MapElement) Autofocus() *MapElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -377,7 +424,7 @@ func (e *MapElement) Autofocus() *MapElement {
 // created by the preceding content.
 func (e *MapElement) IfAutofocus(condition bool) *MapElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -398,10 +445,8 @@ func (e *MapElement) IfAutofocus(condition bool) *MapElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MapElement) AutofocusRemove() *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -421,10 +466,8 @@ func (e *MapElement) AutofocusRemove() *MapElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MapElement) AutofocusIfRemove() *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -433,16 +476,9 @@ func (e *MapElement) AutofocusIfRemove() *MapElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *MapElement) Class(s string) *MapElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -452,7 +488,7 @@ func (e *MapElement) Class(s string) *MapElement {
 // document.getElementsByClassName.
 func (e *MapElement) IfClass(condition bool, s string) *MapElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -463,14 +499,8 @@ func (e *MapElement) IfClass(condition bool, s string) *MapElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *MapElement) ClassRemove(s ...string) *MapElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -478,10 +508,8 @@ func (e *MapElement) ClassRemove(s ...string) *MapElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *MapElement) Contenteditable(c MapContenteditableChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[MapContenteditableChoice])
 	return e
 }
 
@@ -504,10 +532,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *MapElement) ContenteditableRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -525,10 +551,8 @@ func (e *MapElement) ContenteditableRemove() *MapElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *MapElement) Dir(c MapDirChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MapDirChoice])
 	return e
 }
 
@@ -562,10 +586,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *MapElement) DirRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -573,10 +595,8 @@ func (e *MapElement) DirRemove() *MapElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *MapElement) Draggable(c MapDraggableChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[MapDraggableChoice])
 	return e
 }
 
@@ -602,20 +622,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *MapElement) DraggableRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *MapElement) Enterkeyhint(c MapEnterkeyhintChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[MapEnterkeyhintChoice])
 	return e
 }
 
@@ -645,10 +661,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *MapElement) EnterkeyhintRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -670,16 +684,9 @@ func (e *MapElement) EnterkeyhintRemove() *MapElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *MapElement) Exportparts(s string) *MapElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -702,7 +709,7 @@ func (e *MapElement) Exportparts(s string) *MapElement {
 // the current structure.
 func (e *MapElement) IfExportparts(condition bool, s string) *MapElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -726,14 +733,8 @@ func (e *MapElement) IfExportparts(condition bool, s string) *MapElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *MapElement) ExportpartsRemove(s ...string) *MapElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -750,10 +751,8 @@ func (e *MapElement) ExportpartsRemove(s ...string) *MapElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *MapElement) Hidden(c MapHiddenChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[MapHiddenChoice])
 	return e
 }
 
@@ -789,10 +788,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *MapElement) HiddenRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -800,10 +797,8 @@ func (e *MapElement) HiddenRemove() *MapElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MapElement) ID(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -819,7 +814,7 @@ func (e *MapElement) IDf(format string, args ...any) *MapElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MapElement) IfID(condition bool, s string) *MapElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -829,7 +824,7 @@ func (e *MapElement) IfID(condition bool, s string) *MapElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MapElement) IfIDf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -839,10 +834,8 @@ func (e *MapElement) IfIDf(condition bool, format string, args ...any) *MapEleme
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *MapElement) IDRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -857,10 +850,8 @@ func (e *MapElement) IDRemove() *MapElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MapElement) Inert() *MapElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -876,7 +867,7 @@ func (e *MapElement) Inert() *MapElement {
 // excluding them from the accessibility tree.
 func (e *MapElement) IfInert(condition bool) *MapElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -893,10 +884,8 @@ func (e *MapElement) IfInert(condition bool) *MapElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MapElement) InertRemove() *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -912,10 +901,8 @@ func (e *MapElement) InertRemove() *MapElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MapElement) InertIfRemove() *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -929,10 +916,8 @@ func (e *MapElement) InertIfRemove() *MapElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *MapElement) Inputmode(c MapInputmodeChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[MapInputmodeChoice])
 	return e
 }
 
@@ -983,10 +968,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *MapElement) InputmodeRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -996,10 +979,8 @@ func (e *MapElement) InputmodeRemove() *MapElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *MapElement) Is(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1019,7 +1000,7 @@ func (e *MapElement) Isf(format string, args ...any) *MapElement {
 // and extends the element type it is being applied to.
 func (e *MapElement) IfIs(condition bool, s string) *MapElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1031,7 +1012,7 @@ func (e *MapElement) IfIs(condition bool, s string) *MapElement {
 // and extends the element type it is being applied to.
 func (e *MapElement) IfIsf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1043,10 +1024,8 @@ func (e *MapElement) IfIsf(condition bool, format string, args ...any) *MapEleme
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *MapElement) IsRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1062,10 +1041,8 @@ func (e *MapElement) IsRemove() *MapElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *MapElement) Itemid(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1097,7 +1074,7 @@ func (e *MapElement) Itemidf(format string, args ...any) *MapElement {
 // how items with the same identifier are handled.
 func (e *MapElement) IfItemid(condition bool, s string) *MapElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1115,7 +1092,7 @@ func (e *MapElement) IfItemid(condition bool, s string) *MapElement {
 // how items with the same identifier are handled.
 func (e *MapElement) IfItemidf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1133,10 +1110,8 @@ func (e *MapElement) IfItemidf(condition bool, format string, args ...any) *MapE
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *MapElement) ItemidRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1148,10 +1123,8 @@ func (e *MapElement) ItemidRemove() *MapElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *MapElement) Itemprop(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1175,7 +1148,7 @@ func (e *MapElement) Itempropf(format string, args ...any) *MapElement {
 // <track>, and <video>.
 func (e *MapElement) IfItemprop(condition bool, s string) *MapElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1189,7 +1162,7 @@ func (e *MapElement) IfItemprop(condition bool, s string) *MapElement {
 // <track>, and <video>.
 func (e *MapElement) IfItempropf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1203,10 +1176,8 @@ func (e *MapElement) IfItempropf(condition bool, format string, args ...any) *Ma
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *MapElement) ItempropRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1216,10 +1187,8 @@ func (e *MapElement) ItempropRemove() *MapElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *MapElement) Itemref(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1239,7 +1208,7 @@ func (e *MapElement) Itemreff(format string, args ...any) *MapElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MapElement) IfItemref(condition bool, s string) *MapElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1251,7 +1220,7 @@ func (e *MapElement) IfItemref(condition bool, s string) *MapElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MapElement) IfItemreff(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1263,10 +1232,8 @@ func (e *MapElement) IfItemreff(condition bool, format string, args ...any) *Map
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *MapElement) ItemrefRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1278,10 +1245,8 @@ func (e *MapElement) ItemrefRemove() *MapElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MapElement) Itemscope() *MapElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1294,7 +1259,7 @@ func (e *MapElement) Itemscope() *MapElement {
 // <object>, <source>, <track>, and <video>.
 func (e *MapElement) IfItemscope(condition bool) *MapElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1308,10 +1273,8 @@ func (e *MapElement) IfItemscope(condition bool) *MapElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MapElement) ItemscopeRemove() *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1324,10 +1287,8 @@ func (e *MapElement) ItemscopeRemove() *MapElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MapElement) ItemscopeIfRemove() *MapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1339,10 +1300,8 @@ func (e *MapElement) ItemscopeIfRemove() *MapElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *MapElement) Itemtype(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1366,7 +1325,7 @@ func (e *MapElement) Itemtypef(format string, args ...any) *MapElement {
 // <video>.
 func (e *MapElement) IfItemtype(condition bool, s string) *MapElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1380,7 +1339,7 @@ func (e *MapElement) IfItemtype(condition bool, s string) *MapElement {
 // <video>.
 func (e *MapElement) IfItemtypef(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1394,10 +1353,8 @@ func (e *MapElement) IfItemtypef(condition bool, format string, args ...any) *Ma
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *MapElement) ItemtypeRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1407,10 +1364,8 @@ func (e *MapElement) ItemtypeRemove() *MapElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MapElement) Lang(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1430,7 +1385,7 @@ func (e *MapElement) Langf(format string, args ...any) *MapElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MapElement) IfLang(condition bool, s string) *MapElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1442,7 +1397,7 @@ func (e *MapElement) IfLang(condition bool, s string) *MapElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MapElement) IfLangf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1454,10 +1409,8 @@ func (e *MapElement) IfLangf(condition bool, format string, args ...any) *MapEle
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *MapElement) LangRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1467,10 +1420,8 @@ func (e *MapElement) LangRemove() *MapElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *MapElement) Nonce(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1490,7 +1441,7 @@ func (e *MapElement) Noncef(format string, args ...any) *MapElement {
 // execute.
 func (e *MapElement) IfNonce(condition bool, s string) *MapElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1502,7 +1453,7 @@ func (e *MapElement) IfNonce(condition bool, s string) *MapElement {
 // execute.
 func (e *MapElement) IfNoncef(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1514,10 +1465,8 @@ func (e *MapElement) IfNoncef(condition bool, format string, args ...any) *MapEl
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *MapElement) NonceRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1525,16 +1474,9 @@ func (e *MapElement) NonceRemove() *MapElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *MapElement) Part(s string) *MapElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1543,7 +1485,7 @@ func (e *MapElement) Part(s string) *MapElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *MapElement) IfPart(condition bool, s string) *MapElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1553,14 +1495,8 @@ func (e *MapElement) IfPart(condition bool, s string) *MapElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *MapElement) PartRemove(s ...string) *MapElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1571,10 +1507,8 @@ func (e *MapElement) PartRemove(s ...string) *MapElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *MapElement) Popover(c MapPopoverChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[MapPopoverChoice])
 	return e
 }
 
@@ -1602,10 +1536,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *MapElement) PopoverRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1614,10 +1546,8 @@ func (e *MapElement) PopoverRemove() *MapElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *MapElement) Role(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1635,7 +1565,7 @@ func (e *MapElement) Rolef(format string, args ...any) *MapElement {
 // role of an element.
 func (e *MapElement) IfRole(condition bool, s string) *MapElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1646,7 +1576,7 @@ func (e *MapElement) IfRole(condition bool, s string) *MapElement {
 // role of an element.
 func (e *MapElement) IfRolef(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1657,10 +1587,8 @@ func (e *MapElement) IfRolef(condition bool, format string, args ...any) *MapEle
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *MapElement) RoleRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1669,10 +1597,8 @@ func (e *MapElement) RoleRemove() *MapElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *MapElement) Slot(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1690,7 +1616,7 @@ func (e *MapElement) Slotf(format string, args ...any) *MapElement {
 // value.
 func (e *MapElement) IfSlot(condition bool, s string) *MapElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1701,7 +1627,7 @@ func (e *MapElement) IfSlot(condition bool, s string) *MapElement {
 // value.
 func (e *MapElement) IfSlotf(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1712,10 +1638,8 @@ func (e *MapElement) IfSlotf(condition bool, format string, args ...any) *MapEle
 // value.
 // Remove the attribute Slot from the element.
 func (e *MapElement) SlotRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1731,10 +1655,8 @@ func (e *MapElement) SlotRemove() *MapElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *MapElement) Spellcheck(c MapSpellcheckChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[MapSpellcheckChoice])
 	return e
 }
 
@@ -1762,10 +1684,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *MapElement) SpellcheckRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1775,14 +1695,8 @@ func (e *MapElement) StylePairs(pairs ...string) *MapElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1797,14 +1711,8 @@ func (e *MapElement) StylePairs(pairs ...string) *MapElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MapElement) Style(s string) *MapElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1812,7 +1720,7 @@ func (e *MapElement) Style(s string) *MapElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1821,7 +1729,7 @@ func (e *MapElement) Style(s string) *MapElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *MapElement) IfStyle(condition bool, s string) *MapElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1829,15 +1737,7 @@ func (e *MapElement) IfStyle(condition bool, s string) *MapElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MapElement) StyleAdd(k string, v string) *MapElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1851,7 +1751,7 @@ func (e *MapElement) StyleAddf(k string, format string, args ...any) *MapElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *MapElement) IfStyleAdd(condition bool, k string, v string) *MapElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1860,7 +1760,7 @@ func (e *MapElement) IfStyleAdd(condition bool, k string, v string) *MapElement
 // color, font, size, and more. Styles are written in CSS.
 func (e *MapElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MapElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1869,21 +1769,15 @@ func (e *MapElement) IfStyleAddf(condition bool, k string, format string, args .
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *MapElement) StyleMap(m map[string]string) *MapElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1892,14 +1786,8 @@ func (e *MapElement) StyleMap(m map[string]string) *MapElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *MapElement) StyleRemove(keys ...string) *MapElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1917,10 +1805,8 @@ func (e *MapElement) StyleRemove(keys ...string) *MapElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *MapElement) Tabindex(i int) *MapElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1939,7 +1825,7 @@ func (e *MapElement) Tabindex(i int) *MapElement {
 // their relative position in the document.
 func (e *MapElement) IfTabindex(condition bool, i int) *MapElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1959,10 +1845,8 @@ func (e *MapElement) IfTabindex(condition bool, i int) *MapElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *MapElement) TabindexRemove() *MapElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1983,10 +1867,8 @@ func (e *MapElement) TabindexRemove() *MapElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *MapElement) Title(s string) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2028,7 +1910,7 @@ func (e *MapElement) Titlef(format string, args ...any) *MapElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MapElement) IfTitle(condition bool, s string) *MapElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2051,7 +1933,7 @@ func (e *MapElement) IfTitle(condition bool, s string) *MapElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MapElement) IfTitlef(condition bool, format string, args ...any) *MapElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2074,10 +1956,8 @@ func (e *MapElement) IfTitlef(condition bool, format string, args ...any) *MapEl
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *MapElement) TitleRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2086,10 +1966,8 @@ func (e *MapElement) TitleRemove() *MapElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *MapElement) Translate(c MapTranslateChoice) *MapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[MapTranslateChoice])
 	return e
 }
 
@@ -2110,9 +1988,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *MapElement) TranslateRemove() *MapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <menu> element represents a group of commands that a user can
@@ -24,18 +22,79 @@ type MenuElement struct {
 // with the tag "menu" during rendering.
 func Menu(children ...ElementRenderer) *MenuElement {
 	e := NewElement("menu", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MenuElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MenuElement) Clone() *MenuElement {
+	return &MenuElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MenuElement) CloneShallow() *MenuElement {
+	return &MenuElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MenuElement) Freeze() *MenuElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MenuElement) writable() *MenuElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MenuElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MenuElement) choiceType(name string) func(string) any {
+	switch name {
+	case "type":
+		return choiceValue[MenuTypeChoice]
+	case "autocapitalize":
+		return choiceValue[MenuAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[MenuContenteditableChoice]
+	case "dir":
+		return choiceValue[MenuDirChoice]
+	case "draggable":
+		return choiceValue[MenuDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[MenuEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[MenuHiddenChoice]
+	case "inputmode":
+		return choiceValue[MenuInputmodeChoice]
+	case "popover":
+		return choiceValue[MenuPopoverChoice]
+	case "spellcheck":
+		return choiceValue[MenuSpellcheckChoice]
+	case "translate":
+		return choiceValue[MenuTranslateChoice]
+	}
+	return nil
+}
+
 func (e *MenuElement) Children(children ...ElementRenderer) *MenuElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MenuElement) IfChildren(condition bool, children ...ElementRenderer) *MenuElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +102,30 @@ func (e *MenuElement) IfChildren(condition bool, children ...ElementRenderer) *M
 
 func (e *MenuElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MenuElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MenuElement) BoolAttr(name string) *MenuElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MenuElement) BoolAttrRemove(name string) *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MenuElement) IfBoolAttr(condition bool, name string) *MenuElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +136,40 @@ func (e *MenuElement) BoolAttrf(format string, args ...any) *MenuElement {
 
 func (e *MenuElement) IfBoolAttrf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MenuElement) BoolAttrs(names ...string) *MenuElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MenuElement) IfBoolAttrs(condition bool, names ...string) *MenuElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MenuElement) Attr(name, value string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MenuElement) RawAttr(name, value string) *MenuElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MenuElement) IfAttr(condition bool, name, value string) *MenuElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +180,52 @@ func (e *MenuElement) Attrf(name, format string, args ...any) *MenuElement {
 
 func (e *MenuElement) IfAttrf(condition bool, name, format string, args ...any) *MenuElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MenuElement) Attrs(attrs ...string) *MenuElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MenuElement) IfAttrs(condition bool, attrs ...string) *MenuElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MenuElement) AttrsMap(attrs map[string]string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MenuElement) IfAttrsMap(condition bool, attrs map[string]string) *MenuElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MenuElement) Text(text string) *MenuElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MenuElement) Textf(format string, args ...any) *MenuElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MenuElement) IfText(condition bool, text string) *MenuElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +233,28 @@ func (e *MenuElement) IfText(condition bool, text string) *MenuElement {
 
 func (e *MenuElement) IfTextf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MenuElement) Raw(text string) *MenuElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MenuElement) Escaped(text string) *MenuElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MenuElement) IfEscaped(condition bool, text string) *MenuElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +266,7 @@ func (e *MenuElement) Escapedf(format string, args ...any) *MenuElement {
 
 func (e *MenuElement) IfEscapedf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +274,8 @@ func (e *MenuElement) IfEscapedf(condition bool, format string, args ...any) *Me
 
 // Indicates the kind of menu.
 func (e *MenuElement) Type(c MenuTypeChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[MenuTypeChoice])
 	return e
 }
 
@@ -232,10 +293,8 @@ const (
 // Indicates the kind of menu.
 // Remove the attribute Type from the element.
 func (e *MenuElement) TypeRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -244,10 +303,8 @@ func (e *MenuElement) TypeRemove() *MenuElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *MenuElement) Accesskey(r rune) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -257,7 +314,7 @@ func (e *MenuElement) Accesskey(r rune) *MenuElement {
 // can be generated by the keyboard).
 func (e *MenuElement) IfAccesskey(condition bool, r rune) *MenuElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -268,10 +325,8 @@ func (e *MenuElement) IfAccesskey(condition bool, r rune) *MenuElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *MenuElement) AccesskeyRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -287,10 +342,8 @@ func (e *MenuElement) AccesskeyRemove() *MenuElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *MenuElement) Autocapitalize(c MenuAutocapitalizeChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[MenuAutocapitalizeChoice])
 	return e
 }
 
@@ -324,10 +377,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *MenuElement) AutocapitalizeRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -346,10 +397,8 @@ func (e *MenuElement) AutocapitalizeRemove() *MenuElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MenuElement) Autofocus() *MenuElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -369,7 +418,7 @@ func (e *MenuElement) Autofocus() *MenuElement {
 // created by the preceding content.
 func (e *MenuElement) IfAutofocus(condition bool) *MenuElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -390,10 +439,8 @@ func (e *MenuElement) IfAutofocus(condition bool) *MenuElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MenuElement) AutofocusRemove() *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -413,10 +460,8 @@ func (e *MenuElement) AutofocusRemove() *MenuElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MenuElement) AutofocusIfRemove() *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -425,16 +470,9 @@ func (e *MenuElement) AutofocusIfRemove() *MenuElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *MenuElement) Class(s string) *MenuElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -444,7 +482,7 @@ func (e *MenuElement) Class(s string) *MenuElement {
 // document.getElementsByClassName.
 func (e *MenuElement) IfClass(condition bool, s string) *MenuElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -455,14 +493,8 @@ func (e *MenuElement) IfClass(condition bool, s string) *MenuElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *MenuElement) ClassRemove(s ...string) *MenuElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -470,10 +502,8 @@ func (e *MenuElement) ClassRemove(s ...string) *MenuElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *MenuElement) Contenteditable(c MenuContenteditableChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[MenuContenteditableChoice])
 	return e
 }
 
@@ -496,10 +526,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *MenuElement) ContenteditableRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -517,10 +545,8 @@ func (e *MenuElement) ContenteditableRemove() *MenuElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *MenuElement) Dir(c MenuDirChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MenuDirChoice])
 	return e
 }
 
@@ -554,10 +580,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *MenuElement) DirRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -565,10 +589,8 @@ func (e *MenuElement) DirRemove() *MenuElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *MenuElement) Draggable(c MenuDraggableChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[MenuDraggableChoice])
 	return e
 }
 
@@ -594,20 +616,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *MenuElement) DraggableRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *MenuElement) Enterkeyhint(c MenuEnterkeyhintChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[MenuEnterkeyhintChoice])
 	return e
 }
 
@@ -637,10 +655,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *MenuElement) EnterkeyhintRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -662,16 +678,9 @@ func (e *MenuElement) EnterkeyhintRemove() *MenuElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *MenuElement) Exportparts(s string) *MenuElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -694,7 +703,7 @@ func (e *MenuElement) Exportparts(s string) *MenuElement {
 // the current structure.
 func (e *MenuElement) IfExportparts(condition bool, s string) *MenuElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -718,14 +727,8 @@ func (e *MenuElement) IfExportparts(condition bool, s string) *MenuElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *MenuElement) ExportpartsRemove(s ...string) *MenuElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -742,10 +745,8 @@ func (e *MenuElement) ExportpartsRemove(s ...string) *MenuElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *MenuElement) Hidden(c MenuHiddenChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[MenuHiddenChoice])
 	return e
 }
 
@@ -781,10 +782,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *MenuElement) HiddenRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -792,10 +791,8 @@ func (e *MenuElement) HiddenRemove() *MenuElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MenuElement) ID(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -811,7 +808,7 @@ func (e *MenuElement) IDf(format string, args ...any) *MenuElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MenuElement) IfID(condition bool, s string) *MenuElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -821,7 +818,7 @@ func (e *MenuElement) IfID(condition bool, s string) *MenuElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MenuElement) IfIDf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -831,10 +828,8 @@ func (e *MenuElement) IfIDf(condition bool, format string, args ...any) *MenuEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *MenuElement) IDRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -849,10 +844,8 @@ func (e *MenuElement) IDRemove() *MenuElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MenuElement) Inert() *MenuElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -868,7 +861,7 @@ func (e *MenuElement) Inert() *MenuElement {
 // excluding them from the accessibility tree.
 func (e *MenuElement) IfInert(condition bool) *MenuElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -885,10 +878,8 @@ func (e *MenuElement) IfInert(condition bool) *MenuElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MenuElement) InertRemove() *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -904,10 +895,8 @@ func (e *MenuElement) InertRemove() *MenuElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MenuElement) InertIfRemove() *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -921,10 +910,8 @@ func (e *MenuElement) InertIfRemove() *MenuElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *MenuElement) Inputmode(c MenuInputmodeChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[MenuInputmodeChoice])
 	return e
 }
 
@@ -975,10 +962,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *MenuElement) InputmodeRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -988,10 +973,8 @@ func (e *MenuElement) InputmodeRemove() *MenuElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *MenuElement) Is(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1011,7 +994,7 @@ func (e *MenuElement) Isf(format string, args ...any) *MenuElement {
 // and extends the element type it is being applied to.
 func (e *MenuElement) IfIs(condition bool, s string) *MenuElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1023,7 +1006,7 @@ func (e *MenuElement) IfIs(condition bool, s string) *MenuElement {
 // and extends the element type it is being applied to.
 func (e *MenuElement) IfIsf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1035,10 +1018,8 @@ func (e *MenuElement) IfIsf(condition bool, format string, args ...any) *MenuEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *MenuElement) IsRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1054,10 +1035,8 @@ func (e *MenuElement) IsRemove() *MenuElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *MenuElement) Itemid(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1089,7 +1068,7 @@ func (e *MenuElement) Itemidf(format string, args ...any) *MenuElement {
 // how items with the same identifier are handled.
 func (e *MenuElement) IfItemid(condition bool, s string) *MenuElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1107,7 +1086,7 @@ func (e *MenuElement) IfItemid(condition bool, s string) *MenuElement {
 // how items with the same identifier are handled.
 func (e *MenuElement) IfItemidf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1125,10 +1104,8 @@ func (e *MenuElement) IfItemidf(condition bool, format string, args ...any) *Men
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *MenuElement) ItemidRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1140,10 +1117,8 @@ func (e *MenuElement) ItemidRemove() *MenuElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *MenuElement) Itemprop(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1167,7 +1142,7 @@ func (e *MenuElement) Itempropf(format string, args ...any) *MenuElement {
 // <track>, and <video>.
 func (e *MenuElement) IfItemprop(condition bool, s string) *MenuElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1181,7 +1156,7 @@ func (e *MenuElement) IfItemprop(condition bool, s string) *MenuElement {
 // <track>, and <video>.
 func (e *MenuElement) IfItempropf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1195,10 +1170,8 @@ func (e *MenuElement) IfItempropf(condition bool, format string, args ...any) *M
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *MenuElement) ItempropRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1208,10 +1181,8 @@ func (e *MenuElement) ItempropRemove() *MenuElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *MenuElement) Itemref(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1231,7 +1202,7 @@ func (e *MenuElement) Itemreff(format string, args ...any) *MenuElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MenuElement) IfItemref(condition bool, s string) *MenuElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1243,7 +1214,7 @@ func (e *MenuElement) IfItemref(condition bool, s string) *MenuElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MenuElement) IfItemreff(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1255,10 +1226,8 @@ func (e *MenuElement) IfItemreff(condition bool, format string, args ...any) *Me
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *MenuElement) ItemrefRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1270,10 +1239,8 @@ func (e *MenuElement) ItemrefRemove() *MenuElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MenuElement) Itemscope() *MenuElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1286,7 +1253,7 @@ func (e *MenuElement) Itemscope() *MenuElement {
 // <object>, <source>, <track>, and <video>.
 func (e *MenuElement) IfItemscope(condition bool) *MenuElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1300,10 +1267,8 @@ func (e *MenuElement) IfItemscope(condition bool) *MenuElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MenuElement) ItemscopeRemove() *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1316,10 +1281,8 @@ func (e *MenuElement) ItemscopeRemove() *MenuElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MenuElement) ItemscopeIfRemove() *MenuElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1331,10 +1294,8 @@ func (e *MenuElement) ItemscopeIfRemove() *MenuElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *MenuElement) Itemtype(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1358,7 +1319,7 @@ func (e *MenuElement) Itemtypef(format string, args ...any) *MenuElement {
 // <video>.
 func (e *MenuElement) IfItemtype(condition bool, s string) *MenuElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1372,7 +1333,7 @@ func (e *MenuElement) IfItemtype(condition bool, s string) *MenuElement {
 // <video>.
 func (e *MenuElement) IfItemtypef(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1386,10 +1347,8 @@ func (e *MenuElement) IfItemtypef(condition bool, format string, args ...any) *M
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *MenuElement) ItemtypeRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1399,10 +1358,8 @@ func (e *MenuElement) ItemtypeRemove() *MenuElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MenuElement) Lang(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1422,7 +1379,7 @@ func (e *MenuElement) Langf(format string, args ...any) *MenuElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MenuElement) IfLang(condition bool, s string) *MenuElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1434,7 +1391,7 @@ func (e *MenuElement) IfLang(condition bool, s string) *MenuElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MenuElement) IfLangf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1446,10 +1403,8 @@ func (e *MenuElement) IfLangf(condition bool, format string, args ...any) *MenuE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *MenuElement) LangRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1459,10 +1414,8 @@ func (e *MenuElement) LangRemove() *MenuElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *MenuElement) Nonce(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1482,7 +1435,7 @@ func (e *MenuElement) Noncef(format string, args ...any) *MenuElement {
 // execute.
 func (e *MenuElement) IfNonce(condition bool, s string) *MenuElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1494,7 +1447,7 @@ func (e *MenuElement) IfNonce(condition bool, s string) *MenuElement {
 // execute.
 func (e *MenuElement) IfNoncef(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1506,10 +1459,8 @@ func (e *MenuElement) IfNoncef(condition bool, format string, args ...any) *Menu
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *MenuElement) NonceRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1517,16 +1468,9 @@ func (e *MenuElement) NonceRemove() *MenuElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *MenuElement) Part(s string) *MenuElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1535,7 +1479,7 @@ func (e *MenuElement) Part(s string) *MenuElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *MenuElement) IfPart(condition bool, s string) *MenuElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1545,14 +1489,8 @@ func (e *MenuElement) IfPart(condition bool, s string) *MenuElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *MenuElement) PartRemove(s ...string) *MenuElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1563,10 +1501,8 @@ func (e *MenuElement) PartRemove(s ...string) *MenuElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *MenuElement) Popover(c MenuPopoverChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[MenuPopoverChoice])
 	return e
 }
 
@@ -1594,10 +1530,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *MenuElement) PopoverRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1606,10 +1540,8 @@ func (e *MenuElement) PopoverRemove() *MenuElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *MenuElement) Role(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1627,7 +1559,7 @@ func (e *MenuElement) Rolef(format string, args ...any) *MenuElement {
 // role of an element.
 func (e *MenuElement) IfRole(condition bool, s string) *MenuElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1638,7 +1570,7 @@ func (e *MenuElement) IfRole(condition bool, s string) *MenuElement {
 // role of an element.
 func (e *MenuElement) IfRolef(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1649,10 +1581,8 @@ func (e *MenuElement) IfRolef(condition bool, format string, args ...any) *MenuE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *MenuElement) RoleRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1661,10 +1591,8 @@ func (e *MenuElement) RoleRemove() *MenuElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *MenuElement) Slot(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1682,7 +1610,7 @@ func (e *MenuElement) Slotf(format string, args ...any) *MenuElement {
 // value.
 func (e *MenuElement) IfSlot(condition bool, s string) *MenuElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1693,7 +1621,7 @@ func (e *MenuElement) IfSlot(condition bool, s string) *MenuElement {
 // value.
 func (e *MenuElement) IfSlotf(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1704,10 +1632,8 @@ func (e *MenuElement) IfSlotf(condition bool, format string, args ...any) *MenuE
 // value.
 // Remove the attribute Slot from the element.
 func (e *MenuElement) SlotRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1723,10 +1649,8 @@ func (e *MenuElement) SlotRemove() *MenuElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *MenuElement) Spellcheck(c MenuSpellcheckChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[MenuSpellcheckChoice])
 	return e
 }
 
@@ -1754,10 +1678,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *MenuElement) SpellcheckRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1767,14 +1689,8 @@ func (e *MenuElement) StylePairs(pairs ...string) *MenuElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1789,14 +1705,8 @@ func (e *MenuElement) StylePairs(pairs ...string) *MenuElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MenuElement) Style(s string) *MenuElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1804,7 +1714,7 @@ func (e *MenuElement) Style(s string) *MenuElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1813,7 +1723,7 @@ func (e *MenuElement) Style(s string) *MenuElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *MenuElement) IfStyle(condition bool, s string) *MenuElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1821,15 +1731,7 @@ func (e *MenuElement) IfStyle(condition bool, s string) *MenuElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MenuElement) StyleAdd(k string, v string) *MenuElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1843,7 +1745,7 @@ func (e *MenuElement) StyleAddf(k string, format string, args ...any) *MenuEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *MenuElement) IfStyleAdd(condition bool, k string, v string) *MenuElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1852,7 +1754,7 @@ func (e *MenuElement) IfStyleAdd(condition bool, k string, v string) *MenuElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *MenuElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MenuElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1861,21 +1763,15 @@ func (e *MenuElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *MenuElement) StyleMap(m map[string]string) *MenuElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1884,14 +1780,8 @@ func (e *MenuElement) StyleMap(m map[string]string) *MenuElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *MenuElement) StyleRemove(keys ...string) *MenuElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1909,10 +1799,8 @@ func (e *MenuElement) StyleRemove(keys ...string) *MenuElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *MenuElement) Tabindex(i int) *MenuElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1931,7 +1819,7 @@ func (e *MenuElement) Tabindex(i int) *MenuElement {
 // their relative position in the document.
 func (e *MenuElement) IfTabindex(condition bool, i int) *MenuElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1951,10 +1839,8 @@ func (e *MenuElement) IfTabindex(condition bool, i int) *MenuElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *MenuElement) TabindexRemove() *MenuElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1975,10 +1861,8 @@ func (e *MenuElement) TabindexRemove() *MenuElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *MenuElement) Title(s string) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2020,7 +1904,7 @@ func (e *MenuElement) Titlef(format string, args ...any) *MenuElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MenuElement) IfTitle(condition bool, s string) *MenuElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2043,7 +1927,7 @@ func (e *MenuElement) IfTitle(condition bool, s string) *MenuElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MenuElement) IfTitlef(condition bool, format string, args ...any) *MenuElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2066,10 +1950,8 @@ func (e *MenuElement) IfTitlef(condition bool, format string, args ...any) *Menu
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *MenuElement) TitleRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2078,10 +1960,8 @@ func (e *MenuElement) TitleRemove() *MenuElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *MenuElement) Translate(c MenuTranslateChoice) *MenuElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[MenuTranslateChoice])
 	return e
 }
 
@@ -2102,9 +1982,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *MenuElement) TranslateRemove() *MenuElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
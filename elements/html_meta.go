@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <meta> element represents metadata that cannot be represented by
@@ -23,18 +21,77 @@ type MetaElement struct {
 // with the tag "meta" during rendering.
 func Meta() *MetaElement {
 	e := NewElement("meta")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &MetaElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MetaElement) Clone() *MetaElement {
+	return &MetaElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MetaElement) CloneShallow() *MetaElement {
+	return &MetaElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MetaElement) Freeze() *MetaElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MetaElement) writable() *MetaElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MetaElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MetaElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[MetaAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[MetaContenteditableChoice]
+	case "dir":
+		return choiceValue[MetaDirChoice]
+	case "draggable":
+		return choiceValue[MetaDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[MetaEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[MetaHiddenChoice]
+	case "inputmode":
+		return choiceValue[MetaInputmodeChoice]
+	case "popover":
+		return choiceValue[MetaPopoverChoice]
+	case "spellcheck":
+		return choiceValue[MetaSpellcheckChoice]
+	case "translate":
+		return choiceValue[MetaTranslateChoice]
+	}
+	return nil
+}
+
 func (e *MetaElement) Children(children ...ElementRenderer) *MetaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MetaElement) IfChildren(condition bool, children ...ElementRenderer) *MetaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *MetaElement) IfChildren(condition bool, children ...ElementRenderer) *M
 
 func (e *MetaElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MetaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MetaElement) BoolAttr(name string) *MetaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MetaElement) BoolAttrRemove(name string) *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MetaElement) IfBoolAttr(condition bool, name string) *MetaElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *MetaElement) BoolAttrf(format string, args ...any) *MetaElement {
 
 func (e *MetaElement) IfBoolAttrf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MetaElement) BoolAttrs(names ...string) *MetaElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MetaElement) IfBoolAttrs(condition bool, names ...string) *MetaElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MetaElement) Attr(name, value string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MetaElement) RawAttr(name, value string) *MetaElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MetaElement) IfAttr(condition bool, name, value string) *MetaElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *MetaElement) Attrf(name, format string, args ...any) *MetaElement {
 
 func (e *MetaElement) IfAttrf(condition bool, name, format string, args ...any) *MetaElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MetaElement) Attrs(attrs ...string) *MetaElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MetaElement) IfAttrs(condition bool, attrs ...string) *MetaElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MetaElement) AttrsMap(attrs map[string]string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MetaElement) IfAttrsMap(condition bool, attrs map[string]string) *MetaElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MetaElement) Text(text string) *MetaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MetaElement) Textf(format string, args ...any) *MetaElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MetaElement) IfText(condition bool, text string) *MetaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *MetaElement) IfText(condition bool, text string) *MetaElement {
 
 func (e *MetaElement) IfTextf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MetaElement) Raw(text string) *MetaElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MetaElement) Escaped(text string) *MetaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MetaElement) IfEscaped(condition bool, text string) *MetaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *MetaElement) Escapedf(format string, args ...any) *MetaElement {
 
 func (e *MetaElement) IfEscapedf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +271,8 @@ func (e *MetaElement) IfEscapedf(condition bool, format string, args ...any) *Me
 
 // This attribute declares the document's character encoding.
 func (e *MetaElement) Charset(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("charset", s)
+	e = e.writable()
+	e.setStringAttribute("charset", s)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *MetaElement) Charsetf(format string, args ...any) *MetaElement {
 // This attribute declares the document's character encoding.
 func (e *MetaElement) IfCharset(condition bool, s string) *MetaElement {
 	if condition {
-		e.Charset(s)
+		e = e.Charset(s)
 	}
 	return e
 }
@@ -233,7 +292,7 @@ func (e *MetaElement) IfCharset(condition bool, s string) *MetaElement {
 // This attribute declares the document's character encoding.
 func (e *MetaElement) IfCharsetf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Charset(fmt.Sprintf(format, args...))
+		e = e.Charset(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,20 +300,16 @@ func (e *MetaElement) IfCharsetf(condition bool, format string, args ...any) *Me
 // This attribute declares the document's character encoding.
 // Remove the attribute Charset from the element.
 func (e *MetaElement) CharsetRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("charset")
+	e = e.writable()
+	e.removeAttribute("charset")
 	return e
 }
 
 // This attribute contains the value for the http-equiv or name attribute,
 // depending on which is used.
 func (e *MetaElement) Content(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("content", s)
+	e = e.writable()
+	e.setStringAttribute("content", s)
 	return e
 }
 
@@ -268,7 +323,7 @@ func (e *MetaElement) Contentf(format string, args ...any) *MetaElement {
 // depending on which is used.
 func (e *MetaElement) IfContent(condition bool, s string) *MetaElement {
 	if condition {
-		e.Content(s)
+		e = e.Content(s)
 	}
 	return e
 }
@@ -277,7 +332,7 @@ func (e *MetaElement) IfContent(condition bool, s string) *MetaElement {
 // depending on which is used.
 func (e *MetaElement) IfContentf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Content(fmt.Sprintf(format, args...))
+		e = e.Content(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -286,20 +341,16 @@ func (e *MetaElement) IfContentf(condition bool, format string, args ...any) *Me
 // depending on which is used.
 // Remove the attribute Content from the element.
 func (e *MetaElement) ContentRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("content")
+	e = e.writable()
+	e.removeAttribute("content")
 	return e
 }
 
 // Defines a pragma directive, which are instructions for the browser for
 // processing the document.
 func (e *MetaElement) HTTPEquiv(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("http-equiv", s)
+	e = e.writable()
+	e.setStringAttribute("http-equiv", s)
 	return e
 }
 
@@ -313,7 +364,7 @@ func (e *MetaElement) HTTPEquivf(format string, args ...any) *MetaElement {
 // processing the document.
 func (e *MetaElement) IfHTTPEquiv(condition bool, s string) *MetaElement {
 	if condition {
-		e.HTTPEquiv(s)
+		e = e.HTTPEquiv(s)
 	}
 	return e
 }
@@ -322,7 +373,7 @@ func (e *MetaElement) IfHTTPEquiv(condition bool, s string) *MetaElement {
 // processing the document.
 func (e *MetaElement) IfHTTPEquivf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.HTTPEquiv(fmt.Sprintf(format, args...))
+		e = e.HTTPEquiv(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -331,20 +382,16 @@ func (e *MetaElement) IfHTTPEquivf(condition bool, format string, args ...any) *
 // processing the document.
 // Remove the attribute HTTPEquiv from the element.
 func (e *MetaElement) HTTPEquivRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("http-equiv")
+	e = e.writable()
+	e.removeAttribute("http-equiv")
 	return e
 }
 
 // The media attribute defines which media the theme color defined in the
 // content attribute should be applied to.
 func (e *MetaElement) Media(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("media", s)
+	e = e.writable()
+	e.setStringAttribute("media", s)
 	return e
 }
 
@@ -358,7 +405,7 @@ func (e *MetaElement) Mediaf(format string, args ...any) *MetaElement {
 // content attribute should be applied to.
 func (e *MetaElement) IfMedia(condition bool, s string) *MetaElement {
 	if condition {
-		e.Media(s)
+		e = e.Media(s)
 	}
 	return e
 }
@@ -367,7 +414,7 @@ func (e *MetaElement) IfMedia(condition bool, s string) *MetaElement {
 // content attribute should be applied to.
 func (e *MetaElement) IfMediaf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Media(fmt.Sprintf(format, args...))
+		e = e.Media(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -376,10 +423,8 @@ func (e *MetaElement) IfMediaf(condition bool, format string, args ...any) *Meta
 // content attribute should be applied to.
 // Remove the attribute Media from the element.
 func (e *MetaElement) MediaRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("media")
+	e = e.writable()
+	e.removeAttribute("media")
 	return e
 }
 
@@ -387,10 +432,8 @@ func (e *MetaElement) MediaRemove() *MetaElement {
 // metadata in terms of name-value pairs, with the name attribute giving the
 // metadata name, and the content attribute giving the value.
 func (e *MetaElement) Name(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -406,7 +449,7 @@ func (e *MetaElement) Namef(format string, args ...any) *MetaElement {
 // metadata name, and the content attribute giving the value.
 func (e *MetaElement) IfName(condition bool, s string) *MetaElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -416,7 +459,7 @@ func (e *MetaElement) IfName(condition bool, s string) *MetaElement {
 // metadata name, and the content attribute giving the value.
 func (e *MetaElement) IfNamef(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -426,10 +469,8 @@ func (e *MetaElement) IfNamef(condition bool, format string, args ...any) *MetaE
 // metadata name, and the content attribute giving the value.
 // Remove the attribute Name from the element.
 func (e *MetaElement) NameRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -438,10 +479,8 @@ func (e *MetaElement) NameRemove() *MetaElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *MetaElement) Accesskey(r rune) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -451,7 +490,7 @@ func (e *MetaElement) Accesskey(r rune) *MetaElement {
 // can be generated by the keyboard).
 func (e *MetaElement) IfAccesskey(condition bool, r rune) *MetaElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -462,10 +501,8 @@ func (e *MetaElement) IfAccesskey(condition bool, r rune) *MetaElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *MetaElement) AccesskeyRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -481,10 +518,8 @@ func (e *MetaElement) AccesskeyRemove() *MetaElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *MetaElement) Autocapitalize(c MetaAutocapitalizeChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[MetaAutocapitalizeChoice])
 	return e
 }
 
@@ -518,10 +553,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *MetaElement) AutocapitalizeRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -540,10 +573,8 @@ func (e *MetaElement) AutocapitalizeRemove() *MetaElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MetaElement) Autofocus() *MetaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -563,7 +594,7 @@ func (e *MetaElement) Autofocus() *MetaElement {
 // created by the preceding content.
 func (e *MetaElement) IfAutofocus(condition bool) *MetaElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -584,10 +615,8 @@ func (e *MetaElement) IfAutofocus(condition bool) *MetaElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MetaElement) AutofocusRemove() *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -607,10 +636,8 @@ func (e *MetaElement) AutofocusRemove() *MetaElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MetaElement) AutofocusIfRemove() *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -619,16 +646,9 @@ func (e *MetaElement) AutofocusIfRemove() *MetaElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *MetaElement) Class(s string) *MetaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -638,7 +658,7 @@ func (e *MetaElement) Class(s string) *MetaElement {
 // document.getElementsByClassName.
 func (e *MetaElement) IfClass(condition bool, s string) *MetaElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -649,14 +669,8 @@ func (e *MetaElement) IfClass(condition bool, s string) *MetaElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *MetaElement) ClassRemove(s ...string) *MetaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -664,10 +678,8 @@ func (e *MetaElement) ClassRemove(s ...string) *MetaElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *MetaElement) Contenteditable(c MetaContenteditableChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[MetaContenteditableChoice])
 	return e
 }
 
@@ -690,10 +702,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *MetaElement) ContenteditableRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -711,10 +721,8 @@ func (e *MetaElement) ContenteditableRemove() *MetaElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *MetaElement) Dir(c MetaDirChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MetaDirChoice])
 	return e
 }
 
@@ -748,10 +756,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *MetaElement) DirRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -759,10 +765,8 @@ func (e *MetaElement) DirRemove() *MetaElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *MetaElement) Draggable(c MetaDraggableChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[MetaDraggableChoice])
 	return e
 }
 
@@ -788,20 +792,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *MetaElement) DraggableRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *MetaElement) Enterkeyhint(c MetaEnterkeyhintChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[MetaEnterkeyhintChoice])
 	return e
 }
 
@@ -831,10 +831,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *MetaElement) EnterkeyhintRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -856,16 +854,9 @@ func (e *MetaElement) EnterkeyhintRemove() *MetaElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *MetaElement) Exportparts(s string) *MetaElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -888,7 +879,7 @@ func (e *MetaElement) Exportparts(s string) *MetaElement {
 // the current structure.
 func (e *MetaElement) IfExportparts(condition bool, s string) *MetaElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -912,14 +903,8 @@ func (e *MetaElement) IfExportparts(condition bool, s string) *MetaElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *MetaElement) ExportpartsRemove(s ...string) *MetaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -936,10 +921,8 @@ func (e *MetaElement) ExportpartsRemove(s ...string) *MetaElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *MetaElement) Hidden(c MetaHiddenChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[MetaHiddenChoice])
 	return e
 }
 
@@ -975,10 +958,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *MetaElement) HiddenRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -986,10 +967,8 @@ func (e *MetaElement) HiddenRemove() *MetaElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MetaElement) ID(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1005,7 +984,7 @@ func (e *MetaElement) IDf(format string, args ...any) *MetaElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MetaElement) IfID(condition bool, s string) *MetaElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1015,7 +994,7 @@ func (e *MetaElement) IfID(condition bool, s string) *MetaElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MetaElement) IfIDf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1025,10 +1004,8 @@ func (e *MetaElement) IfIDf(condition bool, format string, args ...any) *MetaEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *MetaElement) IDRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1043,10 +1020,8 @@ func (e *MetaElement) IDRemove() *MetaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MetaElement) Inert() *MetaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1062,7 +1037,7 @@ func (e *MetaElement) Inert() *MetaElement {
 // excluding them from the accessibility tree.
 func (e *MetaElement) IfInert(condition bool) *MetaElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1079,10 +1054,8 @@ func (e *MetaElement) IfInert(condition bool) *MetaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MetaElement) InertRemove() *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1098,10 +1071,8 @@ func (e *MetaElement) InertRemove() *MetaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MetaElement) InertIfRemove() *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1115,10 +1086,8 @@ func (e *MetaElement) InertIfRemove() *MetaElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *MetaElement) Inputmode(c MetaInputmodeChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[MetaInputmodeChoice])
 	return e
 }
 
@@ -1169,10 +1138,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *MetaElement) InputmodeRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1182,10 +1149,8 @@ func (e *MetaElement) InputmodeRemove() *MetaElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *MetaElement) Is(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1205,7 +1170,7 @@ func (e *MetaElement) Isf(format string, args ...any) *MetaElement {
 // and extends the element type it is being applied to.
 func (e *MetaElement) IfIs(condition bool, s string) *MetaElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1217,7 +1182,7 @@ func (e *MetaElement) IfIs(condition bool, s string) *MetaElement {
 // and extends the element type it is being applied to.
 func (e *MetaElement) IfIsf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1229,10 +1194,8 @@ func (e *MetaElement) IfIsf(condition bool, format string, args ...any) *MetaEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *MetaElement) IsRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1248,10 +1211,8 @@ func (e *MetaElement) IsRemove() *MetaElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *MetaElement) Itemid(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1283,7 +1244,7 @@ func (e *MetaElement) Itemidf(format string, args ...any) *MetaElement {
 // how items with the same identifier are handled.
 func (e *MetaElement) IfItemid(condition bool, s string) *MetaElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1301,7 +1262,7 @@ func (e *MetaElement) IfItemid(condition bool, s string) *MetaElement {
 // how items with the same identifier are handled.
 func (e *MetaElement) IfItemidf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1319,10 +1280,8 @@ func (e *MetaElement) IfItemidf(condition bool, format string, args ...any) *Met
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *MetaElement) ItemidRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1334,10 +1293,8 @@ func (e *MetaElement) ItemidRemove() *MetaElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *MetaElement) Itemprop(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1361,7 +1318,7 @@ func (e *MetaElement) Itempropf(format string, args ...any) *MetaElement {
 // <track>, and <video>.
 func (e *MetaElement) IfItemprop(condition bool, s string) *MetaElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1375,7 +1332,7 @@ func (e *MetaElement) IfItemprop(condition bool, s string) *MetaElement {
 // <track>, and <video>.
 func (e *MetaElement) IfItempropf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1389,10 +1346,8 @@ func (e *MetaElement) IfItempropf(condition bool, format string, args ...any) *M
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *MetaElement) ItempropRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1402,10 +1357,8 @@ func (e *MetaElement) ItempropRemove() *MetaElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *MetaElement) Itemref(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1425,7 +1378,7 @@ func (e *MetaElement) Itemreff(format string, args ...any) *MetaElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MetaElement) IfItemref(condition bool, s string) *MetaElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1437,7 +1390,7 @@ func (e *MetaElement) IfItemref(condition bool, s string) *MetaElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MetaElement) IfItemreff(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1449,10 +1402,8 @@ func (e *MetaElement) IfItemreff(condition bool, format string, args ...any) *Me
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *MetaElement) ItemrefRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1464,10 +1415,8 @@ func (e *MetaElement) ItemrefRemove() *MetaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MetaElement) Itemscope() *MetaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1480,7 +1429,7 @@ func (e *MetaElement) Itemscope() *MetaElement {
 // <object>, <source>, <track>, and <video>.
 func (e *MetaElement) IfItemscope(condition bool) *MetaElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1494,10 +1443,8 @@ func (e *MetaElement) IfItemscope(condition bool) *MetaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MetaElement) ItemscopeRemove() *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1510,10 +1457,8 @@ func (e *MetaElement) ItemscopeRemove() *MetaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MetaElement) ItemscopeIfRemove() *MetaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1525,10 +1470,8 @@ func (e *MetaElement) ItemscopeIfRemove() *MetaElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *MetaElement) Itemtype(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1552,7 +1495,7 @@ func (e *MetaElement) Itemtypef(format string, args ...any) *MetaElement {
 // <video>.
 func (e *MetaElement) IfItemtype(condition bool, s string) *MetaElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1566,7 +1509,7 @@ func (e *MetaElement) IfItemtype(condition bool, s string) *MetaElement {
 // <video>.
 func (e *MetaElement) IfItemtypef(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1580,10 +1523,8 @@ func (e *MetaElement) IfItemtypef(condition bool, format string, args ...any) *M
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *MetaElement) ItemtypeRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1593,10 +1534,8 @@ func (e *MetaElement) ItemtypeRemove() *MetaElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MetaElement) Lang(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1616,7 +1555,7 @@ func (e *MetaElement) Langf(format string, args ...any) *MetaElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MetaElement) IfLang(condition bool, s string) *MetaElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1628,7 +1567,7 @@ func (e *MetaElement) IfLang(condition bool, s string) *MetaElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MetaElement) IfLangf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1640,10 +1579,8 @@ func (e *MetaElement) IfLangf(condition bool, format string, args ...any) *MetaE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *MetaElement) LangRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1653,10 +1590,8 @@ func (e *MetaElement) LangRemove() *MetaElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *MetaElement) Nonce(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1676,7 +1611,7 @@ func (e *MetaElement) Noncef(format string, args ...any) *MetaElement {
 // execute.
 func (e *MetaElement) IfNonce(condition bool, s string) *MetaElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1688,7 +1623,7 @@ func (e *MetaElement) IfNonce(condition bool, s string) *MetaElement {
 // execute.
 func (e *MetaElement) IfNoncef(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1700,10 +1635,8 @@ func (e *MetaElement) IfNoncef(condition bool, format string, args ...any) *Meta
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *MetaElement) NonceRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1711,16 +1644,9 @@ func (e *MetaElement) NonceRemove() *MetaElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *MetaElement) Part(s string) *MetaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1729,7 +1655,7 @@ func (e *MetaElement) Part(s string) *MetaElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *MetaElement) IfPart(condition bool, s string) *MetaElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1739,14 +1665,8 @@ func (e *MetaElement) IfPart(condition bool, s string) *MetaElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *MetaElement) PartRemove(s ...string) *MetaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1757,10 +1677,8 @@ func (e *MetaElement) PartRemove(s ...string) *MetaElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *MetaElement) Popover(c MetaPopoverChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[MetaPopoverChoice])
 	return e
 }
 
@@ -1788,10 +1706,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *MetaElement) PopoverRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1800,10 +1716,8 @@ func (e *MetaElement) PopoverRemove() *MetaElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *MetaElement) Role(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1821,7 +1735,7 @@ func (e *MetaElement) Rolef(format string, args ...any) *MetaElement {
 // role of an element.
 func (e *MetaElement) IfRole(condition bool, s string) *MetaElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1832,7 +1746,7 @@ func (e *MetaElement) IfRole(condition bool, s string) *MetaElement {
 // role of an element.
 func (e *MetaElement) IfRolef(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1843,10 +1757,8 @@ func (e *MetaElement) IfRolef(condition bool, format string, args ...any) *MetaE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *MetaElement) RoleRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1855,10 +1767,8 @@ func (e *MetaElement) RoleRemove() *MetaElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *MetaElement) Slot(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1876,7 +1786,7 @@ func (e *MetaElement) Slotf(format string, args ...any) *MetaElement {
 // value.
 func (e *MetaElement) IfSlot(condition bool, s string) *MetaElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1887,7 +1797,7 @@ func (e *MetaElement) IfSlot(condition bool, s string) *MetaElement {
 // value.
 func (e *MetaElement) IfSlotf(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1898,10 +1808,8 @@ func (e *MetaElement) IfSlotf(condition bool, format string, args ...any) *MetaE
 // value.
 // Remove the attribute Slot from the element.
 func (e *MetaElement) SlotRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1917,10 +1825,8 @@ func (e *MetaElement) SlotRemove() *MetaElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *MetaElement) Spellcheck(c MetaSpellcheckChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[MetaSpellcheckChoice])
 	return e
 }
 
@@ -1948,10 +1854,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *MetaElement) SpellcheckRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1961,14 +1865,8 @@ func (e *MetaElement) StylePairs(pairs ...string) *MetaElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1983,14 +1881,8 @@ func (e *MetaElement) StylePairs(pairs ...string) *MetaElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MetaElement) Style(s string) *MetaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1998,7 +1890,7 @@ func (e *MetaElement) Style(s string) *MetaElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2007,7 +1899,7 @@ func (e *MetaElement) Style(s string) *MetaElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *MetaElement) IfStyle(condition bool, s string) *MetaElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2015,15 +1907,7 @@ func (e *MetaElement) IfStyle(condition bool, s string) *MetaElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MetaElement) StyleAdd(k string, v string) *MetaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2037,7 +1921,7 @@ func (e *MetaElement) StyleAddf(k string, format string, args ...any) *MetaEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *MetaElement) IfStyleAdd(condition bool, k string, v string) *MetaElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2046,7 +1930,7 @@ func (e *MetaElement) IfStyleAdd(condition bool, k string, v string) *MetaElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *MetaElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MetaElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2055,21 +1939,15 @@ func (e *MetaElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *MetaElement) StyleMap(m map[string]string) *MetaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2078,14 +1956,8 @@ func (e *MetaElement) StyleMap(m map[string]string) *MetaElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *MetaElement) StyleRemove(keys ...string) *MetaElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2103,10 +1975,8 @@ func (e *MetaElement) StyleRemove(keys ...string) *MetaElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *MetaElement) Tabindex(i int) *MetaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2125,7 +1995,7 @@ func (e *MetaElement) Tabindex(i int) *MetaElement {
 // their relative position in the document.
 func (e *MetaElement) IfTabindex(condition bool, i int) *MetaElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2145,10 +2015,8 @@ func (e *MetaElement) IfTabindex(condition bool, i int) *MetaElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *MetaElement) TabindexRemove() *MetaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2169,10 +2037,8 @@ func (e *MetaElement) TabindexRemove() *MetaElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *MetaElement) Title(s string) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2214,7 +2080,7 @@ func (e *MetaElement) Titlef(format string, args ...any) *MetaElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MetaElement) IfTitle(condition bool, s string) *MetaElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2237,7 +2103,7 @@ func (e *MetaElement) IfTitle(condition bool, s string) *MetaElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MetaElement) IfTitlef(condition bool, format string, args ...any) *MetaElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2260,10 +2126,8 @@ func (e *MetaElement) IfTitlef(condition bool, format string, args ...any) *Meta
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *MetaElement) TitleRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2272,10 +2136,8 @@ func (e *MetaElement) TitleRemove() *MetaElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *MetaElement) Translate(c MetaTranslateChoice) *MetaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[MetaTranslateChoice])
 	return e
 }
 
@@ -2296,9 +2158,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *MetaElement) TranslateRemove() *MetaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <meter> element represents either a scalar value within a known
@@ -22,18 +20,77 @@ type MeterElement struct {
 // with the tag "meter" during rendering.
 func Meter(children ...ElementRenderer) *MeterElement {
 	e := NewElement("meter", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MeterElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MeterElement) Clone() *MeterElement {
+	return &MeterElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MeterElement) CloneShallow() *MeterElement {
+	return &MeterElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MeterElement) Freeze() *MeterElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MeterElement) writable() *MeterElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MeterElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MeterElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[MeterAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[MeterContenteditableChoice]
+	case "dir":
+		return choiceValue[MeterDirChoice]
+	case "draggable":
+		return choiceValue[MeterDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[MeterEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[MeterHiddenChoice]
+	case "inputmode":
+		return choiceValue[MeterInputmodeChoice]
+	case "popover":
+		return choiceValue[MeterPopoverChoice]
+	case "spellcheck":
+		return choiceValue[MeterSpellcheckChoice]
+	case "translate":
+		return choiceValue[MeterTranslateChoice]
+	}
+	return nil
+}
+
 func (e *MeterElement) Children(children ...ElementRenderer) *MeterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MeterElement) IfChildren(condition bool, children ...ElementRenderer) *MeterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *MeterElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *MeterElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MeterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MeterElement) BoolAttr(name string) *MeterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MeterElement) BoolAttrRemove(name string) *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MeterElement) IfBoolAttr(condition bool, name string) *MeterElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *MeterElement) BoolAttrf(format string, args ...any) *MeterElement {
 
 func (e *MeterElement) IfBoolAttrf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MeterElement) BoolAttrs(names ...string) *MeterElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MeterElement) IfBoolAttrs(condition bool, names ...string) *MeterElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MeterElement) Attr(name, value string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MeterElement) RawAttr(name, value string) *MeterElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MeterElement) IfAttr(condition bool, name, value string) *MeterElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *MeterElement) Attrf(name, format string, args ...any) *MeterElement {
 
 func (e *MeterElement) IfAttrf(condition bool, name, format string, args ...any) *MeterElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MeterElement) Attrs(attrs ...string) *MeterElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MeterElement) IfAttrs(condition bool, attrs ...string) *MeterElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MeterElement) AttrsMap(attrs map[string]string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MeterElement) IfAttrsMap(condition bool, attrs map[string]string) *MeterElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MeterElement) Text(text string) *MeterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MeterElement) Textf(format string, args ...any) *MeterElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MeterElement) IfText(condition bool, text string) *MeterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *MeterElement) IfText(condition bool, text string) *MeterElement {
 
 func (e *MeterElement) IfTextf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MeterElement) Raw(text string) *MeterElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MeterElement) Escaped(text string) *MeterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MeterElement) IfEscaped(condition bool, text string) *MeterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *MeterElement) Escapedf(format string, args ...any) *MeterElement {
 
 func (e *MeterElement) IfEscapedf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,102 +270,90 @@ func (e *MeterElement) IfEscapedf(condition bool, format string, args ...any) *M
 
 // Indicates the range's upper bound.
 func (e *MeterElement) High(f float64) *MeterElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("high", f)
+	e = e.writable()
+	e.setFloatAttribute("high", f, 0)
 	return e
 }
 
 // Indicates the range's upper bound.
 func (e *MeterElement) IfHigh(condition bool, f float64) *MeterElement {
 	if condition {
-		e.High(f)
+		e = e.High(f)
 	}
 	return e
 }
 
 // Indicates the range's lower bound.
 func (e *MeterElement) Low(f float64) *MeterElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("low", f)
+	e = e.writable()
+	e.setFloatAttribute("low", f, 0)
 	return e
 }
 
 // Indicates the range's lower bound.
 func (e *MeterElement) IfLow(condition bool, f float64) *MeterElement {
 	if condition {
-		e.Low(f)
+		e = e.Low(f)
 	}
 	return e
 }
 
 // Indicates the maximum value allowed.
 func (e *MeterElement) Max(f float64) *MeterElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("max", f)
+	e = e.writable()
+	e.setFloatAttribute("max", f, 0)
 	return e
 }
 
 // Indicates the maximum value allowed.
 func (e *MeterElement) IfMax(condition bool, f float64) *MeterElement {
 	if condition {
-		e.Max(f)
+		e = e.Max(f)
 	}
 	return e
 }
 
 // Indicates the minimum value allowed.
 func (e *MeterElement) Min(f float64) *MeterElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("min", f)
+	e = e.writable()
+	e.setFloatAttribute("min", f, 0)
 	return e
 }
 
 // Indicates the minimum value allowed.
 func (e *MeterElement) IfMin(condition bool, f float64) *MeterElement {
 	if condition {
-		e.Min(f)
+		e = e.Min(f)
 	}
 	return e
 }
 
 // Indicates the optimal numeric value.
 func (e *MeterElement) Optimum(f float64) *MeterElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("optimum", f)
+	e = e.writable()
+	e.setFloatAttribute("optimum", f, 0)
 	return e
 }
 
 // Indicates the optimal numeric value.
 func (e *MeterElement) IfOptimum(condition bool, f float64) *MeterElement {
 	if condition {
-		e.Optimum(f)
+		e = e.Optimum(f)
 	}
 	return e
 }
 
 // Current numeric value.
 func (e *MeterElement) Value(f float64) *MeterElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("value", f)
+	e = e.writable()
+	e.setFloatAttribute("value", f, 0)
 	return e
 }
 
 // Current numeric value.
 func (e *MeterElement) IfValue(condition bool, f float64) *MeterElement {
 	if condition {
-		e.Value(f)
+		e = e.Value(f)
 	}
 	return e
 }
@@ -314,10 +363,8 @@ func (e *MeterElement) IfValue(condition bool, f float64) *MeterElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *MeterElement) Accesskey(r rune) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -327,7 +374,7 @@ func (e *MeterElement) Accesskey(r rune) *MeterElement {
 // can be generated by the keyboard).
 func (e *MeterElement) IfAccesskey(condition bool, r rune) *MeterElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -338,10 +385,8 @@ func (e *MeterElement) IfAccesskey(condition bool, r rune) *MeterElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *MeterElement) AccesskeyRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -357,10 +402,8 @@ func (e *MeterElement) AccesskeyRemove() *MeterElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *MeterElement) Autocapitalize(c MeterAutocapitalizeChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[MeterAutocapitalizeChoice])
 	return e
 }
 
@@ -394,10 +437,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *MeterElement) AutocapitalizeRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -416,10 +457,8 @@ func (e *MeterElement) AutocapitalizeRemove() *MeterElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MeterElement) Autofocus() *MeterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -439,7 +478,7 @@ func (e *MeterElement) Autofocus() *MeterElement {
 // created by the preceding content.
 func (e *MeterElement) IfAutofocus(condition bool) *MeterElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -460,10 +499,8 @@ func (e *MeterElement) IfAutofocus(condition bool) *MeterElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MeterElement) AutofocusRemove() *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -483,10 +520,8 @@ func (e *MeterElement) AutofocusRemove() *MeterElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *MeterElement) AutofocusIfRemove() *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -495,16 +530,9 @@ func (e *MeterElement) AutofocusIfRemove() *MeterElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *MeterElement) Class(s string) *MeterElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -514,7 +542,7 @@ func (e *MeterElement) Class(s string) *MeterElement {
 // document.getElementsByClassName.
 func (e *MeterElement) IfClass(condition bool, s string) *MeterElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -525,14 +553,8 @@ func (e *MeterElement) IfClass(condition bool, s string) *MeterElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *MeterElement) ClassRemove(s ...string) *MeterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -540,10 +562,8 @@ func (e *MeterElement) ClassRemove(s ...string) *MeterElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *MeterElement) Contenteditable(c MeterContenteditableChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[MeterContenteditableChoice])
 	return e
 }
 
@@ -566,10 +586,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *MeterElement) ContenteditableRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -587,10 +605,8 @@ func (e *MeterElement) ContenteditableRemove() *MeterElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *MeterElement) Dir(c MeterDirChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MeterDirChoice])
 	return e
 }
 
@@ -624,10 +640,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *MeterElement) DirRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -635,10 +649,8 @@ func (e *MeterElement) DirRemove() *MeterElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *MeterElement) Draggable(c MeterDraggableChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[MeterDraggableChoice])
 	return e
 }
 
@@ -664,20 +676,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *MeterElement) DraggableRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *MeterElement) Enterkeyhint(c MeterEnterkeyhintChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[MeterEnterkeyhintChoice])
 	return e
 }
 
@@ -707,10 +715,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *MeterElement) EnterkeyhintRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -732,16 +738,9 @@ func (e *MeterElement) EnterkeyhintRemove() *MeterElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *MeterElement) Exportparts(s string) *MeterElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -764,7 +763,7 @@ func (e *MeterElement) Exportparts(s string) *MeterElement {
 // the current structure.
 func (e *MeterElement) IfExportparts(condition bool, s string) *MeterElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -788,14 +787,8 @@ func (e *MeterElement) IfExportparts(condition bool, s string) *MeterElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *MeterElement) ExportpartsRemove(s ...string) *MeterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -812,10 +805,8 @@ func (e *MeterElement) ExportpartsRemove(s ...string) *MeterElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *MeterElement) Hidden(c MeterHiddenChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[MeterHiddenChoice])
 	return e
 }
 
@@ -851,10 +842,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *MeterElement) HiddenRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -862,10 +851,8 @@ func (e *MeterElement) HiddenRemove() *MeterElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MeterElement) ID(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -881,7 +868,7 @@ func (e *MeterElement) IDf(format string, args ...any) *MeterElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MeterElement) IfID(condition bool, s string) *MeterElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -891,7 +878,7 @@ func (e *MeterElement) IfID(condition bool, s string) *MeterElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *MeterElement) IfIDf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -901,10 +888,8 @@ func (e *MeterElement) IfIDf(condition bool, format string, args ...any) *MeterE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *MeterElement) IDRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -919,10 +904,8 @@ func (e *MeterElement) IDRemove() *MeterElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MeterElement) Inert() *MeterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -938,7 +921,7 @@ func (e *MeterElement) Inert() *MeterElement {
 // excluding them from the accessibility tree.
 func (e *MeterElement) IfInert(condition bool) *MeterElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -955,10 +938,8 @@ func (e *MeterElement) IfInert(condition bool) *MeterElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MeterElement) InertRemove() *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -974,10 +955,8 @@ func (e *MeterElement) InertRemove() *MeterElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *MeterElement) InertIfRemove() *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -991,10 +970,8 @@ func (e *MeterElement) InertIfRemove() *MeterElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *MeterElement) Inputmode(c MeterInputmodeChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[MeterInputmodeChoice])
 	return e
 }
 
@@ -1045,10 +1022,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *MeterElement) InputmodeRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1058,10 +1033,8 @@ func (e *MeterElement) InputmodeRemove() *MeterElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *MeterElement) Is(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1081,7 +1054,7 @@ func (e *MeterElement) Isf(format string, args ...any) *MeterElement {
 // and extends the element type it is being applied to.
 func (e *MeterElement) IfIs(condition bool, s string) *MeterElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1093,7 +1066,7 @@ func (e *MeterElement) IfIs(condition bool, s string) *MeterElement {
 // and extends the element type it is being applied to.
 func (e *MeterElement) IfIsf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1105,10 +1078,8 @@ func (e *MeterElement) IfIsf(condition bool, format string, args ...any) *MeterE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *MeterElement) IsRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1124,10 +1095,8 @@ func (e *MeterElement) IsRemove() *MeterElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *MeterElement) Itemid(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1159,7 +1128,7 @@ func (e *MeterElement) Itemidf(format string, args ...any) *MeterElement {
 // how items with the same identifier are handled.
 func (e *MeterElement) IfItemid(condition bool, s string) *MeterElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1177,7 +1146,7 @@ func (e *MeterElement) IfItemid(condition bool, s string) *MeterElement {
 // how items with the same identifier are handled.
 func (e *MeterElement) IfItemidf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1195,10 +1164,8 @@ func (e *MeterElement) IfItemidf(condition bool, format string, args ...any) *Me
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *MeterElement) ItemidRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1210,10 +1177,8 @@ func (e *MeterElement) ItemidRemove() *MeterElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *MeterElement) Itemprop(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1237,7 +1202,7 @@ func (e *MeterElement) Itempropf(format string, args ...any) *MeterElement {
 // <track>, and <video>.
 func (e *MeterElement) IfItemprop(condition bool, s string) *MeterElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1251,7 +1216,7 @@ func (e *MeterElement) IfItemprop(condition bool, s string) *MeterElement {
 // <track>, and <video>.
 func (e *MeterElement) IfItempropf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1265,10 +1230,8 @@ func (e *MeterElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *MeterElement) ItempropRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1278,10 +1241,8 @@ func (e *MeterElement) ItempropRemove() *MeterElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *MeterElement) Itemref(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1301,7 +1262,7 @@ func (e *MeterElement) Itemreff(format string, args ...any) *MeterElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MeterElement) IfItemref(condition bool, s string) *MeterElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1313,7 +1274,7 @@ func (e *MeterElement) IfItemref(condition bool, s string) *MeterElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *MeterElement) IfItemreff(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1325,10 +1286,8 @@ func (e *MeterElement) IfItemreff(condition bool, format string, args ...any) *M
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *MeterElement) ItemrefRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1340,10 +1299,8 @@ func (e *MeterElement) ItemrefRemove() *MeterElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MeterElement) Itemscope() *MeterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1356,7 +1313,7 @@ func (e *MeterElement) Itemscope() *MeterElement {
 // <object>, <source>, <track>, and <video>.
 func (e *MeterElement) IfItemscope(condition bool) *MeterElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1370,10 +1327,8 @@ func (e *MeterElement) IfItemscope(condition bool) *MeterElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MeterElement) ItemscopeRemove() *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1386,10 +1341,8 @@ func (e *MeterElement) ItemscopeRemove() *MeterElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *MeterElement) ItemscopeIfRemove() *MeterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1401,10 +1354,8 @@ func (e *MeterElement) ItemscopeIfRemove() *MeterElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *MeterElement) Itemtype(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1428,7 +1379,7 @@ func (e *MeterElement) Itemtypef(format string, args ...any) *MeterElement {
 // <video>.
 func (e *MeterElement) IfItemtype(condition bool, s string) *MeterElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1442,7 +1393,7 @@ func (e *MeterElement) IfItemtype(condition bool, s string) *MeterElement {
 // <video>.
 func (e *MeterElement) IfItemtypef(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1456,10 +1407,8 @@ func (e *MeterElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *MeterElement) ItemtypeRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1469,10 +1418,8 @@ func (e *MeterElement) ItemtypeRemove() *MeterElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MeterElement) Lang(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1492,7 +1439,7 @@ func (e *MeterElement) Langf(format string, args ...any) *MeterElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MeterElement) IfLang(condition bool, s string) *MeterElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1504,7 +1451,7 @@ func (e *MeterElement) IfLang(condition bool, s string) *MeterElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *MeterElement) IfLangf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1516,10 +1463,8 @@ func (e *MeterElement) IfLangf(condition bool, format string, args ...any) *Mete
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *MeterElement) LangRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1529,10 +1474,8 @@ func (e *MeterElement) LangRemove() *MeterElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *MeterElement) Nonce(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1552,7 +1495,7 @@ func (e *MeterElement) Noncef(format string, args ...any) *MeterElement {
 // execute.
 func (e *MeterElement) IfNonce(condition bool, s string) *MeterElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1564,7 +1507,7 @@ func (e *MeterElement) IfNonce(condition bool, s string) *MeterElement {
 // execute.
 func (e *MeterElement) IfNoncef(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1576,10 +1519,8 @@ func (e *MeterElement) IfNoncef(condition bool, format string, args ...any) *Met
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *MeterElement) NonceRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1587,16 +1528,9 @@ func (e *MeterElement) NonceRemove() *MeterElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *MeterElement) Part(s string) *MeterElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1605,7 +1539,7 @@ func (e *MeterElement) Part(s string) *MeterElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *MeterElement) IfPart(condition bool, s string) *MeterElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1615,14 +1549,8 @@ func (e *MeterElement) IfPart(condition bool, s string) *MeterElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *MeterElement) PartRemove(s ...string) *MeterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1633,10 +1561,8 @@ func (e *MeterElement) PartRemove(s ...string) *MeterElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *MeterElement) Popover(c MeterPopoverChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[MeterPopoverChoice])
 	return e
 }
 
@@ -1664,10 +1590,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *MeterElement) PopoverRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1676,10 +1600,8 @@ func (e *MeterElement) PopoverRemove() *MeterElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *MeterElement) Role(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1697,7 +1619,7 @@ func (e *MeterElement) Rolef(format string, args ...any) *MeterElement {
 // role of an element.
 func (e *MeterElement) IfRole(condition bool, s string) *MeterElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1708,7 +1630,7 @@ func (e *MeterElement) IfRole(condition bool, s string) *MeterElement {
 // role of an element.
 func (e *MeterElement) IfRolef(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1719,10 +1641,8 @@ func (e *MeterElement) IfRolef(condition bool, format string, args ...any) *Mete
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *MeterElement) RoleRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1731,10 +1651,8 @@ func (e *MeterElement) RoleRemove() *MeterElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *MeterElement) Slot(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1752,7 +1670,7 @@ func (e *MeterElement) Slotf(format string, args ...any) *MeterElement {
 // value.
 func (e *MeterElement) IfSlot(condition bool, s string) *MeterElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1763,7 +1681,7 @@ func (e *MeterElement) IfSlot(condition bool, s string) *MeterElement {
 // value.
 func (e *MeterElement) IfSlotf(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1774,10 +1692,8 @@ func (e *MeterElement) IfSlotf(condition bool, format string, args ...any) *Mete
 // value.
 // Remove the attribute Slot from the element.
 func (e *MeterElement) SlotRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1793,10 +1709,8 @@ func (e *MeterElement) SlotRemove() *MeterElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *MeterElement) Spellcheck(c MeterSpellcheckChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[MeterSpellcheckChoice])
 	return e
 }
 
@@ -1824,10 +1738,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *MeterElement) SpellcheckRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1837,14 +1749,8 @@ func (e *MeterElement) StylePairs(pairs ...string) *MeterElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1859,14 +1765,8 @@ func (e *MeterElement) StylePairs(pairs ...string) *MeterElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MeterElement) Style(s string) *MeterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1874,7 +1774,7 @@ func (e *MeterElement) Style(s string) *MeterElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1883,7 +1783,7 @@ func (e *MeterElement) Style(s string) *MeterElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *MeterElement) IfStyle(condition bool, s string) *MeterElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1891,15 +1791,7 @@ func (e *MeterElement) IfStyle(condition bool, s string) *MeterElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *MeterElement) StyleAdd(k string, v string) *MeterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1913,7 +1805,7 @@ func (e *MeterElement) StyleAddf(k string, format string, args ...any) *MeterEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *MeterElement) IfStyleAdd(condition bool, k string, v string) *MeterElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1922,7 +1814,7 @@ func (e *MeterElement) IfStyleAdd(condition bool, k string, v string) *MeterElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *MeterElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MeterElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1931,21 +1823,15 @@ func (e *MeterElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *MeterElement) StyleMap(m map[string]string) *MeterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1954,14 +1840,8 @@ func (e *MeterElement) StyleMap(m map[string]string) *MeterElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *MeterElement) StyleRemove(keys ...string) *MeterElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1979,10 +1859,8 @@ func (e *MeterElement) StyleRemove(keys ...string) *MeterElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *MeterElement) Tabindex(i int) *MeterElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2001,7 +1879,7 @@ func (e *MeterElement) Tabindex(i int) *MeterElement {
 // their relative position in the document.
 func (e *MeterElement) IfTabindex(condition bool, i int) *MeterElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2021,10 +1899,8 @@ func (e *MeterElement) IfTabindex(condition bool, i int) *MeterElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *MeterElement) TabindexRemove() *MeterElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2045,10 +1921,8 @@ func (e *MeterElement) TabindexRemove() *MeterElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *MeterElement) Title(s string) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2090,7 +1964,7 @@ func (e *MeterElement) Titlef(format string, args ...any) *MeterElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MeterElement) IfTitle(condition bool, s string) *MeterElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2113,7 +1987,7 @@ func (e *MeterElement) IfTitle(condition bool, s string) *MeterElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *MeterElement) IfTitlef(condition bool, format string, args ...any) *MeterElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2136,10 +2010,8 @@ func (e *MeterElement) IfTitlef(condition bool, format string, args ...any) *Met
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *MeterElement) TitleRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2148,10 +2020,8 @@ func (e *MeterElement) TitleRemove() *MeterElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *MeterElement) Translate(c MeterTranslateChoice) *MeterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[MeterTranslateChoice])
 	return e
 }
 
@@ -2172,9 +2042,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *MeterElement) TranslateRemove() *MeterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <object> element represents an external resource, which can be
@@ -23,18 +21,77 @@ type ObjectElement struct {
 // with the tag "object" during rendering.
 func Object(children ...ElementRenderer) *ObjectElement {
 	e := NewElement("object", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &ObjectElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ObjectElement) Clone() *ObjectElement {
+	return &ObjectElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ObjectElement) CloneShallow() *ObjectElement {
+	return &ObjectElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ObjectElement) Freeze() *ObjectElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ObjectElement) writable() *ObjectElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ObjectElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ObjectElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[ObjectAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ObjectContenteditableChoice]
+	case "dir":
+		return choiceValue[ObjectDirChoice]
+	case "draggable":
+		return choiceValue[ObjectDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ObjectEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ObjectHiddenChoice]
+	case "inputmode":
+		return choiceValue[ObjectInputmodeChoice]
+	case "popover":
+		return choiceValue[ObjectPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ObjectSpellcheckChoice]
+	case "translate":
+		return choiceValue[ObjectTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ObjectElement) Children(children ...ElementRenderer) *ObjectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ObjectElement) IfChildren(condition bool, children ...ElementRenderer) *ObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *ObjectElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *ObjectElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ObjectElement) BoolAttr(name string) *ObjectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ObjectElement) BoolAttrRemove(name string) *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ObjectElement) IfBoolAttr(condition bool, name string) *ObjectElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *ObjectElement) BoolAttrf(format string, args ...any) *ObjectElement {
 
 func (e *ObjectElement) IfBoolAttrf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ObjectElement) BoolAttrs(names ...string) *ObjectElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ObjectElement) IfBoolAttrs(condition bool, names ...string) *ObjectElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ObjectElement) Attr(name, value string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ObjectElement) RawAttr(name, value string) *ObjectElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ObjectElement) IfAttr(condition bool, name, value string) *ObjectElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *ObjectElement) Attrf(name, format string, args ...any) *ObjectElement {
 
 func (e *ObjectElement) IfAttrf(condition bool, name, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ObjectElement) Attrs(attrs ...string) *ObjectElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ObjectElement) IfAttrs(condition bool, attrs ...string) *ObjectElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ObjectElement) AttrsMap(attrs map[string]string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ObjectElement) IfAttrsMap(condition bool, attrs map[string]string) *ObjectElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ObjectElement) Text(text string) *ObjectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ObjectElement) Textf(format string, args ...any) *ObjectElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ObjectElement) IfText(condition bool, text string) *ObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *ObjectElement) IfText(condition bool, text string) *ObjectElement {
 
 func (e *ObjectElement) IfTextf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ObjectElement) Raw(text string) *ObjectElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ObjectElement) Escaped(text string) *ObjectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ObjectElement) IfEscaped(condition bool, text string) *ObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *ObjectElement) Escapedf(format string, args ...any) *ObjectElement {
 
 func (e *ObjectElement) IfEscapedf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +271,8 @@ func (e *ObjectElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Address of the resource.
 func (e *ObjectElement) Data(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("data", s)
+	e = e.writable()
+	e.setStringAttribute("data", s)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *ObjectElement) Dataf(format string, args ...any) *ObjectElement {
 // Address of the resource.
 func (e *ObjectElement) IfData(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Data(s)
+		e = e.Data(s)
 	}
 	return e
 }
@@ -233,7 +292,7 @@ func (e *ObjectElement) IfData(condition bool, s string) *ObjectElement {
 // Address of the resource.
 func (e *ObjectElement) IfDataf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Data(fmt.Sprintf(format, args...))
+		e = e.Data(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +300,15 @@ func (e *ObjectElement) IfDataf(condition bool, format string, args ...any) *Obj
 // Address of the resource.
 // Remove the attribute Data from the element.
 func (e *ObjectElement) DataRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("data")
+	e = e.writable()
+	e.removeAttribute("data")
 	return e
 }
 
 // Associates the control with a form element.
 func (e *ObjectElement) Form(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -265,7 +320,7 @@ func (e *ObjectElement) Formf(format string, args ...any) *ObjectElement {
 // Associates the control with a form element.
 func (e *ObjectElement) IfForm(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -273,7 +328,7 @@ func (e *ObjectElement) IfForm(condition bool, s string) *ObjectElement {
 // Associates the control with a form element.
 func (e *ObjectElement) IfFormf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,26 +336,22 @@ func (e *ObjectElement) IfFormf(condition bool, format string, args ...any) *Obj
 // Associates the control with a form element.
 // Remove the attribute Form from the element.
 func (e *ObjectElement) FormRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // Height of the element.
 func (e *ObjectElement) Height(i int) *ObjectElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // Height of the element.
 func (e *ObjectElement) IfHeight(condition bool, i int) *ObjectElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -308,19 +359,15 @@ func (e *ObjectElement) IfHeight(condition bool, i int) *ObjectElement {
 // Height of the element.
 // Remove the attribute Height from the element.
 func (e *ObjectElement) HeightRemove() *ObjectElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // Name of the element to use for form submission and in the form.elements API.
 func (e *ObjectElement) Name(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -332,7 +379,7 @@ func (e *ObjectElement) Namef(format string, args ...any) *ObjectElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *ObjectElement) IfName(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -340,7 +387,7 @@ func (e *ObjectElement) IfName(condition bool, s string) *ObjectElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *ObjectElement) IfNamef(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -348,19 +395,15 @@ func (e *ObjectElement) IfNamef(condition bool, format string, args ...any) *Obj
 // Name of the element to use for form submission and in the form.elements API.
 // Remove the attribute Name from the element.
 func (e *ObjectElement) NameRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
 // Type of embedded resource.
 func (e *ObjectElement) Type(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -372,7 +415,7 @@ func (e *ObjectElement) Typef(format string, args ...any) *ObjectElement {
 // Type of embedded resource.
 func (e *ObjectElement) IfType(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -380,7 +423,7 @@ func (e *ObjectElement) IfType(condition bool, s string) *ObjectElement {
 // Type of embedded resource.
 func (e *ObjectElement) IfTypef(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -388,20 +431,16 @@ func (e *ObjectElement) IfTypef(condition bool, format string, args ...any) *Obj
 // Type of embedded resource.
 // Remove the attribute Type from the element.
 func (e *ObjectElement) TypeRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Whether the type Attribute and the Content-Type value need to match for the
 // resource to be used.
 func (e *ObjectElement) Typemustmatch() *ObjectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("typemustmatch", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("typemustmatch")
 	return e
 }
 
@@ -409,7 +448,7 @@ func (e *ObjectElement) Typemustmatch() *ObjectElement {
 // resource to be used.
 func (e *ObjectElement) IfTypemustmatch(condition bool) *ObjectElement {
 	if condition {
-		e.Typemustmatch()
+		e = e.Typemustmatch()
 	}
 	return e
 }
@@ -418,10 +457,8 @@ func (e *ObjectElement) IfTypemustmatch(condition bool) *ObjectElement {
 // Whether the type Attribute and the Content-Type value need to match for the
 // resource to be used.
 func (e *ObjectElement) TypemustmatchRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("typemustmatch")
+	e = e.writable()
+	e.removeAttribute("typemustmatch")
 	return e
 }
 
@@ -429,19 +466,15 @@ func (e *ObjectElement) TypemustmatchRemove() *ObjectElement {
 // Whether the type Attribute and the Content-Type value need to match for the
 // resource to be used.
 func (e *ObjectElement) TypemustmatchIfRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("typemustmatch")
+	e = e.writable()
+	e.removeAttribute("typemustmatch")
 	return e
 }
 
 // Name of image map to use.
 func (e *ObjectElement) Usemap(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("usemap", s)
+	e = e.writable()
+	e.setStringAttribute("usemap", s)
 	return e
 }
 
@@ -453,7 +486,7 @@ func (e *ObjectElement) Usemapf(format string, args ...any) *ObjectElement {
 // Name of image map to use.
 func (e *ObjectElement) IfUsemap(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Usemap(s)
+		e = e.Usemap(s)
 	}
 	return e
 }
@@ -461,7 +494,7 @@ func (e *ObjectElement) IfUsemap(condition bool, s string) *ObjectElement {
 // Name of image map to use.
 func (e *ObjectElement) IfUsemapf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Usemap(fmt.Sprintf(format, args...))
+		e = e.Usemap(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -469,26 +502,22 @@ func (e *ObjectElement) IfUsemapf(condition bool, format string, args ...any) *O
 // Name of image map to use.
 // Remove the attribute Usemap from the element.
 func (e *ObjectElement) UsemapRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("usemap")
+	e = e.writable()
+	e.removeAttribute("usemap")
 	return e
 }
 
 // Width of the element.
 func (e *ObjectElement) Width(i int) *ObjectElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // Width of the element.
 func (e *ObjectElement) IfWidth(condition bool, i int) *ObjectElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -496,10 +525,8 @@ func (e *ObjectElement) IfWidth(condition bool, i int) *ObjectElement {
 // Width of the element.
 // Remove the attribute Width from the element.
 func (e *ObjectElement) WidthRemove() *ObjectElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -508,10 +535,8 @@ func (e *ObjectElement) WidthRemove() *ObjectElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ObjectElement) Accesskey(r rune) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -521,7 +546,7 @@ func (e *ObjectElement) Accesskey(r rune) *ObjectElement {
 // can be generated by the keyboard).
 func (e *ObjectElement) IfAccesskey(condition bool, r rune) *ObjectElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -532,10 +557,8 @@ func (e *ObjectElement) IfAccesskey(condition bool, r rune) *ObjectElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ObjectElement) AccesskeyRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -551,10 +574,8 @@ func (e *ObjectElement) AccesskeyRemove() *ObjectElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ObjectElement) Autocapitalize(c ObjectAutocapitalizeChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ObjectAutocapitalizeChoice])
 	return e
 }
 
@@ -588,10 +609,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ObjectElement) AutocapitalizeRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -610,10 +629,8 @@ func (e *ObjectElement) AutocapitalizeRemove() *ObjectElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ObjectElement) Autofocus() *ObjectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -633,7 +650,7 @@ func (e *ObjectElement) Autofocus() *ObjectElement {
 // created by the preceding content.
 func (e *ObjectElement) IfAutofocus(condition bool) *ObjectElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -654,10 +671,8 @@ func (e *ObjectElement) IfAutofocus(condition bool) *ObjectElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ObjectElement) AutofocusRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -677,10 +692,8 @@ func (e *ObjectElement) AutofocusRemove() *ObjectElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ObjectElement) AutofocusIfRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -689,16 +702,9 @@ func (e *ObjectElement) AutofocusIfRemove() *ObjectElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ObjectElement) Class(s string) *ObjectElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -708,7 +714,7 @@ func (e *ObjectElement) Class(s string) *ObjectElement {
 // document.getElementsByClassName.
 func (e *ObjectElement) IfClass(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -719,14 +725,8 @@ func (e *ObjectElement) IfClass(condition bool, s string) *ObjectElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ObjectElement) ClassRemove(s ...string) *ObjectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -734,10 +734,8 @@ func (e *ObjectElement) ClassRemove(s ...string) *ObjectElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ObjectElement) Contenteditable(c ObjectContenteditableChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ObjectContenteditableChoice])
 	return e
 }
 
@@ -760,10 +758,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ObjectElement) ContenteditableRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -781,10 +777,8 @@ func (e *ObjectElement) ContenteditableRemove() *ObjectElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ObjectElement) Dir(c ObjectDirChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ObjectDirChoice])
 	return e
 }
 
@@ -818,10 +812,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ObjectElement) DirRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -829,10 +821,8 @@ func (e *ObjectElement) DirRemove() *ObjectElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ObjectElement) Draggable(c ObjectDraggableChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ObjectDraggableChoice])
 	return e
 }
 
@@ -858,20 +848,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ObjectElement) DraggableRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ObjectElement) Enterkeyhint(c ObjectEnterkeyhintChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ObjectEnterkeyhintChoice])
 	return e
 }
 
@@ -901,10 +887,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ObjectElement) EnterkeyhintRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -926,16 +910,9 @@ func (e *ObjectElement) EnterkeyhintRemove() *ObjectElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ObjectElement) Exportparts(s string) *ObjectElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -958,7 +935,7 @@ func (e *ObjectElement) Exportparts(s string) *ObjectElement {
 // the current structure.
 func (e *ObjectElement) IfExportparts(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -982,14 +959,8 @@ func (e *ObjectElement) IfExportparts(condition bool, s string) *ObjectElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ObjectElement) ExportpartsRemove(s ...string) *ObjectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1006,10 +977,8 @@ func (e *ObjectElement) ExportpartsRemove(s ...string) *ObjectElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ObjectElement) Hidden(c ObjectHiddenChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ObjectHiddenChoice])
 	return e
 }
 
@@ -1045,10 +1014,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ObjectElement) HiddenRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1056,10 +1023,8 @@ func (e *ObjectElement) HiddenRemove() *ObjectElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ObjectElement) ID(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1075,7 +1040,7 @@ func (e *ObjectElement) IDf(format string, args ...any) *ObjectElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ObjectElement) IfID(condition bool, s string) *ObjectElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1085,7 +1050,7 @@ func (e *ObjectElement) IfID(condition bool, s string) *ObjectElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ObjectElement) IfIDf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1095,10 +1060,8 @@ func (e *ObjectElement) IfIDf(condition bool, format string, args ...any) *Objec
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ObjectElement) IDRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1113,10 +1076,8 @@ func (e *ObjectElement) IDRemove() *ObjectElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ObjectElement) Inert() *ObjectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1132,7 +1093,7 @@ func (e *ObjectElement) Inert() *ObjectElement {
 // excluding them from the accessibility tree.
 func (e *ObjectElement) IfInert(condition bool) *ObjectElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1149,10 +1110,8 @@ func (e *ObjectElement) IfInert(condition bool) *ObjectElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ObjectElement) InertRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1168,10 +1127,8 @@ func (e *ObjectElement) InertRemove() *ObjectElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ObjectElement) InertIfRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1185,10 +1142,8 @@ func (e *ObjectElement) InertIfRemove() *ObjectElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ObjectElement) Inputmode(c ObjectInputmodeChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ObjectInputmodeChoice])
 	return e
 }
 
@@ -1239,10 +1194,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ObjectElement) InputmodeRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1252,10 +1205,8 @@ func (e *ObjectElement) InputmodeRemove() *ObjectElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ObjectElement) Is(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1275,7 +1226,7 @@ func (e *ObjectElement) Isf(format string, args ...any) *ObjectElement {
 // and extends the element type it is being applied to.
 func (e *ObjectElement) IfIs(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1287,7 +1238,7 @@ func (e *ObjectElement) IfIs(condition bool, s string) *ObjectElement {
 // and extends the element type it is being applied to.
 func (e *ObjectElement) IfIsf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1299,10 +1250,8 @@ func (e *ObjectElement) IfIsf(condition bool, format string, args ...any) *Objec
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ObjectElement) IsRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1318,10 +1267,8 @@ func (e *ObjectElement) IsRemove() *ObjectElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ObjectElement) Itemid(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1353,7 +1300,7 @@ func (e *ObjectElement) Itemidf(format string, args ...any) *ObjectElement {
 // how items with the same identifier are handled.
 func (e *ObjectElement) IfItemid(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1371,7 +1318,7 @@ func (e *ObjectElement) IfItemid(condition bool, s string) *ObjectElement {
 // how items with the same identifier are handled.
 func (e *ObjectElement) IfItemidf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1389,10 +1336,8 @@ func (e *ObjectElement) IfItemidf(condition bool, format string, args ...any) *O
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ObjectElement) ItemidRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1404,10 +1349,8 @@ func (e *ObjectElement) ItemidRemove() *ObjectElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ObjectElement) Itemprop(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1431,7 +1374,7 @@ func (e *ObjectElement) Itempropf(format string, args ...any) *ObjectElement {
 // <track>, and <video>.
 func (e *ObjectElement) IfItemprop(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1445,7 +1388,7 @@ func (e *ObjectElement) IfItemprop(condition bool, s string) *ObjectElement {
 // <track>, and <video>.
 func (e *ObjectElement) IfItempropf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1459,10 +1402,8 @@ func (e *ObjectElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ObjectElement) ItempropRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1472,10 +1413,8 @@ func (e *ObjectElement) ItempropRemove() *ObjectElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ObjectElement) Itemref(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1495,7 +1434,7 @@ func (e *ObjectElement) Itemreff(format string, args ...any) *ObjectElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ObjectElement) IfItemref(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1507,7 +1446,7 @@ func (e *ObjectElement) IfItemref(condition bool, s string) *ObjectElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ObjectElement) IfItemreff(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1519,10 +1458,8 @@ func (e *ObjectElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ObjectElement) ItemrefRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1534,10 +1471,8 @@ func (e *ObjectElement) ItemrefRemove() *ObjectElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ObjectElement) Itemscope() *ObjectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1550,7 +1485,7 @@ func (e *ObjectElement) Itemscope() *ObjectElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ObjectElement) IfItemscope(condition bool) *ObjectElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1564,10 +1499,8 @@ func (e *ObjectElement) IfItemscope(condition bool) *ObjectElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ObjectElement) ItemscopeRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1580,10 +1513,8 @@ func (e *ObjectElement) ItemscopeRemove() *ObjectElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ObjectElement) ItemscopeIfRemove() *ObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1595,10 +1526,8 @@ func (e *ObjectElement) ItemscopeIfRemove() *ObjectElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ObjectElement) Itemtype(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1622,7 +1551,7 @@ func (e *ObjectElement) Itemtypef(format string, args ...any) *ObjectElement {
 // <video>.
 func (e *ObjectElement) IfItemtype(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1636,7 +1565,7 @@ func (e *ObjectElement) IfItemtype(condition bool, s string) *ObjectElement {
 // <video>.
 func (e *ObjectElement) IfItemtypef(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1650,10 +1579,8 @@ func (e *ObjectElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ObjectElement) ItemtypeRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1663,10 +1590,8 @@ func (e *ObjectElement) ItemtypeRemove() *ObjectElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ObjectElement) Lang(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1686,7 +1611,7 @@ func (e *ObjectElement) Langf(format string, args ...any) *ObjectElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ObjectElement) IfLang(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1698,7 +1623,7 @@ func (e *ObjectElement) IfLang(condition bool, s string) *ObjectElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ObjectElement) IfLangf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1710,10 +1635,8 @@ func (e *ObjectElement) IfLangf(condition bool, format string, args ...any) *Obj
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ObjectElement) LangRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1723,10 +1646,8 @@ func (e *ObjectElement) LangRemove() *ObjectElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ObjectElement) Nonce(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1746,7 +1667,7 @@ func (e *ObjectElement) Noncef(format string, args ...any) *ObjectElement {
 // execute.
 func (e *ObjectElement) IfNonce(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1758,7 +1679,7 @@ func (e *ObjectElement) IfNonce(condition bool, s string) *ObjectElement {
 // execute.
 func (e *ObjectElement) IfNoncef(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1770,10 +1691,8 @@ func (e *ObjectElement) IfNoncef(condition bool, format string, args ...any) *Ob
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ObjectElement) NonceRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1781,16 +1700,9 @@ func (e *ObjectElement) NonceRemove() *ObjectElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ObjectElement) Part(s string) *ObjectElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1799,7 +1711,7 @@ func (e *ObjectElement) Part(s string) *ObjectElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ObjectElement) IfPart(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1809,14 +1721,8 @@ func (e *ObjectElement) IfPart(condition bool, s string) *ObjectElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ObjectElement) PartRemove(s ...string) *ObjectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1827,10 +1733,8 @@ func (e *ObjectElement) PartRemove(s ...string) *ObjectElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ObjectElement) Popover(c ObjectPopoverChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ObjectPopoverChoice])
 	return e
 }
 
@@ -1858,10 +1762,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ObjectElement) PopoverRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1870,10 +1772,8 @@ func (e *ObjectElement) PopoverRemove() *ObjectElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ObjectElement) Role(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1891,7 +1791,7 @@ func (e *ObjectElement) Rolef(format string, args ...any) *ObjectElement {
 // role of an element.
 func (e *ObjectElement) IfRole(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1902,7 +1802,7 @@ func (e *ObjectElement) IfRole(condition bool, s string) *ObjectElement {
 // role of an element.
 func (e *ObjectElement) IfRolef(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1913,10 +1813,8 @@ func (e *ObjectElement) IfRolef(condition bool, format string, args ...any) *Obj
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ObjectElement) RoleRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1925,10 +1823,8 @@ func (e *ObjectElement) RoleRemove() *ObjectElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ObjectElement) Slot(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1946,7 +1842,7 @@ func (e *ObjectElement) Slotf(format string, args ...any) *ObjectElement {
 // value.
 func (e *ObjectElement) IfSlot(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1957,7 +1853,7 @@ func (e *ObjectElement) IfSlot(condition bool, s string) *ObjectElement {
 // value.
 func (e *ObjectElement) IfSlotf(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1968,10 +1864,8 @@ func (e *ObjectElement) IfSlotf(condition bool, format string, args ...any) *Obj
 // value.
 // Remove the attribute Slot from the element.
 func (e *ObjectElement) SlotRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1987,10 +1881,8 @@ func (e *ObjectElement) SlotRemove() *ObjectElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ObjectElement) Spellcheck(c ObjectSpellcheckChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ObjectSpellcheckChoice])
 	return e
 }
 
@@ -2018,10 +1910,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ObjectElement) SpellcheckRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2031,14 +1921,8 @@ func (e *ObjectElement) StylePairs(pairs ...string) *ObjectElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2053,14 +1937,8 @@ func (e *ObjectElement) StylePairs(pairs ...string) *ObjectElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ObjectElement) Style(s string) *ObjectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2068,7 +1946,7 @@ func (e *ObjectElement) Style(s string) *ObjectElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2077,7 +1955,7 @@ func (e *ObjectElement) Style(s string) *ObjectElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ObjectElement) IfStyle(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2085,15 +1963,7 @@ func (e *ObjectElement) IfStyle(condition bool, s string) *ObjectElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ObjectElement) StyleAdd(k string, v string) *ObjectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2107,7 +1977,7 @@ func (e *ObjectElement) StyleAddf(k string, format string, args ...any) *ObjectE
 // color, font, size, and more. Styles are written in CSS.
 func (e *ObjectElement) IfStyleAdd(condition bool, k string, v string) *ObjectElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2116,7 +1986,7 @@ func (e *ObjectElement) IfStyleAdd(condition bool, k string, v string) *ObjectEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *ObjectElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ObjectElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2125,21 +1995,15 @@ func (e *ObjectElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ObjectElement) StyleMap(m map[string]string) *ObjectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2148,14 +2012,8 @@ func (e *ObjectElement) StyleMap(m map[string]string) *ObjectElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ObjectElement) StyleRemove(keys ...string) *ObjectElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2173,10 +2031,8 @@ func (e *ObjectElement) StyleRemove(keys ...string) *ObjectElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ObjectElement) Tabindex(i int) *ObjectElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2195,7 +2051,7 @@ func (e *ObjectElement) Tabindex(i int) *ObjectElement {
 // their relative position in the document.
 func (e *ObjectElement) IfTabindex(condition bool, i int) *ObjectElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2215,10 +2071,8 @@ func (e *ObjectElement) IfTabindex(condition bool, i int) *ObjectElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ObjectElement) TabindexRemove() *ObjectElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2239,10 +2093,8 @@ func (e *ObjectElement) TabindexRemove() *ObjectElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ObjectElement) Title(s string) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2284,7 +2136,7 @@ func (e *ObjectElement) Titlef(format string, args ...any) *ObjectElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ObjectElement) IfTitle(condition bool, s string) *ObjectElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2307,7 +2159,7 @@ func (e *ObjectElement) IfTitle(condition bool, s string) *ObjectElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ObjectElement) IfTitlef(condition bool, format string, args ...any) *ObjectElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2330,10 +2182,8 @@ func (e *ObjectElement) IfTitlef(condition bool, format string, args ...any) *Ob
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ObjectElement) TitleRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2342,10 +2192,8 @@ func (e *ObjectElement) TitleRemove() *ObjectElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ObjectElement) Translate(c ObjectTranslateChoice) *ObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ObjectTranslateChoice])
 	return e
 }
 
@@ -2366,9 +2214,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ObjectElement) TranslateRemove() *ObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
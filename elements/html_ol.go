@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <ol> element represents an ordered list of items, typically rendered
@@ -22,18 +20,79 @@ type OlElement struct {
 // with the tag "ol" during rendering.
 func Ol(children ...ElementRenderer) *OlElement {
 	e := NewElement("ol", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &OlElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *OlElement) Clone() *OlElement {
+	return &OlElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *OlElement) CloneShallow() *OlElement {
+	return &OlElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *OlElement) Freeze() *OlElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *OlElement) writable() *OlElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *OlElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *OlElement) choiceType(name string) func(string) any {
+	switch name {
+	case "type":
+		return choiceValue[OlTypeChoice]
+	case "autocapitalize":
+		return choiceValue[OlAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[OlContenteditableChoice]
+	case "dir":
+		return choiceValue[OlDirChoice]
+	case "draggable":
+		return choiceValue[OlDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[OlEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[OlHiddenChoice]
+	case "inputmode":
+		return choiceValue[OlInputmodeChoice]
+	case "popover":
+		return choiceValue[OlPopoverChoice]
+	case "spellcheck":
+		return choiceValue[OlSpellcheckChoice]
+	case "translate":
+		return choiceValue[OlTranslateChoice]
+	}
+	return nil
+}
+
 func (e *OlElement) Children(children ...ElementRenderer) *OlElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *OlElement) IfChildren(condition bool, children ...ElementRenderer) *OlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +100,30 @@ func (e *OlElement) IfChildren(condition bool, children ...ElementRenderer) *OlE
 
 func (e *OlElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *OlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *OlElement) BoolAttr(name string) *OlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *OlElement) BoolAttrRemove(name string) *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *OlElement) IfBoolAttr(condition bool, name string) *OlElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +134,40 @@ func (e *OlElement) BoolAttrf(format string, args ...any) *OlElement {
 
 func (e *OlElement) IfBoolAttrf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *OlElement) BoolAttrs(names ...string) *OlElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *OlElement) IfBoolAttrs(condition bool, names ...string) *OlElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *OlElement) Attr(name, value string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *OlElement) RawAttr(name, value string) *OlElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *OlElement) IfAttr(condition bool, name, value string) *OlElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +178,52 @@ func (e *OlElement) Attrf(name, format string, args ...any) *OlElement {
 
 func (e *OlElement) IfAttrf(condition bool, name, format string, args ...any) *OlElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *OlElement) Attrs(attrs ...string) *OlElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *OlElement) IfAttrs(condition bool, attrs ...string) *OlElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *OlElement) AttrsMap(attrs map[string]string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *OlElement) IfAttrsMap(condition bool, attrs map[string]string) *OlElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *OlElement) Text(text string) *OlElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *OlElement) Textf(format string, args ...any) *OlElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *OlElement) IfText(condition bool, text string) *OlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +231,28 @@ func (e *OlElement) IfText(condition bool, text string) *OlElement {
 
 func (e *OlElement) IfTextf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *OlElement) Raw(text string) *OlElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *OlElement) Escaped(text string) *OlElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *OlElement) IfEscaped(condition bool, text string) *OlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +264,7 @@ func (e *OlElement) Escapedf(format string, args ...any) *OlElement {
 
 func (e *OlElement) IfEscapedf(condition bool, format string, args ...any) *OlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,17 +272,15 @@ func (e *OlElement) IfEscapedf(condition bool, format string, args ...any) *OlEl
 
 // Number the list backwards.
 func (e *OlElement) Reversed() *OlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("reversed", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("reversed")
 	return e
 }
 
 // Number the list backwards.
 func (e *OlElement) IfReversed(condition bool) *OlElement {
 	if condition {
-		e.Reversed()
+		e = e.Reversed()
 	}
 	return e
 }
@@ -227,36 +288,30 @@ func (e *OlElement) IfReversed(condition bool) *OlElement {
 // Remove the attribute Reversed from the element.
 // Number the list backwards.
 func (e *OlElement) ReversedRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("reversed")
+	e = e.writable()
+	e.removeAttribute("reversed")
 	return e
 }
 
 // Remove the attribute Reversed from the element.
 // Number the list backwards.
 func (e *OlElement) ReversedIfRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("reversed")
+	e = e.writable()
+	e.removeAttribute("reversed")
 	return e
 }
 
 // Starting value of the list.
 func (e *OlElement) Start(i int) *OlElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("start", i)
+	e = e.writable()
+	e.setIntAttribute("start", i)
 	return e
 }
 
 // Starting value of the list.
 func (e *OlElement) IfStart(condition bool, i int) *OlElement {
 	if condition {
-		e.Start(i)
+		e = e.Start(i)
 	}
 	return e
 }
@@ -264,19 +319,15 @@ func (e *OlElement) IfStart(condition bool, i int) *OlElement {
 // Starting value of the list.
 // Remove the attribute Start from the element.
 func (e *OlElement) StartRemove() *OlElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("start")
+	e = e.writable()
+	e.removeAttribute("start")
 	return e
 }
 
 // Kind of list marker.
 func (e *OlElement) Type(c OlTypeChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[OlTypeChoice])
 	return e
 }
 
@@ -298,10 +349,8 @@ const (
 // Kind of list marker.
 // Remove the attribute Type from the element.
 func (e *OlElement) TypeRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -310,10 +359,8 @@ func (e *OlElement) TypeRemove() *OlElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *OlElement) Accesskey(r rune) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -323,7 +370,7 @@ func (e *OlElement) Accesskey(r rune) *OlElement {
 // can be generated by the keyboard).
 func (e *OlElement) IfAccesskey(condition bool, r rune) *OlElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -334,10 +381,8 @@ func (e *OlElement) IfAccesskey(condition bool, r rune) *OlElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *OlElement) AccesskeyRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -353,10 +398,8 @@ func (e *OlElement) AccesskeyRemove() *OlElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *OlElement) Autocapitalize(c OlAutocapitalizeChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[OlAutocapitalizeChoice])
 	return e
 }
 
@@ -390,10 +433,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *OlElement) AutocapitalizeRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -412,10 +453,8 @@ func (e *OlElement) AutocapitalizeRemove() *OlElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OlElement) Autofocus() *OlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -435,7 +474,7 @@ func (e *OlElement) Autofocus() *OlElement {
 // created by the preceding content.
 func (e *OlElement) IfAutofocus(condition bool) *OlElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -456,10 +495,8 @@ func (e *OlElement) IfAutofocus(condition bool) *OlElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OlElement) AutofocusRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -479,10 +516,8 @@ func (e *OlElement) AutofocusRemove() *OlElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OlElement) AutofocusIfRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -491,16 +526,9 @@ func (e *OlElement) AutofocusIfRemove() *OlElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *OlElement) Class(s string) *OlElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -510,7 +538,7 @@ func (e *OlElement) Class(s string) *OlElement {
 // document.getElementsByClassName.
 func (e *OlElement) IfClass(condition bool, s string) *OlElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -521,14 +549,8 @@ func (e *OlElement) IfClass(condition bool, s string) *OlElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *OlElement) ClassRemove(s ...string) *OlElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -536,10 +558,8 @@ func (e *OlElement) ClassRemove(s ...string) *OlElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *OlElement) Contenteditable(c OlContenteditableChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[OlContenteditableChoice])
 	return e
 }
 
@@ -562,10 +582,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *OlElement) ContenteditableRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -583,10 +601,8 @@ func (e *OlElement) ContenteditableRemove() *OlElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *OlElement) Dir(c OlDirChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[OlDirChoice])
 	return e
 }
 
@@ -620,10 +636,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *OlElement) DirRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -631,10 +645,8 @@ func (e *OlElement) DirRemove() *OlElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *OlElement) Draggable(c OlDraggableChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[OlDraggableChoice])
 	return e
 }
 
@@ -660,20 +672,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *OlElement) DraggableRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *OlElement) Enterkeyhint(c OlEnterkeyhintChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[OlEnterkeyhintChoice])
 	return e
 }
 
@@ -703,10 +711,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *OlElement) EnterkeyhintRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -728,16 +734,9 @@ func (e *OlElement) EnterkeyhintRemove() *OlElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *OlElement) Exportparts(s string) *OlElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -760,7 +759,7 @@ func (e *OlElement) Exportparts(s string) *OlElement {
 // the current structure.
 func (e *OlElement) IfExportparts(condition bool, s string) *OlElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -784,14 +783,8 @@ func (e *OlElement) IfExportparts(condition bool, s string) *OlElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *OlElement) ExportpartsRemove(s ...string) *OlElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -808,10 +801,8 @@ func (e *OlElement) ExportpartsRemove(s ...string) *OlElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *OlElement) Hidden(c OlHiddenChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[OlHiddenChoice])
 	return e
 }
 
@@ -847,10 +838,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *OlElement) HiddenRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -858,10 +847,8 @@ func (e *OlElement) HiddenRemove() *OlElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OlElement) ID(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -877,7 +864,7 @@ func (e *OlElement) IDf(format string, args ...any) *OlElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OlElement) IfID(condition bool, s string) *OlElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -887,7 +874,7 @@ func (e *OlElement) IfID(condition bool, s string) *OlElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OlElement) IfIDf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -897,10 +884,8 @@ func (e *OlElement) IfIDf(condition bool, format string, args ...any) *OlElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *OlElement) IDRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -915,10 +900,8 @@ func (e *OlElement) IDRemove() *OlElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OlElement) Inert() *OlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -934,7 +917,7 @@ func (e *OlElement) Inert() *OlElement {
 // excluding them from the accessibility tree.
 func (e *OlElement) IfInert(condition bool) *OlElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -951,10 +934,8 @@ func (e *OlElement) IfInert(condition bool) *OlElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OlElement) InertRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -970,10 +951,8 @@ func (e *OlElement) InertRemove() *OlElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OlElement) InertIfRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -987,10 +966,8 @@ func (e *OlElement) InertIfRemove() *OlElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *OlElement) Inputmode(c OlInputmodeChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[OlInputmodeChoice])
 	return e
 }
 
@@ -1041,10 +1018,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *OlElement) InputmodeRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1054,10 +1029,8 @@ func (e *OlElement) InputmodeRemove() *OlElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *OlElement) Is(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1077,7 +1050,7 @@ func (e *OlElement) Isf(format string, args ...any) *OlElement {
 // and extends the element type it is being applied to.
 func (e *OlElement) IfIs(condition bool, s string) *OlElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1089,7 +1062,7 @@ func (e *OlElement) IfIs(condition bool, s string) *OlElement {
 // and extends the element type it is being applied to.
 func (e *OlElement) IfIsf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1101,10 +1074,8 @@ func (e *OlElement) IfIsf(condition bool, format string, args ...any) *OlElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *OlElement) IsRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1120,10 +1091,8 @@ func (e *OlElement) IsRemove() *OlElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *OlElement) Itemid(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1155,7 +1124,7 @@ func (e *OlElement) Itemidf(format string, args ...any) *OlElement {
 // how items with the same identifier are handled.
 func (e *OlElement) IfItemid(condition bool, s string) *OlElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1173,7 +1142,7 @@ func (e *OlElement) IfItemid(condition bool, s string) *OlElement {
 // how items with the same identifier are handled.
 func (e *OlElement) IfItemidf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1191,10 +1160,8 @@ func (e *OlElement) IfItemidf(condition bool, format string, args ...any) *OlEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *OlElement) ItemidRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1206,10 +1173,8 @@ func (e *OlElement) ItemidRemove() *OlElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *OlElement) Itemprop(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1233,7 +1198,7 @@ func (e *OlElement) Itempropf(format string, args ...any) *OlElement {
 // <track>, and <video>.
 func (e *OlElement) IfItemprop(condition bool, s string) *OlElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1247,7 +1212,7 @@ func (e *OlElement) IfItemprop(condition bool, s string) *OlElement {
 // <track>, and <video>.
 func (e *OlElement) IfItempropf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1261,10 +1226,8 @@ func (e *OlElement) IfItempropf(condition bool, format string, args ...any) *OlE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *OlElement) ItempropRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1274,10 +1237,8 @@ func (e *OlElement) ItempropRemove() *OlElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *OlElement) Itemref(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1297,7 +1258,7 @@ func (e *OlElement) Itemreff(format string, args ...any) *OlElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OlElement) IfItemref(condition bool, s string) *OlElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1309,7 +1270,7 @@ func (e *OlElement) IfItemref(condition bool, s string) *OlElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OlElement) IfItemreff(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1321,10 +1282,8 @@ func (e *OlElement) IfItemreff(condition bool, format string, args ...any) *OlEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *OlElement) ItemrefRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1336,10 +1295,8 @@ func (e *OlElement) ItemrefRemove() *OlElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OlElement) Itemscope() *OlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1352,7 +1309,7 @@ func (e *OlElement) Itemscope() *OlElement {
 // <object>, <source>, <track>, and <video>.
 func (e *OlElement) IfItemscope(condition bool) *OlElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1366,10 +1323,8 @@ func (e *OlElement) IfItemscope(condition bool) *OlElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OlElement) ItemscopeRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1382,10 +1337,8 @@ func (e *OlElement) ItemscopeRemove() *OlElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OlElement) ItemscopeIfRemove() *OlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1397,10 +1350,8 @@ func (e *OlElement) ItemscopeIfRemove() *OlElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *OlElement) Itemtype(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1424,7 +1375,7 @@ func (e *OlElement) Itemtypef(format string, args ...any) *OlElement {
 // <video>.
 func (e *OlElement) IfItemtype(condition bool, s string) *OlElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1438,7 +1389,7 @@ func (e *OlElement) IfItemtype(condition bool, s string) *OlElement {
 // <video>.
 func (e *OlElement) IfItemtypef(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1452,10 +1403,8 @@ func (e *OlElement) IfItemtypef(condition bool, format string, args ...any) *OlE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *OlElement) ItemtypeRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1465,10 +1414,8 @@ func (e *OlElement) ItemtypeRemove() *OlElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OlElement) Lang(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1488,7 +1435,7 @@ func (e *OlElement) Langf(format string, args ...any) *OlElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OlElement) IfLang(condition bool, s string) *OlElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1500,7 +1447,7 @@ func (e *OlElement) IfLang(condition bool, s string) *OlElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OlElement) IfLangf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1512,10 +1459,8 @@ func (e *OlElement) IfLangf(condition bool, format string, args ...any) *OlEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *OlElement) LangRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1525,10 +1470,8 @@ func (e *OlElement) LangRemove() *OlElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *OlElement) Nonce(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1548,7 +1491,7 @@ func (e *OlElement) Noncef(format string, args ...any) *OlElement {
 // execute.
 func (e *OlElement) IfNonce(condition bool, s string) *OlElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1560,7 +1503,7 @@ func (e *OlElement) IfNonce(condition bool, s string) *OlElement {
 // execute.
 func (e *OlElement) IfNoncef(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1572,10 +1515,8 @@ func (e *OlElement) IfNoncef(condition bool, format string, args ...any) *OlElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *OlElement) NonceRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1583,16 +1524,9 @@ func (e *OlElement) NonceRemove() *OlElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *OlElement) Part(s string) *OlElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1601,7 +1535,7 @@ func (e *OlElement) Part(s string) *OlElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *OlElement) IfPart(condition bool, s string) *OlElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1611,14 +1545,8 @@ func (e *OlElement) IfPart(condition bool, s string) *OlElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *OlElement) PartRemove(s ...string) *OlElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1629,10 +1557,8 @@ func (e *OlElement) PartRemove(s ...string) *OlElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *OlElement) Popover(c OlPopoverChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[OlPopoverChoice])
 	return e
 }
 
@@ -1660,10 +1586,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *OlElement) PopoverRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1672,10 +1596,8 @@ func (e *OlElement) PopoverRemove() *OlElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *OlElement) Role(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1693,7 +1615,7 @@ func (e *OlElement) Rolef(format string, args ...any) *OlElement {
 // role of an element.
 func (e *OlElement) IfRole(condition bool, s string) *OlElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1704,7 +1626,7 @@ func (e *OlElement) IfRole(condition bool, s string) *OlElement {
 // role of an element.
 func (e *OlElement) IfRolef(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1715,10 +1637,8 @@ func (e *OlElement) IfRolef(condition bool, format string, args ...any) *OlEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *OlElement) RoleRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1727,10 +1647,8 @@ func (e *OlElement) RoleRemove() *OlElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *OlElement) Slot(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1748,7 +1666,7 @@ func (e *OlElement) Slotf(format string, args ...any) *OlElement {
 // value.
 func (e *OlElement) IfSlot(condition bool, s string) *OlElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1759,7 +1677,7 @@ func (e *OlElement) IfSlot(condition bool, s string) *OlElement {
 // value.
 func (e *OlElement) IfSlotf(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1770,10 +1688,8 @@ func (e *OlElement) IfSlotf(condition bool, format string, args ...any) *OlEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *OlElement) SlotRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1789,10 +1705,8 @@ func (e *OlElement) SlotRemove() *OlElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *OlElement) Spellcheck(c OlSpellcheckChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[OlSpellcheckChoice])
 	return e
 }
 
@@ -1820,10 +1734,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *OlElement) SpellcheckRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1833,14 +1745,8 @@ func (e *OlElement) StylePairs(pairs ...string) *OlElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1855,14 +1761,8 @@ func (e *OlElement) StylePairs(pairs ...string) *OlElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OlElement) Style(s string) *OlElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1870,7 +1770,7 @@ func (e *OlElement) Style(s string) *OlElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1879,7 +1779,7 @@ func (e *OlElement) Style(s string) *OlElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *OlElement) IfStyle(condition bool, s string) *OlElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1887,15 +1787,7 @@ func (e *OlElement) IfStyle(condition bool, s string) *OlElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OlElement) StyleAdd(k string, v string) *OlElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1909,7 +1801,7 @@ func (e *OlElement) StyleAddf(k string, format string, args ...any) *OlElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *OlElement) IfStyleAdd(condition bool, k string, v string) *OlElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1918,7 +1810,7 @@ func (e *OlElement) IfStyleAdd(condition bool, k string, v string) *OlElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *OlElement) IfStyleAddf(condition bool, k string, format string, args ...any) *OlElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1927,21 +1819,15 @@ func (e *OlElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *OlElement) StyleMap(m map[string]string) *OlElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1950,14 +1836,8 @@ func (e *OlElement) StyleMap(m map[string]string) *OlElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *OlElement) StyleRemove(keys ...string) *OlElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1975,10 +1855,8 @@ func (e *OlElement) StyleRemove(keys ...string) *OlElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *OlElement) Tabindex(i int) *OlElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1997,7 +1875,7 @@ func (e *OlElement) Tabindex(i int) *OlElement {
 // their relative position in the document.
 func (e *OlElement) IfTabindex(condition bool, i int) *OlElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2017,10 +1895,8 @@ func (e *OlElement) IfTabindex(condition bool, i int) *OlElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *OlElement) TabindexRemove() *OlElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2041,10 +1917,8 @@ func (e *OlElement) TabindexRemove() *OlElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *OlElement) Title(s string) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2086,7 +1960,7 @@ func (e *OlElement) Titlef(format string, args ...any) *OlElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OlElement) IfTitle(condition bool, s string) *OlElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2109,7 +1983,7 @@ func (e *OlElement) IfTitle(condition bool, s string) *OlElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OlElement) IfTitlef(condition bool, format string, args ...any) *OlElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2132,10 +2006,8 @@ func (e *OlElement) IfTitlef(condition bool, format string, args ...any) *OlElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *OlElement) TitleRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2144,10 +2016,8 @@ func (e *OlElement) TitleRemove() *OlElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *OlElement) Translate(c OlTranslateChoice) *OlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[OlTranslateChoice])
 	return e
 }
 
@@ -2168,9 +2038,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *OlElement) TranslateRemove() *OlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
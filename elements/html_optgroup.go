@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <optgroup> element creates a grouping of options within a <select>
@@ -22,18 +20,77 @@ type OptgroupElement struct {
 // with the tag "optgroup" during rendering.
 func Optgroup(children ...ElementRenderer) *OptgroupElement {
 	e := NewElement("optgroup", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &OptgroupElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *OptgroupElement) Clone() *OptgroupElement {
+	return &OptgroupElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *OptgroupElement) CloneShallow() *OptgroupElement {
+	return &OptgroupElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *OptgroupElement) Freeze() *OptgroupElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *OptgroupElement) writable() *OptgroupElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *OptgroupElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *OptgroupElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[OptgroupAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[OptgroupContenteditableChoice]
+	case "dir":
+		return choiceValue[OptgroupDirChoice]
+	case "draggable":
+		return choiceValue[OptgroupDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[OptgroupEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[OptgroupHiddenChoice]
+	case "inputmode":
+		return choiceValue[OptgroupInputmodeChoice]
+	case "popover":
+		return choiceValue[OptgroupPopoverChoice]
+	case "spellcheck":
+		return choiceValue[OptgroupSpellcheckChoice]
+	case "translate":
+		return choiceValue[OptgroupTranslateChoice]
+	}
+	return nil
+}
+
 func (e *OptgroupElement) Children(children ...ElementRenderer) *OptgroupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *OptgroupElement) IfChildren(condition bool, children ...ElementRenderer) *OptgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *OptgroupElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *OptgroupElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *OptgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *OptgroupElement) BoolAttr(name string) *OptgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *OptgroupElement) BoolAttrRemove(name string) *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *OptgroupElement) IfBoolAttr(condition bool, name string) *OptgroupElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *OptgroupElement) BoolAttrf(format string, args ...any) *OptgroupElement
 
 func (e *OptgroupElement) IfBoolAttrf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *OptgroupElement) BoolAttrs(names ...string) *OptgroupElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *OptgroupElement) IfBoolAttrs(condition bool, names ...string) *OptgroupElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *OptgroupElement) Attr(name, value string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *OptgroupElement) RawAttr(name, value string) *OptgroupElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *OptgroupElement) IfAttr(condition bool, name, value string) *OptgroupElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *OptgroupElement) Attrf(name, format string, args ...any) *OptgroupEleme
 
 func (e *OptgroupElement) IfAttrf(condition bool, name, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *OptgroupElement) Attrs(attrs ...string) *OptgroupElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *OptgroupElement) IfAttrs(condition bool, attrs ...string) *OptgroupElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *OptgroupElement) AttrsMap(attrs map[string]string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *OptgroupElement) IfAttrsMap(condition bool, attrs map[string]string) *OptgroupElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *OptgroupElement) Text(text string) *OptgroupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *OptgroupElement) Textf(format string, args ...any) *OptgroupElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *OptgroupElement) IfText(condition bool, text string) *OptgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *OptgroupElement) IfText(condition bool, text string) *OptgroupElement {
 
 func (e *OptgroupElement) IfTextf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *OptgroupElement) Raw(text string) *OptgroupElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *OptgroupElement) Escaped(text string) *OptgroupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *OptgroupElement) IfEscaped(condition bool, text string) *OptgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *OptgroupElement) Escapedf(format string, args ...any) *OptgroupElement
 
 func (e *OptgroupElement) IfEscapedf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,17 +270,15 @@ func (e *OptgroupElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Whether the form control is disabled.
 func (e *OptgroupElement) Disabled() *OptgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *OptgroupElement) IfDisabled(condition bool) *OptgroupElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -227,29 +286,23 @@ func (e *OptgroupElement) IfDisabled(condition bool) *OptgroupElement {
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *OptgroupElement) DisabledRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *OptgroupElement) DisabledIfRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // User-visible label to give the option group a caption.
 func (e *OptgroupElement) Label(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("label", s)
+	e = e.writable()
+	e.setStringAttribute("label", s)
 	return e
 }
 
@@ -261,7 +314,7 @@ func (e *OptgroupElement) Labelf(format string, args ...any) *OptgroupElement {
 // User-visible label to give the option group a caption.
 func (e *OptgroupElement) IfLabel(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Label(s)
+		e = e.Label(s)
 	}
 	return e
 }
@@ -269,7 +322,7 @@ func (e *OptgroupElement) IfLabel(condition bool, s string) *OptgroupElement {
 // User-visible label to give the option group a caption.
 func (e *OptgroupElement) IfLabelf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Label(fmt.Sprintf(format, args...))
+		e = e.Label(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -277,10 +330,8 @@ func (e *OptgroupElement) IfLabelf(condition bool, format string, args ...any) *
 // User-visible label to give the option group a caption.
 // Remove the attribute Label from the element.
 func (e *OptgroupElement) LabelRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("label")
+	e = e.writable()
+	e.removeAttribute("label")
 	return e
 }
 
@@ -289,10 +340,8 @@ func (e *OptgroupElement) LabelRemove() *OptgroupElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *OptgroupElement) Accesskey(r rune) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -302,7 +351,7 @@ func (e *OptgroupElement) Accesskey(r rune) *OptgroupElement {
 // can be generated by the keyboard).
 func (e *OptgroupElement) IfAccesskey(condition bool, r rune) *OptgroupElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -313,10 +362,8 @@ func (e *OptgroupElement) IfAccesskey(condition bool, r rune) *OptgroupElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *OptgroupElement) AccesskeyRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -332,10 +379,8 @@ func (e *OptgroupElement) AccesskeyRemove() *OptgroupElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *OptgroupElement) Autocapitalize(c OptgroupAutocapitalizeChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[OptgroupAutocapitalizeChoice])
 	return e
 }
 
@@ -369,10 +414,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *OptgroupElement) AutocapitalizeRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -391,10 +434,8 @@ func (e *OptgroupElement) AutocapitalizeRemove() *OptgroupElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OptgroupElement) Autofocus() *OptgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -414,7 +455,7 @@ func (e *OptgroupElement) Autofocus() *OptgroupElement {
 // created by the preceding content.
 func (e *OptgroupElement) IfAutofocus(condition bool) *OptgroupElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -435,10 +476,8 @@ func (e *OptgroupElement) IfAutofocus(condition bool) *OptgroupElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OptgroupElement) AutofocusRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -458,10 +497,8 @@ func (e *OptgroupElement) AutofocusRemove() *OptgroupElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OptgroupElement) AutofocusIfRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -470,16 +507,9 @@ func (e *OptgroupElement) AutofocusIfRemove() *OptgroupElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *OptgroupElement) Class(s string) *OptgroupElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -489,7 +519,7 @@ func (e *OptgroupElement) Class(s string) *OptgroupElement {
 // document.getElementsByClassName.
 func (e *OptgroupElement) IfClass(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -500,14 +530,8 @@ func (e *OptgroupElement) IfClass(condition bool, s string) *OptgroupElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *OptgroupElement) ClassRemove(s ...string) *OptgroupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -515,10 +539,8 @@ func (e *OptgroupElement) ClassRemove(s ...string) *OptgroupElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *OptgroupElement) Contenteditable(c OptgroupContenteditableChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[OptgroupContenteditableChoice])
 	return e
 }
 
@@ -541,10 +563,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *OptgroupElement) ContenteditableRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -562,10 +582,8 @@ func (e *OptgroupElement) ContenteditableRemove() *OptgroupElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *OptgroupElement) Dir(c OptgroupDirChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[OptgroupDirChoice])
 	return e
 }
 
@@ -599,10 +617,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *OptgroupElement) DirRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -610,10 +626,8 @@ func (e *OptgroupElement) DirRemove() *OptgroupElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *OptgroupElement) Draggable(c OptgroupDraggableChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[OptgroupDraggableChoice])
 	return e
 }
 
@@ -639,20 +653,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *OptgroupElement) DraggableRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *OptgroupElement) Enterkeyhint(c OptgroupEnterkeyhintChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[OptgroupEnterkeyhintChoice])
 	return e
 }
 
@@ -682,10 +692,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *OptgroupElement) EnterkeyhintRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -707,16 +715,9 @@ func (e *OptgroupElement) EnterkeyhintRemove() *OptgroupElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *OptgroupElement) Exportparts(s string) *OptgroupElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -739,7 +740,7 @@ func (e *OptgroupElement) Exportparts(s string) *OptgroupElement {
 // the current structure.
 func (e *OptgroupElement) IfExportparts(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -763,14 +764,8 @@ func (e *OptgroupElement) IfExportparts(condition bool, s string) *OptgroupEleme
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *OptgroupElement) ExportpartsRemove(s ...string) *OptgroupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -787,10 +782,8 @@ func (e *OptgroupElement) ExportpartsRemove(s ...string) *OptgroupElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *OptgroupElement) Hidden(c OptgroupHiddenChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[OptgroupHiddenChoice])
 	return e
 }
 
@@ -826,10 +819,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *OptgroupElement) HiddenRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -837,10 +828,8 @@ func (e *OptgroupElement) HiddenRemove() *OptgroupElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OptgroupElement) ID(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -856,7 +845,7 @@ func (e *OptgroupElement) IDf(format string, args ...any) *OptgroupElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OptgroupElement) IfID(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -866,7 +855,7 @@ func (e *OptgroupElement) IfID(condition bool, s string) *OptgroupElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OptgroupElement) IfIDf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -876,10 +865,8 @@ func (e *OptgroupElement) IfIDf(condition bool, format string, args ...any) *Opt
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *OptgroupElement) IDRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -894,10 +881,8 @@ func (e *OptgroupElement) IDRemove() *OptgroupElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OptgroupElement) Inert() *OptgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -913,7 +898,7 @@ func (e *OptgroupElement) Inert() *OptgroupElement {
 // excluding them from the accessibility tree.
 func (e *OptgroupElement) IfInert(condition bool) *OptgroupElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -930,10 +915,8 @@ func (e *OptgroupElement) IfInert(condition bool) *OptgroupElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OptgroupElement) InertRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -949,10 +932,8 @@ func (e *OptgroupElement) InertRemove() *OptgroupElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OptgroupElement) InertIfRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -966,10 +947,8 @@ func (e *OptgroupElement) InertIfRemove() *OptgroupElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *OptgroupElement) Inputmode(c OptgroupInputmodeChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[OptgroupInputmodeChoice])
 	return e
 }
 
@@ -1020,10 +999,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *OptgroupElement) InputmodeRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1033,10 +1010,8 @@ func (e *OptgroupElement) InputmodeRemove() *OptgroupElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *OptgroupElement) Is(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1056,7 +1031,7 @@ func (e *OptgroupElement) Isf(format string, args ...any) *OptgroupElement {
 // and extends the element type it is being applied to.
 func (e *OptgroupElement) IfIs(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1068,7 +1043,7 @@ func (e *OptgroupElement) IfIs(condition bool, s string) *OptgroupElement {
 // and extends the element type it is being applied to.
 func (e *OptgroupElement) IfIsf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1080,10 +1055,8 @@ func (e *OptgroupElement) IfIsf(condition bool, format string, args ...any) *Opt
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *OptgroupElement) IsRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1099,10 +1072,8 @@ func (e *OptgroupElement) IsRemove() *OptgroupElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *OptgroupElement) Itemid(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1134,7 +1105,7 @@ func (e *OptgroupElement) Itemidf(format string, args ...any) *OptgroupElement {
 // how items with the same identifier are handled.
 func (e *OptgroupElement) IfItemid(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1152,7 +1123,7 @@ func (e *OptgroupElement) IfItemid(condition bool, s string) *OptgroupElement {
 // how items with the same identifier are handled.
 func (e *OptgroupElement) IfItemidf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1170,10 +1141,8 @@ func (e *OptgroupElement) IfItemidf(condition bool, format string, args ...any)
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *OptgroupElement) ItemidRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1185,10 +1154,8 @@ func (e *OptgroupElement) ItemidRemove() *OptgroupElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *OptgroupElement) Itemprop(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1212,7 +1179,7 @@ func (e *OptgroupElement) Itempropf(format string, args ...any) *OptgroupElement
 // <track>, and <video>.
 func (e *OptgroupElement) IfItemprop(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1226,7 +1193,7 @@ func (e *OptgroupElement) IfItemprop(condition bool, s string) *OptgroupElement
 // <track>, and <video>.
 func (e *OptgroupElement) IfItempropf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1240,10 +1207,8 @@ func (e *OptgroupElement) IfItempropf(condition bool, format string, args ...any
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *OptgroupElement) ItempropRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1253,10 +1218,8 @@ func (e *OptgroupElement) ItempropRemove() *OptgroupElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *OptgroupElement) Itemref(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1276,7 +1239,7 @@ func (e *OptgroupElement) Itemreff(format string, args ...any) *OptgroupElement
 // specified on elements that have an itemscope attribute specified.
 func (e *OptgroupElement) IfItemref(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1288,7 +1251,7 @@ func (e *OptgroupElement) IfItemref(condition bool, s string) *OptgroupElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OptgroupElement) IfItemreff(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1300,10 +1263,8 @@ func (e *OptgroupElement) IfItemreff(condition bool, format string, args ...any)
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *OptgroupElement) ItemrefRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1315,10 +1276,8 @@ func (e *OptgroupElement) ItemrefRemove() *OptgroupElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OptgroupElement) Itemscope() *OptgroupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1331,7 +1290,7 @@ func (e *OptgroupElement) Itemscope() *OptgroupElement {
 // <object>, <source>, <track>, and <video>.
 func (e *OptgroupElement) IfItemscope(condition bool) *OptgroupElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1345,10 +1304,8 @@ func (e *OptgroupElement) IfItemscope(condition bool) *OptgroupElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OptgroupElement) ItemscopeRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1361,10 +1318,8 @@ func (e *OptgroupElement) ItemscopeRemove() *OptgroupElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OptgroupElement) ItemscopeIfRemove() *OptgroupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1376,10 +1331,8 @@ func (e *OptgroupElement) ItemscopeIfRemove() *OptgroupElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *OptgroupElement) Itemtype(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1403,7 +1356,7 @@ func (e *OptgroupElement) Itemtypef(format string, args ...any) *OptgroupElement
 // <video>.
 func (e *OptgroupElement) IfItemtype(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1417,7 +1370,7 @@ func (e *OptgroupElement) IfItemtype(condition bool, s string) *OptgroupElement
 // <video>.
 func (e *OptgroupElement) IfItemtypef(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1431,10 +1384,8 @@ func (e *OptgroupElement) IfItemtypef(condition bool, format string, args ...any
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *OptgroupElement) ItemtypeRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1444,10 +1395,8 @@ func (e *OptgroupElement) ItemtypeRemove() *OptgroupElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OptgroupElement) Lang(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1467,7 +1416,7 @@ func (e *OptgroupElement) Langf(format string, args ...any) *OptgroupElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OptgroupElement) IfLang(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1479,7 +1428,7 @@ func (e *OptgroupElement) IfLang(condition bool, s string) *OptgroupElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OptgroupElement) IfLangf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1491,10 +1440,8 @@ func (e *OptgroupElement) IfLangf(condition bool, format string, args ...any) *O
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *OptgroupElement) LangRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1504,10 +1451,8 @@ func (e *OptgroupElement) LangRemove() *OptgroupElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *OptgroupElement) Nonce(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1527,7 +1472,7 @@ func (e *OptgroupElement) Noncef(format string, args ...any) *OptgroupElement {
 // execute.
 func (e *OptgroupElement) IfNonce(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1539,7 +1484,7 @@ func (e *OptgroupElement) IfNonce(condition bool, s string) *OptgroupElement {
 // execute.
 func (e *OptgroupElement) IfNoncef(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1551,10 +1496,8 @@ func (e *OptgroupElement) IfNoncef(condition bool, format string, args ...any) *
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *OptgroupElement) NonceRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1562,16 +1505,9 @@ func (e *OptgroupElement) NonceRemove() *OptgroupElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *OptgroupElement) Part(s string) *OptgroupElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1580,7 +1516,7 @@ func (e *OptgroupElement) Part(s string) *OptgroupElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *OptgroupElement) IfPart(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1590,14 +1526,8 @@ func (e *OptgroupElement) IfPart(condition bool, s string) *OptgroupElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *OptgroupElement) PartRemove(s ...string) *OptgroupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1608,10 +1538,8 @@ func (e *OptgroupElement) PartRemove(s ...string) *OptgroupElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *OptgroupElement) Popover(c OptgroupPopoverChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[OptgroupPopoverChoice])
 	return e
 }
 
@@ -1639,10 +1567,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *OptgroupElement) PopoverRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1651,10 +1577,8 @@ func (e *OptgroupElement) PopoverRemove() *OptgroupElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *OptgroupElement) Role(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1672,7 +1596,7 @@ func (e *OptgroupElement) Rolef(format string, args ...any) *OptgroupElement {
 // role of an element.
 func (e *OptgroupElement) IfRole(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1683,7 +1607,7 @@ func (e *OptgroupElement) IfRole(condition bool, s string) *OptgroupElement {
 // role of an element.
 func (e *OptgroupElement) IfRolef(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1694,10 +1618,8 @@ func (e *OptgroupElement) IfRolef(condition bool, format string, args ...any) *O
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *OptgroupElement) RoleRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1706,10 +1628,8 @@ func (e *OptgroupElement) RoleRemove() *OptgroupElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *OptgroupElement) Slot(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1727,7 +1647,7 @@ func (e *OptgroupElement) Slotf(format string, args ...any) *OptgroupElement {
 // value.
 func (e *OptgroupElement) IfSlot(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1738,7 +1658,7 @@ func (e *OptgroupElement) IfSlot(condition bool, s string) *OptgroupElement {
 // value.
 func (e *OptgroupElement) IfSlotf(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1749,10 +1669,8 @@ func (e *OptgroupElement) IfSlotf(condition bool, format string, args ...any) *O
 // value.
 // Remove the attribute Slot from the element.
 func (e *OptgroupElement) SlotRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1768,10 +1686,8 @@ func (e *OptgroupElement) SlotRemove() *OptgroupElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *OptgroupElement) Spellcheck(c OptgroupSpellcheckChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[OptgroupSpellcheckChoice])
 	return e
 }
 
@@ -1799,10 +1715,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *OptgroupElement) SpellcheckRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1812,14 +1726,8 @@ func (e *OptgroupElement) StylePairs(pairs ...string) *OptgroupElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1834,14 +1742,8 @@ func (e *OptgroupElement) StylePairs(pairs ...string) *OptgroupElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptgroupElement) Style(s string) *OptgroupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1849,7 +1751,7 @@ func (e *OptgroupElement) Style(s string) *OptgroupElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1858,7 +1760,7 @@ func (e *OptgroupElement) Style(s string) *OptgroupElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptgroupElement) IfStyle(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1866,15 +1768,7 @@ func (e *OptgroupElement) IfStyle(condition bool, s string) *OptgroupElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptgroupElement) StyleAdd(k string, v string) *OptgroupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1888,7 +1782,7 @@ func (e *OptgroupElement) StyleAddf(k string, format string, args ...any) *Optgr
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptgroupElement) IfStyleAdd(condition bool, k string, v string) *OptgroupElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1897,7 +1791,7 @@ func (e *OptgroupElement) IfStyleAdd(condition bool, k string, v string) *Optgro
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptgroupElement) IfStyleAddf(condition bool, k string, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1906,21 +1800,15 @@ func (e *OptgroupElement) IfStyleAddf(condition bool, k string, format string, a
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *OptgroupElement) StyleMap(m map[string]string) *OptgroupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1929,14 +1817,8 @@ func (e *OptgroupElement) StyleMap(m map[string]string) *OptgroupElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *OptgroupElement) StyleRemove(keys ...string) *OptgroupElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1954,10 +1836,8 @@ func (e *OptgroupElement) StyleRemove(keys ...string) *OptgroupElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *OptgroupElement) Tabindex(i int) *OptgroupElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1976,7 +1856,7 @@ func (e *OptgroupElement) Tabindex(i int) *OptgroupElement {
 // their relative position in the document.
 func (e *OptgroupElement) IfTabindex(condition bool, i int) *OptgroupElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1996,10 +1876,8 @@ func (e *OptgroupElement) IfTabindex(condition bool, i int) *OptgroupElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *OptgroupElement) TabindexRemove() *OptgroupElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2020,10 +1898,8 @@ func (e *OptgroupElement) TabindexRemove() *OptgroupElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *OptgroupElement) Title(s string) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2065,7 +1941,7 @@ func (e *OptgroupElement) Titlef(format string, args ...any) *OptgroupElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OptgroupElement) IfTitle(condition bool, s string) *OptgroupElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2088,7 +1964,7 @@ func (e *OptgroupElement) IfTitle(condition bool, s string) *OptgroupElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OptgroupElement) IfTitlef(condition bool, format string, args ...any) *OptgroupElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2111,10 +1987,8 @@ func (e *OptgroupElement) IfTitlef(condition bool, format string, args ...any) *
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *OptgroupElement) TitleRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2123,10 +1997,8 @@ func (e *OptgroupElement) TitleRemove() *OptgroupElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *OptgroupElement) Translate(c OptgroupTranslateChoice) *OptgroupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[OptgroupTranslateChoice])
 	return e
 }
 
@@ -2147,9 +2019,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *OptgroupElement) TranslateRemove() *OptgroupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
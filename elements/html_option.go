@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <option> element is used to define an item contained in a <select>,
@@ -23,18 +21,77 @@ type OptionElement struct {
 // with the tag "option" during rendering.
 func Option(children ...ElementRenderer) *OptionElement {
 	e := NewElement("option", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &OptionElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *OptionElement) Clone() *OptionElement {
+	return &OptionElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *OptionElement) CloneShallow() *OptionElement {
+	return &OptionElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *OptionElement) Freeze() *OptionElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *OptionElement) writable() *OptionElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *OptionElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *OptionElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[OptionAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[OptionContenteditableChoice]
+	case "dir":
+		return choiceValue[OptionDirChoice]
+	case "draggable":
+		return choiceValue[OptionDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[OptionEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[OptionHiddenChoice]
+	case "inputmode":
+		return choiceValue[OptionInputmodeChoice]
+	case "popover":
+		return choiceValue[OptionPopoverChoice]
+	case "spellcheck":
+		return choiceValue[OptionSpellcheckChoice]
+	case "translate":
+		return choiceValue[OptionTranslateChoice]
+	}
+	return nil
+}
+
 func (e *OptionElement) Children(children ...ElementRenderer) *OptionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *OptionElement) IfChildren(condition bool, children ...ElementRenderer) *OptionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *OptionElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *OptionElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *OptionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *OptionElement) BoolAttr(name string) *OptionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *OptionElement) BoolAttrRemove(name string) *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *OptionElement) IfBoolAttr(condition bool, name string) *OptionElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *OptionElement) BoolAttrf(format string, args ...any) *OptionElement {
 
 func (e *OptionElement) IfBoolAttrf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *OptionElement) BoolAttrs(names ...string) *OptionElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *OptionElement) IfBoolAttrs(condition bool, names ...string) *OptionElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *OptionElement) Attr(name, value string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *OptionElement) RawAttr(name, value string) *OptionElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *OptionElement) IfAttr(condition bool, name, value string) *OptionElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *OptionElement) Attrf(name, format string, args ...any) *OptionElement {
 
 func (e *OptionElement) IfAttrf(condition bool, name, format string, args ...any) *OptionElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *OptionElement) Attrs(attrs ...string) *OptionElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *OptionElement) IfAttrs(condition bool, attrs ...string) *OptionElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *OptionElement) AttrsMap(attrs map[string]string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *OptionElement) IfAttrsMap(condition bool, attrs map[string]string) *OptionElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *OptionElement) Text(text string) *OptionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *OptionElement) Textf(format string, args ...any) *OptionElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *OptionElement) IfText(condition bool, text string) *OptionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *OptionElement) IfText(condition bool, text string) *OptionElement {
 
 func (e *OptionElement) IfTextf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *OptionElement) Raw(text string) *OptionElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *OptionElement) Escaped(text string) *OptionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *OptionElement) IfEscaped(condition bool, text string) *OptionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *OptionElement) Escapedf(format string, args ...any) *OptionElement {
 
 func (e *OptionElement) IfEscapedf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +271,15 @@ func (e *OptionElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Whether the form control is disabled.
 func (e *OptionElement) Disabled() *OptionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *OptionElement) IfDisabled(condition bool) *OptionElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -228,29 +287,23 @@ func (e *OptionElement) IfDisabled(condition bool) *OptionElement {
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *OptionElement) DisabledRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *OptionElement) DisabledIfRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // User-visible label to give the option a caption.
 func (e *OptionElement) Label(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("label", s)
+	e = e.writable()
+	e.setStringAttribute("label", s)
 	return e
 }
 
@@ -262,7 +315,7 @@ func (e *OptionElement) Labelf(format string, args ...any) *OptionElement {
 // User-visible label to give the option a caption.
 func (e *OptionElement) IfLabel(condition bool, s string) *OptionElement {
 	if condition {
-		e.Label(s)
+		e = e.Label(s)
 	}
 	return e
 }
@@ -270,7 +323,7 @@ func (e *OptionElement) IfLabel(condition bool, s string) *OptionElement {
 // User-visible label to give the option a caption.
 func (e *OptionElement) IfLabelf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Label(fmt.Sprintf(format, args...))
+		e = e.Label(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -278,26 +331,22 @@ func (e *OptionElement) IfLabelf(condition bool, format string, args ...any) *Op
 // User-visible label to give the option a caption.
 // Remove the attribute Label from the element.
 func (e *OptionElement) LabelRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("label")
+	e = e.writable()
+	e.removeAttribute("label")
 	return e
 }
 
 // Whether the option is selected by default.
 func (e *OptionElement) Selected() *OptionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("selected", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("selected")
 	return e
 }
 
 // Whether the option is selected by default.
 func (e *OptionElement) IfSelected(condition bool) *OptionElement {
 	if condition {
-		e.Selected()
+		e = e.Selected()
 	}
 	return e
 }
@@ -305,29 +354,23 @@ func (e *OptionElement) IfSelected(condition bool) *OptionElement {
 // Remove the attribute Selected from the element.
 // Whether the option is selected by default.
 func (e *OptionElement) SelectedRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("selected")
+	e = e.writable()
+	e.removeAttribute("selected")
 	return e
 }
 
 // Remove the attribute Selected from the element.
 // Whether the option is selected by default.
 func (e *OptionElement) SelectedIfRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("selected")
+	e = e.writable()
+	e.removeAttribute("selected")
 	return e
 }
 
 // Value to be used for form submission.
 func (e *OptionElement) Value(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("value", s)
+	e = e.writable()
+	e.setStringAttribute("value", s)
 	return e
 }
 
@@ -339,7 +382,7 @@ func (e *OptionElement) Valuef(format string, args ...any) *OptionElement {
 // Value to be used for form submission.
 func (e *OptionElement) IfValue(condition bool, s string) *OptionElement {
 	if condition {
-		e.Value(s)
+		e = e.Value(s)
 	}
 	return e
 }
@@ -347,7 +390,7 @@ func (e *OptionElement) IfValue(condition bool, s string) *OptionElement {
 // Value to be used for form submission.
 func (e *OptionElement) IfValuef(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Value(fmt.Sprintf(format, args...))
+		e = e.Value(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -355,10 +398,8 @@ func (e *OptionElement) IfValuef(condition bool, format string, args ...any) *Op
 // Value to be used for form submission.
 // Remove the attribute Value from the element.
 func (e *OptionElement) ValueRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("value")
+	e = e.writable()
+	e.removeAttribute("value")
 	return e
 }
 
@@ -367,10 +408,8 @@ func (e *OptionElement) ValueRemove() *OptionElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *OptionElement) Accesskey(r rune) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -380,7 +419,7 @@ func (e *OptionElement) Accesskey(r rune) *OptionElement {
 // can be generated by the keyboard).
 func (e *OptionElement) IfAccesskey(condition bool, r rune) *OptionElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -391,10 +430,8 @@ func (e *OptionElement) IfAccesskey(condition bool, r rune) *OptionElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *OptionElement) AccesskeyRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -410,10 +447,8 @@ func (e *OptionElement) AccesskeyRemove() *OptionElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *OptionElement) Autocapitalize(c OptionAutocapitalizeChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[OptionAutocapitalizeChoice])
 	return e
 }
 
@@ -447,10 +482,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *OptionElement) AutocapitalizeRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -469,10 +502,8 @@ func (e *OptionElement) AutocapitalizeRemove() *OptionElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OptionElement) Autofocus() *OptionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -492,7 +523,7 @@ func (e *OptionElement) Autofocus() *OptionElement {
 // created by the preceding content.
 func (e *OptionElement) IfAutofocus(condition bool) *OptionElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -513,10 +544,8 @@ func (e *OptionElement) IfAutofocus(condition bool) *OptionElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OptionElement) AutofocusRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -536,10 +565,8 @@ func (e *OptionElement) AutofocusRemove() *OptionElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OptionElement) AutofocusIfRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -548,16 +575,9 @@ func (e *OptionElement) AutofocusIfRemove() *OptionElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *OptionElement) Class(s string) *OptionElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -567,7 +587,7 @@ func (e *OptionElement) Class(s string) *OptionElement {
 // document.getElementsByClassName.
 func (e *OptionElement) IfClass(condition bool, s string) *OptionElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -578,14 +598,8 @@ func (e *OptionElement) IfClass(condition bool, s string) *OptionElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *OptionElement) ClassRemove(s ...string) *OptionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -593,10 +607,8 @@ func (e *OptionElement) ClassRemove(s ...string) *OptionElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *OptionElement) Contenteditable(c OptionContenteditableChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[OptionContenteditableChoice])
 	return e
 }
 
@@ -619,10 +631,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *OptionElement) ContenteditableRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -640,10 +650,8 @@ func (e *OptionElement) ContenteditableRemove() *OptionElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *OptionElement) Dir(c OptionDirChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[OptionDirChoice])
 	return e
 }
 
@@ -677,10 +685,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *OptionElement) DirRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -688,10 +694,8 @@ func (e *OptionElement) DirRemove() *OptionElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *OptionElement) Draggable(c OptionDraggableChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[OptionDraggableChoice])
 	return e
 }
 
@@ -717,20 +721,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *OptionElement) DraggableRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *OptionElement) Enterkeyhint(c OptionEnterkeyhintChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[OptionEnterkeyhintChoice])
 	return e
 }
 
@@ -760,10 +760,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *OptionElement) EnterkeyhintRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -785,16 +783,9 @@ func (e *OptionElement) EnterkeyhintRemove() *OptionElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *OptionElement) Exportparts(s string) *OptionElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -817,7 +808,7 @@ func (e *OptionElement) Exportparts(s string) *OptionElement {
 // the current structure.
 func (e *OptionElement) IfExportparts(condition bool, s string) *OptionElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -841,14 +832,8 @@ func (e *OptionElement) IfExportparts(condition bool, s string) *OptionElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *OptionElement) ExportpartsRemove(s ...string) *OptionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -865,10 +850,8 @@ func (e *OptionElement) ExportpartsRemove(s ...string) *OptionElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *OptionElement) Hidden(c OptionHiddenChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[OptionHiddenChoice])
 	return e
 }
 
@@ -904,10 +887,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *OptionElement) HiddenRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -915,10 +896,8 @@ func (e *OptionElement) HiddenRemove() *OptionElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OptionElement) ID(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -934,7 +913,7 @@ func (e *OptionElement) IDf(format string, args ...any) *OptionElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OptionElement) IfID(condition bool, s string) *OptionElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -944,7 +923,7 @@ func (e *OptionElement) IfID(condition bool, s string) *OptionElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OptionElement) IfIDf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -954,10 +933,8 @@ func (e *OptionElement) IfIDf(condition bool, format string, args ...any) *Optio
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *OptionElement) IDRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -972,10 +949,8 @@ func (e *OptionElement) IDRemove() *OptionElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OptionElement) Inert() *OptionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -991,7 +966,7 @@ func (e *OptionElement) Inert() *OptionElement {
 // excluding them from the accessibility tree.
 func (e *OptionElement) IfInert(condition bool) *OptionElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1008,10 +983,8 @@ func (e *OptionElement) IfInert(condition bool) *OptionElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OptionElement) InertRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1027,10 +1000,8 @@ func (e *OptionElement) InertRemove() *OptionElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OptionElement) InertIfRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1044,10 +1015,8 @@ func (e *OptionElement) InertIfRemove() *OptionElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *OptionElement) Inputmode(c OptionInputmodeChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[OptionInputmodeChoice])
 	return e
 }
 
@@ -1098,10 +1067,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *OptionElement) InputmodeRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1111,10 +1078,8 @@ func (e *OptionElement) InputmodeRemove() *OptionElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *OptionElement) Is(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1134,7 +1099,7 @@ func (e *OptionElement) Isf(format string, args ...any) *OptionElement {
 // and extends the element type it is being applied to.
 func (e *OptionElement) IfIs(condition bool, s string) *OptionElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1146,7 +1111,7 @@ func (e *OptionElement) IfIs(condition bool, s string) *OptionElement {
 // and extends the element type it is being applied to.
 func (e *OptionElement) IfIsf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1158,10 +1123,8 @@ func (e *OptionElement) IfIsf(condition bool, format string, args ...any) *Optio
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *OptionElement) IsRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1177,10 +1140,8 @@ func (e *OptionElement) IsRemove() *OptionElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *OptionElement) Itemid(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1212,7 +1173,7 @@ func (e *OptionElement) Itemidf(format string, args ...any) *OptionElement {
 // how items with the same identifier are handled.
 func (e *OptionElement) IfItemid(condition bool, s string) *OptionElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1230,7 +1191,7 @@ func (e *OptionElement) IfItemid(condition bool, s string) *OptionElement {
 // how items with the same identifier are handled.
 func (e *OptionElement) IfItemidf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1248,10 +1209,8 @@ func (e *OptionElement) IfItemidf(condition bool, format string, args ...any) *O
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *OptionElement) ItemidRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1263,10 +1222,8 @@ func (e *OptionElement) ItemidRemove() *OptionElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *OptionElement) Itemprop(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1290,7 +1247,7 @@ func (e *OptionElement) Itempropf(format string, args ...any) *OptionElement {
 // <track>, and <video>.
 func (e *OptionElement) IfItemprop(condition bool, s string) *OptionElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1304,7 +1261,7 @@ func (e *OptionElement) IfItemprop(condition bool, s string) *OptionElement {
 // <track>, and <video>.
 func (e *OptionElement) IfItempropf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1318,10 +1275,8 @@ func (e *OptionElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *OptionElement) ItempropRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1331,10 +1286,8 @@ func (e *OptionElement) ItempropRemove() *OptionElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *OptionElement) Itemref(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1354,7 +1307,7 @@ func (e *OptionElement) Itemreff(format string, args ...any) *OptionElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OptionElement) IfItemref(condition bool, s string) *OptionElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1366,7 +1319,7 @@ func (e *OptionElement) IfItemref(condition bool, s string) *OptionElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OptionElement) IfItemreff(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1378,10 +1331,8 @@ func (e *OptionElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *OptionElement) ItemrefRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1393,10 +1344,8 @@ func (e *OptionElement) ItemrefRemove() *OptionElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OptionElement) Itemscope() *OptionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1409,7 +1358,7 @@ func (e *OptionElement) Itemscope() *OptionElement {
 // <object>, <source>, <track>, and <video>.
 func (e *OptionElement) IfItemscope(condition bool) *OptionElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1423,10 +1372,8 @@ func (e *OptionElement) IfItemscope(condition bool) *OptionElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OptionElement) ItemscopeRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1439,10 +1386,8 @@ func (e *OptionElement) ItemscopeRemove() *OptionElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OptionElement) ItemscopeIfRemove() *OptionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1454,10 +1399,8 @@ func (e *OptionElement) ItemscopeIfRemove() *OptionElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *OptionElement) Itemtype(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1481,7 +1424,7 @@ func (e *OptionElement) Itemtypef(format string, args ...any) *OptionElement {
 // <video>.
 func (e *OptionElement) IfItemtype(condition bool, s string) *OptionElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1495,7 +1438,7 @@ func (e *OptionElement) IfItemtype(condition bool, s string) *OptionElement {
 // <video>.
 func (e *OptionElement) IfItemtypef(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1509,10 +1452,8 @@ func (e *OptionElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *OptionElement) ItemtypeRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1522,10 +1463,8 @@ func (e *OptionElement) ItemtypeRemove() *OptionElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OptionElement) Lang(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1545,7 +1484,7 @@ func (e *OptionElement) Langf(format string, args ...any) *OptionElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OptionElement) IfLang(condition bool, s string) *OptionElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1557,7 +1496,7 @@ func (e *OptionElement) IfLang(condition bool, s string) *OptionElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OptionElement) IfLangf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1569,10 +1508,8 @@ func (e *OptionElement) IfLangf(condition bool, format string, args ...any) *Opt
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *OptionElement) LangRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1582,10 +1519,8 @@ func (e *OptionElement) LangRemove() *OptionElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *OptionElement) Nonce(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1605,7 +1540,7 @@ func (e *OptionElement) Noncef(format string, args ...any) *OptionElement {
 // execute.
 func (e *OptionElement) IfNonce(condition bool, s string) *OptionElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1617,7 +1552,7 @@ func (e *OptionElement) IfNonce(condition bool, s string) *OptionElement {
 // execute.
 func (e *OptionElement) IfNoncef(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1629,10 +1564,8 @@ func (e *OptionElement) IfNoncef(condition bool, format string, args ...any) *Op
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *OptionElement) NonceRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1640,16 +1573,9 @@ func (e *OptionElement) NonceRemove() *OptionElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *OptionElement) Part(s string) *OptionElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1658,7 +1584,7 @@ func (e *OptionElement) Part(s string) *OptionElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *OptionElement) IfPart(condition bool, s string) *OptionElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1668,14 +1594,8 @@ func (e *OptionElement) IfPart(condition bool, s string) *OptionElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *OptionElement) PartRemove(s ...string) *OptionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1686,10 +1606,8 @@ func (e *OptionElement) PartRemove(s ...string) *OptionElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *OptionElement) Popover(c OptionPopoverChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[OptionPopoverChoice])
 	return e
 }
 
@@ -1717,10 +1635,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *OptionElement) PopoverRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1729,10 +1645,8 @@ func (e *OptionElement) PopoverRemove() *OptionElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *OptionElement) Role(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1750,7 +1664,7 @@ func (e *OptionElement) Rolef(format string, args ...any) *OptionElement {
 // role of an element.
 func (e *OptionElement) IfRole(condition bool, s string) *OptionElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1761,7 +1675,7 @@ func (e *OptionElement) IfRole(condition bool, s string) *OptionElement {
 // role of an element.
 func (e *OptionElement) IfRolef(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1772,10 +1686,8 @@ func (e *OptionElement) IfRolef(condition bool, format string, args ...any) *Opt
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *OptionElement) RoleRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1784,10 +1696,8 @@ func (e *OptionElement) RoleRemove() *OptionElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *OptionElement) Slot(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1805,7 +1715,7 @@ func (e *OptionElement) Slotf(format string, args ...any) *OptionElement {
 // value.
 func (e *OptionElement) IfSlot(condition bool, s string) *OptionElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1816,7 +1726,7 @@ func (e *OptionElement) IfSlot(condition bool, s string) *OptionElement {
 // value.
 func (e *OptionElement) IfSlotf(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1827,10 +1737,8 @@ func (e *OptionElement) IfSlotf(condition bool, format string, args ...any) *Opt
 // value.
 // Remove the attribute Slot from the element.
 func (e *OptionElement) SlotRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1846,10 +1754,8 @@ func (e *OptionElement) SlotRemove() *OptionElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *OptionElement) Spellcheck(c OptionSpellcheckChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[OptionSpellcheckChoice])
 	return e
 }
 
@@ -1877,10 +1783,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *OptionElement) SpellcheckRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1890,14 +1794,8 @@ func (e *OptionElement) StylePairs(pairs ...string) *OptionElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1912,14 +1810,8 @@ func (e *OptionElement) StylePairs(pairs ...string) *OptionElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptionElement) Style(s string) *OptionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1927,7 +1819,7 @@ func (e *OptionElement) Style(s string) *OptionElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1936,7 +1828,7 @@ func (e *OptionElement) Style(s string) *OptionElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptionElement) IfStyle(condition bool, s string) *OptionElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1944,15 +1836,7 @@ func (e *OptionElement) IfStyle(condition bool, s string) *OptionElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptionElement) StyleAdd(k string, v string) *OptionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1966,7 +1850,7 @@ func (e *OptionElement) StyleAddf(k string, format string, args ...any) *OptionE
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptionElement) IfStyleAdd(condition bool, k string, v string) *OptionElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1975,7 +1859,7 @@ func (e *OptionElement) IfStyleAdd(condition bool, k string, v string) *OptionEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *OptionElement) IfStyleAddf(condition bool, k string, format string, args ...any) *OptionElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1984,21 +1868,15 @@ func (e *OptionElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *OptionElement) StyleMap(m map[string]string) *OptionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2007,14 +1885,8 @@ func (e *OptionElement) StyleMap(m map[string]string) *OptionElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *OptionElement) StyleRemove(keys ...string) *OptionElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2032,10 +1904,8 @@ func (e *OptionElement) StyleRemove(keys ...string) *OptionElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *OptionElement) Tabindex(i int) *OptionElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2054,7 +1924,7 @@ func (e *OptionElement) Tabindex(i int) *OptionElement {
 // their relative position in the document.
 func (e *OptionElement) IfTabindex(condition bool, i int) *OptionElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2074,10 +1944,8 @@ func (e *OptionElement) IfTabindex(condition bool, i int) *OptionElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *OptionElement) TabindexRemove() *OptionElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2098,10 +1966,8 @@ func (e *OptionElement) TabindexRemove() *OptionElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *OptionElement) Title(s string) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2143,7 +2009,7 @@ func (e *OptionElement) Titlef(format string, args ...any) *OptionElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OptionElement) IfTitle(condition bool, s string) *OptionElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2166,7 +2032,7 @@ func (e *OptionElement) IfTitle(condition bool, s string) *OptionElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OptionElement) IfTitlef(condition bool, format string, args ...any) *OptionElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2189,10 +2055,8 @@ func (e *OptionElement) IfTitlef(condition bool, format string, args ...any) *Op
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *OptionElement) TitleRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2201,10 +2065,8 @@ func (e *OptionElement) TitleRemove() *OptionElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *OptionElement) Translate(c OptionTranslateChoice) *OptionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[OptionTranslateChoice])
 	return e
 }
 
@@ -2225,9 +2087,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *OptionElement) TranslateRemove() *OptionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <output> element represents the result of a calculation or user
@@ -22,18 +20,77 @@ type OutputElement struct {
 // with the tag "output" during rendering.
 func Output(children ...ElementRenderer) *OutputElement {
 	e := NewElement("output", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &OutputElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *OutputElement) Clone() *OutputElement {
+	return &OutputElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *OutputElement) CloneShallow() *OutputElement {
+	return &OutputElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *OutputElement) Freeze() *OutputElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *OutputElement) writable() *OutputElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *OutputElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *OutputElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[OutputAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[OutputContenteditableChoice]
+	case "dir":
+		return choiceValue[OutputDirChoice]
+	case "draggable":
+		return choiceValue[OutputDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[OutputEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[OutputHiddenChoice]
+	case "inputmode":
+		return choiceValue[OutputInputmodeChoice]
+	case "popover":
+		return choiceValue[OutputPopoverChoice]
+	case "spellcheck":
+		return choiceValue[OutputSpellcheckChoice]
+	case "translate":
+		return choiceValue[OutputTranslateChoice]
+	}
+	return nil
+}
+
 func (e *OutputElement) Children(children ...ElementRenderer) *OutputElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *OutputElement) IfChildren(condition bool, children ...ElementRenderer) *OutputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *OutputElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *OutputElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *OutputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *OutputElement) BoolAttr(name string) *OutputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *OutputElement) BoolAttrRemove(name string) *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *OutputElement) IfBoolAttr(condition bool, name string) *OutputElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *OutputElement) BoolAttrf(format string, args ...any) *OutputElement {
 
 func (e *OutputElement) IfBoolAttrf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *OutputElement) BoolAttrs(names ...string) *OutputElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *OutputElement) IfBoolAttrs(condition bool, names ...string) *OutputElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *OutputElement) Attr(name, value string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *OutputElement) RawAttr(name, value string) *OutputElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *OutputElement) IfAttr(condition bool, name, value string) *OutputElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *OutputElement) Attrf(name, format string, args ...any) *OutputElement {
 
 func (e *OutputElement) IfAttrf(condition bool, name, format string, args ...any) *OutputElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *OutputElement) Attrs(attrs ...string) *OutputElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *OutputElement) IfAttrs(condition bool, attrs ...string) *OutputElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *OutputElement) AttrsMap(attrs map[string]string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *OutputElement) IfAttrsMap(condition bool, attrs map[string]string) *OutputElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *OutputElement) Text(text string) *OutputElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *OutputElement) Textf(format string, args ...any) *OutputElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *OutputElement) IfText(condition bool, text string) *OutputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *OutputElement) IfText(condition bool, text string) *OutputElement {
 
 func (e *OutputElement) IfTextf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *OutputElement) Raw(text string) *OutputElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *OutputElement) Escaped(text string) *OutputElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *OutputElement) IfEscaped(condition bool, text string) *OutputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *OutputElement) Escapedf(format string, args ...any) *OutputElement {
 
 func (e *OutputElement) IfEscapedf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +270,8 @@ func (e *OutputElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Specifies controls from which the output was calculated.
 func (e *OutputElement) For(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("for", s)
+	e = e.writable()
+	e.setStringAttribute("for", s)
 	return e
 }
 
@@ -224,7 +283,7 @@ func (e *OutputElement) Forf(format string, args ...any) *OutputElement {
 // Specifies controls from which the output was calculated.
 func (e *OutputElement) IfFor(condition bool, s string) *OutputElement {
 	if condition {
-		e.For(s)
+		e = e.For(s)
 	}
 	return e
 }
@@ -232,7 +291,7 @@ func (e *OutputElement) IfFor(condition bool, s string) *OutputElement {
 // Specifies controls from which the output was calculated.
 func (e *OutputElement) IfForf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.For(fmt.Sprintf(format, args...))
+		e = e.For(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +299,15 @@ func (e *OutputElement) IfForf(condition bool, format string, args ...any) *Outp
 // Specifies controls from which the output was calculated.
 // Remove the attribute For from the element.
 func (e *OutputElement) ForRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("for")
+	e = e.writable()
+	e.removeAttribute("for")
 	return e
 }
 
 // Associates the control with a form element.
 func (e *OutputElement) Form(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -264,7 +319,7 @@ func (e *OutputElement) Formf(format string, args ...any) *OutputElement {
 // Associates the control with a form element.
 func (e *OutputElement) IfForm(condition bool, s string) *OutputElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -272,7 +327,7 @@ func (e *OutputElement) IfForm(condition bool, s string) *OutputElement {
 // Associates the control with a form element.
 func (e *OutputElement) IfFormf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,19 +335,15 @@ func (e *OutputElement) IfFormf(condition bool, format string, args ...any) *Out
 // Associates the control with a form element.
 // Remove the attribute Form from the element.
 func (e *OutputElement) FormRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // Name of the element to use for form submission and in the form.elements API.
 func (e *OutputElement) Name(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -304,7 +355,7 @@ func (e *OutputElement) Namef(format string, args ...any) *OutputElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *OutputElement) IfName(condition bool, s string) *OutputElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -312,7 +363,7 @@ func (e *OutputElement) IfName(condition bool, s string) *OutputElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *OutputElement) IfNamef(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -320,10 +371,8 @@ func (e *OutputElement) IfNamef(condition bool, format string, args ...any) *Out
 // Name of the element to use for form submission and in the form.elements API.
 // Remove the attribute Name from the element.
 func (e *OutputElement) NameRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -332,10 +381,8 @@ func (e *OutputElement) NameRemove() *OutputElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *OutputElement) Accesskey(r rune) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -345,7 +392,7 @@ func (e *OutputElement) Accesskey(r rune) *OutputElement {
 // can be generated by the keyboard).
 func (e *OutputElement) IfAccesskey(condition bool, r rune) *OutputElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -356,10 +403,8 @@ func (e *OutputElement) IfAccesskey(condition bool, r rune) *OutputElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *OutputElement) AccesskeyRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -375,10 +420,8 @@ func (e *OutputElement) AccesskeyRemove() *OutputElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *OutputElement) Autocapitalize(c OutputAutocapitalizeChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[OutputAutocapitalizeChoice])
 	return e
 }
 
@@ -412,10 +455,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *OutputElement) AutocapitalizeRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -434,10 +475,8 @@ func (e *OutputElement) AutocapitalizeRemove() *OutputElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OutputElement) Autofocus() *OutputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -457,7 +496,7 @@ func (e *OutputElement) Autofocus() *OutputElement {
 // created by the preceding content.
 func (e *OutputElement) IfAutofocus(condition bool) *OutputElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -478,10 +517,8 @@ func (e *OutputElement) IfAutofocus(condition bool) *OutputElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OutputElement) AutofocusRemove() *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -501,10 +538,8 @@ func (e *OutputElement) AutofocusRemove() *OutputElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *OutputElement) AutofocusIfRemove() *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -513,16 +548,9 @@ func (e *OutputElement) AutofocusIfRemove() *OutputElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *OutputElement) Class(s string) *OutputElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -532,7 +560,7 @@ func (e *OutputElement) Class(s string) *OutputElement {
 // document.getElementsByClassName.
 func (e *OutputElement) IfClass(condition bool, s string) *OutputElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -543,14 +571,8 @@ func (e *OutputElement) IfClass(condition bool, s string) *OutputElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *OutputElement) ClassRemove(s ...string) *OutputElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -558,10 +580,8 @@ func (e *OutputElement) ClassRemove(s ...string) *OutputElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *OutputElement) Contenteditable(c OutputContenteditableChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[OutputContenteditableChoice])
 	return e
 }
 
@@ -584,10 +604,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *OutputElement) ContenteditableRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -605,10 +623,8 @@ func (e *OutputElement) ContenteditableRemove() *OutputElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *OutputElement) Dir(c OutputDirChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[OutputDirChoice])
 	return e
 }
 
@@ -642,10 +658,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *OutputElement) DirRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -653,10 +667,8 @@ func (e *OutputElement) DirRemove() *OutputElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *OutputElement) Draggable(c OutputDraggableChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[OutputDraggableChoice])
 	return e
 }
 
@@ -682,20 +694,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *OutputElement) DraggableRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *OutputElement) Enterkeyhint(c OutputEnterkeyhintChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[OutputEnterkeyhintChoice])
 	return e
 }
 
@@ -725,10 +733,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *OutputElement) EnterkeyhintRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -750,16 +756,9 @@ func (e *OutputElement) EnterkeyhintRemove() *OutputElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *OutputElement) Exportparts(s string) *OutputElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -782,7 +781,7 @@ func (e *OutputElement) Exportparts(s string) *OutputElement {
 // the current structure.
 func (e *OutputElement) IfExportparts(condition bool, s string) *OutputElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -806,14 +805,8 @@ func (e *OutputElement) IfExportparts(condition bool, s string) *OutputElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *OutputElement) ExportpartsRemove(s ...string) *OutputElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -830,10 +823,8 @@ func (e *OutputElement) ExportpartsRemove(s ...string) *OutputElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *OutputElement) Hidden(c OutputHiddenChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[OutputHiddenChoice])
 	return e
 }
 
@@ -869,10 +860,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *OutputElement) HiddenRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -880,10 +869,8 @@ func (e *OutputElement) HiddenRemove() *OutputElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OutputElement) ID(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -899,7 +886,7 @@ func (e *OutputElement) IDf(format string, args ...any) *OutputElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OutputElement) IfID(condition bool, s string) *OutputElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -909,7 +896,7 @@ func (e *OutputElement) IfID(condition bool, s string) *OutputElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *OutputElement) IfIDf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -919,10 +906,8 @@ func (e *OutputElement) IfIDf(condition bool, format string, args ...any) *Outpu
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *OutputElement) IDRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -937,10 +922,8 @@ func (e *OutputElement) IDRemove() *OutputElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OutputElement) Inert() *OutputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -956,7 +939,7 @@ func (e *OutputElement) Inert() *OutputElement {
 // excluding them from the accessibility tree.
 func (e *OutputElement) IfInert(condition bool) *OutputElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -973,10 +956,8 @@ func (e *OutputElement) IfInert(condition bool) *OutputElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OutputElement) InertRemove() *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -992,10 +973,8 @@ func (e *OutputElement) InertRemove() *OutputElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *OutputElement) InertIfRemove() *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1009,10 +988,8 @@ func (e *OutputElement) InertIfRemove() *OutputElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *OutputElement) Inputmode(c OutputInputmodeChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[OutputInputmodeChoice])
 	return e
 }
 
@@ -1063,10 +1040,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *OutputElement) InputmodeRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1076,10 +1051,8 @@ func (e *OutputElement) InputmodeRemove() *OutputElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *OutputElement) Is(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1099,7 +1072,7 @@ func (e *OutputElement) Isf(format string, args ...any) *OutputElement {
 // and extends the element type it is being applied to.
 func (e *OutputElement) IfIs(condition bool, s string) *OutputElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1111,7 +1084,7 @@ func (e *OutputElement) IfIs(condition bool, s string) *OutputElement {
 // and extends the element type it is being applied to.
 func (e *OutputElement) IfIsf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1123,10 +1096,8 @@ func (e *OutputElement) IfIsf(condition bool, format string, args ...any) *Outpu
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *OutputElement) IsRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1142,10 +1113,8 @@ func (e *OutputElement) IsRemove() *OutputElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *OutputElement) Itemid(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1177,7 +1146,7 @@ func (e *OutputElement) Itemidf(format string, args ...any) *OutputElement {
 // how items with the same identifier are handled.
 func (e *OutputElement) IfItemid(condition bool, s string) *OutputElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1195,7 +1164,7 @@ func (e *OutputElement) IfItemid(condition bool, s string) *OutputElement {
 // how items with the same identifier are handled.
 func (e *OutputElement) IfItemidf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1213,10 +1182,8 @@ func (e *OutputElement) IfItemidf(condition bool, format string, args ...any) *O
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *OutputElement) ItemidRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1228,10 +1195,8 @@ func (e *OutputElement) ItemidRemove() *OutputElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *OutputElement) Itemprop(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1255,7 +1220,7 @@ func (e *OutputElement) Itempropf(format string, args ...any) *OutputElement {
 // <track>, and <video>.
 func (e *OutputElement) IfItemprop(condition bool, s string) *OutputElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1269,7 +1234,7 @@ func (e *OutputElement) IfItemprop(condition bool, s string) *OutputElement {
 // <track>, and <video>.
 func (e *OutputElement) IfItempropf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1283,10 +1248,8 @@ func (e *OutputElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *OutputElement) ItempropRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1296,10 +1259,8 @@ func (e *OutputElement) ItempropRemove() *OutputElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *OutputElement) Itemref(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1319,7 +1280,7 @@ func (e *OutputElement) Itemreff(format string, args ...any) *OutputElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OutputElement) IfItemref(condition bool, s string) *OutputElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1331,7 +1292,7 @@ func (e *OutputElement) IfItemref(condition bool, s string) *OutputElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *OutputElement) IfItemreff(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1343,10 +1304,8 @@ func (e *OutputElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *OutputElement) ItemrefRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1358,10 +1317,8 @@ func (e *OutputElement) ItemrefRemove() *OutputElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OutputElement) Itemscope() *OutputElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1374,7 +1331,7 @@ func (e *OutputElement) Itemscope() *OutputElement {
 // <object>, <source>, <track>, and <video>.
 func (e *OutputElement) IfItemscope(condition bool) *OutputElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1388,10 +1345,8 @@ func (e *OutputElement) IfItemscope(condition bool) *OutputElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OutputElement) ItemscopeRemove() *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1404,10 +1359,8 @@ func (e *OutputElement) ItemscopeRemove() *OutputElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *OutputElement) ItemscopeIfRemove() *OutputElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1419,10 +1372,8 @@ func (e *OutputElement) ItemscopeIfRemove() *OutputElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *OutputElement) Itemtype(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1446,7 +1397,7 @@ func (e *OutputElement) Itemtypef(format string, args ...any) *OutputElement {
 // <video>.
 func (e *OutputElement) IfItemtype(condition bool, s string) *OutputElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1460,7 +1411,7 @@ func (e *OutputElement) IfItemtype(condition bool, s string) *OutputElement {
 // <video>.
 func (e *OutputElement) IfItemtypef(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1474,10 +1425,8 @@ func (e *OutputElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *OutputElement) ItemtypeRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1487,10 +1436,8 @@ func (e *OutputElement) ItemtypeRemove() *OutputElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OutputElement) Lang(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1510,7 +1457,7 @@ func (e *OutputElement) Langf(format string, args ...any) *OutputElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OutputElement) IfLang(condition bool, s string) *OutputElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1522,7 +1469,7 @@ func (e *OutputElement) IfLang(condition bool, s string) *OutputElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *OutputElement) IfLangf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1534,10 +1481,8 @@ func (e *OutputElement) IfLangf(condition bool, format string, args ...any) *Out
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *OutputElement) LangRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1547,10 +1492,8 @@ func (e *OutputElement) LangRemove() *OutputElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *OutputElement) Nonce(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1570,7 +1513,7 @@ func (e *OutputElement) Noncef(format string, args ...any) *OutputElement {
 // execute.
 func (e *OutputElement) IfNonce(condition bool, s string) *OutputElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1582,7 +1525,7 @@ func (e *OutputElement) IfNonce(condition bool, s string) *OutputElement {
 // execute.
 func (e *OutputElement) IfNoncef(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1594,10 +1537,8 @@ func (e *OutputElement) IfNoncef(condition bool, format string, args ...any) *Ou
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *OutputElement) NonceRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1605,16 +1546,9 @@ func (e *OutputElement) NonceRemove() *OutputElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *OutputElement) Part(s string) *OutputElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1623,7 +1557,7 @@ func (e *OutputElement) Part(s string) *OutputElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *OutputElement) IfPart(condition bool, s string) *OutputElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1633,14 +1567,8 @@ func (e *OutputElement) IfPart(condition bool, s string) *OutputElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *OutputElement) PartRemove(s ...string) *OutputElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1651,10 +1579,8 @@ func (e *OutputElement) PartRemove(s ...string) *OutputElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *OutputElement) Popover(c OutputPopoverChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[OutputPopoverChoice])
 	return e
 }
 
@@ -1682,10 +1608,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *OutputElement) PopoverRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1694,10 +1618,8 @@ func (e *OutputElement) PopoverRemove() *OutputElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *OutputElement) Role(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1715,7 +1637,7 @@ func (e *OutputElement) Rolef(format string, args ...any) *OutputElement {
 // role of an element.
 func (e *OutputElement) IfRole(condition bool, s string) *OutputElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1726,7 +1648,7 @@ func (e *OutputElement) IfRole(condition bool, s string) *OutputElement {
 // role of an element.
 func (e *OutputElement) IfRolef(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1737,10 +1659,8 @@ func (e *OutputElement) IfRolef(condition bool, format string, args ...any) *Out
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *OutputElement) RoleRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1749,10 +1669,8 @@ func (e *OutputElement) RoleRemove() *OutputElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *OutputElement) Slot(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1770,7 +1688,7 @@ func (e *OutputElement) Slotf(format string, args ...any) *OutputElement {
 // value.
 func (e *OutputElement) IfSlot(condition bool, s string) *OutputElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1781,7 +1699,7 @@ func (e *OutputElement) IfSlot(condition bool, s string) *OutputElement {
 // value.
 func (e *OutputElement) IfSlotf(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1792,10 +1710,8 @@ func (e *OutputElement) IfSlotf(condition bool, format string, args ...any) *Out
 // value.
 // Remove the attribute Slot from the element.
 func (e *OutputElement) SlotRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1811,10 +1727,8 @@ func (e *OutputElement) SlotRemove() *OutputElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *OutputElement) Spellcheck(c OutputSpellcheckChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[OutputSpellcheckChoice])
 	return e
 }
 
@@ -1842,10 +1756,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *OutputElement) SpellcheckRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1855,14 +1767,8 @@ func (e *OutputElement) StylePairs(pairs ...string) *OutputElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1877,14 +1783,8 @@ func (e *OutputElement) StylePairs(pairs ...string) *OutputElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OutputElement) Style(s string) *OutputElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1892,7 +1792,7 @@ func (e *OutputElement) Style(s string) *OutputElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1901,7 +1801,7 @@ func (e *OutputElement) Style(s string) *OutputElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *OutputElement) IfStyle(condition bool, s string) *OutputElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1909,15 +1809,7 @@ func (e *OutputElement) IfStyle(condition bool, s string) *OutputElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *OutputElement) StyleAdd(k string, v string) *OutputElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1931,7 +1823,7 @@ func (e *OutputElement) StyleAddf(k string, format string, args ...any) *OutputE
 // color, font, size, and more. Styles are written in CSS.
 func (e *OutputElement) IfStyleAdd(condition bool, k string, v string) *OutputElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1940,7 +1832,7 @@ func (e *OutputElement) IfStyleAdd(condition bool, k string, v string) *OutputEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *OutputElement) IfStyleAddf(condition bool, k string, format string, args ...any) *OutputElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1949,21 +1841,15 @@ func (e *OutputElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *OutputElement) StyleMap(m map[string]string) *OutputElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1972,14 +1858,8 @@ func (e *OutputElement) StyleMap(m map[string]string) *OutputElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *OutputElement) StyleRemove(keys ...string) *OutputElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1997,10 +1877,8 @@ func (e *OutputElement) StyleRemove(keys ...string) *OutputElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *OutputElement) Tabindex(i int) *OutputElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2019,7 +1897,7 @@ func (e *OutputElement) Tabindex(i int) *OutputElement {
 // their relative position in the document.
 func (e *OutputElement) IfTabindex(condition bool, i int) *OutputElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2039,10 +1917,8 @@ func (e *OutputElement) IfTabindex(condition bool, i int) *OutputElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *OutputElement) TabindexRemove() *OutputElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2063,10 +1939,8 @@ func (e *OutputElement) TabindexRemove() *OutputElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *OutputElement) Title(s string) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2108,7 +1982,7 @@ func (e *OutputElement) Titlef(format string, args ...any) *OutputElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OutputElement) IfTitle(condition bool, s string) *OutputElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2131,7 +2005,7 @@ func (e *OutputElement) IfTitle(condition bool, s string) *OutputElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *OutputElement) IfTitlef(condition bool, format string, args ...any) *OutputElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2154,10 +2028,8 @@ func (e *OutputElement) IfTitlef(condition bool, format string, args ...any) *Ou
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *OutputElement) TitleRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2166,10 +2038,8 @@ func (e *OutputElement) TitleRemove() *OutputElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *OutputElement) Translate(c OutputTranslateChoice) *OutputElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[OutputTranslateChoice])
 	return e
 }
 
@@ -2190,9 +2060,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *OutputElement) TranslateRemove() *OutputElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <p> element represents a paragraph.
@@ -21,18 +19,77 @@ type PElement struct {
 // with the tag "p" during rendering.
 func P(children ...ElementRenderer) *PElement {
 	e := NewElement("p", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &PElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *PElement) Clone() *PElement {
+	return &PElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *PElement) CloneShallow() *PElement {
+	return &PElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *PElement) Freeze() *PElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *PElement) writable() *PElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *PElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *PElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[PAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[PContenteditableChoice]
+	case "dir":
+		return choiceValue[PDirChoice]
+	case "draggable":
+		return choiceValue[PDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[PEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[PHiddenChoice]
+	case "inputmode":
+		return choiceValue[PInputmodeChoice]
+	case "popover":
+		return choiceValue[PPopoverChoice]
+	case "spellcheck":
+		return choiceValue[PSpellcheckChoice]
+	case "translate":
+		return choiceValue[PTranslateChoice]
+	}
+	return nil
+}
+
 func (e *PElement) Children(children ...ElementRenderer) *PElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *PElement) IfChildren(condition bool, children ...ElementRenderer) *PElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +97,30 @@ func (e *PElement) IfChildren(condition bool, children ...ElementRenderer) *PEle
 
 func (e *PElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *PElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *PElement) BoolAttr(name string) *PElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *PElement) BoolAttrRemove(name string) *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *PElement) IfBoolAttr(condition bool, name string) *PElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +131,40 @@ func (e *PElement) BoolAttrf(format string, args ...any) *PElement {
 
 func (e *PElement) IfBoolAttrf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *PElement) BoolAttrs(names ...string) *PElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *PElement) IfBoolAttrs(condition bool, names ...string) *PElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *PElement) Attr(name, value string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *PElement) RawAttr(name, value string) *PElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *PElement) IfAttr(condition bool, name, value string) *PElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +175,52 @@ func (e *PElement) Attrf(name, format string, args ...any) *PElement {
 
 func (e *PElement) IfAttrf(condition bool, name, format string, args ...any) *PElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *PElement) Attrs(attrs ...string) *PElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *PElement) IfAttrs(condition bool, attrs ...string) *PElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *PElement) AttrsMap(attrs map[string]string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *PElement) IfAttrsMap(condition bool, attrs map[string]string) *PElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *PElement) Text(text string) *PElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *PElement) Textf(format string, args ...any) *PElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *PElement) IfText(condition bool, text string) *PElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +228,28 @@ func (e *PElement) IfText(condition bool, text string) *PElement {
 
 func (e *PElement) IfTextf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *PElement) Raw(text string) *PElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *PElement) Escaped(text string) *PElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *PElement) IfEscaped(condition bool, text string) *PElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +261,7 @@ func (e *PElement) Escapedf(format string, args ...any) *PElement {
 
 func (e *PElement) IfEscapedf(condition bool, format string, args ...any) *PElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +272,8 @@ func (e *PElement) IfEscapedf(condition bool, format string, args ...any) *PElem
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *PElement) Accesskey(r rune) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -224,7 +283,7 @@ func (e *PElement) Accesskey(r rune) *PElement {
 // can be generated by the keyboard).
 func (e *PElement) IfAccesskey(condition bool, r rune) *PElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -235,10 +294,8 @@ func (e *PElement) IfAccesskey(condition bool, r rune) *PElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *PElement) AccesskeyRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -254,10 +311,8 @@ func (e *PElement) AccesskeyRemove() *PElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *PElement) Autocapitalize(c PAutocapitalizeChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[PAutocapitalizeChoice])
 	return e
 }
 
@@ -291,10 +346,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *PElement) AutocapitalizeRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -313,10 +366,8 @@ func (e *PElement) AutocapitalizeRemove() *PElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *PElement) Autofocus() *PElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -336,7 +387,7 @@ func (e *PElement) Autofocus() *PElement {
 // created by the preceding content.
 func (e *PElement) IfAutofocus(condition bool) *PElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -357,10 +408,8 @@ func (e *PElement) IfAutofocus(condition bool) *PElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *PElement) AutofocusRemove() *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -380,10 +429,8 @@ func (e *PElement) AutofocusRemove() *PElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *PElement) AutofocusIfRemove() *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -392,16 +439,9 @@ func (e *PElement) AutofocusIfRemove() *PElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *PElement) Class(s string) *PElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -411,7 +451,7 @@ func (e *PElement) Class(s string) *PElement {
 // document.getElementsByClassName.
 func (e *PElement) IfClass(condition bool, s string) *PElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -422,14 +462,8 @@ func (e *PElement) IfClass(condition bool, s string) *PElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *PElement) ClassRemove(s ...string) *PElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -437,10 +471,8 @@ func (e *PElement) ClassRemove(s ...string) *PElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *PElement) Contenteditable(c PContenteditableChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[PContenteditableChoice])
 	return e
 }
 
@@ -463,10 +495,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *PElement) ContenteditableRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -484,10 +514,8 @@ func (e *PElement) ContenteditableRemove() *PElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *PElement) Dir(c PDirChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[PDirChoice])
 	return e
 }
 
@@ -521,10 +549,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *PElement) DirRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -532,10 +558,8 @@ func (e *PElement) DirRemove() *PElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *PElement) Draggable(c PDraggableChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[PDraggableChoice])
 	return e
 }
 
@@ -561,20 +585,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *PElement) DraggableRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *PElement) Enterkeyhint(c PEnterkeyhintChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[PEnterkeyhintChoice])
 	return e
 }
 
@@ -604,10 +624,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *PElement) EnterkeyhintRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -629,16 +647,9 @@ func (e *PElement) EnterkeyhintRemove() *PElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *PElement) Exportparts(s string) *PElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -661,7 +672,7 @@ func (e *PElement) Exportparts(s string) *PElement {
 // the current structure.
 func (e *PElement) IfExportparts(condition bool, s string) *PElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -685,14 +696,8 @@ func (e *PElement) IfExportparts(condition bool, s string) *PElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *PElement) ExportpartsRemove(s ...string) *PElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -709,10 +714,8 @@ func (e *PElement) ExportpartsRemove(s ...string) *PElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *PElement) Hidden(c PHiddenChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[PHiddenChoice])
 	return e
 }
 
@@ -748,10 +751,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *PElement) HiddenRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -759,10 +760,8 @@ func (e *PElement) HiddenRemove() *PElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *PElement) ID(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -778,7 +777,7 @@ func (e *PElement) IDf(format string, args ...any) *PElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *PElement) IfID(condition bool, s string) *PElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -788,7 +787,7 @@ func (e *PElement) IfID(condition bool, s string) *PElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *PElement) IfIDf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -798,10 +797,8 @@ func (e *PElement) IfIDf(condition bool, format string, args ...any) *PElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *PElement) IDRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -816,10 +813,8 @@ func (e *PElement) IDRemove() *PElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *PElement) Inert() *PElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -835,7 +830,7 @@ func (e *PElement) Inert() *PElement {
 // excluding them from the accessibility tree.
 func (e *PElement) IfInert(condition bool) *PElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -852,10 +847,8 @@ func (e *PElement) IfInert(condition bool) *PElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *PElement) InertRemove() *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -871,10 +864,8 @@ func (e *PElement) InertRemove() *PElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *PElement) InertIfRemove() *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -888,10 +879,8 @@ func (e *PElement) InertIfRemove() *PElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *PElement) Inputmode(c PInputmodeChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[PInputmodeChoice])
 	return e
 }
 
@@ -942,10 +931,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *PElement) InputmodeRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -955,10 +942,8 @@ func (e *PElement) InputmodeRemove() *PElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *PElement) Is(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -978,7 +963,7 @@ func (e *PElement) Isf(format string, args ...any) *PElement {
 // and extends the element type it is being applied to.
 func (e *PElement) IfIs(condition bool, s string) *PElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -990,7 +975,7 @@ func (e *PElement) IfIs(condition bool, s string) *PElement {
 // and extends the element type it is being applied to.
 func (e *PElement) IfIsf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1002,10 +987,8 @@ func (e *PElement) IfIsf(condition bool, format string, args ...any) *PElement {
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *PElement) IsRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1021,10 +1004,8 @@ func (e *PElement) IsRemove() *PElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *PElement) Itemid(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1056,7 +1037,7 @@ func (e *PElement) Itemidf(format string, args ...any) *PElement {
 // how items with the same identifier are handled.
 func (e *PElement) IfItemid(condition bool, s string) *PElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1074,7 +1055,7 @@ func (e *PElement) IfItemid(condition bool, s string) *PElement {
 // how items with the same identifier are handled.
 func (e *PElement) IfItemidf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1092,10 +1073,8 @@ func (e *PElement) IfItemidf(condition bool, format string, args ...any) *PEleme
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *PElement) ItemidRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1107,10 +1086,8 @@ func (e *PElement) ItemidRemove() *PElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *PElement) Itemprop(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1134,7 +1111,7 @@ func (e *PElement) Itempropf(format string, args ...any) *PElement {
 // <track>, and <video>.
 func (e *PElement) IfItemprop(condition bool, s string) *PElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1148,7 +1125,7 @@ func (e *PElement) IfItemprop(condition bool, s string) *PElement {
 // <track>, and <video>.
 func (e *PElement) IfItempropf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1162,10 +1139,8 @@ func (e *PElement) IfItempropf(condition bool, format string, args ...any) *PEle
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *PElement) ItempropRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1175,10 +1150,8 @@ func (e *PElement) ItempropRemove() *PElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *PElement) Itemref(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1198,7 +1171,7 @@ func (e *PElement) Itemreff(format string, args ...any) *PElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *PElement) IfItemref(condition bool, s string) *PElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1210,7 +1183,7 @@ func (e *PElement) IfItemref(condition bool, s string) *PElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *PElement) IfItemreff(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1222,10 +1195,8 @@ func (e *PElement) IfItemreff(condition bool, format string, args ...any) *PElem
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *PElement) ItemrefRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1237,10 +1208,8 @@ func (e *PElement) ItemrefRemove() *PElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *PElement) Itemscope() *PElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1253,7 +1222,7 @@ func (e *PElement) Itemscope() *PElement {
 // <object>, <source>, <track>, and <video>.
 func (e *PElement) IfItemscope(condition bool) *PElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1267,10 +1236,8 @@ func (e *PElement) IfItemscope(condition bool) *PElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *PElement) ItemscopeRemove() *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1283,10 +1250,8 @@ func (e *PElement) ItemscopeRemove() *PElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *PElement) ItemscopeIfRemove() *PElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1298,10 +1263,8 @@ func (e *PElement) ItemscopeIfRemove() *PElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *PElement) Itemtype(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1325,7 +1288,7 @@ func (e *PElement) Itemtypef(format string, args ...any) *PElement {
 // <video>.
 func (e *PElement) IfItemtype(condition bool, s string) *PElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1339,7 +1302,7 @@ func (e *PElement) IfItemtype(condition bool, s string) *PElement {
 // <video>.
 func (e *PElement) IfItemtypef(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1353,10 +1316,8 @@ func (e *PElement) IfItemtypef(condition bool, format string, args ...any) *PEle
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *PElement) ItemtypeRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1366,10 +1327,8 @@ func (e *PElement) ItemtypeRemove() *PElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *PElement) Lang(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1389,7 +1348,7 @@ func (e *PElement) Langf(format string, args ...any) *PElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *PElement) IfLang(condition bool, s string) *PElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1401,7 +1360,7 @@ func (e *PElement) IfLang(condition bool, s string) *PElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *PElement) IfLangf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1413,10 +1372,8 @@ func (e *PElement) IfLangf(condition bool, format string, args ...any) *PElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *PElement) LangRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1426,10 +1383,8 @@ func (e *PElement) LangRemove() *PElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *PElement) Nonce(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1449,7 +1404,7 @@ func (e *PElement) Noncef(format string, args ...any) *PElement {
 // execute.
 func (e *PElement) IfNonce(condition bool, s string) *PElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1461,7 +1416,7 @@ func (e *PElement) IfNonce(condition bool, s string) *PElement {
 // execute.
 func (e *PElement) IfNoncef(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1473,10 +1428,8 @@ func (e *PElement) IfNoncef(condition bool, format string, args ...any) *PElemen
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *PElement) NonceRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1484,16 +1437,9 @@ func (e *PElement) NonceRemove() *PElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *PElement) Part(s string) *PElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1502,7 +1448,7 @@ func (e *PElement) Part(s string) *PElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *PElement) IfPart(condition bool, s string) *PElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1512,14 +1458,8 @@ func (e *PElement) IfPart(condition bool, s string) *PElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *PElement) PartRemove(s ...string) *PElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1530,10 +1470,8 @@ func (e *PElement) PartRemove(s ...string) *PElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *PElement) Popover(c PPopoverChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[PPopoverChoice])
 	return e
 }
 
@@ -1561,10 +1499,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *PElement) PopoverRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1573,10 +1509,8 @@ func (e *PElement) PopoverRemove() *PElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *PElement) Role(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1594,7 +1528,7 @@ func (e *PElement) Rolef(format string, args ...any) *PElement {
 // role of an element.
 func (e *PElement) IfRole(condition bool, s string) *PElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1605,7 +1539,7 @@ func (e *PElement) IfRole(condition bool, s string) *PElement {
 // role of an element.
 func (e *PElement) IfRolef(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1616,10 +1550,8 @@ func (e *PElement) IfRolef(condition bool, format string, args ...any) *PElement
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *PElement) RoleRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1628,10 +1560,8 @@ func (e *PElement) RoleRemove() *PElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *PElement) Slot(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1649,7 +1579,7 @@ func (e *PElement) Slotf(format string, args ...any) *PElement {
 // value.
 func (e *PElement) IfSlot(condition bool, s string) *PElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1660,7 +1590,7 @@ func (e *PElement) IfSlot(condition bool, s string) *PElement {
 // value.
 func (e *PElement) IfSlotf(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1671,10 +1601,8 @@ func (e *PElement) IfSlotf(condition bool, format string, args ...any) *PElement
 // value.
 // Remove the attribute Slot from the element.
 func (e *PElement) SlotRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1690,10 +1618,8 @@ func (e *PElement) SlotRemove() *PElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *PElement) Spellcheck(c PSpellcheckChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[PSpellcheckChoice])
 	return e
 }
 
@@ -1721,10 +1647,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *PElement) SpellcheckRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1734,14 +1658,8 @@ func (e *PElement) StylePairs(pairs ...string) *PElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1756,14 +1674,8 @@ func (e *PElement) StylePairs(pairs ...string) *PElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *PElement) Style(s string) *PElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1771,7 +1683,7 @@ func (e *PElement) Style(s string) *PElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1780,7 +1692,7 @@ func (e *PElement) Style(s string) *PElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *PElement) IfStyle(condition bool, s string) *PElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1788,15 +1700,7 @@ func (e *PElement) IfStyle(condition bool, s string) *PElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *PElement) StyleAdd(k string, v string) *PElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1810,7 +1714,7 @@ func (e *PElement) StyleAddf(k string, format string, args ...any) *PElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *PElement) IfStyleAdd(condition bool, k string, v string) *PElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1819,7 +1723,7 @@ func (e *PElement) IfStyleAdd(condition bool, k string, v string) *PElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *PElement) IfStyleAddf(condition bool, k string, format string, args ...any) *PElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1828,21 +1732,15 @@ func (e *PElement) IfStyleAddf(condition bool, k string, format string, args ...
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *PElement) StyleMap(m map[string]string) *PElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1851,14 +1749,8 @@ func (e *PElement) StyleMap(m map[string]string) *PElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *PElement) StyleRemove(keys ...string) *PElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1876,10 +1768,8 @@ func (e *PElement) StyleRemove(keys ...string) *PElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *PElement) Tabindex(i int) *PElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1898,7 +1788,7 @@ func (e *PElement) Tabindex(i int) *PElement {
 // their relative position in the document.
 func (e *PElement) IfTabindex(condition bool, i int) *PElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1918,10 +1808,8 @@ func (e *PElement) IfTabindex(condition bool, i int) *PElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *PElement) TabindexRemove() *PElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1942,10 +1830,8 @@ func (e *PElement) TabindexRemove() *PElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *PElement) Title(s string) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1987,7 +1873,7 @@ func (e *PElement) Titlef(format string, args ...any) *PElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *PElement) IfTitle(condition bool, s string) *PElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2010,7 +1896,7 @@ func (e *PElement) IfTitle(condition bool, s string) *PElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *PElement) IfTitlef(condition bool, format string, args ...any) *PElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2033,10 +1919,8 @@ func (e *PElement) IfTitlef(condition bool, format string, args ...any) *PElemen
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *PElement) TitleRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2045,10 +1929,8 @@ func (e *PElement) TitleRemove() *PElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *PElement) Translate(c PTranslateChoice) *PElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[PTranslateChoice])
 	return e
 }
 
@@ -2069,9 +1951,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *PElement) TranslateRemove() *PElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
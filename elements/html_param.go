@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <param> element defines parameters for an <object> element.
@@ -21,18 +19,77 @@ type ParamElement struct {
 // with the tag "param" during rendering.
 func Param() *ParamElement {
 	e := NewElement("param")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &ParamElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ParamElement) Clone() *ParamElement {
+	return &ParamElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ParamElement) CloneShallow() *ParamElement {
+	return &ParamElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ParamElement) Freeze() *ParamElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ParamElement) writable() *ParamElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ParamElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ParamElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[ParamAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ParamContenteditableChoice]
+	case "dir":
+		return choiceValue[ParamDirChoice]
+	case "draggable":
+		return choiceValue[ParamDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ParamEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ParamHiddenChoice]
+	case "inputmode":
+		return choiceValue[ParamInputmodeChoice]
+	case "popover":
+		return choiceValue[ParamPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ParamSpellcheckChoice]
+	case "translate":
+		return choiceValue[ParamTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ParamElement) Children(children ...ElementRenderer) *ParamElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ParamElement) IfChildren(condition bool, children ...ElementRenderer) *ParamElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +97,30 @@ func (e *ParamElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *ParamElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ParamElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ParamElement) BoolAttr(name string) *ParamElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ParamElement) BoolAttrRemove(name string) *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ParamElement) IfBoolAttr(condition bool, name string) *ParamElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +131,40 @@ func (e *ParamElement) BoolAttrf(format string, args ...any) *ParamElement {
 
 func (e *ParamElement) IfBoolAttrf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ParamElement) BoolAttrs(names ...string) *ParamElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ParamElement) IfBoolAttrs(condition bool, names ...string) *ParamElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ParamElement) Attr(name, value string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ParamElement) RawAttr(name, value string) *ParamElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ParamElement) IfAttr(condition bool, name, value string) *ParamElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +175,52 @@ func (e *ParamElement) Attrf(name, format string, args ...any) *ParamElement {
 
 func (e *ParamElement) IfAttrf(condition bool, name, format string, args ...any) *ParamElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ParamElement) Attrs(attrs ...string) *ParamElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ParamElement) IfAttrs(condition bool, attrs ...string) *ParamElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ParamElement) AttrsMap(attrs map[string]string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ParamElement) IfAttrsMap(condition bool, attrs map[string]string) *ParamElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ParamElement) Text(text string) *ParamElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ParamElement) Textf(format string, args ...any) *ParamElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ParamElement) IfText(condition bool, text string) *ParamElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +228,28 @@ func (e *ParamElement) IfText(condition bool, text string) *ParamElement {
 
 func (e *ParamElement) IfTextf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ParamElement) Raw(text string) *ParamElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ParamElement) Escaped(text string) *ParamElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ParamElement) IfEscaped(condition bool, text string) *ParamElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +261,7 @@ func (e *ParamElement) Escapedf(format string, args ...any) *ParamElement {
 
 func (e *ParamElement) IfEscapedf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,10 +269,8 @@ func (e *ParamElement) IfEscapedf(condition bool, format string, args ...any) *P
 
 // Name of the parameter.
 func (e *ParamElement) Name(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -223,7 +282,7 @@ func (e *ParamElement) Namef(format string, args ...any) *ParamElement {
 // Name of the parameter.
 func (e *ParamElement) IfName(condition bool, s string) *ParamElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -231,7 +290,7 @@ func (e *ParamElement) IfName(condition bool, s string) *ParamElement {
 // Name of the parameter.
 func (e *ParamElement) IfNamef(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -239,19 +298,15 @@ func (e *ParamElement) IfNamef(condition bool, format string, args ...any) *Para
 // Name of the parameter.
 // Remove the attribute Name from the element.
 func (e *ParamElement) NameRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
 // Value of the parameter.
 func (e *ParamElement) Value(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("value", s)
+	e = e.writable()
+	e.setStringAttribute("value", s)
 	return e
 }
 
@@ -263,7 +318,7 @@ func (e *ParamElement) Valuef(format string, args ...any) *ParamElement {
 // Value of the parameter.
 func (e *ParamElement) IfValue(condition bool, s string) *ParamElement {
 	if condition {
-		e.Value(s)
+		e = e.Value(s)
 	}
 	return e
 }
@@ -271,7 +326,7 @@ func (e *ParamElement) IfValue(condition bool, s string) *ParamElement {
 // Value of the parameter.
 func (e *ParamElement) IfValuef(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Value(fmt.Sprintf(format, args...))
+		e = e.Value(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -279,10 +334,8 @@ func (e *ParamElement) IfValuef(condition bool, format string, args ...any) *Par
 // Value of the parameter.
 // Remove the attribute Value from the element.
 func (e *ParamElement) ValueRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("value")
+	e = e.writable()
+	e.removeAttribute("value")
 	return e
 }
 
@@ -291,10 +344,8 @@ func (e *ParamElement) ValueRemove() *ParamElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ParamElement) Accesskey(r rune) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -304,7 +355,7 @@ func (e *ParamElement) Accesskey(r rune) *ParamElement {
 // can be generated by the keyboard).
 func (e *ParamElement) IfAccesskey(condition bool, r rune) *ParamElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -315,10 +366,8 @@ func (e *ParamElement) IfAccesskey(condition bool, r rune) *ParamElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ParamElement) AccesskeyRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -334,10 +383,8 @@ func (e *ParamElement) AccesskeyRemove() *ParamElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ParamElement) Autocapitalize(c ParamAutocapitalizeChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ParamAutocapitalizeChoice])
 	return e
 }
 
@@ -371,10 +418,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ParamElement) AutocapitalizeRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -393,10 +438,8 @@ func (e *ParamElement) AutocapitalizeRemove() *ParamElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ParamElement) Autofocus() *ParamElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -416,7 +459,7 @@ func (e *ParamElement) Autofocus() *ParamElement {
 // created by the preceding content.
 func (e *ParamElement) IfAutofocus(condition bool) *ParamElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -437,10 +480,8 @@ func (e *ParamElement) IfAutofocus(condition bool) *ParamElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ParamElement) AutofocusRemove() *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -460,10 +501,8 @@ func (e *ParamElement) AutofocusRemove() *ParamElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ParamElement) AutofocusIfRemove() *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -472,16 +511,9 @@ func (e *ParamElement) AutofocusIfRemove() *ParamElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ParamElement) Class(s string) *ParamElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -491,7 +523,7 @@ func (e *ParamElement) Class(s string) *ParamElement {
 // document.getElementsByClassName.
 func (e *ParamElement) IfClass(condition bool, s string) *ParamElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -502,14 +534,8 @@ func (e *ParamElement) IfClass(condition bool, s string) *ParamElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ParamElement) ClassRemove(s ...string) *ParamElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -517,10 +543,8 @@ func (e *ParamElement) ClassRemove(s ...string) *ParamElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ParamElement) Contenteditable(c ParamContenteditableChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ParamContenteditableChoice])
 	return e
 }
 
@@ -543,10 +567,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ParamElement) ContenteditableRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -564,10 +586,8 @@ func (e *ParamElement) ContenteditableRemove() *ParamElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ParamElement) Dir(c ParamDirChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ParamDirChoice])
 	return e
 }
 
@@ -601,10 +621,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ParamElement) DirRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -612,10 +630,8 @@ func (e *ParamElement) DirRemove() *ParamElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ParamElement) Draggable(c ParamDraggableChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ParamDraggableChoice])
 	return e
 }
 
@@ -641,20 +657,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ParamElement) DraggableRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ParamElement) Enterkeyhint(c ParamEnterkeyhintChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ParamEnterkeyhintChoice])
 	return e
 }
 
@@ -684,10 +696,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ParamElement) EnterkeyhintRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -709,16 +719,9 @@ func (e *ParamElement) EnterkeyhintRemove() *ParamElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ParamElement) Exportparts(s string) *ParamElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -741,7 +744,7 @@ func (e *ParamElement) Exportparts(s string) *ParamElement {
 // the current structure.
 func (e *ParamElement) IfExportparts(condition bool, s string) *ParamElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -765,14 +768,8 @@ func (e *ParamElement) IfExportparts(condition bool, s string) *ParamElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ParamElement) ExportpartsRemove(s ...string) *ParamElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -789,10 +786,8 @@ func (e *ParamElement) ExportpartsRemove(s ...string) *ParamElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ParamElement) Hidden(c ParamHiddenChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ParamHiddenChoice])
 	return e
 }
 
@@ -828,10 +823,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ParamElement) HiddenRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -839,10 +832,8 @@ func (e *ParamElement) HiddenRemove() *ParamElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ParamElement) ID(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -858,7 +849,7 @@ func (e *ParamElement) IDf(format string, args ...any) *ParamElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ParamElement) IfID(condition bool, s string) *ParamElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -868,7 +859,7 @@ func (e *ParamElement) IfID(condition bool, s string) *ParamElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ParamElement) IfIDf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -878,10 +869,8 @@ func (e *ParamElement) IfIDf(condition bool, format string, args ...any) *ParamE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ParamElement) IDRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -896,10 +885,8 @@ func (e *ParamElement) IDRemove() *ParamElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ParamElement) Inert() *ParamElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -915,7 +902,7 @@ func (e *ParamElement) Inert() *ParamElement {
 // excluding them from the accessibility tree.
 func (e *ParamElement) IfInert(condition bool) *ParamElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -932,10 +919,8 @@ func (e *ParamElement) IfInert(condition bool) *ParamElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ParamElement) InertRemove() *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -951,10 +936,8 @@ func (e *ParamElement) InertRemove() *ParamElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ParamElement) InertIfRemove() *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -968,10 +951,8 @@ func (e *ParamElement) InertIfRemove() *ParamElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ParamElement) Inputmode(c ParamInputmodeChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ParamInputmodeChoice])
 	return e
 }
 
@@ -1022,10 +1003,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ParamElement) InputmodeRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1035,10 +1014,8 @@ func (e *ParamElement) InputmodeRemove() *ParamElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ParamElement) Is(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1058,7 +1035,7 @@ func (e *ParamElement) Isf(format string, args ...any) *ParamElement {
 // and extends the element type it is being applied to.
 func (e *ParamElement) IfIs(condition bool, s string) *ParamElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1070,7 +1047,7 @@ func (e *ParamElement) IfIs(condition bool, s string) *ParamElement {
 // and extends the element type it is being applied to.
 func (e *ParamElement) IfIsf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1082,10 +1059,8 @@ func (e *ParamElement) IfIsf(condition bool, format string, args ...any) *ParamE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ParamElement) IsRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1101,10 +1076,8 @@ func (e *ParamElement) IsRemove() *ParamElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ParamElement) Itemid(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1136,7 +1109,7 @@ func (e *ParamElement) Itemidf(format string, args ...any) *ParamElement {
 // how items with the same identifier are handled.
 func (e *ParamElement) IfItemid(condition bool, s string) *ParamElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1154,7 +1127,7 @@ func (e *ParamElement) IfItemid(condition bool, s string) *ParamElement {
 // how items with the same identifier are handled.
 func (e *ParamElement) IfItemidf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1172,10 +1145,8 @@ func (e *ParamElement) IfItemidf(condition bool, format string, args ...any) *Pa
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ParamElement) ItemidRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1187,10 +1158,8 @@ func (e *ParamElement) ItemidRemove() *ParamElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ParamElement) Itemprop(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1214,7 +1183,7 @@ func (e *ParamElement) Itempropf(format string, args ...any) *ParamElement {
 // <track>, and <video>.
 func (e *ParamElement) IfItemprop(condition bool, s string) *ParamElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1228,7 +1197,7 @@ func (e *ParamElement) IfItemprop(condition bool, s string) *ParamElement {
 // <track>, and <video>.
 func (e *ParamElement) IfItempropf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1242,10 +1211,8 @@ func (e *ParamElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ParamElement) ItempropRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1255,10 +1222,8 @@ func (e *ParamElement) ItempropRemove() *ParamElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ParamElement) Itemref(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1278,7 +1243,7 @@ func (e *ParamElement) Itemreff(format string, args ...any) *ParamElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ParamElement) IfItemref(condition bool, s string) *ParamElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1290,7 +1255,7 @@ func (e *ParamElement) IfItemref(condition bool, s string) *ParamElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ParamElement) IfItemreff(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1302,10 +1267,8 @@ func (e *ParamElement) IfItemreff(condition bool, format string, args ...any) *P
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ParamElement) ItemrefRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1317,10 +1280,8 @@ func (e *ParamElement) ItemrefRemove() *ParamElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ParamElement) Itemscope() *ParamElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1333,7 +1294,7 @@ func (e *ParamElement) Itemscope() *ParamElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ParamElement) IfItemscope(condition bool) *ParamElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1347,10 +1308,8 @@ func (e *ParamElement) IfItemscope(condition bool) *ParamElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ParamElement) ItemscopeRemove() *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1363,10 +1322,8 @@ func (e *ParamElement) ItemscopeRemove() *ParamElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ParamElement) ItemscopeIfRemove() *ParamElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1378,10 +1335,8 @@ func (e *ParamElement) ItemscopeIfRemove() *ParamElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ParamElement) Itemtype(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1405,7 +1360,7 @@ func (e *ParamElement) Itemtypef(format string, args ...any) *ParamElement {
 // <video>.
 func (e *ParamElement) IfItemtype(condition bool, s string) *ParamElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1419,7 +1374,7 @@ func (e *ParamElement) IfItemtype(condition bool, s string) *ParamElement {
 // <video>.
 func (e *ParamElement) IfItemtypef(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1433,10 +1388,8 @@ func (e *ParamElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ParamElement) ItemtypeRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1446,10 +1399,8 @@ func (e *ParamElement) ItemtypeRemove() *ParamElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ParamElement) Lang(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1469,7 +1420,7 @@ func (e *ParamElement) Langf(format string, args ...any) *ParamElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ParamElement) IfLang(condition bool, s string) *ParamElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1481,7 +1432,7 @@ func (e *ParamElement) IfLang(condition bool, s string) *ParamElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ParamElement) IfLangf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1493,10 +1444,8 @@ func (e *ParamElement) IfLangf(condition bool, format string, args ...any) *Para
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ParamElement) LangRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1506,10 +1455,8 @@ func (e *ParamElement) LangRemove() *ParamElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ParamElement) Nonce(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1529,7 +1476,7 @@ func (e *ParamElement) Noncef(format string, args ...any) *ParamElement {
 // execute.
 func (e *ParamElement) IfNonce(condition bool, s string) *ParamElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1541,7 +1488,7 @@ func (e *ParamElement) IfNonce(condition bool, s string) *ParamElement {
 // execute.
 func (e *ParamElement) IfNoncef(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1553,10 +1500,8 @@ func (e *ParamElement) IfNoncef(condition bool, format string, args ...any) *Par
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ParamElement) NonceRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1564,16 +1509,9 @@ func (e *ParamElement) NonceRemove() *ParamElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ParamElement) Part(s string) *ParamElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1582,7 +1520,7 @@ func (e *ParamElement) Part(s string) *ParamElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ParamElement) IfPart(condition bool, s string) *ParamElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1592,14 +1530,8 @@ func (e *ParamElement) IfPart(condition bool, s string) *ParamElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ParamElement) PartRemove(s ...string) *ParamElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1610,10 +1542,8 @@ func (e *ParamElement) PartRemove(s ...string) *ParamElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ParamElement) Popover(c ParamPopoverChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ParamPopoverChoice])
 	return e
 }
 
@@ -1641,10 +1571,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ParamElement) PopoverRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1653,10 +1581,8 @@ func (e *ParamElement) PopoverRemove() *ParamElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ParamElement) Role(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1674,7 +1600,7 @@ func (e *ParamElement) Rolef(format string, args ...any) *ParamElement {
 // role of an element.
 func (e *ParamElement) IfRole(condition bool, s string) *ParamElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1685,7 +1611,7 @@ func (e *ParamElement) IfRole(condition bool, s string) *ParamElement {
 // role of an element.
 func (e *ParamElement) IfRolef(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1696,10 +1622,8 @@ func (e *ParamElement) IfRolef(condition bool, format string, args ...any) *Para
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ParamElement) RoleRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1708,10 +1632,8 @@ func (e *ParamElement) RoleRemove() *ParamElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ParamElement) Slot(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1729,7 +1651,7 @@ func (e *ParamElement) Slotf(format string, args ...any) *ParamElement {
 // value.
 func (e *ParamElement) IfSlot(condition bool, s string) *ParamElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1740,7 +1662,7 @@ func (e *ParamElement) IfSlot(condition bool, s string) *ParamElement {
 // value.
 func (e *ParamElement) IfSlotf(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1751,10 +1673,8 @@ func (e *ParamElement) IfSlotf(condition bool, format string, args ...any) *Para
 // value.
 // Remove the attribute Slot from the element.
 func (e *ParamElement) SlotRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1770,10 +1690,8 @@ func (e *ParamElement) SlotRemove() *ParamElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ParamElement) Spellcheck(c ParamSpellcheckChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ParamSpellcheckChoice])
 	return e
 }
 
@@ -1801,10 +1719,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ParamElement) SpellcheckRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1814,14 +1730,8 @@ func (e *ParamElement) StylePairs(pairs ...string) *ParamElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1836,14 +1746,8 @@ func (e *ParamElement) StylePairs(pairs ...string) *ParamElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ParamElement) Style(s string) *ParamElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1851,7 +1755,7 @@ func (e *ParamElement) Style(s string) *ParamElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1860,7 +1764,7 @@ func (e *ParamElement) Style(s string) *ParamElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ParamElement) IfStyle(condition bool, s string) *ParamElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1868,15 +1772,7 @@ func (e *ParamElement) IfStyle(condition bool, s string) *ParamElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ParamElement) StyleAdd(k string, v string) *ParamElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1890,7 +1786,7 @@ func (e *ParamElement) StyleAddf(k string, format string, args ...any) *ParamEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *ParamElement) IfStyleAdd(condition bool, k string, v string) *ParamElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1899,7 +1795,7 @@ func (e *ParamElement) IfStyleAdd(condition bool, k string, v string) *ParamElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *ParamElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ParamElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1908,21 +1804,15 @@ func (e *ParamElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ParamElement) StyleMap(m map[string]string) *ParamElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1931,14 +1821,8 @@ func (e *ParamElement) StyleMap(m map[string]string) *ParamElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ParamElement) StyleRemove(keys ...string) *ParamElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1956,10 +1840,8 @@ func (e *ParamElement) StyleRemove(keys ...string) *ParamElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ParamElement) Tabindex(i int) *ParamElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1978,7 +1860,7 @@ func (e *ParamElement) Tabindex(i int) *ParamElement {
 // their relative position in the document.
 func (e *ParamElement) IfTabindex(condition bool, i int) *ParamElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1998,10 +1880,8 @@ func (e *ParamElement) IfTabindex(condition bool, i int) *ParamElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ParamElement) TabindexRemove() *ParamElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2022,10 +1902,8 @@ func (e *ParamElement) TabindexRemove() *ParamElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ParamElement) Title(s string) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2067,7 +1945,7 @@ func (e *ParamElement) Titlef(format string, args ...any) *ParamElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ParamElement) IfTitle(condition bool, s string) *ParamElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2090,7 +1968,7 @@ func (e *ParamElement) IfTitle(condition bool, s string) *ParamElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ParamElement) IfTitlef(condition bool, format string, args ...any) *ParamElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2113,10 +1991,8 @@ func (e *ParamElement) IfTitlef(condition bool, format string, args ...any) *Par
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ParamElement) TitleRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2125,10 +2001,8 @@ func (e *ParamElement) TitleRemove() *ParamElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ParamElement) Translate(c ParamTranslateChoice) *ParamElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ParamTranslateChoice])
 	return e
 }
 
@@ -2149,9 +2023,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ParamElement) TranslateRemove() *ParamElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
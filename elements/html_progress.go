@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <progress> element displays an indicator showing the completion
@@ -22,18 +20,77 @@ type ProgressElement struct {
 // with the tag "progress" during rendering.
 func Progress(children ...ElementRenderer) *ProgressElement {
 	e := NewElement("progress", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &ProgressElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ProgressElement) Clone() *ProgressElement {
+	return &ProgressElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ProgressElement) CloneShallow() *ProgressElement {
+	return &ProgressElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ProgressElement) Freeze() *ProgressElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ProgressElement) writable() *ProgressElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ProgressElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ProgressElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[ProgressAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ProgressContenteditableChoice]
+	case "dir":
+		return choiceValue[ProgressDirChoice]
+	case "draggable":
+		return choiceValue[ProgressDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ProgressEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ProgressHiddenChoice]
+	case "inputmode":
+		return choiceValue[ProgressInputmodeChoice]
+	case "popover":
+		return choiceValue[ProgressPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ProgressSpellcheckChoice]
+	case "translate":
+		return choiceValue[ProgressTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ProgressElement) Children(children ...ElementRenderer) *ProgressElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ProgressElement) IfChildren(condition bool, children ...ElementRenderer) *ProgressElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *ProgressElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *ProgressElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ProgressElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ProgressElement) BoolAttr(name string) *ProgressElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ProgressElement) BoolAttrRemove(name string) *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ProgressElement) IfBoolAttr(condition bool, name string) *ProgressElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *ProgressElement) BoolAttrf(format string, args ...any) *ProgressElement
 
 func (e *ProgressElement) IfBoolAttrf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ProgressElement) BoolAttrs(names ...string) *ProgressElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ProgressElement) IfBoolAttrs(condition bool, names ...string) *ProgressElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ProgressElement) Attr(name, value string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ProgressElement) RawAttr(name, value string) *ProgressElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ProgressElement) IfAttr(condition bool, name, value string) *ProgressElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *ProgressElement) Attrf(name, format string, args ...any) *ProgressEleme
 
 func (e *ProgressElement) IfAttrf(condition bool, name, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ProgressElement) Attrs(attrs ...string) *ProgressElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ProgressElement) IfAttrs(condition bool, attrs ...string) *ProgressElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ProgressElement) AttrsMap(attrs map[string]string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ProgressElement) IfAttrsMap(condition bool, attrs map[string]string) *ProgressElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ProgressElement) Text(text string) *ProgressElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ProgressElement) Textf(format string, args ...any) *ProgressElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ProgressElement) IfText(condition bool, text string) *ProgressElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *ProgressElement) IfText(condition bool, text string) *ProgressElement {
 
 func (e *ProgressElement) IfTextf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ProgressElement) Raw(text string) *ProgressElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ProgressElement) Escaped(text string) *ProgressElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ProgressElement) IfEscaped(condition bool, text string) *ProgressElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *ProgressElement) Escapedf(format string, args ...any) *ProgressElement
 
 func (e *ProgressElement) IfEscapedf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,34 +270,30 @@ func (e *ProgressElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Upper bound of range.
 func (e *ProgressElement) Max(f float64) *ProgressElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("max", f)
+	e = e.writable()
+	e.setFloatAttribute("max", f, 0)
 	return e
 }
 
 // Upper bound of range.
 func (e *ProgressElement) IfMax(condition bool, f float64) *ProgressElement {
 	if condition {
-		e.Max(f)
+		e = e.Max(f)
 	}
 	return e
 }
 
 // Current value of the element.
 func (e *ProgressElement) Value(f float64) *ProgressElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("value", f)
+	e = e.writable()
+	e.setFloatAttribute("value", f, 0)
 	return e
 }
 
 // Current value of the element.
 func (e *ProgressElement) IfValue(condition bool, f float64) *ProgressElement {
 	if condition {
-		e.Value(f)
+		e = e.Value(f)
 	}
 	return e
 }
@@ -246,10 +303,8 @@ func (e *ProgressElement) IfValue(condition bool, f float64) *ProgressElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ProgressElement) Accesskey(r rune) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -259,7 +314,7 @@ func (e *ProgressElement) Accesskey(r rune) *ProgressElement {
 // can be generated by the keyboard).
 func (e *ProgressElement) IfAccesskey(condition bool, r rune) *ProgressElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -270,10 +325,8 @@ func (e *ProgressElement) IfAccesskey(condition bool, r rune) *ProgressElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ProgressElement) AccesskeyRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -289,10 +342,8 @@ func (e *ProgressElement) AccesskeyRemove() *ProgressElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ProgressElement) Autocapitalize(c ProgressAutocapitalizeChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ProgressAutocapitalizeChoice])
 	return e
 }
 
@@ -326,10 +377,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ProgressElement) AutocapitalizeRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -348,10 +397,8 @@ func (e *ProgressElement) AutocapitalizeRemove() *ProgressElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ProgressElement) Autofocus() *ProgressElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -371,7 +418,7 @@ func (e *ProgressElement) Autofocus() *ProgressElement {
 // created by the preceding content.
 func (e *ProgressElement) IfAutofocus(condition bool) *ProgressElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -392,10 +439,8 @@ func (e *ProgressElement) IfAutofocus(condition bool) *ProgressElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ProgressElement) AutofocusRemove() *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -415,10 +460,8 @@ func (e *ProgressElement) AutofocusRemove() *ProgressElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ProgressElement) AutofocusIfRemove() *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -427,16 +470,9 @@ func (e *ProgressElement) AutofocusIfRemove() *ProgressElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ProgressElement) Class(s string) *ProgressElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -446,7 +482,7 @@ func (e *ProgressElement) Class(s string) *ProgressElement {
 // document.getElementsByClassName.
 func (e *ProgressElement) IfClass(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -457,14 +493,8 @@ func (e *ProgressElement) IfClass(condition bool, s string) *ProgressElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ProgressElement) ClassRemove(s ...string) *ProgressElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -472,10 +502,8 @@ func (e *ProgressElement) ClassRemove(s ...string) *ProgressElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ProgressElement) Contenteditable(c ProgressContenteditableChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ProgressContenteditableChoice])
 	return e
 }
 
@@ -498,10 +526,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ProgressElement) ContenteditableRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -519,10 +545,8 @@ func (e *ProgressElement) ContenteditableRemove() *ProgressElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ProgressElement) Dir(c ProgressDirChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ProgressDirChoice])
 	return e
 }
 
@@ -556,10 +580,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ProgressElement) DirRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -567,10 +589,8 @@ func (e *ProgressElement) DirRemove() *ProgressElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ProgressElement) Draggable(c ProgressDraggableChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ProgressDraggableChoice])
 	return e
 }
 
@@ -596,20 +616,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ProgressElement) DraggableRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ProgressElement) Enterkeyhint(c ProgressEnterkeyhintChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ProgressEnterkeyhintChoice])
 	return e
 }
 
@@ -639,10 +655,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ProgressElement) EnterkeyhintRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -664,16 +678,9 @@ func (e *ProgressElement) EnterkeyhintRemove() *ProgressElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ProgressElement) Exportparts(s string) *ProgressElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -696,7 +703,7 @@ func (e *ProgressElement) Exportparts(s string) *ProgressElement {
 // the current structure.
 func (e *ProgressElement) IfExportparts(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -720,14 +727,8 @@ func (e *ProgressElement) IfExportparts(condition bool, s string) *ProgressEleme
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ProgressElement) ExportpartsRemove(s ...string) *ProgressElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -744,10 +745,8 @@ func (e *ProgressElement) ExportpartsRemove(s ...string) *ProgressElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ProgressElement) Hidden(c ProgressHiddenChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ProgressHiddenChoice])
 	return e
 }
 
@@ -783,10 +782,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ProgressElement) HiddenRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -794,10 +791,8 @@ func (e *ProgressElement) HiddenRemove() *ProgressElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ProgressElement) ID(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -813,7 +808,7 @@ func (e *ProgressElement) IDf(format string, args ...any) *ProgressElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ProgressElement) IfID(condition bool, s string) *ProgressElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -823,7 +818,7 @@ func (e *ProgressElement) IfID(condition bool, s string) *ProgressElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ProgressElement) IfIDf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -833,10 +828,8 @@ func (e *ProgressElement) IfIDf(condition bool, format string, args ...any) *Pro
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ProgressElement) IDRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -851,10 +844,8 @@ func (e *ProgressElement) IDRemove() *ProgressElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ProgressElement) Inert() *ProgressElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -870,7 +861,7 @@ func (e *ProgressElement) Inert() *ProgressElement {
 // excluding them from the accessibility tree.
 func (e *ProgressElement) IfInert(condition bool) *ProgressElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -887,10 +878,8 @@ func (e *ProgressElement) IfInert(condition bool) *ProgressElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ProgressElement) InertRemove() *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -906,10 +895,8 @@ func (e *ProgressElement) InertRemove() *ProgressElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ProgressElement) InertIfRemove() *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -923,10 +910,8 @@ func (e *ProgressElement) InertIfRemove() *ProgressElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ProgressElement) Inputmode(c ProgressInputmodeChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ProgressInputmodeChoice])
 	return e
 }
 
@@ -977,10 +962,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ProgressElement) InputmodeRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -990,10 +973,8 @@ func (e *ProgressElement) InputmodeRemove() *ProgressElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ProgressElement) Is(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1013,7 +994,7 @@ func (e *ProgressElement) Isf(format string, args ...any) *ProgressElement {
 // and extends the element type it is being applied to.
 func (e *ProgressElement) IfIs(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1025,7 +1006,7 @@ func (e *ProgressElement) IfIs(condition bool, s string) *ProgressElement {
 // and extends the element type it is being applied to.
 func (e *ProgressElement) IfIsf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1037,10 +1018,8 @@ func (e *ProgressElement) IfIsf(condition bool, format string, args ...any) *Pro
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ProgressElement) IsRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1056,10 +1035,8 @@ func (e *ProgressElement) IsRemove() *ProgressElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ProgressElement) Itemid(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1091,7 +1068,7 @@ func (e *ProgressElement) Itemidf(format string, args ...any) *ProgressElement {
 // how items with the same identifier are handled.
 func (e *ProgressElement) IfItemid(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1109,7 +1086,7 @@ func (e *ProgressElement) IfItemid(condition bool, s string) *ProgressElement {
 // how items with the same identifier are handled.
 func (e *ProgressElement) IfItemidf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1127,10 +1104,8 @@ func (e *ProgressElement) IfItemidf(condition bool, format string, args ...any)
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ProgressElement) ItemidRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1142,10 +1117,8 @@ func (e *ProgressElement) ItemidRemove() *ProgressElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ProgressElement) Itemprop(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1169,7 +1142,7 @@ func (e *ProgressElement) Itempropf(format string, args ...any) *ProgressElement
 // <track>, and <video>.
 func (e *ProgressElement) IfItemprop(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1183,7 +1156,7 @@ func (e *ProgressElement) IfItemprop(condition bool, s string) *ProgressElement
 // <track>, and <video>.
 func (e *ProgressElement) IfItempropf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1197,10 +1170,8 @@ func (e *ProgressElement) IfItempropf(condition bool, format string, args ...any
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ProgressElement) ItempropRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1210,10 +1181,8 @@ func (e *ProgressElement) ItempropRemove() *ProgressElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ProgressElement) Itemref(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1233,7 +1202,7 @@ func (e *ProgressElement) Itemreff(format string, args ...any) *ProgressElement
 // specified on elements that have an itemscope attribute specified.
 func (e *ProgressElement) IfItemref(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1245,7 +1214,7 @@ func (e *ProgressElement) IfItemref(condition bool, s string) *ProgressElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ProgressElement) IfItemreff(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1257,10 +1226,8 @@ func (e *ProgressElement) IfItemreff(condition bool, format string, args ...any)
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ProgressElement) ItemrefRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1272,10 +1239,8 @@ func (e *ProgressElement) ItemrefRemove() *ProgressElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ProgressElement) Itemscope() *ProgressElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1288,7 +1253,7 @@ func (e *ProgressElement) Itemscope() *ProgressElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ProgressElement) IfItemscope(condition bool) *ProgressElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1302,10 +1267,8 @@ func (e *ProgressElement) IfItemscope(condition bool) *ProgressElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ProgressElement) ItemscopeRemove() *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1318,10 +1281,8 @@ func (e *ProgressElement) ItemscopeRemove() *ProgressElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ProgressElement) ItemscopeIfRemove() *ProgressElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1333,10 +1294,8 @@ func (e *ProgressElement) ItemscopeIfRemove() *ProgressElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ProgressElement) Itemtype(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1360,7 +1319,7 @@ func (e *ProgressElement) Itemtypef(format string, args ...any) *ProgressElement
 // <video>.
 func (e *ProgressElement) IfItemtype(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1374,7 +1333,7 @@ func (e *ProgressElement) IfItemtype(condition bool, s string) *ProgressElement
 // <video>.
 func (e *ProgressElement) IfItemtypef(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1388,10 +1347,8 @@ func (e *ProgressElement) IfItemtypef(condition bool, format string, args ...any
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ProgressElement) ItemtypeRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1401,10 +1358,8 @@ func (e *ProgressElement) ItemtypeRemove() *ProgressElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ProgressElement) Lang(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1424,7 +1379,7 @@ func (e *ProgressElement) Langf(format string, args ...any) *ProgressElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ProgressElement) IfLang(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1436,7 +1391,7 @@ func (e *ProgressElement) IfLang(condition bool, s string) *ProgressElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ProgressElement) IfLangf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1448,10 +1403,8 @@ func (e *ProgressElement) IfLangf(condition bool, format string, args ...any) *P
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ProgressElement) LangRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1461,10 +1414,8 @@ func (e *ProgressElement) LangRemove() *ProgressElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ProgressElement) Nonce(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1484,7 +1435,7 @@ func (e *ProgressElement) Noncef(format string, args ...any) *ProgressElement {
 // execute.
 func (e *ProgressElement) IfNonce(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1496,7 +1447,7 @@ func (e *ProgressElement) IfNonce(condition bool, s string) *ProgressElement {
 // execute.
 func (e *ProgressElement) IfNoncef(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1508,10 +1459,8 @@ func (e *ProgressElement) IfNoncef(condition bool, format string, args ...any) *
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ProgressElement) NonceRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1519,16 +1468,9 @@ func (e *ProgressElement) NonceRemove() *ProgressElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ProgressElement) Part(s string) *ProgressElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1537,7 +1479,7 @@ func (e *ProgressElement) Part(s string) *ProgressElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ProgressElement) IfPart(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1547,14 +1489,8 @@ func (e *ProgressElement) IfPart(condition bool, s string) *ProgressElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ProgressElement) PartRemove(s ...string) *ProgressElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1565,10 +1501,8 @@ func (e *ProgressElement) PartRemove(s ...string) *ProgressElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ProgressElement) Popover(c ProgressPopoverChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ProgressPopoverChoice])
 	return e
 }
 
@@ -1596,10 +1530,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ProgressElement) PopoverRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1608,10 +1540,8 @@ func (e *ProgressElement) PopoverRemove() *ProgressElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ProgressElement) Role(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1629,7 +1559,7 @@ func (e *ProgressElement) Rolef(format string, args ...any) *ProgressElement {
 // role of an element.
 func (e *ProgressElement) IfRole(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1640,7 +1570,7 @@ func (e *ProgressElement) IfRole(condition bool, s string) *ProgressElement {
 // role of an element.
 func (e *ProgressElement) IfRolef(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1651,10 +1581,8 @@ func (e *ProgressElement) IfRolef(condition bool, format string, args ...any) *P
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ProgressElement) RoleRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1663,10 +1591,8 @@ func (e *ProgressElement) RoleRemove() *ProgressElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ProgressElement) Slot(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1684,7 +1610,7 @@ func (e *ProgressElement) Slotf(format string, args ...any) *ProgressElement {
 // value.
 func (e *ProgressElement) IfSlot(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1695,7 +1621,7 @@ func (e *ProgressElement) IfSlot(condition bool, s string) *ProgressElement {
 // value.
 func (e *ProgressElement) IfSlotf(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1706,10 +1632,8 @@ func (e *ProgressElement) IfSlotf(condition bool, format string, args ...any) *P
 // value.
 // Remove the attribute Slot from the element.
 func (e *ProgressElement) SlotRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1725,10 +1649,8 @@ func (e *ProgressElement) SlotRemove() *ProgressElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ProgressElement) Spellcheck(c ProgressSpellcheckChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ProgressSpellcheckChoice])
 	return e
 }
 
@@ -1756,10 +1678,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ProgressElement) SpellcheckRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1769,14 +1689,8 @@ func (e *ProgressElement) StylePairs(pairs ...string) *ProgressElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1791,14 +1705,8 @@ func (e *ProgressElement) StylePairs(pairs ...string) *ProgressElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ProgressElement) Style(s string) *ProgressElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1806,7 +1714,7 @@ func (e *ProgressElement) Style(s string) *ProgressElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1815,7 +1723,7 @@ func (e *ProgressElement) Style(s string) *ProgressElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ProgressElement) IfStyle(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1823,15 +1731,7 @@ func (e *ProgressElement) IfStyle(condition bool, s string) *ProgressElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ProgressElement) StyleAdd(k string, v string) *ProgressElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1845,7 +1745,7 @@ func (e *ProgressElement) StyleAddf(k string, format string, args ...any) *Progr
 // color, font, size, and more. Styles are written in CSS.
 func (e *ProgressElement) IfStyleAdd(condition bool, k string, v string) *ProgressElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1854,7 +1754,7 @@ func (e *ProgressElement) IfStyleAdd(condition bool, k string, v string) *Progre
 // color, font, size, and more. Styles are written in CSS.
 func (e *ProgressElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ProgressElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1863,21 +1763,15 @@ func (e *ProgressElement) IfStyleAddf(condition bool, k string, format string, a
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ProgressElement) StyleMap(m map[string]string) *ProgressElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1886,14 +1780,8 @@ func (e *ProgressElement) StyleMap(m map[string]string) *ProgressElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ProgressElement) StyleRemove(keys ...string) *ProgressElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1911,10 +1799,8 @@ func (e *ProgressElement) StyleRemove(keys ...string) *ProgressElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ProgressElement) Tabindex(i int) *ProgressElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1933,7 +1819,7 @@ func (e *ProgressElement) Tabindex(i int) *ProgressElement {
 // their relative position in the document.
 func (e *ProgressElement) IfTabindex(condition bool, i int) *ProgressElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1953,10 +1839,8 @@ func (e *ProgressElement) IfTabindex(condition bool, i int) *ProgressElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ProgressElement) TabindexRemove() *ProgressElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1977,10 +1861,8 @@ func (e *ProgressElement) TabindexRemove() *ProgressElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ProgressElement) Title(s string) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2022,7 +1904,7 @@ func (e *ProgressElement) Titlef(format string, args ...any) *ProgressElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ProgressElement) IfTitle(condition bool, s string) *ProgressElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2045,7 +1927,7 @@ func (e *ProgressElement) IfTitle(condition bool, s string) *ProgressElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ProgressElement) IfTitlef(condition bool, format string, args ...any) *ProgressElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2068,10 +1950,8 @@ func (e *ProgressElement) IfTitlef(condition bool, format string, args ...any) *
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ProgressElement) TitleRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2080,10 +1960,8 @@ func (e *ProgressElement) TitleRemove() *ProgressElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ProgressElement) Translate(c ProgressTranslateChoice) *ProgressElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ProgressTranslateChoice])
 	return e
 }
 
@@ -2104,9 +1982,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ProgressElement) TranslateRemove() *ProgressElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <q> element indicates that the enclosed text is a short inline
@@ -23,18 +21,77 @@ type QElement struct {
 // with the tag "q" during rendering.
 func Q(children ...ElementRenderer) *QElement {
 	e := NewElement("q", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &QElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *QElement) Clone() *QElement {
+	return &QElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *QElement) CloneShallow() *QElement {
+	return &QElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *QElement) Freeze() *QElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *QElement) writable() *QElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *QElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *QElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[QAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[QContenteditableChoice]
+	case "dir":
+		return choiceValue[QDirChoice]
+	case "draggable":
+		return choiceValue[QDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[QEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[QHiddenChoice]
+	case "inputmode":
+		return choiceValue[QInputmodeChoice]
+	case "popover":
+		return choiceValue[QPopoverChoice]
+	case "spellcheck":
+		return choiceValue[QSpellcheckChoice]
+	case "translate":
+		return choiceValue[QTranslateChoice]
+	}
+	return nil
+}
+
 func (e *QElement) Children(children ...ElementRenderer) *QElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *QElement) IfChildren(condition bool, children ...ElementRenderer) *QElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *QElement) IfChildren(condition bool, children ...ElementRenderer) *QEle
 
 func (e *QElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *QElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *QElement) BoolAttr(name string) *QElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *QElement) BoolAttrRemove(name string) *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *QElement) IfBoolAttr(condition bool, name string) *QElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *QElement) BoolAttrf(format string, args ...any) *QElement {
 
 func (e *QElement) IfBoolAttrf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *QElement) BoolAttrs(names ...string) *QElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *QElement) IfBoolAttrs(condition bool, names ...string) *QElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *QElement) Attr(name, value string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *QElement) RawAttr(name, value string) *QElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *QElement) IfAttr(condition bool, name, value string) *QElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *QElement) Attrf(name, format string, args ...any) *QElement {
 
 func (e *QElement) IfAttrf(condition bool, name, format string, args ...any) *QElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *QElement) Attrs(attrs ...string) *QElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *QElement) IfAttrs(condition bool, attrs ...string) *QElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *QElement) AttrsMap(attrs map[string]string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *QElement) IfAttrsMap(condition bool, attrs map[string]string) *QElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *QElement) Text(text string) *QElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *QElement) Textf(format string, args ...any) *QElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *QElement) IfText(condition bool, text string) *QElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *QElement) IfText(condition bool, text string) *QElement {
 
 func (e *QElement) IfTextf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *QElement) Raw(text string) *QElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *QElement) Escaped(text string) *QElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *QElement) IfEscaped(condition bool, text string) *QElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *QElement) Escapedf(format string, args ...any) *QElement {
 
 func (e *QElement) IfEscapedf(condition bool, format string, args ...any) *QElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +271,8 @@ func (e *QElement) IfEscapedf(condition bool, format string, args ...any) *QElem
 
 // Contains a URI which points to the source of the quote or change.
 func (e *QElement) Cite(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("cite", s)
+	e = e.writable()
+	e.setStringAttribute("cite", s)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *QElement) Citef(format string, args ...any) *QElement {
 // Contains a URI which points to the source of the quote or change.
 func (e *QElement) IfCite(condition bool, s string) *QElement {
 	if condition {
-		e.Cite(s)
+		e = e.Cite(s)
 	}
 	return e
 }
@@ -233,7 +292,7 @@ func (e *QElement) IfCite(condition bool, s string) *QElement {
 // Contains a URI which points to the source of the quote or change.
 func (e *QElement) IfCitef(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Cite(fmt.Sprintf(format, args...))
+		e = e.Cite(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,10 +300,8 @@ func (e *QElement) IfCitef(condition bool, format string, args ...any) *QElement
 // Contains a URI which points to the source of the quote or change.
 // Remove the attribute Cite from the element.
 func (e *QElement) CiteRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("cite")
+	e = e.writable()
+	e.removeAttribute("cite")
 	return e
 }
 
@@ -253,10 +310,8 @@ func (e *QElement) CiteRemove() *QElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *QElement) Accesskey(r rune) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -266,7 +321,7 @@ func (e *QElement) Accesskey(r rune) *QElement {
 // can be generated by the keyboard).
 func (e *QElement) IfAccesskey(condition bool, r rune) *QElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -277,10 +332,8 @@ func (e *QElement) IfAccesskey(condition bool, r rune) *QElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *QElement) AccesskeyRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -296,10 +349,8 @@ func (e *QElement) AccesskeyRemove() *QElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *QElement) Autocapitalize(c QAutocapitalizeChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[QAutocapitalizeChoice])
 	return e
 }
 
@@ -333,10 +384,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *QElement) AutocapitalizeRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -355,10 +404,8 @@ func (e *QElement) AutocapitalizeRemove() *QElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *QElement) Autofocus() *QElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -378,7 +425,7 @@ func (e *QElement) Autofocus() *QElement {
 // created by the preceding content.
 func (e *QElement) IfAutofocus(condition bool) *QElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -399,10 +446,8 @@ func (e *QElement) IfAutofocus(condition bool) *QElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *QElement) AutofocusRemove() *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -422,10 +467,8 @@ func (e *QElement) AutofocusRemove() *QElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *QElement) AutofocusIfRemove() *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -434,16 +477,9 @@ func (e *QElement) AutofocusIfRemove() *QElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *QElement) Class(s string) *QElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -453,7 +489,7 @@ func (e *QElement) Class(s string) *QElement {
 // document.getElementsByClassName.
 func (e *QElement) IfClass(condition bool, s string) *QElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -464,14 +500,8 @@ func (e *QElement) IfClass(condition bool, s string) *QElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *QElement) ClassRemove(s ...string) *QElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -479,10 +509,8 @@ func (e *QElement) ClassRemove(s ...string) *QElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *QElement) Contenteditable(c QContenteditableChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[QContenteditableChoice])
 	return e
 }
 
@@ -505,10 +533,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *QElement) ContenteditableRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -526,10 +552,8 @@ func (e *QElement) ContenteditableRemove() *QElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *QElement) Dir(c QDirChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[QDirChoice])
 	return e
 }
 
@@ -563,10 +587,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *QElement) DirRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -574,10 +596,8 @@ func (e *QElement) DirRemove() *QElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *QElement) Draggable(c QDraggableChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[QDraggableChoice])
 	return e
 }
 
@@ -603,20 +623,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *QElement) DraggableRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *QElement) Enterkeyhint(c QEnterkeyhintChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[QEnterkeyhintChoice])
 	return e
 }
 
@@ -646,10 +662,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *QElement) EnterkeyhintRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -671,16 +685,9 @@ func (e *QElement) EnterkeyhintRemove() *QElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *QElement) Exportparts(s string) *QElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -703,7 +710,7 @@ func (e *QElement) Exportparts(s string) *QElement {
 // the current structure.
 func (e *QElement) IfExportparts(condition bool, s string) *QElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -727,14 +734,8 @@ func (e *QElement) IfExportparts(condition bool, s string) *QElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *QElement) ExportpartsRemove(s ...string) *QElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -751,10 +752,8 @@ func (e *QElement) ExportpartsRemove(s ...string) *QElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *QElement) Hidden(c QHiddenChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[QHiddenChoice])
 	return e
 }
 
@@ -790,10 +789,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *QElement) HiddenRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -801,10 +798,8 @@ func (e *QElement) HiddenRemove() *QElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *QElement) ID(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -820,7 +815,7 @@ func (e *QElement) IDf(format string, args ...any) *QElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *QElement) IfID(condition bool, s string) *QElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -830,7 +825,7 @@ func (e *QElement) IfID(condition bool, s string) *QElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *QElement) IfIDf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -840,10 +835,8 @@ func (e *QElement) IfIDf(condition bool, format string, args ...any) *QElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *QElement) IDRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -858,10 +851,8 @@ func (e *QElement) IDRemove() *QElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *QElement) Inert() *QElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -877,7 +868,7 @@ func (e *QElement) Inert() *QElement {
 // excluding them from the accessibility tree.
 func (e *QElement) IfInert(condition bool) *QElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -894,10 +885,8 @@ func (e *QElement) IfInert(condition bool) *QElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *QElement) InertRemove() *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -913,10 +902,8 @@ func (e *QElement) InertRemove() *QElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *QElement) InertIfRemove() *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -930,10 +917,8 @@ func (e *QElement) InertIfRemove() *QElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *QElement) Inputmode(c QInputmodeChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[QInputmodeChoice])
 	return e
 }
 
@@ -984,10 +969,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *QElement) InputmodeRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -997,10 +980,8 @@ func (e *QElement) InputmodeRemove() *QElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *QElement) Is(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1020,7 +1001,7 @@ func (e *QElement) Isf(format string, args ...any) *QElement {
 // and extends the element type it is being applied to.
 func (e *QElement) IfIs(condition bool, s string) *QElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1032,7 +1013,7 @@ func (e *QElement) IfIs(condition bool, s string) *QElement {
 // and extends the element type it is being applied to.
 func (e *QElement) IfIsf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1044,10 +1025,8 @@ func (e *QElement) IfIsf(condition bool, format string, args ...any) *QElement {
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *QElement) IsRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1063,10 +1042,8 @@ func (e *QElement) IsRemove() *QElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *QElement) Itemid(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1098,7 +1075,7 @@ func (e *QElement) Itemidf(format string, args ...any) *QElement {
 // how items with the same identifier are handled.
 func (e *QElement) IfItemid(condition bool, s string) *QElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1116,7 +1093,7 @@ func (e *QElement) IfItemid(condition bool, s string) *QElement {
 // how items with the same identifier are handled.
 func (e *QElement) IfItemidf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1134,10 +1111,8 @@ func (e *QElement) IfItemidf(condition bool, format string, args ...any) *QEleme
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *QElement) ItemidRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1149,10 +1124,8 @@ func (e *QElement) ItemidRemove() *QElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *QElement) Itemprop(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1176,7 +1149,7 @@ func (e *QElement) Itempropf(format string, args ...any) *QElement {
 // <track>, and <video>.
 func (e *QElement) IfItemprop(condition bool, s string) *QElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1190,7 +1163,7 @@ func (e *QElement) IfItemprop(condition bool, s string) *QElement {
 // <track>, and <video>.
 func (e *QElement) IfItempropf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1204,10 +1177,8 @@ func (e *QElement) IfItempropf(condition bool, format string, args ...any) *QEle
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *QElement) ItempropRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1217,10 +1188,8 @@ func (e *QElement) ItempropRemove() *QElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *QElement) Itemref(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1240,7 +1209,7 @@ func (e *QElement) Itemreff(format string, args ...any) *QElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *QElement) IfItemref(condition bool, s string) *QElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1252,7 +1221,7 @@ func (e *QElement) IfItemref(condition bool, s string) *QElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *QElement) IfItemreff(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1264,10 +1233,8 @@ func (e *QElement) IfItemreff(condition bool, format string, args ...any) *QElem
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *QElement) ItemrefRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1279,10 +1246,8 @@ func (e *QElement) ItemrefRemove() *QElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *QElement) Itemscope() *QElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1295,7 +1260,7 @@ func (e *QElement) Itemscope() *QElement {
 // <object>, <source>, <track>, and <video>.
 func (e *QElement) IfItemscope(condition bool) *QElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1309,10 +1274,8 @@ func (e *QElement) IfItemscope(condition bool) *QElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *QElement) ItemscopeRemove() *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1325,10 +1288,8 @@ func (e *QElement) ItemscopeRemove() *QElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *QElement) ItemscopeIfRemove() *QElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1340,10 +1301,8 @@ func (e *QElement) ItemscopeIfRemove() *QElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *QElement) Itemtype(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1367,7 +1326,7 @@ func (e *QElement) Itemtypef(format string, args ...any) *QElement {
 // <video>.
 func (e *QElement) IfItemtype(condition bool, s string) *QElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1381,7 +1340,7 @@ func (e *QElement) IfItemtype(condition bool, s string) *QElement {
 // <video>.
 func (e *QElement) IfItemtypef(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1395,10 +1354,8 @@ func (e *QElement) IfItemtypef(condition bool, format string, args ...any) *QEle
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *QElement) ItemtypeRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1408,10 +1365,8 @@ func (e *QElement) ItemtypeRemove() *QElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *QElement) Lang(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1431,7 +1386,7 @@ func (e *QElement) Langf(format string, args ...any) *QElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *QElement) IfLang(condition bool, s string) *QElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1443,7 +1398,7 @@ func (e *QElement) IfLang(condition bool, s string) *QElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *QElement) IfLangf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1455,10 +1410,8 @@ func (e *QElement) IfLangf(condition bool, format string, args ...any) *QElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *QElement) LangRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1468,10 +1421,8 @@ func (e *QElement) LangRemove() *QElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *QElement) Nonce(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1491,7 +1442,7 @@ func (e *QElement) Noncef(format string, args ...any) *QElement {
 // execute.
 func (e *QElement) IfNonce(condition bool, s string) *QElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1503,7 +1454,7 @@ func (e *QElement) IfNonce(condition bool, s string) *QElement {
 // execute.
 func (e *QElement) IfNoncef(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1515,10 +1466,8 @@ func (e *QElement) IfNoncef(condition bool, format string, args ...any) *QElemen
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *QElement) NonceRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1526,16 +1475,9 @@ func (e *QElement) NonceRemove() *QElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *QElement) Part(s string) *QElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1544,7 +1486,7 @@ func (e *QElement) Part(s string) *QElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *QElement) IfPart(condition bool, s string) *QElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1554,14 +1496,8 @@ func (e *QElement) IfPart(condition bool, s string) *QElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *QElement) PartRemove(s ...string) *QElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1572,10 +1508,8 @@ func (e *QElement) PartRemove(s ...string) *QElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *QElement) Popover(c QPopoverChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[QPopoverChoice])
 	return e
 }
 
@@ -1603,10 +1537,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *QElement) PopoverRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1615,10 +1547,8 @@ func (e *QElement) PopoverRemove() *QElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *QElement) Role(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1636,7 +1566,7 @@ func (e *QElement) Rolef(format string, args ...any) *QElement {
 // role of an element.
 func (e *QElement) IfRole(condition bool, s string) *QElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1647,7 +1577,7 @@ func (e *QElement) IfRole(condition bool, s string) *QElement {
 // role of an element.
 func (e *QElement) IfRolef(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1658,10 +1588,8 @@ func (e *QElement) IfRolef(condition bool, format string, args ...any) *QElement
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *QElement) RoleRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1670,10 +1598,8 @@ func (e *QElement) RoleRemove() *QElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *QElement) Slot(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1691,7 +1617,7 @@ func (e *QElement) Slotf(format string, args ...any) *QElement {
 // value.
 func (e *QElement) IfSlot(condition bool, s string) *QElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1702,7 +1628,7 @@ func (e *QElement) IfSlot(condition bool, s string) *QElement {
 // value.
 func (e *QElement) IfSlotf(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1713,10 +1639,8 @@ func (e *QElement) IfSlotf(condition bool, format string, args ...any) *QElement
 // value.
 // Remove the attribute Slot from the element.
 func (e *QElement) SlotRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1732,10 +1656,8 @@ func (e *QElement) SlotRemove() *QElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *QElement) Spellcheck(c QSpellcheckChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[QSpellcheckChoice])
 	return e
 }
 
@@ -1763,10 +1685,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *QElement) SpellcheckRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1776,14 +1696,8 @@ func (e *QElement) StylePairs(pairs ...string) *QElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1798,14 +1712,8 @@ func (e *QElement) StylePairs(pairs ...string) *QElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *QElement) Style(s string) *QElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1813,7 +1721,7 @@ func (e *QElement) Style(s string) *QElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1822,7 +1730,7 @@ func (e *QElement) Style(s string) *QElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *QElement) IfStyle(condition bool, s string) *QElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1830,15 +1738,7 @@ func (e *QElement) IfStyle(condition bool, s string) *QElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *QElement) StyleAdd(k string, v string) *QElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1852,7 +1752,7 @@ func (e *QElement) StyleAddf(k string, format string, args ...any) *QElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *QElement) IfStyleAdd(condition bool, k string, v string) *QElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1861,7 +1761,7 @@ func (e *QElement) IfStyleAdd(condition bool, k string, v string) *QElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *QElement) IfStyleAddf(condition bool, k string, format string, args ...any) *QElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1870,21 +1770,15 @@ func (e *QElement) IfStyleAddf(condition bool, k string, format string, args ...
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *QElement) StyleMap(m map[string]string) *QElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1893,14 +1787,8 @@ func (e *QElement) StyleMap(m map[string]string) *QElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *QElement) StyleRemove(keys ...string) *QElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1918,10 +1806,8 @@ func (e *QElement) StyleRemove(keys ...string) *QElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *QElement) Tabindex(i int) *QElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1940,7 +1826,7 @@ func (e *QElement) Tabindex(i int) *QElement {
 // their relative position in the document.
 func (e *QElement) IfTabindex(condition bool, i int) *QElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1960,10 +1846,8 @@ func (e *QElement) IfTabindex(condition bool, i int) *QElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *QElement) TabindexRemove() *QElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1984,10 +1868,8 @@ func (e *QElement) TabindexRemove() *QElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *QElement) Title(s string) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2029,7 +1911,7 @@ func (e *QElement) Titlef(format string, args ...any) *QElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *QElement) IfTitle(condition bool, s string) *QElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2052,7 +1934,7 @@ func (e *QElement) IfTitle(condition bool, s string) *QElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *QElement) IfTitlef(condition bool, format string, args ...any) *QElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2075,10 +1957,8 @@ func (e *QElement) IfTitlef(condition bool, format string, args ...any) *QElemen
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *QElement) TitleRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2087,10 +1967,8 @@ func (e *QElement) TitleRemove() *QElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *QElement) Translate(c QTranslateChoice) *QElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[QTranslateChoice])
 	return e
 }
 
@@ -2111,9 +1989,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *QElement) TranslateRemove() *QElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
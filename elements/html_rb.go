@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <rb> element marks the base text component of a ruby annotation,
@@ -26,18 +24,77 @@ type RbElement struct {
 // with the tag "rb" during rendering.
 func Rb(children ...ElementRenderer) *RbElement {
 	e := NewElement("rb", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &RbElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *RbElement) Clone() *RbElement {
+	return &RbElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *RbElement) CloneShallow() *RbElement {
+	return &RbElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *RbElement) Freeze() *RbElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *RbElement) writable() *RbElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *RbElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *RbElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[RbAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[RbContenteditableChoice]
+	case "dir":
+		return choiceValue[RbDirChoice]
+	case "draggable":
+		return choiceValue[RbDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[RbEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[RbHiddenChoice]
+	case "inputmode":
+		return choiceValue[RbInputmodeChoice]
+	case "popover":
+		return choiceValue[RbPopoverChoice]
+	case "spellcheck":
+		return choiceValue[RbSpellcheckChoice]
+	case "translate":
+		return choiceValue[RbTranslateChoice]
+	}
+	return nil
+}
+
 func (e *RbElement) Children(children ...ElementRenderer) *RbElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *RbElement) IfChildren(condition bool, children ...ElementRenderer) *RbElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -45,32 +102,30 @@ func (e *RbElement) IfChildren(condition bool, children ...ElementRenderer) *RbE
 
 func (e *RbElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *RbElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *RbElement) BoolAttr(name string) *RbElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *RbElement) BoolAttrRemove(name string) *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *RbElement) IfBoolAttr(condition bool, name string) *RbElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -81,36 +136,40 @@ func (e *RbElement) BoolAttrf(format string, args ...any) *RbElement {
 
 func (e *RbElement) IfBoolAttrf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *RbElement) BoolAttrs(names ...string) *RbElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *RbElement) IfBoolAttrs(condition bool, names ...string) *RbElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *RbElement) Attr(name, value string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *RbElement) RawAttr(name, value string) *RbElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *RbElement) IfAttr(condition bool, name, value string) *RbElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -121,61 +180,52 @@ func (e *RbElement) Attrf(name, format string, args ...any) *RbElement {
 
 func (e *RbElement) IfAttrf(condition bool, name, format string, args ...any) *RbElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *RbElement) Attrs(attrs ...string) *RbElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *RbElement) IfAttrs(condition bool, attrs ...string) *RbElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *RbElement) AttrsMap(attrs map[string]string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *RbElement) IfAttrsMap(condition bool, attrs map[string]string) *RbElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *RbElement) Text(text string) *RbElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *RbElement) Textf(format string, args ...any) *RbElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *RbElement) IfText(condition bool, text string) *RbElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -183,18 +233,28 @@ func (e *RbElement) IfText(condition bool, text string) *RbElement {
 
 func (e *RbElement) IfTextf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *RbElement) Raw(text string) *RbElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *RbElement) Escaped(text string) *RbElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *RbElement) IfEscaped(condition bool, text string) *RbElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -206,6 +266,7 @@ func (e *RbElement) Escapedf(format string, args ...any) *RbElement {
 
 func (e *RbElement) IfEscapedf(condition bool, format string, args ...any) *RbElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -216,10 +277,8 @@ func (e *RbElement) IfEscapedf(condition bool, format string, args ...any) *RbEl
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *RbElement) Accesskey(r rune) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -229,7 +288,7 @@ func (e *RbElement) Accesskey(r rune) *RbElement {
 // can be generated by the keyboard).
 func (e *RbElement) IfAccesskey(condition bool, r rune) *RbElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -240,10 +299,8 @@ func (e *RbElement) IfAccesskey(condition bool, r rune) *RbElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *RbElement) AccesskeyRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -259,10 +316,8 @@ func (e *RbElement) AccesskeyRemove() *RbElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *RbElement) Autocapitalize(c RbAutocapitalizeChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[RbAutocapitalizeChoice])
 	return e
 }
 
@@ -296,10 +351,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *RbElement) AutocapitalizeRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -318,10 +371,8 @@ func (e *RbElement) AutocapitalizeRemove() *RbElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *RbElement) Autofocus() *RbElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -341,7 +392,7 @@ func (e *RbElement) Autofocus() *RbElement {
 // created by the preceding content.
 func (e *RbElement) IfAutofocus(condition bool) *RbElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -362,10 +413,8 @@ func (e *RbElement) IfAutofocus(condition bool) *RbElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *RbElement) AutofocusRemove() *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -385,10 +434,8 @@ func (e *RbElement) AutofocusRemove() *RbElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *RbElement) AutofocusIfRemove() *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -397,16 +444,9 @@ func (e *RbElement) AutofocusIfRemove() *RbElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *RbElement) Class(s string) *RbElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -416,7 +456,7 @@ func (e *RbElement) Class(s string) *RbElement {
 // document.getElementsByClassName.
 func (e *RbElement) IfClass(condition bool, s string) *RbElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -427,14 +467,8 @@ func (e *RbElement) IfClass(condition bool, s string) *RbElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *RbElement) ClassRemove(s ...string) *RbElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -442,10 +476,8 @@ func (e *RbElement) ClassRemove(s ...string) *RbElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *RbElement) Contenteditable(c RbContenteditableChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[RbContenteditableChoice])
 	return e
 }
 
@@ -468,10 +500,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *RbElement) ContenteditableRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -489,10 +519,8 @@ func (e *RbElement) ContenteditableRemove() *RbElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *RbElement) Dir(c RbDirChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[RbDirChoice])
 	return e
 }
 
@@ -526,10 +554,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *RbElement) DirRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -537,10 +563,8 @@ func (e *RbElement) DirRemove() *RbElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *RbElement) Draggable(c RbDraggableChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[RbDraggableChoice])
 	return e
 }
 
@@ -566,20 +590,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *RbElement) DraggableRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *RbElement) Enterkeyhint(c RbEnterkeyhintChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[RbEnterkeyhintChoice])
 	return e
 }
 
@@ -609,10 +629,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *RbElement) EnterkeyhintRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -634,16 +652,9 @@ func (e *RbElement) EnterkeyhintRemove() *RbElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *RbElement) Exportparts(s string) *RbElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -666,7 +677,7 @@ func (e *RbElement) Exportparts(s string) *RbElement {
 // the current structure.
 func (e *RbElement) IfExportparts(condition bool, s string) *RbElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -690,14 +701,8 @@ func (e *RbElement) IfExportparts(condition bool, s string) *RbElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *RbElement) ExportpartsRemove(s ...string) *RbElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -714,10 +719,8 @@ func (e *RbElement) ExportpartsRemove(s ...string) *RbElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *RbElement) Hidden(c RbHiddenChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[RbHiddenChoice])
 	return e
 }
 
@@ -753,10 +756,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *RbElement) HiddenRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -764,10 +765,8 @@ func (e *RbElement) HiddenRemove() *RbElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *RbElement) ID(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -783,7 +782,7 @@ func (e *RbElement) IDf(format string, args ...any) *RbElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *RbElement) IfID(condition bool, s string) *RbElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -793,7 +792,7 @@ func (e *RbElement) IfID(condition bool, s string) *RbElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *RbElement) IfIDf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -803,10 +802,8 @@ func (e *RbElement) IfIDf(condition bool, format string, args ...any) *RbElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *RbElement) IDRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -821,10 +818,8 @@ func (e *RbElement) IDRemove() *RbElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *RbElement) Inert() *RbElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -840,7 +835,7 @@ func (e *RbElement) Inert() *RbElement {
 // excluding them from the accessibility tree.
 func (e *RbElement) IfInert(condition bool) *RbElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -857,10 +852,8 @@ func (e *RbElement) IfInert(condition bool) *RbElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *RbElement) InertRemove() *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -876,10 +869,8 @@ func (e *RbElement) InertRemove() *RbElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *RbElement) InertIfRemove() *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -893,10 +884,8 @@ func (e *RbElement) InertIfRemove() *RbElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *RbElement) Inputmode(c RbInputmodeChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[RbInputmodeChoice])
 	return e
 }
 
@@ -947,10 +936,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *RbElement) InputmodeRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -960,10 +947,8 @@ func (e *RbElement) InputmodeRemove() *RbElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *RbElement) Is(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -983,7 +968,7 @@ func (e *RbElement) Isf(format string, args ...any) *RbElement {
 // and extends the element type it is being applied to.
 func (e *RbElement) IfIs(condition bool, s string) *RbElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -995,7 +980,7 @@ func (e *RbElement) IfIs(condition bool, s string) *RbElement {
 // and extends the element type it is being applied to.
 func (e *RbElement) IfIsf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1007,10 +992,8 @@ func (e *RbElement) IfIsf(condition bool, format string, args ...any) *RbElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *RbElement) IsRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1026,10 +1009,8 @@ func (e *RbElement) IsRemove() *RbElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *RbElement) Itemid(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1061,7 +1042,7 @@ func (e *RbElement) Itemidf(format string, args ...any) *RbElement {
 // how items with the same identifier are handled.
 func (e *RbElement) IfItemid(condition bool, s string) *RbElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1079,7 +1060,7 @@ func (e *RbElement) IfItemid(condition bool, s string) *RbElement {
 // how items with the same identifier are handled.
 func (e *RbElement) IfItemidf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1097,10 +1078,8 @@ func (e *RbElement) IfItemidf(condition bool, format string, args ...any) *RbEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *RbElement) ItemidRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1112,10 +1091,8 @@ func (e *RbElement) ItemidRemove() *RbElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *RbElement) Itemprop(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1139,7 +1116,7 @@ func (e *RbElement) Itempropf(format string, args ...any) *RbElement {
 // <track>, and <video>.
 func (e *RbElement) IfItemprop(condition bool, s string) *RbElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1153,7 +1130,7 @@ func (e *RbElement) IfItemprop(condition bool, s string) *RbElement {
 // <track>, and <video>.
 func (e *RbElement) IfItempropf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1167,10 +1144,8 @@ func (e *RbElement) IfItempropf(condition bool, format string, args ...any) *RbE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *RbElement) ItempropRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1180,10 +1155,8 @@ func (e *RbElement) ItempropRemove() *RbElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *RbElement) Itemref(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1203,7 +1176,7 @@ func (e *RbElement) Itemreff(format string, args ...any) *RbElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *RbElement) IfItemref(condition bool, s string) *RbElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1215,7 +1188,7 @@ func (e *RbElement) IfItemref(condition bool, s string) *RbElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *RbElement) IfItemreff(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1227,10 +1200,8 @@ func (e *RbElement) IfItemreff(condition bool, format string, args ...any) *RbEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *RbElement) ItemrefRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1242,10 +1213,8 @@ func (e *RbElement) ItemrefRemove() *RbElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *RbElement) Itemscope() *RbElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1258,7 +1227,7 @@ func (e *RbElement) Itemscope() *RbElement {
 // <object>, <source>, <track>, and <video>.
 func (e *RbElement) IfItemscope(condition bool) *RbElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1272,10 +1241,8 @@ func (e *RbElement) IfItemscope(condition bool) *RbElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *RbElement) ItemscopeRemove() *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1288,10 +1255,8 @@ func (e *RbElement) ItemscopeRemove() *RbElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *RbElement) ItemscopeIfRemove() *RbElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1303,10 +1268,8 @@ func (e *RbElement) ItemscopeIfRemove() *RbElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *RbElement) Itemtype(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1330,7 +1293,7 @@ func (e *RbElement) Itemtypef(format string, args ...any) *RbElement {
 // <video>.
 func (e *RbElement) IfItemtype(condition bool, s string) *RbElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1344,7 +1307,7 @@ func (e *RbElement) IfItemtype(condition bool, s string) *RbElement {
 // <video>.
 func (e *RbElement) IfItemtypef(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1358,10 +1321,8 @@ func (e *RbElement) IfItemtypef(condition bool, format string, args ...any) *RbE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *RbElement) ItemtypeRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1371,10 +1332,8 @@ func (e *RbElement) ItemtypeRemove() *RbElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *RbElement) Lang(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1394,7 +1353,7 @@ func (e *RbElement) Langf(format string, args ...any) *RbElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *RbElement) IfLang(condition bool, s string) *RbElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1406,7 +1365,7 @@ func (e *RbElement) IfLang(condition bool, s string) *RbElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *RbElement) IfLangf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1418,10 +1377,8 @@ func (e *RbElement) IfLangf(condition bool, format string, args ...any) *RbEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *RbElement) LangRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1431,10 +1388,8 @@ func (e *RbElement) LangRemove() *RbElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *RbElement) Nonce(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1454,7 +1409,7 @@ func (e *RbElement) Noncef(format string, args ...any) *RbElement {
 // execute.
 func (e *RbElement) IfNonce(condition bool, s string) *RbElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1466,7 +1421,7 @@ func (e *RbElement) IfNonce(condition bool, s string) *RbElement {
 // execute.
 func (e *RbElement) IfNoncef(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1478,10 +1433,8 @@ func (e *RbElement) IfNoncef(condition bool, format string, args ...any) *RbElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *RbElement) NonceRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1489,16 +1442,9 @@ func (e *RbElement) NonceRemove() *RbElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *RbElement) Part(s string) *RbElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1507,7 +1453,7 @@ func (e *RbElement) Part(s string) *RbElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *RbElement) IfPart(condition bool, s string) *RbElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1517,14 +1463,8 @@ func (e *RbElement) IfPart(condition bool, s string) *RbElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *RbElement) PartRemove(s ...string) *RbElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1535,10 +1475,8 @@ func (e *RbElement) PartRemove(s ...string) *RbElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *RbElement) Popover(c RbPopoverChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[RbPopoverChoice])
 	return e
 }
 
@@ -1566,10 +1504,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *RbElement) PopoverRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1578,10 +1514,8 @@ func (e *RbElement) PopoverRemove() *RbElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *RbElement) Role(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1599,7 +1533,7 @@ func (e *RbElement) Rolef(format string, args ...any) *RbElement {
 // role of an element.
 func (e *RbElement) IfRole(condition bool, s string) *RbElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1610,7 +1544,7 @@ func (e *RbElement) IfRole(condition bool, s string) *RbElement {
 // role of an element.
 func (e *RbElement) IfRolef(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1621,10 +1555,8 @@ func (e *RbElement) IfRolef(condition bool, format string, args ...any) *RbEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *RbElement) RoleRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1633,10 +1565,8 @@ func (e *RbElement) RoleRemove() *RbElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *RbElement) Slot(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1654,7 +1584,7 @@ func (e *RbElement) Slotf(format string, args ...any) *RbElement {
 // value.
 func (e *RbElement) IfSlot(condition bool, s string) *RbElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1665,7 +1595,7 @@ func (e *RbElement) IfSlot(condition bool, s string) *RbElement {
 // value.
 func (e *RbElement) IfSlotf(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1676,10 +1606,8 @@ func (e *RbElement) IfSlotf(condition bool, format string, args ...any) *RbEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *RbElement) SlotRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1695,10 +1623,8 @@ func (e *RbElement) SlotRemove() *RbElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *RbElement) Spellcheck(c RbSpellcheckChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[RbSpellcheckChoice])
 	return e
 }
 
@@ -1726,10 +1652,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *RbElement) SpellcheckRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1739,14 +1663,8 @@ func (e *RbElement) StylePairs(pairs ...string) *RbElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1761,14 +1679,8 @@ func (e *RbElement) StylePairs(pairs ...string) *RbElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *RbElement) Style(s string) *RbElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1776,7 +1688,7 @@ func (e *RbElement) Style(s string) *RbElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1785,7 +1697,7 @@ func (e *RbElement) Style(s string) *RbElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *RbElement) IfStyle(condition bool, s string) *RbElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1793,15 +1705,7 @@ func (e *RbElement) IfStyle(condition bool, s string) *RbElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *RbElement) StyleAdd(k string, v string) *RbElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1815,7 +1719,7 @@ func (e *RbElement) StyleAddf(k string, format string, args ...any) *RbElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *RbElement) IfStyleAdd(condition bool, k string, v string) *RbElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1824,7 +1728,7 @@ func (e *RbElement) IfStyleAdd(condition bool, k string, v string) *RbElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *RbElement) IfStyleAddf(condition bool, k string, format string, args ...any) *RbElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1833,21 +1737,15 @@ func (e *RbElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *RbElement) StyleMap(m map[string]string) *RbElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1856,14 +1754,8 @@ func (e *RbElement) StyleMap(m map[string]string) *RbElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *RbElement) StyleRemove(keys ...string) *RbElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1881,10 +1773,8 @@ func (e *RbElement) StyleRemove(keys ...string) *RbElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *RbElement) Tabindex(i int) *RbElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1903,7 +1793,7 @@ func (e *RbElement) Tabindex(i int) *RbElement {
 // their relative position in the document.
 func (e *RbElement) IfTabindex(condition bool, i int) *RbElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1923,10 +1813,8 @@ func (e *RbElement) IfTabindex(condition bool, i int) *RbElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *RbElement) TabindexRemove() *RbElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1947,10 +1835,8 @@ func (e *RbElement) TabindexRemove() *RbElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *RbElement) Title(s string) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1992,7 +1878,7 @@ func (e *RbElement) Titlef(format string, args ...any) *RbElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *RbElement) IfTitle(condition bool, s string) *RbElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2015,7 +1901,7 @@ func (e *RbElement) IfTitle(condition bool, s string) *RbElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *RbElement) IfTitlef(condition bool, format string, args ...any) *RbElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2038,10 +1924,8 @@ func (e *RbElement) IfTitlef(condition bool, format string, args ...any) *RbElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *RbElement) TitleRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2050,10 +1934,8 @@ func (e *RbElement) TitleRemove() *RbElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *RbElement) Translate(c RbTranslateChoice) *RbElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[RbTranslateChoice])
 	return e
 }
 
@@ -2074,9 +1956,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *RbElement) TranslateRemove() *RbElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
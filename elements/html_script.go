@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <script> element is used to embed or reference executable code; this
@@ -22,18 +20,81 @@ type ScriptElement struct {
 // with the tag "script" during rendering.
 func Script(children ...ElementRenderer) *ScriptElement {
 	e := NewElement("script", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &ScriptElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ScriptElement) Clone() *ScriptElement {
+	return &ScriptElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ScriptElement) CloneShallow() *ScriptElement {
+	return &ScriptElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ScriptElement) Freeze() *ScriptElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ScriptElement) writable() *ScriptElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ScriptElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ScriptElement) choiceType(name string) func(string) any {
+	switch name {
+	case "crossorigin":
+		return choiceValue[ScriptCrossoriginChoice]
+	case "referrerpolicy":
+		return choiceValue[ScriptReferrerpolicyChoice]
+	case "autocapitalize":
+		return choiceValue[ScriptAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ScriptContenteditableChoice]
+	case "dir":
+		return choiceValue[ScriptDirChoice]
+	case "draggable":
+		return choiceValue[ScriptDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ScriptEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ScriptHiddenChoice]
+	case "inputmode":
+		return choiceValue[ScriptInputmodeChoice]
+	case "popover":
+		return choiceValue[ScriptPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ScriptSpellcheckChoice]
+	case "translate":
+		return choiceValue[ScriptTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ScriptElement) Children(children ...ElementRenderer) *ScriptElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ScriptElement) IfChildren(condition bool, children ...ElementRenderer) *ScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +102,30 @@ func (e *ScriptElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *ScriptElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ScriptElement) BoolAttr(name string) *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ScriptElement) BoolAttrRemove(name string) *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ScriptElement) IfBoolAttr(condition bool, name string) *ScriptElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +136,40 @@ func (e *ScriptElement) BoolAttrf(format string, args ...any) *ScriptElement {
 
 func (e *ScriptElement) IfBoolAttrf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ScriptElement) BoolAttrs(names ...string) *ScriptElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ScriptElement) IfBoolAttrs(condition bool, names ...string) *ScriptElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ScriptElement) Attr(name, value string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ScriptElement) RawAttr(name, value string) *ScriptElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ScriptElement) IfAttr(condition bool, name, value string) *ScriptElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +180,52 @@ func (e *ScriptElement) Attrf(name, format string, args ...any) *ScriptElement {
 
 func (e *ScriptElement) IfAttrf(condition bool, name, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ScriptElement) Attrs(attrs ...string) *ScriptElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ScriptElement) IfAttrs(condition bool, attrs ...string) *ScriptElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ScriptElement) AttrsMap(attrs map[string]string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ScriptElement) IfAttrsMap(condition bool, attrs map[string]string) *ScriptElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ScriptElement) Text(text string) *ScriptElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ScriptElement) Textf(format string, args ...any) *ScriptElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ScriptElement) IfText(condition bool, text string) *ScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +233,28 @@ func (e *ScriptElement) IfText(condition bool, text string) *ScriptElement {
 
 func (e *ScriptElement) IfTextf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ScriptElement) Raw(text string) *ScriptElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ScriptElement) Escaped(text string) *ScriptElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ScriptElement) IfEscaped(condition bool, text string) *ScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +266,7 @@ func (e *ScriptElement) Escapedf(format string, args ...any) *ScriptElement {
 
 func (e *ScriptElement) IfEscapedf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,17 +274,15 @@ func (e *ScriptElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Execute script when available, without blocking.
 func (e *ScriptElement) Async() *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("async", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("async")
 	return e
 }
 
 // Execute script when available, without blocking.
 func (e *ScriptElement) IfAsync(condition bool) *ScriptElement {
 	if condition {
-		e.Async()
+		e = e.Async()
 	}
 	return e
 }
@@ -227,29 +290,23 @@ func (e *ScriptElement) IfAsync(condition bool) *ScriptElement {
 // Remove the attribute Async from the element.
 // Execute script when available, without blocking.
 func (e *ScriptElement) AsyncRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("async")
+	e = e.writable()
+	e.removeAttribute("async")
 	return e
 }
 
 // Remove the attribute Async from the element.
 // Execute script when available, without blocking.
 func (e *ScriptElement) AsyncIfRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("async")
+	e = e.writable()
+	e.removeAttribute("async")
 	return e
 }
 
 // How the element handles crossorigin requests.
 func (e *ScriptElement) Crossorigin(c ScriptCrossoriginChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("crossorigin", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("crossorigin", string(c), choiceValue[ScriptCrossoriginChoice])
 	return e
 }
 
@@ -267,26 +324,22 @@ const (
 // How the element handles crossorigin requests.
 // Remove the attribute Crossorigin from the element.
 func (e *ScriptElement) CrossoriginRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("crossorigin")
+	e = e.writable()
+	e.removeAttribute("crossorigin")
 	return e
 }
 
 // Defer script execution.
 func (e *ScriptElement) Defer() *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("defer", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("defer")
 	return e
 }
 
 // Defer script execution.
 func (e *ScriptElement) IfDefer(condition bool) *ScriptElement {
 	if condition {
-		e.Defer()
+		e = e.Defer()
 	}
 	return e
 }
@@ -294,29 +347,23 @@ func (e *ScriptElement) IfDefer(condition bool) *ScriptElement {
 // Remove the attribute Defer from the element.
 // Defer script execution.
 func (e *ScriptElement) DeferRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("defer")
+	e = e.writable()
+	e.removeAttribute("defer")
 	return e
 }
 
 // Remove the attribute Defer from the element.
 // Defer script execution.
 func (e *ScriptElement) DeferIfRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("defer")
+	e = e.writable()
+	e.removeAttribute("defer")
 	return e
 }
 
 // Subresource integrity.
 func (e *ScriptElement) Integrity(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("integrity", s)
+	e = e.writable()
+	e.setStringAttribute("integrity", s)
 	return e
 }
 
@@ -328,7 +375,7 @@ func (e *ScriptElement) Integrityf(format string, args ...any) *ScriptElement {
 // Subresource integrity.
 func (e *ScriptElement) IfIntegrity(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Integrity(s)
+		e = e.Integrity(s)
 	}
 	return e
 }
@@ -336,7 +383,7 @@ func (e *ScriptElement) IfIntegrity(condition bool, s string) *ScriptElement {
 // Subresource integrity.
 func (e *ScriptElement) IfIntegrityf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Integrity(fmt.Sprintf(format, args...))
+		e = e.Integrity(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -344,19 +391,15 @@ func (e *ScriptElement) IfIntegrityf(condition bool, format string, args ...any)
 // Subresource integrity.
 // Remove the attribute Integrity from the element.
 func (e *ScriptElement) IntegrityRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("integrity")
+	e = e.writable()
+	e.removeAttribute("integrity")
 	return e
 }
 
 // Type of script.
 func (e *ScriptElement) Language(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("language", s)
+	e = e.writable()
+	e.setStringAttribute("language", s)
 	return e
 }
 
@@ -368,7 +411,7 @@ func (e *ScriptElement) Languagef(format string, args ...any) *ScriptElement {
 // Type of script.
 func (e *ScriptElement) IfLanguage(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Language(s)
+		e = e.Language(s)
 	}
 	return e
 }
@@ -376,7 +419,7 @@ func (e *ScriptElement) IfLanguage(condition bool, s string) *ScriptElement {
 // Type of script.
 func (e *ScriptElement) IfLanguagef(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Language(fmt.Sprintf(format, args...))
+		e = e.Language(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -384,26 +427,22 @@ func (e *ScriptElement) IfLanguagef(condition bool, format string, args ...any)
 // Type of script.
 // Remove the attribute Language from the element.
 func (e *ScriptElement) LanguageRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("language")
+	e = e.writable()
+	e.removeAttribute("language")
 	return e
 }
 
 // Prevents execution in user agents that support module scripts.
 func (e *ScriptElement) Nomodule() *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("nomodule", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("nomodule")
 	return e
 }
 
 // Prevents execution in user agents that support module scripts.
 func (e *ScriptElement) IfNomodule(condition bool) *ScriptElement {
 	if condition {
-		e.Nomodule()
+		e = e.Nomodule()
 	}
 	return e
 }
@@ -411,29 +450,23 @@ func (e *ScriptElement) IfNomodule(condition bool) *ScriptElement {
 // Remove the attribute Nomodule from the element.
 // Prevents execution in user agents that support module scripts.
 func (e *ScriptElement) NomoduleRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("nomodule")
+	e = e.writable()
+	e.removeAttribute("nomodule")
 	return e
 }
 
 // Remove the attribute Nomodule from the element.
 // Prevents execution in user agents that support module scripts.
 func (e *ScriptElement) NomoduleIfRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("nomodule")
+	e = e.writable()
+	e.removeAttribute("nomodule")
 	return e
 }
 
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 func (e *ScriptElement) Nonce(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -445,7 +478,7 @@ func (e *ScriptElement) Noncef(format string, args ...any) *ScriptElement {
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 func (e *ScriptElement) IfNonce(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -453,7 +486,7 @@ func (e *ScriptElement) IfNonce(condition bool, s string) *ScriptElement {
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 func (e *ScriptElement) IfNoncef(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -461,20 +494,16 @@ func (e *ScriptElement) IfNoncef(condition bool, format string, args ...any) *Sc
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 // Remove the attribute Nonce from the element.
 func (e *ScriptElement) NonceRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // Specifies which referrer to send when fetching the resource. See
 // Referrer-Policy for possible values and their effects.
 func (e *ScriptElement) Referrerpolicy(c ScriptReferrerpolicyChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[ScriptReferrerpolicyChoice])
 	return e
 }
 
@@ -508,19 +537,15 @@ const (
 // Referrer-Policy for possible values and their effects.
 // Remove the attribute Referrerpolicy from the element.
 func (e *ScriptElement) ReferrerpolicyRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
 // Address of the resource.
 func (e *ScriptElement) Src(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -532,7 +557,7 @@ func (e *ScriptElement) Srcf(format string, args ...any) *ScriptElement {
 // Address of the resource.
 func (e *ScriptElement) IfSrc(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -540,7 +565,7 @@ func (e *ScriptElement) IfSrc(condition bool, s string) *ScriptElement {
 // Address of the resource.
 func (e *ScriptElement) IfSrcf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -548,19 +573,15 @@ func (e *ScriptElement) IfSrcf(condition bool, format string, args ...any) *Scri
 // Address of the resource.
 // Remove the attribute Src from the element.
 func (e *ScriptElement) SrcRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // Type of embedded resource.
 func (e *ScriptElement) Type(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -572,7 +593,7 @@ func (e *ScriptElement) Typef(format string, args ...any) *ScriptElement {
 // Type of embedded resource.
 func (e *ScriptElement) IfType(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -580,7 +601,7 @@ func (e *ScriptElement) IfType(condition bool, s string) *ScriptElement {
 // Type of embedded resource.
 func (e *ScriptElement) IfTypef(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -588,10 +609,8 @@ func (e *ScriptElement) IfTypef(condition bool, format string, args ...any) *Scr
 // Type of embedded resource.
 // Remove the attribute Type from the element.
 func (e *ScriptElement) TypeRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -600,10 +619,8 @@ func (e *ScriptElement) TypeRemove() *ScriptElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ScriptElement) Accesskey(r rune) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -613,7 +630,7 @@ func (e *ScriptElement) Accesskey(r rune) *ScriptElement {
 // can be generated by the keyboard).
 func (e *ScriptElement) IfAccesskey(condition bool, r rune) *ScriptElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -624,10 +641,8 @@ func (e *ScriptElement) IfAccesskey(condition bool, r rune) *ScriptElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ScriptElement) AccesskeyRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -643,10 +658,8 @@ func (e *ScriptElement) AccesskeyRemove() *ScriptElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ScriptElement) Autocapitalize(c ScriptAutocapitalizeChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ScriptAutocapitalizeChoice])
 	return e
 }
 
@@ -680,10 +693,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ScriptElement) AutocapitalizeRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -702,10 +713,8 @@ func (e *ScriptElement) AutocapitalizeRemove() *ScriptElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ScriptElement) Autofocus() *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -725,7 +734,7 @@ func (e *ScriptElement) Autofocus() *ScriptElement {
 // created by the preceding content.
 func (e *ScriptElement) IfAutofocus(condition bool) *ScriptElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -746,10 +755,8 @@ func (e *ScriptElement) IfAutofocus(condition bool) *ScriptElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ScriptElement) AutofocusRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -769,10 +776,8 @@ func (e *ScriptElement) AutofocusRemove() *ScriptElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ScriptElement) AutofocusIfRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -781,16 +786,9 @@ func (e *ScriptElement) AutofocusIfRemove() *ScriptElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ScriptElement) Class(s string) *ScriptElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -800,7 +798,7 @@ func (e *ScriptElement) Class(s string) *ScriptElement {
 // document.getElementsByClassName.
 func (e *ScriptElement) IfClass(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -811,14 +809,8 @@ func (e *ScriptElement) IfClass(condition bool, s string) *ScriptElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ScriptElement) ClassRemove(s ...string) *ScriptElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -826,10 +818,8 @@ func (e *ScriptElement) ClassRemove(s ...string) *ScriptElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ScriptElement) Contenteditable(c ScriptContenteditableChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ScriptContenteditableChoice])
 	return e
 }
 
@@ -852,10 +842,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ScriptElement) ContenteditableRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -873,10 +861,8 @@ func (e *ScriptElement) ContenteditableRemove() *ScriptElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ScriptElement) Dir(c ScriptDirChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ScriptDirChoice])
 	return e
 }
 
@@ -910,10 +896,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ScriptElement) DirRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -921,10 +905,8 @@ func (e *ScriptElement) DirRemove() *ScriptElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ScriptElement) Draggable(c ScriptDraggableChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ScriptDraggableChoice])
 	return e
 }
 
@@ -950,20 +932,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ScriptElement) DraggableRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ScriptElement) Enterkeyhint(c ScriptEnterkeyhintChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ScriptEnterkeyhintChoice])
 	return e
 }
 
@@ -993,10 +971,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ScriptElement) EnterkeyhintRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1018,16 +994,9 @@ func (e *ScriptElement) EnterkeyhintRemove() *ScriptElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ScriptElement) Exportparts(s string) *ScriptElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1050,7 +1019,7 @@ func (e *ScriptElement) Exportparts(s string) *ScriptElement {
 // the current structure.
 func (e *ScriptElement) IfExportparts(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1074,14 +1043,8 @@ func (e *ScriptElement) IfExportparts(condition bool, s string) *ScriptElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ScriptElement) ExportpartsRemove(s ...string) *ScriptElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1098,10 +1061,8 @@ func (e *ScriptElement) ExportpartsRemove(s ...string) *ScriptElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ScriptElement) Hidden(c ScriptHiddenChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ScriptHiddenChoice])
 	return e
 }
 
@@ -1137,10 +1098,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ScriptElement) HiddenRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1148,10 +1107,8 @@ func (e *ScriptElement) HiddenRemove() *ScriptElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ScriptElement) ID(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1167,7 +1124,7 @@ func (e *ScriptElement) IDf(format string, args ...any) *ScriptElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ScriptElement) IfID(condition bool, s string) *ScriptElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1177,7 +1134,7 @@ func (e *ScriptElement) IfID(condition bool, s string) *ScriptElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ScriptElement) IfIDf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1187,10 +1144,8 @@ func (e *ScriptElement) IfIDf(condition bool, format string, args ...any) *Scrip
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ScriptElement) IDRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1205,10 +1160,8 @@ func (e *ScriptElement) IDRemove() *ScriptElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ScriptElement) Inert() *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1224,7 +1177,7 @@ func (e *ScriptElement) Inert() *ScriptElement {
 // excluding them from the accessibility tree.
 func (e *ScriptElement) IfInert(condition bool) *ScriptElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1241,10 +1194,8 @@ func (e *ScriptElement) IfInert(condition bool) *ScriptElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ScriptElement) InertRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1260,10 +1211,8 @@ func (e *ScriptElement) InertRemove() *ScriptElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ScriptElement) InertIfRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1277,10 +1226,8 @@ func (e *ScriptElement) InertIfRemove() *ScriptElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ScriptElement) Inputmode(c ScriptInputmodeChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ScriptInputmodeChoice])
 	return e
 }
 
@@ -1331,10 +1278,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ScriptElement) InputmodeRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1344,10 +1289,8 @@ func (e *ScriptElement) InputmodeRemove() *ScriptElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ScriptElement) Is(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1367,7 +1310,7 @@ func (e *ScriptElement) Isf(format string, args ...any) *ScriptElement {
 // and extends the element type it is being applied to.
 func (e *ScriptElement) IfIs(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1379,7 +1322,7 @@ func (e *ScriptElement) IfIs(condition bool, s string) *ScriptElement {
 // and extends the element type it is being applied to.
 func (e *ScriptElement) IfIsf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1391,10 +1334,8 @@ func (e *ScriptElement) IfIsf(condition bool, format string, args ...any) *Scrip
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ScriptElement) IsRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1410,10 +1351,8 @@ func (e *ScriptElement) IsRemove() *ScriptElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ScriptElement) Itemid(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1445,7 +1384,7 @@ func (e *ScriptElement) Itemidf(format string, args ...any) *ScriptElement {
 // how items with the same identifier are handled.
 func (e *ScriptElement) IfItemid(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1463,7 +1402,7 @@ func (e *ScriptElement) IfItemid(condition bool, s string) *ScriptElement {
 // how items with the same identifier are handled.
 func (e *ScriptElement) IfItemidf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1481,10 +1420,8 @@ func (e *ScriptElement) IfItemidf(condition bool, format string, args ...any) *S
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ScriptElement) ItemidRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1496,10 +1433,8 @@ func (e *ScriptElement) ItemidRemove() *ScriptElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ScriptElement) Itemprop(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1523,7 +1458,7 @@ func (e *ScriptElement) Itempropf(format string, args ...any) *ScriptElement {
 // <track>, and <video>.
 func (e *ScriptElement) IfItemprop(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1537,7 +1472,7 @@ func (e *ScriptElement) IfItemprop(condition bool, s string) *ScriptElement {
 // <track>, and <video>.
 func (e *ScriptElement) IfItempropf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1551,10 +1486,8 @@ func (e *ScriptElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ScriptElement) ItempropRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1564,10 +1497,8 @@ func (e *ScriptElement) ItempropRemove() *ScriptElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ScriptElement) Itemref(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1587,7 +1518,7 @@ func (e *ScriptElement) Itemreff(format string, args ...any) *ScriptElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ScriptElement) IfItemref(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1599,7 +1530,7 @@ func (e *ScriptElement) IfItemref(condition bool, s string) *ScriptElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ScriptElement) IfItemreff(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1611,10 +1542,8 @@ func (e *ScriptElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ScriptElement) ItemrefRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1626,10 +1555,8 @@ func (e *ScriptElement) ItemrefRemove() *ScriptElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ScriptElement) Itemscope() *ScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1642,7 +1569,7 @@ func (e *ScriptElement) Itemscope() *ScriptElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ScriptElement) IfItemscope(condition bool) *ScriptElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1656,10 +1583,8 @@ func (e *ScriptElement) IfItemscope(condition bool) *ScriptElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ScriptElement) ItemscopeRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1672,10 +1597,8 @@ func (e *ScriptElement) ItemscopeRemove() *ScriptElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ScriptElement) ItemscopeIfRemove() *ScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1687,10 +1610,8 @@ func (e *ScriptElement) ItemscopeIfRemove() *ScriptElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ScriptElement) Itemtype(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1714,7 +1635,7 @@ func (e *ScriptElement) Itemtypef(format string, args ...any) *ScriptElement {
 // <video>.
 func (e *ScriptElement) IfItemtype(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1728,7 +1649,7 @@ func (e *ScriptElement) IfItemtype(condition bool, s string) *ScriptElement {
 // <video>.
 func (e *ScriptElement) IfItemtypef(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1742,10 +1663,8 @@ func (e *ScriptElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ScriptElement) ItemtypeRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1755,10 +1674,8 @@ func (e *ScriptElement) ItemtypeRemove() *ScriptElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ScriptElement) Lang(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1778,7 +1695,7 @@ func (e *ScriptElement) Langf(format string, args ...any) *ScriptElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ScriptElement) IfLang(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1790,7 +1707,7 @@ func (e *ScriptElement) IfLang(condition bool, s string) *ScriptElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ScriptElement) IfLangf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1802,10 +1719,8 @@ func (e *ScriptElement) IfLangf(condition bool, format string, args ...any) *Scr
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ScriptElement) LangRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1813,16 +1728,9 @@ func (e *ScriptElement) LangRemove() *ScriptElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ScriptElement) Part(s string) *ScriptElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1831,7 +1739,7 @@ func (e *ScriptElement) Part(s string) *ScriptElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ScriptElement) IfPart(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1841,14 +1749,8 @@ func (e *ScriptElement) IfPart(condition bool, s string) *ScriptElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ScriptElement) PartRemove(s ...string) *ScriptElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1859,10 +1761,8 @@ func (e *ScriptElement) PartRemove(s ...string) *ScriptElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ScriptElement) Popover(c ScriptPopoverChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ScriptPopoverChoice])
 	return e
 }
 
@@ -1890,10 +1790,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ScriptElement) PopoverRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1902,10 +1800,8 @@ func (e *ScriptElement) PopoverRemove() *ScriptElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ScriptElement) Role(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1923,7 +1819,7 @@ func (e *ScriptElement) Rolef(format string, args ...any) *ScriptElement {
 // role of an element.
 func (e *ScriptElement) IfRole(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1934,7 +1830,7 @@ func (e *ScriptElement) IfRole(condition bool, s string) *ScriptElement {
 // role of an element.
 func (e *ScriptElement) IfRolef(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1945,10 +1841,8 @@ func (e *ScriptElement) IfRolef(condition bool, format string, args ...any) *Scr
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ScriptElement) RoleRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1957,10 +1851,8 @@ func (e *ScriptElement) RoleRemove() *ScriptElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ScriptElement) Slot(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1978,7 +1870,7 @@ func (e *ScriptElement) Slotf(format string, args ...any) *ScriptElement {
 // value.
 func (e *ScriptElement) IfSlot(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1989,7 +1881,7 @@ func (e *ScriptElement) IfSlot(condition bool, s string) *ScriptElement {
 // value.
 func (e *ScriptElement) IfSlotf(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2000,10 +1892,8 @@ func (e *ScriptElement) IfSlotf(condition bool, format string, args ...any) *Scr
 // value.
 // Remove the attribute Slot from the element.
 func (e *ScriptElement) SlotRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2019,10 +1909,8 @@ func (e *ScriptElement) SlotRemove() *ScriptElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ScriptElement) Spellcheck(c ScriptSpellcheckChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ScriptSpellcheckChoice])
 	return e
 }
 
@@ -2050,10 +1938,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ScriptElement) SpellcheckRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2063,14 +1949,8 @@ func (e *ScriptElement) StylePairs(pairs ...string) *ScriptElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2085,14 +1965,8 @@ func (e *ScriptElement) StylePairs(pairs ...string) *ScriptElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ScriptElement) Style(s string) *ScriptElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2100,7 +1974,7 @@ func (e *ScriptElement) Style(s string) *ScriptElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2109,7 +1983,7 @@ func (e *ScriptElement) Style(s string) *ScriptElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ScriptElement) IfStyle(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2117,15 +1991,7 @@ func (e *ScriptElement) IfStyle(condition bool, s string) *ScriptElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ScriptElement) StyleAdd(k string, v string) *ScriptElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2139,7 +2005,7 @@ func (e *ScriptElement) StyleAddf(k string, format string, args ...any) *ScriptE
 // color, font, size, and more. Styles are written in CSS.
 func (e *ScriptElement) IfStyleAdd(condition bool, k string, v string) *ScriptElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2148,7 +2014,7 @@ func (e *ScriptElement) IfStyleAdd(condition bool, k string, v string) *ScriptEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *ScriptElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ScriptElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2157,21 +2023,15 @@ func (e *ScriptElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ScriptElement) StyleMap(m map[string]string) *ScriptElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2180,14 +2040,8 @@ func (e *ScriptElement) StyleMap(m map[string]string) *ScriptElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ScriptElement) StyleRemove(keys ...string) *ScriptElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2205,10 +2059,8 @@ func (e *ScriptElement) StyleRemove(keys ...string) *ScriptElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ScriptElement) Tabindex(i int) *ScriptElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2227,7 +2079,7 @@ func (e *ScriptElement) Tabindex(i int) *ScriptElement {
 // their relative position in the document.
 func (e *ScriptElement) IfTabindex(condition bool, i int) *ScriptElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2247,10 +2099,8 @@ func (e *ScriptElement) IfTabindex(condition bool, i int) *ScriptElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ScriptElement) TabindexRemove() *ScriptElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2271,10 +2121,8 @@ func (e *ScriptElement) TabindexRemove() *ScriptElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ScriptElement) Title(s string) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2316,7 +2164,7 @@ func (e *ScriptElement) Titlef(format string, args ...any) *ScriptElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ScriptElement) IfTitle(condition bool, s string) *ScriptElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2339,7 +2187,7 @@ func (e *ScriptElement) IfTitle(condition bool, s string) *ScriptElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ScriptElement) IfTitlef(condition bool, format string, args ...any) *ScriptElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2362,10 +2210,8 @@ func (e *ScriptElement) IfTitlef(condition bool, format string, args ...any) *Sc
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ScriptElement) TitleRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2374,10 +2220,8 @@ func (e *ScriptElement) TitleRemove() *ScriptElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ScriptElement) Translate(c ScriptTranslateChoice) *ScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ScriptTranslateChoice])
 	return e
 }
 
@@ -2398,9 +2242,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ScriptElement) TranslateRemove() *ScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <section> element represents a standalone section — which doesn't
@@ -23,18 +21,77 @@ type SectionElement struct {
 // with the tag "section" during rendering.
 func Section(children ...ElementRenderer) *SectionElement {
 	e := NewElement("section", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SectionElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SectionElement) Clone() *SectionElement {
+	return &SectionElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SectionElement) CloneShallow() *SectionElement {
+	return &SectionElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SectionElement) Freeze() *SectionElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SectionElement) writable() *SectionElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SectionElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SectionElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[SectionAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[SectionContenteditableChoice]
+	case "dir":
+		return choiceValue[SectionDirChoice]
+	case "draggable":
+		return choiceValue[SectionDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[SectionEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[SectionHiddenChoice]
+	case "inputmode":
+		return choiceValue[SectionInputmodeChoice]
+	case "popover":
+		return choiceValue[SectionPopoverChoice]
+	case "spellcheck":
+		return choiceValue[SectionSpellcheckChoice]
+	case "translate":
+		return choiceValue[SectionTranslateChoice]
+	}
+	return nil
+}
+
 func (e *SectionElement) Children(children ...ElementRenderer) *SectionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SectionElement) IfChildren(condition bool, children ...ElementRenderer) *SectionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *SectionElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SectionElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SectionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SectionElement) BoolAttr(name string) *SectionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SectionElement) BoolAttrRemove(name string) *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SectionElement) IfBoolAttr(condition bool, name string) *SectionElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *SectionElement) BoolAttrf(format string, args ...any) *SectionElement {
 
 func (e *SectionElement) IfBoolAttrf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SectionElement) BoolAttrs(names ...string) *SectionElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SectionElement) IfBoolAttrs(condition bool, names ...string) *SectionElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SectionElement) Attr(name, value string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SectionElement) RawAttr(name, value string) *SectionElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SectionElement) IfAttr(condition bool, name, value string) *SectionElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *SectionElement) Attrf(name, format string, args ...any) *SectionElement
 
 func (e *SectionElement) IfAttrf(condition bool, name, format string, args ...any) *SectionElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SectionElement) Attrs(attrs ...string) *SectionElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SectionElement) IfAttrs(condition bool, attrs ...string) *SectionElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SectionElement) AttrsMap(attrs map[string]string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SectionElement) IfAttrsMap(condition bool, attrs map[string]string) *SectionElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SectionElement) Text(text string) *SectionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SectionElement) Textf(format string, args ...any) *SectionElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SectionElement) IfText(condition bool, text string) *SectionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *SectionElement) IfText(condition bool, text string) *SectionElement {
 
 func (e *SectionElement) IfTextf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SectionElement) Raw(text string) *SectionElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SectionElement) Escaped(text string) *SectionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SectionElement) IfEscaped(condition bool, text string) *SectionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *SectionElement) Escapedf(format string, args ...any) *SectionElement {
 
 func (e *SectionElement) IfEscapedf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -213,10 +274,8 @@ func (e *SectionElement) IfEscapedf(condition bool, format string, args ...any)
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *SectionElement) Accesskey(r rune) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -226,7 +285,7 @@ func (e *SectionElement) Accesskey(r rune) *SectionElement {
 // can be generated by the keyboard).
 func (e *SectionElement) IfAccesskey(condition bool, r rune) *SectionElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -237,10 +296,8 @@ func (e *SectionElement) IfAccesskey(condition bool, r rune) *SectionElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *SectionElement) AccesskeyRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -256,10 +313,8 @@ func (e *SectionElement) AccesskeyRemove() *SectionElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *SectionElement) Autocapitalize(c SectionAutocapitalizeChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[SectionAutocapitalizeChoice])
 	return e
 }
 
@@ -293,10 +348,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *SectionElement) AutocapitalizeRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -315,10 +368,8 @@ func (e *SectionElement) AutocapitalizeRemove() *SectionElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SectionElement) Autofocus() *SectionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -338,7 +389,7 @@ func (e *SectionElement) Autofocus() *SectionElement {
 // created by the preceding content.
 func (e *SectionElement) IfAutofocus(condition bool) *SectionElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -359,10 +410,8 @@ func (e *SectionElement) IfAutofocus(condition bool) *SectionElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SectionElement) AutofocusRemove() *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -382,10 +431,8 @@ func (e *SectionElement) AutofocusRemove() *SectionElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SectionElement) AutofocusIfRemove() *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -394,16 +441,9 @@ func (e *SectionElement) AutofocusIfRemove() *SectionElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *SectionElement) Class(s string) *SectionElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +453,7 @@ func (e *SectionElement) Class(s string) *SectionElement {
 // document.getElementsByClassName.
 func (e *SectionElement) IfClass(condition bool, s string) *SectionElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -424,14 +464,8 @@ func (e *SectionElement) IfClass(condition bool, s string) *SectionElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *SectionElement) ClassRemove(s ...string) *SectionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -439,10 +473,8 @@ func (e *SectionElement) ClassRemove(s ...string) *SectionElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *SectionElement) Contenteditable(c SectionContenteditableChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[SectionContenteditableChoice])
 	return e
 }
 
@@ -465,10 +497,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *SectionElement) ContenteditableRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -486,10 +516,8 @@ func (e *SectionElement) ContenteditableRemove() *SectionElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *SectionElement) Dir(c SectionDirChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[SectionDirChoice])
 	return e
 }
 
@@ -523,10 +551,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *SectionElement) DirRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -534,10 +560,8 @@ func (e *SectionElement) DirRemove() *SectionElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *SectionElement) Draggable(c SectionDraggableChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[SectionDraggableChoice])
 	return e
 }
 
@@ -563,20 +587,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *SectionElement) DraggableRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *SectionElement) Enterkeyhint(c SectionEnterkeyhintChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[SectionEnterkeyhintChoice])
 	return e
 }
 
@@ -606,10 +626,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *SectionElement) EnterkeyhintRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -631,16 +649,9 @@ func (e *SectionElement) EnterkeyhintRemove() *SectionElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *SectionElement) Exportparts(s string) *SectionElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -663,7 +674,7 @@ func (e *SectionElement) Exportparts(s string) *SectionElement {
 // the current structure.
 func (e *SectionElement) IfExportparts(condition bool, s string) *SectionElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -687,14 +698,8 @@ func (e *SectionElement) IfExportparts(condition bool, s string) *SectionElement
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *SectionElement) ExportpartsRemove(s ...string) *SectionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -711,10 +716,8 @@ func (e *SectionElement) ExportpartsRemove(s ...string) *SectionElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *SectionElement) Hidden(c SectionHiddenChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[SectionHiddenChoice])
 	return e
 }
 
@@ -750,10 +753,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *SectionElement) HiddenRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -761,10 +762,8 @@ func (e *SectionElement) HiddenRemove() *SectionElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SectionElement) ID(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -780,7 +779,7 @@ func (e *SectionElement) IDf(format string, args ...any) *SectionElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SectionElement) IfID(condition bool, s string) *SectionElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -790,7 +789,7 @@ func (e *SectionElement) IfID(condition bool, s string) *SectionElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SectionElement) IfIDf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -800,10 +799,8 @@ func (e *SectionElement) IfIDf(condition bool, format string, args ...any) *Sect
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *SectionElement) IDRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -818,10 +815,8 @@ func (e *SectionElement) IDRemove() *SectionElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SectionElement) Inert() *SectionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -837,7 +832,7 @@ func (e *SectionElement) Inert() *SectionElement {
 // excluding them from the accessibility tree.
 func (e *SectionElement) IfInert(condition bool) *SectionElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -854,10 +849,8 @@ func (e *SectionElement) IfInert(condition bool) *SectionElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SectionElement) InertRemove() *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -873,10 +866,8 @@ func (e *SectionElement) InertRemove() *SectionElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SectionElement) InertIfRemove() *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -890,10 +881,8 @@ func (e *SectionElement) InertIfRemove() *SectionElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *SectionElement) Inputmode(c SectionInputmodeChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[SectionInputmodeChoice])
 	return e
 }
 
@@ -944,10 +933,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *SectionElement) InputmodeRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -957,10 +944,8 @@ func (e *SectionElement) InputmodeRemove() *SectionElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *SectionElement) Is(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -980,7 +965,7 @@ func (e *SectionElement) Isf(format string, args ...any) *SectionElement {
 // and extends the element type it is being applied to.
 func (e *SectionElement) IfIs(condition bool, s string) *SectionElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -992,7 +977,7 @@ func (e *SectionElement) IfIs(condition bool, s string) *SectionElement {
 // and extends the element type it is being applied to.
 func (e *SectionElement) IfIsf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1004,10 +989,8 @@ func (e *SectionElement) IfIsf(condition bool, format string, args ...any) *Sect
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *SectionElement) IsRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1023,10 +1006,8 @@ func (e *SectionElement) IsRemove() *SectionElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *SectionElement) Itemid(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1058,7 +1039,7 @@ func (e *SectionElement) Itemidf(format string, args ...any) *SectionElement {
 // how items with the same identifier are handled.
 func (e *SectionElement) IfItemid(condition bool, s string) *SectionElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1076,7 +1057,7 @@ func (e *SectionElement) IfItemid(condition bool, s string) *SectionElement {
 // how items with the same identifier are handled.
 func (e *SectionElement) IfItemidf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1094,10 +1075,8 @@ func (e *SectionElement) IfItemidf(condition bool, format string, args ...any) *
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *SectionElement) ItemidRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1109,10 +1088,8 @@ func (e *SectionElement) ItemidRemove() *SectionElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *SectionElement) Itemprop(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1136,7 +1113,7 @@ func (e *SectionElement) Itempropf(format string, args ...any) *SectionElement {
 // <track>, and <video>.
 func (e *SectionElement) IfItemprop(condition bool, s string) *SectionElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1150,7 +1127,7 @@ func (e *SectionElement) IfItemprop(condition bool, s string) *SectionElement {
 // <track>, and <video>.
 func (e *SectionElement) IfItempropf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1164,10 +1141,8 @@ func (e *SectionElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *SectionElement) ItempropRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1177,10 +1152,8 @@ func (e *SectionElement) ItempropRemove() *SectionElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *SectionElement) Itemref(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1200,7 +1173,7 @@ func (e *SectionElement) Itemreff(format string, args ...any) *SectionElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SectionElement) IfItemref(condition bool, s string) *SectionElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1212,7 +1185,7 @@ func (e *SectionElement) IfItemref(condition bool, s string) *SectionElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SectionElement) IfItemreff(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1224,10 +1197,8 @@ func (e *SectionElement) IfItemreff(condition bool, format string, args ...any)
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *SectionElement) ItemrefRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1239,10 +1210,8 @@ func (e *SectionElement) ItemrefRemove() *SectionElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SectionElement) Itemscope() *SectionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1255,7 +1224,7 @@ func (e *SectionElement) Itemscope() *SectionElement {
 // <object>, <source>, <track>, and <video>.
 func (e *SectionElement) IfItemscope(condition bool) *SectionElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1269,10 +1238,8 @@ func (e *SectionElement) IfItemscope(condition bool) *SectionElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SectionElement) ItemscopeRemove() *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1285,10 +1252,8 @@ func (e *SectionElement) ItemscopeRemove() *SectionElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SectionElement) ItemscopeIfRemove() *SectionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1300,10 +1265,8 @@ func (e *SectionElement) ItemscopeIfRemove() *SectionElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *SectionElement) Itemtype(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1327,7 +1290,7 @@ func (e *SectionElement) Itemtypef(format string, args ...any) *SectionElement {
 // <video>.
 func (e *SectionElement) IfItemtype(condition bool, s string) *SectionElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1341,7 +1304,7 @@ func (e *SectionElement) IfItemtype(condition bool, s string) *SectionElement {
 // <video>.
 func (e *SectionElement) IfItemtypef(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1355,10 +1318,8 @@ func (e *SectionElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *SectionElement) ItemtypeRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1368,10 +1329,8 @@ func (e *SectionElement) ItemtypeRemove() *SectionElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SectionElement) Lang(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1391,7 +1350,7 @@ func (e *SectionElement) Langf(format string, args ...any) *SectionElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SectionElement) IfLang(condition bool, s string) *SectionElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1403,7 +1362,7 @@ func (e *SectionElement) IfLang(condition bool, s string) *SectionElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SectionElement) IfLangf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1415,10 +1374,8 @@ func (e *SectionElement) IfLangf(condition bool, format string, args ...any) *Se
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *SectionElement) LangRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1428,10 +1385,8 @@ func (e *SectionElement) LangRemove() *SectionElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *SectionElement) Nonce(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1451,7 +1406,7 @@ func (e *SectionElement) Noncef(format string, args ...any) *SectionElement {
 // execute.
 func (e *SectionElement) IfNonce(condition bool, s string) *SectionElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1463,7 +1418,7 @@ func (e *SectionElement) IfNonce(condition bool, s string) *SectionElement {
 // execute.
 func (e *SectionElement) IfNoncef(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1475,10 +1430,8 @@ func (e *SectionElement) IfNoncef(condition bool, format string, args ...any) *S
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *SectionElement) NonceRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1486,16 +1439,9 @@ func (e *SectionElement) NonceRemove() *SectionElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *SectionElement) Part(s string) *SectionElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1504,7 +1450,7 @@ func (e *SectionElement) Part(s string) *SectionElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *SectionElement) IfPart(condition bool, s string) *SectionElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1514,14 +1460,8 @@ func (e *SectionElement) IfPart(condition bool, s string) *SectionElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *SectionElement) PartRemove(s ...string) *SectionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1532,10 +1472,8 @@ func (e *SectionElement) PartRemove(s ...string) *SectionElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *SectionElement) Popover(c SectionPopoverChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[SectionPopoverChoice])
 	return e
 }
 
@@ -1563,10 +1501,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *SectionElement) PopoverRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1575,10 +1511,8 @@ func (e *SectionElement) PopoverRemove() *SectionElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *SectionElement) Role(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1596,7 +1530,7 @@ func (e *SectionElement) Rolef(format string, args ...any) *SectionElement {
 // role of an element.
 func (e *SectionElement) IfRole(condition bool, s string) *SectionElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1607,7 +1541,7 @@ func (e *SectionElement) IfRole(condition bool, s string) *SectionElement {
 // role of an element.
 func (e *SectionElement) IfRolef(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1618,10 +1552,8 @@ func (e *SectionElement) IfRolef(condition bool, format string, args ...any) *Se
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *SectionElement) RoleRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1630,10 +1562,8 @@ func (e *SectionElement) RoleRemove() *SectionElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *SectionElement) Slot(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1651,7 +1581,7 @@ func (e *SectionElement) Slotf(format string, args ...any) *SectionElement {
 // value.
 func (e *SectionElement) IfSlot(condition bool, s string) *SectionElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1662,7 +1592,7 @@ func (e *SectionElement) IfSlot(condition bool, s string) *SectionElement {
 // value.
 func (e *SectionElement) IfSlotf(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1673,10 +1603,8 @@ func (e *SectionElement) IfSlotf(condition bool, format string, args ...any) *Se
 // value.
 // Remove the attribute Slot from the element.
 func (e *SectionElement) SlotRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1692,10 +1620,8 @@ func (e *SectionElement) SlotRemove() *SectionElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *SectionElement) Spellcheck(c SectionSpellcheckChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[SectionSpellcheckChoice])
 	return e
 }
 
@@ -1723,10 +1649,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *SectionElement) SpellcheckRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1736,14 +1660,8 @@ func (e *SectionElement) StylePairs(pairs ...string) *SectionElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1758,14 +1676,8 @@ func (e *SectionElement) StylePairs(pairs ...string) *SectionElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SectionElement) Style(s string) *SectionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1773,7 +1685,7 @@ func (e *SectionElement) Style(s string) *SectionElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1782,7 +1694,7 @@ func (e *SectionElement) Style(s string) *SectionElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *SectionElement) IfStyle(condition bool, s string) *SectionElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1790,15 +1702,7 @@ func (e *SectionElement) IfStyle(condition bool, s string) *SectionElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SectionElement) StyleAdd(k string, v string) *SectionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1812,7 +1716,7 @@ func (e *SectionElement) StyleAddf(k string, format string, args ...any) *Sectio
 // color, font, size, and more. Styles are written in CSS.
 func (e *SectionElement) IfStyleAdd(condition bool, k string, v string) *SectionElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1821,7 +1725,7 @@ func (e *SectionElement) IfStyleAdd(condition bool, k string, v string) *Section
 // color, font, size, and more. Styles are written in CSS.
 func (e *SectionElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SectionElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1830,21 +1734,15 @@ func (e *SectionElement) IfStyleAddf(condition bool, k string, format string, ar
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *SectionElement) StyleMap(m map[string]string) *SectionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1853,14 +1751,8 @@ func (e *SectionElement) StyleMap(m map[string]string) *SectionElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *SectionElement) StyleRemove(keys ...string) *SectionElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1878,10 +1770,8 @@ func (e *SectionElement) StyleRemove(keys ...string) *SectionElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *SectionElement) Tabindex(i int) *SectionElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1900,7 +1790,7 @@ func (e *SectionElement) Tabindex(i int) *SectionElement {
 // their relative position in the document.
 func (e *SectionElement) IfTabindex(condition bool, i int) *SectionElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1920,10 +1810,8 @@ func (e *SectionElement) IfTabindex(condition bool, i int) *SectionElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *SectionElement) TabindexRemove() *SectionElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1944,10 +1832,8 @@ func (e *SectionElement) TabindexRemove() *SectionElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *SectionElement) Title(s string) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1989,7 +1875,7 @@ func (e *SectionElement) Titlef(format string, args ...any) *SectionElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SectionElement) IfTitle(condition bool, s string) *SectionElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2012,7 +1898,7 @@ func (e *SectionElement) IfTitle(condition bool, s string) *SectionElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SectionElement) IfTitlef(condition bool, format string, args ...any) *SectionElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2035,10 +1921,8 @@ func (e *SectionElement) IfTitlef(condition bool, format string, args ...any) *S
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *SectionElement) TitleRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2047,10 +1931,8 @@ func (e *SectionElement) TitleRemove() *SectionElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *SectionElement) Translate(c SectionTranslateChoice) *SectionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[SectionTranslateChoice])
 	return e
 }
 
@@ -2071,9 +1953,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *SectionElement) TranslateRemove() *SectionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
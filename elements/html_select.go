@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <select> element represents a control that provides a menu of
@@ -22,18 +20,79 @@ type SelectElement struct {
 // with the tag "select" during rendering.
 func Select(children ...ElementRenderer) *SelectElement {
 	e := NewElement("select", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SelectElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SelectElement) Clone() *SelectElement {
+	return &SelectElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SelectElement) CloneShallow() *SelectElement {
+	return &SelectElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SelectElement) Freeze() *SelectElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SelectElement) writable() *SelectElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SelectElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SelectElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocomplete":
+		return choiceValue[SelectAutocompleteChoice]
+	case "autocapitalize":
+		return choiceValue[SelectAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[SelectContenteditableChoice]
+	case "dir":
+		return choiceValue[SelectDirChoice]
+	case "draggable":
+		return choiceValue[SelectDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[SelectEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[SelectHiddenChoice]
+	case "inputmode":
+		return choiceValue[SelectInputmodeChoice]
+	case "popover":
+		return choiceValue[SelectPopoverChoice]
+	case "spellcheck":
+		return choiceValue[SelectSpellcheckChoice]
+	case "translate":
+		return choiceValue[SelectTranslateChoice]
+	}
+	return nil
+}
+
 func (e *SelectElement) Children(children ...ElementRenderer) *SelectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SelectElement) IfChildren(condition bool, children ...ElementRenderer) *SelectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +100,30 @@ func (e *SelectElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SelectElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SelectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SelectElement) BoolAttr(name string) *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SelectElement) BoolAttrRemove(name string) *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SelectElement) IfBoolAttr(condition bool, name string) *SelectElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +134,40 @@ func (e *SelectElement) BoolAttrf(format string, args ...any) *SelectElement {
 
 func (e *SelectElement) IfBoolAttrf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SelectElement) BoolAttrs(names ...string) *SelectElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SelectElement) IfBoolAttrs(condition bool, names ...string) *SelectElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SelectElement) Attr(name, value string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SelectElement) RawAttr(name, value string) *SelectElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SelectElement) IfAttr(condition bool, name, value string) *SelectElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +178,52 @@ func (e *SelectElement) Attrf(name, format string, args ...any) *SelectElement {
 
 func (e *SelectElement) IfAttrf(condition bool, name, format string, args ...any) *SelectElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SelectElement) Attrs(attrs ...string) *SelectElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SelectElement) IfAttrs(condition bool, attrs ...string) *SelectElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SelectElement) AttrsMap(attrs map[string]string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SelectElement) IfAttrsMap(condition bool, attrs map[string]string) *SelectElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SelectElement) Text(text string) *SelectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SelectElement) Textf(format string, args ...any) *SelectElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SelectElement) IfText(condition bool, text string) *SelectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +231,28 @@ func (e *SelectElement) IfText(condition bool, text string) *SelectElement {
 
 func (e *SelectElement) IfTextf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SelectElement) Raw(text string) *SelectElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SelectElement) Escaped(text string) *SelectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SelectElement) IfEscaped(condition bool, text string) *SelectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +264,7 @@ func (e *SelectElement) Escapedf(format string, args ...any) *SelectElement {
 
 func (e *SelectElement) IfEscapedf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +272,8 @@ func (e *SelectElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // Hint for form autofill feature.
 func (e *SelectElement) Autocomplete(c SelectAutocompleteChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocomplete", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocomplete", string(c), choiceValue[SelectAutocompleteChoice])
 	return e
 }
 
@@ -228,26 +289,22 @@ const (
 // Hint for form autofill feature.
 // Remove the attribute Autocomplete from the element.
 func (e *SelectElement) AutocompleteRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocomplete")
+	e = e.writable()
+	e.removeAttribute("autocomplete")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *SelectElement) Disabled() *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *SelectElement) IfDisabled(condition bool) *SelectElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -255,29 +312,23 @@ func (e *SelectElement) IfDisabled(condition bool) *SelectElement {
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *SelectElement) DisabledRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *SelectElement) DisabledIfRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Associates the control with a form element.
 func (e *SelectElement) Form(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -289,7 +340,7 @@ func (e *SelectElement) Formf(format string, args ...any) *SelectElement {
 // Associates the control with a form element.
 func (e *SelectElement) IfForm(condition bool, s string) *SelectElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -297,7 +348,7 @@ func (e *SelectElement) IfForm(condition bool, s string) *SelectElement {
 // Associates the control with a form element.
 func (e *SelectElement) IfFormf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -305,26 +356,22 @@ func (e *SelectElement) IfFormf(condition bool, format string, args ...any) *Sel
 // Associates the control with a form element.
 // Remove the attribute Form from the element.
 func (e *SelectElement) FormRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // Whether to allow multiple values.
 func (e *SelectElement) Multiple() *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("multiple", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("multiple")
 	return e
 }
 
 // Whether to allow multiple values.
 func (e *SelectElement) IfMultiple(condition bool) *SelectElement {
 	if condition {
-		e.Multiple()
+		e = e.Multiple()
 	}
 	return e
 }
@@ -332,29 +379,23 @@ func (e *SelectElement) IfMultiple(condition bool) *SelectElement {
 // Remove the attribute Multiple from the element.
 // Whether to allow multiple values.
 func (e *SelectElement) MultipleRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("multiple")
+	e = e.writable()
+	e.removeAttribute("multiple")
 	return e
 }
 
 // Remove the attribute Multiple from the element.
 // Whether to allow multiple values.
 func (e *SelectElement) MultipleIfRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("multiple")
+	e = e.writable()
+	e.removeAttribute("multiple")
 	return e
 }
 
 // Name of the element to use for form submission and in the form.elements API.
 func (e *SelectElement) Name(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -366,7 +407,7 @@ func (e *SelectElement) Namef(format string, args ...any) *SelectElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *SelectElement) IfName(condition bool, s string) *SelectElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -374,7 +415,7 @@ func (e *SelectElement) IfName(condition bool, s string) *SelectElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *SelectElement) IfNamef(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -382,26 +423,22 @@ func (e *SelectElement) IfNamef(condition bool, format string, args ...any) *Sel
 // Name of the element to use for form submission and in the form.elements API.
 // Remove the attribute Name from the element.
 func (e *SelectElement) NameRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
 // Whether the control is required for form submission.
 func (e *SelectElement) Required() *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("required", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("required")
 	return e
 }
 
 // Whether the control is required for form submission.
 func (e *SelectElement) IfRequired(condition bool) *SelectElement {
 	if condition {
-		e.Required()
+		e = e.Required()
 	}
 	return e
 }
@@ -409,36 +446,30 @@ func (e *SelectElement) IfRequired(condition bool) *SelectElement {
 // Remove the attribute Required from the element.
 // Whether the control is required for form submission.
 func (e *SelectElement) RequiredRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("required")
+	e = e.writable()
+	e.removeAttribute("required")
 	return e
 }
 
 // Remove the attribute Required from the element.
 // Whether the control is required for form submission.
 func (e *SelectElement) RequiredIfRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("required")
+	e = e.writable()
+	e.removeAttribute("required")
 	return e
 }
 
 // Number of options to show.
 func (e *SelectElement) Size(i int) *SelectElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("size", i)
+	e = e.writable()
+	e.setIntAttribute("size", i)
 	return e
 }
 
 // Number of options to show.
 func (e *SelectElement) IfSize(condition bool, i int) *SelectElement {
 	if condition {
-		e.Size(i)
+		e = e.Size(i)
 	}
 	return e
 }
@@ -446,10 +477,8 @@ func (e *SelectElement) IfSize(condition bool, i int) *SelectElement {
 // Number of options to show.
 // Remove the attribute Size from the element.
 func (e *SelectElement) SizeRemove() *SelectElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("size")
+	e = e.writable()
+	e.removeAttribute("size")
 	return e
 }
 
@@ -458,10 +487,8 @@ func (e *SelectElement) SizeRemove() *SelectElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *SelectElement) Accesskey(r rune) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -471,7 +498,7 @@ func (e *SelectElement) Accesskey(r rune) *SelectElement {
 // can be generated by the keyboard).
 func (e *SelectElement) IfAccesskey(condition bool, r rune) *SelectElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -482,10 +509,8 @@ func (e *SelectElement) IfAccesskey(condition bool, r rune) *SelectElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *SelectElement) AccesskeyRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -501,10 +526,8 @@ func (e *SelectElement) AccesskeyRemove() *SelectElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *SelectElement) Autocapitalize(c SelectAutocapitalizeChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[SelectAutocapitalizeChoice])
 	return e
 }
 
@@ -538,10 +561,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *SelectElement) AutocapitalizeRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -560,10 +581,8 @@ func (e *SelectElement) AutocapitalizeRemove() *SelectElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SelectElement) Autofocus() *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -583,7 +602,7 @@ func (e *SelectElement) Autofocus() *SelectElement {
 // created by the preceding content.
 func (e *SelectElement) IfAutofocus(condition bool) *SelectElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -604,10 +623,8 @@ func (e *SelectElement) IfAutofocus(condition bool) *SelectElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SelectElement) AutofocusRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -627,10 +644,8 @@ func (e *SelectElement) AutofocusRemove() *SelectElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SelectElement) AutofocusIfRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -639,16 +654,9 @@ func (e *SelectElement) AutofocusIfRemove() *SelectElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *SelectElement) Class(s string) *SelectElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -658,7 +666,7 @@ func (e *SelectElement) Class(s string) *SelectElement {
 // document.getElementsByClassName.
 func (e *SelectElement) IfClass(condition bool, s string) *SelectElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -669,14 +677,8 @@ func (e *SelectElement) IfClass(condition bool, s string) *SelectElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *SelectElement) ClassRemove(s ...string) *SelectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -684,10 +686,8 @@ func (e *SelectElement) ClassRemove(s ...string) *SelectElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *SelectElement) Contenteditable(c SelectContenteditableChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[SelectContenteditableChoice])
 	return e
 }
 
@@ -710,10 +710,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *SelectElement) ContenteditableRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -731,10 +729,8 @@ func (e *SelectElement) ContenteditableRemove() *SelectElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *SelectElement) Dir(c SelectDirChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[SelectDirChoice])
 	return e
 }
 
@@ -768,10 +764,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *SelectElement) DirRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -779,10 +773,8 @@ func (e *SelectElement) DirRemove() *SelectElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *SelectElement) Draggable(c SelectDraggableChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[SelectDraggableChoice])
 	return e
 }
 
@@ -808,20 +800,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *SelectElement) DraggableRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *SelectElement) Enterkeyhint(c SelectEnterkeyhintChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[SelectEnterkeyhintChoice])
 	return e
 }
 
@@ -851,10 +839,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *SelectElement) EnterkeyhintRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -876,16 +862,9 @@ func (e *SelectElement) EnterkeyhintRemove() *SelectElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *SelectElement) Exportparts(s string) *SelectElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -908,7 +887,7 @@ func (e *SelectElement) Exportparts(s string) *SelectElement {
 // the current structure.
 func (e *SelectElement) IfExportparts(condition bool, s string) *SelectElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -932,14 +911,8 @@ func (e *SelectElement) IfExportparts(condition bool, s string) *SelectElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *SelectElement) ExportpartsRemove(s ...string) *SelectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -956,10 +929,8 @@ func (e *SelectElement) ExportpartsRemove(s ...string) *SelectElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *SelectElement) Hidden(c SelectHiddenChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[SelectHiddenChoice])
 	return e
 }
 
@@ -995,10 +966,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *SelectElement) HiddenRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1006,10 +975,8 @@ func (e *SelectElement) HiddenRemove() *SelectElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SelectElement) ID(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1025,7 +992,7 @@ func (e *SelectElement) IDf(format string, args ...any) *SelectElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SelectElement) IfID(condition bool, s string) *SelectElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1035,7 +1002,7 @@ func (e *SelectElement) IfID(condition bool, s string) *SelectElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SelectElement) IfIDf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1045,10 +1012,8 @@ func (e *SelectElement) IfIDf(condition bool, format string, args ...any) *Selec
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *SelectElement) IDRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1063,10 +1028,8 @@ func (e *SelectElement) IDRemove() *SelectElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SelectElement) Inert() *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1082,7 +1045,7 @@ func (e *SelectElement) Inert() *SelectElement {
 // excluding them from the accessibility tree.
 func (e *SelectElement) IfInert(condition bool) *SelectElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1099,10 +1062,8 @@ func (e *SelectElement) IfInert(condition bool) *SelectElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SelectElement) InertRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1118,10 +1079,8 @@ func (e *SelectElement) InertRemove() *SelectElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SelectElement) InertIfRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1135,10 +1094,8 @@ func (e *SelectElement) InertIfRemove() *SelectElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *SelectElement) Inputmode(c SelectInputmodeChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[SelectInputmodeChoice])
 	return e
 }
 
@@ -1189,10 +1146,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *SelectElement) InputmodeRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1202,10 +1157,8 @@ func (e *SelectElement) InputmodeRemove() *SelectElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *SelectElement) Is(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1225,7 +1178,7 @@ func (e *SelectElement) Isf(format string, args ...any) *SelectElement {
 // and extends the element type it is being applied to.
 func (e *SelectElement) IfIs(condition bool, s string) *SelectElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1237,7 +1190,7 @@ func (e *SelectElement) IfIs(condition bool, s string) *SelectElement {
 // and extends the element type it is being applied to.
 func (e *SelectElement) IfIsf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1249,10 +1202,8 @@ func (e *SelectElement) IfIsf(condition bool, format string, args ...any) *Selec
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *SelectElement) IsRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1268,10 +1219,8 @@ func (e *SelectElement) IsRemove() *SelectElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *SelectElement) Itemid(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1303,7 +1252,7 @@ func (e *SelectElement) Itemidf(format string, args ...any) *SelectElement {
 // how items with the same identifier are handled.
 func (e *SelectElement) IfItemid(condition bool, s string) *SelectElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1321,7 +1270,7 @@ func (e *SelectElement) IfItemid(condition bool, s string) *SelectElement {
 // how items with the same identifier are handled.
 func (e *SelectElement) IfItemidf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1339,10 +1288,8 @@ func (e *SelectElement) IfItemidf(condition bool, format string, args ...any) *S
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *SelectElement) ItemidRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1354,10 +1301,8 @@ func (e *SelectElement) ItemidRemove() *SelectElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *SelectElement) Itemprop(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1381,7 +1326,7 @@ func (e *SelectElement) Itempropf(format string, args ...any) *SelectElement {
 // <track>, and <video>.
 func (e *SelectElement) IfItemprop(condition bool, s string) *SelectElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1395,7 +1340,7 @@ func (e *SelectElement) IfItemprop(condition bool, s string) *SelectElement {
 // <track>, and <video>.
 func (e *SelectElement) IfItempropf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1409,10 +1354,8 @@ func (e *SelectElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *SelectElement) ItempropRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1422,10 +1365,8 @@ func (e *SelectElement) ItempropRemove() *SelectElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *SelectElement) Itemref(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1445,7 +1386,7 @@ func (e *SelectElement) Itemreff(format string, args ...any) *SelectElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SelectElement) IfItemref(condition bool, s string) *SelectElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1457,7 +1398,7 @@ func (e *SelectElement) IfItemref(condition bool, s string) *SelectElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SelectElement) IfItemreff(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1469,10 +1410,8 @@ func (e *SelectElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *SelectElement) ItemrefRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1484,10 +1423,8 @@ func (e *SelectElement) ItemrefRemove() *SelectElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SelectElement) Itemscope() *SelectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1500,7 +1437,7 @@ func (e *SelectElement) Itemscope() *SelectElement {
 // <object>, <source>, <track>, and <video>.
 func (e *SelectElement) IfItemscope(condition bool) *SelectElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1514,10 +1451,8 @@ func (e *SelectElement) IfItemscope(condition bool) *SelectElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SelectElement) ItemscopeRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1530,10 +1465,8 @@ func (e *SelectElement) ItemscopeRemove() *SelectElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SelectElement) ItemscopeIfRemove() *SelectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1545,10 +1478,8 @@ func (e *SelectElement) ItemscopeIfRemove() *SelectElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *SelectElement) Itemtype(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1572,7 +1503,7 @@ func (e *SelectElement) Itemtypef(format string, args ...any) *SelectElement {
 // <video>.
 func (e *SelectElement) IfItemtype(condition bool, s string) *SelectElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1586,7 +1517,7 @@ func (e *SelectElement) IfItemtype(condition bool, s string) *SelectElement {
 // <video>.
 func (e *SelectElement) IfItemtypef(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1600,10 +1531,8 @@ func (e *SelectElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *SelectElement) ItemtypeRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1613,10 +1542,8 @@ func (e *SelectElement) ItemtypeRemove() *SelectElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SelectElement) Lang(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1636,7 +1563,7 @@ func (e *SelectElement) Langf(format string, args ...any) *SelectElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SelectElement) IfLang(condition bool, s string) *SelectElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1648,7 +1575,7 @@ func (e *SelectElement) IfLang(condition bool, s string) *SelectElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SelectElement) IfLangf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1660,10 +1587,8 @@ func (e *SelectElement) IfLangf(condition bool, format string, args ...any) *Sel
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *SelectElement) LangRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1673,10 +1598,8 @@ func (e *SelectElement) LangRemove() *SelectElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *SelectElement) Nonce(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1696,7 +1619,7 @@ func (e *SelectElement) Noncef(format string, args ...any) *SelectElement {
 // execute.
 func (e *SelectElement) IfNonce(condition bool, s string) *SelectElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1708,7 +1631,7 @@ func (e *SelectElement) IfNonce(condition bool, s string) *SelectElement {
 // execute.
 func (e *SelectElement) IfNoncef(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1720,10 +1643,8 @@ func (e *SelectElement) IfNoncef(condition bool, format string, args ...any) *Se
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *SelectElement) NonceRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1731,16 +1652,9 @@ func (e *SelectElement) NonceRemove() *SelectElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *SelectElement) Part(s string) *SelectElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1749,7 +1663,7 @@ func (e *SelectElement) Part(s string) *SelectElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *SelectElement) IfPart(condition bool, s string) *SelectElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1759,14 +1673,8 @@ func (e *SelectElement) IfPart(condition bool, s string) *SelectElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *SelectElement) PartRemove(s ...string) *SelectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1777,10 +1685,8 @@ func (e *SelectElement) PartRemove(s ...string) *SelectElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *SelectElement) Popover(c SelectPopoverChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[SelectPopoverChoice])
 	return e
 }
 
@@ -1808,10 +1714,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *SelectElement) PopoverRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1820,10 +1724,8 @@ func (e *SelectElement) PopoverRemove() *SelectElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *SelectElement) Role(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1841,7 +1743,7 @@ func (e *SelectElement) Rolef(format string, args ...any) *SelectElement {
 // role of an element.
 func (e *SelectElement) IfRole(condition bool, s string) *SelectElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1852,7 +1754,7 @@ func (e *SelectElement) IfRole(condition bool, s string) *SelectElement {
 // role of an element.
 func (e *SelectElement) IfRolef(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1863,10 +1765,8 @@ func (e *SelectElement) IfRolef(condition bool, format string, args ...any) *Sel
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *SelectElement) RoleRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1875,10 +1775,8 @@ func (e *SelectElement) RoleRemove() *SelectElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *SelectElement) Slot(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1896,7 +1794,7 @@ func (e *SelectElement) Slotf(format string, args ...any) *SelectElement {
 // value.
 func (e *SelectElement) IfSlot(condition bool, s string) *SelectElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1907,7 +1805,7 @@ func (e *SelectElement) IfSlot(condition bool, s string) *SelectElement {
 // value.
 func (e *SelectElement) IfSlotf(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1918,10 +1816,8 @@ func (e *SelectElement) IfSlotf(condition bool, format string, args ...any) *Sel
 // value.
 // Remove the attribute Slot from the element.
 func (e *SelectElement) SlotRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1937,10 +1833,8 @@ func (e *SelectElement) SlotRemove() *SelectElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *SelectElement) Spellcheck(c SelectSpellcheckChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[SelectSpellcheckChoice])
 	return e
 }
 
@@ -1968,10 +1862,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *SelectElement) SpellcheckRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1981,14 +1873,8 @@ func (e *SelectElement) StylePairs(pairs ...string) *SelectElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2003,14 +1889,8 @@ func (e *SelectElement) StylePairs(pairs ...string) *SelectElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SelectElement) Style(s string) *SelectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2018,7 +1898,7 @@ func (e *SelectElement) Style(s string) *SelectElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2027,7 +1907,7 @@ func (e *SelectElement) Style(s string) *SelectElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *SelectElement) IfStyle(condition bool, s string) *SelectElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2035,15 +1915,7 @@ func (e *SelectElement) IfStyle(condition bool, s string) *SelectElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SelectElement) StyleAdd(k string, v string) *SelectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2057,7 +1929,7 @@ func (e *SelectElement) StyleAddf(k string, format string, args ...any) *SelectE
 // color, font, size, and more. Styles are written in CSS.
 func (e *SelectElement) IfStyleAdd(condition bool, k string, v string) *SelectElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2066,7 +1938,7 @@ func (e *SelectElement) IfStyleAdd(condition bool, k string, v string) *SelectEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *SelectElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SelectElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2075,21 +1947,15 @@ func (e *SelectElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *SelectElement) StyleMap(m map[string]string) *SelectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2098,14 +1964,8 @@ func (e *SelectElement) StyleMap(m map[string]string) *SelectElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *SelectElement) StyleRemove(keys ...string) *SelectElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2123,10 +1983,8 @@ func (e *SelectElement) StyleRemove(keys ...string) *SelectElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *SelectElement) Tabindex(i int) *SelectElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2145,7 +2003,7 @@ func (e *SelectElement) Tabindex(i int) *SelectElement {
 // their relative position in the document.
 func (e *SelectElement) IfTabindex(condition bool, i int) *SelectElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2165,10 +2023,8 @@ func (e *SelectElement) IfTabindex(condition bool, i int) *SelectElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *SelectElement) TabindexRemove() *SelectElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2189,10 +2045,8 @@ func (e *SelectElement) TabindexRemove() *SelectElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *SelectElement) Title(s string) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2234,7 +2088,7 @@ func (e *SelectElement) Titlef(format string, args ...any) *SelectElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SelectElement) IfTitle(condition bool, s string) *SelectElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2257,7 +2111,7 @@ func (e *SelectElement) IfTitle(condition bool, s string) *SelectElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SelectElement) IfTitlef(condition bool, format string, args ...any) *SelectElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2280,10 +2134,8 @@ func (e *SelectElement) IfTitlef(condition bool, format string, args ...any) *Se
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *SelectElement) TitleRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2292,10 +2144,8 @@ func (e *SelectElement) TitleRemove() *SelectElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *SelectElement) Translate(c SelectTranslateChoice) *SelectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[SelectTranslateChoice])
 	return e
 }
 
@@ -2316,9 +2166,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *SelectElement) TranslateRemove() *SelectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <small> element makes the text font size one size smaller (for
@@ -24,18 +22,77 @@ type SmallElement struct {
 // with the tag "small" during rendering.
 func Small(children ...ElementRenderer) *SmallElement {
 	e := NewElement("small", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SmallElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SmallElement) Clone() *SmallElement {
+	return &SmallElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SmallElement) CloneShallow() *SmallElement {
+	return &SmallElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SmallElement) Freeze() *SmallElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SmallElement) writable() *SmallElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SmallElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SmallElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[SmallAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[SmallContenteditableChoice]
+	case "dir":
+		return choiceValue[SmallDirChoice]
+	case "draggable":
+		return choiceValue[SmallDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[SmallEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[SmallHiddenChoice]
+	case "inputmode":
+		return choiceValue[SmallInputmodeChoice]
+	case "popover":
+		return choiceValue[SmallPopoverChoice]
+	case "spellcheck":
+		return choiceValue[SmallSpellcheckChoice]
+	case "translate":
+		return choiceValue[SmallTranslateChoice]
+	}
+	return nil
+}
+
 func (e *SmallElement) Children(children ...ElementRenderer) *SmallElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SmallElement) IfChildren(condition bool, children ...ElementRenderer) *SmallElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +100,30 @@ func (e *SmallElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *SmallElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SmallElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SmallElement) BoolAttr(name string) *SmallElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SmallElement) BoolAttrRemove(name string) *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SmallElement) IfBoolAttr(condition bool, name string) *SmallElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +134,40 @@ func (e *SmallElement) BoolAttrf(format string, args ...any) *SmallElement {
 
 func (e *SmallElement) IfBoolAttrf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SmallElement) BoolAttrs(names ...string) *SmallElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SmallElement) IfBoolAttrs(condition bool, names ...string) *SmallElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SmallElement) Attr(name, value string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SmallElement) RawAttr(name, value string) *SmallElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SmallElement) IfAttr(condition bool, name, value string) *SmallElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +178,52 @@ func (e *SmallElement) Attrf(name, format string, args ...any) *SmallElement {
 
 func (e *SmallElement) IfAttrf(condition bool, name, format string, args ...any) *SmallElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SmallElement) Attrs(attrs ...string) *SmallElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SmallElement) IfAttrs(condition bool, attrs ...string) *SmallElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SmallElement) AttrsMap(attrs map[string]string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SmallElement) IfAttrsMap(condition bool, attrs map[string]string) *SmallElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SmallElement) Text(text string) *SmallElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SmallElement) Textf(format string, args ...any) *SmallElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SmallElement) IfText(condition bool, text string) *SmallElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +231,28 @@ func (e *SmallElement) IfText(condition bool, text string) *SmallElement {
 
 func (e *SmallElement) IfTextf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SmallElement) Raw(text string) *SmallElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SmallElement) Escaped(text string) *SmallElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SmallElement) IfEscaped(condition bool, text string) *SmallElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +264,7 @@ func (e *SmallElement) Escapedf(format string, args ...any) *SmallElement {
 
 func (e *SmallElement) IfEscapedf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -214,10 +275,8 @@ func (e *SmallElement) IfEscapedf(condition bool, format string, args ...any) *S
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *SmallElement) Accesskey(r rune) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -227,7 +286,7 @@ func (e *SmallElement) Accesskey(r rune) *SmallElement {
 // can be generated by the keyboard).
 func (e *SmallElement) IfAccesskey(condition bool, r rune) *SmallElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -238,10 +297,8 @@ func (e *SmallElement) IfAccesskey(condition bool, r rune) *SmallElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *SmallElement) AccesskeyRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -257,10 +314,8 @@ func (e *SmallElement) AccesskeyRemove() *SmallElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *SmallElement) Autocapitalize(c SmallAutocapitalizeChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[SmallAutocapitalizeChoice])
 	return e
 }
 
@@ -294,10 +349,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *SmallElement) AutocapitalizeRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -316,10 +369,8 @@ func (e *SmallElement) AutocapitalizeRemove() *SmallElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SmallElement) Autofocus() *SmallElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -339,7 +390,7 @@ func (e *SmallElement) Autofocus() *SmallElement {
 // created by the preceding content.
 func (e *SmallElement) IfAutofocus(condition bool) *SmallElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -360,10 +411,8 @@ func (e *SmallElement) IfAutofocus(condition bool) *SmallElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SmallElement) AutofocusRemove() *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -383,10 +432,8 @@ func (e *SmallElement) AutofocusRemove() *SmallElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SmallElement) AutofocusIfRemove() *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -395,16 +442,9 @@ func (e *SmallElement) AutofocusIfRemove() *SmallElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *SmallElement) Class(s string) *SmallElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -414,7 +454,7 @@ func (e *SmallElement) Class(s string) *SmallElement {
 // document.getElementsByClassName.
 func (e *SmallElement) IfClass(condition bool, s string) *SmallElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -425,14 +465,8 @@ func (e *SmallElement) IfClass(condition bool, s string) *SmallElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *SmallElement) ClassRemove(s ...string) *SmallElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -440,10 +474,8 @@ func (e *SmallElement) ClassRemove(s ...string) *SmallElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *SmallElement) Contenteditable(c SmallContenteditableChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[SmallContenteditableChoice])
 	return e
 }
 
@@ -466,10 +498,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *SmallElement) ContenteditableRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -487,10 +517,8 @@ func (e *SmallElement) ContenteditableRemove() *SmallElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *SmallElement) Dir(c SmallDirChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[SmallDirChoice])
 	return e
 }
 
@@ -524,10 +552,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *SmallElement) DirRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -535,10 +561,8 @@ func (e *SmallElement) DirRemove() *SmallElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *SmallElement) Draggable(c SmallDraggableChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[SmallDraggableChoice])
 	return e
 }
 
@@ -564,20 +588,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *SmallElement) DraggableRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *SmallElement) Enterkeyhint(c SmallEnterkeyhintChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[SmallEnterkeyhintChoice])
 	return e
 }
 
@@ -607,10 +627,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *SmallElement) EnterkeyhintRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -632,16 +650,9 @@ func (e *SmallElement) EnterkeyhintRemove() *SmallElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *SmallElement) Exportparts(s string) *SmallElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -664,7 +675,7 @@ func (e *SmallElement) Exportparts(s string) *SmallElement {
 // the current structure.
 func (e *SmallElement) IfExportparts(condition bool, s string) *SmallElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -688,14 +699,8 @@ func (e *SmallElement) IfExportparts(condition bool, s string) *SmallElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *SmallElement) ExportpartsRemove(s ...string) *SmallElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -712,10 +717,8 @@ func (e *SmallElement) ExportpartsRemove(s ...string) *SmallElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *SmallElement) Hidden(c SmallHiddenChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[SmallHiddenChoice])
 	return e
 }
 
@@ -751,10 +754,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *SmallElement) HiddenRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -762,10 +763,8 @@ func (e *SmallElement) HiddenRemove() *SmallElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SmallElement) ID(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -781,7 +780,7 @@ func (e *SmallElement) IDf(format string, args ...any) *SmallElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SmallElement) IfID(condition bool, s string) *SmallElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -791,7 +790,7 @@ func (e *SmallElement) IfID(condition bool, s string) *SmallElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SmallElement) IfIDf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -801,10 +800,8 @@ func (e *SmallElement) IfIDf(condition bool, format string, args ...any) *SmallE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *SmallElement) IDRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -819,10 +816,8 @@ func (e *SmallElement) IDRemove() *SmallElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SmallElement) Inert() *SmallElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -838,7 +833,7 @@ func (e *SmallElement) Inert() *SmallElement {
 // excluding them from the accessibility tree.
 func (e *SmallElement) IfInert(condition bool) *SmallElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -855,10 +850,8 @@ func (e *SmallElement) IfInert(condition bool) *SmallElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SmallElement) InertRemove() *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -874,10 +867,8 @@ func (e *SmallElement) InertRemove() *SmallElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SmallElement) InertIfRemove() *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -891,10 +882,8 @@ func (e *SmallElement) InertIfRemove() *SmallElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *SmallElement) Inputmode(c SmallInputmodeChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[SmallInputmodeChoice])
 	return e
 }
 
@@ -945,10 +934,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *SmallElement) InputmodeRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -958,10 +945,8 @@ func (e *SmallElement) InputmodeRemove() *SmallElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *SmallElement) Is(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -981,7 +966,7 @@ func (e *SmallElement) Isf(format string, args ...any) *SmallElement {
 // and extends the element type it is being applied to.
 func (e *SmallElement) IfIs(condition bool, s string) *SmallElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -993,7 +978,7 @@ func (e *SmallElement) IfIs(condition bool, s string) *SmallElement {
 // and extends the element type it is being applied to.
 func (e *SmallElement) IfIsf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1005,10 +990,8 @@ func (e *SmallElement) IfIsf(condition bool, format string, args ...any) *SmallE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *SmallElement) IsRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1024,10 +1007,8 @@ func (e *SmallElement) IsRemove() *SmallElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *SmallElement) Itemid(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1059,7 +1040,7 @@ func (e *SmallElement) Itemidf(format string, args ...any) *SmallElement {
 // how items with the same identifier are handled.
 func (e *SmallElement) IfItemid(condition bool, s string) *SmallElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1077,7 +1058,7 @@ func (e *SmallElement) IfItemid(condition bool, s string) *SmallElement {
 // how items with the same identifier are handled.
 func (e *SmallElement) IfItemidf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1095,10 +1076,8 @@ func (e *SmallElement) IfItemidf(condition bool, format string, args ...any) *Sm
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *SmallElement) ItemidRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1110,10 +1089,8 @@ func (e *SmallElement) ItemidRemove() *SmallElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *SmallElement) Itemprop(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1137,7 +1114,7 @@ func (e *SmallElement) Itempropf(format string, args ...any) *SmallElement {
 // <track>, and <video>.
 func (e *SmallElement) IfItemprop(condition bool, s string) *SmallElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1151,7 +1128,7 @@ func (e *SmallElement) IfItemprop(condition bool, s string) *SmallElement {
 // <track>, and <video>.
 func (e *SmallElement) IfItempropf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1165,10 +1142,8 @@ func (e *SmallElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *SmallElement) ItempropRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1178,10 +1153,8 @@ func (e *SmallElement) ItempropRemove() *SmallElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *SmallElement) Itemref(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1201,7 +1174,7 @@ func (e *SmallElement) Itemreff(format string, args ...any) *SmallElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SmallElement) IfItemref(condition bool, s string) *SmallElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1213,7 +1186,7 @@ func (e *SmallElement) IfItemref(condition bool, s string) *SmallElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SmallElement) IfItemreff(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1225,10 +1198,8 @@ func (e *SmallElement) IfItemreff(condition bool, format string, args ...any) *S
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *SmallElement) ItemrefRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1240,10 +1211,8 @@ func (e *SmallElement) ItemrefRemove() *SmallElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SmallElement) Itemscope() *SmallElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1256,7 +1225,7 @@ func (e *SmallElement) Itemscope() *SmallElement {
 // <object>, <source>, <track>, and <video>.
 func (e *SmallElement) IfItemscope(condition bool) *SmallElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1270,10 +1239,8 @@ func (e *SmallElement) IfItemscope(condition bool) *SmallElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SmallElement) ItemscopeRemove() *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1286,10 +1253,8 @@ func (e *SmallElement) ItemscopeRemove() *SmallElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SmallElement) ItemscopeIfRemove() *SmallElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1301,10 +1266,8 @@ func (e *SmallElement) ItemscopeIfRemove() *SmallElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *SmallElement) Itemtype(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1328,7 +1291,7 @@ func (e *SmallElement) Itemtypef(format string, args ...any) *SmallElement {
 // <video>.
 func (e *SmallElement) IfItemtype(condition bool, s string) *SmallElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1342,7 +1305,7 @@ func (e *SmallElement) IfItemtype(condition bool, s string) *SmallElement {
 // <video>.
 func (e *SmallElement) IfItemtypef(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1356,10 +1319,8 @@ func (e *SmallElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *SmallElement) ItemtypeRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1369,10 +1330,8 @@ func (e *SmallElement) ItemtypeRemove() *SmallElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SmallElement) Lang(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1392,7 +1351,7 @@ func (e *SmallElement) Langf(format string, args ...any) *SmallElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SmallElement) IfLang(condition bool, s string) *SmallElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1404,7 +1363,7 @@ func (e *SmallElement) IfLang(condition bool, s string) *SmallElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SmallElement) IfLangf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1416,10 +1375,8 @@ func (e *SmallElement) IfLangf(condition bool, format string, args ...any) *Smal
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *SmallElement) LangRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1429,10 +1386,8 @@ func (e *SmallElement) LangRemove() *SmallElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *SmallElement) Nonce(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1452,7 +1407,7 @@ func (e *SmallElement) Noncef(format string, args ...any) *SmallElement {
 // execute.
 func (e *SmallElement) IfNonce(condition bool, s string) *SmallElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1464,7 +1419,7 @@ func (e *SmallElement) IfNonce(condition bool, s string) *SmallElement {
 // execute.
 func (e *SmallElement) IfNoncef(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1476,10 +1431,8 @@ func (e *SmallElement) IfNoncef(condition bool, format string, args ...any) *Sma
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *SmallElement) NonceRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1487,16 +1440,9 @@ func (e *SmallElement) NonceRemove() *SmallElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *SmallElement) Part(s string) *SmallElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1505,7 +1451,7 @@ func (e *SmallElement) Part(s string) *SmallElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *SmallElement) IfPart(condition bool, s string) *SmallElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1515,14 +1461,8 @@ func (e *SmallElement) IfPart(condition bool, s string) *SmallElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *SmallElement) PartRemove(s ...string) *SmallElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1533,10 +1473,8 @@ func (e *SmallElement) PartRemove(s ...string) *SmallElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *SmallElement) Popover(c SmallPopoverChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[SmallPopoverChoice])
 	return e
 }
 
@@ -1564,10 +1502,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *SmallElement) PopoverRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1576,10 +1512,8 @@ func (e *SmallElement) PopoverRemove() *SmallElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *SmallElement) Role(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1597,7 +1531,7 @@ func (e *SmallElement) Rolef(format string, args ...any) *SmallElement {
 // role of an element.
 func (e *SmallElement) IfRole(condition bool, s string) *SmallElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1608,7 +1542,7 @@ func (e *SmallElement) IfRole(condition bool, s string) *SmallElement {
 // role of an element.
 func (e *SmallElement) IfRolef(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1619,10 +1553,8 @@ func (e *SmallElement) IfRolef(condition bool, format string, args ...any) *Smal
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *SmallElement) RoleRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1631,10 +1563,8 @@ func (e *SmallElement) RoleRemove() *SmallElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *SmallElement) Slot(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1652,7 +1582,7 @@ func (e *SmallElement) Slotf(format string, args ...any) *SmallElement {
 // value.
 func (e *SmallElement) IfSlot(condition bool, s string) *SmallElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1663,7 +1593,7 @@ func (e *SmallElement) IfSlot(condition bool, s string) *SmallElement {
 // value.
 func (e *SmallElement) IfSlotf(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1674,10 +1604,8 @@ func (e *SmallElement) IfSlotf(condition bool, format string, args ...any) *Smal
 // value.
 // Remove the attribute Slot from the element.
 func (e *SmallElement) SlotRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1693,10 +1621,8 @@ func (e *SmallElement) SlotRemove() *SmallElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *SmallElement) Spellcheck(c SmallSpellcheckChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[SmallSpellcheckChoice])
 	return e
 }
 
@@ -1724,10 +1650,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *SmallElement) SpellcheckRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1737,14 +1661,8 @@ func (e *SmallElement) StylePairs(pairs ...string) *SmallElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1759,14 +1677,8 @@ func (e *SmallElement) StylePairs(pairs ...string) *SmallElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SmallElement) Style(s string) *SmallElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1774,7 +1686,7 @@ func (e *SmallElement) Style(s string) *SmallElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1783,7 +1695,7 @@ func (e *SmallElement) Style(s string) *SmallElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *SmallElement) IfStyle(condition bool, s string) *SmallElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1791,15 +1703,7 @@ func (e *SmallElement) IfStyle(condition bool, s string) *SmallElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SmallElement) StyleAdd(k string, v string) *SmallElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1813,7 +1717,7 @@ func (e *SmallElement) StyleAddf(k string, format string, args ...any) *SmallEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *SmallElement) IfStyleAdd(condition bool, k string, v string) *SmallElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1822,7 +1726,7 @@ func (e *SmallElement) IfStyleAdd(condition bool, k string, v string) *SmallElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *SmallElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SmallElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1831,21 +1735,15 @@ func (e *SmallElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *SmallElement) StyleMap(m map[string]string) *SmallElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1854,14 +1752,8 @@ func (e *SmallElement) StyleMap(m map[string]string) *SmallElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *SmallElement) StyleRemove(keys ...string) *SmallElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1879,10 +1771,8 @@ func (e *SmallElement) StyleRemove(keys ...string) *SmallElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *SmallElement) Tabindex(i int) *SmallElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1901,7 +1791,7 @@ func (e *SmallElement) Tabindex(i int) *SmallElement {
 // their relative position in the document.
 func (e *SmallElement) IfTabindex(condition bool, i int) *SmallElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1921,10 +1811,8 @@ func (e *SmallElement) IfTabindex(condition bool, i int) *SmallElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *SmallElement) TabindexRemove() *SmallElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1945,10 +1833,8 @@ func (e *SmallElement) TabindexRemove() *SmallElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *SmallElement) Title(s string) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1990,7 +1876,7 @@ func (e *SmallElement) Titlef(format string, args ...any) *SmallElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SmallElement) IfTitle(condition bool, s string) *SmallElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2013,7 +1899,7 @@ func (e *SmallElement) IfTitle(condition bool, s string) *SmallElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SmallElement) IfTitlef(condition bool, format string, args ...any) *SmallElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2036,10 +1922,8 @@ func (e *SmallElement) IfTitlef(condition bool, format string, args ...any) *Sma
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *SmallElement) TitleRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2048,10 +1932,8 @@ func (e *SmallElement) TitleRemove() *SmallElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *SmallElement) Translate(c SmallTranslateChoice) *SmallElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[SmallTranslateChoice])
 	return e
 }
 
@@ -2072,9 +1954,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *SmallElement) TranslateRemove() *SmallElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <source> element specifies multiple media resources for the
@@ -24,18 +22,77 @@ type SourceElement struct {
 // with the tag "source" during rendering.
 func Source() *SourceElement {
 	e := NewElement("source")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &SourceElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SourceElement) Clone() *SourceElement {
+	return &SourceElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SourceElement) CloneShallow() *SourceElement {
+	return &SourceElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SourceElement) Freeze() *SourceElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SourceElement) writable() *SourceElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SourceElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SourceElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[SourceAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[SourceContenteditableChoice]
+	case "dir":
+		return choiceValue[SourceDirChoice]
+	case "draggable":
+		return choiceValue[SourceDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[SourceEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[SourceHiddenChoice]
+	case "inputmode":
+		return choiceValue[SourceInputmodeChoice]
+	case "popover":
+		return choiceValue[SourcePopoverChoice]
+	case "spellcheck":
+		return choiceValue[SourceSpellcheckChoice]
+	case "translate":
+		return choiceValue[SourceTranslateChoice]
+	}
+	return nil
+}
+
 func (e *SourceElement) Children(children ...ElementRenderer) *SourceElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SourceElement) IfChildren(condition bool, children ...ElementRenderer) *SourceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +100,30 @@ func (e *SourceElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SourceElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SourceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SourceElement) BoolAttr(name string) *SourceElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SourceElement) BoolAttrRemove(name string) *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SourceElement) IfBoolAttr(condition bool, name string) *SourceElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +134,40 @@ func (e *SourceElement) BoolAttrf(format string, args ...any) *SourceElement {
 
 func (e *SourceElement) IfBoolAttrf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SourceElement) BoolAttrs(names ...string) *SourceElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SourceElement) IfBoolAttrs(condition bool, names ...string) *SourceElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SourceElement) Attr(name, value string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SourceElement) RawAttr(name, value string) *SourceElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SourceElement) IfAttr(condition bool, name, value string) *SourceElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +178,52 @@ func (e *SourceElement) Attrf(name, format string, args ...any) *SourceElement {
 
 func (e *SourceElement) IfAttrf(condition bool, name, format string, args ...any) *SourceElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SourceElement) Attrs(attrs ...string) *SourceElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SourceElement) IfAttrs(condition bool, attrs ...string) *SourceElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SourceElement) AttrsMap(attrs map[string]string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SourceElement) IfAttrsMap(condition bool, attrs map[string]string) *SourceElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SourceElement) Text(text string) *SourceElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SourceElement) Textf(format string, args ...any) *SourceElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SourceElement) IfText(condition bool, text string) *SourceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +231,28 @@ func (e *SourceElement) IfText(condition bool, text string) *SourceElement {
 
 func (e *SourceElement) IfTextf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SourceElement) Raw(text string) *SourceElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SourceElement) Escaped(text string) *SourceElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SourceElement) IfEscaped(condition bool, text string) *SourceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +264,7 @@ func (e *SourceElement) Escapedf(format string, args ...any) *SourceElement {
 
 func (e *SourceElement) IfEscapedf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +273,8 @@ func (e *SourceElement) IfEscapedf(condition bool, format string, args ...any) *
 // Specifies the intended media type of the media resource, to help the browser
 // determine if it can be played.
 func (e *SourceElement) Media(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("media", s)
+	e = e.writable()
+	e.setStringAttribute("media", s)
 	return e
 }
 
@@ -229,7 +288,7 @@ func (e *SourceElement) Mediaf(format string, args ...any) *SourceElement {
 // determine if it can be played.
 func (e *SourceElement) IfMedia(condition bool, s string) *SourceElement {
 	if condition {
-		e.Media(s)
+		e = e.Media(s)
 	}
 	return e
 }
@@ -238,7 +297,7 @@ func (e *SourceElement) IfMedia(condition bool, s string) *SourceElement {
 // determine if it can be played.
 func (e *SourceElement) IfMediaf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Media(fmt.Sprintf(format, args...))
+		e = e.Media(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -247,19 +306,15 @@ func (e *SourceElement) IfMediaf(condition bool, format string, args ...any) *So
 // determine if it can be played.
 // Remove the attribute Media from the element.
 func (e *SourceElement) MediaRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("media")
+	e = e.writable()
+	e.removeAttribute("media")
 	return e
 }
 
 // Specifies the size of the linked resource.
 func (e *SourceElement) Sizes(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("sizes", s)
+	e = e.writable()
+	e.setStringAttribute("sizes", s)
 	return e
 }
 
@@ -271,7 +326,7 @@ func (e *SourceElement) Sizesf(format string, args ...any) *SourceElement {
 // Specifies the size of the linked resource.
 func (e *SourceElement) IfSizes(condition bool, s string) *SourceElement {
 	if condition {
-		e.Sizes(s)
+		e = e.Sizes(s)
 	}
 	return e
 }
@@ -279,7 +334,7 @@ func (e *SourceElement) IfSizes(condition bool, s string) *SourceElement {
 // Specifies the size of the linked resource.
 func (e *SourceElement) IfSizesf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Sizes(fmt.Sprintf(format, args...))
+		e = e.Sizes(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -287,19 +342,15 @@ func (e *SourceElement) IfSizesf(condition bool, format string, args ...any) *So
 // Specifies the size of the linked resource.
 // Remove the attribute Sizes from the element.
 func (e *SourceElement) SizesRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("sizes")
+	e = e.writable()
+	e.removeAttribute("sizes")
 	return e
 }
 
 // The URL of the media resource.
 func (e *SourceElement) Src(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -311,7 +362,7 @@ func (e *SourceElement) Srcf(format string, args ...any) *SourceElement {
 // The URL of the media resource.
 func (e *SourceElement) IfSrc(condition bool, s string) *SourceElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -319,7 +370,7 @@ func (e *SourceElement) IfSrc(condition bool, s string) *SourceElement {
 // The URL of the media resource.
 func (e *SourceElement) IfSrcf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -327,20 +378,16 @@ func (e *SourceElement) IfSrcf(condition bool, format string, args ...any) *Sour
 // The URL of the media resource.
 // Remove the attribute Src from the element.
 func (e *SourceElement) SrcRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // A list of one or more strings separated by commas indicating a set of
 // possible image sources for the user agent to use.
 func (e *SourceElement) Srcset(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("srcset", s)
+	e = e.writable()
+	e.setStringAttribute("srcset", s)
 	return e
 }
 
@@ -354,7 +401,7 @@ func (e *SourceElement) Srcsetf(format string, args ...any) *SourceElement {
 // possible image sources for the user agent to use.
 func (e *SourceElement) IfSrcset(condition bool, s string) *SourceElement {
 	if condition {
-		e.Srcset(s)
+		e = e.Srcset(s)
 	}
 	return e
 }
@@ -363,7 +410,7 @@ func (e *SourceElement) IfSrcset(condition bool, s string) *SourceElement {
 // possible image sources for the user agent to use.
 func (e *SourceElement) IfSrcsetf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Srcset(fmt.Sprintf(format, args...))
+		e = e.Srcset(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -372,19 +419,15 @@ func (e *SourceElement) IfSrcsetf(condition bool, format string, args ...any) *S
 // possible image sources for the user agent to use.
 // Remove the attribute Srcset from the element.
 func (e *SourceElement) SrcsetRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("srcset")
+	e = e.writable()
+	e.removeAttribute("srcset")
 	return e
 }
 
 // Specifies the MIME type of the linked resource.
 func (e *SourceElement) Type(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -396,7 +439,7 @@ func (e *SourceElement) Typef(format string, args ...any) *SourceElement {
 // Specifies the MIME type of the linked resource.
 func (e *SourceElement) IfType(condition bool, s string) *SourceElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -404,7 +447,7 @@ func (e *SourceElement) IfType(condition bool, s string) *SourceElement {
 // Specifies the MIME type of the linked resource.
 func (e *SourceElement) IfTypef(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -412,10 +455,8 @@ func (e *SourceElement) IfTypef(condition bool, format string, args ...any) *Sou
 // Specifies the MIME type of the linked resource.
 // Remove the attribute Type from the element.
 func (e *SourceElement) TypeRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -424,10 +465,8 @@ func (e *SourceElement) TypeRemove() *SourceElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *SourceElement) Accesskey(r rune) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -437,7 +476,7 @@ func (e *SourceElement) Accesskey(r rune) *SourceElement {
 // can be generated by the keyboard).
 func (e *SourceElement) IfAccesskey(condition bool, r rune) *SourceElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -448,10 +487,8 @@ func (e *SourceElement) IfAccesskey(condition bool, r rune) *SourceElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *SourceElement) AccesskeyRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -467,10 +504,8 @@ func (e *SourceElement) AccesskeyRemove() *SourceElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *SourceElement) Autocapitalize(c SourceAutocapitalizeChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[SourceAutocapitalizeChoice])
 	return e
 }
 
@@ -504,10 +539,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *SourceElement) AutocapitalizeRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -526,10 +559,8 @@ func (e *SourceElement) AutocapitalizeRemove() *SourceElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SourceElement) Autofocus() *SourceElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -549,7 +580,7 @@ func (e *SourceElement) Autofocus() *SourceElement {
 // created by the preceding content.
 func (e *SourceElement) IfAutofocus(condition bool) *SourceElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -570,10 +601,8 @@ func (e *SourceElement) IfAutofocus(condition bool) *SourceElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SourceElement) AutofocusRemove() *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -593,10 +622,8 @@ func (e *SourceElement) AutofocusRemove() *SourceElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *SourceElement) AutofocusIfRemove() *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -605,16 +632,9 @@ func (e *SourceElement) AutofocusIfRemove() *SourceElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *SourceElement) Class(s string) *SourceElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -624,7 +644,7 @@ func (e *SourceElement) Class(s string) *SourceElement {
 // document.getElementsByClassName.
 func (e *SourceElement) IfClass(condition bool, s string) *SourceElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -635,14 +655,8 @@ func (e *SourceElement) IfClass(condition bool, s string) *SourceElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *SourceElement) ClassRemove(s ...string) *SourceElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -650,10 +664,8 @@ func (e *SourceElement) ClassRemove(s ...string) *SourceElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *SourceElement) Contenteditable(c SourceContenteditableChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[SourceContenteditableChoice])
 	return e
 }
 
@@ -676,10 +688,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *SourceElement) ContenteditableRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -697,10 +707,8 @@ func (e *SourceElement) ContenteditableRemove() *SourceElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *SourceElement) Dir(c SourceDirChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[SourceDirChoice])
 	return e
 }
 
@@ -734,10 +742,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *SourceElement) DirRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -745,10 +751,8 @@ func (e *SourceElement) DirRemove() *SourceElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *SourceElement) Draggable(c SourceDraggableChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[SourceDraggableChoice])
 	return e
 }
 
@@ -774,20 +778,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *SourceElement) DraggableRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *SourceElement) Enterkeyhint(c SourceEnterkeyhintChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[SourceEnterkeyhintChoice])
 	return e
 }
 
@@ -817,10 +817,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *SourceElement) EnterkeyhintRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -842,16 +840,9 @@ func (e *SourceElement) EnterkeyhintRemove() *SourceElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *SourceElement) Exportparts(s string) *SourceElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -874,7 +865,7 @@ func (e *SourceElement) Exportparts(s string) *SourceElement {
 // the current structure.
 func (e *SourceElement) IfExportparts(condition bool, s string) *SourceElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -898,14 +889,8 @@ func (e *SourceElement) IfExportparts(condition bool, s string) *SourceElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *SourceElement) ExportpartsRemove(s ...string) *SourceElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -922,10 +907,8 @@ func (e *SourceElement) ExportpartsRemove(s ...string) *SourceElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *SourceElement) Hidden(c SourceHiddenChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[SourceHiddenChoice])
 	return e
 }
 
@@ -961,10 +944,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *SourceElement) HiddenRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -972,10 +953,8 @@ func (e *SourceElement) HiddenRemove() *SourceElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SourceElement) ID(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -991,7 +970,7 @@ func (e *SourceElement) IDf(format string, args ...any) *SourceElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SourceElement) IfID(condition bool, s string) *SourceElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1001,7 +980,7 @@ func (e *SourceElement) IfID(condition bool, s string) *SourceElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *SourceElement) IfIDf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1011,10 +990,8 @@ func (e *SourceElement) IfIDf(condition bool, format string, args ...any) *Sourc
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *SourceElement) IDRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1029,10 +1006,8 @@ func (e *SourceElement) IDRemove() *SourceElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SourceElement) Inert() *SourceElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1048,7 +1023,7 @@ func (e *SourceElement) Inert() *SourceElement {
 // excluding them from the accessibility tree.
 func (e *SourceElement) IfInert(condition bool) *SourceElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1065,10 +1040,8 @@ func (e *SourceElement) IfInert(condition bool) *SourceElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SourceElement) InertRemove() *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1084,10 +1057,8 @@ func (e *SourceElement) InertRemove() *SourceElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *SourceElement) InertIfRemove() *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1101,10 +1072,8 @@ func (e *SourceElement) InertIfRemove() *SourceElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *SourceElement) Inputmode(c SourceInputmodeChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[SourceInputmodeChoice])
 	return e
 }
 
@@ -1155,10 +1124,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *SourceElement) InputmodeRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1168,10 +1135,8 @@ func (e *SourceElement) InputmodeRemove() *SourceElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *SourceElement) Is(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1191,7 +1156,7 @@ func (e *SourceElement) Isf(format string, args ...any) *SourceElement {
 // and extends the element type it is being applied to.
 func (e *SourceElement) IfIs(condition bool, s string) *SourceElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1203,7 +1168,7 @@ func (e *SourceElement) IfIs(condition bool, s string) *SourceElement {
 // and extends the element type it is being applied to.
 func (e *SourceElement) IfIsf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1215,10 +1180,8 @@ func (e *SourceElement) IfIsf(condition bool, format string, args ...any) *Sourc
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *SourceElement) IsRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1234,10 +1197,8 @@ func (e *SourceElement) IsRemove() *SourceElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *SourceElement) Itemid(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1269,7 +1230,7 @@ func (e *SourceElement) Itemidf(format string, args ...any) *SourceElement {
 // how items with the same identifier are handled.
 func (e *SourceElement) IfItemid(condition bool, s string) *SourceElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1287,7 +1248,7 @@ func (e *SourceElement) IfItemid(condition bool, s string) *SourceElement {
 // how items with the same identifier are handled.
 func (e *SourceElement) IfItemidf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1305,10 +1266,8 @@ func (e *SourceElement) IfItemidf(condition bool, format string, args ...any) *S
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *SourceElement) ItemidRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1320,10 +1279,8 @@ func (e *SourceElement) ItemidRemove() *SourceElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *SourceElement) Itemprop(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1347,7 +1304,7 @@ func (e *SourceElement) Itempropf(format string, args ...any) *SourceElement {
 // <track>, and <video>.
 func (e *SourceElement) IfItemprop(condition bool, s string) *SourceElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1361,7 +1318,7 @@ func (e *SourceElement) IfItemprop(condition bool, s string) *SourceElement {
 // <track>, and <video>.
 func (e *SourceElement) IfItempropf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1375,10 +1332,8 @@ func (e *SourceElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *SourceElement) ItempropRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1388,10 +1343,8 @@ func (e *SourceElement) ItempropRemove() *SourceElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *SourceElement) Itemref(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1411,7 +1364,7 @@ func (e *SourceElement) Itemreff(format string, args ...any) *SourceElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SourceElement) IfItemref(condition bool, s string) *SourceElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1423,7 +1376,7 @@ func (e *SourceElement) IfItemref(condition bool, s string) *SourceElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *SourceElement) IfItemreff(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1435,10 +1388,8 @@ func (e *SourceElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *SourceElement) ItemrefRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1450,10 +1401,8 @@ func (e *SourceElement) ItemrefRemove() *SourceElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SourceElement) Itemscope() *SourceElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1466,7 +1415,7 @@ func (e *SourceElement) Itemscope() *SourceElement {
 // <object>, <source>, <track>, and <video>.
 func (e *SourceElement) IfItemscope(condition bool) *SourceElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1480,10 +1429,8 @@ func (e *SourceElement) IfItemscope(condition bool) *SourceElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SourceElement) ItemscopeRemove() *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1496,10 +1443,8 @@ func (e *SourceElement) ItemscopeRemove() *SourceElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *SourceElement) ItemscopeIfRemove() *SourceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1511,10 +1456,8 @@ func (e *SourceElement) ItemscopeIfRemove() *SourceElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *SourceElement) Itemtype(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1538,7 +1481,7 @@ func (e *SourceElement) Itemtypef(format string, args ...any) *SourceElement {
 // <video>.
 func (e *SourceElement) IfItemtype(condition bool, s string) *SourceElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1552,7 +1495,7 @@ func (e *SourceElement) IfItemtype(condition bool, s string) *SourceElement {
 // <video>.
 func (e *SourceElement) IfItemtypef(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1566,10 +1509,8 @@ func (e *SourceElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *SourceElement) ItemtypeRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1579,10 +1520,8 @@ func (e *SourceElement) ItemtypeRemove() *SourceElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SourceElement) Lang(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1602,7 +1541,7 @@ func (e *SourceElement) Langf(format string, args ...any) *SourceElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SourceElement) IfLang(condition bool, s string) *SourceElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1614,7 +1553,7 @@ func (e *SourceElement) IfLang(condition bool, s string) *SourceElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *SourceElement) IfLangf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1626,10 +1565,8 @@ func (e *SourceElement) IfLangf(condition bool, format string, args ...any) *Sou
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *SourceElement) LangRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1639,10 +1576,8 @@ func (e *SourceElement) LangRemove() *SourceElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *SourceElement) Nonce(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1662,7 +1597,7 @@ func (e *SourceElement) Noncef(format string, args ...any) *SourceElement {
 // execute.
 func (e *SourceElement) IfNonce(condition bool, s string) *SourceElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1674,7 +1609,7 @@ func (e *SourceElement) IfNonce(condition bool, s string) *SourceElement {
 // execute.
 func (e *SourceElement) IfNoncef(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1686,10 +1621,8 @@ func (e *SourceElement) IfNoncef(condition bool, format string, args ...any) *So
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *SourceElement) NonceRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1697,16 +1630,9 @@ func (e *SourceElement) NonceRemove() *SourceElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *SourceElement) Part(s string) *SourceElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1715,7 +1641,7 @@ func (e *SourceElement) Part(s string) *SourceElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *SourceElement) IfPart(condition bool, s string) *SourceElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1725,14 +1651,8 @@ func (e *SourceElement) IfPart(condition bool, s string) *SourceElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *SourceElement) PartRemove(s ...string) *SourceElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1743,10 +1663,8 @@ func (e *SourceElement) PartRemove(s ...string) *SourceElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *SourceElement) Popover(c SourcePopoverChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[SourcePopoverChoice])
 	return e
 }
 
@@ -1774,10 +1692,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *SourceElement) PopoverRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1786,10 +1702,8 @@ func (e *SourceElement) PopoverRemove() *SourceElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *SourceElement) Role(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1807,7 +1721,7 @@ func (e *SourceElement) Rolef(format string, args ...any) *SourceElement {
 // role of an element.
 func (e *SourceElement) IfRole(condition bool, s string) *SourceElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1818,7 +1732,7 @@ func (e *SourceElement) IfRole(condition bool, s string) *SourceElement {
 // role of an element.
 func (e *SourceElement) IfRolef(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1829,10 +1743,8 @@ func (e *SourceElement) IfRolef(condition bool, format string, args ...any) *Sou
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *SourceElement) RoleRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1841,10 +1753,8 @@ func (e *SourceElement) RoleRemove() *SourceElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *SourceElement) Slot(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1862,7 +1772,7 @@ func (e *SourceElement) Slotf(format string, args ...any) *SourceElement {
 // value.
 func (e *SourceElement) IfSlot(condition bool, s string) *SourceElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1873,7 +1783,7 @@ func (e *SourceElement) IfSlot(condition bool, s string) *SourceElement {
 // value.
 func (e *SourceElement) IfSlotf(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1884,10 +1794,8 @@ func (e *SourceElement) IfSlotf(condition bool, format string, args ...any) *Sou
 // value.
 // Remove the attribute Slot from the element.
 func (e *SourceElement) SlotRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1903,10 +1811,8 @@ func (e *SourceElement) SlotRemove() *SourceElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *SourceElement) Spellcheck(c SourceSpellcheckChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[SourceSpellcheckChoice])
 	return e
 }
 
@@ -1934,10 +1840,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *SourceElement) SpellcheckRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1947,14 +1851,8 @@ func (e *SourceElement) StylePairs(pairs ...string) *SourceElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1969,14 +1867,8 @@ func (e *SourceElement) StylePairs(pairs ...string) *SourceElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SourceElement) Style(s string) *SourceElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1984,7 +1876,7 @@ func (e *SourceElement) Style(s string) *SourceElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1993,7 +1885,7 @@ func (e *SourceElement) Style(s string) *SourceElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *SourceElement) IfStyle(condition bool, s string) *SourceElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2001,15 +1893,7 @@ func (e *SourceElement) IfStyle(condition bool, s string) *SourceElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *SourceElement) StyleAdd(k string, v string) *SourceElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2023,7 +1907,7 @@ func (e *SourceElement) StyleAddf(k string, format string, args ...any) *SourceE
 // color, font, size, and more. Styles are written in CSS.
 func (e *SourceElement) IfStyleAdd(condition bool, k string, v string) *SourceElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2032,7 +1916,7 @@ func (e *SourceElement) IfStyleAdd(condition bool, k string, v string) *SourceEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *SourceElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SourceElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2041,21 +1925,15 @@ func (e *SourceElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *SourceElement) StyleMap(m map[string]string) *SourceElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2064,14 +1942,8 @@ func (e *SourceElement) StyleMap(m map[string]string) *SourceElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *SourceElement) StyleRemove(keys ...string) *SourceElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2089,10 +1961,8 @@ func (e *SourceElement) StyleRemove(keys ...string) *SourceElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *SourceElement) Tabindex(i int) *SourceElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2111,7 +1981,7 @@ func (e *SourceElement) Tabindex(i int) *SourceElement {
 // their relative position in the document.
 func (e *SourceElement) IfTabindex(condition bool, i int) *SourceElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2131,10 +2001,8 @@ func (e *SourceElement) IfTabindex(condition bool, i int) *SourceElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *SourceElement) TabindexRemove() *SourceElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2155,10 +2023,8 @@ func (e *SourceElement) TabindexRemove() *SourceElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *SourceElement) Title(s string) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2200,7 +2066,7 @@ func (e *SourceElement) Titlef(format string, args ...any) *SourceElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SourceElement) IfTitle(condition bool, s string) *SourceElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2223,7 +2089,7 @@ func (e *SourceElement) IfTitle(condition bool, s string) *SourceElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *SourceElement) IfTitlef(condition bool, format string, args ...any) *SourceElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2246,10 +2112,8 @@ func (e *SourceElement) IfTitlef(condition bool, format string, args ...any) *So
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *SourceElement) TitleRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2258,10 +2122,8 @@ func (e *SourceElement) TitleRemove() *SourceElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *SourceElement) Translate(c SourceTranslateChoice) *SourceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[SourceTranslateChoice])
 	return e
 }
 
@@ -2282,9 +2144,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *SourceElement) TranslateRemove() *SourceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
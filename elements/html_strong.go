@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML Strong Importance Element (<strong>) indicates that its contents
@@ -23,18 +21,77 @@ type StrongElement struct {
 // with the tag "strong" during rendering.
 func Strong(children ...ElementRenderer) *StrongElement {
 	e := NewElement("strong", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &StrongElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *StrongElement) Clone() *StrongElement {
+	return &StrongElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *StrongElement) CloneShallow() *StrongElement {
+	return &StrongElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *StrongElement) Freeze() *StrongElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *StrongElement) writable() *StrongElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *StrongElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *StrongElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[StrongAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[StrongContenteditableChoice]
+	case "dir":
+		return choiceValue[StrongDirChoice]
+	case "draggable":
+		return choiceValue[StrongDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[StrongEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[StrongHiddenChoice]
+	case "inputmode":
+		return choiceValue[StrongInputmodeChoice]
+	case "popover":
+		return choiceValue[StrongPopoverChoice]
+	case "spellcheck":
+		return choiceValue[StrongSpellcheckChoice]
+	case "translate":
+		return choiceValue[StrongTranslateChoice]
+	}
+	return nil
+}
+
 func (e *StrongElement) Children(children ...ElementRenderer) *StrongElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *StrongElement) IfChildren(condition bool, children ...ElementRenderer) *StrongElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *StrongElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *StrongElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *StrongElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *StrongElement) BoolAttr(name string) *StrongElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *StrongElement) BoolAttrRemove(name string) *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *StrongElement) IfBoolAttr(condition bool, name string) *StrongElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *StrongElement) BoolAttrf(format string, args ...any) *StrongElement {
 
 func (e *StrongElement) IfBoolAttrf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *StrongElement) BoolAttrs(names ...string) *StrongElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *StrongElement) IfBoolAttrs(condition bool, names ...string) *StrongElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *StrongElement) Attr(name, value string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *StrongElement) RawAttr(name, value string) *StrongElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *StrongElement) IfAttr(condition bool, name, value string) *StrongElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *StrongElement) Attrf(name, format string, args ...any) *StrongElement {
 
 func (e *StrongElement) IfAttrf(condition bool, name, format string, args ...any) *StrongElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *StrongElement) Attrs(attrs ...string) *StrongElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *StrongElement) IfAttrs(condition bool, attrs ...string) *StrongElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *StrongElement) AttrsMap(attrs map[string]string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *StrongElement) IfAttrsMap(condition bool, attrs map[string]string) *StrongElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *StrongElement) Text(text string) *StrongElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *StrongElement) Textf(format string, args ...any) *StrongElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *StrongElement) IfText(condition bool, text string) *StrongElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *StrongElement) IfText(condition bool, text string) *StrongElement {
 
 func (e *StrongElement) IfTextf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *StrongElement) Raw(text string) *StrongElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *StrongElement) Escaped(text string) *StrongElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *StrongElement) IfEscaped(condition bool, text string) *StrongElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *StrongElement) Escapedf(format string, args ...any) *StrongElement {
 
 func (e *StrongElement) IfEscapedf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -213,10 +274,8 @@ func (e *StrongElement) IfEscapedf(condition bool, format string, args ...any) *
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *StrongElement) Accesskey(r rune) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -226,7 +285,7 @@ func (e *StrongElement) Accesskey(r rune) *StrongElement {
 // can be generated by the keyboard).
 func (e *StrongElement) IfAccesskey(condition bool, r rune) *StrongElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -237,10 +296,8 @@ func (e *StrongElement) IfAccesskey(condition bool, r rune) *StrongElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *StrongElement) AccesskeyRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -256,10 +313,8 @@ func (e *StrongElement) AccesskeyRemove() *StrongElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *StrongElement) Autocapitalize(c StrongAutocapitalizeChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[StrongAutocapitalizeChoice])
 	return e
 }
 
@@ -293,10 +348,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *StrongElement) AutocapitalizeRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -315,10 +368,8 @@ func (e *StrongElement) AutocapitalizeRemove() *StrongElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *StrongElement) Autofocus() *StrongElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -338,7 +389,7 @@ func (e *StrongElement) Autofocus() *StrongElement {
 // created by the preceding content.
 func (e *StrongElement) IfAutofocus(condition bool) *StrongElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -359,10 +410,8 @@ func (e *StrongElement) IfAutofocus(condition bool) *StrongElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *StrongElement) AutofocusRemove() *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -382,10 +431,8 @@ func (e *StrongElement) AutofocusRemove() *StrongElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *StrongElement) AutofocusIfRemove() *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -394,16 +441,9 @@ func (e *StrongElement) AutofocusIfRemove() *StrongElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *StrongElement) Class(s string) *StrongElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +453,7 @@ func (e *StrongElement) Class(s string) *StrongElement {
 // document.getElementsByClassName.
 func (e *StrongElement) IfClass(condition bool, s string) *StrongElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -424,14 +464,8 @@ func (e *StrongElement) IfClass(condition bool, s string) *StrongElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *StrongElement) ClassRemove(s ...string) *StrongElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -439,10 +473,8 @@ func (e *StrongElement) ClassRemove(s ...string) *StrongElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *StrongElement) Contenteditable(c StrongContenteditableChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[StrongContenteditableChoice])
 	return e
 }
 
@@ -465,10 +497,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *StrongElement) ContenteditableRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -486,10 +516,8 @@ func (e *StrongElement) ContenteditableRemove() *StrongElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *StrongElement) Dir(c StrongDirChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[StrongDirChoice])
 	return e
 }
 
@@ -523,10 +551,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *StrongElement) DirRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -534,10 +560,8 @@ func (e *StrongElement) DirRemove() *StrongElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *StrongElement) Draggable(c StrongDraggableChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[StrongDraggableChoice])
 	return e
 }
 
@@ -563,20 +587,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *StrongElement) DraggableRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *StrongElement) Enterkeyhint(c StrongEnterkeyhintChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[StrongEnterkeyhintChoice])
 	return e
 }
 
@@ -606,10 +626,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *StrongElement) EnterkeyhintRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -631,16 +649,9 @@ func (e *StrongElement) EnterkeyhintRemove() *StrongElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *StrongElement) Exportparts(s string) *StrongElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -663,7 +674,7 @@ func (e *StrongElement) Exportparts(s string) *StrongElement {
 // the current structure.
 func (e *StrongElement) IfExportparts(condition bool, s string) *StrongElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -687,14 +698,8 @@ func (e *StrongElement) IfExportparts(condition bool, s string) *StrongElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *StrongElement) ExportpartsRemove(s ...string) *StrongElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -711,10 +716,8 @@ func (e *StrongElement) ExportpartsRemove(s ...string) *StrongElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *StrongElement) Hidden(c StrongHiddenChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[StrongHiddenChoice])
 	return e
 }
 
@@ -750,10 +753,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *StrongElement) HiddenRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -761,10 +762,8 @@ func (e *StrongElement) HiddenRemove() *StrongElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *StrongElement) ID(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -780,7 +779,7 @@ func (e *StrongElement) IDf(format string, args ...any) *StrongElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *StrongElement) IfID(condition bool, s string) *StrongElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -790,7 +789,7 @@ func (e *StrongElement) IfID(condition bool, s string) *StrongElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *StrongElement) IfIDf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -800,10 +799,8 @@ func (e *StrongElement) IfIDf(condition bool, format string, args ...any) *Stron
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *StrongElement) IDRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -818,10 +815,8 @@ func (e *StrongElement) IDRemove() *StrongElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *StrongElement) Inert() *StrongElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -837,7 +832,7 @@ func (e *StrongElement) Inert() *StrongElement {
 // excluding them from the accessibility tree.
 func (e *StrongElement) IfInert(condition bool) *StrongElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -854,10 +849,8 @@ func (e *StrongElement) IfInert(condition bool) *StrongElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *StrongElement) InertRemove() *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -873,10 +866,8 @@ func (e *StrongElement) InertRemove() *StrongElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *StrongElement) InertIfRemove() *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -890,10 +881,8 @@ func (e *StrongElement) InertIfRemove() *StrongElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *StrongElement) Inputmode(c StrongInputmodeChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[StrongInputmodeChoice])
 	return e
 }
 
@@ -944,10 +933,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *StrongElement) InputmodeRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -957,10 +944,8 @@ func (e *StrongElement) InputmodeRemove() *StrongElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *StrongElement) Is(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -980,7 +965,7 @@ func (e *StrongElement) Isf(format string, args ...any) *StrongElement {
 // and extends the element type it is being applied to.
 func (e *StrongElement) IfIs(condition bool, s string) *StrongElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -992,7 +977,7 @@ func (e *StrongElement) IfIs(condition bool, s string) *StrongElement {
 // and extends the element type it is being applied to.
 func (e *StrongElement) IfIsf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1004,10 +989,8 @@ func (e *StrongElement) IfIsf(condition bool, format string, args ...any) *Stron
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *StrongElement) IsRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1023,10 +1006,8 @@ func (e *StrongElement) IsRemove() *StrongElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *StrongElement) Itemid(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1058,7 +1039,7 @@ func (e *StrongElement) Itemidf(format string, args ...any) *StrongElement {
 // how items with the same identifier are handled.
 func (e *StrongElement) IfItemid(condition bool, s string) *StrongElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1076,7 +1057,7 @@ func (e *StrongElement) IfItemid(condition bool, s string) *StrongElement {
 // how items with the same identifier are handled.
 func (e *StrongElement) IfItemidf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1094,10 +1075,8 @@ func (e *StrongElement) IfItemidf(condition bool, format string, args ...any) *S
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *StrongElement) ItemidRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1109,10 +1088,8 @@ func (e *StrongElement) ItemidRemove() *StrongElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *StrongElement) Itemprop(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1136,7 +1113,7 @@ func (e *StrongElement) Itempropf(format string, args ...any) *StrongElement {
 // <track>, and <video>.
 func (e *StrongElement) IfItemprop(condition bool, s string) *StrongElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1150,7 +1127,7 @@ func (e *StrongElement) IfItemprop(condition bool, s string) *StrongElement {
 // <track>, and <video>.
 func (e *StrongElement) IfItempropf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1164,10 +1141,8 @@ func (e *StrongElement) IfItempropf(condition bool, format string, args ...any)
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *StrongElement) ItempropRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1177,10 +1152,8 @@ func (e *StrongElement) ItempropRemove() *StrongElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *StrongElement) Itemref(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1200,7 +1173,7 @@ func (e *StrongElement) Itemreff(format string, args ...any) *StrongElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *StrongElement) IfItemref(condition bool, s string) *StrongElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1212,7 +1185,7 @@ func (e *StrongElement) IfItemref(condition bool, s string) *StrongElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *StrongElement) IfItemreff(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1224,10 +1197,8 @@ func (e *StrongElement) IfItemreff(condition bool, format string, args ...any) *
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *StrongElement) ItemrefRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1239,10 +1210,8 @@ func (e *StrongElement) ItemrefRemove() *StrongElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *StrongElement) Itemscope() *StrongElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1255,7 +1224,7 @@ func (e *StrongElement) Itemscope() *StrongElement {
 // <object>, <source>, <track>, and <video>.
 func (e *StrongElement) IfItemscope(condition bool) *StrongElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1269,10 +1238,8 @@ func (e *StrongElement) IfItemscope(condition bool) *StrongElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *StrongElement) ItemscopeRemove() *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1285,10 +1252,8 @@ func (e *StrongElement) ItemscopeRemove() *StrongElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *StrongElement) ItemscopeIfRemove() *StrongElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1300,10 +1265,8 @@ func (e *StrongElement) ItemscopeIfRemove() *StrongElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *StrongElement) Itemtype(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1327,7 +1290,7 @@ func (e *StrongElement) Itemtypef(format string, args ...any) *StrongElement {
 // <video>.
 func (e *StrongElement) IfItemtype(condition bool, s string) *StrongElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1341,7 +1304,7 @@ func (e *StrongElement) IfItemtype(condition bool, s string) *StrongElement {
 // <video>.
 func (e *StrongElement) IfItemtypef(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1355,10 +1318,8 @@ func (e *StrongElement) IfItemtypef(condition bool, format string, args ...any)
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *StrongElement) ItemtypeRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1368,10 +1329,8 @@ func (e *StrongElement) ItemtypeRemove() *StrongElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *StrongElement) Lang(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1391,7 +1350,7 @@ func (e *StrongElement) Langf(format string, args ...any) *StrongElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *StrongElement) IfLang(condition bool, s string) *StrongElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1403,7 +1362,7 @@ func (e *StrongElement) IfLang(condition bool, s string) *StrongElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *StrongElement) IfLangf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1415,10 +1374,8 @@ func (e *StrongElement) IfLangf(condition bool, format string, args ...any) *Str
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *StrongElement) LangRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1428,10 +1385,8 @@ func (e *StrongElement) LangRemove() *StrongElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *StrongElement) Nonce(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1451,7 +1406,7 @@ func (e *StrongElement) Noncef(format string, args ...any) *StrongElement {
 // execute.
 func (e *StrongElement) IfNonce(condition bool, s string) *StrongElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1463,7 +1418,7 @@ func (e *StrongElement) IfNonce(condition bool, s string) *StrongElement {
 // execute.
 func (e *StrongElement) IfNoncef(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1475,10 +1430,8 @@ func (e *StrongElement) IfNoncef(condition bool, format string, args ...any) *St
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *StrongElement) NonceRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1486,16 +1439,9 @@ func (e *StrongElement) NonceRemove() *StrongElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *StrongElement) Part(s string) *StrongElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1504,7 +1450,7 @@ func (e *StrongElement) Part(s string) *StrongElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *StrongElement) IfPart(condition bool, s string) *StrongElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1514,14 +1460,8 @@ func (e *StrongElement) IfPart(condition bool, s string) *StrongElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *StrongElement) PartRemove(s ...string) *StrongElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1532,10 +1472,8 @@ func (e *StrongElement) PartRemove(s ...string) *StrongElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *StrongElement) Popover(c StrongPopoverChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[StrongPopoverChoice])
 	return e
 }
 
@@ -1563,10 +1501,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *StrongElement) PopoverRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1575,10 +1511,8 @@ func (e *StrongElement) PopoverRemove() *StrongElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *StrongElement) Role(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1596,7 +1530,7 @@ func (e *StrongElement) Rolef(format string, args ...any) *StrongElement {
 // role of an element.
 func (e *StrongElement) IfRole(condition bool, s string) *StrongElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1607,7 +1541,7 @@ func (e *StrongElement) IfRole(condition bool, s string) *StrongElement {
 // role of an element.
 func (e *StrongElement) IfRolef(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1618,10 +1552,8 @@ func (e *StrongElement) IfRolef(condition bool, format string, args ...any) *Str
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *StrongElement) RoleRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1630,10 +1562,8 @@ func (e *StrongElement) RoleRemove() *StrongElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *StrongElement) Slot(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1651,7 +1581,7 @@ func (e *StrongElement) Slotf(format string, args ...any) *StrongElement {
 // value.
 func (e *StrongElement) IfSlot(condition bool, s string) *StrongElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1662,7 +1592,7 @@ func (e *StrongElement) IfSlot(condition bool, s string) *StrongElement {
 // value.
 func (e *StrongElement) IfSlotf(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1673,10 +1603,8 @@ func (e *StrongElement) IfSlotf(condition bool, format string, args ...any) *Str
 // value.
 // Remove the attribute Slot from the element.
 func (e *StrongElement) SlotRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1692,10 +1620,8 @@ func (e *StrongElement) SlotRemove() *StrongElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *StrongElement) Spellcheck(c StrongSpellcheckChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[StrongSpellcheckChoice])
 	return e
 }
 
@@ -1723,10 +1649,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *StrongElement) SpellcheckRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1736,14 +1660,8 @@ func (e *StrongElement) StylePairs(pairs ...string) *StrongElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1758,14 +1676,8 @@ func (e *StrongElement) StylePairs(pairs ...string) *StrongElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *StrongElement) Style(s string) *StrongElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1773,7 +1685,7 @@ func (e *StrongElement) Style(s string) *StrongElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1782,7 +1694,7 @@ func (e *StrongElement) Style(s string) *StrongElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *StrongElement) IfStyle(condition bool, s string) *StrongElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1790,15 +1702,7 @@ func (e *StrongElement) IfStyle(condition bool, s string) *StrongElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *StrongElement) StyleAdd(k string, v string) *StrongElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1812,7 +1716,7 @@ func (e *StrongElement) StyleAddf(k string, format string, args ...any) *StrongE
 // color, font, size, and more. Styles are written in CSS.
 func (e *StrongElement) IfStyleAdd(condition bool, k string, v string) *StrongElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1821,7 +1725,7 @@ func (e *StrongElement) IfStyleAdd(condition bool, k string, v string) *StrongEl
 // color, font, size, and more. Styles are written in CSS.
 func (e *StrongElement) IfStyleAddf(condition bool, k string, format string, args ...any) *StrongElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1830,21 +1734,15 @@ func (e *StrongElement) IfStyleAddf(condition bool, k string, format string, arg
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *StrongElement) StyleMap(m map[string]string) *StrongElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1853,14 +1751,8 @@ func (e *StrongElement) StyleMap(m map[string]string) *StrongElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *StrongElement) StyleRemove(keys ...string) *StrongElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1878,10 +1770,8 @@ func (e *StrongElement) StyleRemove(keys ...string) *StrongElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *StrongElement) Tabindex(i int) *StrongElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1900,7 +1790,7 @@ func (e *StrongElement) Tabindex(i int) *StrongElement {
 // their relative position in the document.
 func (e *StrongElement) IfTabindex(condition bool, i int) *StrongElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1920,10 +1810,8 @@ func (e *StrongElement) IfTabindex(condition bool, i int) *StrongElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *StrongElement) TabindexRemove() *StrongElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1944,10 +1832,8 @@ func (e *StrongElement) TabindexRemove() *StrongElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *StrongElement) Title(s string) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1989,7 +1875,7 @@ func (e *StrongElement) Titlef(format string, args ...any) *StrongElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *StrongElement) IfTitle(condition bool, s string) *StrongElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2012,7 +1898,7 @@ func (e *StrongElement) IfTitle(condition bool, s string) *StrongElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *StrongElement) IfTitlef(condition bool, format string, args ...any) *StrongElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2035,10 +1921,8 @@ func (e *StrongElement) IfTitlef(condition bool, format string, args ...any) *St
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *StrongElement) TitleRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2047,10 +1931,8 @@ func (e *StrongElement) TitleRemove() *StrongElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *StrongElement) Translate(c StrongTranslateChoice) *StrongElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[StrongTranslateChoice])
 	return e
 }
 
@@ -2071,9 +1953,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *StrongElement) TranslateRemove() *StrongElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <style> element contains style information for a document, or part
@@ -23,18 +21,77 @@ type StyleElement struct {
 // with the tag "style" during rendering.
 func Style(children ...ElementRenderer) *StyleElement {
 	e := NewElement("style", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &StyleElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *StyleElement) Clone() *StyleElement {
+	return &StyleElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *StyleElement) CloneShallow() *StyleElement {
+	return &StyleElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *StyleElement) Freeze() *StyleElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *StyleElement) writable() *StyleElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *StyleElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *StyleElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[StyleAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[StyleContenteditableChoice]
+	case "dir":
+		return choiceValue[StyleDirChoice]
+	case "draggable":
+		return choiceValue[StyleDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[StyleEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[StyleHiddenChoice]
+	case "inputmode":
+		return choiceValue[StyleInputmodeChoice]
+	case "popover":
+		return choiceValue[StylePopoverChoice]
+	case "spellcheck":
+		return choiceValue[StyleSpellcheckChoice]
+	case "translate":
+		return choiceValue[StyleTranslateChoice]
+	}
+	return nil
+}
+
 func (e *StyleElement) Children(children ...ElementRenderer) *StyleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *StyleElement) IfChildren(condition bool, children ...ElementRenderer) *StyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *StyleElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *StyleElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *StyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *StyleElement) BoolAttr(name string) *StyleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *StyleElement) BoolAttrRemove(name string) *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *StyleElement) IfBoolAttr(condition bool, name string) *StyleElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *StyleElement) BoolAttrf(format string, args ...any) *StyleElement {
 
 func (e *StyleElement) IfBoolAttrf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *StyleElement) BoolAttrs(names ...string) *StyleElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *StyleElement) IfBoolAttrs(condition bool, names ...string) *StyleElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *StyleElement) Attr(name, value string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *StyleElement) RawAttr(name, value string) *StyleElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *StyleElement) IfAttr(condition bool, name, value string) *StyleElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *StyleElement) Attrf(name, format string, args ...any) *StyleElement {
 
 func (e *StyleElement) IfAttrf(condition bool, name, format string, args ...any) *StyleElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *StyleElement) Attrs(attrs ...string) *StyleElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *StyleElement) IfAttrs(condition bool, attrs ...string) *StyleElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *StyleElement) AttrsMap(attrs map[string]string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *StyleElement) IfAttrsMap(condition bool, attrs map[string]string) *StyleElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *StyleElement) Text(text string) *StyleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *StyleElement) Textf(format string, args ...any) *StyleElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *StyleElement) IfText(condition bool, text string) *StyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *StyleElement) IfText(condition bool, text string) *StyleElement {
 
 func (e *StyleElement) IfTextf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *StyleElement) Raw(text string) *StyleElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *StyleElement) Escaped(text string) *StyleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *StyleElement) IfEscaped(condition bool, text string) *StyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *StyleElement) Escapedf(format string, args ...any) *StyleElement {
 
 func (e *StyleElement) IfEscapedf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +271,8 @@ func (e *StyleElement) IfEscapedf(condition bool, format string, args ...any) *S
 
 // Specifies what media/device the media resource is optimized for.
 func (e *StyleElement) Media(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("media", s)
+	e = e.writable()
+	e.setStringAttribute("media", s)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *StyleElement) Mediaf(format string, args ...any) *StyleElement {
 // Specifies what media/device the media resource is optimized for.
 func (e *StyleElement) IfMedia(condition bool, s string) *StyleElement {
 	if condition {
-		e.Media(s)
+		e = e.Media(s)
 	}
 	return e
 }
@@ -233,7 +292,7 @@ func (e *StyleElement) IfMedia(condition bool, s string) *StyleElement {
 // Specifies what media/device the media resource is optimized for.
 func (e *StyleElement) IfMediaf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Media(fmt.Sprintf(format, args...))
+		e = e.Media(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +300,15 @@ func (e *StyleElement) IfMediaf(condition bool, format string, args ...any) *Sty
 // Specifies what media/device the media resource is optimized for.
 // Remove the attribute Media from the element.
 func (e *StyleElement) MediaRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("media")
+	e = e.writable()
+	e.removeAttribute("media")
 	return e
 }
 
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 func (e *StyleElement) Nonce(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -265,7 +320,7 @@ func (e *StyleElement) Noncef(format string, args ...any) *StyleElement {
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 func (e *StyleElement) IfNonce(condition bool, s string) *StyleElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -273,7 +328,7 @@ func (e *StyleElement) IfNonce(condition bool, s string) *StyleElement {
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 func (e *StyleElement) IfNoncef(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,19 +336,15 @@ func (e *StyleElement) IfNoncef(condition bool, format string, args ...any) *Sty
 // Cryptographic nonce used in Content Security Policy checks [CSP]
 // Remove the attribute Nonce from the element.
 func (e *StyleElement) NonceRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // Type of embedded resource.
 func (e *StyleElement) Type(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -305,7 +356,7 @@ func (e *StyleElement) Typef(format string, args ...any) *StyleElement {
 // Type of embedded resource.
 func (e *StyleElement) IfType(condition bool, s string) *StyleElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -313,7 +364,7 @@ func (e *StyleElement) IfType(condition bool, s string) *StyleElement {
 // Type of embedded resource.
 func (e *StyleElement) IfTypef(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -321,10 +372,8 @@ func (e *StyleElement) IfTypef(condition bool, format string, args ...any) *Styl
 // Type of embedded resource.
 // Remove the attribute Type from the element.
 func (e *StyleElement) TypeRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -333,10 +382,8 @@ func (e *StyleElement) TypeRemove() *StyleElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *StyleElement) Accesskey(r rune) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -346,7 +393,7 @@ func (e *StyleElement) Accesskey(r rune) *StyleElement {
 // can be generated by the keyboard).
 func (e *StyleElement) IfAccesskey(condition bool, r rune) *StyleElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -357,10 +404,8 @@ func (e *StyleElement) IfAccesskey(condition bool, r rune) *StyleElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *StyleElement) AccesskeyRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -376,10 +421,8 @@ func (e *StyleElement) AccesskeyRemove() *StyleElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *StyleElement) Autocapitalize(c StyleAutocapitalizeChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[StyleAutocapitalizeChoice])
 	return e
 }
 
@@ -413,10 +456,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *StyleElement) AutocapitalizeRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -435,10 +476,8 @@ func (e *StyleElement) AutocapitalizeRemove() *StyleElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *StyleElement) Autofocus() *StyleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -458,7 +497,7 @@ func (e *StyleElement) Autofocus() *StyleElement {
 // created by the preceding content.
 func (e *StyleElement) IfAutofocus(condition bool) *StyleElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -479,10 +518,8 @@ func (e *StyleElement) IfAutofocus(condition bool) *StyleElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *StyleElement) AutofocusRemove() *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -502,10 +539,8 @@ func (e *StyleElement) AutofocusRemove() *StyleElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *StyleElement) AutofocusIfRemove() *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -514,16 +549,9 @@ func (e *StyleElement) AutofocusIfRemove() *StyleElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *StyleElement) Class(s string) *StyleElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -533,7 +561,7 @@ func (e *StyleElement) Class(s string) *StyleElement {
 // document.getElementsByClassName.
 func (e *StyleElement) IfClass(condition bool, s string) *StyleElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -544,14 +572,8 @@ func (e *StyleElement) IfClass(condition bool, s string) *StyleElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *StyleElement) ClassRemove(s ...string) *StyleElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -559,10 +581,8 @@ func (e *StyleElement) ClassRemove(s ...string) *StyleElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *StyleElement) Contenteditable(c StyleContenteditableChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[StyleContenteditableChoice])
 	return e
 }
 
@@ -585,10 +605,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *StyleElement) ContenteditableRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -606,10 +624,8 @@ func (e *StyleElement) ContenteditableRemove() *StyleElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *StyleElement) Dir(c StyleDirChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[StyleDirChoice])
 	return e
 }
 
@@ -643,10 +659,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *StyleElement) DirRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -654,10 +668,8 @@ func (e *StyleElement) DirRemove() *StyleElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *StyleElement) Draggable(c StyleDraggableChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[StyleDraggableChoice])
 	return e
 }
 
@@ -683,20 +695,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *StyleElement) DraggableRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *StyleElement) Enterkeyhint(c StyleEnterkeyhintChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[StyleEnterkeyhintChoice])
 	return e
 }
 
@@ -726,10 +734,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *StyleElement) EnterkeyhintRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -751,16 +757,9 @@ func (e *StyleElement) EnterkeyhintRemove() *StyleElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *StyleElement) Exportparts(s string) *StyleElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -783,7 +782,7 @@ func (e *StyleElement) Exportparts(s string) *StyleElement {
 // the current structure.
 func (e *StyleElement) IfExportparts(condition bool, s string) *StyleElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -807,14 +806,8 @@ func (e *StyleElement) IfExportparts(condition bool, s string) *StyleElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *StyleElement) ExportpartsRemove(s ...string) *StyleElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -831,10 +824,8 @@ func (e *StyleElement) ExportpartsRemove(s ...string) *StyleElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *StyleElement) Hidden(c StyleHiddenChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[StyleHiddenChoice])
 	return e
 }
 
@@ -870,10 +861,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *StyleElement) HiddenRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -881,10 +870,8 @@ func (e *StyleElement) HiddenRemove() *StyleElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *StyleElement) ID(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -900,7 +887,7 @@ func (e *StyleElement) IDf(format string, args ...any) *StyleElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *StyleElement) IfID(condition bool, s string) *StyleElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -910,7 +897,7 @@ func (e *StyleElement) IfID(condition bool, s string) *StyleElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *StyleElement) IfIDf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -920,10 +907,8 @@ func (e *StyleElement) IfIDf(condition bool, format string, args ...any) *StyleE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *StyleElement) IDRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -938,10 +923,8 @@ func (e *StyleElement) IDRemove() *StyleElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *StyleElement) Inert() *StyleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -957,7 +940,7 @@ func (e *StyleElement) Inert() *StyleElement {
 // excluding them from the accessibility tree.
 func (e *StyleElement) IfInert(condition bool) *StyleElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -974,10 +957,8 @@ func (e *StyleElement) IfInert(condition bool) *StyleElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *StyleElement) InertRemove() *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -993,10 +974,8 @@ func (e *StyleElement) InertRemove() *StyleElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *StyleElement) InertIfRemove() *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1010,10 +989,8 @@ func (e *StyleElement) InertIfRemove() *StyleElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *StyleElement) Inputmode(c StyleInputmodeChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[StyleInputmodeChoice])
 	return e
 }
 
@@ -1064,10 +1041,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *StyleElement) InputmodeRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1077,10 +1052,8 @@ func (e *StyleElement) InputmodeRemove() *StyleElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *StyleElement) Is(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1100,7 +1073,7 @@ func (e *StyleElement) Isf(format string, args ...any) *StyleElement {
 // and extends the element type it is being applied to.
 func (e *StyleElement) IfIs(condition bool, s string) *StyleElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1112,7 +1085,7 @@ func (e *StyleElement) IfIs(condition bool, s string) *StyleElement {
 // and extends the element type it is being applied to.
 func (e *StyleElement) IfIsf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1124,10 +1097,8 @@ func (e *StyleElement) IfIsf(condition bool, format string, args ...any) *StyleE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *StyleElement) IsRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1143,10 +1114,8 @@ func (e *StyleElement) IsRemove() *StyleElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *StyleElement) Itemid(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1178,7 +1147,7 @@ func (e *StyleElement) Itemidf(format string, args ...any) *StyleElement {
 // how items with the same identifier are handled.
 func (e *StyleElement) IfItemid(condition bool, s string) *StyleElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1196,7 +1165,7 @@ func (e *StyleElement) IfItemid(condition bool, s string) *StyleElement {
 // how items with the same identifier are handled.
 func (e *StyleElement) IfItemidf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1214,10 +1183,8 @@ func (e *StyleElement) IfItemidf(condition bool, format string, args ...any) *St
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *StyleElement) ItemidRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1229,10 +1196,8 @@ func (e *StyleElement) ItemidRemove() *StyleElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *StyleElement) Itemprop(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1256,7 +1221,7 @@ func (e *StyleElement) Itempropf(format string, args ...any) *StyleElement {
 // <track>, and <video>.
 func (e *StyleElement) IfItemprop(condition bool, s string) *StyleElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1270,7 +1235,7 @@ func (e *StyleElement) IfItemprop(condition bool, s string) *StyleElement {
 // <track>, and <video>.
 func (e *StyleElement) IfItempropf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1284,10 +1249,8 @@ func (e *StyleElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *StyleElement) ItempropRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1297,10 +1260,8 @@ func (e *StyleElement) ItempropRemove() *StyleElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *StyleElement) Itemref(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1320,7 +1281,7 @@ func (e *StyleElement) Itemreff(format string, args ...any) *StyleElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *StyleElement) IfItemref(condition bool, s string) *StyleElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1332,7 +1293,7 @@ func (e *StyleElement) IfItemref(condition bool, s string) *StyleElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *StyleElement) IfItemreff(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1344,10 +1305,8 @@ func (e *StyleElement) IfItemreff(condition bool, format string, args ...any) *S
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *StyleElement) ItemrefRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1359,10 +1318,8 @@ func (e *StyleElement) ItemrefRemove() *StyleElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *StyleElement) Itemscope() *StyleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1375,7 +1332,7 @@ func (e *StyleElement) Itemscope() *StyleElement {
 // <object>, <source>, <track>, and <video>.
 func (e *StyleElement) IfItemscope(condition bool) *StyleElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1389,10 +1346,8 @@ func (e *StyleElement) IfItemscope(condition bool) *StyleElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *StyleElement) ItemscopeRemove() *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1405,10 +1360,8 @@ func (e *StyleElement) ItemscopeRemove() *StyleElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *StyleElement) ItemscopeIfRemove() *StyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1420,10 +1373,8 @@ func (e *StyleElement) ItemscopeIfRemove() *StyleElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *StyleElement) Itemtype(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1447,7 +1398,7 @@ func (e *StyleElement) Itemtypef(format string, args ...any) *StyleElement {
 // <video>.
 func (e *StyleElement) IfItemtype(condition bool, s string) *StyleElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1461,7 +1412,7 @@ func (e *StyleElement) IfItemtype(condition bool, s string) *StyleElement {
 // <video>.
 func (e *StyleElement) IfItemtypef(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1475,10 +1426,8 @@ func (e *StyleElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *StyleElement) ItemtypeRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1488,10 +1437,8 @@ func (e *StyleElement) ItemtypeRemove() *StyleElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *StyleElement) Lang(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1511,7 +1458,7 @@ func (e *StyleElement) Langf(format string, args ...any) *StyleElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *StyleElement) IfLang(condition bool, s string) *StyleElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1523,7 +1470,7 @@ func (e *StyleElement) IfLang(condition bool, s string) *StyleElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *StyleElement) IfLangf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1535,10 +1482,8 @@ func (e *StyleElement) IfLangf(condition bool, format string, args ...any) *Styl
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *StyleElement) LangRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1546,16 +1491,9 @@ func (e *StyleElement) LangRemove() *StyleElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *StyleElement) Part(s string) *StyleElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1564,7 +1502,7 @@ func (e *StyleElement) Part(s string) *StyleElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *StyleElement) IfPart(condition bool, s string) *StyleElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1574,14 +1512,8 @@ func (e *StyleElement) IfPart(condition bool, s string) *StyleElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *StyleElement) PartRemove(s ...string) *StyleElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1592,10 +1524,8 @@ func (e *StyleElement) PartRemove(s ...string) *StyleElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *StyleElement) Popover(c StylePopoverChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[StylePopoverChoice])
 	return e
 }
 
@@ -1623,10 +1553,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *StyleElement) PopoverRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1635,10 +1563,8 @@ func (e *StyleElement) PopoverRemove() *StyleElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *StyleElement) Role(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1656,7 +1582,7 @@ func (e *StyleElement) Rolef(format string, args ...any) *StyleElement {
 // role of an element.
 func (e *StyleElement) IfRole(condition bool, s string) *StyleElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1667,7 +1593,7 @@ func (e *StyleElement) IfRole(condition bool, s string) *StyleElement {
 // role of an element.
 func (e *StyleElement) IfRolef(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1678,10 +1604,8 @@ func (e *StyleElement) IfRolef(condition bool, format string, args ...any) *Styl
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *StyleElement) RoleRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1690,10 +1614,8 @@ func (e *StyleElement) RoleRemove() *StyleElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *StyleElement) Slot(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1711,7 +1633,7 @@ func (e *StyleElement) Slotf(format string, args ...any) *StyleElement {
 // value.
 func (e *StyleElement) IfSlot(condition bool, s string) *StyleElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1722,7 +1644,7 @@ func (e *StyleElement) IfSlot(condition bool, s string) *StyleElement {
 // value.
 func (e *StyleElement) IfSlotf(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1733,10 +1655,8 @@ func (e *StyleElement) IfSlotf(condition bool, format string, args ...any) *Styl
 // value.
 // Remove the attribute Slot from the element.
 func (e *StyleElement) SlotRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1752,10 +1672,8 @@ func (e *StyleElement) SlotRemove() *StyleElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *StyleElement) Spellcheck(c StyleSpellcheckChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[StyleSpellcheckChoice])
 	return e
 }
 
@@ -1783,10 +1701,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *StyleElement) SpellcheckRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1796,14 +1712,8 @@ func (e *StyleElement) StylePairs(pairs ...string) *StyleElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1818,14 +1728,8 @@ func (e *StyleElement) StylePairs(pairs ...string) *StyleElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *StyleElement) Style(s string) *StyleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1833,7 +1737,7 @@ func (e *StyleElement) Style(s string) *StyleElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1842,7 +1746,7 @@ func (e *StyleElement) Style(s string) *StyleElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *StyleElement) IfStyle(condition bool, s string) *StyleElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1850,15 +1754,7 @@ func (e *StyleElement) IfStyle(condition bool, s string) *StyleElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *StyleElement) StyleAdd(k string, v string) *StyleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1872,7 +1768,7 @@ func (e *StyleElement) StyleAddf(k string, format string, args ...any) *StyleEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *StyleElement) IfStyleAdd(condition bool, k string, v string) *StyleElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1881,7 +1777,7 @@ func (e *StyleElement) IfStyleAdd(condition bool, k string, v string) *StyleElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *StyleElement) IfStyleAddf(condition bool, k string, format string, args ...any) *StyleElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1890,21 +1786,15 @@ func (e *StyleElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *StyleElement) StyleMap(m map[string]string) *StyleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1913,14 +1803,8 @@ func (e *StyleElement) StyleMap(m map[string]string) *StyleElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *StyleElement) StyleRemove(keys ...string) *StyleElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1938,10 +1822,8 @@ func (e *StyleElement) StyleRemove(keys ...string) *StyleElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *StyleElement) Tabindex(i int) *StyleElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1960,7 +1842,7 @@ func (e *StyleElement) Tabindex(i int) *StyleElement {
 // their relative position in the document.
 func (e *StyleElement) IfTabindex(condition bool, i int) *StyleElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1980,10 +1862,8 @@ func (e *StyleElement) IfTabindex(condition bool, i int) *StyleElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *StyleElement) TabindexRemove() *StyleElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2004,10 +1884,8 @@ func (e *StyleElement) TabindexRemove() *StyleElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *StyleElement) Title(s string) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2049,7 +1927,7 @@ func (e *StyleElement) Titlef(format string, args ...any) *StyleElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *StyleElement) IfTitle(condition bool, s string) *StyleElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2072,7 +1950,7 @@ func (e *StyleElement) IfTitle(condition bool, s string) *StyleElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *StyleElement) IfTitlef(condition bool, format string, args ...any) *StyleElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2095,10 +1973,8 @@ func (e *StyleElement) IfTitlef(condition bool, format string, args ...any) *Sty
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *StyleElement) TitleRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2107,10 +1983,8 @@ func (e *StyleElement) TitleRemove() *StyleElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *StyleElement) Translate(c StyleTranslateChoice) *StyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[StyleTranslateChoice])
 	return e
 }
 
@@ -2131,9 +2005,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *StyleElement) TranslateRemove() *StyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <table> element represents tabular data — that is, information
@@ -23,18 +21,77 @@ type TableElement struct {
 // with the tag "table" during rendering.
 func Table(children ...ElementRenderer) *TableElement {
 	e := NewElement("table", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &TableElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *TableElement) Clone() *TableElement {
+	return &TableElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *TableElement) CloneShallow() *TableElement {
+	return &TableElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *TableElement) Freeze() *TableElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *TableElement) writable() *TableElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *TableElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *TableElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[TableAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[TableContenteditableChoice]
+	case "dir":
+		return choiceValue[TableDirChoice]
+	case "draggable":
+		return choiceValue[TableDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[TableEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[TableHiddenChoice]
+	case "inputmode":
+		return choiceValue[TableInputmodeChoice]
+	case "popover":
+		return choiceValue[TablePopoverChoice]
+	case "spellcheck":
+		return choiceValue[TableSpellcheckChoice]
+	case "translate":
+		return choiceValue[TableTranslateChoice]
+	}
+	return nil
+}
+
 func (e *TableElement) Children(children ...ElementRenderer) *TableElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *TableElement) IfChildren(condition bool, children ...ElementRenderer) *TableElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *TableElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *TableElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *TableElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *TableElement) BoolAttr(name string) *TableElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *TableElement) BoolAttrRemove(name string) *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *TableElement) IfBoolAttr(condition bool, name string) *TableElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *TableElement) BoolAttrf(format string, args ...any) *TableElement {
 
 func (e *TableElement) IfBoolAttrf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *TableElement) BoolAttrs(names ...string) *TableElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *TableElement) IfBoolAttrs(condition bool, names ...string) *TableElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *TableElement) Attr(name, value string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *TableElement) RawAttr(name, value string) *TableElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *TableElement) IfAttr(condition bool, name, value string) *TableElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *TableElement) Attrf(name, format string, args ...any) *TableElement {
 
 func (e *TableElement) IfAttrf(condition bool, name, format string, args ...any) *TableElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *TableElement) Attrs(attrs ...string) *TableElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *TableElement) IfAttrs(condition bool, attrs ...string) *TableElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *TableElement) AttrsMap(attrs map[string]string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *TableElement) IfAttrsMap(condition bool, attrs map[string]string) *TableElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *TableElement) Text(text string) *TableElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *TableElement) Textf(format string, args ...any) *TableElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *TableElement) IfText(condition bool, text string) *TableElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *TableElement) IfText(condition bool, text string) *TableElement {
 
 func (e *TableElement) IfTextf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *TableElement) Raw(text string) *TableElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *TableElement) Escaped(text string) *TableElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *TableElement) IfEscaped(condition bool, text string) *TableElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *TableElement) Escapedf(format string, args ...any) *TableElement {
 
 func (e *TableElement) IfEscapedf(condition bool, format string, args ...any) *TableElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +271,15 @@ func (e *TableElement) IfEscapedf(condition bool, format string, args ...any) *T
 
 // Controls whether to show cell borders.
 func (e *TableElement) Border(i int) *TableElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("border", i)
+	e = e.writable()
+	e.setIntAttribute("border", i)
 	return e
 }
 
 // Controls whether to show cell borders.
 func (e *TableElement) IfBorder(condition bool, i int) *TableElement {
 	if condition {
-		e.Border(i)
+		e = e.Border(i)
 	}
 	return e
 }
@@ -228,10 +287,8 @@ func (e *TableElement) IfBorder(condition bool, i int) *TableElement {
 // Controls whether to show cell borders.
 // Remove the attribute Border from the element.
 func (e *TableElement) BorderRemove() *TableElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("border")
+	e = e.writable()
+	e.removeAttribute("border")
 	return e
 }
 
@@ -240,10 +297,8 @@ func (e *TableElement) BorderRemove() *TableElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *TableElement) Accesskey(r rune) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -253,7 +308,7 @@ func (e *TableElement) Accesskey(r rune) *TableElement {
 // can be generated by the keyboard).
 func (e *TableElement) IfAccesskey(condition bool, r rune) *TableElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -264,10 +319,8 @@ func (e *TableElement) IfAccesskey(condition bool, r rune) *TableElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *TableElement) AccesskeyRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -283,10 +336,8 @@ func (e *TableElement) AccesskeyRemove() *TableElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *TableElement) Autocapitalize(c TableAutocapitalizeChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[TableAutocapitalizeChoice])
 	return e
 }
 
@@ -320,10 +371,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *TableElement) AutocapitalizeRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -342,10 +391,8 @@ func (e *TableElement) AutocapitalizeRemove() *TableElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TableElement) Autofocus() *TableElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -365,7 +412,7 @@ func (e *TableElement) Autofocus() *TableElement {
 // created by the preceding content.
 func (e *TableElement) IfAutofocus(condition bool) *TableElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -386,10 +433,8 @@ func (e *TableElement) IfAutofocus(condition bool) *TableElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TableElement) AutofocusRemove() *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -409,10 +454,8 @@ func (e *TableElement) AutofocusRemove() *TableElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TableElement) AutofocusIfRemove() *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -421,16 +464,9 @@ func (e *TableElement) AutofocusIfRemove() *TableElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *TableElement) Class(s string) *TableElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -440,7 +476,7 @@ func (e *TableElement) Class(s string) *TableElement {
 // document.getElementsByClassName.
 func (e *TableElement) IfClass(condition bool, s string) *TableElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -451,14 +487,8 @@ func (e *TableElement) IfClass(condition bool, s string) *TableElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *TableElement) ClassRemove(s ...string) *TableElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -466,10 +496,8 @@ func (e *TableElement) ClassRemove(s ...string) *TableElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *TableElement) Contenteditable(c TableContenteditableChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[TableContenteditableChoice])
 	return e
 }
 
@@ -492,10 +520,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *TableElement) ContenteditableRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -513,10 +539,8 @@ func (e *TableElement) ContenteditableRemove() *TableElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *TableElement) Dir(c TableDirChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[TableDirChoice])
 	return e
 }
 
@@ -550,10 +574,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *TableElement) DirRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -561,10 +583,8 @@ func (e *TableElement) DirRemove() *TableElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *TableElement) Draggable(c TableDraggableChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[TableDraggableChoice])
 	return e
 }
 
@@ -590,20 +610,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *TableElement) DraggableRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *TableElement) Enterkeyhint(c TableEnterkeyhintChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[TableEnterkeyhintChoice])
 	return e
 }
 
@@ -633,10 +649,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *TableElement) EnterkeyhintRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -658,16 +672,9 @@ func (e *TableElement) EnterkeyhintRemove() *TableElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *TableElement) Exportparts(s string) *TableElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -690,7 +697,7 @@ func (e *TableElement) Exportparts(s string) *TableElement {
 // the current structure.
 func (e *TableElement) IfExportparts(condition bool, s string) *TableElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -714,14 +721,8 @@ func (e *TableElement) IfExportparts(condition bool, s string) *TableElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *TableElement) ExportpartsRemove(s ...string) *TableElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -738,10 +739,8 @@ func (e *TableElement) ExportpartsRemove(s ...string) *TableElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *TableElement) Hidden(c TableHiddenChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[TableHiddenChoice])
 	return e
 }
 
@@ -777,10 +776,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *TableElement) HiddenRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -788,10 +785,8 @@ func (e *TableElement) HiddenRemove() *TableElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TableElement) ID(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -807,7 +802,7 @@ func (e *TableElement) IDf(format string, args ...any) *TableElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TableElement) IfID(condition bool, s string) *TableElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -817,7 +812,7 @@ func (e *TableElement) IfID(condition bool, s string) *TableElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TableElement) IfIDf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -827,10 +822,8 @@ func (e *TableElement) IfIDf(condition bool, format string, args ...any) *TableE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *TableElement) IDRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -845,10 +838,8 @@ func (e *TableElement) IDRemove() *TableElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TableElement) Inert() *TableElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -864,7 +855,7 @@ func (e *TableElement) Inert() *TableElement {
 // excluding them from the accessibility tree.
 func (e *TableElement) IfInert(condition bool) *TableElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -881,10 +872,8 @@ func (e *TableElement) IfInert(condition bool) *TableElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TableElement) InertRemove() *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -900,10 +889,8 @@ func (e *TableElement) InertRemove() *TableElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TableElement) InertIfRemove() *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -917,10 +904,8 @@ func (e *TableElement) InertIfRemove() *TableElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *TableElement) Inputmode(c TableInputmodeChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[TableInputmodeChoice])
 	return e
 }
 
@@ -971,10 +956,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *TableElement) InputmodeRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -984,10 +967,8 @@ func (e *TableElement) InputmodeRemove() *TableElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *TableElement) Is(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1007,7 +988,7 @@ func (e *TableElement) Isf(format string, args ...any) *TableElement {
 // and extends the element type it is being applied to.
 func (e *TableElement) IfIs(condition bool, s string) *TableElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1019,7 +1000,7 @@ func (e *TableElement) IfIs(condition bool, s string) *TableElement {
 // and extends the element type it is being applied to.
 func (e *TableElement) IfIsf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1031,10 +1012,8 @@ func (e *TableElement) IfIsf(condition bool, format string, args ...any) *TableE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *TableElement) IsRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1050,10 +1029,8 @@ func (e *TableElement) IsRemove() *TableElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *TableElement) Itemid(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1085,7 +1062,7 @@ func (e *TableElement) Itemidf(format string, args ...any) *TableElement {
 // how items with the same identifier are handled.
 func (e *TableElement) IfItemid(condition bool, s string) *TableElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1103,7 +1080,7 @@ func (e *TableElement) IfItemid(condition bool, s string) *TableElement {
 // how items with the same identifier are handled.
 func (e *TableElement) IfItemidf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1121,10 +1098,8 @@ func (e *TableElement) IfItemidf(condition bool, format string, args ...any) *Ta
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *TableElement) ItemidRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1136,10 +1111,8 @@ func (e *TableElement) ItemidRemove() *TableElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *TableElement) Itemprop(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1163,7 +1136,7 @@ func (e *TableElement) Itempropf(format string, args ...any) *TableElement {
 // <track>, and <video>.
 func (e *TableElement) IfItemprop(condition bool, s string) *TableElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1177,7 +1150,7 @@ func (e *TableElement) IfItemprop(condition bool, s string) *TableElement {
 // <track>, and <video>.
 func (e *TableElement) IfItempropf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1191,10 +1164,8 @@ func (e *TableElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *TableElement) ItempropRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1204,10 +1175,8 @@ func (e *TableElement) ItempropRemove() *TableElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *TableElement) Itemref(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1227,7 +1196,7 @@ func (e *TableElement) Itemreff(format string, args ...any) *TableElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TableElement) IfItemref(condition bool, s string) *TableElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1239,7 +1208,7 @@ func (e *TableElement) IfItemref(condition bool, s string) *TableElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TableElement) IfItemreff(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1251,10 +1220,8 @@ func (e *TableElement) IfItemreff(condition bool, format string, args ...any) *T
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *TableElement) ItemrefRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1266,10 +1233,8 @@ func (e *TableElement) ItemrefRemove() *TableElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TableElement) Itemscope() *TableElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1282,7 +1247,7 @@ func (e *TableElement) Itemscope() *TableElement {
 // <object>, <source>, <track>, and <video>.
 func (e *TableElement) IfItemscope(condition bool) *TableElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1296,10 +1261,8 @@ func (e *TableElement) IfItemscope(condition bool) *TableElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TableElement) ItemscopeRemove() *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1312,10 +1275,8 @@ func (e *TableElement) ItemscopeRemove() *TableElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TableElement) ItemscopeIfRemove() *TableElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1327,10 +1288,8 @@ func (e *TableElement) ItemscopeIfRemove() *TableElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *TableElement) Itemtype(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1354,7 +1313,7 @@ func (e *TableElement) Itemtypef(format string, args ...any) *TableElement {
 // <video>.
 func (e *TableElement) IfItemtype(condition bool, s string) *TableElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1368,7 +1327,7 @@ func (e *TableElement) IfItemtype(condition bool, s string) *TableElement {
 // <video>.
 func (e *TableElement) IfItemtypef(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1382,10 +1341,8 @@ func (e *TableElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *TableElement) ItemtypeRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1395,10 +1352,8 @@ func (e *TableElement) ItemtypeRemove() *TableElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TableElement) Lang(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1418,7 +1373,7 @@ func (e *TableElement) Langf(format string, args ...any) *TableElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TableElement) IfLang(condition bool, s string) *TableElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1430,7 +1385,7 @@ func (e *TableElement) IfLang(condition bool, s string) *TableElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TableElement) IfLangf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1442,10 +1397,8 @@ func (e *TableElement) IfLangf(condition bool, format string, args ...any) *Tabl
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *TableElement) LangRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1455,10 +1408,8 @@ func (e *TableElement) LangRemove() *TableElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *TableElement) Nonce(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1478,7 +1429,7 @@ func (e *TableElement) Noncef(format string, args ...any) *TableElement {
 // execute.
 func (e *TableElement) IfNonce(condition bool, s string) *TableElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1490,7 +1441,7 @@ func (e *TableElement) IfNonce(condition bool, s string) *TableElement {
 // execute.
 func (e *TableElement) IfNoncef(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1502,10 +1453,8 @@ func (e *TableElement) IfNoncef(condition bool, format string, args ...any) *Tab
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *TableElement) NonceRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1513,16 +1462,9 @@ func (e *TableElement) NonceRemove() *TableElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *TableElement) Part(s string) *TableElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1531,7 +1473,7 @@ func (e *TableElement) Part(s string) *TableElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *TableElement) IfPart(condition bool, s string) *TableElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1541,14 +1483,8 @@ func (e *TableElement) IfPart(condition bool, s string) *TableElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *TableElement) PartRemove(s ...string) *TableElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1559,10 +1495,8 @@ func (e *TableElement) PartRemove(s ...string) *TableElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *TableElement) Popover(c TablePopoverChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[TablePopoverChoice])
 	return e
 }
 
@@ -1590,10 +1524,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *TableElement) PopoverRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1602,10 +1534,8 @@ func (e *TableElement) PopoverRemove() *TableElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *TableElement) Role(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1623,7 +1553,7 @@ func (e *TableElement) Rolef(format string, args ...any) *TableElement {
 // role of an element.
 func (e *TableElement) IfRole(condition bool, s string) *TableElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1634,7 +1564,7 @@ func (e *TableElement) IfRole(condition bool, s string) *TableElement {
 // role of an element.
 func (e *TableElement) IfRolef(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1645,10 +1575,8 @@ func (e *TableElement) IfRolef(condition bool, format string, args ...any) *Tabl
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *TableElement) RoleRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1657,10 +1585,8 @@ func (e *TableElement) RoleRemove() *TableElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *TableElement) Slot(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1678,7 +1604,7 @@ func (e *TableElement) Slotf(format string, args ...any) *TableElement {
 // value.
 func (e *TableElement) IfSlot(condition bool, s string) *TableElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1689,7 +1615,7 @@ func (e *TableElement) IfSlot(condition bool, s string) *TableElement {
 // value.
 func (e *TableElement) IfSlotf(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1700,10 +1626,8 @@ func (e *TableElement) IfSlotf(condition bool, format string, args ...any) *Tabl
 // value.
 // Remove the attribute Slot from the element.
 func (e *TableElement) SlotRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1719,10 +1643,8 @@ func (e *TableElement) SlotRemove() *TableElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *TableElement) Spellcheck(c TableSpellcheckChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[TableSpellcheckChoice])
 	return e
 }
 
@@ -1750,10 +1672,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *TableElement) SpellcheckRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1763,14 +1683,8 @@ func (e *TableElement) StylePairs(pairs ...string) *TableElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1785,14 +1699,8 @@ func (e *TableElement) StylePairs(pairs ...string) *TableElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TableElement) Style(s string) *TableElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1800,7 +1708,7 @@ func (e *TableElement) Style(s string) *TableElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1809,7 +1717,7 @@ func (e *TableElement) Style(s string) *TableElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TableElement) IfStyle(condition bool, s string) *TableElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1817,15 +1725,7 @@ func (e *TableElement) IfStyle(condition bool, s string) *TableElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TableElement) StyleAdd(k string, v string) *TableElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1839,7 +1739,7 @@ func (e *TableElement) StyleAddf(k string, format string, args ...any) *TableEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *TableElement) IfStyleAdd(condition bool, k string, v string) *TableElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1848,7 +1748,7 @@ func (e *TableElement) IfStyleAdd(condition bool, k string, v string) *TableElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *TableElement) IfStyleAddf(condition bool, k string, format string, args ...any) *TableElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1857,21 +1757,15 @@ func (e *TableElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *TableElement) StyleMap(m map[string]string) *TableElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1880,14 +1774,8 @@ func (e *TableElement) StyleMap(m map[string]string) *TableElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *TableElement) StyleRemove(keys ...string) *TableElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1905,10 +1793,8 @@ func (e *TableElement) StyleRemove(keys ...string) *TableElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *TableElement) Tabindex(i int) *TableElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1927,7 +1813,7 @@ func (e *TableElement) Tabindex(i int) *TableElement {
 // their relative position in the document.
 func (e *TableElement) IfTabindex(condition bool, i int) *TableElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1947,10 +1833,8 @@ func (e *TableElement) IfTabindex(condition bool, i int) *TableElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *TableElement) TabindexRemove() *TableElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1971,10 +1855,8 @@ func (e *TableElement) TabindexRemove() *TableElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *TableElement) Title(s string) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2016,7 +1898,7 @@ func (e *TableElement) Titlef(format string, args ...any) *TableElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TableElement) IfTitle(condition bool, s string) *TableElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2039,7 +1921,7 @@ func (e *TableElement) IfTitle(condition bool, s string) *TableElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TableElement) IfTitlef(condition bool, format string, args ...any) *TableElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2062,10 +1944,8 @@ func (e *TableElement) IfTitlef(condition bool, format string, args ...any) *Tab
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *TableElement) TitleRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2074,10 +1954,8 @@ func (e *TableElement) TitleRemove() *TableElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *TableElement) Translate(c TableTranslateChoice) *TableElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[TableTranslateChoice])
 	return e
 }
 
@@ -2098,9 +1976,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *TableElement) TranslateRemove() *TableElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
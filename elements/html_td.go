@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <td> element defines a cell of a table that contains data. It
@@ -22,18 +20,77 @@ type TdElement struct {
 // with the tag "td" during rendering.
 func Td(children ...ElementRenderer) *TdElement {
 	e := NewElement("td", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &TdElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *TdElement) Clone() *TdElement {
+	return &TdElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *TdElement) CloneShallow() *TdElement {
+	return &TdElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *TdElement) Freeze() *TdElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *TdElement) writable() *TdElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *TdElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *TdElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[TdAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[TdContenteditableChoice]
+	case "dir":
+		return choiceValue[TdDirChoice]
+	case "draggable":
+		return choiceValue[TdDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[TdEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[TdHiddenChoice]
+	case "inputmode":
+		return choiceValue[TdInputmodeChoice]
+	case "popover":
+		return choiceValue[TdPopoverChoice]
+	case "spellcheck":
+		return choiceValue[TdSpellcheckChoice]
+	case "translate":
+		return choiceValue[TdTranslateChoice]
+	}
+	return nil
+}
+
 func (e *TdElement) Children(children ...ElementRenderer) *TdElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *TdElement) IfChildren(condition bool, children ...ElementRenderer) *TdElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +98,30 @@ func (e *TdElement) IfChildren(condition bool, children ...ElementRenderer) *TdE
 
 func (e *TdElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *TdElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *TdElement) BoolAttr(name string) *TdElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *TdElement) BoolAttrRemove(name string) *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *TdElement) IfBoolAttr(condition bool, name string) *TdElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +132,40 @@ func (e *TdElement) BoolAttrf(format string, args ...any) *TdElement {
 
 func (e *TdElement) IfBoolAttrf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *TdElement) BoolAttrs(names ...string) *TdElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *TdElement) IfBoolAttrs(condition bool, names ...string) *TdElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *TdElement) Attr(name, value string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *TdElement) RawAttr(name, value string) *TdElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *TdElement) IfAttr(condition bool, name, value string) *TdElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +176,52 @@ func (e *TdElement) Attrf(name, format string, args ...any) *TdElement {
 
 func (e *TdElement) IfAttrf(condition bool, name, format string, args ...any) *TdElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *TdElement) Attrs(attrs ...string) *TdElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *TdElement) IfAttrs(condition bool, attrs ...string) *TdElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *TdElement) AttrsMap(attrs map[string]string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *TdElement) IfAttrsMap(condition bool, attrs map[string]string) *TdElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *TdElement) Text(text string) *TdElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *TdElement) Textf(format string, args ...any) *TdElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *TdElement) IfText(condition bool, text string) *TdElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +229,28 @@ func (e *TdElement) IfText(condition bool, text string) *TdElement {
 
 func (e *TdElement) IfTextf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *TdElement) Raw(text string) *TdElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *TdElement) Escaped(text string) *TdElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *TdElement) IfEscaped(condition bool, text string) *TdElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +262,7 @@ func (e *TdElement) Escapedf(format string, args ...any) *TdElement {
 
 func (e *TdElement) IfEscapedf(condition bool, format string, args ...any) *TdElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,17 +270,15 @@ func (e *TdElement) IfEscapedf(condition bool, format string, args ...any) *TdEl
 
 // Number of columns that the cell is to span.
 func (e *TdElement) Colspan(i int) *TdElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("colspan", i)
+	e = e.writable()
+	e.setIntAttribute("colspan", i)
 	return e
 }
 
 // Number of columns that the cell is to span.
 func (e *TdElement) IfColspan(condition bool, i int) *TdElement {
 	if condition {
-		e.Colspan(i)
+		e = e.Colspan(i)
 	}
 	return e
 }
@@ -227,19 +286,15 @@ func (e *TdElement) IfColspan(condition bool, i int) *TdElement {
 // Number of columns that the cell is to span.
 // Remove the attribute Colspan from the element.
 func (e *TdElement) ColspanRemove() *TdElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("colspan")
+	e = e.writable()
+	e.removeAttribute("colspan")
 	return e
 }
 
 // Specifies one or more header cells a cell is related to.
 func (e *TdElement) Headers(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("headers", s)
+	e = e.writable()
+	e.setStringAttribute("headers", s)
 	return e
 }
 
@@ -251,7 +306,7 @@ func (e *TdElement) Headersf(format string, args ...any) *TdElement {
 // Specifies one or more header cells a cell is related to.
 func (e *TdElement) IfHeaders(condition bool, s string) *TdElement {
 	if condition {
-		e.Headers(s)
+		e = e.Headers(s)
 	}
 	return e
 }
@@ -259,7 +314,7 @@ func (e *TdElement) IfHeaders(condition bool, s string) *TdElement {
 // Specifies one or more header cells a cell is related to.
 func (e *TdElement) IfHeadersf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Headers(fmt.Sprintf(format, args...))
+		e = e.Headers(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -267,26 +322,22 @@ func (e *TdElement) IfHeadersf(condition bool, format string, args ...any) *TdEl
 // Specifies one or more header cells a cell is related to.
 // Remove the attribute Headers from the element.
 func (e *TdElement) HeadersRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("headers")
+	e = e.writable()
+	e.removeAttribute("headers")
 	return e
 }
 
 // Number of rows that the cell is to span.
 func (e *TdElement) Rowspan(i int) *TdElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("rowspan", i)
+	e = e.writable()
+	e.setIntAttribute("rowspan", i)
 	return e
 }
 
 // Number of rows that the cell is to span.
 func (e *TdElement) IfRowspan(condition bool, i int) *TdElement {
 	if condition {
-		e.Rowspan(i)
+		e = e.Rowspan(i)
 	}
 	return e
 }
@@ -294,10 +345,8 @@ func (e *TdElement) IfRowspan(condition bool, i int) *TdElement {
 // Number of rows that the cell is to span.
 // Remove the attribute Rowspan from the element.
 func (e *TdElement) RowspanRemove() *TdElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("rowspan")
+	e = e.writable()
+	e.removeAttribute("rowspan")
 	return e
 }
 
@@ -306,10 +355,8 @@ func (e *TdElement) RowspanRemove() *TdElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *TdElement) Accesskey(r rune) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -319,7 +366,7 @@ func (e *TdElement) Accesskey(r rune) *TdElement {
 // can be generated by the keyboard).
 func (e *TdElement) IfAccesskey(condition bool, r rune) *TdElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -330,10 +377,8 @@ func (e *TdElement) IfAccesskey(condition bool, r rune) *TdElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *TdElement) AccesskeyRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -349,10 +394,8 @@ func (e *TdElement) AccesskeyRemove() *TdElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *TdElement) Autocapitalize(c TdAutocapitalizeChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[TdAutocapitalizeChoice])
 	return e
 }
 
@@ -386,10 +429,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *TdElement) AutocapitalizeRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -408,10 +449,8 @@ func (e *TdElement) AutocapitalizeRemove() *TdElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TdElement) Autofocus() *TdElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -431,7 +470,7 @@ func (e *TdElement) Autofocus() *TdElement {
 // created by the preceding content.
 func (e *TdElement) IfAutofocus(condition bool) *TdElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -452,10 +491,8 @@ func (e *TdElement) IfAutofocus(condition bool) *TdElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TdElement) AutofocusRemove() *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -475,10 +512,8 @@ func (e *TdElement) AutofocusRemove() *TdElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TdElement) AutofocusIfRemove() *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -487,16 +522,9 @@ func (e *TdElement) AutofocusIfRemove() *TdElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *TdElement) Class(s string) *TdElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -506,7 +534,7 @@ func (e *TdElement) Class(s string) *TdElement {
 // document.getElementsByClassName.
 func (e *TdElement) IfClass(condition bool, s string) *TdElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -517,14 +545,8 @@ func (e *TdElement) IfClass(condition bool, s string) *TdElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *TdElement) ClassRemove(s ...string) *TdElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -532,10 +554,8 @@ func (e *TdElement) ClassRemove(s ...string) *TdElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *TdElement) Contenteditable(c TdContenteditableChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[TdContenteditableChoice])
 	return e
 }
 
@@ -558,10 +578,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *TdElement) ContenteditableRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -579,10 +597,8 @@ func (e *TdElement) ContenteditableRemove() *TdElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *TdElement) Dir(c TdDirChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[TdDirChoice])
 	return e
 }
 
@@ -616,10 +632,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *TdElement) DirRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -627,10 +641,8 @@ func (e *TdElement) DirRemove() *TdElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *TdElement) Draggable(c TdDraggableChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[TdDraggableChoice])
 	return e
 }
 
@@ -656,20 +668,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *TdElement) DraggableRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *TdElement) Enterkeyhint(c TdEnterkeyhintChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[TdEnterkeyhintChoice])
 	return e
 }
 
@@ -699,10 +707,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *TdElement) EnterkeyhintRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -724,16 +730,9 @@ func (e *TdElement) EnterkeyhintRemove() *TdElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *TdElement) Exportparts(s string) *TdElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -756,7 +755,7 @@ func (e *TdElement) Exportparts(s string) *TdElement {
 // the current structure.
 func (e *TdElement) IfExportparts(condition bool, s string) *TdElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -780,14 +779,8 @@ func (e *TdElement) IfExportparts(condition bool, s string) *TdElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *TdElement) ExportpartsRemove(s ...string) *TdElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -804,10 +797,8 @@ func (e *TdElement) ExportpartsRemove(s ...string) *TdElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *TdElement) Hidden(c TdHiddenChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[TdHiddenChoice])
 	return e
 }
 
@@ -843,10 +834,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *TdElement) HiddenRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -854,10 +843,8 @@ func (e *TdElement) HiddenRemove() *TdElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TdElement) ID(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -873,7 +860,7 @@ func (e *TdElement) IDf(format string, args ...any) *TdElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TdElement) IfID(condition bool, s string) *TdElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -883,7 +870,7 @@ func (e *TdElement) IfID(condition bool, s string) *TdElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TdElement) IfIDf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -893,10 +880,8 @@ func (e *TdElement) IfIDf(condition bool, format string, args ...any) *TdElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *TdElement) IDRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -911,10 +896,8 @@ func (e *TdElement) IDRemove() *TdElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TdElement) Inert() *TdElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -930,7 +913,7 @@ func (e *TdElement) Inert() *TdElement {
 // excluding them from the accessibility tree.
 func (e *TdElement) IfInert(condition bool) *TdElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -947,10 +930,8 @@ func (e *TdElement) IfInert(condition bool) *TdElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TdElement) InertRemove() *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -966,10 +947,8 @@ func (e *TdElement) InertRemove() *TdElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TdElement) InertIfRemove() *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -983,10 +962,8 @@ func (e *TdElement) InertIfRemove() *TdElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *TdElement) Inputmode(c TdInputmodeChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[TdInputmodeChoice])
 	return e
 }
 
@@ -1037,10 +1014,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *TdElement) InputmodeRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1050,10 +1025,8 @@ func (e *TdElement) InputmodeRemove() *TdElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *TdElement) Is(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1073,7 +1046,7 @@ func (e *TdElement) Isf(format string, args ...any) *TdElement {
 // and extends the element type it is being applied to.
 func (e *TdElement) IfIs(condition bool, s string) *TdElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1085,7 +1058,7 @@ func (e *TdElement) IfIs(condition bool, s string) *TdElement {
 // and extends the element type it is being applied to.
 func (e *TdElement) IfIsf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1097,10 +1070,8 @@ func (e *TdElement) IfIsf(condition bool, format string, args ...any) *TdElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *TdElement) IsRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1116,10 +1087,8 @@ func (e *TdElement) IsRemove() *TdElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *TdElement) Itemid(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1151,7 +1120,7 @@ func (e *TdElement) Itemidf(format string, args ...any) *TdElement {
 // how items with the same identifier are handled.
 func (e *TdElement) IfItemid(condition bool, s string) *TdElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1169,7 +1138,7 @@ func (e *TdElement) IfItemid(condition bool, s string) *TdElement {
 // how items with the same identifier are handled.
 func (e *TdElement) IfItemidf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1187,10 +1156,8 @@ func (e *TdElement) IfItemidf(condition bool, format string, args ...any) *TdEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *TdElement) ItemidRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1202,10 +1169,8 @@ func (e *TdElement) ItemidRemove() *TdElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *TdElement) Itemprop(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1229,7 +1194,7 @@ func (e *TdElement) Itempropf(format string, args ...any) *TdElement {
 // <track>, and <video>.
 func (e *TdElement) IfItemprop(condition bool, s string) *TdElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1243,7 +1208,7 @@ func (e *TdElement) IfItemprop(condition bool, s string) *TdElement {
 // <track>, and <video>.
 func (e *TdElement) IfItempropf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1257,10 +1222,8 @@ func (e *TdElement) IfItempropf(condition bool, format string, args ...any) *TdE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *TdElement) ItempropRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1270,10 +1233,8 @@ func (e *TdElement) ItempropRemove() *TdElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *TdElement) Itemref(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1293,7 +1254,7 @@ func (e *TdElement) Itemreff(format string, args ...any) *TdElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TdElement) IfItemref(condition bool, s string) *TdElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1305,7 +1266,7 @@ func (e *TdElement) IfItemref(condition bool, s string) *TdElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TdElement) IfItemreff(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1317,10 +1278,8 @@ func (e *TdElement) IfItemreff(condition bool, format string, args ...any) *TdEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *TdElement) ItemrefRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1332,10 +1291,8 @@ func (e *TdElement) ItemrefRemove() *TdElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TdElement) Itemscope() *TdElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1348,7 +1305,7 @@ func (e *TdElement) Itemscope() *TdElement {
 // <object>, <source>, <track>, and <video>.
 func (e *TdElement) IfItemscope(condition bool) *TdElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1362,10 +1319,8 @@ func (e *TdElement) IfItemscope(condition bool) *TdElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TdElement) ItemscopeRemove() *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1378,10 +1333,8 @@ func (e *TdElement) ItemscopeRemove() *TdElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TdElement) ItemscopeIfRemove() *TdElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1393,10 +1346,8 @@ func (e *TdElement) ItemscopeIfRemove() *TdElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *TdElement) Itemtype(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1420,7 +1371,7 @@ func (e *TdElement) Itemtypef(format string, args ...any) *TdElement {
 // <video>.
 func (e *TdElement) IfItemtype(condition bool, s string) *TdElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1434,7 +1385,7 @@ func (e *TdElement) IfItemtype(condition bool, s string) *TdElement {
 // <video>.
 func (e *TdElement) IfItemtypef(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1448,10 +1399,8 @@ func (e *TdElement) IfItemtypef(condition bool, format string, args ...any) *TdE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *TdElement) ItemtypeRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1461,10 +1410,8 @@ func (e *TdElement) ItemtypeRemove() *TdElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TdElement) Lang(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1484,7 +1431,7 @@ func (e *TdElement) Langf(format string, args ...any) *TdElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TdElement) IfLang(condition bool, s string) *TdElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1496,7 +1443,7 @@ func (e *TdElement) IfLang(condition bool, s string) *TdElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TdElement) IfLangf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1508,10 +1455,8 @@ func (e *TdElement) IfLangf(condition bool, format string, args ...any) *TdEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *TdElement) LangRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1521,10 +1466,8 @@ func (e *TdElement) LangRemove() *TdElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *TdElement) Nonce(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1544,7 +1487,7 @@ func (e *TdElement) Noncef(format string, args ...any) *TdElement {
 // execute.
 func (e *TdElement) IfNonce(condition bool, s string) *TdElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1556,7 +1499,7 @@ func (e *TdElement) IfNonce(condition bool, s string) *TdElement {
 // execute.
 func (e *TdElement) IfNoncef(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1568,10 +1511,8 @@ func (e *TdElement) IfNoncef(condition bool, format string, args ...any) *TdElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *TdElement) NonceRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1579,16 +1520,9 @@ func (e *TdElement) NonceRemove() *TdElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *TdElement) Part(s string) *TdElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1597,7 +1531,7 @@ func (e *TdElement) Part(s string) *TdElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *TdElement) IfPart(condition bool, s string) *TdElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1607,14 +1541,8 @@ func (e *TdElement) IfPart(condition bool, s string) *TdElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *TdElement) PartRemove(s ...string) *TdElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1625,10 +1553,8 @@ func (e *TdElement) PartRemove(s ...string) *TdElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *TdElement) Popover(c TdPopoverChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[TdPopoverChoice])
 	return e
 }
 
@@ -1656,10 +1582,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *TdElement) PopoverRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1668,10 +1592,8 @@ func (e *TdElement) PopoverRemove() *TdElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *TdElement) Role(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1689,7 +1611,7 @@ func (e *TdElement) Rolef(format string, args ...any) *TdElement {
 // role of an element.
 func (e *TdElement) IfRole(condition bool, s string) *TdElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1700,7 +1622,7 @@ func (e *TdElement) IfRole(condition bool, s string) *TdElement {
 // role of an element.
 func (e *TdElement) IfRolef(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1711,10 +1633,8 @@ func (e *TdElement) IfRolef(condition bool, format string, args ...any) *TdEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *TdElement) RoleRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1723,10 +1643,8 @@ func (e *TdElement) RoleRemove() *TdElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *TdElement) Slot(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1744,7 +1662,7 @@ func (e *TdElement) Slotf(format string, args ...any) *TdElement {
 // value.
 func (e *TdElement) IfSlot(condition bool, s string) *TdElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1755,7 +1673,7 @@ func (e *TdElement) IfSlot(condition bool, s string) *TdElement {
 // value.
 func (e *TdElement) IfSlotf(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1766,10 +1684,8 @@ func (e *TdElement) IfSlotf(condition bool, format string, args ...any) *TdEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *TdElement) SlotRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1785,10 +1701,8 @@ func (e *TdElement) SlotRemove() *TdElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *TdElement) Spellcheck(c TdSpellcheckChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[TdSpellcheckChoice])
 	return e
 }
 
@@ -1816,10 +1730,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *TdElement) SpellcheckRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1829,14 +1741,8 @@ func (e *TdElement) StylePairs(pairs ...string) *TdElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1851,14 +1757,8 @@ func (e *TdElement) StylePairs(pairs ...string) *TdElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TdElement) Style(s string) *TdElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1866,7 +1766,7 @@ func (e *TdElement) Style(s string) *TdElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1875,7 +1775,7 @@ func (e *TdElement) Style(s string) *TdElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TdElement) IfStyle(condition bool, s string) *TdElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1883,15 +1783,7 @@ func (e *TdElement) IfStyle(condition bool, s string) *TdElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TdElement) StyleAdd(k string, v string) *TdElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1905,7 +1797,7 @@ func (e *TdElement) StyleAddf(k string, format string, args ...any) *TdElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TdElement) IfStyleAdd(condition bool, k string, v string) *TdElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1914,7 +1806,7 @@ func (e *TdElement) IfStyleAdd(condition bool, k string, v string) *TdElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TdElement) IfStyleAddf(condition bool, k string, format string, args ...any) *TdElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1923,21 +1815,15 @@ func (e *TdElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *TdElement) StyleMap(m map[string]string) *TdElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1946,14 +1832,8 @@ func (e *TdElement) StyleMap(m map[string]string) *TdElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *TdElement) StyleRemove(keys ...string) *TdElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1971,10 +1851,8 @@ func (e *TdElement) StyleRemove(keys ...string) *TdElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *TdElement) Tabindex(i int) *TdElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1993,7 +1871,7 @@ func (e *TdElement) Tabindex(i int) *TdElement {
 // their relative position in the document.
 func (e *TdElement) IfTabindex(condition bool, i int) *TdElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2013,10 +1891,8 @@ func (e *TdElement) IfTabindex(condition bool, i int) *TdElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *TdElement) TabindexRemove() *TdElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2037,10 +1913,8 @@ func (e *TdElement) TabindexRemove() *TdElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *TdElement) Title(s string) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2082,7 +1956,7 @@ func (e *TdElement) Titlef(format string, args ...any) *TdElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TdElement) IfTitle(condition bool, s string) *TdElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2105,7 +1979,7 @@ func (e *TdElement) IfTitle(condition bool, s string) *TdElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TdElement) IfTitlef(condition bool, format string, args ...any) *TdElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2128,10 +2002,8 @@ func (e *TdElement) IfTitlef(condition bool, format string, args ...any) *TdElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *TdElement) TitleRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2140,10 +2012,8 @@ func (e *TdElement) TitleRemove() *TdElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *TdElement) Translate(c TdTranslateChoice) *TdElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[TdTranslateChoice])
 	return e
 }
 
@@ -2164,9 +2034,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *TdElement) TranslateRemove() *TdElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
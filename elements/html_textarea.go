@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <textarea> element represents a multi-line plain-text editing
@@ -23,18 +21,81 @@ type TextareaElement struct {
 // with the tag "textarea" during rendering.
 func Textarea(children ...ElementRenderer) *TextareaElement {
 	e := NewElement("textarea", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &TextareaElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *TextareaElement) Clone() *TextareaElement {
+	return &TextareaElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *TextareaElement) CloneShallow() *TextareaElement {
+	return &TextareaElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *TextareaElement) Freeze() *TextareaElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *TextareaElement) writable() *TextareaElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *TextareaElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *TextareaElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[TextareaAutocapitalizeChoice]
+	case "autocomplete":
+		return choiceValue[TextareaAutocompleteChoice]
+	case "spellcheck":
+		return choiceValue[TextareaSpellcheckChoice]
+	case "wrap":
+		return choiceValue[TextareaWrapChoice]
+	case "contenteditable":
+		return choiceValue[TextareaContenteditableChoice]
+	case "dir":
+		return choiceValue[TextareaDirChoice]
+	case "draggable":
+		return choiceValue[TextareaDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[TextareaEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[TextareaHiddenChoice]
+	case "inputmode":
+		return choiceValue[TextareaInputmodeChoice]
+	case "popover":
+		return choiceValue[TextareaPopoverChoice]
+	case "translate":
+		return choiceValue[TextareaTranslateChoice]
+	}
+	return nil
+}
+
 func (e *TextareaElement) Children(children ...ElementRenderer) *TextareaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *TextareaElement) IfChildren(condition bool, children ...ElementRenderer) *TextareaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +103,30 @@ func (e *TextareaElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *TextareaElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *TextareaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *TextareaElement) BoolAttr(name string) *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *TextareaElement) BoolAttrRemove(name string) *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *TextareaElement) IfBoolAttr(condition bool, name string) *TextareaElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +137,40 @@ func (e *TextareaElement) BoolAttrf(format string, args ...any) *TextareaElement
 
 func (e *TextareaElement) IfBoolAttrf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *TextareaElement) BoolAttrs(names ...string) *TextareaElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *TextareaElement) IfBoolAttrs(condition bool, names ...string) *TextareaElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *TextareaElement) Attr(name, value string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *TextareaElement) RawAttr(name, value string) *TextareaElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *TextareaElement) IfAttr(condition bool, name, value string) *TextareaElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +181,52 @@ func (e *TextareaElement) Attrf(name, format string, args ...any) *TextareaEleme
 
 func (e *TextareaElement) IfAttrf(condition bool, name, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *TextareaElement) Attrs(attrs ...string) *TextareaElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *TextareaElement) IfAttrs(condition bool, attrs ...string) *TextareaElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *TextareaElement) AttrsMap(attrs map[string]string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *TextareaElement) IfAttrsMap(condition bool, attrs map[string]string) *TextareaElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *TextareaElement) Text(text string) *TextareaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *TextareaElement) Textf(format string, args ...any) *TextareaElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *TextareaElement) IfText(condition bool, text string) *TextareaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +234,28 @@ func (e *TextareaElement) IfText(condition bool, text string) *TextareaElement {
 
 func (e *TextareaElement) IfTextf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *TextareaElement) Raw(text string) *TextareaElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *TextareaElement) Escaped(text string) *TextareaElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *TextareaElement) IfEscaped(condition bool, text string) *TextareaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +267,7 @@ func (e *TextareaElement) Escapedf(format string, args ...any) *TextareaElement
 
 func (e *TextareaElement) IfEscapedf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +276,8 @@ func (e *TextareaElement) IfEscapedf(condition bool, format string, args ...any)
 // Controls whether and how text input is automatically capitalized as it is
 // entered/edited by the user.
 func (e *TextareaElement) Autocapitalize(c TextareaAutocapitalizeChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[TextareaAutocapitalizeChoice])
 	return e
 }
 
@@ -243,19 +306,15 @@ const (
 // entered/edited by the user.
 // Remove the attribute Autocapitalize from the element.
 func (e *TextareaElement) AutocapitalizeRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
 // Hint for form autofill feature.
 func (e *TextareaElement) Autocomplete(c TextareaAutocompleteChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocomplete", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocomplete", string(c), choiceValue[TextareaAutocompleteChoice])
 	return e
 }
 
@@ -271,26 +330,22 @@ const (
 // Hint for form autofill feature.
 // Remove the attribute Autocomplete from the element.
 func (e *TextareaElement) AutocompleteRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocomplete")
+	e = e.writable()
+	e.removeAttribute("autocomplete")
 	return e
 }
 
 // Automatically focus the form control when the page is loaded.
 func (e *TextareaElement) Autofocus() *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
 // Automatically focus the form control when the page is loaded.
 func (e *TextareaElement) IfAutofocus(condition bool) *TextareaElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -298,36 +353,30 @@ func (e *TextareaElement) IfAutofocus(condition bool) *TextareaElement {
 // Remove the attribute Autofocus from the element.
 // Automatically focus the form control when the page is loaded.
 func (e *TextareaElement) AutofocusRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
 // Remove the attribute Autofocus from the element.
 // Automatically focus the form control when the page is loaded.
 func (e *TextareaElement) AutofocusIfRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
 // Visible width of the text control, in average character widths.
 func (e *TextareaElement) Cols(i int) *TextareaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("cols", i)
+	e = e.writable()
+	e.setIntAttribute("cols", i)
 	return e
 }
 
 // Visible width of the text control, in average character widths.
 func (e *TextareaElement) IfCols(condition bool, i int) *TextareaElement {
 	if condition {
-		e.Cols(i)
+		e = e.Cols(i)
 	}
 	return e
 }
@@ -335,20 +384,16 @@ func (e *TextareaElement) IfCols(condition bool, i int) *TextareaElement {
 // Visible width of the text control, in average character widths.
 // Remove the attribute Cols from the element.
 func (e *TextareaElement) ColsRemove() *TextareaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("cols")
+	e = e.writable()
+	e.removeAttribute("cols")
 	return e
 }
 
 // Name of form field to use for sending the element's directionality in form
 // submission.
 func (e *TextareaElement) Dirname(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dirname", s)
+	e = e.writable()
+	e.setStringAttribute("dirname", s)
 	return e
 }
 
@@ -362,7 +407,7 @@ func (e *TextareaElement) Dirnamef(format string, args ...any) *TextareaElement
 // submission.
 func (e *TextareaElement) IfDirname(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Dirname(s)
+		e = e.Dirname(s)
 	}
 	return e
 }
@@ -371,7 +416,7 @@ func (e *TextareaElement) IfDirname(condition bool, s string) *TextareaElement {
 // submission.
 func (e *TextareaElement) IfDirnamef(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Dirname(fmt.Sprintf(format, args...))
+		e = e.Dirname(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -380,26 +425,22 @@ func (e *TextareaElement) IfDirnamef(condition bool, format string, args ...any)
 // submission.
 // Remove the attribute Dirname from the element.
 func (e *TextareaElement) DirnameRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dirname")
+	e = e.writable()
+	e.removeAttribute("dirname")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *TextareaElement) Disabled() *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("disabled", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("disabled")
 	return e
 }
 
 // Whether the form control is disabled.
 func (e *TextareaElement) IfDisabled(condition bool) *TextareaElement {
 	if condition {
-		e.Disabled()
+		e = e.Disabled()
 	}
 	return e
 }
@@ -407,29 +448,23 @@ func (e *TextareaElement) IfDisabled(condition bool) *TextareaElement {
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *TextareaElement) DisabledRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Remove the attribute Disabled from the element.
 // Whether the form control is disabled.
 func (e *TextareaElement) DisabledIfRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("disabled")
+	e = e.writable()
+	e.removeAttribute("disabled")
 	return e
 }
 
 // Associates the control with a form element.
 func (e *TextareaElement) Form(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("form", s)
+	e = e.writable()
+	e.setStringAttribute("form", s)
 	return e
 }
 
@@ -441,7 +476,7 @@ func (e *TextareaElement) Formf(format string, args ...any) *TextareaElement {
 // Associates the control with a form element.
 func (e *TextareaElement) IfForm(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Form(s)
+		e = e.Form(s)
 	}
 	return e
 }
@@ -449,7 +484,7 @@ func (e *TextareaElement) IfForm(condition bool, s string) *TextareaElement {
 // Associates the control with a form element.
 func (e *TextareaElement) IfFormf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Form(fmt.Sprintf(format, args...))
+		e = e.Form(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -457,26 +492,22 @@ func (e *TextareaElement) IfFormf(condition bool, format string, args ...any) *T
 // Associates the control with a form element.
 // Remove the attribute Form from the element.
 func (e *TextareaElement) FormRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("form")
+	e = e.writable()
+	e.removeAttribute("form")
 	return e
 }
 
 // Maximum length of value.
 func (e *TextareaElement) Maxlength(i int) *TextareaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("maxlength", i)
+	e = e.writable()
+	e.setIntAttribute("maxlength", i)
 	return e
 }
 
 // Maximum length of value.
 func (e *TextareaElement) IfMaxlength(condition bool, i int) *TextareaElement {
 	if condition {
-		e.Maxlength(i)
+		e = e.Maxlength(i)
 	}
 	return e
 }
@@ -484,26 +515,22 @@ func (e *TextareaElement) IfMaxlength(condition bool, i int) *TextareaElement {
 // Maximum length of value.
 // Remove the attribute Maxlength from the element.
 func (e *TextareaElement) MaxlengthRemove() *TextareaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("maxlength")
+	e = e.writable()
+	e.removeAttribute("maxlength")
 	return e
 }
 
 // Minimum length of value.
 func (e *TextareaElement) Minlength(i int) *TextareaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("minlength", i)
+	e = e.writable()
+	e.setIntAttribute("minlength", i)
 	return e
 }
 
 // Minimum length of value.
 func (e *TextareaElement) IfMinlength(condition bool, i int) *TextareaElement {
 	if condition {
-		e.Minlength(i)
+		e = e.Minlength(i)
 	}
 	return e
 }
@@ -511,19 +538,15 @@ func (e *TextareaElement) IfMinlength(condition bool, i int) *TextareaElement {
 // Minimum length of value.
 // Remove the attribute Minlength from the element.
 func (e *TextareaElement) MinlengthRemove() *TextareaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("minlength")
+	e = e.writable()
+	e.removeAttribute("minlength")
 	return e
 }
 
 // Name of the element to use for form submission and in the form.elements API.
 func (e *TextareaElement) Name(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -535,7 +558,7 @@ func (e *TextareaElement) Namef(format string, args ...any) *TextareaElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *TextareaElement) IfName(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -543,7 +566,7 @@ func (e *TextareaElement) IfName(condition bool, s string) *TextareaElement {
 // Name of the element to use for form submission and in the form.elements API.
 func (e *TextareaElement) IfNamef(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -551,19 +574,15 @@ func (e *TextareaElement) IfNamef(condition bool, format string, args ...any) *T
 // Name of the element to use for form submission and in the form.elements API.
 // Remove the attribute Name from the element.
 func (e *TextareaElement) NameRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
 // User-visible label to give the user an idea of what to type.
 func (e *TextareaElement) Placeholder(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("placeholder", s)
+	e = e.writable()
+	e.setStringAttribute("placeholder", s)
 	return e
 }
 
@@ -575,7 +594,7 @@ func (e *TextareaElement) Placeholderf(format string, args ...any) *TextareaElem
 // User-visible label to give the user an idea of what to type.
 func (e *TextareaElement) IfPlaceholder(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Placeholder(s)
+		e = e.Placeholder(s)
 	}
 	return e
 }
@@ -583,7 +602,7 @@ func (e *TextareaElement) IfPlaceholder(condition bool, s string) *TextareaEleme
 // User-visible label to give the user an idea of what to type.
 func (e *TextareaElement) IfPlaceholderf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Placeholder(fmt.Sprintf(format, args...))
+		e = e.Placeholder(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -591,26 +610,22 @@ func (e *TextareaElement) IfPlaceholderf(condition bool, format string, args ...
 // User-visible label to give the user an idea of what to type.
 // Remove the attribute Placeholder from the element.
 func (e *TextareaElement) PlaceholderRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("placeholder")
+	e = e.writable()
+	e.removeAttribute("placeholder")
 	return e
 }
 
 // Whether to allow the value to be edited by the user.
 func (e *TextareaElement) Readonly() *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("readonly", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("readonly")
 	return e
 }
 
 // Whether to allow the value to be edited by the user.
 func (e *TextareaElement) IfReadonly(condition bool) *TextareaElement {
 	if condition {
-		e.Readonly()
+		e = e.Readonly()
 	}
 	return e
 }
@@ -618,36 +633,30 @@ func (e *TextareaElement) IfReadonly(condition bool) *TextareaElement {
 // Remove the attribute Readonly from the element.
 // Whether to allow the value to be edited by the user.
 func (e *TextareaElement) ReadonlyRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("readonly")
+	e = e.writable()
+	e.removeAttribute("readonly")
 	return e
 }
 
 // Remove the attribute Readonly from the element.
 // Whether to allow the value to be edited by the user.
 func (e *TextareaElement) ReadonlyIfRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("readonly")
+	e = e.writable()
+	e.removeAttribute("readonly")
 	return e
 }
 
 // Whether the control is required for form submission.
 func (e *TextareaElement) Required() *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("required", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("required")
 	return e
 }
 
 // Whether the control is required for form submission.
 func (e *TextareaElement) IfRequired(condition bool) *TextareaElement {
 	if condition {
-		e.Required()
+		e = e.Required()
 	}
 	return e
 }
@@ -655,36 +664,30 @@ func (e *TextareaElement) IfRequired(condition bool) *TextareaElement {
 // Remove the attribute Required from the element.
 // Whether the control is required for form submission.
 func (e *TextareaElement) RequiredRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("required")
+	e = e.writable()
+	e.removeAttribute("required")
 	return e
 }
 
 // Remove the attribute Required from the element.
 // Whether the control is required for form submission.
 func (e *TextareaElement) RequiredIfRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("required")
+	e = e.writable()
+	e.removeAttribute("required")
 	return e
 }
 
 // Visible number of lines in the control.
 func (e *TextareaElement) Rows(i int) *TextareaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("rows", i)
+	e = e.writable()
+	e.setIntAttribute("rows", i)
 	return e
 }
 
 // Visible number of lines in the control.
 func (e *TextareaElement) IfRows(condition bool, i int) *TextareaElement {
 	if condition {
-		e.Rows(i)
+		e = e.Rows(i)
 	}
 	return e
 }
@@ -692,19 +695,15 @@ func (e *TextareaElement) IfRows(condition bool, i int) *TextareaElement {
 // Visible number of lines in the control.
 // Remove the attribute Rows from the element.
 func (e *TextareaElement) RowsRemove() *TextareaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("rows")
+	e = e.writable()
+	e.removeAttribute("rows")
 	return e
 }
 
 // Whether the element is to have its spelling and grammar checked.
 func (e *TextareaElement) Spellcheck(c TextareaSpellcheckChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[TextareaSpellcheckChoice])
 	return e
 }
 
@@ -723,19 +722,15 @@ const (
 // Whether the element is to have its spelling and grammar checked.
 // Remove the attribute Spellcheck from the element.
 func (e *TextareaElement) SpellcheckRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
 // How the value of the form control is to be wrapped for form submission.
 func (e *TextareaElement) Wrap(c TextareaWrapChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("wrap", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("wrap", string(c), choiceValue[TextareaWrapChoice])
 	return e
 }
 
@@ -753,10 +748,8 @@ const (
 // How the value of the form control is to be wrapped for form submission.
 // Remove the attribute Wrap from the element.
 func (e *TextareaElement) WrapRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("wrap")
+	e = e.writable()
+	e.removeAttribute("wrap")
 	return e
 }
 
@@ -765,10 +758,8 @@ func (e *TextareaElement) WrapRemove() *TextareaElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *TextareaElement) Accesskey(r rune) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -778,7 +769,7 @@ func (e *TextareaElement) Accesskey(r rune) *TextareaElement {
 // can be generated by the keyboard).
 func (e *TextareaElement) IfAccesskey(condition bool, r rune) *TextareaElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -789,10 +780,8 @@ func (e *TextareaElement) IfAccesskey(condition bool, r rune) *TextareaElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *TextareaElement) AccesskeyRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -801,16 +790,9 @@ func (e *TextareaElement) AccesskeyRemove() *TextareaElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *TextareaElement) Class(s string) *TextareaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -820,7 +802,7 @@ func (e *TextareaElement) Class(s string) *TextareaElement {
 // document.getElementsByClassName.
 func (e *TextareaElement) IfClass(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -831,14 +813,8 @@ func (e *TextareaElement) IfClass(condition bool, s string) *TextareaElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *TextareaElement) ClassRemove(s ...string) *TextareaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -846,10 +822,8 @@ func (e *TextareaElement) ClassRemove(s ...string) *TextareaElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *TextareaElement) Contenteditable(c TextareaContenteditableChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[TextareaContenteditableChoice])
 	return e
 }
 
@@ -872,10 +846,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *TextareaElement) ContenteditableRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -893,10 +865,8 @@ func (e *TextareaElement) ContenteditableRemove() *TextareaElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *TextareaElement) Dir(c TextareaDirChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[TextareaDirChoice])
 	return e
 }
 
@@ -930,10 +900,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *TextareaElement) DirRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -941,10 +909,8 @@ func (e *TextareaElement) DirRemove() *TextareaElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *TextareaElement) Draggable(c TextareaDraggableChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[TextareaDraggableChoice])
 	return e
 }
 
@@ -970,20 +936,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *TextareaElement) DraggableRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *TextareaElement) Enterkeyhint(c TextareaEnterkeyhintChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[TextareaEnterkeyhintChoice])
 	return e
 }
 
@@ -1013,10 +975,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *TextareaElement) EnterkeyhintRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1038,16 +998,9 @@ func (e *TextareaElement) EnterkeyhintRemove() *TextareaElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *TextareaElement) Exportparts(s string) *TextareaElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1070,7 +1023,7 @@ func (e *TextareaElement) Exportparts(s string) *TextareaElement {
 // the current structure.
 func (e *TextareaElement) IfExportparts(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1094,14 +1047,8 @@ func (e *TextareaElement) IfExportparts(condition bool, s string) *TextareaEleme
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *TextareaElement) ExportpartsRemove(s ...string) *TextareaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1118,10 +1065,8 @@ func (e *TextareaElement) ExportpartsRemove(s ...string) *TextareaElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *TextareaElement) Hidden(c TextareaHiddenChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[TextareaHiddenChoice])
 	return e
 }
 
@@ -1157,10 +1102,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *TextareaElement) HiddenRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1168,10 +1111,8 @@ func (e *TextareaElement) HiddenRemove() *TextareaElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TextareaElement) ID(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1187,7 +1128,7 @@ func (e *TextareaElement) IDf(format string, args ...any) *TextareaElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TextareaElement) IfID(condition bool, s string) *TextareaElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1197,7 +1138,7 @@ func (e *TextareaElement) IfID(condition bool, s string) *TextareaElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TextareaElement) IfIDf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1207,10 +1148,8 @@ func (e *TextareaElement) IfIDf(condition bool, format string, args ...any) *Tex
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *TextareaElement) IDRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1225,10 +1164,8 @@ func (e *TextareaElement) IDRemove() *TextareaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TextareaElement) Inert() *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1244,7 +1181,7 @@ func (e *TextareaElement) Inert() *TextareaElement {
 // excluding them from the accessibility tree.
 func (e *TextareaElement) IfInert(condition bool) *TextareaElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1261,10 +1198,8 @@ func (e *TextareaElement) IfInert(condition bool) *TextareaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TextareaElement) InertRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1280,10 +1215,8 @@ func (e *TextareaElement) InertRemove() *TextareaElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TextareaElement) InertIfRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1297,10 +1230,8 @@ func (e *TextareaElement) InertIfRemove() *TextareaElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *TextareaElement) Inputmode(c TextareaInputmodeChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[TextareaInputmodeChoice])
 	return e
 }
 
@@ -1351,10 +1282,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *TextareaElement) InputmodeRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1364,10 +1293,8 @@ func (e *TextareaElement) InputmodeRemove() *TextareaElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *TextareaElement) Is(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1387,7 +1314,7 @@ func (e *TextareaElement) Isf(format string, args ...any) *TextareaElement {
 // and extends the element type it is being applied to.
 func (e *TextareaElement) IfIs(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1399,7 +1326,7 @@ func (e *TextareaElement) IfIs(condition bool, s string) *TextareaElement {
 // and extends the element type it is being applied to.
 func (e *TextareaElement) IfIsf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1411,10 +1338,8 @@ func (e *TextareaElement) IfIsf(condition bool, format string, args ...any) *Tex
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *TextareaElement) IsRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1430,10 +1355,8 @@ func (e *TextareaElement) IsRemove() *TextareaElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *TextareaElement) Itemid(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1465,7 +1388,7 @@ func (e *TextareaElement) Itemidf(format string, args ...any) *TextareaElement {
 // how items with the same identifier are handled.
 func (e *TextareaElement) IfItemid(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1483,7 +1406,7 @@ func (e *TextareaElement) IfItemid(condition bool, s string) *TextareaElement {
 // how items with the same identifier are handled.
 func (e *TextareaElement) IfItemidf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1501,10 +1424,8 @@ func (e *TextareaElement) IfItemidf(condition bool, format string, args ...any)
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *TextareaElement) ItemidRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1516,10 +1437,8 @@ func (e *TextareaElement) ItemidRemove() *TextareaElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *TextareaElement) Itemprop(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1543,7 +1462,7 @@ func (e *TextareaElement) Itempropf(format string, args ...any) *TextareaElement
 // <track>, and <video>.
 func (e *TextareaElement) IfItemprop(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1557,7 +1476,7 @@ func (e *TextareaElement) IfItemprop(condition bool, s string) *TextareaElement
 // <track>, and <video>.
 func (e *TextareaElement) IfItempropf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1571,10 +1490,8 @@ func (e *TextareaElement) IfItempropf(condition bool, format string, args ...any
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *TextareaElement) ItempropRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1584,10 +1501,8 @@ func (e *TextareaElement) ItempropRemove() *TextareaElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *TextareaElement) Itemref(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1607,7 +1522,7 @@ func (e *TextareaElement) Itemreff(format string, args ...any) *TextareaElement
 // specified on elements that have an itemscope attribute specified.
 func (e *TextareaElement) IfItemref(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1619,7 +1534,7 @@ func (e *TextareaElement) IfItemref(condition bool, s string) *TextareaElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TextareaElement) IfItemreff(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1631,10 +1546,8 @@ func (e *TextareaElement) IfItemreff(condition bool, format string, args ...any)
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *TextareaElement) ItemrefRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1646,10 +1559,8 @@ func (e *TextareaElement) ItemrefRemove() *TextareaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TextareaElement) Itemscope() *TextareaElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1662,7 +1573,7 @@ func (e *TextareaElement) Itemscope() *TextareaElement {
 // <object>, <source>, <track>, and <video>.
 func (e *TextareaElement) IfItemscope(condition bool) *TextareaElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1676,10 +1587,8 @@ func (e *TextareaElement) IfItemscope(condition bool) *TextareaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TextareaElement) ItemscopeRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1692,10 +1601,8 @@ func (e *TextareaElement) ItemscopeRemove() *TextareaElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TextareaElement) ItemscopeIfRemove() *TextareaElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1707,10 +1614,8 @@ func (e *TextareaElement) ItemscopeIfRemove() *TextareaElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *TextareaElement) Itemtype(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1734,7 +1639,7 @@ func (e *TextareaElement) Itemtypef(format string, args ...any) *TextareaElement
 // <video>.
 func (e *TextareaElement) IfItemtype(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1748,7 +1653,7 @@ func (e *TextareaElement) IfItemtype(condition bool, s string) *TextareaElement
 // <video>.
 func (e *TextareaElement) IfItemtypef(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1762,10 +1667,8 @@ func (e *TextareaElement) IfItemtypef(condition bool, format string, args ...any
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *TextareaElement) ItemtypeRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1775,10 +1678,8 @@ func (e *TextareaElement) ItemtypeRemove() *TextareaElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TextareaElement) Lang(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1798,7 +1699,7 @@ func (e *TextareaElement) Langf(format string, args ...any) *TextareaElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TextareaElement) IfLang(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1810,7 +1711,7 @@ func (e *TextareaElement) IfLang(condition bool, s string) *TextareaElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TextareaElement) IfLangf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1822,10 +1723,8 @@ func (e *TextareaElement) IfLangf(condition bool, format string, args ...any) *T
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *TextareaElement) LangRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1835,10 +1734,8 @@ func (e *TextareaElement) LangRemove() *TextareaElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *TextareaElement) Nonce(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1858,7 +1755,7 @@ func (e *TextareaElement) Noncef(format string, args ...any) *TextareaElement {
 // execute.
 func (e *TextareaElement) IfNonce(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1870,7 +1767,7 @@ func (e *TextareaElement) IfNonce(condition bool, s string) *TextareaElement {
 // execute.
 func (e *TextareaElement) IfNoncef(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1882,10 +1779,8 @@ func (e *TextareaElement) IfNoncef(condition bool, format string, args ...any) *
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *TextareaElement) NonceRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1893,16 +1788,9 @@ func (e *TextareaElement) NonceRemove() *TextareaElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *TextareaElement) Part(s string) *TextareaElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1911,7 +1799,7 @@ func (e *TextareaElement) Part(s string) *TextareaElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *TextareaElement) IfPart(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1921,14 +1809,8 @@ func (e *TextareaElement) IfPart(condition bool, s string) *TextareaElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *TextareaElement) PartRemove(s ...string) *TextareaElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1939,10 +1821,8 @@ func (e *TextareaElement) PartRemove(s ...string) *TextareaElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *TextareaElement) Popover(c TextareaPopoverChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[TextareaPopoverChoice])
 	return e
 }
 
@@ -1970,10 +1850,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *TextareaElement) PopoverRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1982,10 +1860,8 @@ func (e *TextareaElement) PopoverRemove() *TextareaElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *TextareaElement) Role(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -2003,7 +1879,7 @@ func (e *TextareaElement) Rolef(format string, args ...any) *TextareaElement {
 // role of an element.
 func (e *TextareaElement) IfRole(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -2014,7 +1890,7 @@ func (e *TextareaElement) IfRole(condition bool, s string) *TextareaElement {
 // role of an element.
 func (e *TextareaElement) IfRolef(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2025,10 +1901,8 @@ func (e *TextareaElement) IfRolef(condition bool, format string, args ...any) *T
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *TextareaElement) RoleRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2037,10 +1911,8 @@ func (e *TextareaElement) RoleRemove() *TextareaElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *TextareaElement) Slot(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2058,7 +1930,7 @@ func (e *TextareaElement) Slotf(format string, args ...any) *TextareaElement {
 // value.
 func (e *TextareaElement) IfSlot(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2069,7 +1941,7 @@ func (e *TextareaElement) IfSlot(condition bool, s string) *TextareaElement {
 // value.
 func (e *TextareaElement) IfSlotf(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2080,10 +1952,8 @@ func (e *TextareaElement) IfSlotf(condition bool, format string, args ...any) *T
 // value.
 // Remove the attribute Slot from the element.
 func (e *TextareaElement) SlotRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2093,14 +1963,8 @@ func (e *TextareaElement) StylePairs(pairs ...string) *TextareaElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2115,14 +1979,8 @@ func (e *TextareaElement) StylePairs(pairs ...string) *TextareaElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TextareaElement) Style(s string) *TextareaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2130,7 +1988,7 @@ func (e *TextareaElement) Style(s string) *TextareaElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2139,7 +1997,7 @@ func (e *TextareaElement) Style(s string) *TextareaElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TextareaElement) IfStyle(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2147,15 +2005,7 @@ func (e *TextareaElement) IfStyle(condition bool, s string) *TextareaElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TextareaElement) StyleAdd(k string, v string) *TextareaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2169,7 +2019,7 @@ func (e *TextareaElement) StyleAddf(k string, format string, args ...any) *Texta
 // color, font, size, and more. Styles are written in CSS.
 func (e *TextareaElement) IfStyleAdd(condition bool, k string, v string) *TextareaElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2178,7 +2028,7 @@ func (e *TextareaElement) IfStyleAdd(condition bool, k string, v string) *Textar
 // color, font, size, and more. Styles are written in CSS.
 func (e *TextareaElement) IfStyleAddf(condition bool, k string, format string, args ...any) *TextareaElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2187,21 +2037,15 @@ func (e *TextareaElement) IfStyleAddf(condition bool, k string, format string, a
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *TextareaElement) StyleMap(m map[string]string) *TextareaElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2210,14 +2054,8 @@ func (e *TextareaElement) StyleMap(m map[string]string) *TextareaElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *TextareaElement) StyleRemove(keys ...string) *TextareaElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2235,10 +2073,8 @@ func (e *TextareaElement) StyleRemove(keys ...string) *TextareaElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *TextareaElement) Tabindex(i int) *TextareaElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2257,7 +2093,7 @@ func (e *TextareaElement) Tabindex(i int) *TextareaElement {
 // their relative position in the document.
 func (e *TextareaElement) IfTabindex(condition bool, i int) *TextareaElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2277,10 +2113,8 @@ func (e *TextareaElement) IfTabindex(condition bool, i int) *TextareaElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *TextareaElement) TabindexRemove() *TextareaElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2301,10 +2135,8 @@ func (e *TextareaElement) TabindexRemove() *TextareaElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *TextareaElement) Title(s string) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2346,7 +2178,7 @@ func (e *TextareaElement) Titlef(format string, args ...any) *TextareaElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TextareaElement) IfTitle(condition bool, s string) *TextareaElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2369,7 +2201,7 @@ func (e *TextareaElement) IfTitle(condition bool, s string) *TextareaElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TextareaElement) IfTitlef(condition bool, format string, args ...any) *TextareaElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2392,10 +2224,8 @@ func (e *TextareaElement) IfTitlef(condition bool, format string, args ...any) *
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *TextareaElement) TitleRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2404,10 +2234,8 @@ func (e *TextareaElement) TitleRemove() *TextareaElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *TextareaElement) Translate(c TextareaTranslateChoice) *TextareaElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[TextareaTranslateChoice])
 	return e
 }
 
@@ -2428,9 +2256,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *TextareaElement) TranslateRemove() *TextareaElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
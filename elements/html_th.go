@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <th> element defines a cell as header of a group of table cells. The
@@ -22,18 +20,79 @@ type ThElement struct {
 // with the tag "th" during rendering.
 func Th(children ...ElementRenderer) *ThElement {
 	e := NewElement("th", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &ThElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *ThElement) Clone() *ThElement {
+	return &ThElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *ThElement) CloneShallow() *ThElement {
+	return &ThElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *ThElement) Freeze() *ThElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *ThElement) writable() *ThElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *ThElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *ThElement) choiceType(name string) func(string) any {
+	switch name {
+	case "scope":
+		return choiceValue[ThScopeChoice]
+	case "autocapitalize":
+		return choiceValue[ThAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[ThContenteditableChoice]
+	case "dir":
+		return choiceValue[ThDirChoice]
+	case "draggable":
+		return choiceValue[ThDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[ThEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[ThHiddenChoice]
+	case "inputmode":
+		return choiceValue[ThInputmodeChoice]
+	case "popover":
+		return choiceValue[ThPopoverChoice]
+	case "spellcheck":
+		return choiceValue[ThSpellcheckChoice]
+	case "translate":
+		return choiceValue[ThTranslateChoice]
+	}
+	return nil
+}
+
 func (e *ThElement) Children(children ...ElementRenderer) *ThElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *ThElement) IfChildren(condition bool, children ...ElementRenderer) *ThElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +100,30 @@ func (e *ThElement) IfChildren(condition bool, children ...ElementRenderer) *ThE
 
 func (e *ThElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *ThElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *ThElement) BoolAttr(name string) *ThElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *ThElement) BoolAttrRemove(name string) *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *ThElement) IfBoolAttr(condition bool, name string) *ThElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +134,40 @@ func (e *ThElement) BoolAttrf(format string, args ...any) *ThElement {
 
 func (e *ThElement) IfBoolAttrf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *ThElement) BoolAttrs(names ...string) *ThElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *ThElement) IfBoolAttrs(condition bool, names ...string) *ThElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *ThElement) Attr(name, value string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *ThElement) RawAttr(name, value string) *ThElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *ThElement) IfAttr(condition bool, name, value string) *ThElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +178,52 @@ func (e *ThElement) Attrf(name, format string, args ...any) *ThElement {
 
 func (e *ThElement) IfAttrf(condition bool, name, format string, args ...any) *ThElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *ThElement) Attrs(attrs ...string) *ThElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *ThElement) IfAttrs(condition bool, attrs ...string) *ThElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *ThElement) AttrsMap(attrs map[string]string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *ThElement) IfAttrsMap(condition bool, attrs map[string]string) *ThElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *ThElement) Text(text string) *ThElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *ThElement) Textf(format string, args ...any) *ThElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *ThElement) IfText(condition bool, text string) *ThElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +231,28 @@ func (e *ThElement) IfText(condition bool, text string) *ThElement {
 
 func (e *ThElement) IfTextf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *ThElement) Raw(text string) *ThElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *ThElement) Escaped(text string) *ThElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *ThElement) IfEscaped(condition bool, text string) *ThElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +264,7 @@ func (e *ThElement) Escapedf(format string, args ...any) *ThElement {
 
 func (e *ThElement) IfEscapedf(condition bool, format string, args ...any) *ThElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +273,8 @@ func (e *ThElement) IfEscapedf(condition bool, format string, args ...any) *ThEl
 // Alternative label to use for the header cell when referencing the cell in
 // other contexts.
 func (e *ThElement) Abbr(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("abbr", s)
+	e = e.writable()
+	e.setStringAttribute("abbr", s)
 	return e
 }
 
@@ -227,7 +288,7 @@ func (e *ThElement) Abbrf(format string, args ...any) *ThElement {
 // other contexts.
 func (e *ThElement) IfAbbr(condition bool, s string) *ThElement {
 	if condition {
-		e.Abbr(s)
+		e = e.Abbr(s)
 	}
 	return e
 }
@@ -236,7 +297,7 @@ func (e *ThElement) IfAbbr(condition bool, s string) *ThElement {
 // other contexts.
 func (e *ThElement) IfAbbrf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Abbr(fmt.Sprintf(format, args...))
+		e = e.Abbr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -245,26 +306,22 @@ func (e *ThElement) IfAbbrf(condition bool, format string, args ...any) *ThEleme
 // other contexts.
 // Remove the attribute Abbr from the element.
 func (e *ThElement) AbbrRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("abbr")
+	e = e.writable()
+	e.removeAttribute("abbr")
 	return e
 }
 
 // Number of columns that the cell is to span.
 func (e *ThElement) Colspan(i int) *ThElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("colspan", i)
+	e = e.writable()
+	e.setIntAttribute("colspan", i)
 	return e
 }
 
 // Number of columns that the cell is to span.
 func (e *ThElement) IfColspan(condition bool, i int) *ThElement {
 	if condition {
-		e.Colspan(i)
+		e = e.Colspan(i)
 	}
 	return e
 }
@@ -272,19 +329,15 @@ func (e *ThElement) IfColspan(condition bool, i int) *ThElement {
 // Number of columns that the cell is to span.
 // Remove the attribute Colspan from the element.
 func (e *ThElement) ColspanRemove() *ThElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("colspan")
+	e = e.writable()
+	e.removeAttribute("colspan")
 	return e
 }
 
 // Specifies one or more header cells a cell is related to.
 func (e *ThElement) Headers(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("headers", s)
+	e = e.writable()
+	e.setStringAttribute("headers", s)
 	return e
 }
 
@@ -296,7 +349,7 @@ func (e *ThElement) Headersf(format string, args ...any) *ThElement {
 // Specifies one or more header cells a cell is related to.
 func (e *ThElement) IfHeaders(condition bool, s string) *ThElement {
 	if condition {
-		e.Headers(s)
+		e = e.Headers(s)
 	}
 	return e
 }
@@ -304,7 +357,7 @@ func (e *ThElement) IfHeaders(condition bool, s string) *ThElement {
 // Specifies one or more header cells a cell is related to.
 func (e *ThElement) IfHeadersf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Headers(fmt.Sprintf(format, args...))
+		e = e.Headers(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -312,26 +365,22 @@ func (e *ThElement) IfHeadersf(condition bool, format string, args ...any) *ThEl
 // Specifies one or more header cells a cell is related to.
 // Remove the attribute Headers from the element.
 func (e *ThElement) HeadersRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("headers")
+	e = e.writable()
+	e.removeAttribute("headers")
 	return e
 }
 
 // Number of rows that the cell is to span.
 func (e *ThElement) Rowspan(i int) *ThElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("rowspan", i)
+	e = e.writable()
+	e.setIntAttribute("rowspan", i)
 	return e
 }
 
 // Number of rows that the cell is to span.
 func (e *ThElement) IfRowspan(condition bool, i int) *ThElement {
 	if condition {
-		e.Rowspan(i)
+		e = e.Rowspan(i)
 	}
 	return e
 }
@@ -339,19 +388,15 @@ func (e *ThElement) IfRowspan(condition bool, i int) *ThElement {
 // Number of rows that the cell is to span.
 // Remove the attribute Rowspan from the element.
 func (e *ThElement) RowspanRemove() *ThElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("rowspan")
+	e = e.writable()
+	e.removeAttribute("rowspan")
 	return e
 }
 
 // Specifies which cells the header cell applies to.
 func (e *ThElement) Scope(c ThScopeChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("scope", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("scope", string(c), choiceValue[ThScopeChoice])
 	return e
 }
 
@@ -371,10 +416,8 @@ const (
 // Specifies which cells the header cell applies to.
 // Remove the attribute Scope from the element.
 func (e *ThElement) ScopeRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("scope")
+	e = e.writable()
+	e.removeAttribute("scope")
 	return e
 }
 
@@ -383,10 +426,8 @@ func (e *ThElement) ScopeRemove() *ThElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *ThElement) Accesskey(r rune) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -396,7 +437,7 @@ func (e *ThElement) Accesskey(r rune) *ThElement {
 // can be generated by the keyboard).
 func (e *ThElement) IfAccesskey(condition bool, r rune) *ThElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -407,10 +448,8 @@ func (e *ThElement) IfAccesskey(condition bool, r rune) *ThElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *ThElement) AccesskeyRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -426,10 +465,8 @@ func (e *ThElement) AccesskeyRemove() *ThElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *ThElement) Autocapitalize(c ThAutocapitalizeChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[ThAutocapitalizeChoice])
 	return e
 }
 
@@ -463,10 +500,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *ThElement) AutocapitalizeRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -485,10 +520,8 @@ func (e *ThElement) AutocapitalizeRemove() *ThElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ThElement) Autofocus() *ThElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -508,7 +541,7 @@ func (e *ThElement) Autofocus() *ThElement {
 // created by the preceding content.
 func (e *ThElement) IfAutofocus(condition bool) *ThElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -529,10 +562,8 @@ func (e *ThElement) IfAutofocus(condition bool) *ThElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ThElement) AutofocusRemove() *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -552,10 +583,8 @@ func (e *ThElement) AutofocusRemove() *ThElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *ThElement) AutofocusIfRemove() *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -564,16 +593,9 @@ func (e *ThElement) AutofocusIfRemove() *ThElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *ThElement) Class(s string) *ThElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -583,7 +605,7 @@ func (e *ThElement) Class(s string) *ThElement {
 // document.getElementsByClassName.
 func (e *ThElement) IfClass(condition bool, s string) *ThElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -594,14 +616,8 @@ func (e *ThElement) IfClass(condition bool, s string) *ThElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *ThElement) ClassRemove(s ...string) *ThElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -609,10 +625,8 @@ func (e *ThElement) ClassRemove(s ...string) *ThElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *ThElement) Contenteditable(c ThContenteditableChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[ThContenteditableChoice])
 	return e
 }
 
@@ -635,10 +649,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *ThElement) ContenteditableRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -656,10 +668,8 @@ func (e *ThElement) ContenteditableRemove() *ThElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *ThElement) Dir(c ThDirChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[ThDirChoice])
 	return e
 }
 
@@ -693,10 +703,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *ThElement) DirRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -704,10 +712,8 @@ func (e *ThElement) DirRemove() *ThElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *ThElement) Draggable(c ThDraggableChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[ThDraggableChoice])
 	return e
 }
 
@@ -733,20 +739,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *ThElement) DraggableRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *ThElement) Enterkeyhint(c ThEnterkeyhintChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[ThEnterkeyhintChoice])
 	return e
 }
 
@@ -776,10 +778,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *ThElement) EnterkeyhintRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -801,16 +801,9 @@ func (e *ThElement) EnterkeyhintRemove() *ThElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *ThElement) Exportparts(s string) *ThElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -833,7 +826,7 @@ func (e *ThElement) Exportparts(s string) *ThElement {
 // the current structure.
 func (e *ThElement) IfExportparts(condition bool, s string) *ThElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -857,14 +850,8 @@ func (e *ThElement) IfExportparts(condition bool, s string) *ThElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *ThElement) ExportpartsRemove(s ...string) *ThElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -881,10 +868,8 @@ func (e *ThElement) ExportpartsRemove(s ...string) *ThElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *ThElement) Hidden(c ThHiddenChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[ThHiddenChoice])
 	return e
 }
 
@@ -920,10 +905,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *ThElement) HiddenRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -931,10 +914,8 @@ func (e *ThElement) HiddenRemove() *ThElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ThElement) ID(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -950,7 +931,7 @@ func (e *ThElement) IDf(format string, args ...any) *ThElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ThElement) IfID(condition bool, s string) *ThElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -960,7 +941,7 @@ func (e *ThElement) IfID(condition bool, s string) *ThElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *ThElement) IfIDf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -970,10 +951,8 @@ func (e *ThElement) IfIDf(condition bool, format string, args ...any) *ThElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *ThElement) IDRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -988,10 +967,8 @@ func (e *ThElement) IDRemove() *ThElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ThElement) Inert() *ThElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1007,7 +984,7 @@ func (e *ThElement) Inert() *ThElement {
 // excluding them from the accessibility tree.
 func (e *ThElement) IfInert(condition bool) *ThElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1024,10 +1001,8 @@ func (e *ThElement) IfInert(condition bool) *ThElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ThElement) InertRemove() *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1043,10 +1018,8 @@ func (e *ThElement) InertRemove() *ThElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *ThElement) InertIfRemove() *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1060,10 +1033,8 @@ func (e *ThElement) InertIfRemove() *ThElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *ThElement) Inputmode(c ThInputmodeChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[ThInputmodeChoice])
 	return e
 }
 
@@ -1114,10 +1085,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *ThElement) InputmodeRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1127,10 +1096,8 @@ func (e *ThElement) InputmodeRemove() *ThElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *ThElement) Is(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1150,7 +1117,7 @@ func (e *ThElement) Isf(format string, args ...any) *ThElement {
 // and extends the element type it is being applied to.
 func (e *ThElement) IfIs(condition bool, s string) *ThElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1162,7 +1129,7 @@ func (e *ThElement) IfIs(condition bool, s string) *ThElement {
 // and extends the element type it is being applied to.
 func (e *ThElement) IfIsf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1174,10 +1141,8 @@ func (e *ThElement) IfIsf(condition bool, format string, args ...any) *ThElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *ThElement) IsRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1193,10 +1158,8 @@ func (e *ThElement) IsRemove() *ThElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *ThElement) Itemid(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1228,7 +1191,7 @@ func (e *ThElement) Itemidf(format string, args ...any) *ThElement {
 // how items with the same identifier are handled.
 func (e *ThElement) IfItemid(condition bool, s string) *ThElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1246,7 +1209,7 @@ func (e *ThElement) IfItemid(condition bool, s string) *ThElement {
 // how items with the same identifier are handled.
 func (e *ThElement) IfItemidf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1264,10 +1227,8 @@ func (e *ThElement) IfItemidf(condition bool, format string, args ...any) *ThEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *ThElement) ItemidRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1279,10 +1240,8 @@ func (e *ThElement) ItemidRemove() *ThElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *ThElement) Itemprop(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1306,7 +1265,7 @@ func (e *ThElement) Itempropf(format string, args ...any) *ThElement {
 // <track>, and <video>.
 func (e *ThElement) IfItemprop(condition bool, s string) *ThElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1320,7 +1279,7 @@ func (e *ThElement) IfItemprop(condition bool, s string) *ThElement {
 // <track>, and <video>.
 func (e *ThElement) IfItempropf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1334,10 +1293,8 @@ func (e *ThElement) IfItempropf(condition bool, format string, args ...any) *ThE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *ThElement) ItempropRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1347,10 +1304,8 @@ func (e *ThElement) ItempropRemove() *ThElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *ThElement) Itemref(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1370,7 +1325,7 @@ func (e *ThElement) Itemreff(format string, args ...any) *ThElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ThElement) IfItemref(condition bool, s string) *ThElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1382,7 +1337,7 @@ func (e *ThElement) IfItemref(condition bool, s string) *ThElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *ThElement) IfItemreff(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1394,10 +1349,8 @@ func (e *ThElement) IfItemreff(condition bool, format string, args ...any) *ThEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *ThElement) ItemrefRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1409,10 +1362,8 @@ func (e *ThElement) ItemrefRemove() *ThElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ThElement) Itemscope() *ThElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1425,7 +1376,7 @@ func (e *ThElement) Itemscope() *ThElement {
 // <object>, <source>, <track>, and <video>.
 func (e *ThElement) IfItemscope(condition bool) *ThElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1439,10 +1390,8 @@ func (e *ThElement) IfItemscope(condition bool) *ThElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ThElement) ItemscopeRemove() *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1455,10 +1404,8 @@ func (e *ThElement) ItemscopeRemove() *ThElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *ThElement) ItemscopeIfRemove() *ThElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1470,10 +1417,8 @@ func (e *ThElement) ItemscopeIfRemove() *ThElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *ThElement) Itemtype(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1497,7 +1442,7 @@ func (e *ThElement) Itemtypef(format string, args ...any) *ThElement {
 // <video>.
 func (e *ThElement) IfItemtype(condition bool, s string) *ThElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1511,7 +1456,7 @@ func (e *ThElement) IfItemtype(condition bool, s string) *ThElement {
 // <video>.
 func (e *ThElement) IfItemtypef(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1525,10 +1470,8 @@ func (e *ThElement) IfItemtypef(condition bool, format string, args ...any) *ThE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *ThElement) ItemtypeRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1538,10 +1481,8 @@ func (e *ThElement) ItemtypeRemove() *ThElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ThElement) Lang(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1561,7 +1502,7 @@ func (e *ThElement) Langf(format string, args ...any) *ThElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ThElement) IfLang(condition bool, s string) *ThElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1573,7 +1514,7 @@ func (e *ThElement) IfLang(condition bool, s string) *ThElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *ThElement) IfLangf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1585,10 +1526,8 @@ func (e *ThElement) IfLangf(condition bool, format string, args ...any) *ThEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *ThElement) LangRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1598,10 +1537,8 @@ func (e *ThElement) LangRemove() *ThElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *ThElement) Nonce(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1621,7 +1558,7 @@ func (e *ThElement) Noncef(format string, args ...any) *ThElement {
 // execute.
 func (e *ThElement) IfNonce(condition bool, s string) *ThElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1633,7 +1570,7 @@ func (e *ThElement) IfNonce(condition bool, s string) *ThElement {
 // execute.
 func (e *ThElement) IfNoncef(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1645,10 +1582,8 @@ func (e *ThElement) IfNoncef(condition bool, format string, args ...any) *ThElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *ThElement) NonceRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1656,16 +1591,9 @@ func (e *ThElement) NonceRemove() *ThElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *ThElement) Part(s string) *ThElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1674,7 +1602,7 @@ func (e *ThElement) Part(s string) *ThElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *ThElement) IfPart(condition bool, s string) *ThElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1684,14 +1612,8 @@ func (e *ThElement) IfPart(condition bool, s string) *ThElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *ThElement) PartRemove(s ...string) *ThElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1702,10 +1624,8 @@ func (e *ThElement) PartRemove(s ...string) *ThElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *ThElement) Popover(c ThPopoverChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[ThPopoverChoice])
 	return e
 }
 
@@ -1733,10 +1653,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *ThElement) PopoverRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1745,10 +1663,8 @@ func (e *ThElement) PopoverRemove() *ThElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *ThElement) Role(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1766,7 +1682,7 @@ func (e *ThElement) Rolef(format string, args ...any) *ThElement {
 // role of an element.
 func (e *ThElement) IfRole(condition bool, s string) *ThElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1777,7 +1693,7 @@ func (e *ThElement) IfRole(condition bool, s string) *ThElement {
 // role of an element.
 func (e *ThElement) IfRolef(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1788,10 +1704,8 @@ func (e *ThElement) IfRolef(condition bool, format string, args ...any) *ThEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *ThElement) RoleRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1800,10 +1714,8 @@ func (e *ThElement) RoleRemove() *ThElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *ThElement) Slot(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1821,7 +1733,7 @@ func (e *ThElement) Slotf(format string, args ...any) *ThElement {
 // value.
 func (e *ThElement) IfSlot(condition bool, s string) *ThElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1832,7 +1744,7 @@ func (e *ThElement) IfSlot(condition bool, s string) *ThElement {
 // value.
 func (e *ThElement) IfSlotf(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1843,10 +1755,8 @@ func (e *ThElement) IfSlotf(condition bool, format string, args ...any) *ThEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *ThElement) SlotRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1862,10 +1772,8 @@ func (e *ThElement) SlotRemove() *ThElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *ThElement) Spellcheck(c ThSpellcheckChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[ThSpellcheckChoice])
 	return e
 }
 
@@ -1893,10 +1801,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *ThElement) SpellcheckRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1906,14 +1812,8 @@ func (e *ThElement) StylePairs(pairs ...string) *ThElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1928,14 +1828,8 @@ func (e *ThElement) StylePairs(pairs ...string) *ThElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ThElement) Style(s string) *ThElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1943,7 +1837,7 @@ func (e *ThElement) Style(s string) *ThElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1952,7 +1846,7 @@ func (e *ThElement) Style(s string) *ThElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ThElement) IfStyle(condition bool, s string) *ThElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1960,15 +1854,7 @@ func (e *ThElement) IfStyle(condition bool, s string) *ThElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *ThElement) StyleAdd(k string, v string) *ThElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1982,7 +1868,7 @@ func (e *ThElement) StyleAddf(k string, format string, args ...any) *ThElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ThElement) IfStyleAdd(condition bool, k string, v string) *ThElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1991,7 +1877,7 @@ func (e *ThElement) IfStyleAdd(condition bool, k string, v string) *ThElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *ThElement) IfStyleAddf(condition bool, k string, format string, args ...any) *ThElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2000,21 +1886,15 @@ func (e *ThElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *ThElement) StyleMap(m map[string]string) *ThElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2023,14 +1903,8 @@ func (e *ThElement) StyleMap(m map[string]string) *ThElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *ThElement) StyleRemove(keys ...string) *ThElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2048,10 +1922,8 @@ func (e *ThElement) StyleRemove(keys ...string) *ThElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *ThElement) Tabindex(i int) *ThElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2070,7 +1942,7 @@ func (e *ThElement) Tabindex(i int) *ThElement {
 // their relative position in the document.
 func (e *ThElement) IfTabindex(condition bool, i int) *ThElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2090,10 +1962,8 @@ func (e *ThElement) IfTabindex(condition bool, i int) *ThElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *ThElement) TabindexRemove() *ThElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2114,10 +1984,8 @@ func (e *ThElement) TabindexRemove() *ThElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *ThElement) Title(s string) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2159,7 +2027,7 @@ func (e *ThElement) Titlef(format string, args ...any) *ThElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ThElement) IfTitle(condition bool, s string) *ThElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2182,7 +2050,7 @@ func (e *ThElement) IfTitle(condition bool, s string) *ThElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *ThElement) IfTitlef(condition bool, format string, args ...any) *ThElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2205,10 +2073,8 @@ func (e *ThElement) IfTitlef(condition bool, format string, args ...any) *ThElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *ThElement) TitleRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2217,10 +2083,8 @@ func (e *ThElement) TitleRemove() *ThElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *ThElement) Translate(c ThTranslateChoice) *ThElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[ThTranslateChoice])
 	return e
 }
 
@@ -2241,9 +2105,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *ThElement) TranslateRemove() *ThElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <time> element represents either a time on a 24-hour clock or a
@@ -23,18 +21,77 @@ type TimeElement struct {
 // with the tag "time" during rendering.
 func Time(children ...ElementRenderer) *TimeElement {
 	e := NewElement("time", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &TimeElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *TimeElement) Clone() *TimeElement {
+	return &TimeElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *TimeElement) CloneShallow() *TimeElement {
+	return &TimeElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *TimeElement) Freeze() *TimeElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *TimeElement) writable() *TimeElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *TimeElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *TimeElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[TimeAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[TimeContenteditableChoice]
+	case "dir":
+		return choiceValue[TimeDirChoice]
+	case "draggable":
+		return choiceValue[TimeDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[TimeEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[TimeHiddenChoice]
+	case "inputmode":
+		return choiceValue[TimeInputmodeChoice]
+	case "popover":
+		return choiceValue[TimePopoverChoice]
+	case "spellcheck":
+		return choiceValue[TimeSpellcheckChoice]
+	case "translate":
+		return choiceValue[TimeTranslateChoice]
+	}
+	return nil
+}
+
 func (e *TimeElement) Children(children ...ElementRenderer) *TimeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *TimeElement) IfChildren(condition bool, children ...ElementRenderer) *TimeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +99,30 @@ func (e *TimeElement) IfChildren(condition bool, children ...ElementRenderer) *T
 
 func (e *TimeElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *TimeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *TimeElement) BoolAttr(name string) *TimeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *TimeElement) BoolAttrRemove(name string) *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *TimeElement) IfBoolAttr(condition bool, name string) *TimeElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +133,40 @@ func (e *TimeElement) BoolAttrf(format string, args ...any) *TimeElement {
 
 func (e *TimeElement) IfBoolAttrf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *TimeElement) BoolAttrs(names ...string) *TimeElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *TimeElement) IfBoolAttrs(condition bool, names ...string) *TimeElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *TimeElement) Attr(name, value string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *TimeElement) RawAttr(name, value string) *TimeElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *TimeElement) IfAttr(condition bool, name, value string) *TimeElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +177,52 @@ func (e *TimeElement) Attrf(name, format string, args ...any) *TimeElement {
 
 func (e *TimeElement) IfAttrf(condition bool, name, format string, args ...any) *TimeElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *TimeElement) Attrs(attrs ...string) *TimeElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *TimeElement) IfAttrs(condition bool, attrs ...string) *TimeElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *TimeElement) AttrsMap(attrs map[string]string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *TimeElement) IfAttrsMap(condition bool, attrs map[string]string) *TimeElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *TimeElement) Text(text string) *TimeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *TimeElement) Textf(format string, args ...any) *TimeElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *TimeElement) IfText(condition bool, text string) *TimeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +230,28 @@ func (e *TimeElement) IfText(condition bool, text string) *TimeElement {
 
 func (e *TimeElement) IfTextf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *TimeElement) Raw(text string) *TimeElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *TimeElement) Escaped(text string) *TimeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *TimeElement) IfEscaped(condition bool, text string) *TimeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +263,7 @@ func (e *TimeElement) Escapedf(format string, args ...any) *TimeElement {
 
 func (e *TimeElement) IfEscapedf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +271,8 @@ func (e *TimeElement) IfEscapedf(condition bool, format string, args ...any) *Ti
 
 // Machine-readable value.
 func (e *TimeElement) Datetime(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("datetime", s)
+	e = e.writable()
+	e.setStringAttribute("datetime", s)
 	return e
 }
 
@@ -225,7 +284,7 @@ func (e *TimeElement) Datetimef(format string, args ...any) *TimeElement {
 // Machine-readable value.
 func (e *TimeElement) IfDatetime(condition bool, s string) *TimeElement {
 	if condition {
-		e.Datetime(s)
+		e = e.Datetime(s)
 	}
 	return e
 }
@@ -233,7 +292,7 @@ func (e *TimeElement) IfDatetime(condition bool, s string) *TimeElement {
 // Machine-readable value.
 func (e *TimeElement) IfDatetimef(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Datetime(fmt.Sprintf(format, args...))
+		e = e.Datetime(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,10 +300,8 @@ func (e *TimeElement) IfDatetimef(condition bool, format string, args ...any) *T
 // Machine-readable value.
 // Remove the attribute Datetime from the element.
 func (e *TimeElement) DatetimeRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("datetime")
+	e = e.writable()
+	e.removeAttribute("datetime")
 	return e
 }
 
@@ -253,10 +310,8 @@ func (e *TimeElement) DatetimeRemove() *TimeElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *TimeElement) Accesskey(r rune) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -266,7 +321,7 @@ func (e *TimeElement) Accesskey(r rune) *TimeElement {
 // can be generated by the keyboard).
 func (e *TimeElement) IfAccesskey(condition bool, r rune) *TimeElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -277,10 +332,8 @@ func (e *TimeElement) IfAccesskey(condition bool, r rune) *TimeElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *TimeElement) AccesskeyRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -296,10 +349,8 @@ func (e *TimeElement) AccesskeyRemove() *TimeElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *TimeElement) Autocapitalize(c TimeAutocapitalizeChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[TimeAutocapitalizeChoice])
 	return e
 }
 
@@ -333,10 +384,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *TimeElement) AutocapitalizeRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -355,10 +404,8 @@ func (e *TimeElement) AutocapitalizeRemove() *TimeElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TimeElement) Autofocus() *TimeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -378,7 +425,7 @@ func (e *TimeElement) Autofocus() *TimeElement {
 // created by the preceding content.
 func (e *TimeElement) IfAutofocus(condition bool) *TimeElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -399,10 +446,8 @@ func (e *TimeElement) IfAutofocus(condition bool) *TimeElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TimeElement) AutofocusRemove() *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -422,10 +467,8 @@ func (e *TimeElement) AutofocusRemove() *TimeElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TimeElement) AutofocusIfRemove() *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -434,16 +477,9 @@ func (e *TimeElement) AutofocusIfRemove() *TimeElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *TimeElement) Class(s string) *TimeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -453,7 +489,7 @@ func (e *TimeElement) Class(s string) *TimeElement {
 // document.getElementsByClassName.
 func (e *TimeElement) IfClass(condition bool, s string) *TimeElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -464,14 +500,8 @@ func (e *TimeElement) IfClass(condition bool, s string) *TimeElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *TimeElement) ClassRemove(s ...string) *TimeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -479,10 +509,8 @@ func (e *TimeElement) ClassRemove(s ...string) *TimeElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *TimeElement) Contenteditable(c TimeContenteditableChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[TimeContenteditableChoice])
 	return e
 }
 
@@ -505,10 +533,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *TimeElement) ContenteditableRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -526,10 +552,8 @@ func (e *TimeElement) ContenteditableRemove() *TimeElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *TimeElement) Dir(c TimeDirChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[TimeDirChoice])
 	return e
 }
 
@@ -563,10 +587,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *TimeElement) DirRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -574,10 +596,8 @@ func (e *TimeElement) DirRemove() *TimeElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *TimeElement) Draggable(c TimeDraggableChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[TimeDraggableChoice])
 	return e
 }
 
@@ -603,20 +623,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *TimeElement) DraggableRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *TimeElement) Enterkeyhint(c TimeEnterkeyhintChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[TimeEnterkeyhintChoice])
 	return e
 }
 
@@ -646,10 +662,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *TimeElement) EnterkeyhintRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -671,16 +685,9 @@ func (e *TimeElement) EnterkeyhintRemove() *TimeElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *TimeElement) Exportparts(s string) *TimeElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -703,7 +710,7 @@ func (e *TimeElement) Exportparts(s string) *TimeElement {
 // the current structure.
 func (e *TimeElement) IfExportparts(condition bool, s string) *TimeElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -727,14 +734,8 @@ func (e *TimeElement) IfExportparts(condition bool, s string) *TimeElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *TimeElement) ExportpartsRemove(s ...string) *TimeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -751,10 +752,8 @@ func (e *TimeElement) ExportpartsRemove(s ...string) *TimeElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *TimeElement) Hidden(c TimeHiddenChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[TimeHiddenChoice])
 	return e
 }
 
@@ -790,10 +789,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *TimeElement) HiddenRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -801,10 +798,8 @@ func (e *TimeElement) HiddenRemove() *TimeElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TimeElement) ID(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -820,7 +815,7 @@ func (e *TimeElement) IDf(format string, args ...any) *TimeElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TimeElement) IfID(condition bool, s string) *TimeElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -830,7 +825,7 @@ func (e *TimeElement) IfID(condition bool, s string) *TimeElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TimeElement) IfIDf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -840,10 +835,8 @@ func (e *TimeElement) IfIDf(condition bool, format string, args ...any) *TimeEle
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *TimeElement) IDRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -858,10 +851,8 @@ func (e *TimeElement) IDRemove() *TimeElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TimeElement) Inert() *TimeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -877,7 +868,7 @@ func (e *TimeElement) Inert() *TimeElement {
 // excluding them from the accessibility tree.
 func (e *TimeElement) IfInert(condition bool) *TimeElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -894,10 +885,8 @@ func (e *TimeElement) IfInert(condition bool) *TimeElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TimeElement) InertRemove() *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -913,10 +902,8 @@ func (e *TimeElement) InertRemove() *TimeElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TimeElement) InertIfRemove() *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -930,10 +917,8 @@ func (e *TimeElement) InertIfRemove() *TimeElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *TimeElement) Inputmode(c TimeInputmodeChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[TimeInputmodeChoice])
 	return e
 }
 
@@ -984,10 +969,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *TimeElement) InputmodeRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -997,10 +980,8 @@ func (e *TimeElement) InputmodeRemove() *TimeElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *TimeElement) Is(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1020,7 +1001,7 @@ func (e *TimeElement) Isf(format string, args ...any) *TimeElement {
 // and extends the element type it is being applied to.
 func (e *TimeElement) IfIs(condition bool, s string) *TimeElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1032,7 +1013,7 @@ func (e *TimeElement) IfIs(condition bool, s string) *TimeElement {
 // and extends the element type it is being applied to.
 func (e *TimeElement) IfIsf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1044,10 +1025,8 @@ func (e *TimeElement) IfIsf(condition bool, format string, args ...any) *TimeEle
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *TimeElement) IsRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1063,10 +1042,8 @@ func (e *TimeElement) IsRemove() *TimeElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *TimeElement) Itemid(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1098,7 +1075,7 @@ func (e *TimeElement) Itemidf(format string, args ...any) *TimeElement {
 // how items with the same identifier are handled.
 func (e *TimeElement) IfItemid(condition bool, s string) *TimeElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1116,7 +1093,7 @@ func (e *TimeElement) IfItemid(condition bool, s string) *TimeElement {
 // how items with the same identifier are handled.
 func (e *TimeElement) IfItemidf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1134,10 +1111,8 @@ func (e *TimeElement) IfItemidf(condition bool, format string, args ...any) *Tim
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *TimeElement) ItemidRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1149,10 +1124,8 @@ func (e *TimeElement) ItemidRemove() *TimeElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *TimeElement) Itemprop(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1176,7 +1149,7 @@ func (e *TimeElement) Itempropf(format string, args ...any) *TimeElement {
 // <track>, and <video>.
 func (e *TimeElement) IfItemprop(condition bool, s string) *TimeElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1190,7 +1163,7 @@ func (e *TimeElement) IfItemprop(condition bool, s string) *TimeElement {
 // <track>, and <video>.
 func (e *TimeElement) IfItempropf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1204,10 +1177,8 @@ func (e *TimeElement) IfItempropf(condition bool, format string, args ...any) *T
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *TimeElement) ItempropRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1217,10 +1188,8 @@ func (e *TimeElement) ItempropRemove() *TimeElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *TimeElement) Itemref(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1240,7 +1209,7 @@ func (e *TimeElement) Itemreff(format string, args ...any) *TimeElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TimeElement) IfItemref(condition bool, s string) *TimeElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1252,7 +1221,7 @@ func (e *TimeElement) IfItemref(condition bool, s string) *TimeElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TimeElement) IfItemreff(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1264,10 +1233,8 @@ func (e *TimeElement) IfItemreff(condition bool, format string, args ...any) *Ti
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *TimeElement) ItemrefRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1279,10 +1246,8 @@ func (e *TimeElement) ItemrefRemove() *TimeElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TimeElement) Itemscope() *TimeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1295,7 +1260,7 @@ func (e *TimeElement) Itemscope() *TimeElement {
 // <object>, <source>, <track>, and <video>.
 func (e *TimeElement) IfItemscope(condition bool) *TimeElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1309,10 +1274,8 @@ func (e *TimeElement) IfItemscope(condition bool) *TimeElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TimeElement) ItemscopeRemove() *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1325,10 +1288,8 @@ func (e *TimeElement) ItemscopeRemove() *TimeElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TimeElement) ItemscopeIfRemove() *TimeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1340,10 +1301,8 @@ func (e *TimeElement) ItemscopeIfRemove() *TimeElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *TimeElement) Itemtype(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1367,7 +1326,7 @@ func (e *TimeElement) Itemtypef(format string, args ...any) *TimeElement {
 // <video>.
 func (e *TimeElement) IfItemtype(condition bool, s string) *TimeElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1381,7 +1340,7 @@ func (e *TimeElement) IfItemtype(condition bool, s string) *TimeElement {
 // <video>.
 func (e *TimeElement) IfItemtypef(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1395,10 +1354,8 @@ func (e *TimeElement) IfItemtypef(condition bool, format string, args ...any) *T
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *TimeElement) ItemtypeRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1408,10 +1365,8 @@ func (e *TimeElement) ItemtypeRemove() *TimeElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TimeElement) Lang(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1431,7 +1386,7 @@ func (e *TimeElement) Langf(format string, args ...any) *TimeElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TimeElement) IfLang(condition bool, s string) *TimeElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1443,7 +1398,7 @@ func (e *TimeElement) IfLang(condition bool, s string) *TimeElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TimeElement) IfLangf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1455,10 +1410,8 @@ func (e *TimeElement) IfLangf(condition bool, format string, args ...any) *TimeE
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *TimeElement) LangRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1468,10 +1421,8 @@ func (e *TimeElement) LangRemove() *TimeElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *TimeElement) Nonce(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1491,7 +1442,7 @@ func (e *TimeElement) Noncef(format string, args ...any) *TimeElement {
 // execute.
 func (e *TimeElement) IfNonce(condition bool, s string) *TimeElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1503,7 +1454,7 @@ func (e *TimeElement) IfNonce(condition bool, s string) *TimeElement {
 // execute.
 func (e *TimeElement) IfNoncef(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1515,10 +1466,8 @@ func (e *TimeElement) IfNoncef(condition bool, format string, args ...any) *Time
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *TimeElement) NonceRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1526,16 +1475,9 @@ func (e *TimeElement) NonceRemove() *TimeElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *TimeElement) Part(s string) *TimeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1544,7 +1486,7 @@ func (e *TimeElement) Part(s string) *TimeElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *TimeElement) IfPart(condition bool, s string) *TimeElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1554,14 +1496,8 @@ func (e *TimeElement) IfPart(condition bool, s string) *TimeElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *TimeElement) PartRemove(s ...string) *TimeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1572,10 +1508,8 @@ func (e *TimeElement) PartRemove(s ...string) *TimeElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *TimeElement) Popover(c TimePopoverChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[TimePopoverChoice])
 	return e
 }
 
@@ -1603,10 +1537,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *TimeElement) PopoverRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1615,10 +1547,8 @@ func (e *TimeElement) PopoverRemove() *TimeElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *TimeElement) Role(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1636,7 +1566,7 @@ func (e *TimeElement) Rolef(format string, args ...any) *TimeElement {
 // role of an element.
 func (e *TimeElement) IfRole(condition bool, s string) *TimeElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1647,7 +1577,7 @@ func (e *TimeElement) IfRole(condition bool, s string) *TimeElement {
 // role of an element.
 func (e *TimeElement) IfRolef(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1658,10 +1588,8 @@ func (e *TimeElement) IfRolef(condition bool, format string, args ...any) *TimeE
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *TimeElement) RoleRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1670,10 +1598,8 @@ func (e *TimeElement) RoleRemove() *TimeElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *TimeElement) Slot(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1691,7 +1617,7 @@ func (e *TimeElement) Slotf(format string, args ...any) *TimeElement {
 // value.
 func (e *TimeElement) IfSlot(condition bool, s string) *TimeElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1702,7 +1628,7 @@ func (e *TimeElement) IfSlot(condition bool, s string) *TimeElement {
 // value.
 func (e *TimeElement) IfSlotf(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1713,10 +1639,8 @@ func (e *TimeElement) IfSlotf(condition bool, format string, args ...any) *TimeE
 // value.
 // Remove the attribute Slot from the element.
 func (e *TimeElement) SlotRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1732,10 +1656,8 @@ func (e *TimeElement) SlotRemove() *TimeElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *TimeElement) Spellcheck(c TimeSpellcheckChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[TimeSpellcheckChoice])
 	return e
 }
 
@@ -1763,10 +1685,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *TimeElement) SpellcheckRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1776,14 +1696,8 @@ func (e *TimeElement) StylePairs(pairs ...string) *TimeElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1798,14 +1712,8 @@ func (e *TimeElement) StylePairs(pairs ...string) *TimeElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TimeElement) Style(s string) *TimeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1813,7 +1721,7 @@ func (e *TimeElement) Style(s string) *TimeElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1822,7 +1730,7 @@ func (e *TimeElement) Style(s string) *TimeElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TimeElement) IfStyle(condition bool, s string) *TimeElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1830,15 +1738,7 @@ func (e *TimeElement) IfStyle(condition bool, s string) *TimeElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TimeElement) StyleAdd(k string, v string) *TimeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1852,7 +1752,7 @@ func (e *TimeElement) StyleAddf(k string, format string, args ...any) *TimeEleme
 // color, font, size, and more. Styles are written in CSS.
 func (e *TimeElement) IfStyleAdd(condition bool, k string, v string) *TimeElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1861,7 +1761,7 @@ func (e *TimeElement) IfStyleAdd(condition bool, k string, v string) *TimeElemen
 // color, font, size, and more. Styles are written in CSS.
 func (e *TimeElement) IfStyleAddf(condition bool, k string, format string, args ...any) *TimeElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1870,21 +1770,15 @@ func (e *TimeElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *TimeElement) StyleMap(m map[string]string) *TimeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1893,14 +1787,8 @@ func (e *TimeElement) StyleMap(m map[string]string) *TimeElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *TimeElement) StyleRemove(keys ...string) *TimeElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1918,10 +1806,8 @@ func (e *TimeElement) StyleRemove(keys ...string) *TimeElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *TimeElement) Tabindex(i int) *TimeElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1940,7 +1826,7 @@ func (e *TimeElement) Tabindex(i int) *TimeElement {
 // their relative position in the document.
 func (e *TimeElement) IfTabindex(condition bool, i int) *TimeElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1960,10 +1846,8 @@ func (e *TimeElement) IfTabindex(condition bool, i int) *TimeElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *TimeElement) TabindexRemove() *TimeElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1984,10 +1868,8 @@ func (e *TimeElement) TabindexRemove() *TimeElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *TimeElement) Title(s string) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2029,7 +1911,7 @@ func (e *TimeElement) Titlef(format string, args ...any) *TimeElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TimeElement) IfTitle(condition bool, s string) *TimeElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2052,7 +1934,7 @@ func (e *TimeElement) IfTitle(condition bool, s string) *TimeElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TimeElement) IfTitlef(condition bool, format string, args ...any) *TimeElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2075,10 +1957,8 @@ func (e *TimeElement) IfTitlef(condition bool, format string, args ...any) *Time
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *TimeElement) TitleRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2087,10 +1967,8 @@ func (e *TimeElement) TitleRemove() *TimeElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *TimeElement) Translate(c TimeTranslateChoice) *TimeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[TimeTranslateChoice])
 	return e
 }
 
@@ -2111,9 +1989,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *TimeElement) TranslateRemove() *TimeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
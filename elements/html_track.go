@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <track> element is used as a child of the media elements—<audio>
@@ -25,18 +23,79 @@ type TrackElement struct {
 // with the tag "track" during rendering.
 func Track() *TrackElement {
 	e := NewElement("track")
+	e.namespace = namespaceHTML
 	e.isSelfClosing = true
 
 	return &TrackElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *TrackElement) Clone() *TrackElement {
+	return &TrackElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *TrackElement) CloneShallow() *TrackElement {
+	return &TrackElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *TrackElement) Freeze() *TrackElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *TrackElement) writable() *TrackElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *TrackElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *TrackElement) choiceType(name string) func(string) any {
+	switch name {
+	case "kind":
+		return choiceValue[TrackKindChoice]
+	case "autocapitalize":
+		return choiceValue[TrackAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[TrackContenteditableChoice]
+	case "dir":
+		return choiceValue[TrackDirChoice]
+	case "draggable":
+		return choiceValue[TrackDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[TrackEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[TrackHiddenChoice]
+	case "inputmode":
+		return choiceValue[TrackInputmodeChoice]
+	case "popover":
+		return choiceValue[TrackPopoverChoice]
+	case "spellcheck":
+		return choiceValue[TrackSpellcheckChoice]
+	case "translate":
+		return choiceValue[TrackTranslateChoice]
+	}
+	return nil
+}
+
 func (e *TrackElement) Children(children ...ElementRenderer) *TrackElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *TrackElement) IfChildren(condition bool, children ...ElementRenderer) *TrackElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -44,32 +103,30 @@ func (e *TrackElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *TrackElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *TrackElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *TrackElement) BoolAttr(name string) *TrackElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *TrackElement) BoolAttrRemove(name string) *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *TrackElement) IfBoolAttr(condition bool, name string) *TrackElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -80,36 +137,40 @@ func (e *TrackElement) BoolAttrf(format string, args ...any) *TrackElement {
 
 func (e *TrackElement) IfBoolAttrf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *TrackElement) BoolAttrs(names ...string) *TrackElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *TrackElement) IfBoolAttrs(condition bool, names ...string) *TrackElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *TrackElement) Attr(name, value string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *TrackElement) RawAttr(name, value string) *TrackElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *TrackElement) IfAttr(condition bool, name, value string) *TrackElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -120,61 +181,52 @@ func (e *TrackElement) Attrf(name, format string, args ...any) *TrackElement {
 
 func (e *TrackElement) IfAttrf(condition bool, name, format string, args ...any) *TrackElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *TrackElement) Attrs(attrs ...string) *TrackElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *TrackElement) IfAttrs(condition bool, attrs ...string) *TrackElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *TrackElement) AttrsMap(attrs map[string]string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *TrackElement) IfAttrsMap(condition bool, attrs map[string]string) *TrackElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *TrackElement) Text(text string) *TrackElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *TrackElement) Textf(format string, args ...any) *TrackElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *TrackElement) IfText(condition bool, text string) *TrackElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -182,18 +234,28 @@ func (e *TrackElement) IfText(condition bool, text string) *TrackElement {
 
 func (e *TrackElement) IfTextf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *TrackElement) Raw(text string) *TrackElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *TrackElement) Escaped(text string) *TrackElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *TrackElement) IfEscaped(condition bool, text string) *TrackElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -205,6 +267,7 @@ func (e *TrackElement) Escapedf(format string, args ...any) *TrackElement {
 
 func (e *TrackElement) IfEscapedf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,17 +275,15 @@ func (e *TrackElement) IfEscapedf(condition bool, format string, args ...any) *T
 
 // Enables the track if set to true.
 func (e *TrackElement) Default() *TrackElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("default", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("default")
 	return e
 }
 
 // Enables the track if set to true.
 func (e *TrackElement) IfDefault(condition bool) *TrackElement {
 	if condition {
-		e.Default()
+		e = e.Default()
 	}
 	return e
 }
@@ -230,29 +291,23 @@ func (e *TrackElement) IfDefault(condition bool) *TrackElement {
 // Remove the attribute Default from the element.
 // Enables the track if set to true.
 func (e *TrackElement) DefaultRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("default")
+	e = e.writable()
+	e.removeAttribute("default")
 	return e
 }
 
 // Remove the attribute Default from the element.
 // Enables the track if set to true.
 func (e *TrackElement) DefaultIfRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("default")
+	e = e.writable()
+	e.removeAttribute("default")
 	return e
 }
 
 // Specifies the kind of text track.
 func (e *TrackElement) Kind(c TrackKindChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("kind", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("kind", string(c), choiceValue[TrackKindChoice])
 	return e
 }
 
@@ -274,19 +329,15 @@ const (
 // Specifies the kind of text track.
 // Remove the attribute Kind from the element.
 func (e *TrackElement) KindRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("kind")
+	e = e.writable()
+	e.removeAttribute("kind")
 	return e
 }
 
 // Specifies a user-readable title of the text track.
 func (e *TrackElement) Label(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("label", s)
+	e = e.writable()
+	e.setStringAttribute("label", s)
 	return e
 }
 
@@ -298,7 +349,7 @@ func (e *TrackElement) Labelf(format string, args ...any) *TrackElement {
 // Specifies a user-readable title of the text track.
 func (e *TrackElement) IfLabel(condition bool, s string) *TrackElement {
 	if condition {
-		e.Label(s)
+		e = e.Label(s)
 	}
 	return e
 }
@@ -306,7 +357,7 @@ func (e *TrackElement) IfLabel(condition bool, s string) *TrackElement {
 // Specifies a user-readable title of the text track.
 func (e *TrackElement) IfLabelf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Label(fmt.Sprintf(format, args...))
+		e = e.Label(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -314,19 +365,15 @@ func (e *TrackElement) IfLabelf(condition bool, format string, args ...any) *Tra
 // Specifies a user-readable title of the text track.
 // Remove the attribute Label from the element.
 func (e *TrackElement) LabelRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("label")
+	e = e.writable()
+	e.removeAttribute("label")
 	return e
 }
 
 // Address of the resource.
 func (e *TrackElement) Src(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -338,7 +385,7 @@ func (e *TrackElement) Srcf(format string, args ...any) *TrackElement {
 // Address of the resource.
 func (e *TrackElement) IfSrc(condition bool, s string) *TrackElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -346,7 +393,7 @@ func (e *TrackElement) IfSrc(condition bool, s string) *TrackElement {
 // Address of the resource.
 func (e *TrackElement) IfSrcf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -354,19 +401,15 @@ func (e *TrackElement) IfSrcf(condition bool, format string, args ...any) *Track
 // Address of the resource.
 // Remove the attribute Src from the element.
 func (e *TrackElement) SrcRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // Language of the text track.
 func (e *TrackElement) Srclang(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("srclang", s)
+	e = e.writable()
+	e.setStringAttribute("srclang", s)
 	return e
 }
 
@@ -378,7 +421,7 @@ func (e *TrackElement) Srclangf(format string, args ...any) *TrackElement {
 // Language of the text track.
 func (e *TrackElement) IfSrclang(condition bool, s string) *TrackElement {
 	if condition {
-		e.Srclang(s)
+		e = e.Srclang(s)
 	}
 	return e
 }
@@ -386,7 +429,7 @@ func (e *TrackElement) IfSrclang(condition bool, s string) *TrackElement {
 // Language of the text track.
 func (e *TrackElement) IfSrclangf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Srclang(fmt.Sprintf(format, args...))
+		e = e.Srclang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -394,10 +437,8 @@ func (e *TrackElement) IfSrclangf(condition bool, format string, args ...any) *T
 // Language of the text track.
 // Remove the attribute Srclang from the element.
 func (e *TrackElement) SrclangRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("srclang")
+	e = e.writable()
+	e.removeAttribute("srclang")
 	return e
 }
 
@@ -406,10 +447,8 @@ func (e *TrackElement) SrclangRemove() *TrackElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *TrackElement) Accesskey(r rune) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -419,7 +458,7 @@ func (e *TrackElement) Accesskey(r rune) *TrackElement {
 // can be generated by the keyboard).
 func (e *TrackElement) IfAccesskey(condition bool, r rune) *TrackElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -430,10 +469,8 @@ func (e *TrackElement) IfAccesskey(condition bool, r rune) *TrackElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *TrackElement) AccesskeyRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -449,10 +486,8 @@ func (e *TrackElement) AccesskeyRemove() *TrackElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *TrackElement) Autocapitalize(c TrackAutocapitalizeChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[TrackAutocapitalizeChoice])
 	return e
 }
 
@@ -486,10 +521,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *TrackElement) AutocapitalizeRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -508,10 +541,8 @@ func (e *TrackElement) AutocapitalizeRemove() *TrackElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TrackElement) Autofocus() *TrackElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -531,7 +562,7 @@ func (e *TrackElement) Autofocus() *TrackElement {
 // created by the preceding content.
 func (e *TrackElement) IfAutofocus(condition bool) *TrackElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -552,10 +583,8 @@ func (e *TrackElement) IfAutofocus(condition bool) *TrackElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TrackElement) AutofocusRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -575,10 +604,8 @@ func (e *TrackElement) AutofocusRemove() *TrackElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *TrackElement) AutofocusIfRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -587,16 +614,9 @@ func (e *TrackElement) AutofocusIfRemove() *TrackElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *TrackElement) Class(s string) *TrackElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -606,7 +626,7 @@ func (e *TrackElement) Class(s string) *TrackElement {
 // document.getElementsByClassName.
 func (e *TrackElement) IfClass(condition bool, s string) *TrackElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -617,14 +637,8 @@ func (e *TrackElement) IfClass(condition bool, s string) *TrackElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *TrackElement) ClassRemove(s ...string) *TrackElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -632,10 +646,8 @@ func (e *TrackElement) ClassRemove(s ...string) *TrackElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *TrackElement) Contenteditable(c TrackContenteditableChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[TrackContenteditableChoice])
 	return e
 }
 
@@ -658,10 +670,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *TrackElement) ContenteditableRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -679,10 +689,8 @@ func (e *TrackElement) ContenteditableRemove() *TrackElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *TrackElement) Dir(c TrackDirChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[TrackDirChoice])
 	return e
 }
 
@@ -716,10 +724,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *TrackElement) DirRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -727,10 +733,8 @@ func (e *TrackElement) DirRemove() *TrackElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *TrackElement) Draggable(c TrackDraggableChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[TrackDraggableChoice])
 	return e
 }
 
@@ -756,20 +760,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *TrackElement) DraggableRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *TrackElement) Enterkeyhint(c TrackEnterkeyhintChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[TrackEnterkeyhintChoice])
 	return e
 }
 
@@ -799,10 +799,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *TrackElement) EnterkeyhintRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -824,16 +822,9 @@ func (e *TrackElement) EnterkeyhintRemove() *TrackElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *TrackElement) Exportparts(s string) *TrackElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -856,7 +847,7 @@ func (e *TrackElement) Exportparts(s string) *TrackElement {
 // the current structure.
 func (e *TrackElement) IfExportparts(condition bool, s string) *TrackElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -880,14 +871,8 @@ func (e *TrackElement) IfExportparts(condition bool, s string) *TrackElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *TrackElement) ExportpartsRemove(s ...string) *TrackElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -904,10 +889,8 @@ func (e *TrackElement) ExportpartsRemove(s ...string) *TrackElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *TrackElement) Hidden(c TrackHiddenChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[TrackHiddenChoice])
 	return e
 }
 
@@ -943,10 +926,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *TrackElement) HiddenRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -954,10 +935,8 @@ func (e *TrackElement) HiddenRemove() *TrackElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TrackElement) ID(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -973,7 +952,7 @@ func (e *TrackElement) IDf(format string, args ...any) *TrackElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TrackElement) IfID(condition bool, s string) *TrackElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -983,7 +962,7 @@ func (e *TrackElement) IfID(condition bool, s string) *TrackElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *TrackElement) IfIDf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -993,10 +972,8 @@ func (e *TrackElement) IfIDf(condition bool, format string, args ...any) *TrackE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *TrackElement) IDRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1011,10 +988,8 @@ func (e *TrackElement) IDRemove() *TrackElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TrackElement) Inert() *TrackElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1030,7 +1005,7 @@ func (e *TrackElement) Inert() *TrackElement {
 // excluding them from the accessibility tree.
 func (e *TrackElement) IfInert(condition bool) *TrackElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1047,10 +1022,8 @@ func (e *TrackElement) IfInert(condition bool) *TrackElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TrackElement) InertRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1066,10 +1039,8 @@ func (e *TrackElement) InertRemove() *TrackElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *TrackElement) InertIfRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1083,10 +1054,8 @@ func (e *TrackElement) InertIfRemove() *TrackElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *TrackElement) Inputmode(c TrackInputmodeChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[TrackInputmodeChoice])
 	return e
 }
 
@@ -1137,10 +1106,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *TrackElement) InputmodeRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1150,10 +1117,8 @@ func (e *TrackElement) InputmodeRemove() *TrackElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *TrackElement) Is(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1173,7 +1138,7 @@ func (e *TrackElement) Isf(format string, args ...any) *TrackElement {
 // and extends the element type it is being applied to.
 func (e *TrackElement) IfIs(condition bool, s string) *TrackElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1185,7 +1150,7 @@ func (e *TrackElement) IfIs(condition bool, s string) *TrackElement {
 // and extends the element type it is being applied to.
 func (e *TrackElement) IfIsf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1197,10 +1162,8 @@ func (e *TrackElement) IfIsf(condition bool, format string, args ...any) *TrackE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *TrackElement) IsRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1216,10 +1179,8 @@ func (e *TrackElement) IsRemove() *TrackElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *TrackElement) Itemid(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1251,7 +1212,7 @@ func (e *TrackElement) Itemidf(format string, args ...any) *TrackElement {
 // how items with the same identifier are handled.
 func (e *TrackElement) IfItemid(condition bool, s string) *TrackElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1269,7 +1230,7 @@ func (e *TrackElement) IfItemid(condition bool, s string) *TrackElement {
 // how items with the same identifier are handled.
 func (e *TrackElement) IfItemidf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1287,10 +1248,8 @@ func (e *TrackElement) IfItemidf(condition bool, format string, args ...any) *Tr
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *TrackElement) ItemidRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1302,10 +1261,8 @@ func (e *TrackElement) ItemidRemove() *TrackElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *TrackElement) Itemprop(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1329,7 +1286,7 @@ func (e *TrackElement) Itempropf(format string, args ...any) *TrackElement {
 // <track>, and <video>.
 func (e *TrackElement) IfItemprop(condition bool, s string) *TrackElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1343,7 +1300,7 @@ func (e *TrackElement) IfItemprop(condition bool, s string) *TrackElement {
 // <track>, and <video>.
 func (e *TrackElement) IfItempropf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1357,10 +1314,8 @@ func (e *TrackElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *TrackElement) ItempropRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1370,10 +1325,8 @@ func (e *TrackElement) ItempropRemove() *TrackElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *TrackElement) Itemref(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1393,7 +1346,7 @@ func (e *TrackElement) Itemreff(format string, args ...any) *TrackElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TrackElement) IfItemref(condition bool, s string) *TrackElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1405,7 +1358,7 @@ func (e *TrackElement) IfItemref(condition bool, s string) *TrackElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *TrackElement) IfItemreff(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1417,10 +1370,8 @@ func (e *TrackElement) IfItemreff(condition bool, format string, args ...any) *T
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *TrackElement) ItemrefRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1432,10 +1383,8 @@ func (e *TrackElement) ItemrefRemove() *TrackElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TrackElement) Itemscope() *TrackElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1448,7 +1397,7 @@ func (e *TrackElement) Itemscope() *TrackElement {
 // <object>, <source>, <track>, and <video>.
 func (e *TrackElement) IfItemscope(condition bool) *TrackElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1462,10 +1411,8 @@ func (e *TrackElement) IfItemscope(condition bool) *TrackElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TrackElement) ItemscopeRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1478,10 +1425,8 @@ func (e *TrackElement) ItemscopeRemove() *TrackElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *TrackElement) ItemscopeIfRemove() *TrackElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1493,10 +1438,8 @@ func (e *TrackElement) ItemscopeIfRemove() *TrackElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *TrackElement) Itemtype(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1520,7 +1463,7 @@ func (e *TrackElement) Itemtypef(format string, args ...any) *TrackElement {
 // <video>.
 func (e *TrackElement) IfItemtype(condition bool, s string) *TrackElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1534,7 +1477,7 @@ func (e *TrackElement) IfItemtype(condition bool, s string) *TrackElement {
 // <video>.
 func (e *TrackElement) IfItemtypef(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1548,10 +1491,8 @@ func (e *TrackElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *TrackElement) ItemtypeRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1561,10 +1502,8 @@ func (e *TrackElement) ItemtypeRemove() *TrackElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TrackElement) Lang(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1584,7 +1523,7 @@ func (e *TrackElement) Langf(format string, args ...any) *TrackElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TrackElement) IfLang(condition bool, s string) *TrackElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1596,7 +1535,7 @@ func (e *TrackElement) IfLang(condition bool, s string) *TrackElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *TrackElement) IfLangf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1608,10 +1547,8 @@ func (e *TrackElement) IfLangf(condition bool, format string, args ...any) *Trac
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *TrackElement) LangRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1621,10 +1558,8 @@ func (e *TrackElement) LangRemove() *TrackElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *TrackElement) Nonce(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1644,7 +1579,7 @@ func (e *TrackElement) Noncef(format string, args ...any) *TrackElement {
 // execute.
 func (e *TrackElement) IfNonce(condition bool, s string) *TrackElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1656,7 +1591,7 @@ func (e *TrackElement) IfNonce(condition bool, s string) *TrackElement {
 // execute.
 func (e *TrackElement) IfNoncef(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1668,10 +1603,8 @@ func (e *TrackElement) IfNoncef(condition bool, format string, args ...any) *Tra
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *TrackElement) NonceRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1679,16 +1612,9 @@ func (e *TrackElement) NonceRemove() *TrackElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *TrackElement) Part(s string) *TrackElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1697,7 +1623,7 @@ func (e *TrackElement) Part(s string) *TrackElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *TrackElement) IfPart(condition bool, s string) *TrackElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1707,14 +1633,8 @@ func (e *TrackElement) IfPart(condition bool, s string) *TrackElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *TrackElement) PartRemove(s ...string) *TrackElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1725,10 +1645,8 @@ func (e *TrackElement) PartRemove(s ...string) *TrackElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *TrackElement) Popover(c TrackPopoverChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[TrackPopoverChoice])
 	return e
 }
 
@@ -1756,10 +1674,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *TrackElement) PopoverRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1768,10 +1684,8 @@ func (e *TrackElement) PopoverRemove() *TrackElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *TrackElement) Role(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1789,7 +1703,7 @@ func (e *TrackElement) Rolef(format string, args ...any) *TrackElement {
 // role of an element.
 func (e *TrackElement) IfRole(condition bool, s string) *TrackElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1800,7 +1714,7 @@ func (e *TrackElement) IfRole(condition bool, s string) *TrackElement {
 // role of an element.
 func (e *TrackElement) IfRolef(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1811,10 +1725,8 @@ func (e *TrackElement) IfRolef(condition bool, format string, args ...any) *Trac
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *TrackElement) RoleRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1823,10 +1735,8 @@ func (e *TrackElement) RoleRemove() *TrackElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *TrackElement) Slot(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1844,7 +1754,7 @@ func (e *TrackElement) Slotf(format string, args ...any) *TrackElement {
 // value.
 func (e *TrackElement) IfSlot(condition bool, s string) *TrackElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1855,7 +1765,7 @@ func (e *TrackElement) IfSlot(condition bool, s string) *TrackElement {
 // value.
 func (e *TrackElement) IfSlotf(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1866,10 +1776,8 @@ func (e *TrackElement) IfSlotf(condition bool, format string, args ...any) *Trac
 // value.
 // Remove the attribute Slot from the element.
 func (e *TrackElement) SlotRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1885,10 +1793,8 @@ func (e *TrackElement) SlotRemove() *TrackElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *TrackElement) Spellcheck(c TrackSpellcheckChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[TrackSpellcheckChoice])
 	return e
 }
 
@@ -1916,10 +1822,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *TrackElement) SpellcheckRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1929,14 +1833,8 @@ func (e *TrackElement) StylePairs(pairs ...string) *TrackElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1951,14 +1849,8 @@ func (e *TrackElement) StylePairs(pairs ...string) *TrackElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TrackElement) Style(s string) *TrackElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1966,7 +1858,7 @@ func (e *TrackElement) Style(s string) *TrackElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1975,7 +1867,7 @@ func (e *TrackElement) Style(s string) *TrackElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *TrackElement) IfStyle(condition bool, s string) *TrackElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1983,15 +1875,7 @@ func (e *TrackElement) IfStyle(condition bool, s string) *TrackElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *TrackElement) StyleAdd(k string, v string) *TrackElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2005,7 +1889,7 @@ func (e *TrackElement) StyleAddf(k string, format string, args ...any) *TrackEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *TrackElement) IfStyleAdd(condition bool, k string, v string) *TrackElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2014,7 +1898,7 @@ func (e *TrackElement) IfStyleAdd(condition bool, k string, v string) *TrackElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *TrackElement) IfStyleAddf(condition bool, k string, format string, args ...any) *TrackElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2023,21 +1907,15 @@ func (e *TrackElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *TrackElement) StyleMap(m map[string]string) *TrackElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2046,14 +1924,8 @@ func (e *TrackElement) StyleMap(m map[string]string) *TrackElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *TrackElement) StyleRemove(keys ...string) *TrackElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2071,10 +1943,8 @@ func (e *TrackElement) StyleRemove(keys ...string) *TrackElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *TrackElement) Tabindex(i int) *TrackElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2093,7 +1963,7 @@ func (e *TrackElement) Tabindex(i int) *TrackElement {
 // their relative position in the document.
 func (e *TrackElement) IfTabindex(condition bool, i int) *TrackElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2113,10 +1983,8 @@ func (e *TrackElement) IfTabindex(condition bool, i int) *TrackElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *TrackElement) TabindexRemove() *TrackElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2137,10 +2005,8 @@ func (e *TrackElement) TabindexRemove() *TrackElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *TrackElement) Title(s string) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2182,7 +2048,7 @@ func (e *TrackElement) Titlef(format string, args ...any) *TrackElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TrackElement) IfTitle(condition bool, s string) *TrackElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2205,7 +2071,7 @@ func (e *TrackElement) IfTitle(condition bool, s string) *TrackElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *TrackElement) IfTitlef(condition bool, format string, args ...any) *TrackElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2228,10 +2094,8 @@ func (e *TrackElement) IfTitlef(condition bool, format string, args ...any) *Tra
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *TrackElement) TitleRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2240,10 +2104,8 @@ func (e *TrackElement) TitleRemove() *TrackElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *TrackElement) Translate(c TrackTranslateChoice) *TrackElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[TrackTranslateChoice])
 	return e
 }
 
@@ -2264,9 +2126,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *TrackElement) TranslateRemove() *TrackElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
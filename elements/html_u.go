@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <u> element renders text with an underline, a line under the
@@ -25,18 +23,77 @@ type UElement struct {
 // with the tag "u" during rendering.
 func U(children ...ElementRenderer) *UElement {
 	e := NewElement("u", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &UElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *UElement) Clone() *UElement {
+	return &UElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *UElement) CloneShallow() *UElement {
+	return &UElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *UElement) Freeze() *UElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *UElement) writable() *UElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *UElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *UElement) choiceType(name string) func(string) any {
+	switch name {
+	case "autocapitalize":
+		return choiceValue[UAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[UContenteditableChoice]
+	case "dir":
+		return choiceValue[UDirChoice]
+	case "draggable":
+		return choiceValue[UDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[UEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[UHiddenChoice]
+	case "inputmode":
+		return choiceValue[UInputmodeChoice]
+	case "popover":
+		return choiceValue[UPopoverChoice]
+	case "spellcheck":
+		return choiceValue[USpellcheckChoice]
+	case "translate":
+		return choiceValue[UTranslateChoice]
+	}
+	return nil
+}
+
 func (e *UElement) Children(children ...ElementRenderer) *UElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *UElement) IfChildren(condition bool, children ...ElementRenderer) *UElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -44,32 +101,30 @@ func (e *UElement) IfChildren(condition bool, children ...ElementRenderer) *UEle
 
 func (e *UElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *UElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *UElement) BoolAttr(name string) *UElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *UElement) BoolAttrRemove(name string) *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *UElement) IfBoolAttr(condition bool, name string) *UElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -80,36 +135,40 @@ func (e *UElement) BoolAttrf(format string, args ...any) *UElement {
 
 func (e *UElement) IfBoolAttrf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *UElement) BoolAttrs(names ...string) *UElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *UElement) IfBoolAttrs(condition bool, names ...string) *UElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *UElement) Attr(name, value string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *UElement) RawAttr(name, value string) *UElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *UElement) IfAttr(condition bool, name, value string) *UElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -120,61 +179,52 @@ func (e *UElement) Attrf(name, format string, args ...any) *UElement {
 
 func (e *UElement) IfAttrf(condition bool, name, format string, args ...any) *UElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *UElement) Attrs(attrs ...string) *UElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *UElement) IfAttrs(condition bool, attrs ...string) *UElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *UElement) AttrsMap(attrs map[string]string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *UElement) IfAttrsMap(condition bool, attrs map[string]string) *UElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *UElement) Text(text string) *UElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *UElement) Textf(format string, args ...any) *UElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *UElement) IfText(condition bool, text string) *UElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -182,18 +232,28 @@ func (e *UElement) IfText(condition bool, text string) *UElement {
 
 func (e *UElement) IfTextf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *UElement) Raw(text string) *UElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *UElement) Escaped(text string) *UElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *UElement) IfEscaped(condition bool, text string) *UElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -205,6 +265,7 @@ func (e *UElement) Escapedf(format string, args ...any) *UElement {
 
 func (e *UElement) IfEscapedf(condition bool, format string, args ...any) *UElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -215,10 +276,8 @@ func (e *UElement) IfEscapedf(condition bool, format string, args ...any) *UElem
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *UElement) Accesskey(r rune) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -228,7 +287,7 @@ func (e *UElement) Accesskey(r rune) *UElement {
 // can be generated by the keyboard).
 func (e *UElement) IfAccesskey(condition bool, r rune) *UElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -239,10 +298,8 @@ func (e *UElement) IfAccesskey(condition bool, r rune) *UElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *UElement) AccesskeyRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -258,10 +315,8 @@ func (e *UElement) AccesskeyRemove() *UElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *UElement) Autocapitalize(c UAutocapitalizeChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[UAutocapitalizeChoice])
 	return e
 }
 
@@ -295,10 +350,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *UElement) AutocapitalizeRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -317,10 +370,8 @@ func (e *UElement) AutocapitalizeRemove() *UElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *UElement) Autofocus() *UElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -340,7 +391,7 @@ func (e *UElement) Autofocus() *UElement {
 // created by the preceding content.
 func (e *UElement) IfAutofocus(condition bool) *UElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -361,10 +412,8 @@ func (e *UElement) IfAutofocus(condition bool) *UElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *UElement) AutofocusRemove() *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -384,10 +433,8 @@ func (e *UElement) AutofocusRemove() *UElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *UElement) AutofocusIfRemove() *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -396,16 +443,9 @@ func (e *UElement) AutofocusIfRemove() *UElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *UElement) Class(s string) *UElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -415,7 +455,7 @@ func (e *UElement) Class(s string) *UElement {
 // document.getElementsByClassName.
 func (e *UElement) IfClass(condition bool, s string) *UElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -426,14 +466,8 @@ func (e *UElement) IfClass(condition bool, s string) *UElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *UElement) ClassRemove(s ...string) *UElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -441,10 +475,8 @@ func (e *UElement) ClassRemove(s ...string) *UElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *UElement) Contenteditable(c UContenteditableChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[UContenteditableChoice])
 	return e
 }
 
@@ -467,10 +499,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *UElement) ContenteditableRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -488,10 +518,8 @@ func (e *UElement) ContenteditableRemove() *UElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *UElement) Dir(c UDirChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[UDirChoice])
 	return e
 }
 
@@ -525,10 +553,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *UElement) DirRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -536,10 +562,8 @@ func (e *UElement) DirRemove() *UElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *UElement) Draggable(c UDraggableChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[UDraggableChoice])
 	return e
 }
 
@@ -565,20 +589,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *UElement) DraggableRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *UElement) Enterkeyhint(c UEnterkeyhintChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[UEnterkeyhintChoice])
 	return e
 }
 
@@ -608,10 +628,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *UElement) EnterkeyhintRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -633,16 +651,9 @@ func (e *UElement) EnterkeyhintRemove() *UElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *UElement) Exportparts(s string) *UElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -665,7 +676,7 @@ func (e *UElement) Exportparts(s string) *UElement {
 // the current structure.
 func (e *UElement) IfExportparts(condition bool, s string) *UElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -689,14 +700,8 @@ func (e *UElement) IfExportparts(condition bool, s string) *UElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *UElement) ExportpartsRemove(s ...string) *UElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -713,10 +718,8 @@ func (e *UElement) ExportpartsRemove(s ...string) *UElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *UElement) Hidden(c UHiddenChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[UHiddenChoice])
 	return e
 }
 
@@ -752,10 +755,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *UElement) HiddenRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -763,10 +764,8 @@ func (e *UElement) HiddenRemove() *UElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *UElement) ID(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -782,7 +781,7 @@ func (e *UElement) IDf(format string, args ...any) *UElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *UElement) IfID(condition bool, s string) *UElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -792,7 +791,7 @@ func (e *UElement) IfID(condition bool, s string) *UElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *UElement) IfIDf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -802,10 +801,8 @@ func (e *UElement) IfIDf(condition bool, format string, args ...any) *UElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *UElement) IDRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -820,10 +817,8 @@ func (e *UElement) IDRemove() *UElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *UElement) Inert() *UElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -839,7 +834,7 @@ func (e *UElement) Inert() *UElement {
 // excluding them from the accessibility tree.
 func (e *UElement) IfInert(condition bool) *UElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -856,10 +851,8 @@ func (e *UElement) IfInert(condition bool) *UElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *UElement) InertRemove() *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -875,10 +868,8 @@ func (e *UElement) InertRemove() *UElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *UElement) InertIfRemove() *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -892,10 +883,8 @@ func (e *UElement) InertIfRemove() *UElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *UElement) Inputmode(c UInputmodeChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[UInputmodeChoice])
 	return e
 }
 
@@ -946,10 +935,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *UElement) InputmodeRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -959,10 +946,8 @@ func (e *UElement) InputmodeRemove() *UElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *UElement) Is(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -982,7 +967,7 @@ func (e *UElement) Isf(format string, args ...any) *UElement {
 // and extends the element type it is being applied to.
 func (e *UElement) IfIs(condition bool, s string) *UElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -994,7 +979,7 @@ func (e *UElement) IfIs(condition bool, s string) *UElement {
 // and extends the element type it is being applied to.
 func (e *UElement) IfIsf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1006,10 +991,8 @@ func (e *UElement) IfIsf(condition bool, format string, args ...any) *UElement {
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *UElement) IsRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1025,10 +1008,8 @@ func (e *UElement) IsRemove() *UElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *UElement) Itemid(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1060,7 +1041,7 @@ func (e *UElement) Itemidf(format string, args ...any) *UElement {
 // how items with the same identifier are handled.
 func (e *UElement) IfItemid(condition bool, s string) *UElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1078,7 +1059,7 @@ func (e *UElement) IfItemid(condition bool, s string) *UElement {
 // how items with the same identifier are handled.
 func (e *UElement) IfItemidf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1096,10 +1077,8 @@ func (e *UElement) IfItemidf(condition bool, format string, args ...any) *UEleme
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *UElement) ItemidRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1111,10 +1090,8 @@ func (e *UElement) ItemidRemove() *UElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *UElement) Itemprop(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1138,7 +1115,7 @@ func (e *UElement) Itempropf(format string, args ...any) *UElement {
 // <track>, and <video>.
 func (e *UElement) IfItemprop(condition bool, s string) *UElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1152,7 +1129,7 @@ func (e *UElement) IfItemprop(condition bool, s string) *UElement {
 // <track>, and <video>.
 func (e *UElement) IfItempropf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1166,10 +1143,8 @@ func (e *UElement) IfItempropf(condition bool, format string, args ...any) *UEle
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *UElement) ItempropRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1179,10 +1154,8 @@ func (e *UElement) ItempropRemove() *UElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *UElement) Itemref(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1202,7 +1175,7 @@ func (e *UElement) Itemreff(format string, args ...any) *UElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *UElement) IfItemref(condition bool, s string) *UElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1214,7 +1187,7 @@ func (e *UElement) IfItemref(condition bool, s string) *UElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *UElement) IfItemreff(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1226,10 +1199,8 @@ func (e *UElement) IfItemreff(condition bool, format string, args ...any) *UElem
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *UElement) ItemrefRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1241,10 +1212,8 @@ func (e *UElement) ItemrefRemove() *UElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *UElement) Itemscope() *UElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1257,7 +1226,7 @@ func (e *UElement) Itemscope() *UElement {
 // <object>, <source>, <track>, and <video>.
 func (e *UElement) IfItemscope(condition bool) *UElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1271,10 +1240,8 @@ func (e *UElement) IfItemscope(condition bool) *UElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *UElement) ItemscopeRemove() *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1287,10 +1254,8 @@ func (e *UElement) ItemscopeRemove() *UElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *UElement) ItemscopeIfRemove() *UElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1302,10 +1267,8 @@ func (e *UElement) ItemscopeIfRemove() *UElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *UElement) Itemtype(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1329,7 +1292,7 @@ func (e *UElement) Itemtypef(format string, args ...any) *UElement {
 // <video>.
 func (e *UElement) IfItemtype(condition bool, s string) *UElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1343,7 +1306,7 @@ func (e *UElement) IfItemtype(condition bool, s string) *UElement {
 // <video>.
 func (e *UElement) IfItemtypef(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1357,10 +1320,8 @@ func (e *UElement) IfItemtypef(condition bool, format string, args ...any) *UEle
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *UElement) ItemtypeRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1370,10 +1331,8 @@ func (e *UElement) ItemtypeRemove() *UElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *UElement) Lang(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1393,7 +1352,7 @@ func (e *UElement) Langf(format string, args ...any) *UElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *UElement) IfLang(condition bool, s string) *UElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1405,7 +1364,7 @@ func (e *UElement) IfLang(condition bool, s string) *UElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *UElement) IfLangf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1417,10 +1376,8 @@ func (e *UElement) IfLangf(condition bool, format string, args ...any) *UElement
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *UElement) LangRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1430,10 +1387,8 @@ func (e *UElement) LangRemove() *UElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *UElement) Nonce(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1453,7 +1408,7 @@ func (e *UElement) Noncef(format string, args ...any) *UElement {
 // execute.
 func (e *UElement) IfNonce(condition bool, s string) *UElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1465,7 +1420,7 @@ func (e *UElement) IfNonce(condition bool, s string) *UElement {
 // execute.
 func (e *UElement) IfNoncef(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1477,10 +1432,8 @@ func (e *UElement) IfNoncef(condition bool, format string, args ...any) *UElemen
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *UElement) NonceRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1488,16 +1441,9 @@ func (e *UElement) NonceRemove() *UElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *UElement) Part(s string) *UElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1506,7 +1452,7 @@ func (e *UElement) Part(s string) *UElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *UElement) IfPart(condition bool, s string) *UElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1516,14 +1462,8 @@ func (e *UElement) IfPart(condition bool, s string) *UElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *UElement) PartRemove(s ...string) *UElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1534,10 +1474,8 @@ func (e *UElement) PartRemove(s ...string) *UElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *UElement) Popover(c UPopoverChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[UPopoverChoice])
 	return e
 }
 
@@ -1565,10 +1503,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *UElement) PopoverRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1577,10 +1513,8 @@ func (e *UElement) PopoverRemove() *UElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *UElement) Role(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1598,7 +1532,7 @@ func (e *UElement) Rolef(format string, args ...any) *UElement {
 // role of an element.
 func (e *UElement) IfRole(condition bool, s string) *UElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1609,7 +1543,7 @@ func (e *UElement) IfRole(condition bool, s string) *UElement {
 // role of an element.
 func (e *UElement) IfRolef(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1620,10 +1554,8 @@ func (e *UElement) IfRolef(condition bool, format string, args ...any) *UElement
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *UElement) RoleRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1632,10 +1564,8 @@ func (e *UElement) RoleRemove() *UElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *UElement) Slot(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1653,7 +1583,7 @@ func (e *UElement) Slotf(format string, args ...any) *UElement {
 // value.
 func (e *UElement) IfSlot(condition bool, s string) *UElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1664,7 +1594,7 @@ func (e *UElement) IfSlot(condition bool, s string) *UElement {
 // value.
 func (e *UElement) IfSlotf(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1675,10 +1605,8 @@ func (e *UElement) IfSlotf(condition bool, format string, args ...any) *UElement
 // value.
 // Remove the attribute Slot from the element.
 func (e *UElement) SlotRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1694,10 +1622,8 @@ func (e *UElement) SlotRemove() *UElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *UElement) Spellcheck(c USpellcheckChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[USpellcheckChoice])
 	return e
 }
 
@@ -1725,10 +1651,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *UElement) SpellcheckRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1738,14 +1662,8 @@ func (e *UElement) StylePairs(pairs ...string) *UElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1760,14 +1678,8 @@ func (e *UElement) StylePairs(pairs ...string) *UElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *UElement) Style(s string) *UElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1775,7 +1687,7 @@ func (e *UElement) Style(s string) *UElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1784,7 +1696,7 @@ func (e *UElement) Style(s string) *UElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *UElement) IfStyle(condition bool, s string) *UElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1792,15 +1704,7 @@ func (e *UElement) IfStyle(condition bool, s string) *UElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *UElement) StyleAdd(k string, v string) *UElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1814,7 +1718,7 @@ func (e *UElement) StyleAddf(k string, format string, args ...any) *UElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *UElement) IfStyleAdd(condition bool, k string, v string) *UElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1823,7 +1727,7 @@ func (e *UElement) IfStyleAdd(condition bool, k string, v string) *UElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *UElement) IfStyleAddf(condition bool, k string, format string, args ...any) *UElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1832,21 +1736,15 @@ func (e *UElement) IfStyleAddf(condition bool, k string, format string, args ...
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *UElement) StyleMap(m map[string]string) *UElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1855,14 +1753,8 @@ func (e *UElement) StyleMap(m map[string]string) *UElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *UElement) StyleRemove(keys ...string) *UElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1880,10 +1772,8 @@ func (e *UElement) StyleRemove(keys ...string) *UElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *UElement) Tabindex(i int) *UElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1902,7 +1792,7 @@ func (e *UElement) Tabindex(i int) *UElement {
 // their relative position in the document.
 func (e *UElement) IfTabindex(condition bool, i int) *UElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1922,10 +1812,8 @@ func (e *UElement) IfTabindex(condition bool, i int) *UElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *UElement) TabindexRemove() *UElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1946,10 +1834,8 @@ func (e *UElement) TabindexRemove() *UElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *UElement) Title(s string) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -1991,7 +1877,7 @@ func (e *UElement) Titlef(format string, args ...any) *UElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *UElement) IfTitle(condition bool, s string) *UElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2014,7 +1900,7 @@ func (e *UElement) IfTitle(condition bool, s string) *UElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *UElement) IfTitlef(condition bool, format string, args ...any) *UElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2037,10 +1923,8 @@ func (e *UElement) IfTitlef(condition bool, format string, args ...any) *UElemen
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *UElement) TitleRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2049,10 +1933,8 @@ func (e *UElement) TitleRemove() *UElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *UElement) Translate(c UTranslateChoice) *UElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[UTranslateChoice])
 	return e
 }
 
@@ -2073,9 +1955,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *UElement) TranslateRemove() *UElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
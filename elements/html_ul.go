@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <ul> element represents an unordered list of items, typically
@@ -22,18 +20,79 @@ type UlElement struct {
 // with the tag "ul" during rendering.
 func Ul(children ...ElementRenderer) *UlElement {
 	e := NewElement("ul", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &UlElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *UlElement) Clone() *UlElement {
+	return &UlElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *UlElement) CloneShallow() *UlElement {
+	return &UlElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *UlElement) Freeze() *UlElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *UlElement) writable() *UlElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *UlElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *UlElement) choiceType(name string) func(string) any {
+	switch name {
+	case "type":
+		return choiceValue[UlTypeChoice]
+	case "autocapitalize":
+		return choiceValue[UlAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[UlContenteditableChoice]
+	case "dir":
+		return choiceValue[UlDirChoice]
+	case "draggable":
+		return choiceValue[UlDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[UlEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[UlHiddenChoice]
+	case "inputmode":
+		return choiceValue[UlInputmodeChoice]
+	case "popover":
+		return choiceValue[UlPopoverChoice]
+	case "spellcheck":
+		return choiceValue[UlSpellcheckChoice]
+	case "translate":
+		return choiceValue[UlTranslateChoice]
+	}
+	return nil
+}
+
 func (e *UlElement) Children(children ...ElementRenderer) *UlElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *UlElement) IfChildren(condition bool, children ...ElementRenderer) *UlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +100,30 @@ func (e *UlElement) IfChildren(condition bool, children ...ElementRenderer) *UlE
 
 func (e *UlElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *UlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *UlElement) BoolAttr(name string) *UlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *UlElement) BoolAttrRemove(name string) *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *UlElement) IfBoolAttr(condition bool, name string) *UlElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +134,40 @@ func (e *UlElement) BoolAttrf(format string, args ...any) *UlElement {
 
 func (e *UlElement) IfBoolAttrf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *UlElement) BoolAttrs(names ...string) *UlElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *UlElement) IfBoolAttrs(condition bool, names ...string) *UlElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *UlElement) Attr(name, value string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *UlElement) RawAttr(name, value string) *UlElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *UlElement) IfAttr(condition bool, name, value string) *UlElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +178,52 @@ func (e *UlElement) Attrf(name, format string, args ...any) *UlElement {
 
 func (e *UlElement) IfAttrf(condition bool, name, format string, args ...any) *UlElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *UlElement) Attrs(attrs ...string) *UlElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *UlElement) IfAttrs(condition bool, attrs ...string) *UlElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *UlElement) AttrsMap(attrs map[string]string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *UlElement) IfAttrsMap(condition bool, attrs map[string]string) *UlElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *UlElement) Text(text string) *UlElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *UlElement) Textf(format string, args ...any) *UlElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *UlElement) IfText(condition bool, text string) *UlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +231,28 @@ func (e *UlElement) IfText(condition bool, text string) *UlElement {
 
 func (e *UlElement) IfTextf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *UlElement) Raw(text string) *UlElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *UlElement) Escaped(text string) *UlElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *UlElement) IfEscaped(condition bool, text string) *UlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +264,7 @@ func (e *UlElement) Escapedf(format string, args ...any) *UlElement {
 
 func (e *UlElement) IfEscapedf(condition bool, format string, args ...any) *UlElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +272,8 @@ func (e *UlElement) IfEscapedf(condition bool, format string, args ...any) *UlEl
 
 // Kind of list marker.
 func (e *UlElement) Type(c UlTypeChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[UlTypeChoice])
 	return e
 }
 
@@ -230,10 +291,8 @@ const (
 // Kind of list marker.
 // Remove the attribute Type from the element.
 func (e *UlElement) TypeRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
@@ -242,10 +301,8 @@ func (e *UlElement) TypeRemove() *UlElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *UlElement) Accesskey(r rune) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -255,7 +312,7 @@ func (e *UlElement) Accesskey(r rune) *UlElement {
 // can be generated by the keyboard).
 func (e *UlElement) IfAccesskey(condition bool, r rune) *UlElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -266,10 +323,8 @@ func (e *UlElement) IfAccesskey(condition bool, r rune) *UlElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *UlElement) AccesskeyRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -285,10 +340,8 @@ func (e *UlElement) AccesskeyRemove() *UlElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *UlElement) Autocapitalize(c UlAutocapitalizeChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[UlAutocapitalizeChoice])
 	return e
 }
 
@@ -322,10 +375,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *UlElement) AutocapitalizeRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -344,10 +395,8 @@ func (e *UlElement) AutocapitalizeRemove() *UlElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *UlElement) Autofocus() *UlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -367,7 +416,7 @@ func (e *UlElement) Autofocus() *UlElement {
 // created by the preceding content.
 func (e *UlElement) IfAutofocus(condition bool) *UlElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -388,10 +437,8 @@ func (e *UlElement) IfAutofocus(condition bool) *UlElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *UlElement) AutofocusRemove() *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -411,10 +458,8 @@ func (e *UlElement) AutofocusRemove() *UlElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *UlElement) AutofocusIfRemove() *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -423,16 +468,9 @@ func (e *UlElement) AutofocusIfRemove() *UlElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *UlElement) Class(s string) *UlElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -442,7 +480,7 @@ func (e *UlElement) Class(s string) *UlElement {
 // document.getElementsByClassName.
 func (e *UlElement) IfClass(condition bool, s string) *UlElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -453,14 +491,8 @@ func (e *UlElement) IfClass(condition bool, s string) *UlElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *UlElement) ClassRemove(s ...string) *UlElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -468,10 +500,8 @@ func (e *UlElement) ClassRemove(s ...string) *UlElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *UlElement) Contenteditable(c UlContenteditableChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[UlContenteditableChoice])
 	return e
 }
 
@@ -494,10 +524,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *UlElement) ContenteditableRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -515,10 +543,8 @@ func (e *UlElement) ContenteditableRemove() *UlElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *UlElement) Dir(c UlDirChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[UlDirChoice])
 	return e
 }
 
@@ -552,10 +578,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *UlElement) DirRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -563,10 +587,8 @@ func (e *UlElement) DirRemove() *UlElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *UlElement) Draggable(c UlDraggableChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[UlDraggableChoice])
 	return e
 }
 
@@ -592,20 +614,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *UlElement) DraggableRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *UlElement) Enterkeyhint(c UlEnterkeyhintChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[UlEnterkeyhintChoice])
 	return e
 }
 
@@ -635,10 +653,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *UlElement) EnterkeyhintRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -660,16 +676,9 @@ func (e *UlElement) EnterkeyhintRemove() *UlElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *UlElement) Exportparts(s string) *UlElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -692,7 +701,7 @@ func (e *UlElement) Exportparts(s string) *UlElement {
 // the current structure.
 func (e *UlElement) IfExportparts(condition bool, s string) *UlElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -716,14 +725,8 @@ func (e *UlElement) IfExportparts(condition bool, s string) *UlElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *UlElement) ExportpartsRemove(s ...string) *UlElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -740,10 +743,8 @@ func (e *UlElement) ExportpartsRemove(s ...string) *UlElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *UlElement) Hidden(c UlHiddenChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[UlHiddenChoice])
 	return e
 }
 
@@ -779,10 +780,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *UlElement) HiddenRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -790,10 +789,8 @@ func (e *UlElement) HiddenRemove() *UlElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *UlElement) ID(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -809,7 +806,7 @@ func (e *UlElement) IDf(format string, args ...any) *UlElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *UlElement) IfID(condition bool, s string) *UlElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -819,7 +816,7 @@ func (e *UlElement) IfID(condition bool, s string) *UlElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *UlElement) IfIDf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -829,10 +826,8 @@ func (e *UlElement) IfIDf(condition bool, format string, args ...any) *UlElement
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *UlElement) IDRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -847,10 +842,8 @@ func (e *UlElement) IDRemove() *UlElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *UlElement) Inert() *UlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -866,7 +859,7 @@ func (e *UlElement) Inert() *UlElement {
 // excluding them from the accessibility tree.
 func (e *UlElement) IfInert(condition bool) *UlElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -883,10 +876,8 @@ func (e *UlElement) IfInert(condition bool) *UlElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *UlElement) InertRemove() *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -902,10 +893,8 @@ func (e *UlElement) InertRemove() *UlElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *UlElement) InertIfRemove() *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -919,10 +908,8 @@ func (e *UlElement) InertIfRemove() *UlElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *UlElement) Inputmode(c UlInputmodeChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[UlInputmodeChoice])
 	return e
 }
 
@@ -973,10 +960,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *UlElement) InputmodeRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -986,10 +971,8 @@ func (e *UlElement) InputmodeRemove() *UlElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *UlElement) Is(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1009,7 +992,7 @@ func (e *UlElement) Isf(format string, args ...any) *UlElement {
 // and extends the element type it is being applied to.
 func (e *UlElement) IfIs(condition bool, s string) *UlElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1021,7 +1004,7 @@ func (e *UlElement) IfIs(condition bool, s string) *UlElement {
 // and extends the element type it is being applied to.
 func (e *UlElement) IfIsf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1033,10 +1016,8 @@ func (e *UlElement) IfIsf(condition bool, format string, args ...any) *UlElement
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *UlElement) IsRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1052,10 +1033,8 @@ func (e *UlElement) IsRemove() *UlElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *UlElement) Itemid(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1087,7 +1066,7 @@ func (e *UlElement) Itemidf(format string, args ...any) *UlElement {
 // how items with the same identifier are handled.
 func (e *UlElement) IfItemid(condition bool, s string) *UlElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1105,7 +1084,7 @@ func (e *UlElement) IfItemid(condition bool, s string) *UlElement {
 // how items with the same identifier are handled.
 func (e *UlElement) IfItemidf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1123,10 +1102,8 @@ func (e *UlElement) IfItemidf(condition bool, format string, args ...any) *UlEle
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *UlElement) ItemidRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1138,10 +1115,8 @@ func (e *UlElement) ItemidRemove() *UlElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *UlElement) Itemprop(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1165,7 +1140,7 @@ func (e *UlElement) Itempropf(format string, args ...any) *UlElement {
 // <track>, and <video>.
 func (e *UlElement) IfItemprop(condition bool, s string) *UlElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1179,7 +1154,7 @@ func (e *UlElement) IfItemprop(condition bool, s string) *UlElement {
 // <track>, and <video>.
 func (e *UlElement) IfItempropf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1193,10 +1168,8 @@ func (e *UlElement) IfItempropf(condition bool, format string, args ...any) *UlE
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *UlElement) ItempropRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1206,10 +1179,8 @@ func (e *UlElement) ItempropRemove() *UlElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *UlElement) Itemref(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1229,7 +1200,7 @@ func (e *UlElement) Itemreff(format string, args ...any) *UlElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *UlElement) IfItemref(condition bool, s string) *UlElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1241,7 +1212,7 @@ func (e *UlElement) IfItemref(condition bool, s string) *UlElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *UlElement) IfItemreff(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1253,10 +1224,8 @@ func (e *UlElement) IfItemreff(condition bool, format string, args ...any) *UlEl
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *UlElement) ItemrefRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1268,10 +1237,8 @@ func (e *UlElement) ItemrefRemove() *UlElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *UlElement) Itemscope() *UlElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1284,7 +1251,7 @@ func (e *UlElement) Itemscope() *UlElement {
 // <object>, <source>, <track>, and <video>.
 func (e *UlElement) IfItemscope(condition bool) *UlElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1298,10 +1265,8 @@ func (e *UlElement) IfItemscope(condition bool) *UlElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *UlElement) ItemscopeRemove() *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1314,10 +1279,8 @@ func (e *UlElement) ItemscopeRemove() *UlElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *UlElement) ItemscopeIfRemove() *UlElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1329,10 +1292,8 @@ func (e *UlElement) ItemscopeIfRemove() *UlElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *UlElement) Itemtype(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1356,7 +1317,7 @@ func (e *UlElement) Itemtypef(format string, args ...any) *UlElement {
 // <video>.
 func (e *UlElement) IfItemtype(condition bool, s string) *UlElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1370,7 +1331,7 @@ func (e *UlElement) IfItemtype(condition bool, s string) *UlElement {
 // <video>.
 func (e *UlElement) IfItemtypef(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1384,10 +1345,8 @@ func (e *UlElement) IfItemtypef(condition bool, format string, args ...any) *UlE
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *UlElement) ItemtypeRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1397,10 +1356,8 @@ func (e *UlElement) ItemtypeRemove() *UlElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *UlElement) Lang(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1420,7 +1377,7 @@ func (e *UlElement) Langf(format string, args ...any) *UlElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *UlElement) IfLang(condition bool, s string) *UlElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1432,7 +1389,7 @@ func (e *UlElement) IfLang(condition bool, s string) *UlElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *UlElement) IfLangf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1444,10 +1401,8 @@ func (e *UlElement) IfLangf(condition bool, format string, args ...any) *UlEleme
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *UlElement) LangRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1457,10 +1412,8 @@ func (e *UlElement) LangRemove() *UlElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *UlElement) Nonce(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1480,7 +1433,7 @@ func (e *UlElement) Noncef(format string, args ...any) *UlElement {
 // execute.
 func (e *UlElement) IfNonce(condition bool, s string) *UlElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1492,7 +1445,7 @@ func (e *UlElement) IfNonce(condition bool, s string) *UlElement {
 // execute.
 func (e *UlElement) IfNoncef(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1504,10 +1457,8 @@ func (e *UlElement) IfNoncef(condition bool, format string, args ...any) *UlElem
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *UlElement) NonceRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1515,16 +1466,9 @@ func (e *UlElement) NonceRemove() *UlElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *UlElement) Part(s string) *UlElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1533,7 +1477,7 @@ func (e *UlElement) Part(s string) *UlElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *UlElement) IfPart(condition bool, s string) *UlElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1543,14 +1487,8 @@ func (e *UlElement) IfPart(condition bool, s string) *UlElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *UlElement) PartRemove(s ...string) *UlElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1561,10 +1499,8 @@ func (e *UlElement) PartRemove(s ...string) *UlElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *UlElement) Popover(c UlPopoverChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[UlPopoverChoice])
 	return e
 }
 
@@ -1592,10 +1528,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *UlElement) PopoverRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1604,10 +1538,8 @@ func (e *UlElement) PopoverRemove() *UlElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *UlElement) Role(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1625,7 +1557,7 @@ func (e *UlElement) Rolef(format string, args ...any) *UlElement {
 // role of an element.
 func (e *UlElement) IfRole(condition bool, s string) *UlElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1636,7 +1568,7 @@ func (e *UlElement) IfRole(condition bool, s string) *UlElement {
 // role of an element.
 func (e *UlElement) IfRolef(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1647,10 +1579,8 @@ func (e *UlElement) IfRolef(condition bool, format string, args ...any) *UlEleme
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *UlElement) RoleRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -1659,10 +1589,8 @@ func (e *UlElement) RoleRemove() *UlElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *UlElement) Slot(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -1680,7 +1608,7 @@ func (e *UlElement) Slotf(format string, args ...any) *UlElement {
 // value.
 func (e *UlElement) IfSlot(condition bool, s string) *UlElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -1691,7 +1619,7 @@ func (e *UlElement) IfSlot(condition bool, s string) *UlElement {
 // value.
 func (e *UlElement) IfSlotf(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1702,10 +1630,8 @@ func (e *UlElement) IfSlotf(condition bool, format string, args ...any) *UlEleme
 // value.
 // Remove the attribute Slot from the element.
 func (e *UlElement) SlotRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -1721,10 +1647,8 @@ func (e *UlElement) SlotRemove() *UlElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *UlElement) Spellcheck(c UlSpellcheckChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[UlSpellcheckChoice])
 	return e
 }
 
@@ -1752,10 +1676,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *UlElement) SpellcheckRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -1765,14 +1687,8 @@ func (e *UlElement) StylePairs(pairs ...string) *UlElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1787,14 +1703,8 @@ func (e *UlElement) StylePairs(pairs ...string) *UlElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *UlElement) Style(s string) *UlElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1802,7 +1712,7 @@ func (e *UlElement) Style(s string) *UlElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1811,7 +1721,7 @@ func (e *UlElement) Style(s string) *UlElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *UlElement) IfStyle(condition bool, s string) *UlElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -1819,15 +1729,7 @@ func (e *UlElement) IfStyle(condition bool, s string) *UlElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *UlElement) StyleAdd(k string, v string) *UlElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1841,7 +1743,7 @@ func (e *UlElement) StyleAddf(k string, format string, args ...any) *UlElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *UlElement) IfStyleAdd(condition bool, k string, v string) *UlElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1850,7 +1752,7 @@ func (e *UlElement) IfStyleAdd(condition bool, k string, v string) *UlElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *UlElement) IfStyleAddf(condition bool, k string, format string, args ...any) *UlElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1859,21 +1761,15 @@ func (e *UlElement) IfStyleAddf(condition bool, k string, format string, args ..
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *UlElement) StyleMap(m map[string]string) *UlElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1882,14 +1778,8 @@ func (e *UlElement) StyleMap(m map[string]string) *UlElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *UlElement) StyleRemove(keys ...string) *UlElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -1907,10 +1797,8 @@ func (e *UlElement) StyleRemove(keys ...string) *UlElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *UlElement) Tabindex(i int) *UlElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -1929,7 +1817,7 @@ func (e *UlElement) Tabindex(i int) *UlElement {
 // their relative position in the document.
 func (e *UlElement) IfTabindex(condition bool, i int) *UlElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -1949,10 +1837,8 @@ func (e *UlElement) IfTabindex(condition bool, i int) *UlElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *UlElement) TabindexRemove() *UlElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -1973,10 +1859,8 @@ func (e *UlElement) TabindexRemove() *UlElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *UlElement) Title(s string) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2018,7 +1902,7 @@ func (e *UlElement) Titlef(format string, args ...any) *UlElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *UlElement) IfTitle(condition bool, s string) *UlElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2041,7 +1925,7 @@ func (e *UlElement) IfTitle(condition bool, s string) *UlElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *UlElement) IfTitlef(condition bool, format string, args ...any) *UlElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2064,10 +1948,8 @@ func (e *UlElement) IfTitlef(condition bool, format string, args ...any) *UlElem
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *UlElement) TitleRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2076,10 +1958,8 @@ func (e *UlElement) TitleRemove() *UlElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *UlElement) Translate(c UlTranslateChoice) *UlElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[UlTranslateChoice])
 	return e
 }
 
@@ -2100,9 +1980,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *UlElement) TranslateRemove() *UlElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The HTML <video> element is used to embed video content.
@@ -21,18 +19,81 @@ type VideoElement struct {
 // with the tag "video" during rendering.
 func Video(children ...ElementRenderer) *VideoElement {
 	e := NewElement("video", children...)
+	e.namespace = namespaceHTML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &VideoElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *VideoElement) Clone() *VideoElement {
+	return &VideoElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *VideoElement) CloneShallow() *VideoElement {
+	return &VideoElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *VideoElement) Freeze() *VideoElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *VideoElement) writable() *VideoElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *VideoElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *VideoElement) choiceType(name string) func(string) any {
+	switch name {
+	case "crossorigin":
+		return choiceValue[VideoCrossoriginChoice]
+	case "preload":
+		return choiceValue[VideoPreloadChoice]
+	case "autocapitalize":
+		return choiceValue[VideoAutocapitalizeChoice]
+	case "contenteditable":
+		return choiceValue[VideoContenteditableChoice]
+	case "dir":
+		return choiceValue[VideoDirChoice]
+	case "draggable":
+		return choiceValue[VideoDraggableChoice]
+	case "enterkeyhint":
+		return choiceValue[VideoEnterkeyhintChoice]
+	case "hidden":
+		return choiceValue[VideoHiddenChoice]
+	case "inputmode":
+		return choiceValue[VideoInputmodeChoice]
+	case "popover":
+		return choiceValue[VideoPopoverChoice]
+	case "spellcheck":
+		return choiceValue[VideoSpellcheckChoice]
+	case "translate":
+		return choiceValue[VideoTranslateChoice]
+	}
+	return nil
+}
+
 func (e *VideoElement) Children(children ...ElementRenderer) *VideoElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *VideoElement) IfChildren(condition bool, children ...ElementRenderer) *VideoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +101,30 @@ func (e *VideoElement) IfChildren(condition bool, children ...ElementRenderer) *
 
 func (e *VideoElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *VideoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *VideoElement) BoolAttr(name string) *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *VideoElement) BoolAttrRemove(name string) *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *VideoElement) IfBoolAttr(condition bool, name string) *VideoElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +135,40 @@ func (e *VideoElement) BoolAttrf(format string, args ...any) *VideoElement {
 
 func (e *VideoElement) IfBoolAttrf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *VideoElement) BoolAttrs(names ...string) *VideoElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *VideoElement) IfBoolAttrs(condition bool, names ...string) *VideoElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *VideoElement) Attr(name, value string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *VideoElement) RawAttr(name, value string) *VideoElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *VideoElement) IfAttr(condition bool, name, value string) *VideoElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +179,52 @@ func (e *VideoElement) Attrf(name, format string, args ...any) *VideoElement {
 
 func (e *VideoElement) IfAttrf(condition bool, name, format string, args ...any) *VideoElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *VideoElement) Attrs(attrs ...string) *VideoElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *VideoElement) IfAttrs(condition bool, attrs ...string) *VideoElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *VideoElement) AttrsMap(attrs map[string]string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *VideoElement) IfAttrsMap(condition bool, attrs map[string]string) *VideoElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *VideoElement) Text(text string) *VideoElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *VideoElement) Textf(format string, args ...any) *VideoElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *VideoElement) IfText(condition bool, text string) *VideoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +232,28 @@ func (e *VideoElement) IfText(condition bool, text string) *VideoElement {
 
 func (e *VideoElement) IfTextf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *VideoElement) Raw(text string) *VideoElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *VideoElement) Escaped(text string) *VideoElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *VideoElement) IfEscaped(condition bool, text string) *VideoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +265,7 @@ func (e *VideoElement) Escapedf(format string, args ...any) *VideoElement {
 
 func (e *VideoElement) IfEscapedf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,17 +273,15 @@ func (e *VideoElement) IfEscapedf(condition bool, format string, args ...any) *V
 
 // The video should play as soon as possible.
 func (e *VideoElement) Autoplay() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autoplay", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autoplay")
 	return e
 }
 
 // The video should play as soon as possible.
 func (e *VideoElement) IfAutoplay(condition bool) *VideoElement {
 	if condition {
-		e.Autoplay()
+		e = e.Autoplay()
 	}
 	return e
 }
@@ -226,36 +289,30 @@ func (e *VideoElement) IfAutoplay(condition bool) *VideoElement {
 // Remove the attribute Autoplay from the element.
 // The video should play as soon as possible.
 func (e *VideoElement) AutoplayRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autoplay")
+	e = e.writable()
+	e.removeAttribute("autoplay")
 	return e
 }
 
 // Remove the attribute Autoplay from the element.
 // The video should play as soon as possible.
 func (e *VideoElement) AutoplayIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autoplay")
+	e = e.writable()
+	e.removeAttribute("autoplay")
 	return e
 }
 
 // Show user agent controls.
 func (e *VideoElement) Controls() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("controls", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("controls")
 	return e
 }
 
 // Show user agent controls.
 func (e *VideoElement) IfControls(condition bool) *VideoElement {
 	if condition {
-		e.Controls()
+		e = e.Controls()
 	}
 	return e
 }
@@ -263,29 +320,23 @@ func (e *VideoElement) IfControls(condition bool) *VideoElement {
 // Remove the attribute Controls from the element.
 // Show user agent controls.
 func (e *VideoElement) ControlsRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("controls")
+	e = e.writable()
+	e.removeAttribute("controls")
 	return e
 }
 
 // Remove the attribute Controls from the element.
 // Show user agent controls.
 func (e *VideoElement) ControlsIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("controls")
+	e = e.writable()
+	e.removeAttribute("controls")
 	return e
 }
 
 // How the element handles crossorigin requests.
 func (e *VideoElement) Crossorigin(c VideoCrossoriginChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("crossorigin", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("crossorigin", string(c), choiceValue[VideoCrossoriginChoice])
 	return e
 }
 
@@ -303,26 +354,22 @@ const (
 // How the element handles crossorigin requests.
 // Remove the attribute Crossorigin from the element.
 func (e *VideoElement) CrossoriginRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("crossorigin")
+	e = e.writable()
+	e.removeAttribute("crossorigin")
 	return e
 }
 
 // Vertical dimension.
 func (e *VideoElement) Height(i int) *VideoElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("height", i)
+	e = e.writable()
+	e.setIntAttribute("height", i)
 	return e
 }
 
 // Vertical dimension.
 func (e *VideoElement) IfHeight(condition bool, i int) *VideoElement {
 	if condition {
-		e.Height(i)
+		e = e.Height(i)
 	}
 	return e
 }
@@ -330,26 +377,22 @@ func (e *VideoElement) IfHeight(condition bool, i int) *VideoElement {
 // Vertical dimension.
 // Remove the attribute Height from the element.
 func (e *VideoElement) HeightRemove() *VideoElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // Whether to loop the media resource.
 func (e *VideoElement) Loop() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("loop", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("loop")
 	return e
 }
 
 // Whether to loop the media resource.
 func (e *VideoElement) IfLoop(condition bool) *VideoElement {
 	if condition {
-		e.Loop()
+		e = e.Loop()
 	}
 	return e
 }
@@ -357,36 +400,30 @@ func (e *VideoElement) IfLoop(condition bool) *VideoElement {
 // Remove the attribute Loop from the element.
 // Whether to loop the media resource.
 func (e *VideoElement) LoopRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("loop")
+	e = e.writable()
+	e.removeAttribute("loop")
 	return e
 }
 
 // Remove the attribute Loop from the element.
 // Whether to loop the media resource.
 func (e *VideoElement) LoopIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("loop")
+	e = e.writable()
+	e.removeAttribute("loop")
 	return e
 }
 
 // Whether to mute the media resource by default.
 func (e *VideoElement) Muted() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("muted", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("muted")
 	return e
 }
 
 // Whether to mute the media resource by default.
 func (e *VideoElement) IfMuted(condition bool) *VideoElement {
 	if condition {
-		e.Muted()
+		e = e.Muted()
 	}
 	return e
 }
@@ -394,30 +431,24 @@ func (e *VideoElement) IfMuted(condition bool) *VideoElement {
 // Remove the attribute Muted from the element.
 // Whether to mute the media resource by default.
 func (e *VideoElement) MutedRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("muted")
+	e = e.writable()
+	e.removeAttribute("muted")
 	return e
 }
 
 // Remove the attribute Muted from the element.
 // Whether to mute the media resource by default.
 func (e *VideoElement) MutedIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("muted")
+	e = e.writable()
+	e.removeAttribute("muted")
 	return e
 }
 
 // Encourage the user agent to display video content within the element's
 // playback area.
 func (e *VideoElement) Playsinline() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("playsinline", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("playsinline")
 	return e
 }
 
@@ -425,7 +456,7 @@ func (e *VideoElement) Playsinline() *VideoElement {
 // playback area.
 func (e *VideoElement) IfPlaysinline(condition bool) *VideoElement {
 	if condition {
-		e.Playsinline()
+		e = e.Playsinline()
 	}
 	return e
 }
@@ -434,10 +465,8 @@ func (e *VideoElement) IfPlaysinline(condition bool) *VideoElement {
 // Encourage the user agent to display video content within the element's
 // playback area.
 func (e *VideoElement) PlaysinlineRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("playsinline")
+	e = e.writable()
+	e.removeAttribute("playsinline")
 	return e
 }
 
@@ -445,19 +474,15 @@ func (e *VideoElement) PlaysinlineRemove() *VideoElement {
 // Encourage the user agent to display video content within the element's
 // playback area.
 func (e *VideoElement) PlaysinlineIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("playsinline")
+	e = e.writable()
+	e.removeAttribute("playsinline")
 	return e
 }
 
 // Poster frame to show prior to video playback.
 func (e *VideoElement) Poster(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("poster", s)
+	e = e.writable()
+	e.setStringAttribute("poster", s)
 	return e
 }
 
@@ -469,7 +494,7 @@ func (e *VideoElement) Posterf(format string, args ...any) *VideoElement {
 // Poster frame to show prior to video playback.
 func (e *VideoElement) IfPoster(condition bool, s string) *VideoElement {
 	if condition {
-		e.Poster(s)
+		e = e.Poster(s)
 	}
 	return e
 }
@@ -477,7 +502,7 @@ func (e *VideoElement) IfPoster(condition bool, s string) *VideoElement {
 // Poster frame to show prior to video playback.
 func (e *VideoElement) IfPosterf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Poster(fmt.Sprintf(format, args...))
+		e = e.Poster(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -485,19 +510,15 @@ func (e *VideoElement) IfPosterf(condition bool, format string, args ...any) *Vi
 // Poster frame to show prior to video playback.
 // Remove the attribute Poster from the element.
 func (e *VideoElement) PosterRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("poster")
+	e = e.writable()
+	e.removeAttribute("poster")
 	return e
 }
 
 // Hints how much buffering the media resource will likely need.
 func (e *VideoElement) Preload(c VideoPreloadChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("preload", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("preload", string(c), choiceValue[VideoPreloadChoice])
 	return e
 }
 
@@ -515,19 +536,15 @@ const (
 // Hints how much buffering the media resource will likely need.
 // Remove the attribute Preload from the element.
 func (e *VideoElement) PreloadRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("preload")
+	e = e.writable()
+	e.removeAttribute("preload")
 	return e
 }
 
 // Address of the resource.
 func (e *VideoElement) Src(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("src", s)
+	e = e.writable()
+	e.setStringAttribute("src", s)
 	return e
 }
 
@@ -539,7 +556,7 @@ func (e *VideoElement) Srcf(format string, args ...any) *VideoElement {
 // Address of the resource.
 func (e *VideoElement) IfSrc(condition bool, s string) *VideoElement {
 	if condition {
-		e.Src(s)
+		e = e.Src(s)
 	}
 	return e
 }
@@ -547,7 +564,7 @@ func (e *VideoElement) IfSrc(condition bool, s string) *VideoElement {
 // Address of the resource.
 func (e *VideoElement) IfSrcf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Src(fmt.Sprintf(format, args...))
+		e = e.Src(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -555,26 +572,22 @@ func (e *VideoElement) IfSrcf(condition bool, format string, args ...any) *Video
 // Address of the resource.
 // Remove the attribute Src from the element.
 func (e *VideoElement) SrcRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("src")
+	e = e.writable()
+	e.removeAttribute("src")
 	return e
 }
 
 // Horizontal dimension.
 func (e *VideoElement) Width(i int) *VideoElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("width", i)
+	e = e.writable()
+	e.setIntAttribute("width", i)
 	return e
 }
 
 // Horizontal dimension.
 func (e *VideoElement) IfWidth(condition bool, i int) *VideoElement {
 	if condition {
-		e.Width(i)
+		e = e.Width(i)
 	}
 	return e
 }
@@ -582,10 +595,8 @@ func (e *VideoElement) IfWidth(condition bool, i int) *VideoElement {
 // Horizontal dimension.
 // Remove the attribute Width from the element.
 func (e *VideoElement) WidthRemove() *VideoElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
@@ -594,10 +605,8 @@ func (e *VideoElement) WidthRemove() *VideoElement {
 // single printable character (which includes accented and other characters that
 // can be generated by the keyboard).
 func (e *VideoElement) Accesskey(r rune) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accesskey", string(r))
+	e = e.writable()
+	e.setRuneAttribute("accesskey", r)
 	return e
 }
 
@@ -607,7 +616,7 @@ func (e *VideoElement) Accesskey(r rune) *VideoElement {
 // can be generated by the keyboard).
 func (e *VideoElement) IfAccesskey(condition bool, r rune) *VideoElement {
 	if condition {
-		e.Accesskey(r)
+		e = e.Accesskey(r)
 	}
 	return e
 }
@@ -618,10 +627,8 @@ func (e *VideoElement) IfAccesskey(condition bool, r rune) *VideoElement {
 // can be generated by the keyboard).
 // Remove the attribute Accesskey from the element.
 func (e *VideoElement) AccesskeyRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accesskey")
+	e = e.writable()
+	e.removeAttribute("accesskey")
 	return e
 }
 
@@ -637,10 +644,8 @@ func (e *VideoElement) AccesskeyRemove() *VideoElement {
 // behavior varies between browsers. For example: Chrome and Safari default to
 // on/sentences Firefox defaults to off/none.
 func (e *VideoElement) Autocapitalize(c VideoAutocapitalizeChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("autocapitalize", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("autocapitalize", string(c), choiceValue[VideoAutocapitalizeChoice])
 	return e
 }
 
@@ -674,10 +679,8 @@ const (
 // on/sentences Firefox defaults to off/none.
 // Remove the attribute Autocapitalize from the element.
 func (e *VideoElement) AutocapitalizeRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("autocapitalize")
+	e = e.writable()
+	e.removeAttribute("autocapitalize")
 	return e
 }
 
@@ -696,10 +699,8 @@ func (e *VideoElement) AutocapitalizeRemove() *VideoElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *VideoElement) Autofocus() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("autofocus", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("autofocus")
 	return e
 }
 
@@ -719,7 +720,7 @@ func (e *VideoElement) Autofocus() *VideoElement {
 // created by the preceding content.
 func (e *VideoElement) IfAutofocus(condition bool) *VideoElement {
 	if condition {
-		e.Autofocus()
+		e = e.Autofocus()
 	}
 	return e
 }
@@ -740,10 +741,8 @@ func (e *VideoElement) IfAutofocus(condition bool) *VideoElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *VideoElement) AutofocusRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -763,10 +762,8 @@ func (e *VideoElement) AutofocusRemove() *VideoElement {
 // label, and the sighted user on a small device will equally miss the context
 // created by the preceding content.
 func (e *VideoElement) AutofocusIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("autofocus")
+	e = e.writable()
+	e.removeAttribute("autofocus")
 	return e
 }
 
@@ -775,16 +772,9 @@ func (e *VideoElement) AutofocusIfRemove() *VideoElement {
 // specific elements via the class selectors or functions like the DOM method
 // document.getElementsByClassName.
 func (e *VideoElement) Class(s string) *VideoElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -794,7 +784,7 @@ func (e *VideoElement) Class(s string) *VideoElement {
 // document.getElementsByClassName.
 func (e *VideoElement) IfClass(condition bool, s string) *VideoElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -805,14 +795,8 @@ func (e *VideoElement) IfClass(condition bool, s string) *VideoElement {
 // document.getElementsByClassName.
 // Remove the values from the attribute Class in the element.
 func (e *VideoElement) ClassRemove(s ...string) *VideoElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -820,10 +804,8 @@ func (e *VideoElement) ClassRemove(s ...string) *VideoElement {
 // the element should be editable by the user. If so, the browser modifies its
 // widget to allow editing.
 func (e *VideoElement) Contenteditable(c VideoContenteditableChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("contenteditable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("contenteditable", string(c), choiceValue[VideoContenteditableChoice])
 	return e
 }
 
@@ -846,10 +828,8 @@ const (
 // widget to allow editing.
 // Remove the attribute Contenteditable from the element.
 func (e *VideoElement) ContenteditableRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("contenteditable")
+	e = e.writable()
+	e.removeAttribute("contenteditable")
 	return e
 }
 
@@ -867,10 +847,8 @@ func (e *VideoElement) ContenteditableRemove() *VideoElement {
 // directionality, like data coming from user input, eventually stored in a
 // database.
 func (e *VideoElement) Dir(c VideoDirChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[VideoDirChoice])
 	return e
 }
 
@@ -904,10 +882,8 @@ const (
 // database.
 // Remove the attribute Dir from the element.
 func (e *VideoElement) DirRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
@@ -915,10 +891,8 @@ func (e *VideoElement) DirRemove() *VideoElement {
 // whether the element can be dragged, either with native browser behavior or
 // the HTML Drag and Drop API.
 func (e *VideoElement) Draggable(c VideoDraggableChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("draggable", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("draggable", string(c), choiceValue[VideoDraggableChoice])
 	return e
 }
 
@@ -944,20 +918,16 @@ const (
 // the HTML Drag and Drop API.
 // Remove the attribute Draggable from the element.
 func (e *VideoElement) DraggableRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("draggable")
+	e = e.writable()
+	e.removeAttribute("draggable")
 	return e
 }
 
 // The enterkeyhint global Attribute is an enumerated attribute defining what
 // action label (or icon) to present for the enter key on virtual keyboards.
 func (e *VideoElement) Enterkeyhint(c VideoEnterkeyhintChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("enterkeyhint", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("enterkeyhint", string(c), choiceValue[VideoEnterkeyhintChoice])
 	return e
 }
 
@@ -987,10 +957,8 @@ const (
 // action label (or icon) to present for the enter key on virtual keyboards.
 // Remove the attribute Enterkeyhint from the element.
 func (e *VideoElement) EnterkeyhintRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("enterkeyhint")
+	e = e.writable()
+	e.removeAttribute("enterkeyhint")
 	return e
 }
 
@@ -1012,16 +980,9 @@ func (e *VideoElement) EnterkeyhintRemove() *VideoElement {
 // in the shadow tree and which should be made available via a DOM outside of
 // the current structure.
 func (e *VideoElement) Exportparts(s string) *VideoElement {
+	e = e.writable()
 	values := strings.Split(s, ",")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		ds = newDelimitedBuilder[string](",")
-		e.delimitedStrings.Set("exportparts", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("exportparts", ",").Add(values...)
 	return e
 }
 
@@ -1044,7 +1005,7 @@ func (e *VideoElement) Exportparts(s string) *VideoElement {
 // the current structure.
 func (e *VideoElement) IfExportparts(condition bool, s string) *VideoElement {
 	if condition {
-		e.Exportparts(s)
+		e = e.Exportparts(s)
 	}
 	return e
 }
@@ -1068,14 +1029,8 @@ func (e *VideoElement) IfExportparts(condition bool, s string) *VideoElement {
 // the current structure.
 // Remove the values from the attribute Exportparts in the element.
 func (e *VideoElement) ExportpartsRemove(s ...string) *VideoElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("exportparts")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("exportparts", s...)
 	return e
 }
 
@@ -1092,10 +1047,8 @@ func (e *VideoElement) ExportpartsRemove(s ...string) *VideoElement {
 // of none, contents, or inline, then the element will not be revealed by find
 // in page or fragment navigation.
 func (e *VideoElement) Hidden(c VideoHiddenChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hidden", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("hidden", string(c), choiceValue[VideoHiddenChoice])
 	return e
 }
 
@@ -1131,10 +1084,8 @@ const (
 // in page or fragment navigation.
 // Remove the attribute Hidden from the element.
 func (e *VideoElement) HiddenRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hidden")
+	e = e.writable()
+	e.removeAttribute("hidden")
 	return e
 }
 
@@ -1142,10 +1093,8 @@ func (e *VideoElement) HiddenRemove() *VideoElement {
 // in the whole document. Its purpose is to identify the element when linking
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *VideoElement) ID(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1161,7 +1110,7 @@ func (e *VideoElement) IDf(format string, args ...any) *VideoElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *VideoElement) IfID(condition bool, s string) *VideoElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1171,7 +1120,7 @@ func (e *VideoElement) IfID(condition bool, s string) *VideoElement {
 // (using a fragment identifier), scripting, or styling (with CSS).
 func (e *VideoElement) IfIDf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1181,10 +1130,8 @@ func (e *VideoElement) IfIDf(condition bool, format string, args ...any) *VideoE
 // (using a fragment identifier), scripting, or styling (with CSS).
 // Remove the attribute ID from the element.
 func (e *VideoElement) IDRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -1199,10 +1146,8 @@ func (e *VideoElement) IDRemove() *VideoElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *VideoElement) Inert() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("inert", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("inert")
 	return e
 }
 
@@ -1218,7 +1163,7 @@ func (e *VideoElement) Inert() *VideoElement {
 // excluding them from the accessibility tree.
 func (e *VideoElement) IfInert(condition bool) *VideoElement {
 	if condition {
-		e.Inert()
+		e = e.Inert()
 	}
 	return e
 }
@@ -1235,10 +1180,8 @@ func (e *VideoElement) IfInert(condition bool) *VideoElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *VideoElement) InertRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1254,10 +1197,8 @@ func (e *VideoElement) InertRemove() *VideoElement {
 // focus. Hides the element and its content from assistive technologies by
 // excluding them from the accessibility tree.
 func (e *VideoElement) InertIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("inert")
+	e = e.writable()
+	e.removeAttribute("inert")
 	return e
 }
 
@@ -1271,10 +1212,8 @@ func (e *VideoElement) InertIfRemove() *VideoElement {
 // appropriate <input> element type. For specific guidance on choosing <input>
 // types, see the Values section.
 func (e *VideoElement) Inputmode(c VideoInputmodeChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("inputmode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("inputmode", string(c), choiceValue[VideoInputmodeChoice])
 	return e
 }
 
@@ -1325,10 +1264,8 @@ const (
 // types, see the Values section.
 // Remove the attribute Inputmode from the element.
 func (e *VideoElement) InputmodeRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("inputmode")
+	e = e.writable()
+	e.removeAttribute("inputmode")
 	return e
 }
 
@@ -1338,10 +1275,8 @@ func (e *VideoElement) InputmodeRemove() *VideoElement {
 // custom element name has been successfully defined in the current document,
 // and extends the element type it is being applied to.
 func (e *VideoElement) Is(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("is", s)
+	e = e.writable()
+	e.setStringAttribute("is", s)
 	return e
 }
 
@@ -1361,7 +1296,7 @@ func (e *VideoElement) Isf(format string, args ...any) *VideoElement {
 // and extends the element type it is being applied to.
 func (e *VideoElement) IfIs(condition bool, s string) *VideoElement {
 	if condition {
-		e.Is(s)
+		e = e.Is(s)
 	}
 	return e
 }
@@ -1373,7 +1308,7 @@ func (e *VideoElement) IfIs(condition bool, s string) *VideoElement {
 // and extends the element type it is being applied to.
 func (e *VideoElement) IfIsf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Is(fmt.Sprintf(format, args...))
+		e = e.Is(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1385,10 +1320,8 @@ func (e *VideoElement) IfIsf(condition bool, format string, args ...any) *VideoE
 // and extends the element type it is being applied to.
 // Remove the attribute Is from the element.
 func (e *VideoElement) IsRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("is")
+	e = e.writable()
+	e.removeAttribute("is")
 	return e
 }
 
@@ -1404,10 +1337,8 @@ func (e *VideoElement) IsRemove() *VideoElement {
 // whether several items with the same global identifier can coexist and, if so,
 // how items with the same identifier are handled.
 func (e *VideoElement) Itemid(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemid", s)
+	e = e.writable()
+	e.setStringAttribute("itemid", s)
 	return e
 }
 
@@ -1439,7 +1370,7 @@ func (e *VideoElement) Itemidf(format string, args ...any) *VideoElement {
 // how items with the same identifier are handled.
 func (e *VideoElement) IfItemid(condition bool, s string) *VideoElement {
 	if condition {
-		e.Itemid(s)
+		e = e.Itemid(s)
 	}
 	return e
 }
@@ -1457,7 +1388,7 @@ func (e *VideoElement) IfItemid(condition bool, s string) *VideoElement {
 // how items with the same identifier are handled.
 func (e *VideoElement) IfItemidf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Itemid(fmt.Sprintf(format, args...))
+		e = e.Itemid(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1475,10 +1406,8 @@ func (e *VideoElement) IfItemidf(condition bool, format string, args ...any) *Vi
 // how items with the same identifier are handled.
 // Remove the attribute Itemid from the element.
 func (e *VideoElement) ItemidRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemid")
+	e = e.writable()
+	e.removeAttribute("itemid")
 	return e
 }
 
@@ -1490,10 +1419,8 @@ func (e *VideoElement) ItemidRemove() *VideoElement {
 // including <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>,
 // <track>, and <video>.
 func (e *VideoElement) Itemprop(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemprop", s)
+	e = e.writable()
+	e.setStringAttribute("itemprop", s)
 	return e
 }
 
@@ -1517,7 +1444,7 @@ func (e *VideoElement) Itempropf(format string, args ...any) *VideoElement {
 // <track>, and <video>.
 func (e *VideoElement) IfItemprop(condition bool, s string) *VideoElement {
 	if condition {
-		e.Itemprop(s)
+		e = e.Itemprop(s)
 	}
 	return e
 }
@@ -1531,7 +1458,7 @@ func (e *VideoElement) IfItemprop(condition bool, s string) *VideoElement {
 // <track>, and <video>.
 func (e *VideoElement) IfItempropf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Itemprop(fmt.Sprintf(format, args...))
+		e = e.Itemprop(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1545,10 +1472,8 @@ func (e *VideoElement) IfItempropf(condition bool, format string, args ...any) *
 // <track>, and <video>.
 // Remove the attribute Itemprop from the element.
 func (e *VideoElement) ItempropRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemprop")
+	e = e.writable()
+	e.removeAttribute("itemprop")
 	return e
 }
 
@@ -1558,10 +1483,8 @@ func (e *VideoElement) ItempropRemove() *VideoElement {
 // document, with additional properties The itemref attribute can only be
 // specified on elements that have an itemscope attribute specified.
 func (e *VideoElement) Itemref(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemref", s)
+	e = e.writable()
+	e.setStringAttribute("itemref", s)
 	return e
 }
 
@@ -1581,7 +1504,7 @@ func (e *VideoElement) Itemreff(format string, args ...any) *VideoElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *VideoElement) IfItemref(condition bool, s string) *VideoElement {
 	if condition {
-		e.Itemref(s)
+		e = e.Itemref(s)
 	}
 	return e
 }
@@ -1593,7 +1516,7 @@ func (e *VideoElement) IfItemref(condition bool, s string) *VideoElement {
 // specified on elements that have an itemscope attribute specified.
 func (e *VideoElement) IfItemreff(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Itemref(fmt.Sprintf(format, args...))
+		e = e.Itemref(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1605,10 +1528,8 @@ func (e *VideoElement) IfItemreff(condition bool, format string, args ...any) *V
 // specified on elements that have an itemscope attribute specified.
 // Remove the attribute Itemref from the element.
 func (e *VideoElement) ItemrefRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemref")
+	e = e.writable()
+	e.removeAttribute("itemref")
 	return e
 }
 
@@ -1620,10 +1541,8 @@ func (e *VideoElement) ItemrefRemove() *VideoElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *VideoElement) Itemscope() *VideoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("itemscope", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("itemscope")
 	return e
 }
 
@@ -1636,7 +1555,7 @@ func (e *VideoElement) Itemscope() *VideoElement {
 // <object>, <source>, <track>, and <video>.
 func (e *VideoElement) IfItemscope(condition bool) *VideoElement {
 	if condition {
-		e.Itemscope()
+		e = e.Itemscope()
 	}
 	return e
 }
@@ -1650,10 +1569,8 @@ func (e *VideoElement) IfItemscope(condition bool) *VideoElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *VideoElement) ItemscopeRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1666,10 +1583,8 @@ func (e *VideoElement) ItemscopeRemove() *VideoElement {
 // range of elements including <audio>, <embed>, <iframe>, <img>, <link>,
 // <object>, <source>, <track>, and <video>.
 func (e *VideoElement) ItemscopeIfRemove() *VideoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("itemscope")
+	e = e.writable()
+	e.removeAttribute("itemscope")
 	return e
 }
 
@@ -1681,10 +1596,8 @@ func (e *VideoElement) ItemscopeIfRemove() *VideoElement {
 // <audio>, <embed>, <iframe>, <img>, <link>, <object>, <source>, <track>, and
 // <video>.
 func (e *VideoElement) Itemtype(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("itemtype", s)
+	e = e.writable()
+	e.setStringAttribute("itemtype", s)
 	return e
 }
 
@@ -1708,7 +1621,7 @@ func (e *VideoElement) Itemtypef(format string, args ...any) *VideoElement {
 // <video>.
 func (e *VideoElement) IfItemtype(condition bool, s string) *VideoElement {
 	if condition {
-		e.Itemtype(s)
+		e = e.Itemtype(s)
 	}
 	return e
 }
@@ -1722,7 +1635,7 @@ func (e *VideoElement) IfItemtype(condition bool, s string) *VideoElement {
 // <video>.
 func (e *VideoElement) IfItemtypef(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Itemtype(fmt.Sprintf(format, args...))
+		e = e.Itemtype(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1736,10 +1649,8 @@ func (e *VideoElement) IfItemtypef(condition bool, format string, args ...any) *
 // <video>.
 // Remove the attribute Itemtype from the element.
 func (e *VideoElement) ItemtypeRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("itemtype")
+	e = e.writable()
+	e.removeAttribute("itemtype")
 	return e
 }
 
@@ -1749,10 +1660,8 @@ func (e *VideoElement) ItemtypeRemove() *VideoElement {
 // single entry value in the format defines in the Tags for Identifying
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *VideoElement) Lang(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lang", s)
+	e = e.writable()
+	e.setStringAttribute("lang", s)
 	return e
 }
 
@@ -1772,7 +1681,7 @@ func (e *VideoElement) Langf(format string, args ...any) *VideoElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *VideoElement) IfLang(condition bool, s string) *VideoElement {
 	if condition {
-		e.Lang(s)
+		e = e.Lang(s)
 	}
 	return e
 }
@@ -1784,7 +1693,7 @@ func (e *VideoElement) IfLang(condition bool, s string) *VideoElement {
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 func (e *VideoElement) IfLangf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Lang(fmt.Sprintf(format, args...))
+		e = e.Lang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1796,10 +1705,8 @@ func (e *VideoElement) IfLangf(condition bool, format string, args ...any) *Vide
 // Languages (BCP47) IETF document. xml:lang has priority over it.
 // Remove the attribute Lang from the element.
 func (e *VideoElement) LangRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lang")
+	e = e.writable()
+	e.removeAttribute("lang")
 	return e
 }
 
@@ -1809,10 +1716,8 @@ func (e *VideoElement) LangRemove() *VideoElement {
 // Policy to determine whether or not a given inline script is allowed to
 // execute.
 func (e *VideoElement) Nonce(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -1832,7 +1737,7 @@ func (e *VideoElement) Noncef(format string, args ...any) *VideoElement {
 // execute.
 func (e *VideoElement) IfNonce(condition bool, s string) *VideoElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -1844,7 +1749,7 @@ func (e *VideoElement) IfNonce(condition bool, s string) *VideoElement {
 // execute.
 func (e *VideoElement) IfNoncef(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1856,10 +1761,8 @@ func (e *VideoElement) IfNoncef(condition bool, format string, args ...any) *Vid
 // execute.
 // Remove the attribute Nonce from the element.
 func (e *VideoElement) NonceRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
@@ -1867,16 +1770,9 @@ func (e *VideoElement) NonceRemove() *VideoElement {
 // of the element. Part names allows CSS to select and style specific elements
 // in a shadow tree via the ::part pseudo-element.
 func (e *VideoElement) Part(s string) *VideoElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("part", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("part", " ").Add(values...)
 	return e
 }
 
@@ -1885,7 +1781,7 @@ func (e *VideoElement) Part(s string) *VideoElement {
 // in a shadow tree via the ::part pseudo-element.
 func (e *VideoElement) IfPart(condition bool, s string) *VideoElement {
 	if condition {
-		e.Part(s)
+		e = e.Part(s)
 	}
 	return e
 }
@@ -1895,14 +1791,8 @@ func (e *VideoElement) IfPart(condition bool, s string) *VideoElement {
 // in a shadow tree via the ::part pseudo-element.
 // Remove the values from the attribute Part in the element.
 func (e *VideoElement) PartRemove(s ...string) *VideoElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("part")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("part", s...)
 	return e
 }
 
@@ -1913,10 +1803,8 @@ func (e *VideoElement) PartRemove(s ...string) *VideoElement {
 // popover elements will appear above all other elements in the top layer, and
 // won't be influenced by parent elements' position or overflow styling.
 func (e *VideoElement) Popover(c VideoPopoverChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("popover", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("popover", string(c), choiceValue[VideoPopoverChoice])
 	return e
 }
 
@@ -1944,10 +1832,8 @@ const (
 // won't be influenced by parent elements' position or overflow styling.
 // Remove the attribute Popover from the element.
 func (e *VideoElement) PopoverRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("popover")
+	e = e.writable()
+	e.removeAttribute("popover")
 	return e
 }
 
@@ -1956,10 +1842,8 @@ func (e *VideoElement) PopoverRemove() *VideoElement {
 // screen readers. It is a simple string value that can be used to describe the
 // role of an element.
 func (e *VideoElement) Role(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("role", s)
+	e = e.writable()
+	e.setStringAttribute("role", s)
 	return e
 }
 
@@ -1977,7 +1861,7 @@ func (e *VideoElement) Rolef(format string, args ...any) *VideoElement {
 // role of an element.
 func (e *VideoElement) IfRole(condition bool, s string) *VideoElement {
 	if condition {
-		e.Role(s)
+		e = e.Role(s)
 	}
 	return e
 }
@@ -1988,7 +1872,7 @@ func (e *VideoElement) IfRole(condition bool, s string) *VideoElement {
 // role of an element.
 func (e *VideoElement) IfRolef(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Role(fmt.Sprintf(format, args...))
+		e = e.Role(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1999,10 +1883,8 @@ func (e *VideoElement) IfRolef(condition bool, format string, args ...any) *Vide
 // role of an element.
 // Remove the attribute Role from the element.
 func (e *VideoElement) RoleRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("role")
+	e = e.writable()
+	e.removeAttribute("role")
 	return e
 }
 
@@ -2011,10 +1893,8 @@ func (e *VideoElement) RoleRemove() *VideoElement {
 // the <slot> element whose name attribute's value matches that slot attribute's
 // value.
 func (e *VideoElement) Slot(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("slot", s)
+	e = e.writable()
+	e.setStringAttribute("slot", s)
 	return e
 }
 
@@ -2032,7 +1912,7 @@ func (e *VideoElement) Slotf(format string, args ...any) *VideoElement {
 // value.
 func (e *VideoElement) IfSlot(condition bool, s string) *VideoElement {
 	if condition {
-		e.Slot(s)
+		e = e.Slot(s)
 	}
 	return e
 }
@@ -2043,7 +1923,7 @@ func (e *VideoElement) IfSlot(condition bool, s string) *VideoElement {
 // value.
 func (e *VideoElement) IfSlotf(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Slot(fmt.Sprintf(format, args...))
+		e = e.Slot(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2054,10 +1934,8 @@ func (e *VideoElement) IfSlotf(condition bool, format string, args ...any) *Vide
 // value.
 // Remove the attribute Slot from the element.
 func (e *VideoElement) SlotRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("slot")
+	e = e.writable()
+	e.removeAttribute("slot")
 	return e
 }
 
@@ -2073,10 +1951,8 @@ func (e *VideoElement) SlotRemove() *VideoElement {
 // "spell-jacking"). You should consider setting spellcheck to false for
 // elements that can contain sensitive information.
 func (e *VideoElement) Spellcheck(c VideoSpellcheckChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spellcheck", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spellcheck", string(c), choiceValue[VideoSpellcheckChoice])
 	return e
 }
 
@@ -2104,10 +1980,8 @@ const (
 // elements that can contain sensitive information.
 // Remove the attribute Spellcheck from the element.
 func (e *VideoElement) SpellcheckRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spellcheck")
+	e = e.writable()
+	e.removeAttribute("spellcheck")
 	return e
 }
 
@@ -2117,14 +1991,8 @@ func (e *VideoElement) StylePairs(pairs ...string) *VideoElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -2139,14 +2007,8 @@ func (e *VideoElement) StylePairs(pairs ...string) *VideoElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *VideoElement) Style(s string) *VideoElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -2154,7 +2016,7 @@ func (e *VideoElement) Style(s string) *VideoElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -2163,7 +2025,7 @@ func (e *VideoElement) Style(s string) *VideoElement {
 // color, font, size, and more. Styles are written in CSS.
 func (e *VideoElement) IfStyle(condition bool, s string) *VideoElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -2171,15 +2033,7 @@ func (e *VideoElement) IfStyle(condition bool, s string) *VideoElement {
 // The style global Attribute is used to add styles to an element, such as
 // color, font, size, and more. Styles are written in CSS.
 func (e *VideoElement) StyleAdd(k string, v string) *VideoElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -2193,7 +2047,7 @@ func (e *VideoElement) StyleAddf(k string, format string, args ...any) *VideoEle
 // color, font, size, and more. Styles are written in CSS.
 func (e *VideoElement) IfStyleAdd(condition bool, k string, v string) *VideoElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -2202,7 +2056,7 @@ func (e *VideoElement) IfStyleAdd(condition bool, k string, v string) *VideoElem
 // color, font, size, and more. Styles are written in CSS.
 func (e *VideoElement) IfStyleAddf(condition bool, k string, format string, args ...any) *VideoElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -2211,21 +2065,15 @@ func (e *VideoElement) IfStyleAddf(condition bool, k string, format string, args
 // color, font, size, and more. Styles are written in CSS.
 // Add the attributes in the map to the element.
 func (e *VideoElement) StyleMap(m map[string]string) *VideoElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -2234,14 +2082,8 @@ func (e *VideoElement) StyleMap(m map[string]string) *VideoElement {
 // color, font, size, and more. Styles are written in CSS.
 // Remove the attribute Style from the element.
 func (e *VideoElement) StyleRemove(keys ...string) *VideoElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -2259,10 +2101,8 @@ func (e *VideoElement) StyleRemove(keys ...string) *VideoElement {
 // If several elements share the same tabindex, their relative order follows
 // their relative position in the document.
 func (e *VideoElement) Tabindex(i int) *VideoElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -2281,7 +2121,7 @@ func (e *VideoElement) Tabindex(i int) *VideoElement {
 // their relative position in the document.
 func (e *VideoElement) IfTabindex(condition bool, i int) *VideoElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -2301,10 +2141,8 @@ func (e *VideoElement) IfTabindex(condition bool, i int) *VideoElement {
 // their relative position in the document.
 // Remove the attribute Tabindex from the element.
 func (e *VideoElement) TabindexRemove() *VideoElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
 
@@ -2325,10 +2163,8 @@ func (e *VideoElement) TabindexRemove() *VideoElement {
 // can be used to provide a programmatically associated label for an <input>
 // element, this is not good practice. Use a <label> instead.
 func (e *VideoElement) Title(s string) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -2370,7 +2206,7 @@ func (e *VideoElement) Titlef(format string, args ...any) *VideoElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *VideoElement) IfTitle(condition bool, s string) *VideoElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -2393,7 +2229,7 @@ func (e *VideoElement) IfTitle(condition bool, s string) *VideoElement {
 // element, this is not good practice. Use a <label> instead.
 func (e *VideoElement) IfTitlef(condition bool, format string, args ...any) *VideoElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -2416,10 +2252,8 @@ func (e *VideoElement) IfTitlef(condition bool, format string, args ...any) *Vid
 // element, this is not good practice. Use a <label> instead.
 // Remove the attribute Title from the element.
 func (e *VideoElement) TitleRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
@@ -2428,10 +2262,8 @@ func (e *VideoElement) TitleRemove() *VideoElement {
 // children are to be translated when the page is localized, or whether to leave
 // them unchanged.
 func (e *VideoElement) Translate(c VideoTranslateChoice) *VideoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("translate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("translate", string(c), choiceValue[VideoTranslateChoice])
 	return e
 }
 
@@ -2452,9 +2284,7 @@ const (
 // them unchanged.
 // Remove the attribute Translate from the element.
 func (e *VideoElement) TranslateRemove() *VideoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("translate")
+	e = e.writable()
+	e.removeAttribute("translate")
 	return e
 }
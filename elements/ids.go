@@ -0,0 +1,39 @@
+package elements
+
+import (
+	"context"
+	"strconv"
+)
+
+type idAllocatorKey struct{}
+
+// idAllocator numbers the IDs of a render pass by prefix.
+type idAllocator struct {
+	counts map[string]int
+	// recording is set while a cached fragment renders, see recording.
+	recording *recording
+}
+
+// UseID returns an ID starting with prefix that is unique within the render
+// pass of ctx: the first call for a prefix returns prefix-1, the next one
+// prefix-2 and so on. Rendering the same tree gives the same IDs. The IDs of
+// Cached fragments are allocated again whenever the fragment is written, in
+// the order of the calls that first rendered it.
+//
+// ctx must be the context given to the callbacks of DynGroupContext,
+// DynIfContext or NewComponentContext, outside of a render pass the prefix is
+// returned as is.
+func UseID(ctx context.Context, prefix string) string {
+	ids, ok := ctx.Value(idAllocatorKey{}).(*idAllocator)
+	if !ok {
+		return prefix
+	}
+	if ids.recording != nil {
+		return ids.recording.useID(prefix)
+	}
+	if ids.counts == nil {
+		ids.counts = make(map[string]int)
+	}
+	ids.counts[prefix]++
+	return prefix + "-" + strconv.Itoa(ids.counts[prefix])
+}
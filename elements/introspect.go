@@ -0,0 +1,98 @@
+package elements
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Tag returns the tag name of the element.
+func (e *Element) Tag() string {
+	return string(e.tag)
+}
+
+// GetChildren returns a copy of the children of the element.
+func (e *Element) GetChildren() []ElementRenderer {
+	return slices.Clone(e.descendants)
+}
+
+// HasAttr reports whether the attribute is set.
+func (e *Element) HasAttr(name string) bool {
+	return e.getAttribute(name) != nil
+}
+
+// GetAttr returns the value of the attribute as it is rendered, before
+// escaping. Boolean attributes have an empty value.
+func (e *Element) GetAttr(name string) (string, bool) {
+	a := e.getAttribute(name)
+	if a == nil {
+		return "", false
+	}
+	return a.String(), true
+}
+
+// AttrValue returns the value of the attribute in the form the builders
+// accept: an int, a float64, true for boolean attributes, the choice type of
+// the setter for choice attributes, a rune for rune attributes and a string
+// for the others. Choice and rune values set with Attr are strings.
+func (e *Element) AttrValue(name string) (any, bool) {
+	a := e.getAttribute(name)
+	if a == nil {
+		return nil, false
+	}
+	return a.typedValue(), true
+}
+
+// Attributes iterates over the attributes in render order, with their values
+// as returned by AttrValue.
+func (e *Element) Attributes() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for i := range e.attributes {
+			if !yield(e.attributes[i].name, e.attributes[i].typedValue()) {
+				return
+			}
+		}
+	}
+}
+
+// ClassList returns a copy of the classes of the element.
+func (e *Element) ClassList() []string {
+	a := e.getAttribute("class")
+	switch {
+	case a == nil:
+		return nil
+	case a.kind == attributeDelimited:
+		return slices.Clone(a.delimited.values)
+	}
+	return strings.Fields(a.String())
+}
+
+// HasClass reports whether the class is in the class list of the element.
+func (e *Element) HasClass(class string) bool {
+	a := e.getAttribute("class")
+	if a != nil && a.kind == attributeDelimited {
+		return slices.Contains(a.delimited.values, class)
+	}
+	return slices.Contains(e.ClassList(), class)
+}
+
+// String returns the value of the attribute as it is rendered, before
+// escaping.
+func (a *attribute) String() string {
+	return string(a.appendValue(nil, 0))
+}
+
+func (a *attribute) typedValue() any {
+	if a.typed != nil {
+		return a.typed(a.String())
+	}
+	switch a.kind {
+	case attributeBool:
+		return true
+	case attributeInt:
+		return a.num
+	case attributeFloat:
+		return a.float
+	}
+	return a.String()
+}
@@ -0,0 +1,133 @@
+package elements
+
+// The tables below drive the indented rendering mode. Whitespace is only added
+// between the children of an element when the element lays them out as blocks
+// and every child is block-level, so that the added whitespace does not change
+// how the document displays.
+
+// htmlBlockTags holds the HTML elements around which whitespace is not
+// significant.
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "base": true,
+	"blockquote": true, "body": true, "caption": true, "col": true,
+	"colgroup": true, "dd": true, "details": true, "dialog": true, "div": true,
+	"dl": true, "dt": true, "fieldset": true, "figcaption": true, "figure": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "head": true, "header": true, "hgroup": true,
+	"hr": true, "html": true, "legend": true, "li": true, "link": true,
+	"main": true, "menu": true, "meta": true, "nav": true, "noscript": true,
+	"ol": true, "optgroup": true, "option": true, "p": true, "pre": true,
+	"script": true, "search": true, "section": true, "source": true,
+	"style": true, "summary": true, "table": true, "tbody": true, "td": true,
+	"template": true, "tfoot": true, "th": true, "thead": true, "title": true,
+	"tr": true, "track": true, "ul": true,
+}
+
+// htmlBlockContainerTags holds the HTML elements that are not block-level
+// themselves but ignore whitespace between their block-level children.
+var htmlBlockContainerTags = map[string]bool{
+	"audio": true, "datalist": true, "picture": true, "select": true, "video": true,
+}
+
+// htmlWhitespaceSensitiveTags holds the block-level HTML elements whose
+// content must be rendered as is.
+var htmlWhitespaceSensitiveTags = map[string]bool{
+	"pre": true, "script": true, "style": true, "title": true,
+}
+
+// svgTextTags holds the SVG elements whose content is rendered as text.
+var svgTextTags = map[string]bool{
+	"desc": true, "script": true, "style": true, "text": true, "textPath": true,
+	"title": true, "tspan": true,
+}
+
+// mathMLTokenTags holds the MathML elements whose content is rendered as text.
+var mathMLTokenTags = map[string]bool{
+	"annotation": true, "annotation-xml": true, "mi": true, "mn": true, "mo": true,
+	"ms": true, "mtext": true,
+}
+
+// isBlockContainer reports whether whitespace may be added between the
+// block-level children of e.
+func isBlockContainer(e *Element) bool {
+	tag := string(e.tag)
+	switch e.namespace {
+	case namespaceSVG:
+		return !svgTextTags[tag]
+	case namespaceMathML:
+		return !mathMLTokenTags[tag]
+	}
+	if htmlWhitespaceSensitiveTags[tag] {
+		return false
+	}
+	return htmlBlockTags[tag] || htmlBlockContainerTags[tag]
+}
+
+// isBlockLevel reports whether whitespace around child is insignificant when
+// it is placed in parent. A nil parent stands for the document.
+func isBlockLevel(parent *Element, child ElementRenderer) bool {
+	var c *Element
+	switch child := child.(type) {
+	case *CommentContent, *DoctypeContent, *ProcessingInstructionContent:
+		return true
+	case interface{ baseElement() *Element }:
+		c = child.baseElement()
+	default:
+		return false
+	}
+	switch c.namespace {
+	case namespaceSVG, namespaceMathML:
+		// The svg and math roots are inline-level in HTML content.
+		return parent == nil || parent.namespace == c.namespace
+	}
+	return htmlBlockTags[string(c.tag)]
+}
+
+// eachChild calls fn for the non-nil children, looking through Groupers. It
+// stops and returns false as soon as fn does.
+func eachChild(children []ElementRenderer, fn func(ElementRenderer) bool) bool {
+	for _, child := range children {
+		switch c := child.(type) {
+		case nil:
+		case *Grouper:
+			if c != nil && !eachChild(c.Children, fn) {
+				return false
+			}
+		default:
+			if !fn(child) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// allBlockLevel reports whether every child is block-level in parent.
+func allBlockLevel(parent *Element, children []ElementRenderer) bool {
+	return eachChild(children, func(child ElementRenderer) bool {
+		return isBlockLevel(parent, child)
+	})
+}
+
+// renderIndented renders each child on its own line, one level deeper than
+// the current one. The closing line is left to the caller.
+func (rw *renderWriter) renderIndented(children []ElementRenderer) (n int, err error) {
+	rw.depth++
+	eachChild(children, func(child ElementRenderer) bool {
+		if n > 0 || rw.depth > 0 {
+			rw.newline()
+		}
+		n++
+		err = child.Render(rw)
+		return err == nil
+	})
+	rw.depth--
+	return n, err
+}
+
+func (rw *renderWriter) newline() {
+	rw.WriteString("\n")
+	for range rw.depth {
+		rw.WriteString(rw.renderer.Indent)
+	}
+}
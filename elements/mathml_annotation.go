@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to include comments or annotations within a MathML
@@ -23,18 +21,61 @@ type MathMLAnnotationElement struct {
 // with the tag "annotation" during rendering.
 func MathMLAnnotation(children ...ElementRenderer) *MathMLAnnotationElement {
 	e := NewElement("annotation", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLAnnotationElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLAnnotationElement) Clone() *MathMLAnnotationElement {
+	return &MathMLAnnotationElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLAnnotationElement) CloneShallow() *MathMLAnnotationElement {
+	return &MathMLAnnotationElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLAnnotationElement) Freeze() *MathMLAnnotationElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLAnnotationElement) writable() *MathMLAnnotationElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLAnnotationElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLAnnotationElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLAnnotationDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLAnnotationDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLAnnotationElement) Children(children ...ElementRenderer) *MathMLAnnotationElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLAnnotationElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +83,30 @@ func (e *MathMLAnnotationElement) IfChildren(condition bool, children ...Element
 
 func (e *MathMLAnnotationElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLAnnotationElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) BoolAttr(name string) *MathMLAnnotationElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLAnnotationElement) BoolAttrRemove(name string) *MathMLAnnotationElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfBoolAttr(condition bool, name string) *MathMLAnnotationElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +117,40 @@ func (e *MathMLAnnotationElement) BoolAttrf(format string, args ...any) *MathMLA
 
 func (e *MathMLAnnotationElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) BoolAttrs(names ...string) *MathMLAnnotationElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfBoolAttrs(condition bool, names ...string) *MathMLAnnotationElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) Attr(name, value string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLAnnotationElement) RawAttr(name, value string) *MathMLAnnotationElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfAttr(condition bool, name, value string) *MathMLAnnotationElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +161,52 @@ func (e *MathMLAnnotationElement) Attrf(name, format string, args ...any) *MathM
 
 func (e *MathMLAnnotationElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) Attrs(attrs ...string) *MathMLAnnotationElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfAttrs(condition bool, attrs ...string) *MathMLAnnotationElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) AttrsMap(attrs map[string]string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLAnnotationElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationElement) Text(text string) *MathMLAnnotationElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLAnnotationElement) Textf(format string, args ...any) *MathMLAnnotationElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLAnnotationElement) IfText(condition bool, text string) *MathMLAnnotationElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +214,28 @@ func (e *MathMLAnnotationElement) IfText(condition bool, text string) *MathMLAnn
 
 func (e *MathMLAnnotationElement) IfTextf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLAnnotationElement) Raw(text string) *MathMLAnnotationElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLAnnotationElement) Escaped(text string) *MathMLAnnotationElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLAnnotationElement) IfEscaped(condition bool, text string) *MathMLAnnotationElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +247,7 @@ func (e *MathMLAnnotationElement) Escapedf(format string, args ...any) *MathMLAn
 
 func (e *MathMLAnnotationElement) IfEscapedf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +256,8 @@ func (e *MathMLAnnotationElement) IfEscapedf(condition bool, format string, args
 // The encoding of the semantic information in the annotation (e.g.,
 // "application/x-tex")
 func (e *MathMLAnnotationElement) Encoding(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("encoding", s)
+	e = e.writable()
+	e.setStringAttribute("encoding", s)
 	return e
 }
 
@@ -228,7 +271,7 @@ func (e *MathMLAnnotationElement) Encodingf(format string, args ...any) *MathMLA
 // "application/x-tex")
 func (e *MathMLAnnotationElement) IfEncoding(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Encoding(s)
+		e = e.Encoding(s)
 	}
 	return e
 }
@@ -237,7 +280,7 @@ func (e *MathMLAnnotationElement) IfEncoding(condition bool, s string) *MathMLAn
 // "application/x-tex")
 func (e *MathMLAnnotationElement) IfEncodingf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.Encoding(fmt.Sprintf(format, args...))
+		e = e.Encoding(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -246,19 +289,15 @@ func (e *MathMLAnnotationElement) IfEncodingf(condition bool, format string, arg
 // "application/x-tex")
 // Remove the attribute Encoding from the element.
 func (e *MathMLAnnotationElement) EncodingRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("encoding")
+	e = e.writable()
+	e.removeAttribute("encoding")
 	return e
 }
 
 // This Attribute specifies the name of the annotation.
 func (e *MathMLAnnotationElement) Name(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -270,7 +309,7 @@ func (e *MathMLAnnotationElement) Namef(format string, args ...any) *MathMLAnnot
 // This Attribute specifies the name of the annotation.
 func (e *MathMLAnnotationElement) IfName(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -278,7 +317,7 @@ func (e *MathMLAnnotationElement) IfName(condition bool, s string) *MathMLAnnota
 // This Attribute specifies the name of the annotation.
 func (e *MathMLAnnotationElement) IfNamef(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -286,10 +325,8 @@ func (e *MathMLAnnotationElement) IfNamef(condition bool, format string, args ..
 // This Attribute specifies the name of the annotation.
 // Remove the attribute Name from the element.
 func (e *MathMLAnnotationElement) NameRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -297,16 +334,9 @@ func (e *MathMLAnnotationElement) NameRemove() *MathMLAnnotationElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLAnnotationElement) Class(s string) *MathMLAnnotationElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -315,7 +345,7 @@ func (e *MathMLAnnotationElement) Class(s string) *MathMLAnnotationElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLAnnotationElement) IfClass(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -325,14 +355,8 @@ func (e *MathMLAnnotationElement) IfClass(condition bool, s string) *MathMLAnnot
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLAnnotationElement) ClassRemove(s ...string) *MathMLAnnotationElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -341,10 +365,8 @@ func (e *MathMLAnnotationElement) ClassRemove(s ...string) *MathMLAnnotationElem
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLAnnotationElement) Dir(c MathMLAnnotationDirChoice) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLAnnotationDirChoice])
 	return e
 }
 
@@ -363,20 +385,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLAnnotationElement) DirRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLAnnotationElement) Displaystyle(c MathMLAnnotationDisplaystyleChoice) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLAnnotationDisplaystyleChoice])
 	return e
 }
 
@@ -393,20 +411,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLAnnotationElement) DisplaystyleRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLAnnotationElement) ID(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -420,7 +434,7 @@ func (e *MathMLAnnotationElement) IDf(format string, args ...any) *MathMLAnnotat
 // document.
 func (e *MathMLAnnotationElement) IfID(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -429,7 +443,7 @@ func (e *MathMLAnnotationElement) IfID(condition bool, s string) *MathMLAnnotati
 // document.
 func (e *MathMLAnnotationElement) IfIDf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -438,10 +452,8 @@ func (e *MathMLAnnotationElement) IfIDf(condition bool, format string, args ...a
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLAnnotationElement) IDRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -449,10 +461,8 @@ func (e *MathMLAnnotationElement) IDRemove() *MathMLAnnotationElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLAnnotationElement) Mathbackground(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -468,7 +478,7 @@ func (e *MathMLAnnotationElement) Mathbackgroundf(format string, args ...any) *M
 // Color Module [CSS3COLOR].
 func (e *MathMLAnnotationElement) IfMathbackground(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -478,7 +488,7 @@ func (e *MathMLAnnotationElement) IfMathbackground(condition bool, s string) *Ma
 // Color Module [CSS3COLOR].
 func (e *MathMLAnnotationElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -488,10 +498,8 @@ func (e *MathMLAnnotationElement) IfMathbackgroundf(condition bool, format strin
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLAnnotationElement) MathbackgroundRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -499,10 +507,8 @@ func (e *MathMLAnnotationElement) MathbackgroundRemove() *MathMLAnnotationElemen
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLAnnotationElement) Mathcolor(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -518,7 +524,7 @@ func (e *MathMLAnnotationElement) Mathcolorf(format string, args ...any) *MathML
 // Module [CSS3COLOR].
 func (e *MathMLAnnotationElement) IfMathcolor(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -528,7 +534,7 @@ func (e *MathMLAnnotationElement) IfMathcolor(condition bool, s string) *MathMLA
 // Module [CSS3COLOR].
 func (e *MathMLAnnotationElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -538,20 +544,16 @@ func (e *MathMLAnnotationElement) IfMathcolorf(condition bool, format string, ar
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLAnnotationElement) MathcolorRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLAnnotationElement) MathsizeStr(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -565,7 +567,7 @@ func (e *MathMLAnnotationElement) MathsizeStrf(format string, args ...any) *Math
 // dimension or a dimensionless number.
 func (e *MathMLAnnotationElement) IfMathsizeStr(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -574,7 +576,7 @@ func (e *MathMLAnnotationElement) IfMathsizeStr(condition bool, s string) *MathM
 // dimension or a dimensionless number.
 func (e *MathMLAnnotationElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -583,10 +585,8 @@ func (e *MathMLAnnotationElement) IfMathsizeStrf(condition bool, format string,
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLAnnotationElement) MathsizeStrRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -595,10 +595,8 @@ func (e *MathMLAnnotationElement) MathsizeStrRemove() *MathMLAnnotationElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLAnnotationElement) Nonce(s string) *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -616,7 +614,7 @@ func (e *MathMLAnnotationElement) Noncef(format string, args ...any) *MathMLAnno
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLAnnotationElement) IfNonce(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -627,7 +625,7 @@ func (e *MathMLAnnotationElement) IfNonce(condition bool, s string) *MathMLAnnot
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLAnnotationElement) IfNoncef(condition bool, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -638,20 +636,16 @@ func (e *MathMLAnnotationElement) IfNoncef(condition bool, format string, args .
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLAnnotationElement) NonceRemove() *MathMLAnnotationElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLAnnotationElement) Scriptlevel(i int) *MathMLAnnotationElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -659,7 +653,7 @@ func (e *MathMLAnnotationElement) Scriptlevel(i int) *MathMLAnnotationElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLAnnotationElement) IfScriptlevel(condition bool, i int) *MathMLAnnotationElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -668,10 +662,8 @@ func (e *MathMLAnnotationElement) IfScriptlevel(condition bool, i int) *MathMLAn
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLAnnotationElement) ScriptlevelRemove() *MathMLAnnotationElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -681,14 +673,8 @@ func (e *MathMLAnnotationElement) StylePairs(pairs ...string) *MathMLAnnotationE
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -703,14 +689,8 @@ func (e *MathMLAnnotationElement) StylePairs(pairs ...string) *MathMLAnnotationE
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLAnnotationElement) Style(s string) *MathMLAnnotationElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -718,7 +698,7 @@ func (e *MathMLAnnotationElement) Style(s string) *MathMLAnnotationElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -727,7 +707,7 @@ func (e *MathMLAnnotationElement) Style(s string) *MathMLAnnotationElement {
 // set.
 func (e *MathMLAnnotationElement) IfStyle(condition bool, s string) *MathMLAnnotationElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -735,15 +715,7 @@ func (e *MathMLAnnotationElement) IfStyle(condition bool, s string) *MathMLAnnot
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLAnnotationElement) StyleAdd(k string, v string) *MathMLAnnotationElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -757,7 +729,7 @@ func (e *MathMLAnnotationElement) StyleAddf(k string, format string, args ...any
 // set.
 func (e *MathMLAnnotationElement) IfStyleAdd(condition bool, k string, v string) *MathMLAnnotationElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -766,7 +738,7 @@ func (e *MathMLAnnotationElement) IfStyleAdd(condition bool, k string, v string)
 // set.
 func (e *MathMLAnnotationElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLAnnotationElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -775,21 +747,15 @@ func (e *MathMLAnnotationElement) IfStyleAddf(condition bool, k string, format s
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLAnnotationElement) StyleMap(m map[string]string) *MathMLAnnotationElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -798,14 +764,8 @@ func (e *MathMLAnnotationElement) StyleMap(m map[string]string) *MathMLAnnotatio
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLAnnotationElement) StyleRemove(keys ...string) *MathMLAnnotationElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -813,10 +773,8 @@ func (e *MathMLAnnotationElement) StyleRemove(keys ...string) *MathMLAnnotationE
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLAnnotationElement) Tabindex(i int) *MathMLAnnotationElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -825,7 +783,7 @@ func (e *MathMLAnnotationElement) Tabindex(i int) *MathMLAnnotationElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLAnnotationElement) IfTabindex(condition bool, i int) *MathMLAnnotationElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -835,9 +793,7 @@ func (e *MathMLAnnotationElement) IfTabindex(condition bool, i int) *MathMLAnnot
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLAnnotationElement) TabindexRemove() *MathMLAnnotationElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to include comments or annotations within a MathML
@@ -23,18 +21,61 @@ type MathMLAnnotationXMLElement struct {
 // with the tag "annotation-xml" during rendering.
 func MathMLAnnotationXML(children ...ElementRenderer) *MathMLAnnotationXMLElement {
 	e := NewElement("annotation-xml", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLAnnotationXMLElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLAnnotationXMLElement) Clone() *MathMLAnnotationXMLElement {
+	return &MathMLAnnotationXMLElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLAnnotationXMLElement) CloneShallow() *MathMLAnnotationXMLElement {
+	return &MathMLAnnotationXMLElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLAnnotationXMLElement) Freeze() *MathMLAnnotationXMLElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLAnnotationXMLElement) writable() *MathMLAnnotationXMLElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLAnnotationXMLElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLAnnotationXMLElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLAnnotationXMLDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLAnnotationXMLDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLAnnotationXMLElement) Children(children ...ElementRenderer) *MathMLAnnotationXMLElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLAnnotationXMLElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +83,30 @@ func (e *MathMLAnnotationXMLElement) IfChildren(condition bool, children ...Elem
 
 func (e *MathMLAnnotationXMLElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLAnnotationXMLElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) BoolAttr(name string) *MathMLAnnotationXMLElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) BoolAttrRemove(name string) *MathMLAnnotationXMLElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfBoolAttr(condition bool, name string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +117,40 @@ func (e *MathMLAnnotationXMLElement) BoolAttrf(format string, args ...any) *Math
 
 func (e *MathMLAnnotationXMLElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) BoolAttrs(names ...string) *MathMLAnnotationXMLElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfBoolAttrs(condition bool, names ...string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) Attr(name, value string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLAnnotationXMLElement) RawAttr(name, value string) *MathMLAnnotationXMLElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfAttr(condition bool, name, value string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +161,52 @@ func (e *MathMLAnnotationXMLElement) Attrf(name, format string, args ...any) *Ma
 
 func (e *MathMLAnnotationXMLElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) Attrs(attrs ...string) *MathMLAnnotationXMLElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfAttrs(condition bool, attrs ...string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) AttrsMap(attrs map[string]string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) Text(text string) *MathMLAnnotationXMLElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) Textf(format string, args ...any) *MathMLAnnotationXMLElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfText(condition bool, text string) *MathMLAnnotationXMLElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +214,28 @@ func (e *MathMLAnnotationXMLElement) IfText(condition bool, text string) *MathML
 
 func (e *MathMLAnnotationXMLElement) IfTextf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLAnnotationXMLElement) Raw(text string) *MathMLAnnotationXMLElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLAnnotationXMLElement) Escaped(text string) *MathMLAnnotationXMLElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLAnnotationXMLElement) IfEscaped(condition bool, text string) *MathMLAnnotationXMLElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +247,7 @@ func (e *MathMLAnnotationXMLElement) Escapedf(format string, args ...any) *MathM
 
 func (e *MathMLAnnotationXMLElement) IfEscapedf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +257,8 @@ func (e *MathMLAnnotationXMLElement) IfEscapedf(condition bool, format string, a
 // "application/mathml+xml", "application/mathml-presentation+xml",
 // "application/mathml-content+xml")
 func (e *MathMLAnnotationXMLElement) Encoding(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("encoding", s)
+	e = e.writable()
+	e.setStringAttribute("encoding", s)
 	return e
 }
 
@@ -231,7 +274,7 @@ func (e *MathMLAnnotationXMLElement) Encodingf(format string, args ...any) *Math
 // "application/mathml-content+xml")
 func (e *MathMLAnnotationXMLElement) IfEncoding(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Encoding(s)
+		e = e.Encoding(s)
 	}
 	return e
 }
@@ -241,7 +284,7 @@ func (e *MathMLAnnotationXMLElement) IfEncoding(condition bool, s string) *MathM
 // "application/mathml-content+xml")
 func (e *MathMLAnnotationXMLElement) IfEncodingf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Encoding(fmt.Sprintf(format, args...))
+		e = e.Encoding(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -251,19 +294,15 @@ func (e *MathMLAnnotationXMLElement) IfEncodingf(condition bool, format string,
 // "application/mathml-content+xml")
 // Remove the attribute Encoding from the element.
 func (e *MathMLAnnotationXMLElement) EncodingRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("encoding")
+	e = e.writable()
+	e.removeAttribute("encoding")
 	return e
 }
 
 // This Attribute specifies the name of the annotation.
 func (e *MathMLAnnotationXMLElement) Name(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("name", s)
+	e = e.writable()
+	e.setStringAttribute("name", s)
 	return e
 }
 
@@ -275,7 +314,7 @@ func (e *MathMLAnnotationXMLElement) Namef(format string, args ...any) *MathMLAn
 // This Attribute specifies the name of the annotation.
 func (e *MathMLAnnotationXMLElement) IfName(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Name(s)
+		e = e.Name(s)
 	}
 	return e
 }
@@ -283,7 +322,7 @@ func (e *MathMLAnnotationXMLElement) IfName(condition bool, s string) *MathMLAnn
 // This Attribute specifies the name of the annotation.
 func (e *MathMLAnnotationXMLElement) IfNamef(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Name(fmt.Sprintf(format, args...))
+		e = e.Name(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -291,10 +330,8 @@ func (e *MathMLAnnotationXMLElement) IfNamef(condition bool, format string, args
 // This Attribute specifies the name of the annotation.
 // Remove the attribute Name from the element.
 func (e *MathMLAnnotationXMLElement) NameRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("name")
+	e = e.writable()
+	e.removeAttribute("name")
 	return e
 }
 
@@ -302,16 +339,9 @@ func (e *MathMLAnnotationXMLElement) NameRemove() *MathMLAnnotationXMLElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLAnnotationXMLElement) Class(s string) *MathMLAnnotationXMLElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -320,7 +350,7 @@ func (e *MathMLAnnotationXMLElement) Class(s string) *MathMLAnnotationXMLElement
 // class names, they must be separated by whitespace characters.
 func (e *MathMLAnnotationXMLElement) IfClass(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -330,14 +360,8 @@ func (e *MathMLAnnotationXMLElement) IfClass(condition bool, s string) *MathMLAn
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLAnnotationXMLElement) ClassRemove(s ...string) *MathMLAnnotationXMLElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -346,10 +370,8 @@ func (e *MathMLAnnotationXMLElement) ClassRemove(s ...string) *MathMLAnnotationX
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLAnnotationXMLElement) Dir(c MathMLAnnotationXMLDirChoice) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLAnnotationXMLDirChoice])
 	return e
 }
 
@@ -368,20 +390,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLAnnotationXMLElement) DirRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLAnnotationXMLElement) Displaystyle(c MathMLAnnotationXMLDisplaystyleChoice) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLAnnotationXMLDisplaystyleChoice])
 	return e
 }
 
@@ -398,20 +416,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLAnnotationXMLElement) DisplaystyleRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLAnnotationXMLElement) ID(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -425,7 +439,7 @@ func (e *MathMLAnnotationXMLElement) IDf(format string, args ...any) *MathMLAnno
 // document.
 func (e *MathMLAnnotationXMLElement) IfID(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -434,7 +448,7 @@ func (e *MathMLAnnotationXMLElement) IfID(condition bool, s string) *MathMLAnnot
 // document.
 func (e *MathMLAnnotationXMLElement) IfIDf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -443,10 +457,8 @@ func (e *MathMLAnnotationXMLElement) IfIDf(condition bool, format string, args .
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLAnnotationXMLElement) IDRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -454,10 +466,8 @@ func (e *MathMLAnnotationXMLElement) IDRemove() *MathMLAnnotationXMLElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLAnnotationXMLElement) Mathbackground(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -473,7 +483,7 @@ func (e *MathMLAnnotationXMLElement) Mathbackgroundf(format string, args ...any)
 // Color Module [CSS3COLOR].
 func (e *MathMLAnnotationXMLElement) IfMathbackground(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -483,7 +493,7 @@ func (e *MathMLAnnotationXMLElement) IfMathbackground(condition bool, s string)
 // Color Module [CSS3COLOR].
 func (e *MathMLAnnotationXMLElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -493,10 +503,8 @@ func (e *MathMLAnnotationXMLElement) IfMathbackgroundf(condition bool, format st
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLAnnotationXMLElement) MathbackgroundRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -504,10 +512,8 @@ func (e *MathMLAnnotationXMLElement) MathbackgroundRemove() *MathMLAnnotationXML
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLAnnotationXMLElement) Mathcolor(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -523,7 +529,7 @@ func (e *MathMLAnnotationXMLElement) Mathcolorf(format string, args ...any) *Mat
 // Module [CSS3COLOR].
 func (e *MathMLAnnotationXMLElement) IfMathcolor(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -533,7 +539,7 @@ func (e *MathMLAnnotationXMLElement) IfMathcolor(condition bool, s string) *Math
 // Module [CSS3COLOR].
 func (e *MathMLAnnotationXMLElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -543,20 +549,16 @@ func (e *MathMLAnnotationXMLElement) IfMathcolorf(condition bool, format string,
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLAnnotationXMLElement) MathcolorRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLAnnotationXMLElement) MathsizeStr(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -570,7 +572,7 @@ func (e *MathMLAnnotationXMLElement) MathsizeStrf(format string, args ...any) *M
 // dimension or a dimensionless number.
 func (e *MathMLAnnotationXMLElement) IfMathsizeStr(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -579,7 +581,7 @@ func (e *MathMLAnnotationXMLElement) IfMathsizeStr(condition bool, s string) *Ma
 // dimension or a dimensionless number.
 func (e *MathMLAnnotationXMLElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -588,10 +590,8 @@ func (e *MathMLAnnotationXMLElement) IfMathsizeStrf(condition bool, format strin
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLAnnotationXMLElement) MathsizeStrRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -600,10 +600,8 @@ func (e *MathMLAnnotationXMLElement) MathsizeStrRemove() *MathMLAnnotationXMLEle
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLAnnotationXMLElement) Nonce(s string) *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -621,7 +619,7 @@ func (e *MathMLAnnotationXMLElement) Noncef(format string, args ...any) *MathMLA
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLAnnotationXMLElement) IfNonce(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -632,7 +630,7 @@ func (e *MathMLAnnotationXMLElement) IfNonce(condition bool, s string) *MathMLAn
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLAnnotationXMLElement) IfNoncef(condition bool, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -643,20 +641,16 @@ func (e *MathMLAnnotationXMLElement) IfNoncef(condition bool, format string, arg
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLAnnotationXMLElement) NonceRemove() *MathMLAnnotationXMLElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLAnnotationXMLElement) Scriptlevel(i int) *MathMLAnnotationXMLElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -664,7 +658,7 @@ func (e *MathMLAnnotationXMLElement) Scriptlevel(i int) *MathMLAnnotationXMLElem
 // an integer between 0 and 7, inclusive.
 func (e *MathMLAnnotationXMLElement) IfScriptlevel(condition bool, i int) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -673,10 +667,8 @@ func (e *MathMLAnnotationXMLElement) IfScriptlevel(condition bool, i int) *MathM
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLAnnotationXMLElement) ScriptlevelRemove() *MathMLAnnotationXMLElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -686,14 +678,8 @@ func (e *MathMLAnnotationXMLElement) StylePairs(pairs ...string) *MathMLAnnotati
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -708,14 +694,8 @@ func (e *MathMLAnnotationXMLElement) StylePairs(pairs ...string) *MathMLAnnotati
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLAnnotationXMLElement) Style(s string) *MathMLAnnotationXMLElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -723,7 +703,7 @@ func (e *MathMLAnnotationXMLElement) Style(s string) *MathMLAnnotationXMLElement
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -732,7 +712,7 @@ func (e *MathMLAnnotationXMLElement) Style(s string) *MathMLAnnotationXMLElement
 // set.
 func (e *MathMLAnnotationXMLElement) IfStyle(condition bool, s string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -740,15 +720,7 @@ func (e *MathMLAnnotationXMLElement) IfStyle(condition bool, s string) *MathMLAn
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLAnnotationXMLElement) StyleAdd(k string, v string) *MathMLAnnotationXMLElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -762,7 +734,7 @@ func (e *MathMLAnnotationXMLElement) StyleAddf(k string, format string, args ...
 // set.
 func (e *MathMLAnnotationXMLElement) IfStyleAdd(condition bool, k string, v string) *MathMLAnnotationXMLElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -771,7 +743,7 @@ func (e *MathMLAnnotationXMLElement) IfStyleAdd(condition bool, k string, v stri
 // set.
 func (e *MathMLAnnotationXMLElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLAnnotationXMLElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -780,21 +752,15 @@ func (e *MathMLAnnotationXMLElement) IfStyleAddf(condition bool, k string, forma
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLAnnotationXMLElement) StyleMap(m map[string]string) *MathMLAnnotationXMLElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -803,14 +769,8 @@ func (e *MathMLAnnotationXMLElement) StyleMap(m map[string]string) *MathMLAnnota
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLAnnotationXMLElement) StyleRemove(keys ...string) *MathMLAnnotationXMLElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -818,10 +778,8 @@ func (e *MathMLAnnotationXMLElement) StyleRemove(keys ...string) *MathMLAnnotati
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLAnnotationXMLElement) Tabindex(i int) *MathMLAnnotationXMLElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -830,7 +788,7 @@ func (e *MathMLAnnotationXMLElement) Tabindex(i int) *MathMLAnnotationXMLElement
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLAnnotationXMLElement) IfTabindex(condition bool, i int) *MathMLAnnotationXMLElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -840,9 +798,7 @@ func (e *MathMLAnnotationXMLElement) IfTabindex(condition bool, i int) *MathMLAn
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLAnnotationXMLElement) TabindexRemove() *MathMLAnnotationXMLElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to specify the behavior of a subexpression when it is
@@ -24,18 +22,65 @@ type MathMLMactionElement struct {
 // with the tag "maction" during rendering.
 func MathMLMaction(children ...ElementRenderer) *MathMLMactionElement {
 	e := NewElement("maction", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMactionElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMactionElement) Clone() *MathMLMactionElement {
+	return &MathMLMactionElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMactionElement) CloneShallow() *MathMLMactionElement {
+	return &MathMLMactionElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMactionElement) Freeze() *MathMLMactionElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMactionElement) writable() *MathMLMactionElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMactionElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMactionElement) choiceType(name string) func(string) any {
+	switch name {
+	case "actiontype":
+		return choiceValue[MathMLMactionActiontypeChoice]
+	case "selection":
+		return choiceValue[MathMLMactionSelectionChoice]
+	case "dir":
+		return choiceValue[MathMLMactionDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMactionDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMactionElement) Children(children ...ElementRenderer) *MathMLMactionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMactionElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMactionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +88,30 @@ func (e *MathMLMactionElement) IfChildren(condition bool, children ...ElementRen
 
 func (e *MathMLMactionElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMactionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) BoolAttr(name string) *MathMLMactionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMactionElement) BoolAttrRemove(name string) *MathMLMactionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMactionElement) IfBoolAttr(condition bool, name string) *MathMLMactionElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +122,40 @@ func (e *MathMLMactionElement) BoolAttrf(format string, args ...any) *MathMLMact
 
 func (e *MathMLMactionElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) BoolAttrs(names ...string) *MathMLMactionElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) IfBoolAttrs(condition bool, names ...string) *MathMLMactionElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) Attr(name, value string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMactionElement) RawAttr(name, value string) *MathMLMactionElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMactionElement) IfAttr(condition bool, name, value string) *MathMLMactionElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +166,52 @@ func (e *MathMLMactionElement) Attrf(name, format string, args ...any) *MathMLMa
 
 func (e *MathMLMactionElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) Attrs(attrs ...string) *MathMLMactionElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMactionElement) IfAttrs(condition bool, attrs ...string) *MathMLMactionElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) AttrsMap(attrs map[string]string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMactionElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMactionElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMactionElement) Text(text string) *MathMLMactionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMactionElement) Textf(format string, args ...any) *MathMLMactionElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMactionElement) IfText(condition bool, text string) *MathMLMactionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +219,28 @@ func (e *MathMLMactionElement) IfText(condition bool, text string) *MathMLMactio
 
 func (e *MathMLMactionElement) IfTextf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMactionElement) Raw(text string) *MathMLMactionElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMactionElement) Escaped(text string) *MathMLMactionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMactionElement) IfEscaped(condition bool, text string) *MathMLMactionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +252,7 @@ func (e *MathMLMactionElement) Escapedf(format string, args ...any) *MathMLMacti
 
 func (e *MathMLMactionElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +261,8 @@ func (e *MathMLMactionElement) IfEscapedf(condition bool, format string, args ..
 // This Attribute specifies the type of action performed when the element is
 // activated. Possible values are toggle, statusline, tooltip, and script.
 func (e *MathMLMactionElement) Actiontype(c MathMLMactionActiontypeChoice) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("actiontype", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("actiontype", string(c), choiceValue[MathMLMactionActiontypeChoice])
 	return e
 }
 
@@ -236,20 +283,16 @@ const (
 // activated. Possible values are toggle, statusline, tooltip, and script.
 // Remove the attribute Actiontype from the element.
 func (e *MathMLMactionElement) ActiontypeRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("actiontype")
+	e = e.writable()
+	e.removeAttribute("actiontype")
 	return e
 }
 
 // This Attribute specifies the type of selection performed when the element is
 // activated. Possible values are none, highlight, and unhighlight.
 func (e *MathMLMactionElement) Selection(c MathMLMactionSelectionChoice) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("selection", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("selection", string(c), choiceValue[MathMLMactionSelectionChoice])
 	return e
 }
 
@@ -268,10 +311,8 @@ const (
 // activated. Possible values are none, highlight, and unhighlight.
 // Remove the attribute Selection from the element.
 func (e *MathMLMactionElement) SelectionRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("selection")
+	e = e.writable()
+	e.removeAttribute("selection")
 	return e
 }
 
@@ -279,16 +320,9 @@ func (e *MathMLMactionElement) SelectionRemove() *MathMLMactionElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMactionElement) Class(s string) *MathMLMactionElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -297,7 +331,7 @@ func (e *MathMLMactionElement) Class(s string) *MathMLMactionElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMactionElement) IfClass(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -307,14 +341,8 @@ func (e *MathMLMactionElement) IfClass(condition bool, s string) *MathMLMactionE
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMactionElement) ClassRemove(s ...string) *MathMLMactionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -323,10 +351,8 @@ func (e *MathMLMactionElement) ClassRemove(s ...string) *MathMLMactionElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMactionElement) Dir(c MathMLMactionDirChoice) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMactionDirChoice])
 	return e
 }
 
@@ -345,20 +371,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMactionElement) DirRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMactionElement) Displaystyle(c MathMLMactionDisplaystyleChoice) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMactionDisplaystyleChoice])
 	return e
 }
 
@@ -375,20 +397,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMactionElement) DisplaystyleRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMactionElement) ID(s string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -402,7 +420,7 @@ func (e *MathMLMactionElement) IDf(format string, args ...any) *MathMLMactionEle
 // document.
 func (e *MathMLMactionElement) IfID(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -411,7 +429,7 @@ func (e *MathMLMactionElement) IfID(condition bool, s string) *MathMLMactionElem
 // document.
 func (e *MathMLMactionElement) IfIDf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -420,10 +438,8 @@ func (e *MathMLMactionElement) IfIDf(condition bool, format string, args ...any)
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMactionElement) IDRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -431,10 +447,8 @@ func (e *MathMLMactionElement) IDRemove() *MathMLMactionElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMactionElement) Mathbackground(s string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -450,7 +464,7 @@ func (e *MathMLMactionElement) Mathbackgroundf(format string, args ...any) *Math
 // Color Module [CSS3COLOR].
 func (e *MathMLMactionElement) IfMathbackground(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -460,7 +474,7 @@ func (e *MathMLMactionElement) IfMathbackground(condition bool, s string) *MathM
 // Color Module [CSS3COLOR].
 func (e *MathMLMactionElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -470,10 +484,8 @@ func (e *MathMLMactionElement) IfMathbackgroundf(condition bool, format string,
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMactionElement) MathbackgroundRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -481,10 +493,8 @@ func (e *MathMLMactionElement) MathbackgroundRemove() *MathMLMactionElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMactionElement) Mathcolor(s string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -500,7 +510,7 @@ func (e *MathMLMactionElement) Mathcolorf(format string, args ...any) *MathMLMac
 // Module [CSS3COLOR].
 func (e *MathMLMactionElement) IfMathcolor(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -510,7 +520,7 @@ func (e *MathMLMactionElement) IfMathcolor(condition bool, s string) *MathMLMact
 // Module [CSS3COLOR].
 func (e *MathMLMactionElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -520,20 +530,16 @@ func (e *MathMLMactionElement) IfMathcolorf(condition bool, format string, args
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMactionElement) MathcolorRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMactionElement) MathsizeStr(s string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -547,7 +553,7 @@ func (e *MathMLMactionElement) MathsizeStrf(format string, args ...any) *MathMLM
 // dimension or a dimensionless number.
 func (e *MathMLMactionElement) IfMathsizeStr(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -556,7 +562,7 @@ func (e *MathMLMactionElement) IfMathsizeStr(condition bool, s string) *MathMLMa
 // dimension or a dimensionless number.
 func (e *MathMLMactionElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -565,10 +571,8 @@ func (e *MathMLMactionElement) IfMathsizeStrf(condition bool, format string, arg
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMactionElement) MathsizeStrRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -577,10 +581,8 @@ func (e *MathMLMactionElement) MathsizeStrRemove() *MathMLMactionElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMactionElement) Nonce(s string) *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -598,7 +600,7 @@ func (e *MathMLMactionElement) Noncef(format string, args ...any) *MathMLMaction
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMactionElement) IfNonce(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -609,7 +611,7 @@ func (e *MathMLMactionElement) IfNonce(condition bool, s string) *MathMLMactionE
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMactionElement) IfNoncef(condition bool, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -620,20 +622,16 @@ func (e *MathMLMactionElement) IfNoncef(condition bool, format string, args ...a
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMactionElement) NonceRemove() *MathMLMactionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMactionElement) Scriptlevel(i int) *MathMLMactionElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -641,7 +639,7 @@ func (e *MathMLMactionElement) Scriptlevel(i int) *MathMLMactionElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMactionElement) IfScriptlevel(condition bool, i int) *MathMLMactionElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -650,10 +648,8 @@ func (e *MathMLMactionElement) IfScriptlevel(condition bool, i int) *MathMLMacti
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMactionElement) ScriptlevelRemove() *MathMLMactionElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -663,14 +659,8 @@ func (e *MathMLMactionElement) StylePairs(pairs ...string) *MathMLMactionElement
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -685,14 +675,8 @@ func (e *MathMLMactionElement) StylePairs(pairs ...string) *MathMLMactionElement
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMactionElement) Style(s string) *MathMLMactionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -700,7 +684,7 @@ func (e *MathMLMactionElement) Style(s string) *MathMLMactionElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -709,7 +693,7 @@ func (e *MathMLMactionElement) Style(s string) *MathMLMactionElement {
 // set.
 func (e *MathMLMactionElement) IfStyle(condition bool, s string) *MathMLMactionElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -717,15 +701,7 @@ func (e *MathMLMactionElement) IfStyle(condition bool, s string) *MathMLMactionE
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMactionElement) StyleAdd(k string, v string) *MathMLMactionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -739,7 +715,7 @@ func (e *MathMLMactionElement) StyleAddf(k string, format string, args ...any) *
 // set.
 func (e *MathMLMactionElement) IfStyleAdd(condition bool, k string, v string) *MathMLMactionElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -748,7 +724,7 @@ func (e *MathMLMactionElement) IfStyleAdd(condition bool, k string, v string) *M
 // set.
 func (e *MathMLMactionElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMactionElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -757,21 +733,15 @@ func (e *MathMLMactionElement) IfStyleAddf(condition bool, k string, format stri
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMactionElement) StyleMap(m map[string]string) *MathMLMactionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -780,14 +750,8 @@ func (e *MathMLMactionElement) StyleMap(m map[string]string) *MathMLMactionEleme
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMactionElement) StyleRemove(keys ...string) *MathMLMactionElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -795,10 +759,8 @@ func (e *MathMLMactionElement) StyleRemove(keys ...string) *MathMLMactionElement
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMactionElement) Tabindex(i int) *MathMLMactionElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -807,7 +769,7 @@ func (e *MathMLMactionElement) Tabindex(i int) *MathMLMactionElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMactionElement) IfTabindex(condition bool, i int) *MathMLMactionElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -817,9 +779,7 @@ func (e *MathMLMactionElement) IfTabindex(condition bool, i int) *MathMLMactionE
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMactionElement) TabindexRemove() *MathMLMactionElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
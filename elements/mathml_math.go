@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is the root element of a MathML expression. It is used to
@@ -23,18 +21,69 @@ type MathMLMathElement struct {
 // with the tag "math" during rendering.
 func MathMLMath(children ...ElementRenderer) *MathMLMathElement {
 	e := NewElement("math", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMathElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMathElement) Clone() *MathMLMathElement {
+	return &MathMLMathElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMathElement) CloneShallow() *MathMLMathElement {
+	return &MathMLMathElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMathElement) Freeze() *MathMLMathElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMathElement) writable() *MathMLMathElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMathElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMathElement) choiceType(name string) func(string) any {
+	switch name {
+	case "xmlns":
+		return choiceValue[MathMLMathXMLNSChoice]
+	case "xmlns:m":
+		return choiceValue[MathMLMathXMLNSMChoice]
+	case "xmlns:xlink":
+		return choiceValue[MathMLMathXMLNSXlinkChoice]
+	case "xmlns:xml":
+		return choiceValue[MathMLMathXMLNSXMLChoice]
+	case "dir":
+		return choiceValue[MathMLMathDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMathDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMathElement) Children(children ...ElementRenderer) *MathMLMathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMathElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +91,30 @@ func (e *MathMLMathElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *MathMLMathElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) BoolAttr(name string) *MathMLMathElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMathElement) BoolAttrRemove(name string) *MathMLMathElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMathElement) IfBoolAttr(condition bool, name string) *MathMLMathElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +125,40 @@ func (e *MathMLMathElement) BoolAttrf(format string, args ...any) *MathMLMathEle
 
 func (e *MathMLMathElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) BoolAttrs(names ...string) *MathMLMathElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) IfBoolAttrs(condition bool, names ...string) *MathMLMathElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) Attr(name, value string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMathElement) RawAttr(name, value string) *MathMLMathElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMathElement) IfAttr(condition bool, name, value string) *MathMLMathElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +169,52 @@ func (e *MathMLMathElement) Attrf(name, format string, args ...any) *MathMLMathE
 
 func (e *MathMLMathElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) Attrs(attrs ...string) *MathMLMathElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMathElement) IfAttrs(condition bool, attrs ...string) *MathMLMathElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) AttrsMap(attrs map[string]string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMathElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMathElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMathElement) Text(text string) *MathMLMathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMathElement) Textf(format string, args ...any) *MathMLMathElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMathElement) IfText(condition bool, text string) *MathMLMathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +222,28 @@ func (e *MathMLMathElement) IfText(condition bool, text string) *MathMLMathEleme
 
 func (e *MathMLMathElement) IfTextf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMathElement) Raw(text string) *MathMLMathElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMathElement) Escaped(text string) *MathMLMathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMathElement) IfEscaped(condition bool, text string) *MathMLMathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +255,7 @@ func (e *MathMLMathElement) Escapedf(format string, args ...any) *MathMLMathElem
 
 func (e *MathMLMathElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +265,8 @@ func (e *MathMLMathElement) IfEscapedf(condition bool, format string, args ...an
 // the document. Possible values are http://www.w3.org/1998/Math/MathML and
 // http://www.w3.org/1999/xhtml.
 func (e *MathMLMathElement) XMLNS(c MathMLMathXMLNSChoice) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("xmlns", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("xmlns", string(c), choiceValue[MathMLMathXMLNSChoice])
 	return e
 }
 
@@ -233,10 +284,8 @@ const (
 // http://www.w3.org/1999/xhtml.
 // Remove the attribute XMLNS from the element.
 func (e *MathMLMathElement) XMLNSRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("xmlns")
+	e = e.writable()
+	e.removeAttribute("xmlns")
 	return e
 }
 
@@ -244,10 +293,8 @@ func (e *MathMLMathElement) XMLNSRemove() *MathMLMathElement {
 // document whose names start with the letter m. Possible values are
 // http://www.w3.org/1998/Math/MathML and http://www.w3.org/1999/xhtml.
 func (e *MathMLMathElement) XMLNSM(c MathMLMathXMLNSMChoice) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("xmlns:m", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("xmlns:m", string(c), choiceValue[MathMLMathXMLNSMChoice])
 	return e
 }
 
@@ -265,10 +312,8 @@ const (
 // http://www.w3.org/1998/Math/MathML and http://www.w3.org/1999/xhtml.
 // Remove the attribute XMLNSM from the element.
 func (e *MathMLMathElement) XMLNSMRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("xmlns:m")
+	e = e.writable()
+	e.removeAttribute("xmlns:m")
 	return e
 }
 
@@ -276,10 +321,8 @@ func (e *MathMLMathElement) XMLNSMRemove() *MathMLMathElement {
 // document whose names start with the letters xlink. Possible values are
 // http://www.w3.org/1999/xlink and http://www.w3.org/1999/xhtml.
 func (e *MathMLMathElement) XMLNSXlink(c MathMLMathXMLNSXlinkChoice) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("xmlns:xlink", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("xmlns:xlink", string(c), choiceValue[MathMLMathXMLNSXlinkChoice])
 	return e
 }
 
@@ -297,10 +340,8 @@ const (
 // http://www.w3.org/1999/xlink and http://www.w3.org/1999/xhtml.
 // Remove the attribute XMLNSXlink from the element.
 func (e *MathMLMathElement) XMLNSXlinkRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("xmlns:xlink")
+	e = e.writable()
+	e.removeAttribute("xmlns:xlink")
 	return e
 }
 
@@ -308,10 +349,8 @@ func (e *MathMLMathElement) XMLNSXlinkRemove() *MathMLMathElement {
 // document whose names start with the letters xml. Possible values are
 // http://www.w3.org/XML/1998/namespace and http://www.w3.org/1999/xhtml.
 func (e *MathMLMathElement) XMLNSXML(c MathMLMathXMLNSXMLChoice) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("xmlns:xml", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("xmlns:xml", string(c), choiceValue[MathMLMathXMLNSXMLChoice])
 	return e
 }
 
@@ -329,10 +368,8 @@ const (
 // http://www.w3.org/XML/1998/namespace and http://www.w3.org/1999/xhtml.
 // Remove the attribute XMLNSXML from the element.
 func (e *MathMLMathElement) XMLNSXMLRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("xmlns:xml")
+	e = e.writable()
+	e.removeAttribute("xmlns:xml")
 	return e
 }
 
@@ -340,16 +377,9 @@ func (e *MathMLMathElement) XMLNSXMLRemove() *MathMLMathElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMathElement) Class(s string) *MathMLMathElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -358,7 +388,7 @@ func (e *MathMLMathElement) Class(s string) *MathMLMathElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMathElement) IfClass(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -368,14 +398,8 @@ func (e *MathMLMathElement) IfClass(condition bool, s string) *MathMLMathElement
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMathElement) ClassRemove(s ...string) *MathMLMathElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -384,10 +408,8 @@ func (e *MathMLMathElement) ClassRemove(s ...string) *MathMLMathElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMathElement) Dir(c MathMLMathDirChoice) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMathDirChoice])
 	return e
 }
 
@@ -406,20 +428,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMathElement) DirRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMathElement) Displaystyle(c MathMLMathDisplaystyleChoice) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMathDisplaystyleChoice])
 	return e
 }
 
@@ -436,20 +454,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMathElement) DisplaystyleRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMathElement) ID(s string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -463,7 +477,7 @@ func (e *MathMLMathElement) IDf(format string, args ...any) *MathMLMathElement {
 // document.
 func (e *MathMLMathElement) IfID(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -472,7 +486,7 @@ func (e *MathMLMathElement) IfID(condition bool, s string) *MathMLMathElement {
 // document.
 func (e *MathMLMathElement) IfIDf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -481,10 +495,8 @@ func (e *MathMLMathElement) IfIDf(condition bool, format string, args ...any) *M
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMathElement) IDRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -492,10 +504,8 @@ func (e *MathMLMathElement) IDRemove() *MathMLMathElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMathElement) Mathbackground(s string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -511,7 +521,7 @@ func (e *MathMLMathElement) Mathbackgroundf(format string, args ...any) *MathMLM
 // Color Module [CSS3COLOR].
 func (e *MathMLMathElement) IfMathbackground(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -521,7 +531,7 @@ func (e *MathMLMathElement) IfMathbackground(condition bool, s string) *MathMLMa
 // Color Module [CSS3COLOR].
 func (e *MathMLMathElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -531,10 +541,8 @@ func (e *MathMLMathElement) IfMathbackgroundf(condition bool, format string, arg
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMathElement) MathbackgroundRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -542,10 +550,8 @@ func (e *MathMLMathElement) MathbackgroundRemove() *MathMLMathElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMathElement) Mathcolor(s string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -561,7 +567,7 @@ func (e *MathMLMathElement) Mathcolorf(format string, args ...any) *MathMLMathEl
 // Module [CSS3COLOR].
 func (e *MathMLMathElement) IfMathcolor(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -571,7 +577,7 @@ func (e *MathMLMathElement) IfMathcolor(condition bool, s string) *MathMLMathEle
 // Module [CSS3COLOR].
 func (e *MathMLMathElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -581,20 +587,16 @@ func (e *MathMLMathElement) IfMathcolorf(condition bool, format string, args ...
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMathElement) MathcolorRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMathElement) MathsizeStr(s string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -608,7 +610,7 @@ func (e *MathMLMathElement) MathsizeStrf(format string, args ...any) *MathMLMath
 // dimension or a dimensionless number.
 func (e *MathMLMathElement) IfMathsizeStr(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -617,7 +619,7 @@ func (e *MathMLMathElement) IfMathsizeStr(condition bool, s string) *MathMLMathE
 // dimension or a dimensionless number.
 func (e *MathMLMathElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -626,10 +628,8 @@ func (e *MathMLMathElement) IfMathsizeStrf(condition bool, format string, args .
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMathElement) MathsizeStrRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -638,10 +638,8 @@ func (e *MathMLMathElement) MathsizeStrRemove() *MathMLMathElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMathElement) Nonce(s string) *MathMLMathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -659,7 +657,7 @@ func (e *MathMLMathElement) Noncef(format string, args ...any) *MathMLMathElemen
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMathElement) IfNonce(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -670,7 +668,7 @@ func (e *MathMLMathElement) IfNonce(condition bool, s string) *MathMLMathElement
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMathElement) IfNoncef(condition bool, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -681,20 +679,16 @@ func (e *MathMLMathElement) IfNoncef(condition bool, format string, args ...any)
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMathElement) NonceRemove() *MathMLMathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMathElement) Scriptlevel(i int) *MathMLMathElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -702,7 +696,7 @@ func (e *MathMLMathElement) Scriptlevel(i int) *MathMLMathElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMathElement) IfScriptlevel(condition bool, i int) *MathMLMathElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -711,10 +705,8 @@ func (e *MathMLMathElement) IfScriptlevel(condition bool, i int) *MathMLMathElem
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMathElement) ScriptlevelRemove() *MathMLMathElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -724,14 +716,8 @@ func (e *MathMLMathElement) StylePairs(pairs ...string) *MathMLMathElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -746,14 +732,8 @@ func (e *MathMLMathElement) StylePairs(pairs ...string) *MathMLMathElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMathElement) Style(s string) *MathMLMathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -761,7 +741,7 @@ func (e *MathMLMathElement) Style(s string) *MathMLMathElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -770,7 +750,7 @@ func (e *MathMLMathElement) Style(s string) *MathMLMathElement {
 // set.
 func (e *MathMLMathElement) IfStyle(condition bool, s string) *MathMLMathElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -778,15 +758,7 @@ func (e *MathMLMathElement) IfStyle(condition bool, s string) *MathMLMathElement
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMathElement) StyleAdd(k string, v string) *MathMLMathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -800,7 +772,7 @@ func (e *MathMLMathElement) StyleAddf(k string, format string, args ...any) *Mat
 // set.
 func (e *MathMLMathElement) IfStyleAdd(condition bool, k string, v string) *MathMLMathElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -809,7 +781,7 @@ func (e *MathMLMathElement) IfStyleAdd(condition bool, k string, v string) *Math
 // set.
 func (e *MathMLMathElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMathElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -818,21 +790,15 @@ func (e *MathMLMathElement) IfStyleAddf(condition bool, k string, format string,
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMathElement) StyleMap(m map[string]string) *MathMLMathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -841,14 +807,8 @@ func (e *MathMLMathElement) StyleMap(m map[string]string) *MathMLMathElement {
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMathElement) StyleRemove(keys ...string) *MathMLMathElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -856,10 +816,8 @@ func (e *MathMLMathElement) StyleRemove(keys ...string) *MathMLMathElement {
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMathElement) Tabindex(i int) *MathMLMathElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -868,7 +826,7 @@ func (e *MathMLMathElement) Tabindex(i int) *MathMLMathElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMathElement) IfTabindex(condition bool, i int) *MathMLMathElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -878,9 +836,7 @@ func (e *MathMLMathElement) IfTabindex(condition bool, i int) *MathMLMathElement
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMathElement) TabindexRemove() *MathMLMathElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
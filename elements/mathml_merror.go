@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to indicate that an error has occurred while processing
@@ -23,18 +21,61 @@ type MathMLMerrorElement struct {
 // with the tag "merror" during rendering.
 func MathMLMerror(children ...ElementRenderer) *MathMLMerrorElement {
 	e := NewElement("merror", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMerrorElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMerrorElement) Clone() *MathMLMerrorElement {
+	return &MathMLMerrorElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMerrorElement) CloneShallow() *MathMLMerrorElement {
+	return &MathMLMerrorElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMerrorElement) Freeze() *MathMLMerrorElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMerrorElement) writable() *MathMLMerrorElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMerrorElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMerrorElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLMerrorDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMerrorDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMerrorElement) Children(children ...ElementRenderer) *MathMLMerrorElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMerrorElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMerrorElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +83,30 @@ func (e *MathMLMerrorElement) IfChildren(condition bool, children ...ElementRend
 
 func (e *MathMLMerrorElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMerrorElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) BoolAttr(name string) *MathMLMerrorElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMerrorElement) BoolAttrRemove(name string) *MathMLMerrorElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMerrorElement) IfBoolAttr(condition bool, name string) *MathMLMerrorElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +117,40 @@ func (e *MathMLMerrorElement) BoolAttrf(format string, args ...any) *MathMLMerro
 
 func (e *MathMLMerrorElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) BoolAttrs(names ...string) *MathMLMerrorElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) IfBoolAttrs(condition bool, names ...string) *MathMLMerrorElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) Attr(name, value string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMerrorElement) RawAttr(name, value string) *MathMLMerrorElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMerrorElement) IfAttr(condition bool, name, value string) *MathMLMerrorElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +161,52 @@ func (e *MathMLMerrorElement) Attrf(name, format string, args ...any) *MathMLMer
 
 func (e *MathMLMerrorElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) Attrs(attrs ...string) *MathMLMerrorElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMerrorElement) IfAttrs(condition bool, attrs ...string) *MathMLMerrorElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) AttrsMap(attrs map[string]string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMerrorElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMerrorElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMerrorElement) Text(text string) *MathMLMerrorElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMerrorElement) Textf(format string, args ...any) *MathMLMerrorElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMerrorElement) IfText(condition bool, text string) *MathMLMerrorElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +214,28 @@ func (e *MathMLMerrorElement) IfText(condition bool, text string) *MathMLMerrorE
 
 func (e *MathMLMerrorElement) IfTextf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMerrorElement) Raw(text string) *MathMLMerrorElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMerrorElement) Escaped(text string) *MathMLMerrorElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMerrorElement) IfEscaped(condition bool, text string) *MathMLMerrorElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +247,7 @@ func (e *MathMLMerrorElement) Escapedf(format string, args ...any) *MathMLMerror
 
 func (e *MathMLMerrorElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,16 +257,9 @@ func (e *MathMLMerrorElement) IfEscapedf(condition bool, format string, args ...
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMerrorElement) Class(s string) *MathMLMerrorElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -230,7 +268,7 @@ func (e *MathMLMerrorElement) Class(s string) *MathMLMerrorElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMerrorElement) IfClass(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -240,14 +278,8 @@ func (e *MathMLMerrorElement) IfClass(condition bool, s string) *MathMLMerrorEle
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMerrorElement) ClassRemove(s ...string) *MathMLMerrorElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -256,10 +288,8 @@ func (e *MathMLMerrorElement) ClassRemove(s ...string) *MathMLMerrorElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMerrorElement) Dir(c MathMLMerrorDirChoice) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMerrorDirChoice])
 	return e
 }
 
@@ -278,20 +308,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMerrorElement) DirRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMerrorElement) Displaystyle(c MathMLMerrorDisplaystyleChoice) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMerrorDisplaystyleChoice])
 	return e
 }
 
@@ -308,20 +334,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMerrorElement) DisplaystyleRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMerrorElement) ID(s string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -335,7 +357,7 @@ func (e *MathMLMerrorElement) IDf(format string, args ...any) *MathMLMerrorEleme
 // document.
 func (e *MathMLMerrorElement) IfID(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -344,7 +366,7 @@ func (e *MathMLMerrorElement) IfID(condition bool, s string) *MathMLMerrorElemen
 // document.
 func (e *MathMLMerrorElement) IfIDf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -353,10 +375,8 @@ func (e *MathMLMerrorElement) IfIDf(condition bool, format string, args ...any)
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMerrorElement) IDRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -364,10 +384,8 @@ func (e *MathMLMerrorElement) IDRemove() *MathMLMerrorElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMerrorElement) Mathbackground(s string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -383,7 +401,7 @@ func (e *MathMLMerrorElement) Mathbackgroundf(format string, args ...any) *MathM
 // Color Module [CSS3COLOR].
 func (e *MathMLMerrorElement) IfMathbackground(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -393,7 +411,7 @@ func (e *MathMLMerrorElement) IfMathbackground(condition bool, s string) *MathML
 // Color Module [CSS3COLOR].
 func (e *MathMLMerrorElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -403,10 +421,8 @@ func (e *MathMLMerrorElement) IfMathbackgroundf(condition bool, format string, a
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMerrorElement) MathbackgroundRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -414,10 +430,8 @@ func (e *MathMLMerrorElement) MathbackgroundRemove() *MathMLMerrorElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMerrorElement) Mathcolor(s string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -433,7 +447,7 @@ func (e *MathMLMerrorElement) Mathcolorf(format string, args ...any) *MathMLMerr
 // Module [CSS3COLOR].
 func (e *MathMLMerrorElement) IfMathcolor(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -443,7 +457,7 @@ func (e *MathMLMerrorElement) IfMathcolor(condition bool, s string) *MathMLMerro
 // Module [CSS3COLOR].
 func (e *MathMLMerrorElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -453,20 +467,16 @@ func (e *MathMLMerrorElement) IfMathcolorf(condition bool, format string, args .
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMerrorElement) MathcolorRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMerrorElement) MathsizeStr(s string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -480,7 +490,7 @@ func (e *MathMLMerrorElement) MathsizeStrf(format string, args ...any) *MathMLMe
 // dimension or a dimensionless number.
 func (e *MathMLMerrorElement) IfMathsizeStr(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -489,7 +499,7 @@ func (e *MathMLMerrorElement) IfMathsizeStr(condition bool, s string) *MathMLMer
 // dimension or a dimensionless number.
 func (e *MathMLMerrorElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -498,10 +508,8 @@ func (e *MathMLMerrorElement) IfMathsizeStrf(condition bool, format string, args
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMerrorElement) MathsizeStrRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -510,10 +518,8 @@ func (e *MathMLMerrorElement) MathsizeStrRemove() *MathMLMerrorElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMerrorElement) Nonce(s string) *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -531,7 +537,7 @@ func (e *MathMLMerrorElement) Noncef(format string, args ...any) *MathMLMerrorEl
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMerrorElement) IfNonce(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -542,7 +548,7 @@ func (e *MathMLMerrorElement) IfNonce(condition bool, s string) *MathMLMerrorEle
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMerrorElement) IfNoncef(condition bool, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -553,20 +559,16 @@ func (e *MathMLMerrorElement) IfNoncef(condition bool, format string, args ...an
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMerrorElement) NonceRemove() *MathMLMerrorElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMerrorElement) Scriptlevel(i int) *MathMLMerrorElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -574,7 +576,7 @@ func (e *MathMLMerrorElement) Scriptlevel(i int) *MathMLMerrorElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMerrorElement) IfScriptlevel(condition bool, i int) *MathMLMerrorElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -583,10 +585,8 @@ func (e *MathMLMerrorElement) IfScriptlevel(condition bool, i int) *MathMLMerror
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMerrorElement) ScriptlevelRemove() *MathMLMerrorElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -596,14 +596,8 @@ func (e *MathMLMerrorElement) StylePairs(pairs ...string) *MathMLMerrorElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -618,14 +612,8 @@ func (e *MathMLMerrorElement) StylePairs(pairs ...string) *MathMLMerrorElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMerrorElement) Style(s string) *MathMLMerrorElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -633,7 +621,7 @@ func (e *MathMLMerrorElement) Style(s string) *MathMLMerrorElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -642,7 +630,7 @@ func (e *MathMLMerrorElement) Style(s string) *MathMLMerrorElement {
 // set.
 func (e *MathMLMerrorElement) IfStyle(condition bool, s string) *MathMLMerrorElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -650,15 +638,7 @@ func (e *MathMLMerrorElement) IfStyle(condition bool, s string) *MathMLMerrorEle
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMerrorElement) StyleAdd(k string, v string) *MathMLMerrorElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -672,7 +652,7 @@ func (e *MathMLMerrorElement) StyleAddf(k string, format string, args ...any) *M
 // set.
 func (e *MathMLMerrorElement) IfStyleAdd(condition bool, k string, v string) *MathMLMerrorElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -681,7 +661,7 @@ func (e *MathMLMerrorElement) IfStyleAdd(condition bool, k string, v string) *Ma
 // set.
 func (e *MathMLMerrorElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMerrorElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -690,21 +670,15 @@ func (e *MathMLMerrorElement) IfStyleAddf(condition bool, k string, format strin
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMerrorElement) StyleMap(m map[string]string) *MathMLMerrorElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -713,14 +687,8 @@ func (e *MathMLMerrorElement) StyleMap(m map[string]string) *MathMLMerrorElement
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMerrorElement) StyleRemove(keys ...string) *MathMLMerrorElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -728,10 +696,8 @@ func (e *MathMLMerrorElement) StyleRemove(keys ...string) *MathMLMerrorElement {
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMerrorElement) Tabindex(i int) *MathMLMerrorElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -740,7 +706,7 @@ func (e *MathMLMerrorElement) Tabindex(i int) *MathMLMerrorElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMerrorElement) IfTabindex(condition bool, i int) *MathMLMerrorElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -750,9 +716,7 @@ func (e *MathMLMerrorElement) IfTabindex(condition bool, i int) *MathMLMerrorEle
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMerrorElement) TabindexRemove() *MathMLMerrorElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
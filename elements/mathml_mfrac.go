@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display a fraction.
@@ -21,18 +19,63 @@ type MathMLMfracElement struct {
 // with the tag "mfrac" during rendering.
 func MathMLMfrac(children ...ElementRenderer) *MathMLMfracElement {
 	e := NewElement("mfrac", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMfracElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMfracElement) Clone() *MathMLMfracElement {
+	return &MathMLMfracElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMfracElement) CloneShallow() *MathMLMfracElement {
+	return &MathMLMfracElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMfracElement) Freeze() *MathMLMfracElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMfracElement) writable() *MathMLMfracElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMfracElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMfracElement) choiceType(name string) func(string) any {
+	switch name {
+	case "bevelled":
+		return choiceValue[MathMLMfracBevelledChoice]
+	case "dir":
+		return choiceValue[MathMLMfracDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMfracDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMfracElement) Children(children ...ElementRenderer) *MathMLMfracElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMfracElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMfracElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +83,30 @@ func (e *MathMLMfracElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *MathMLMfracElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMfracElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) BoolAttr(name string) *MathMLMfracElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMfracElement) BoolAttrRemove(name string) *MathMLMfracElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMfracElement) IfBoolAttr(condition bool, name string) *MathMLMfracElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +117,40 @@ func (e *MathMLMfracElement) BoolAttrf(format string, args ...any) *MathMLMfracE
 
 func (e *MathMLMfracElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) BoolAttrs(names ...string) *MathMLMfracElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) IfBoolAttrs(condition bool, names ...string) *MathMLMfracElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) Attr(name, value string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMfracElement) RawAttr(name, value string) *MathMLMfracElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMfracElement) IfAttr(condition bool, name, value string) *MathMLMfracElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +161,52 @@ func (e *MathMLMfracElement) Attrf(name, format string, args ...any) *MathMLMfra
 
 func (e *MathMLMfracElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) Attrs(attrs ...string) *MathMLMfracElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMfracElement) IfAttrs(condition bool, attrs ...string) *MathMLMfracElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) AttrsMap(attrs map[string]string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMfracElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMfracElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMfracElement) Text(text string) *MathMLMfracElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMfracElement) Textf(format string, args ...any) *MathMLMfracElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMfracElement) IfText(condition bool, text string) *MathMLMfracElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +214,28 @@ func (e *MathMLMfracElement) IfText(condition bool, text string) *MathMLMfracEle
 
 func (e *MathMLMfracElement) IfTextf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMfracElement) Raw(text string) *MathMLMfracElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMfracElement) Escaped(text string) *MathMLMfracElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMfracElement) IfEscaped(condition bool, text string) *MathMLMfracElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +247,7 @@ func (e *MathMLMfracElement) Escapedf(format string, args ...any) *MathMLMfracEl
 
 func (e *MathMLMfracElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +256,8 @@ func (e *MathMLMfracElement) IfEscapedf(condition bool, format string, args ...a
 // This Attribute specifies whether the fraction line is to be drawn straight or
 // to beveled. Possible values are true and false.
 func (e *MathMLMfracElement) Bevelled(c MathMLMfracBevelledChoice) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("bevelled", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("bevelled", string(c), choiceValue[MathMLMfracBevelledChoice])
 	return e
 }
 
@@ -229,10 +274,8 @@ const (
 // to beveled. Possible values are true and false.
 // Remove the attribute Bevelled from the element.
 func (e *MathMLMfracElement) BevelledRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("bevelled")
+	e = e.writable()
+	e.removeAttribute("bevelled")
 	return e
 }
 
@@ -240,16 +283,9 @@ func (e *MathMLMfracElement) BevelledRemove() *MathMLMfracElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMfracElement) Class(s string) *MathMLMfracElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -258,7 +294,7 @@ func (e *MathMLMfracElement) Class(s string) *MathMLMfracElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMfracElement) IfClass(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -268,14 +304,8 @@ func (e *MathMLMfracElement) IfClass(condition bool, s string) *MathMLMfracEleme
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMfracElement) ClassRemove(s ...string) *MathMLMfracElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -284,10 +314,8 @@ func (e *MathMLMfracElement) ClassRemove(s ...string) *MathMLMfracElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMfracElement) Dir(c MathMLMfracDirChoice) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMfracDirChoice])
 	return e
 }
 
@@ -306,20 +334,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMfracElement) DirRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMfracElement) Displaystyle(c MathMLMfracDisplaystyleChoice) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMfracDisplaystyleChoice])
 	return e
 }
 
@@ -336,20 +360,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMfracElement) DisplaystyleRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMfracElement) ID(s string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -363,7 +383,7 @@ func (e *MathMLMfracElement) IDf(format string, args ...any) *MathMLMfracElement
 // document.
 func (e *MathMLMfracElement) IfID(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -372,7 +392,7 @@ func (e *MathMLMfracElement) IfID(condition bool, s string) *MathMLMfracElement
 // document.
 func (e *MathMLMfracElement) IfIDf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -381,10 +401,8 @@ func (e *MathMLMfracElement) IfIDf(condition bool, format string, args ...any) *
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMfracElement) IDRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -392,10 +410,8 @@ func (e *MathMLMfracElement) IDRemove() *MathMLMfracElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMfracElement) Mathbackground(s string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -411,7 +427,7 @@ func (e *MathMLMfracElement) Mathbackgroundf(format string, args ...any) *MathML
 // Color Module [CSS3COLOR].
 func (e *MathMLMfracElement) IfMathbackground(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -421,7 +437,7 @@ func (e *MathMLMfracElement) IfMathbackground(condition bool, s string) *MathMLM
 // Color Module [CSS3COLOR].
 func (e *MathMLMfracElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -431,10 +447,8 @@ func (e *MathMLMfracElement) IfMathbackgroundf(condition bool, format string, ar
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMfracElement) MathbackgroundRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -442,10 +456,8 @@ func (e *MathMLMfracElement) MathbackgroundRemove() *MathMLMfracElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMfracElement) Mathcolor(s string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -461,7 +473,7 @@ func (e *MathMLMfracElement) Mathcolorf(format string, args ...any) *MathMLMfrac
 // Module [CSS3COLOR].
 func (e *MathMLMfracElement) IfMathcolor(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -471,7 +483,7 @@ func (e *MathMLMfracElement) IfMathcolor(condition bool, s string) *MathMLMfracE
 // Module [CSS3COLOR].
 func (e *MathMLMfracElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -481,20 +493,16 @@ func (e *MathMLMfracElement) IfMathcolorf(condition bool, format string, args ..
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMfracElement) MathcolorRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMfracElement) MathsizeStr(s string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -508,7 +516,7 @@ func (e *MathMLMfracElement) MathsizeStrf(format string, args ...any) *MathMLMfr
 // dimension or a dimensionless number.
 func (e *MathMLMfracElement) IfMathsizeStr(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -517,7 +525,7 @@ func (e *MathMLMfracElement) IfMathsizeStr(condition bool, s string) *MathMLMfra
 // dimension or a dimensionless number.
 func (e *MathMLMfracElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -526,10 +534,8 @@ func (e *MathMLMfracElement) IfMathsizeStrf(condition bool, format string, args
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMfracElement) MathsizeStrRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -538,10 +544,8 @@ func (e *MathMLMfracElement) MathsizeStrRemove() *MathMLMfracElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMfracElement) Nonce(s string) *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -559,7 +563,7 @@ func (e *MathMLMfracElement) Noncef(format string, args ...any) *MathMLMfracElem
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMfracElement) IfNonce(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -570,7 +574,7 @@ func (e *MathMLMfracElement) IfNonce(condition bool, s string) *MathMLMfracEleme
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMfracElement) IfNoncef(condition bool, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -581,20 +585,16 @@ func (e *MathMLMfracElement) IfNoncef(condition bool, format string, args ...any
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMfracElement) NonceRemove() *MathMLMfracElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMfracElement) Scriptlevel(i int) *MathMLMfracElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -602,7 +602,7 @@ func (e *MathMLMfracElement) Scriptlevel(i int) *MathMLMfracElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMfracElement) IfScriptlevel(condition bool, i int) *MathMLMfracElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -611,10 +611,8 @@ func (e *MathMLMfracElement) IfScriptlevel(condition bool, i int) *MathMLMfracEl
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMfracElement) ScriptlevelRemove() *MathMLMfracElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -624,14 +622,8 @@ func (e *MathMLMfracElement) StylePairs(pairs ...string) *MathMLMfracElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -646,14 +638,8 @@ func (e *MathMLMfracElement) StylePairs(pairs ...string) *MathMLMfracElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMfracElement) Style(s string) *MathMLMfracElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -661,7 +647,7 @@ func (e *MathMLMfracElement) Style(s string) *MathMLMfracElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -670,7 +656,7 @@ func (e *MathMLMfracElement) Style(s string) *MathMLMfracElement {
 // set.
 func (e *MathMLMfracElement) IfStyle(condition bool, s string) *MathMLMfracElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -678,15 +664,7 @@ func (e *MathMLMfracElement) IfStyle(condition bool, s string) *MathMLMfracEleme
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMfracElement) StyleAdd(k string, v string) *MathMLMfracElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -700,7 +678,7 @@ func (e *MathMLMfracElement) StyleAddf(k string, format string, args ...any) *Ma
 // set.
 func (e *MathMLMfracElement) IfStyleAdd(condition bool, k string, v string) *MathMLMfracElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -709,7 +687,7 @@ func (e *MathMLMfracElement) IfStyleAdd(condition bool, k string, v string) *Mat
 // set.
 func (e *MathMLMfracElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMfracElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -718,21 +696,15 @@ func (e *MathMLMfracElement) IfStyleAddf(condition bool, k string, format string
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMfracElement) StyleMap(m map[string]string) *MathMLMfracElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -741,14 +713,8 @@ func (e *MathMLMfracElement) StyleMap(m map[string]string) *MathMLMfracElement {
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMfracElement) StyleRemove(keys ...string) *MathMLMfracElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -756,10 +722,8 @@ func (e *MathMLMfracElement) StyleRemove(keys ...string) *MathMLMfracElement {
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMfracElement) Tabindex(i int) *MathMLMfracElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -768,7 +732,7 @@ func (e *MathMLMfracElement) Tabindex(i int) *MathMLMfracElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMfracElement) IfTabindex(condition bool, i int) *MathMLMfracElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -778,9 +742,7 @@ func (e *MathMLMfracElement) IfTabindex(condition bool, i int) *MathMLMfracEleme
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMfracElement) TabindexRemove() *MathMLMfracElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
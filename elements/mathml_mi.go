@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display a single identifier or a single operator.
@@ -21,18 +19,63 @@ type MathMLMiElement struct {
 // with the tag "mi" during rendering.
 func MathMLMi(children ...ElementRenderer) *MathMLMiElement {
 	e := NewElement("mi", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMiElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMiElement) Clone() *MathMLMiElement {
+	return &MathMLMiElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMiElement) CloneShallow() *MathMLMiElement {
+	return &MathMLMiElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMiElement) Freeze() *MathMLMiElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMiElement) writable() *MathMLMiElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMiElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMiElement) choiceType(name string) func(string) any {
+	switch name {
+	case "mathvariant":
+		return choiceValue[MathMLMiMathvariantChoice]
+	case "dir":
+		return choiceValue[MathMLMiDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMiDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMiElement) Children(children ...ElementRenderer) *MathMLMiElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMiElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +83,30 @@ func (e *MathMLMiElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *MathMLMiElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) BoolAttr(name string) *MathMLMiElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMiElement) BoolAttrRemove(name string) *MathMLMiElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMiElement) IfBoolAttr(condition bool, name string) *MathMLMiElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +117,40 @@ func (e *MathMLMiElement) BoolAttrf(format string, args ...any) *MathMLMiElement
 
 func (e *MathMLMiElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) BoolAttrs(names ...string) *MathMLMiElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) IfBoolAttrs(condition bool, names ...string) *MathMLMiElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) Attr(name, value string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMiElement) RawAttr(name, value string) *MathMLMiElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMiElement) IfAttr(condition bool, name, value string) *MathMLMiElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +161,52 @@ func (e *MathMLMiElement) Attrf(name, format string, args ...any) *MathMLMiEleme
 
 func (e *MathMLMiElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) Attrs(attrs ...string) *MathMLMiElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMiElement) IfAttrs(condition bool, attrs ...string) *MathMLMiElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) AttrsMap(attrs map[string]string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMiElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMiElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMiElement) Text(text string) *MathMLMiElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMiElement) Textf(format string, args ...any) *MathMLMiElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMiElement) IfText(condition bool, text string) *MathMLMiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +214,28 @@ func (e *MathMLMiElement) IfText(condition bool, text string) *MathMLMiElement {
 
 func (e *MathMLMiElement) IfTextf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMiElement) Raw(text string) *MathMLMiElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMiElement) Escaped(text string) *MathMLMiElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMiElement) IfEscaped(condition bool, text string) *MathMLMiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +247,7 @@ func (e *MathMLMiElement) Escapedf(format string, args ...any) *MathMLMiElement
 
 func (e *MathMLMiElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +258,8 @@ func (e *MathMLMiElement) IfEscapedf(condition bool, format string, args ...any)
 // bold-script, fraktur, sans-serif, bold-sans-serif, sans-serif-italic,
 // sans-serif-bold-italic, monospace, initial, and tailed.
 func (e *MathMLMiElement) Mathvariant(c MathMLMiMathvariantChoice) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathvariant", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("mathvariant", string(c), choiceValue[MathMLMiMathvariantChoice])
 	return e
 }
 
@@ -261,10 +306,8 @@ const (
 // sans-serif-bold-italic, monospace, initial, and tailed.
 // Remove the attribute Mathvariant from the element.
 func (e *MathMLMiElement) MathvariantRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathvariant")
+	e = e.writable()
+	e.removeAttribute("mathvariant")
 	return e
 }
 
@@ -272,16 +315,9 @@ func (e *MathMLMiElement) MathvariantRemove() *MathMLMiElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMiElement) Class(s string) *MathMLMiElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -290,7 +326,7 @@ func (e *MathMLMiElement) Class(s string) *MathMLMiElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMiElement) IfClass(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -300,14 +336,8 @@ func (e *MathMLMiElement) IfClass(condition bool, s string) *MathMLMiElement {
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMiElement) ClassRemove(s ...string) *MathMLMiElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -316,10 +346,8 @@ func (e *MathMLMiElement) ClassRemove(s ...string) *MathMLMiElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMiElement) Dir(c MathMLMiDirChoice) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMiDirChoice])
 	return e
 }
 
@@ -338,20 +366,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMiElement) DirRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMiElement) Displaystyle(c MathMLMiDisplaystyleChoice) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMiDisplaystyleChoice])
 	return e
 }
 
@@ -368,20 +392,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMiElement) DisplaystyleRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMiElement) ID(s string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -395,7 +415,7 @@ func (e *MathMLMiElement) IDf(format string, args ...any) *MathMLMiElement {
 // document.
 func (e *MathMLMiElement) IfID(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -404,7 +424,7 @@ func (e *MathMLMiElement) IfID(condition bool, s string) *MathMLMiElement {
 // document.
 func (e *MathMLMiElement) IfIDf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -413,10 +433,8 @@ func (e *MathMLMiElement) IfIDf(condition bool, format string, args ...any) *Mat
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMiElement) IDRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -424,10 +442,8 @@ func (e *MathMLMiElement) IDRemove() *MathMLMiElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMiElement) Mathbackground(s string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -443,7 +459,7 @@ func (e *MathMLMiElement) Mathbackgroundf(format string, args ...any) *MathMLMiE
 // Color Module [CSS3COLOR].
 func (e *MathMLMiElement) IfMathbackground(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -453,7 +469,7 @@ func (e *MathMLMiElement) IfMathbackground(condition bool, s string) *MathMLMiEl
 // Color Module [CSS3COLOR].
 func (e *MathMLMiElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -463,10 +479,8 @@ func (e *MathMLMiElement) IfMathbackgroundf(condition bool, format string, args
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMiElement) MathbackgroundRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -474,10 +488,8 @@ func (e *MathMLMiElement) MathbackgroundRemove() *MathMLMiElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMiElement) Mathcolor(s string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -493,7 +505,7 @@ func (e *MathMLMiElement) Mathcolorf(format string, args ...any) *MathMLMiElemen
 // Module [CSS3COLOR].
 func (e *MathMLMiElement) IfMathcolor(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -503,7 +515,7 @@ func (e *MathMLMiElement) IfMathcolor(condition bool, s string) *MathMLMiElement
 // Module [CSS3COLOR].
 func (e *MathMLMiElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -513,20 +525,16 @@ func (e *MathMLMiElement) IfMathcolorf(condition bool, format string, args ...an
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMiElement) MathcolorRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMiElement) MathsizeStr(s string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -540,7 +548,7 @@ func (e *MathMLMiElement) MathsizeStrf(format string, args ...any) *MathMLMiElem
 // dimension or a dimensionless number.
 func (e *MathMLMiElement) IfMathsizeStr(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -549,7 +557,7 @@ func (e *MathMLMiElement) IfMathsizeStr(condition bool, s string) *MathMLMiEleme
 // dimension or a dimensionless number.
 func (e *MathMLMiElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -558,10 +566,8 @@ func (e *MathMLMiElement) IfMathsizeStrf(condition bool, format string, args ...
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMiElement) MathsizeStrRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -570,10 +576,8 @@ func (e *MathMLMiElement) MathsizeStrRemove() *MathMLMiElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMiElement) Nonce(s string) *MathMLMiElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -591,7 +595,7 @@ func (e *MathMLMiElement) Noncef(format string, args ...any) *MathMLMiElement {
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMiElement) IfNonce(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -602,7 +606,7 @@ func (e *MathMLMiElement) IfNonce(condition bool, s string) *MathMLMiElement {
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMiElement) IfNoncef(condition bool, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -613,20 +617,16 @@ func (e *MathMLMiElement) IfNoncef(condition bool, format string, args ...any) *
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMiElement) NonceRemove() *MathMLMiElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMiElement) Scriptlevel(i int) *MathMLMiElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -634,7 +634,7 @@ func (e *MathMLMiElement) Scriptlevel(i int) *MathMLMiElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMiElement) IfScriptlevel(condition bool, i int) *MathMLMiElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -643,10 +643,8 @@ func (e *MathMLMiElement) IfScriptlevel(condition bool, i int) *MathMLMiElement
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMiElement) ScriptlevelRemove() *MathMLMiElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -656,14 +654,8 @@ func (e *MathMLMiElement) StylePairs(pairs ...string) *MathMLMiElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -678,14 +670,8 @@ func (e *MathMLMiElement) StylePairs(pairs ...string) *MathMLMiElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMiElement) Style(s string) *MathMLMiElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -693,7 +679,7 @@ func (e *MathMLMiElement) Style(s string) *MathMLMiElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -702,7 +688,7 @@ func (e *MathMLMiElement) Style(s string) *MathMLMiElement {
 // set.
 func (e *MathMLMiElement) IfStyle(condition bool, s string) *MathMLMiElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -710,15 +696,7 @@ func (e *MathMLMiElement) IfStyle(condition bool, s string) *MathMLMiElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMiElement) StyleAdd(k string, v string) *MathMLMiElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -732,7 +710,7 @@ func (e *MathMLMiElement) StyleAddf(k string, format string, args ...any) *MathM
 // set.
 func (e *MathMLMiElement) IfStyleAdd(condition bool, k string, v string) *MathMLMiElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -741,7 +719,7 @@ func (e *MathMLMiElement) IfStyleAdd(condition bool, k string, v string) *MathML
 // set.
 func (e *MathMLMiElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMiElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -750,21 +728,15 @@ func (e *MathMLMiElement) IfStyleAddf(condition bool, k string, format string, a
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMiElement) StyleMap(m map[string]string) *MathMLMiElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -773,14 +745,8 @@ func (e *MathMLMiElement) StyleMap(m map[string]string) *MathMLMiElement {
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMiElement) StyleRemove(keys ...string) *MathMLMiElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -788,10 +754,8 @@ func (e *MathMLMiElement) StyleRemove(keys ...string) *MathMLMiElement {
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMiElement) Tabindex(i int) *MathMLMiElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -800,7 +764,7 @@ func (e *MathMLMiElement) Tabindex(i int) *MathMLMiElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMiElement) IfTabindex(condition bool, i int) *MathMLMiElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -810,9 +774,7 @@ func (e *MathMLMiElement) IfTabindex(condition bool, i int) *MathMLMiElement {
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMiElement) TabindexRemove() *MathMLMiElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
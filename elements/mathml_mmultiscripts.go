@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display a base expression with multiple subscripts
@@ -22,18 +20,61 @@ type MathMLMmultiscriptsElement struct {
 // with the tag "mmultiscripts" during rendering.
 func MathMLMmultiscripts(children ...ElementRenderer) *MathMLMmultiscriptsElement {
 	e := NewElement("mmultiscripts", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMmultiscriptsElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMmultiscriptsElement) Clone() *MathMLMmultiscriptsElement {
+	return &MathMLMmultiscriptsElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMmultiscriptsElement) CloneShallow() *MathMLMmultiscriptsElement {
+	return &MathMLMmultiscriptsElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMmultiscriptsElement) Freeze() *MathMLMmultiscriptsElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMmultiscriptsElement) writable() *MathMLMmultiscriptsElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMmultiscriptsElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMmultiscriptsElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLMmultiscriptsDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMmultiscriptsDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMmultiscriptsElement) Children(children ...ElementRenderer) *MathMLMmultiscriptsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMmultiscriptsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *MathMLMmultiscriptsElement) IfChildren(condition bool, children ...Elem
 
 func (e *MathMLMmultiscriptsElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMmultiscriptsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) BoolAttr(name string) *MathMLMmultiscriptsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) BoolAttrRemove(name string) *MathMLMmultiscriptsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfBoolAttr(condition bool, name string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *MathMLMmultiscriptsElement) BoolAttrf(format string, args ...any) *Math
 
 func (e *MathMLMmultiscriptsElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) BoolAttrs(names ...string) *MathMLMmultiscriptsElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfBoolAttrs(condition bool, names ...string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) Attr(name, value string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMmultiscriptsElement) RawAttr(name, value string) *MathMLMmultiscriptsElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfAttr(condition bool, name, value string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *MathMLMmultiscriptsElement) Attrf(name, format string, args ...any) *Ma
 
 func (e *MathMLMmultiscriptsElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) Attrs(attrs ...string) *MathMLMmultiscriptsElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfAttrs(condition bool, attrs ...string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) AttrsMap(attrs map[string]string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) Text(text string) *MathMLMmultiscriptsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) Textf(format string, args ...any) *MathMLMmultiscriptsElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfText(condition bool, text string) *MathMLMmultiscriptsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *MathMLMmultiscriptsElement) IfText(condition bool, text string) *MathML
 
 func (e *MathMLMmultiscriptsElement) IfTextf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMmultiscriptsElement) Raw(text string) *MathMLMmultiscriptsElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMmultiscriptsElement) Escaped(text string) *MathMLMmultiscriptsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMmultiscriptsElement) IfEscaped(condition bool, text string) *MathMLMmultiscriptsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *MathMLMmultiscriptsElement) Escapedf(format string, args ...any) *MathM
 
 func (e *MathMLMmultiscriptsElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,16 +256,9 @@ func (e *MathMLMmultiscriptsElement) IfEscapedf(condition bool, format string, a
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMmultiscriptsElement) Class(s string) *MathMLMmultiscriptsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -229,7 +267,7 @@ func (e *MathMLMmultiscriptsElement) Class(s string) *MathMLMmultiscriptsElement
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMmultiscriptsElement) IfClass(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -239,14 +277,8 @@ func (e *MathMLMmultiscriptsElement) IfClass(condition bool, s string) *MathMLMm
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMmultiscriptsElement) ClassRemove(s ...string) *MathMLMmultiscriptsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -255,10 +287,8 @@ func (e *MathMLMmultiscriptsElement) ClassRemove(s ...string) *MathMLMmultiscrip
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMmultiscriptsElement) Dir(c MathMLMmultiscriptsDirChoice) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMmultiscriptsDirChoice])
 	return e
 }
 
@@ -277,20 +307,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMmultiscriptsElement) DirRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMmultiscriptsElement) Displaystyle(c MathMLMmultiscriptsDisplaystyleChoice) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMmultiscriptsDisplaystyleChoice])
 	return e
 }
 
@@ -307,20 +333,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMmultiscriptsElement) DisplaystyleRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMmultiscriptsElement) ID(s string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -334,7 +356,7 @@ func (e *MathMLMmultiscriptsElement) IDf(format string, args ...any) *MathMLMmul
 // document.
 func (e *MathMLMmultiscriptsElement) IfID(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -343,7 +365,7 @@ func (e *MathMLMmultiscriptsElement) IfID(condition bool, s string) *MathMLMmult
 // document.
 func (e *MathMLMmultiscriptsElement) IfIDf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -352,10 +374,8 @@ func (e *MathMLMmultiscriptsElement) IfIDf(condition bool, format string, args .
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMmultiscriptsElement) IDRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -363,10 +383,8 @@ func (e *MathMLMmultiscriptsElement) IDRemove() *MathMLMmultiscriptsElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMmultiscriptsElement) Mathbackground(s string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -382,7 +400,7 @@ func (e *MathMLMmultiscriptsElement) Mathbackgroundf(format string, args ...any)
 // Color Module [CSS3COLOR].
 func (e *MathMLMmultiscriptsElement) IfMathbackground(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -392,7 +410,7 @@ func (e *MathMLMmultiscriptsElement) IfMathbackground(condition bool, s string)
 // Color Module [CSS3COLOR].
 func (e *MathMLMmultiscriptsElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -402,10 +420,8 @@ func (e *MathMLMmultiscriptsElement) IfMathbackgroundf(condition bool, format st
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMmultiscriptsElement) MathbackgroundRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -413,10 +429,8 @@ func (e *MathMLMmultiscriptsElement) MathbackgroundRemove() *MathMLMmultiscripts
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMmultiscriptsElement) Mathcolor(s string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -432,7 +446,7 @@ func (e *MathMLMmultiscriptsElement) Mathcolorf(format string, args ...any) *Mat
 // Module [CSS3COLOR].
 func (e *MathMLMmultiscriptsElement) IfMathcolor(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -442,7 +456,7 @@ func (e *MathMLMmultiscriptsElement) IfMathcolor(condition bool, s string) *Math
 // Module [CSS3COLOR].
 func (e *MathMLMmultiscriptsElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -452,20 +466,16 @@ func (e *MathMLMmultiscriptsElement) IfMathcolorf(condition bool, format string,
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMmultiscriptsElement) MathcolorRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMmultiscriptsElement) MathsizeStr(s string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -479,7 +489,7 @@ func (e *MathMLMmultiscriptsElement) MathsizeStrf(format string, args ...any) *M
 // dimension or a dimensionless number.
 func (e *MathMLMmultiscriptsElement) IfMathsizeStr(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -488,7 +498,7 @@ func (e *MathMLMmultiscriptsElement) IfMathsizeStr(condition bool, s string) *Ma
 // dimension or a dimensionless number.
 func (e *MathMLMmultiscriptsElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -497,10 +507,8 @@ func (e *MathMLMmultiscriptsElement) IfMathsizeStrf(condition bool, format strin
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMmultiscriptsElement) MathsizeStrRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -509,10 +517,8 @@ func (e *MathMLMmultiscriptsElement) MathsizeStrRemove() *MathMLMmultiscriptsEle
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMmultiscriptsElement) Nonce(s string) *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -530,7 +536,7 @@ func (e *MathMLMmultiscriptsElement) Noncef(format string, args ...any) *MathMLM
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMmultiscriptsElement) IfNonce(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -541,7 +547,7 @@ func (e *MathMLMmultiscriptsElement) IfNonce(condition bool, s string) *MathMLMm
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMmultiscriptsElement) IfNoncef(condition bool, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -552,20 +558,16 @@ func (e *MathMLMmultiscriptsElement) IfNoncef(condition bool, format string, arg
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMmultiscriptsElement) NonceRemove() *MathMLMmultiscriptsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMmultiscriptsElement) Scriptlevel(i int) *MathMLMmultiscriptsElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -573,7 +575,7 @@ func (e *MathMLMmultiscriptsElement) Scriptlevel(i int) *MathMLMmultiscriptsElem
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMmultiscriptsElement) IfScriptlevel(condition bool, i int) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -582,10 +584,8 @@ func (e *MathMLMmultiscriptsElement) IfScriptlevel(condition bool, i int) *MathM
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMmultiscriptsElement) ScriptlevelRemove() *MathMLMmultiscriptsElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -595,14 +595,8 @@ func (e *MathMLMmultiscriptsElement) StylePairs(pairs ...string) *MathMLMmultisc
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -617,14 +611,8 @@ func (e *MathMLMmultiscriptsElement) StylePairs(pairs ...string) *MathMLMmultisc
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMmultiscriptsElement) Style(s string) *MathMLMmultiscriptsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -632,7 +620,7 @@ func (e *MathMLMmultiscriptsElement) Style(s string) *MathMLMmultiscriptsElement
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -641,7 +629,7 @@ func (e *MathMLMmultiscriptsElement) Style(s string) *MathMLMmultiscriptsElement
 // set.
 func (e *MathMLMmultiscriptsElement) IfStyle(condition bool, s string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -649,15 +637,7 @@ func (e *MathMLMmultiscriptsElement) IfStyle(condition bool, s string) *MathMLMm
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMmultiscriptsElement) StyleAdd(k string, v string) *MathMLMmultiscriptsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -671,7 +651,7 @@ func (e *MathMLMmultiscriptsElement) StyleAddf(k string, format string, args ...
 // set.
 func (e *MathMLMmultiscriptsElement) IfStyleAdd(condition bool, k string, v string) *MathMLMmultiscriptsElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -680,7 +660,7 @@ func (e *MathMLMmultiscriptsElement) IfStyleAdd(condition bool, k string, v stri
 // set.
 func (e *MathMLMmultiscriptsElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMmultiscriptsElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -689,21 +669,15 @@ func (e *MathMLMmultiscriptsElement) IfStyleAddf(condition bool, k string, forma
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMmultiscriptsElement) StyleMap(m map[string]string) *MathMLMmultiscriptsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -712,14 +686,8 @@ func (e *MathMLMmultiscriptsElement) StyleMap(m map[string]string) *MathMLMmulti
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMmultiscriptsElement) StyleRemove(keys ...string) *MathMLMmultiscriptsElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -727,10 +695,8 @@ func (e *MathMLMmultiscriptsElement) StyleRemove(keys ...string) *MathMLMmultisc
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMmultiscriptsElement) Tabindex(i int) *MathMLMmultiscriptsElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -739,7 +705,7 @@ func (e *MathMLMmultiscriptsElement) Tabindex(i int) *MathMLMmultiscriptsElement
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMmultiscriptsElement) IfTabindex(condition bool, i int) *MathMLMmultiscriptsElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -749,9 +715,7 @@ func (e *MathMLMmultiscriptsElement) IfTabindex(condition bool, i int) *MathMLMm
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMmultiscriptsElement) TabindexRemove() *MathMLMmultiscriptsElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
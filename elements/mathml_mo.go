@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display a single operator.
@@ -21,18 +19,63 @@ type MathMLMoElement struct {
 // with the tag "mo" during rendering.
 func MathMLMo(children ...ElementRenderer) *MathMLMoElement {
 	e := NewElement("mo", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMoElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMoElement) Clone() *MathMLMoElement {
+	return &MathMLMoElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMoElement) CloneShallow() *MathMLMoElement {
+	return &MathMLMoElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMoElement) Freeze() *MathMLMoElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMoElement) writable() *MathMLMoElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMoElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMoElement) choiceType(name string) func(string) any {
+	switch name {
+	case "fence":
+		return choiceValue[MathMLMoFenceChoice]
+	case "dir":
+		return choiceValue[MathMLMoDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMoDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMoElement) Children(children ...ElementRenderer) *MathMLMoElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMoElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +83,30 @@ func (e *MathMLMoElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *MathMLMoElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) BoolAttr(name string) *MathMLMoElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMoElement) BoolAttrRemove(name string) *MathMLMoElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMoElement) IfBoolAttr(condition bool, name string) *MathMLMoElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +117,40 @@ func (e *MathMLMoElement) BoolAttrf(format string, args ...any) *MathMLMoElement
 
 func (e *MathMLMoElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) BoolAttrs(names ...string) *MathMLMoElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) IfBoolAttrs(condition bool, names ...string) *MathMLMoElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) Attr(name, value string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMoElement) RawAttr(name, value string) *MathMLMoElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMoElement) IfAttr(condition bool, name, value string) *MathMLMoElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +161,52 @@ func (e *MathMLMoElement) Attrf(name, format string, args ...any) *MathMLMoEleme
 
 func (e *MathMLMoElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) Attrs(attrs ...string) *MathMLMoElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMoElement) IfAttrs(condition bool, attrs ...string) *MathMLMoElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) AttrsMap(attrs map[string]string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMoElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMoElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMoElement) Text(text string) *MathMLMoElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMoElement) Textf(format string, args ...any) *MathMLMoElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMoElement) IfText(condition bool, text string) *MathMLMoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +214,28 @@ func (e *MathMLMoElement) IfText(condition bool, text string) *MathMLMoElement {
 
 func (e *MathMLMoElement) IfTextf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMoElement) Raw(text string) *MathMLMoElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMoElement) Escaped(text string) *MathMLMoElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMoElement) IfEscaped(condition bool, text string) *MathMLMoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +247,7 @@ func (e *MathMLMoElement) Escapedf(format string, args ...any) *MathMLMoElement
 
 func (e *MathMLMoElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +256,8 @@ func (e *MathMLMoElement) IfEscapedf(condition bool, format string, args ...any)
 // This Attribute specifies whether the operator is to be rendered as a fence.
 // Possible values are true and false.
 func (e *MathMLMoElement) Fence(c MathMLMoFenceChoice) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fence", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("fence", string(c), choiceValue[MathMLMoFenceChoice])
 	return e
 }
 
@@ -229,10 +274,8 @@ const (
 // Possible values are true and false.
 // Remove the attribute Fence from the element.
 func (e *MathMLMoElement) FenceRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fence")
+	e = e.writable()
+	e.removeAttribute("fence")
 	return e
 }
 
@@ -240,10 +283,8 @@ func (e *MathMLMoElement) FenceRemove() *MathMLMoElement {
 // the left side of the operator. Possible values are a dimension or a
 // dimensionless number.
 func (e *MathMLMoElement) Lspace(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lspace", s)
+	e = e.writable()
+	e.setStringAttribute("lspace", s)
 	return e
 }
 
@@ -259,7 +300,7 @@ func (e *MathMLMoElement) Lspacef(format string, args ...any) *MathMLMoElement {
 // dimensionless number.
 func (e *MathMLMoElement) IfLspace(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Lspace(s)
+		e = e.Lspace(s)
 	}
 	return e
 }
@@ -269,7 +310,7 @@ func (e *MathMLMoElement) IfLspace(condition bool, s string) *MathMLMoElement {
 // dimensionless number.
 func (e *MathMLMoElement) IfLspacef(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.Lspace(fmt.Sprintf(format, args...))
+		e = e.Lspace(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -279,10 +320,8 @@ func (e *MathMLMoElement) IfLspacef(condition bool, format string, args ...any)
 // dimensionless number.
 // Remove the attribute Lspace from the element.
 func (e *MathMLMoElement) LspaceRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lspace")
+	e = e.writable()
+	e.removeAttribute("lspace")
 	return e
 }
 
@@ -290,10 +329,8 @@ func (e *MathMLMoElement) LspaceRemove() *MathMLMoElement {
 // the right side of the operator. Possible values are a dimension or a
 // dimensionless number.
 func (e *MathMLMoElement) Rspace(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("rspace", s)
+	e = e.writable()
+	e.setStringAttribute("rspace", s)
 	return e
 }
 
@@ -309,7 +346,7 @@ func (e *MathMLMoElement) Rspacef(format string, args ...any) *MathMLMoElement {
 // dimensionless number.
 func (e *MathMLMoElement) IfRspace(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Rspace(s)
+		e = e.Rspace(s)
 	}
 	return e
 }
@@ -319,7 +356,7 @@ func (e *MathMLMoElement) IfRspace(condition bool, s string) *MathMLMoElement {
 // dimensionless number.
 func (e *MathMLMoElement) IfRspacef(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.Rspace(fmt.Sprintf(format, args...))
+		e = e.Rspace(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -329,10 +366,8 @@ func (e *MathMLMoElement) IfRspacef(condition bool, format string, args ...any)
 // dimensionless number.
 // Remove the attribute Rspace from the element.
 func (e *MathMLMoElement) RspaceRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("rspace")
+	e = e.writable()
+	e.removeAttribute("rspace")
 	return e
 }
 
@@ -340,16 +375,9 @@ func (e *MathMLMoElement) RspaceRemove() *MathMLMoElement {
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMoElement) Class(s string) *MathMLMoElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -358,7 +386,7 @@ func (e *MathMLMoElement) Class(s string) *MathMLMoElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMoElement) IfClass(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -368,14 +396,8 @@ func (e *MathMLMoElement) IfClass(condition bool, s string) *MathMLMoElement {
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMoElement) ClassRemove(s ...string) *MathMLMoElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -384,10 +406,8 @@ func (e *MathMLMoElement) ClassRemove(s ...string) *MathMLMoElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMoElement) Dir(c MathMLMoDirChoice) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMoDirChoice])
 	return e
 }
 
@@ -406,20 +426,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMoElement) DirRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMoElement) Displaystyle(c MathMLMoDisplaystyleChoice) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMoDisplaystyleChoice])
 	return e
 }
 
@@ -436,20 +452,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMoElement) DisplaystyleRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMoElement) ID(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -463,7 +475,7 @@ func (e *MathMLMoElement) IDf(format string, args ...any) *MathMLMoElement {
 // document.
 func (e *MathMLMoElement) IfID(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -472,7 +484,7 @@ func (e *MathMLMoElement) IfID(condition bool, s string) *MathMLMoElement {
 // document.
 func (e *MathMLMoElement) IfIDf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -481,10 +493,8 @@ func (e *MathMLMoElement) IfIDf(condition bool, format string, args ...any) *Mat
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMoElement) IDRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -492,10 +502,8 @@ func (e *MathMLMoElement) IDRemove() *MathMLMoElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMoElement) Mathbackground(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -511,7 +519,7 @@ func (e *MathMLMoElement) Mathbackgroundf(format string, args ...any) *MathMLMoE
 // Color Module [CSS3COLOR].
 func (e *MathMLMoElement) IfMathbackground(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -521,7 +529,7 @@ func (e *MathMLMoElement) IfMathbackground(condition bool, s string) *MathMLMoEl
 // Color Module [CSS3COLOR].
 func (e *MathMLMoElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -531,10 +539,8 @@ func (e *MathMLMoElement) IfMathbackgroundf(condition bool, format string, args
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMoElement) MathbackgroundRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -542,10 +548,8 @@ func (e *MathMLMoElement) MathbackgroundRemove() *MathMLMoElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMoElement) Mathcolor(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -561,7 +565,7 @@ func (e *MathMLMoElement) Mathcolorf(format string, args ...any) *MathMLMoElemen
 // Module [CSS3COLOR].
 func (e *MathMLMoElement) IfMathcolor(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -571,7 +575,7 @@ func (e *MathMLMoElement) IfMathcolor(condition bool, s string) *MathMLMoElement
 // Module [CSS3COLOR].
 func (e *MathMLMoElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -581,20 +585,16 @@ func (e *MathMLMoElement) IfMathcolorf(condition bool, format string, args ...an
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMoElement) MathcolorRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMoElement) MathsizeStr(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -608,7 +608,7 @@ func (e *MathMLMoElement) MathsizeStrf(format string, args ...any) *MathMLMoElem
 // dimension or a dimensionless number.
 func (e *MathMLMoElement) IfMathsizeStr(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -617,7 +617,7 @@ func (e *MathMLMoElement) IfMathsizeStr(condition bool, s string) *MathMLMoEleme
 // dimension or a dimensionless number.
 func (e *MathMLMoElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -626,10 +626,8 @@ func (e *MathMLMoElement) IfMathsizeStrf(condition bool, format string, args ...
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMoElement) MathsizeStrRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -638,10 +636,8 @@ func (e *MathMLMoElement) MathsizeStrRemove() *MathMLMoElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMoElement) Nonce(s string) *MathMLMoElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -659,7 +655,7 @@ func (e *MathMLMoElement) Noncef(format string, args ...any) *MathMLMoElement {
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMoElement) IfNonce(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -670,7 +666,7 @@ func (e *MathMLMoElement) IfNonce(condition bool, s string) *MathMLMoElement {
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMoElement) IfNoncef(condition bool, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -681,20 +677,16 @@ func (e *MathMLMoElement) IfNoncef(condition bool, format string, args ...any) *
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMoElement) NonceRemove() *MathMLMoElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMoElement) Scriptlevel(i int) *MathMLMoElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -702,7 +694,7 @@ func (e *MathMLMoElement) Scriptlevel(i int) *MathMLMoElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMoElement) IfScriptlevel(condition bool, i int) *MathMLMoElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -711,10 +703,8 @@ func (e *MathMLMoElement) IfScriptlevel(condition bool, i int) *MathMLMoElement
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMoElement) ScriptlevelRemove() *MathMLMoElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -724,14 +714,8 @@ func (e *MathMLMoElement) StylePairs(pairs ...string) *MathMLMoElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -746,14 +730,8 @@ func (e *MathMLMoElement) StylePairs(pairs ...string) *MathMLMoElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMoElement) Style(s string) *MathMLMoElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -761,7 +739,7 @@ func (e *MathMLMoElement) Style(s string) *MathMLMoElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -770,7 +748,7 @@ func (e *MathMLMoElement) Style(s string) *MathMLMoElement {
 // set.
 func (e *MathMLMoElement) IfStyle(condition bool, s string) *MathMLMoElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -778,15 +756,7 @@ func (e *MathMLMoElement) IfStyle(condition bool, s string) *MathMLMoElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMoElement) StyleAdd(k string, v string) *MathMLMoElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -800,7 +770,7 @@ func (e *MathMLMoElement) StyleAddf(k string, format string, args ...any) *MathM
 // set.
 func (e *MathMLMoElement) IfStyleAdd(condition bool, k string, v string) *MathMLMoElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -809,7 +779,7 @@ func (e *MathMLMoElement) IfStyleAdd(condition bool, k string, v string) *MathML
 // set.
 func (e *MathMLMoElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMoElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -818,21 +788,15 @@ func (e *MathMLMoElement) IfStyleAddf(condition bool, k string, format string, a
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMoElement) StyleMap(m map[string]string) *MathMLMoElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -841,14 +805,8 @@ func (e *MathMLMoElement) StyleMap(m map[string]string) *MathMLMoElement {
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMoElement) StyleRemove(keys ...string) *MathMLMoElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -856,10 +814,8 @@ func (e *MathMLMoElement) StyleRemove(keys ...string) *MathMLMoElement {
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMoElement) Tabindex(i int) *MathMLMoElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -868,7 +824,7 @@ func (e *MathMLMoElement) Tabindex(i int) *MathMLMoElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMoElement) IfTabindex(condition bool, i int) *MathMLMoElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -878,9 +834,7 @@ func (e *MathMLMoElement) IfTabindex(condition bool, i int) *MathMLMoElement {
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMoElement) TabindexRemove() *MathMLMoElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
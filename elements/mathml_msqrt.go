@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display an expression with a radical.
@@ -21,18 +19,61 @@ type MathMLMsqrtElement struct {
 // with the tag "msqrt" during rendering.
 func MathMLMsqrt(children ...ElementRenderer) *MathMLMsqrtElement {
 	e := NewElement("msqrt", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMsqrtElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMsqrtElement) Clone() *MathMLMsqrtElement {
+	return &MathMLMsqrtElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMsqrtElement) CloneShallow() *MathMLMsqrtElement {
+	return &MathMLMsqrtElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMsqrtElement) Freeze() *MathMLMsqrtElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMsqrtElement) writable() *MathMLMsqrtElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMsqrtElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMsqrtElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLMsqrtDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMsqrtDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMsqrtElement) Children(children ...ElementRenderer) *MathMLMsqrtElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMsqrtElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +81,30 @@ func (e *MathMLMsqrtElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *MathMLMsqrtElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMsqrtElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) BoolAttr(name string) *MathMLMsqrtElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMsqrtElement) BoolAttrRemove(name string) *MathMLMsqrtElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfBoolAttr(condition bool, name string) *MathMLMsqrtElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +115,40 @@ func (e *MathMLMsqrtElement) BoolAttrf(format string, args ...any) *MathMLMsqrtE
 
 func (e *MathMLMsqrtElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) BoolAttrs(names ...string) *MathMLMsqrtElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfBoolAttrs(condition bool, names ...string) *MathMLMsqrtElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) Attr(name, value string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMsqrtElement) RawAttr(name, value string) *MathMLMsqrtElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfAttr(condition bool, name, value string) *MathMLMsqrtElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +159,52 @@ func (e *MathMLMsqrtElement) Attrf(name, format string, args ...any) *MathMLMsqr
 
 func (e *MathMLMsqrtElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) Attrs(attrs ...string) *MathMLMsqrtElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfAttrs(condition bool, attrs ...string) *MathMLMsqrtElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) AttrsMap(attrs map[string]string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMsqrtElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMsqrtElement) Text(text string) *MathMLMsqrtElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMsqrtElement) Textf(format string, args ...any) *MathMLMsqrtElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMsqrtElement) IfText(condition bool, text string) *MathMLMsqrtElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +212,28 @@ func (e *MathMLMsqrtElement) IfText(condition bool, text string) *MathMLMsqrtEle
 
 func (e *MathMLMsqrtElement) IfTextf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMsqrtElement) Raw(text string) *MathMLMsqrtElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMsqrtElement) Escaped(text string) *MathMLMsqrtElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMsqrtElement) IfEscaped(condition bool, text string) *MathMLMsqrtElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +245,7 @@ func (e *MathMLMsqrtElement) Escapedf(format string, args ...any) *MathMLMsqrtEl
 
 func (e *MathMLMsqrtElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,16 +255,9 @@ func (e *MathMLMsqrtElement) IfEscapedf(condition bool, format string, args ...a
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMsqrtElement) Class(s string) *MathMLMsqrtElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -228,7 +266,7 @@ func (e *MathMLMsqrtElement) Class(s string) *MathMLMsqrtElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMsqrtElement) IfClass(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -238,14 +276,8 @@ func (e *MathMLMsqrtElement) IfClass(condition bool, s string) *MathMLMsqrtEleme
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMsqrtElement) ClassRemove(s ...string) *MathMLMsqrtElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -254,10 +286,8 @@ func (e *MathMLMsqrtElement) ClassRemove(s ...string) *MathMLMsqrtElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMsqrtElement) Dir(c MathMLMsqrtDirChoice) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMsqrtDirChoice])
 	return e
 }
 
@@ -276,20 +306,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMsqrtElement) DirRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMsqrtElement) Displaystyle(c MathMLMsqrtDisplaystyleChoice) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMsqrtDisplaystyleChoice])
 	return e
 }
 
@@ -306,20 +332,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMsqrtElement) DisplaystyleRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMsqrtElement) ID(s string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -333,7 +355,7 @@ func (e *MathMLMsqrtElement) IDf(format string, args ...any) *MathMLMsqrtElement
 // document.
 func (e *MathMLMsqrtElement) IfID(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -342,7 +364,7 @@ func (e *MathMLMsqrtElement) IfID(condition bool, s string) *MathMLMsqrtElement
 // document.
 func (e *MathMLMsqrtElement) IfIDf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -351,10 +373,8 @@ func (e *MathMLMsqrtElement) IfIDf(condition bool, format string, args ...any) *
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMsqrtElement) IDRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -362,10 +382,8 @@ func (e *MathMLMsqrtElement) IDRemove() *MathMLMsqrtElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMsqrtElement) Mathbackground(s string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -381,7 +399,7 @@ func (e *MathMLMsqrtElement) Mathbackgroundf(format string, args ...any) *MathML
 // Color Module [CSS3COLOR].
 func (e *MathMLMsqrtElement) IfMathbackground(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -391,7 +409,7 @@ func (e *MathMLMsqrtElement) IfMathbackground(condition bool, s string) *MathMLM
 // Color Module [CSS3COLOR].
 func (e *MathMLMsqrtElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -401,10 +419,8 @@ func (e *MathMLMsqrtElement) IfMathbackgroundf(condition bool, format string, ar
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMsqrtElement) MathbackgroundRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -412,10 +428,8 @@ func (e *MathMLMsqrtElement) MathbackgroundRemove() *MathMLMsqrtElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMsqrtElement) Mathcolor(s string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -431,7 +445,7 @@ func (e *MathMLMsqrtElement) Mathcolorf(format string, args ...any) *MathMLMsqrt
 // Module [CSS3COLOR].
 func (e *MathMLMsqrtElement) IfMathcolor(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -441,7 +455,7 @@ func (e *MathMLMsqrtElement) IfMathcolor(condition bool, s string) *MathMLMsqrtE
 // Module [CSS3COLOR].
 func (e *MathMLMsqrtElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -451,20 +465,16 @@ func (e *MathMLMsqrtElement) IfMathcolorf(condition bool, format string, args ..
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMsqrtElement) MathcolorRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMsqrtElement) MathsizeStr(s string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -478,7 +488,7 @@ func (e *MathMLMsqrtElement) MathsizeStrf(format string, args ...any) *MathMLMsq
 // dimension or a dimensionless number.
 func (e *MathMLMsqrtElement) IfMathsizeStr(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -487,7 +497,7 @@ func (e *MathMLMsqrtElement) IfMathsizeStr(condition bool, s string) *MathMLMsqr
 // dimension or a dimensionless number.
 func (e *MathMLMsqrtElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -496,10 +506,8 @@ func (e *MathMLMsqrtElement) IfMathsizeStrf(condition bool, format string, args
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMsqrtElement) MathsizeStrRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -508,10 +516,8 @@ func (e *MathMLMsqrtElement) MathsizeStrRemove() *MathMLMsqrtElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMsqrtElement) Nonce(s string) *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -529,7 +535,7 @@ func (e *MathMLMsqrtElement) Noncef(format string, args ...any) *MathMLMsqrtElem
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMsqrtElement) IfNonce(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -540,7 +546,7 @@ func (e *MathMLMsqrtElement) IfNonce(condition bool, s string) *MathMLMsqrtEleme
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMsqrtElement) IfNoncef(condition bool, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -551,20 +557,16 @@ func (e *MathMLMsqrtElement) IfNoncef(condition bool, format string, args ...any
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMsqrtElement) NonceRemove() *MathMLMsqrtElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMsqrtElement) Scriptlevel(i int) *MathMLMsqrtElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -572,7 +574,7 @@ func (e *MathMLMsqrtElement) Scriptlevel(i int) *MathMLMsqrtElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMsqrtElement) IfScriptlevel(condition bool, i int) *MathMLMsqrtElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -581,10 +583,8 @@ func (e *MathMLMsqrtElement) IfScriptlevel(condition bool, i int) *MathMLMsqrtEl
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMsqrtElement) ScriptlevelRemove() *MathMLMsqrtElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -594,14 +594,8 @@ func (e *MathMLMsqrtElement) StylePairs(pairs ...string) *MathMLMsqrtElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -616,14 +610,8 @@ func (e *MathMLMsqrtElement) StylePairs(pairs ...string) *MathMLMsqrtElement {
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMsqrtElement) Style(s string) *MathMLMsqrtElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -631,7 +619,7 @@ func (e *MathMLMsqrtElement) Style(s string) *MathMLMsqrtElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -640,7 +628,7 @@ func (e *MathMLMsqrtElement) Style(s string) *MathMLMsqrtElement {
 // set.
 func (e *MathMLMsqrtElement) IfStyle(condition bool, s string) *MathMLMsqrtElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -648,15 +636,7 @@ func (e *MathMLMsqrtElement) IfStyle(condition bool, s string) *MathMLMsqrtEleme
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMsqrtElement) StyleAdd(k string, v string) *MathMLMsqrtElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -670,7 +650,7 @@ func (e *MathMLMsqrtElement) StyleAddf(k string, format string, args ...any) *Ma
 // set.
 func (e *MathMLMsqrtElement) IfStyleAdd(condition bool, k string, v string) *MathMLMsqrtElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -679,7 +659,7 @@ func (e *MathMLMsqrtElement) IfStyleAdd(condition bool, k string, v string) *Mat
 // set.
 func (e *MathMLMsqrtElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMsqrtElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -688,21 +668,15 @@ func (e *MathMLMsqrtElement) IfStyleAddf(condition bool, k string, format string
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMsqrtElement) StyleMap(m map[string]string) *MathMLMsqrtElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -711,14 +685,8 @@ func (e *MathMLMsqrtElement) StyleMap(m map[string]string) *MathMLMsqrtElement {
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMsqrtElement) StyleRemove(keys ...string) *MathMLMsqrtElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -726,10 +694,8 @@ func (e *MathMLMsqrtElement) StyleRemove(keys ...string) *MathMLMsqrtElement {
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMsqrtElement) Tabindex(i int) *MathMLMsqrtElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -738,7 +704,7 @@ func (e *MathMLMsqrtElement) Tabindex(i int) *MathMLMsqrtElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMsqrtElement) IfTabindex(condition bool, i int) *MathMLMsqrtElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -748,9 +714,7 @@ func (e *MathMLMsqrtElement) IfTabindex(condition bool, i int) *MathMLMsqrtEleme
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMsqrtElement) TabindexRemove() *MathMLMsqrtElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
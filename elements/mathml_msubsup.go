@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display a subscript expression with a superscript
@@ -22,18 +20,61 @@ type MathMLMsubsupElement struct {
 // with the tag "msubsup" during rendering.
 func MathMLMsubsup(children ...ElementRenderer) *MathMLMsubsupElement {
 	e := NewElement("msubsup", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMsubsupElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMsubsupElement) Clone() *MathMLMsubsupElement {
+	return &MathMLMsubsupElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMsubsupElement) CloneShallow() *MathMLMsubsupElement {
+	return &MathMLMsubsupElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMsubsupElement) Freeze() *MathMLMsubsupElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMsubsupElement) writable() *MathMLMsubsupElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMsubsupElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMsubsupElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLMsubsupDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMsubsupDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMsubsupElement) Children(children ...ElementRenderer) *MathMLMsubsupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMsubsupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *MathMLMsubsupElement) IfChildren(condition bool, children ...ElementRen
 
 func (e *MathMLMsubsupElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMsubsupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) BoolAttr(name string) *MathMLMsubsupElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMsubsupElement) BoolAttrRemove(name string) *MathMLMsubsupElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfBoolAttr(condition bool, name string) *MathMLMsubsupElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *MathMLMsubsupElement) BoolAttrf(format string, args ...any) *MathMLMsub
 
 func (e *MathMLMsubsupElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) BoolAttrs(names ...string) *MathMLMsubsupElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfBoolAttrs(condition bool, names ...string) *MathMLMsubsupElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) Attr(name, value string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMsubsupElement) RawAttr(name, value string) *MathMLMsubsupElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfAttr(condition bool, name, value string) *MathMLMsubsupElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *MathMLMsubsupElement) Attrf(name, format string, args ...any) *MathMLMs
 
 func (e *MathMLMsubsupElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) Attrs(attrs ...string) *MathMLMsubsupElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfAttrs(condition bool, attrs ...string) *MathMLMsubsupElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) AttrsMap(attrs map[string]string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMsubsupElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMsubsupElement) Text(text string) *MathMLMsubsupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMsubsupElement) Textf(format string, args ...any) *MathMLMsubsupElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMsubsupElement) IfText(condition bool, text string) *MathMLMsubsupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *MathMLMsubsupElement) IfText(condition bool, text string) *MathMLMsubsu
 
 func (e *MathMLMsubsupElement) IfTextf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMsubsupElement) Raw(text string) *MathMLMsubsupElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMsubsupElement) Escaped(text string) *MathMLMsubsupElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMsubsupElement) IfEscaped(condition bool, text string) *MathMLMsubsupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *MathMLMsubsupElement) Escapedf(format string, args ...any) *MathMLMsubs
 
 func (e *MathMLMsubsupElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,16 +256,9 @@ func (e *MathMLMsubsupElement) IfEscapedf(condition bool, format string, args ..
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMsubsupElement) Class(s string) *MathMLMsubsupElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -229,7 +267,7 @@ func (e *MathMLMsubsupElement) Class(s string) *MathMLMsubsupElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMsubsupElement) IfClass(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -239,14 +277,8 @@ func (e *MathMLMsubsupElement) IfClass(condition bool, s string) *MathMLMsubsupE
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMsubsupElement) ClassRemove(s ...string) *MathMLMsubsupElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -255,10 +287,8 @@ func (e *MathMLMsubsupElement) ClassRemove(s ...string) *MathMLMsubsupElement {
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMsubsupElement) Dir(c MathMLMsubsupDirChoice) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMsubsupDirChoice])
 	return e
 }
 
@@ -277,20 +307,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMsubsupElement) DirRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMsubsupElement) Displaystyle(c MathMLMsubsupDisplaystyleChoice) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMsubsupDisplaystyleChoice])
 	return e
 }
 
@@ -307,20 +333,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMsubsupElement) DisplaystyleRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMsubsupElement) ID(s string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -334,7 +356,7 @@ func (e *MathMLMsubsupElement) IDf(format string, args ...any) *MathMLMsubsupEle
 // document.
 func (e *MathMLMsubsupElement) IfID(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -343,7 +365,7 @@ func (e *MathMLMsubsupElement) IfID(condition bool, s string) *MathMLMsubsupElem
 // document.
 func (e *MathMLMsubsupElement) IfIDf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -352,10 +374,8 @@ func (e *MathMLMsubsupElement) IfIDf(condition bool, format string, args ...any)
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMsubsupElement) IDRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -363,10 +383,8 @@ func (e *MathMLMsubsupElement) IDRemove() *MathMLMsubsupElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMsubsupElement) Mathbackground(s string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -382,7 +400,7 @@ func (e *MathMLMsubsupElement) Mathbackgroundf(format string, args ...any) *Math
 // Color Module [CSS3COLOR].
 func (e *MathMLMsubsupElement) IfMathbackground(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -392,7 +410,7 @@ func (e *MathMLMsubsupElement) IfMathbackground(condition bool, s string) *MathM
 // Color Module [CSS3COLOR].
 func (e *MathMLMsubsupElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -402,10 +420,8 @@ func (e *MathMLMsubsupElement) IfMathbackgroundf(condition bool, format string,
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMsubsupElement) MathbackgroundRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -413,10 +429,8 @@ func (e *MathMLMsubsupElement) MathbackgroundRemove() *MathMLMsubsupElement {
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMsubsupElement) Mathcolor(s string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -432,7 +446,7 @@ func (e *MathMLMsubsupElement) Mathcolorf(format string, args ...any) *MathMLMsu
 // Module [CSS3COLOR].
 func (e *MathMLMsubsupElement) IfMathcolor(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -442,7 +456,7 @@ func (e *MathMLMsubsupElement) IfMathcolor(condition bool, s string) *MathMLMsub
 // Module [CSS3COLOR].
 func (e *MathMLMsubsupElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -452,20 +466,16 @@ func (e *MathMLMsubsupElement) IfMathcolorf(condition bool, format string, args
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMsubsupElement) MathcolorRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMsubsupElement) MathsizeStr(s string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -479,7 +489,7 @@ func (e *MathMLMsubsupElement) MathsizeStrf(format string, args ...any) *MathMLM
 // dimension or a dimensionless number.
 func (e *MathMLMsubsupElement) IfMathsizeStr(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -488,7 +498,7 @@ func (e *MathMLMsubsupElement) IfMathsizeStr(condition bool, s string) *MathMLMs
 // dimension or a dimensionless number.
 func (e *MathMLMsubsupElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -497,10 +507,8 @@ func (e *MathMLMsubsupElement) IfMathsizeStrf(condition bool, format string, arg
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMsubsupElement) MathsizeStrRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -509,10 +517,8 @@ func (e *MathMLMsubsupElement) MathsizeStrRemove() *MathMLMsubsupElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMsubsupElement) Nonce(s string) *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -530,7 +536,7 @@ func (e *MathMLMsubsupElement) Noncef(format string, args ...any) *MathMLMsubsup
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMsubsupElement) IfNonce(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -541,7 +547,7 @@ func (e *MathMLMsubsupElement) IfNonce(condition bool, s string) *MathMLMsubsupE
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMsubsupElement) IfNoncef(condition bool, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -552,20 +558,16 @@ func (e *MathMLMsubsupElement) IfNoncef(condition bool, format string, args ...a
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMsubsupElement) NonceRemove() *MathMLMsubsupElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMsubsupElement) Scriptlevel(i int) *MathMLMsubsupElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -573,7 +575,7 @@ func (e *MathMLMsubsupElement) Scriptlevel(i int) *MathMLMsubsupElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMsubsupElement) IfScriptlevel(condition bool, i int) *MathMLMsubsupElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -582,10 +584,8 @@ func (e *MathMLMsubsupElement) IfScriptlevel(condition bool, i int) *MathMLMsubs
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMsubsupElement) ScriptlevelRemove() *MathMLMsubsupElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -595,14 +595,8 @@ func (e *MathMLMsubsupElement) StylePairs(pairs ...string) *MathMLMsubsupElement
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -617,14 +611,8 @@ func (e *MathMLMsubsupElement) StylePairs(pairs ...string) *MathMLMsubsupElement
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMsubsupElement) Style(s string) *MathMLMsubsupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -632,7 +620,7 @@ func (e *MathMLMsubsupElement) Style(s string) *MathMLMsubsupElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -641,7 +629,7 @@ func (e *MathMLMsubsupElement) Style(s string) *MathMLMsubsupElement {
 // set.
 func (e *MathMLMsubsupElement) IfStyle(condition bool, s string) *MathMLMsubsupElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -649,15 +637,7 @@ func (e *MathMLMsubsupElement) IfStyle(condition bool, s string) *MathMLMsubsupE
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMsubsupElement) StyleAdd(k string, v string) *MathMLMsubsupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -671,7 +651,7 @@ func (e *MathMLMsubsupElement) StyleAddf(k string, format string, args ...any) *
 // set.
 func (e *MathMLMsubsupElement) IfStyleAdd(condition bool, k string, v string) *MathMLMsubsupElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -680,7 +660,7 @@ func (e *MathMLMsubsupElement) IfStyleAdd(condition bool, k string, v string) *M
 // set.
 func (e *MathMLMsubsupElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMsubsupElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -689,21 +669,15 @@ func (e *MathMLMsubsupElement) IfStyleAddf(condition bool, k string, format stri
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMsubsupElement) StyleMap(m map[string]string) *MathMLMsubsupElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -712,14 +686,8 @@ func (e *MathMLMsubsupElement) StyleMap(m map[string]string) *MathMLMsubsupEleme
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMsubsupElement) StyleRemove(keys ...string) *MathMLMsubsupElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -727,10 +695,8 @@ func (e *MathMLMsubsupElement) StyleRemove(keys ...string) *MathMLMsubsupElement
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMsubsupElement) Tabindex(i int) *MathMLMsubsupElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -739,7 +705,7 @@ func (e *MathMLMsubsupElement) Tabindex(i int) *MathMLMsubsupElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMsubsupElement) IfTabindex(condition bool, i int) *MathMLMsubsupElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -749,9 +715,7 @@ func (e *MathMLMsubsupElement) IfTabindex(condition bool, i int) *MathMLMsubsupE
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMsubsupElement) TabindexRemove() *MathMLMsubsupElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
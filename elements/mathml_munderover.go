@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to display an expression with both an underbar and an
@@ -22,18 +20,61 @@ type MathMLMunderoverElement struct {
 // with the tag "munderover" during rendering.
 func MathMLMunderover(children ...ElementRenderer) *MathMLMunderoverElement {
 	e := NewElement("munderover", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLMunderoverElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLMunderoverElement) Clone() *MathMLMunderoverElement {
+	return &MathMLMunderoverElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLMunderoverElement) CloneShallow() *MathMLMunderoverElement {
+	return &MathMLMunderoverElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLMunderoverElement) Freeze() *MathMLMunderoverElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLMunderoverElement) writable() *MathMLMunderoverElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLMunderoverElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLMunderoverElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLMunderoverDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLMunderoverDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLMunderoverElement) Children(children ...ElementRenderer) *MathMLMunderoverElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLMunderoverElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *MathMLMunderoverElement) IfChildren(condition bool, children ...Element
 
 func (e *MathMLMunderoverElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLMunderoverElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) BoolAttr(name string) *MathMLMunderoverElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLMunderoverElement) BoolAttrRemove(name string) *MathMLMunderoverElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfBoolAttr(condition bool, name string) *MathMLMunderoverElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *MathMLMunderoverElement) BoolAttrf(format string, args ...any) *MathMLM
 
 func (e *MathMLMunderoverElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) BoolAttrs(names ...string) *MathMLMunderoverElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfBoolAttrs(condition bool, names ...string) *MathMLMunderoverElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) Attr(name, value string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLMunderoverElement) RawAttr(name, value string) *MathMLMunderoverElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfAttr(condition bool, name, value string) *MathMLMunderoverElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *MathMLMunderoverElement) Attrf(name, format string, args ...any) *MathM
 
 func (e *MathMLMunderoverElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) Attrs(attrs ...string) *MathMLMunderoverElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfAttrs(condition bool, attrs ...string) *MathMLMunderoverElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) AttrsMap(attrs map[string]string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLMunderoverElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLMunderoverElement) Text(text string) *MathMLMunderoverElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLMunderoverElement) Textf(format string, args ...any) *MathMLMunderoverElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLMunderoverElement) IfText(condition bool, text string) *MathMLMunderoverElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *MathMLMunderoverElement) IfText(condition bool, text string) *MathMLMun
 
 func (e *MathMLMunderoverElement) IfTextf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLMunderoverElement) Raw(text string) *MathMLMunderoverElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLMunderoverElement) Escaped(text string) *MathMLMunderoverElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLMunderoverElement) IfEscaped(condition bool, text string) *MathMLMunderoverElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *MathMLMunderoverElement) Escapedf(format string, args ...any) *MathMLMu
 
 func (e *MathMLMunderoverElement) IfEscapedf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,16 +256,9 @@ func (e *MathMLMunderoverElement) IfEscapedf(condition bool, format string, args
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMunderoverElement) Class(s string) *MathMLMunderoverElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -229,7 +267,7 @@ func (e *MathMLMunderoverElement) Class(s string) *MathMLMunderoverElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLMunderoverElement) IfClass(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -239,14 +277,8 @@ func (e *MathMLMunderoverElement) IfClass(condition bool, s string) *MathMLMunde
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLMunderoverElement) ClassRemove(s ...string) *MathMLMunderoverElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -255,10 +287,8 @@ func (e *MathMLMunderoverElement) ClassRemove(s ...string) *MathMLMunderoverElem
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLMunderoverElement) Dir(c MathMLMunderoverDirChoice) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLMunderoverDirChoice])
 	return e
 }
 
@@ -277,20 +307,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLMunderoverElement) DirRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLMunderoverElement) Displaystyle(c MathMLMunderoverDisplaystyleChoice) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLMunderoverDisplaystyleChoice])
 	return e
 }
 
@@ -307,20 +333,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLMunderoverElement) DisplaystyleRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLMunderoverElement) ID(s string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -334,7 +356,7 @@ func (e *MathMLMunderoverElement) IDf(format string, args ...any) *MathMLMundero
 // document.
 func (e *MathMLMunderoverElement) IfID(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -343,7 +365,7 @@ func (e *MathMLMunderoverElement) IfID(condition bool, s string) *MathMLMunderov
 // document.
 func (e *MathMLMunderoverElement) IfIDf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -352,10 +374,8 @@ func (e *MathMLMunderoverElement) IfIDf(condition bool, format string, args ...a
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLMunderoverElement) IDRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -363,10 +383,8 @@ func (e *MathMLMunderoverElement) IDRemove() *MathMLMunderoverElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLMunderoverElement) Mathbackground(s string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -382,7 +400,7 @@ func (e *MathMLMunderoverElement) Mathbackgroundf(format string, args ...any) *M
 // Color Module [CSS3COLOR].
 func (e *MathMLMunderoverElement) IfMathbackground(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -392,7 +410,7 @@ func (e *MathMLMunderoverElement) IfMathbackground(condition bool, s string) *Ma
 // Color Module [CSS3COLOR].
 func (e *MathMLMunderoverElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -402,10 +420,8 @@ func (e *MathMLMunderoverElement) IfMathbackgroundf(condition bool, format strin
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLMunderoverElement) MathbackgroundRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -413,10 +429,8 @@ func (e *MathMLMunderoverElement) MathbackgroundRemove() *MathMLMunderoverElemen
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLMunderoverElement) Mathcolor(s string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -432,7 +446,7 @@ func (e *MathMLMunderoverElement) Mathcolorf(format string, args ...any) *MathML
 // Module [CSS3COLOR].
 func (e *MathMLMunderoverElement) IfMathcolor(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -442,7 +456,7 @@ func (e *MathMLMunderoverElement) IfMathcolor(condition bool, s string) *MathMLM
 // Module [CSS3COLOR].
 func (e *MathMLMunderoverElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -452,20 +466,16 @@ func (e *MathMLMunderoverElement) IfMathcolorf(condition bool, format string, ar
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLMunderoverElement) MathcolorRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLMunderoverElement) MathsizeStr(s string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -479,7 +489,7 @@ func (e *MathMLMunderoverElement) MathsizeStrf(format string, args ...any) *Math
 // dimension or a dimensionless number.
 func (e *MathMLMunderoverElement) IfMathsizeStr(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -488,7 +498,7 @@ func (e *MathMLMunderoverElement) IfMathsizeStr(condition bool, s string) *MathM
 // dimension or a dimensionless number.
 func (e *MathMLMunderoverElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -497,10 +507,8 @@ func (e *MathMLMunderoverElement) IfMathsizeStrf(condition bool, format string,
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLMunderoverElement) MathsizeStrRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -509,10 +517,8 @@ func (e *MathMLMunderoverElement) MathsizeStrRemove() *MathMLMunderoverElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMunderoverElement) Nonce(s string) *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -530,7 +536,7 @@ func (e *MathMLMunderoverElement) Noncef(format string, args ...any) *MathMLMund
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMunderoverElement) IfNonce(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -541,7 +547,7 @@ func (e *MathMLMunderoverElement) IfNonce(condition bool, s string) *MathMLMunde
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLMunderoverElement) IfNoncef(condition bool, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -552,20 +558,16 @@ func (e *MathMLMunderoverElement) IfNoncef(condition bool, format string, args .
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLMunderoverElement) NonceRemove() *MathMLMunderoverElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMunderoverElement) Scriptlevel(i int) *MathMLMunderoverElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -573,7 +575,7 @@ func (e *MathMLMunderoverElement) Scriptlevel(i int) *MathMLMunderoverElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLMunderoverElement) IfScriptlevel(condition bool, i int) *MathMLMunderoverElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -582,10 +584,8 @@ func (e *MathMLMunderoverElement) IfScriptlevel(condition bool, i int) *MathMLMu
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLMunderoverElement) ScriptlevelRemove() *MathMLMunderoverElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -595,14 +595,8 @@ func (e *MathMLMunderoverElement) StylePairs(pairs ...string) *MathMLMunderoverE
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -617,14 +611,8 @@ func (e *MathMLMunderoverElement) StylePairs(pairs ...string) *MathMLMunderoverE
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMunderoverElement) Style(s string) *MathMLMunderoverElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -632,7 +620,7 @@ func (e *MathMLMunderoverElement) Style(s string) *MathMLMunderoverElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -641,7 +629,7 @@ func (e *MathMLMunderoverElement) Style(s string) *MathMLMunderoverElement {
 // set.
 func (e *MathMLMunderoverElement) IfStyle(condition bool, s string) *MathMLMunderoverElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -649,15 +637,7 @@ func (e *MathMLMunderoverElement) IfStyle(condition bool, s string) *MathMLMunde
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLMunderoverElement) StyleAdd(k string, v string) *MathMLMunderoverElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -671,7 +651,7 @@ func (e *MathMLMunderoverElement) StyleAddf(k string, format string, args ...any
 // set.
 func (e *MathMLMunderoverElement) IfStyleAdd(condition bool, k string, v string) *MathMLMunderoverElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -680,7 +660,7 @@ func (e *MathMLMunderoverElement) IfStyleAdd(condition bool, k string, v string)
 // set.
 func (e *MathMLMunderoverElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLMunderoverElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -689,21 +669,15 @@ func (e *MathMLMunderoverElement) IfStyleAddf(condition bool, k string, format s
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLMunderoverElement) StyleMap(m map[string]string) *MathMLMunderoverElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -712,14 +686,8 @@ func (e *MathMLMunderoverElement) StyleMap(m map[string]string) *MathMLMunderove
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLMunderoverElement) StyleRemove(keys ...string) *MathMLMunderoverElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -727,10 +695,8 @@ func (e *MathMLMunderoverElement) StyleRemove(keys ...string) *MathMLMunderoverE
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMunderoverElement) Tabindex(i int) *MathMLMunderoverElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -739,7 +705,7 @@ func (e *MathMLMunderoverElement) Tabindex(i int) *MathMLMunderoverElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLMunderoverElement) IfTabindex(condition bool, i int) *MathMLMunderoverElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -749,9 +715,7 @@ func (e *MathMLMunderoverElement) IfTabindex(condition bool, i int) *MathMLMunde
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLMunderoverElement) TabindexRemove() *MathMLMunderoverElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
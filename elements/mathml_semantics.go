@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // This element is used to specify the meaning of a MathML expression.
@@ -21,18 +19,61 @@ type MathMLSemanticsElement struct {
 // with the tag "semantics" during rendering.
 func MathMLSemantics(children ...ElementRenderer) *MathMLSemanticsElement {
 	e := NewElement("semantics", children...)
+	e.namespace = namespaceMathML
 	e.isSelfClosing = false
 	e.descendants = children
 	return &MathMLSemanticsElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *MathMLSemanticsElement) Clone() *MathMLSemanticsElement {
+	return &MathMLSemanticsElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *MathMLSemanticsElement) CloneShallow() *MathMLSemanticsElement {
+	return &MathMLSemanticsElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *MathMLSemanticsElement) Freeze() *MathMLSemanticsElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *MathMLSemanticsElement) writable() *MathMLSemanticsElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *MathMLSemanticsElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *MathMLSemanticsElement) choiceType(name string) func(string) any {
+	switch name {
+	case "dir":
+		return choiceValue[MathMLSemanticsDirChoice]
+	case "displaystyle":
+		return choiceValue[MathMLSemanticsDisplaystyleChoice]
+	}
+	return nil
+}
+
 func (e *MathMLSemanticsElement) Children(children ...ElementRenderer) *MathMLSemanticsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfChildren(condition bool, children ...ElementRenderer) *MathMLSemanticsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +81,30 @@ func (e *MathMLSemanticsElement) IfChildren(condition bool, children ...ElementR
 
 func (e *MathMLSemanticsElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *MathMLSemanticsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) BoolAttr(name string) *MathMLSemanticsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *MathMLSemanticsElement) BoolAttrRemove(name string) *MathMLSemanticsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfBoolAttr(condition bool, name string) *MathMLSemanticsElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +115,40 @@ func (e *MathMLSemanticsElement) BoolAttrf(format string, args ...any) *MathMLSe
 
 func (e *MathMLSemanticsElement) IfBoolAttrf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) BoolAttrs(names ...string) *MathMLSemanticsElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfBoolAttrs(condition bool, names ...string) *MathMLSemanticsElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) Attr(name, value string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *MathMLSemanticsElement) RawAttr(name, value string) *MathMLSemanticsElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfAttr(condition bool, name, value string) *MathMLSemanticsElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +159,52 @@ func (e *MathMLSemanticsElement) Attrf(name, format string, args ...any) *MathML
 
 func (e *MathMLSemanticsElement) IfAttrf(condition bool, name, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) Attrs(attrs ...string) *MathMLSemanticsElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfAttrs(condition bool, attrs ...string) *MathMLSemanticsElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) AttrsMap(attrs map[string]string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfAttrsMap(condition bool, attrs map[string]string) *MathMLSemanticsElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *MathMLSemanticsElement) Text(text string) *MathMLSemanticsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *MathMLSemanticsElement) Textf(format string, args ...any) *MathMLSemanticsElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *MathMLSemanticsElement) IfText(condition bool, text string) *MathMLSemanticsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +212,28 @@ func (e *MathMLSemanticsElement) IfText(condition bool, text string) *MathMLSema
 
 func (e *MathMLSemanticsElement) IfTextf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *MathMLSemanticsElement) Raw(text string) *MathMLSemanticsElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *MathMLSemanticsElement) Escaped(text string) *MathMLSemanticsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *MathMLSemanticsElement) IfEscaped(condition bool, text string) *MathMLSemanticsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +245,7 @@ func (e *MathMLSemanticsElement) Escapedf(format string, args ...any) *MathMLSem
 
 func (e *MathMLSemanticsElement) IfEscapedf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,16 +255,9 @@ func (e *MathMLSemanticsElement) IfEscapedf(condition bool, format string, args
 // same class name or names to any number of elements. If you specify multiple
 // class names, they must be separated by whitespace characters.
 func (e *MathMLSemanticsElement) Class(s string) *MathMLSemanticsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -228,7 +266,7 @@ func (e *MathMLSemanticsElement) Class(s string) *MathMLSemanticsElement {
 // class names, they must be separated by whitespace characters.
 func (e *MathMLSemanticsElement) IfClass(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -238,14 +276,8 @@ func (e *MathMLSemanticsElement) IfClass(condition bool, s string) *MathMLSemant
 // class names, they must be separated by whitespace characters.
 // Remove the values from the attribute Class in the element.
 func (e *MathMLSemanticsElement) ClassRemove(s ...string) *MathMLSemanticsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -254,10 +286,8 @@ func (e *MathMLSemanticsElement) ClassRemove(s ...string) *MathMLSemanticsElemen
 // inherent directionality (such as Arabic or Hebrew). Possible values are ltr
 // (left-to-right) and rtl (right-to-left).
 func (e *MathMLSemanticsElement) Dir(c MathMLSemanticsDirChoice) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dir", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("dir", string(c), choiceValue[MathMLSemanticsDirChoice])
 	return e
 }
 
@@ -276,20 +306,16 @@ const (
 // (left-to-right) and rtl (right-to-left).
 // Remove the attribute Dir from the element.
 func (e *MathMLSemanticsElement) DirRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dir")
+	e = e.writable()
+	e.removeAttribute("dir")
 	return e
 }
 
 // This Attribute specifies whether the element should be rendered using
 // displaystyle rules or not. Possible values are true and false.
 func (e *MathMLSemanticsElement) Displaystyle(c MathMLSemanticsDisplaystyleChoice) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("displaystyle", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("displaystyle", string(c), choiceValue[MathMLSemanticsDisplaystyleChoice])
 	return e
 }
 
@@ -306,20 +332,16 @@ const (
 // displaystyle rules or not. Possible values are true and false.
 // Remove the attribute Displaystyle from the element.
 func (e *MathMLSemanticsElement) DisplaystyleRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("displaystyle")
+	e = e.writable()
+	e.removeAttribute("displaystyle")
 	return e
 }
 
 // This Attribute assigns a name to an element. This name must be unique in a
 // document.
 func (e *MathMLSemanticsElement) ID(s string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -333,7 +355,7 @@ func (e *MathMLSemanticsElement) IDf(format string, args ...any) *MathMLSemantic
 // document.
 func (e *MathMLSemanticsElement) IfID(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -342,7 +364,7 @@ func (e *MathMLSemanticsElement) IfID(condition bool, s string) *MathMLSemantics
 // document.
 func (e *MathMLSemanticsElement) IfIDf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -351,10 +373,8 @@ func (e *MathMLSemanticsElement) IfIDf(condition bool, format string, args ...an
 // document.
 // Remove the attribute ID from the element.
 func (e *MathMLSemanticsElement) IDRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
@@ -362,10 +382,8 @@ func (e *MathMLSemanticsElement) IDRemove() *MathMLSemanticsElement {
 // are a color name or a color specification in the format defined in the CSS3
 // Color Module [CSS3COLOR].
 func (e *MathMLSemanticsElement) Mathbackground(s string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathbackground", s)
+	e = e.writable()
+	e.setStringAttribute("mathbackground", s)
 	return e
 }
 
@@ -381,7 +399,7 @@ func (e *MathMLSemanticsElement) Mathbackgroundf(format string, args ...any) *Ma
 // Color Module [CSS3COLOR].
 func (e *MathMLSemanticsElement) IfMathbackground(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.Mathbackground(s)
+		e = e.Mathbackground(s)
 	}
 	return e
 }
@@ -391,7 +409,7 @@ func (e *MathMLSemanticsElement) IfMathbackground(condition bool, s string) *Mat
 // Color Module [CSS3COLOR].
 func (e *MathMLSemanticsElement) IfMathbackgroundf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.Mathbackground(fmt.Sprintf(format, args...))
+		e = e.Mathbackground(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -401,10 +419,8 @@ func (e *MathMLSemanticsElement) IfMathbackgroundf(condition bool, format string
 // Color Module [CSS3COLOR].
 // Remove the attribute Mathbackground from the element.
 func (e *MathMLSemanticsElement) MathbackgroundRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathbackground")
+	e = e.writable()
+	e.removeAttribute("mathbackground")
 	return e
 }
 
@@ -412,10 +428,8 @@ func (e *MathMLSemanticsElement) MathbackgroundRemove() *MathMLSemanticsElement
 // color name or a color specification in the format defined in the CSS3 Color
 // Module [CSS3COLOR].
 func (e *MathMLSemanticsElement) Mathcolor(s string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathcolor", s)
+	e = e.writable()
+	e.setStringAttribute("mathcolor", s)
 	return e
 }
 
@@ -431,7 +445,7 @@ func (e *MathMLSemanticsElement) Mathcolorf(format string, args ...any) *MathMLS
 // Module [CSS3COLOR].
 func (e *MathMLSemanticsElement) IfMathcolor(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.Mathcolor(s)
+		e = e.Mathcolor(s)
 	}
 	return e
 }
@@ -441,7 +455,7 @@ func (e *MathMLSemanticsElement) IfMathcolor(condition bool, s string) *MathMLSe
 // Module [CSS3COLOR].
 func (e *MathMLSemanticsElement) IfMathcolorf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.Mathcolor(fmt.Sprintf(format, args...))
+		e = e.Mathcolor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -451,20 +465,16 @@ func (e *MathMLSemanticsElement) IfMathcolorf(condition bool, format string, arg
 // Module [CSS3COLOR].
 // Remove the attribute Mathcolor from the element.
 func (e *MathMLSemanticsElement) MathcolorRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathcolor")
+	e = e.writable()
+	e.removeAttribute("mathcolor")
 	return e
 }
 
 // This Attribute specifies the size of the element. Possible values are a
 // dimension or a dimensionless number.
 func (e *MathMLSemanticsElement) MathsizeStr(s string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mathsize", s)
+	e = e.writable()
+	e.setStringAttribute("mathsize", s)
 	return e
 }
 
@@ -478,7 +488,7 @@ func (e *MathMLSemanticsElement) MathsizeStrf(format string, args ...any) *MathM
 // dimension or a dimensionless number.
 func (e *MathMLSemanticsElement) IfMathsizeStr(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.MathsizeStr(s)
+		e = e.MathsizeStr(s)
 	}
 	return e
 }
@@ -487,7 +497,7 @@ func (e *MathMLSemanticsElement) IfMathsizeStr(condition bool, s string) *MathML
 // dimension or a dimensionless number.
 func (e *MathMLSemanticsElement) IfMathsizeStrf(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.MathsizeStr(fmt.Sprintf(format, args...))
+		e = e.MathsizeStr(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -496,10 +506,8 @@ func (e *MathMLSemanticsElement) IfMathsizeStrf(condition bool, format string, a
 // dimension or a dimensionless number.
 // Remove the attribute MathsizeStr from the element.
 func (e *MathMLSemanticsElement) MathsizeStrRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mathsize")
+	e = e.writable()
+	e.removeAttribute("mathsize")
 	return e
 }
 
@@ -508,10 +516,8 @@ func (e *MathMLSemanticsElement) MathsizeStrRemove() *MathMLSemanticsElement {
 // resulting resource must be delivered with a Content-Security-Policy nonce
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLSemanticsElement) Nonce(s string) *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("nonce", s)
+	e = e.writable()
+	e.setStringAttribute("nonce", s)
 	return e
 }
 
@@ -529,7 +535,7 @@ func (e *MathMLSemanticsElement) Noncef(format string, args ...any) *MathMLSeman
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLSemanticsElement) IfNonce(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.Nonce(s)
+		e = e.Nonce(s)
 	}
 	return e
 }
@@ -540,7 +546,7 @@ func (e *MathMLSemanticsElement) IfNonce(condition bool, s string) *MathMLSemant
 // Attribute matching the value of the nonce Attribute.
 func (e *MathMLSemanticsElement) IfNoncef(condition bool, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.Nonce(fmt.Sprintf(format, args...))
+		e = e.Nonce(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -551,20 +557,16 @@ func (e *MathMLSemanticsElement) IfNoncef(condition bool, format string, args ..
 // Attribute matching the value of the nonce Attribute.
 // Remove the attribute Nonce from the element.
 func (e *MathMLSemanticsElement) NonceRemove() *MathMLSemanticsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("nonce")
+	e = e.writable()
+	e.removeAttribute("nonce")
 	return e
 }
 
 // This Attribute specifies the script level of the element. Possible values are
 // an integer between 0 and 7, inclusive.
 func (e *MathMLSemanticsElement) Scriptlevel(i int) *MathMLSemanticsElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("scriptlevel", i)
+	e = e.writable()
+	e.setIntAttribute("scriptlevel", i)
 	return e
 }
 
@@ -572,7 +574,7 @@ func (e *MathMLSemanticsElement) Scriptlevel(i int) *MathMLSemanticsElement {
 // an integer between 0 and 7, inclusive.
 func (e *MathMLSemanticsElement) IfScriptlevel(condition bool, i int) *MathMLSemanticsElement {
 	if condition {
-		e.Scriptlevel(i)
+		e = e.Scriptlevel(i)
 	}
 	return e
 }
@@ -581,10 +583,8 @@ func (e *MathMLSemanticsElement) IfScriptlevel(condition bool, i int) *MathMLSem
 // an integer between 0 and 7, inclusive.
 // Remove the attribute Scriptlevel from the element.
 func (e *MathMLSemanticsElement) ScriptlevelRemove() *MathMLSemanticsElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("scriptlevel")
+	e = e.writable()
+	e.removeAttribute("scriptlevel")
 	return e
 }
 
@@ -594,14 +594,8 @@ func (e *MathMLSemanticsElement) StylePairs(pairs ...string) *MathMLSemanticsEle
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -616,14 +610,8 @@ func (e *MathMLSemanticsElement) StylePairs(pairs ...string) *MathMLSemanticsEle
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLSemanticsElement) Style(s string) *MathMLSemanticsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -631,7 +619,7 @@ func (e *MathMLSemanticsElement) Style(s string) *MathMLSemanticsElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -640,7 +628,7 @@ func (e *MathMLSemanticsElement) Style(s string) *MathMLSemanticsElement {
 // set.
 func (e *MathMLSemanticsElement) IfStyle(condition bool, s string) *MathMLSemanticsElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
@@ -648,15 +636,7 @@ func (e *MathMLSemanticsElement) IfStyle(condition bool, s string) *MathMLSemant
 // This Attribute offers advisory information about the element for which it is
 // set.
 func (e *MathMLSemanticsElement) StyleAdd(k string, v string) *MathMLSemanticsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -670,7 +650,7 @@ func (e *MathMLSemanticsElement) StyleAddf(k string, format string, args ...any)
 // set.
 func (e *MathMLSemanticsElement) IfStyleAdd(condition bool, k string, v string) *MathMLSemanticsElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -679,7 +659,7 @@ func (e *MathMLSemanticsElement) IfStyleAdd(condition bool, k string, v string)
 // set.
 func (e *MathMLSemanticsElement) IfStyleAddf(condition bool, k string, format string, args ...any) *MathMLSemanticsElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -688,21 +668,15 @@ func (e *MathMLSemanticsElement) IfStyleAddf(condition bool, k string, format st
 // set.
 // Add the attributes in the map to the element.
 func (e *MathMLSemanticsElement) StyleMap(m map[string]string) *MathMLSemanticsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -711,14 +685,8 @@ func (e *MathMLSemanticsElement) StyleMap(m map[string]string) *MathMLSemanticsE
 // set.
 // Remove the attribute Style from the element.
 func (e *MathMLSemanticsElement) StyleRemove(keys ...string) *MathMLSemanticsElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
 
@@ -726,10 +694,8 @@ func (e *MathMLSemanticsElement) StyleRemove(keys ...string) *MathMLSemanticsEle
 // order for the current document. This value must be a number between 0 and
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLSemanticsElement) Tabindex(i int) *MathMLSemanticsElement {
-	if e.intAttributes == nil {
-		e.intAttributes = treemap.New[string, int]()
-	}
-	e.intAttributes.Set("tabindex", i)
+	e = e.writable()
+	e.setIntAttribute("tabindex", i)
 	return e
 }
 
@@ -738,7 +704,7 @@ func (e *MathMLSemanticsElement) Tabindex(i int) *MathMLSemanticsElement {
 // 32767. User agents should ignore leading zeros.
 func (e *MathMLSemanticsElement) IfTabindex(condition bool, i int) *MathMLSemanticsElement {
 	if condition {
-		e.Tabindex(i)
+		e = e.Tabindex(i)
 	}
 	return e
 }
@@ -748,9 +714,7 @@ func (e *MathMLSemanticsElement) IfTabindex(condition bool, i int) *MathMLSemant
 // 32767. User agents should ignore leading zeros.
 // Remove the attribute Tabindex from the element.
 func (e *MathMLSemanticsElement) TabindexRemove() *MathMLSemanticsElement {
-	if e.intAttributes == nil {
-		return e
-	}
-	e.intAttributes.Del("tabindex")
+	e = e.writable()
+	e.removeAttribute("tabindex")
 	return e
 }
@@ -0,0 +1,145 @@
+package elements
+
+import "strings"
+
+// following describes what comes after the element being rendered, as far as
+// its parent knows. It is only tracked in minify mode.
+type following struct {
+	// known is false when the element is not rendered by an Element, in which
+	// case nothing is assumed about its siblings.
+	known bool
+	// next is the following sibling, nil at the end of the parent.
+	next ElementRenderer
+}
+
+// pClosers holds the elements in front of which the end tag of a p element
+// may be omitted.
+var pClosers = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"details": true, "dialog": true, "div": true, "dl": true, "fieldset": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true, "h1": true,
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hgroup": true, "hr": true, "main": true, "menu": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "search": true, "section": true, "table": true,
+	"ul": true,
+}
+
+// pTransparentParents holds the parents in which a trailing p element keeps
+// its end tag.
+var pTransparentParents = map[string]bool{
+	"a": true, "audio": true, "del": true, "ins": true, "map": true,
+	"noscript": true, "video": true,
+}
+
+// whitespacePreservingTags holds the elements whose text is never collapsed.
+var whitespacePreservingTags = map[string]bool{
+	"listing": true, "plaintext": true, "pre": true, "script": true,
+	"style": true, "textarea": true, "xmp": true,
+}
+
+// canOmitEndTag applies the optional tag rules of the HTML Living Standard to
+// the end tag of e. parent is nil at the top of the tree.
+func canOmitEndTag(e, parent *Element, f following) bool {
+	if e.namespace != namespaceHTML || !f.known {
+		return false
+	}
+	tag := string(e.tag)
+	if parent == nil {
+		// A fragment may be followed by anything, only the document element
+		// is known to be last.
+		return tag == "html" && f.next == nil
+	}
+
+	next := ""
+	if f.next != nil {
+		el, ok := f.next.(interface{ baseElement() *Element })
+		if !ok || el.baseElement().namespace != namespaceHTML {
+			// Text, comments and foreign content keep the end tag.
+			return false
+		}
+		next = string(el.baseElement().tag)
+	}
+	last := f.next == nil
+
+	switch tag {
+	case "html", "head", "body", "colgroup", "caption":
+		return true
+	case "li":
+		return last || next == "li"
+	case "dt":
+		return next == "dt" || next == "dd"
+	case "dd":
+		return last || next == "dd" || next == "dt"
+	case "p":
+		if last {
+			ptag := string(parent.tag)
+			return parent.namespace == namespaceHTML && !pTransparentParents[ptag] && !strings.Contains(ptag, "-")
+		}
+		return pClosers[next]
+	case "rt", "rp":
+		return last || next == "rt" || next == "rp"
+	case "optgroup":
+		return last || next == "optgroup" || next == "hr"
+	case "option":
+		return last || next == "option" || next == "optgroup" || next == "hr"
+	case "thead":
+		return next == "tbody" || next == "tfoot"
+	case "tbody":
+		return last || next == "tbody" || next == "tfoot"
+	case "tfoot":
+		return last
+	case "tr":
+		return last || next == "tr"
+	case "td", "th":
+		return last || next == "td" || next == "th"
+	}
+	return false
+}
+
+// collapseWhitespace replaces each run of ASCII whitespace with a single
+// space.
+func collapseWhitespace(s string) string {
+	if !strings.ContainsAny(s, " \t\n\f\r") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	inSpace := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\f', '\r':
+			if !inSpace {
+				b.WriteByte(' ')
+			}
+			inSpace = true
+		default:
+			b.WriteByte(c)
+			inSpace = false
+		}
+	}
+	return b.String()
+}
+
+// renderMinified renders the children, letting each element know which
+// sibling follows it.
+func (rw *renderWriter) renderMinified(children []ElementRenderer) error {
+	var prev ElementRenderer
+	var err error
+	render := func(child, next ElementRenderer) error {
+		if _, ok := child.(interface{ baseElement() *Element }); ok {
+			rw.following = following{known: true, next: next}
+		}
+		return child.Render(rw)
+	}
+	eachChild(children, func(child ElementRenderer) bool {
+		if prev != nil {
+			err = render(prev, child)
+		}
+		prev = child
+		return err == nil
+	})
+	if err == nil && prev != nil {
+		err = render(prev, nil)
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package elements
+
+import (
+	"io"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// RenderString renders root to a string.
+func RenderString(root ElementRenderer) (string, error) {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+	if err := defaultRenderer.Render(buf, root); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderBytes renders root to a newly allocated byte slice.
+func RenderBytes(root ElementRenderer) ([]byte, error) {
+	return AppendHTML(nil, root)
+}
+
+// AppendHTML appends the rendering of root to dst. On error dst is returned
+// unchanged.
+func AppendHTML(dst []byte, root ElementRenderer) ([]byte, error) {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+	if err := defaultRenderer.Render(buf, root); err != nil {
+		return dst, err
+	}
+	return append(dst, buf.B...), nil
+}
+
+// writeTo renders root to a pooled buffer before copying it to w, so nothing
+// is written when rendering fails.
+func writeTo(w io.Writer, root ElementRenderer) (int64, error) {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+	if err := defaultRenderer.Render(buf, root); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
+
+// mustString is used by the fmt.Stringer implementations, which have no way
+// to report an error.
+func mustString(root ElementRenderer) string {
+	s, err := RenderString(root)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func mustBytes(root ElementRenderer) []byte {
+	b, err := RenderBytes(root)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// String renders the element, it returns an empty string if rendering fails.
+func (e *Element) String() string { return mustString(e) }
+
+// Bytes renders the element, it returns nil if rendering fails.
+func (e *Element) Bytes() []byte { return mustBytes(e) }
+
+func (e *Element) AppendHTML(dst []byte) ([]byte, error) { return AppendHTML(dst, e) }
+
+func (e *Element) WriteTo(w io.Writer) (int64, error) { return writeTo(w, e) }
+
+// String renders the children, it returns an empty string if rendering fails.
+func (g *Grouper) String() string { return mustString(g) }
+
+// Bytes renders the children, it returns nil if rendering fails.
+func (g *Grouper) Bytes() []byte { return mustBytes(g) }
+
+func (g *Grouper) AppendHTML(dst []byte) ([]byte, error) { return AppendHTML(dst, g) }
+
+func (g *Grouper) WriteTo(w io.Writer) (int64, error) { return writeTo(w, g) }
+
+// String renders the document, it returns an empty string if rendering fails.
+func (d *DocumentContent) String() string { return mustString(d) }
+
+// Bytes renders the document, it returns nil if rendering fails.
+func (d *DocumentContent) Bytes() []byte { return mustBytes(d) }
+
+func (d *DocumentContent) AppendHTML(dst []byte) ([]byte, error) { return AppendHTML(dst, d) }
+
+func (d *DocumentContent) WriteTo(w io.Writer) (int64, error) { return writeTo(w, d) }
+
+func (tc *TextContent) String() string { return mustString(tc) }
+
+func (tc *TextContent) Bytes() []byte { return mustBytes(tc) }
+
+func (tc *TextContent) AppendHTML(dst []byte) ([]byte, error) { return AppendHTML(dst, tc) }
+
+func (tc *TextContent) WriteTo(w io.Writer) (int64, error) { return writeTo(w, tc) }
+
+func (ec *EscapedContent) String() string { return mustString(ec) }
+
+func (ec *EscapedContent) Bytes() []byte { return mustBytes(ec) }
+
+func (ec *EscapedContent) AppendHTML(dst []byte) ([]byte, error) { return AppendHTML(dst, ec) }
+
+func (ec *EscapedContent) WriteTo(w io.Writer) (int64, error) { return writeTo(w, ec) }
+
+var (
+	_ io.WriterTo = (*Element)(nil)
+	_ io.WriterTo = (*Grouper)(nil)
+	_ io.WriterTo = (*DocumentContent)(nil)
+	_ io.WriterTo = (*TextContent)(nil)
+	_ io.WriterTo = (*EscapedContent)(nil)
+)
@@ -0,0 +1,528 @@
+package elements
+
+import (
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type attributeValueKind uint8
+
+const (
+	valueString attributeValueKind = iota
+	valueBool
+	valueChoice
+	valueRune
+	valueInt
+	valueNumber
+	valueDelimited
+	valueKeyValue
+)
+
+// attributeType tells the parser how to store the value of a generated
+// attribute, and how its name is written.
+type attributeType struct {
+	name                         string
+	kind                         attributeValueKind
+	delimiter, keyValueDelimiter string
+	precision                    int
+}
+
+// Parse reads HTML, a document or a fragment, into a tree of the generated
+// element types. Elements the package does not know become NewElement
+// elements, and the attribute values are stored the way their setters store
+// them, so the tree can be queried and modified as if built in Go.
+//
+// Like browsers, the parser accepts any input: end tags are implied where the
+// HTML standard implies them, and stray end tags are dropped. It does not
+// reparent misnested content the way browsers do for tables and formatting
+// elements though. Text is stored escaped and the text of script and style
+// elements raw, so rendering the tree gives back equivalent HTML.
+//
+// A document, starting with a doctype, is returned as a DocumentContent, a
+// fragment with one top-level node as that node, and any other fragment as a
+// Group.
+func Parse(r io.Reader) (ElementRenderer, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseString(string(b)), nil
+}
+
+// ParseString is like Parse for HTML in a string.
+func ParseString(s string) ElementRenderer {
+	p := &parser{z: tokenizer{s: s}}
+	for {
+		t, ok := p.z.next()
+		if !ok {
+			break
+		}
+		p.token(t)
+	}
+
+	if p.doctype {
+		return Document(p.nodes...)
+	}
+	var single ElementRenderer
+	for _, node := range p.nodes {
+		if isWhitespaceText(node) {
+			continue
+		}
+		if single != nil {
+			return Group(p.nodes...)
+		}
+		single = node
+	}
+	if single == nil {
+		return Group(p.nodes...)
+	}
+	return single
+}
+
+func isWhitespaceText(node ElementRenderer) bool {
+	t, ok := node.(*EscapedContent)
+	return ok && strings.TrimLeft(string(*t), " \t\n\f\r") == ""
+}
+
+type parser struct {
+	z tokenizer
+	// nodes holds the top-level nodes, stack the open elements.
+	nodes []ElementRenderer
+	stack []*Element
+	// doctype is set when the input starts with a doctype.
+	doctype bool
+	// skipNewline drops the newline starting the text of pre, listing and
+	// textarea elements.
+	skipNewline bool
+}
+
+func (p *parser) current() *Element {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *parser) append(node ElementRenderer) {
+	if e := p.current(); e != nil {
+		e.descendants = append(e.descendants, node)
+		return
+	}
+	p.nodes = append(p.nodes, node)
+}
+
+func (p *parser) token(t token) {
+	skipNewline := p.skipNewline
+	p.skipNewline = false
+
+	switch t.kind {
+	case textToken:
+		if skipNewline {
+			t.data = strings.TrimPrefix(t.data, "\n")
+			if t.data == "" {
+				return
+			}
+		}
+		if e := p.current(); e != nil && e.namespace == namespaceHTML && rawTextElements[string(e.tag)] {
+			p.append(Raw(t.data))
+			return
+		}
+		p.append(Escaped(t.data))
+	case cdataToken:
+		if p.foreign() {
+			p.append(Escaped(t.data))
+			return
+		}
+		p.append(Comment("[CDATA[" + t.data + "]]"))
+	case commentToken:
+		p.append(Comment(t.data))
+	case doctypeToken:
+		if len(p.nodes) == 0 && len(p.stack) == 0 {
+			p.doctype = true
+		}
+	case startTagToken:
+		p.startTag(t)
+	case endTagToken:
+		p.endTag(strings.ToLower(t.data))
+	}
+}
+
+// foreign reports whether the content at the current position is SVG or
+// MathML.
+func (p *parser) foreign() bool {
+	e := p.current()
+	return e != nil && e.namespace != namespaceHTML && !isIntegrationPoint(e)
+}
+
+// isIntegrationPoint reports whether e is a foreign element holding HTML.
+func isIntegrationPoint(e *Element) bool {
+	switch e.namespace {
+	case namespaceSVG:
+		switch strings.ToLower(string(e.tag)) {
+		case "foreignobject", "desc", "title":
+			return true
+		}
+	case namespaceMathML:
+		switch string(e.tag) {
+		case "mi", "mo", "mn", "ms", "mtext", "annotation-xml":
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) startTag(t token) {
+	tag := strings.ToLower(t.data)
+	ns := namespaceHTML
+	if p.foreign() {
+		ns = p.current().namespace
+		if breakoutElements[tag] || tag == "font" && slices.ContainsFunc(t.attrs, isFontBreakout) {
+			// HTML elements end the foreign content they appear in.
+			for p.foreign() {
+				p.pop()
+			}
+			ns = namespaceHTML
+		}
+	}
+	if ns == namespaceHTML {
+		switch tag {
+		case "svg":
+			ns = namespaceSVG
+		case "math":
+			ns = namespaceMathML
+		default:
+			p.closeImplied(tag)
+		}
+	}
+
+	var node ElementRenderer
+	var e *Element
+	if ctor, ok := elementConstructors[ns][tag]; ok {
+		node = ctor()
+		e = node.(interface{ baseElement() *Element }).baseElement()
+	} else {
+		name := tag
+		if ns != namespaceHTML {
+			name = t.data
+		}
+		e = NewElement(name)
+		e.namespace = ns
+		node = e
+	}
+	var choiceType func(string) func(string) any
+	if c, ok := node.(interface{ choiceType(string) func(string) any }); ok {
+		choiceType = c.choiceType
+	}
+	for _, a := range t.attrs {
+		setParsedAttribute(e, a, choiceType)
+	}
+	p.append(node)
+
+	if e.isSelfClosing || ns != namespaceHTML && t.selfClosing {
+		return
+	}
+	p.stack = append(p.stack, e)
+	if ns != namespaceHTML {
+		return
+	}
+	switch tag {
+	case "script", "style", "xmp", "iframe", "noembed", "noframes":
+		p.z.setRawText(tag, false)
+	case "textarea", "title":
+		p.z.setRawText(tag, true)
+		p.skipNewline = tag == "textarea"
+	case "pre", "listing":
+		p.skipNewline = true
+	}
+}
+
+func isFontBreakout(a tokenAttribute) bool {
+	switch strings.ToLower(a.name) {
+	case "color", "face", "size":
+		return true
+	}
+	return false
+}
+
+func (p *parser) pop() {
+	p.stack = p.stack[:len(p.stack)-1]
+}
+
+// popUntil pops the open elements up to and including the one at i.
+func (p *parser) popUntil(i int) {
+	p.stack = p.stack[:i]
+}
+
+// closeImplied closes the elements the start tag of an HTML element implies
+// the end of.
+func (p *parser) closeImplied(tag string) {
+	if closesParagraph[tag] {
+		p.close("p", buttonScope)
+	}
+	switch tag {
+	case "li":
+		p.close("li", listItemScope)
+	case "dd", "dt":
+		if !p.close("dd", dlScope) {
+			p.close("dt", dlScope)
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		if e := p.current(); e != nil && e.namespace == namespaceHTML && headings[string(e.tag)] {
+			p.pop()
+		}
+	default:
+		closed := impliedByStartTag[tag]
+		for e := p.current(); e != nil && e.namespace == namespaceHTML && closed[string(e.tag)]; e = p.current() {
+			p.pop()
+		}
+	}
+}
+
+// close closes the open element with tag if one is in scope, along with the
+// elements opened after it.
+func (p *parser) close(tag string, scope map[string]bool) bool {
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		e := p.stack[i]
+		if e.namespace != namespaceHTML {
+			return false
+		}
+		if string(e.tag) == tag {
+			p.popUntil(i)
+			return true
+		}
+		if scope[string(e.tag)] {
+			return false
+		}
+	}
+	return false
+}
+
+// endTag closes the matching open element, the end tag is dropped when an
+// element that cannot be closed implicitly is open after it.
+func (p *parser) endTag(tag string) {
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		e := p.stack[i]
+		if strings.EqualFold(string(e.tag), tag) {
+			p.popUntil(i)
+			return
+		}
+		if e.namespace == namespaceHTML && specialElements[string(e.tag)] && !impliedEnd[string(e.tag)] {
+			return
+		}
+		if isIntegrationPoint(e) {
+			return
+		}
+	}
+}
+
+// setParsedAttribute stores a parsed attribute the way its setter does,
+// choiceType being the choice types of the element. The first of duplicate
+// attributes wins, and invalid names are dropped.
+func setParsedAttribute(e *Element, a tokenAttribute, choiceType func(string) func(string) any) {
+	key := strings.ToLower(a.name)
+	t, ok := elementAttributeTypes[e.namespace][string(e.tag)][key]
+	if !ok {
+		t, ok = globalAttributeTypes[e.namespace][key]
+	}
+	name := key
+	switch {
+	case ok:
+		name = t.name
+	case e.namespace != namespaceHTML:
+		name = a.name
+	}
+	if !isValidAttributeName(name) || e.getAttribute(name) != nil {
+		return
+	}
+
+	switch t.kind {
+	case valueBool:
+		e.setBoolAttribute(name)
+		return
+	case valueChoice:
+		var typed func(string) any
+		if choiceType != nil {
+			typed = choiceType(name)
+		}
+		e.setChoiceAttribute(name, a.value, typed)
+		return
+	case valueRune:
+		if r, size := utf8.DecodeRuneInString(a.value); size > 0 && size == len(a.value) && r != utf8.RuneError {
+			e.setRuneAttribute(name, r)
+			return
+		}
+	case valueInt:
+		if n, err := strconv.Atoi(strings.TrimSpace(a.value)); err == nil {
+			e.setIntAttribute(name, n)
+			return
+		}
+	case valueNumber:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(a.value), 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			e.setFloatAttribute(name, f, t.precision)
+			return
+		}
+	case valueDelimited:
+		if values := splitDelimited(a.value, t.delimiter); len(values) > 0 {
+			e.delimitedAttribute(name, t.delimiter).Add(values...)
+			return
+		}
+	case valueKeyValue:
+		if entries, ok := splitKeyValues(a.value, t.keyValueDelimiter, t.delimiter); ok && len(entries) > 0 {
+			kv := e.keyValueAttribute(name, t.keyValueDelimiter, t.delimiter)
+			for _, entry := range entries {
+				kv.Add(entry[0], entry[1])
+			}
+			return
+		}
+	}
+	// Values the typed storage does not fit are kept as written.
+	e.setStringAttribute(name, a.value)
+}
+
+func splitDelimited(value, delimiter string) []string {
+	if strings.TrimSpace(delimiter) == "" {
+		return strings.Fields(value)
+	}
+	var values []string
+	for _, v := range strings.Split(value, delimiter) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitKeyValues splits value into key-value entries, the delimiters inside
+// quotes and parentheses being part of the values. It fails when an entry has
+// no key.
+func splitKeyValues(value, keyValueDelimiter, delimiter string) ([][2]string, bool) {
+	var entries [][2]string
+	for _, entry := range splitOutsideQuotes(value, delimiter) {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(entry, keyValueDelimiter)
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" {
+			return nil, false
+		}
+		entries = append(entries, [2]string{k, v})
+	}
+	return entries, true
+}
+
+func splitOutsideQuotes(s, delimiter string) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case depth == 0 && strings.HasPrefix(s[i:], delimiter):
+			parts = append(parts, s[start:i])
+			i += len(delimiter) - 1
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// rawTextElements holds the HTML elements whose text is not escaped.
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "xmp": true, "iframe": true, "noembed": true, "noframes": true,
+}
+
+// breakoutElements holds the start tags ending foreign content.
+var breakoutElements = setOf(
+	"b", "big", "blockquote", "body", "br", "center", "code", "dd", "div", "dl",
+	"dt", "em", "embed", "h1", "h2", "h3", "h4", "h5", "h6", "head", "hr", "i",
+	"img", "li", "listing", "menu", "meta", "nobr", "ol", "p", "pre", "ruby", "s",
+	"small", "span", "strong", "strike", "sub", "sup", "table", "tt", "u", "ul",
+	"var",
+)
+
+// closesParagraph holds the start tags closing an open p element.
+var closesParagraph = setOf(
+	"address", "article", "aside", "blockquote", "center", "details", "dialog",
+	"dir", "div", "dl", "dd", "dt", "fieldset", "figcaption", "figure", "footer",
+	"form", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hgroup", "hr", "li",
+	"listing", "main", "menu", "nav", "ol", "p", "pre", "search", "section",
+	"summary", "table", "ul", "xmp",
+)
+
+var headings = setOf("h1", "h2", "h3", "h4", "h5", "h6")
+
+// impliedByStartTag holds the open elements the start tags close, when they
+// are the current element.
+var impliedByStartTag = map[string]map[string]bool{
+	"option":   setOf("option"),
+	"optgroup": setOf("option", "optgroup"),
+	"tr":       setOf("td", "th", "tr"),
+	"td":       setOf("td", "th"),
+	"th":       setOf("td", "th"),
+	"thead":    setOf("td", "th", "tr", "thead", "tbody", "tfoot", "caption", "colgroup"),
+	"tbody":    setOf("td", "th", "tr", "thead", "tbody", "tfoot", "caption", "colgroup"),
+	"tfoot":    setOf("td", "th", "tr", "thead", "tbody", "tfoot", "caption", "colgroup"),
+	"rb":       setOf("rb", "rt", "rtc", "rp"),
+	"rtc":      setOf("rb", "rt", "rtc", "rp"),
+	"rt":       setOf("rb", "rt", "rp"),
+	"rp":       setOf("rb", "rt", "rp"),
+}
+
+// impliedEnd holds the elements whose end tag may be omitted.
+var impliedEnd = setOf("dd", "dt", "li", "optgroup", "option", "p", "rb", "rp", "rt", "rtc", "tbody", "td", "tfoot", "th", "thead", "tr")
+
+var (
+	defaultScope  = setOf("applet", "caption", "html", "table", "td", "th", "marquee", "object", "template")
+	buttonScope   = union(defaultScope, setOf("button"))
+	listItemScope = union(defaultScope, setOf("ol", "ul"))
+	dlScope       = union(defaultScope, setOf("dl"))
+)
+
+// specialElements holds the HTML elements that end tags of other elements do
+// not close.
+var specialElements = setOf(
+	"address", "applet", "area", "article", "aside", "base", "basefont",
+	"bgsound", "blockquote", "body", "br", "button", "caption", "center", "col",
+	"colgroup", "dd", "details", "dir", "div", "dl", "dt", "embed", "fieldset",
+	"figcaption", "figure", "footer", "form", "frame", "frameset", "h1", "h2",
+	"h3", "h4", "h5", "h6", "head", "header", "hgroup", "hr", "html", "iframe",
+	"img", "input", "keygen", "li", "link", "listing", "main", "marquee", "menu",
+	"meta", "nav", "noembed", "noframes", "noscript", "object", "ol", "p",
+	"param", "plaintext", "pre", "script", "search", "section", "select",
+	"source", "style", "summary", "table", "tbody", "td", "template", "textarea",
+	"tfoot", "th", "thead", "title", "tr", "track", "ul", "wbr", "xmp",
+)
+
+func setOf(values ...string) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
+
+func union(sets ...map[string]bool) map[string]bool {
+	m := map[string]bool{}
+	for _, set := range sets {
+		for v := range set {
+			m[v] = true
+		}
+	}
+	return m
+}
@@ -0,0 +1,343 @@
+package elements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// Renderer holds the options of a render pass. The zero value renders the
+// same output as calling Render on the tree directly.
+type Renderer struct {
+	// Contextual enables context-aware escaping. Attribute values are escaped
+	// according to the attribute they are assigned to (URLs, event handlers,
+	// inline styles) and Escaped content is escaped according to the element
+	// it is placed in (script, style or HTML). Values set with RawAttr are
+	// trusted and only escaped as attribute values.
+	Contextual bool
+
+	// Indent, when not empty, puts the block-level children of block
+	// containers on their own lines, indented with Indent per level. Inline
+	// formatting contexts and whitespace-sensitive elements such as pre,
+	// textarea, script and style are rendered byte for byte as without
+	// indentation, so the document displays the same.
+	Indent string
+
+	// Minify omits the end tags the HTML Living Standard allows to omit, drops
+	// the quotes around attribute values that do not need them and collapses
+	// whitespace in text outside of pre, textarea, script and style. It takes
+	// precedence over Indent.
+	Minify bool
+
+	// XML renders well-formed XML: elements without children are
+	// self-closing, boolean attributes repeat their name as value and the
+	// root element of each namespace declares it with xmlns, along with
+	// xmlns:xlink for SVG trees using xlink attributes. It disables the
+	// optional tag and unquoted attribute rules of Minify.
+	XML bool
+
+	// Precision, when positive, rounds the number attributes to at most
+	// Precision decimal places. Numbers are always written in decimal
+	// notation, without exponent.
+	Precision int
+
+	// Transformers are applied in order to a copy of each element before it
+	// is rendered, so the tree itself is left untouched.
+	Transformers []Transformer
+
+	// TransformersKey identifies the chain of Transformers. Without it, Cached
+	// fragments and compiled content are rendered anew on every render pass
+	// using transformers, as functions cannot be compared. With it, they are
+	// stored transformed and shared by every renderer using the same key, so
+	// the key must change whenever the chain does.
+	//
+	// Stored fragments are replayed as is: transformers reading the render
+	// context, such as the ones injecting a per-request value, must leave
+	// TransformersKey empty. Compile runs them with an empty context.
+	TransformersKey string
+
+	// RequireNonce makes rendering an inline script fail with ErrMissingNonce
+	// when neither the script nor the render context has a nonce, see
+	// WithNonce.
+	RequireNonce bool
+
+	// Cache stores the fragments rendered by Cached, DefaultCache is used when
+	// it is nil.
+	Cache Cache
+}
+
+var defaultRenderer = &Renderer{}
+
+// sameOutput reports whether r and o render trees the same way.
+func (r *Renderer) sameOutput(o *Renderer) bool {
+	return r.Contextual == o.Contextual && r.Indent == o.Indent && r.Minify == o.Minify && r.XML == o.XML &&
+		r.Precision == o.Precision && r.sameTransformers(o)
+}
+
+// Render writes the root to w using the options of the renderer.
+func (r *Renderer) Render(w io.Writer, root ElementRenderer) error {
+	return r.RenderContext(context.Background(), w, root)
+}
+
+// RenderContext writes the root to w using the options of the renderer. The
+// context is passed to the callbacks of DynGroupContext and DynIfContext and
+// rendering stops with its error once it is done.
+func (r *Renderer) RenderContext(ctx context.Context, w io.Writer, root ElementRenderer) error {
+	if root == nil {
+		return nil
+	}
+	rw := newRenderWriter(w, r)
+	defer rw.release()
+	rw.ctx = ctx
+	return rw.renderRoot(root)
+}
+
+func (rw *renderWriter) renderRoot(root ElementRenderer) error {
+	if g, ok := root.(*Grouper); ok && g != nil {
+		return rw.renderTopLevel(g.Children)
+	}
+	return rw.renderTopLevel([]ElementRenderer{root})
+}
+
+// renderTopLevel renders the children of the document.
+func (rw *renderWriter) renderTopLevel(children []ElementRenderer) error {
+	if rw.minify() {
+		return rw.renderMinified(children)
+	}
+	if rw.renderer.Indent != "" && !rw.compact && len(rw.stack) == 0 && allBlockLevel(nil, children) {
+		// Top level blocks go on their own lines, without indentation.
+		depth := rw.depth
+		rw.depth = -1
+		_, err := rw.renderIndented(children)
+		rw.depth = depth
+		return err
+	}
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		if err := rw.ctxErr(); err != nil {
+			return rw.fail(err)
+		}
+		if err := child.Render(rw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderWriter wraps the destination writer of a render pass and carries its
+// state down the tree. Elements look for it in the writer they are given, so
+// any ElementRenderer passing its writer along keeps the state intact.
+type renderWriter struct {
+	w        io.Writer
+	renderer *Renderer
+	ctx      context.Context
+	rawText  escapeContext
+	// depth is the indentation level of the element being rendered, compact is
+	// set within inline content, where no indentation is added.
+	depth   int
+	compact bool
+	// following is tracked in minify mode, preserveSpace whenever Minify is
+	// set.
+	following     following
+	preserveSpace bool
+	// err is the first error returned by w, later writes are dropped.
+	err error
+	// stack holds the elements being rendered, from the root down.
+	stack []*Element
+	// scratch is reused to format attribute values.
+	scratch []byte
+	// holes fills the Hole placeholders, before the context is looked up.
+	holes map[string]ElementRenderer
+	// compiler records the Hole placeholders while compiling.
+	compiler *compiler
+	// ids allocates the IDs of UseID, it is set on scopedCtx, the context
+	// given to the callbacks, which is derived from ctx on first use.
+	ids       *idAllocator
+	scopedCtx context.Context
+	// body records the script or style body being hashed, while rw.w writes
+	// to both bodyOut and body.
+	body    *bytebufferpool.ByteBuffer
+	bodyOut io.Writer
+}
+
+var renderWriterPool = sync.Pool{
+	New: func() any { return &renderWriter{} },
+}
+
+func newRenderWriter(w io.Writer, r *Renderer) *renderWriter {
+	rw := renderWriterPool.Get().(*renderWriter)
+	rw.w = w
+	rw.renderer = r
+	return rw
+}
+
+// asRenderWriter returns the render writer carried by w, or wraps w with the
+// default renderer. When owned is true the caller must release the writer once
+// rendering is done.
+func asRenderWriter(w io.Writer) (rw *renderWriter, owned bool) {
+	if rw, ok := w.(*renderWriter); ok {
+		return rw, false
+	}
+	return newRenderWriter(w, defaultRenderer), true
+}
+
+// context returns the context of the render pass, carrying its ID
+// allocator.
+func (rw *renderWriter) context() context.Context {
+	if rw.scopedCtx == nil {
+		ctx := rw.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if rw.ids == nil {
+			rw.ids = &idAllocator{}
+		}
+		rw.scopedCtx = context.WithValue(ctx, idAllocatorKey{}, rw.ids)
+	}
+	return rw.scopedCtx
+}
+
+// withContext sets the context of the render pass and returns a function
+// restoring the previous one.
+func (rw *renderWriter) withContext(ctx context.Context) func() {
+	prev, prevScoped := rw.ctx, rw.scopedCtx
+	rw.ctx, rw.scopedCtx = ctx, nil
+	return func() { rw.ctx, rw.scopedCtx = prev, prevScoped }
+}
+
+// ctxErr returns the error of the render context once it is done.
+func (rw *renderWriter) ctxErr() error {
+	if rw.ctx == nil {
+		return nil
+	}
+	return rw.ctx.Err()
+}
+
+func (rw *renderWriter) minify() bool {
+	return rw.renderer.Minify && !rw.renderer.XML
+}
+
+func (rw *renderWriter) collapseSpace() bool {
+	return rw.renderer.Minify && !rw.preserveSpace
+}
+
+// isNamespaceRoot reports whether the element being rendered is the topmost
+// one of its namespace.
+func (rw *renderWriter) isNamespaceRoot() bool {
+	n := len(rw.stack)
+	return n == 1 || rw.stack[n-2].namespace != rw.stack[n-1].namespace
+}
+
+func (rw *renderWriter) release() {
+	clear(rw.stack)
+	*rw = renderWriter{stack: rw.stack[:0], scratch: rw.scratch[:0]}
+	renderWriterPool.Put(rw)
+}
+
+func (rw *renderWriter) Write(p []byte) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	n, err := rw.w.Write(p)
+	if err != nil {
+		rw.err = err
+	}
+	return n, err
+}
+
+func (rw *renderWriter) WriteString(s string) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	n, err := io.WriteString(rw.w, s)
+	if err != nil {
+		rw.err = err
+	}
+	return n, err
+}
+
+// fail wraps err in a RenderError carrying the path of the element being
+// rendered. Errors that already carry a path are returned as is.
+func (rw *renderWriter) fail(err error) error {
+	var renderErr *RenderError
+	if errors.As(err, &renderErr) {
+		return err
+	}
+	return &RenderError{Path: rw.path(), Err: err}
+}
+
+// path describes the position of the element being rendered, such as
+// html>body>div#main>ul>li[3]. Elements are identified by their id when they
+// have one, and by their position among the siblings sharing their tag
+// otherwise.
+func (rw *renderWriter) path() string {
+	var sb strings.Builder
+	for i, e := range rw.stack {
+		if i > 0 {
+			sb.WriteByte('>')
+		}
+		sb.Write(e.tag)
+		if id, ok := e.id(); ok {
+			sb.WriteByte('#')
+			sb.WriteString(id)
+			continue
+		}
+		if i == 0 {
+			continue
+		}
+		if n, count := siblingPosition(rw.stack[i-1].descendants, e); count > 1 {
+			fmt.Fprintf(&sb, "[%d]", n)
+		}
+	}
+	return sb.String()
+}
+
+// siblingPosition returns the 1-based position of e among the children sharing
+// its tag, and the number of those children. Groupers are looked through.
+func siblingPosition(children []ElementRenderer, e *Element) (n, count int) {
+	for _, child := range children {
+		switch c := child.(type) {
+		case *Grouper:
+			if c == nil {
+				continue
+			}
+			cn, cc := siblingPosition(c.Children, e)
+			if cn > 0 {
+				n = count + cn
+			}
+			count += cc
+		case interface{ baseElement() *Element }:
+			sibling := c.baseElement()
+			if string(sibling.tag) != string(e.tag) {
+				continue
+			}
+			count++
+			if sibling == e.source() {
+				n = count
+			}
+		}
+	}
+	return n, count
+}
+
+// RenderError reports a failure while rendering an element. Path locates the
+// element in the tree, see renderWriter.path for its format.
+type RenderError struct {
+	Path string
+	Err  error
+}
+
+func (e *RenderError) Error() string {
+	return fmt.Sprintf("failed to render %s: %v", e.Path, e.Err)
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,418 @@
+package elements
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidSelector = errors.New("invalid selector")
+
+// Selector is a parsed CSS selector list. The supported subset is made of
+// type selectors and *, #id, .class, [attr] and [attr=value] (the value
+// optionally quoted), :nth-child(an+b), :first-child, the descendant and
+// child combinators, and comma-separated lists.
+type Selector struct {
+	complexes [][]compound
+}
+
+// compound is a compound selector along with the combinator to the previous
+// compound of the complex selector.
+type compound struct {
+	child    bool
+	tag      string
+	id       string
+	classes  []string
+	attrs    []attributeSelector
+	nthChild []nth
+}
+
+type attributeSelector struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+// nth matches the positions a*n+b for n >= 0.
+type nth struct {
+	a, b int
+}
+
+func (n nth) matches(position int) bool {
+	if n.a == 0 {
+		return position == n.b
+	}
+	d := position - n.b
+	return d%n.a == 0 && d/n.a >= 0
+}
+
+// ParseSelector parses a selector list.
+func ParseSelector(selector string) (*Selector, error) {
+	p := &selectorParser{s: selector}
+	s := &Selector{}
+	for {
+		complex, err := p.complex()
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidSelector, selector, err)
+		}
+		s.complexes = append(s.complexes, complex)
+		if p.done() {
+			return s, nil
+		}
+		p.pos++ // ','
+	}
+}
+
+// MustParseSelector is like ParseSelector but panics if the selector is
+// invalid.
+func MustParseSelector(selector string) *Selector {
+	s, err := ParseSelector(selector)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+type selectorParser struct {
+	s   string
+	pos int
+}
+
+func (p *selectorParser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *selectorParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for !p.done() && strings.IndexByte(" \t\n\r\f", p.peek()) >= 0 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) complex() ([]compound, error) {
+	var complex []compound
+	p.skipSpace()
+	child := false
+	for {
+		c, err := p.compound()
+		if err != nil {
+			return nil, err
+		}
+		c.child = child
+		complex = append(complex, c)
+
+		spaced := p.skipSpace()
+		switch p.peek() {
+		case 0, ',':
+			return complex, nil
+		case '>':
+			p.pos++
+			p.skipSpace()
+			child = true
+		default:
+			if !spaced {
+				return nil, fmt.Errorf("unexpected %q", p.peek())
+			}
+			child = false
+		}
+	}
+}
+
+func (p *selectorParser) compound() (compound, error) {
+	var c compound
+	start := p.pos
+	if p.peek() == '*' {
+		p.pos++
+	} else if name := p.ident(); name != "" {
+		c.tag = name
+	}
+	for {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			if c.id = p.ident(); c.id == "" {
+				return c, errors.New("missing id")
+			}
+		case '.':
+			p.pos++
+			class := p.ident()
+			if class == "" {
+				return c, errors.New("missing class")
+			}
+			c.classes = append(c.classes, class)
+		case '[':
+			p.pos++
+			a, err := p.attribute()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, a)
+		case ':':
+			p.pos++
+			n, err := p.pseudoClass()
+			if err != nil {
+				return c, err
+			}
+			c.nthChild = append(c.nthChild, n)
+		default:
+			if p.pos == start {
+				if p.done() {
+					return c, errors.New("missing selector")
+				}
+				return c, fmt.Errorf("unexpected %q", p.peek())
+			}
+			return c, nil
+		}
+	}
+}
+
+func isIdentByte(c byte) bool {
+	return c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func (p *selectorParser) ident() string {
+	start := p.pos
+	for !p.done() && isIdentByte(p.peek()) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *selectorParser) attribute() (attributeSelector, error) {
+	var a attributeSelector
+	p.skipSpace()
+	start := p.pos
+	for !p.done() && strings.IndexByte("]= \t\n\r\f", p.peek()) < 0 {
+		p.pos++
+	}
+	if a.name = p.s[start:p.pos]; a.name == "" {
+		return a, errors.New("missing attribute name")
+	}
+	p.skipSpace()
+	if p.peek() == '=' {
+		p.pos++
+		p.skipSpace()
+		a.hasValue = true
+		if q := p.peek(); q == '"' || q == '\'' {
+			end := strings.IndexByte(p.s[p.pos+1:], q)
+			if end < 0 {
+				return a, errors.New("unterminated string")
+			}
+			a.value = p.s[p.pos+1 : p.pos+1+end]
+			p.pos += end + 2
+		} else {
+			// Unquoted values go up to the ] or a space, like the names.
+			start := p.pos
+			for !p.done() && strings.IndexByte("] \t\n\r\f", p.peek()) < 0 {
+				p.pos++
+			}
+			if a.value = p.s[start:p.pos]; a.value == "" {
+				return a, errors.New("missing attribute value")
+			}
+		}
+		p.skipSpace()
+	}
+	if p.peek() != ']' {
+		return a, errors.New("missing ]")
+	}
+	p.pos++
+	return a, nil
+}
+
+func (p *selectorParser) pseudoClass() (nth, error) {
+	switch name := strings.ToLower(p.ident()); name {
+	case "first-child":
+		return nth{b: 1}, nil
+	case "nth-child":
+		if p.peek() != '(' {
+			return nth{}, errors.New("missing (")
+		}
+		end := strings.IndexByte(p.s[p.pos:], ')')
+		if end < 0 {
+			return nth{}, errors.New("missing )")
+		}
+		n, err := parseNth(p.s[p.pos+1 : p.pos+end])
+		p.pos += end + 1
+		return n, err
+	default:
+		return nth{}, fmt.Errorf("unsupported pseudo-class %q", name)
+	}
+}
+
+// parseNth parses the an+b argument of :nth-child.
+func parseNth(s string) (nth, error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), ""))
+	switch s {
+	case "odd":
+		return nth{a: 2, b: 1}, nil
+	case "even":
+		return nth{a: 2}, nil
+	}
+	i := strings.IndexByte(s, 'n')
+	if i < 0 {
+		b, err := strconv.Atoi(s)
+		return nth{b: b}, err
+	}
+	var n nth
+	switch a := s[:i]; a {
+	case "", "+":
+		n.a = 1
+	case "-":
+		n.a = -1
+	default:
+		var err error
+		if n.a, err = strconv.Atoi(a); err != nil {
+			return n, fmt.Errorf("invalid :nth-child argument %q", s)
+		}
+	}
+	if b := s[i+1:]; b != "" {
+		if b[0] != '+' && b[0] != '-' {
+			return n, fmt.Errorf("invalid :nth-child argument %q", s)
+		}
+		var err error
+		if n.b, err = strconv.Atoi(b); err != nil {
+			return n, fmt.Errorf("invalid :nth-child argument %q", s)
+		}
+	}
+	return n, nil
+}
+
+// match is an element being matched, along with its ancestors. position is
+// the 1-based index of each element among the element children of its
+// parent, Groupers being looked through.
+type match struct {
+	elements  []*Element
+	positions []int
+}
+
+func (c *compound) matches(e *Element, position int) bool {
+	if c.tag != "" && !strings.EqualFold(c.tag, string(e.tag)) {
+		return false
+	}
+	if c.id != "" {
+		if id, ok := e.id(); !ok || id != c.id {
+			return false
+		}
+	}
+	for _, class := range c.classes {
+		if !e.HasClass(class) {
+			return false
+		}
+	}
+	for _, a := range c.attrs {
+		v, ok := e.GetAttr(a.name)
+		if !ok || a.hasValue && v != a.value {
+			return false
+		}
+	}
+	for _, n := range c.nthChild {
+		if !n.matches(position) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchComplex reports whether the compounds up to i match the element at
+// depth k of m, and its ancestors.
+func matchComplex(complex []compound, i int, m *match, k int) bool {
+	c := &complex[i]
+	if !c.matches(m.elements[k], m.positions[k]) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	if c.child {
+		return k > 0 && matchComplex(complex, i-1, m, k-1)
+	}
+	for j := k - 1; j >= 0; j-- {
+		if matchComplex(complex, i-1, m, j) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Selector) matches(m *match) bool {
+	k := len(m.elements) - 1
+	for _, complex := range s.complexes {
+		if matchComplex(complex, len(complex)-1, m, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryAll returns the elements of the tree matching the selector, in
+// document order. The root itself may match.
+func (s *Selector) QueryAll(root ElementRenderer) []*Element {
+	var found []*Element
+	s.query(root, func(e *Element) bool {
+		found = append(found, e)
+		return true
+	})
+	return found
+}
+
+// Query returns the first element of the tree matching the selector, nil if
+// there is none.
+func (s *Selector) Query(root ElementRenderer) *Element {
+	var found *Element
+	s.query(root, func(e *Element) bool {
+		found = e
+		return false
+	})
+	return found
+}
+
+func (s *Selector) query(root ElementRenderer, fn func(*Element) bool) {
+	m := &match{}
+	var walk func(children []ElementRenderer) bool
+	walk = func(children []ElementRenderer) bool {
+		position := 0
+		return eachChild(children, func(child ElementRenderer) bool {
+			if doc, ok := child.(*DocumentContent); ok {
+				return walk(doc.Children)
+			}
+			el, ok := child.(interface{ baseElement() *Element })
+			if !ok {
+				return true
+			}
+			e := el.baseElement()
+			position++
+			m.elements = append(m.elements, e)
+			m.positions = append(m.positions, position)
+			ok = !s.matches(m) || fn(e)
+			ok = ok && walk(e.descendants)
+			m.elements = m.elements[:len(m.elements)-1]
+			m.positions = m.positions[:len(m.positions)-1]
+			return ok
+		})
+	}
+	walk([]ElementRenderer{root})
+}
+
+// MustQuerySelectorAll returns the elements of the tree matching the
+// selector. It panics if the selector is invalid, selectors that are not
+// constant should be parsed with ParseSelector and queried with
+// Selector.QueryAll instead.
+func MustQuerySelectorAll(root ElementRenderer, selector string) []*Element {
+	return MustParseSelector(selector).QueryAll(root)
+}
+
+// MustQuerySelector returns the first element of the tree matching the
+// selector, nil if there is none. It panics if the selector is invalid, see
+// MustQuerySelectorAll.
+func MustQuerySelector(root ElementRenderer, selector string) *Element {
+	return MustParseSelector(selector).Query(root)
+}
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <a> SVG element creates a hyperlink to other web pages, files, locations
@@ -24,18 +22,63 @@ type SVGAElement struct {
 // with the tag "a" during rendering.
 func SVGA(children ...ElementRenderer) *SVGAElement {
 	e := NewElement("a", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGAElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGAElement) Clone() *SVGAElement {
+	return &SVGAElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGAElement) CloneShallow() *SVGAElement {
+	return &SVGAElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGAElement) Freeze() *SVGAElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGAElement) writable() *SVGAElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGAElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGAElement) choiceType(name string) func(string) any {
+	switch name {
+	case "referrerpolicy":
+		return choiceValue[SVGAReferrerpolicyChoice]
+	case "rel":
+		return choiceValue[SVGARelChoice]
+	case "target":
+		return choiceValue[SVGATargetChoice]
+	}
+	return nil
+}
+
 func (e *SVGAElement) Children(children ...ElementRenderer) *SVGAElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGAElement) IfChildren(condition bool, children ...ElementRenderer) *SVGAElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +86,30 @@ func (e *SVGAElement) IfChildren(condition bool, children ...ElementRenderer) *S
 
 func (e *SVGAElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGAElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGAElement) BoolAttr(name string) *SVGAElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGAElement) BoolAttrRemove(name string) *SVGAElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGAElement) IfBoolAttr(condition bool, name string) *SVGAElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +120,40 @@ func (e *SVGAElement) BoolAttrf(format string, args ...any) *SVGAElement {
 
 func (e *SVGAElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGAElement) BoolAttrs(names ...string) *SVGAElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGAElement) IfBoolAttrs(condition bool, names ...string) *SVGAElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGAElement) Attr(name, value string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGAElement) RawAttr(name, value string) *SVGAElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGAElement) IfAttr(condition bool, name, value string) *SVGAElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +164,52 @@ func (e *SVGAElement) Attrf(name, format string, args ...any) *SVGAElement {
 
 func (e *SVGAElement) IfAttrf(condition bool, name, format string, args ...any) *SVGAElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGAElement) Attrs(attrs ...string) *SVGAElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGAElement) IfAttrs(condition bool, attrs ...string) *SVGAElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGAElement) AttrsMap(attrs map[string]string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGAElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGAElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGAElement) Text(text string) *SVGAElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGAElement) Textf(format string, args ...any) *SVGAElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGAElement) IfText(condition bool, text string) *SVGAElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +217,28 @@ func (e *SVGAElement) IfText(condition bool, text string) *SVGAElement {
 
 func (e *SVGAElement) IfTextf(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGAElement) Raw(text string) *SVGAElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGAElement) Escaped(text string) *SVGAElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGAElement) IfEscaped(condition bool, text string) *SVGAElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +250,7 @@ func (e *SVGAElement) Escapedf(format string, args ...any) *SVGAElement {
 
 func (e *SVGAElement) IfEscapedf(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -215,10 +262,8 @@ func (e *SVGAElement) IfEscapedf(condition bool, format string, args ...any) *SV
 // values, though / and \ will be converted to underscores and leading spaces in
 // filenames will be removed.
 func (e *SVGAElement) Download(s string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("download", s)
+	e = e.writable()
+	e.setStringAttribute("download", s)
 	return e
 }
 
@@ -238,7 +283,7 @@ func (e *SVGAElement) Downloadf(format string, args ...any) *SVGAElement {
 // filenames will be removed.
 func (e *SVGAElement) IfDownload(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Download(s)
+		e = e.Download(s)
 	}
 	return e
 }
@@ -250,7 +295,7 @@ func (e *SVGAElement) IfDownload(condition bool, s string) *SVGAElement {
 // filenames will be removed.
 func (e *SVGAElement) IfDownloadf(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.Download(fmt.Sprintf(format, args...))
+		e = e.Download(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -262,19 +307,15 @@ func (e *SVGAElement) IfDownloadf(condition bool, format string, args ...any) *S
 // filenames will be removed.
 // Remove the attribute Download from the element.
 func (e *SVGAElement) DownloadRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("download")
+	e = e.writable()
+	e.removeAttribute("download")
 	return e
 }
 
 // The URL of a linked resource.
 func (e *SVGAElement) Href(s string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -286,7 +327,7 @@ func (e *SVGAElement) Hreff(format string, args ...any) *SVGAElement {
 // The URL of a linked resource.
 func (e *SVGAElement) IfHref(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -294,7 +335,7 @@ func (e *SVGAElement) IfHref(condition bool, s string) *SVGAElement {
 // The URL of a linked resource.
 func (e *SVGAElement) IfHreff(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -302,19 +343,15 @@ func (e *SVGAElement) IfHreff(condition bool, format string, args ...any) *SVGAE
 // The URL of a linked resource.
 // Remove the attribute Href from the element.
 func (e *SVGAElement) HrefRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies the language of the linked resource.
 func (e *SVGAElement) Hreflang(s string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("hreflang", s)
+	e = e.writable()
+	e.setStringAttribute("hreflang", s)
 	return e
 }
 
@@ -326,7 +363,7 @@ func (e *SVGAElement) Hreflangf(format string, args ...any) *SVGAElement {
 // Specifies the language of the linked resource.
 func (e *SVGAElement) IfHreflang(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Hreflang(s)
+		e = e.Hreflang(s)
 	}
 	return e
 }
@@ -334,7 +371,7 @@ func (e *SVGAElement) IfHreflang(condition bool, s string) *SVGAElement {
 // Specifies the language of the linked resource.
 func (e *SVGAElement) IfHreflangf(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.Hreflang(fmt.Sprintf(format, args...))
+		e = e.Hreflang(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -342,26 +379,17 @@ func (e *SVGAElement) IfHreflangf(condition bool, format string, args ...any) *S
 // Specifies the language of the linked resource.
 // Remove the attribute Hreflang from the element.
 func (e *SVGAElement) HreflangRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("hreflang")
+	e = e.writable()
+	e.removeAttribute("hreflang")
 	return e
 }
 
 // A space-separated list of URLs. When the link is followed, the browser should
 // send POST requests with the body PING to the URLs. Typically for tracking.
 func (e *SVGAElement) Ping(s string) *SVGAElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("ping")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("ping", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("ping", " ").Add(values...)
 	return e
 }
 
@@ -369,7 +397,7 @@ func (e *SVGAElement) Ping(s string) *SVGAElement {
 // send POST requests with the body PING to the URLs. Typically for tracking.
 func (e *SVGAElement) IfPing(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Ping(s)
+		e = e.Ping(s)
 	}
 	return e
 }
@@ -378,23 +406,15 @@ func (e *SVGAElement) IfPing(condition bool, s string) *SVGAElement {
 // send POST requests with the body PING to the URLs. Typically for tracking.
 // Remove the values from the attribute Ping in the element.
 func (e *SVGAElement) PingRemove(s ...string) *SVGAElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("ping")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("ping", s...)
 	return e
 }
 
 // Referrer policy to use when fetching the resource.
 func (e *SVGAElement) Referrerpolicy(c SVGAReferrerpolicyChoice) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("referrerpolicy", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("referrerpolicy", string(c), choiceValue[SVGAReferrerpolicyChoice])
 	return e
 }
 
@@ -427,19 +447,15 @@ const (
 // Referrer policy to use when fetching the resource.
 // Remove the attribute Referrerpolicy from the element.
 func (e *SVGAElement) ReferrerpolicyRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("referrerpolicy")
+	e = e.writable()
+	e.removeAttribute("referrerpolicy")
 	return e
 }
 
 // Specifies the relationship of the target object to the link object.
 func (e *SVGAElement) Rel(c SVGARelChoice) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("rel", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("rel", string(c), choiceValue[SVGARelChoice])
 	return e
 }
 
@@ -510,19 +526,15 @@ const (
 // Specifies the relationship of the target object to the link object.
 // Remove the attribute Rel from the element.
 func (e *SVGAElement) RelRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("rel")
+	e = e.writable()
+	e.removeAttribute("rel")
 	return e
 }
 
 // Specifies where to display the linked resource.
 func (e *SVGAElement) Target(c SVGATargetChoice) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("target", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("target", string(c), choiceValue[SVGATargetChoice])
 	return e
 }
 
@@ -544,19 +556,15 @@ const (
 // Specifies where to display the linked resource.
 // Remove the attribute Target from the element.
 func (e *SVGAElement) TargetRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("target")
+	e = e.writable()
+	e.removeAttribute("target")
 	return e
 }
 
 // Specifies the MIME type of the linked resource.
 func (e *SVGAElement) Type(s string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -568,7 +576,7 @@ func (e *SVGAElement) Typef(format string, args ...any) *SVGAElement {
 // Specifies the MIME type of the linked resource.
 func (e *SVGAElement) IfType(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -576,7 +584,7 @@ func (e *SVGAElement) IfType(condition bool, s string) *SVGAElement {
 // Specifies the MIME type of the linked resource.
 func (e *SVGAElement) IfTypef(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -584,19 +592,15 @@ func (e *SVGAElement) IfTypef(condition bool, format string, args ...any) *SVGAE
 // Specifies the MIME type of the linked resource.
 // Remove the attribute Type from the element.
 func (e *SVGAElement) TypeRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGAElement) ID(s string) *SVGAElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -608,7 +612,7 @@ func (e *SVGAElement) IDf(format string, args ...any) *SVGAElement {
 // Specifies a unique id for an element
 func (e *SVGAElement) IfID(condition bool, s string) *SVGAElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -616,7 +620,7 @@ func (e *SVGAElement) IfID(condition bool, s string) *SVGAElement {
 // Specifies a unique id for an element
 func (e *SVGAElement) IfIDf(condition bool, format string, args ...any) *SVGAElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -624,26 +628,17 @@ func (e *SVGAElement) IfIDf(condition bool, format string, args ...any) *SVGAEle
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGAElement) IDRemove() *SVGAElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGAElement) Class(s string) *SVGAElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -651,7 +646,7 @@ func (e *SVGAElement) Class(s string) *SVGAElement {
 // sheet)
 func (e *SVGAElement) IfClass(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -660,14 +655,8 @@ func (e *SVGAElement) IfClass(condition bool, s string) *SVGAElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGAElement) ClassRemove(s ...string) *SVGAElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -676,14 +665,8 @@ func (e *SVGAElement) StylePairs(pairs ...string) *SVGAElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -697,14 +680,8 @@ func (e *SVGAElement) StylePairs(pairs ...string) *SVGAElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGAElement) Style(s string) *SVGAElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -712,7 +689,7 @@ func (e *SVGAElement) Style(s string) *SVGAElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -720,22 +697,14 @@ func (e *SVGAElement) Style(s string) *SVGAElement {
 // Specifies an inline CSS style for an element
 func (e *SVGAElement) IfStyle(condition bool, s string) *SVGAElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGAElement) StyleAdd(k string, v string) *SVGAElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -747,7 +716,7 @@ func (e *SVGAElement) StyleAddf(k string, format string, args ...any) *SVGAEleme
 // Specifies an inline CSS style for an element
 func (e *SVGAElement) IfStyleAdd(condition bool, k string, v string) *SVGAElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -755,7 +724,7 @@ func (e *SVGAElement) IfStyleAdd(condition bool, k string, v string) *SVGAElemen
 // Specifies an inline CSS style for an element
 func (e *SVGAElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGAElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -763,21 +732,15 @@ func (e *SVGAElement) IfStyleAddf(condition bool, k string, format string, args
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGAElement) StyleMap(m map[string]string) *SVGAElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -785,13 +748,7 @@ func (e *SVGAElement) StyleMap(m map[string]string) *SVGAElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGAElement) StyleRemove(keys ...string) *SVGAElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
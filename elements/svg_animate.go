@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <animate> SVG element is used to animate an Attribute or property of an
@@ -22,18 +20,69 @@ type SVGAnimateElement struct {
 // with the tag "animate" during rendering.
 func SVGAnimate(children ...ElementRenderer) *SVGAnimateElement {
 	e := NewElement("animate", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGAnimateElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGAnimateElement) Clone() *SVGAnimateElement {
+	return &SVGAnimateElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGAnimateElement) CloneShallow() *SVGAnimateElement {
+	return &SVGAnimateElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGAnimateElement) Freeze() *SVGAnimateElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGAnimateElement) writable() *SVGAnimateElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGAnimateElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGAnimateElement) choiceType(name string) func(string) any {
+	switch name {
+	case "accumulate":
+		return choiceValue[SVGAnimateAccumulateChoice]
+	case "additive":
+		return choiceValue[SVGAnimateAdditiveChoice]
+	case "AttributeType":
+		return choiceValue[SVGAnimateAttributeTypeChoice]
+	case "calcMode":
+		return choiceValue[SVGAnimateCalcModeChoice]
+	case "fill":
+		return choiceValue[SVGAnimateFillChoice]
+	case "restart":
+		return choiceValue[SVGAnimateRestartChoice]
+	}
+	return nil
+}
+
 func (e *SVGAnimateElement) Children(children ...ElementRenderer) *SVGAnimateElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGAnimateElement) IfChildren(condition bool, children ...ElementRenderer) *SVGAnimateElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +90,30 @@ func (e *SVGAnimateElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGAnimateElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGAnimateElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) BoolAttr(name string) *SVGAnimateElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGAnimateElement) BoolAttrRemove(name string) *SVGAnimateElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGAnimateElement) IfBoolAttr(condition bool, name string) *SVGAnimateElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +124,40 @@ func (e *SVGAnimateElement) BoolAttrf(format string, args ...any) *SVGAnimateEle
 
 func (e *SVGAnimateElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) BoolAttrs(names ...string) *SVGAnimateElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) IfBoolAttrs(condition bool, names ...string) *SVGAnimateElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) Attr(name, value string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGAnimateElement) RawAttr(name, value string) *SVGAnimateElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGAnimateElement) IfAttr(condition bool, name, value string) *SVGAnimateElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +168,52 @@ func (e *SVGAnimateElement) Attrf(name, format string, args ...any) *SVGAnimateE
 
 func (e *SVGAnimateElement) IfAttrf(condition bool, name, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) Attrs(attrs ...string) *SVGAnimateElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGAnimateElement) IfAttrs(condition bool, attrs ...string) *SVGAnimateElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) AttrsMap(attrs map[string]string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGAnimateElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGAnimateElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGAnimateElement) Text(text string) *SVGAnimateElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGAnimateElement) Textf(format string, args ...any) *SVGAnimateElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGAnimateElement) IfText(condition bool, text string) *SVGAnimateElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +221,28 @@ func (e *SVGAnimateElement) IfText(condition bool, text string) *SVGAnimateEleme
 
 func (e *SVGAnimateElement) IfTextf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGAnimateElement) Raw(text string) *SVGAnimateElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGAnimateElement) Escaped(text string) *SVGAnimateElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGAnimateElement) IfEscaped(condition bool, text string) *SVGAnimateElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +254,7 @@ func (e *SVGAnimateElement) Escapedf(format string, args ...any) *SVGAnimateElem
 
 func (e *SVGAnimateElement) IfEscapedf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +262,8 @@ func (e *SVGAnimateElement) IfEscapedf(condition bool, format string, args ...an
 
 // Controls whether or not the animation is cumulative.
 func (e *SVGAnimateElement) Accumulate(c SVGAnimateAccumulateChoice) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accumulate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("accumulate", string(c), choiceValue[SVGAnimateAccumulateChoice])
 	return e
 }
 
@@ -230,19 +281,15 @@ const (
 // Controls whether or not the animation is cumulative.
 // Remove the attribute Accumulate from the element.
 func (e *SVGAnimateElement) AccumulateRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accumulate")
+	e = e.writable()
+	e.removeAttribute("accumulate")
 	return e
 }
 
 // Controls whether or not the animation is additive.
 func (e *SVGAnimateElement) Additive(c SVGAnimateAdditiveChoice) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("additive", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("additive", string(c), choiceValue[SVGAnimateAdditiveChoice])
 	return e
 }
 
@@ -258,19 +305,15 @@ const (
 // Controls whether or not the animation is additive.
 // Remove the attribute Additive from the element.
 func (e *SVGAnimateElement) AdditiveRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("additive")
+	e = e.writable()
+	e.removeAttribute("additive")
 	return e
 }
 
 // The name of the Attribute to animate.
 func (e *SVGAnimateElement) AttributeName(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("AttributeName", s)
+	e = e.writable()
+	e.setStringAttribute("AttributeName", s)
 	return e
 }
 
@@ -282,7 +325,7 @@ func (e *SVGAnimateElement) AttributeNamef(format string, args ...any) *SVGAnima
 // The name of the Attribute to animate.
 func (e *SVGAnimateElement) IfAttributeName(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.AttributeName(s)
+		e = e.AttributeName(s)
 	}
 	return e
 }
@@ -290,7 +333,7 @@ func (e *SVGAnimateElement) IfAttributeName(condition bool, s string) *SVGAnimat
 // The name of the Attribute to animate.
 func (e *SVGAnimateElement) IfAttributeNamef(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.AttributeName(fmt.Sprintf(format, args...))
+		e = e.AttributeName(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -298,19 +341,15 @@ func (e *SVGAnimateElement) IfAttributeNamef(condition bool, format string, args
 // The name of the Attribute to animate.
 // Remove the attribute AttributeName from the element.
 func (e *SVGAnimateElement) AttributeNameRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("AttributeName")
+	e = e.writable()
+	e.removeAttribute("AttributeName")
 	return e
 }
 
 // The namespace of the Attribute to animate.
 func (e *SVGAnimateElement) AttributeType(c SVGAnimateAttributeTypeChoice) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("AttributeType", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("AttributeType", string(c), choiceValue[SVGAnimateAttributeTypeChoice])
 	return e
 }
 
@@ -337,19 +376,15 @@ const (
 // The namespace of the Attribute to animate.
 // Remove the attribute AttributeType from the element.
 func (e *SVGAnimateElement) AttributeTypeRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("AttributeType")
+	e = e.writable()
+	e.removeAttribute("AttributeType")
 	return e
 }
 
 // Defines when the animation should begin.
 func (e *SVGAnimateElement) Begin(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("begin", s)
+	e = e.writable()
+	e.setStringAttribute("begin", s)
 	return e
 }
 
@@ -361,7 +396,7 @@ func (e *SVGAnimateElement) Beginf(format string, args ...any) *SVGAnimateElemen
 // Defines when the animation should begin.
 func (e *SVGAnimateElement) IfBegin(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Begin(s)
+		e = e.Begin(s)
 	}
 	return e
 }
@@ -369,7 +404,7 @@ func (e *SVGAnimateElement) IfBegin(condition bool, s string) *SVGAnimateElement
 // Defines when the animation should begin.
 func (e *SVGAnimateElement) IfBeginf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.Begin(fmt.Sprintf(format, args...))
+		e = e.Begin(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -377,19 +412,15 @@ func (e *SVGAnimateElement) IfBeginf(condition bool, format string, args ...any)
 // Defines when the animation should begin.
 // Remove the attribute Begin from the element.
 func (e *SVGAnimateElement) BeginRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("begin")
+	e = e.writable()
+	e.removeAttribute("begin")
 	return e
 }
 
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateElement) By(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("by", s)
+	e = e.writable()
+	e.setStringAttribute("by", s)
 	return e
 }
 
@@ -401,7 +432,7 @@ func (e *SVGAnimateElement) Byf(format string, args ...any) *SVGAnimateElement {
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateElement) IfBy(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.By(s)
+		e = e.By(s)
 	}
 	return e
 }
@@ -409,7 +440,7 @@ func (e *SVGAnimateElement) IfBy(condition bool, s string) *SVGAnimateElement {
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateElement) IfByf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.By(fmt.Sprintf(format, args...))
+		e = e.By(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -417,19 +448,15 @@ func (e *SVGAnimateElement) IfByf(condition bool, format string, args ...any) *S
 // Defines a relative offset value for the animation.
 // Remove the attribute By from the element.
 func (e *SVGAnimateElement) ByRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("by")
+	e = e.writable()
+	e.removeAttribute("by")
 	return e
 }
 
 // Defines the pacing of the animation.
 func (e *SVGAnimateElement) CalcMode(c SVGAnimateCalcModeChoice) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("calcMode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("calcMode", string(c), choiceValue[SVGAnimateCalcModeChoice])
 	return e
 }
 
@@ -452,19 +479,15 @@ const (
 // Defines the pacing of the animation.
 // Remove the attribute CalcMode from the element.
 func (e *SVGAnimateElement) CalcModeRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("calcMode")
+	e = e.writable()
+	e.removeAttribute("calcMode")
 	return e
 }
 
 // Defines the duration of the animation.
 func (e *SVGAnimateElement) Dur(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dur", s)
+	e = e.writable()
+	e.setStringAttribute("dur", s)
 	return e
 }
 
@@ -476,7 +499,7 @@ func (e *SVGAnimateElement) Durf(format string, args ...any) *SVGAnimateElement
 // Defines the duration of the animation.
 func (e *SVGAnimateElement) IfDur(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Dur(s)
+		e = e.Dur(s)
 	}
 	return e
 }
@@ -484,7 +507,7 @@ func (e *SVGAnimateElement) IfDur(condition bool, s string) *SVGAnimateElement {
 // Defines the duration of the animation.
 func (e *SVGAnimateElement) IfDurf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.Dur(fmt.Sprintf(format, args...))
+		e = e.Dur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -492,19 +515,15 @@ func (e *SVGAnimateElement) IfDurf(condition bool, format string, args ...any) *
 // Defines the duration of the animation.
 // Remove the attribute Dur from the element.
 func (e *SVGAnimateElement) DurRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dur")
+	e = e.writable()
+	e.removeAttribute("dur")
 	return e
 }
 
 // Defines when the animation should end.
 func (e *SVGAnimateElement) End(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("end", s)
+	e = e.writable()
+	e.setStringAttribute("end", s)
 	return e
 }
 
@@ -516,7 +535,7 @@ func (e *SVGAnimateElement) Endf(format string, args ...any) *SVGAnimateElement
 // Defines when the animation should end.
 func (e *SVGAnimateElement) IfEnd(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.End(s)
+		e = e.End(s)
 	}
 	return e
 }
@@ -524,7 +543,7 @@ func (e *SVGAnimateElement) IfEnd(condition bool, s string) *SVGAnimateElement {
 // Defines when the animation should end.
 func (e *SVGAnimateElement) IfEndf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.End(fmt.Sprintf(format, args...))
+		e = e.End(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -532,19 +551,15 @@ func (e *SVGAnimateElement) IfEndf(condition bool, format string, args ...any) *
 // Defines when the animation should end.
 // Remove the attribute End from the element.
 func (e *SVGAnimateElement) EndRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("end")
+	e = e.writable()
+	e.removeAttribute("end")
 	return e
 }
 
 // Defines the fill behavior for the animation.
 func (e *SVGAnimateElement) Fill(c SVGAnimateFillChoice) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fill", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("fill", string(c), choiceValue[SVGAnimateFillChoice])
 	return e
 }
 
@@ -560,19 +575,15 @@ const (
 // Defines the fill behavior for the animation.
 // Remove the attribute Fill from the element.
 func (e *SVGAnimateElement) FillRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fill")
+	e = e.writable()
+	e.removeAttribute("fill")
 	return e
 }
 
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateElement) From(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("from", s)
+	e = e.writable()
+	e.setStringAttribute("from", s)
 	return e
 }
 
@@ -584,7 +595,7 @@ func (e *SVGAnimateElement) Fromf(format string, args ...any) *SVGAnimateElement
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateElement) IfFrom(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.From(s)
+		e = e.From(s)
 	}
 	return e
 }
@@ -592,7 +603,7 @@ func (e *SVGAnimateElement) IfFrom(condition bool, s string) *SVGAnimateElement
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateElement) IfFromf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.From(fmt.Sprintf(format, args...))
+		e = e.From(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -600,20 +611,16 @@ func (e *SVGAnimateElement) IfFromf(condition bool, format string, args ...any)
 // Defines the initial value of the Attribute.
 // Remove the attribute From from the element.
 func (e *SVGAnimateElement) FromRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("from")
+	e = e.writable()
+	e.removeAttribute("from")
 	return e
 }
 
 // Defines the values for a cubic Bézier function that controls interval
 // pacing.
 func (e *SVGAnimateElement) KeySplines(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("keySplines", s)
+	e = e.writable()
+	e.setStringAttribute("keySplines", s)
 	return e
 }
 
@@ -627,7 +634,7 @@ func (e *SVGAnimateElement) KeySplinesf(format string, args ...any) *SVGAnimateE
 // pacing.
 func (e *SVGAnimateElement) IfKeySplines(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.KeySplines(s)
+		e = e.KeySplines(s)
 	}
 	return e
 }
@@ -636,7 +643,7 @@ func (e *SVGAnimateElement) IfKeySplines(condition bool, s string) *SVGAnimateEl
 // pacing.
 func (e *SVGAnimateElement) IfKeySplinesf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.KeySplines(fmt.Sprintf(format, args...))
+		e = e.KeySplines(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -645,19 +652,15 @@ func (e *SVGAnimateElement) IfKeySplinesf(condition bool, format string, args ..
 // pacing.
 // Remove the attribute KeySplines from the element.
 func (e *SVGAnimateElement) KeySplinesRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("keySplines")
+	e = e.writable()
+	e.removeAttribute("keySplines")
 	return e
 }
 
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateElement) KeyTimes(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("keyTimes", s)
+	e = e.writable()
+	e.setStringAttribute("keyTimes", s)
 	return e
 }
 
@@ -669,7 +672,7 @@ func (e *SVGAnimateElement) KeyTimesf(format string, args ...any) *SVGAnimateEle
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateElement) IfKeyTimes(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.KeyTimes(s)
+		e = e.KeyTimes(s)
 	}
 	return e
 }
@@ -677,7 +680,7 @@ func (e *SVGAnimateElement) IfKeyTimes(condition bool, s string) *SVGAnimateElem
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateElement) IfKeyTimesf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.KeyTimes(fmt.Sprintf(format, args...))
+		e = e.KeyTimes(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -685,19 +688,15 @@ func (e *SVGAnimateElement) IfKeyTimesf(condition bool, format string, args ...a
 // Defines when the animation should take place in terms of time fractions.
 // Remove the attribute KeyTimes from the element.
 func (e *SVGAnimateElement) KeyTimesRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("keyTimes")
+	e = e.writable()
+	e.removeAttribute("keyTimes")
 	return e
 }
 
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateElement) Max(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("max", s)
+	e = e.writable()
+	e.setStringAttribute("max", s)
 	return e
 }
 
@@ -709,7 +708,7 @@ func (e *SVGAnimateElement) Maxf(format string, args ...any) *SVGAnimateElement
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateElement) IfMax(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Max(s)
+		e = e.Max(s)
 	}
 	return e
 }
@@ -717,7 +716,7 @@ func (e *SVGAnimateElement) IfMax(condition bool, s string) *SVGAnimateElement {
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateElement) IfMaxf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.Max(fmt.Sprintf(format, args...))
+		e = e.Max(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -725,19 +724,15 @@ func (e *SVGAnimateElement) IfMaxf(condition bool, format string, args ...any) *
 // Defines the maximum value allowed for the Attribute.
 // Remove the attribute Max from the element.
 func (e *SVGAnimateElement) MaxRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("max")
+	e = e.writable()
+	e.removeAttribute("max")
 	return e
 }
 
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateElement) Min(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("min", s)
+	e = e.writable()
+	e.setStringAttribute("min", s)
 	return e
 }
 
@@ -749,7 +744,7 @@ func (e *SVGAnimateElement) Minf(format string, args ...any) *SVGAnimateElement
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateElement) IfMin(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Min(s)
+		e = e.Min(s)
 	}
 	return e
 }
@@ -757,7 +752,7 @@ func (e *SVGAnimateElement) IfMin(condition bool, s string) *SVGAnimateElement {
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateElement) IfMinf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.Min(fmt.Sprintf(format, args...))
+		e = e.Min(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -765,19 +760,15 @@ func (e *SVGAnimateElement) IfMinf(condition bool, format string, args ...any) *
 // Defines the minimum value allowed for the Attribute.
 // Remove the attribute Min from the element.
 func (e *SVGAnimateElement) MinRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("min")
+	e = e.writable()
+	e.removeAttribute("min")
 	return e
 }
 
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateElement) RepeatCount(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatCount", s)
+	e = e.writable()
+	e.setStringAttribute("repeatCount", s)
 	return e
 }
 
@@ -789,7 +780,7 @@ func (e *SVGAnimateElement) RepeatCountf(format string, args ...any) *SVGAnimate
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateElement) IfRepeatCount(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.RepeatCount(s)
+		e = e.RepeatCount(s)
 	}
 	return e
 }
@@ -797,7 +788,7 @@ func (e *SVGAnimateElement) IfRepeatCount(condition bool, s string) *SVGAnimateE
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateElement) IfRepeatCountf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.RepeatCount(fmt.Sprintf(format, args...))
+		e = e.RepeatCount(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -805,19 +796,15 @@ func (e *SVGAnimateElement) IfRepeatCountf(condition bool, format string, args .
 // Defines the number of times the animation should repeat.
 // Remove the attribute RepeatCount from the element.
 func (e *SVGAnimateElement) RepeatCountRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatCount")
+	e = e.writable()
+	e.removeAttribute("repeatCount")
 	return e
 }
 
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateElement) RepeatDur(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatDur", s)
+	e = e.writable()
+	e.setStringAttribute("repeatDur", s)
 	return e
 }
 
@@ -829,7 +816,7 @@ func (e *SVGAnimateElement) RepeatDurf(format string, args ...any) *SVGAnimateEl
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateElement) IfRepeatDur(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.RepeatDur(s)
+		e = e.RepeatDur(s)
 	}
 	return e
 }
@@ -837,7 +824,7 @@ func (e *SVGAnimateElement) IfRepeatDur(condition bool, s string) *SVGAnimateEle
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateElement) IfRepeatDurf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.RepeatDur(fmt.Sprintf(format, args...))
+		e = e.RepeatDur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -845,19 +832,15 @@ func (e *SVGAnimateElement) IfRepeatDurf(condition bool, format string, args ...
 // Defines the duration for repeating an animation.
 // Remove the attribute RepeatDur from the element.
 func (e *SVGAnimateElement) RepeatDurRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatDur")
+	e = e.writable()
+	e.removeAttribute("repeatDur")
 	return e
 }
 
 // Defines if an animation should restart after it completes.
 func (e *SVGAnimateElement) Restart(c SVGAnimateRestartChoice) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("restart", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("restart", string(c), choiceValue[SVGAnimateRestartChoice])
 	return e
 }
 
@@ -876,19 +859,15 @@ const (
 // Defines if an animation should restart after it completes.
 // Remove the attribute Restart from the element.
 func (e *SVGAnimateElement) RestartRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("restart")
+	e = e.writable()
+	e.removeAttribute("restart")
 	return e
 }
 
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateElement) To(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("to", s)
+	e = e.writable()
+	e.setStringAttribute("to", s)
 	return e
 }
 
@@ -900,7 +879,7 @@ func (e *SVGAnimateElement) Tof(format string, args ...any) *SVGAnimateElement {
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateElement) IfTo(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.To(s)
+		e = e.To(s)
 	}
 	return e
 }
@@ -908,7 +887,7 @@ func (e *SVGAnimateElement) IfTo(condition bool, s string) *SVGAnimateElement {
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateElement) IfTof(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.To(fmt.Sprintf(format, args...))
+		e = e.To(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -916,19 +895,15 @@ func (e *SVGAnimateElement) IfTof(condition bool, format string, args ...any) *S
 // Defines the ending value of the Attribute.
 // Remove the attribute To from the element.
 func (e *SVGAnimateElement) ToRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("to")
+	e = e.writable()
+	e.removeAttribute("to")
 	return e
 }
 
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateElement) Values(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("values", s)
+	e = e.writable()
+	e.setStringAttribute("values", s)
 	return e
 }
 
@@ -940,7 +915,7 @@ func (e *SVGAnimateElement) Valuesf(format string, args ...any) *SVGAnimateEleme
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateElement) IfValues(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Values(s)
+		e = e.Values(s)
 	}
 	return e
 }
@@ -948,7 +923,7 @@ func (e *SVGAnimateElement) IfValues(condition bool, s string) *SVGAnimateElemen
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateElement) IfValuesf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.Values(fmt.Sprintf(format, args...))
+		e = e.Values(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -956,19 +931,15 @@ func (e *SVGAnimateElement) IfValuesf(condition bool, format string, args ...any
 // Defines a list of discrete values to interpolate.
 // Remove the attribute Values from the element.
 func (e *SVGAnimateElement) ValuesRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("values")
+	e = e.writable()
+	e.removeAttribute("values")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGAnimateElement) ID(s string) *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -980,7 +951,7 @@ func (e *SVGAnimateElement) IDf(format string, args ...any) *SVGAnimateElement {
 // Specifies a unique id for an element
 func (e *SVGAnimateElement) IfID(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -988,7 +959,7 @@ func (e *SVGAnimateElement) IfID(condition bool, s string) *SVGAnimateElement {
 // Specifies a unique id for an element
 func (e *SVGAnimateElement) IfIDf(condition bool, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -996,26 +967,17 @@ func (e *SVGAnimateElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGAnimateElement) IDRemove() *SVGAnimateElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGAnimateElement) Class(s string) *SVGAnimateElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -1023,7 +985,7 @@ func (e *SVGAnimateElement) Class(s string) *SVGAnimateElement {
 // sheet)
 func (e *SVGAnimateElement) IfClass(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -1032,14 +994,8 @@ func (e *SVGAnimateElement) IfClass(condition bool, s string) *SVGAnimateElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGAnimateElement) ClassRemove(s ...string) *SVGAnimateElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -1048,14 +1004,8 @@ func (e *SVGAnimateElement) StylePairs(pairs ...string) *SVGAnimateElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1069,14 +1019,8 @@ func (e *SVGAnimateElement) StylePairs(pairs ...string) *SVGAnimateElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateElement) Style(s string) *SVGAnimateElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1084,7 +1028,7 @@ func (e *SVGAnimateElement) Style(s string) *SVGAnimateElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1092,22 +1036,14 @@ func (e *SVGAnimateElement) Style(s string) *SVGAnimateElement {
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateElement) IfStyle(condition bool, s string) *SVGAnimateElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateElement) StyleAdd(k string, v string) *SVGAnimateElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1119,7 +1055,7 @@ func (e *SVGAnimateElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateElement) IfStyleAdd(condition bool, k string, v string) *SVGAnimateElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1127,7 +1063,7 @@ func (e *SVGAnimateElement) IfStyleAdd(condition bool, k string, v string) *SVGA
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGAnimateElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1135,21 +1071,15 @@ func (e *SVGAnimateElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGAnimateElement) StyleMap(m map[string]string) *SVGAnimateElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1157,13 +1087,7 @@ func (e *SVGAnimateElement) StyleMap(m map[string]string) *SVGAnimateElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGAnimateElement) StyleRemove(keys ...string) *SVGAnimateElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <animateMotion> SVG element is used to animate a transformation Attribute
@@ -23,18 +21,67 @@ type SVGAnimateMotionElement struct {
 // with the tag "animateMotion" during rendering.
 func SVGAnimateMotion(children ...ElementRenderer) *SVGAnimateMotionElement {
 	e := NewElement("animateMotion", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGAnimateMotionElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGAnimateMotionElement) Clone() *SVGAnimateMotionElement {
+	return &SVGAnimateMotionElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGAnimateMotionElement) CloneShallow() *SVGAnimateMotionElement {
+	return &SVGAnimateMotionElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGAnimateMotionElement) Freeze() *SVGAnimateMotionElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGAnimateMotionElement) writable() *SVGAnimateMotionElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGAnimateMotionElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGAnimateMotionElement) choiceType(name string) func(string) any {
+	switch name {
+	case "accumulate":
+		return choiceValue[SVGAnimateMotionAccumulateChoice]
+	case "additive":
+		return choiceValue[SVGAnimateMotionAdditiveChoice]
+	case "calcMode":
+		return choiceValue[SVGAnimateMotionCalcModeChoice]
+	case "fill":
+		return choiceValue[SVGAnimateMotionFillChoice]
+	case "restart":
+		return choiceValue[SVGAnimateMotionRestartChoice]
+	}
+	return nil
+}
+
 func (e *SVGAnimateMotionElement) Children(children ...ElementRenderer) *SVGAnimateMotionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfChildren(condition bool, children ...ElementRenderer) *SVGAnimateMotionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +89,30 @@ func (e *SVGAnimateMotionElement) IfChildren(condition bool, children ...Element
 
 func (e *SVGAnimateMotionElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGAnimateMotionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) BoolAttr(name string) *SVGAnimateMotionElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGAnimateMotionElement) BoolAttrRemove(name string) *SVGAnimateMotionElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfBoolAttr(condition bool, name string) *SVGAnimateMotionElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +123,40 @@ func (e *SVGAnimateMotionElement) BoolAttrf(format string, args ...any) *SVGAnim
 
 func (e *SVGAnimateMotionElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) BoolAttrs(names ...string) *SVGAnimateMotionElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfBoolAttrs(condition bool, names ...string) *SVGAnimateMotionElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) Attr(name, value string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGAnimateMotionElement) RawAttr(name, value string) *SVGAnimateMotionElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfAttr(condition bool, name, value string) *SVGAnimateMotionElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +167,52 @@ func (e *SVGAnimateMotionElement) Attrf(name, format string, args ...any) *SVGAn
 
 func (e *SVGAnimateMotionElement) IfAttrf(condition bool, name, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) Attrs(attrs ...string) *SVGAnimateMotionElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfAttrs(condition bool, attrs ...string) *SVGAnimateMotionElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) AttrsMap(attrs map[string]string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGAnimateMotionElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGAnimateMotionElement) Text(text string) *SVGAnimateMotionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGAnimateMotionElement) Textf(format string, args ...any) *SVGAnimateMotionElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGAnimateMotionElement) IfText(condition bool, text string) *SVGAnimateMotionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +220,28 @@ func (e *SVGAnimateMotionElement) IfText(condition bool, text string) *SVGAnimat
 
 func (e *SVGAnimateMotionElement) IfTextf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGAnimateMotionElement) Raw(text string) *SVGAnimateMotionElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGAnimateMotionElement) Escaped(text string) *SVGAnimateMotionElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGAnimateMotionElement) IfEscaped(condition bool, text string) *SVGAnimateMotionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +253,7 @@ func (e *SVGAnimateMotionElement) Escapedf(format string, args ...any) *SVGAnima
 
 func (e *SVGAnimateMotionElement) IfEscapedf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +261,8 @@ func (e *SVGAnimateMotionElement) IfEscapedf(condition bool, format string, args
 
 // Controls whether or not the animation is cumulative.
 func (e *SVGAnimateMotionElement) Accumulate(c SVGAnimateMotionAccumulateChoice) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accumulate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("accumulate", string(c), choiceValue[SVGAnimateMotionAccumulateChoice])
 	return e
 }
 
@@ -231,19 +280,15 @@ const (
 // Controls whether or not the animation is cumulative.
 // Remove the attribute Accumulate from the element.
 func (e *SVGAnimateMotionElement) AccumulateRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accumulate")
+	e = e.writable()
+	e.removeAttribute("accumulate")
 	return e
 }
 
 // Controls whether or not the animation is additive.
 func (e *SVGAnimateMotionElement) Additive(c SVGAnimateMotionAdditiveChoice) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("additive", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("additive", string(c), choiceValue[SVGAnimateMotionAdditiveChoice])
 	return e
 }
 
@@ -259,19 +304,15 @@ const (
 // Controls whether or not the animation is additive.
 // Remove the attribute Additive from the element.
 func (e *SVGAnimateMotionElement) AdditiveRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("additive")
+	e = e.writable()
+	e.removeAttribute("additive")
 	return e
 }
 
 // Defines when the animation should begin.
 func (e *SVGAnimateMotionElement) Begin(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("begin", s)
+	e = e.writable()
+	e.setStringAttribute("begin", s)
 	return e
 }
 
@@ -283,7 +324,7 @@ func (e *SVGAnimateMotionElement) Beginf(format string, args ...any) *SVGAnimate
 // Defines when the animation should begin.
 func (e *SVGAnimateMotionElement) IfBegin(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Begin(s)
+		e = e.Begin(s)
 	}
 	return e
 }
@@ -291,7 +332,7 @@ func (e *SVGAnimateMotionElement) IfBegin(condition bool, s string) *SVGAnimateM
 // Defines when the animation should begin.
 func (e *SVGAnimateMotionElement) IfBeginf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.Begin(fmt.Sprintf(format, args...))
+		e = e.Begin(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -299,19 +340,15 @@ func (e *SVGAnimateMotionElement) IfBeginf(condition bool, format string, args .
 // Defines when the animation should begin.
 // Remove the attribute Begin from the element.
 func (e *SVGAnimateMotionElement) BeginRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("begin")
+	e = e.writable()
+	e.removeAttribute("begin")
 	return e
 }
 
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateMotionElement) By(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("by", s)
+	e = e.writable()
+	e.setStringAttribute("by", s)
 	return e
 }
 
@@ -323,7 +360,7 @@ func (e *SVGAnimateMotionElement) Byf(format string, args ...any) *SVGAnimateMot
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateMotionElement) IfBy(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.By(s)
+		e = e.By(s)
 	}
 	return e
 }
@@ -331,7 +368,7 @@ func (e *SVGAnimateMotionElement) IfBy(condition bool, s string) *SVGAnimateMoti
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateMotionElement) IfByf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.By(fmt.Sprintf(format, args...))
+		e = e.By(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -339,19 +376,15 @@ func (e *SVGAnimateMotionElement) IfByf(condition bool, format string, args ...a
 // Defines a relative offset value for the animation.
 // Remove the attribute By from the element.
 func (e *SVGAnimateMotionElement) ByRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("by")
+	e = e.writable()
+	e.removeAttribute("by")
 	return e
 }
 
 // Defines the pacing of the animation.
 func (e *SVGAnimateMotionElement) CalcMode(c SVGAnimateMotionCalcModeChoice) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("calcMode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("calcMode", string(c), choiceValue[SVGAnimateMotionCalcModeChoice])
 	return e
 }
 
@@ -374,19 +407,15 @@ const (
 // Defines the pacing of the animation.
 // Remove the attribute CalcMode from the element.
 func (e *SVGAnimateMotionElement) CalcModeRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("calcMode")
+	e = e.writable()
+	e.removeAttribute("calcMode")
 	return e
 }
 
 // Defines the duration of the animation.
 func (e *SVGAnimateMotionElement) Dur(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dur", s)
+	e = e.writable()
+	e.setStringAttribute("dur", s)
 	return e
 }
 
@@ -398,7 +427,7 @@ func (e *SVGAnimateMotionElement) Durf(format string, args ...any) *SVGAnimateMo
 // Defines the duration of the animation.
 func (e *SVGAnimateMotionElement) IfDur(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Dur(s)
+		e = e.Dur(s)
 	}
 	return e
 }
@@ -406,7 +435,7 @@ func (e *SVGAnimateMotionElement) IfDur(condition bool, s string) *SVGAnimateMot
 // Defines the duration of the animation.
 func (e *SVGAnimateMotionElement) IfDurf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.Dur(fmt.Sprintf(format, args...))
+		e = e.Dur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -414,19 +443,15 @@ func (e *SVGAnimateMotionElement) IfDurf(condition bool, format string, args ...
 // Defines the duration of the animation.
 // Remove the attribute Dur from the element.
 func (e *SVGAnimateMotionElement) DurRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dur")
+	e = e.writable()
+	e.removeAttribute("dur")
 	return e
 }
 
 // Defines when the animation should end.
 func (e *SVGAnimateMotionElement) End(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("end", s)
+	e = e.writable()
+	e.setStringAttribute("end", s)
 	return e
 }
 
@@ -438,7 +463,7 @@ func (e *SVGAnimateMotionElement) Endf(format string, args ...any) *SVGAnimateMo
 // Defines when the animation should end.
 func (e *SVGAnimateMotionElement) IfEnd(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.End(s)
+		e = e.End(s)
 	}
 	return e
 }
@@ -446,7 +471,7 @@ func (e *SVGAnimateMotionElement) IfEnd(condition bool, s string) *SVGAnimateMot
 // Defines when the animation should end.
 func (e *SVGAnimateMotionElement) IfEndf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.End(fmt.Sprintf(format, args...))
+		e = e.End(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -454,19 +479,15 @@ func (e *SVGAnimateMotionElement) IfEndf(condition bool, format string, args ...
 // Defines when the animation should end.
 // Remove the attribute End from the element.
 func (e *SVGAnimateMotionElement) EndRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("end")
+	e = e.writable()
+	e.removeAttribute("end")
 	return e
 }
 
 // Defines the fill behavior for the animation.
 func (e *SVGAnimateMotionElement) Fill(c SVGAnimateMotionFillChoice) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fill", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("fill", string(c), choiceValue[SVGAnimateMotionFillChoice])
 	return e
 }
 
@@ -482,19 +503,15 @@ const (
 // Defines the fill behavior for the animation.
 // Remove the attribute Fill from the element.
 func (e *SVGAnimateMotionElement) FillRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fill")
+	e = e.writable()
+	e.removeAttribute("fill")
 	return e
 }
 
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateMotionElement) From(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("from", s)
+	e = e.writable()
+	e.setStringAttribute("from", s)
 	return e
 }
 
@@ -506,7 +523,7 @@ func (e *SVGAnimateMotionElement) Fromf(format string, args ...any) *SVGAnimateM
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateMotionElement) IfFrom(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.From(s)
+		e = e.From(s)
 	}
 	return e
 }
@@ -514,7 +531,7 @@ func (e *SVGAnimateMotionElement) IfFrom(condition bool, s string) *SVGAnimateMo
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateMotionElement) IfFromf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.From(fmt.Sprintf(format, args...))
+		e = e.From(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -522,20 +539,16 @@ func (e *SVGAnimateMotionElement) IfFromf(condition bool, format string, args ..
 // Defines the initial value of the Attribute.
 // Remove the attribute From from the element.
 func (e *SVGAnimateMotionElement) FromRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("from")
+	e = e.writable()
+	e.removeAttribute("from")
 	return e
 }
 
 // Defines the values for a cubic Bézier function that controls interval
 // pacing.
 func (e *SVGAnimateMotionElement) KeySplines(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("keySplines", s)
+	e = e.writable()
+	e.setStringAttribute("keySplines", s)
 	return e
 }
 
@@ -549,7 +562,7 @@ func (e *SVGAnimateMotionElement) KeySplinesf(format string, args ...any) *SVGAn
 // pacing.
 func (e *SVGAnimateMotionElement) IfKeySplines(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.KeySplines(s)
+		e = e.KeySplines(s)
 	}
 	return e
 }
@@ -558,7 +571,7 @@ func (e *SVGAnimateMotionElement) IfKeySplines(condition bool, s string) *SVGAni
 // pacing.
 func (e *SVGAnimateMotionElement) IfKeySplinesf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.KeySplines(fmt.Sprintf(format, args...))
+		e = e.KeySplines(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -567,19 +580,15 @@ func (e *SVGAnimateMotionElement) IfKeySplinesf(condition bool, format string, a
 // pacing.
 // Remove the attribute KeySplines from the element.
 func (e *SVGAnimateMotionElement) KeySplinesRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("keySplines")
+	e = e.writable()
+	e.removeAttribute("keySplines")
 	return e
 }
 
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateMotionElement) KeyTimes(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("keyTimes", s)
+	e = e.writable()
+	e.setStringAttribute("keyTimes", s)
 	return e
 }
 
@@ -591,7 +600,7 @@ func (e *SVGAnimateMotionElement) KeyTimesf(format string, args ...any) *SVGAnim
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateMotionElement) IfKeyTimes(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.KeyTimes(s)
+		e = e.KeyTimes(s)
 	}
 	return e
 }
@@ -599,7 +608,7 @@ func (e *SVGAnimateMotionElement) IfKeyTimes(condition bool, s string) *SVGAnima
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateMotionElement) IfKeyTimesf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.KeyTimes(fmt.Sprintf(format, args...))
+		e = e.KeyTimes(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -607,19 +616,15 @@ func (e *SVGAnimateMotionElement) IfKeyTimesf(condition bool, format string, arg
 // Defines when the animation should take place in terms of time fractions.
 // Remove the attribute KeyTimes from the element.
 func (e *SVGAnimateMotionElement) KeyTimesRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("keyTimes")
+	e = e.writable()
+	e.removeAttribute("keyTimes")
 	return e
 }
 
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateMotionElement) Max(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("max", s)
+	e = e.writable()
+	e.setStringAttribute("max", s)
 	return e
 }
 
@@ -631,7 +636,7 @@ func (e *SVGAnimateMotionElement) Maxf(format string, args ...any) *SVGAnimateMo
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateMotionElement) IfMax(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Max(s)
+		e = e.Max(s)
 	}
 	return e
 }
@@ -639,7 +644,7 @@ func (e *SVGAnimateMotionElement) IfMax(condition bool, s string) *SVGAnimateMot
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateMotionElement) IfMaxf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.Max(fmt.Sprintf(format, args...))
+		e = e.Max(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -647,19 +652,15 @@ func (e *SVGAnimateMotionElement) IfMaxf(condition bool, format string, args ...
 // Defines the maximum value allowed for the Attribute.
 // Remove the attribute Max from the element.
 func (e *SVGAnimateMotionElement) MaxRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("max")
+	e = e.writable()
+	e.removeAttribute("max")
 	return e
 }
 
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateMotionElement) Min(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("min", s)
+	e = e.writable()
+	e.setStringAttribute("min", s)
 	return e
 }
 
@@ -671,7 +672,7 @@ func (e *SVGAnimateMotionElement) Minf(format string, args ...any) *SVGAnimateMo
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateMotionElement) IfMin(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Min(s)
+		e = e.Min(s)
 	}
 	return e
 }
@@ -679,7 +680,7 @@ func (e *SVGAnimateMotionElement) IfMin(condition bool, s string) *SVGAnimateMot
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateMotionElement) IfMinf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.Min(fmt.Sprintf(format, args...))
+		e = e.Min(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -687,19 +688,15 @@ func (e *SVGAnimateMotionElement) IfMinf(condition bool, format string, args ...
 // Defines the minimum value allowed for the Attribute.
 // Remove the attribute Min from the element.
 func (e *SVGAnimateMotionElement) MinRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("min")
+	e = e.writable()
+	e.removeAttribute("min")
 	return e
 }
 
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateMotionElement) RepeatCount(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatCount", s)
+	e = e.writable()
+	e.setStringAttribute("repeatCount", s)
 	return e
 }
 
@@ -711,7 +708,7 @@ func (e *SVGAnimateMotionElement) RepeatCountf(format string, args ...any) *SVGA
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateMotionElement) IfRepeatCount(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.RepeatCount(s)
+		e = e.RepeatCount(s)
 	}
 	return e
 }
@@ -719,7 +716,7 @@ func (e *SVGAnimateMotionElement) IfRepeatCount(condition bool, s string) *SVGAn
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateMotionElement) IfRepeatCountf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.RepeatCount(fmt.Sprintf(format, args...))
+		e = e.RepeatCount(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -727,19 +724,15 @@ func (e *SVGAnimateMotionElement) IfRepeatCountf(condition bool, format string,
 // Defines the number of times the animation should repeat.
 // Remove the attribute RepeatCount from the element.
 func (e *SVGAnimateMotionElement) RepeatCountRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatCount")
+	e = e.writable()
+	e.removeAttribute("repeatCount")
 	return e
 }
 
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateMotionElement) RepeatDur(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatDur", s)
+	e = e.writable()
+	e.setStringAttribute("repeatDur", s)
 	return e
 }
 
@@ -751,7 +744,7 @@ func (e *SVGAnimateMotionElement) RepeatDurf(format string, args ...any) *SVGAni
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateMotionElement) IfRepeatDur(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.RepeatDur(s)
+		e = e.RepeatDur(s)
 	}
 	return e
 }
@@ -759,7 +752,7 @@ func (e *SVGAnimateMotionElement) IfRepeatDur(condition bool, s string) *SVGAnim
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateMotionElement) IfRepeatDurf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.RepeatDur(fmt.Sprintf(format, args...))
+		e = e.RepeatDur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -767,19 +760,15 @@ func (e *SVGAnimateMotionElement) IfRepeatDurf(condition bool, format string, ar
 // Defines the duration for repeating an animation.
 // Remove the attribute RepeatDur from the element.
 func (e *SVGAnimateMotionElement) RepeatDurRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatDur")
+	e = e.writable()
+	e.removeAttribute("repeatDur")
 	return e
 }
 
 // Defines if an animation should restart after it completes.
 func (e *SVGAnimateMotionElement) Restart(c SVGAnimateMotionRestartChoice) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("restart", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("restart", string(c), choiceValue[SVGAnimateMotionRestartChoice])
 	return e
 }
 
@@ -798,19 +787,15 @@ const (
 // Defines if an animation should restart after it completes.
 // Remove the attribute Restart from the element.
 func (e *SVGAnimateMotionElement) RestartRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("restart")
+	e = e.writable()
+	e.removeAttribute("restart")
 	return e
 }
 
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateMotionElement) To(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("to", s)
+	e = e.writable()
+	e.setStringAttribute("to", s)
 	return e
 }
 
@@ -822,7 +807,7 @@ func (e *SVGAnimateMotionElement) Tof(format string, args ...any) *SVGAnimateMot
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateMotionElement) IfTo(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.To(s)
+		e = e.To(s)
 	}
 	return e
 }
@@ -830,7 +815,7 @@ func (e *SVGAnimateMotionElement) IfTo(condition bool, s string) *SVGAnimateMoti
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateMotionElement) IfTof(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.To(fmt.Sprintf(format, args...))
+		e = e.To(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -838,19 +823,15 @@ func (e *SVGAnimateMotionElement) IfTof(condition bool, format string, args ...a
 // Defines the ending value of the Attribute.
 // Remove the attribute To from the element.
 func (e *SVGAnimateMotionElement) ToRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("to")
+	e = e.writable()
+	e.removeAttribute("to")
 	return e
 }
 
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateMotionElement) Values(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("values", s)
+	e = e.writable()
+	e.setStringAttribute("values", s)
 	return e
 }
 
@@ -862,7 +843,7 @@ func (e *SVGAnimateMotionElement) Valuesf(format string, args ...any) *SVGAnimat
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateMotionElement) IfValues(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Values(s)
+		e = e.Values(s)
 	}
 	return e
 }
@@ -870,7 +851,7 @@ func (e *SVGAnimateMotionElement) IfValues(condition bool, s string) *SVGAnimate
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateMotionElement) IfValuesf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.Values(fmt.Sprintf(format, args...))
+		e = e.Values(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -878,19 +859,15 @@ func (e *SVGAnimateMotionElement) IfValuesf(condition bool, format string, args
 // Defines a list of discrete values to interpolate.
 // Remove the attribute Values from the element.
 func (e *SVGAnimateMotionElement) ValuesRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("values")
+	e = e.writable()
+	e.removeAttribute("values")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGAnimateMotionElement) ID(s string) *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -902,7 +879,7 @@ func (e *SVGAnimateMotionElement) IDf(format string, args ...any) *SVGAnimateMot
 // Specifies a unique id for an element
 func (e *SVGAnimateMotionElement) IfID(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -910,7 +887,7 @@ func (e *SVGAnimateMotionElement) IfID(condition bool, s string) *SVGAnimateMoti
 // Specifies a unique id for an element
 func (e *SVGAnimateMotionElement) IfIDf(condition bool, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -918,26 +895,17 @@ func (e *SVGAnimateMotionElement) IfIDf(condition bool, format string, args ...a
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGAnimateMotionElement) IDRemove() *SVGAnimateMotionElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGAnimateMotionElement) Class(s string) *SVGAnimateMotionElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -945,7 +913,7 @@ func (e *SVGAnimateMotionElement) Class(s string) *SVGAnimateMotionElement {
 // sheet)
 func (e *SVGAnimateMotionElement) IfClass(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -954,14 +922,8 @@ func (e *SVGAnimateMotionElement) IfClass(condition bool, s string) *SVGAnimateM
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGAnimateMotionElement) ClassRemove(s ...string) *SVGAnimateMotionElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -970,14 +932,8 @@ func (e *SVGAnimateMotionElement) StylePairs(pairs ...string) *SVGAnimateMotionE
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -991,14 +947,8 @@ func (e *SVGAnimateMotionElement) StylePairs(pairs ...string) *SVGAnimateMotionE
 
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateMotionElement) Style(s string) *SVGAnimateMotionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1006,7 +956,7 @@ func (e *SVGAnimateMotionElement) Style(s string) *SVGAnimateMotionElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1014,22 +964,14 @@ func (e *SVGAnimateMotionElement) Style(s string) *SVGAnimateMotionElement {
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateMotionElement) IfStyle(condition bool, s string) *SVGAnimateMotionElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateMotionElement) StyleAdd(k string, v string) *SVGAnimateMotionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1041,7 +983,7 @@ func (e *SVGAnimateMotionElement) StyleAddf(k string, format string, args ...any
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateMotionElement) IfStyleAdd(condition bool, k string, v string) *SVGAnimateMotionElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1049,7 +991,7 @@ func (e *SVGAnimateMotionElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateMotionElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGAnimateMotionElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1057,21 +999,15 @@ func (e *SVGAnimateMotionElement) IfStyleAddf(condition bool, k string, format s
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGAnimateMotionElement) StyleMap(m map[string]string) *SVGAnimateMotionElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1079,13 +1015,7 @@ func (e *SVGAnimateMotionElement) StyleMap(m map[string]string) *SVGAnimateMotio
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGAnimateMotionElement) StyleRemove(keys ...string) *SVGAnimateMotionElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <animateTransform> SVG element animates a transformation Attribute on a
@@ -23,18 +21,71 @@ type SVGAnimateTransformElement struct {
 // with the tag "animateTransform" during rendering.
 func SVGAnimateTransform(children ...ElementRenderer) *SVGAnimateTransformElement {
 	e := NewElement("animateTransform", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGAnimateTransformElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGAnimateTransformElement) Clone() *SVGAnimateTransformElement {
+	return &SVGAnimateTransformElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGAnimateTransformElement) CloneShallow() *SVGAnimateTransformElement {
+	return &SVGAnimateTransformElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGAnimateTransformElement) Freeze() *SVGAnimateTransformElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGAnimateTransformElement) writable() *SVGAnimateTransformElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGAnimateTransformElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGAnimateTransformElement) choiceType(name string) func(string) any {
+	switch name {
+	case "accumulate":
+		return choiceValue[SVGAnimateTransformAccumulateChoice]
+	case "additive":
+		return choiceValue[SVGAnimateTransformAdditiveChoice]
+	case "AttributeType":
+		return choiceValue[SVGAnimateTransformAttributeTypeChoice]
+	case "calcMode":
+		return choiceValue[SVGAnimateTransformCalcModeChoice]
+	case "fill":
+		return choiceValue[SVGAnimateTransformFillChoice]
+	case "restart":
+		return choiceValue[SVGAnimateTransformRestartChoice]
+	case "type":
+		return choiceValue[SVGAnimateTransformTypeChoice]
+	}
+	return nil
+}
+
 func (e *SVGAnimateTransformElement) Children(children ...ElementRenderer) *SVGAnimateTransformElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfChildren(condition bool, children ...ElementRenderer) *SVGAnimateTransformElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +93,30 @@ func (e *SVGAnimateTransformElement) IfChildren(condition bool, children ...Elem
 
 func (e *SVGAnimateTransformElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGAnimateTransformElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) BoolAttr(name string) *SVGAnimateTransformElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGAnimateTransformElement) BoolAttrRemove(name string) *SVGAnimateTransformElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfBoolAttr(condition bool, name string) *SVGAnimateTransformElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +127,40 @@ func (e *SVGAnimateTransformElement) BoolAttrf(format string, args ...any) *SVGA
 
 func (e *SVGAnimateTransformElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) BoolAttrs(names ...string) *SVGAnimateTransformElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfBoolAttrs(condition bool, names ...string) *SVGAnimateTransformElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) Attr(name, value string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGAnimateTransformElement) RawAttr(name, value string) *SVGAnimateTransformElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfAttr(condition bool, name, value string) *SVGAnimateTransformElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +171,52 @@ func (e *SVGAnimateTransformElement) Attrf(name, format string, args ...any) *SV
 
 func (e *SVGAnimateTransformElement) IfAttrf(condition bool, name, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) Attrs(attrs ...string) *SVGAnimateTransformElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfAttrs(condition bool, attrs ...string) *SVGAnimateTransformElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) AttrsMap(attrs map[string]string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGAnimateTransformElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGAnimateTransformElement) Text(text string) *SVGAnimateTransformElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGAnimateTransformElement) Textf(format string, args ...any) *SVGAnimateTransformElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGAnimateTransformElement) IfText(condition bool, text string) *SVGAnimateTransformElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +224,28 @@ func (e *SVGAnimateTransformElement) IfText(condition bool, text string) *SVGAni
 
 func (e *SVGAnimateTransformElement) IfTextf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGAnimateTransformElement) Raw(text string) *SVGAnimateTransformElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGAnimateTransformElement) Escaped(text string) *SVGAnimateTransformElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGAnimateTransformElement) IfEscaped(condition bool, text string) *SVGAnimateTransformElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +257,7 @@ func (e *SVGAnimateTransformElement) Escapedf(format string, args ...any) *SVGAn
 
 func (e *SVGAnimateTransformElement) IfEscapedf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +265,8 @@ func (e *SVGAnimateTransformElement) IfEscapedf(condition bool, format string, a
 
 // Controls whether or not the animation is cumulative.
 func (e *SVGAnimateTransformElement) Accumulate(c SVGAnimateTransformAccumulateChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("accumulate", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("accumulate", string(c), choiceValue[SVGAnimateTransformAccumulateChoice])
 	return e
 }
 
@@ -231,19 +284,15 @@ const (
 // Controls whether or not the animation is cumulative.
 // Remove the attribute Accumulate from the element.
 func (e *SVGAnimateTransformElement) AccumulateRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("accumulate")
+	e = e.writable()
+	e.removeAttribute("accumulate")
 	return e
 }
 
 // Controls whether or not the animation is additive.
 func (e *SVGAnimateTransformElement) Additive(c SVGAnimateTransformAdditiveChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("additive", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("additive", string(c), choiceValue[SVGAnimateTransformAdditiveChoice])
 	return e
 }
 
@@ -259,19 +308,15 @@ const (
 // Controls whether or not the animation is additive.
 // Remove the attribute Additive from the element.
 func (e *SVGAnimateTransformElement) AdditiveRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("additive")
+	e = e.writable()
+	e.removeAttribute("additive")
 	return e
 }
 
 // The name of the Attribute to animate.
 func (e *SVGAnimateTransformElement) AttributeName(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("AttributeName", s)
+	e = e.writable()
+	e.setStringAttribute("AttributeName", s)
 	return e
 }
 
@@ -283,7 +328,7 @@ func (e *SVGAnimateTransformElement) AttributeNamef(format string, args ...any)
 // The name of the Attribute to animate.
 func (e *SVGAnimateTransformElement) IfAttributeName(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.AttributeName(s)
+		e = e.AttributeName(s)
 	}
 	return e
 }
@@ -291,7 +336,7 @@ func (e *SVGAnimateTransformElement) IfAttributeName(condition bool, s string) *
 // The name of the Attribute to animate.
 func (e *SVGAnimateTransformElement) IfAttributeNamef(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.AttributeName(fmt.Sprintf(format, args...))
+		e = e.AttributeName(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -299,19 +344,15 @@ func (e *SVGAnimateTransformElement) IfAttributeNamef(condition bool, format str
 // The name of the Attribute to animate.
 // Remove the attribute AttributeName from the element.
 func (e *SVGAnimateTransformElement) AttributeNameRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("AttributeName")
+	e = e.writable()
+	e.removeAttribute("AttributeName")
 	return e
 }
 
 // The namespace of the Attribute to animate.
 func (e *SVGAnimateTransformElement) AttributeType(c SVGAnimateTransformAttributeTypeChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("AttributeType", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("AttributeType", string(c), choiceValue[SVGAnimateTransformAttributeTypeChoice])
 	return e
 }
 
@@ -338,19 +379,15 @@ const (
 // The namespace of the Attribute to animate.
 // Remove the attribute AttributeType from the element.
 func (e *SVGAnimateTransformElement) AttributeTypeRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("AttributeType")
+	e = e.writable()
+	e.removeAttribute("AttributeType")
 	return e
 }
 
 // Defines when the animation should begin.
 func (e *SVGAnimateTransformElement) Begin(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("begin", s)
+	e = e.writable()
+	e.setStringAttribute("begin", s)
 	return e
 }
 
@@ -362,7 +399,7 @@ func (e *SVGAnimateTransformElement) Beginf(format string, args ...any) *SVGAnim
 // Defines when the animation should begin.
 func (e *SVGAnimateTransformElement) IfBegin(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Begin(s)
+		e = e.Begin(s)
 	}
 	return e
 }
@@ -370,7 +407,7 @@ func (e *SVGAnimateTransformElement) IfBegin(condition bool, s string) *SVGAnima
 // Defines when the animation should begin.
 func (e *SVGAnimateTransformElement) IfBeginf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.Begin(fmt.Sprintf(format, args...))
+		e = e.Begin(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -378,19 +415,15 @@ func (e *SVGAnimateTransformElement) IfBeginf(condition bool, format string, arg
 // Defines when the animation should begin.
 // Remove the attribute Begin from the element.
 func (e *SVGAnimateTransformElement) BeginRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("begin")
+	e = e.writable()
+	e.removeAttribute("begin")
 	return e
 }
 
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateTransformElement) By(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("by", s)
+	e = e.writable()
+	e.setStringAttribute("by", s)
 	return e
 }
 
@@ -402,7 +435,7 @@ func (e *SVGAnimateTransformElement) Byf(format string, args ...any) *SVGAnimate
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateTransformElement) IfBy(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.By(s)
+		e = e.By(s)
 	}
 	return e
 }
@@ -410,7 +443,7 @@ func (e *SVGAnimateTransformElement) IfBy(condition bool, s string) *SVGAnimateT
 // Defines a relative offset value for the animation.
 func (e *SVGAnimateTransformElement) IfByf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.By(fmt.Sprintf(format, args...))
+		e = e.By(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -418,19 +451,15 @@ func (e *SVGAnimateTransformElement) IfByf(condition bool, format string, args .
 // Defines a relative offset value for the animation.
 // Remove the attribute By from the element.
 func (e *SVGAnimateTransformElement) ByRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("by")
+	e = e.writable()
+	e.removeAttribute("by")
 	return e
 }
 
 // Defines the pacing of the animation.
 func (e *SVGAnimateTransformElement) CalcMode(c SVGAnimateTransformCalcModeChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("calcMode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("calcMode", string(c), choiceValue[SVGAnimateTransformCalcModeChoice])
 	return e
 }
 
@@ -453,19 +482,15 @@ const (
 // Defines the pacing of the animation.
 // Remove the attribute CalcMode from the element.
 func (e *SVGAnimateTransformElement) CalcModeRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("calcMode")
+	e = e.writable()
+	e.removeAttribute("calcMode")
 	return e
 }
 
 // Defines the duration of the animation.
 func (e *SVGAnimateTransformElement) Dur(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dur", s)
+	e = e.writable()
+	e.setStringAttribute("dur", s)
 	return e
 }
 
@@ -477,7 +502,7 @@ func (e *SVGAnimateTransformElement) Durf(format string, args ...any) *SVGAnimat
 // Defines the duration of the animation.
 func (e *SVGAnimateTransformElement) IfDur(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Dur(s)
+		e = e.Dur(s)
 	}
 	return e
 }
@@ -485,7 +510,7 @@ func (e *SVGAnimateTransformElement) IfDur(condition bool, s string) *SVGAnimate
 // Defines the duration of the animation.
 func (e *SVGAnimateTransformElement) IfDurf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.Dur(fmt.Sprintf(format, args...))
+		e = e.Dur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -493,19 +518,15 @@ func (e *SVGAnimateTransformElement) IfDurf(condition bool, format string, args
 // Defines the duration of the animation.
 // Remove the attribute Dur from the element.
 func (e *SVGAnimateTransformElement) DurRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dur")
+	e = e.writable()
+	e.removeAttribute("dur")
 	return e
 }
 
 // Defines when the animation should end.
 func (e *SVGAnimateTransformElement) End(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("end", s)
+	e = e.writable()
+	e.setStringAttribute("end", s)
 	return e
 }
 
@@ -517,7 +538,7 @@ func (e *SVGAnimateTransformElement) Endf(format string, args ...any) *SVGAnimat
 // Defines when the animation should end.
 func (e *SVGAnimateTransformElement) IfEnd(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.End(s)
+		e = e.End(s)
 	}
 	return e
 }
@@ -525,7 +546,7 @@ func (e *SVGAnimateTransformElement) IfEnd(condition bool, s string) *SVGAnimate
 // Defines when the animation should end.
 func (e *SVGAnimateTransformElement) IfEndf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.End(fmt.Sprintf(format, args...))
+		e = e.End(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -533,19 +554,15 @@ func (e *SVGAnimateTransformElement) IfEndf(condition bool, format string, args
 // Defines when the animation should end.
 // Remove the attribute End from the element.
 func (e *SVGAnimateTransformElement) EndRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("end")
+	e = e.writable()
+	e.removeAttribute("end")
 	return e
 }
 
 // Defines the fill behavior for the animation.
 func (e *SVGAnimateTransformElement) Fill(c SVGAnimateTransformFillChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fill", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("fill", string(c), choiceValue[SVGAnimateTransformFillChoice])
 	return e
 }
 
@@ -561,19 +578,15 @@ const (
 // Defines the fill behavior for the animation.
 // Remove the attribute Fill from the element.
 func (e *SVGAnimateTransformElement) FillRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fill")
+	e = e.writable()
+	e.removeAttribute("fill")
 	return e
 }
 
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateTransformElement) From(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("from", s)
+	e = e.writable()
+	e.setStringAttribute("from", s)
 	return e
 }
 
@@ -585,7 +598,7 @@ func (e *SVGAnimateTransformElement) Fromf(format string, args ...any) *SVGAnima
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateTransformElement) IfFrom(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.From(s)
+		e = e.From(s)
 	}
 	return e
 }
@@ -593,7 +606,7 @@ func (e *SVGAnimateTransformElement) IfFrom(condition bool, s string) *SVGAnimat
 // Defines the initial value of the Attribute.
 func (e *SVGAnimateTransformElement) IfFromf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.From(fmt.Sprintf(format, args...))
+		e = e.From(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -601,20 +614,16 @@ func (e *SVGAnimateTransformElement) IfFromf(condition bool, format string, args
 // Defines the initial value of the Attribute.
 // Remove the attribute From from the element.
 func (e *SVGAnimateTransformElement) FromRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("from")
+	e = e.writable()
+	e.removeAttribute("from")
 	return e
 }
 
 // Defines the values for a cubic Bézier function that controls interval
 // pacing.
 func (e *SVGAnimateTransformElement) KeySplines(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("keySplines", s)
+	e = e.writable()
+	e.setStringAttribute("keySplines", s)
 	return e
 }
 
@@ -628,7 +637,7 @@ func (e *SVGAnimateTransformElement) KeySplinesf(format string, args ...any) *SV
 // pacing.
 func (e *SVGAnimateTransformElement) IfKeySplines(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.KeySplines(s)
+		e = e.KeySplines(s)
 	}
 	return e
 }
@@ -637,7 +646,7 @@ func (e *SVGAnimateTransformElement) IfKeySplines(condition bool, s string) *SVG
 // pacing.
 func (e *SVGAnimateTransformElement) IfKeySplinesf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.KeySplines(fmt.Sprintf(format, args...))
+		e = e.KeySplines(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -646,19 +655,15 @@ func (e *SVGAnimateTransformElement) IfKeySplinesf(condition bool, format string
 // pacing.
 // Remove the attribute KeySplines from the element.
 func (e *SVGAnimateTransformElement) KeySplinesRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("keySplines")
+	e = e.writable()
+	e.removeAttribute("keySplines")
 	return e
 }
 
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateTransformElement) KeyTimes(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("keyTimes", s)
+	e = e.writable()
+	e.setStringAttribute("keyTimes", s)
 	return e
 }
 
@@ -670,7 +675,7 @@ func (e *SVGAnimateTransformElement) KeyTimesf(format string, args ...any) *SVGA
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateTransformElement) IfKeyTimes(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.KeyTimes(s)
+		e = e.KeyTimes(s)
 	}
 	return e
 }
@@ -678,7 +683,7 @@ func (e *SVGAnimateTransformElement) IfKeyTimes(condition bool, s string) *SVGAn
 // Defines when the animation should take place in terms of time fractions.
 func (e *SVGAnimateTransformElement) IfKeyTimesf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.KeyTimes(fmt.Sprintf(format, args...))
+		e = e.KeyTimes(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -686,19 +691,15 @@ func (e *SVGAnimateTransformElement) IfKeyTimesf(condition bool, format string,
 // Defines when the animation should take place in terms of time fractions.
 // Remove the attribute KeyTimes from the element.
 func (e *SVGAnimateTransformElement) KeyTimesRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("keyTimes")
+	e = e.writable()
+	e.removeAttribute("keyTimes")
 	return e
 }
 
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateTransformElement) Max(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("max", s)
+	e = e.writable()
+	e.setStringAttribute("max", s)
 	return e
 }
 
@@ -710,7 +711,7 @@ func (e *SVGAnimateTransformElement) Maxf(format string, args ...any) *SVGAnimat
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateTransformElement) IfMax(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Max(s)
+		e = e.Max(s)
 	}
 	return e
 }
@@ -718,7 +719,7 @@ func (e *SVGAnimateTransformElement) IfMax(condition bool, s string) *SVGAnimate
 // Defines the maximum value allowed for the Attribute.
 func (e *SVGAnimateTransformElement) IfMaxf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.Max(fmt.Sprintf(format, args...))
+		e = e.Max(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -726,19 +727,15 @@ func (e *SVGAnimateTransformElement) IfMaxf(condition bool, format string, args
 // Defines the maximum value allowed for the Attribute.
 // Remove the attribute Max from the element.
 func (e *SVGAnimateTransformElement) MaxRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("max")
+	e = e.writable()
+	e.removeAttribute("max")
 	return e
 }
 
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateTransformElement) Min(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("min", s)
+	e = e.writable()
+	e.setStringAttribute("min", s)
 	return e
 }
 
@@ -750,7 +747,7 @@ func (e *SVGAnimateTransformElement) Minf(format string, args ...any) *SVGAnimat
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateTransformElement) IfMin(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Min(s)
+		e = e.Min(s)
 	}
 	return e
 }
@@ -758,7 +755,7 @@ func (e *SVGAnimateTransformElement) IfMin(condition bool, s string) *SVGAnimate
 // Defines the minimum value allowed for the Attribute.
 func (e *SVGAnimateTransformElement) IfMinf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.Min(fmt.Sprintf(format, args...))
+		e = e.Min(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -766,19 +763,15 @@ func (e *SVGAnimateTransformElement) IfMinf(condition bool, format string, args
 // Defines the minimum value allowed for the Attribute.
 // Remove the attribute Min from the element.
 func (e *SVGAnimateTransformElement) MinRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("min")
+	e = e.writable()
+	e.removeAttribute("min")
 	return e
 }
 
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateTransformElement) RepeatCount(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatCount", s)
+	e = e.writable()
+	e.setStringAttribute("repeatCount", s)
 	return e
 }
 
@@ -790,7 +783,7 @@ func (e *SVGAnimateTransformElement) RepeatCountf(format string, args ...any) *S
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateTransformElement) IfRepeatCount(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.RepeatCount(s)
+		e = e.RepeatCount(s)
 	}
 	return e
 }
@@ -798,7 +791,7 @@ func (e *SVGAnimateTransformElement) IfRepeatCount(condition bool, s string) *SV
 // Defines the number of times the animation should repeat.
 func (e *SVGAnimateTransformElement) IfRepeatCountf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.RepeatCount(fmt.Sprintf(format, args...))
+		e = e.RepeatCount(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -806,19 +799,15 @@ func (e *SVGAnimateTransformElement) IfRepeatCountf(condition bool, format strin
 // Defines the number of times the animation should repeat.
 // Remove the attribute RepeatCount from the element.
 func (e *SVGAnimateTransformElement) RepeatCountRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatCount")
+	e = e.writable()
+	e.removeAttribute("repeatCount")
 	return e
 }
 
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateTransformElement) RepeatDur(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatDur", s)
+	e = e.writable()
+	e.setStringAttribute("repeatDur", s)
 	return e
 }
 
@@ -830,7 +819,7 @@ func (e *SVGAnimateTransformElement) RepeatDurf(format string, args ...any) *SVG
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateTransformElement) IfRepeatDur(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.RepeatDur(s)
+		e = e.RepeatDur(s)
 	}
 	return e
 }
@@ -838,7 +827,7 @@ func (e *SVGAnimateTransformElement) IfRepeatDur(condition bool, s string) *SVGA
 // Defines the duration for repeating an animation.
 func (e *SVGAnimateTransformElement) IfRepeatDurf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.RepeatDur(fmt.Sprintf(format, args...))
+		e = e.RepeatDur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -846,19 +835,15 @@ func (e *SVGAnimateTransformElement) IfRepeatDurf(condition bool, format string,
 // Defines the duration for repeating an animation.
 // Remove the attribute RepeatDur from the element.
 func (e *SVGAnimateTransformElement) RepeatDurRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatDur")
+	e = e.writable()
+	e.removeAttribute("repeatDur")
 	return e
 }
 
 // Defines if an animation should restart after it completes.
 func (e *SVGAnimateTransformElement) Restart(c SVGAnimateTransformRestartChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("restart", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("restart", string(c), choiceValue[SVGAnimateTransformRestartChoice])
 	return e
 }
 
@@ -877,19 +862,15 @@ const (
 // Defines if an animation should restart after it completes.
 // Remove the attribute Restart from the element.
 func (e *SVGAnimateTransformElement) RestartRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("restart")
+	e = e.writable()
+	e.removeAttribute("restart")
 	return e
 }
 
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateTransformElement) To(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("to", s)
+	e = e.writable()
+	e.setStringAttribute("to", s)
 	return e
 }
 
@@ -901,7 +882,7 @@ func (e *SVGAnimateTransformElement) Tof(format string, args ...any) *SVGAnimate
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateTransformElement) IfTo(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.To(s)
+		e = e.To(s)
 	}
 	return e
 }
@@ -909,7 +890,7 @@ func (e *SVGAnimateTransformElement) IfTo(condition bool, s string) *SVGAnimateT
 // Defines the ending value of the Attribute.
 func (e *SVGAnimateTransformElement) IfTof(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.To(fmt.Sprintf(format, args...))
+		e = e.To(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -917,19 +898,15 @@ func (e *SVGAnimateTransformElement) IfTof(condition bool, format string, args .
 // Defines the ending value of the Attribute.
 // Remove the attribute To from the element.
 func (e *SVGAnimateTransformElement) ToRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("to")
+	e = e.writable()
+	e.removeAttribute("to")
 	return e
 }
 
 // Defines which transform to use.
 func (e *SVGAnimateTransformElement) Type(c SVGAnimateTransformTypeChoice) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[SVGAnimateTransformTypeChoice])
 	return e
 }
 
@@ -947,19 +924,15 @@ const (
 // Defines which transform to use.
 // Remove the attribute Type from the element.
 func (e *SVGAnimateTransformElement) TypeRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateTransformElement) Values(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("values", s)
+	e = e.writable()
+	e.setStringAttribute("values", s)
 	return e
 }
 
@@ -971,7 +944,7 @@ func (e *SVGAnimateTransformElement) Valuesf(format string, args ...any) *SVGAni
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateTransformElement) IfValues(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Values(s)
+		e = e.Values(s)
 	}
 	return e
 }
@@ -979,7 +952,7 @@ func (e *SVGAnimateTransformElement) IfValues(condition bool, s string) *SVGAnim
 // Defines a list of discrete values to interpolate.
 func (e *SVGAnimateTransformElement) IfValuesf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.Values(fmt.Sprintf(format, args...))
+		e = e.Values(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -987,19 +960,15 @@ func (e *SVGAnimateTransformElement) IfValuesf(condition bool, format string, ar
 // Defines a list of discrete values to interpolate.
 // Remove the attribute Values from the element.
 func (e *SVGAnimateTransformElement) ValuesRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("values")
+	e = e.writable()
+	e.removeAttribute("values")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGAnimateTransformElement) ID(s string) *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -1011,7 +980,7 @@ func (e *SVGAnimateTransformElement) IDf(format string, args ...any) *SVGAnimate
 // Specifies a unique id for an element
 func (e *SVGAnimateTransformElement) IfID(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -1019,7 +988,7 @@ func (e *SVGAnimateTransformElement) IfID(condition bool, s string) *SVGAnimateT
 // Specifies a unique id for an element
 func (e *SVGAnimateTransformElement) IfIDf(condition bool, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -1027,26 +996,17 @@ func (e *SVGAnimateTransformElement) IfIDf(condition bool, format string, args .
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGAnimateTransformElement) IDRemove() *SVGAnimateTransformElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGAnimateTransformElement) Class(s string) *SVGAnimateTransformElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -1054,7 +1014,7 @@ func (e *SVGAnimateTransformElement) Class(s string) *SVGAnimateTransformElement
 // sheet)
 func (e *SVGAnimateTransformElement) IfClass(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -1063,14 +1023,8 @@ func (e *SVGAnimateTransformElement) IfClass(condition bool, s string) *SVGAnima
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGAnimateTransformElement) ClassRemove(s ...string) *SVGAnimateTransformElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -1079,14 +1033,8 @@ func (e *SVGAnimateTransformElement) StylePairs(pairs ...string) *SVGAnimateTran
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -1100,14 +1048,8 @@ func (e *SVGAnimateTransformElement) StylePairs(pairs ...string) *SVGAnimateTran
 
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateTransformElement) Style(s string) *SVGAnimateTransformElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -1115,7 +1057,7 @@ func (e *SVGAnimateTransformElement) Style(s string) *SVGAnimateTransformElement
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -1123,22 +1065,14 @@ func (e *SVGAnimateTransformElement) Style(s string) *SVGAnimateTransformElement
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateTransformElement) IfStyle(condition bool, s string) *SVGAnimateTransformElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateTransformElement) StyleAdd(k string, v string) *SVGAnimateTransformElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -1150,7 +1084,7 @@ func (e *SVGAnimateTransformElement) StyleAddf(k string, format string, args ...
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateTransformElement) IfStyleAdd(condition bool, k string, v string) *SVGAnimateTransformElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -1158,7 +1092,7 @@ func (e *SVGAnimateTransformElement) IfStyleAdd(condition bool, k string, v stri
 // Specifies an inline CSS style for an element
 func (e *SVGAnimateTransformElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGAnimateTransformElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -1166,21 +1100,15 @@ func (e *SVGAnimateTransformElement) IfStyleAddf(condition bool, k string, forma
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGAnimateTransformElement) StyleMap(m map[string]string) *SVGAnimateTransformElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -1188,13 +1116,7 @@ func (e *SVGAnimateTransformElement) StyleMap(m map[string]string) *SVGAnimateTr
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGAnimateTransformElement) StyleRemove(keys ...string) *SVGAnimateTransformElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
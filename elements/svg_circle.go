@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <circle> SVG element is an SVG basic shape, used to create circles based
@@ -22,18 +20,49 @@ type SVGCircleElement struct {
 // with the tag "circle" during rendering.
 func SVGCircle(children ...ElementRenderer) *SVGCircleElement {
 	e := NewElement("circle", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGCircleElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGCircleElement) Clone() *SVGCircleElement {
+	return &SVGCircleElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGCircleElement) CloneShallow() *SVGCircleElement {
+	return &SVGCircleElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGCircleElement) Freeze() *SVGCircleElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGCircleElement) writable() *SVGCircleElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGCircleElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGCircleElement) Children(children ...ElementRenderer) *SVGCircleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGCircleElement) IfChildren(condition bool, children ...ElementRenderer) *SVGCircleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGCircleElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGCircleElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGCircleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) BoolAttr(name string) *SVGCircleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGCircleElement) BoolAttrRemove(name string) *SVGCircleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGCircleElement) IfBoolAttr(condition bool, name string) *SVGCircleElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGCircleElement) BoolAttrf(format string, args ...any) *SVGCircleEleme
 
 func (e *SVGCircleElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGCircleElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) BoolAttrs(names ...string) *SVGCircleElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) IfBoolAttrs(condition bool, names ...string) *SVGCircleElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) Attr(name, value string) *SVGCircleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGCircleElement) RawAttr(name, value string) *SVGCircleElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGCircleElement) IfAttr(condition bool, name, value string) *SVGCircleElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGCircleElement) Attrf(name, format string, args ...any) *SVGCircleEle
 
 func (e *SVGCircleElement) IfAttrf(condition bool, name, format string, args ...any) *SVGCircleElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) Attrs(attrs ...string) *SVGCircleElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGCircleElement) IfAttrs(condition bool, attrs ...string) *SVGCircleElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) AttrsMap(attrs map[string]string) *SVGCircleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGCircleElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGCircleElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGCircleElement) Text(text string) *SVGCircleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGCircleElement) Textf(format string, args ...any) *SVGCircleElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGCircleElement) IfText(condition bool, text string) *SVGCircleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGCircleElement) IfText(condition bool, text string) *SVGCircleElement
 
 func (e *SVGCircleElement) IfTextf(condition bool, format string, args ...any) *SVGCircleElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGCircleElement) Raw(text string) *SVGCircleElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGCircleElement) Escaped(text string) *SVGCircleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGCircleElement) IfEscaped(condition bool, text string) *SVGCircleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGCircleElement) Escapedf(format string, args ...any) *SVGCircleElemen
 
 func (e *SVGCircleElement) IfEscapedf(condition bool, format string, args ...any) *SVGCircleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,61 +242,53 @@ func (e *SVGCircleElement) IfEscapedf(condition bool, format string, args ...any
 
 // The x-axis coordinate of the center of the circle.
 func (e *SVGCircleElement) Cx(f float64) *SVGCircleElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("cx", f)
+	e = e.writable()
+	e.setFloatAttribute("cx", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the center of the circle.
 func (e *SVGCircleElement) IfCx(condition bool, f float64) *SVGCircleElement {
 	if condition {
-		e.Cx(f)
+		e = e.Cx(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the center of the circle.
 func (e *SVGCircleElement) Cy(f float64) *SVGCircleElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("cy", f)
+	e = e.writable()
+	e.setFloatAttribute("cy", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the center of the circle.
 func (e *SVGCircleElement) IfCy(condition bool, f float64) *SVGCircleElement {
 	if condition {
-		e.Cy(f)
+		e = e.Cy(f)
 	}
 	return e
 }
 
 // The radius of the circle.
 func (e *SVGCircleElement) R(f float64) *SVGCircleElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("r", f)
+	e = e.writable()
+	e.setFloatAttribute("r", f, 0)
 	return e
 }
 
 // The radius of the circle.
 func (e *SVGCircleElement) IfR(condition bool, f float64) *SVGCircleElement {
 	if condition {
-		e.R(f)
+		e = e.R(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGCircleElement) ID(s string) *SVGCircleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -275,7 +300,7 @@ func (e *SVGCircleElement) IDf(format string, args ...any) *SVGCircleElement {
 // Specifies a unique id for an element
 func (e *SVGCircleElement) IfID(condition bool, s string) *SVGCircleElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -283,7 +308,7 @@ func (e *SVGCircleElement) IfID(condition bool, s string) *SVGCircleElement {
 // Specifies a unique id for an element
 func (e *SVGCircleElement) IfIDf(condition bool, format string, args ...any) *SVGCircleElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -291,26 +316,17 @@ func (e *SVGCircleElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGCircleElement) IDRemove() *SVGCircleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGCircleElement) Class(s string) *SVGCircleElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -318,7 +334,7 @@ func (e *SVGCircleElement) Class(s string) *SVGCircleElement {
 // sheet)
 func (e *SVGCircleElement) IfClass(condition bool, s string) *SVGCircleElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -327,14 +343,8 @@ func (e *SVGCircleElement) IfClass(condition bool, s string) *SVGCircleElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGCircleElement) ClassRemove(s ...string) *SVGCircleElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -343,14 +353,8 @@ func (e *SVGCircleElement) StylePairs(pairs ...string) *SVGCircleElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -364,14 +368,8 @@ func (e *SVGCircleElement) StylePairs(pairs ...string) *SVGCircleElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGCircleElement) Style(s string) *SVGCircleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -379,7 +377,7 @@ func (e *SVGCircleElement) Style(s string) *SVGCircleElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -387,22 +385,14 @@ func (e *SVGCircleElement) Style(s string) *SVGCircleElement {
 // Specifies an inline CSS style for an element
 func (e *SVGCircleElement) IfStyle(condition bool, s string) *SVGCircleElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGCircleElement) StyleAdd(k string, v string) *SVGCircleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -414,7 +404,7 @@ func (e *SVGCircleElement) StyleAddf(k string, format string, args ...any) *SVGC
 // Specifies an inline CSS style for an element
 func (e *SVGCircleElement) IfStyleAdd(condition bool, k string, v string) *SVGCircleElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -422,7 +412,7 @@ func (e *SVGCircleElement) IfStyleAdd(condition bool, k string, v string) *SVGCi
 // Specifies an inline CSS style for an element
 func (e *SVGCircleElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGCircleElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -430,21 +420,15 @@ func (e *SVGCircleElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGCircleElement) StyleMap(m map[string]string) *SVGCircleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -452,13 +436,7 @@ func (e *SVGCircleElement) StyleMap(m map[string]string) *SVGCircleElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGCircleElement) StyleRemove(keys ...string) *SVGCircleElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <clipPath> SVG element defines a clipping path. A clipping path is
@@ -22,18 +20,59 @@ type SVGClipPathElement struct {
 // with the tag "clipPath" during rendering.
 func SVGClipPath(children ...ElementRenderer) *SVGClipPathElement {
 	e := NewElement("clipPath", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGClipPathElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGClipPathElement) Clone() *SVGClipPathElement {
+	return &SVGClipPathElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGClipPathElement) CloneShallow() *SVGClipPathElement {
+	return &SVGClipPathElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGClipPathElement) Freeze() *SVGClipPathElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGClipPathElement) writable() *SVGClipPathElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGClipPathElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGClipPathElement) choiceType(name string) func(string) any {
+	switch name {
+	case "clipPathUnits":
+		return choiceValue[SVGClipPathClipPathUnitsChoice]
+	}
+	return nil
+}
+
 func (e *SVGClipPathElement) Children(children ...ElementRenderer) *SVGClipPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGClipPathElement) IfChildren(condition bool, children ...ElementRenderer) *SVGClipPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +80,30 @@ func (e *SVGClipPathElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *SVGClipPathElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGClipPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) BoolAttr(name string) *SVGClipPathElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGClipPathElement) BoolAttrRemove(name string) *SVGClipPathElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGClipPathElement) IfBoolAttr(condition bool, name string) *SVGClipPathElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +114,40 @@ func (e *SVGClipPathElement) BoolAttrf(format string, args ...any) *SVGClipPathE
 
 func (e *SVGClipPathElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGClipPathElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) BoolAttrs(names ...string) *SVGClipPathElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) IfBoolAttrs(condition bool, names ...string) *SVGClipPathElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) Attr(name, value string) *SVGClipPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGClipPathElement) RawAttr(name, value string) *SVGClipPathElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGClipPathElement) IfAttr(condition bool, name, value string) *SVGClipPathElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +158,52 @@ func (e *SVGClipPathElement) Attrf(name, format string, args ...any) *SVGClipPat
 
 func (e *SVGClipPathElement) IfAttrf(condition bool, name, format string, args ...any) *SVGClipPathElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) Attrs(attrs ...string) *SVGClipPathElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGClipPathElement) IfAttrs(condition bool, attrs ...string) *SVGClipPathElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) AttrsMap(attrs map[string]string) *SVGClipPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGClipPathElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGClipPathElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGClipPathElement) Text(text string) *SVGClipPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGClipPathElement) Textf(format string, args ...any) *SVGClipPathElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGClipPathElement) IfText(condition bool, text string) *SVGClipPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +211,28 @@ func (e *SVGClipPathElement) IfText(condition bool, text string) *SVGClipPathEle
 
 func (e *SVGClipPathElement) IfTextf(condition bool, format string, args ...any) *SVGClipPathElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGClipPathElement) Raw(text string) *SVGClipPathElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGClipPathElement) Escaped(text string) *SVGClipPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGClipPathElement) IfEscaped(condition bool, text string) *SVGClipPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +244,7 @@ func (e *SVGClipPathElement) Escapedf(format string, args ...any) *SVGClipPathEl
 
 func (e *SVGClipPathElement) IfEscapedf(condition bool, format string, args ...any) *SVGClipPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +252,8 @@ func (e *SVGClipPathElement) IfEscapedf(condition bool, format string, args ...a
 
 // The coordinate system for the contents of the <clipPath> element.
 func (e *SVGClipPathElement) ClipPathUnits(c SVGClipPathClipPathUnitsChoice) *SVGClipPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("clipPathUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("clipPathUnits", string(c), choiceValue[SVGClipPathClipPathUnitsChoice])
 	return e
 }
 
@@ -233,19 +274,15 @@ const (
 // The coordinate system for the contents of the <clipPath> element.
 // Remove the attribute ClipPathUnits from the element.
 func (e *SVGClipPathElement) ClipPathUnitsRemove() *SVGClipPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("clipPathUnits")
+	e = e.writable()
+	e.removeAttribute("clipPathUnits")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGClipPathElement) ID(s string) *SVGClipPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -257,7 +294,7 @@ func (e *SVGClipPathElement) IDf(format string, args ...any) *SVGClipPathElement
 // Specifies a unique id for an element
 func (e *SVGClipPathElement) IfID(condition bool, s string) *SVGClipPathElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -265,7 +302,7 @@ func (e *SVGClipPathElement) IfID(condition bool, s string) *SVGClipPathElement
 // Specifies a unique id for an element
 func (e *SVGClipPathElement) IfIDf(condition bool, format string, args ...any) *SVGClipPathElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -273,26 +310,17 @@ func (e *SVGClipPathElement) IfIDf(condition bool, format string, args ...any) *
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGClipPathElement) IDRemove() *SVGClipPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGClipPathElement) Class(s string) *SVGClipPathElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -300,7 +328,7 @@ func (e *SVGClipPathElement) Class(s string) *SVGClipPathElement {
 // sheet)
 func (e *SVGClipPathElement) IfClass(condition bool, s string) *SVGClipPathElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -309,14 +337,8 @@ func (e *SVGClipPathElement) IfClass(condition bool, s string) *SVGClipPathEleme
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGClipPathElement) ClassRemove(s ...string) *SVGClipPathElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -325,14 +347,8 @@ func (e *SVGClipPathElement) StylePairs(pairs ...string) *SVGClipPathElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -346,14 +362,8 @@ func (e *SVGClipPathElement) StylePairs(pairs ...string) *SVGClipPathElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGClipPathElement) Style(s string) *SVGClipPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -361,7 +371,7 @@ func (e *SVGClipPathElement) Style(s string) *SVGClipPathElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -369,22 +379,14 @@ func (e *SVGClipPathElement) Style(s string) *SVGClipPathElement {
 // Specifies an inline CSS style for an element
 func (e *SVGClipPathElement) IfStyle(condition bool, s string) *SVGClipPathElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGClipPathElement) StyleAdd(k string, v string) *SVGClipPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -396,7 +398,7 @@ func (e *SVGClipPathElement) StyleAddf(k string, format string, args ...any) *SV
 // Specifies an inline CSS style for an element
 func (e *SVGClipPathElement) IfStyleAdd(condition bool, k string, v string) *SVGClipPathElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -404,7 +406,7 @@ func (e *SVGClipPathElement) IfStyleAdd(condition bool, k string, v string) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGClipPathElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGClipPathElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -412,21 +414,15 @@ func (e *SVGClipPathElement) IfStyleAddf(condition bool, k string, format string
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGClipPathElement) StyleMap(m map[string]string) *SVGClipPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -434,13 +430,7 @@ func (e *SVGClipPathElement) StyleMap(m map[string]string) *SVGClipPathElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGClipPathElement) StyleRemove(keys ...string) *SVGClipPathElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <defs> SVG element is used to embed definitions that can be reused inside
@@ -22,18 +20,49 @@ type SVGDefsElement struct {
 // with the tag "defs" during rendering.
 func SVGDefs(children ...ElementRenderer) *SVGDefsElement {
 	e := NewElement("defs", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGDefsElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGDefsElement) Clone() *SVGDefsElement {
+	return &SVGDefsElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGDefsElement) CloneShallow() *SVGDefsElement {
+	return &SVGDefsElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGDefsElement) Freeze() *SVGDefsElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGDefsElement) writable() *SVGDefsElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGDefsElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGDefsElement) Children(children ...ElementRenderer) *SVGDefsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGDefsElement) IfChildren(condition bool, children ...ElementRenderer) *SVGDefsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGDefsElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGDefsElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGDefsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) BoolAttr(name string) *SVGDefsElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGDefsElement) BoolAttrRemove(name string) *SVGDefsElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGDefsElement) IfBoolAttr(condition bool, name string) *SVGDefsElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGDefsElement) BoolAttrf(format string, args ...any) *SVGDefsElement {
 
 func (e *SVGDefsElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGDefsElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) BoolAttrs(names ...string) *SVGDefsElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) IfBoolAttrs(condition bool, names ...string) *SVGDefsElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) Attr(name, value string) *SVGDefsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGDefsElement) RawAttr(name, value string) *SVGDefsElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGDefsElement) IfAttr(condition bool, name, value string) *SVGDefsElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGDefsElement) Attrf(name, format string, args ...any) *SVGDefsElement
 
 func (e *SVGDefsElement) IfAttrf(condition bool, name, format string, args ...any) *SVGDefsElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) Attrs(attrs ...string) *SVGDefsElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGDefsElement) IfAttrs(condition bool, attrs ...string) *SVGDefsElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) AttrsMap(attrs map[string]string) *SVGDefsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGDefsElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGDefsElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGDefsElement) Text(text string) *SVGDefsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGDefsElement) Textf(format string, args ...any) *SVGDefsElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGDefsElement) IfText(condition bool, text string) *SVGDefsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGDefsElement) IfText(condition bool, text string) *SVGDefsElement {
 
 func (e *SVGDefsElement) IfTextf(condition bool, format string, args ...any) *SVGDefsElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGDefsElement) Raw(text string) *SVGDefsElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGDefsElement) Escaped(text string) *SVGDefsElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGDefsElement) IfEscaped(condition bool, text string) *SVGDefsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGDefsElement) Escapedf(format string, args ...any) *SVGDefsElement {
 
 func (e *SVGDefsElement) IfEscapedf(condition bool, format string, args ...any) *SVGDefsElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGDefsElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Specifies a unique id for an element
 func (e *SVGDefsElement) ID(s string) *SVGDefsElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGDefsElement) IDf(format string, args ...any) *SVGDefsElement {
 // Specifies a unique id for an element
 func (e *SVGDefsElement) IfID(condition bool, s string) *SVGDefsElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGDefsElement) IfID(condition bool, s string) *SVGDefsElement {
 // Specifies a unique id for an element
 func (e *SVGDefsElement) IfIDf(condition bool, format string, args ...any) *SVGDefsElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,26 +271,17 @@ func (e *SVGDefsElement) IfIDf(condition bool, format string, args ...any) *SVGD
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGDefsElement) IDRemove() *SVGDefsElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGDefsElement) Class(s string) *SVGDefsElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -267,7 +289,7 @@ func (e *SVGDefsElement) Class(s string) *SVGDefsElement {
 // sheet)
 func (e *SVGDefsElement) IfClass(condition bool, s string) *SVGDefsElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -276,14 +298,8 @@ func (e *SVGDefsElement) IfClass(condition bool, s string) *SVGDefsElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGDefsElement) ClassRemove(s ...string) *SVGDefsElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -292,14 +308,8 @@ func (e *SVGDefsElement) StylePairs(pairs ...string) *SVGDefsElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -313,14 +323,8 @@ func (e *SVGDefsElement) StylePairs(pairs ...string) *SVGDefsElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGDefsElement) Style(s string) *SVGDefsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -328,7 +332,7 @@ func (e *SVGDefsElement) Style(s string) *SVGDefsElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -336,22 +340,14 @@ func (e *SVGDefsElement) Style(s string) *SVGDefsElement {
 // Specifies an inline CSS style for an element
 func (e *SVGDefsElement) IfStyle(condition bool, s string) *SVGDefsElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGDefsElement) StyleAdd(k string, v string) *SVGDefsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -363,7 +359,7 @@ func (e *SVGDefsElement) StyleAddf(k string, format string, args ...any) *SVGDef
 // Specifies an inline CSS style for an element
 func (e *SVGDefsElement) IfStyleAdd(condition bool, k string, v string) *SVGDefsElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -371,7 +367,7 @@ func (e *SVGDefsElement) IfStyleAdd(condition bool, k string, v string) *SVGDefs
 // Specifies an inline CSS style for an element
 func (e *SVGDefsElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGDefsElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -379,21 +375,15 @@ func (e *SVGDefsElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGDefsElement) StyleMap(m map[string]string) *SVGDefsElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -401,13 +391,7 @@ func (e *SVGDefsElement) StyleMap(m map[string]string) *SVGDefsElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGDefsElement) StyleRemove(keys ...string) *SVGDefsElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <desc> SVG element provides a description container for SVG content.
@@ -21,18 +19,49 @@ type SVGDescElement struct {
 // with the tag "desc" during rendering.
 func SVGDesc(children ...ElementRenderer) *SVGDescElement {
 	e := NewElement("desc", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGDescElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGDescElement) Clone() *SVGDescElement {
+	return &SVGDescElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGDescElement) CloneShallow() *SVGDescElement {
+	return &SVGDescElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGDescElement) Freeze() *SVGDescElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGDescElement) writable() *SVGDescElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGDescElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGDescElement) Children(children ...ElementRenderer) *SVGDescElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGDescElement) IfChildren(condition bool, children ...ElementRenderer) *SVGDescElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +69,30 @@ func (e *SVGDescElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGDescElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGDescElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGDescElement) BoolAttr(name string) *SVGDescElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGDescElement) BoolAttrRemove(name string) *SVGDescElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGDescElement) IfBoolAttr(condition bool, name string) *SVGDescElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +103,40 @@ func (e *SVGDescElement) BoolAttrf(format string, args ...any) *SVGDescElement {
 
 func (e *SVGDescElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGDescElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGDescElement) BoolAttrs(names ...string) *SVGDescElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGDescElement) IfBoolAttrs(condition bool, names ...string) *SVGDescElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGDescElement) Attr(name, value string) *SVGDescElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGDescElement) RawAttr(name, value string) *SVGDescElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGDescElement) IfAttr(condition bool, name, value string) *SVGDescElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +147,52 @@ func (e *SVGDescElement) Attrf(name, format string, args ...any) *SVGDescElement
 
 func (e *SVGDescElement) IfAttrf(condition bool, name, format string, args ...any) *SVGDescElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGDescElement) Attrs(attrs ...string) *SVGDescElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGDescElement) IfAttrs(condition bool, attrs ...string) *SVGDescElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGDescElement) AttrsMap(attrs map[string]string) *SVGDescElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGDescElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGDescElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGDescElement) Text(text string) *SVGDescElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGDescElement) Textf(format string, args ...any) *SVGDescElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGDescElement) IfText(condition bool, text string) *SVGDescElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +200,28 @@ func (e *SVGDescElement) IfText(condition bool, text string) *SVGDescElement {
 
 func (e *SVGDescElement) IfTextf(condition bool, format string, args ...any) *SVGDescElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGDescElement) Raw(text string) *SVGDescElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGDescElement) Escaped(text string) *SVGDescElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGDescElement) IfEscaped(condition bool, text string) *SVGDescElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +233,7 @@ func (e *SVGDescElement) Escapedf(format string, args ...any) *SVGDescElement {
 
 func (e *SVGDescElement) IfEscapedf(condition bool, format string, args ...any) *SVGDescElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,10 +241,8 @@ func (e *SVGDescElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Specifies a unique id for an element
 func (e *SVGDescElement) ID(s string) *SVGDescElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -223,7 +254,7 @@ func (e *SVGDescElement) IDf(format string, args ...any) *SVGDescElement {
 // Specifies a unique id for an element
 func (e *SVGDescElement) IfID(condition bool, s string) *SVGDescElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -231,7 +262,7 @@ func (e *SVGDescElement) IfID(condition bool, s string) *SVGDescElement {
 // Specifies a unique id for an element
 func (e *SVGDescElement) IfIDf(condition bool, format string, args ...any) *SVGDescElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -239,26 +270,17 @@ func (e *SVGDescElement) IfIDf(condition bool, format string, args ...any) *SVGD
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGDescElement) IDRemove() *SVGDescElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGDescElement) Class(s string) *SVGDescElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -266,7 +288,7 @@ func (e *SVGDescElement) Class(s string) *SVGDescElement {
 // sheet)
 func (e *SVGDescElement) IfClass(condition bool, s string) *SVGDescElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -275,14 +297,8 @@ func (e *SVGDescElement) IfClass(condition bool, s string) *SVGDescElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGDescElement) ClassRemove(s ...string) *SVGDescElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -291,14 +307,8 @@ func (e *SVGDescElement) StylePairs(pairs ...string) *SVGDescElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -312,14 +322,8 @@ func (e *SVGDescElement) StylePairs(pairs ...string) *SVGDescElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGDescElement) Style(s string) *SVGDescElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -327,7 +331,7 @@ func (e *SVGDescElement) Style(s string) *SVGDescElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -335,22 +339,14 @@ func (e *SVGDescElement) Style(s string) *SVGDescElement {
 // Specifies an inline CSS style for an element
 func (e *SVGDescElement) IfStyle(condition bool, s string) *SVGDescElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGDescElement) StyleAdd(k string, v string) *SVGDescElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -362,7 +358,7 @@ func (e *SVGDescElement) StyleAddf(k string, format string, args ...any) *SVGDes
 // Specifies an inline CSS style for an element
 func (e *SVGDescElement) IfStyleAdd(condition bool, k string, v string) *SVGDescElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -370,7 +366,7 @@ func (e *SVGDescElement) IfStyleAdd(condition bool, k string, v string) *SVGDesc
 // Specifies an inline CSS style for an element
 func (e *SVGDescElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGDescElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -378,21 +374,15 @@ func (e *SVGDescElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGDescElement) StyleMap(m map[string]string) *SVGDescElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -400,13 +390,7 @@ func (e *SVGDescElement) StyleMap(m map[string]string) *SVGDescElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGDescElement) StyleRemove(keys ...string) *SVGDescElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
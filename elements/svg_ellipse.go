@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <ellipse> SVG element is an SVG basic shape, used to create ellipses
@@ -22,18 +20,49 @@ type SVGEllipseElement struct {
 // with the tag "ellipse" during rendering.
 func SVGEllipse(children ...ElementRenderer) *SVGEllipseElement {
 	e := NewElement("ellipse", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGEllipseElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGEllipseElement) Clone() *SVGEllipseElement {
+	return &SVGEllipseElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGEllipseElement) CloneShallow() *SVGEllipseElement {
+	return &SVGEllipseElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGEllipseElement) Freeze() *SVGEllipseElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGEllipseElement) writable() *SVGEllipseElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGEllipseElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGEllipseElement) Children(children ...ElementRenderer) *SVGEllipseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGEllipseElement) IfChildren(condition bool, children ...ElementRenderer) *SVGEllipseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGEllipseElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGEllipseElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGEllipseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) BoolAttr(name string) *SVGEllipseElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGEllipseElement) BoolAttrRemove(name string) *SVGEllipseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGEllipseElement) IfBoolAttr(condition bool, name string) *SVGEllipseElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGEllipseElement) BoolAttrf(format string, args ...any) *SVGEllipseEle
 
 func (e *SVGEllipseElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGEllipseElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) BoolAttrs(names ...string) *SVGEllipseElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) IfBoolAttrs(condition bool, names ...string) *SVGEllipseElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) Attr(name, value string) *SVGEllipseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGEllipseElement) RawAttr(name, value string) *SVGEllipseElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGEllipseElement) IfAttr(condition bool, name, value string) *SVGEllipseElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGEllipseElement) Attrf(name, format string, args ...any) *SVGEllipseE
 
 func (e *SVGEllipseElement) IfAttrf(condition bool, name, format string, args ...any) *SVGEllipseElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) Attrs(attrs ...string) *SVGEllipseElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGEllipseElement) IfAttrs(condition bool, attrs ...string) *SVGEllipseElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) AttrsMap(attrs map[string]string) *SVGEllipseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGEllipseElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGEllipseElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGEllipseElement) Text(text string) *SVGEllipseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGEllipseElement) Textf(format string, args ...any) *SVGEllipseElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGEllipseElement) IfText(condition bool, text string) *SVGEllipseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGEllipseElement) IfText(condition bool, text string) *SVGEllipseEleme
 
 func (e *SVGEllipseElement) IfTextf(condition bool, format string, args ...any) *SVGEllipseElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGEllipseElement) Raw(text string) *SVGEllipseElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGEllipseElement) Escaped(text string) *SVGEllipseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGEllipseElement) IfEscaped(condition bool, text string) *SVGEllipseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGEllipseElement) Escapedf(format string, args ...any) *SVGEllipseElem
 
 func (e *SVGEllipseElement) IfEscapedf(condition bool, format string, args ...any) *SVGEllipseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,78 +242,68 @@ func (e *SVGEllipseElement) IfEscapedf(condition bool, format string, args ...an
 
 // The x-axis coordinate of the center of the ellipse.
 func (e *SVGEllipseElement) Cx(f float64) *SVGEllipseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("cx", f)
+	e = e.writable()
+	e.setFloatAttribute("cx", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the center of the ellipse.
 func (e *SVGEllipseElement) IfCx(condition bool, f float64) *SVGEllipseElement {
 	if condition {
-		e.Cx(f)
+		e = e.Cx(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the center of the ellipse.
 func (e *SVGEllipseElement) Cy(f float64) *SVGEllipseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("cy", f)
+	e = e.writable()
+	e.setFloatAttribute("cy", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the center of the ellipse.
 func (e *SVGEllipseElement) IfCy(condition bool, f float64) *SVGEllipseElement {
 	if condition {
-		e.Cy(f)
+		e = e.Cy(f)
 	}
 	return e
 }
 
 // The x-axis radius of the ellipse.
 func (e *SVGEllipseElement) Rx(f float64) *SVGEllipseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("rx", f)
+	e = e.writable()
+	e.setFloatAttribute("rx", f, 0)
 	return e
 }
 
 // The x-axis radius of the ellipse.
 func (e *SVGEllipseElement) IfRx(condition bool, f float64) *SVGEllipseElement {
 	if condition {
-		e.Rx(f)
+		e = e.Rx(f)
 	}
 	return e
 }
 
 // The y-axis radius of the ellipse.
 func (e *SVGEllipseElement) Ry(f float64) *SVGEllipseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("ry", f)
+	e = e.writable()
+	e.setFloatAttribute("ry", f, 0)
 	return e
 }
 
 // The y-axis radius of the ellipse.
 func (e *SVGEllipseElement) IfRy(condition bool, f float64) *SVGEllipseElement {
 	if condition {
-		e.Ry(f)
+		e = e.Ry(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGEllipseElement) ID(s string) *SVGEllipseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -292,7 +315,7 @@ func (e *SVGEllipseElement) IDf(format string, args ...any) *SVGEllipseElement {
 // Specifies a unique id for an element
 func (e *SVGEllipseElement) IfID(condition bool, s string) *SVGEllipseElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -300,7 +323,7 @@ func (e *SVGEllipseElement) IfID(condition bool, s string) *SVGEllipseElement {
 // Specifies a unique id for an element
 func (e *SVGEllipseElement) IfIDf(condition bool, format string, args ...any) *SVGEllipseElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -308,26 +331,17 @@ func (e *SVGEllipseElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGEllipseElement) IDRemove() *SVGEllipseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGEllipseElement) Class(s string) *SVGEllipseElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -335,7 +349,7 @@ func (e *SVGEllipseElement) Class(s string) *SVGEllipseElement {
 // sheet)
 func (e *SVGEllipseElement) IfClass(condition bool, s string) *SVGEllipseElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -344,14 +358,8 @@ func (e *SVGEllipseElement) IfClass(condition bool, s string) *SVGEllipseElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGEllipseElement) ClassRemove(s ...string) *SVGEllipseElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -360,14 +368,8 @@ func (e *SVGEllipseElement) StylePairs(pairs ...string) *SVGEllipseElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -381,14 +383,8 @@ func (e *SVGEllipseElement) StylePairs(pairs ...string) *SVGEllipseElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGEllipseElement) Style(s string) *SVGEllipseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -396,7 +392,7 @@ func (e *SVGEllipseElement) Style(s string) *SVGEllipseElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -404,22 +400,14 @@ func (e *SVGEllipseElement) Style(s string) *SVGEllipseElement {
 // Specifies an inline CSS style for an element
 func (e *SVGEllipseElement) IfStyle(condition bool, s string) *SVGEllipseElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGEllipseElement) StyleAdd(k string, v string) *SVGEllipseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -431,7 +419,7 @@ func (e *SVGEllipseElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGEllipseElement) IfStyleAdd(condition bool, k string, v string) *SVGEllipseElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -439,7 +427,7 @@ func (e *SVGEllipseElement) IfStyleAdd(condition bool, k string, v string) *SVGE
 // Specifies an inline CSS style for an element
 func (e *SVGEllipseElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGEllipseElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -447,21 +435,15 @@ func (e *SVGEllipseElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGEllipseElement) StyleMap(m map[string]string) *SVGEllipseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -469,13 +451,7 @@ func (e *SVGEllipseElement) StyleMap(m map[string]string) *SVGEllipseElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGEllipseElement) StyleRemove(keys ...string) *SVGEllipseElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
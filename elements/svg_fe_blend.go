@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feBlend> SVG filter primitive composes two objects together ruled by a
@@ -23,18 +21,59 @@ type SVGFeBlendElement struct {
 // with the tag "feBlend" during rendering.
 func SVGFeBlend(children ...ElementRenderer) *SVGFeBlendElement {
 	e := NewElement("feBlend", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeBlendElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeBlendElement) Clone() *SVGFeBlendElement {
+	return &SVGFeBlendElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeBlendElement) CloneShallow() *SVGFeBlendElement {
+	return &SVGFeBlendElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeBlendElement) Freeze() *SVGFeBlendElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeBlendElement) writable() *SVGFeBlendElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeBlendElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeBlendElement) choiceType(name string) func(string) any {
+	switch name {
+	case "mode":
+		return choiceValue[SVGFeBlendModeChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeBlendElement) Children(children ...ElementRenderer) *SVGFeBlendElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeBlendElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeBlendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGFeBlendElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGFeBlendElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeBlendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) BoolAttr(name string) *SVGFeBlendElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeBlendElement) BoolAttrRemove(name string) *SVGFeBlendElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeBlendElement) IfBoolAttr(condition bool, name string) *SVGFeBlendElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGFeBlendElement) BoolAttrf(format string, args ...any) *SVGFeBlendEle
 
 func (e *SVGFeBlendElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) BoolAttrs(names ...string) *SVGFeBlendElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) IfBoolAttrs(condition bool, names ...string) *SVGFeBlendElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) Attr(name, value string) *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeBlendElement) RawAttr(name, value string) *SVGFeBlendElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeBlendElement) IfAttr(condition bool, name, value string) *SVGFeBlendElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGFeBlendElement) Attrf(name, format string, args ...any) *SVGFeBlendE
 
 func (e *SVGFeBlendElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) Attrs(attrs ...string) *SVGFeBlendElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeBlendElement) IfAttrs(condition bool, attrs ...string) *SVGFeBlendElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) AttrsMap(attrs map[string]string) *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeBlendElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeBlendElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeBlendElement) Text(text string) *SVGFeBlendElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeBlendElement) Textf(format string, args ...any) *SVGFeBlendElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeBlendElement) IfText(condition bool, text string) *SVGFeBlendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGFeBlendElement) IfText(condition bool, text string) *SVGFeBlendEleme
 
 func (e *SVGFeBlendElement) IfTextf(condition bool, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeBlendElement) Raw(text string) *SVGFeBlendElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeBlendElement) Escaped(text string) *SVGFeBlendElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeBlendElement) IfEscaped(condition bool, text string) *SVGFeBlendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGFeBlendElement) Escapedf(format string, args ...any) *SVGFeBlendElem
 
 func (e *SVGFeBlendElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeBlendElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +253,8 @@ func (e *SVGFeBlendElement) IfEscapedf(condition bool, format string, args ...an
 
 // Input for the blending.
 func (e *SVGFeBlendElement) In(s string) *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -225,7 +266,7 @@ func (e *SVGFeBlendElement) Inf(format string, args ...any) *SVGFeBlendElement {
 // Input for the blending.
 func (e *SVGFeBlendElement) IfIn(condition bool, s string) *SVGFeBlendElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -233,7 +274,7 @@ func (e *SVGFeBlendElement) IfIn(condition bool, s string) *SVGFeBlendElement {
 // Input for the blending.
 func (e *SVGFeBlendElement) IfInf(condition bool, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +282,15 @@ func (e *SVGFeBlendElement) IfInf(condition bool, format string, args ...any) *S
 // Input for the blending.
 // Remove the attribute In from the element.
 func (e *SVGFeBlendElement) InRemove() *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // Second input for the blending.
 func (e *SVGFeBlendElement) In2(s string) *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in2", s)
+	e = e.writable()
+	e.setStringAttribute("in2", s)
 	return e
 }
 
@@ -265,7 +302,7 @@ func (e *SVGFeBlendElement) In2f(format string, args ...any) *SVGFeBlendElement
 // Second input for the blending.
 func (e *SVGFeBlendElement) IfIn2(condition bool, s string) *SVGFeBlendElement {
 	if condition {
-		e.In2(s)
+		e = e.In2(s)
 	}
 	return e
 }
@@ -273,7 +310,7 @@ func (e *SVGFeBlendElement) IfIn2(condition bool, s string) *SVGFeBlendElement {
 // Second input for the blending.
 func (e *SVGFeBlendElement) IfIn2f(condition bool, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.In2(fmt.Sprintf(format, args...))
+		e = e.In2(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,19 +318,15 @@ func (e *SVGFeBlendElement) IfIn2f(condition bool, format string, args ...any) *
 // Second input for the blending.
 // Remove the attribute In2 from the element.
 func (e *SVGFeBlendElement) In2Remove() *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in2")
+	e = e.writable()
+	e.removeAttribute("in2")
 	return e
 }
 
 // The mode used to blend the two inputs together.
 func (e *SVGFeBlendElement) Mode(c SVGFeBlendModeChoice) *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("mode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("mode", string(c), choiceValue[SVGFeBlendModeChoice])
 	return e
 }
 
@@ -317,19 +350,15 @@ const (
 // The mode used to blend the two inputs together.
 // Remove the attribute Mode from the element.
 func (e *SVGFeBlendElement) ModeRemove() *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("mode")
+	e = e.writable()
+	e.removeAttribute("mode")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeBlendElement) ID(s string) *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -341,7 +370,7 @@ func (e *SVGFeBlendElement) IDf(format string, args ...any) *SVGFeBlendElement {
 // Specifies a unique id for an element
 func (e *SVGFeBlendElement) IfID(condition bool, s string) *SVGFeBlendElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -349,7 +378,7 @@ func (e *SVGFeBlendElement) IfID(condition bool, s string) *SVGFeBlendElement {
 // Specifies a unique id for an element
 func (e *SVGFeBlendElement) IfIDf(condition bool, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -357,26 +386,17 @@ func (e *SVGFeBlendElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeBlendElement) IDRemove() *SVGFeBlendElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeBlendElement) Class(s string) *SVGFeBlendElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -384,7 +404,7 @@ func (e *SVGFeBlendElement) Class(s string) *SVGFeBlendElement {
 // sheet)
 func (e *SVGFeBlendElement) IfClass(condition bool, s string) *SVGFeBlendElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -393,14 +413,8 @@ func (e *SVGFeBlendElement) IfClass(condition bool, s string) *SVGFeBlendElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeBlendElement) ClassRemove(s ...string) *SVGFeBlendElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -409,14 +423,8 @@ func (e *SVGFeBlendElement) StylePairs(pairs ...string) *SVGFeBlendElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -430,14 +438,8 @@ func (e *SVGFeBlendElement) StylePairs(pairs ...string) *SVGFeBlendElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeBlendElement) Style(s string) *SVGFeBlendElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -445,7 +447,7 @@ func (e *SVGFeBlendElement) Style(s string) *SVGFeBlendElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -453,22 +455,14 @@ func (e *SVGFeBlendElement) Style(s string) *SVGFeBlendElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeBlendElement) IfStyle(condition bool, s string) *SVGFeBlendElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeBlendElement) StyleAdd(k string, v string) *SVGFeBlendElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -480,7 +474,7 @@ func (e *SVGFeBlendElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeBlendElement) IfStyleAdd(condition bool, k string, v string) *SVGFeBlendElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -488,7 +482,7 @@ func (e *SVGFeBlendElement) IfStyleAdd(condition bool, k string, v string) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeBlendElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeBlendElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -496,21 +490,15 @@ func (e *SVGFeBlendElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeBlendElement) StyleMap(m map[string]string) *SVGFeBlendElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -518,13 +506,7 @@ func (e *SVGFeBlendElement) StyleMap(m map[string]string) *SVGFeBlendElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeBlendElement) StyleRemove(keys ...string) *SVGFeBlendElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feColorMatrix> SVG filter element changes colors based on a
@@ -23,18 +21,59 @@ type SVGFeColorMatrixElement struct {
 // with the tag "feColorMatrix" during rendering.
 func SVGFeColorMatrix(children ...ElementRenderer) *SVGFeColorMatrixElement {
 	e := NewElement("feColorMatrix", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeColorMatrixElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeColorMatrixElement) Clone() *SVGFeColorMatrixElement {
+	return &SVGFeColorMatrixElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeColorMatrixElement) CloneShallow() *SVGFeColorMatrixElement {
+	return &SVGFeColorMatrixElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeColorMatrixElement) Freeze() *SVGFeColorMatrixElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeColorMatrixElement) writable() *SVGFeColorMatrixElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeColorMatrixElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeColorMatrixElement) choiceType(name string) func(string) any {
+	switch name {
+	case "type":
+		return choiceValue[SVGFeColorMatrixTypeChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeColorMatrixElement) Children(children ...ElementRenderer) *SVGFeColorMatrixElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeColorMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGFeColorMatrixElement) IfChildren(condition bool, children ...Element
 
 func (e *SVGFeColorMatrixElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeColorMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) BoolAttr(name string) *SVGFeColorMatrixElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) BoolAttrRemove(name string) *SVGFeColorMatrixElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfBoolAttr(condition bool, name string) *SVGFeColorMatrixElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGFeColorMatrixElement) BoolAttrf(format string, args ...any) *SVGFeCo
 
 func (e *SVGFeColorMatrixElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) BoolAttrs(names ...string) *SVGFeColorMatrixElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfBoolAttrs(condition bool, names ...string) *SVGFeColorMatrixElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) Attr(name, value string) *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeColorMatrixElement) RawAttr(name, value string) *SVGFeColorMatrixElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfAttr(condition bool, name, value string) *SVGFeColorMatrixElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGFeColorMatrixElement) Attrf(name, format string, args ...any) *SVGFe
 
 func (e *SVGFeColorMatrixElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) Attrs(attrs ...string) *SVGFeColorMatrixElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfAttrs(condition bool, attrs ...string) *SVGFeColorMatrixElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) AttrsMap(attrs map[string]string) *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeColorMatrixElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) Text(text string) *SVGFeColorMatrixElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) Textf(format string, args ...any) *SVGFeColorMatrixElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfText(condition bool, text string) *SVGFeColorMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGFeColorMatrixElement) IfText(condition bool, text string) *SVGFeColo
 
 func (e *SVGFeColorMatrixElement) IfTextf(condition bool, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeColorMatrixElement) Raw(text string) *SVGFeColorMatrixElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeColorMatrixElement) Escaped(text string) *SVGFeColorMatrixElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeColorMatrixElement) IfEscaped(condition bool, text string) *SVGFeColorMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGFeColorMatrixElement) Escapedf(format string, args ...any) *SVGFeCol
 
 func (e *SVGFeColorMatrixElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +253,8 @@ func (e *SVGFeColorMatrixElement) IfEscapedf(condition bool, format string, args
 
 // The input for this filter.
 func (e *SVGFeColorMatrixElement) In(s string) *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -225,7 +266,7 @@ func (e *SVGFeColorMatrixElement) Inf(format string, args ...any) *SVGFeColorMat
 // The input for this filter.
 func (e *SVGFeColorMatrixElement) IfIn(condition bool, s string) *SVGFeColorMatrixElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -233,7 +274,7 @@ func (e *SVGFeColorMatrixElement) IfIn(condition bool, s string) *SVGFeColorMatr
 // The input for this filter.
 func (e *SVGFeColorMatrixElement) IfInf(condition bool, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +282,15 @@ func (e *SVGFeColorMatrixElement) IfInf(condition bool, format string, args ...a
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeColorMatrixElement) InRemove() *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // The type of matrix operation.
 func (e *SVGFeColorMatrixElement) Type(c SVGFeColorMatrixTypeChoice) *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[SVGFeColorMatrixTypeChoice])
 	return e
 }
 
@@ -273,19 +310,15 @@ const (
 // The type of matrix operation.
 // Remove the attribute Type from the element.
 func (e *SVGFeColorMatrixElement) TypeRemove() *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // The list of one or more numbers that represent the matrix.
 func (e *SVGFeColorMatrixElement) Values(s string) *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("values", s)
+	e = e.writable()
+	e.setStringAttribute("values", s)
 	return e
 }
 
@@ -297,7 +330,7 @@ func (e *SVGFeColorMatrixElement) Valuesf(format string, args ...any) *SVGFeColo
 // The list of one or more numbers that represent the matrix.
 func (e *SVGFeColorMatrixElement) IfValues(condition bool, s string) *SVGFeColorMatrixElement {
 	if condition {
-		e.Values(s)
+		e = e.Values(s)
 	}
 	return e
 }
@@ -305,7 +338,7 @@ func (e *SVGFeColorMatrixElement) IfValues(condition bool, s string) *SVGFeColor
 // The list of one or more numbers that represent the matrix.
 func (e *SVGFeColorMatrixElement) IfValuesf(condition bool, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.Values(fmt.Sprintf(format, args...))
+		e = e.Values(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -313,19 +346,15 @@ func (e *SVGFeColorMatrixElement) IfValuesf(condition bool, format string, args
 // The list of one or more numbers that represent the matrix.
 // Remove the attribute Values from the element.
 func (e *SVGFeColorMatrixElement) ValuesRemove() *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("values")
+	e = e.writable()
+	e.removeAttribute("values")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeColorMatrixElement) ID(s string) *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -337,7 +366,7 @@ func (e *SVGFeColorMatrixElement) IDf(format string, args ...any) *SVGFeColorMat
 // Specifies a unique id for an element
 func (e *SVGFeColorMatrixElement) IfID(condition bool, s string) *SVGFeColorMatrixElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -345,7 +374,7 @@ func (e *SVGFeColorMatrixElement) IfID(condition bool, s string) *SVGFeColorMatr
 // Specifies a unique id for an element
 func (e *SVGFeColorMatrixElement) IfIDf(condition bool, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -353,26 +382,17 @@ func (e *SVGFeColorMatrixElement) IfIDf(condition bool, format string, args ...a
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeColorMatrixElement) IDRemove() *SVGFeColorMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeColorMatrixElement) Class(s string) *SVGFeColorMatrixElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -380,7 +400,7 @@ func (e *SVGFeColorMatrixElement) Class(s string) *SVGFeColorMatrixElement {
 // sheet)
 func (e *SVGFeColorMatrixElement) IfClass(condition bool, s string) *SVGFeColorMatrixElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -389,14 +409,8 @@ func (e *SVGFeColorMatrixElement) IfClass(condition bool, s string) *SVGFeColorM
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeColorMatrixElement) ClassRemove(s ...string) *SVGFeColorMatrixElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -405,14 +419,8 @@ func (e *SVGFeColorMatrixElement) StylePairs(pairs ...string) *SVGFeColorMatrixE
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -426,14 +434,8 @@ func (e *SVGFeColorMatrixElement) StylePairs(pairs ...string) *SVGFeColorMatrixE
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeColorMatrixElement) Style(s string) *SVGFeColorMatrixElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -441,7 +443,7 @@ func (e *SVGFeColorMatrixElement) Style(s string) *SVGFeColorMatrixElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -449,22 +451,14 @@ func (e *SVGFeColorMatrixElement) Style(s string) *SVGFeColorMatrixElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeColorMatrixElement) IfStyle(condition bool, s string) *SVGFeColorMatrixElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeColorMatrixElement) StyleAdd(k string, v string) *SVGFeColorMatrixElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -476,7 +470,7 @@ func (e *SVGFeColorMatrixElement) StyleAddf(k string, format string, args ...any
 // Specifies an inline CSS style for an element
 func (e *SVGFeColorMatrixElement) IfStyleAdd(condition bool, k string, v string) *SVGFeColorMatrixElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -484,7 +478,7 @@ func (e *SVGFeColorMatrixElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGFeColorMatrixElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeColorMatrixElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -492,21 +486,15 @@ func (e *SVGFeColorMatrixElement) IfStyleAddf(condition bool, k string, format s
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeColorMatrixElement) StyleMap(m map[string]string) *SVGFeColorMatrixElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -514,13 +502,7 @@ func (e *SVGFeColorMatrixElement) StyleMap(m map[string]string) *SVGFeColorMatri
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeColorMatrixElement) StyleRemove(keys ...string) *SVGFeColorMatrixElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
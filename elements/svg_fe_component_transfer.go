@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feComponentTransfer> SVG filter primitive performs color-component-wise
@@ -23,18 +21,49 @@ type SVGFeComponentTransferElement struct {
 // with the tag "feComponentTransfer" during rendering.
 func SVGFeComponentTransfer(children ...ElementRenderer) *SVGFeComponentTransferElement {
 	e := NewElement("feComponentTransfer", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeComponentTransferElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeComponentTransferElement) Clone() *SVGFeComponentTransferElement {
+	return &SVGFeComponentTransferElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeComponentTransferElement) CloneShallow() *SVGFeComponentTransferElement {
+	return &SVGFeComponentTransferElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeComponentTransferElement) Freeze() *SVGFeComponentTransferElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeComponentTransferElement) writable() *SVGFeComponentTransferElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeComponentTransferElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeComponentTransferElement) Children(children ...ElementRenderer) *SVGFeComponentTransferElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeComponentTransferElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGFeComponentTransferElement) IfChildren(condition bool, children ...E
 
 func (e *SVGFeComponentTransferElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeComponentTransferElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) BoolAttr(name string) *SVGFeComponentTransferElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) BoolAttrRemove(name string) *SVGFeComponentTransferElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfBoolAttr(condition bool, name string) *SVGFeComponentTransferElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGFeComponentTransferElement) BoolAttrf(format string, args ...any) *S
 
 func (e *SVGFeComponentTransferElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) BoolAttrs(names ...string) *SVGFeComponentTransferElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfBoolAttrs(condition bool, names ...string) *SVGFeComponentTransferElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) Attr(name, value string) *SVGFeComponentTransferElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeComponentTransferElement) RawAttr(name, value string) *SVGFeComponentTransferElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfAttr(condition bool, name, value string) *SVGFeComponentTransferElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGFeComponentTransferElement) Attrf(name, format string, args ...any)
 
 func (e *SVGFeComponentTransferElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) Attrs(attrs ...string) *SVGFeComponentTransferElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfAttrs(condition bool, attrs ...string) *SVGFeComponentTransferElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) AttrsMap(attrs map[string]string) *SVGFeComponentTransferElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeComponentTransferElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) Text(text string) *SVGFeComponentTransferElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) Textf(format string, args ...any) *SVGFeComponentTransferElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfText(condition bool, text string) *SVGFeComponentTransferElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGFeComponentTransferElement) IfText(condition bool, text string) *SVG
 
 func (e *SVGFeComponentTransferElement) IfTextf(condition bool, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeComponentTransferElement) Raw(text string) *SVGFeComponentTransferElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeComponentTransferElement) Escaped(text string) *SVGFeComponentTransferElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeComponentTransferElement) IfEscaped(condition bool, text string) *SVGFeComponentTransferElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGFeComponentTransferElement) Escapedf(format string, args ...any) *SV
 
 func (e *SVGFeComponentTransferElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +243,8 @@ func (e *SVGFeComponentTransferElement) IfEscapedf(condition bool, format string
 
 // The input for this filter.
 func (e *SVGFeComponentTransferElement) In(s string) *SVGFeComponentTransferElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -225,7 +256,7 @@ func (e *SVGFeComponentTransferElement) Inf(format string, args ...any) *SVGFeCo
 // The input for this filter.
 func (e *SVGFeComponentTransferElement) IfIn(condition bool, s string) *SVGFeComponentTransferElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -233,7 +264,7 @@ func (e *SVGFeComponentTransferElement) IfIn(condition bool, s string) *SVGFeCom
 // The input for this filter.
 func (e *SVGFeComponentTransferElement) IfInf(condition bool, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +272,15 @@ func (e *SVGFeComponentTransferElement) IfInf(condition bool, format string, arg
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeComponentTransferElement) InRemove() *SVGFeComponentTransferElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeComponentTransferElement) ID(s string) *SVGFeComponentTransferElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -265,7 +292,7 @@ func (e *SVGFeComponentTransferElement) IDf(format string, args ...any) *SVGFeCo
 // Specifies a unique id for an element
 func (e *SVGFeComponentTransferElement) IfID(condition bool, s string) *SVGFeComponentTransferElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -273,7 +300,7 @@ func (e *SVGFeComponentTransferElement) IfID(condition bool, s string) *SVGFeCom
 // Specifies a unique id for an element
 func (e *SVGFeComponentTransferElement) IfIDf(condition bool, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,26 +308,17 @@ func (e *SVGFeComponentTransferElement) IfIDf(condition bool, format string, arg
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeComponentTransferElement) IDRemove() *SVGFeComponentTransferElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeComponentTransferElement) Class(s string) *SVGFeComponentTransferElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -308,7 +326,7 @@ func (e *SVGFeComponentTransferElement) Class(s string) *SVGFeComponentTransferE
 // sheet)
 func (e *SVGFeComponentTransferElement) IfClass(condition bool, s string) *SVGFeComponentTransferElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -317,14 +335,8 @@ func (e *SVGFeComponentTransferElement) IfClass(condition bool, s string) *SVGFe
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeComponentTransferElement) ClassRemove(s ...string) *SVGFeComponentTransferElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -333,14 +345,8 @@ func (e *SVGFeComponentTransferElement) StylePairs(pairs ...string) *SVGFeCompon
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -354,14 +360,8 @@ func (e *SVGFeComponentTransferElement) StylePairs(pairs ...string) *SVGFeCompon
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeComponentTransferElement) Style(s string) *SVGFeComponentTransferElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -369,7 +369,7 @@ func (e *SVGFeComponentTransferElement) Style(s string) *SVGFeComponentTransferE
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -377,22 +377,14 @@ func (e *SVGFeComponentTransferElement) Style(s string) *SVGFeComponentTransferE
 // Specifies an inline CSS style for an element
 func (e *SVGFeComponentTransferElement) IfStyle(condition bool, s string) *SVGFeComponentTransferElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeComponentTransferElement) StyleAdd(k string, v string) *SVGFeComponentTransferElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -404,7 +396,7 @@ func (e *SVGFeComponentTransferElement) StyleAddf(k string, format string, args
 // Specifies an inline CSS style for an element
 func (e *SVGFeComponentTransferElement) IfStyleAdd(condition bool, k string, v string) *SVGFeComponentTransferElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -412,7 +404,7 @@ func (e *SVGFeComponentTransferElement) IfStyleAdd(condition bool, k string, v s
 // Specifies an inline CSS style for an element
 func (e *SVGFeComponentTransferElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeComponentTransferElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -420,21 +412,15 @@ func (e *SVGFeComponentTransferElement) IfStyleAddf(condition bool, k string, fo
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeComponentTransferElement) StyleMap(m map[string]string) *SVGFeComponentTransferElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -442,13 +428,7 @@ func (e *SVGFeComponentTransferElement) StyleMap(m map[string]string) *SVGFeComp
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeComponentTransferElement) StyleRemove(keys ...string) *SVGFeComponentTransferElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feComposite> SVG filter primitive performs the combination of two input
@@ -23,18 +21,59 @@ type SVGFeCompositeElement struct {
 // with the tag "feComposite" during rendering.
 func SVGFeComposite(children ...ElementRenderer) *SVGFeCompositeElement {
 	e := NewElement("feComposite", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeCompositeElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeCompositeElement) Clone() *SVGFeCompositeElement {
+	return &SVGFeCompositeElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeCompositeElement) CloneShallow() *SVGFeCompositeElement {
+	return &SVGFeCompositeElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeCompositeElement) Freeze() *SVGFeCompositeElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeCompositeElement) writable() *SVGFeCompositeElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeCompositeElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeCompositeElement) choiceType(name string) func(string) any {
+	switch name {
+	case "operator":
+		return choiceValue[SVGFeCompositeOperatorChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeCompositeElement) Children(children ...ElementRenderer) *SVGFeCompositeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeCompositeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGFeCompositeElement) IfChildren(condition bool, children ...ElementRe
 
 func (e *SVGFeCompositeElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeCompositeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) BoolAttr(name string) *SVGFeCompositeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeCompositeElement) BoolAttrRemove(name string) *SVGFeCompositeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfBoolAttr(condition bool, name string) *SVGFeCompositeElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGFeCompositeElement) BoolAttrf(format string, args ...any) *SVGFeComp
 
 func (e *SVGFeCompositeElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) BoolAttrs(names ...string) *SVGFeCompositeElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfBoolAttrs(condition bool, names ...string) *SVGFeCompositeElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) Attr(name, value string) *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeCompositeElement) RawAttr(name, value string) *SVGFeCompositeElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfAttr(condition bool, name, value string) *SVGFeCompositeElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGFeCompositeElement) Attrf(name, format string, args ...any) *SVGFeCo
 
 func (e *SVGFeCompositeElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) Attrs(attrs ...string) *SVGFeCompositeElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfAttrs(condition bool, attrs ...string) *SVGFeCompositeElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) AttrsMap(attrs map[string]string) *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeCompositeElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeCompositeElement) Text(text string) *SVGFeCompositeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeCompositeElement) Textf(format string, args ...any) *SVGFeCompositeElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeCompositeElement) IfText(condition bool, text string) *SVGFeCompositeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGFeCompositeElement) IfText(condition bool, text string) *SVGFeCompos
 
 func (e *SVGFeCompositeElement) IfTextf(condition bool, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeCompositeElement) Raw(text string) *SVGFeCompositeElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeCompositeElement) Escaped(text string) *SVGFeCompositeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeCompositeElement) IfEscaped(condition bool, text string) *SVGFeCompositeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGFeCompositeElement) Escapedf(format string, args ...any) *SVGFeCompo
 
 func (e *SVGFeCompositeElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +253,8 @@ func (e *SVGFeCompositeElement) IfEscapedf(condition bool, format string, args .
 
 // Input for the compositing operation.
 func (e *SVGFeCompositeElement) In(s string) *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -225,7 +266,7 @@ func (e *SVGFeCompositeElement) Inf(format string, args ...any) *SVGFeCompositeE
 // Input for the compositing operation.
 func (e *SVGFeCompositeElement) IfIn(condition bool, s string) *SVGFeCompositeElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -233,7 +274,7 @@ func (e *SVGFeCompositeElement) IfIn(condition bool, s string) *SVGFeCompositeEl
 // Input for the compositing operation.
 func (e *SVGFeCompositeElement) IfInf(condition bool, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +282,15 @@ func (e *SVGFeCompositeElement) IfInf(condition bool, format string, args ...any
 // Input for the compositing operation.
 // Remove the attribute In from the element.
 func (e *SVGFeCompositeElement) InRemove() *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // Second input for the compositing operation.
 func (e *SVGFeCompositeElement) In2(s string) *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in2", s)
+	e = e.writable()
+	e.setStringAttribute("in2", s)
 	return e
 }
 
@@ -265,7 +302,7 @@ func (e *SVGFeCompositeElement) In2f(format string, args ...any) *SVGFeComposite
 // Second input for the compositing operation.
 func (e *SVGFeCompositeElement) IfIn2(condition bool, s string) *SVGFeCompositeElement {
 	if condition {
-		e.In2(s)
+		e = e.In2(s)
 	}
 	return e
 }
@@ -273,7 +310,7 @@ func (e *SVGFeCompositeElement) IfIn2(condition bool, s string) *SVGFeCompositeE
 // Second input for the compositing operation.
 func (e *SVGFeCompositeElement) IfIn2f(condition bool, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.In2(fmt.Sprintf(format, args...))
+		e = e.In2(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,19 +318,15 @@ func (e *SVGFeCompositeElement) IfIn2f(condition bool, format string, args ...an
 // Second input for the compositing operation.
 // Remove the attribute In2 from the element.
 func (e *SVGFeCompositeElement) In2Remove() *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in2")
+	e = e.writable()
+	e.removeAttribute("in2")
 	return e
 }
 
 // The type of compositing operation.
 func (e *SVGFeCompositeElement) Operator(c SVGFeCompositeOperatorChoice) *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("operator", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("operator", string(c), choiceValue[SVGFeCompositeOperatorChoice])
 	return e
 }
 
@@ -321,87 +354,75 @@ const (
 // The type of compositing operation.
 // Remove the attribute Operator from the element.
 func (e *SVGFeCompositeElement) OperatorRemove() *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("operator")
+	e = e.writable()
+	e.removeAttribute("operator")
 	return e
 }
 
 // First value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) K1(f float64) *SVGFeCompositeElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("k1", f)
+	e = e.writable()
+	e.setFloatAttribute("k1", f, 0)
 	return e
 }
 
 // First value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) IfK1(condition bool, f float64) *SVGFeCompositeElement {
 	if condition {
-		e.K1(f)
+		e = e.K1(f)
 	}
 	return e
 }
 
 // Second value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) K2(f float64) *SVGFeCompositeElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("k2", f)
+	e = e.writable()
+	e.setFloatAttribute("k2", f, 0)
 	return e
 }
 
 // Second value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) IfK2(condition bool, f float64) *SVGFeCompositeElement {
 	if condition {
-		e.K2(f)
+		e = e.K2(f)
 	}
 	return e
 }
 
 // Third value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) K3(f float64) *SVGFeCompositeElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("k3", f)
+	e = e.writable()
+	e.setFloatAttribute("k3", f, 0)
 	return e
 }
 
 // Third value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) IfK3(condition bool, f float64) *SVGFeCompositeElement {
 	if condition {
-		e.K3(f)
+		e = e.K3(f)
 	}
 	return e
 }
 
 // Fourth value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) K4(f float64) *SVGFeCompositeElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("k4", f)
+	e = e.writable()
+	e.setFloatAttribute("k4", f, 0)
 	return e
 }
 
 // Fourth value to use in the arithmetic operation.
 func (e *SVGFeCompositeElement) IfK4(condition bool, f float64) *SVGFeCompositeElement {
 	if condition {
-		e.K4(f)
+		e = e.K4(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeCompositeElement) ID(s string) *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -413,7 +434,7 @@ func (e *SVGFeCompositeElement) IDf(format string, args ...any) *SVGFeCompositeE
 // Specifies a unique id for an element
 func (e *SVGFeCompositeElement) IfID(condition bool, s string) *SVGFeCompositeElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -421,7 +442,7 @@ func (e *SVGFeCompositeElement) IfID(condition bool, s string) *SVGFeCompositeEl
 // Specifies a unique id for an element
 func (e *SVGFeCompositeElement) IfIDf(condition bool, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -429,26 +450,17 @@ func (e *SVGFeCompositeElement) IfIDf(condition bool, format string, args ...any
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeCompositeElement) IDRemove() *SVGFeCompositeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeCompositeElement) Class(s string) *SVGFeCompositeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -456,7 +468,7 @@ func (e *SVGFeCompositeElement) Class(s string) *SVGFeCompositeElement {
 // sheet)
 func (e *SVGFeCompositeElement) IfClass(condition bool, s string) *SVGFeCompositeElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -465,14 +477,8 @@ func (e *SVGFeCompositeElement) IfClass(condition bool, s string) *SVGFeComposit
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeCompositeElement) ClassRemove(s ...string) *SVGFeCompositeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -481,14 +487,8 @@ func (e *SVGFeCompositeElement) StylePairs(pairs ...string) *SVGFeCompositeEleme
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -502,14 +502,8 @@ func (e *SVGFeCompositeElement) StylePairs(pairs ...string) *SVGFeCompositeEleme
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeCompositeElement) Style(s string) *SVGFeCompositeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -517,7 +511,7 @@ func (e *SVGFeCompositeElement) Style(s string) *SVGFeCompositeElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -525,22 +519,14 @@ func (e *SVGFeCompositeElement) Style(s string) *SVGFeCompositeElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeCompositeElement) IfStyle(condition bool, s string) *SVGFeCompositeElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeCompositeElement) StyleAdd(k string, v string) *SVGFeCompositeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -552,7 +538,7 @@ func (e *SVGFeCompositeElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFeCompositeElement) IfStyleAdd(condition bool, k string, v string) *SVGFeCompositeElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -560,7 +546,7 @@ func (e *SVGFeCompositeElement) IfStyleAdd(condition bool, k string, v string) *
 // Specifies an inline CSS style for an element
 func (e *SVGFeCompositeElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeCompositeElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -568,21 +554,15 @@ func (e *SVGFeCompositeElement) IfStyleAddf(condition bool, k string, format str
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeCompositeElement) StyleMap(m map[string]string) *SVGFeCompositeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -590,13 +570,7 @@ func (e *SVGFeCompositeElement) StyleMap(m map[string]string) *SVGFeCompositeEle
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeCompositeElement) StyleRemove(keys ...string) *SVGFeCompositeElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
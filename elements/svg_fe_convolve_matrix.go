@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feConvolveMatrix> SVG filter primitive applies a matrix convolution
@@ -22,18 +20,59 @@ type SVGFeConvolveMatrixElement struct {
 // with the tag "feConvolveMatrix" during rendering.
 func SVGFeConvolveMatrix(children ...ElementRenderer) *SVGFeConvolveMatrixElement {
 	e := NewElement("feConvolveMatrix", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeConvolveMatrixElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeConvolveMatrixElement) Clone() *SVGFeConvolveMatrixElement {
+	return &SVGFeConvolveMatrixElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeConvolveMatrixElement) CloneShallow() *SVGFeConvolveMatrixElement {
+	return &SVGFeConvolveMatrixElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeConvolveMatrixElement) Freeze() *SVGFeConvolveMatrixElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeConvolveMatrixElement) writable() *SVGFeConvolveMatrixElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeConvolveMatrixElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeConvolveMatrixElement) choiceType(name string) func(string) any {
+	switch name {
+	case "edgeMode":
+		return choiceValue[SVGFeConvolveMatrixEdgeModeChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeConvolveMatrixElement) Children(children ...ElementRenderer) *SVGFeConvolveMatrixElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeConvolveMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +80,30 @@ func (e *SVGFeConvolveMatrixElement) IfChildren(condition bool, children ...Elem
 
 func (e *SVGFeConvolveMatrixElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeConvolveMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) BoolAttr(name string) *SVGFeConvolveMatrixElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) BoolAttrRemove(name string) *SVGFeConvolveMatrixElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfBoolAttr(condition bool, name string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +114,40 @@ func (e *SVGFeConvolveMatrixElement) BoolAttrf(format string, args ...any) *SVGF
 
 func (e *SVGFeConvolveMatrixElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) BoolAttrs(names ...string) *SVGFeConvolveMatrixElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfBoolAttrs(condition bool, names ...string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) Attr(name, value string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeConvolveMatrixElement) RawAttr(name, value string) *SVGFeConvolveMatrixElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfAttr(condition bool, name, value string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +158,52 @@ func (e *SVGFeConvolveMatrixElement) Attrf(name, format string, args ...any) *SV
 
 func (e *SVGFeConvolveMatrixElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) Attrs(attrs ...string) *SVGFeConvolveMatrixElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfAttrs(condition bool, attrs ...string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) AttrsMap(attrs map[string]string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) Text(text string) *SVGFeConvolveMatrixElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) Textf(format string, args ...any) *SVGFeConvolveMatrixElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfText(condition bool, text string) *SVGFeConvolveMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +211,28 @@ func (e *SVGFeConvolveMatrixElement) IfText(condition bool, text string) *SVGFeC
 
 func (e *SVGFeConvolveMatrixElement) IfTextf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeConvolveMatrixElement) Raw(text string) *SVGFeConvolveMatrixElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeConvolveMatrixElement) Escaped(text string) *SVGFeConvolveMatrixElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeConvolveMatrixElement) IfEscaped(condition bool, text string) *SVGFeConvolveMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +244,7 @@ func (e *SVGFeConvolveMatrixElement) Escapedf(format string, args ...any) *SVGFe
 
 func (e *SVGFeConvolveMatrixElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +252,8 @@ func (e *SVGFeConvolveMatrixElement) IfEscapedf(condition bool, format string, a
 
 // The input for this filter.
 func (e *SVGFeConvolveMatrixElement) In(s string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -224,7 +265,7 @@ func (e *SVGFeConvolveMatrixElement) Inf(format string, args ...any) *SVGFeConvo
 // The input for this filter.
 func (e *SVGFeConvolveMatrixElement) IfIn(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -232,7 +273,7 @@ func (e *SVGFeConvolveMatrixElement) IfIn(condition bool, s string) *SVGFeConvol
 // The input for this filter.
 func (e *SVGFeConvolveMatrixElement) IfInf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +281,15 @@ func (e *SVGFeConvolveMatrixElement) IfInf(condition bool, format string, args .
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeConvolveMatrixElement) InRemove() *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // The number of cells in each dimension for 'kernelMatrix'
 func (e *SVGFeConvolveMatrixElement) Order(s string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("order", s)
+	e = e.writable()
+	e.setStringAttribute("order", s)
 	return e
 }
 
@@ -264,7 +301,7 @@ func (e *SVGFeConvolveMatrixElement) Orderf(format string, args ...any) *SVGFeCo
 // The number of cells in each dimension for 'kernelMatrix'
 func (e *SVGFeConvolveMatrixElement) IfOrder(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Order(s)
+		e = e.Order(s)
 	}
 	return e
 }
@@ -272,7 +309,7 @@ func (e *SVGFeConvolveMatrixElement) IfOrder(condition bool, s string) *SVGFeCon
 // The number of cells in each dimension for 'kernelMatrix'
 func (e *SVGFeConvolveMatrixElement) IfOrderf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Order(fmt.Sprintf(format, args...))
+		e = e.Order(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,19 +317,15 @@ func (e *SVGFeConvolveMatrixElement) IfOrderf(condition bool, format string, arg
 // The number of cells in each dimension for 'kernelMatrix'
 // Remove the attribute Order from the element.
 func (e *SVGFeConvolveMatrixElement) OrderRemove() *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("order")
+	e = e.writable()
+	e.removeAttribute("order")
 	return e
 }
 
 // A list of numbers that make up the kernel matrix for the convolution.
 func (e *SVGFeConvolveMatrixElement) KernelMatrix(s string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("kernelMatrix", s)
+	e = e.writable()
+	e.setStringAttribute("kernelMatrix", s)
 	return e
 }
 
@@ -304,7 +337,7 @@ func (e *SVGFeConvolveMatrixElement) KernelMatrixf(format string, args ...any) *
 // A list of numbers that make up the kernel matrix for the convolution.
 func (e *SVGFeConvolveMatrixElement) IfKernelMatrix(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.KernelMatrix(s)
+		e = e.KernelMatrix(s)
 	}
 	return e
 }
@@ -312,7 +345,7 @@ func (e *SVGFeConvolveMatrixElement) IfKernelMatrix(condition bool, s string) *S
 // A list of numbers that make up the kernel matrix for the convolution.
 func (e *SVGFeConvolveMatrixElement) IfKernelMatrixf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.KernelMatrix(fmt.Sprintf(format, args...))
+		e = e.KernelMatrix(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -320,20 +353,16 @@ func (e *SVGFeConvolveMatrixElement) IfKernelMatrixf(condition bool, format stri
 // A list of numbers that make up the kernel matrix for the convolution.
 // Remove the attribute KernelMatrix from the element.
 func (e *SVGFeConvolveMatrixElement) KernelMatrixRemove() *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("kernelMatrix")
+	e = e.writable()
+	e.removeAttribute("kernelMatrix")
 	return e
 }
 
 // The divisor Attribute specifies the value by which to divide the result of
 // applying the convolution operator.
 func (e *SVGFeConvolveMatrixElement) Divisor(f float64) *SVGFeConvolveMatrixElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("divisor", f)
+	e = e.writable()
+	e.setFloatAttribute("divisor", f, 0)
 	return e
 }
 
@@ -341,7 +370,7 @@ func (e *SVGFeConvolveMatrixElement) Divisor(f float64) *SVGFeConvolveMatrixElem
 // applying the convolution operator.
 func (e *SVGFeConvolveMatrixElement) IfDivisor(condition bool, f float64) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Divisor(f)
+		e = e.Divisor(f)
 	}
 	return e
 }
@@ -349,10 +378,8 @@ func (e *SVGFeConvolveMatrixElement) IfDivisor(condition bool, f float64) *SVGFe
 // The bias Attribute shifts the range of the filter. After applying the matrix
 // operation, this bias value is added to each component.
 func (e *SVGFeConvolveMatrixElement) Bias(f float64) *SVGFeConvolveMatrixElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("bias", f)
+	e = e.writable()
+	e.setFloatAttribute("bias", f, 0)
 	return e
 }
 
@@ -360,7 +387,7 @@ func (e *SVGFeConvolveMatrixElement) Bias(f float64) *SVGFeConvolveMatrixElement
 // operation, this bias value is added to each component.
 func (e *SVGFeConvolveMatrixElement) IfBias(condition bool, f float64) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Bias(f)
+		e = e.Bias(f)
 	}
 	return e
 }
@@ -368,10 +395,8 @@ func (e *SVGFeConvolveMatrixElement) IfBias(condition bool, f float64) *SVGFeCon
 // The targetX Attribute determines the positioning in X of the convolution
 // matrix relative to a given target pixel in the input image.
 func (e *SVGFeConvolveMatrixElement) TargetX(f float64) *SVGFeConvolveMatrixElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("targetX", f)
+	e = e.writable()
+	e.setFloatAttribute("targetX", f, 0)
 	return e
 }
 
@@ -379,7 +404,7 @@ func (e *SVGFeConvolveMatrixElement) TargetX(f float64) *SVGFeConvolveMatrixElem
 // matrix relative to a given target pixel in the input image.
 func (e *SVGFeConvolveMatrixElement) IfTargetX(condition bool, f float64) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.TargetX(f)
+		e = e.TargetX(f)
 	}
 	return e
 }
@@ -387,10 +412,8 @@ func (e *SVGFeConvolveMatrixElement) IfTargetX(condition bool, f float64) *SVGFe
 // The targetY Attribute determines the positioning in Y of the convolution
 // matrix relative to a given target pixel in the input image.
 func (e *SVGFeConvolveMatrixElement) TargetY(f float64) *SVGFeConvolveMatrixElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("targetY", f)
+	e = e.writable()
+	e.setFloatAttribute("targetY", f, 0)
 	return e
 }
 
@@ -398,7 +421,7 @@ func (e *SVGFeConvolveMatrixElement) TargetY(f float64) *SVGFeConvolveMatrixElem
 // matrix relative to a given target pixel in the input image.
 func (e *SVGFeConvolveMatrixElement) IfTargetY(condition bool, f float64) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.TargetY(f)
+		e = e.TargetY(f)
 	}
 	return e
 }
@@ -407,10 +430,8 @@ func (e *SVGFeConvolveMatrixElement) IfTargetY(condition bool, f float64) *SVGFe
 // with color values so that the matrix operations can be applied when the
 // kernel is positioned at or near the edge of the input image.
 func (e *SVGFeConvolveMatrixElement) EdgeMode(c SVGFeConvolveMatrixEdgeModeChoice) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("edgeMode", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("edgeMode", string(c), choiceValue[SVGFeConvolveMatrixEdgeModeChoice])
 	return e
 }
 
@@ -432,10 +453,8 @@ const (
 // kernel is positioned at or near the edge of the input image.
 // Remove the attribute EdgeMode from the element.
 func (e *SVGFeConvolveMatrixElement) EdgeModeRemove() *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("edgeMode")
+	e = e.writable()
+	e.removeAttribute("edgeMode")
 	return e
 }
 
@@ -443,10 +462,8 @@ func (e *SVGFeConvolveMatrixElement) EdgeModeRemove() *SVGFeConvolveMatrixElemen
 // filter units (i.e., units as determined by the value of Attribute
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeConvolveMatrixElement) KernelUnitLength(s string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("kernelUnitLength", s)
+	e = e.writable()
+	e.setStringAttribute("kernelUnitLength", s)
 	return e
 }
 
@@ -462,7 +479,7 @@ func (e *SVGFeConvolveMatrixElement) KernelUnitLengthf(format string, args ...an
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeConvolveMatrixElement) IfKernelUnitLength(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.KernelUnitLength(s)
+		e = e.KernelUnitLength(s)
 	}
 	return e
 }
@@ -472,7 +489,7 @@ func (e *SVGFeConvolveMatrixElement) IfKernelUnitLength(condition bool, s string
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeConvolveMatrixElement) IfKernelUnitLengthf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.KernelUnitLength(fmt.Sprintf(format, args...))
+		e = e.KernelUnitLength(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -482,20 +499,16 @@ func (e *SVGFeConvolveMatrixElement) IfKernelUnitLengthf(condition bool, format
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 // Remove the attribute KernelUnitLength from the element.
 func (e *SVGFeConvolveMatrixElement) KernelUnitLengthRemove() *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("kernelUnitLength")
+	e = e.writable()
+	e.removeAttribute("kernelUnitLength")
 	return e
 }
 
 // The preserveAlpha Attribute indicates how the convolution will handle the
 // alpha channel of the input image.
 func (e *SVGFeConvolveMatrixElement) PreserveAlpha() *SVGFeConvolveMatrixElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("preserveAlpha", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("preserveAlpha")
 	return e
 }
 
@@ -503,7 +516,7 @@ func (e *SVGFeConvolveMatrixElement) PreserveAlpha() *SVGFeConvolveMatrixElement
 // alpha channel of the input image.
 func (e *SVGFeConvolveMatrixElement) IfPreserveAlpha(condition bool) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.PreserveAlpha()
+		e = e.PreserveAlpha()
 	}
 	return e
 }
@@ -512,10 +525,8 @@ func (e *SVGFeConvolveMatrixElement) IfPreserveAlpha(condition bool) *SVGFeConvo
 // The preserveAlpha Attribute indicates how the convolution will handle the
 // alpha channel of the input image.
 func (e *SVGFeConvolveMatrixElement) PreserveAlphaRemove() *SVGFeConvolveMatrixElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("preserveAlpha")
+	e = e.writable()
+	e.removeAttribute("preserveAlpha")
 	return e
 }
 
@@ -523,19 +534,15 @@ func (e *SVGFeConvolveMatrixElement) PreserveAlphaRemove() *SVGFeConvolveMatrixE
 // The preserveAlpha Attribute indicates how the convolution will handle the
 // alpha channel of the input image.
 func (e *SVGFeConvolveMatrixElement) PreserveAlphaIfRemove() *SVGFeConvolveMatrixElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("preserveAlpha")
+	e = e.writable()
+	e.removeAttribute("preserveAlpha")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeConvolveMatrixElement) ID(s string) *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -547,7 +554,7 @@ func (e *SVGFeConvolveMatrixElement) IDf(format string, args ...any) *SVGFeConvo
 // Specifies a unique id for an element
 func (e *SVGFeConvolveMatrixElement) IfID(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -555,7 +562,7 @@ func (e *SVGFeConvolveMatrixElement) IfID(condition bool, s string) *SVGFeConvol
 // Specifies a unique id for an element
 func (e *SVGFeConvolveMatrixElement) IfIDf(condition bool, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -563,26 +570,17 @@ func (e *SVGFeConvolveMatrixElement) IfIDf(condition bool, format string, args .
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeConvolveMatrixElement) IDRemove() *SVGFeConvolveMatrixElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeConvolveMatrixElement) Class(s string) *SVGFeConvolveMatrixElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -590,7 +588,7 @@ func (e *SVGFeConvolveMatrixElement) Class(s string) *SVGFeConvolveMatrixElement
 // sheet)
 func (e *SVGFeConvolveMatrixElement) IfClass(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -599,14 +597,8 @@ func (e *SVGFeConvolveMatrixElement) IfClass(condition bool, s string) *SVGFeCon
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeConvolveMatrixElement) ClassRemove(s ...string) *SVGFeConvolveMatrixElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -615,14 +607,8 @@ func (e *SVGFeConvolveMatrixElement) StylePairs(pairs ...string) *SVGFeConvolveM
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -636,14 +622,8 @@ func (e *SVGFeConvolveMatrixElement) StylePairs(pairs ...string) *SVGFeConvolveM
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeConvolveMatrixElement) Style(s string) *SVGFeConvolveMatrixElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -651,7 +631,7 @@ func (e *SVGFeConvolveMatrixElement) Style(s string) *SVGFeConvolveMatrixElement
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -659,22 +639,14 @@ func (e *SVGFeConvolveMatrixElement) Style(s string) *SVGFeConvolveMatrixElement
 // Specifies an inline CSS style for an element
 func (e *SVGFeConvolveMatrixElement) IfStyle(condition bool, s string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeConvolveMatrixElement) StyleAdd(k string, v string) *SVGFeConvolveMatrixElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -686,7 +658,7 @@ func (e *SVGFeConvolveMatrixElement) StyleAddf(k string, format string, args ...
 // Specifies an inline CSS style for an element
 func (e *SVGFeConvolveMatrixElement) IfStyleAdd(condition bool, k string, v string) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -694,7 +666,7 @@ func (e *SVGFeConvolveMatrixElement) IfStyleAdd(condition bool, k string, v stri
 // Specifies an inline CSS style for an element
 func (e *SVGFeConvolveMatrixElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeConvolveMatrixElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -702,21 +674,15 @@ func (e *SVGFeConvolveMatrixElement) IfStyleAddf(condition bool, k string, forma
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeConvolveMatrixElement) StyleMap(m map[string]string) *SVGFeConvolveMatrixElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -724,13 +690,7 @@ func (e *SVGFeConvolveMatrixElement) StyleMap(m map[string]string) *SVGFeConvolv
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeConvolveMatrixElement) StyleRemove(keys ...string) *SVGFeConvolveMatrixElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feDiffuseLighting> SVG filter primitive lights an image using the alpha
@@ -24,18 +22,49 @@ type SVGFeDiffuseLightingElement struct {
 // with the tag "feDiffuseLighting" during rendering.
 func SVGFeDiffuseLighting(children ...ElementRenderer) *SVGFeDiffuseLightingElement {
 	e := NewElement("feDiffuseLighting", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeDiffuseLightingElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeDiffuseLightingElement) Clone() *SVGFeDiffuseLightingElement {
+	return &SVGFeDiffuseLightingElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeDiffuseLightingElement) CloneShallow() *SVGFeDiffuseLightingElement {
+	return &SVGFeDiffuseLightingElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeDiffuseLightingElement) Freeze() *SVGFeDiffuseLightingElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeDiffuseLightingElement) writable() *SVGFeDiffuseLightingElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeDiffuseLightingElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeDiffuseLightingElement) Children(children ...ElementRenderer) *SVGFeDiffuseLightingElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeDiffuseLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +72,30 @@ func (e *SVGFeDiffuseLightingElement) IfChildren(condition bool, children ...Ele
 
 func (e *SVGFeDiffuseLightingElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeDiffuseLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) BoolAttr(name string) *SVGFeDiffuseLightingElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) BoolAttrRemove(name string) *SVGFeDiffuseLightingElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfBoolAttr(condition bool, name string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +106,40 @@ func (e *SVGFeDiffuseLightingElement) BoolAttrf(format string, args ...any) *SVG
 
 func (e *SVGFeDiffuseLightingElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) BoolAttrs(names ...string) *SVGFeDiffuseLightingElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfBoolAttrs(condition bool, names ...string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) Attr(name, value string) *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeDiffuseLightingElement) RawAttr(name, value string) *SVGFeDiffuseLightingElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfAttr(condition bool, name, value string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +150,52 @@ func (e *SVGFeDiffuseLightingElement) Attrf(name, format string, args ...any) *S
 
 func (e *SVGFeDiffuseLightingElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) Attrs(attrs ...string) *SVGFeDiffuseLightingElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfAttrs(condition bool, attrs ...string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) AttrsMap(attrs map[string]string) *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) Text(text string) *SVGFeDiffuseLightingElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) Textf(format string, args ...any) *SVGFeDiffuseLightingElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfText(condition bool, text string) *SVGFeDiffuseLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +203,28 @@ func (e *SVGFeDiffuseLightingElement) IfText(condition bool, text string) *SVGFe
 
 func (e *SVGFeDiffuseLightingElement) IfTextf(condition bool, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeDiffuseLightingElement) Raw(text string) *SVGFeDiffuseLightingElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeDiffuseLightingElement) Escaped(text string) *SVGFeDiffuseLightingElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeDiffuseLightingElement) IfEscaped(condition bool, text string) *SVGFeDiffuseLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +236,7 @@ func (e *SVGFeDiffuseLightingElement) Escapedf(format string, args ...any) *SVGF
 
 func (e *SVGFeDiffuseLightingElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFeDiffuseLightingElement) IfEscapedf(condition bool, format string,
 
 // The input for this filter.
 func (e *SVGFeDiffuseLightingElement) In(s string) *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -226,7 +257,7 @@ func (e *SVGFeDiffuseLightingElement) Inf(format string, args ...any) *SVGFeDiff
 // The input for this filter.
 func (e *SVGFeDiffuseLightingElement) IfIn(condition bool, s string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -234,7 +265,7 @@ func (e *SVGFeDiffuseLightingElement) IfIn(condition bool, s string) *SVGFeDiffu
 // The input for this filter.
 func (e *SVGFeDiffuseLightingElement) IfInf(condition bool, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -242,20 +273,16 @@ func (e *SVGFeDiffuseLightingElement) IfInf(condition bool, format string, args
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeDiffuseLightingElement) InRemove() *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // The 'surfaceScale' Attribute indicates the height of the surface when the
 // alpha channel is 1.0.
 func (e *SVGFeDiffuseLightingElement) SurfaceScale(f float64) *SVGFeDiffuseLightingElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("surfaceScale", f)
+	e = e.writable()
+	e.setFloatAttribute("surfaceScale", f, 0)
 	return e
 }
 
@@ -263,7 +290,7 @@ func (e *SVGFeDiffuseLightingElement) SurfaceScale(f float64) *SVGFeDiffuseLight
 // alpha channel is 1.0.
 func (e *SVGFeDiffuseLightingElement) IfSurfaceScale(condition bool, f float64) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.SurfaceScale(f)
+		e = e.SurfaceScale(f)
 	}
 	return e
 }
@@ -271,10 +298,8 @@ func (e *SVGFeDiffuseLightingElement) IfSurfaceScale(condition bool, f float64)
 // The diffuseConstant Attribute represents the proportion of the light that is
 // reflected by the surface.
 func (e *SVGFeDiffuseLightingElement) DiffuseConstant(f float64) *SVGFeDiffuseLightingElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("diffuseConstant", f)
+	e = e.writable()
+	e.setFloatAttribute("diffuseConstant", f, 0)
 	return e
 }
 
@@ -282,7 +307,7 @@ func (e *SVGFeDiffuseLightingElement) DiffuseConstant(f float64) *SVGFeDiffuseLi
 // reflected by the surface.
 func (e *SVGFeDiffuseLightingElement) IfDiffuseConstant(condition bool, f float64) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.DiffuseConstant(f)
+		e = e.DiffuseConstant(f)
 	}
 	return e
 }
@@ -291,10 +316,8 @@ func (e *SVGFeDiffuseLightingElement) IfDiffuseConstant(condition bool, f float6
 // filter units (i.e., units as determined by the value of Attribute
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeDiffuseLightingElement) KernelUnitLength(s string) *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("kernelUnitLength", s)
+	e = e.writable()
+	e.setStringAttribute("kernelUnitLength", s)
 	return e
 }
 
@@ -310,7 +333,7 @@ func (e *SVGFeDiffuseLightingElement) KernelUnitLengthf(format string, args ...a
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeDiffuseLightingElement) IfKernelUnitLength(condition bool, s string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.KernelUnitLength(s)
+		e = e.KernelUnitLength(s)
 	}
 	return e
 }
@@ -320,7 +343,7 @@ func (e *SVGFeDiffuseLightingElement) IfKernelUnitLength(condition bool, s strin
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeDiffuseLightingElement) IfKernelUnitLengthf(condition bool, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.KernelUnitLength(fmt.Sprintf(format, args...))
+		e = e.KernelUnitLength(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -330,19 +353,15 @@ func (e *SVGFeDiffuseLightingElement) IfKernelUnitLengthf(condition bool, format
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 // Remove the attribute KernelUnitLength from the element.
 func (e *SVGFeDiffuseLightingElement) KernelUnitLengthRemove() *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("kernelUnitLength")
+	e = e.writable()
+	e.removeAttribute("kernelUnitLength")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeDiffuseLightingElement) ID(s string) *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -354,7 +373,7 @@ func (e *SVGFeDiffuseLightingElement) IDf(format string, args ...any) *SVGFeDiff
 // Specifies a unique id for an element
 func (e *SVGFeDiffuseLightingElement) IfID(condition bool, s string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -362,7 +381,7 @@ func (e *SVGFeDiffuseLightingElement) IfID(condition bool, s string) *SVGFeDiffu
 // Specifies a unique id for an element
 func (e *SVGFeDiffuseLightingElement) IfIDf(condition bool, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -370,26 +389,17 @@ func (e *SVGFeDiffuseLightingElement) IfIDf(condition bool, format string, args
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeDiffuseLightingElement) IDRemove() *SVGFeDiffuseLightingElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeDiffuseLightingElement) Class(s string) *SVGFeDiffuseLightingElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -397,7 +407,7 @@ func (e *SVGFeDiffuseLightingElement) Class(s string) *SVGFeDiffuseLightingEleme
 // sheet)
 func (e *SVGFeDiffuseLightingElement) IfClass(condition bool, s string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -406,14 +416,8 @@ func (e *SVGFeDiffuseLightingElement) IfClass(condition bool, s string) *SVGFeDi
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeDiffuseLightingElement) ClassRemove(s ...string) *SVGFeDiffuseLightingElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -422,14 +426,8 @@ func (e *SVGFeDiffuseLightingElement) StylePairs(pairs ...string) *SVGFeDiffuseL
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -443,14 +441,8 @@ func (e *SVGFeDiffuseLightingElement) StylePairs(pairs ...string) *SVGFeDiffuseL
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDiffuseLightingElement) Style(s string) *SVGFeDiffuseLightingElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -458,7 +450,7 @@ func (e *SVGFeDiffuseLightingElement) Style(s string) *SVGFeDiffuseLightingEleme
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -466,22 +458,14 @@ func (e *SVGFeDiffuseLightingElement) Style(s string) *SVGFeDiffuseLightingEleme
 // Specifies an inline CSS style for an element
 func (e *SVGFeDiffuseLightingElement) IfStyle(condition bool, s string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDiffuseLightingElement) StyleAdd(k string, v string) *SVGFeDiffuseLightingElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -493,7 +477,7 @@ func (e *SVGFeDiffuseLightingElement) StyleAddf(k string, format string, args ..
 // Specifies an inline CSS style for an element
 func (e *SVGFeDiffuseLightingElement) IfStyleAdd(condition bool, k string, v string) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -501,7 +485,7 @@ func (e *SVGFeDiffuseLightingElement) IfStyleAdd(condition bool, k string, v str
 // Specifies an inline CSS style for an element
 func (e *SVGFeDiffuseLightingElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeDiffuseLightingElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -509,21 +493,15 @@ func (e *SVGFeDiffuseLightingElement) IfStyleAddf(condition bool, k string, form
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeDiffuseLightingElement) StyleMap(m map[string]string) *SVGFeDiffuseLightingElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -531,13 +509,7 @@ func (e *SVGFeDiffuseLightingElement) StyleMap(m map[string]string) *SVGFeDiffus
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeDiffuseLightingElement) StyleRemove(keys ...string) *SVGFeDiffuseLightingElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
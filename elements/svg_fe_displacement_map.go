@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feDisplacementMap> SVG filter primitive uses the pixel values from the
@@ -22,18 +20,61 @@ type SVGFeDisplacementMapElement struct {
 // with the tag "feDisplacementMap" during rendering.
 func SVGFeDisplacementMap(children ...ElementRenderer) *SVGFeDisplacementMapElement {
 	e := NewElement("feDisplacementMap", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeDisplacementMapElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeDisplacementMapElement) Clone() *SVGFeDisplacementMapElement {
+	return &SVGFeDisplacementMapElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeDisplacementMapElement) CloneShallow() *SVGFeDisplacementMapElement {
+	return &SVGFeDisplacementMapElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeDisplacementMapElement) Freeze() *SVGFeDisplacementMapElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeDisplacementMapElement) writable() *SVGFeDisplacementMapElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeDisplacementMapElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeDisplacementMapElement) choiceType(name string) func(string) any {
+	switch name {
+	case "xChannelSelector":
+		return choiceValue[SVGFeDisplacementMapXChannelSelectorChoice]
+	case "yChannelSelector":
+		return choiceValue[SVGFeDisplacementMapYChannelSelectorChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeDisplacementMapElement) Children(children ...ElementRenderer) *SVGFeDisplacementMapElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeDisplacementMapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *SVGFeDisplacementMapElement) IfChildren(condition bool, children ...Ele
 
 func (e *SVGFeDisplacementMapElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeDisplacementMapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) BoolAttr(name string) *SVGFeDisplacementMapElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) BoolAttrRemove(name string) *SVGFeDisplacementMapElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfBoolAttr(condition bool, name string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *SVGFeDisplacementMapElement) BoolAttrf(format string, args ...any) *SVG
 
 func (e *SVGFeDisplacementMapElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) BoolAttrs(names ...string) *SVGFeDisplacementMapElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfBoolAttrs(condition bool, names ...string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) Attr(name, value string) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeDisplacementMapElement) RawAttr(name, value string) *SVGFeDisplacementMapElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfAttr(condition bool, name, value string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *SVGFeDisplacementMapElement) Attrf(name, format string, args ...any) *S
 
 func (e *SVGFeDisplacementMapElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) Attrs(attrs ...string) *SVGFeDisplacementMapElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfAttrs(condition bool, attrs ...string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) AttrsMap(attrs map[string]string) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) Text(text string) *SVGFeDisplacementMapElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) Textf(format string, args ...any) *SVGFeDisplacementMapElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfText(condition bool, text string) *SVGFeDisplacementMapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *SVGFeDisplacementMapElement) IfText(condition bool, text string) *SVGFe
 
 func (e *SVGFeDisplacementMapElement) IfTextf(condition bool, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeDisplacementMapElement) Raw(text string) *SVGFeDisplacementMapElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeDisplacementMapElement) Escaped(text string) *SVGFeDisplacementMapElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeDisplacementMapElement) IfEscaped(condition bool, text string) *SVGFeDisplacementMapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *SVGFeDisplacementMapElement) Escapedf(format string, args ...any) *SVGF
 
 func (e *SVGFeDisplacementMapElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +254,8 @@ func (e *SVGFeDisplacementMapElement) IfEscapedf(condition bool, format string,
 
 // The input for this filter.
 func (e *SVGFeDisplacementMapElement) In(s string) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -224,7 +267,7 @@ func (e *SVGFeDisplacementMapElement) Inf(format string, args ...any) *SVGFeDisp
 // The input for this filter.
 func (e *SVGFeDisplacementMapElement) IfIn(condition bool, s string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -232,7 +275,7 @@ func (e *SVGFeDisplacementMapElement) IfIn(condition bool, s string) *SVGFeDispl
 // The input for this filter.
 func (e *SVGFeDisplacementMapElement) IfInf(condition bool, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,20 +283,16 @@ func (e *SVGFeDisplacementMapElement) IfInf(condition bool, format string, args
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeDisplacementMapElement) InRemove() *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // The displacement map. This Attribute can take on the same values as the 'in'
 // Attribute.
 func (e *SVGFeDisplacementMapElement) In2(s string) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in2", s)
+	e = e.writable()
+	e.setStringAttribute("in2", s)
 	return e
 }
 
@@ -267,7 +306,7 @@ func (e *SVGFeDisplacementMapElement) In2f(format string, args ...any) *SVGFeDis
 // Attribute.
 func (e *SVGFeDisplacementMapElement) IfIn2(condition bool, s string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.In2(s)
+		e = e.In2(s)
 	}
 	return e
 }
@@ -276,7 +315,7 @@ func (e *SVGFeDisplacementMapElement) IfIn2(condition bool, s string) *SVGFeDisp
 // Attribute.
 func (e *SVGFeDisplacementMapElement) IfIn2f(condition bool, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.In2(fmt.Sprintf(format, args...))
+		e = e.In2(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -285,20 +324,16 @@ func (e *SVGFeDisplacementMapElement) IfIn2f(condition bool, format string, args
 // Attribute.
 // Remove the attribute In2 from the element.
 func (e *SVGFeDisplacementMapElement) In2Remove() *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in2")
+	e = e.writable()
+	e.removeAttribute("in2")
 	return e
 }
 
 // The scale Attribute defines the maximum value for the in2 displacement. A
 // value of 0 disables the effect of the displacement map.
 func (e *SVGFeDisplacementMapElement) Scale(f float64) *SVGFeDisplacementMapElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("scale", f)
+	e = e.writable()
+	e.setFloatAttribute("scale", f, 0)
 	return e
 }
 
@@ -306,7 +341,7 @@ func (e *SVGFeDisplacementMapElement) Scale(f float64) *SVGFeDisplacementMapElem
 // value of 0 disables the effect of the displacement map.
 func (e *SVGFeDisplacementMapElement) IfScale(condition bool, f float64) *SVGFeDisplacementMapElement {
 	if condition {
-		e.Scale(f)
+		e = e.Scale(f)
 	}
 	return e
 }
@@ -314,10 +349,8 @@ func (e *SVGFeDisplacementMapElement) IfScale(condition bool, f float64) *SVGFeD
 // The xChannelSelector Attribute indicates which color channel from in2 to use
 // to displace the pixels in in the horizontal direction.
 func (e *SVGFeDisplacementMapElement) XChannelSelector(c SVGFeDisplacementMapXChannelSelectorChoice) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("xChannelSelector", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("xChannelSelector", string(c), choiceValue[SVGFeDisplacementMapXChannelSelectorChoice])
 	return e
 }
 
@@ -338,20 +371,16 @@ const (
 // to displace the pixels in in the horizontal direction.
 // Remove the attribute XChannelSelector from the element.
 func (e *SVGFeDisplacementMapElement) XChannelSelectorRemove() *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("xChannelSelector")
+	e = e.writable()
+	e.removeAttribute("xChannelSelector")
 	return e
 }
 
 // The yChannelSelector Attribute indicates which color channel from in2 to use
 // to displace the pixels in in the vertical direction.
 func (e *SVGFeDisplacementMapElement) YChannelSelector(c SVGFeDisplacementMapYChannelSelectorChoice) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("yChannelSelector", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("yChannelSelector", string(c), choiceValue[SVGFeDisplacementMapYChannelSelectorChoice])
 	return e
 }
 
@@ -372,19 +401,15 @@ const (
 // to displace the pixels in in the vertical direction.
 // Remove the attribute YChannelSelector from the element.
 func (e *SVGFeDisplacementMapElement) YChannelSelectorRemove() *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("yChannelSelector")
+	e = e.writable()
+	e.removeAttribute("yChannelSelector")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeDisplacementMapElement) ID(s string) *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -396,7 +421,7 @@ func (e *SVGFeDisplacementMapElement) IDf(format string, args ...any) *SVGFeDisp
 // Specifies a unique id for an element
 func (e *SVGFeDisplacementMapElement) IfID(condition bool, s string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -404,7 +429,7 @@ func (e *SVGFeDisplacementMapElement) IfID(condition bool, s string) *SVGFeDispl
 // Specifies a unique id for an element
 func (e *SVGFeDisplacementMapElement) IfIDf(condition bool, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -412,26 +437,17 @@ func (e *SVGFeDisplacementMapElement) IfIDf(condition bool, format string, args
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeDisplacementMapElement) IDRemove() *SVGFeDisplacementMapElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeDisplacementMapElement) Class(s string) *SVGFeDisplacementMapElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -439,7 +455,7 @@ func (e *SVGFeDisplacementMapElement) Class(s string) *SVGFeDisplacementMapEleme
 // sheet)
 func (e *SVGFeDisplacementMapElement) IfClass(condition bool, s string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -448,14 +464,8 @@ func (e *SVGFeDisplacementMapElement) IfClass(condition bool, s string) *SVGFeDi
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeDisplacementMapElement) ClassRemove(s ...string) *SVGFeDisplacementMapElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -464,14 +474,8 @@ func (e *SVGFeDisplacementMapElement) StylePairs(pairs ...string) *SVGFeDisplace
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -485,14 +489,8 @@ func (e *SVGFeDisplacementMapElement) StylePairs(pairs ...string) *SVGFeDisplace
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDisplacementMapElement) Style(s string) *SVGFeDisplacementMapElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -500,7 +498,7 @@ func (e *SVGFeDisplacementMapElement) Style(s string) *SVGFeDisplacementMapEleme
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -508,22 +506,14 @@ func (e *SVGFeDisplacementMapElement) Style(s string) *SVGFeDisplacementMapEleme
 // Specifies an inline CSS style for an element
 func (e *SVGFeDisplacementMapElement) IfStyle(condition bool, s string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDisplacementMapElement) StyleAdd(k string, v string) *SVGFeDisplacementMapElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -535,7 +525,7 @@ func (e *SVGFeDisplacementMapElement) StyleAddf(k string, format string, args ..
 // Specifies an inline CSS style for an element
 func (e *SVGFeDisplacementMapElement) IfStyleAdd(condition bool, k string, v string) *SVGFeDisplacementMapElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -543,7 +533,7 @@ func (e *SVGFeDisplacementMapElement) IfStyleAdd(condition bool, k string, v str
 // Specifies an inline CSS style for an element
 func (e *SVGFeDisplacementMapElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeDisplacementMapElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -551,21 +541,15 @@ func (e *SVGFeDisplacementMapElement) IfStyleAddf(condition bool, k string, form
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeDisplacementMapElement) StyleMap(m map[string]string) *SVGFeDisplacementMapElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -573,13 +557,7 @@ func (e *SVGFeDisplacementMapElement) StyleMap(m map[string]string) *SVGFeDispla
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeDisplacementMapElement) StyleRemove(keys ...string) *SVGFeDisplacementMapElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
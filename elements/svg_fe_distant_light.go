@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feDistantLight> SVG filter primitive defines a distant light source that
@@ -23,18 +21,49 @@ type SVGFeDistantLightElement struct {
 // with the tag "feDistantLight" during rendering.
 func SVGFeDistantLight(children ...ElementRenderer) *SVGFeDistantLightElement {
 	e := NewElement("feDistantLight", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeDistantLightElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeDistantLightElement) Clone() *SVGFeDistantLightElement {
+	return &SVGFeDistantLightElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeDistantLightElement) CloneShallow() *SVGFeDistantLightElement {
+	return &SVGFeDistantLightElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeDistantLightElement) Freeze() *SVGFeDistantLightElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeDistantLightElement) writable() *SVGFeDistantLightElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeDistantLightElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeDistantLightElement) Children(children ...ElementRenderer) *SVGFeDistantLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeDistantLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGFeDistantLightElement) IfChildren(condition bool, children ...Elemen
 
 func (e *SVGFeDistantLightElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeDistantLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) BoolAttr(name string) *SVGFeDistantLightElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeDistantLightElement) BoolAttrRemove(name string) *SVGFeDistantLightElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfBoolAttr(condition bool, name string) *SVGFeDistantLightElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGFeDistantLightElement) BoolAttrf(format string, args ...any) *SVGFeD
 
 func (e *SVGFeDistantLightElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeDistantLightElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) BoolAttrs(names ...string) *SVGFeDistantLightElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfBoolAttrs(condition bool, names ...string) *SVGFeDistantLightElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) Attr(name, value string) *SVGFeDistantLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeDistantLightElement) RawAttr(name, value string) *SVGFeDistantLightElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfAttr(condition bool, name, value string) *SVGFeDistantLightElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGFeDistantLightElement) Attrf(name, format string, args ...any) *SVGF
 
 func (e *SVGFeDistantLightElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeDistantLightElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) Attrs(attrs ...string) *SVGFeDistantLightElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfAttrs(condition bool, attrs ...string) *SVGFeDistantLightElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) AttrsMap(attrs map[string]string) *SVGFeDistantLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeDistantLightElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeDistantLightElement) Text(text string) *SVGFeDistantLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeDistantLightElement) Textf(format string, args ...any) *SVGFeDistantLightElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeDistantLightElement) IfText(condition bool, text string) *SVGFeDistantLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGFeDistantLightElement) IfText(condition bool, text string) *SVGFeDis
 
 func (e *SVGFeDistantLightElement) IfTextf(condition bool, format string, args ...any) *SVGFeDistantLightElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeDistantLightElement) Raw(text string) *SVGFeDistantLightElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeDistantLightElement) Escaped(text string) *SVGFeDistantLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeDistantLightElement) IfEscaped(condition bool, text string) *SVGFeDistantLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGFeDistantLightElement) Escapedf(format string, args ...any) *SVGFeDi
 
 func (e *SVGFeDistantLightElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeDistantLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFeDistantLightElement) IfEscapedf(condition bool, format string, arg
 // The azimuth Attribute represent the direction vector of the light source in
 // the XY plane (clockwise), in degrees from the x axis.
 func (e *SVGFeDistantLightElement) Azimuth(f float64) *SVGFeDistantLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("azimuth", f)
+	e = e.writable()
+	e.setFloatAttribute("azimuth", f, 0)
 	return e
 }
 
@@ -222,7 +253,7 @@ func (e *SVGFeDistantLightElement) Azimuth(f float64) *SVGFeDistantLightElement
 // the XY plane (clockwise), in degrees from the x axis.
 func (e *SVGFeDistantLightElement) IfAzimuth(condition bool, f float64) *SVGFeDistantLightElement {
 	if condition {
-		e.Azimuth(f)
+		e = e.Azimuth(f)
 	}
 	return e
 }
@@ -231,10 +262,8 @@ func (e *SVGFeDistantLightElement) IfAzimuth(condition bool, f float64) *SVGFeDi
 // perpendicular to the XY plane, in degrees from the XY plane towards the z
 // axis (clockwise).
 func (e *SVGFeDistantLightElement) Elevation(f float64) *SVGFeDistantLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("elevation", f)
+	e = e.writable()
+	e.setFloatAttribute("elevation", f, 0)
 	return e
 }
 
@@ -243,17 +272,15 @@ func (e *SVGFeDistantLightElement) Elevation(f float64) *SVGFeDistantLightElemen
 // axis (clockwise).
 func (e *SVGFeDistantLightElement) IfElevation(condition bool, f float64) *SVGFeDistantLightElement {
 	if condition {
-		e.Elevation(f)
+		e = e.Elevation(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeDistantLightElement) ID(s string) *SVGFeDistantLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -265,7 +292,7 @@ func (e *SVGFeDistantLightElement) IDf(format string, args ...any) *SVGFeDistant
 // Specifies a unique id for an element
 func (e *SVGFeDistantLightElement) IfID(condition bool, s string) *SVGFeDistantLightElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -273,7 +300,7 @@ func (e *SVGFeDistantLightElement) IfID(condition bool, s string) *SVGFeDistantL
 // Specifies a unique id for an element
 func (e *SVGFeDistantLightElement) IfIDf(condition bool, format string, args ...any) *SVGFeDistantLightElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,26 +308,17 @@ func (e *SVGFeDistantLightElement) IfIDf(condition bool, format string, args ...
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeDistantLightElement) IDRemove() *SVGFeDistantLightElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeDistantLightElement) Class(s string) *SVGFeDistantLightElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -308,7 +326,7 @@ func (e *SVGFeDistantLightElement) Class(s string) *SVGFeDistantLightElement {
 // sheet)
 func (e *SVGFeDistantLightElement) IfClass(condition bool, s string) *SVGFeDistantLightElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -317,14 +335,8 @@ func (e *SVGFeDistantLightElement) IfClass(condition bool, s string) *SVGFeDista
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeDistantLightElement) ClassRemove(s ...string) *SVGFeDistantLightElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -333,14 +345,8 @@ func (e *SVGFeDistantLightElement) StylePairs(pairs ...string) *SVGFeDistantLigh
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -354,14 +360,8 @@ func (e *SVGFeDistantLightElement) StylePairs(pairs ...string) *SVGFeDistantLigh
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDistantLightElement) Style(s string) *SVGFeDistantLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -369,7 +369,7 @@ func (e *SVGFeDistantLightElement) Style(s string) *SVGFeDistantLightElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -377,22 +377,14 @@ func (e *SVGFeDistantLightElement) Style(s string) *SVGFeDistantLightElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeDistantLightElement) IfStyle(condition bool, s string) *SVGFeDistantLightElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDistantLightElement) StyleAdd(k string, v string) *SVGFeDistantLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -404,7 +396,7 @@ func (e *SVGFeDistantLightElement) StyleAddf(k string, format string, args ...an
 // Specifies an inline CSS style for an element
 func (e *SVGFeDistantLightElement) IfStyleAdd(condition bool, k string, v string) *SVGFeDistantLightElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -412,7 +404,7 @@ func (e *SVGFeDistantLightElement) IfStyleAdd(condition bool, k string, v string
 // Specifies an inline CSS style for an element
 func (e *SVGFeDistantLightElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeDistantLightElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -420,21 +412,15 @@ func (e *SVGFeDistantLightElement) IfStyleAddf(condition bool, k string, format
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeDistantLightElement) StyleMap(m map[string]string) *SVGFeDistantLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -442,13 +428,7 @@ func (e *SVGFeDistantLightElement) StyleMap(m map[string]string) *SVGFeDistantLi
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeDistantLightElement) StyleRemove(keys ...string) *SVGFeDistantLightElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feDropShadow> filter primitive creates a drop shadow of the input image.
@@ -23,18 +21,49 @@ type SVGFeDropShadowElement struct {
 // with the tag "feDropShadow" during rendering.
 func SVGFeDropShadow(children ...ElementRenderer) *SVGFeDropShadowElement {
 	e := NewElement("feDropShadow", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeDropShadowElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeDropShadowElement) Clone() *SVGFeDropShadowElement {
+	return &SVGFeDropShadowElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeDropShadowElement) CloneShallow() *SVGFeDropShadowElement {
+	return &SVGFeDropShadowElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeDropShadowElement) Freeze() *SVGFeDropShadowElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeDropShadowElement) writable() *SVGFeDropShadowElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeDropShadowElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeDropShadowElement) Children(children ...ElementRenderer) *SVGFeDropShadowElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeDropShadowElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGFeDropShadowElement) IfChildren(condition bool, children ...ElementR
 
 func (e *SVGFeDropShadowElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeDropShadowElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) BoolAttr(name string) *SVGFeDropShadowElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeDropShadowElement) BoolAttrRemove(name string) *SVGFeDropShadowElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfBoolAttr(condition bool, name string) *SVGFeDropShadowElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGFeDropShadowElement) BoolAttrf(format string, args ...any) *SVGFeDro
 
 func (e *SVGFeDropShadowElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) BoolAttrs(names ...string) *SVGFeDropShadowElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfBoolAttrs(condition bool, names ...string) *SVGFeDropShadowElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) Attr(name, value string) *SVGFeDropShadowElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeDropShadowElement) RawAttr(name, value string) *SVGFeDropShadowElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfAttr(condition bool, name, value string) *SVGFeDropShadowElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGFeDropShadowElement) Attrf(name, format string, args ...any) *SVGFeD
 
 func (e *SVGFeDropShadowElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) Attrs(attrs ...string) *SVGFeDropShadowElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfAttrs(condition bool, attrs ...string) *SVGFeDropShadowElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) AttrsMap(attrs map[string]string) *SVGFeDropShadowElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeDropShadowElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeDropShadowElement) Text(text string) *SVGFeDropShadowElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeDropShadowElement) Textf(format string, args ...any) *SVGFeDropShadowElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeDropShadowElement) IfText(condition bool, text string) *SVGFeDropShadowElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGFeDropShadowElement) IfText(condition bool, text string) *SVGFeDropS
 
 func (e *SVGFeDropShadowElement) IfTextf(condition bool, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeDropShadowElement) Raw(text string) *SVGFeDropShadowElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeDropShadowElement) Escaped(text string) *SVGFeDropShadowElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeDropShadowElement) IfEscaped(condition bool, text string) *SVGFeDropShadowElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGFeDropShadowElement) Escapedf(format string, args ...any) *SVGFeDrop
 
 func (e *SVGFeDropShadowElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFeDropShadowElement) IfEscapedf(condition bool, format string, args
 // The amount of offset in the x direction. If the <length> is 0, the shadow is
 // placed at the same position as the input.
 func (e *SVGFeDropShadowElement) Dx(f float64) *SVGFeDropShadowElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dx", f)
+	e = e.writable()
+	e.setFloatAttribute("dx", f, 0)
 	return e
 }
 
@@ -222,7 +253,7 @@ func (e *SVGFeDropShadowElement) Dx(f float64) *SVGFeDropShadowElement {
 // placed at the same position as the input.
 func (e *SVGFeDropShadowElement) IfDx(condition bool, f float64) *SVGFeDropShadowElement {
 	if condition {
-		e.Dx(f)
+		e = e.Dx(f)
 	}
 	return e
 }
@@ -230,10 +261,8 @@ func (e *SVGFeDropShadowElement) IfDx(condition bool, f float64) *SVGFeDropShado
 // The amount of offset in the y direction. If the <length> is 0, the shadow is
 // placed at the same position as the input.
 func (e *SVGFeDropShadowElement) Dy(f float64) *SVGFeDropShadowElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dy", f)
+	e = e.writable()
+	e.setFloatAttribute("dy", f, 0)
 	return e
 }
 
@@ -241,7 +270,7 @@ func (e *SVGFeDropShadowElement) Dy(f float64) *SVGFeDropShadowElement {
 // placed at the same position as the input.
 func (e *SVGFeDropShadowElement) IfDy(condition bool, f float64) *SVGFeDropShadowElement {
 	if condition {
-		e.Dy(f)
+		e = e.Dy(f)
 	}
 	return e
 }
@@ -254,10 +283,8 @@ func (e *SVGFeDropShadowElement) IfDy(condition bool, f float64) *SVGFeDropShado
 // values are not allowed. A value of zero disables the effect of the given
 // filter primitive (i.e., the result is a transparent black image).
 func (e *SVGFeDropShadowElement) StdDeviation(f float64) *SVGFeDropShadowElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("stdDeviation", f)
+	e = e.writable()
+	e.setFloatAttribute("stdDeviation", f, 0)
 	return e
 }
 
@@ -270,7 +297,7 @@ func (e *SVGFeDropShadowElement) StdDeviation(f float64) *SVGFeDropShadowElement
 // filter primitive (i.e., the result is a transparent black image).
 func (e *SVGFeDropShadowElement) IfStdDeviation(condition bool, f float64) *SVGFeDropShadowElement {
 	if condition {
-		e.StdDeviation(f)
+		e = e.StdDeviation(f)
 	}
 	return e
 }
@@ -280,10 +307,8 @@ func (e *SVGFeDropShadowElement) IfStdDeviation(condition bool, f float64) *SVGF
 // Attribute 'flood-color' is not specified, then the effect is as if a value of
 // black were specified.
 func (e *SVGFeDropShadowElement) FloodColor(s string) *SVGFeDropShadowElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("flood-color", s)
+	e = e.writable()
+	e.setStringAttribute("flood-color", s)
 	return e
 }
 
@@ -301,7 +326,7 @@ func (e *SVGFeDropShadowElement) FloodColorf(format string, args ...any) *SVGFeD
 // black were specified.
 func (e *SVGFeDropShadowElement) IfFloodColor(condition bool, s string) *SVGFeDropShadowElement {
 	if condition {
-		e.FloodColor(s)
+		e = e.FloodColor(s)
 	}
 	return e
 }
@@ -312,7 +337,7 @@ func (e *SVGFeDropShadowElement) IfFloodColor(condition bool, s string) *SVGFeDr
 // black were specified.
 func (e *SVGFeDropShadowElement) IfFloodColorf(condition bool, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
-		e.FloodColor(fmt.Sprintf(format, args...))
+		e = e.FloodColor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -323,20 +348,16 @@ func (e *SVGFeDropShadowElement) IfFloodColorf(condition bool, format string, ar
 // black were specified.
 // Remove the attribute FloodColor from the element.
 func (e *SVGFeDropShadowElement) FloodColorRemove() *SVGFeDropShadowElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("flood-color")
+	e = e.writable()
+	e.removeAttribute("flood-color")
 	return e
 }
 
 // The flood-opacity Attribute indicates the opacity value to use across the
 // current filter primitive subregion defined through the <feFlood> element.
 func (e *SVGFeDropShadowElement) FloodOpacity(f float64) *SVGFeDropShadowElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("flood-opacity", f)
+	e = e.writable()
+	e.setFloatAttribute("flood-opacity", f, 3)
 	return e
 }
 
@@ -344,17 +365,15 @@ func (e *SVGFeDropShadowElement) FloodOpacity(f float64) *SVGFeDropShadowElement
 // current filter primitive subregion defined through the <feFlood> element.
 func (e *SVGFeDropShadowElement) IfFloodOpacity(condition bool, f float64) *SVGFeDropShadowElement {
 	if condition {
-		e.FloodOpacity(f)
+		e = e.FloodOpacity(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeDropShadowElement) ID(s string) *SVGFeDropShadowElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -366,7 +385,7 @@ func (e *SVGFeDropShadowElement) IDf(format string, args ...any) *SVGFeDropShado
 // Specifies a unique id for an element
 func (e *SVGFeDropShadowElement) IfID(condition bool, s string) *SVGFeDropShadowElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -374,7 +393,7 @@ func (e *SVGFeDropShadowElement) IfID(condition bool, s string) *SVGFeDropShadow
 // Specifies a unique id for an element
 func (e *SVGFeDropShadowElement) IfIDf(condition bool, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -382,26 +401,17 @@ func (e *SVGFeDropShadowElement) IfIDf(condition bool, format string, args ...an
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeDropShadowElement) IDRemove() *SVGFeDropShadowElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeDropShadowElement) Class(s string) *SVGFeDropShadowElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -409,7 +419,7 @@ func (e *SVGFeDropShadowElement) Class(s string) *SVGFeDropShadowElement {
 // sheet)
 func (e *SVGFeDropShadowElement) IfClass(condition bool, s string) *SVGFeDropShadowElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -418,14 +428,8 @@ func (e *SVGFeDropShadowElement) IfClass(condition bool, s string) *SVGFeDropSha
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeDropShadowElement) ClassRemove(s ...string) *SVGFeDropShadowElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -434,14 +438,8 @@ func (e *SVGFeDropShadowElement) StylePairs(pairs ...string) *SVGFeDropShadowEle
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -455,14 +453,8 @@ func (e *SVGFeDropShadowElement) StylePairs(pairs ...string) *SVGFeDropShadowEle
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDropShadowElement) Style(s string) *SVGFeDropShadowElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -470,7 +462,7 @@ func (e *SVGFeDropShadowElement) Style(s string) *SVGFeDropShadowElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -478,22 +470,14 @@ func (e *SVGFeDropShadowElement) Style(s string) *SVGFeDropShadowElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeDropShadowElement) IfStyle(condition bool, s string) *SVGFeDropShadowElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeDropShadowElement) StyleAdd(k string, v string) *SVGFeDropShadowElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -505,7 +489,7 @@ func (e *SVGFeDropShadowElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFeDropShadowElement) IfStyleAdd(condition bool, k string, v string) *SVGFeDropShadowElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -513,7 +497,7 @@ func (e *SVGFeDropShadowElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGFeDropShadowElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeDropShadowElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -521,21 +505,15 @@ func (e *SVGFeDropShadowElement) IfStyleAddf(condition bool, k string, format st
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeDropShadowElement) StyleMap(m map[string]string) *SVGFeDropShadowElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -543,13 +521,7 @@ func (e *SVGFeDropShadowElement) StyleMap(m map[string]string) *SVGFeDropShadowE
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeDropShadowElement) StyleRemove(keys ...string) *SVGFeDropShadowElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feFlood> SVG filter primitive fills the filter subregion with the color
@@ -22,18 +20,49 @@ type SVGFeFloodElement struct {
 // with the tag "feFlood" during rendering.
 func SVGFeFlood(children ...ElementRenderer) *SVGFeFloodElement {
 	e := NewElement("feFlood", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeFloodElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeFloodElement) Clone() *SVGFeFloodElement {
+	return &SVGFeFloodElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeFloodElement) CloneShallow() *SVGFeFloodElement {
+	return &SVGFeFloodElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeFloodElement) Freeze() *SVGFeFloodElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeFloodElement) writable() *SVGFeFloodElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeFloodElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeFloodElement) Children(children ...ElementRenderer) *SVGFeFloodElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeFloodElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeFloodElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGFeFloodElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGFeFloodElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeFloodElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) BoolAttr(name string) *SVGFeFloodElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeFloodElement) BoolAttrRemove(name string) *SVGFeFloodElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeFloodElement) IfBoolAttr(condition bool, name string) *SVGFeFloodElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGFeFloodElement) BoolAttrf(format string, args ...any) *SVGFeFloodEle
 
 func (e *SVGFeFloodElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeFloodElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) BoolAttrs(names ...string) *SVGFeFloodElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) IfBoolAttrs(condition bool, names ...string) *SVGFeFloodElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) Attr(name, value string) *SVGFeFloodElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeFloodElement) RawAttr(name, value string) *SVGFeFloodElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeFloodElement) IfAttr(condition bool, name, value string) *SVGFeFloodElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGFeFloodElement) Attrf(name, format string, args ...any) *SVGFeFloodE
 
 func (e *SVGFeFloodElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeFloodElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) Attrs(attrs ...string) *SVGFeFloodElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeFloodElement) IfAttrs(condition bool, attrs ...string) *SVGFeFloodElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) AttrsMap(attrs map[string]string) *SVGFeFloodElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeFloodElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeFloodElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeFloodElement) Text(text string) *SVGFeFloodElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeFloodElement) Textf(format string, args ...any) *SVGFeFloodElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeFloodElement) IfText(condition bool, text string) *SVGFeFloodElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGFeFloodElement) IfText(condition bool, text string) *SVGFeFloodEleme
 
 func (e *SVGFeFloodElement) IfTextf(condition bool, format string, args ...any) *SVGFeFloodElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeFloodElement) Raw(text string) *SVGFeFloodElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeFloodElement) Escaped(text string) *SVGFeFloodElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeFloodElement) IfEscaped(condition bool, text string) *SVGFeFloodElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGFeFloodElement) Escapedf(format string, args ...any) *SVGFeFloodElem
 
 func (e *SVGFeFloodElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeFloodElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +245,8 @@ func (e *SVGFeFloodElement) IfEscapedf(condition bool, format string, args ...an
 // Attribute 'flood-color' is not specified, then the effect is as if a value of
 // black were specified.
 func (e *SVGFeFloodElement) FloodColor(s string) *SVGFeFloodElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("flood-color", s)
+	e = e.writable()
+	e.setStringAttribute("flood-color", s)
 	return e
 }
 
@@ -233,7 +264,7 @@ func (e *SVGFeFloodElement) FloodColorf(format string, args ...any) *SVGFeFloodE
 // black were specified.
 func (e *SVGFeFloodElement) IfFloodColor(condition bool, s string) *SVGFeFloodElement {
 	if condition {
-		e.FloodColor(s)
+		e = e.FloodColor(s)
 	}
 	return e
 }
@@ -244,7 +275,7 @@ func (e *SVGFeFloodElement) IfFloodColor(condition bool, s string) *SVGFeFloodEl
 // black were specified.
 func (e *SVGFeFloodElement) IfFloodColorf(condition bool, format string, args ...any) *SVGFeFloodElement {
 	if condition {
-		e.FloodColor(fmt.Sprintf(format, args...))
+		e = e.FloodColor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -255,20 +286,16 @@ func (e *SVGFeFloodElement) IfFloodColorf(condition bool, format string, args ..
 // black were specified.
 // Remove the attribute FloodColor from the element.
 func (e *SVGFeFloodElement) FloodColorRemove() *SVGFeFloodElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("flood-color")
+	e = e.writable()
+	e.removeAttribute("flood-color")
 	return e
 }
 
 // The flood-opacity Attribute indicates the opacity value to use across the
 // current filter primitive subregion defined through the <feFlood> element.
 func (e *SVGFeFloodElement) FloodOpacity(f float64) *SVGFeFloodElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("flood-opacity", f)
+	e = e.writable()
+	e.setFloatAttribute("flood-opacity", f, 3)
 	return e
 }
 
@@ -276,17 +303,15 @@ func (e *SVGFeFloodElement) FloodOpacity(f float64) *SVGFeFloodElement {
 // current filter primitive subregion defined through the <feFlood> element.
 func (e *SVGFeFloodElement) IfFloodOpacity(condition bool, f float64) *SVGFeFloodElement {
 	if condition {
-		e.FloodOpacity(f)
+		e = e.FloodOpacity(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeFloodElement) ID(s string) *SVGFeFloodElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -298,7 +323,7 @@ func (e *SVGFeFloodElement) IDf(format string, args ...any) *SVGFeFloodElement {
 // Specifies a unique id for an element
 func (e *SVGFeFloodElement) IfID(condition bool, s string) *SVGFeFloodElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -306,7 +331,7 @@ func (e *SVGFeFloodElement) IfID(condition bool, s string) *SVGFeFloodElement {
 // Specifies a unique id for an element
 func (e *SVGFeFloodElement) IfIDf(condition bool, format string, args ...any) *SVGFeFloodElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -314,26 +339,17 @@ func (e *SVGFeFloodElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeFloodElement) IDRemove() *SVGFeFloodElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeFloodElement) Class(s string) *SVGFeFloodElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -341,7 +357,7 @@ func (e *SVGFeFloodElement) Class(s string) *SVGFeFloodElement {
 // sheet)
 func (e *SVGFeFloodElement) IfClass(condition bool, s string) *SVGFeFloodElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -350,14 +366,8 @@ func (e *SVGFeFloodElement) IfClass(condition bool, s string) *SVGFeFloodElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeFloodElement) ClassRemove(s ...string) *SVGFeFloodElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -366,14 +376,8 @@ func (e *SVGFeFloodElement) StylePairs(pairs ...string) *SVGFeFloodElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -387,14 +391,8 @@ func (e *SVGFeFloodElement) StylePairs(pairs ...string) *SVGFeFloodElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeFloodElement) Style(s string) *SVGFeFloodElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -402,7 +400,7 @@ func (e *SVGFeFloodElement) Style(s string) *SVGFeFloodElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -410,22 +408,14 @@ func (e *SVGFeFloodElement) Style(s string) *SVGFeFloodElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeFloodElement) IfStyle(condition bool, s string) *SVGFeFloodElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeFloodElement) StyleAdd(k string, v string) *SVGFeFloodElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -437,7 +427,7 @@ func (e *SVGFeFloodElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeFloodElement) IfStyleAdd(condition bool, k string, v string) *SVGFeFloodElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -445,7 +435,7 @@ func (e *SVGFeFloodElement) IfStyleAdd(condition bool, k string, v string) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeFloodElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeFloodElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -453,21 +443,15 @@ func (e *SVGFeFloodElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeFloodElement) StyleMap(m map[string]string) *SVGFeFloodElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -475,13 +459,7 @@ func (e *SVGFeFloodElement) StyleMap(m map[string]string) *SVGFeFloodElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeFloodElement) StyleRemove(keys ...string) *SVGFeFloodElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feFuncB> SVG filter primitive defines the transfer function for the blue
@@ -22,18 +20,59 @@ type SVGFeFuncBElement struct {
 // with the tag "feFuncB" during rendering.
 func SVGFeFuncB(children ...ElementRenderer) *SVGFeFuncBElement {
 	e := NewElement("feFuncB", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeFuncBElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeFuncBElement) Clone() *SVGFeFuncBElement {
+	return &SVGFeFuncBElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeFuncBElement) CloneShallow() *SVGFeFuncBElement {
+	return &SVGFeFuncBElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeFuncBElement) Freeze() *SVGFeFuncBElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeFuncBElement) writable() *SVGFeFuncBElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeFuncBElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeFuncBElement) choiceType(name string) func(string) any {
+	switch name {
+	case "type":
+		return choiceValue[SVGFeFuncBTypeChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeFuncBElement) Children(children ...ElementRenderer) *SVGFeFuncBElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeFuncBElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +80,30 @@ func (e *SVGFeFuncBElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGFeFuncBElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeFuncBElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) BoolAttr(name string) *SVGFeFuncBElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeFuncBElement) BoolAttrRemove(name string) *SVGFeFuncBElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfBoolAttr(condition bool, name string) *SVGFeFuncBElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +114,40 @@ func (e *SVGFeFuncBElement) BoolAttrf(format string, args ...any) *SVGFeFuncBEle
 
 func (e *SVGFeFuncBElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) BoolAttrs(names ...string) *SVGFeFuncBElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfBoolAttrs(condition bool, names ...string) *SVGFeFuncBElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) Attr(name, value string) *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeFuncBElement) RawAttr(name, value string) *SVGFeFuncBElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfAttr(condition bool, name, value string) *SVGFeFuncBElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +158,52 @@ func (e *SVGFeFuncBElement) Attrf(name, format string, args ...any) *SVGFeFuncBE
 
 func (e *SVGFeFuncBElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) Attrs(attrs ...string) *SVGFeFuncBElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfAttrs(condition bool, attrs ...string) *SVGFeFuncBElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) AttrsMap(attrs map[string]string) *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeFuncBElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeFuncBElement) Text(text string) *SVGFeFuncBElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeFuncBElement) Textf(format string, args ...any) *SVGFeFuncBElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeFuncBElement) IfText(condition bool, text string) *SVGFeFuncBElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +211,28 @@ func (e *SVGFeFuncBElement) IfText(condition bool, text string) *SVGFeFuncBEleme
 
 func (e *SVGFeFuncBElement) IfTextf(condition bool, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeFuncBElement) Raw(text string) *SVGFeFuncBElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeFuncBElement) Escaped(text string) *SVGFeFuncBElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeFuncBElement) IfEscaped(condition bool, text string) *SVGFeFuncBElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +244,7 @@ func (e *SVGFeFuncBElement) Escapedf(format string, args ...any) *SVGFeFuncBElem
 
 func (e *SVGFeFuncBElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +252,8 @@ func (e *SVGFeFuncBElement) IfEscapedf(condition bool, format string, args ...an
 
 // The type of transfer function.
 func (e *SVGFeFuncBElement) Type(c SVGFeFuncBTypeChoice) *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[SVGFeFuncBTypeChoice])
 	return e
 }
 
@@ -234,20 +275,16 @@ const (
 // The type of transfer function.
 // Remove the attribute Type from the element.
 func (e *SVGFeFuncBElement) TypeRemove() *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Contains the list of <number>s that define the lookup table. Values must be
 // in the 0-1 range and be equally spaced. There must be at least two values.
 func (e *SVGFeFuncBElement) TableValues(s string) *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("tableValues", s)
+	e = e.writable()
+	e.setStringAttribute("tableValues", s)
 	return e
 }
 
@@ -261,7 +298,7 @@ func (e *SVGFeFuncBElement) TableValuesf(format string, args ...any) *SVGFeFuncB
 // in the 0-1 range and be equally spaced. There must be at least two values.
 func (e *SVGFeFuncBElement) IfTableValues(condition bool, s string) *SVGFeFuncBElement {
 	if condition {
-		e.TableValues(s)
+		e = e.TableValues(s)
 	}
 	return e
 }
@@ -270,7 +307,7 @@ func (e *SVGFeFuncBElement) IfTableValues(condition bool, s string) *SVGFeFuncBE
 // in the 0-1 range and be equally spaced. There must be at least two values.
 func (e *SVGFeFuncBElement) IfTableValuesf(condition bool, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
-		e.TableValues(fmt.Sprintf(format, args...))
+		e = e.TableValues(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -279,104 +316,90 @@ func (e *SVGFeFuncBElement) IfTableValuesf(condition bool, format string, args .
 // in the 0-1 range and be equally spaced. There must be at least two values.
 // Remove the attribute TableValues from the element.
 func (e *SVGFeFuncBElement) TableValuesRemove() *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("tableValues")
+	e = e.writable()
+	e.removeAttribute("tableValues")
 	return e
 }
 
 // The slope Attribute indicates the slope of the linear function.
 func (e *SVGFeFuncBElement) Slope(f float64) *SVGFeFuncBElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("slope", f)
+	e = e.writable()
+	e.setFloatAttribute("slope", f, 0)
 	return e
 }
 
 // The slope Attribute indicates the slope of the linear function.
 func (e *SVGFeFuncBElement) IfSlope(condition bool, f float64) *SVGFeFuncBElement {
 	if condition {
-		e.Slope(f)
+		e = e.Slope(f)
 	}
 	return e
 }
 
 // The intercept Attribute indicates the intercept of the linear function.
 func (e *SVGFeFuncBElement) Intercept(f float64) *SVGFeFuncBElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("intercept", f)
+	e = e.writable()
+	e.setFloatAttribute("intercept", f, 0)
 	return e
 }
 
 // The intercept Attribute indicates the intercept of the linear function.
 func (e *SVGFeFuncBElement) IfIntercept(condition bool, f float64) *SVGFeFuncBElement {
 	if condition {
-		e.Intercept(f)
+		e = e.Intercept(f)
 	}
 	return e
 }
 
 // The amplitude Attribute indicates the amplitude of the cubic function.
 func (e *SVGFeFuncBElement) Amplitude(f float64) *SVGFeFuncBElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("amplitude", f)
+	e = e.writable()
+	e.setFloatAttribute("amplitude", f, 0)
 	return e
 }
 
 // The amplitude Attribute indicates the amplitude of the cubic function.
 func (e *SVGFeFuncBElement) IfAmplitude(condition bool, f float64) *SVGFeFuncBElement {
 	if condition {
-		e.Amplitude(f)
+		e = e.Amplitude(f)
 	}
 	return e
 }
 
 // The exponent Attribute indicates the exponent of the exponential function.
 func (e *SVGFeFuncBElement) Exponent(f float64) *SVGFeFuncBElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("exponent", f)
+	e = e.writable()
+	e.setFloatAttribute("exponent", f, 0)
 	return e
 }
 
 // The exponent Attribute indicates the exponent of the exponential function.
 func (e *SVGFeFuncBElement) IfExponent(condition bool, f float64) *SVGFeFuncBElement {
 	if condition {
-		e.Exponent(f)
+		e = e.Exponent(f)
 	}
 	return e
 }
 
 // The offset Attribute indicates the offset of the function.
 func (e *SVGFeFuncBElement) Offset(f float64) *SVGFeFuncBElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("offset", f)
+	e = e.writable()
+	e.setFloatAttribute("offset", f, 0)
 	return e
 }
 
 // The offset Attribute indicates the offset of the function.
 func (e *SVGFeFuncBElement) IfOffset(condition bool, f float64) *SVGFeFuncBElement {
 	if condition {
-		e.Offset(f)
+		e = e.Offset(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeFuncBElement) ID(s string) *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -388,7 +411,7 @@ func (e *SVGFeFuncBElement) IDf(format string, args ...any) *SVGFeFuncBElement {
 // Specifies a unique id for an element
 func (e *SVGFeFuncBElement) IfID(condition bool, s string) *SVGFeFuncBElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -396,7 +419,7 @@ func (e *SVGFeFuncBElement) IfID(condition bool, s string) *SVGFeFuncBElement {
 // Specifies a unique id for an element
 func (e *SVGFeFuncBElement) IfIDf(condition bool, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -404,26 +427,17 @@ func (e *SVGFeFuncBElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeFuncBElement) IDRemove() *SVGFeFuncBElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeFuncBElement) Class(s string) *SVGFeFuncBElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -431,7 +445,7 @@ func (e *SVGFeFuncBElement) Class(s string) *SVGFeFuncBElement {
 // sheet)
 func (e *SVGFeFuncBElement) IfClass(condition bool, s string) *SVGFeFuncBElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -440,14 +454,8 @@ func (e *SVGFeFuncBElement) IfClass(condition bool, s string) *SVGFeFuncBElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeFuncBElement) ClassRemove(s ...string) *SVGFeFuncBElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -456,14 +464,8 @@ func (e *SVGFeFuncBElement) StylePairs(pairs ...string) *SVGFeFuncBElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -477,14 +479,8 @@ func (e *SVGFeFuncBElement) StylePairs(pairs ...string) *SVGFeFuncBElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncBElement) Style(s string) *SVGFeFuncBElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -492,7 +488,7 @@ func (e *SVGFeFuncBElement) Style(s string) *SVGFeFuncBElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -500,22 +496,14 @@ func (e *SVGFeFuncBElement) Style(s string) *SVGFeFuncBElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncBElement) IfStyle(condition bool, s string) *SVGFeFuncBElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncBElement) StyleAdd(k string, v string) *SVGFeFuncBElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -527,7 +515,7 @@ func (e *SVGFeFuncBElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncBElement) IfStyleAdd(condition bool, k string, v string) *SVGFeFuncBElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -535,7 +523,7 @@ func (e *SVGFeFuncBElement) IfStyleAdd(condition bool, k string, v string) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncBElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeFuncBElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -543,21 +531,15 @@ func (e *SVGFeFuncBElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeFuncBElement) StyleMap(m map[string]string) *SVGFeFuncBElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -565,13 +547,7 @@ func (e *SVGFeFuncBElement) StyleMap(m map[string]string) *SVGFeFuncBElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeFuncBElement) StyleRemove(keys ...string) *SVGFeFuncBElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
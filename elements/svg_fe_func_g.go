@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feFuncG> SVG filter primitive defines the transfer function for the
@@ -23,18 +21,59 @@ type SVGFeFuncGElement struct {
 // with the tag "feFuncG" during rendering.
 func SVGFeFuncG(children ...ElementRenderer) *SVGFeFuncGElement {
 	e := NewElement("feFuncG", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeFuncGElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeFuncGElement) Clone() *SVGFeFuncGElement {
+	return &SVGFeFuncGElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeFuncGElement) CloneShallow() *SVGFeFuncGElement {
+	return &SVGFeFuncGElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeFuncGElement) Freeze() *SVGFeFuncGElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeFuncGElement) writable() *SVGFeFuncGElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeFuncGElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeFuncGElement) choiceType(name string) func(string) any {
+	switch name {
+	case "type":
+		return choiceValue[SVGFeFuncGTypeChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeFuncGElement) Children(children ...ElementRenderer) *SVGFeFuncGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeFuncGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGFeFuncGElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGFeFuncGElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeFuncGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) BoolAttr(name string) *SVGFeFuncGElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeFuncGElement) BoolAttrRemove(name string) *SVGFeFuncGElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfBoolAttr(condition bool, name string) *SVGFeFuncGElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGFeFuncGElement) BoolAttrf(format string, args ...any) *SVGFeFuncGEle
 
 func (e *SVGFeFuncGElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) BoolAttrs(names ...string) *SVGFeFuncGElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfBoolAttrs(condition bool, names ...string) *SVGFeFuncGElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) Attr(name, value string) *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeFuncGElement) RawAttr(name, value string) *SVGFeFuncGElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfAttr(condition bool, name, value string) *SVGFeFuncGElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGFeFuncGElement) Attrf(name, format string, args ...any) *SVGFeFuncGE
 
 func (e *SVGFeFuncGElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) Attrs(attrs ...string) *SVGFeFuncGElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfAttrs(condition bool, attrs ...string) *SVGFeFuncGElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) AttrsMap(attrs map[string]string) *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeFuncGElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeFuncGElement) Text(text string) *SVGFeFuncGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeFuncGElement) Textf(format string, args ...any) *SVGFeFuncGElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeFuncGElement) IfText(condition bool, text string) *SVGFeFuncGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGFeFuncGElement) IfText(condition bool, text string) *SVGFeFuncGEleme
 
 func (e *SVGFeFuncGElement) IfTextf(condition bool, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeFuncGElement) Raw(text string) *SVGFeFuncGElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeFuncGElement) Escaped(text string) *SVGFeFuncGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeFuncGElement) IfEscaped(condition bool, text string) *SVGFeFuncGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGFeFuncGElement) Escapedf(format string, args ...any) *SVGFeFuncGElem
 
 func (e *SVGFeFuncGElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +253,8 @@ func (e *SVGFeFuncGElement) IfEscapedf(condition bool, format string, args ...an
 
 // The type of transfer function.
 func (e *SVGFeFuncGElement) Type(c SVGFeFuncGTypeChoice) *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[SVGFeFuncGTypeChoice])
 	return e
 }
 
@@ -235,20 +276,16 @@ const (
 // The type of transfer function.
 // Remove the attribute Type from the element.
 func (e *SVGFeFuncGElement) TypeRemove() *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Contains the list of <number>s that define the lookup table. Values must be
 // in the 0-1 range and be equally spaced. There must be at least two values.
 func (e *SVGFeFuncGElement) TableValues(s string) *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("tableValues", s)
+	e = e.writable()
+	e.setStringAttribute("tableValues", s)
 	return e
 }
 
@@ -262,7 +299,7 @@ func (e *SVGFeFuncGElement) TableValuesf(format string, args ...any) *SVGFeFuncG
 // in the 0-1 range and be equally spaced. There must be at least two values.
 func (e *SVGFeFuncGElement) IfTableValues(condition bool, s string) *SVGFeFuncGElement {
 	if condition {
-		e.TableValues(s)
+		e = e.TableValues(s)
 	}
 	return e
 }
@@ -271,7 +308,7 @@ func (e *SVGFeFuncGElement) IfTableValues(condition bool, s string) *SVGFeFuncGE
 // in the 0-1 range and be equally spaced. There must be at least two values.
 func (e *SVGFeFuncGElement) IfTableValuesf(condition bool, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
-		e.TableValues(fmt.Sprintf(format, args...))
+		e = e.TableValues(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,104 +317,90 @@ func (e *SVGFeFuncGElement) IfTableValuesf(condition bool, format string, args .
 // in the 0-1 range and be equally spaced. There must be at least two values.
 // Remove the attribute TableValues from the element.
 func (e *SVGFeFuncGElement) TableValuesRemove() *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("tableValues")
+	e = e.writable()
+	e.removeAttribute("tableValues")
 	return e
 }
 
 // The slope Attribute indicates the slope of the linear function.
 func (e *SVGFeFuncGElement) Slope(f float64) *SVGFeFuncGElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("slope", f)
+	e = e.writable()
+	e.setFloatAttribute("slope", f, 0)
 	return e
 }
 
 // The slope Attribute indicates the slope of the linear function.
 func (e *SVGFeFuncGElement) IfSlope(condition bool, f float64) *SVGFeFuncGElement {
 	if condition {
-		e.Slope(f)
+		e = e.Slope(f)
 	}
 	return e
 }
 
 // The intercept Attribute indicates the intercept of the linear function.
 func (e *SVGFeFuncGElement) Intercept(f float64) *SVGFeFuncGElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("intercept", f)
+	e = e.writable()
+	e.setFloatAttribute("intercept", f, 0)
 	return e
 }
 
 // The intercept Attribute indicates the intercept of the linear function.
 func (e *SVGFeFuncGElement) IfIntercept(condition bool, f float64) *SVGFeFuncGElement {
 	if condition {
-		e.Intercept(f)
+		e = e.Intercept(f)
 	}
 	return e
 }
 
 // The amplitude Attribute indicates the amplitude of the cubic function.
 func (e *SVGFeFuncGElement) Amplitude(f float64) *SVGFeFuncGElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("amplitude", f)
+	e = e.writable()
+	e.setFloatAttribute("amplitude", f, 0)
 	return e
 }
 
 // The amplitude Attribute indicates the amplitude of the cubic function.
 func (e *SVGFeFuncGElement) IfAmplitude(condition bool, f float64) *SVGFeFuncGElement {
 	if condition {
-		e.Amplitude(f)
+		e = e.Amplitude(f)
 	}
 	return e
 }
 
 // The exponent Attribute indicates the exponent of the exponential function.
 func (e *SVGFeFuncGElement) Exponent(f float64) *SVGFeFuncGElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("exponent", f)
+	e = e.writable()
+	e.setFloatAttribute("exponent", f, 0)
 	return e
 }
 
 // The exponent Attribute indicates the exponent of the exponential function.
 func (e *SVGFeFuncGElement) IfExponent(condition bool, f float64) *SVGFeFuncGElement {
 	if condition {
-		e.Exponent(f)
+		e = e.Exponent(f)
 	}
 	return e
 }
 
 // The offset Attribute indicates the offset of the function.
 func (e *SVGFeFuncGElement) Offset(f float64) *SVGFeFuncGElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("offset", f)
+	e = e.writable()
+	e.setFloatAttribute("offset", f, 0)
 	return e
 }
 
 // The offset Attribute indicates the offset of the function.
 func (e *SVGFeFuncGElement) IfOffset(condition bool, f float64) *SVGFeFuncGElement {
 	if condition {
-		e.Offset(f)
+		e = e.Offset(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeFuncGElement) ID(s string) *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -389,7 +412,7 @@ func (e *SVGFeFuncGElement) IDf(format string, args ...any) *SVGFeFuncGElement {
 // Specifies a unique id for an element
 func (e *SVGFeFuncGElement) IfID(condition bool, s string) *SVGFeFuncGElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -397,7 +420,7 @@ func (e *SVGFeFuncGElement) IfID(condition bool, s string) *SVGFeFuncGElement {
 // Specifies a unique id for an element
 func (e *SVGFeFuncGElement) IfIDf(condition bool, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -405,26 +428,17 @@ func (e *SVGFeFuncGElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeFuncGElement) IDRemove() *SVGFeFuncGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeFuncGElement) Class(s string) *SVGFeFuncGElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -432,7 +446,7 @@ func (e *SVGFeFuncGElement) Class(s string) *SVGFeFuncGElement {
 // sheet)
 func (e *SVGFeFuncGElement) IfClass(condition bool, s string) *SVGFeFuncGElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -441,14 +455,8 @@ func (e *SVGFeFuncGElement) IfClass(condition bool, s string) *SVGFeFuncGElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeFuncGElement) ClassRemove(s ...string) *SVGFeFuncGElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -457,14 +465,8 @@ func (e *SVGFeFuncGElement) StylePairs(pairs ...string) *SVGFeFuncGElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -478,14 +480,8 @@ func (e *SVGFeFuncGElement) StylePairs(pairs ...string) *SVGFeFuncGElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncGElement) Style(s string) *SVGFeFuncGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -493,7 +489,7 @@ func (e *SVGFeFuncGElement) Style(s string) *SVGFeFuncGElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -501,22 +497,14 @@ func (e *SVGFeFuncGElement) Style(s string) *SVGFeFuncGElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncGElement) IfStyle(condition bool, s string) *SVGFeFuncGElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncGElement) StyleAdd(k string, v string) *SVGFeFuncGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -528,7 +516,7 @@ func (e *SVGFeFuncGElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncGElement) IfStyleAdd(condition bool, k string, v string) *SVGFeFuncGElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -536,7 +524,7 @@ func (e *SVGFeFuncGElement) IfStyleAdd(condition bool, k string, v string) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeFuncGElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeFuncGElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -544,21 +532,15 @@ func (e *SVGFeFuncGElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeFuncGElement) StyleMap(m map[string]string) *SVGFeFuncGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -566,13 +548,7 @@ func (e *SVGFeFuncGElement) StyleMap(m map[string]string) *SVGFeFuncGElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeFuncGElement) StyleRemove(keys ...string) *SVGFeFuncGElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
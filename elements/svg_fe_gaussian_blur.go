@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feGaussianBlur> SVG filter primitive blurs the input image by the amount
@@ -22,18 +20,49 @@ type SVGFeGaussianBlurElement struct {
 // with the tag "feGaussianBlur" during rendering.
 func SVGFeGaussianBlur(children ...ElementRenderer) *SVGFeGaussianBlurElement {
 	e := NewElement("feGaussianBlur", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeGaussianBlurElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeGaussianBlurElement) Clone() *SVGFeGaussianBlurElement {
+	return &SVGFeGaussianBlurElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeGaussianBlurElement) CloneShallow() *SVGFeGaussianBlurElement {
+	return &SVGFeGaussianBlurElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeGaussianBlurElement) Freeze() *SVGFeGaussianBlurElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeGaussianBlurElement) writable() *SVGFeGaussianBlurElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeGaussianBlurElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeGaussianBlurElement) Children(children ...ElementRenderer) *SVGFeGaussianBlurElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeGaussianBlurElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGFeGaussianBlurElement) IfChildren(condition bool, children ...Elemen
 
 func (e *SVGFeGaussianBlurElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeGaussianBlurElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) BoolAttr(name string) *SVGFeGaussianBlurElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) BoolAttrRemove(name string) *SVGFeGaussianBlurElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfBoolAttr(condition bool, name string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGFeGaussianBlurElement) BoolAttrf(format string, args ...any) *SVGFeG
 
 func (e *SVGFeGaussianBlurElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) BoolAttrs(names ...string) *SVGFeGaussianBlurElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfBoolAttrs(condition bool, names ...string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) Attr(name, value string) *SVGFeGaussianBlurElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeGaussianBlurElement) RawAttr(name, value string) *SVGFeGaussianBlurElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfAttr(condition bool, name, value string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGFeGaussianBlurElement) Attrf(name, format string, args ...any) *SVGF
 
 func (e *SVGFeGaussianBlurElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) Attrs(attrs ...string) *SVGFeGaussianBlurElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfAttrs(condition bool, attrs ...string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) AttrsMap(attrs map[string]string) *SVGFeGaussianBlurElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) Text(text string) *SVGFeGaussianBlurElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) Textf(format string, args ...any) *SVGFeGaussianBlurElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfText(condition bool, text string) *SVGFeGaussianBlurElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGFeGaussianBlurElement) IfText(condition bool, text string) *SVGFeGau
 
 func (e *SVGFeGaussianBlurElement) IfTextf(condition bool, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeGaussianBlurElement) Raw(text string) *SVGFeGaussianBlurElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeGaussianBlurElement) Escaped(text string) *SVGFeGaussianBlurElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeGaussianBlurElement) IfEscaped(condition bool, text string) *SVGFeGaussianBlurElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGFeGaussianBlurElement) Escapedf(format string, args ...any) *SVGFeGa
 
 func (e *SVGFeGaussianBlurElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGFeGaussianBlurElement) IfEscapedf(condition bool, format string, arg
 
 // The input for this filter.
 func (e *SVGFeGaussianBlurElement) In(s string) *SVGFeGaussianBlurElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGFeGaussianBlurElement) Inf(format string, args ...any) *SVGFeGaussia
 // The input for this filter.
 func (e *SVGFeGaussianBlurElement) IfIn(condition bool, s string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGFeGaussianBlurElement) IfIn(condition bool, s string) *SVGFeGaussian
 // The input for this filter.
 func (e *SVGFeGaussianBlurElement) IfInf(condition bool, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,10 +271,8 @@ func (e *SVGFeGaussianBlurElement) IfInf(condition bool, format string, args ...
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeGaussianBlurElement) InRemove() *SVGFeGaussianBlurElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
@@ -255,10 +284,8 @@ func (e *SVGFeGaussianBlurElement) InRemove() *SVGFeGaussianBlurElement {
 // values are not allowed. A value of zero disables the effect of the given
 // filter primitive (i.e., the result is a transparent black image).
 func (e *SVGFeGaussianBlurElement) StdDeviation(f float64) *SVGFeGaussianBlurElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("stdDeviation", f)
+	e = e.writable()
+	e.setFloatAttribute("stdDeviation", f, 0)
 	return e
 }
 
@@ -271,17 +298,15 @@ func (e *SVGFeGaussianBlurElement) StdDeviation(f float64) *SVGFeGaussianBlurEle
 // filter primitive (i.e., the result is a transparent black image).
 func (e *SVGFeGaussianBlurElement) IfStdDeviation(condition bool, f float64) *SVGFeGaussianBlurElement {
 	if condition {
-		e.StdDeviation(f)
+		e = e.StdDeviation(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeGaussianBlurElement) ID(s string) *SVGFeGaussianBlurElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -293,7 +318,7 @@ func (e *SVGFeGaussianBlurElement) IDf(format string, args ...any) *SVGFeGaussia
 // Specifies a unique id for an element
 func (e *SVGFeGaussianBlurElement) IfID(condition bool, s string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -301,7 +326,7 @@ func (e *SVGFeGaussianBlurElement) IfID(condition bool, s string) *SVGFeGaussian
 // Specifies a unique id for an element
 func (e *SVGFeGaussianBlurElement) IfIDf(condition bool, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -309,26 +334,17 @@ func (e *SVGFeGaussianBlurElement) IfIDf(condition bool, format string, args ...
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeGaussianBlurElement) IDRemove() *SVGFeGaussianBlurElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeGaussianBlurElement) Class(s string) *SVGFeGaussianBlurElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -336,7 +352,7 @@ func (e *SVGFeGaussianBlurElement) Class(s string) *SVGFeGaussianBlurElement {
 // sheet)
 func (e *SVGFeGaussianBlurElement) IfClass(condition bool, s string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -345,14 +361,8 @@ func (e *SVGFeGaussianBlurElement) IfClass(condition bool, s string) *SVGFeGauss
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeGaussianBlurElement) ClassRemove(s ...string) *SVGFeGaussianBlurElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -361,14 +371,8 @@ func (e *SVGFeGaussianBlurElement) StylePairs(pairs ...string) *SVGFeGaussianBlu
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -382,14 +386,8 @@ func (e *SVGFeGaussianBlurElement) StylePairs(pairs ...string) *SVGFeGaussianBlu
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeGaussianBlurElement) Style(s string) *SVGFeGaussianBlurElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -397,7 +395,7 @@ func (e *SVGFeGaussianBlurElement) Style(s string) *SVGFeGaussianBlurElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -405,22 +403,14 @@ func (e *SVGFeGaussianBlurElement) Style(s string) *SVGFeGaussianBlurElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeGaussianBlurElement) IfStyle(condition bool, s string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeGaussianBlurElement) StyleAdd(k string, v string) *SVGFeGaussianBlurElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -432,7 +422,7 @@ func (e *SVGFeGaussianBlurElement) StyleAddf(k string, format string, args ...an
 // Specifies an inline CSS style for an element
 func (e *SVGFeGaussianBlurElement) IfStyleAdd(condition bool, k string, v string) *SVGFeGaussianBlurElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -440,7 +430,7 @@ func (e *SVGFeGaussianBlurElement) IfStyleAdd(condition bool, k string, v string
 // Specifies an inline CSS style for an element
 func (e *SVGFeGaussianBlurElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeGaussianBlurElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -448,21 +438,15 @@ func (e *SVGFeGaussianBlurElement) IfStyleAddf(condition bool, k string, format
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeGaussianBlurElement) StyleMap(m map[string]string) *SVGFeGaussianBlurElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -470,13 +454,7 @@ func (e *SVGFeGaussianBlurElement) StyleMap(m map[string]string) *SVGFeGaussianB
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeGaussianBlurElement) StyleRemove(keys ...string) *SVGFeGaussianBlurElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feImage> SVG filter primitive fetches image data from an external source
@@ -23,18 +21,59 @@ type SVGFeImageElement struct {
 // with the tag "feImage" during rendering.
 func SVGFeImage(children ...ElementRenderer) *SVGFeImageElement {
 	e := NewElement("feImage", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeImageElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeImageElement) Clone() *SVGFeImageElement {
+	return &SVGFeImageElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeImageElement) CloneShallow() *SVGFeImageElement {
+	return &SVGFeImageElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeImageElement) Freeze() *SVGFeImageElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeImageElement) writable() *SVGFeImageElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeImageElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeImageElement) choiceType(name string) func(string) any {
+	switch name {
+	case "preserveAspectRatio":
+		return choiceValue[SVGFeImagePreserveAspectRatioChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeImageElement) Children(children ...ElementRenderer) *SVGFeImageElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeImageElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGFeImageElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGFeImageElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) BoolAttr(name string) *SVGFeImageElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeImageElement) BoolAttrRemove(name string) *SVGFeImageElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeImageElement) IfBoolAttr(condition bool, name string) *SVGFeImageElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGFeImageElement) BoolAttrf(format string, args ...any) *SVGFeImageEle
 
 func (e *SVGFeImageElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeImageElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) BoolAttrs(names ...string) *SVGFeImageElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) IfBoolAttrs(condition bool, names ...string) *SVGFeImageElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) Attr(name, value string) *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeImageElement) RawAttr(name, value string) *SVGFeImageElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeImageElement) IfAttr(condition bool, name, value string) *SVGFeImageElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGFeImageElement) Attrf(name, format string, args ...any) *SVGFeImageE
 
 func (e *SVGFeImageElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeImageElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) Attrs(attrs ...string) *SVGFeImageElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeImageElement) IfAttrs(condition bool, attrs ...string) *SVGFeImageElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) AttrsMap(attrs map[string]string) *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeImageElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeImageElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeImageElement) Text(text string) *SVGFeImageElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeImageElement) Textf(format string, args ...any) *SVGFeImageElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeImageElement) IfText(condition bool, text string) *SVGFeImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGFeImageElement) IfText(condition bool, text string) *SVGFeImageEleme
 
 func (e *SVGFeImageElement) IfTextf(condition bool, format string, args ...any) *SVGFeImageElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeImageElement) Raw(text string) *SVGFeImageElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeImageElement) Escaped(text string) *SVGFeImageElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeImageElement) IfEscaped(condition bool, text string) *SVGFeImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGFeImageElement) Escapedf(format string, args ...any) *SVGFeImageElem
 
 func (e *SVGFeImageElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,17 +253,15 @@ func (e *SVGFeImageElement) IfEscapedf(condition bool, format string, args ...an
 
 // Indicates whether or not to force synchronous behavior.
 func (e *SVGFeImageElement) ExternalResourcesRequired() *SVGFeImageElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set("externalResourcesRequired", struct{}{})
+	e = e.writable()
+	e.setBoolAttribute("externalResourcesRequired")
 	return e
 }
 
 // Indicates whether or not to force synchronous behavior.
 func (e *SVGFeImageElement) IfExternalResourcesRequired(condition bool) *SVGFeImageElement {
 	if condition {
-		e.ExternalResourcesRequired()
+		e = e.ExternalResourcesRequired()
 	}
 	return e
 }
@@ -228,29 +269,23 @@ func (e *SVGFeImageElement) IfExternalResourcesRequired(condition bool) *SVGFeIm
 // Remove the attribute ExternalResourcesRequired from the element.
 // Indicates whether or not to force synchronous behavior.
 func (e *SVGFeImageElement) ExternalResourcesRequiredRemove() *SVGFeImageElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("externalResourcesRequired")
+	e = e.writable()
+	e.removeAttribute("externalResourcesRequired")
 	return e
 }
 
 // Remove the attribute ExternalResourcesRequired from the element.
 // Indicates whether or not to force synchronous behavior.
 func (e *SVGFeImageElement) ExternalResourcesRequiredIfRemove() *SVGFeImageElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del("externalResourcesRequired")
+	e = e.writable()
+	e.removeAttribute("externalResourcesRequired")
 	return e
 }
 
 // Indicates how the fetched image is fitted into the destination rectangle.
 func (e *SVGFeImageElement) PreserveAspectRatio(c SVGFeImagePreserveAspectRatioChoice) *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("preserveAspectRatio", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("preserveAspectRatio", string(c), choiceValue[SVGFeImagePreserveAspectRatioChoice])
 	return e
 }
 
@@ -288,19 +323,15 @@ const (
 // Indicates how the fetched image is fitted into the destination rectangle.
 // Remove the attribute PreserveAspectRatio from the element.
 func (e *SVGFeImageElement) PreserveAspectRatioRemove() *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("preserveAspectRatio")
+	e = e.writable()
+	e.removeAttribute("preserveAspectRatio")
 	return e
 }
 
 // A URI reference to an external resource.
 func (e *SVGFeImageElement) Href(s string) *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -312,7 +343,7 @@ func (e *SVGFeImageElement) Hreff(format string, args ...any) *SVGFeImageElement
 // A URI reference to an external resource.
 func (e *SVGFeImageElement) IfHref(condition bool, s string) *SVGFeImageElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -320,7 +351,7 @@ func (e *SVGFeImageElement) IfHref(condition bool, s string) *SVGFeImageElement
 // A URI reference to an external resource.
 func (e *SVGFeImageElement) IfHreff(condition bool, format string, args ...any) *SVGFeImageElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -328,19 +359,15 @@ func (e *SVGFeImageElement) IfHreff(condition bool, format string, args ...any)
 // A URI reference to an external resource.
 // Remove the attribute Href from the element.
 func (e *SVGFeImageElement) HrefRemove() *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeImageElement) ID(s string) *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -352,7 +379,7 @@ func (e *SVGFeImageElement) IDf(format string, args ...any) *SVGFeImageElement {
 // Specifies a unique id for an element
 func (e *SVGFeImageElement) IfID(condition bool, s string) *SVGFeImageElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -360,7 +387,7 @@ func (e *SVGFeImageElement) IfID(condition bool, s string) *SVGFeImageElement {
 // Specifies a unique id for an element
 func (e *SVGFeImageElement) IfIDf(condition bool, format string, args ...any) *SVGFeImageElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -368,26 +395,17 @@ func (e *SVGFeImageElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeImageElement) IDRemove() *SVGFeImageElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeImageElement) Class(s string) *SVGFeImageElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -395,7 +413,7 @@ func (e *SVGFeImageElement) Class(s string) *SVGFeImageElement {
 // sheet)
 func (e *SVGFeImageElement) IfClass(condition bool, s string) *SVGFeImageElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -404,14 +422,8 @@ func (e *SVGFeImageElement) IfClass(condition bool, s string) *SVGFeImageElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeImageElement) ClassRemove(s ...string) *SVGFeImageElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -420,14 +432,8 @@ func (e *SVGFeImageElement) StylePairs(pairs ...string) *SVGFeImageElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -441,14 +447,8 @@ func (e *SVGFeImageElement) StylePairs(pairs ...string) *SVGFeImageElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeImageElement) Style(s string) *SVGFeImageElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -456,7 +456,7 @@ func (e *SVGFeImageElement) Style(s string) *SVGFeImageElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -464,22 +464,14 @@ func (e *SVGFeImageElement) Style(s string) *SVGFeImageElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeImageElement) IfStyle(condition bool, s string) *SVGFeImageElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeImageElement) StyleAdd(k string, v string) *SVGFeImageElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -491,7 +483,7 @@ func (e *SVGFeImageElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeImageElement) IfStyleAdd(condition bool, k string, v string) *SVGFeImageElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -499,7 +491,7 @@ func (e *SVGFeImageElement) IfStyleAdd(condition bool, k string, v string) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeImageElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeImageElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -507,21 +499,15 @@ func (e *SVGFeImageElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeImageElement) StyleMap(m map[string]string) *SVGFeImageElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -529,13 +515,7 @@ func (e *SVGFeImageElement) StyleMap(m map[string]string) *SVGFeImageElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeImageElement) StyleRemove(keys ...string) *SVGFeImageElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
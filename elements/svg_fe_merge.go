@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feMerge> SVG element allows filter effects to be applied concurrently
@@ -22,18 +20,49 @@ type SVGFeMergeElement struct {
 // with the tag "feMerge" during rendering.
 func SVGFeMerge(children ...ElementRenderer) *SVGFeMergeElement {
 	e := NewElement("feMerge", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeMergeElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeMergeElement) Clone() *SVGFeMergeElement {
+	return &SVGFeMergeElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeMergeElement) CloneShallow() *SVGFeMergeElement {
+	return &SVGFeMergeElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeMergeElement) Freeze() *SVGFeMergeElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeMergeElement) writable() *SVGFeMergeElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeMergeElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeMergeElement) Children(children ...ElementRenderer) *SVGFeMergeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeMergeElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeMergeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGFeMergeElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGFeMergeElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeMergeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) BoolAttr(name string) *SVGFeMergeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeMergeElement) BoolAttrRemove(name string) *SVGFeMergeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeMergeElement) IfBoolAttr(condition bool, name string) *SVGFeMergeElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGFeMergeElement) BoolAttrf(format string, args ...any) *SVGFeMergeEle
 
 func (e *SVGFeMergeElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeMergeElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) BoolAttrs(names ...string) *SVGFeMergeElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) IfBoolAttrs(condition bool, names ...string) *SVGFeMergeElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) Attr(name, value string) *SVGFeMergeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeMergeElement) RawAttr(name, value string) *SVGFeMergeElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeMergeElement) IfAttr(condition bool, name, value string) *SVGFeMergeElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGFeMergeElement) Attrf(name, format string, args ...any) *SVGFeMergeE
 
 func (e *SVGFeMergeElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeMergeElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) Attrs(attrs ...string) *SVGFeMergeElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeMergeElement) IfAttrs(condition bool, attrs ...string) *SVGFeMergeElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) AttrsMap(attrs map[string]string) *SVGFeMergeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeMergeElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeMergeElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeMergeElement) Text(text string) *SVGFeMergeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeMergeElement) Textf(format string, args ...any) *SVGFeMergeElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeMergeElement) IfText(condition bool, text string) *SVGFeMergeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGFeMergeElement) IfText(condition bool, text string) *SVGFeMergeEleme
 
 func (e *SVGFeMergeElement) IfTextf(condition bool, format string, args ...any) *SVGFeMergeElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeMergeElement) Raw(text string) *SVGFeMergeElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeMergeElement) Escaped(text string) *SVGFeMergeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeMergeElement) IfEscaped(condition bool, text string) *SVGFeMergeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGFeMergeElement) Escapedf(format string, args ...any) *SVGFeMergeElem
 
 func (e *SVGFeMergeElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeMergeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGFeMergeElement) IfEscapedf(condition bool, format string, args ...an
 
 // Specifies a unique id for an element
 func (e *SVGFeMergeElement) ID(s string) *SVGFeMergeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGFeMergeElement) IDf(format string, args ...any) *SVGFeMergeElement {
 // Specifies a unique id for an element
 func (e *SVGFeMergeElement) IfID(condition bool, s string) *SVGFeMergeElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGFeMergeElement) IfID(condition bool, s string) *SVGFeMergeElement {
 // Specifies a unique id for an element
 func (e *SVGFeMergeElement) IfIDf(condition bool, format string, args ...any) *SVGFeMergeElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,26 +271,17 @@ func (e *SVGFeMergeElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeMergeElement) IDRemove() *SVGFeMergeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeMergeElement) Class(s string) *SVGFeMergeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -267,7 +289,7 @@ func (e *SVGFeMergeElement) Class(s string) *SVGFeMergeElement {
 // sheet)
 func (e *SVGFeMergeElement) IfClass(condition bool, s string) *SVGFeMergeElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -276,14 +298,8 @@ func (e *SVGFeMergeElement) IfClass(condition bool, s string) *SVGFeMergeElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeMergeElement) ClassRemove(s ...string) *SVGFeMergeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -292,14 +308,8 @@ func (e *SVGFeMergeElement) StylePairs(pairs ...string) *SVGFeMergeElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -313,14 +323,8 @@ func (e *SVGFeMergeElement) StylePairs(pairs ...string) *SVGFeMergeElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeElement) Style(s string) *SVGFeMergeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -328,7 +332,7 @@ func (e *SVGFeMergeElement) Style(s string) *SVGFeMergeElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -336,22 +340,14 @@ func (e *SVGFeMergeElement) Style(s string) *SVGFeMergeElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeElement) IfStyle(condition bool, s string) *SVGFeMergeElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeElement) StyleAdd(k string, v string) *SVGFeMergeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -363,7 +359,7 @@ func (e *SVGFeMergeElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeElement) IfStyleAdd(condition bool, k string, v string) *SVGFeMergeElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -371,7 +367,7 @@ func (e *SVGFeMergeElement) IfStyleAdd(condition bool, k string, v string) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeMergeElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -379,21 +375,15 @@ func (e *SVGFeMergeElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeMergeElement) StyleMap(m map[string]string) *SVGFeMergeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -401,13 +391,7 @@ func (e *SVGFeMergeElement) StyleMap(m map[string]string) *SVGFeMergeElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeMergeElement) StyleRemove(keys ...string) *SVGFeMergeElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
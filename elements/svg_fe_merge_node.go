@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feMergeNode> SVG element allows a series of filter primitives to be
@@ -23,18 +21,49 @@ type SVGFeMergeNodeElement struct {
 // with the tag "feMergeNode" during rendering.
 func SVGFeMergeNode(children ...ElementRenderer) *SVGFeMergeNodeElement {
 	e := NewElement("feMergeNode", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeMergeNodeElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeMergeNodeElement) Clone() *SVGFeMergeNodeElement {
+	return &SVGFeMergeNodeElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeMergeNodeElement) CloneShallow() *SVGFeMergeNodeElement {
+	return &SVGFeMergeNodeElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeMergeNodeElement) Freeze() *SVGFeMergeNodeElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeMergeNodeElement) writable() *SVGFeMergeNodeElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeMergeNodeElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeMergeNodeElement) Children(children ...ElementRenderer) *SVGFeMergeNodeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeMergeNodeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGFeMergeNodeElement) IfChildren(condition bool, children ...ElementRe
 
 func (e *SVGFeMergeNodeElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeMergeNodeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) BoolAttr(name string) *SVGFeMergeNodeElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) BoolAttrRemove(name string) *SVGFeMergeNodeElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfBoolAttr(condition bool, name string) *SVGFeMergeNodeElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGFeMergeNodeElement) BoolAttrf(format string, args ...any) *SVGFeMerg
 
 func (e *SVGFeMergeNodeElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) BoolAttrs(names ...string) *SVGFeMergeNodeElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfBoolAttrs(condition bool, names ...string) *SVGFeMergeNodeElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) Attr(name, value string) *SVGFeMergeNodeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeMergeNodeElement) RawAttr(name, value string) *SVGFeMergeNodeElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfAttr(condition bool, name, value string) *SVGFeMergeNodeElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGFeMergeNodeElement) Attrf(name, format string, args ...any) *SVGFeMe
 
 func (e *SVGFeMergeNodeElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) Attrs(attrs ...string) *SVGFeMergeNodeElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfAttrs(condition bool, attrs ...string) *SVGFeMergeNodeElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) AttrsMap(attrs map[string]string) *SVGFeMergeNodeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeMergeNodeElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) Text(text string) *SVGFeMergeNodeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) Textf(format string, args ...any) *SVGFeMergeNodeElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfText(condition bool, text string) *SVGFeMergeNodeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGFeMergeNodeElement) IfText(condition bool, text string) *SVGFeMergeN
 
 func (e *SVGFeMergeNodeElement) IfTextf(condition bool, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeMergeNodeElement) Raw(text string) *SVGFeMergeNodeElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeMergeNodeElement) Escaped(text string) *SVGFeMergeNodeElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeMergeNodeElement) IfEscaped(condition bool, text string) *SVGFeMergeNodeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGFeMergeNodeElement) Escapedf(format string, args ...any) *SVGFeMerge
 
 func (e *SVGFeMergeNodeElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFeMergeNodeElement) IfEscapedf(condition bool, format string, args .
 // The identifier for the input SVGAnimatedString Attribute on the given
 // 'feMergeNode' element.
 func (e *SVGFeMergeNodeElement) In(s string) *SVGFeMergeNodeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -228,7 +259,7 @@ func (e *SVGFeMergeNodeElement) Inf(format string, args ...any) *SVGFeMergeNodeE
 // 'feMergeNode' element.
 func (e *SVGFeMergeNodeElement) IfIn(condition bool, s string) *SVGFeMergeNodeElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -237,7 +268,7 @@ func (e *SVGFeMergeNodeElement) IfIn(condition bool, s string) *SVGFeMergeNodeEl
 // 'feMergeNode' element.
 func (e *SVGFeMergeNodeElement) IfInf(condition bool, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -246,19 +277,15 @@ func (e *SVGFeMergeNodeElement) IfInf(condition bool, format string, args ...any
 // 'feMergeNode' element.
 // Remove the attribute In from the element.
 func (e *SVGFeMergeNodeElement) InRemove() *SVGFeMergeNodeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeMergeNodeElement) ID(s string) *SVGFeMergeNodeElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -270,7 +297,7 @@ func (e *SVGFeMergeNodeElement) IDf(format string, args ...any) *SVGFeMergeNodeE
 // Specifies a unique id for an element
 func (e *SVGFeMergeNodeElement) IfID(condition bool, s string) *SVGFeMergeNodeElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -278,7 +305,7 @@ func (e *SVGFeMergeNodeElement) IfID(condition bool, s string) *SVGFeMergeNodeEl
 // Specifies a unique id for an element
 func (e *SVGFeMergeNodeElement) IfIDf(condition bool, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -286,26 +313,17 @@ func (e *SVGFeMergeNodeElement) IfIDf(condition bool, format string, args ...any
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeMergeNodeElement) IDRemove() *SVGFeMergeNodeElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeMergeNodeElement) Class(s string) *SVGFeMergeNodeElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -313,7 +331,7 @@ func (e *SVGFeMergeNodeElement) Class(s string) *SVGFeMergeNodeElement {
 // sheet)
 func (e *SVGFeMergeNodeElement) IfClass(condition bool, s string) *SVGFeMergeNodeElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -322,14 +340,8 @@ func (e *SVGFeMergeNodeElement) IfClass(condition bool, s string) *SVGFeMergeNod
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeMergeNodeElement) ClassRemove(s ...string) *SVGFeMergeNodeElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -338,14 +350,8 @@ func (e *SVGFeMergeNodeElement) StylePairs(pairs ...string) *SVGFeMergeNodeEleme
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -359,14 +365,8 @@ func (e *SVGFeMergeNodeElement) StylePairs(pairs ...string) *SVGFeMergeNodeEleme
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeNodeElement) Style(s string) *SVGFeMergeNodeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -374,7 +374,7 @@ func (e *SVGFeMergeNodeElement) Style(s string) *SVGFeMergeNodeElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -382,22 +382,14 @@ func (e *SVGFeMergeNodeElement) Style(s string) *SVGFeMergeNodeElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeNodeElement) IfStyle(condition bool, s string) *SVGFeMergeNodeElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeNodeElement) StyleAdd(k string, v string) *SVGFeMergeNodeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -409,7 +401,7 @@ func (e *SVGFeMergeNodeElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeNodeElement) IfStyleAdd(condition bool, k string, v string) *SVGFeMergeNodeElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -417,7 +409,7 @@ func (e *SVGFeMergeNodeElement) IfStyleAdd(condition bool, k string, v string) *
 // Specifies an inline CSS style for an element
 func (e *SVGFeMergeNodeElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeMergeNodeElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -425,21 +417,15 @@ func (e *SVGFeMergeNodeElement) IfStyleAddf(condition bool, k string, format str
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeMergeNodeElement) StyleMap(m map[string]string) *SVGFeMergeNodeElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -447,13 +433,7 @@ func (e *SVGFeMergeNodeElement) StyleMap(m map[string]string) *SVGFeMergeNodeEle
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeMergeNodeElement) StyleRemove(keys ...string) *SVGFeMergeNodeElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
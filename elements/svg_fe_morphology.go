@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feMorphology> SVG filter primitive is used to erode or dilate the input
@@ -22,18 +20,59 @@ type SVGFeMorphologyElement struct {
 // with the tag "feMorphology" during rendering.
 func SVGFeMorphology(children ...ElementRenderer) *SVGFeMorphologyElement {
 	e := NewElement("feMorphology", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeMorphologyElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeMorphologyElement) Clone() *SVGFeMorphologyElement {
+	return &SVGFeMorphologyElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeMorphologyElement) CloneShallow() *SVGFeMorphologyElement {
+	return &SVGFeMorphologyElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeMorphologyElement) Freeze() *SVGFeMorphologyElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeMorphologyElement) writable() *SVGFeMorphologyElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeMorphologyElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeMorphologyElement) choiceType(name string) func(string) any {
+	switch name {
+	case "operator":
+		return choiceValue[SVGFeMorphologyOperatorChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeMorphologyElement) Children(children ...ElementRenderer) *SVGFeMorphologyElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeMorphologyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +80,30 @@ func (e *SVGFeMorphologyElement) IfChildren(condition bool, children ...ElementR
 
 func (e *SVGFeMorphologyElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeMorphologyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) BoolAttr(name string) *SVGFeMorphologyElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeMorphologyElement) BoolAttrRemove(name string) *SVGFeMorphologyElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfBoolAttr(condition bool, name string) *SVGFeMorphologyElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +114,40 @@ func (e *SVGFeMorphologyElement) BoolAttrf(format string, args ...any) *SVGFeMor
 
 func (e *SVGFeMorphologyElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) BoolAttrs(names ...string) *SVGFeMorphologyElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfBoolAttrs(condition bool, names ...string) *SVGFeMorphologyElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) Attr(name, value string) *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeMorphologyElement) RawAttr(name, value string) *SVGFeMorphologyElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfAttr(condition bool, name, value string) *SVGFeMorphologyElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +158,52 @@ func (e *SVGFeMorphologyElement) Attrf(name, format string, args ...any) *SVGFeM
 
 func (e *SVGFeMorphologyElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) Attrs(attrs ...string) *SVGFeMorphologyElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfAttrs(condition bool, attrs ...string) *SVGFeMorphologyElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) AttrsMap(attrs map[string]string) *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeMorphologyElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeMorphologyElement) Text(text string) *SVGFeMorphologyElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeMorphologyElement) Textf(format string, args ...any) *SVGFeMorphologyElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeMorphologyElement) IfText(condition bool, text string) *SVGFeMorphologyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +211,28 @@ func (e *SVGFeMorphologyElement) IfText(condition bool, text string) *SVGFeMorph
 
 func (e *SVGFeMorphologyElement) IfTextf(condition bool, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeMorphologyElement) Raw(text string) *SVGFeMorphologyElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeMorphologyElement) Escaped(text string) *SVGFeMorphologyElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeMorphologyElement) IfEscaped(condition bool, text string) *SVGFeMorphologyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +244,7 @@ func (e *SVGFeMorphologyElement) Escapedf(format string, args ...any) *SVGFeMorp
 
 func (e *SVGFeMorphologyElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +252,8 @@ func (e *SVGFeMorphologyElement) IfEscapedf(condition bool, format string, args
 
 // The input for this filter.
 func (e *SVGFeMorphologyElement) In(s string) *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -224,7 +265,7 @@ func (e *SVGFeMorphologyElement) Inf(format string, args ...any) *SVGFeMorpholog
 // The input for this filter.
 func (e *SVGFeMorphologyElement) IfIn(condition bool, s string) *SVGFeMorphologyElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -232,7 +273,7 @@ func (e *SVGFeMorphologyElement) IfIn(condition bool, s string) *SVGFeMorphology
 // The input for this filter.
 func (e *SVGFeMorphologyElement) IfInf(condition bool, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +281,15 @@ func (e *SVGFeMorphologyElement) IfInf(condition bool, format string, args ...an
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeMorphologyElement) InRemove() *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // The operator Attribute defines what type of operation is performed.
 func (e *SVGFeMorphologyElement) Operator(c SVGFeMorphologyOperatorChoice) *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("operator", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("operator", string(c), choiceValue[SVGFeMorphologyOperatorChoice])
 	return e
 }
 
@@ -268,36 +305,30 @@ const (
 // The operator Attribute defines what type of operation is performed.
 // Remove the attribute Operator from the element.
 func (e *SVGFeMorphologyElement) OperatorRemove() *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("operator")
+	e = e.writable()
+	e.removeAttribute("operator")
 	return e
 }
 
 // The radius Attribute indicates the size of the matrix.
 func (e *SVGFeMorphologyElement) Radius(f float64) *SVGFeMorphologyElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("radius", f)
+	e = e.writable()
+	e.setFloatAttribute("radius", f, 0)
 	return e
 }
 
 // The radius Attribute indicates the size of the matrix.
 func (e *SVGFeMorphologyElement) IfRadius(condition bool, f float64) *SVGFeMorphologyElement {
 	if condition {
-		e.Radius(f)
+		e = e.Radius(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeMorphologyElement) ID(s string) *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -309,7 +340,7 @@ func (e *SVGFeMorphologyElement) IDf(format string, args ...any) *SVGFeMorpholog
 // Specifies a unique id for an element
 func (e *SVGFeMorphologyElement) IfID(condition bool, s string) *SVGFeMorphologyElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -317,7 +348,7 @@ func (e *SVGFeMorphologyElement) IfID(condition bool, s string) *SVGFeMorphology
 // Specifies a unique id for an element
 func (e *SVGFeMorphologyElement) IfIDf(condition bool, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -325,26 +356,17 @@ func (e *SVGFeMorphologyElement) IfIDf(condition bool, format string, args ...an
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeMorphologyElement) IDRemove() *SVGFeMorphologyElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeMorphologyElement) Class(s string) *SVGFeMorphologyElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -352,7 +374,7 @@ func (e *SVGFeMorphologyElement) Class(s string) *SVGFeMorphologyElement {
 // sheet)
 func (e *SVGFeMorphologyElement) IfClass(condition bool, s string) *SVGFeMorphologyElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -361,14 +383,8 @@ func (e *SVGFeMorphologyElement) IfClass(condition bool, s string) *SVGFeMorphol
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeMorphologyElement) ClassRemove(s ...string) *SVGFeMorphologyElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -377,14 +393,8 @@ func (e *SVGFeMorphologyElement) StylePairs(pairs ...string) *SVGFeMorphologyEle
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -398,14 +408,8 @@ func (e *SVGFeMorphologyElement) StylePairs(pairs ...string) *SVGFeMorphologyEle
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeMorphologyElement) Style(s string) *SVGFeMorphologyElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -413,7 +417,7 @@ func (e *SVGFeMorphologyElement) Style(s string) *SVGFeMorphologyElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -421,22 +425,14 @@ func (e *SVGFeMorphologyElement) Style(s string) *SVGFeMorphologyElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeMorphologyElement) IfStyle(condition bool, s string) *SVGFeMorphologyElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeMorphologyElement) StyleAdd(k string, v string) *SVGFeMorphologyElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -448,7 +444,7 @@ func (e *SVGFeMorphologyElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFeMorphologyElement) IfStyleAdd(condition bool, k string, v string) *SVGFeMorphologyElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -456,7 +452,7 @@ func (e *SVGFeMorphologyElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGFeMorphologyElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeMorphologyElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -464,21 +460,15 @@ func (e *SVGFeMorphologyElement) IfStyleAddf(condition bool, k string, format st
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeMorphologyElement) StyleMap(m map[string]string) *SVGFeMorphologyElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -486,13 +476,7 @@ func (e *SVGFeMorphologyElement) StyleMap(m map[string]string) *SVGFeMorphologyE
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeMorphologyElement) StyleRemove(keys ...string) *SVGFeMorphologyElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
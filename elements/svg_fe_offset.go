@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feOffset> SVG filter primitive allows to offset the input image. The
@@ -22,18 +20,49 @@ type SVGFeOffsetElement struct {
 // with the tag "feOffset" during rendering.
 func SVGFeOffset(children ...ElementRenderer) *SVGFeOffsetElement {
 	e := NewElement("feOffset", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeOffsetElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeOffsetElement) Clone() *SVGFeOffsetElement {
+	return &SVGFeOffsetElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeOffsetElement) CloneShallow() *SVGFeOffsetElement {
+	return &SVGFeOffsetElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeOffsetElement) Freeze() *SVGFeOffsetElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeOffsetElement) writable() *SVGFeOffsetElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeOffsetElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeOffsetElement) Children(children ...ElementRenderer) *SVGFeOffsetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeOffsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGFeOffsetElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *SVGFeOffsetElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeOffsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) BoolAttr(name string) *SVGFeOffsetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeOffsetElement) BoolAttrRemove(name string) *SVGFeOffsetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfBoolAttr(condition bool, name string) *SVGFeOffsetElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGFeOffsetElement) BoolAttrf(format string, args ...any) *SVGFeOffsetE
 
 func (e *SVGFeOffsetElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeOffsetElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) BoolAttrs(names ...string) *SVGFeOffsetElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfBoolAttrs(condition bool, names ...string) *SVGFeOffsetElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) Attr(name, value string) *SVGFeOffsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeOffsetElement) RawAttr(name, value string) *SVGFeOffsetElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfAttr(condition bool, name, value string) *SVGFeOffsetElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGFeOffsetElement) Attrf(name, format string, args ...any) *SVGFeOffse
 
 func (e *SVGFeOffsetElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeOffsetElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) Attrs(attrs ...string) *SVGFeOffsetElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfAttrs(condition bool, attrs ...string) *SVGFeOffsetElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) AttrsMap(attrs map[string]string) *SVGFeOffsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeOffsetElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeOffsetElement) Text(text string) *SVGFeOffsetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeOffsetElement) Textf(format string, args ...any) *SVGFeOffsetElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeOffsetElement) IfText(condition bool, text string) *SVGFeOffsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGFeOffsetElement) IfText(condition bool, text string) *SVGFeOffsetEle
 
 func (e *SVGFeOffsetElement) IfTextf(condition bool, format string, args ...any) *SVGFeOffsetElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeOffsetElement) Raw(text string) *SVGFeOffsetElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeOffsetElement) Escaped(text string) *SVGFeOffsetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeOffsetElement) IfEscaped(condition bool, text string) *SVGFeOffsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGFeOffsetElement) Escapedf(format string, args ...any) *SVGFeOffsetEl
 
 func (e *SVGFeOffsetElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeOffsetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,44 +242,38 @@ func (e *SVGFeOffsetElement) IfEscapedf(condition bool, format string, args ...a
 
 // The dx Attribute indicates a shift along the x-axis on the kernel matrix.
 func (e *SVGFeOffsetElement) Dx(f float64) *SVGFeOffsetElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dx", f)
+	e = e.writable()
+	e.setFloatAttribute("dx", f, 0)
 	return e
 }
 
 // The dx Attribute indicates a shift along the x-axis on the kernel matrix.
 func (e *SVGFeOffsetElement) IfDx(condition bool, f float64) *SVGFeOffsetElement {
 	if condition {
-		e.Dx(f)
+		e = e.Dx(f)
 	}
 	return e
 }
 
 // The dy Attribute indicates a shift along the y-axis on the kernel matrix.
 func (e *SVGFeOffsetElement) Dy(f float64) *SVGFeOffsetElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dy", f)
+	e = e.writable()
+	e.setFloatAttribute("dy", f, 0)
 	return e
 }
 
 // The dy Attribute indicates a shift along the y-axis on the kernel matrix.
 func (e *SVGFeOffsetElement) IfDy(condition bool, f float64) *SVGFeOffsetElement {
 	if condition {
-		e.Dy(f)
+		e = e.Dy(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeOffsetElement) ID(s string) *SVGFeOffsetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -258,7 +285,7 @@ func (e *SVGFeOffsetElement) IDf(format string, args ...any) *SVGFeOffsetElement
 // Specifies a unique id for an element
 func (e *SVGFeOffsetElement) IfID(condition bool, s string) *SVGFeOffsetElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -266,7 +293,7 @@ func (e *SVGFeOffsetElement) IfID(condition bool, s string) *SVGFeOffsetElement
 // Specifies a unique id for an element
 func (e *SVGFeOffsetElement) IfIDf(condition bool, format string, args ...any) *SVGFeOffsetElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -274,26 +301,17 @@ func (e *SVGFeOffsetElement) IfIDf(condition bool, format string, args ...any) *
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeOffsetElement) IDRemove() *SVGFeOffsetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeOffsetElement) Class(s string) *SVGFeOffsetElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -301,7 +319,7 @@ func (e *SVGFeOffsetElement) Class(s string) *SVGFeOffsetElement {
 // sheet)
 func (e *SVGFeOffsetElement) IfClass(condition bool, s string) *SVGFeOffsetElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -310,14 +328,8 @@ func (e *SVGFeOffsetElement) IfClass(condition bool, s string) *SVGFeOffsetEleme
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeOffsetElement) ClassRemove(s ...string) *SVGFeOffsetElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -326,14 +338,8 @@ func (e *SVGFeOffsetElement) StylePairs(pairs ...string) *SVGFeOffsetElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -347,14 +353,8 @@ func (e *SVGFeOffsetElement) StylePairs(pairs ...string) *SVGFeOffsetElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeOffsetElement) Style(s string) *SVGFeOffsetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -362,7 +362,7 @@ func (e *SVGFeOffsetElement) Style(s string) *SVGFeOffsetElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -370,22 +370,14 @@ func (e *SVGFeOffsetElement) Style(s string) *SVGFeOffsetElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeOffsetElement) IfStyle(condition bool, s string) *SVGFeOffsetElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeOffsetElement) StyleAdd(k string, v string) *SVGFeOffsetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -397,7 +389,7 @@ func (e *SVGFeOffsetElement) StyleAddf(k string, format string, args ...any) *SV
 // Specifies an inline CSS style for an element
 func (e *SVGFeOffsetElement) IfStyleAdd(condition bool, k string, v string) *SVGFeOffsetElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -405,7 +397,7 @@ func (e *SVGFeOffsetElement) IfStyleAdd(condition bool, k string, v string) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGFeOffsetElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeOffsetElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -413,21 +405,15 @@ func (e *SVGFeOffsetElement) IfStyleAddf(condition bool, k string, format string
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeOffsetElement) StyleMap(m map[string]string) *SVGFeOffsetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -435,13 +421,7 @@ func (e *SVGFeOffsetElement) StyleMap(m map[string]string) *SVGFeOffsetElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeOffsetElement) StyleRemove(keys ...string) *SVGFeOffsetElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
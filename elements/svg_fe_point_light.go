@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <fePointLight> SVG filter primitive defines a light source at a point on
@@ -22,18 +20,49 @@ type SVGFePointLightElement struct {
 // with the tag "fePointLight" during rendering.
 func SVGFePointLight(children ...ElementRenderer) *SVGFePointLightElement {
 	e := NewElement("fePointLight", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFePointLightElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFePointLightElement) Clone() *SVGFePointLightElement {
+	return &SVGFePointLightElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFePointLightElement) CloneShallow() *SVGFePointLightElement {
+	return &SVGFePointLightElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFePointLightElement) Freeze() *SVGFePointLightElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFePointLightElement) writable() *SVGFePointLightElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFePointLightElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFePointLightElement) Children(children ...ElementRenderer) *SVGFePointLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFePointLightElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFePointLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGFePointLightElement) IfChildren(condition bool, children ...ElementR
 
 func (e *SVGFePointLightElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFePointLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) BoolAttr(name string) *SVGFePointLightElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFePointLightElement) BoolAttrRemove(name string) *SVGFePointLightElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFePointLightElement) IfBoolAttr(condition bool, name string) *SVGFePointLightElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGFePointLightElement) BoolAttrf(format string, args ...any) *SVGFePoi
 
 func (e *SVGFePointLightElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFePointLightElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) BoolAttrs(names ...string) *SVGFePointLightElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) IfBoolAttrs(condition bool, names ...string) *SVGFePointLightElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) Attr(name, value string) *SVGFePointLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFePointLightElement) RawAttr(name, value string) *SVGFePointLightElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFePointLightElement) IfAttr(condition bool, name, value string) *SVGFePointLightElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGFePointLightElement) Attrf(name, format string, args ...any) *SVGFeP
 
 func (e *SVGFePointLightElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFePointLightElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) Attrs(attrs ...string) *SVGFePointLightElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFePointLightElement) IfAttrs(condition bool, attrs ...string) *SVGFePointLightElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) AttrsMap(attrs map[string]string) *SVGFePointLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFePointLightElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFePointLightElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFePointLightElement) Text(text string) *SVGFePointLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFePointLightElement) Textf(format string, args ...any) *SVGFePointLightElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFePointLightElement) IfText(condition bool, text string) *SVGFePointLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGFePointLightElement) IfText(condition bool, text string) *SVGFePoint
 
 func (e *SVGFePointLightElement) IfTextf(condition bool, format string, args ...any) *SVGFePointLightElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFePointLightElement) Raw(text string) *SVGFePointLightElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFePointLightElement) Escaped(text string) *SVGFePointLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFePointLightElement) IfEscaped(condition bool, text string) *SVGFePointLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGFePointLightElement) Escapedf(format string, args ...any) *SVGFePoin
 
 func (e *SVGFePointLightElement) IfEscapedf(condition bool, format string, args ...any) *SVGFePointLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFePointLightElement) IfEscapedf(condition bool, format string, args
 // coordinate system established by Attribute 'primitiveUnits' on the <filter>
 // element.
 func (e *SVGFePointLightElement) X(f float64) *SVGFePointLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
@@ -223,7 +254,7 @@ func (e *SVGFePointLightElement) X(f float64) *SVGFePointLightElement {
 // element.
 func (e *SVGFePointLightElement) IfX(condition bool, f float64) *SVGFePointLightElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
@@ -232,10 +263,8 @@ func (e *SVGFePointLightElement) IfX(condition bool, f float64) *SVGFePointLight
 // coordinate system established by Attribute 'primitiveUnits' on the <filter>
 // element.
 func (e *SVGFePointLightElement) Y(f float64) *SVGFePointLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
@@ -244,7 +273,7 @@ func (e *SVGFePointLightElement) Y(f float64) *SVGFePointLightElement {
 // element.
 func (e *SVGFePointLightElement) IfY(condition bool, f float64) *SVGFePointLightElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
@@ -253,10 +282,8 @@ func (e *SVGFePointLightElement) IfY(condition bool, f float64) *SVGFePointLight
 // coordinate system established by Attribute 'primitiveUnits' on the <filter>
 // element.
 func (e *SVGFePointLightElement) Z(f float64) *SVGFePointLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("z", f)
+	e = e.writable()
+	e.setFloatAttribute("z", f, 0)
 	return e
 }
 
@@ -265,17 +292,15 @@ func (e *SVGFePointLightElement) Z(f float64) *SVGFePointLightElement {
 // element.
 func (e *SVGFePointLightElement) IfZ(condition bool, f float64) *SVGFePointLightElement {
 	if condition {
-		e.Z(f)
+		e = e.Z(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFePointLightElement) ID(s string) *SVGFePointLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -287,7 +312,7 @@ func (e *SVGFePointLightElement) IDf(format string, args ...any) *SVGFePointLigh
 // Specifies a unique id for an element
 func (e *SVGFePointLightElement) IfID(condition bool, s string) *SVGFePointLightElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -295,7 +320,7 @@ func (e *SVGFePointLightElement) IfID(condition bool, s string) *SVGFePointLight
 // Specifies a unique id for an element
 func (e *SVGFePointLightElement) IfIDf(condition bool, format string, args ...any) *SVGFePointLightElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -303,26 +328,17 @@ func (e *SVGFePointLightElement) IfIDf(condition bool, format string, args ...an
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFePointLightElement) IDRemove() *SVGFePointLightElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFePointLightElement) Class(s string) *SVGFePointLightElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -330,7 +346,7 @@ func (e *SVGFePointLightElement) Class(s string) *SVGFePointLightElement {
 // sheet)
 func (e *SVGFePointLightElement) IfClass(condition bool, s string) *SVGFePointLightElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -339,14 +355,8 @@ func (e *SVGFePointLightElement) IfClass(condition bool, s string) *SVGFePointLi
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFePointLightElement) ClassRemove(s ...string) *SVGFePointLightElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -355,14 +365,8 @@ func (e *SVGFePointLightElement) StylePairs(pairs ...string) *SVGFePointLightEle
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -376,14 +380,8 @@ func (e *SVGFePointLightElement) StylePairs(pairs ...string) *SVGFePointLightEle
 
 // Specifies an inline CSS style for an element
 func (e *SVGFePointLightElement) Style(s string) *SVGFePointLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -391,7 +389,7 @@ func (e *SVGFePointLightElement) Style(s string) *SVGFePointLightElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -399,22 +397,14 @@ func (e *SVGFePointLightElement) Style(s string) *SVGFePointLightElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFePointLightElement) IfStyle(condition bool, s string) *SVGFePointLightElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFePointLightElement) StyleAdd(k string, v string) *SVGFePointLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -426,7 +416,7 @@ func (e *SVGFePointLightElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFePointLightElement) IfStyleAdd(condition bool, k string, v string) *SVGFePointLightElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -434,7 +424,7 @@ func (e *SVGFePointLightElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGFePointLightElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFePointLightElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -442,21 +432,15 @@ func (e *SVGFePointLightElement) IfStyleAddf(condition bool, k string, format st
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFePointLightElement) StyleMap(m map[string]string) *SVGFePointLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -464,13 +448,7 @@ func (e *SVGFePointLightElement) StyleMap(m map[string]string) *SVGFePointLightE
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFePointLightElement) StyleRemove(keys ...string) *SVGFePointLightElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feSpecularLighting> SVG filter primitive lights a source graphic using
@@ -25,18 +23,49 @@ type SVGFeSpecularLightingElement struct {
 // with the tag "feSpecularLighting" during rendering.
 func SVGFeSpecularLighting(children ...ElementRenderer) *SVGFeSpecularLightingElement {
 	e := NewElement("feSpecularLighting", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeSpecularLightingElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeSpecularLightingElement) Clone() *SVGFeSpecularLightingElement {
+	return &SVGFeSpecularLightingElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeSpecularLightingElement) CloneShallow() *SVGFeSpecularLightingElement {
+	return &SVGFeSpecularLightingElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeSpecularLightingElement) Freeze() *SVGFeSpecularLightingElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeSpecularLightingElement) writable() *SVGFeSpecularLightingElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeSpecularLightingElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeSpecularLightingElement) Children(children ...ElementRenderer) *SVGFeSpecularLightingElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeSpecularLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -44,32 +73,30 @@ func (e *SVGFeSpecularLightingElement) IfChildren(condition bool, children ...El
 
 func (e *SVGFeSpecularLightingElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeSpecularLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) BoolAttr(name string) *SVGFeSpecularLightingElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) BoolAttrRemove(name string) *SVGFeSpecularLightingElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfBoolAttr(condition bool, name string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -80,36 +107,40 @@ func (e *SVGFeSpecularLightingElement) BoolAttrf(format string, args ...any) *SV
 
 func (e *SVGFeSpecularLightingElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) BoolAttrs(names ...string) *SVGFeSpecularLightingElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfBoolAttrs(condition bool, names ...string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) Attr(name, value string) *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeSpecularLightingElement) RawAttr(name, value string) *SVGFeSpecularLightingElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfAttr(condition bool, name, value string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -120,61 +151,52 @@ func (e *SVGFeSpecularLightingElement) Attrf(name, format string, args ...any) *
 
 func (e *SVGFeSpecularLightingElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) Attrs(attrs ...string) *SVGFeSpecularLightingElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfAttrs(condition bool, attrs ...string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) AttrsMap(attrs map[string]string) *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) Text(text string) *SVGFeSpecularLightingElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) Textf(format string, args ...any) *SVGFeSpecularLightingElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfText(condition bool, text string) *SVGFeSpecularLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -182,18 +204,28 @@ func (e *SVGFeSpecularLightingElement) IfText(condition bool, text string) *SVGF
 
 func (e *SVGFeSpecularLightingElement) IfTextf(condition bool, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeSpecularLightingElement) Raw(text string) *SVGFeSpecularLightingElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeSpecularLightingElement) Escaped(text string) *SVGFeSpecularLightingElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeSpecularLightingElement) IfEscaped(condition bool, text string) *SVGFeSpecularLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -205,6 +237,7 @@ func (e *SVGFeSpecularLightingElement) Escapedf(format string, args ...any) *SVG
 
 func (e *SVGFeSpecularLightingElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +245,8 @@ func (e *SVGFeSpecularLightingElement) IfEscapedf(condition bool, format string,
 
 // The input for this filter.
 func (e *SVGFeSpecularLightingElement) In(s string) *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -227,7 +258,7 @@ func (e *SVGFeSpecularLightingElement) Inf(format string, args ...any) *SVGFeSpe
 // The input for this filter.
 func (e *SVGFeSpecularLightingElement) IfIn(condition bool, s string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -235,7 +266,7 @@ func (e *SVGFeSpecularLightingElement) IfIn(condition bool, s string) *SVGFeSpec
 // The input for this filter.
 func (e *SVGFeSpecularLightingElement) IfInf(condition bool, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -243,20 +274,16 @@ func (e *SVGFeSpecularLightingElement) IfInf(condition bool, format string, args
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeSpecularLightingElement) InRemove() *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // The 'surfaceScale' Attribute indicates the height of the surface when the
 // alpha channel is 1.0.
 func (e *SVGFeSpecularLightingElement) SurfaceScale(f float64) *SVGFeSpecularLightingElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("surfaceScale", f)
+	e = e.writable()
+	e.setFloatAttribute("surfaceScale", f, 0)
 	return e
 }
 
@@ -264,41 +291,37 @@ func (e *SVGFeSpecularLightingElement) SurfaceScale(f float64) *SVGFeSpecularLig
 // alpha channel is 1.0.
 func (e *SVGFeSpecularLightingElement) IfSurfaceScale(condition bool, f float64) *SVGFeSpecularLightingElement {
 	if condition {
-		e.SurfaceScale(f)
+		e = e.SurfaceScale(f)
 	}
 	return e
 }
 
 // The specularConstant Attribute represents the diffuse reflection constant.
 func (e *SVGFeSpecularLightingElement) SpecularConstant(f float64) *SVGFeSpecularLightingElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("specularConstant", f)
+	e = e.writable()
+	e.setFloatAttribute("specularConstant", f, 0)
 	return e
 }
 
 // The specularConstant Attribute represents the diffuse reflection constant.
 func (e *SVGFeSpecularLightingElement) IfSpecularConstant(condition bool, f float64) *SVGFeSpecularLightingElement {
 	if condition {
-		e.SpecularConstant(f)
+		e = e.SpecularConstant(f)
 	}
 	return e
 }
 
 // The specularExponent Attribute represents the specular reflection constant.
 func (e *SVGFeSpecularLightingElement) SpecularExponent(f float64) *SVGFeSpecularLightingElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("specularExponent", f)
+	e = e.writable()
+	e.setFloatAttribute("specularExponent", f, 0)
 	return e
 }
 
 // The specularExponent Attribute represents the specular reflection constant.
 func (e *SVGFeSpecularLightingElement) IfSpecularExponent(condition bool, f float64) *SVGFeSpecularLightingElement {
 	if condition {
-		e.SpecularExponent(f)
+		e = e.SpecularExponent(f)
 	}
 	return e
 }
@@ -307,10 +330,8 @@ func (e *SVGFeSpecularLightingElement) IfSpecularExponent(condition bool, f floa
 // filter units (i.e., units as determined by the value of Attribute
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeSpecularLightingElement) KernelUnitLength(s string) *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("kernelUnitLength", s)
+	e = e.writable()
+	e.setStringAttribute("kernelUnitLength", s)
 	return e
 }
 
@@ -326,7 +347,7 @@ func (e *SVGFeSpecularLightingElement) KernelUnitLengthf(format string, args ...
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeSpecularLightingElement) IfKernelUnitLength(condition bool, s string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.KernelUnitLength(s)
+		e = e.KernelUnitLength(s)
 	}
 	return e
 }
@@ -336,7 +357,7 @@ func (e *SVGFeSpecularLightingElement) IfKernelUnitLength(condition bool, s stri
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 func (e *SVGFeSpecularLightingElement) IfKernelUnitLengthf(condition bool, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.KernelUnitLength(fmt.Sprintf(format, args...))
+		e = e.KernelUnitLength(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -346,19 +367,15 @@ func (e *SVGFeSpecularLightingElement) IfKernelUnitLengthf(condition bool, forma
 // 'primitiveUnits') for dx and dy in the surface normal calculation formulas.
 // Remove the attribute KernelUnitLength from the element.
 func (e *SVGFeSpecularLightingElement) KernelUnitLengthRemove() *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("kernelUnitLength")
+	e = e.writable()
+	e.removeAttribute("kernelUnitLength")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeSpecularLightingElement) ID(s string) *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -370,7 +387,7 @@ func (e *SVGFeSpecularLightingElement) IDf(format string, args ...any) *SVGFeSpe
 // Specifies a unique id for an element
 func (e *SVGFeSpecularLightingElement) IfID(condition bool, s string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -378,7 +395,7 @@ func (e *SVGFeSpecularLightingElement) IfID(condition bool, s string) *SVGFeSpec
 // Specifies a unique id for an element
 func (e *SVGFeSpecularLightingElement) IfIDf(condition bool, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -386,26 +403,17 @@ func (e *SVGFeSpecularLightingElement) IfIDf(condition bool, format string, args
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeSpecularLightingElement) IDRemove() *SVGFeSpecularLightingElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeSpecularLightingElement) Class(s string) *SVGFeSpecularLightingElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +421,7 @@ func (e *SVGFeSpecularLightingElement) Class(s string) *SVGFeSpecularLightingEle
 // sheet)
 func (e *SVGFeSpecularLightingElement) IfClass(condition bool, s string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -422,14 +430,8 @@ func (e *SVGFeSpecularLightingElement) IfClass(condition bool, s string) *SVGFeS
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeSpecularLightingElement) ClassRemove(s ...string) *SVGFeSpecularLightingElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,14 +440,8 @@ func (e *SVGFeSpecularLightingElement) StylePairs(pairs ...string) *SVGFeSpecula
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -459,14 +455,8 @@ func (e *SVGFeSpecularLightingElement) StylePairs(pairs ...string) *SVGFeSpecula
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpecularLightingElement) Style(s string) *SVGFeSpecularLightingElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -474,7 +464,7 @@ func (e *SVGFeSpecularLightingElement) Style(s string) *SVGFeSpecularLightingEle
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -482,22 +472,14 @@ func (e *SVGFeSpecularLightingElement) Style(s string) *SVGFeSpecularLightingEle
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpecularLightingElement) IfStyle(condition bool, s string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpecularLightingElement) StyleAdd(k string, v string) *SVGFeSpecularLightingElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -509,7 +491,7 @@ func (e *SVGFeSpecularLightingElement) StyleAddf(k string, format string, args .
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpecularLightingElement) IfStyleAdd(condition bool, k string, v string) *SVGFeSpecularLightingElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -517,7 +499,7 @@ func (e *SVGFeSpecularLightingElement) IfStyleAdd(condition bool, k string, v st
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpecularLightingElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeSpecularLightingElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -525,21 +507,15 @@ func (e *SVGFeSpecularLightingElement) IfStyleAddf(condition bool, k string, for
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeSpecularLightingElement) StyleMap(m map[string]string) *SVGFeSpecularLightingElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -547,13 +523,7 @@ func (e *SVGFeSpecularLightingElement) StyleMap(m map[string]string) *SVGFeSpecu
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeSpecularLightingElement) StyleRemove(keys ...string) *SVGFeSpecularLightingElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
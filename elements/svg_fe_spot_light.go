@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feSpotLight> SVG filter primitive allows to create a light source placed
@@ -22,18 +20,49 @@ type SVGFeSpotLightElement struct {
 // with the tag "feSpotLight" during rendering.
 func SVGFeSpotLight(children ...ElementRenderer) *SVGFeSpotLightElement {
 	e := NewElement("feSpotLight", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeSpotLightElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeSpotLightElement) Clone() *SVGFeSpotLightElement {
+	return &SVGFeSpotLightElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeSpotLightElement) CloneShallow() *SVGFeSpotLightElement {
+	return &SVGFeSpotLightElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeSpotLightElement) Freeze() *SVGFeSpotLightElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeSpotLightElement) writable() *SVGFeSpotLightElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeSpotLightElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeSpotLightElement) Children(children ...ElementRenderer) *SVGFeSpotLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeSpotLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGFeSpotLightElement) IfChildren(condition bool, children ...ElementRe
 
 func (e *SVGFeSpotLightElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeSpotLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) BoolAttr(name string) *SVGFeSpotLightElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeSpotLightElement) BoolAttrRemove(name string) *SVGFeSpotLightElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfBoolAttr(condition bool, name string) *SVGFeSpotLightElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGFeSpotLightElement) BoolAttrf(format string, args ...any) *SVGFeSpot
 
 func (e *SVGFeSpotLightElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeSpotLightElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) BoolAttrs(names ...string) *SVGFeSpotLightElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfBoolAttrs(condition bool, names ...string) *SVGFeSpotLightElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) Attr(name, value string) *SVGFeSpotLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeSpotLightElement) RawAttr(name, value string) *SVGFeSpotLightElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfAttr(condition bool, name, value string) *SVGFeSpotLightElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGFeSpotLightElement) Attrf(name, format string, args ...any) *SVGFeSp
 
 func (e *SVGFeSpotLightElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeSpotLightElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) Attrs(attrs ...string) *SVGFeSpotLightElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfAttrs(condition bool, attrs ...string) *SVGFeSpotLightElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) AttrsMap(attrs map[string]string) *SVGFeSpotLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeSpotLightElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeSpotLightElement) Text(text string) *SVGFeSpotLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeSpotLightElement) Textf(format string, args ...any) *SVGFeSpotLightElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeSpotLightElement) IfText(condition bool, text string) *SVGFeSpotLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGFeSpotLightElement) IfText(condition bool, text string) *SVGFeSpotLi
 
 func (e *SVGFeSpotLightElement) IfTextf(condition bool, format string, args ...any) *SVGFeSpotLightElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeSpotLightElement) Raw(text string) *SVGFeSpotLightElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeSpotLightElement) Escaped(text string) *SVGFeSpotLightElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeSpotLightElement) IfEscaped(condition bool, text string) *SVGFeSpotLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGFeSpotLightElement) Escapedf(format string, args ...any) *SVGFeSpotL
 
 func (e *SVGFeSpotLightElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeSpotLightElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFeSpotLightElement) IfEscapedf(condition bool, format string, args .
 // coordinate system established by Attribute 'primitiveUnits' on the <filter>
 // element.
 func (e *SVGFeSpotLightElement) X(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
@@ -223,7 +254,7 @@ func (e *SVGFeSpotLightElement) X(f float64) *SVGFeSpotLightElement {
 // element.
 func (e *SVGFeSpotLightElement) IfX(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
@@ -232,10 +263,8 @@ func (e *SVGFeSpotLightElement) IfX(condition bool, f float64) *SVGFeSpotLightEl
 // coordinate system established by Attribute 'primitiveUnits' on the <filter>
 // element.
 func (e *SVGFeSpotLightElement) Y(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
@@ -244,7 +273,7 @@ func (e *SVGFeSpotLightElement) Y(f float64) *SVGFeSpotLightElement {
 // element.
 func (e *SVGFeSpotLightElement) IfY(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
@@ -253,10 +282,8 @@ func (e *SVGFeSpotLightElement) IfY(condition bool, f float64) *SVGFeSpotLightEl
 // coordinate system established by Attribute 'primitiveUnits' on the <filter>
 // element.
 func (e *SVGFeSpotLightElement) Z(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("z", f)
+	e = e.writable()
+	e.setFloatAttribute("z", f, 0)
 	return e
 }
 
@@ -265,7 +292,7 @@ func (e *SVGFeSpotLightElement) Z(f float64) *SVGFeSpotLightElement {
 // element.
 func (e *SVGFeSpotLightElement) IfZ(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.Z(f)
+		e = e.Z(f)
 	}
 	return e
 }
@@ -274,10 +301,8 @@ func (e *SVGFeSpotLightElement) IfZ(condition bool, f float64) *SVGFeSpotLightEl
 // established by Attribute 'primitiveUnits' on the <filter> element of the
 // point at which the light source is pointing.
 func (e *SVGFeSpotLightElement) PointsAtX(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("pointsAtX", f)
+	e = e.writable()
+	e.setFloatAttribute("pointsAtX", f, 0)
 	return e
 }
 
@@ -286,7 +311,7 @@ func (e *SVGFeSpotLightElement) PointsAtX(f float64) *SVGFeSpotLightElement {
 // point at which the light source is pointing.
 func (e *SVGFeSpotLightElement) IfPointsAtX(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.PointsAtX(f)
+		e = e.PointsAtX(f)
 	}
 	return e
 }
@@ -295,10 +320,8 @@ func (e *SVGFeSpotLightElement) IfPointsAtX(condition bool, f float64) *SVGFeSpo
 // established by Attribute 'primitiveUnits' on the <filter> element of the
 // point at which the light source is pointing.
 func (e *SVGFeSpotLightElement) PointsAtY(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("pointsAtY", f)
+	e = e.writable()
+	e.setFloatAttribute("pointsAtY", f, 0)
 	return e
 }
 
@@ -307,7 +330,7 @@ func (e *SVGFeSpotLightElement) PointsAtY(f float64) *SVGFeSpotLightElement {
 // point at which the light source is pointing.
 func (e *SVGFeSpotLightElement) IfPointsAtY(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.PointsAtY(f)
+		e = e.PointsAtY(f)
 	}
 	return e
 }
@@ -316,10 +339,8 @@ func (e *SVGFeSpotLightElement) IfPointsAtY(condition bool, f float64) *SVGFeSpo
 // established by Attribute 'primitiveUnits' on the <filter> element of the
 // point at which the light source is pointing.
 func (e *SVGFeSpotLightElement) PointsAtZ(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("pointsAtZ", f)
+	e = e.writable()
+	e.setFloatAttribute("pointsAtZ", f, 0)
 	return e
 }
 
@@ -328,24 +349,22 @@ func (e *SVGFeSpotLightElement) PointsAtZ(f float64) *SVGFeSpotLightElement {
 // point at which the light source is pointing.
 func (e *SVGFeSpotLightElement) IfPointsAtZ(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.PointsAtZ(f)
+		e = e.PointsAtZ(f)
 	}
 	return e
 }
 
 // The specularExponent Attribute represents the specular reflection constant.
 func (e *SVGFeSpotLightElement) SpecularExponent(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("specularExponent", f)
+	e = e.writable()
+	e.setFloatAttribute("specularExponent", f, 0)
 	return e
 }
 
 // The specularExponent Attribute represents the specular reflection constant.
 func (e *SVGFeSpotLightElement) IfSpecularExponent(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.SpecularExponent(f)
+		e = e.SpecularExponent(f)
 	}
 	return e
 }
@@ -353,10 +372,8 @@ func (e *SVGFeSpotLightElement) IfSpecularExponent(condition bool, f float64) *S
 // The limitingConeAngle Attribute represents the angle in degrees between the
 // spot light axis and the spot light cone.
 func (e *SVGFeSpotLightElement) LimitingConeAngle(f float64) *SVGFeSpotLightElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("limitingConeAngle", f)
+	e = e.writable()
+	e.setFloatAttribute("limitingConeAngle", f, 0)
 	return e
 }
 
@@ -364,17 +381,15 @@ func (e *SVGFeSpotLightElement) LimitingConeAngle(f float64) *SVGFeSpotLightElem
 // spot light axis and the spot light cone.
 func (e *SVGFeSpotLightElement) IfLimitingConeAngle(condition bool, f float64) *SVGFeSpotLightElement {
 	if condition {
-		e.LimitingConeAngle(f)
+		e = e.LimitingConeAngle(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeSpotLightElement) ID(s string) *SVGFeSpotLightElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -386,7 +401,7 @@ func (e *SVGFeSpotLightElement) IDf(format string, args ...any) *SVGFeSpotLightE
 // Specifies a unique id for an element
 func (e *SVGFeSpotLightElement) IfID(condition bool, s string) *SVGFeSpotLightElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -394,7 +409,7 @@ func (e *SVGFeSpotLightElement) IfID(condition bool, s string) *SVGFeSpotLightEl
 // Specifies a unique id for an element
 func (e *SVGFeSpotLightElement) IfIDf(condition bool, format string, args ...any) *SVGFeSpotLightElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -402,26 +417,17 @@ func (e *SVGFeSpotLightElement) IfIDf(condition bool, format string, args ...any
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeSpotLightElement) IDRemove() *SVGFeSpotLightElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeSpotLightElement) Class(s string) *SVGFeSpotLightElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -429,7 +435,7 @@ func (e *SVGFeSpotLightElement) Class(s string) *SVGFeSpotLightElement {
 // sheet)
 func (e *SVGFeSpotLightElement) IfClass(condition bool, s string) *SVGFeSpotLightElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -438,14 +444,8 @@ func (e *SVGFeSpotLightElement) IfClass(condition bool, s string) *SVGFeSpotLigh
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeSpotLightElement) ClassRemove(s ...string) *SVGFeSpotLightElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -454,14 +454,8 @@ func (e *SVGFeSpotLightElement) StylePairs(pairs ...string) *SVGFeSpotLightEleme
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -475,14 +469,8 @@ func (e *SVGFeSpotLightElement) StylePairs(pairs ...string) *SVGFeSpotLightEleme
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpotLightElement) Style(s string) *SVGFeSpotLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -490,7 +478,7 @@ func (e *SVGFeSpotLightElement) Style(s string) *SVGFeSpotLightElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -498,22 +486,14 @@ func (e *SVGFeSpotLightElement) Style(s string) *SVGFeSpotLightElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpotLightElement) IfStyle(condition bool, s string) *SVGFeSpotLightElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpotLightElement) StyleAdd(k string, v string) *SVGFeSpotLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -525,7 +505,7 @@ func (e *SVGFeSpotLightElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpotLightElement) IfStyleAdd(condition bool, k string, v string) *SVGFeSpotLightElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -533,7 +513,7 @@ func (e *SVGFeSpotLightElement) IfStyleAdd(condition bool, k string, v string) *
 // Specifies an inline CSS style for an element
 func (e *SVGFeSpotLightElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeSpotLightElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -541,21 +521,15 @@ func (e *SVGFeSpotLightElement) IfStyleAddf(condition bool, k string, format str
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeSpotLightElement) StyleMap(m map[string]string) *SVGFeSpotLightElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -563,13 +537,7 @@ func (e *SVGFeSpotLightElement) StyleMap(m map[string]string) *SVGFeSpotLightEle
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeSpotLightElement) StyleRemove(keys ...string) *SVGFeSpotLightElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
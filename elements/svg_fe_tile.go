@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feTile> SVG filter primitive allows to fill a target rectangle with a
@@ -24,18 +22,49 @@ type SVGFeTileElement struct {
 // with the tag "feTile" during rendering.
 func SVGFeTile(children ...ElementRenderer) *SVGFeTileElement {
 	e := NewElement("feTile", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeTileElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeTileElement) Clone() *SVGFeTileElement {
+	return &SVGFeTileElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeTileElement) CloneShallow() *SVGFeTileElement {
+	return &SVGFeTileElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeTileElement) Freeze() *SVGFeTileElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeTileElement) writable() *SVGFeTileElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeTileElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGFeTileElement) Children(children ...ElementRenderer) *SVGFeTileElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeTileElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeTileElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +72,30 @@ func (e *SVGFeTileElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGFeTileElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeTileElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) BoolAttr(name string) *SVGFeTileElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeTileElement) BoolAttrRemove(name string) *SVGFeTileElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeTileElement) IfBoolAttr(condition bool, name string) *SVGFeTileElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +106,40 @@ func (e *SVGFeTileElement) BoolAttrf(format string, args ...any) *SVGFeTileEleme
 
 func (e *SVGFeTileElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeTileElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) BoolAttrs(names ...string) *SVGFeTileElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) IfBoolAttrs(condition bool, names ...string) *SVGFeTileElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) Attr(name, value string) *SVGFeTileElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeTileElement) RawAttr(name, value string) *SVGFeTileElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeTileElement) IfAttr(condition bool, name, value string) *SVGFeTileElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +150,52 @@ func (e *SVGFeTileElement) Attrf(name, format string, args ...any) *SVGFeTileEle
 
 func (e *SVGFeTileElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeTileElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) Attrs(attrs ...string) *SVGFeTileElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeTileElement) IfAttrs(condition bool, attrs ...string) *SVGFeTileElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) AttrsMap(attrs map[string]string) *SVGFeTileElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeTileElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeTileElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeTileElement) Text(text string) *SVGFeTileElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeTileElement) Textf(format string, args ...any) *SVGFeTileElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeTileElement) IfText(condition bool, text string) *SVGFeTileElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +203,28 @@ func (e *SVGFeTileElement) IfText(condition bool, text string) *SVGFeTileElement
 
 func (e *SVGFeTileElement) IfTextf(condition bool, format string, args ...any) *SVGFeTileElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeTileElement) Raw(text string) *SVGFeTileElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeTileElement) Escaped(text string) *SVGFeTileElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeTileElement) IfEscaped(condition bool, text string) *SVGFeTileElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +236,7 @@ func (e *SVGFeTileElement) Escapedf(format string, args ...any) *SVGFeTileElemen
 
 func (e *SVGFeTileElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeTileElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGFeTileElement) IfEscapedf(condition bool, format string, args ...any
 
 // The input for this filter.
 func (e *SVGFeTileElement) In(s string) *SVGFeTileElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("in", s)
+	e = e.writable()
+	e.setStringAttribute("in", s)
 	return e
 }
 
@@ -226,7 +257,7 @@ func (e *SVGFeTileElement) Inf(format string, args ...any) *SVGFeTileElement {
 // The input for this filter.
 func (e *SVGFeTileElement) IfIn(condition bool, s string) *SVGFeTileElement {
 	if condition {
-		e.In(s)
+		e = e.In(s)
 	}
 	return e
 }
@@ -234,7 +265,7 @@ func (e *SVGFeTileElement) IfIn(condition bool, s string) *SVGFeTileElement {
 // The input for this filter.
 func (e *SVGFeTileElement) IfInf(condition bool, format string, args ...any) *SVGFeTileElement {
 	if condition {
-		e.In(fmt.Sprintf(format, args...))
+		e = e.In(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -242,19 +273,15 @@ func (e *SVGFeTileElement) IfInf(condition bool, format string, args ...any) *SV
 // The input for this filter.
 // Remove the attribute In from the element.
 func (e *SVGFeTileElement) InRemove() *SVGFeTileElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("in")
+	e = e.writable()
+	e.removeAttribute("in")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeTileElement) ID(s string) *SVGFeTileElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -266,7 +293,7 @@ func (e *SVGFeTileElement) IDf(format string, args ...any) *SVGFeTileElement {
 // Specifies a unique id for an element
 func (e *SVGFeTileElement) IfID(condition bool, s string) *SVGFeTileElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -274,7 +301,7 @@ func (e *SVGFeTileElement) IfID(condition bool, s string) *SVGFeTileElement {
 // Specifies a unique id for an element
 func (e *SVGFeTileElement) IfIDf(condition bool, format string, args ...any) *SVGFeTileElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -282,26 +309,17 @@ func (e *SVGFeTileElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeTileElement) IDRemove() *SVGFeTileElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeTileElement) Class(s string) *SVGFeTileElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -309,7 +327,7 @@ func (e *SVGFeTileElement) Class(s string) *SVGFeTileElement {
 // sheet)
 func (e *SVGFeTileElement) IfClass(condition bool, s string) *SVGFeTileElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -318,14 +336,8 @@ func (e *SVGFeTileElement) IfClass(condition bool, s string) *SVGFeTileElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeTileElement) ClassRemove(s ...string) *SVGFeTileElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -334,14 +346,8 @@ func (e *SVGFeTileElement) StylePairs(pairs ...string) *SVGFeTileElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -355,14 +361,8 @@ func (e *SVGFeTileElement) StylePairs(pairs ...string) *SVGFeTileElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeTileElement) Style(s string) *SVGFeTileElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -370,7 +370,7 @@ func (e *SVGFeTileElement) Style(s string) *SVGFeTileElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -378,22 +378,14 @@ func (e *SVGFeTileElement) Style(s string) *SVGFeTileElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeTileElement) IfStyle(condition bool, s string) *SVGFeTileElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeTileElement) StyleAdd(k string, v string) *SVGFeTileElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -405,7 +397,7 @@ func (e *SVGFeTileElement) StyleAddf(k string, format string, args ...any) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFeTileElement) IfStyleAdd(condition bool, k string, v string) *SVGFeTileElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -413,7 +405,7 @@ func (e *SVGFeTileElement) IfStyleAdd(condition bool, k string, v string) *SVGFe
 // Specifies an inline CSS style for an element
 func (e *SVGFeTileElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeTileElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -421,21 +413,15 @@ func (e *SVGFeTileElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeTileElement) StyleMap(m map[string]string) *SVGFeTileElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -443,13 +429,7 @@ func (e *SVGFeTileElement) StyleMap(m map[string]string) *SVGFeTileElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeTileElement) StyleRemove(keys ...string) *SVGFeTileElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <feTurbulence> SVG filter primitive creates an image using the Perlin
@@ -23,18 +21,61 @@ type SVGFeTurbulenceElement struct {
 // with the tag "feTurbulence" during rendering.
 func SVGFeTurbulence(children ...ElementRenderer) *SVGFeTurbulenceElement {
 	e := NewElement("feTurbulence", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFeTurbulenceElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFeTurbulenceElement) Clone() *SVGFeTurbulenceElement {
+	return &SVGFeTurbulenceElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFeTurbulenceElement) CloneShallow() *SVGFeTurbulenceElement {
+	return &SVGFeTurbulenceElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFeTurbulenceElement) Freeze() *SVGFeTurbulenceElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFeTurbulenceElement) writable() *SVGFeTurbulenceElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFeTurbulenceElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFeTurbulenceElement) choiceType(name string) func(string) any {
+	switch name {
+	case "stitchTiles":
+		return choiceValue[SVGFeTurbulenceStitchTilesChoice]
+	case "type":
+		return choiceValue[SVGFeTurbulenceTypeChoice]
+	}
+	return nil
+}
+
 func (e *SVGFeTurbulenceElement) Children(children ...ElementRenderer) *SVGFeTurbulenceElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFeTurbulenceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +83,30 @@ func (e *SVGFeTurbulenceElement) IfChildren(condition bool, children ...ElementR
 
 func (e *SVGFeTurbulenceElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFeTurbulenceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) BoolAttr(name string) *SVGFeTurbulenceElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) BoolAttrRemove(name string) *SVGFeTurbulenceElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfBoolAttr(condition bool, name string) *SVGFeTurbulenceElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +117,40 @@ func (e *SVGFeTurbulenceElement) BoolAttrf(format string, args ...any) *SVGFeTur
 
 func (e *SVGFeTurbulenceElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) BoolAttrs(names ...string) *SVGFeTurbulenceElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfBoolAttrs(condition bool, names ...string) *SVGFeTurbulenceElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) Attr(name, value string) *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFeTurbulenceElement) RawAttr(name, value string) *SVGFeTurbulenceElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfAttr(condition bool, name, value string) *SVGFeTurbulenceElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +161,52 @@ func (e *SVGFeTurbulenceElement) Attrf(name, format string, args ...any) *SVGFeT
 
 func (e *SVGFeTurbulenceElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) Attrs(attrs ...string) *SVGFeTurbulenceElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfAttrs(condition bool, attrs ...string) *SVGFeTurbulenceElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) AttrsMap(attrs map[string]string) *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFeTurbulenceElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) Text(text string) *SVGFeTurbulenceElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) Textf(format string, args ...any) *SVGFeTurbulenceElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfText(condition bool, text string) *SVGFeTurbulenceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +214,28 @@ func (e *SVGFeTurbulenceElement) IfText(condition bool, text string) *SVGFeTurbu
 
 func (e *SVGFeTurbulenceElement) IfTextf(condition bool, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFeTurbulenceElement) Raw(text string) *SVGFeTurbulenceElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFeTurbulenceElement) Escaped(text string) *SVGFeTurbulenceElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFeTurbulenceElement) IfEscaped(condition bool, text string) *SVGFeTurbulenceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +247,7 @@ func (e *SVGFeTurbulenceElement) Escapedf(format string, args ...any) *SVGFeTurb
 
 func (e *SVGFeTurbulenceElement) IfEscapedf(condition bool, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +256,8 @@ func (e *SVGFeTurbulenceElement) IfEscapedf(condition bool, format string, args
 // The baseFrequency Attribute represent the base frequencies in the X and Y
 // directions of the turbulence function.
 func (e *SVGFeTurbulenceElement) BaseFrequency(s string) *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("baseFrequency", s)
+	e = e.writable()
+	e.setStringAttribute("baseFrequency", s)
 	return e
 }
 
@@ -228,7 +271,7 @@ func (e *SVGFeTurbulenceElement) BaseFrequencyf(format string, args ...any) *SVG
 // directions of the turbulence function.
 func (e *SVGFeTurbulenceElement) IfBaseFrequency(condition bool, s string) *SVGFeTurbulenceElement {
 	if condition {
-		e.BaseFrequency(s)
+		e = e.BaseFrequency(s)
 	}
 	return e
 }
@@ -237,7 +280,7 @@ func (e *SVGFeTurbulenceElement) IfBaseFrequency(condition bool, s string) *SVGF
 // directions of the turbulence function.
 func (e *SVGFeTurbulenceElement) IfBaseFrequencyf(condition bool, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
-		e.BaseFrequency(fmt.Sprintf(format, args...))
+		e = e.BaseFrequency(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -246,20 +289,16 @@ func (e *SVGFeTurbulenceElement) IfBaseFrequencyf(condition bool, format string,
 // directions of the turbulence function.
 // Remove the attribute BaseFrequency from the element.
 func (e *SVGFeTurbulenceElement) BaseFrequencyRemove() *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("baseFrequency")
+	e = e.writable()
+	e.removeAttribute("baseFrequency")
 	return e
 }
 
 // The numOctaves Attribute indicates the number of octaves to be used by the
 // noise function.
 func (e *SVGFeTurbulenceElement) NumOctaves(f float64) *SVGFeTurbulenceElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("numOctaves", f)
+	e = e.writable()
+	e.setFloatAttribute("numOctaves", f, 0)
 	return e
 }
 
@@ -267,7 +306,7 @@ func (e *SVGFeTurbulenceElement) NumOctaves(f float64) *SVGFeTurbulenceElement {
 // noise function.
 func (e *SVGFeTurbulenceElement) IfNumOctaves(condition bool, f float64) *SVGFeTurbulenceElement {
 	if condition {
-		e.NumOctaves(f)
+		e = e.NumOctaves(f)
 	}
 	return e
 }
@@ -275,10 +314,8 @@ func (e *SVGFeTurbulenceElement) IfNumOctaves(condition bool, f float64) *SVGFeT
 // The seed Attribute indicates which number to use to seed the random number
 // generator.
 func (e *SVGFeTurbulenceElement) Seed(f float64) *SVGFeTurbulenceElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("seed", f)
+	e = e.writable()
+	e.setFloatAttribute("seed", f, 0)
 	return e
 }
 
@@ -286,7 +323,7 @@ func (e *SVGFeTurbulenceElement) Seed(f float64) *SVGFeTurbulenceElement {
 // generator.
 func (e *SVGFeTurbulenceElement) IfSeed(condition bool, f float64) *SVGFeTurbulenceElement {
 	if condition {
-		e.Seed(f)
+		e = e.Seed(f)
 	}
 	return e
 }
@@ -294,10 +331,8 @@ func (e *SVGFeTurbulenceElement) IfSeed(condition bool, f float64) *SVGFeTurbule
 // The stitchTiles Attribute indicates how the Perlin noise function should be
 // tiled. It is ignored if type is not set to 'turbulence'.
 func (e *SVGFeTurbulenceElement) StitchTiles(c SVGFeTurbulenceStitchTilesChoice) *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("stitchTiles", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("stitchTiles", string(c), choiceValue[SVGFeTurbulenceStitchTilesChoice])
 	return e
 }
 
@@ -318,19 +353,15 @@ const (
 // tiled. It is ignored if type is not set to 'turbulence'.
 // Remove the attribute StitchTiles from the element.
 func (e *SVGFeTurbulenceElement) StitchTilesRemove() *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("stitchTiles")
+	e = e.writable()
+	e.removeAttribute("stitchTiles")
 	return e
 }
 
 // The type of turbulence function.
 func (e *SVGFeTurbulenceElement) Type(c SVGFeTurbulenceTypeChoice) *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("type", string(c), choiceValue[SVGFeTurbulenceTypeChoice])
 	return e
 }
 
@@ -346,19 +377,15 @@ const (
 // The type of turbulence function.
 // Remove the attribute Type from the element.
 func (e *SVGFeTurbulenceElement) TypeRemove() *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFeTurbulenceElement) ID(s string) *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -370,7 +397,7 @@ func (e *SVGFeTurbulenceElement) IDf(format string, args ...any) *SVGFeTurbulenc
 // Specifies a unique id for an element
 func (e *SVGFeTurbulenceElement) IfID(condition bool, s string) *SVGFeTurbulenceElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -378,7 +405,7 @@ func (e *SVGFeTurbulenceElement) IfID(condition bool, s string) *SVGFeTurbulence
 // Specifies a unique id for an element
 func (e *SVGFeTurbulenceElement) IfIDf(condition bool, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -386,26 +413,17 @@ func (e *SVGFeTurbulenceElement) IfIDf(condition bool, format string, args ...an
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFeTurbulenceElement) IDRemove() *SVGFeTurbulenceElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFeTurbulenceElement) Class(s string) *SVGFeTurbulenceElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +431,7 @@ func (e *SVGFeTurbulenceElement) Class(s string) *SVGFeTurbulenceElement {
 // sheet)
 func (e *SVGFeTurbulenceElement) IfClass(condition bool, s string) *SVGFeTurbulenceElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -422,14 +440,8 @@ func (e *SVGFeTurbulenceElement) IfClass(condition bool, s string) *SVGFeTurbule
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFeTurbulenceElement) ClassRemove(s ...string) *SVGFeTurbulenceElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,14 +450,8 @@ func (e *SVGFeTurbulenceElement) StylePairs(pairs ...string) *SVGFeTurbulenceEle
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -459,14 +465,8 @@ func (e *SVGFeTurbulenceElement) StylePairs(pairs ...string) *SVGFeTurbulenceEle
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeTurbulenceElement) Style(s string) *SVGFeTurbulenceElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -474,7 +474,7 @@ func (e *SVGFeTurbulenceElement) Style(s string) *SVGFeTurbulenceElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -482,22 +482,14 @@ func (e *SVGFeTurbulenceElement) Style(s string) *SVGFeTurbulenceElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFeTurbulenceElement) IfStyle(condition bool, s string) *SVGFeTurbulenceElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFeTurbulenceElement) StyleAdd(k string, v string) *SVGFeTurbulenceElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -509,7 +501,7 @@ func (e *SVGFeTurbulenceElement) StyleAddf(k string, format string, args ...any)
 // Specifies an inline CSS style for an element
 func (e *SVGFeTurbulenceElement) IfStyleAdd(condition bool, k string, v string) *SVGFeTurbulenceElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -517,7 +509,7 @@ func (e *SVGFeTurbulenceElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGFeTurbulenceElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFeTurbulenceElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -525,21 +517,15 @@ func (e *SVGFeTurbulenceElement) IfStyleAddf(condition bool, k string, format st
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFeTurbulenceElement) StyleMap(m map[string]string) *SVGFeTurbulenceElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -547,13 +533,7 @@ func (e *SVGFeTurbulenceElement) StyleMap(m map[string]string) *SVGFeTurbulenceE
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFeTurbulenceElement) StyleRemove(keys ...string) *SVGFeTurbulenceElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
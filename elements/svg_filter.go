@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <filter> SVG element defines a custom filter effect by grouping atomic
@@ -24,18 +22,61 @@ type SVGFilterElement struct {
 // with the tag "filter" during rendering.
 func SVGFilter(children ...ElementRenderer) *SVGFilterElement {
 	e := NewElement("filter", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGFilterElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGFilterElement) Clone() *SVGFilterElement {
+	return &SVGFilterElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGFilterElement) CloneShallow() *SVGFilterElement {
+	return &SVGFilterElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGFilterElement) Freeze() *SVGFilterElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGFilterElement) writable() *SVGFilterElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGFilterElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGFilterElement) choiceType(name string) func(string) any {
+	switch name {
+	case "filterUnits":
+		return choiceValue[SVGFilterFilterUnitsChoice]
+	case "primitiveUnits":
+		return choiceValue[SVGFilterPrimitiveUnitsChoice]
+	}
+	return nil
+}
+
 func (e *SVGFilterElement) Children(children ...ElementRenderer) *SVGFilterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGFilterElement) IfChildren(condition bool, children ...ElementRenderer) *SVGFilterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +84,30 @@ func (e *SVGFilterElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGFilterElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGFilterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) BoolAttr(name string) *SVGFilterElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGFilterElement) BoolAttrRemove(name string) *SVGFilterElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGFilterElement) IfBoolAttr(condition bool, name string) *SVGFilterElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +118,40 @@ func (e *SVGFilterElement) BoolAttrf(format string, args ...any) *SVGFilterEleme
 
 func (e *SVGFilterElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) BoolAttrs(names ...string) *SVGFilterElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) IfBoolAttrs(condition bool, names ...string) *SVGFilterElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) Attr(name, value string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGFilterElement) RawAttr(name, value string) *SVGFilterElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGFilterElement) IfAttr(condition bool, name, value string) *SVGFilterElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +162,52 @@ func (e *SVGFilterElement) Attrf(name, format string, args ...any) *SVGFilterEle
 
 func (e *SVGFilterElement) IfAttrf(condition bool, name, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) Attrs(attrs ...string) *SVGFilterElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGFilterElement) IfAttrs(condition bool, attrs ...string) *SVGFilterElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) AttrsMap(attrs map[string]string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGFilterElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGFilterElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGFilterElement) Text(text string) *SVGFilterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGFilterElement) Textf(format string, args ...any) *SVGFilterElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGFilterElement) IfText(condition bool, text string) *SVGFilterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +215,28 @@ func (e *SVGFilterElement) IfText(condition bool, text string) *SVGFilterElement
 
 func (e *SVGFilterElement) IfTextf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGFilterElement) Raw(text string) *SVGFilterElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGFilterElement) Escaped(text string) *SVGFilterElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGFilterElement) IfEscaped(condition bool, text string) *SVGFilterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +248,7 @@ func (e *SVGFilterElement) Escapedf(format string, args ...any) *SVGFilterElemen
 
 func (e *SVGFilterElement) IfEscapedf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +256,8 @@ func (e *SVGFilterElement) IfEscapedf(condition bool, format string, args ...any
 
 // The coordinate system for Attributes x, y, width and height.
 func (e *SVGFilterElement) FilterUnits(c SVGFilterFilterUnitsChoice) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("filterUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("filterUnits", string(c), choiceValue[SVGFilterFilterUnitsChoice])
 	return e
 }
 
@@ -230,19 +273,15 @@ const (
 // The coordinate system for Attributes x, y, width and height.
 // Remove the attribute FilterUnits from the element.
 func (e *SVGFilterElement) FilterUnitsRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("filterUnits")
+	e = e.writable()
+	e.removeAttribute("filterUnits")
 	return e
 }
 
 // The coordinate system for the various length values within the filter.
 func (e *SVGFilterElement) PrimitiveUnits(c SVGFilterPrimitiveUnitsChoice) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("primitiveUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("primitiveUnits", string(c), choiceValue[SVGFilterPrimitiveUnitsChoice])
 	return e
 }
 
@@ -258,19 +297,15 @@ const (
 // The coordinate system for the various length values within the filter.
 // Remove the attribute PrimitiveUnits from the element.
 func (e *SVGFilterElement) PrimitiveUnitsRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("primitiveUnits")
+	e = e.writable()
+	e.removeAttribute("primitiveUnits")
 	return e
 }
 
 // The x Attribute indicates where the left edge of the filter is placed.
 func (e *SVGFilterElement) X(s string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("x", s)
+	e = e.writable()
+	e.setStringAttribute("x", s)
 	return e
 }
 
@@ -282,7 +317,7 @@ func (e *SVGFilterElement) Xf(format string, args ...any) *SVGFilterElement {
 // The x Attribute indicates where the left edge of the filter is placed.
 func (e *SVGFilterElement) IfX(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.X(s)
+		e = e.X(s)
 	}
 	return e
 }
@@ -290,7 +325,7 @@ func (e *SVGFilterElement) IfX(condition bool, s string) *SVGFilterElement {
 // The x Attribute indicates where the left edge of the filter is placed.
 func (e *SVGFilterElement) IfXf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.X(fmt.Sprintf(format, args...))
+		e = e.X(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -298,19 +333,15 @@ func (e *SVGFilterElement) IfXf(condition bool, format string, args ...any) *SVG
 // The x Attribute indicates where the left edge of the filter is placed.
 // Remove the attribute X from the element.
 func (e *SVGFilterElement) XRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("x")
+	e = e.writable()
+	e.removeAttribute("x")
 	return e
 }
 
 // The y Attribute indicates where the top edge of the filter is placed.
 func (e *SVGFilterElement) Y(s string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("y", s)
+	e = e.writable()
+	e.setStringAttribute("y", s)
 	return e
 }
 
@@ -322,7 +353,7 @@ func (e *SVGFilterElement) Yf(format string, args ...any) *SVGFilterElement {
 // The y Attribute indicates where the top edge of the filter is placed.
 func (e *SVGFilterElement) IfY(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.Y(s)
+		e = e.Y(s)
 	}
 	return e
 }
@@ -330,7 +361,7 @@ func (e *SVGFilterElement) IfY(condition bool, s string) *SVGFilterElement {
 // The y Attribute indicates where the top edge of the filter is placed.
 func (e *SVGFilterElement) IfYf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.Y(fmt.Sprintf(format, args...))
+		e = e.Y(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -338,19 +369,15 @@ func (e *SVGFilterElement) IfYf(condition bool, format string, args ...any) *SVG
 // The y Attribute indicates where the top edge of the filter is placed.
 // Remove the attribute Y from the element.
 func (e *SVGFilterElement) YRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("y")
+	e = e.writable()
+	e.removeAttribute("y")
 	return e
 }
 
 // The width Attribute indicates the width of the filter primitive box.
 func (e *SVGFilterElement) Width(s string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("width", s)
+	e = e.writable()
+	e.setStringAttribute("width", s)
 	return e
 }
 
@@ -362,7 +389,7 @@ func (e *SVGFilterElement) Widthf(format string, args ...any) *SVGFilterElement
 // The width Attribute indicates the width of the filter primitive box.
 func (e *SVGFilterElement) IfWidth(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.Width(s)
+		e = e.Width(s)
 	}
 	return e
 }
@@ -370,7 +397,7 @@ func (e *SVGFilterElement) IfWidth(condition bool, s string) *SVGFilterElement {
 // The width Attribute indicates the width of the filter primitive box.
 func (e *SVGFilterElement) IfWidthf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.Width(fmt.Sprintf(format, args...))
+		e = e.Width(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -378,19 +405,15 @@ func (e *SVGFilterElement) IfWidthf(condition bool, format string, args ...any)
 // The width Attribute indicates the width of the filter primitive box.
 // Remove the attribute Width from the element.
 func (e *SVGFilterElement) WidthRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
 // The height Attribute indicates the height of the filter primitive box.
 func (e *SVGFilterElement) Height(s string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("height", s)
+	e = e.writable()
+	e.setStringAttribute("height", s)
 	return e
 }
 
@@ -402,7 +425,7 @@ func (e *SVGFilterElement) Heightf(format string, args ...any) *SVGFilterElement
 // The height Attribute indicates the height of the filter primitive box.
 func (e *SVGFilterElement) IfHeight(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.Height(s)
+		e = e.Height(s)
 	}
 	return e
 }
@@ -410,7 +433,7 @@ func (e *SVGFilterElement) IfHeight(condition bool, s string) *SVGFilterElement
 // The height Attribute indicates the height of the filter primitive box.
 func (e *SVGFilterElement) IfHeightf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.Height(fmt.Sprintf(format, args...))
+		e = e.Height(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -418,19 +441,15 @@ func (e *SVGFilterElement) IfHeightf(condition bool, format string, args ...any)
 // The height Attribute indicates the height of the filter primitive box.
 // Remove the attribute Height from the element.
 func (e *SVGFilterElement) HeightRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGFilterElement) ID(s string) *SVGFilterElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -442,7 +461,7 @@ func (e *SVGFilterElement) IDf(format string, args ...any) *SVGFilterElement {
 // Specifies a unique id for an element
 func (e *SVGFilterElement) IfID(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -450,7 +469,7 @@ func (e *SVGFilterElement) IfID(condition bool, s string) *SVGFilterElement {
 // Specifies a unique id for an element
 func (e *SVGFilterElement) IfIDf(condition bool, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -458,26 +477,17 @@ func (e *SVGFilterElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGFilterElement) IDRemove() *SVGFilterElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGFilterElement) Class(s string) *SVGFilterElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -485,7 +495,7 @@ func (e *SVGFilterElement) Class(s string) *SVGFilterElement {
 // sheet)
 func (e *SVGFilterElement) IfClass(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -494,14 +504,8 @@ func (e *SVGFilterElement) IfClass(condition bool, s string) *SVGFilterElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGFilterElement) ClassRemove(s ...string) *SVGFilterElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -510,14 +514,8 @@ func (e *SVGFilterElement) StylePairs(pairs ...string) *SVGFilterElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -531,14 +529,8 @@ func (e *SVGFilterElement) StylePairs(pairs ...string) *SVGFilterElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGFilterElement) Style(s string) *SVGFilterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -546,7 +538,7 @@ func (e *SVGFilterElement) Style(s string) *SVGFilterElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -554,22 +546,14 @@ func (e *SVGFilterElement) Style(s string) *SVGFilterElement {
 // Specifies an inline CSS style for an element
 func (e *SVGFilterElement) IfStyle(condition bool, s string) *SVGFilterElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGFilterElement) StyleAdd(k string, v string) *SVGFilterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -581,7 +565,7 @@ func (e *SVGFilterElement) StyleAddf(k string, format string, args ...any) *SVGF
 // Specifies an inline CSS style for an element
 func (e *SVGFilterElement) IfStyleAdd(condition bool, k string, v string) *SVGFilterElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -589,7 +573,7 @@ func (e *SVGFilterElement) IfStyleAdd(condition bool, k string, v string) *SVGFi
 // Specifies an inline CSS style for an element
 func (e *SVGFilterElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGFilterElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -597,21 +581,15 @@ func (e *SVGFilterElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGFilterElement) StyleMap(m map[string]string) *SVGFilterElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -619,13 +597,7 @@ func (e *SVGFilterElement) StyleMap(m map[string]string) *SVGFilterElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGFilterElement) StyleRemove(keys ...string) *SVGFilterElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
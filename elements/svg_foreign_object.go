@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <foreignObject> SVG element allows for inclusion of a foreign XML
@@ -24,18 +22,49 @@ type SVGForeignObjectElement struct {
 // with the tag "foreignObject" during rendering.
 func SVGForeignObject(children ...ElementRenderer) *SVGForeignObjectElement {
 	e := NewElement("foreignObject", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGForeignObjectElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGForeignObjectElement) Clone() *SVGForeignObjectElement {
+	return &SVGForeignObjectElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGForeignObjectElement) CloneShallow() *SVGForeignObjectElement {
+	return &SVGForeignObjectElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGForeignObjectElement) Freeze() *SVGForeignObjectElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGForeignObjectElement) writable() *SVGForeignObjectElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGForeignObjectElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGForeignObjectElement) Children(children ...ElementRenderer) *SVGForeignObjectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfChildren(condition bool, children ...ElementRenderer) *SVGForeignObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +72,30 @@ func (e *SVGForeignObjectElement) IfChildren(condition bool, children ...Element
 
 func (e *SVGForeignObjectElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGForeignObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) BoolAttr(name string) *SVGForeignObjectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGForeignObjectElement) BoolAttrRemove(name string) *SVGForeignObjectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfBoolAttr(condition bool, name string) *SVGForeignObjectElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +106,40 @@ func (e *SVGForeignObjectElement) BoolAttrf(format string, args ...any) *SVGFore
 
 func (e *SVGForeignObjectElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) BoolAttrs(names ...string) *SVGForeignObjectElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfBoolAttrs(condition bool, names ...string) *SVGForeignObjectElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) Attr(name, value string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGForeignObjectElement) RawAttr(name, value string) *SVGForeignObjectElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfAttr(condition bool, name, value string) *SVGForeignObjectElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +150,52 @@ func (e *SVGForeignObjectElement) Attrf(name, format string, args ...any) *SVGFo
 
 func (e *SVGForeignObjectElement) IfAttrf(condition bool, name, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) Attrs(attrs ...string) *SVGForeignObjectElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfAttrs(condition bool, attrs ...string) *SVGForeignObjectElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) AttrsMap(attrs map[string]string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGForeignObjectElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGForeignObjectElement) Text(text string) *SVGForeignObjectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGForeignObjectElement) Textf(format string, args ...any) *SVGForeignObjectElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGForeignObjectElement) IfText(condition bool, text string) *SVGForeignObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +203,28 @@ func (e *SVGForeignObjectElement) IfText(condition bool, text string) *SVGForeig
 
 func (e *SVGForeignObjectElement) IfTextf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGForeignObjectElement) Raw(text string) *SVGForeignObjectElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGForeignObjectElement) Escaped(text string) *SVGForeignObjectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGForeignObjectElement) IfEscaped(condition bool, text string) *SVGForeignObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +236,7 @@ func (e *SVGForeignObjectElement) Escapedf(format string, args ...any) *SVGForei
 
 func (e *SVGForeignObjectElement) IfEscapedf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +245,8 @@ func (e *SVGForeignObjectElement) IfEscapedf(condition bool, format string, args
 // The x-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGForeignObjectElement) X(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("x", s)
+	e = e.writable()
+	e.setStringAttribute("x", s)
 	return e
 }
 
@@ -229,7 +260,7 @@ func (e *SVGForeignObjectElement) Xf(format string, args ...any) *SVGForeignObje
 // to the user.
 func (e *SVGForeignObjectElement) IfX(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.X(s)
+		e = e.X(s)
 	}
 	return e
 }
@@ -238,7 +269,7 @@ func (e *SVGForeignObjectElement) IfX(condition bool, s string) *SVGForeignObjec
 // to the user.
 func (e *SVGForeignObjectElement) IfXf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.X(fmt.Sprintf(format, args...))
+		e = e.X(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -247,20 +278,16 @@ func (e *SVGForeignObjectElement) IfXf(condition bool, format string, args ...an
 // to the user.
 // Remove the attribute X from the element.
 func (e *SVGForeignObjectElement) XRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("x")
+	e = e.writable()
+	e.removeAttribute("x")
 	return e
 }
 
 // The y-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGForeignObjectElement) Y(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("y", s)
+	e = e.writable()
+	e.setStringAttribute("y", s)
 	return e
 }
 
@@ -274,7 +301,7 @@ func (e *SVGForeignObjectElement) Yf(format string, args ...any) *SVGForeignObje
 // to the user.
 func (e *SVGForeignObjectElement) IfY(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.Y(s)
+		e = e.Y(s)
 	}
 	return e
 }
@@ -283,7 +310,7 @@ func (e *SVGForeignObjectElement) IfY(condition bool, s string) *SVGForeignObjec
 // to the user.
 func (e *SVGForeignObjectElement) IfYf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.Y(fmt.Sprintf(format, args...))
+		e = e.Y(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -292,19 +319,15 @@ func (e *SVGForeignObjectElement) IfYf(condition bool, format string, args ...an
 // to the user.
 // Remove the attribute Y from the element.
 func (e *SVGForeignObjectElement) YRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("y")
+	e = e.writable()
+	e.removeAttribute("y")
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGForeignObjectElement) Width(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("width", s)
+	e = e.writable()
+	e.setStringAttribute("width", s)
 	return e
 }
 
@@ -316,7 +339,7 @@ func (e *SVGForeignObjectElement) Widthf(format string, args ...any) *SVGForeign
 // The width of the rectangular region.
 func (e *SVGForeignObjectElement) IfWidth(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.Width(s)
+		e = e.Width(s)
 	}
 	return e
 }
@@ -324,7 +347,7 @@ func (e *SVGForeignObjectElement) IfWidth(condition bool, s string) *SVGForeignO
 // The width of the rectangular region.
 func (e *SVGForeignObjectElement) IfWidthf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.Width(fmt.Sprintf(format, args...))
+		e = e.Width(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -332,19 +355,15 @@ func (e *SVGForeignObjectElement) IfWidthf(condition bool, format string, args .
 // The width of the rectangular region.
 // Remove the attribute Width from the element.
 func (e *SVGForeignObjectElement) WidthRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGForeignObjectElement) Height(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("height", s)
+	e = e.writable()
+	e.setStringAttribute("height", s)
 	return e
 }
 
@@ -356,7 +375,7 @@ func (e *SVGForeignObjectElement) Heightf(format string, args ...any) *SVGForeig
 // The height of the rectangular region.
 func (e *SVGForeignObjectElement) IfHeight(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.Height(s)
+		e = e.Height(s)
 	}
 	return e
 }
@@ -364,7 +383,7 @@ func (e *SVGForeignObjectElement) IfHeight(condition bool, s string) *SVGForeign
 // The height of the rectangular region.
 func (e *SVGForeignObjectElement) IfHeightf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.Height(fmt.Sprintf(format, args...))
+		e = e.Height(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -372,20 +391,16 @@ func (e *SVGForeignObjectElement) IfHeightf(condition bool, format string, args
 // The height of the rectangular region.
 // Remove the attribute Height from the element.
 func (e *SVGForeignObjectElement) HeightRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // A space-separated list of required extensions, indicating that the parent SVG
 // document must include the specified extensions for this element to be valid.
 func (e *SVGForeignObjectElement) RequiredExtensions(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredExtensions", s)
+	e = e.writable()
+	e.setStringAttribute("requiredExtensions", s)
 	return e
 }
 
@@ -399,7 +414,7 @@ func (e *SVGForeignObjectElement) RequiredExtensionsf(format string, args ...any
 // document must include the specified extensions for this element to be valid.
 func (e *SVGForeignObjectElement) IfRequiredExtensions(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.RequiredExtensions(s)
+		e = e.RequiredExtensions(s)
 	}
 	return e
 }
@@ -408,7 +423,7 @@ func (e *SVGForeignObjectElement) IfRequiredExtensions(condition bool, s string)
 // document must include the specified extensions for this element to be valid.
 func (e *SVGForeignObjectElement) IfRequiredExtensionsf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.RequiredExtensions(fmt.Sprintf(format, args...))
+		e = e.RequiredExtensions(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -417,10 +432,8 @@ func (e *SVGForeignObjectElement) IfRequiredExtensionsf(condition bool, format s
 // document must include the specified extensions for this element to be valid.
 // Remove the attribute RequiredExtensions from the element.
 func (e *SVGForeignObjectElement) RequiredExtensionsRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredExtensions")
+	e = e.writable()
+	e.removeAttribute("requiredExtensions")
 	return e
 }
 
@@ -428,10 +441,8 @@ func (e *SVGForeignObjectElement) RequiredExtensionsRemove() *SVGForeignObjectEl
 // document must include support for all of the specified features for this
 // element to be valid.
 func (e *SVGForeignObjectElement) RequiredFeatures(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredFeatures", s)
+	e = e.writable()
+	e.setStringAttribute("requiredFeatures", s)
 	return e
 }
 
@@ -447,7 +458,7 @@ func (e *SVGForeignObjectElement) RequiredFeaturesf(format string, args ...any)
 // element to be valid.
 func (e *SVGForeignObjectElement) IfRequiredFeatures(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.RequiredFeatures(s)
+		e = e.RequiredFeatures(s)
 	}
 	return e
 }
@@ -457,7 +468,7 @@ func (e *SVGForeignObjectElement) IfRequiredFeatures(condition bool, s string) *
 // element to be valid.
 func (e *SVGForeignObjectElement) IfRequiredFeaturesf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.RequiredFeatures(fmt.Sprintf(format, args...))
+		e = e.RequiredFeatures(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -467,10 +478,8 @@ func (e *SVGForeignObjectElement) IfRequiredFeaturesf(condition bool, format str
 // element to be valid.
 // Remove the attribute RequiredFeatures from the element.
 func (e *SVGForeignObjectElement) RequiredFeaturesRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredFeatures")
+	e = e.writable()
+	e.removeAttribute("requiredFeatures")
 	return e
 }
 
@@ -478,10 +487,8 @@ func (e *SVGForeignObjectElement) RequiredFeaturesRemove() *SVGForeignObjectElem
 // document must include support for all of the specified languages for this
 // element to be valid.
 func (e *SVGForeignObjectElement) SystemLanguage(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("systemLanguage", s)
+	e = e.writable()
+	e.setStringAttribute("systemLanguage", s)
 	return e
 }
 
@@ -497,7 +504,7 @@ func (e *SVGForeignObjectElement) SystemLanguagef(format string, args ...any) *S
 // element to be valid.
 func (e *SVGForeignObjectElement) IfSystemLanguage(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.SystemLanguage(s)
+		e = e.SystemLanguage(s)
 	}
 	return e
 }
@@ -507,7 +514,7 @@ func (e *SVGForeignObjectElement) IfSystemLanguage(condition bool, s string) *SV
 // element to be valid.
 func (e *SVGForeignObjectElement) IfSystemLanguagef(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.SystemLanguage(fmt.Sprintf(format, args...))
+		e = e.SystemLanguage(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -517,19 +524,15 @@ func (e *SVGForeignObjectElement) IfSystemLanguagef(condition bool, format strin
 // element to be valid.
 // Remove the attribute SystemLanguage from the element.
 func (e *SVGForeignObjectElement) SystemLanguageRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("systemLanguage")
+	e = e.writable()
+	e.removeAttribute("systemLanguage")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGForeignObjectElement) ID(s string) *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -541,7 +544,7 @@ func (e *SVGForeignObjectElement) IDf(format string, args ...any) *SVGForeignObj
 // Specifies a unique id for an element
 func (e *SVGForeignObjectElement) IfID(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -549,7 +552,7 @@ func (e *SVGForeignObjectElement) IfID(condition bool, s string) *SVGForeignObje
 // Specifies a unique id for an element
 func (e *SVGForeignObjectElement) IfIDf(condition bool, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -557,26 +560,17 @@ func (e *SVGForeignObjectElement) IfIDf(condition bool, format string, args ...a
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGForeignObjectElement) IDRemove() *SVGForeignObjectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGForeignObjectElement) Class(s string) *SVGForeignObjectElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -584,7 +578,7 @@ func (e *SVGForeignObjectElement) Class(s string) *SVGForeignObjectElement {
 // sheet)
 func (e *SVGForeignObjectElement) IfClass(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -593,14 +587,8 @@ func (e *SVGForeignObjectElement) IfClass(condition bool, s string) *SVGForeignO
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGForeignObjectElement) ClassRemove(s ...string) *SVGForeignObjectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -609,14 +597,8 @@ func (e *SVGForeignObjectElement) StylePairs(pairs ...string) *SVGForeignObjectE
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -630,14 +612,8 @@ func (e *SVGForeignObjectElement) StylePairs(pairs ...string) *SVGForeignObjectE
 
 // Specifies an inline CSS style for an element
 func (e *SVGForeignObjectElement) Style(s string) *SVGForeignObjectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -645,7 +621,7 @@ func (e *SVGForeignObjectElement) Style(s string) *SVGForeignObjectElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -653,22 +629,14 @@ func (e *SVGForeignObjectElement) Style(s string) *SVGForeignObjectElement {
 // Specifies an inline CSS style for an element
 func (e *SVGForeignObjectElement) IfStyle(condition bool, s string) *SVGForeignObjectElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGForeignObjectElement) StyleAdd(k string, v string) *SVGForeignObjectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -680,7 +648,7 @@ func (e *SVGForeignObjectElement) StyleAddf(k string, format string, args ...any
 // Specifies an inline CSS style for an element
 func (e *SVGForeignObjectElement) IfStyleAdd(condition bool, k string, v string) *SVGForeignObjectElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -688,7 +656,7 @@ func (e *SVGForeignObjectElement) IfStyleAdd(condition bool, k string, v string)
 // Specifies an inline CSS style for an element
 func (e *SVGForeignObjectElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGForeignObjectElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -696,21 +664,15 @@ func (e *SVGForeignObjectElement) IfStyleAddf(condition bool, k string, format s
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGForeignObjectElement) StyleMap(m map[string]string) *SVGForeignObjectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -718,13 +680,7 @@ func (e *SVGForeignObjectElement) StyleMap(m map[string]string) *SVGForeignObjec
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGForeignObjectElement) StyleRemove(keys ...string) *SVGForeignObjectElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <g> SVG element is a container used to group other SVG elements.
@@ -21,18 +19,49 @@ type SVGGElement struct {
 // with the tag "g" during rendering.
 func SVGG(children ...ElementRenderer) *SVGGElement {
 	e := NewElement("g", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGGElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGGElement) Clone() *SVGGElement {
+	return &SVGGElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGGElement) CloneShallow() *SVGGElement {
+	return &SVGGElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGGElement) Freeze() *SVGGElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGGElement) writable() *SVGGElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGGElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGGElement) Children(children ...ElementRenderer) *SVGGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGGElement) IfChildren(condition bool, children ...ElementRenderer) *SVGGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +69,30 @@ func (e *SVGGElement) IfChildren(condition bool, children ...ElementRenderer) *S
 
 func (e *SVGGElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGGElement) BoolAttr(name string) *SVGGElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGGElement) BoolAttrRemove(name string) *SVGGElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGGElement) IfBoolAttr(condition bool, name string) *SVGGElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +103,40 @@ func (e *SVGGElement) BoolAttrf(format string, args ...any) *SVGGElement {
 
 func (e *SVGGElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGGElement) BoolAttrs(names ...string) *SVGGElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGGElement) IfBoolAttrs(condition bool, names ...string) *SVGGElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGGElement) Attr(name, value string) *SVGGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGGElement) RawAttr(name, value string) *SVGGElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGGElement) IfAttr(condition bool, name, value string) *SVGGElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +147,52 @@ func (e *SVGGElement) Attrf(name, format string, args ...any) *SVGGElement {
 
 func (e *SVGGElement) IfAttrf(condition bool, name, format string, args ...any) *SVGGElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGGElement) Attrs(attrs ...string) *SVGGElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGGElement) IfAttrs(condition bool, attrs ...string) *SVGGElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGGElement) AttrsMap(attrs map[string]string) *SVGGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGGElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGGElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGGElement) Text(text string) *SVGGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGGElement) Textf(format string, args ...any) *SVGGElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGGElement) IfText(condition bool, text string) *SVGGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +200,28 @@ func (e *SVGGElement) IfText(condition bool, text string) *SVGGElement {
 
 func (e *SVGGElement) IfTextf(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGGElement) Raw(text string) *SVGGElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGGElement) Escaped(text string) *SVGGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGGElement) IfEscaped(condition bool, text string) *SVGGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +233,7 @@ func (e *SVGGElement) Escapedf(format string, args ...any) *SVGGElement {
 
 func (e *SVGGElement) IfEscapedf(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGGElement) IfEscapedf(condition bool, format string, args ...any) *SV
 // A space-separated list of required extensions, indicating that the parent SVG
 // document must include the specified extensions for this element to be valid.
 func (e *SVGGElement) RequiredExtensions(s string) *SVGGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredExtensions", s)
+	e = e.writable()
+	e.setStringAttribute("requiredExtensions", s)
 	return e
 }
 
@@ -226,7 +257,7 @@ func (e *SVGGElement) RequiredExtensionsf(format string, args ...any) *SVGGEleme
 // document must include the specified extensions for this element to be valid.
 func (e *SVGGElement) IfRequiredExtensions(condition bool, s string) *SVGGElement {
 	if condition {
-		e.RequiredExtensions(s)
+		e = e.RequiredExtensions(s)
 	}
 	return e
 }
@@ -235,7 +266,7 @@ func (e *SVGGElement) IfRequiredExtensions(condition bool, s string) *SVGGElemen
 // document must include the specified extensions for this element to be valid.
 func (e *SVGGElement) IfRequiredExtensionsf(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
-		e.RequiredExtensions(fmt.Sprintf(format, args...))
+		e = e.RequiredExtensions(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -244,10 +275,8 @@ func (e *SVGGElement) IfRequiredExtensionsf(condition bool, format string, args
 // document must include the specified extensions for this element to be valid.
 // Remove the attribute RequiredExtensions from the element.
 func (e *SVGGElement) RequiredExtensionsRemove() *SVGGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredExtensions")
+	e = e.writable()
+	e.removeAttribute("requiredExtensions")
 	return e
 }
 
@@ -255,10 +284,8 @@ func (e *SVGGElement) RequiredExtensionsRemove() *SVGGElement {
 // document must include support for all of the specified features for this
 // element to be valid.
 func (e *SVGGElement) RequiredFeatures(s string) *SVGGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredFeatures", s)
+	e = e.writable()
+	e.setStringAttribute("requiredFeatures", s)
 	return e
 }
 
@@ -274,7 +301,7 @@ func (e *SVGGElement) RequiredFeaturesf(format string, args ...any) *SVGGElement
 // element to be valid.
 func (e *SVGGElement) IfRequiredFeatures(condition bool, s string) *SVGGElement {
 	if condition {
-		e.RequiredFeatures(s)
+		e = e.RequiredFeatures(s)
 	}
 	return e
 }
@@ -284,7 +311,7 @@ func (e *SVGGElement) IfRequiredFeatures(condition bool, s string) *SVGGElement
 // element to be valid.
 func (e *SVGGElement) IfRequiredFeaturesf(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
-		e.RequiredFeatures(fmt.Sprintf(format, args...))
+		e = e.RequiredFeatures(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -294,10 +321,8 @@ func (e *SVGGElement) IfRequiredFeaturesf(condition bool, format string, args ..
 // element to be valid.
 // Remove the attribute RequiredFeatures from the element.
 func (e *SVGGElement) RequiredFeaturesRemove() *SVGGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredFeatures")
+	e = e.writable()
+	e.removeAttribute("requiredFeatures")
 	return e
 }
 
@@ -305,10 +330,8 @@ func (e *SVGGElement) RequiredFeaturesRemove() *SVGGElement {
 // document must include support for all of the specified languages for this
 // element to be valid.
 func (e *SVGGElement) SystemLanguage(s string) *SVGGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("systemLanguage", s)
+	e = e.writable()
+	e.setStringAttribute("systemLanguage", s)
 	return e
 }
 
@@ -324,7 +347,7 @@ func (e *SVGGElement) SystemLanguagef(format string, args ...any) *SVGGElement {
 // element to be valid.
 func (e *SVGGElement) IfSystemLanguage(condition bool, s string) *SVGGElement {
 	if condition {
-		e.SystemLanguage(s)
+		e = e.SystemLanguage(s)
 	}
 	return e
 }
@@ -334,7 +357,7 @@ func (e *SVGGElement) IfSystemLanguage(condition bool, s string) *SVGGElement {
 // element to be valid.
 func (e *SVGGElement) IfSystemLanguagef(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
-		e.SystemLanguage(fmt.Sprintf(format, args...))
+		e = e.SystemLanguage(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -344,19 +367,15 @@ func (e *SVGGElement) IfSystemLanguagef(condition bool, format string, args ...a
 // element to be valid.
 // Remove the attribute SystemLanguage from the element.
 func (e *SVGGElement) SystemLanguageRemove() *SVGGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("systemLanguage")
+	e = e.writable()
+	e.removeAttribute("systemLanguage")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGGElement) ID(s string) *SVGGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -368,7 +387,7 @@ func (e *SVGGElement) IDf(format string, args ...any) *SVGGElement {
 // Specifies a unique id for an element
 func (e *SVGGElement) IfID(condition bool, s string) *SVGGElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -376,7 +395,7 @@ func (e *SVGGElement) IfID(condition bool, s string) *SVGGElement {
 // Specifies a unique id for an element
 func (e *SVGGElement) IfIDf(condition bool, format string, args ...any) *SVGGElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -384,26 +403,17 @@ func (e *SVGGElement) IfIDf(condition bool, format string, args ...any) *SVGGEle
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGGElement) IDRemove() *SVGGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGGElement) Class(s string) *SVGGElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -411,7 +421,7 @@ func (e *SVGGElement) Class(s string) *SVGGElement {
 // sheet)
 func (e *SVGGElement) IfClass(condition bool, s string) *SVGGElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -420,14 +430,8 @@ func (e *SVGGElement) IfClass(condition bool, s string) *SVGGElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGGElement) ClassRemove(s ...string) *SVGGElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -436,14 +440,8 @@ func (e *SVGGElement) StylePairs(pairs ...string) *SVGGElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -457,14 +455,8 @@ func (e *SVGGElement) StylePairs(pairs ...string) *SVGGElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGGElement) Style(s string) *SVGGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -472,7 +464,7 @@ func (e *SVGGElement) Style(s string) *SVGGElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -480,22 +472,14 @@ func (e *SVGGElement) Style(s string) *SVGGElement {
 // Specifies an inline CSS style for an element
 func (e *SVGGElement) IfStyle(condition bool, s string) *SVGGElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGGElement) StyleAdd(k string, v string) *SVGGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -507,7 +491,7 @@ func (e *SVGGElement) StyleAddf(k string, format string, args ...any) *SVGGEleme
 // Specifies an inline CSS style for an element
 func (e *SVGGElement) IfStyleAdd(condition bool, k string, v string) *SVGGElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -515,7 +499,7 @@ func (e *SVGGElement) IfStyleAdd(condition bool, k string, v string) *SVGGElemen
 // Specifies an inline CSS style for an element
 func (e *SVGGElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGGElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -523,21 +507,15 @@ func (e *SVGGElement) IfStyleAddf(condition bool, k string, format string, args
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGGElement) StyleMap(m map[string]string) *SVGGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -545,13 +523,7 @@ func (e *SVGGElement) StyleMap(m map[string]string) *SVGGElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGGElement) StyleRemove(keys ...string) *SVGGElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <image> SVG element includes images inside SVG documents.
@@ -21,18 +19,59 @@ type SVGImageElement struct {
 // with the tag "image" during rendering.
 func SVGImage(children ...ElementRenderer) *SVGImageElement {
 	e := NewElement("image", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGImageElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGImageElement) Clone() *SVGImageElement {
+	return &SVGImageElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGImageElement) CloneShallow() *SVGImageElement {
+	return &SVGImageElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGImageElement) Freeze() *SVGImageElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGImageElement) writable() *SVGImageElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGImageElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGImageElement) choiceType(name string) func(string) any {
+	switch name {
+	case "preserveAspectRatio":
+		return choiceValue[SVGImagePreserveAspectRatioChoice]
+	}
+	return nil
+}
+
 func (e *SVGImageElement) Children(children ...ElementRenderer) *SVGImageElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGImageElement) IfChildren(condition bool, children ...ElementRenderer) *SVGImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +79,30 @@ func (e *SVGImageElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *SVGImageElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGImageElement) BoolAttr(name string) *SVGImageElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGImageElement) BoolAttrRemove(name string) *SVGImageElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGImageElement) IfBoolAttr(condition bool, name string) *SVGImageElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +113,40 @@ func (e *SVGImageElement) BoolAttrf(format string, args ...any) *SVGImageElement
 
 func (e *SVGImageElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGImageElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGImageElement) BoolAttrs(names ...string) *SVGImageElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGImageElement) IfBoolAttrs(condition bool, names ...string) *SVGImageElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGImageElement) Attr(name, value string) *SVGImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGImageElement) RawAttr(name, value string) *SVGImageElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGImageElement) IfAttr(condition bool, name, value string) *SVGImageElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +157,52 @@ func (e *SVGImageElement) Attrf(name, format string, args ...any) *SVGImageEleme
 
 func (e *SVGImageElement) IfAttrf(condition bool, name, format string, args ...any) *SVGImageElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGImageElement) Attrs(attrs ...string) *SVGImageElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGImageElement) IfAttrs(condition bool, attrs ...string) *SVGImageElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGImageElement) AttrsMap(attrs map[string]string) *SVGImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGImageElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGImageElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGImageElement) Text(text string) *SVGImageElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGImageElement) Textf(format string, args ...any) *SVGImageElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGImageElement) IfText(condition bool, text string) *SVGImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +210,28 @@ func (e *SVGImageElement) IfText(condition bool, text string) *SVGImageElement {
 
 func (e *SVGImageElement) IfTextf(condition bool, format string, args ...any) *SVGImageElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGImageElement) Raw(text string) *SVGImageElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGImageElement) Escaped(text string) *SVGImageElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGImageElement) IfEscaped(condition bool, text string) *SVGImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +243,7 @@ func (e *SVGImageElement) Escapedf(format string, args ...any) *SVGImageElement
 
 func (e *SVGImageElement) IfEscapedf(condition bool, format string, args ...any) *SVGImageElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,10 +251,8 @@ func (e *SVGImageElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Indicates how the fetched image is fitted into the destination rectangle.
 func (e *SVGImageElement) PreserveAspectRatio(c SVGImagePreserveAspectRatioChoice) *SVGImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("preserveAspectRatio", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("preserveAspectRatio", string(c), choiceValue[SVGImagePreserveAspectRatioChoice])
 	return e
 }
 
@@ -249,20 +290,16 @@ const (
 // Indicates how the fetched image is fitted into the destination rectangle.
 // Remove the attribute PreserveAspectRatio from the element.
 func (e *SVGImageElement) PreserveAspectRatioRemove() *SVGImageElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("preserveAspectRatio")
+	e = e.writable()
+	e.removeAttribute("preserveAspectRatio")
 	return e
 }
 
 // The x-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGImageElement) X(f float64) *SVGImageElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
@@ -270,7 +307,7 @@ func (e *SVGImageElement) X(f float64) *SVGImageElement {
 // to the user.
 func (e *SVGImageElement) IfX(condition bool, f float64) *SVGImageElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
@@ -278,10 +315,8 @@ func (e *SVGImageElement) IfX(condition bool, f float64) *SVGImageElement {
 // The y-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGImageElement) Y(f float64) *SVGImageElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
@@ -289,51 +324,45 @@ func (e *SVGImageElement) Y(f float64) *SVGImageElement {
 // to the user.
 func (e *SVGImageElement) IfY(condition bool, f float64) *SVGImageElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGImageElement) Width(f float64) *SVGImageElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("width", f)
+	e = e.writable()
+	e.setFloatAttribute("width", f, 0)
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGImageElement) IfWidth(condition bool, f float64) *SVGImageElement {
 	if condition {
-		e.Width(f)
+		e = e.Width(f)
 	}
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGImageElement) Height(f float64) *SVGImageElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("height", f)
+	e = e.writable()
+	e.setFloatAttribute("height", f, 0)
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGImageElement) IfHeight(condition bool, f float64) *SVGImageElement {
 	if condition {
-		e.Height(f)
+		e = e.Height(f)
 	}
 	return e
 }
 
 // A URI reference to the image to embed.
 func (e *SVGImageElement) Href(s string) *SVGImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -345,7 +374,7 @@ func (e *SVGImageElement) Hreff(format string, args ...any) *SVGImageElement {
 // A URI reference to the image to embed.
 func (e *SVGImageElement) IfHref(condition bool, s string) *SVGImageElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -353,7 +382,7 @@ func (e *SVGImageElement) IfHref(condition bool, s string) *SVGImageElement {
 // A URI reference to the image to embed.
 func (e *SVGImageElement) IfHreff(condition bool, format string, args ...any) *SVGImageElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -361,19 +390,15 @@ func (e *SVGImageElement) IfHreff(condition bool, format string, args ...any) *S
 // A URI reference to the image to embed.
 // Remove the attribute Href from the element.
 func (e *SVGImageElement) HrefRemove() *SVGImageElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGImageElement) ID(s string) *SVGImageElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -385,7 +410,7 @@ func (e *SVGImageElement) IDf(format string, args ...any) *SVGImageElement {
 // Specifies a unique id for an element
 func (e *SVGImageElement) IfID(condition bool, s string) *SVGImageElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -393,7 +418,7 @@ func (e *SVGImageElement) IfID(condition bool, s string) *SVGImageElement {
 // Specifies a unique id for an element
 func (e *SVGImageElement) IfIDf(condition bool, format string, args ...any) *SVGImageElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -401,26 +426,17 @@ func (e *SVGImageElement) IfIDf(condition bool, format string, args ...any) *SVG
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGImageElement) IDRemove() *SVGImageElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGImageElement) Class(s string) *SVGImageElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -428,7 +444,7 @@ func (e *SVGImageElement) Class(s string) *SVGImageElement {
 // sheet)
 func (e *SVGImageElement) IfClass(condition bool, s string) *SVGImageElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -437,14 +453,8 @@ func (e *SVGImageElement) IfClass(condition bool, s string) *SVGImageElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGImageElement) ClassRemove(s ...string) *SVGImageElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -453,14 +463,8 @@ func (e *SVGImageElement) StylePairs(pairs ...string) *SVGImageElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -474,14 +478,8 @@ func (e *SVGImageElement) StylePairs(pairs ...string) *SVGImageElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGImageElement) Style(s string) *SVGImageElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -489,7 +487,7 @@ func (e *SVGImageElement) Style(s string) *SVGImageElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -497,22 +495,14 @@ func (e *SVGImageElement) Style(s string) *SVGImageElement {
 // Specifies an inline CSS style for an element
 func (e *SVGImageElement) IfStyle(condition bool, s string) *SVGImageElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGImageElement) StyleAdd(k string, v string) *SVGImageElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -524,7 +514,7 @@ func (e *SVGImageElement) StyleAddf(k string, format string, args ...any) *SVGIm
 // Specifies an inline CSS style for an element
 func (e *SVGImageElement) IfStyleAdd(condition bool, k string, v string) *SVGImageElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -532,7 +522,7 @@ func (e *SVGImageElement) IfStyleAdd(condition bool, k string, v string) *SVGIma
 // Specifies an inline CSS style for an element
 func (e *SVGImageElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGImageElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -540,21 +530,15 @@ func (e *SVGImageElement) IfStyleAddf(condition bool, k string, format string, a
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGImageElement) StyleMap(m map[string]string) *SVGImageElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -562,13 +546,7 @@ func (e *SVGImageElement) StyleMap(m map[string]string) *SVGImageElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGImageElement) StyleRemove(keys ...string) *SVGImageElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <line> SVG element is an SVG basic shape, used to create a line
@@ -22,18 +20,49 @@ type SVGLineElement struct {
 // with the tag "line" during rendering.
 func SVGLine(children ...ElementRenderer) *SVGLineElement {
 	e := NewElement("line", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGLineElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGLineElement) Clone() *SVGLineElement {
+	return &SVGLineElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGLineElement) CloneShallow() *SVGLineElement {
+	return &SVGLineElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGLineElement) Freeze() *SVGLineElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGLineElement) writable() *SVGLineElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGLineElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGLineElement) Children(children ...ElementRenderer) *SVGLineElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGLineElement) IfChildren(condition bool, children ...ElementRenderer) *SVGLineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGLineElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGLineElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGLineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGLineElement) BoolAttr(name string) *SVGLineElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGLineElement) BoolAttrRemove(name string) *SVGLineElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGLineElement) IfBoolAttr(condition bool, name string) *SVGLineElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGLineElement) BoolAttrf(format string, args ...any) *SVGLineElement {
 
 func (e *SVGLineElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGLineElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGLineElement) BoolAttrs(names ...string) *SVGLineElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGLineElement) IfBoolAttrs(condition bool, names ...string) *SVGLineElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGLineElement) Attr(name, value string) *SVGLineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGLineElement) RawAttr(name, value string) *SVGLineElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGLineElement) IfAttr(condition bool, name, value string) *SVGLineElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGLineElement) Attrf(name, format string, args ...any) *SVGLineElement
 
 func (e *SVGLineElement) IfAttrf(condition bool, name, format string, args ...any) *SVGLineElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGLineElement) Attrs(attrs ...string) *SVGLineElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGLineElement) IfAttrs(condition bool, attrs ...string) *SVGLineElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGLineElement) AttrsMap(attrs map[string]string) *SVGLineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGLineElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGLineElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGLineElement) Text(text string) *SVGLineElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGLineElement) Textf(format string, args ...any) *SVGLineElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGLineElement) IfText(condition bool, text string) *SVGLineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGLineElement) IfText(condition bool, text string) *SVGLineElement {
 
 func (e *SVGLineElement) IfTextf(condition bool, format string, args ...any) *SVGLineElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGLineElement) Raw(text string) *SVGLineElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGLineElement) Escaped(text string) *SVGLineElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGLineElement) IfEscaped(condition bool, text string) *SVGLineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGLineElement) Escapedf(format string, args ...any) *SVGLineElement {
 
 func (e *SVGLineElement) IfEscapedf(condition bool, format string, args ...any) *SVGLineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,78 +242,68 @@ func (e *SVGLineElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The x-axis coordinate of the starting point of the line.
 func (e *SVGLineElement) X1(f float64) *SVGLineElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x1", f)
+	e = e.writable()
+	e.setFloatAttribute("x1", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the starting point of the line.
 func (e *SVGLineElement) IfX1(condition bool, f float64) *SVGLineElement {
 	if condition {
-		e.X1(f)
+		e = e.X1(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the starting point of the line.
 func (e *SVGLineElement) Y1(f float64) *SVGLineElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y1", f)
+	e = e.writable()
+	e.setFloatAttribute("y1", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the starting point of the line.
 func (e *SVGLineElement) IfY1(condition bool, f float64) *SVGLineElement {
 	if condition {
-		e.Y1(f)
+		e = e.Y1(f)
 	}
 	return e
 }
 
 // The x-axis coordinate of the ending point of the line.
 func (e *SVGLineElement) X2(f float64) *SVGLineElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x2", f)
+	e = e.writable()
+	e.setFloatAttribute("x2", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the ending point of the line.
 func (e *SVGLineElement) IfX2(condition bool, f float64) *SVGLineElement {
 	if condition {
-		e.X2(f)
+		e = e.X2(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the ending point of the line.
 func (e *SVGLineElement) Y2(f float64) *SVGLineElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y2", f)
+	e = e.writable()
+	e.setFloatAttribute("y2", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the ending point of the line.
 func (e *SVGLineElement) IfY2(condition bool, f float64) *SVGLineElement {
 	if condition {
-		e.Y2(f)
+		e = e.Y2(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGLineElement) ID(s string) *SVGLineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -292,7 +315,7 @@ func (e *SVGLineElement) IDf(format string, args ...any) *SVGLineElement {
 // Specifies a unique id for an element
 func (e *SVGLineElement) IfID(condition bool, s string) *SVGLineElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -300,7 +323,7 @@ func (e *SVGLineElement) IfID(condition bool, s string) *SVGLineElement {
 // Specifies a unique id for an element
 func (e *SVGLineElement) IfIDf(condition bool, format string, args ...any) *SVGLineElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -308,26 +331,17 @@ func (e *SVGLineElement) IfIDf(condition bool, format string, args ...any) *SVGL
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGLineElement) IDRemove() *SVGLineElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGLineElement) Class(s string) *SVGLineElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -335,7 +349,7 @@ func (e *SVGLineElement) Class(s string) *SVGLineElement {
 // sheet)
 func (e *SVGLineElement) IfClass(condition bool, s string) *SVGLineElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -344,14 +358,8 @@ func (e *SVGLineElement) IfClass(condition bool, s string) *SVGLineElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGLineElement) ClassRemove(s ...string) *SVGLineElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -360,14 +368,8 @@ func (e *SVGLineElement) StylePairs(pairs ...string) *SVGLineElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -381,14 +383,8 @@ func (e *SVGLineElement) StylePairs(pairs ...string) *SVGLineElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGLineElement) Style(s string) *SVGLineElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -396,7 +392,7 @@ func (e *SVGLineElement) Style(s string) *SVGLineElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -404,22 +400,14 @@ func (e *SVGLineElement) Style(s string) *SVGLineElement {
 // Specifies an inline CSS style for an element
 func (e *SVGLineElement) IfStyle(condition bool, s string) *SVGLineElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGLineElement) StyleAdd(k string, v string) *SVGLineElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -431,7 +419,7 @@ func (e *SVGLineElement) StyleAddf(k string, format string, args ...any) *SVGLin
 // Specifies an inline CSS style for an element
 func (e *SVGLineElement) IfStyleAdd(condition bool, k string, v string) *SVGLineElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -439,7 +427,7 @@ func (e *SVGLineElement) IfStyleAdd(condition bool, k string, v string) *SVGLine
 // Specifies an inline CSS style for an element
 func (e *SVGLineElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGLineElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -447,21 +435,15 @@ func (e *SVGLineElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGLineElement) StyleMap(m map[string]string) *SVGLineElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -469,13 +451,7 @@ func (e *SVGLineElement) StyleMap(m map[string]string) *SVGLineElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGLineElement) StyleRemove(keys ...string) *SVGLineElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <linearGradient> SVG element lets authors define linear gradients to fill
@@ -22,18 +20,61 @@ type SVGLinearGradientElement struct {
 // with the tag "linearGradient" during rendering.
 func SVGLinearGradient(children ...ElementRenderer) *SVGLinearGradientElement {
 	e := NewElement("linearGradient", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGLinearGradientElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGLinearGradientElement) Clone() *SVGLinearGradientElement {
+	return &SVGLinearGradientElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGLinearGradientElement) CloneShallow() *SVGLinearGradientElement {
+	return &SVGLinearGradientElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGLinearGradientElement) Freeze() *SVGLinearGradientElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGLinearGradientElement) writable() *SVGLinearGradientElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGLinearGradientElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGLinearGradientElement) choiceType(name string) func(string) any {
+	switch name {
+	case "gradientUnits":
+		return choiceValue[SVGLinearGradientGradientUnitsChoice]
+	case "spreadMethod":
+		return choiceValue[SVGLinearGradientSpreadMethodChoice]
+	}
+	return nil
+}
+
 func (e *SVGLinearGradientElement) Children(children ...ElementRenderer) *SVGLinearGradientElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfChildren(condition bool, children ...ElementRenderer) *SVGLinearGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *SVGLinearGradientElement) IfChildren(condition bool, children ...Elemen
 
 func (e *SVGLinearGradientElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGLinearGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) BoolAttr(name string) *SVGLinearGradientElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGLinearGradientElement) BoolAttrRemove(name string) *SVGLinearGradientElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfBoolAttr(condition bool, name string) *SVGLinearGradientElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *SVGLinearGradientElement) BoolAttrf(format string, args ...any) *SVGLin
 
 func (e *SVGLinearGradientElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) BoolAttrs(names ...string) *SVGLinearGradientElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfBoolAttrs(condition bool, names ...string) *SVGLinearGradientElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) Attr(name, value string) *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGLinearGradientElement) RawAttr(name, value string) *SVGLinearGradientElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfAttr(condition bool, name, value string) *SVGLinearGradientElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *SVGLinearGradientElement) Attrf(name, format string, args ...any) *SVGL
 
 func (e *SVGLinearGradientElement) IfAttrf(condition bool, name, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) Attrs(attrs ...string) *SVGLinearGradientElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfAttrs(condition bool, attrs ...string) *SVGLinearGradientElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) AttrsMap(attrs map[string]string) *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGLinearGradientElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGLinearGradientElement) Text(text string) *SVGLinearGradientElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGLinearGradientElement) Textf(format string, args ...any) *SVGLinearGradientElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGLinearGradientElement) IfText(condition bool, text string) *SVGLinearGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *SVGLinearGradientElement) IfText(condition bool, text string) *SVGLinea
 
 func (e *SVGLinearGradientElement) IfTextf(condition bool, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGLinearGradientElement) Raw(text string) *SVGLinearGradientElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGLinearGradientElement) Escaped(text string) *SVGLinearGradientElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGLinearGradientElement) IfEscaped(condition bool, text string) *SVGLinearGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *SVGLinearGradientElement) Escapedf(format string, args ...any) *SVGLine
 
 func (e *SVGLinearGradientElement) IfEscapedf(condition bool, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +254,8 @@ func (e *SVGLinearGradientElement) IfEscapedf(condition bool, format string, arg
 
 // The coordinate system for Attributes x1, y1, x2 and y2.
 func (e *SVGLinearGradientElement) GradientUnits(c SVGLinearGradientGradientUnitsChoice) *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("gradientUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("gradientUnits", string(c), choiceValue[SVGLinearGradientGradientUnitsChoice])
 	return e
 }
 
@@ -228,20 +271,16 @@ const (
 // The coordinate system for Attributes x1, y1, x2 and y2.
 // Remove the attribute GradientUnits from the element.
 func (e *SVGLinearGradientElement) GradientUnitsRemove() *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("gradientUnits")
+	e = e.writable()
+	e.removeAttribute("gradientUnits")
 	return e
 }
 
 // The definition of how the gradient is applied, read about <a
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 func (e *SVGLinearGradientElement) GradientTransform(s string) *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("gradientTransform", s)
+	e = e.writable()
+	e.setStringAttribute("gradientTransform", s)
 	return e
 }
 
@@ -255,7 +294,7 @@ func (e *SVGLinearGradientElement) GradientTransformf(format string, args ...any
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 func (e *SVGLinearGradientElement) IfGradientTransform(condition bool, s string) *SVGLinearGradientElement {
 	if condition {
-		e.GradientTransform(s)
+		e = e.GradientTransform(s)
 	}
 	return e
 }
@@ -264,7 +303,7 @@ func (e *SVGLinearGradientElement) IfGradientTransform(condition bool, s string)
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 func (e *SVGLinearGradientElement) IfGradientTransformf(condition bool, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
-		e.GradientTransform(fmt.Sprintf(format, args...))
+		e = e.GradientTransform(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -273,19 +312,15 @@ func (e *SVGLinearGradientElement) IfGradientTransformf(condition bool, format s
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 // Remove the attribute GradientTransform from the element.
 func (e *SVGLinearGradientElement) GradientTransformRemove() *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("gradientTransform")
+	e = e.writable()
+	e.removeAttribute("gradientTransform")
 	return e
 }
 
 // The method by which to fill a shape.
 func (e *SVGLinearGradientElement) SpreadMethod(c SVGLinearGradientSpreadMethodChoice) *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spreadMethod", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spreadMethod", string(c), choiceValue[SVGLinearGradientSpreadMethodChoice])
 	return e
 }
 
@@ -303,87 +338,75 @@ const (
 // The method by which to fill a shape.
 // Remove the attribute SpreadMethod from the element.
 func (e *SVGLinearGradientElement) SpreadMethodRemove() *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spreadMethod")
+	e = e.writable()
+	e.removeAttribute("spreadMethod")
 	return e
 }
 
 // The x-axis coordinate of the start of the gradient.
 func (e *SVGLinearGradientElement) X1(f float64) *SVGLinearGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x1", f)
+	e = e.writable()
+	e.setFloatAttribute("x1", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the start of the gradient.
 func (e *SVGLinearGradientElement) IfX1(condition bool, f float64) *SVGLinearGradientElement {
 	if condition {
-		e.X1(f)
+		e = e.X1(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the start of the gradient.
 func (e *SVGLinearGradientElement) Y1(f float64) *SVGLinearGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y1", f)
+	e = e.writable()
+	e.setFloatAttribute("y1", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the start of the gradient.
 func (e *SVGLinearGradientElement) IfY1(condition bool, f float64) *SVGLinearGradientElement {
 	if condition {
-		e.Y1(f)
+		e = e.Y1(f)
 	}
 	return e
 }
 
 // The x-axis coordinate of the end of the gradient.
 func (e *SVGLinearGradientElement) X2(f float64) *SVGLinearGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x2", f)
+	e = e.writable()
+	e.setFloatAttribute("x2", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the end of the gradient.
 func (e *SVGLinearGradientElement) IfX2(condition bool, f float64) *SVGLinearGradientElement {
 	if condition {
-		e.X2(f)
+		e = e.X2(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the end of the gradient.
 func (e *SVGLinearGradientElement) Y2(f float64) *SVGLinearGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y2", f)
+	e = e.writable()
+	e.setFloatAttribute("y2", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the end of the gradient.
 func (e *SVGLinearGradientElement) IfY2(condition bool, f float64) *SVGLinearGradientElement {
 	if condition {
-		e.Y2(f)
+		e = e.Y2(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGLinearGradientElement) ID(s string) *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -395,7 +418,7 @@ func (e *SVGLinearGradientElement) IDf(format string, args ...any) *SVGLinearGra
 // Specifies a unique id for an element
 func (e *SVGLinearGradientElement) IfID(condition bool, s string) *SVGLinearGradientElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -403,7 +426,7 @@ func (e *SVGLinearGradientElement) IfID(condition bool, s string) *SVGLinearGrad
 // Specifies a unique id for an element
 func (e *SVGLinearGradientElement) IfIDf(condition bool, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -411,26 +434,17 @@ func (e *SVGLinearGradientElement) IfIDf(condition bool, format string, args ...
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGLinearGradientElement) IDRemove() *SVGLinearGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGLinearGradientElement) Class(s string) *SVGLinearGradientElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -438,7 +452,7 @@ func (e *SVGLinearGradientElement) Class(s string) *SVGLinearGradientElement {
 // sheet)
 func (e *SVGLinearGradientElement) IfClass(condition bool, s string) *SVGLinearGradientElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -447,14 +461,8 @@ func (e *SVGLinearGradientElement) IfClass(condition bool, s string) *SVGLinearG
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGLinearGradientElement) ClassRemove(s ...string) *SVGLinearGradientElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -463,14 +471,8 @@ func (e *SVGLinearGradientElement) StylePairs(pairs ...string) *SVGLinearGradien
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -484,14 +486,8 @@ func (e *SVGLinearGradientElement) StylePairs(pairs ...string) *SVGLinearGradien
 
 // Specifies an inline CSS style for an element
 func (e *SVGLinearGradientElement) Style(s string) *SVGLinearGradientElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -499,7 +495,7 @@ func (e *SVGLinearGradientElement) Style(s string) *SVGLinearGradientElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -507,22 +503,14 @@ func (e *SVGLinearGradientElement) Style(s string) *SVGLinearGradientElement {
 // Specifies an inline CSS style for an element
 func (e *SVGLinearGradientElement) IfStyle(condition bool, s string) *SVGLinearGradientElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGLinearGradientElement) StyleAdd(k string, v string) *SVGLinearGradientElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -534,7 +522,7 @@ func (e *SVGLinearGradientElement) StyleAddf(k string, format string, args ...an
 // Specifies an inline CSS style for an element
 func (e *SVGLinearGradientElement) IfStyleAdd(condition bool, k string, v string) *SVGLinearGradientElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -542,7 +530,7 @@ func (e *SVGLinearGradientElement) IfStyleAdd(condition bool, k string, v string
 // Specifies an inline CSS style for an element
 func (e *SVGLinearGradientElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGLinearGradientElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -550,21 +538,15 @@ func (e *SVGLinearGradientElement) IfStyleAddf(condition bool, k string, format
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGLinearGradientElement) StyleMap(m map[string]string) *SVGLinearGradientElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -572,13 +554,7 @@ func (e *SVGLinearGradientElement) StyleMap(m map[string]string) *SVGLinearGradi
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGLinearGradientElement) StyleRemove(keys ...string) *SVGLinearGradientElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <marker> SVG element defines the graphics that is to be used for drawing
@@ -23,18 +21,61 @@ type SVGMarkerElement struct {
 // with the tag "marker" during rendering.
 func SVGMarker(children ...ElementRenderer) *SVGMarkerElement {
 	e := NewElement("marker", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGMarkerElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGMarkerElement) Clone() *SVGMarkerElement {
+	return &SVGMarkerElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGMarkerElement) CloneShallow() *SVGMarkerElement {
+	return &SVGMarkerElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGMarkerElement) Freeze() *SVGMarkerElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGMarkerElement) writable() *SVGMarkerElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGMarkerElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGMarkerElement) choiceType(name string) func(string) any {
+	switch name {
+	case "orient":
+		return choiceValue[SVGMarkerOrientChoice]
+	case "markerUnits":
+		return choiceValue[SVGMarkerMarkerUnitsChoice]
+	}
+	return nil
+}
+
 func (e *SVGMarkerElement) Children(children ...ElementRenderer) *SVGMarkerElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGMarkerElement) IfChildren(condition bool, children ...ElementRenderer) *SVGMarkerElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +83,30 @@ func (e *SVGMarkerElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGMarkerElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGMarkerElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) BoolAttr(name string) *SVGMarkerElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGMarkerElement) BoolAttrRemove(name string) *SVGMarkerElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGMarkerElement) IfBoolAttr(condition bool, name string) *SVGMarkerElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +117,40 @@ func (e *SVGMarkerElement) BoolAttrf(format string, args ...any) *SVGMarkerEleme
 
 func (e *SVGMarkerElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGMarkerElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) BoolAttrs(names ...string) *SVGMarkerElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) IfBoolAttrs(condition bool, names ...string) *SVGMarkerElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) Attr(name, value string) *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGMarkerElement) RawAttr(name, value string) *SVGMarkerElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGMarkerElement) IfAttr(condition bool, name, value string) *SVGMarkerElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +161,52 @@ func (e *SVGMarkerElement) Attrf(name, format string, args ...any) *SVGMarkerEle
 
 func (e *SVGMarkerElement) IfAttrf(condition bool, name, format string, args ...any) *SVGMarkerElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) Attrs(attrs ...string) *SVGMarkerElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGMarkerElement) IfAttrs(condition bool, attrs ...string) *SVGMarkerElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) AttrsMap(attrs map[string]string) *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGMarkerElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGMarkerElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGMarkerElement) Text(text string) *SVGMarkerElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGMarkerElement) Textf(format string, args ...any) *SVGMarkerElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGMarkerElement) IfText(condition bool, text string) *SVGMarkerElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +214,28 @@ func (e *SVGMarkerElement) IfText(condition bool, text string) *SVGMarkerElement
 
 func (e *SVGMarkerElement) IfTextf(condition bool, format string, args ...any) *SVGMarkerElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGMarkerElement) Raw(text string) *SVGMarkerElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGMarkerElement) Escaped(text string) *SVGMarkerElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGMarkerElement) IfEscaped(condition bool, text string) *SVGMarkerElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +247,7 @@ func (e *SVGMarkerElement) Escapedf(format string, args ...any) *SVGMarkerElemen
 
 func (e *SVGMarkerElement) IfEscapedf(condition bool, format string, args ...any) *SVGMarkerElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +256,8 @@ func (e *SVGMarkerElement) IfEscapedf(condition bool, format string, args ...any
 // The x-axis coordinate of the reference point which is to be aligned exactly
 // at the marker position.
 func (e *SVGMarkerElement) RefX(f float64) *SVGMarkerElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("refX", f)
+	e = e.writable()
+	e.setFloatAttribute("refX", f, 0)
 	return e
 }
 
@@ -222,7 +265,7 @@ func (e *SVGMarkerElement) RefX(f float64) *SVGMarkerElement {
 // at the marker position.
 func (e *SVGMarkerElement) IfRefX(condition bool, f float64) *SVGMarkerElement {
 	if condition {
-		e.RefX(f)
+		e = e.RefX(f)
 	}
 	return e
 }
@@ -230,10 +273,8 @@ func (e *SVGMarkerElement) IfRefX(condition bool, f float64) *SVGMarkerElement {
 // The y-axis coordinate of the reference point which is to be aligned exactly
 // at the marker position.
 func (e *SVGMarkerElement) RefY(f float64) *SVGMarkerElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("refY", f)
+	e = e.writable()
+	e.setFloatAttribute("refY", f, 0)
 	return e
 }
 
@@ -241,51 +282,45 @@ func (e *SVGMarkerElement) RefY(f float64) *SVGMarkerElement {
 // at the marker position.
 func (e *SVGMarkerElement) IfRefY(condition bool, f float64) *SVGMarkerElement {
 	if condition {
-		e.RefY(f)
+		e = e.RefY(f)
 	}
 	return e
 }
 
 // The width of the marker viewport.
 func (e *SVGMarkerElement) MarkerWidth(f float64) *SVGMarkerElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("markerWidth", f)
+	e = e.writable()
+	e.setFloatAttribute("markerWidth", f, 0)
 	return e
 }
 
 // The width of the marker viewport.
 func (e *SVGMarkerElement) IfMarkerWidth(condition bool, f float64) *SVGMarkerElement {
 	if condition {
-		e.MarkerWidth(f)
+		e = e.MarkerWidth(f)
 	}
 	return e
 }
 
 // The height of the marker viewport.
 func (e *SVGMarkerElement) MarkerHeight(f float64) *SVGMarkerElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("markerHeight", f)
+	e = e.writable()
+	e.setFloatAttribute("markerHeight", f, 0)
 	return e
 }
 
 // The height of the marker viewport.
 func (e *SVGMarkerElement) IfMarkerHeight(condition bool, f float64) *SVGMarkerElement {
 	if condition {
-		e.MarkerHeight(f)
+		e = e.MarkerHeight(f)
 	}
 	return e
 }
 
 // The orientation of the marker relative to the shape it is attached to.
 func (e *SVGMarkerElement) Orient(c SVGMarkerOrientChoice) *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("orient", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("orient", string(c), choiceValue[SVGMarkerOrientChoice])
 	return e
 }
 
@@ -303,19 +338,15 @@ const (
 // The orientation of the marker relative to the shape it is attached to.
 // Remove the attribute Orient from the element.
 func (e *SVGMarkerElement) OrientRemove() *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("orient")
+	e = e.writable()
+	e.removeAttribute("orient")
 	return e
 }
 
 // The coordinate system for the various length values within the marker.
 func (e *SVGMarkerElement) MarkerUnits(c SVGMarkerMarkerUnitsChoice) *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("markerUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("markerUnits", string(c), choiceValue[SVGMarkerMarkerUnitsChoice])
 	return e
 }
 
@@ -331,19 +362,15 @@ const (
 // The coordinate system for the various length values within the marker.
 // Remove the attribute MarkerUnits from the element.
 func (e *SVGMarkerElement) MarkerUnitsRemove() *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("markerUnits")
+	e = e.writable()
+	e.removeAttribute("markerUnits")
 	return e
 }
 
 // The position and size of the marker viewport (the bounds of the marker).
 func (e *SVGMarkerElement) ViewBox(s string) *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("viewBox", s)
+	e = e.writable()
+	e.setStringAttribute("viewBox", s)
 	return e
 }
 
@@ -355,7 +382,7 @@ func (e *SVGMarkerElement) ViewBoxf(format string, args ...any) *SVGMarkerElemen
 // The position and size of the marker viewport (the bounds of the marker).
 func (e *SVGMarkerElement) IfViewBox(condition bool, s string) *SVGMarkerElement {
 	if condition {
-		e.ViewBox(s)
+		e = e.ViewBox(s)
 	}
 	return e
 }
@@ -363,7 +390,7 @@ func (e *SVGMarkerElement) IfViewBox(condition bool, s string) *SVGMarkerElement
 // The position and size of the marker viewport (the bounds of the marker).
 func (e *SVGMarkerElement) IfViewBoxf(condition bool, format string, args ...any) *SVGMarkerElement {
 	if condition {
-		e.ViewBox(fmt.Sprintf(format, args...))
+		e = e.ViewBox(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -371,19 +398,15 @@ func (e *SVGMarkerElement) IfViewBoxf(condition bool, format string, args ...any
 // The position and size of the marker viewport (the bounds of the marker).
 // Remove the attribute ViewBox from the element.
 func (e *SVGMarkerElement) ViewBoxRemove() *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("viewBox")
+	e = e.writable()
+	e.removeAttribute("viewBox")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGMarkerElement) ID(s string) *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -395,7 +418,7 @@ func (e *SVGMarkerElement) IDf(format string, args ...any) *SVGMarkerElement {
 // Specifies a unique id for an element
 func (e *SVGMarkerElement) IfID(condition bool, s string) *SVGMarkerElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -403,7 +426,7 @@ func (e *SVGMarkerElement) IfID(condition bool, s string) *SVGMarkerElement {
 // Specifies a unique id for an element
 func (e *SVGMarkerElement) IfIDf(condition bool, format string, args ...any) *SVGMarkerElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -411,26 +434,17 @@ func (e *SVGMarkerElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGMarkerElement) IDRemove() *SVGMarkerElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGMarkerElement) Class(s string) *SVGMarkerElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -438,7 +452,7 @@ func (e *SVGMarkerElement) Class(s string) *SVGMarkerElement {
 // sheet)
 func (e *SVGMarkerElement) IfClass(condition bool, s string) *SVGMarkerElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -447,14 +461,8 @@ func (e *SVGMarkerElement) IfClass(condition bool, s string) *SVGMarkerElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGMarkerElement) ClassRemove(s ...string) *SVGMarkerElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -463,14 +471,8 @@ func (e *SVGMarkerElement) StylePairs(pairs ...string) *SVGMarkerElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -484,14 +486,8 @@ func (e *SVGMarkerElement) StylePairs(pairs ...string) *SVGMarkerElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGMarkerElement) Style(s string) *SVGMarkerElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -499,7 +495,7 @@ func (e *SVGMarkerElement) Style(s string) *SVGMarkerElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -507,22 +503,14 @@ func (e *SVGMarkerElement) Style(s string) *SVGMarkerElement {
 // Specifies an inline CSS style for an element
 func (e *SVGMarkerElement) IfStyle(condition bool, s string) *SVGMarkerElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGMarkerElement) StyleAdd(k string, v string) *SVGMarkerElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -534,7 +522,7 @@ func (e *SVGMarkerElement) StyleAddf(k string, format string, args ...any) *SVGM
 // Specifies an inline CSS style for an element
 func (e *SVGMarkerElement) IfStyleAdd(condition bool, k string, v string) *SVGMarkerElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -542,7 +530,7 @@ func (e *SVGMarkerElement) IfStyleAdd(condition bool, k string, v string) *SVGMa
 // Specifies an inline CSS style for an element
 func (e *SVGMarkerElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGMarkerElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -550,21 +538,15 @@ func (e *SVGMarkerElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGMarkerElement) StyleMap(m map[string]string) *SVGMarkerElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -572,13 +554,7 @@ func (e *SVGMarkerElement) StyleMap(m map[string]string) *SVGMarkerElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGMarkerElement) StyleRemove(keys ...string) *SVGMarkerElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
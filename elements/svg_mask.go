@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <mask> SVG element hides portions of SVG elements for user display.
@@ -21,18 +19,61 @@ type SVGMaskElement struct {
 // with the tag "mask" during rendering.
 func SVGMask(children ...ElementRenderer) *SVGMaskElement {
 	e := NewElement("mask", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGMaskElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGMaskElement) Clone() *SVGMaskElement {
+	return &SVGMaskElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGMaskElement) CloneShallow() *SVGMaskElement {
+	return &SVGMaskElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGMaskElement) Freeze() *SVGMaskElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGMaskElement) writable() *SVGMaskElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGMaskElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGMaskElement) choiceType(name string) func(string) any {
+	switch name {
+	case "maskContentUnits":
+		return choiceValue[SVGMaskMaskContentUnitsChoice]
+	case "maskUnits":
+		return choiceValue[SVGMaskMaskUnitsChoice]
+	}
+	return nil
+}
+
 func (e *SVGMaskElement) Children(children ...ElementRenderer) *SVGMaskElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGMaskElement) IfChildren(condition bool, children ...ElementRenderer) *SVGMaskElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -40,32 +81,30 @@ func (e *SVGMaskElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGMaskElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGMaskElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) BoolAttr(name string) *SVGMaskElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGMaskElement) BoolAttrRemove(name string) *SVGMaskElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGMaskElement) IfBoolAttr(condition bool, name string) *SVGMaskElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -76,36 +115,40 @@ func (e *SVGMaskElement) BoolAttrf(format string, args ...any) *SVGMaskElement {
 
 func (e *SVGMaskElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) BoolAttrs(names ...string) *SVGMaskElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) IfBoolAttrs(condition bool, names ...string) *SVGMaskElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) Attr(name, value string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGMaskElement) RawAttr(name, value string) *SVGMaskElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGMaskElement) IfAttr(condition bool, name, value string) *SVGMaskElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -116,61 +159,52 @@ func (e *SVGMaskElement) Attrf(name, format string, args ...any) *SVGMaskElement
 
 func (e *SVGMaskElement) IfAttrf(condition bool, name, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) Attrs(attrs ...string) *SVGMaskElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGMaskElement) IfAttrs(condition bool, attrs ...string) *SVGMaskElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) AttrsMap(attrs map[string]string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGMaskElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGMaskElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGMaskElement) Text(text string) *SVGMaskElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGMaskElement) Textf(format string, args ...any) *SVGMaskElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGMaskElement) IfText(condition bool, text string) *SVGMaskElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -178,18 +212,28 @@ func (e *SVGMaskElement) IfText(condition bool, text string) *SVGMaskElement {
 
 func (e *SVGMaskElement) IfTextf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGMaskElement) Raw(text string) *SVGMaskElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGMaskElement) Escaped(text string) *SVGMaskElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGMaskElement) IfEscaped(condition bool, text string) *SVGMaskElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -201,6 +245,7 @@ func (e *SVGMaskElement) Escapedf(format string, args ...any) *SVGMaskElement {
 
 func (e *SVGMaskElement) IfEscapedf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -208,10 +253,8 @@ func (e *SVGMaskElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The coordinate system for Attributes x, y, width and height.
 func (e *SVGMaskElement) MaskContentUnits(c SVGMaskMaskContentUnitsChoice) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("maskContentUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("maskContentUnits", string(c), choiceValue[SVGMaskMaskContentUnitsChoice])
 	return e
 }
 
@@ -227,19 +270,15 @@ const (
 // The coordinate system for Attributes x, y, width and height.
 // Remove the attribute MaskContentUnits from the element.
 func (e *SVGMaskElement) MaskContentUnitsRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("maskContentUnits")
+	e = e.writable()
+	e.removeAttribute("maskContentUnits")
 	return e
 }
 
 // The coordinate system for the various length values within the filter.
 func (e *SVGMaskElement) MaskUnits(c SVGMaskMaskUnitsChoice) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("maskUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("maskUnits", string(c), choiceValue[SVGMaskMaskUnitsChoice])
 	return e
 }
 
@@ -255,20 +294,16 @@ const (
 // The coordinate system for the various length values within the filter.
 // Remove the attribute MaskUnits from the element.
 func (e *SVGMaskElement) MaskUnitsRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("maskUnits")
+	e = e.writable()
+	e.removeAttribute("maskUnits")
 	return e
 }
 
 // The x-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGMaskElement) X(s string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("x", s)
+	e = e.writable()
+	e.setStringAttribute("x", s)
 	return e
 }
 
@@ -282,7 +317,7 @@ func (e *SVGMaskElement) Xf(format string, args ...any) *SVGMaskElement {
 // to the user.
 func (e *SVGMaskElement) IfX(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.X(s)
+		e = e.X(s)
 	}
 	return e
 }
@@ -291,7 +326,7 @@ func (e *SVGMaskElement) IfX(condition bool, s string) *SVGMaskElement {
 // to the user.
 func (e *SVGMaskElement) IfXf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.X(fmt.Sprintf(format, args...))
+		e = e.X(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -300,20 +335,16 @@ func (e *SVGMaskElement) IfXf(condition bool, format string, args ...any) *SVGMa
 // to the user.
 // Remove the attribute X from the element.
 func (e *SVGMaskElement) XRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("x")
+	e = e.writable()
+	e.removeAttribute("x")
 	return e
 }
 
 // The y-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGMaskElement) Y(s string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("y", s)
+	e = e.writable()
+	e.setStringAttribute("y", s)
 	return e
 }
 
@@ -327,7 +358,7 @@ func (e *SVGMaskElement) Yf(format string, args ...any) *SVGMaskElement {
 // to the user.
 func (e *SVGMaskElement) IfY(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.Y(s)
+		e = e.Y(s)
 	}
 	return e
 }
@@ -336,7 +367,7 @@ func (e *SVGMaskElement) IfY(condition bool, s string) *SVGMaskElement {
 // to the user.
 func (e *SVGMaskElement) IfYf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.Y(fmt.Sprintf(format, args...))
+		e = e.Y(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -345,19 +376,15 @@ func (e *SVGMaskElement) IfYf(condition bool, format string, args ...any) *SVGMa
 // to the user.
 // Remove the attribute Y from the element.
 func (e *SVGMaskElement) YRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("y")
+	e = e.writable()
+	e.removeAttribute("y")
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGMaskElement) Width(s string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("width", s)
+	e = e.writable()
+	e.setStringAttribute("width", s)
 	return e
 }
 
@@ -369,7 +396,7 @@ func (e *SVGMaskElement) Widthf(format string, args ...any) *SVGMaskElement {
 // The width of the rectangular region.
 func (e *SVGMaskElement) IfWidth(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.Width(s)
+		e = e.Width(s)
 	}
 	return e
 }
@@ -377,7 +404,7 @@ func (e *SVGMaskElement) IfWidth(condition bool, s string) *SVGMaskElement {
 // The width of the rectangular region.
 func (e *SVGMaskElement) IfWidthf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.Width(fmt.Sprintf(format, args...))
+		e = e.Width(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -385,19 +412,15 @@ func (e *SVGMaskElement) IfWidthf(condition bool, format string, args ...any) *S
 // The width of the rectangular region.
 // Remove the attribute Width from the element.
 func (e *SVGMaskElement) WidthRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGMaskElement) Height(s string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("height", s)
+	e = e.writable()
+	e.setStringAttribute("height", s)
 	return e
 }
 
@@ -409,7 +432,7 @@ func (e *SVGMaskElement) Heightf(format string, args ...any) *SVGMaskElement {
 // The height of the rectangular region.
 func (e *SVGMaskElement) IfHeight(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.Height(s)
+		e = e.Height(s)
 	}
 	return e
 }
@@ -417,7 +440,7 @@ func (e *SVGMaskElement) IfHeight(condition bool, s string) *SVGMaskElement {
 // The height of the rectangular region.
 func (e *SVGMaskElement) IfHeightf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.Height(fmt.Sprintf(format, args...))
+		e = e.Height(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -425,19 +448,15 @@ func (e *SVGMaskElement) IfHeightf(condition bool, format string, args ...any) *
 // The height of the rectangular region.
 // Remove the attribute Height from the element.
 func (e *SVGMaskElement) HeightRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGMaskElement) ID(s string) *SVGMaskElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -449,7 +468,7 @@ func (e *SVGMaskElement) IDf(format string, args ...any) *SVGMaskElement {
 // Specifies a unique id for an element
 func (e *SVGMaskElement) IfID(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -457,7 +476,7 @@ func (e *SVGMaskElement) IfID(condition bool, s string) *SVGMaskElement {
 // Specifies a unique id for an element
 func (e *SVGMaskElement) IfIDf(condition bool, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -465,26 +484,17 @@ func (e *SVGMaskElement) IfIDf(condition bool, format string, args ...any) *SVGM
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGMaskElement) IDRemove() *SVGMaskElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGMaskElement) Class(s string) *SVGMaskElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -492,7 +502,7 @@ func (e *SVGMaskElement) Class(s string) *SVGMaskElement {
 // sheet)
 func (e *SVGMaskElement) IfClass(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -501,14 +511,8 @@ func (e *SVGMaskElement) IfClass(condition bool, s string) *SVGMaskElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGMaskElement) ClassRemove(s ...string) *SVGMaskElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -517,14 +521,8 @@ func (e *SVGMaskElement) StylePairs(pairs ...string) *SVGMaskElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -538,14 +536,8 @@ func (e *SVGMaskElement) StylePairs(pairs ...string) *SVGMaskElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGMaskElement) Style(s string) *SVGMaskElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -553,7 +545,7 @@ func (e *SVGMaskElement) Style(s string) *SVGMaskElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -561,22 +553,14 @@ func (e *SVGMaskElement) Style(s string) *SVGMaskElement {
 // Specifies an inline CSS style for an element
 func (e *SVGMaskElement) IfStyle(condition bool, s string) *SVGMaskElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGMaskElement) StyleAdd(k string, v string) *SVGMaskElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -588,7 +572,7 @@ func (e *SVGMaskElement) StyleAddf(k string, format string, args ...any) *SVGMas
 // Specifies an inline CSS style for an element
 func (e *SVGMaskElement) IfStyleAdd(condition bool, k string, v string) *SVGMaskElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -596,7 +580,7 @@ func (e *SVGMaskElement) IfStyleAdd(condition bool, k string, v string) *SVGMask
 // Specifies an inline CSS style for an element
 func (e *SVGMaskElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGMaskElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -604,21 +588,15 @@ func (e *SVGMaskElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGMaskElement) StyleMap(m map[string]string) *SVGMaskElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -626,13 +604,7 @@ func (e *SVGMaskElement) StyleMap(m map[string]string) *SVGMaskElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGMaskElement) StyleRemove(keys ...string) *SVGMaskElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <metadata> SVG element allows to add metadata to SVG content. Metadata is
@@ -23,18 +21,49 @@ type SVGMetadataElement struct {
 // with the tag "metadata" during rendering.
 func SVGMetadata(children ...ElementRenderer) *SVGMetadataElement {
 	e := NewElement("metadata", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGMetadataElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGMetadataElement) Clone() *SVGMetadataElement {
+	return &SVGMetadataElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGMetadataElement) CloneShallow() *SVGMetadataElement {
+	return &SVGMetadataElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGMetadataElement) Freeze() *SVGMetadataElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGMetadataElement) writable() *SVGMetadataElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGMetadataElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGMetadataElement) Children(children ...ElementRenderer) *SVGMetadataElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGMetadataElement) IfChildren(condition bool, children ...ElementRenderer) *SVGMetadataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGMetadataElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *SVGMetadataElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGMetadataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) BoolAttr(name string) *SVGMetadataElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGMetadataElement) BoolAttrRemove(name string) *SVGMetadataElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGMetadataElement) IfBoolAttr(condition bool, name string) *SVGMetadataElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGMetadataElement) BoolAttrf(format string, args ...any) *SVGMetadataE
 
 func (e *SVGMetadataElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) BoolAttrs(names ...string) *SVGMetadataElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) IfBoolAttrs(condition bool, names ...string) *SVGMetadataElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) Attr(name, value string) *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGMetadataElement) RawAttr(name, value string) *SVGMetadataElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGMetadataElement) IfAttr(condition bool, name, value string) *SVGMetadataElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGMetadataElement) Attrf(name, format string, args ...any) *SVGMetadat
 
 func (e *SVGMetadataElement) IfAttrf(condition bool, name, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) Attrs(attrs ...string) *SVGMetadataElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGMetadataElement) IfAttrs(condition bool, attrs ...string) *SVGMetadataElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) AttrsMap(attrs map[string]string) *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGMetadataElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGMetadataElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGMetadataElement) Text(text string) *SVGMetadataElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGMetadataElement) Textf(format string, args ...any) *SVGMetadataElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGMetadataElement) IfText(condition bool, text string) *SVGMetadataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGMetadataElement) IfText(condition bool, text string) *SVGMetadataEle
 
 func (e *SVGMetadataElement) IfTextf(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGMetadataElement) Raw(text string) *SVGMetadataElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGMetadataElement) Escaped(text string) *SVGMetadataElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGMetadataElement) IfEscaped(condition bool, text string) *SVGMetadataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGMetadataElement) Escapedf(format string, args ...any) *SVGMetadataEl
 
 func (e *SVGMetadataElement) IfEscapedf(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGMetadataElement) IfEscapedf(condition bool, format string, args ...a
 // A space-separated list of required extensions, indicating that the parent SVG
 // document must include the specified extensions for this element to be valid.
 func (e *SVGMetadataElement) RequiredExtensions(s string) *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredExtensions", s)
+	e = e.writable()
+	e.setStringAttribute("requiredExtensions", s)
 	return e
 }
 
@@ -228,7 +259,7 @@ func (e *SVGMetadataElement) RequiredExtensionsf(format string, args ...any) *SV
 // document must include the specified extensions for this element to be valid.
 func (e *SVGMetadataElement) IfRequiredExtensions(condition bool, s string) *SVGMetadataElement {
 	if condition {
-		e.RequiredExtensions(s)
+		e = e.RequiredExtensions(s)
 	}
 	return e
 }
@@ -237,7 +268,7 @@ func (e *SVGMetadataElement) IfRequiredExtensions(condition bool, s string) *SVG
 // document must include the specified extensions for this element to be valid.
 func (e *SVGMetadataElement) IfRequiredExtensionsf(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.RequiredExtensions(fmt.Sprintf(format, args...))
+		e = e.RequiredExtensions(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -246,10 +277,8 @@ func (e *SVGMetadataElement) IfRequiredExtensionsf(condition bool, format string
 // document must include the specified extensions for this element to be valid.
 // Remove the attribute RequiredExtensions from the element.
 func (e *SVGMetadataElement) RequiredExtensionsRemove() *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredExtensions")
+	e = e.writable()
+	e.removeAttribute("requiredExtensions")
 	return e
 }
 
@@ -257,10 +286,8 @@ func (e *SVGMetadataElement) RequiredExtensionsRemove() *SVGMetadataElement {
 // document must include support for all of the specified features for this
 // element to be valid.
 func (e *SVGMetadataElement) RequiredFeatures(s string) *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredFeatures", s)
+	e = e.writable()
+	e.setStringAttribute("requiredFeatures", s)
 	return e
 }
 
@@ -276,7 +303,7 @@ func (e *SVGMetadataElement) RequiredFeaturesf(format string, args ...any) *SVGM
 // element to be valid.
 func (e *SVGMetadataElement) IfRequiredFeatures(condition bool, s string) *SVGMetadataElement {
 	if condition {
-		e.RequiredFeatures(s)
+		e = e.RequiredFeatures(s)
 	}
 	return e
 }
@@ -286,7 +313,7 @@ func (e *SVGMetadataElement) IfRequiredFeatures(condition bool, s string) *SVGMe
 // element to be valid.
 func (e *SVGMetadataElement) IfRequiredFeaturesf(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.RequiredFeatures(fmt.Sprintf(format, args...))
+		e = e.RequiredFeatures(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -296,10 +323,8 @@ func (e *SVGMetadataElement) IfRequiredFeaturesf(condition bool, format string,
 // element to be valid.
 // Remove the attribute RequiredFeatures from the element.
 func (e *SVGMetadataElement) RequiredFeaturesRemove() *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredFeatures")
+	e = e.writable()
+	e.removeAttribute("requiredFeatures")
 	return e
 }
 
@@ -307,10 +332,8 @@ func (e *SVGMetadataElement) RequiredFeaturesRemove() *SVGMetadataElement {
 // document must include support for all of the specified languages for this
 // element to be valid.
 func (e *SVGMetadataElement) SystemLanguage(s string) *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("systemLanguage", s)
+	e = e.writable()
+	e.setStringAttribute("systemLanguage", s)
 	return e
 }
 
@@ -326,7 +349,7 @@ func (e *SVGMetadataElement) SystemLanguagef(format string, args ...any) *SVGMet
 // element to be valid.
 func (e *SVGMetadataElement) IfSystemLanguage(condition bool, s string) *SVGMetadataElement {
 	if condition {
-		e.SystemLanguage(s)
+		e = e.SystemLanguage(s)
 	}
 	return e
 }
@@ -336,7 +359,7 @@ func (e *SVGMetadataElement) IfSystemLanguage(condition bool, s string) *SVGMeta
 // element to be valid.
 func (e *SVGMetadataElement) IfSystemLanguagef(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.SystemLanguage(fmt.Sprintf(format, args...))
+		e = e.SystemLanguage(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -346,19 +369,15 @@ func (e *SVGMetadataElement) IfSystemLanguagef(condition bool, format string, ar
 // element to be valid.
 // Remove the attribute SystemLanguage from the element.
 func (e *SVGMetadataElement) SystemLanguageRemove() *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("systemLanguage")
+	e = e.writable()
+	e.removeAttribute("systemLanguage")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGMetadataElement) ID(s string) *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -370,7 +389,7 @@ func (e *SVGMetadataElement) IDf(format string, args ...any) *SVGMetadataElement
 // Specifies a unique id for an element
 func (e *SVGMetadataElement) IfID(condition bool, s string) *SVGMetadataElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -378,7 +397,7 @@ func (e *SVGMetadataElement) IfID(condition bool, s string) *SVGMetadataElement
 // Specifies a unique id for an element
 func (e *SVGMetadataElement) IfIDf(condition bool, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -386,26 +405,17 @@ func (e *SVGMetadataElement) IfIDf(condition bool, format string, args ...any) *
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGMetadataElement) IDRemove() *SVGMetadataElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGMetadataElement) Class(s string) *SVGMetadataElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -413,7 +423,7 @@ func (e *SVGMetadataElement) Class(s string) *SVGMetadataElement {
 // sheet)
 func (e *SVGMetadataElement) IfClass(condition bool, s string) *SVGMetadataElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -422,14 +432,8 @@ func (e *SVGMetadataElement) IfClass(condition bool, s string) *SVGMetadataEleme
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGMetadataElement) ClassRemove(s ...string) *SVGMetadataElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -438,14 +442,8 @@ func (e *SVGMetadataElement) StylePairs(pairs ...string) *SVGMetadataElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -459,14 +457,8 @@ func (e *SVGMetadataElement) StylePairs(pairs ...string) *SVGMetadataElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGMetadataElement) Style(s string) *SVGMetadataElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -474,7 +466,7 @@ func (e *SVGMetadataElement) Style(s string) *SVGMetadataElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -482,22 +474,14 @@ func (e *SVGMetadataElement) Style(s string) *SVGMetadataElement {
 // Specifies an inline CSS style for an element
 func (e *SVGMetadataElement) IfStyle(condition bool, s string) *SVGMetadataElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGMetadataElement) StyleAdd(k string, v string) *SVGMetadataElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -509,7 +493,7 @@ func (e *SVGMetadataElement) StyleAddf(k string, format string, args ...any) *SV
 // Specifies an inline CSS style for an element
 func (e *SVGMetadataElement) IfStyleAdd(condition bool, k string, v string) *SVGMetadataElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -517,7 +501,7 @@ func (e *SVGMetadataElement) IfStyleAdd(condition bool, k string, v string) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGMetadataElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGMetadataElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -525,21 +509,15 @@ func (e *SVGMetadataElement) IfStyleAddf(condition bool, k string, format string
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGMetadataElement) StyleMap(m map[string]string) *SVGMetadataElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -547,13 +525,7 @@ func (e *SVGMetadataElement) StyleMap(m map[string]string) *SVGMetadataElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGMetadataElement) StyleRemove(keys ...string) *SVGMetadataElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
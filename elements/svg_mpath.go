@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <mpath> SVG element allows to use the functionality of <animateMotion> to
@@ -22,18 +20,49 @@ type SVGMpathElement struct {
 // with the tag "mpath" during rendering.
 func SVGMpath(children ...ElementRenderer) *SVGMpathElement {
 	e := NewElement("mpath", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGMpathElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGMpathElement) Clone() *SVGMpathElement {
+	return &SVGMpathElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGMpathElement) CloneShallow() *SVGMpathElement {
+	return &SVGMpathElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGMpathElement) Freeze() *SVGMpathElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGMpathElement) writable() *SVGMpathElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGMpathElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGMpathElement) Children(children ...ElementRenderer) *SVGMpathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGMpathElement) IfChildren(condition bool, children ...ElementRenderer) *SVGMpathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGMpathElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *SVGMpathElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGMpathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) BoolAttr(name string) *SVGMpathElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGMpathElement) BoolAttrRemove(name string) *SVGMpathElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGMpathElement) IfBoolAttr(condition bool, name string) *SVGMpathElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGMpathElement) BoolAttrf(format string, args ...any) *SVGMpathElement
 
 func (e *SVGMpathElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGMpathElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) BoolAttrs(names ...string) *SVGMpathElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) IfBoolAttrs(condition bool, names ...string) *SVGMpathElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) Attr(name, value string) *SVGMpathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGMpathElement) RawAttr(name, value string) *SVGMpathElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGMpathElement) IfAttr(condition bool, name, value string) *SVGMpathElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGMpathElement) Attrf(name, format string, args ...any) *SVGMpathEleme
 
 func (e *SVGMpathElement) IfAttrf(condition bool, name, format string, args ...any) *SVGMpathElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) Attrs(attrs ...string) *SVGMpathElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGMpathElement) IfAttrs(condition bool, attrs ...string) *SVGMpathElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) AttrsMap(attrs map[string]string) *SVGMpathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGMpathElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGMpathElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGMpathElement) Text(text string) *SVGMpathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGMpathElement) Textf(format string, args ...any) *SVGMpathElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGMpathElement) IfText(condition bool, text string) *SVGMpathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGMpathElement) IfText(condition bool, text string) *SVGMpathElement {
 
 func (e *SVGMpathElement) IfTextf(condition bool, format string, args ...any) *SVGMpathElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGMpathElement) Raw(text string) *SVGMpathElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGMpathElement) Escaped(text string) *SVGMpathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGMpathElement) IfEscaped(condition bool, text string) *SVGMpathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGMpathElement) Escapedf(format string, args ...any) *SVGMpathElement
 
 func (e *SVGMpathElement) IfEscapedf(condition bool, format string, args ...any) *SVGMpathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGMpathElement) IfEscapedf(condition bool, format string, args ...any)
 
 // A URI reference to the motion path definition.
 func (e *SVGMpathElement) Href(s string) *SVGMpathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGMpathElement) Hreff(format string, args ...any) *SVGMpathElement {
 // A URI reference to the motion path definition.
 func (e *SVGMpathElement) IfHref(condition bool, s string) *SVGMpathElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGMpathElement) IfHref(condition bool, s string) *SVGMpathElement {
 // A URI reference to the motion path definition.
 func (e *SVGMpathElement) IfHreff(condition bool, format string, args ...any) *SVGMpathElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +271,15 @@ func (e *SVGMpathElement) IfHreff(condition bool, format string, args ...any) *S
 // A URI reference to the motion path definition.
 // Remove the attribute Href from the element.
 func (e *SVGMpathElement) HrefRemove() *SVGMpathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGMpathElement) ID(s string) *SVGMpathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -264,7 +291,7 @@ func (e *SVGMpathElement) IDf(format string, args ...any) *SVGMpathElement {
 // Specifies a unique id for an element
 func (e *SVGMpathElement) IfID(condition bool, s string) *SVGMpathElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -272,7 +299,7 @@ func (e *SVGMpathElement) IfID(condition bool, s string) *SVGMpathElement {
 // Specifies a unique id for an element
 func (e *SVGMpathElement) IfIDf(condition bool, format string, args ...any) *SVGMpathElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,26 +307,17 @@ func (e *SVGMpathElement) IfIDf(condition bool, format string, args ...any) *SVG
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGMpathElement) IDRemove() *SVGMpathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGMpathElement) Class(s string) *SVGMpathElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -307,7 +325,7 @@ func (e *SVGMpathElement) Class(s string) *SVGMpathElement {
 // sheet)
 func (e *SVGMpathElement) IfClass(condition bool, s string) *SVGMpathElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -316,14 +334,8 @@ func (e *SVGMpathElement) IfClass(condition bool, s string) *SVGMpathElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGMpathElement) ClassRemove(s ...string) *SVGMpathElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -332,14 +344,8 @@ func (e *SVGMpathElement) StylePairs(pairs ...string) *SVGMpathElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -353,14 +359,8 @@ func (e *SVGMpathElement) StylePairs(pairs ...string) *SVGMpathElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGMpathElement) Style(s string) *SVGMpathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -368,7 +368,7 @@ func (e *SVGMpathElement) Style(s string) *SVGMpathElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -376,22 +376,14 @@ func (e *SVGMpathElement) Style(s string) *SVGMpathElement {
 // Specifies an inline CSS style for an element
 func (e *SVGMpathElement) IfStyle(condition bool, s string) *SVGMpathElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGMpathElement) StyleAdd(k string, v string) *SVGMpathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -403,7 +395,7 @@ func (e *SVGMpathElement) StyleAddf(k string, format string, args ...any) *SVGMp
 // Specifies an inline CSS style for an element
 func (e *SVGMpathElement) IfStyleAdd(condition bool, k string, v string) *SVGMpathElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -411,7 +403,7 @@ func (e *SVGMpathElement) IfStyleAdd(condition bool, k string, v string) *SVGMpa
 // Specifies an inline CSS style for an element
 func (e *SVGMpathElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGMpathElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -419,21 +411,15 @@ func (e *SVGMpathElement) IfStyleAddf(condition bool, k string, format string, a
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGMpathElement) StyleMap(m map[string]string) *SVGMpathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -441,13 +427,7 @@ func (e *SVGMpathElement) StyleMap(m map[string]string) *SVGMpathElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGMpathElement) StyleRemove(keys ...string) *SVGMpathElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <path> SVG element is the generic element to define a shape. All the
@@ -22,18 +20,49 @@ type SVGPathElement struct {
 // with the tag "path" during rendering.
 func SVGPath(children ...ElementRenderer) *SVGPathElement {
 	e := NewElement("path", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGPathElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGPathElement) Clone() *SVGPathElement {
+	return &SVGPathElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGPathElement) CloneShallow() *SVGPathElement {
+	return &SVGPathElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGPathElement) Freeze() *SVGPathElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGPathElement) writable() *SVGPathElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGPathElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGPathElement) Children(children ...ElementRenderer) *SVGPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGPathElement) IfChildren(condition bool, children ...ElementRenderer) *SVGPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGPathElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGPathElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGPathElement) BoolAttr(name string) *SVGPathElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGPathElement) BoolAttrRemove(name string) *SVGPathElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGPathElement) IfBoolAttr(condition bool, name string) *SVGPathElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGPathElement) BoolAttrf(format string, args ...any) *SVGPathElement {
 
 func (e *SVGPathElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGPathElement) BoolAttrs(names ...string) *SVGPathElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGPathElement) IfBoolAttrs(condition bool, names ...string) *SVGPathElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGPathElement) Attr(name, value string) *SVGPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGPathElement) RawAttr(name, value string) *SVGPathElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGPathElement) IfAttr(condition bool, name, value string) *SVGPathElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGPathElement) Attrf(name, format string, args ...any) *SVGPathElement
 
 func (e *SVGPathElement) IfAttrf(condition bool, name, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGPathElement) Attrs(attrs ...string) *SVGPathElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGPathElement) IfAttrs(condition bool, attrs ...string) *SVGPathElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGPathElement) AttrsMap(attrs map[string]string) *SVGPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGPathElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGPathElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGPathElement) Text(text string) *SVGPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGPathElement) Textf(format string, args ...any) *SVGPathElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGPathElement) IfText(condition bool, text string) *SVGPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGPathElement) IfText(condition bool, text string) *SVGPathElement {
 
 func (e *SVGPathElement) IfTextf(condition bool, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGPathElement) Raw(text string) *SVGPathElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGPathElement) Escaped(text string) *SVGPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGPathElement) IfEscaped(condition bool, text string) *SVGPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGPathElement) Escapedf(format string, args ...any) *SVGPathElement {
 
 func (e *SVGPathElement) IfEscapedf(condition bool, format string, args ...any) *SVGPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGPathElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The definition of the outline of a shape.
 func (e *SVGPathElement) D(s string) *SVGPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("d", s)
+	e = e.writable()
+	e.setStringAttribute("d", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGPathElement) Df(format string, args ...any) *SVGPathElement {
 // The definition of the outline of a shape.
 func (e *SVGPathElement) IfD(condition bool, s string) *SVGPathElement {
 	if condition {
-		e.D(s)
+		e = e.D(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGPathElement) IfD(condition bool, s string) *SVGPathElement {
 // The definition of the outline of a shape.
 func (e *SVGPathElement) IfDf(condition bool, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.D(fmt.Sprintf(format, args...))
+		e = e.D(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,20 +271,16 @@ func (e *SVGPathElement) IfDf(condition bool, format string, args ...any) *SVGPa
 // The definition of the outline of a shape.
 // Remove the attribute D from the element.
 func (e *SVGPathElement) DRemove() *SVGPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("d")
+	e = e.writable()
+	e.removeAttribute("d")
 	return e
 }
 
 // The <path> SVG element is the generic element to define a shape. All the
 // basic shapes can be created with a path element.
 func (e *SVGPathElement) Fill(s string) *SVGPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fill", s)
+	e = e.writable()
+	e.setStringAttribute("fill", s)
 	return e
 }
 
@@ -267,7 +294,7 @@ func (e *SVGPathElement) Fillf(format string, args ...any) *SVGPathElement {
 // basic shapes can be created with a path element.
 func (e *SVGPathElement) IfFill(condition bool, s string) *SVGPathElement {
 	if condition {
-		e.Fill(s)
+		e = e.Fill(s)
 	}
 	return e
 }
@@ -276,7 +303,7 @@ func (e *SVGPathElement) IfFill(condition bool, s string) *SVGPathElement {
 // basic shapes can be created with a path element.
 func (e *SVGPathElement) IfFillf(condition bool, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.Fill(fmt.Sprintf(format, args...))
+		e = e.Fill(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -285,20 +312,16 @@ func (e *SVGPathElement) IfFillf(condition bool, format string, args ...any) *SV
 // basic shapes can be created with a path element.
 // Remove the attribute Fill from the element.
 func (e *SVGPathElement) FillRemove() *SVGPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fill")
+	e = e.writable()
+	e.removeAttribute("fill")
 	return e
 }
 
 // The <path> SVG element is the generic element to define a shape. All the
 // basic shapes can be created with a path element.
 func (e *SVGPathElement) FillOpacity(f float64) *SVGPathElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("fill-opacity", f)
+	e = e.writable()
+	e.setFloatAttribute("fill-opacity", f, 0)
 	return e
 }
 
@@ -306,34 +329,30 @@ func (e *SVGPathElement) FillOpacity(f float64) *SVGPathElement {
 // basic shapes can be created with a path element.
 func (e *SVGPathElement) IfFillOpacity(condition bool, f float64) *SVGPathElement {
 	if condition {
-		e.FillOpacity(f)
+		e = e.FillOpacity(f)
 	}
 	return e
 }
 
 // The total length for the path, in user units.
 func (e *SVGPathElement) PathLength(f float64) *SVGPathElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("pathLength", f)
+	e = e.writable()
+	e.setFloatAttribute("pathLength", f, 0)
 	return e
 }
 
 // The total length for the path, in user units.
 func (e *SVGPathElement) IfPathLength(condition bool, f float64) *SVGPathElement {
 	if condition {
-		e.PathLength(f)
+		e = e.PathLength(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGPathElement) ID(s string) *SVGPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -345,7 +364,7 @@ func (e *SVGPathElement) IDf(format string, args ...any) *SVGPathElement {
 // Specifies a unique id for an element
 func (e *SVGPathElement) IfID(condition bool, s string) *SVGPathElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -353,7 +372,7 @@ func (e *SVGPathElement) IfID(condition bool, s string) *SVGPathElement {
 // Specifies a unique id for an element
 func (e *SVGPathElement) IfIDf(condition bool, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -361,26 +380,17 @@ func (e *SVGPathElement) IfIDf(condition bool, format string, args ...any) *SVGP
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGPathElement) IDRemove() *SVGPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGPathElement) Class(s string) *SVGPathElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -388,7 +398,7 @@ func (e *SVGPathElement) Class(s string) *SVGPathElement {
 // sheet)
 func (e *SVGPathElement) IfClass(condition bool, s string) *SVGPathElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -397,14 +407,8 @@ func (e *SVGPathElement) IfClass(condition bool, s string) *SVGPathElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGPathElement) ClassRemove(s ...string) *SVGPathElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -413,14 +417,8 @@ func (e *SVGPathElement) StylePairs(pairs ...string) *SVGPathElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -434,14 +432,8 @@ func (e *SVGPathElement) StylePairs(pairs ...string) *SVGPathElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGPathElement) Style(s string) *SVGPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -449,7 +441,7 @@ func (e *SVGPathElement) Style(s string) *SVGPathElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -457,22 +449,14 @@ func (e *SVGPathElement) Style(s string) *SVGPathElement {
 // Specifies an inline CSS style for an element
 func (e *SVGPathElement) IfStyle(condition bool, s string) *SVGPathElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGPathElement) StyleAdd(k string, v string) *SVGPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -484,7 +468,7 @@ func (e *SVGPathElement) StyleAddf(k string, format string, args ...any) *SVGPat
 // Specifies an inline CSS style for an element
 func (e *SVGPathElement) IfStyleAdd(condition bool, k string, v string) *SVGPathElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -492,7 +476,7 @@ func (e *SVGPathElement) IfStyleAdd(condition bool, k string, v string) *SVGPath
 // Specifies an inline CSS style for an element
 func (e *SVGPathElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGPathElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -500,21 +484,15 @@ func (e *SVGPathElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGPathElement) StyleMap(m map[string]string) *SVGPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -522,13 +500,7 @@ func (e *SVGPathElement) StyleMap(m map[string]string) *SVGPathElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGPathElement) StyleRemove(keys ...string) *SVGPathElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <pattern> SVG element fills a region with a pattern defined by an SVG
@@ -22,18 +20,61 @@ type SVGPatternElement struct {
 // with the tag "pattern" during rendering.
 func SVGPattern(children ...ElementRenderer) *SVGPatternElement {
 	e := NewElement("pattern", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGPatternElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGPatternElement) Clone() *SVGPatternElement {
+	return &SVGPatternElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGPatternElement) CloneShallow() *SVGPatternElement {
+	return &SVGPatternElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGPatternElement) Freeze() *SVGPatternElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGPatternElement) writable() *SVGPatternElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGPatternElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGPatternElement) choiceType(name string) func(string) any {
+	switch name {
+	case "patternUnits":
+		return choiceValue[SVGPatternPatternUnitsChoice]
+	case "patternContentUnits":
+		return choiceValue[SVGPatternPatternContentUnitsChoice]
+	}
+	return nil
+}
+
 func (e *SVGPatternElement) Children(children ...ElementRenderer) *SVGPatternElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGPatternElement) IfChildren(condition bool, children ...ElementRenderer) *SVGPatternElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *SVGPatternElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGPatternElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGPatternElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) BoolAttr(name string) *SVGPatternElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGPatternElement) BoolAttrRemove(name string) *SVGPatternElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGPatternElement) IfBoolAttr(condition bool, name string) *SVGPatternElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *SVGPatternElement) BoolAttrf(format string, args ...any) *SVGPatternEle
 
 func (e *SVGPatternElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) BoolAttrs(names ...string) *SVGPatternElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) IfBoolAttrs(condition bool, names ...string) *SVGPatternElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) Attr(name, value string) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGPatternElement) RawAttr(name, value string) *SVGPatternElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGPatternElement) IfAttr(condition bool, name, value string) *SVGPatternElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *SVGPatternElement) Attrf(name, format string, args ...any) *SVGPatternE
 
 func (e *SVGPatternElement) IfAttrf(condition bool, name, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) Attrs(attrs ...string) *SVGPatternElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGPatternElement) IfAttrs(condition bool, attrs ...string) *SVGPatternElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) AttrsMap(attrs map[string]string) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGPatternElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGPatternElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGPatternElement) Text(text string) *SVGPatternElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGPatternElement) Textf(format string, args ...any) *SVGPatternElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGPatternElement) IfText(condition bool, text string) *SVGPatternElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *SVGPatternElement) IfText(condition bool, text string) *SVGPatternEleme
 
 func (e *SVGPatternElement) IfTextf(condition bool, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGPatternElement) Raw(text string) *SVGPatternElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGPatternElement) Escaped(text string) *SVGPatternElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGPatternElement) IfEscaped(condition bool, text string) *SVGPatternElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *SVGPatternElement) Escapedf(format string, args ...any) *SVGPatternElem
 
 func (e *SVGPatternElement) IfEscapedf(condition bool, format string, args ...any) *SVGPatternElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +254,8 @@ func (e *SVGPatternElement) IfEscapedf(condition bool, format string, args ...an
 
 // The coordinate system for Attributes x, y, width and height.
 func (e *SVGPatternElement) PatternUnits(c SVGPatternPatternUnitsChoice) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("patternUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("patternUnits", string(c), choiceValue[SVGPatternPatternUnitsChoice])
 	return e
 }
 
@@ -228,19 +271,15 @@ const (
 // The coordinate system for Attributes x, y, width and height.
 // Remove the attribute PatternUnits from the element.
 func (e *SVGPatternElement) PatternUnitsRemove() *SVGPatternElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("patternUnits")
+	e = e.writable()
+	e.removeAttribute("patternUnits")
 	return e
 }
 
 // The coordinate system for the various length values within the filter.
 func (e *SVGPatternElement) PatternContentUnits(c SVGPatternPatternContentUnitsChoice) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("patternContentUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("patternContentUnits", string(c), choiceValue[SVGPatternPatternContentUnitsChoice])
 	return e
 }
 
@@ -256,20 +295,16 @@ const (
 // The coordinate system for the various length values within the filter.
 // Remove the attribute PatternContentUnits from the element.
 func (e *SVGPatternElement) PatternContentUnitsRemove() *SVGPatternElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("patternContentUnits")
+	e = e.writable()
+	e.removeAttribute("patternContentUnits")
 	return e
 }
 
 // The definition of how the pattern is tiled, read about <a
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/patternTransform">patternTransform</a>.
 func (e *SVGPatternElement) PatternTransform(s string) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("patternTransform", s)
+	e = e.writable()
+	e.setStringAttribute("patternTransform", s)
 	return e
 }
 
@@ -283,7 +318,7 @@ func (e *SVGPatternElement) PatternTransformf(format string, args ...any) *SVGPa
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/patternTransform">patternTransform</a>.
 func (e *SVGPatternElement) IfPatternTransform(condition bool, s string) *SVGPatternElement {
 	if condition {
-		e.PatternTransform(s)
+		e = e.PatternTransform(s)
 	}
 	return e
 }
@@ -292,7 +327,7 @@ func (e *SVGPatternElement) IfPatternTransform(condition bool, s string) *SVGPat
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/patternTransform">patternTransform</a>.
 func (e *SVGPatternElement) IfPatternTransformf(condition bool, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.PatternTransform(fmt.Sprintf(format, args...))
+		e = e.PatternTransform(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -301,20 +336,16 @@ func (e *SVGPatternElement) IfPatternTransformf(condition bool, format string, a
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/patternTransform">patternTransform</a>.
 // Remove the attribute PatternTransform from the element.
 func (e *SVGPatternElement) PatternTransformRemove() *SVGPatternElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("patternTransform")
+	e = e.writable()
+	e.removeAttribute("patternTransform")
 	return e
 }
 
 // The x-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGPatternElement) X(f float64) *SVGPatternElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
@@ -322,7 +353,7 @@ func (e *SVGPatternElement) X(f float64) *SVGPatternElement {
 // to the user.
 func (e *SVGPatternElement) IfX(condition bool, f float64) *SVGPatternElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
@@ -330,10 +361,8 @@ func (e *SVGPatternElement) IfX(condition bool, f float64) *SVGPatternElement {
 // The y-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGPatternElement) Y(f float64) *SVGPatternElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
@@ -341,51 +370,45 @@ func (e *SVGPatternElement) Y(f float64) *SVGPatternElement {
 // to the user.
 func (e *SVGPatternElement) IfY(condition bool, f float64) *SVGPatternElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGPatternElement) Width(f float64) *SVGPatternElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("width", f)
+	e = e.writable()
+	e.setFloatAttribute("width", f, 0)
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGPatternElement) IfWidth(condition bool, f float64) *SVGPatternElement {
 	if condition {
-		e.Width(f)
+		e = e.Width(f)
 	}
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGPatternElement) Height(f float64) *SVGPatternElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("height", f)
+	e = e.writable()
+	e.setFloatAttribute("height", f, 0)
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGPatternElement) IfHeight(condition bool, f float64) *SVGPatternElement {
 	if condition {
-		e.Height(f)
+		e = e.Height(f)
 	}
 	return e
 }
 
 // A URI reference to the image to paint.
 func (e *SVGPatternElement) Href(s string) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -397,7 +420,7 @@ func (e *SVGPatternElement) Hreff(format string, args ...any) *SVGPatternElement
 // A URI reference to the image to paint.
 func (e *SVGPatternElement) IfHref(condition bool, s string) *SVGPatternElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -405,7 +428,7 @@ func (e *SVGPatternElement) IfHref(condition bool, s string) *SVGPatternElement
 // A URI reference to the image to paint.
 func (e *SVGPatternElement) IfHreff(condition bool, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -413,19 +436,15 @@ func (e *SVGPatternElement) IfHreff(condition bool, format string, args ...any)
 // A URI reference to the image to paint.
 // Remove the attribute Href from the element.
 func (e *SVGPatternElement) HrefRemove() *SVGPatternElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGPatternElement) ID(s string) *SVGPatternElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -437,7 +456,7 @@ func (e *SVGPatternElement) IDf(format string, args ...any) *SVGPatternElement {
 // Specifies a unique id for an element
 func (e *SVGPatternElement) IfID(condition bool, s string) *SVGPatternElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -445,7 +464,7 @@ func (e *SVGPatternElement) IfID(condition bool, s string) *SVGPatternElement {
 // Specifies a unique id for an element
 func (e *SVGPatternElement) IfIDf(condition bool, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -453,26 +472,17 @@ func (e *SVGPatternElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGPatternElement) IDRemove() *SVGPatternElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGPatternElement) Class(s string) *SVGPatternElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -480,7 +490,7 @@ func (e *SVGPatternElement) Class(s string) *SVGPatternElement {
 // sheet)
 func (e *SVGPatternElement) IfClass(condition bool, s string) *SVGPatternElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -489,14 +499,8 @@ func (e *SVGPatternElement) IfClass(condition bool, s string) *SVGPatternElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGPatternElement) ClassRemove(s ...string) *SVGPatternElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -505,14 +509,8 @@ func (e *SVGPatternElement) StylePairs(pairs ...string) *SVGPatternElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -526,14 +524,8 @@ func (e *SVGPatternElement) StylePairs(pairs ...string) *SVGPatternElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGPatternElement) Style(s string) *SVGPatternElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -541,7 +533,7 @@ func (e *SVGPatternElement) Style(s string) *SVGPatternElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -549,22 +541,14 @@ func (e *SVGPatternElement) Style(s string) *SVGPatternElement {
 // Specifies an inline CSS style for an element
 func (e *SVGPatternElement) IfStyle(condition bool, s string) *SVGPatternElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGPatternElement) StyleAdd(k string, v string) *SVGPatternElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -576,7 +560,7 @@ func (e *SVGPatternElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGPatternElement) IfStyleAdd(condition bool, k string, v string) *SVGPatternElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -584,7 +568,7 @@ func (e *SVGPatternElement) IfStyleAdd(condition bool, k string, v string) *SVGP
 // Specifies an inline CSS style for an element
 func (e *SVGPatternElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGPatternElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -592,21 +576,15 @@ func (e *SVGPatternElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGPatternElement) StyleMap(m map[string]string) *SVGPatternElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -614,13 +592,7 @@ func (e *SVGPatternElement) StyleMap(m map[string]string) *SVGPatternElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGPatternElement) StyleRemove(keys ...string) *SVGPatternElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
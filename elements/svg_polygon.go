@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <polygon> SVG element is an SVG basic shape, used to create a
@@ -22,18 +20,49 @@ type SVGPolygonElement struct {
 // with the tag "polygon" during rendering.
 func SVGPolygon(children ...ElementRenderer) *SVGPolygonElement {
 	e := NewElement("polygon", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGPolygonElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGPolygonElement) Clone() *SVGPolygonElement {
+	return &SVGPolygonElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGPolygonElement) CloneShallow() *SVGPolygonElement {
+	return &SVGPolygonElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGPolygonElement) Freeze() *SVGPolygonElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGPolygonElement) writable() *SVGPolygonElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGPolygonElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGPolygonElement) Children(children ...ElementRenderer) *SVGPolygonElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGPolygonElement) IfChildren(condition bool, children ...ElementRenderer) *SVGPolygonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGPolygonElement) IfChildren(condition bool, children ...ElementRender
 
 func (e *SVGPolygonElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGPolygonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) BoolAttr(name string) *SVGPolygonElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGPolygonElement) BoolAttrRemove(name string) *SVGPolygonElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGPolygonElement) IfBoolAttr(condition bool, name string) *SVGPolygonElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGPolygonElement) BoolAttrf(format string, args ...any) *SVGPolygonEle
 
 func (e *SVGPolygonElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGPolygonElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) BoolAttrs(names ...string) *SVGPolygonElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) IfBoolAttrs(condition bool, names ...string) *SVGPolygonElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) Attr(name, value string) *SVGPolygonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGPolygonElement) RawAttr(name, value string) *SVGPolygonElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGPolygonElement) IfAttr(condition bool, name, value string) *SVGPolygonElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGPolygonElement) Attrf(name, format string, args ...any) *SVGPolygonE
 
 func (e *SVGPolygonElement) IfAttrf(condition bool, name, format string, args ...any) *SVGPolygonElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) Attrs(attrs ...string) *SVGPolygonElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGPolygonElement) IfAttrs(condition bool, attrs ...string) *SVGPolygonElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) AttrsMap(attrs map[string]string) *SVGPolygonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGPolygonElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGPolygonElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGPolygonElement) Text(text string) *SVGPolygonElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGPolygonElement) Textf(format string, args ...any) *SVGPolygonElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGPolygonElement) IfText(condition bool, text string) *SVGPolygonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGPolygonElement) IfText(condition bool, text string) *SVGPolygonEleme
 
 func (e *SVGPolygonElement) IfTextf(condition bool, format string, args ...any) *SVGPolygonElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGPolygonElement) Raw(text string) *SVGPolygonElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGPolygonElement) Escaped(text string) *SVGPolygonElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGPolygonElement) IfEscaped(condition bool, text string) *SVGPolygonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGPolygonElement) Escapedf(format string, args ...any) *SVGPolygonElem
 
 func (e *SVGPolygonElement) IfEscapedf(condition bool, format string, args ...any) *SVGPolygonElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGPolygonElement) IfEscapedf(condition bool, format string, args ...an
 
 // A list of points, each of which is a coordinate pair.
 func (e *SVGPolygonElement) Points(s string) *SVGPolygonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("points", s)
+	e = e.writable()
+	e.setStringAttribute("points", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGPolygonElement) Pointsf(format string, args ...any) *SVGPolygonEleme
 // A list of points, each of which is a coordinate pair.
 func (e *SVGPolygonElement) IfPoints(condition bool, s string) *SVGPolygonElement {
 	if condition {
-		e.Points(s)
+		e = e.Points(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGPolygonElement) IfPoints(condition bool, s string) *SVGPolygonElemen
 // A list of points, each of which is a coordinate pair.
 func (e *SVGPolygonElement) IfPointsf(condition bool, format string, args ...any) *SVGPolygonElement {
 	if condition {
-		e.Points(fmt.Sprintf(format, args...))
+		e = e.Points(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,19 +271,15 @@ func (e *SVGPolygonElement) IfPointsf(condition bool, format string, args ...any
 // A list of points, each of which is a coordinate pair.
 // Remove the attribute Points from the element.
 func (e *SVGPolygonElement) PointsRemove() *SVGPolygonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("points")
+	e = e.writable()
+	e.removeAttribute("points")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGPolygonElement) ID(s string) *SVGPolygonElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -264,7 +291,7 @@ func (e *SVGPolygonElement) IDf(format string, args ...any) *SVGPolygonElement {
 // Specifies a unique id for an element
 func (e *SVGPolygonElement) IfID(condition bool, s string) *SVGPolygonElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -272,7 +299,7 @@ func (e *SVGPolygonElement) IfID(condition bool, s string) *SVGPolygonElement {
 // Specifies a unique id for an element
 func (e *SVGPolygonElement) IfIDf(condition bool, format string, args ...any) *SVGPolygonElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -280,26 +307,17 @@ func (e *SVGPolygonElement) IfIDf(condition bool, format string, args ...any) *S
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGPolygonElement) IDRemove() *SVGPolygonElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGPolygonElement) Class(s string) *SVGPolygonElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -307,7 +325,7 @@ func (e *SVGPolygonElement) Class(s string) *SVGPolygonElement {
 // sheet)
 func (e *SVGPolygonElement) IfClass(condition bool, s string) *SVGPolygonElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -316,14 +334,8 @@ func (e *SVGPolygonElement) IfClass(condition bool, s string) *SVGPolygonElement
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGPolygonElement) ClassRemove(s ...string) *SVGPolygonElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -332,14 +344,8 @@ func (e *SVGPolygonElement) StylePairs(pairs ...string) *SVGPolygonElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -353,14 +359,8 @@ func (e *SVGPolygonElement) StylePairs(pairs ...string) *SVGPolygonElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGPolygonElement) Style(s string) *SVGPolygonElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -368,7 +368,7 @@ func (e *SVGPolygonElement) Style(s string) *SVGPolygonElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -376,22 +376,14 @@ func (e *SVGPolygonElement) Style(s string) *SVGPolygonElement {
 // Specifies an inline CSS style for an element
 func (e *SVGPolygonElement) IfStyle(condition bool, s string) *SVGPolygonElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGPolygonElement) StyleAdd(k string, v string) *SVGPolygonElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -403,7 +395,7 @@ func (e *SVGPolygonElement) StyleAddf(k string, format string, args ...any) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGPolygonElement) IfStyleAdd(condition bool, k string, v string) *SVGPolygonElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -411,7 +403,7 @@ func (e *SVGPolygonElement) IfStyleAdd(condition bool, k string, v string) *SVGP
 // Specifies an inline CSS style for an element
 func (e *SVGPolygonElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGPolygonElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -419,21 +411,15 @@ func (e *SVGPolygonElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGPolygonElement) StyleMap(m map[string]string) *SVGPolygonElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -441,13 +427,7 @@ func (e *SVGPolygonElement) StyleMap(m map[string]string) *SVGPolygonElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGPolygonElement) StyleRemove(keys ...string) *SVGPolygonElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
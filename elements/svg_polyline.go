@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <polyline> SVG element is an SVG basic shape, used to create a series of
@@ -24,18 +22,49 @@ type SVGPolylineElement struct {
 // with the tag "polyline" during rendering.
 func SVGPolyline(children ...ElementRenderer) *SVGPolylineElement {
 	e := NewElement("polyline", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGPolylineElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGPolylineElement) Clone() *SVGPolylineElement {
+	return &SVGPolylineElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGPolylineElement) CloneShallow() *SVGPolylineElement {
+	return &SVGPolylineElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGPolylineElement) Freeze() *SVGPolylineElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGPolylineElement) writable() *SVGPolylineElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGPolylineElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGPolylineElement) Children(children ...ElementRenderer) *SVGPolylineElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGPolylineElement) IfChildren(condition bool, children ...ElementRenderer) *SVGPolylineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +72,30 @@ func (e *SVGPolylineElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *SVGPolylineElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGPolylineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) BoolAttr(name string) *SVGPolylineElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGPolylineElement) BoolAttrRemove(name string) *SVGPolylineElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGPolylineElement) IfBoolAttr(condition bool, name string) *SVGPolylineElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +106,40 @@ func (e *SVGPolylineElement) BoolAttrf(format string, args ...any) *SVGPolylineE
 
 func (e *SVGPolylineElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGPolylineElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) BoolAttrs(names ...string) *SVGPolylineElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) IfBoolAttrs(condition bool, names ...string) *SVGPolylineElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) Attr(name, value string) *SVGPolylineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGPolylineElement) RawAttr(name, value string) *SVGPolylineElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGPolylineElement) IfAttr(condition bool, name, value string) *SVGPolylineElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +150,52 @@ func (e *SVGPolylineElement) Attrf(name, format string, args ...any) *SVGPolylin
 
 func (e *SVGPolylineElement) IfAttrf(condition bool, name, format string, args ...any) *SVGPolylineElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) Attrs(attrs ...string) *SVGPolylineElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGPolylineElement) IfAttrs(condition bool, attrs ...string) *SVGPolylineElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) AttrsMap(attrs map[string]string) *SVGPolylineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGPolylineElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGPolylineElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGPolylineElement) Text(text string) *SVGPolylineElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGPolylineElement) Textf(format string, args ...any) *SVGPolylineElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGPolylineElement) IfText(condition bool, text string) *SVGPolylineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +203,28 @@ func (e *SVGPolylineElement) IfText(condition bool, text string) *SVGPolylineEle
 
 func (e *SVGPolylineElement) IfTextf(condition bool, format string, args ...any) *SVGPolylineElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGPolylineElement) Raw(text string) *SVGPolylineElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGPolylineElement) Escaped(text string) *SVGPolylineElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGPolylineElement) IfEscaped(condition bool, text string) *SVGPolylineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +236,7 @@ func (e *SVGPolylineElement) Escapedf(format string, args ...any) *SVGPolylineEl
 
 func (e *SVGPolylineElement) IfEscapedf(condition bool, format string, args ...any) *SVGPolylineElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGPolylineElement) IfEscapedf(condition bool, format string, args ...a
 
 // A list of points, each of which is a coordinate pair.
 func (e *SVGPolylineElement) Points(s string) *SVGPolylineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("points", s)
+	e = e.writable()
+	e.setStringAttribute("points", s)
 	return e
 }
 
@@ -226,7 +257,7 @@ func (e *SVGPolylineElement) Pointsf(format string, args ...any) *SVGPolylineEle
 // A list of points, each of which is a coordinate pair.
 func (e *SVGPolylineElement) IfPoints(condition bool, s string) *SVGPolylineElement {
 	if condition {
-		e.Points(s)
+		e = e.Points(s)
 	}
 	return e
 }
@@ -234,7 +265,7 @@ func (e *SVGPolylineElement) IfPoints(condition bool, s string) *SVGPolylineElem
 // A list of points, each of which is a coordinate pair.
 func (e *SVGPolylineElement) IfPointsf(condition bool, format string, args ...any) *SVGPolylineElement {
 	if condition {
-		e.Points(fmt.Sprintf(format, args...))
+		e = e.Points(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -242,19 +273,15 @@ func (e *SVGPolylineElement) IfPointsf(condition bool, format string, args ...an
 // A list of points, each of which is a coordinate pair.
 // Remove the attribute Points from the element.
 func (e *SVGPolylineElement) PointsRemove() *SVGPolylineElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("points")
+	e = e.writable()
+	e.removeAttribute("points")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGPolylineElement) ID(s string) *SVGPolylineElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -266,7 +293,7 @@ func (e *SVGPolylineElement) IDf(format string, args ...any) *SVGPolylineElement
 // Specifies a unique id for an element
 func (e *SVGPolylineElement) IfID(condition bool, s string) *SVGPolylineElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -274,7 +301,7 @@ func (e *SVGPolylineElement) IfID(condition bool, s string) *SVGPolylineElement
 // Specifies a unique id for an element
 func (e *SVGPolylineElement) IfIDf(condition bool, format string, args ...any) *SVGPolylineElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -282,26 +309,17 @@ func (e *SVGPolylineElement) IfIDf(condition bool, format string, args ...any) *
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGPolylineElement) IDRemove() *SVGPolylineElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGPolylineElement) Class(s string) *SVGPolylineElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -309,7 +327,7 @@ func (e *SVGPolylineElement) Class(s string) *SVGPolylineElement {
 // sheet)
 func (e *SVGPolylineElement) IfClass(condition bool, s string) *SVGPolylineElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -318,14 +336,8 @@ func (e *SVGPolylineElement) IfClass(condition bool, s string) *SVGPolylineEleme
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGPolylineElement) ClassRemove(s ...string) *SVGPolylineElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -334,14 +346,8 @@ func (e *SVGPolylineElement) StylePairs(pairs ...string) *SVGPolylineElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -355,14 +361,8 @@ func (e *SVGPolylineElement) StylePairs(pairs ...string) *SVGPolylineElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGPolylineElement) Style(s string) *SVGPolylineElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -370,7 +370,7 @@ func (e *SVGPolylineElement) Style(s string) *SVGPolylineElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -378,22 +378,14 @@ func (e *SVGPolylineElement) Style(s string) *SVGPolylineElement {
 // Specifies an inline CSS style for an element
 func (e *SVGPolylineElement) IfStyle(condition bool, s string) *SVGPolylineElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGPolylineElement) StyleAdd(k string, v string) *SVGPolylineElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -405,7 +397,7 @@ func (e *SVGPolylineElement) StyleAddf(k string, format string, args ...any) *SV
 // Specifies an inline CSS style for an element
 func (e *SVGPolylineElement) IfStyleAdd(condition bool, k string, v string) *SVGPolylineElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -413,7 +405,7 @@ func (e *SVGPolylineElement) IfStyleAdd(condition bool, k string, v string) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGPolylineElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGPolylineElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -421,21 +413,15 @@ func (e *SVGPolylineElement) IfStyleAddf(condition bool, k string, format string
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGPolylineElement) StyleMap(m map[string]string) *SVGPolylineElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -443,13 +429,7 @@ func (e *SVGPolylineElement) StyleMap(m map[string]string) *SVGPolylineElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGPolylineElement) StyleRemove(keys ...string) *SVGPolylineElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
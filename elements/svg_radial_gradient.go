@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <radialGradient> SVG element lets authors define radial gradients to fill
@@ -22,18 +20,59 @@ type SVGRadialGradientElement struct {
 // with the tag "radialGradient" during rendering.
 func SVGRadialGradient(children ...ElementRenderer) *SVGRadialGradientElement {
 	e := NewElement("radialGradient", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGRadialGradientElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGRadialGradientElement) Clone() *SVGRadialGradientElement {
+	return &SVGRadialGradientElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGRadialGradientElement) CloneShallow() *SVGRadialGradientElement {
+	return &SVGRadialGradientElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGRadialGradientElement) Freeze() *SVGRadialGradientElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGRadialGradientElement) writable() *SVGRadialGradientElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGRadialGradientElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGRadialGradientElement) choiceType(name string) func(string) any {
+	switch name {
+	case "gradientUnits":
+		return choiceValue[SVGRadialGradientGradientUnitsChoice]
+	}
+	return nil
+}
+
 func (e *SVGRadialGradientElement) Children(children ...ElementRenderer) *SVGRadialGradientElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfChildren(condition bool, children ...ElementRenderer) *SVGRadialGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +80,30 @@ func (e *SVGRadialGradientElement) IfChildren(condition bool, children ...Elemen
 
 func (e *SVGRadialGradientElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGRadialGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) BoolAttr(name string) *SVGRadialGradientElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGRadialGradientElement) BoolAttrRemove(name string) *SVGRadialGradientElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfBoolAttr(condition bool, name string) *SVGRadialGradientElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +114,40 @@ func (e *SVGRadialGradientElement) BoolAttrf(format string, args ...any) *SVGRad
 
 func (e *SVGRadialGradientElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) BoolAttrs(names ...string) *SVGRadialGradientElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfBoolAttrs(condition bool, names ...string) *SVGRadialGradientElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) Attr(name, value string) *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGRadialGradientElement) RawAttr(name, value string) *SVGRadialGradientElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfAttr(condition bool, name, value string) *SVGRadialGradientElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +158,52 @@ func (e *SVGRadialGradientElement) Attrf(name, format string, args ...any) *SVGR
 
 func (e *SVGRadialGradientElement) IfAttrf(condition bool, name, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) Attrs(attrs ...string) *SVGRadialGradientElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfAttrs(condition bool, attrs ...string) *SVGRadialGradientElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) AttrsMap(attrs map[string]string) *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGRadialGradientElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGRadialGradientElement) Text(text string) *SVGRadialGradientElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGRadialGradientElement) Textf(format string, args ...any) *SVGRadialGradientElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGRadialGradientElement) IfText(condition bool, text string) *SVGRadialGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +211,28 @@ func (e *SVGRadialGradientElement) IfText(condition bool, text string) *SVGRadia
 
 func (e *SVGRadialGradientElement) IfTextf(condition bool, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGRadialGradientElement) Raw(text string) *SVGRadialGradientElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGRadialGradientElement) Escaped(text string) *SVGRadialGradientElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGRadialGradientElement) IfEscaped(condition bool, text string) *SVGRadialGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +244,7 @@ func (e *SVGRadialGradientElement) Escapedf(format string, args ...any) *SVGRadi
 
 func (e *SVGRadialGradientElement) IfEscapedf(condition bool, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +252,8 @@ func (e *SVGRadialGradientElement) IfEscapedf(condition bool, format string, arg
 
 // The coordinate system for Attributes cx, cy and r.
 func (e *SVGRadialGradientElement) GradientUnits(c SVGRadialGradientGradientUnitsChoice) *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("gradientUnits", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("gradientUnits", string(c), choiceValue[SVGRadialGradientGradientUnitsChoice])
 	return e
 }
 
@@ -228,20 +269,16 @@ const (
 // The coordinate system for Attributes cx, cy and r.
 // Remove the attribute GradientUnits from the element.
 func (e *SVGRadialGradientElement) GradientUnitsRemove() *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("gradientUnits")
+	e = e.writable()
+	e.removeAttribute("gradientUnits")
 	return e
 }
 
 // The definition of how the gradient is applied, read about <a
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 func (e *SVGRadialGradientElement) GradientTransform(s string) *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("gradientTransform", s)
+	e = e.writable()
+	e.setStringAttribute("gradientTransform", s)
 	return e
 }
 
@@ -255,7 +292,7 @@ func (e *SVGRadialGradientElement) GradientTransformf(format string, args ...any
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 func (e *SVGRadialGradientElement) IfGradientTransform(condition bool, s string) *SVGRadialGradientElement {
 	if condition {
-		e.GradientTransform(s)
+		e = e.GradientTransform(s)
 	}
 	return e
 }
@@ -264,7 +301,7 @@ func (e *SVGRadialGradientElement) IfGradientTransform(condition bool, s string)
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 func (e *SVGRadialGradientElement) IfGradientTransformf(condition bool, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
-		e.GradientTransform(fmt.Sprintf(format, args...))
+		e = e.GradientTransform(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -273,20 +310,16 @@ func (e *SVGRadialGradientElement) IfGradientTransformf(condition bool, format s
 // href="https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/gradientTransform">gradientTransform</a>.
 // Remove the attribute GradientTransform from the element.
 func (e *SVGRadialGradientElement) GradientTransformRemove() *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("gradientTransform")
+	e = e.writable()
+	e.removeAttribute("gradientTransform")
 	return e
 }
 
 // The x-axis coordinate of the largest (i.e., outermost) circle for the radial
 // gradient.
 func (e *SVGRadialGradientElement) Cx(f float64) *SVGRadialGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("cx", f)
+	e = e.writable()
+	e.setFloatAttribute("cx", f, 0)
 	return e
 }
 
@@ -294,7 +327,7 @@ func (e *SVGRadialGradientElement) Cx(f float64) *SVGRadialGradientElement {
 // gradient.
 func (e *SVGRadialGradientElement) IfCx(condition bool, f float64) *SVGRadialGradientElement {
 	if condition {
-		e.Cx(f)
+		e = e.Cx(f)
 	}
 	return e
 }
@@ -302,10 +335,8 @@ func (e *SVGRadialGradientElement) IfCx(condition bool, f float64) *SVGRadialGra
 // The y-axis coordinate of the largest (i.e., outermost) circle for the radial
 // gradient.
 func (e *SVGRadialGradientElement) Cy(f float64) *SVGRadialGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("cy", f)
+	e = e.writable()
+	e.setFloatAttribute("cy", f, 0)
 	return e
 }
 
@@ -313,24 +344,22 @@ func (e *SVGRadialGradientElement) Cy(f float64) *SVGRadialGradientElement {
 // gradient.
 func (e *SVGRadialGradientElement) IfCy(condition bool, f float64) *SVGRadialGradientElement {
 	if condition {
-		e.Cy(f)
+		e = e.Cy(f)
 	}
 	return e
 }
 
 // The radius of the largest (i.e., outermost) circle for the radial gradient.
 func (e *SVGRadialGradientElement) R(f float64) *SVGRadialGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("r", f)
+	e = e.writable()
+	e.setFloatAttribute("r", f, 0)
 	return e
 }
 
 // The radius of the largest (i.e., outermost) circle for the radial gradient.
 func (e *SVGRadialGradientElement) IfR(condition bool, f float64) *SVGRadialGradientElement {
 	if condition {
-		e.R(f)
+		e = e.R(f)
 	}
 	return e
 }
@@ -338,10 +367,8 @@ func (e *SVGRadialGradientElement) IfR(condition bool, f float64) *SVGRadialGrad
 // The x-axis coordinate of the point at which the focal point of the radial
 // gradient is placed.
 func (e *SVGRadialGradientElement) Fx(f float64) *SVGRadialGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("fx", f)
+	e = e.writable()
+	e.setFloatAttribute("fx", f, 0)
 	return e
 }
 
@@ -349,7 +376,7 @@ func (e *SVGRadialGradientElement) Fx(f float64) *SVGRadialGradientElement {
 // gradient is placed.
 func (e *SVGRadialGradientElement) IfFx(condition bool, f float64) *SVGRadialGradientElement {
 	if condition {
-		e.Fx(f)
+		e = e.Fx(f)
 	}
 	return e
 }
@@ -357,10 +384,8 @@ func (e *SVGRadialGradientElement) IfFx(condition bool, f float64) *SVGRadialGra
 // The y-axis coordinate of the point at which the focal point of the radial
 // gradient is placed.
 func (e *SVGRadialGradientElement) Fy(f float64) *SVGRadialGradientElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("fy", f)
+	e = e.writable()
+	e.setFloatAttribute("fy", f, 0)
 	return e
 }
 
@@ -368,17 +393,15 @@ func (e *SVGRadialGradientElement) Fy(f float64) *SVGRadialGradientElement {
 // gradient is placed.
 func (e *SVGRadialGradientElement) IfFy(condition bool, f float64) *SVGRadialGradientElement {
 	if condition {
-		e.Fy(f)
+		e = e.Fy(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGRadialGradientElement) ID(s string) *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -390,7 +413,7 @@ func (e *SVGRadialGradientElement) IDf(format string, args ...any) *SVGRadialGra
 // Specifies a unique id for an element
 func (e *SVGRadialGradientElement) IfID(condition bool, s string) *SVGRadialGradientElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -398,7 +421,7 @@ func (e *SVGRadialGradientElement) IfID(condition bool, s string) *SVGRadialGrad
 // Specifies a unique id for an element
 func (e *SVGRadialGradientElement) IfIDf(condition bool, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -406,26 +429,17 @@ func (e *SVGRadialGradientElement) IfIDf(condition bool, format string, args ...
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGRadialGradientElement) IDRemove() *SVGRadialGradientElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGRadialGradientElement) Class(s string) *SVGRadialGradientElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -433,7 +447,7 @@ func (e *SVGRadialGradientElement) Class(s string) *SVGRadialGradientElement {
 // sheet)
 func (e *SVGRadialGradientElement) IfClass(condition bool, s string) *SVGRadialGradientElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -442,14 +456,8 @@ func (e *SVGRadialGradientElement) IfClass(condition bool, s string) *SVGRadialG
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGRadialGradientElement) ClassRemove(s ...string) *SVGRadialGradientElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -458,14 +466,8 @@ func (e *SVGRadialGradientElement) StylePairs(pairs ...string) *SVGRadialGradien
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -479,14 +481,8 @@ func (e *SVGRadialGradientElement) StylePairs(pairs ...string) *SVGRadialGradien
 
 // Specifies an inline CSS style for an element
 func (e *SVGRadialGradientElement) Style(s string) *SVGRadialGradientElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -494,7 +490,7 @@ func (e *SVGRadialGradientElement) Style(s string) *SVGRadialGradientElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -502,22 +498,14 @@ func (e *SVGRadialGradientElement) Style(s string) *SVGRadialGradientElement {
 // Specifies an inline CSS style for an element
 func (e *SVGRadialGradientElement) IfStyle(condition bool, s string) *SVGRadialGradientElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGRadialGradientElement) StyleAdd(k string, v string) *SVGRadialGradientElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -529,7 +517,7 @@ func (e *SVGRadialGradientElement) StyleAddf(k string, format string, args ...an
 // Specifies an inline CSS style for an element
 func (e *SVGRadialGradientElement) IfStyleAdd(condition bool, k string, v string) *SVGRadialGradientElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -537,7 +525,7 @@ func (e *SVGRadialGradientElement) IfStyleAdd(condition bool, k string, v string
 // Specifies an inline CSS style for an element
 func (e *SVGRadialGradientElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGRadialGradientElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -545,21 +533,15 @@ func (e *SVGRadialGradientElement) IfStyleAddf(condition bool, k string, format
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGRadialGradientElement) StyleMap(m map[string]string) *SVGRadialGradientElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -567,13 +549,7 @@ func (e *SVGRadialGradientElement) StyleMap(m map[string]string) *SVGRadialGradi
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGRadialGradientElement) StyleRemove(keys ...string) *SVGRadialGradientElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
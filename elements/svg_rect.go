@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <rect> SVG element is a basic SVG shape that draws rectangles, defined by
@@ -23,18 +21,49 @@ type SVGRectElement struct {
 // with the tag "rect" during rendering.
 func SVGRect(children ...ElementRenderer) *SVGRectElement {
 	e := NewElement("rect", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGRectElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGRectElement) Clone() *SVGRectElement {
+	return &SVGRectElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGRectElement) CloneShallow() *SVGRectElement {
+	return &SVGRectElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGRectElement) Freeze() *SVGRectElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGRectElement) writable() *SVGRectElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGRectElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGRectElement) Children(children ...ElementRenderer) *SVGRectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGRectElement) IfChildren(condition bool, children ...ElementRenderer) *SVGRectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGRectElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGRectElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGRectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGRectElement) BoolAttr(name string) *SVGRectElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGRectElement) BoolAttrRemove(name string) *SVGRectElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGRectElement) IfBoolAttr(condition bool, name string) *SVGRectElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGRectElement) BoolAttrf(format string, args ...any) *SVGRectElement {
 
 func (e *SVGRectElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGRectElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGRectElement) BoolAttrs(names ...string) *SVGRectElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGRectElement) IfBoolAttrs(condition bool, names ...string) *SVGRectElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGRectElement) Attr(name, value string) *SVGRectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGRectElement) RawAttr(name, value string) *SVGRectElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGRectElement) IfAttr(condition bool, name, value string) *SVGRectElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGRectElement) Attrf(name, format string, args ...any) *SVGRectElement
 
 func (e *SVGRectElement) IfAttrf(condition bool, name, format string, args ...any) *SVGRectElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGRectElement) Attrs(attrs ...string) *SVGRectElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGRectElement) IfAttrs(condition bool, attrs ...string) *SVGRectElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGRectElement) AttrsMap(attrs map[string]string) *SVGRectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGRectElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGRectElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGRectElement) Text(text string) *SVGRectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGRectElement) Textf(format string, args ...any) *SVGRectElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGRectElement) IfText(condition bool, text string) *SVGRectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGRectElement) IfText(condition bool, text string) *SVGRectElement {
 
 func (e *SVGRectElement) IfTextf(condition bool, format string, args ...any) *SVGRectElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGRectElement) Raw(text string) *SVGRectElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGRectElement) Escaped(text string) *SVGRectElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGRectElement) IfEscaped(condition bool, text string) *SVGRectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGRectElement) Escapedf(format string, args ...any) *SVGRectElement {
 
 func (e *SVGRectElement) IfEscapedf(condition bool, format string, args ...any) *SVGRectElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGRectElement) IfEscapedf(condition bool, format string, args ...any)
 // The x-axis coordinate of the side of the rectangle which has the smaller
 // x-axis value.
 func (e *SVGRectElement) X(f float64) *SVGRectElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
@@ -222,7 +253,7 @@ func (e *SVGRectElement) X(f float64) *SVGRectElement {
 // x-axis value.
 func (e *SVGRectElement) IfX(condition bool, f float64) *SVGRectElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
@@ -230,10 +261,8 @@ func (e *SVGRectElement) IfX(condition bool, f float64) *SVGRectElement {
 // The y-axis coordinate of the side of the rectangle which has the smaller
 // y-axis value.
 func (e *SVGRectElement) Y(f float64) *SVGRectElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
@@ -241,41 +270,37 @@ func (e *SVGRectElement) Y(f float64) *SVGRectElement {
 // y-axis value.
 func (e *SVGRectElement) IfY(condition bool, f float64) *SVGRectElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
 
 // The width of the rectangle.
 func (e *SVGRectElement) Width(f float64) *SVGRectElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("width", f)
+	e = e.writable()
+	e.setFloatAttribute("width", f, 0)
 	return e
 }
 
 // The width of the rectangle.
 func (e *SVGRectElement) IfWidth(condition bool, f float64) *SVGRectElement {
 	if condition {
-		e.Width(f)
+		e = e.Width(f)
 	}
 	return e
 }
 
 // The height of the rectangle.
 func (e *SVGRectElement) Height(f float64) *SVGRectElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("height", f)
+	e = e.writable()
+	e.setFloatAttribute("height", f, 0)
 	return e
 }
 
 // The height of the rectangle.
 func (e *SVGRectElement) IfHeight(condition bool, f float64) *SVGRectElement {
 	if condition {
-		e.Height(f)
+		e = e.Height(f)
 	}
 	return e
 }
@@ -283,10 +308,8 @@ func (e *SVGRectElement) IfHeight(condition bool, f float64) *SVGRectElement {
 // The x-axis radius of the ellipse used to round off the corners of the
 // rectangle.
 func (e *SVGRectElement) Rx(f float64) *SVGRectElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("rx", f)
+	e = e.writable()
+	e.setFloatAttribute("rx", f, 0)
 	return e
 }
 
@@ -294,7 +317,7 @@ func (e *SVGRectElement) Rx(f float64) *SVGRectElement {
 // rectangle.
 func (e *SVGRectElement) IfRx(condition bool, f float64) *SVGRectElement {
 	if condition {
-		e.Rx(f)
+		e = e.Rx(f)
 	}
 	return e
 }
@@ -302,10 +325,8 @@ func (e *SVGRectElement) IfRx(condition bool, f float64) *SVGRectElement {
 // The y-axis radius of the ellipse used to round off the corners of the
 // rectangle.
 func (e *SVGRectElement) Ry(f float64) *SVGRectElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("ry", f)
+	e = e.writable()
+	e.setFloatAttribute("ry", f, 0)
 	return e
 }
 
@@ -313,17 +334,15 @@ func (e *SVGRectElement) Ry(f float64) *SVGRectElement {
 // rectangle.
 func (e *SVGRectElement) IfRy(condition bool, f float64) *SVGRectElement {
 	if condition {
-		e.Ry(f)
+		e = e.Ry(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGRectElement) ID(s string) *SVGRectElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -335,7 +354,7 @@ func (e *SVGRectElement) IDf(format string, args ...any) *SVGRectElement {
 // Specifies a unique id for an element
 func (e *SVGRectElement) IfID(condition bool, s string) *SVGRectElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -343,7 +362,7 @@ func (e *SVGRectElement) IfID(condition bool, s string) *SVGRectElement {
 // Specifies a unique id for an element
 func (e *SVGRectElement) IfIDf(condition bool, format string, args ...any) *SVGRectElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -351,26 +370,17 @@ func (e *SVGRectElement) IfIDf(condition bool, format string, args ...any) *SVGR
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGRectElement) IDRemove() *SVGRectElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGRectElement) Class(s string) *SVGRectElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -378,7 +388,7 @@ func (e *SVGRectElement) Class(s string) *SVGRectElement {
 // sheet)
 func (e *SVGRectElement) IfClass(condition bool, s string) *SVGRectElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -387,14 +397,8 @@ func (e *SVGRectElement) IfClass(condition bool, s string) *SVGRectElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGRectElement) ClassRemove(s ...string) *SVGRectElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -403,14 +407,8 @@ func (e *SVGRectElement) StylePairs(pairs ...string) *SVGRectElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -424,14 +422,8 @@ func (e *SVGRectElement) StylePairs(pairs ...string) *SVGRectElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGRectElement) Style(s string) *SVGRectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -439,7 +431,7 @@ func (e *SVGRectElement) Style(s string) *SVGRectElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -447,22 +439,14 @@ func (e *SVGRectElement) Style(s string) *SVGRectElement {
 // Specifies an inline CSS style for an element
 func (e *SVGRectElement) IfStyle(condition bool, s string) *SVGRectElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGRectElement) StyleAdd(k string, v string) *SVGRectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -474,7 +458,7 @@ func (e *SVGRectElement) StyleAddf(k string, format string, args ...any) *SVGRec
 // Specifies an inline CSS style for an element
 func (e *SVGRectElement) IfStyleAdd(condition bool, k string, v string) *SVGRectElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -482,7 +466,7 @@ func (e *SVGRectElement) IfStyleAdd(condition bool, k string, v string) *SVGRect
 // Specifies an inline CSS style for an element
 func (e *SVGRectElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGRectElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -490,21 +474,15 @@ func (e *SVGRectElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGRectElement) StyleMap(m map[string]string) *SVGRectElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -512,13 +490,7 @@ func (e *SVGRectElement) StyleMap(m map[string]string) *SVGRectElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGRectElement) StyleRemove(keys ...string) *SVGRectElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
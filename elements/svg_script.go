@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <script> SVG element includes scripts, which can be used to trigger user
@@ -22,18 +20,59 @@ type SVGScriptElement struct {
 // with the tag "script" during rendering.
 func SVGScript(children ...ElementRenderer) *SVGScriptElement {
 	e := NewElement("script", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGScriptElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGScriptElement) Clone() *SVGScriptElement {
+	return &SVGScriptElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGScriptElement) CloneShallow() *SVGScriptElement {
+	return &SVGScriptElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGScriptElement) Freeze() *SVGScriptElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGScriptElement) writable() *SVGScriptElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGScriptElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGScriptElement) choiceType(name string) func(string) any {
+	switch name {
+	case "crossorigin":
+		return choiceValue[SVGScriptCrossoriginChoice]
+	}
+	return nil
+}
+
 func (e *SVGScriptElement) Children(children ...ElementRenderer) *SVGScriptElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGScriptElement) IfChildren(condition bool, children ...ElementRenderer) *SVGScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +80,30 @@ func (e *SVGScriptElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGScriptElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) BoolAttr(name string) *SVGScriptElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGScriptElement) BoolAttrRemove(name string) *SVGScriptElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGScriptElement) IfBoolAttr(condition bool, name string) *SVGScriptElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +114,40 @@ func (e *SVGScriptElement) BoolAttrf(format string, args ...any) *SVGScriptEleme
 
 func (e *SVGScriptElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) BoolAttrs(names ...string) *SVGScriptElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) IfBoolAttrs(condition bool, names ...string) *SVGScriptElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) Attr(name, value string) *SVGScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGScriptElement) RawAttr(name, value string) *SVGScriptElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGScriptElement) IfAttr(condition bool, name, value string) *SVGScriptElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +158,52 @@ func (e *SVGScriptElement) Attrf(name, format string, args ...any) *SVGScriptEle
 
 func (e *SVGScriptElement) IfAttrf(condition bool, name, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) Attrs(attrs ...string) *SVGScriptElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGScriptElement) IfAttrs(condition bool, attrs ...string) *SVGScriptElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) AttrsMap(attrs map[string]string) *SVGScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGScriptElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGScriptElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGScriptElement) Text(text string) *SVGScriptElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGScriptElement) Textf(format string, args ...any) *SVGScriptElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGScriptElement) IfText(condition bool, text string) *SVGScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +211,28 @@ func (e *SVGScriptElement) IfText(condition bool, text string) *SVGScriptElement
 
 func (e *SVGScriptElement) IfTextf(condition bool, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGScriptElement) Raw(text string) *SVGScriptElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGScriptElement) Escaped(text string) *SVGScriptElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGScriptElement) IfEscaped(condition bool, text string) *SVGScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +244,7 @@ func (e *SVGScriptElement) Escapedf(format string, args ...any) *SVGScriptElemen
 
 func (e *SVGScriptElement) IfEscapedf(condition bool, format string, args ...any) *SVGScriptElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +252,8 @@ func (e *SVGScriptElement) IfEscapedf(condition bool, format string, args ...any
 
 // The scripting language used for the given script element.
 func (e *SVGScriptElement) Type(s string) *SVGScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -224,7 +265,7 @@ func (e *SVGScriptElement) Typef(format string, args ...any) *SVGScriptElement {
 // The scripting language used for the given script element.
 func (e *SVGScriptElement) IfType(condition bool, s string) *SVGScriptElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -232,7 +273,7 @@ func (e *SVGScriptElement) IfType(condition bool, s string) *SVGScriptElement {
 // The scripting language used for the given script element.
 func (e *SVGScriptElement) IfTypef(condition bool, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,20 +281,16 @@ func (e *SVGScriptElement) IfTypef(condition bool, format string, args ...any) *
 // The scripting language used for the given script element.
 // Remove the attribute Type from the element.
 func (e *SVGScriptElement) TypeRemove() *SVGScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // A Uniform Resource Identifier (URI) reference that specifies the location of
 // the script to execute.
 func (e *SVGScriptElement) Href(s string) *SVGScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -267,7 +304,7 @@ func (e *SVGScriptElement) Hreff(format string, args ...any) *SVGScriptElement {
 // the script to execute.
 func (e *SVGScriptElement) IfHref(condition bool, s string) *SVGScriptElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -276,7 +313,7 @@ func (e *SVGScriptElement) IfHref(condition bool, s string) *SVGScriptElement {
 // the script to execute.
 func (e *SVGScriptElement) IfHreff(condition bool, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -285,19 +322,15 @@ func (e *SVGScriptElement) IfHreff(condition bool, format string, args ...any) *
 // the script to execute.
 // Remove the attribute Href from the element.
 func (e *SVGScriptElement) HrefRemove() *SVGScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // How the element handles crossorigin requests.
 func (e *SVGScriptElement) Crossorigin(c SVGScriptCrossoriginChoice) *SVGScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("crossorigin", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("crossorigin", string(c), choiceValue[SVGScriptCrossoriginChoice])
 	return e
 }
 
@@ -313,19 +346,15 @@ const (
 // How the element handles crossorigin requests.
 // Remove the attribute Crossorigin from the element.
 func (e *SVGScriptElement) CrossoriginRemove() *SVGScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("crossorigin")
+	e = e.writable()
+	e.removeAttribute("crossorigin")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGScriptElement) ID(s string) *SVGScriptElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -337,7 +366,7 @@ func (e *SVGScriptElement) IDf(format string, args ...any) *SVGScriptElement {
 // Specifies a unique id for an element
 func (e *SVGScriptElement) IfID(condition bool, s string) *SVGScriptElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -345,7 +374,7 @@ func (e *SVGScriptElement) IfID(condition bool, s string) *SVGScriptElement {
 // Specifies a unique id for an element
 func (e *SVGScriptElement) IfIDf(condition bool, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -353,26 +382,17 @@ func (e *SVGScriptElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGScriptElement) IDRemove() *SVGScriptElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGScriptElement) Class(s string) *SVGScriptElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -380,7 +400,7 @@ func (e *SVGScriptElement) Class(s string) *SVGScriptElement {
 // sheet)
 func (e *SVGScriptElement) IfClass(condition bool, s string) *SVGScriptElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -389,14 +409,8 @@ func (e *SVGScriptElement) IfClass(condition bool, s string) *SVGScriptElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGScriptElement) ClassRemove(s ...string) *SVGScriptElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -405,14 +419,8 @@ func (e *SVGScriptElement) StylePairs(pairs ...string) *SVGScriptElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -426,14 +434,8 @@ func (e *SVGScriptElement) StylePairs(pairs ...string) *SVGScriptElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGScriptElement) Style(s string) *SVGScriptElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -441,7 +443,7 @@ func (e *SVGScriptElement) Style(s string) *SVGScriptElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -449,22 +451,14 @@ func (e *SVGScriptElement) Style(s string) *SVGScriptElement {
 // Specifies an inline CSS style for an element
 func (e *SVGScriptElement) IfStyle(condition bool, s string) *SVGScriptElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGScriptElement) StyleAdd(k string, v string) *SVGScriptElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -476,7 +470,7 @@ func (e *SVGScriptElement) StyleAddf(k string, format string, args ...any) *SVGS
 // Specifies an inline CSS style for an element
 func (e *SVGScriptElement) IfStyleAdd(condition bool, k string, v string) *SVGScriptElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -484,7 +478,7 @@ func (e *SVGScriptElement) IfStyleAdd(condition bool, k string, v string) *SVGSc
 // Specifies an inline CSS style for an element
 func (e *SVGScriptElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGScriptElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -492,21 +486,15 @@ func (e *SVGScriptElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGScriptElement) StyleMap(m map[string]string) *SVGScriptElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -514,13 +502,7 @@ func (e *SVGScriptElement) StyleMap(m map[string]string) *SVGScriptElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGScriptElement) StyleRemove(keys ...string) *SVGScriptElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
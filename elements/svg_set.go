@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <set> SVG element provides a simple means of just setting the value of an
@@ -25,18 +23,63 @@ type SVGSetElement struct {
 // with the tag "set" during rendering.
 func SVGSet(children ...ElementRenderer) *SVGSetElement {
 	e := NewElement("set", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGSetElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGSetElement) Clone() *SVGSetElement {
+	return &SVGSetElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGSetElement) CloneShallow() *SVGSetElement {
+	return &SVGSetElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGSetElement) Freeze() *SVGSetElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGSetElement) writable() *SVGSetElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGSetElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGSetElement) choiceType(name string) func(string) any {
+	switch name {
+	case "AttributeType":
+		return choiceValue[SVGSetAttributeTypeChoice]
+	case "restart":
+		return choiceValue[SVGSetRestartChoice]
+	case "fill":
+		return choiceValue[SVGSetFillChoice]
+	}
+	return nil
+}
+
 func (e *SVGSetElement) Children(children ...ElementRenderer) *SVGSetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGSetElement) IfChildren(condition bool, children ...ElementRenderer) *SVGSetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -44,32 +87,30 @@ func (e *SVGSetElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGSetElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGSetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGSetElement) BoolAttr(name string) *SVGSetElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGSetElement) BoolAttrRemove(name string) *SVGSetElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGSetElement) IfBoolAttr(condition bool, name string) *SVGSetElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -80,36 +121,40 @@ func (e *SVGSetElement) BoolAttrf(format string, args ...any) *SVGSetElement {
 
 func (e *SVGSetElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGSetElement) BoolAttrs(names ...string) *SVGSetElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGSetElement) IfBoolAttrs(condition bool, names ...string) *SVGSetElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGSetElement) Attr(name, value string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGSetElement) RawAttr(name, value string) *SVGSetElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGSetElement) IfAttr(condition bool, name, value string) *SVGSetElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -120,61 +165,52 @@ func (e *SVGSetElement) Attrf(name, format string, args ...any) *SVGSetElement {
 
 func (e *SVGSetElement) IfAttrf(condition bool, name, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGSetElement) Attrs(attrs ...string) *SVGSetElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGSetElement) IfAttrs(condition bool, attrs ...string) *SVGSetElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGSetElement) AttrsMap(attrs map[string]string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGSetElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGSetElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGSetElement) Text(text string) *SVGSetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGSetElement) Textf(format string, args ...any) *SVGSetElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGSetElement) IfText(condition bool, text string) *SVGSetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -182,18 +218,28 @@ func (e *SVGSetElement) IfText(condition bool, text string) *SVGSetElement {
 
 func (e *SVGSetElement) IfTextf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGSetElement) Raw(text string) *SVGSetElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGSetElement) Escaped(text string) *SVGSetElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGSetElement) IfEscaped(condition bool, text string) *SVGSetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -205,6 +251,7 @@ func (e *SVGSetElement) Escapedf(format string, args ...any) *SVGSetElement {
 
 func (e *SVGSetElement) IfEscapedf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -212,10 +259,8 @@ func (e *SVGSetElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // The target Attribute value to assign on end.
 func (e *SVGSetElement) To(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("to", s)
+	e = e.writable()
+	e.setStringAttribute("to", s)
 	return e
 }
 
@@ -227,7 +272,7 @@ func (e *SVGSetElement) Tof(format string, args ...any) *SVGSetElement {
 // The target Attribute value to assign on end.
 func (e *SVGSetElement) IfTo(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.To(s)
+		e = e.To(s)
 	}
 	return e
 }
@@ -235,7 +280,7 @@ func (e *SVGSetElement) IfTo(condition bool, s string) *SVGSetElement {
 // The target Attribute value to assign on end.
 func (e *SVGSetElement) IfTof(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.To(fmt.Sprintf(format, args...))
+		e = e.To(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -243,19 +288,15 @@ func (e *SVGSetElement) IfTof(condition bool, format string, args ...any) *SVGSe
 // The target Attribute value to assign on end.
 // Remove the attribute To from the element.
 func (e *SVGSetElement) ToRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("to")
+	e = e.writable()
+	e.removeAttribute("to")
 	return e
 }
 
 // The name of the Attribute to assign.
 func (e *SVGSetElement) AttributeName(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("AttributeName", s)
+	e = e.writable()
+	e.setStringAttribute("AttributeName", s)
 	return e
 }
 
@@ -267,7 +308,7 @@ func (e *SVGSetElement) AttributeNamef(format string, args ...any) *SVGSetElemen
 // The name of the Attribute to assign.
 func (e *SVGSetElement) IfAttributeName(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.AttributeName(s)
+		e = e.AttributeName(s)
 	}
 	return e
 }
@@ -275,7 +316,7 @@ func (e *SVGSetElement) IfAttributeName(condition bool, s string) *SVGSetElement
 // The name of the Attribute to assign.
 func (e *SVGSetElement) IfAttributeNamef(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.AttributeName(fmt.Sprintf(format, args...))
+		e = e.AttributeName(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -283,20 +324,16 @@ func (e *SVGSetElement) IfAttributeNamef(condition bool, format string, args ...
 // The name of the Attribute to assign.
 // Remove the attribute AttributeName from the element.
 func (e *SVGSetElement) AttributeNameRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("AttributeName")
+	e = e.writable()
+	e.removeAttribute("AttributeName")
 	return e
 }
 
 // The namespace in which the target Attribute and its associated values are
 // defined.
 func (e *SVGSetElement) AttributeType(c SVGSetAttributeTypeChoice) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("AttributeType", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("AttributeType", string(c), choiceValue[SVGSetAttributeTypeChoice])
 	return e
 }
 
@@ -324,19 +361,15 @@ const (
 // defined.
 // Remove the attribute AttributeType from the element.
 func (e *SVGSetElement) AttributeTypeRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("AttributeType")
+	e = e.writable()
+	e.removeAttribute("AttributeType")
 	return e
 }
 
 // The begin time for the element.
 func (e *SVGSetElement) Begin(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("begin", s)
+	e = e.writable()
+	e.setStringAttribute("begin", s)
 	return e
 }
 
@@ -348,7 +381,7 @@ func (e *SVGSetElement) Beginf(format string, args ...any) *SVGSetElement {
 // The begin time for the element.
 func (e *SVGSetElement) IfBegin(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.Begin(s)
+		e = e.Begin(s)
 	}
 	return e
 }
@@ -356,7 +389,7 @@ func (e *SVGSetElement) IfBegin(condition bool, s string) *SVGSetElement {
 // The begin time for the element.
 func (e *SVGSetElement) IfBeginf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.Begin(fmt.Sprintf(format, args...))
+		e = e.Begin(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -364,19 +397,15 @@ func (e *SVGSetElement) IfBeginf(condition bool, format string, args ...any) *SV
 // The begin time for the element.
 // Remove the attribute Begin from the element.
 func (e *SVGSetElement) BeginRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("begin")
+	e = e.writable()
+	e.removeAttribute("begin")
 	return e
 }
 
 // The simple duration for the element.
 func (e *SVGSetElement) Dur(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("dur", s)
+	e = e.writable()
+	e.setStringAttribute("dur", s)
 	return e
 }
 
@@ -388,7 +417,7 @@ func (e *SVGSetElement) Durf(format string, args ...any) *SVGSetElement {
 // The simple duration for the element.
 func (e *SVGSetElement) IfDur(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.Dur(s)
+		e = e.Dur(s)
 	}
 	return e
 }
@@ -396,7 +425,7 @@ func (e *SVGSetElement) IfDur(condition bool, s string) *SVGSetElement {
 // The simple duration for the element.
 func (e *SVGSetElement) IfDurf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.Dur(fmt.Sprintf(format, args...))
+		e = e.Dur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -404,19 +433,15 @@ func (e *SVGSetElement) IfDurf(condition bool, format string, args ...any) *SVGS
 // The simple duration for the element.
 // Remove the attribute Dur from the element.
 func (e *SVGSetElement) DurRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("dur")
+	e = e.writable()
+	e.removeAttribute("dur")
 	return e
 }
 
 // The end for the element.
 func (e *SVGSetElement) End(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("end", s)
+	e = e.writable()
+	e.setStringAttribute("end", s)
 	return e
 }
 
@@ -428,7 +453,7 @@ func (e *SVGSetElement) Endf(format string, args ...any) *SVGSetElement {
 // The end for the element.
 func (e *SVGSetElement) IfEnd(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.End(s)
+		e = e.End(s)
 	}
 	return e
 }
@@ -436,7 +461,7 @@ func (e *SVGSetElement) IfEnd(condition bool, s string) *SVGSetElement {
 // The end for the element.
 func (e *SVGSetElement) IfEndf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.End(fmt.Sprintf(format, args...))
+		e = e.End(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -444,19 +469,15 @@ func (e *SVGSetElement) IfEndf(condition bool, format string, args ...any) *SVGS
 // The end for the element.
 // Remove the attribute End from the element.
 func (e *SVGSetElement) EndRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("end")
+	e = e.writable()
+	e.removeAttribute("end")
 	return e
 }
 
 // The minimum value allowed for the Attribute.
 func (e *SVGSetElement) Min(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("min", s)
+	e = e.writable()
+	e.setStringAttribute("min", s)
 	return e
 }
 
@@ -468,7 +489,7 @@ func (e *SVGSetElement) Minf(format string, args ...any) *SVGSetElement {
 // The minimum value allowed for the Attribute.
 func (e *SVGSetElement) IfMin(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.Min(s)
+		e = e.Min(s)
 	}
 	return e
 }
@@ -476,7 +497,7 @@ func (e *SVGSetElement) IfMin(condition bool, s string) *SVGSetElement {
 // The minimum value allowed for the Attribute.
 func (e *SVGSetElement) IfMinf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.Min(fmt.Sprintf(format, args...))
+		e = e.Min(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -484,19 +505,15 @@ func (e *SVGSetElement) IfMinf(condition bool, format string, args ...any) *SVGS
 // The minimum value allowed for the Attribute.
 // Remove the attribute Min from the element.
 func (e *SVGSetElement) MinRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("min")
+	e = e.writable()
+	e.removeAttribute("min")
 	return e
 }
 
 // The maximum value allowed for the Attribute.
 func (e *SVGSetElement) Max(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("max", s)
+	e = e.writable()
+	e.setStringAttribute("max", s)
 	return e
 }
 
@@ -508,7 +525,7 @@ func (e *SVGSetElement) Maxf(format string, args ...any) *SVGSetElement {
 // The maximum value allowed for the Attribute.
 func (e *SVGSetElement) IfMax(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.Max(s)
+		e = e.Max(s)
 	}
 	return e
 }
@@ -516,7 +533,7 @@ func (e *SVGSetElement) IfMax(condition bool, s string) *SVGSetElement {
 // The maximum value allowed for the Attribute.
 func (e *SVGSetElement) IfMaxf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.Max(fmt.Sprintf(format, args...))
+		e = e.Max(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -524,19 +541,15 @@ func (e *SVGSetElement) IfMaxf(condition bool, format string, args ...any) *SVGS
 // The maximum value allowed for the Attribute.
 // Remove the attribute Max from the element.
 func (e *SVGSetElement) MaxRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("max")
+	e = e.writable()
+	e.removeAttribute("max")
 	return e
 }
 
 // Defines how the element is restarted.
 func (e *SVGSetElement) Restart(c SVGSetRestartChoice) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("restart", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("restart", string(c), choiceValue[SVGSetRestartChoice])
 	return e
 }
 
@@ -554,19 +567,15 @@ const (
 // Defines how the element is restarted.
 // Remove the attribute Restart from the element.
 func (e *SVGSetElement) RestartRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("restart")
+	e = e.writable()
+	e.removeAttribute("restart")
 	return e
 }
 
 // Defines the number of times the element is repeated.
 func (e *SVGSetElement) RepeatCount(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatCount", s)
+	e = e.writable()
+	e.setStringAttribute("repeatCount", s)
 	return e
 }
 
@@ -578,7 +587,7 @@ func (e *SVGSetElement) RepeatCountf(format string, args ...any) *SVGSetElement
 // Defines the number of times the element is repeated.
 func (e *SVGSetElement) IfRepeatCount(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.RepeatCount(s)
+		e = e.RepeatCount(s)
 	}
 	return e
 }
@@ -586,7 +595,7 @@ func (e *SVGSetElement) IfRepeatCount(condition bool, s string) *SVGSetElement {
 // Defines the number of times the element is repeated.
 func (e *SVGSetElement) IfRepeatCountf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.RepeatCount(fmt.Sprintf(format, args...))
+		e = e.RepeatCount(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -594,19 +603,15 @@ func (e *SVGSetElement) IfRepeatCountf(condition bool, format string, args ...an
 // Defines the number of times the element is repeated.
 // Remove the attribute RepeatCount from the element.
 func (e *SVGSetElement) RepeatCountRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatCount")
+	e = e.writable()
+	e.removeAttribute("repeatCount")
 	return e
 }
 
 // Defines the duration for the element to repeat.
 func (e *SVGSetElement) RepeatDur(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("repeatDur", s)
+	e = e.writable()
+	e.setStringAttribute("repeatDur", s)
 	return e
 }
 
@@ -618,7 +623,7 @@ func (e *SVGSetElement) RepeatDurf(format string, args ...any) *SVGSetElement {
 // Defines the duration for the element to repeat.
 func (e *SVGSetElement) IfRepeatDur(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.RepeatDur(s)
+		e = e.RepeatDur(s)
 	}
 	return e
 }
@@ -626,7 +631,7 @@ func (e *SVGSetElement) IfRepeatDur(condition bool, s string) *SVGSetElement {
 // Defines the duration for the element to repeat.
 func (e *SVGSetElement) IfRepeatDurf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.RepeatDur(fmt.Sprintf(format, args...))
+		e = e.RepeatDur(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -634,19 +639,15 @@ func (e *SVGSetElement) IfRepeatDurf(condition bool, format string, args ...any)
 // Defines the duration for the element to repeat.
 // Remove the attribute RepeatDur from the element.
 func (e *SVGSetElement) RepeatDurRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("repeatDur")
+	e = e.writable()
+	e.removeAttribute("repeatDur")
 	return e
 }
 
 // Defines the value the animation will have before the begin event.
 func (e *SVGSetElement) Fill(c SVGSetFillChoice) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("fill", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("fill", string(c), choiceValue[SVGSetFillChoice])
 	return e
 }
 
@@ -662,19 +663,15 @@ const (
 // Defines the value the animation will have before the begin event.
 // Remove the attribute Fill from the element.
 func (e *SVGSetElement) FillRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("fill")
+	e = e.writable()
+	e.removeAttribute("fill")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGSetElement) ID(s string) *SVGSetElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -686,7 +683,7 @@ func (e *SVGSetElement) IDf(format string, args ...any) *SVGSetElement {
 // Specifies a unique id for an element
 func (e *SVGSetElement) IfID(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -694,7 +691,7 @@ func (e *SVGSetElement) IfID(condition bool, s string) *SVGSetElement {
 // Specifies a unique id for an element
 func (e *SVGSetElement) IfIDf(condition bool, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -702,26 +699,17 @@ func (e *SVGSetElement) IfIDf(condition bool, format string, args ...any) *SVGSe
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGSetElement) IDRemove() *SVGSetElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGSetElement) Class(s string) *SVGSetElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -729,7 +717,7 @@ func (e *SVGSetElement) Class(s string) *SVGSetElement {
 // sheet)
 func (e *SVGSetElement) IfClass(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -738,14 +726,8 @@ func (e *SVGSetElement) IfClass(condition bool, s string) *SVGSetElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGSetElement) ClassRemove(s ...string) *SVGSetElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -754,14 +736,8 @@ func (e *SVGSetElement) StylePairs(pairs ...string) *SVGSetElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -775,14 +751,8 @@ func (e *SVGSetElement) StylePairs(pairs ...string) *SVGSetElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGSetElement) Style(s string) *SVGSetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -790,7 +760,7 @@ func (e *SVGSetElement) Style(s string) *SVGSetElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -798,22 +768,14 @@ func (e *SVGSetElement) Style(s string) *SVGSetElement {
 // Specifies an inline CSS style for an element
 func (e *SVGSetElement) IfStyle(condition bool, s string) *SVGSetElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGSetElement) StyleAdd(k string, v string) *SVGSetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -825,7 +787,7 @@ func (e *SVGSetElement) StyleAddf(k string, format string, args ...any) *SVGSetE
 // Specifies an inline CSS style for an element
 func (e *SVGSetElement) IfStyleAdd(condition bool, k string, v string) *SVGSetElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -833,7 +795,7 @@ func (e *SVGSetElement) IfStyleAdd(condition bool, k string, v string) *SVGSetEl
 // Specifies an inline CSS style for an element
 func (e *SVGSetElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGSetElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -841,21 +803,15 @@ func (e *SVGSetElement) IfStyleAddf(condition bool, k string, format string, arg
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGSetElement) StyleMap(m map[string]string) *SVGSetElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -863,13 +819,7 @@ func (e *SVGSetElement) StyleMap(m map[string]string) *SVGSetElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGSetElement) StyleRemove(keys ...string) *SVGSetElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
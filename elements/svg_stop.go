@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <stop> SVG element defines the ramp of colors to use on a gradient, which
@@ -23,18 +21,49 @@ type SVGStopElement struct {
 // with the tag "stop" during rendering.
 func SVGStop(children ...ElementRenderer) *SVGStopElement {
 	e := NewElement("stop", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGStopElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGStopElement) Clone() *SVGStopElement {
+	return &SVGStopElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGStopElement) CloneShallow() *SVGStopElement {
+	return &SVGStopElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGStopElement) Freeze() *SVGStopElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGStopElement) writable() *SVGStopElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGStopElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGStopElement) Children(children ...ElementRenderer) *SVGStopElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGStopElement) IfChildren(condition bool, children ...ElementRenderer) *SVGStopElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGStopElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGStopElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGStopElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGStopElement) BoolAttr(name string) *SVGStopElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGStopElement) BoolAttrRemove(name string) *SVGStopElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGStopElement) IfBoolAttr(condition bool, name string) *SVGStopElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGStopElement) BoolAttrf(format string, args ...any) *SVGStopElement {
 
 func (e *SVGStopElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGStopElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGStopElement) BoolAttrs(names ...string) *SVGStopElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGStopElement) IfBoolAttrs(condition bool, names ...string) *SVGStopElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGStopElement) Attr(name, value string) *SVGStopElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGStopElement) RawAttr(name, value string) *SVGStopElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGStopElement) IfAttr(condition bool, name, value string) *SVGStopElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGStopElement) Attrf(name, format string, args ...any) *SVGStopElement
 
 func (e *SVGStopElement) IfAttrf(condition bool, name, format string, args ...any) *SVGStopElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGStopElement) Attrs(attrs ...string) *SVGStopElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGStopElement) IfAttrs(condition bool, attrs ...string) *SVGStopElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGStopElement) AttrsMap(attrs map[string]string) *SVGStopElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGStopElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGStopElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGStopElement) Text(text string) *SVGStopElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGStopElement) Textf(format string, args ...any) *SVGStopElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGStopElement) IfText(condition bool, text string) *SVGStopElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGStopElement) IfText(condition bool, text string) *SVGStopElement {
 
 func (e *SVGStopElement) IfTextf(condition bool, format string, args ...any) *SVGStopElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGStopElement) Raw(text string) *SVGStopElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGStopElement) Escaped(text string) *SVGStopElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGStopElement) IfEscaped(condition bool, text string) *SVGStopElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGStopElement) Escapedf(format string, args ...any) *SVGStopElement {
 
 func (e *SVGStopElement) IfEscapedf(condition bool, format string, args ...any) *SVGStopElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,27 +243,23 @@ func (e *SVGStopElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The offset from the start of the gradient where the color first takes effect.
 func (e *SVGStopElement) Offset(f float64) *SVGStopElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("offset", f)
+	e = e.writable()
+	e.setFloatAttribute("offset", f, 0)
 	return e
 }
 
 // The offset from the start of the gradient where the color first takes effect.
 func (e *SVGStopElement) IfOffset(condition bool, f float64) *SVGStopElement {
 	if condition {
-		e.Offset(f)
+		e = e.Offset(f)
 	}
 	return e
 }
 
 // The color of the gradient stop.
 func (e *SVGStopElement) StopColor(s string) *SVGStopElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("stop-color", s)
+	e = e.writable()
+	e.setStringAttribute("stop-color", s)
 	return e
 }
 
@@ -242,7 +271,7 @@ func (e *SVGStopElement) StopColorf(format string, args ...any) *SVGStopElement
 // The color of the gradient stop.
 func (e *SVGStopElement) IfStopColor(condition bool, s string) *SVGStopElement {
 	if condition {
-		e.StopColor(s)
+		e = e.StopColor(s)
 	}
 	return e
 }
@@ -250,7 +279,7 @@ func (e *SVGStopElement) IfStopColor(condition bool, s string) *SVGStopElement {
 // The color of the gradient stop.
 func (e *SVGStopElement) IfStopColorf(condition bool, format string, args ...any) *SVGStopElement {
 	if condition {
-		e.StopColor(fmt.Sprintf(format, args...))
+		e = e.StopColor(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -258,19 +287,15 @@ func (e *SVGStopElement) IfStopColorf(condition bool, format string, args ...any
 // The color of the gradient stop.
 // Remove the attribute StopColor from the element.
 func (e *SVGStopElement) StopColorRemove() *SVGStopElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("stop-color")
+	e = e.writable()
+	e.removeAttribute("stop-color")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGStopElement) ID(s string) *SVGStopElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -282,7 +307,7 @@ func (e *SVGStopElement) IDf(format string, args ...any) *SVGStopElement {
 // Specifies a unique id for an element
 func (e *SVGStopElement) IfID(condition bool, s string) *SVGStopElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -290,7 +315,7 @@ func (e *SVGStopElement) IfID(condition bool, s string) *SVGStopElement {
 // Specifies a unique id for an element
 func (e *SVGStopElement) IfIDf(condition bool, format string, args ...any) *SVGStopElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -298,26 +323,17 @@ func (e *SVGStopElement) IfIDf(condition bool, format string, args ...any) *SVGS
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGStopElement) IDRemove() *SVGStopElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGStopElement) Class(s string) *SVGStopElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -325,7 +341,7 @@ func (e *SVGStopElement) Class(s string) *SVGStopElement {
 // sheet)
 func (e *SVGStopElement) IfClass(condition bool, s string) *SVGStopElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -334,14 +350,8 @@ func (e *SVGStopElement) IfClass(condition bool, s string) *SVGStopElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGStopElement) ClassRemove(s ...string) *SVGStopElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -350,14 +360,8 @@ func (e *SVGStopElement) StylePairs(pairs ...string) *SVGStopElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -371,14 +375,8 @@ func (e *SVGStopElement) StylePairs(pairs ...string) *SVGStopElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGStopElement) Style(s string) *SVGStopElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -386,7 +384,7 @@ func (e *SVGStopElement) Style(s string) *SVGStopElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -394,22 +392,14 @@ func (e *SVGStopElement) Style(s string) *SVGStopElement {
 // Specifies an inline CSS style for an element
 func (e *SVGStopElement) IfStyle(condition bool, s string) *SVGStopElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGStopElement) StyleAdd(k string, v string) *SVGStopElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -421,7 +411,7 @@ func (e *SVGStopElement) StyleAddf(k string, format string, args ...any) *SVGSto
 // Specifies an inline CSS style for an element
 func (e *SVGStopElement) IfStyleAdd(condition bool, k string, v string) *SVGStopElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -429,7 +419,7 @@ func (e *SVGStopElement) IfStyleAdd(condition bool, k string, v string) *SVGStop
 // Specifies an inline CSS style for an element
 func (e *SVGStopElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGStopElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -437,21 +427,15 @@ func (e *SVGStopElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGStopElement) StyleMap(m map[string]string) *SVGStopElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -459,13 +443,7 @@ func (e *SVGStopElement) StyleMap(m map[string]string) *SVGStopElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGStopElement) StyleRemove(keys ...string) *SVGStopElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
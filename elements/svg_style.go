@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <style> SVG element allows style sheets to be embedded directly within
@@ -23,18 +21,49 @@ type SVGStyleElement struct {
 // with the tag "style" during rendering.
 func SVGStyle(children ...ElementRenderer) *SVGStyleElement {
 	e := NewElement("style", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGStyleElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGStyleElement) Clone() *SVGStyleElement {
+	return &SVGStyleElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGStyleElement) CloneShallow() *SVGStyleElement {
+	return &SVGStyleElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGStyleElement) Freeze() *SVGStyleElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGStyleElement) writable() *SVGStyleElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGStyleElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGStyleElement) Children(children ...ElementRenderer) *SVGStyleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGStyleElement) IfChildren(condition bool, children ...ElementRenderer) *SVGStyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +71,30 @@ func (e *SVGStyleElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *SVGStyleElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGStyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) BoolAttr(name string) *SVGStyleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGStyleElement) BoolAttrRemove(name string) *SVGStyleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGStyleElement) IfBoolAttr(condition bool, name string) *SVGStyleElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +105,40 @@ func (e *SVGStyleElement) BoolAttrf(format string, args ...any) *SVGStyleElement
 
 func (e *SVGStyleElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) BoolAttrs(names ...string) *SVGStyleElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) IfBoolAttrs(condition bool, names ...string) *SVGStyleElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) Attr(name, value string) *SVGStyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGStyleElement) RawAttr(name, value string) *SVGStyleElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGStyleElement) IfAttr(condition bool, name, value string) *SVGStyleElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +149,52 @@ func (e *SVGStyleElement) Attrf(name, format string, args ...any) *SVGStyleEleme
 
 func (e *SVGStyleElement) IfAttrf(condition bool, name, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) Attrs(attrs ...string) *SVGStyleElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGStyleElement) IfAttrs(condition bool, attrs ...string) *SVGStyleElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) AttrsMap(attrs map[string]string) *SVGStyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGStyleElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGStyleElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGStyleElement) Text(text string) *SVGStyleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGStyleElement) Textf(format string, args ...any) *SVGStyleElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGStyleElement) IfText(condition bool, text string) *SVGStyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +202,28 @@ func (e *SVGStyleElement) IfText(condition bool, text string) *SVGStyleElement {
 
 func (e *SVGStyleElement) IfTextf(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGStyleElement) Raw(text string) *SVGStyleElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGStyleElement) Escaped(text string) *SVGStyleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGStyleElement) IfEscaped(condition bool, text string) *SVGStyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +235,7 @@ func (e *SVGStyleElement) Escapedf(format string, args ...any) *SVGStyleElement
 
 func (e *SVGStyleElement) IfEscapedf(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +243,8 @@ func (e *SVGStyleElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The style sheet language.
 func (e *SVGStyleElement) Type(s string) *SVGStyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("type", s)
+	e = e.writable()
+	e.setStringAttribute("type", s)
 	return e
 }
 
@@ -225,7 +256,7 @@ func (e *SVGStyleElement) Typef(format string, args ...any) *SVGStyleElement {
 // The style sheet language.
 func (e *SVGStyleElement) IfType(condition bool, s string) *SVGStyleElement {
 	if condition {
-		e.Type(s)
+		e = e.Type(s)
 	}
 	return e
 }
@@ -233,7 +264,7 @@ func (e *SVGStyleElement) IfType(condition bool, s string) *SVGStyleElement {
 // The style sheet language.
 func (e *SVGStyleElement) IfTypef(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.Type(fmt.Sprintf(format, args...))
+		e = e.Type(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -241,19 +272,15 @@ func (e *SVGStyleElement) IfTypef(condition bool, format string, args ...any) *S
 // The style sheet language.
 // Remove the attribute Type from the element.
 func (e *SVGStyleElement) TypeRemove() *SVGStyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("type")
+	e = e.writable()
+	e.removeAttribute("type")
 	return e
 }
 
 // The intended destination medium for style information.
 func (e *SVGStyleElement) Media(s string) *SVGStyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("media", s)
+	e = e.writable()
+	e.setStringAttribute("media", s)
 	return e
 }
 
@@ -265,7 +292,7 @@ func (e *SVGStyleElement) Mediaf(format string, args ...any) *SVGStyleElement {
 // The intended destination medium for style information.
 func (e *SVGStyleElement) IfMedia(condition bool, s string) *SVGStyleElement {
 	if condition {
-		e.Media(s)
+		e = e.Media(s)
 	}
 	return e
 }
@@ -273,7 +300,7 @@ func (e *SVGStyleElement) IfMedia(condition bool, s string) *SVGStyleElement {
 // The intended destination medium for style information.
 func (e *SVGStyleElement) IfMediaf(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.Media(fmt.Sprintf(format, args...))
+		e = e.Media(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -281,19 +308,15 @@ func (e *SVGStyleElement) IfMediaf(condition bool, format string, args ...any) *
 // The intended destination medium for style information.
 // Remove the attribute Media from the element.
 func (e *SVGStyleElement) MediaRemove() *SVGStyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("media")
+	e = e.writable()
+	e.removeAttribute("media")
 	return e
 }
 
 // The advisory title.
 func (e *SVGStyleElement) Title(s string) *SVGStyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("title", s)
+	e = e.writable()
+	e.setStringAttribute("title", s)
 	return e
 }
 
@@ -305,7 +328,7 @@ func (e *SVGStyleElement) Titlef(format string, args ...any) *SVGStyleElement {
 // The advisory title.
 func (e *SVGStyleElement) IfTitle(condition bool, s string) *SVGStyleElement {
 	if condition {
-		e.Title(s)
+		e = e.Title(s)
 	}
 	return e
 }
@@ -313,7 +336,7 @@ func (e *SVGStyleElement) IfTitle(condition bool, s string) *SVGStyleElement {
 // The advisory title.
 func (e *SVGStyleElement) IfTitlef(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.Title(fmt.Sprintf(format, args...))
+		e = e.Title(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -321,19 +344,15 @@ func (e *SVGStyleElement) IfTitlef(condition bool, format string, args ...any) *
 // The advisory title.
 // Remove the attribute Title from the element.
 func (e *SVGStyleElement) TitleRemove() *SVGStyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("title")
+	e = e.writable()
+	e.removeAttribute("title")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGStyleElement) ID(s string) *SVGStyleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -345,7 +364,7 @@ func (e *SVGStyleElement) IDf(format string, args ...any) *SVGStyleElement {
 // Specifies a unique id for an element
 func (e *SVGStyleElement) IfID(condition bool, s string) *SVGStyleElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -353,7 +372,7 @@ func (e *SVGStyleElement) IfID(condition bool, s string) *SVGStyleElement {
 // Specifies a unique id for an element
 func (e *SVGStyleElement) IfIDf(condition bool, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -361,26 +380,17 @@ func (e *SVGStyleElement) IfIDf(condition bool, format string, args ...any) *SVG
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGStyleElement) IDRemove() *SVGStyleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGStyleElement) Class(s string) *SVGStyleElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -388,7 +398,7 @@ func (e *SVGStyleElement) Class(s string) *SVGStyleElement {
 // sheet)
 func (e *SVGStyleElement) IfClass(condition bool, s string) *SVGStyleElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -397,14 +407,8 @@ func (e *SVGStyleElement) IfClass(condition bool, s string) *SVGStyleElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGStyleElement) ClassRemove(s ...string) *SVGStyleElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -413,14 +417,8 @@ func (e *SVGStyleElement) StylePairs(pairs ...string) *SVGStyleElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -434,14 +432,8 @@ func (e *SVGStyleElement) StylePairs(pairs ...string) *SVGStyleElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGStyleElement) Style(s string) *SVGStyleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -449,7 +441,7 @@ func (e *SVGStyleElement) Style(s string) *SVGStyleElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -457,22 +449,14 @@ func (e *SVGStyleElement) Style(s string) *SVGStyleElement {
 // Specifies an inline CSS style for an element
 func (e *SVGStyleElement) IfStyle(condition bool, s string) *SVGStyleElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGStyleElement) StyleAdd(k string, v string) *SVGStyleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -484,7 +468,7 @@ func (e *SVGStyleElement) StyleAddf(k string, format string, args ...any) *SVGSt
 // Specifies an inline CSS style for an element
 func (e *SVGStyleElement) IfStyleAdd(condition bool, k string, v string) *SVGStyleElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -492,7 +476,7 @@ func (e *SVGStyleElement) IfStyleAdd(condition bool, k string, v string) *SVGSty
 // Specifies an inline CSS style for an element
 func (e *SVGStyleElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGStyleElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -500,21 +484,15 @@ func (e *SVGStyleElement) IfStyleAddf(condition bool, k string, format string, a
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGStyleElement) StyleMap(m map[string]string) *SVGStyleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -522,13 +500,7 @@ func (e *SVGStyleElement) StyleMap(m map[string]string) *SVGStyleElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGStyleElement) StyleRemove(keys ...string) *SVGStyleElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <svg> element is a container that defines a new coordinate system and
@@ -23,18 +21,59 @@ type SVGSVGElement struct {
 // with the tag "svg" during rendering.
 func SVGSVG(children ...ElementRenderer) *SVGSVGElement {
 	e := NewElement("svg", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGSVGElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGSVGElement) Clone() *SVGSVGElement {
+	return &SVGSVGElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGSVGElement) CloneShallow() *SVGSVGElement {
+	return &SVGSVGElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGSVGElement) Freeze() *SVGSVGElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGSVGElement) writable() *SVGSVGElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGSVGElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGSVGElement) choiceType(name string) func(string) any {
+	switch name {
+	case "preserveAspectRatio":
+		return choiceValue[SVGSVGPreserveAspectRatioChoice]
+	}
+	return nil
+}
+
 func (e *SVGSVGElement) Children(children ...ElementRenderer) *SVGSVGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGSVGElement) IfChildren(condition bool, children ...ElementRenderer) *SVGSVGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGSVGElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGSVGElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGSVGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) BoolAttr(name string) *SVGSVGElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGSVGElement) BoolAttrRemove(name string) *SVGSVGElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGSVGElement) IfBoolAttr(condition bool, name string) *SVGSVGElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGSVGElement) BoolAttrf(format string, args ...any) *SVGSVGElement {
 
 func (e *SVGSVGElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) BoolAttrs(names ...string) *SVGSVGElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) IfBoolAttrs(condition bool, names ...string) *SVGSVGElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) Attr(name, value string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGSVGElement) RawAttr(name, value string) *SVGSVGElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGSVGElement) IfAttr(condition bool, name, value string) *SVGSVGElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGSVGElement) Attrf(name, format string, args ...any) *SVGSVGElement {
 
 func (e *SVGSVGElement) IfAttrf(condition bool, name, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) Attrs(attrs ...string) *SVGSVGElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGSVGElement) IfAttrs(condition bool, attrs ...string) *SVGSVGElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) AttrsMap(attrs map[string]string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGSVGElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGSVGElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGSVGElement) Text(text string) *SVGSVGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGSVGElement) Textf(format string, args ...any) *SVGSVGElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGSVGElement) IfText(condition bool, text string) *SVGSVGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGSVGElement) IfText(condition bool, text string) *SVGSVGElement {
 
 func (e *SVGSVGElement) IfTextf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGSVGElement) Raw(text string) *SVGSVGElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGSVGElement) Escaped(text string) *SVGSVGElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGSVGElement) IfEscaped(condition bool, text string) *SVGSVGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGSVGElement) Escapedf(format string, args ...any) *SVGSVGElement {
 
 func (e *SVGSVGElement) IfEscapedf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +254,8 @@ func (e *SVGSVGElement) IfEscapedf(condition bool, format string, args ...any) *
 // The x-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGSVGElement) X(s string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("x", s)
+	e = e.writable()
+	e.setStringAttribute("x", s)
 	return e
 }
 
@@ -228,7 +269,7 @@ func (e *SVGSVGElement) Xf(format string, args ...any) *SVGSVGElement {
 // to the user.
 func (e *SVGSVGElement) IfX(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.X(s)
+		e = e.X(s)
 	}
 	return e
 }
@@ -237,7 +278,7 @@ func (e *SVGSVGElement) IfX(condition bool, s string) *SVGSVGElement {
 // to the user.
 func (e *SVGSVGElement) IfXf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.X(fmt.Sprintf(format, args...))
+		e = e.X(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -246,20 +287,16 @@ func (e *SVGSVGElement) IfXf(condition bool, format string, args ...any) *SVGSVG
 // to the user.
 // Remove the attribute X from the element.
 func (e *SVGSVGElement) XRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("x")
+	e = e.writable()
+	e.removeAttribute("x")
 	return e
 }
 
 // The y-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGSVGElement) Y(s string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("y", s)
+	e = e.writable()
+	e.setStringAttribute("y", s)
 	return e
 }
 
@@ -273,7 +310,7 @@ func (e *SVGSVGElement) Yf(format string, args ...any) *SVGSVGElement {
 // to the user.
 func (e *SVGSVGElement) IfY(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.Y(s)
+		e = e.Y(s)
 	}
 	return e
 }
@@ -282,7 +319,7 @@ func (e *SVGSVGElement) IfY(condition bool, s string) *SVGSVGElement {
 // to the user.
 func (e *SVGSVGElement) IfYf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.Y(fmt.Sprintf(format, args...))
+		e = e.Y(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -291,19 +328,15 @@ func (e *SVGSVGElement) IfYf(condition bool, format string, args ...any) *SVGSVG
 // to the user.
 // Remove the attribute Y from the element.
 func (e *SVGSVGElement) YRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("y")
+	e = e.writable()
+	e.removeAttribute("y")
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGSVGElement) Width(s string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("width", s)
+	e = e.writable()
+	e.setStringAttribute("width", s)
 	return e
 }
 
@@ -315,7 +348,7 @@ func (e *SVGSVGElement) Widthf(format string, args ...any) *SVGSVGElement {
 // The width of the rectangular region.
 func (e *SVGSVGElement) IfWidth(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.Width(s)
+		e = e.Width(s)
 	}
 	return e
 }
@@ -323,7 +356,7 @@ func (e *SVGSVGElement) IfWidth(condition bool, s string) *SVGSVGElement {
 // The width of the rectangular region.
 func (e *SVGSVGElement) IfWidthf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.Width(fmt.Sprintf(format, args...))
+		e = e.Width(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -331,19 +364,15 @@ func (e *SVGSVGElement) IfWidthf(condition bool, format string, args ...any) *SV
 // The width of the rectangular region.
 // Remove the attribute Width from the element.
 func (e *SVGSVGElement) WidthRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("width")
+	e = e.writable()
+	e.removeAttribute("width")
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGSVGElement) Height(s string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("height", s)
+	e = e.writable()
+	e.setStringAttribute("height", s)
 	return e
 }
 
@@ -355,7 +384,7 @@ func (e *SVGSVGElement) Heightf(format string, args ...any) *SVGSVGElement {
 // The height of the rectangular region.
 func (e *SVGSVGElement) IfHeight(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.Height(s)
+		e = e.Height(s)
 	}
 	return e
 }
@@ -363,7 +392,7 @@ func (e *SVGSVGElement) IfHeight(condition bool, s string) *SVGSVGElement {
 // The height of the rectangular region.
 func (e *SVGSVGElement) IfHeightf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.Height(fmt.Sprintf(format, args...))
+		e = e.Height(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -371,20 +400,16 @@ func (e *SVGSVGElement) IfHeightf(condition bool, format string, args ...any) *S
 // The height of the rectangular region.
 // Remove the attribute Height from the element.
 func (e *SVGSVGElement) HeightRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("height")
+	e = e.writable()
+	e.removeAttribute("height")
 	return e
 }
 
 // The position and size of the viewport (the viewBox) is defined by the viewBox
 // Attribute.
 func (e *SVGSVGElement) ViewBox(s string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("viewBox", s)
+	e = e.writable()
+	e.setStringAttribute("viewBox", s)
 	return e
 }
 
@@ -398,7 +423,7 @@ func (e *SVGSVGElement) ViewBoxf(format string, args ...any) *SVGSVGElement {
 // Attribute.
 func (e *SVGSVGElement) IfViewBox(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.ViewBox(s)
+		e = e.ViewBox(s)
 	}
 	return e
 }
@@ -407,7 +432,7 @@ func (e *SVGSVGElement) IfViewBox(condition bool, s string) *SVGSVGElement {
 // Attribute.
 func (e *SVGSVGElement) IfViewBoxf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.ViewBox(fmt.Sprintf(format, args...))
+		e = e.ViewBox(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -416,20 +441,16 @@ func (e *SVGSVGElement) IfViewBoxf(condition bool, format string, args ...any) *
 // Attribute.
 // Remove the attribute ViewBox from the element.
 func (e *SVGSVGElement) ViewBoxRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("viewBox")
+	e = e.writable()
+	e.removeAttribute("viewBox")
 	return e
 }
 
 // Indicates how the viewport is fitted to the rectangle of the given width and
 // height.
 func (e *SVGSVGElement) PreserveAspectRatio(c SVGSVGPreserveAspectRatioChoice) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("preserveAspectRatio", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("preserveAspectRatio", string(c), choiceValue[SVGSVGPreserveAspectRatioChoice])
 	return e
 }
 
@@ -468,19 +489,15 @@ const (
 // height.
 // Remove the attribute PreserveAspectRatio from the element.
 func (e *SVGSVGElement) PreserveAspectRatioRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("preserveAspectRatio")
+	e = e.writable()
+	e.removeAttribute("preserveAspectRatio")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGSVGElement) ID(s string) *SVGSVGElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -492,7 +509,7 @@ func (e *SVGSVGElement) IDf(format string, args ...any) *SVGSVGElement {
 // Specifies a unique id for an element
 func (e *SVGSVGElement) IfID(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -500,7 +517,7 @@ func (e *SVGSVGElement) IfID(condition bool, s string) *SVGSVGElement {
 // Specifies a unique id for an element
 func (e *SVGSVGElement) IfIDf(condition bool, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -508,26 +525,17 @@ func (e *SVGSVGElement) IfIDf(condition bool, format string, args ...any) *SVGSV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGSVGElement) IDRemove() *SVGSVGElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGSVGElement) Class(s string) *SVGSVGElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -535,7 +543,7 @@ func (e *SVGSVGElement) Class(s string) *SVGSVGElement {
 // sheet)
 func (e *SVGSVGElement) IfClass(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -544,14 +552,8 @@ func (e *SVGSVGElement) IfClass(condition bool, s string) *SVGSVGElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGSVGElement) ClassRemove(s ...string) *SVGSVGElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -560,14 +562,8 @@ func (e *SVGSVGElement) StylePairs(pairs ...string) *SVGSVGElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -581,14 +577,8 @@ func (e *SVGSVGElement) StylePairs(pairs ...string) *SVGSVGElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGSVGElement) Style(s string) *SVGSVGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -596,7 +586,7 @@ func (e *SVGSVGElement) Style(s string) *SVGSVGElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -604,22 +594,14 @@ func (e *SVGSVGElement) Style(s string) *SVGSVGElement {
 // Specifies an inline CSS style for an element
 func (e *SVGSVGElement) IfStyle(condition bool, s string) *SVGSVGElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGSVGElement) StyleAdd(k string, v string) *SVGSVGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -631,7 +613,7 @@ func (e *SVGSVGElement) StyleAddf(k string, format string, args ...any) *SVGSVGE
 // Specifies an inline CSS style for an element
 func (e *SVGSVGElement) IfStyleAdd(condition bool, k string, v string) *SVGSVGElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -639,7 +621,7 @@ func (e *SVGSVGElement) IfStyleAdd(condition bool, k string, v string) *SVGSVGEl
 // Specifies an inline CSS style for an element
 func (e *SVGSVGElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGSVGElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -647,21 +629,15 @@ func (e *SVGSVGElement) IfStyleAddf(condition bool, k string, format string, arg
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGSVGElement) StyleMap(m map[string]string) *SVGSVGElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -669,13 +645,7 @@ func (e *SVGSVGElement) StyleMap(m map[string]string) *SVGSVGElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGSVGElement) StyleRemove(keys ...string) *SVGSVGElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
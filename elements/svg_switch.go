@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <switch> SVG element evaluates the requiredFeatures, requiredExtensions
@@ -26,18 +24,49 @@ type SVGSwitchElement struct {
 // with the tag "switch" during rendering.
 func SVGSwitch(children ...ElementRenderer) *SVGSwitchElement {
 	e := NewElement("switch", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGSwitchElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGSwitchElement) Clone() *SVGSwitchElement {
+	return &SVGSwitchElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGSwitchElement) CloneShallow() *SVGSwitchElement {
+	return &SVGSwitchElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGSwitchElement) Freeze() *SVGSwitchElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGSwitchElement) writable() *SVGSwitchElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGSwitchElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGSwitchElement) Children(children ...ElementRenderer) *SVGSwitchElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGSwitchElement) IfChildren(condition bool, children ...ElementRenderer) *SVGSwitchElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -45,32 +74,30 @@ func (e *SVGSwitchElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGSwitchElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGSwitchElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) BoolAttr(name string) *SVGSwitchElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGSwitchElement) BoolAttrRemove(name string) *SVGSwitchElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGSwitchElement) IfBoolAttr(condition bool, name string) *SVGSwitchElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -81,36 +108,40 @@ func (e *SVGSwitchElement) BoolAttrf(format string, args ...any) *SVGSwitchEleme
 
 func (e *SVGSwitchElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) BoolAttrs(names ...string) *SVGSwitchElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) IfBoolAttrs(condition bool, names ...string) *SVGSwitchElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) Attr(name, value string) *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGSwitchElement) RawAttr(name, value string) *SVGSwitchElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGSwitchElement) IfAttr(condition bool, name, value string) *SVGSwitchElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -121,61 +152,52 @@ func (e *SVGSwitchElement) Attrf(name, format string, args ...any) *SVGSwitchEle
 
 func (e *SVGSwitchElement) IfAttrf(condition bool, name, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) Attrs(attrs ...string) *SVGSwitchElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGSwitchElement) IfAttrs(condition bool, attrs ...string) *SVGSwitchElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) AttrsMap(attrs map[string]string) *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGSwitchElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGSwitchElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGSwitchElement) Text(text string) *SVGSwitchElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGSwitchElement) Textf(format string, args ...any) *SVGSwitchElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGSwitchElement) IfText(condition bool, text string) *SVGSwitchElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -183,18 +205,28 @@ func (e *SVGSwitchElement) IfText(condition bool, text string) *SVGSwitchElement
 
 func (e *SVGSwitchElement) IfTextf(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGSwitchElement) Raw(text string) *SVGSwitchElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGSwitchElement) Escaped(text string) *SVGSwitchElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGSwitchElement) IfEscaped(condition bool, text string) *SVGSwitchElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -206,6 +238,7 @@ func (e *SVGSwitchElement) Escapedf(format string, args ...any) *SVGSwitchElemen
 
 func (e *SVGSwitchElement) IfEscapedf(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -215,10 +248,8 @@ func (e *SVGSwitchElement) IfEscapedf(condition bool, format string, args ...any
 // document must include support for all of the specified features for this
 // element to be valid.
 func (e *SVGSwitchElement) RequiredFeatures(s string) *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredFeatures", s)
+	e = e.writable()
+	e.setStringAttribute("requiredFeatures", s)
 	return e
 }
 
@@ -234,7 +265,7 @@ func (e *SVGSwitchElement) RequiredFeaturesf(format string, args ...any) *SVGSwi
 // element to be valid.
 func (e *SVGSwitchElement) IfRequiredFeatures(condition bool, s string) *SVGSwitchElement {
 	if condition {
-		e.RequiredFeatures(s)
+		e = e.RequiredFeatures(s)
 	}
 	return e
 }
@@ -244,7 +275,7 @@ func (e *SVGSwitchElement) IfRequiredFeatures(condition bool, s string) *SVGSwit
 // element to be valid.
 func (e *SVGSwitchElement) IfRequiredFeaturesf(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.RequiredFeatures(fmt.Sprintf(format, args...))
+		e = e.RequiredFeatures(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -254,20 +285,16 @@ func (e *SVGSwitchElement) IfRequiredFeaturesf(condition bool, format string, ar
 // element to be valid.
 // Remove the attribute RequiredFeatures from the element.
 func (e *SVGSwitchElement) RequiredFeaturesRemove() *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredFeatures")
+	e = e.writable()
+	e.removeAttribute("requiredFeatures")
 	return e
 }
 
 // A space-separated list of required extensions, indicating that the parent SVG
 // document must include the specified extensions for this element to be valid.
 func (e *SVGSwitchElement) RequiredExtensions(s string) *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("requiredExtensions", s)
+	e = e.writable()
+	e.setStringAttribute("requiredExtensions", s)
 	return e
 }
 
@@ -281,7 +308,7 @@ func (e *SVGSwitchElement) RequiredExtensionsf(format string, args ...any) *SVGS
 // document must include the specified extensions for this element to be valid.
 func (e *SVGSwitchElement) IfRequiredExtensions(condition bool, s string) *SVGSwitchElement {
 	if condition {
-		e.RequiredExtensions(s)
+		e = e.RequiredExtensions(s)
 	}
 	return e
 }
@@ -290,7 +317,7 @@ func (e *SVGSwitchElement) IfRequiredExtensions(condition bool, s string) *SVGSw
 // document must include the specified extensions for this element to be valid.
 func (e *SVGSwitchElement) IfRequiredExtensionsf(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.RequiredExtensions(fmt.Sprintf(format, args...))
+		e = e.RequiredExtensions(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -299,10 +326,8 @@ func (e *SVGSwitchElement) IfRequiredExtensionsf(condition bool, format string,
 // document must include the specified extensions for this element to be valid.
 // Remove the attribute RequiredExtensions from the element.
 func (e *SVGSwitchElement) RequiredExtensionsRemove() *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("requiredExtensions")
+	e = e.writable()
+	e.removeAttribute("requiredExtensions")
 	return e
 }
 
@@ -310,10 +335,8 @@ func (e *SVGSwitchElement) RequiredExtensionsRemove() *SVGSwitchElement {
 // document must include support for all of the specified languages for this
 // element to be valid.
 func (e *SVGSwitchElement) SystemLanguage(s string) *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("systemLanguage", s)
+	e = e.writable()
+	e.setStringAttribute("systemLanguage", s)
 	return e
 }
 
@@ -329,7 +352,7 @@ func (e *SVGSwitchElement) SystemLanguagef(format string, args ...any) *SVGSwitc
 // element to be valid.
 func (e *SVGSwitchElement) IfSystemLanguage(condition bool, s string) *SVGSwitchElement {
 	if condition {
-		e.SystemLanguage(s)
+		e = e.SystemLanguage(s)
 	}
 	return e
 }
@@ -339,7 +362,7 @@ func (e *SVGSwitchElement) IfSystemLanguage(condition bool, s string) *SVGSwitch
 // element to be valid.
 func (e *SVGSwitchElement) IfSystemLanguagef(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.SystemLanguage(fmt.Sprintf(format, args...))
+		e = e.SystemLanguage(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -349,19 +372,15 @@ func (e *SVGSwitchElement) IfSystemLanguagef(condition bool, format string, args
 // element to be valid.
 // Remove the attribute SystemLanguage from the element.
 func (e *SVGSwitchElement) SystemLanguageRemove() *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("systemLanguage")
+	e = e.writable()
+	e.removeAttribute("systemLanguage")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGSwitchElement) ID(s string) *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -373,7 +392,7 @@ func (e *SVGSwitchElement) IDf(format string, args ...any) *SVGSwitchElement {
 // Specifies a unique id for an element
 func (e *SVGSwitchElement) IfID(condition bool, s string) *SVGSwitchElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -381,7 +400,7 @@ func (e *SVGSwitchElement) IfID(condition bool, s string) *SVGSwitchElement {
 // Specifies a unique id for an element
 func (e *SVGSwitchElement) IfIDf(condition bool, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -389,26 +408,17 @@ func (e *SVGSwitchElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGSwitchElement) IDRemove() *SVGSwitchElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGSwitchElement) Class(s string) *SVGSwitchElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -416,7 +426,7 @@ func (e *SVGSwitchElement) Class(s string) *SVGSwitchElement {
 // sheet)
 func (e *SVGSwitchElement) IfClass(condition bool, s string) *SVGSwitchElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -425,14 +435,8 @@ func (e *SVGSwitchElement) IfClass(condition bool, s string) *SVGSwitchElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGSwitchElement) ClassRemove(s ...string) *SVGSwitchElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -441,14 +445,8 @@ func (e *SVGSwitchElement) StylePairs(pairs ...string) *SVGSwitchElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -462,14 +460,8 @@ func (e *SVGSwitchElement) StylePairs(pairs ...string) *SVGSwitchElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGSwitchElement) Style(s string) *SVGSwitchElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -477,7 +469,7 @@ func (e *SVGSwitchElement) Style(s string) *SVGSwitchElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -485,22 +477,14 @@ func (e *SVGSwitchElement) Style(s string) *SVGSwitchElement {
 // Specifies an inline CSS style for an element
 func (e *SVGSwitchElement) IfStyle(condition bool, s string) *SVGSwitchElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGSwitchElement) StyleAdd(k string, v string) *SVGSwitchElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -512,7 +496,7 @@ func (e *SVGSwitchElement) StyleAddf(k string, format string, args ...any) *SVGS
 // Specifies an inline CSS style for an element
 func (e *SVGSwitchElement) IfStyleAdd(condition bool, k string, v string) *SVGSwitchElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -520,7 +504,7 @@ func (e *SVGSwitchElement) IfStyleAdd(condition bool, k string, v string) *SVGSw
 // Specifies an inline CSS style for an element
 func (e *SVGSwitchElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGSwitchElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -528,21 +512,15 @@ func (e *SVGSwitchElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGSwitchElement) StyleMap(m map[string]string) *SVGSwitchElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -550,13 +528,7 @@ func (e *SVGSwitchElement) StyleMap(m map[string]string) *SVGSwitchElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGSwitchElement) StyleRemove(keys ...string) *SVGSwitchElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <symbol> SVG element is used to define graphical template objects which
@@ -29,18 +27,59 @@ type SVGSymbolElement struct {
 // with the tag "symbol" during rendering.
 func SVGSymbol(children ...ElementRenderer) *SVGSymbolElement {
 	e := NewElement("symbol", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGSymbolElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGSymbolElement) Clone() *SVGSymbolElement {
+	return &SVGSymbolElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGSymbolElement) CloneShallow() *SVGSymbolElement {
+	return &SVGSymbolElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGSymbolElement) Freeze() *SVGSymbolElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGSymbolElement) writable() *SVGSymbolElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGSymbolElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGSymbolElement) choiceType(name string) func(string) any {
+	switch name {
+	case "preserveAspectRatio":
+		return choiceValue[SVGSymbolPreserveAspectRatioChoice]
+	}
+	return nil
+}
+
 func (e *SVGSymbolElement) Children(children ...ElementRenderer) *SVGSymbolElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGSymbolElement) IfChildren(condition bool, children ...ElementRenderer) *SVGSymbolElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -48,32 +87,30 @@ func (e *SVGSymbolElement) IfChildren(condition bool, children ...ElementRendere
 
 func (e *SVGSymbolElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGSymbolElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) BoolAttr(name string) *SVGSymbolElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGSymbolElement) BoolAttrRemove(name string) *SVGSymbolElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGSymbolElement) IfBoolAttr(condition bool, name string) *SVGSymbolElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -84,36 +121,40 @@ func (e *SVGSymbolElement) BoolAttrf(format string, args ...any) *SVGSymbolEleme
 
 func (e *SVGSymbolElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGSymbolElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) BoolAttrs(names ...string) *SVGSymbolElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) IfBoolAttrs(condition bool, names ...string) *SVGSymbolElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) Attr(name, value string) *SVGSymbolElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGSymbolElement) RawAttr(name, value string) *SVGSymbolElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGSymbolElement) IfAttr(condition bool, name, value string) *SVGSymbolElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -124,61 +165,52 @@ func (e *SVGSymbolElement) Attrf(name, format string, args ...any) *SVGSymbolEle
 
 func (e *SVGSymbolElement) IfAttrf(condition bool, name, format string, args ...any) *SVGSymbolElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) Attrs(attrs ...string) *SVGSymbolElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGSymbolElement) IfAttrs(condition bool, attrs ...string) *SVGSymbolElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) AttrsMap(attrs map[string]string) *SVGSymbolElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGSymbolElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGSymbolElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGSymbolElement) Text(text string) *SVGSymbolElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGSymbolElement) Textf(format string, args ...any) *SVGSymbolElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGSymbolElement) IfText(condition bool, text string) *SVGSymbolElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -186,18 +218,28 @@ func (e *SVGSymbolElement) IfText(condition bool, text string) *SVGSymbolElement
 
 func (e *SVGSymbolElement) IfTextf(condition bool, format string, args ...any) *SVGSymbolElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGSymbolElement) Raw(text string) *SVGSymbolElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGSymbolElement) Escaped(text string) *SVGSymbolElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGSymbolElement) IfEscaped(condition bool, text string) *SVGSymbolElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -209,6 +251,7 @@ func (e *SVGSymbolElement) Escapedf(format string, args ...any) *SVGSymbolElemen
 
 func (e *SVGSymbolElement) IfEscapedf(condition bool, format string, args ...any) *SVGSymbolElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -216,10 +259,8 @@ func (e *SVGSymbolElement) IfEscapedf(condition bool, format string, args ...any
 
 // Indicates how the fetched image is fitted into the destination rectangle.
 func (e *SVGSymbolElement) PreserveAspectRatio(c SVGSymbolPreserveAspectRatioChoice) *SVGSymbolElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("preserveAspectRatio", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("preserveAspectRatio", string(c), choiceValue[SVGSymbolPreserveAspectRatioChoice])
 	return e
 }
 
@@ -257,19 +298,15 @@ const (
 // Indicates how the fetched image is fitted into the destination rectangle.
 // Remove the attribute PreserveAspectRatio from the element.
 func (e *SVGSymbolElement) PreserveAspectRatioRemove() *SVGSymbolElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("preserveAspectRatio")
+	e = e.writable()
+	e.removeAttribute("preserveAspectRatio")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGSymbolElement) ID(s string) *SVGSymbolElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -281,7 +318,7 @@ func (e *SVGSymbolElement) IDf(format string, args ...any) *SVGSymbolElement {
 // Specifies a unique id for an element
 func (e *SVGSymbolElement) IfID(condition bool, s string) *SVGSymbolElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -289,7 +326,7 @@ func (e *SVGSymbolElement) IfID(condition bool, s string) *SVGSymbolElement {
 // Specifies a unique id for an element
 func (e *SVGSymbolElement) IfIDf(condition bool, format string, args ...any) *SVGSymbolElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -297,26 +334,17 @@ func (e *SVGSymbolElement) IfIDf(condition bool, format string, args ...any) *SV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGSymbolElement) IDRemove() *SVGSymbolElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGSymbolElement) Class(s string) *SVGSymbolElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -324,7 +352,7 @@ func (e *SVGSymbolElement) Class(s string) *SVGSymbolElement {
 // sheet)
 func (e *SVGSymbolElement) IfClass(condition bool, s string) *SVGSymbolElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -333,14 +361,8 @@ func (e *SVGSymbolElement) IfClass(condition bool, s string) *SVGSymbolElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGSymbolElement) ClassRemove(s ...string) *SVGSymbolElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -349,14 +371,8 @@ func (e *SVGSymbolElement) StylePairs(pairs ...string) *SVGSymbolElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -370,14 +386,8 @@ func (e *SVGSymbolElement) StylePairs(pairs ...string) *SVGSymbolElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGSymbolElement) Style(s string) *SVGSymbolElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -385,7 +395,7 @@ func (e *SVGSymbolElement) Style(s string) *SVGSymbolElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -393,22 +403,14 @@ func (e *SVGSymbolElement) Style(s string) *SVGSymbolElement {
 // Specifies an inline CSS style for an element
 func (e *SVGSymbolElement) IfStyle(condition bool, s string) *SVGSymbolElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGSymbolElement) StyleAdd(k string, v string) *SVGSymbolElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -420,7 +422,7 @@ func (e *SVGSymbolElement) StyleAddf(k string, format string, args ...any) *SVGS
 // Specifies an inline CSS style for an element
 func (e *SVGSymbolElement) IfStyleAdd(condition bool, k string, v string) *SVGSymbolElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -428,7 +430,7 @@ func (e *SVGSymbolElement) IfStyleAdd(condition bool, k string, v string) *SVGSy
 // Specifies an inline CSS style for an element
 func (e *SVGSymbolElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGSymbolElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -436,21 +438,15 @@ func (e *SVGSymbolElement) IfStyleAddf(condition bool, k string, format string,
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGSymbolElement) StyleMap(m map[string]string) *SVGSymbolElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -458,13 +454,7 @@ func (e *SVGSymbolElement) StyleMap(m map[string]string) *SVGSymbolElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGSymbolElement) StyleRemove(keys ...string) *SVGSymbolElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
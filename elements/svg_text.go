@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <text> SVG element renders the first character at the initial current
@@ -23,18 +21,59 @@ type SVGTextElement struct {
 // with the tag "text" during rendering.
 func SVGText(children ...ElementRenderer) *SVGTextElement {
 	e := NewElement("text", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGTextElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGTextElement) Clone() *SVGTextElement {
+	return &SVGTextElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGTextElement) CloneShallow() *SVGTextElement {
+	return &SVGTextElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGTextElement) Freeze() *SVGTextElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGTextElement) writable() *SVGTextElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGTextElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGTextElement) choiceType(name string) func(string) any {
+	switch name {
+	case "lengthAdjust":
+		return choiceValue[SVGTextLengthAdjustChoice]
+	}
+	return nil
+}
+
 func (e *SVGTextElement) Children(children ...ElementRenderer) *SVGTextElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGTextElement) IfChildren(condition bool, children ...ElementRenderer) *SVGTextElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -42,32 +81,30 @@ func (e *SVGTextElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGTextElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGTextElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGTextElement) BoolAttr(name string) *SVGTextElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGTextElement) BoolAttrRemove(name string) *SVGTextElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGTextElement) IfBoolAttr(condition bool, name string) *SVGTextElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -78,36 +115,40 @@ func (e *SVGTextElement) BoolAttrf(format string, args ...any) *SVGTextElement {
 
 func (e *SVGTextElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGTextElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGTextElement) BoolAttrs(names ...string) *SVGTextElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGTextElement) IfBoolAttrs(condition bool, names ...string) *SVGTextElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGTextElement) Attr(name, value string) *SVGTextElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGTextElement) RawAttr(name, value string) *SVGTextElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGTextElement) IfAttr(condition bool, name, value string) *SVGTextElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -118,61 +159,52 @@ func (e *SVGTextElement) Attrf(name, format string, args ...any) *SVGTextElement
 
 func (e *SVGTextElement) IfAttrf(condition bool, name, format string, args ...any) *SVGTextElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGTextElement) Attrs(attrs ...string) *SVGTextElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGTextElement) IfAttrs(condition bool, attrs ...string) *SVGTextElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGTextElement) AttrsMap(attrs map[string]string) *SVGTextElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGTextElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGTextElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGTextElement) Text(text string) *SVGTextElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGTextElement) Textf(format string, args ...any) *SVGTextElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGTextElement) IfText(condition bool, text string) *SVGTextElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -180,18 +212,28 @@ func (e *SVGTextElement) IfText(condition bool, text string) *SVGTextElement {
 
 func (e *SVGTextElement) IfTextf(condition bool, format string, args ...any) *SVGTextElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGTextElement) Raw(text string) *SVGTextElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGTextElement) Escaped(text string) *SVGTextElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGTextElement) IfEscaped(condition bool, text string) *SVGTextElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -203,6 +245,7 @@ func (e *SVGTextElement) Escapedf(format string, args ...any) *SVGTextElement {
 
 func (e *SVGTextElement) IfEscapedf(condition bool, format string, args ...any) *SVGTextElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,85 +253,75 @@ func (e *SVGTextElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The x-axis coordinate of the initial current text position.
 func (e *SVGTextElement) X(f float64) *SVGTextElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the initial current text position.
 func (e *SVGTextElement) IfX(condition bool, f float64) *SVGTextElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the initial current text position.
 func (e *SVGTextElement) Y(f float64) *SVGTextElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the initial current text position.
 func (e *SVGTextElement) IfY(condition bool, f float64) *SVGTextElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
 
 // The x-axis coordinate of the current text position.
 func (e *SVGTextElement) Dx(f float64) *SVGTextElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dx", f)
+	e = e.writable()
+	e.setFloatAttribute("dx", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the current text position.
 func (e *SVGTextElement) IfDx(condition bool, f float64) *SVGTextElement {
 	if condition {
-		e.Dx(f)
+		e = e.Dx(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the current text position.
 func (e *SVGTextElement) Dy(f float64) *SVGTextElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dy", f)
+	e = e.writable()
+	e.setFloatAttribute("dy", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the current text position.
 func (e *SVGTextElement) IfDy(condition bool, f float64) *SVGTextElement {
 	if condition {
-		e.Dy(f)
+		e = e.Dy(f)
 	}
 	return e
 }
 
 // The rotation angle about the current text position.
 func (e *SVGTextElement) Rotate(f float64) *SVGTextElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("rotate", f)
+	e = e.writable()
+	e.setFloatAttribute("rotate", f, 0)
 	return e
 }
 
 // The rotation angle about the current text position.
 func (e *SVGTextElement) IfRotate(condition bool, f float64) *SVGTextElement {
 	if condition {
-		e.Rotate(f)
+		e = e.Rotate(f)
 	}
 	return e
 }
@@ -299,10 +332,8 @@ func (e *SVGTextElement) IfRotate(condition bool, f float64) *SVGTextElement {
 // 'letter-spacing' and 'word-spacing' and adjustments due to Attributes 'x' and
 // 'y' on the <text> element.
 func (e *SVGTextElement) TextLength(f float64) *SVGTextElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("textLength", f)
+	e = e.writable()
+	e.setFloatAttribute("textLength", f, 0)
 	return e
 }
 
@@ -313,17 +344,15 @@ func (e *SVGTextElement) TextLength(f float64) *SVGTextElement {
 // 'y' on the <text> element.
 func (e *SVGTextElement) IfTextLength(condition bool, f float64) *SVGTextElement {
 	if condition {
-		e.TextLength(f)
+		e = e.TextLength(f)
 	}
 	return e
 }
 
 // Indicates how the text is stretched or compressed to fit the textLength.
 func (e *SVGTextElement) LengthAdjust(c SVGTextLengthAdjustChoice) *SVGTextElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("lengthAdjust", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("lengthAdjust", string(c), choiceValue[SVGTextLengthAdjustChoice])
 	return e
 }
 
@@ -339,19 +368,15 @@ const (
 // Indicates how the text is stretched or compressed to fit the textLength.
 // Remove the attribute LengthAdjust from the element.
 func (e *SVGTextElement) LengthAdjustRemove() *SVGTextElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("lengthAdjust")
+	e = e.writable()
+	e.removeAttribute("lengthAdjust")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGTextElement) ID(s string) *SVGTextElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -363,7 +388,7 @@ func (e *SVGTextElement) IDf(format string, args ...any) *SVGTextElement {
 // Specifies a unique id for an element
 func (e *SVGTextElement) IfID(condition bool, s string) *SVGTextElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -371,7 +396,7 @@ func (e *SVGTextElement) IfID(condition bool, s string) *SVGTextElement {
 // Specifies a unique id for an element
 func (e *SVGTextElement) IfIDf(condition bool, format string, args ...any) *SVGTextElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -379,26 +404,17 @@ func (e *SVGTextElement) IfIDf(condition bool, format string, args ...any) *SVGT
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGTextElement) IDRemove() *SVGTextElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGTextElement) Class(s string) *SVGTextElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -406,7 +422,7 @@ func (e *SVGTextElement) Class(s string) *SVGTextElement {
 // sheet)
 func (e *SVGTextElement) IfClass(condition bool, s string) *SVGTextElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -415,14 +431,8 @@ func (e *SVGTextElement) IfClass(condition bool, s string) *SVGTextElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGTextElement) ClassRemove(s ...string) *SVGTextElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -431,14 +441,8 @@ func (e *SVGTextElement) StylePairs(pairs ...string) *SVGTextElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -452,14 +456,8 @@ func (e *SVGTextElement) StylePairs(pairs ...string) *SVGTextElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGTextElement) Style(s string) *SVGTextElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -467,7 +465,7 @@ func (e *SVGTextElement) Style(s string) *SVGTextElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -475,22 +473,14 @@ func (e *SVGTextElement) Style(s string) *SVGTextElement {
 // Specifies an inline CSS style for an element
 func (e *SVGTextElement) IfStyle(condition bool, s string) *SVGTextElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGTextElement) StyleAdd(k string, v string) *SVGTextElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -502,7 +492,7 @@ func (e *SVGTextElement) StyleAddf(k string, format string, args ...any) *SVGTex
 // Specifies an inline CSS style for an element
 func (e *SVGTextElement) IfStyleAdd(condition bool, k string, v string) *SVGTextElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -510,7 +500,7 @@ func (e *SVGTextElement) IfStyleAdd(condition bool, k string, v string) *SVGText
 // Specifies an inline CSS style for an element
 func (e *SVGTextElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGTextElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -518,21 +508,15 @@ func (e *SVGTextElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGTextElement) StyleMap(m map[string]string) *SVGTextElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -540,13 +524,7 @@ func (e *SVGTextElement) StyleMap(m map[string]string) *SVGTextElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGTextElement) StyleRemove(keys ...string) *SVGTextElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
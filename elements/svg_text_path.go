@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <textPath> SVG element defines a set of glyphs that exactly fit along a
@@ -22,18 +20,61 @@ type SVGTextPathElement struct {
 // with the tag "textPath" during rendering.
 func SVGTextPath(children ...ElementRenderer) *SVGTextPathElement {
 	e := NewElement("textPath", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGTextPathElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGTextPathElement) Clone() *SVGTextPathElement {
+	return &SVGTextPathElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGTextPathElement) CloneShallow() *SVGTextPathElement {
+	return &SVGTextPathElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGTextPathElement) Freeze() *SVGTextPathElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGTextPathElement) writable() *SVGTextPathElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGTextPathElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
+// choiceType returns the conversion of the values of a choice attribute to
+// its type, see Element.AttrValue.
+func (e *SVGTextPathElement) choiceType(name string) func(string) any {
+	switch name {
+	case "method":
+		return choiceValue[SVGTextPathMethodChoice]
+	case "spacing":
+		return choiceValue[SVGTextPathSpacingChoice]
+	}
+	return nil
+}
+
 func (e *SVGTextPathElement) Children(children ...ElementRenderer) *SVGTextPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGTextPathElement) IfChildren(condition bool, children ...ElementRenderer) *SVGTextPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +82,30 @@ func (e *SVGTextPathElement) IfChildren(condition bool, children ...ElementRende
 
 func (e *SVGTextPathElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGTextPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) BoolAttr(name string) *SVGTextPathElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGTextPathElement) BoolAttrRemove(name string) *SVGTextPathElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGTextPathElement) IfBoolAttr(condition bool, name string) *SVGTextPathElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +116,40 @@ func (e *SVGTextPathElement) BoolAttrf(format string, args ...any) *SVGTextPathE
 
 func (e *SVGTextPathElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) BoolAttrs(names ...string) *SVGTextPathElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) IfBoolAttrs(condition bool, names ...string) *SVGTextPathElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) Attr(name, value string) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGTextPathElement) RawAttr(name, value string) *SVGTextPathElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGTextPathElement) IfAttr(condition bool, name, value string) *SVGTextPathElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +160,52 @@ func (e *SVGTextPathElement) Attrf(name, format string, args ...any) *SVGTextPat
 
 func (e *SVGTextPathElement) IfAttrf(condition bool, name, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) Attrs(attrs ...string) *SVGTextPathElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGTextPathElement) IfAttrs(condition bool, attrs ...string) *SVGTextPathElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) AttrsMap(attrs map[string]string) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGTextPathElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGTextPathElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGTextPathElement) Text(text string) *SVGTextPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGTextPathElement) Textf(format string, args ...any) *SVGTextPathElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGTextPathElement) IfText(condition bool, text string) *SVGTextPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +213,28 @@ func (e *SVGTextPathElement) IfText(condition bool, text string) *SVGTextPathEle
 
 func (e *SVGTextPathElement) IfTextf(condition bool, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGTextPathElement) Raw(text string) *SVGTextPathElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGTextPathElement) Escaped(text string) *SVGTextPathElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGTextPathElement) IfEscaped(condition bool, text string) *SVGTextPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +246,7 @@ func (e *SVGTextPathElement) Escapedf(format string, args ...any) *SVGTextPathEl
 
 func (e *SVGTextPathElement) IfEscapedf(condition bool, format string, args ...any) *SVGTextPathElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +254,8 @@ func (e *SVGTextPathElement) IfEscapedf(condition bool, format string, args ...a
 
 // A URI reference to the path to render along.
 func (e *SVGTextPathElement) Href(s string) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -224,7 +267,7 @@ func (e *SVGTextPathElement) Hreff(format string, args ...any) *SVGTextPathEleme
 // A URI reference to the path to render along.
 func (e *SVGTextPathElement) IfHref(condition bool, s string) *SVGTextPathElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -232,7 +275,7 @@ func (e *SVGTextPathElement) IfHref(condition bool, s string) *SVGTextPathElemen
 // A URI reference to the path to render along.
 func (e *SVGTextPathElement) IfHreff(condition bool, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,20 +283,16 @@ func (e *SVGTextPathElement) IfHreff(condition bool, format string, args ...any)
 // A URI reference to the path to render along.
 // Remove the attribute Href from the element.
 func (e *SVGTextPathElement) HrefRemove() *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // Indicates an offset from the start of the path, where the first character is
 // rendered.
 func (e *SVGTextPathElement) StartOffset(s string) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("startOffset", s)
+	e = e.writable()
+	e.setStringAttribute("startOffset", s)
 	return e
 }
 
@@ -267,7 +306,7 @@ func (e *SVGTextPathElement) StartOffsetf(format string, args ...any) *SVGTextPa
 // rendered.
 func (e *SVGTextPathElement) IfStartOffset(condition bool, s string) *SVGTextPathElement {
 	if condition {
-		e.StartOffset(s)
+		e = e.StartOffset(s)
 	}
 	return e
 }
@@ -276,7 +315,7 @@ func (e *SVGTextPathElement) IfStartOffset(condition bool, s string) *SVGTextPat
 // rendered.
 func (e *SVGTextPathElement) IfStartOffsetf(condition bool, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.StartOffset(fmt.Sprintf(format, args...))
+		e = e.StartOffset(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -285,19 +324,15 @@ func (e *SVGTextPathElement) IfStartOffsetf(condition bool, format string, args
 // rendered.
 // Remove the attribute StartOffset from the element.
 func (e *SVGTextPathElement) StartOffsetRemove() *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("startOffset")
+	e = e.writable()
+	e.removeAttribute("startOffset")
 	return e
 }
 
 // Indicates the method by which text should be rendered along the path.
 func (e *SVGTextPathElement) Method(c SVGTextPathMethodChoice) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("method", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("method", string(c), choiceValue[SVGTextPathMethodChoice])
 	return e
 }
 
@@ -313,19 +348,15 @@ const (
 // Indicates the method by which text should be rendered along the path.
 // Remove the attribute Method from the element.
 func (e *SVGTextPathElement) MethodRemove() *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("method")
+	e = e.writable()
+	e.removeAttribute("method")
 	return e
 }
 
 // Indicates the spacing behavior between characters.
 func (e *SVGTextPathElement) Spacing(c SVGTextPathSpacingChoice) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("spacing", string(c))
+	e = e.writable()
+	e.setChoiceAttribute("spacing", string(c), choiceValue[SVGTextPathSpacingChoice])
 	return e
 }
 
@@ -341,19 +372,15 @@ const (
 // Indicates the spacing behavior between characters.
 // Remove the attribute Spacing from the element.
 func (e *SVGTextPathElement) SpacingRemove() *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("spacing")
+	e = e.writable()
+	e.removeAttribute("spacing")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGTextPathElement) ID(s string) *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -365,7 +392,7 @@ func (e *SVGTextPathElement) IDf(format string, args ...any) *SVGTextPathElement
 // Specifies a unique id for an element
 func (e *SVGTextPathElement) IfID(condition bool, s string) *SVGTextPathElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -373,7 +400,7 @@ func (e *SVGTextPathElement) IfID(condition bool, s string) *SVGTextPathElement
 // Specifies a unique id for an element
 func (e *SVGTextPathElement) IfIDf(condition bool, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -381,26 +408,17 @@ func (e *SVGTextPathElement) IfIDf(condition bool, format string, args ...any) *
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGTextPathElement) IDRemove() *SVGTextPathElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGTextPathElement) Class(s string) *SVGTextPathElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -408,7 +426,7 @@ func (e *SVGTextPathElement) Class(s string) *SVGTextPathElement {
 // sheet)
 func (e *SVGTextPathElement) IfClass(condition bool, s string) *SVGTextPathElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -417,14 +435,8 @@ func (e *SVGTextPathElement) IfClass(condition bool, s string) *SVGTextPathEleme
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGTextPathElement) ClassRemove(s ...string) *SVGTextPathElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -433,14 +445,8 @@ func (e *SVGTextPathElement) StylePairs(pairs ...string) *SVGTextPathElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -454,14 +460,8 @@ func (e *SVGTextPathElement) StylePairs(pairs ...string) *SVGTextPathElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGTextPathElement) Style(s string) *SVGTextPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -469,7 +469,7 @@ func (e *SVGTextPathElement) Style(s string) *SVGTextPathElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -477,22 +477,14 @@ func (e *SVGTextPathElement) Style(s string) *SVGTextPathElement {
 // Specifies an inline CSS style for an element
 func (e *SVGTextPathElement) IfStyle(condition bool, s string) *SVGTextPathElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGTextPathElement) StyleAdd(k string, v string) *SVGTextPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -504,7 +496,7 @@ func (e *SVGTextPathElement) StyleAddf(k string, format string, args ...any) *SV
 // Specifies an inline CSS style for an element
 func (e *SVGTextPathElement) IfStyleAdd(condition bool, k string, v string) *SVGTextPathElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -512,7 +504,7 @@ func (e *SVGTextPathElement) IfStyleAdd(condition bool, k string, v string) *SVG
 // Specifies an inline CSS style for an element
 func (e *SVGTextPathElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGTextPathElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -520,21 +512,15 @@ func (e *SVGTextPathElement) IfStyleAddf(condition bool, k string, format string
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGTextPathElement) StyleMap(m map[string]string) *SVGTextPathElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -542,13 +528,7 @@ func (e *SVGTextPathElement) StyleMap(m map[string]string) *SVGTextPathElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGTextPathElement) StyleRemove(keys ...string) *SVGTextPathElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
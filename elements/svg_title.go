@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <title> SVG element represents a text container used for the context
@@ -22,18 +20,49 @@ type SVGTitleElement struct {
 // with the tag "title" during rendering.
 func SVGTitle(children ...ElementRenderer) *SVGTitleElement {
 	e := NewElement("title", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGTitleElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGTitleElement) Clone() *SVGTitleElement {
+	return &SVGTitleElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGTitleElement) CloneShallow() *SVGTitleElement {
+	return &SVGTitleElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGTitleElement) Freeze() *SVGTitleElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGTitleElement) writable() *SVGTitleElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGTitleElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGTitleElement) Children(children ...ElementRenderer) *SVGTitleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGTitleElement) IfChildren(condition bool, children ...ElementRenderer) *SVGTitleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGTitleElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *SVGTitleElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGTitleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) BoolAttr(name string) *SVGTitleElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGTitleElement) BoolAttrRemove(name string) *SVGTitleElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGTitleElement) IfBoolAttr(condition bool, name string) *SVGTitleElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGTitleElement) BoolAttrf(format string, args ...any) *SVGTitleElement
 
 func (e *SVGTitleElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGTitleElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) BoolAttrs(names ...string) *SVGTitleElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) IfBoolAttrs(condition bool, names ...string) *SVGTitleElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) Attr(name, value string) *SVGTitleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGTitleElement) RawAttr(name, value string) *SVGTitleElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGTitleElement) IfAttr(condition bool, name, value string) *SVGTitleElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGTitleElement) Attrf(name, format string, args ...any) *SVGTitleEleme
 
 func (e *SVGTitleElement) IfAttrf(condition bool, name, format string, args ...any) *SVGTitleElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) Attrs(attrs ...string) *SVGTitleElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGTitleElement) IfAttrs(condition bool, attrs ...string) *SVGTitleElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) AttrsMap(attrs map[string]string) *SVGTitleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGTitleElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGTitleElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGTitleElement) Text(text string) *SVGTitleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGTitleElement) Textf(format string, args ...any) *SVGTitleElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGTitleElement) IfText(condition bool, text string) *SVGTitleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGTitleElement) IfText(condition bool, text string) *SVGTitleElement {
 
 func (e *SVGTitleElement) IfTextf(condition bool, format string, args ...any) *SVGTitleElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGTitleElement) Raw(text string) *SVGTitleElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGTitleElement) Escaped(text string) *SVGTitleElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGTitleElement) IfEscaped(condition bool, text string) *SVGTitleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGTitleElement) Escapedf(format string, args ...any) *SVGTitleElement
 
 func (e *SVGTitleElement) IfEscapedf(condition bool, format string, args ...any) *SVGTitleElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,10 +242,8 @@ func (e *SVGTitleElement) IfEscapedf(condition bool, format string, args ...any)
 
 // Specifies a unique id for an element
 func (e *SVGTitleElement) ID(s string) *SVGTitleElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -224,7 +255,7 @@ func (e *SVGTitleElement) IDf(format string, args ...any) *SVGTitleElement {
 // Specifies a unique id for an element
 func (e *SVGTitleElement) IfID(condition bool, s string) *SVGTitleElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -232,7 +263,7 @@ func (e *SVGTitleElement) IfID(condition bool, s string) *SVGTitleElement {
 // Specifies a unique id for an element
 func (e *SVGTitleElement) IfIDf(condition bool, format string, args ...any) *SVGTitleElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -240,26 +271,17 @@ func (e *SVGTitleElement) IfIDf(condition bool, format string, args ...any) *SVG
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGTitleElement) IDRemove() *SVGTitleElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGTitleElement) Class(s string) *SVGTitleElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -267,7 +289,7 @@ func (e *SVGTitleElement) Class(s string) *SVGTitleElement {
 // sheet)
 func (e *SVGTitleElement) IfClass(condition bool, s string) *SVGTitleElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -276,14 +298,8 @@ func (e *SVGTitleElement) IfClass(condition bool, s string) *SVGTitleElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGTitleElement) ClassRemove(s ...string) *SVGTitleElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -292,14 +308,8 @@ func (e *SVGTitleElement) StylePairs(pairs ...string) *SVGTitleElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -313,14 +323,8 @@ func (e *SVGTitleElement) StylePairs(pairs ...string) *SVGTitleElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGTitleElement) Style(s string) *SVGTitleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -328,7 +332,7 @@ func (e *SVGTitleElement) Style(s string) *SVGTitleElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -336,22 +340,14 @@ func (e *SVGTitleElement) Style(s string) *SVGTitleElement {
 // Specifies an inline CSS style for an element
 func (e *SVGTitleElement) IfStyle(condition bool, s string) *SVGTitleElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGTitleElement) StyleAdd(k string, v string) *SVGTitleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -363,7 +359,7 @@ func (e *SVGTitleElement) StyleAddf(k string, format string, args ...any) *SVGTi
 // Specifies an inline CSS style for an element
 func (e *SVGTitleElement) IfStyleAdd(condition bool, k string, v string) *SVGTitleElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -371,7 +367,7 @@ func (e *SVGTitleElement) IfStyleAdd(condition bool, k string, v string) *SVGTit
 // Specifies an inline CSS style for an element
 func (e *SVGTitleElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGTitleElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -379,21 +375,15 @@ func (e *SVGTitleElement) IfStyleAddf(condition bool, k string, format string, a
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGTitleElement) StyleMap(m map[string]string) *SVGTitleElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -401,13 +391,7 @@ func (e *SVGTitleElement) StyleMap(m map[string]string) *SVGTitleElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGTitleElement) StyleRemove(keys ...string) *SVGTitleElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <tspan> SVG element lets authors explicitly specify the location of a
@@ -22,18 +20,49 @@ type SVGTspanElement struct {
 // with the tag "tspan" during rendering.
 func SVGTspan(children ...ElementRenderer) *SVGTspanElement {
 	e := NewElement("tspan", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGTspanElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGTspanElement) Clone() *SVGTspanElement {
+	return &SVGTspanElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGTspanElement) CloneShallow() *SVGTspanElement {
+	return &SVGTspanElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGTspanElement) Freeze() *SVGTspanElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGTspanElement) writable() *SVGTspanElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGTspanElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGTspanElement) Children(children ...ElementRenderer) *SVGTspanElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGTspanElement) IfChildren(condition bool, children ...ElementRenderer) *SVGTspanElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGTspanElement) IfChildren(condition bool, children ...ElementRenderer
 
 func (e *SVGTspanElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGTspanElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) BoolAttr(name string) *SVGTspanElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGTspanElement) BoolAttrRemove(name string) *SVGTspanElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGTspanElement) IfBoolAttr(condition bool, name string) *SVGTspanElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGTspanElement) BoolAttrf(format string, args ...any) *SVGTspanElement
 
 func (e *SVGTspanElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGTspanElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) BoolAttrs(names ...string) *SVGTspanElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) IfBoolAttrs(condition bool, names ...string) *SVGTspanElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) Attr(name, value string) *SVGTspanElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGTspanElement) RawAttr(name, value string) *SVGTspanElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGTspanElement) IfAttr(condition bool, name, value string) *SVGTspanElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGTspanElement) Attrf(name, format string, args ...any) *SVGTspanEleme
 
 func (e *SVGTspanElement) IfAttrf(condition bool, name, format string, args ...any) *SVGTspanElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) Attrs(attrs ...string) *SVGTspanElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGTspanElement) IfAttrs(condition bool, attrs ...string) *SVGTspanElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) AttrsMap(attrs map[string]string) *SVGTspanElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGTspanElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGTspanElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGTspanElement) Text(text string) *SVGTspanElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGTspanElement) Textf(format string, args ...any) *SVGTspanElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGTspanElement) IfText(condition bool, text string) *SVGTspanElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGTspanElement) IfText(condition bool, text string) *SVGTspanElement {
 
 func (e *SVGTspanElement) IfTextf(condition bool, format string, args ...any) *SVGTspanElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGTspanElement) Raw(text string) *SVGTspanElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGTspanElement) Escaped(text string) *SVGTspanElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGTspanElement) IfEscaped(condition bool, text string) *SVGTspanElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGTspanElement) Escapedf(format string, args ...any) *SVGTspanElement
 
 func (e *SVGTspanElement) IfEscapedf(condition bool, format string, args ...any) *SVGTspanElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -209,95 +242,83 @@ func (e *SVGTspanElement) IfEscapedf(condition bool, format string, args ...any)
 
 // The x-axis coordinate of the current text position.
 func (e *SVGTspanElement) X(f float64) *SVGTspanElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the current text position.
 func (e *SVGTspanElement) IfX(condition bool, f float64) *SVGTspanElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the current text position.
 func (e *SVGTspanElement) Y(f float64) *SVGTspanElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the current text position.
 func (e *SVGTspanElement) IfY(condition bool, f float64) *SVGTspanElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
 
 // The x-axis coordinate of the current text position.
 func (e *SVGTspanElement) Dx(f float64) *SVGTspanElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dx", f)
+	e = e.writable()
+	e.setFloatAttribute("dx", f, 0)
 	return e
 }
 
 // The x-axis coordinate of the current text position.
 func (e *SVGTspanElement) IfDx(condition bool, f float64) *SVGTspanElement {
 	if condition {
-		e.Dx(f)
+		e = e.Dx(f)
 	}
 	return e
 }
 
 // The y-axis coordinate of the current text position.
 func (e *SVGTspanElement) Dy(f float64) *SVGTspanElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("dy", f)
+	e = e.writable()
+	e.setFloatAttribute("dy", f, 0)
 	return e
 }
 
 // The y-axis coordinate of the current text position.
 func (e *SVGTspanElement) IfDy(condition bool, f float64) *SVGTspanElement {
 	if condition {
-		e.Dy(f)
+		e = e.Dy(f)
 	}
 	return e
 }
 
 // The rotation angle about the current text position.
 func (e *SVGTspanElement) Rotate(f float64) *SVGTspanElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("rotate", f)
+	e = e.writable()
+	e.setFloatAttribute("rotate", f, 0)
 	return e
 }
 
 // The rotation angle about the current text position.
 func (e *SVGTspanElement) IfRotate(condition bool, f float64) *SVGTspanElement {
 	if condition {
-		e.Rotate(f)
+		e = e.Rotate(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGTspanElement) ID(s string) *SVGTspanElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -309,7 +330,7 @@ func (e *SVGTspanElement) IDf(format string, args ...any) *SVGTspanElement {
 // Specifies a unique id for an element
 func (e *SVGTspanElement) IfID(condition bool, s string) *SVGTspanElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -317,7 +338,7 @@ func (e *SVGTspanElement) IfID(condition bool, s string) *SVGTspanElement {
 // Specifies a unique id for an element
 func (e *SVGTspanElement) IfIDf(condition bool, format string, args ...any) *SVGTspanElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -325,26 +346,17 @@ func (e *SVGTspanElement) IfIDf(condition bool, format string, args ...any) *SVG
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGTspanElement) IDRemove() *SVGTspanElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGTspanElement) Class(s string) *SVGTspanElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -352,7 +364,7 @@ func (e *SVGTspanElement) Class(s string) *SVGTspanElement {
 // sheet)
 func (e *SVGTspanElement) IfClass(condition bool, s string) *SVGTspanElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -361,14 +373,8 @@ func (e *SVGTspanElement) IfClass(condition bool, s string) *SVGTspanElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGTspanElement) ClassRemove(s ...string) *SVGTspanElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -377,14 +383,8 @@ func (e *SVGTspanElement) StylePairs(pairs ...string) *SVGTspanElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -398,14 +398,8 @@ func (e *SVGTspanElement) StylePairs(pairs ...string) *SVGTspanElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGTspanElement) Style(s string) *SVGTspanElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -413,7 +407,7 @@ func (e *SVGTspanElement) Style(s string) *SVGTspanElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -421,22 +415,14 @@ func (e *SVGTspanElement) Style(s string) *SVGTspanElement {
 // Specifies an inline CSS style for an element
 func (e *SVGTspanElement) IfStyle(condition bool, s string) *SVGTspanElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGTspanElement) StyleAdd(k string, v string) *SVGTspanElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -448,7 +434,7 @@ func (e *SVGTspanElement) StyleAddf(k string, format string, args ...any) *SVGTs
 // Specifies an inline CSS style for an element
 func (e *SVGTspanElement) IfStyleAdd(condition bool, k string, v string) *SVGTspanElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -456,7 +442,7 @@ func (e *SVGTspanElement) IfStyleAdd(condition bool, k string, v string) *SVGTsp
 // Specifies an inline CSS style for an element
 func (e *SVGTspanElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGTspanElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -464,21 +450,15 @@ func (e *SVGTspanElement) IfStyleAddf(condition bool, k string, format string, a
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGTspanElement) StyleMap(m map[string]string) *SVGTspanElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -486,13 +466,7 @@ func (e *SVGTspanElement) StyleMap(m map[string]string) *SVGTspanElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGTspanElement) StyleRemove(keys ...string) *SVGTspanElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
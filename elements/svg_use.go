@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <use> SVG element includes a reference to a <symbol> element and attempts
@@ -24,18 +22,49 @@ type SVGUseElement struct {
 // with the tag "use" during rendering.
 func SVGUse(children ...ElementRenderer) *SVGUseElement {
 	e := NewElement("use", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGUseElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGUseElement) Clone() *SVGUseElement {
+	return &SVGUseElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGUseElement) CloneShallow() *SVGUseElement {
+	return &SVGUseElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGUseElement) Freeze() *SVGUseElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGUseElement) writable() *SVGUseElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGUseElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGUseElement) Children(children ...ElementRenderer) *SVGUseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGUseElement) IfChildren(condition bool, children ...ElementRenderer) *SVGUseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -43,32 +72,30 @@ func (e *SVGUseElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGUseElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGUseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGUseElement) BoolAttr(name string) *SVGUseElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGUseElement) BoolAttrRemove(name string) *SVGUseElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGUseElement) IfBoolAttr(condition bool, name string) *SVGUseElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -79,36 +106,40 @@ func (e *SVGUseElement) BoolAttrf(format string, args ...any) *SVGUseElement {
 
 func (e *SVGUseElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGUseElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGUseElement) BoolAttrs(names ...string) *SVGUseElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGUseElement) IfBoolAttrs(condition bool, names ...string) *SVGUseElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGUseElement) Attr(name, value string) *SVGUseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGUseElement) RawAttr(name, value string) *SVGUseElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGUseElement) IfAttr(condition bool, name, value string) *SVGUseElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -119,61 +150,52 @@ func (e *SVGUseElement) Attrf(name, format string, args ...any) *SVGUseElement {
 
 func (e *SVGUseElement) IfAttrf(condition bool, name, format string, args ...any) *SVGUseElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGUseElement) Attrs(attrs ...string) *SVGUseElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGUseElement) IfAttrs(condition bool, attrs ...string) *SVGUseElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGUseElement) AttrsMap(attrs map[string]string) *SVGUseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGUseElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGUseElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGUseElement) Text(text string) *SVGUseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGUseElement) Textf(format string, args ...any) *SVGUseElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGUseElement) IfText(condition bool, text string) *SVGUseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -181,18 +203,28 @@ func (e *SVGUseElement) IfText(condition bool, text string) *SVGUseElement {
 
 func (e *SVGUseElement) IfTextf(condition bool, format string, args ...any) *SVGUseElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGUseElement) Raw(text string) *SVGUseElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGUseElement) Escaped(text string) *SVGUseElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGUseElement) IfEscaped(condition bool, text string) *SVGUseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -204,6 +236,7 @@ func (e *SVGUseElement) Escapedf(format string, args ...any) *SVGUseElement {
 
 func (e *SVGUseElement) IfEscapedf(condition bool, format string, args ...any) *SVGUseElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -211,10 +244,8 @@ func (e *SVGUseElement) IfEscapedf(condition bool, format string, args ...any) *
 
 // A URI reference to the symbol to use.
 func (e *SVGUseElement) Href(s string) *SVGUseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("href", s)
+	e = e.writable()
+	e.setStringAttribute("href", s)
 	return e
 }
 
@@ -226,7 +257,7 @@ func (e *SVGUseElement) Hreff(format string, args ...any) *SVGUseElement {
 // A URI reference to the symbol to use.
 func (e *SVGUseElement) IfHref(condition bool, s string) *SVGUseElement {
 	if condition {
-		e.Href(s)
+		e = e.Href(s)
 	}
 	return e
 }
@@ -234,7 +265,7 @@ func (e *SVGUseElement) IfHref(condition bool, s string) *SVGUseElement {
 // A URI reference to the symbol to use.
 func (e *SVGUseElement) IfHreff(condition bool, format string, args ...any) *SVGUseElement {
 	if condition {
-		e.Href(fmt.Sprintf(format, args...))
+		e = e.Href(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -242,20 +273,16 @@ func (e *SVGUseElement) IfHreff(condition bool, format string, args ...any) *SVG
 // A URI reference to the symbol to use.
 // Remove the attribute Href from the element.
 func (e *SVGUseElement) HrefRemove() *SVGUseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("href")
+	e = e.writable()
+	e.removeAttribute("href")
 	return e
 }
 
 // The x-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGUseElement) X(f float64) *SVGUseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("x", f)
+	e = e.writable()
+	e.setFloatAttribute("x", f, 0)
 	return e
 }
 
@@ -263,7 +290,7 @@ func (e *SVGUseElement) X(f float64) *SVGUseElement {
 // to the user.
 func (e *SVGUseElement) IfX(condition bool, f float64) *SVGUseElement {
 	if condition {
-		e.X(f)
+		e = e.X(f)
 	}
 	return e
 }
@@ -271,10 +298,8 @@ func (e *SVGUseElement) IfX(condition bool, f float64) *SVGUseElement {
 // The y-axis coordinate of the side of the rectangular region which is closest
 // to the user.
 func (e *SVGUseElement) Y(f float64) *SVGUseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("y", f)
+	e = e.writable()
+	e.setFloatAttribute("y", f, 0)
 	return e
 }
 
@@ -282,51 +307,45 @@ func (e *SVGUseElement) Y(f float64) *SVGUseElement {
 // to the user.
 func (e *SVGUseElement) IfY(condition bool, f float64) *SVGUseElement {
 	if condition {
-		e.Y(f)
+		e = e.Y(f)
 	}
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGUseElement) Width(f float64) *SVGUseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("width", f)
+	e = e.writable()
+	e.setFloatAttribute("width", f, 0)
 	return e
 }
 
 // The width of the rectangular region.
 func (e *SVGUseElement) IfWidth(condition bool, f float64) *SVGUseElement {
 	if condition {
-		e.Width(f)
+		e = e.Width(f)
 	}
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGUseElement) Height(f float64) *SVGUseElement {
-	if e.floatAttributes == nil {
-		e.floatAttributes = treemap.New[string, float64]()
-	}
-	e.floatAttributes.Set("height", f)
+	e = e.writable()
+	e.setFloatAttribute("height", f, 0)
 	return e
 }
 
 // The height of the rectangular region.
 func (e *SVGUseElement) IfHeight(condition bool, f float64) *SVGUseElement {
 	if condition {
-		e.Height(f)
+		e = e.Height(f)
 	}
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGUseElement) ID(s string) *SVGUseElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -338,7 +357,7 @@ func (e *SVGUseElement) IDf(format string, args ...any) *SVGUseElement {
 // Specifies a unique id for an element
 func (e *SVGUseElement) IfID(condition bool, s string) *SVGUseElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -346,7 +365,7 @@ func (e *SVGUseElement) IfID(condition bool, s string) *SVGUseElement {
 // Specifies a unique id for an element
 func (e *SVGUseElement) IfIDf(condition bool, format string, args ...any) *SVGUseElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -354,26 +373,17 @@ func (e *SVGUseElement) IfIDf(condition bool, format string, args ...any) *SVGUs
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGUseElement) IDRemove() *SVGUseElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGUseElement) Class(s string) *SVGUseElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -381,7 +391,7 @@ func (e *SVGUseElement) Class(s string) *SVGUseElement {
 // sheet)
 func (e *SVGUseElement) IfClass(condition bool, s string) *SVGUseElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -390,14 +400,8 @@ func (e *SVGUseElement) IfClass(condition bool, s string) *SVGUseElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGUseElement) ClassRemove(s ...string) *SVGUseElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -406,14 +410,8 @@ func (e *SVGUseElement) StylePairs(pairs ...string) *SVGUseElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -427,14 +425,8 @@ func (e *SVGUseElement) StylePairs(pairs ...string) *SVGUseElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGUseElement) Style(s string) *SVGUseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -442,7 +434,7 @@ func (e *SVGUseElement) Style(s string) *SVGUseElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -450,22 +442,14 @@ func (e *SVGUseElement) Style(s string) *SVGUseElement {
 // Specifies an inline CSS style for an element
 func (e *SVGUseElement) IfStyle(condition bool, s string) *SVGUseElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGUseElement) StyleAdd(k string, v string) *SVGUseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -477,7 +461,7 @@ func (e *SVGUseElement) StyleAddf(k string, format string, args ...any) *SVGUseE
 // Specifies an inline CSS style for an element
 func (e *SVGUseElement) IfStyleAdd(condition bool, k string, v string) *SVGUseElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -485,7 +469,7 @@ func (e *SVGUseElement) IfStyleAdd(condition bool, k string, v string) *SVGUseEl
 // Specifies an inline CSS style for an element
 func (e *SVGUseElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGUseElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -493,21 +477,15 @@ func (e *SVGUseElement) IfStyleAddf(condition bool, k string, format string, arg
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGUseElement) StyleMap(m map[string]string) *SVGUseElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -515,13 +493,7 @@ func (e *SVGUseElement) StyleMap(m map[string]string) *SVGUseElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGUseElement) StyleRemove(keys ...string) *SVGUseElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
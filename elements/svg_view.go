@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"github.com/igrmk/treemap/v2"
 )
 
 // The <view> SVG element is used to define a view into a &lt;svg&gt; element.
@@ -22,18 +20,49 @@ type SVGViewElement struct {
 // with the tag "view" during rendering.
 func SVGView(children ...ElementRenderer) *SVGViewElement {
 	e := NewElement("view", children...)
+	e.namespace = namespaceSVG
 	e.isSelfClosing = false
 	e.descendants = children
 	return &SVGViewElement{Element: e}
 }
 
+// Clone returns a deep copy of the element, see Element.Clone.
+func (e *SVGViewElement) Clone() *SVGViewElement {
+	return &SVGViewElement{Element: e.Element.Clone()}
+}
+
+// CloneShallow returns a copy of the element sharing its children, see
+// Element.CloneShallow.
+func (e *SVGViewElement) CloneShallow() *SVGViewElement {
+	return &SVGViewElement{Element: e.Element.CloneShallow()}
+}
+
+// Freeze makes the element immutable, see Element.Freeze.
+func (e *SVGViewElement) Freeze() *SVGViewElement {
+	e.Element.Freeze()
+	return e
+}
+
+func (e *SVGViewElement) writable() *SVGViewElement {
+	if e.frozen {
+		return e.CloneShallow()
+	}
+	return e
+}
+
+func (e *SVGViewElement) cloneNode() ElementRenderer {
+	return e.Clone()
+}
+
 func (e *SVGViewElement) Children(children ...ElementRenderer) *SVGViewElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, children...)
 	return e
 }
 
 func (e *SVGViewElement) IfChildren(condition bool, children ...ElementRenderer) *SVGViewElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, children...)
 	}
 	return e
@@ -41,32 +70,30 @@ func (e *SVGViewElement) IfChildren(condition bool, children ...ElementRenderer)
 
 func (e *SVGViewElement) TernChildren(condition bool, trueChildren, falseChildren ElementRenderer) *SVGViewElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, trueChildren)
 	} else {
+		e = e.writable()
 		e.descendants = append(e.descendants, falseChildren)
 	}
 	return e
 }
 
 func (e *SVGViewElement) BoolAttr(name string) *SVGViewElement {
-	if e.boolAttributes == nil {
-		e.boolAttributes = treemap.New[string, struct{}]()
-	}
-	e.boolAttributes.Set(name, struct{}{})
+	e = e.writable()
+	e.Element.BoolAttr(name)
 	return e
 }
 
 func (e *SVGViewElement) BoolAttrRemove(name string) *SVGViewElement {
-	if e.boolAttributes == nil {
-		return e
-	}
-	e.boolAttributes.Del(name)
+	e = e.writable()
+	e.removeAttribute(name)
 	return e
 }
 
 func (e *SVGViewElement) IfBoolAttr(condition bool, name string) *SVGViewElement {
 	if condition {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
@@ -77,36 +104,40 @@ func (e *SVGViewElement) BoolAttrf(format string, args ...any) *SVGViewElement {
 
 func (e *SVGViewElement) IfBoolAttrf(condition bool, format string, args ...any) *SVGViewElement {
 	if condition {
-		e.BoolAttrf(format, args...)
+		e = e.BoolAttrf(format, args...)
 	}
 	return e
 }
 
 func (e *SVGViewElement) BoolAttrs(names ...string) *SVGViewElement {
 	for _, name := range names {
-		e.BoolAttr(name)
+		e = e.BoolAttr(name)
 	}
 	return e
 }
 
 func (e *SVGViewElement) IfBoolAttrs(condition bool, names ...string) *SVGViewElement {
 	if condition {
-		e.BoolAttrs(names...)
+		e = e.BoolAttrs(names...)
 	}
 	return e
 }
 
 func (e *SVGViewElement) Attr(name, value string) *SVGViewElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set(name, value)
+	e = e.writable()
+	e.Element.Attr(name, value)
+	return e
+}
+
+func (e *SVGViewElement) RawAttr(name, value string) *SVGViewElement {
+	e = e.writable()
+	e.Element.RawAttr(name, value)
 	return e
 }
 
 func (e *SVGViewElement) IfAttr(condition bool, name, value string) *SVGViewElement {
 	if condition {
-		e.Attr(name, value)
+		e = e.Attr(name, value)
 	}
 	return e
 }
@@ -117,61 +148,52 @@ func (e *SVGViewElement) Attrf(name, format string, args ...any) *SVGViewElement
 
 func (e *SVGViewElement) IfAttrf(condition bool, name, format string, args ...any) *SVGViewElement {
 	if condition {
-		e.Attrf(name, format, args...)
+		e = e.Attrf(name, format, args...)
 	}
 	return e
 }
 
 func (e *SVGViewElement) Attrs(attrs ...string) *SVGViewElement {
-	if len(attrs)%2 != 0 {
-		panic("attrs must be a multiple of 2")
-	}
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for i := 0; i < len(attrs); i += 2 {
-		k := attrs[i]
-		v := attrs[i+1]
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.Attrs(attrs...)
 	return e
 }
 
 func (e *SVGViewElement) IfAttrs(condition bool, attrs ...string) *SVGViewElement {
 	if condition {
-		e.Attrs(attrs...)
+		e = e.Attrs(attrs...)
 	}
 	return e
 }
 
 func (e *SVGViewElement) AttrsMap(attrs map[string]string) *SVGViewElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	for k, v := range attrs {
-		e.stringAttributes.Set(k, v)
-	}
+	e = e.writable()
+	e.Element.AttrsMap(attrs)
 	return e
 }
 
 func (e *SVGViewElement) IfAttrsMap(condition bool, attrs map[string]string) *SVGViewElement {
 	if condition {
-		e.AttrsMap(attrs)
+		e = e.AttrsMap(attrs)
 	}
 	return e
 }
 
 func (e *SVGViewElement) Text(text string) *SVGViewElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Text(text))
 	return e
 }
 
 func (e *SVGViewElement) Textf(format string, args ...any) *SVGViewElement {
-	return e.Text(fmt.Sprintf(format, args...))
+	e = e.writable()
+	e.descendants = append(e.descendants, Textf(format, args...))
+	return e
 }
 
 func (e *SVGViewElement) IfText(condition bool, text string) *SVGViewElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Text(text))
 	}
 	return e
@@ -179,18 +201,28 @@ func (e *SVGViewElement) IfText(condition bool, text string) *SVGViewElement {
 
 func (e *SVGViewElement) IfTextf(condition bool, format string, args ...any) *SVGViewElement {
 	if condition {
-		e.descendants = append(e.descendants, Text(fmt.Sprintf(format, args...)))
+		e = e.writable()
+		e.descendants = append(e.descendants, Textf(format, args...))
 	}
 	return e
 }
 
+// Raw adds text written as is, in safe text mode too.
+func (e *SVGViewElement) Raw(text string) *SVGViewElement {
+	e = e.writable()
+	e.descendants = append(e.descendants, Raw(text))
+	return e
+}
+
 func (e *SVGViewElement) Escaped(text string) *SVGViewElement {
+	e = e.writable()
 	e.descendants = append(e.descendants, Escaped(text))
 	return e
 }
 
 func (e *SVGViewElement) IfEscaped(condition bool, text string) *SVGViewElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escaped(text))
 	}
 	return e
@@ -202,6 +234,7 @@ func (e *SVGViewElement) Escapedf(format string, args ...any) *SVGViewElement {
 
 func (e *SVGViewElement) IfEscapedf(condition bool, format string, args ...any) *SVGViewElement {
 	if condition {
+		e = e.writable()
 		e.descendants = append(e.descendants, Escapedf(format, args...))
 	}
 	return e
@@ -210,10 +243,8 @@ func (e *SVGViewElement) IfEscapedf(condition bool, format string, args ...any)
 // The position and size of the viewport (the viewBox) is defined by the viewBox
 // Attribute.
 func (e *SVGViewElement) ViewBox(s string) *SVGViewElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("viewBox", s)
+	e = e.writable()
+	e.setStringAttribute("viewBox", s)
 	return e
 }
 
@@ -227,7 +258,7 @@ func (e *SVGViewElement) ViewBoxf(format string, args ...any) *SVGViewElement {
 // Attribute.
 func (e *SVGViewElement) IfViewBox(condition bool, s string) *SVGViewElement {
 	if condition {
-		e.ViewBox(s)
+		e = e.ViewBox(s)
 	}
 	return e
 }
@@ -236,7 +267,7 @@ func (e *SVGViewElement) IfViewBox(condition bool, s string) *SVGViewElement {
 // Attribute.
 func (e *SVGViewElement) IfViewBoxf(condition bool, format string, args ...any) *SVGViewElement {
 	if condition {
-		e.ViewBox(fmt.Sprintf(format, args...))
+		e = e.ViewBox(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -245,19 +276,15 @@ func (e *SVGViewElement) IfViewBoxf(condition bool, format string, args ...any)
 // Attribute.
 // Remove the attribute ViewBox from the element.
 func (e *SVGViewElement) ViewBoxRemove() *SVGViewElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("viewBox")
+	e = e.writable()
+	e.removeAttribute("viewBox")
 	return e
 }
 
 // Specifies a unique id for an element
 func (e *SVGViewElement) ID(s string) *SVGViewElement {
-	if e.stringAttributes == nil {
-		e.stringAttributes = treemap.New[string, string]()
-	}
-	e.stringAttributes.Set("id", s)
+	e = e.writable()
+	e.setStringAttribute("id", s)
 	return e
 }
 
@@ -269,7 +296,7 @@ func (e *SVGViewElement) IDf(format string, args ...any) *SVGViewElement {
 // Specifies a unique id for an element
 func (e *SVGViewElement) IfID(condition bool, s string) *SVGViewElement {
 	if condition {
-		e.ID(s)
+		e = e.ID(s)
 	}
 	return e
 }
@@ -277,7 +304,7 @@ func (e *SVGViewElement) IfID(condition bool, s string) *SVGViewElement {
 // Specifies a unique id for an element
 func (e *SVGViewElement) IfIDf(condition bool, format string, args ...any) *SVGViewElement {
 	if condition {
-		e.ID(fmt.Sprintf(format, args...))
+		e = e.ID(fmt.Sprintf(format, args...))
 	}
 	return e
 }
@@ -285,26 +312,17 @@ func (e *SVGViewElement) IfIDf(condition bool, format string, args ...any) *SVGV
 // Specifies a unique id for an element
 // Remove the attribute ID from the element.
 func (e *SVGViewElement) IDRemove() *SVGViewElement {
-	if e.stringAttributes == nil {
-		return e
-	}
-	e.stringAttributes.Del("id")
+	e = e.writable()
+	e.removeAttribute("id")
 	return e
 }
 
 // Specifies one or more classnames for an element (refers to a class in a style
 // sheet)
 func (e *SVGViewElement) Class(s string) *SVGViewElement {
+	e = e.writable()
 	values := strings.Split(s, " ")
-	if e.delimitedStrings == nil {
-		e.delimitedStrings = treemap.New[string, *delimitedBuilder[string]]()
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		ds = newDelimitedBuilder[string](" ")
-		e.delimitedStrings.Set("class", ds)
-	}
-	ds.Add(values...)
+	e.delimitedAttribute("class", " ").Add(values...)
 	return e
 }
 
@@ -312,7 +330,7 @@ func (e *SVGViewElement) Class(s string) *SVGViewElement {
 // sheet)
 func (e *SVGViewElement) IfClass(condition bool, s string) *SVGViewElement {
 	if condition {
-		e.Class(s)
+		e = e.Class(s)
 	}
 	return e
 }
@@ -321,14 +339,8 @@ func (e *SVGViewElement) IfClass(condition bool, s string) *SVGViewElement {
 // sheet)
 // Remove the values from the attribute Class in the element.
 func (e *SVGViewElement) ClassRemove(s ...string) *SVGViewElement {
-	if e.delimitedStrings == nil {
-		return e
-	}
-	ds, ok := e.delimitedStrings.Get("class")
-	if !ok {
-		return e
-	}
-	ds.Remove(s...)
+	e = e.writable()
+	e.removeDelimitedValues("class", s...)
 	return e
 }
 
@@ -337,14 +349,8 @@ func (e *SVGViewElement) StylePairs(pairs ...string) *SVGViewElement {
 	if len(pairs) == 0 || len(pairs)%2 != 0 {
 		panic("StylePairs requires an even number of arguments representing key-value pairs.")
 	}
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv = newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	kv := e.keyValueAttribute("style", ":", ";")
 	for i := 0; i < len(pairs)-1; i += 2 {
 		key := strings.TrimSpace(pairs[i])
 		if key == "" {
@@ -358,14 +364,8 @@ func (e *SVGViewElement) StylePairs(pairs ...string) *SVGViewElement {
 
 // Specifies an inline CSS style for an element
 func (e *SVGViewElement) Style(s string) *SVGViewElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	s = strings.TrimRight(s, ";")
 	kvPairs := strings.Split(s, ";")
 	for _, pair := range kvPairs {
@@ -373,7 +373,7 @@ func (e *SVGViewElement) Style(s string) *SVGViewElement {
 		if len(parts) != 2 {
 			panic(fmt.Sprintf("invalid key-value pair: %q", pair))
 		}
-		e.StylePairs(parts[0], parts[1])
+		e = e.StylePairs(parts[0], parts[1])
 	}
 	return e
 }
@@ -381,22 +381,14 @@ func (e *SVGViewElement) Style(s string) *SVGViewElement {
 // Specifies an inline CSS style for an element
 func (e *SVGViewElement) IfStyle(condition bool, s string) *SVGViewElement {
 	if condition {
-		e.Style(s)
+		e = e.Style(s)
 	}
 	return e
 }
 
 // Specifies an inline CSS style for an element
 func (e *SVGViewElement) StyleAdd(k string, v string) *SVGViewElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
-	e.StylePairs(k, v)
+	e = e.StylePairs(k, v)
 	return e
 }
 
@@ -408,7 +400,7 @@ func (e *SVGViewElement) StyleAddf(k string, format string, args ...any) *SVGVie
 // Specifies an inline CSS style for an element
 func (e *SVGViewElement) IfStyleAdd(condition bool, k string, v string) *SVGViewElement {
 	if condition {
-		e.StyleAdd(k, v)
+		e = e.StyleAdd(k, v)
 	}
 	return e
 }
@@ -416,7 +408,7 @@ func (e *SVGViewElement) IfStyleAdd(condition bool, k string, v string) *SVGView
 // Specifies an inline CSS style for an element
 func (e *SVGViewElement) IfStyleAddf(condition bool, k string, format string, args ...any) *SVGViewElement {
 	if condition {
-		e.StyleAddf(k, format, args...)
+		e = e.StyleAddf(k, format, args...)
 	}
 	return e
 }
@@ -424,21 +416,15 @@ func (e *SVGViewElement) IfStyleAddf(condition bool, k string, format string, ar
 // Specifies an inline CSS style for an element
 // Add the attributes in the map to the element.
 func (e *SVGViewElement) StyleMap(m map[string]string) *SVGViewElement {
-	if e.keyValueStrings == nil {
-		e.keyValueStrings = treemap.New[string, *keyValueBuilder]()
-	}
-	_, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		kv := newKVBuilder(":", ";")
-		e.keyValueStrings.Set("style", kv)
-	}
+	e = e.writable()
+	e.keyValueAttribute("style", ":", ";")
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		e.StylePairs(k, m[k])
+		e = e.StylePairs(k, m[k])
 	}
 	return e
 }
@@ -446,13 +432,7 @@ func (e *SVGViewElement) StyleMap(m map[string]string) *SVGViewElement {
 // Specifies an inline CSS style for an element
 // Remove the attribute Style from the element.
 func (e *SVGViewElement) StyleRemove(keys ...string) *SVGViewElement {
-	if e.keyValueStrings == nil {
-		return e
-	}
-	kv, ok := e.keyValueStrings.Get("style")
-	if !ok {
-		return e
-	}
-	kv.Remove(keys...)
+	e = e.writable()
+	e.removeKeyValues("style", keys...)
 	return e
 }
@@ -0,0 +1,96 @@
+package elements
+
+import (
+	"errors"
+	"html/template"
+	"io"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// TemplateContent executes an html/template template as part of the tree.
+// The template is escaped as if it started in HTML text, so it belongs in the
+// content of normal elements: rendering it in script or style fails with
+// ErrTemplateInRawText.
+type TemplateContent struct {
+	Template *template.Template
+	// Name is the template to execute, the root template when empty.
+	Name string
+	Data any
+}
+
+var ErrTemplateInRawText = errors.New("template in script or style")
+
+func (tc *TemplateContent) Render(w io.Writer) error {
+	rw, owned := asRenderWriter(w)
+	if owned {
+		defer rw.release()
+	}
+	if rw.rawText != escapeContextNone {
+		return rw.fail(ErrTemplateInRawText)
+	}
+	var err error
+	if tc.Name == "" {
+		err = tc.Template.Execute(rw, tc.Data)
+	} else {
+		err = tc.Template.ExecuteTemplate(rw, tc.Name, tc.Data)
+	}
+	if err != nil {
+		return rw.fail(err)
+	}
+	return nil
+}
+
+// Template returns a node executing t with data.
+func Template(t *template.Template, data any) *TemplateContent {
+	return &TemplateContent{
+		Template: t,
+		Data:     data,
+	}
+}
+
+// NamedTemplate returns a node executing the template associated with t that
+// has the given name.
+func NamedTemplate(t *template.Template, name string, data any) *TemplateContent {
+	return &TemplateContent{
+		Template: t,
+		Name:     name,
+		Data:     data,
+	}
+}
+
+// TemplateHTML renders root as trusted HTML for html/template. The content is
+// only trusted in HTML text: html/template still escapes it in attributes,
+// scripts and URLs.
+func (r *Renderer) TemplateHTML(root ElementRenderer) (template.HTML, error) {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+	if err := r.Render(buf, root); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// TemplateHTML renders root with the default options, see
+// Renderer.TemplateHTML.
+func TemplateHTML(root ElementRenderer) (template.HTML, error) {
+	return defaultRenderer.TemplateHTML(root)
+}
+
+// FuncMap returns the template functions rendering trees with the options of
+// the renderer:
+//
+//	{{speckles .Nav}}
+//
+// renders the tree in the Nav field of the data.
+func (r *Renderer) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"speckles": r.TemplateHTML,
+	}
+}
+
+// FuncMap returns the template functions rendering trees with the default
+// options, see Renderer.FuncMap.
+func FuncMap() template.FuncMap {
+	return defaultRenderer.FuncMap()
+}
@@ -0,0 +1,328 @@
+package elements
+
+import (
+	"html"
+	"strings"
+)
+
+type tokenKind uint8
+
+const (
+	textToken tokenKind = iota
+	startTagToken
+	endTagToken
+	commentToken
+	doctypeToken
+	// cdataToken is a CDATA section, which is text in foreign content only.
+	cdataToken
+)
+
+// token is a token of the HTML tokenizer. Text and attribute values are
+// decoded, except for the text of raw text elements. Names are the way they
+// are written, the tree builder lowers their case as needed.
+type token struct {
+	kind        tokenKind
+	data        string
+	attrs       []tokenAttribute
+	selfClosing bool
+}
+
+type tokenAttribute struct {
+	name, value string
+}
+
+// tokenizer splits HTML into tokens, following the tokenization rules of the
+// HTML standard for the parts that matter to the tree: tags, attributes,
+// comments, doctypes, CDATA sections and the text of raw text elements.
+type tokenizer struct {
+	s   string
+	pos int
+	// rawTag is the lowercase tag of the raw text element whose text comes
+	// next, rcdata is set when its character references are decoded.
+	rawTag string
+	rcdata bool
+}
+
+// setRawText makes the text up to the end tag of the element the next token.
+func (z *tokenizer) setRawText(tag string, rcdata bool) {
+	z.rawTag, z.rcdata = tag, rcdata
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// next returns the next token, false at the end of the input.
+func (z *tokenizer) next() (token, bool) {
+	if z.rawTag != "" {
+		return z.rawText()
+	}
+	for z.pos < len(z.s) {
+		if z.s[z.pos] != '<' || z.pos+1 >= len(z.s) {
+			return z.text(), true
+		}
+		switch c := z.s[z.pos+1]; {
+		case isASCIILetter(c):
+			return z.startTag(), true
+		case c == '/':
+			if z.pos+2 < len(z.s) && isASCIILetter(z.s[z.pos+2]) {
+				return z.endTag(), true
+			}
+			if z.pos+2 < len(z.s) && z.s[z.pos+2] == '>' {
+				// </> is dropped.
+				z.pos += 3
+				continue
+			}
+			if z.pos+2 >= len(z.s) {
+				return z.text(), true
+			}
+			// A bogus comment, dropped.
+			z.skipPast(">")
+		case c == '!':
+			if t, ok := z.markupDeclaration(); ok {
+				return t, true
+			}
+		case c == '?':
+			// Processing instructions such as <?xml?> are bogus comments.
+			z.skipPast(">")
+		default:
+			return z.text(), true
+		}
+	}
+	return token{}, false
+}
+
+func (z *tokenizer) skipPast(s string) {
+	if i := strings.Index(z.s[z.pos:], s); i >= 0 {
+		z.pos += i + len(s)
+		return
+	}
+	z.pos = len(z.s)
+}
+
+// text returns the text up to the next markup.
+func (z *tokenizer) text() token {
+	start := z.pos
+	z.pos++
+	for z.pos < len(z.s) {
+		i := strings.IndexByte(z.s[z.pos:], '<')
+		if i < 0 {
+			z.pos = len(z.s)
+			break
+		}
+		z.pos += i
+		if z.pos+1 < len(z.s) {
+			if c := z.s[z.pos+1]; isASCIILetter(c) || c == '/' || c == '!' || c == '?' {
+				break
+			}
+		}
+		z.pos++
+	}
+	return token{kind: textToken, data: unescape(z.s[start:z.pos])}
+}
+
+// rawText returns the text of a raw text element, up to its end tag.
+func (z *tokenizer) rawText() (token, bool) {
+	tag, rcdata := z.rawTag, z.rcdata
+	z.rawTag, z.rcdata = "", false
+	start := z.pos
+	for {
+		i := strings.Index(z.s[z.pos:], "</")
+		if i < 0 {
+			z.pos = len(z.s)
+			break
+		}
+		z.pos += i
+		end := z.pos + 2 + len(tag)
+		if end <= len(z.s) && strings.EqualFold(z.s[z.pos+2:end], tag) &&
+			(end == len(z.s) || isHTMLSpace(z.s[end]) || z.s[end] == '/' || z.s[end] == '>') {
+			break
+		}
+		z.pos += 2
+	}
+	text := z.s[start:z.pos]
+	if rcdata {
+		text = unescape(text)
+	}
+	if text == "" {
+		return z.next()
+	}
+	return token{kind: textToken, data: text}, true
+}
+
+// markupDeclaration returns the comment, doctype or CDATA section starting
+// with <!, bogus comments being dropped.
+func (z *tokenizer) markupDeclaration() (token, bool) {
+	rest := z.s[z.pos+2:]
+	switch {
+	case strings.HasPrefix(rest, "--"):
+		z.pos += 4
+		rest = z.s[z.pos:]
+		// <!--> and <!---> are empty comments.
+		for _, empty := range []string{">", "->"} {
+			if strings.HasPrefix(rest, empty) {
+				z.pos += len(empty)
+				return token{kind: commentToken}, true
+			}
+		}
+		end := strings.Index(rest, "-->")
+		if end < 0 {
+			z.pos = len(z.s)
+			return token{kind: commentToken, data: rest}, true
+		}
+		z.pos += end + 3
+		return token{kind: commentToken, data: rest[:end]}, true
+	case len(rest) >= 7 && strings.EqualFold(rest[:7], "doctype"):
+		z.pos += 9
+		start := z.pos
+		z.skipPast(">")
+		data := strings.TrimSuffix(z.s[start:z.pos], ">")
+		return token{kind: doctypeToken, data: strings.TrimSpace(data)}, true
+	case strings.HasPrefix(rest, "[CDATA["):
+		z.pos += 9
+		rest = z.s[z.pos:]
+		end := strings.Index(rest, "]]>")
+		if end < 0 {
+			z.pos = len(z.s)
+			return token{kind: cdataToken, data: rest}, true
+		}
+		z.pos += end + 3
+		return token{kind: cdataToken, data: rest[:end]}, true
+	}
+	z.skipPast(">")
+	return token{}, false
+}
+
+func (z *tokenizer) tagName() string {
+	start := z.pos
+	for z.pos < len(z.s) && !isHTMLSpace(z.s[z.pos]) && z.s[z.pos] != '/' && z.s[z.pos] != '>' {
+		z.pos++
+	}
+	return replaceNUL(z.s[start:z.pos])
+}
+
+// replaceNUL replaces the NUL characters of a tag or attribute name with
+// U+FFFD, as the HTML standard does.
+func replaceNUL(name string) string {
+	return strings.ReplaceAll(name, "\x00", "\uFFFD")
+}
+
+// unescape decodes the character references of s. A numeric reference
+// without digits is text, html.UnescapeString decodes &#x; to U+FFFD though,
+// so it is left out of the decoding.
+func unescape(s string) string {
+	var sb strings.Builder
+	for {
+		i := indexEmptyHexReference(s)
+		if i < 0 {
+			break
+		}
+		sb.WriteString(html.UnescapeString(s[:i]))
+		sb.WriteString(s[i : i+4])
+		s = s[i+4:]
+	}
+	if sb.Len() == 0 {
+		return html.UnescapeString(s)
+	}
+	sb.WriteString(html.UnescapeString(s))
+	return sb.String()
+}
+
+// indexEmptyHexReference returns the index of the first &#x; in s, or -1.
+func indexEmptyHexReference(s string) int {
+	for offset := 0; ; {
+		i := strings.Index(s[offset:], "&#")
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		if i+3 < len(s) && (s[i+2] == 'x' || s[i+2] == 'X') && s[i+3] == ';' {
+			return i
+		}
+		offset = i + 2
+	}
+}
+
+func (z *tokenizer) endTag() token {
+	z.pos += 2
+	t := token{kind: endTagToken, data: z.tagName()}
+	// Attributes of end tags are dropped.
+	z.attributes(&t)
+	return t
+}
+
+func (z *tokenizer) startTag() token {
+	z.pos++
+	t := token{kind: startTagToken, data: z.tagName()}
+	z.attributes(&t)
+	return t
+}
+
+// attributes reads the attributes of a tag, up to and including the >.
+func (z *tokenizer) attributes(t *token) {
+	for z.pos < len(z.s) {
+		c := z.s[z.pos]
+		switch {
+		case c == '>':
+			z.pos++
+			return
+		case c == '/':
+			z.pos++
+			if z.pos < len(z.s) && z.s[z.pos] == '>' {
+				t.selfClosing = true
+			}
+			continue
+		case isHTMLSpace(c):
+			z.pos++
+			continue
+		}
+
+		// The name may start with =, it ends at the next =.
+		start := z.pos
+		z.pos++
+		for z.pos < len(z.s) && !isHTMLSpace(z.s[z.pos]) && strings.IndexByte("/>=", z.s[z.pos]) < 0 {
+			z.pos++
+		}
+		a := tokenAttribute{name: replaceNUL(z.s[start:z.pos])}
+		z.skipSpace()
+		if z.pos < len(z.s) && z.s[z.pos] == '=' {
+			z.pos++
+			z.skipSpace()
+			a.value = unescape(z.attributeValue())
+		}
+		t.attrs = append(t.attrs, a)
+	}
+}
+
+func (z *tokenizer) skipSpace() {
+	for z.pos < len(z.s) && isHTMLSpace(z.s[z.pos]) {
+		z.pos++
+	}
+}
+
+func (z *tokenizer) attributeValue() string {
+	if z.pos >= len(z.s) {
+		return ""
+	}
+	if q := z.s[z.pos]; q == '"' || q == '\'' {
+		z.pos++
+		end := strings.IndexByte(z.s[z.pos:], q)
+		if end < 0 {
+			v := z.s[z.pos:]
+			z.pos = len(z.s)
+			return v
+		}
+		v := z.s[z.pos : z.pos+end]
+		z.pos += end + 1
+		return v
+	}
+	start := z.pos
+	for z.pos < len(z.s) && !isHTMLSpace(z.s[z.pos]) && z.s[z.pos] != '>' {
+		z.pos++
+	}
+	return z.s[start:z.pos]
+}
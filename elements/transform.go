@@ -0,0 +1,90 @@
+package elements
+
+import (
+	"context"
+	"slices"
+)
+
+// Transformer modifies an element before it is rendered, see
+// Renderer.Transformers. The element is a copy made for the render pass: its
+// attributes and children can be changed freely, the changes to its children
+// themselves are not undone though.
+type Transformer func(ctx context.Context, e *Element)
+
+// transform returns a copy of e modified by the transformers.
+func (e *Element) transform(ctx context.Context, transformers []Transformer) *Element {
+	c := e.shallowCopy()
+	c.original = e.source()
+	for _, t := range transformers {
+		t(ctx, c)
+	}
+	return c
+}
+
+// shallowCopy copies e along with its attributes and the list of its
+// children, but not the children. The copy is not frozen.
+func (e *Element) shallowCopy() *Element {
+	c := *e
+	c.original = nil
+	c.frozen = false
+	c.attributes = cloneAttributes(e.attributes)
+	c.descendants = slices.Clone(e.descendants)
+	return &c
+}
+
+func cloneAttributes(attributes []attribute) []attribute {
+	c := slices.Clone(attributes)
+	for i := range c {
+		a := &c[i]
+		switch a.kind {
+		case attributeDelimited:
+			ds := *a.delimited
+			ds.values = slices.Clone(ds.values)
+			a.delimited = &ds
+		case attributeKeyValue:
+			kv := newKVBuilder(a.keyValue.keyPairDelimiter, a.keyValue.entryDelimiter)
+			for _, v := range a.keyValue.values {
+				kv.Add(v.Key, v.Value)
+			}
+			a.keyValue = kv
+		}
+	}
+	return c
+}
+
+// source returns the element of the tree e is a copy of, e itself when it is
+// not a copy.
+func (e *Element) source() *Element {
+	if e.original != nil {
+		return e.original
+	}
+	return e
+}
+
+// sameTransformers reports whether r and o apply the same chain, as told by
+// their TransformersKey.
+func (r *Renderer) sameTransformers(o *Renderer) bool {
+	if len(r.Transformers) == 0 || len(o.Transformers) == 0 {
+		return len(r.Transformers) == len(o.Transformers)
+	}
+	return r.TransformersKey != "" && r.TransformersKey == o.TransformersKey
+}
+
+// replayable reports whether stored fragments can be used with the
+// transformers of r.
+func (r *Renderer) replayable() bool {
+	return len(r.Transformers) == 0 || r.TransformersKey != ""
+}
+
+// AddClass adds classes to the element, which is useful to transformers as
+// Element has no typed Class setter.
+func (e *Element) AddClass(classes ...string) *Element {
+	e = e.writable()
+	ds := e.delimitedAttribute("class", " ")
+	for _, class := range classes {
+		if !slices.Contains(ds.values, class) {
+			ds.Add(class)
+		}
+	}
+	return e
+}
@@ -0,0 +1,55 @@
+package elements
+
+// A Visitor's Visit method is called for each node met by Walk. If the
+// returned visitor w is not nil, Walk visits each child of the node with w.
+type Visitor interface {
+	Visit(node ElementRenderer) (w Visitor)
+}
+
+// Walk traverses the tree in depth-first order. It calls v.Visit(node) and,
+// unless the returned visitor is nil, walks the children of elements, groups
+// and documents with it. Other nodes, such as text, components and compiled
+// content, have no children to walk. Nil children are skipped.
+func Walk(root ElementRenderer, v Visitor) {
+	if root == nil {
+		return
+	}
+	if v = v.Visit(root); v == nil {
+		return
+	}
+	for _, child := range nodeChildren(root) {
+		Walk(child, v)
+	}
+}
+
+type inspector func(ElementRenderer) bool
+
+func (f inspector) Visit(node ElementRenderer) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the tree in depth-first order, calling fn for each node.
+// The children of a node are skipped when fn returns false.
+func Inspect(root ElementRenderer, fn func(node ElementRenderer) bool) {
+	Walk(root, inspector(fn))
+}
+
+// nodeChildren returns the children walked by Walk.
+func nodeChildren(node ElementRenderer) []ElementRenderer {
+	switch n := node.(type) {
+	case *Grouper:
+		if n != nil {
+			return n.Children
+		}
+	case *DocumentContent:
+		if n != nil {
+			return n.Children
+		}
+	case interface{ baseElement() *Element }:
+		return n.baseElement().descendants
+	}
+	return nil
+}
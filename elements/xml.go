@@ -0,0 +1,24 @@
+package elements
+
+const xlinkNamespaceURI = "http://www.w3.org/1999/xlink"
+
+// hasChildren reports whether any child is left once nil children and empty
+// Groupers are skipped.
+func hasChildren(children []ElementRenderer) bool {
+	return !eachChild(children, func(ElementRenderer) bool { return false })
+}
+
+// usesXLink reports whether e or one of its descendants in the same namespace
+// has an attribute in the xlink namespace.
+func usesXLink(e *Element) bool {
+	if e.hasAttributePrefix("xlink:") {
+		return true
+	}
+	return !eachChild(e.descendants, func(child ElementRenderer) bool {
+		el, ok := child.(interface{ baseElement() *Element })
+		if !ok || el.baseElement().namespace != e.namespace {
+			return true
+		}
+		return !usesXLink(el.baseElement())
+	})
+}
@@ -17,8 +17,4 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-require (
-	github.com/iancoleman/strcase v0.3.0
-	github.com/igrmk/treemap/v2 v2.0.1
-	golang.org/x/exp v0.0.0-20231110203233-9a3e6036ecaa // indirect
-)
+require github.com/iancoleman/strcase v0.3.0
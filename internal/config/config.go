@@ -11,8 +11,12 @@ type Namespace struct {
 	Name        string
 	Description string
 	Prefix      string
+	URI         string
 	Elements    []*Element
 	Attributes  []*Attribute
+	// EscapeContexts holds the escape contexts of attributes any element of
+	// the namespace may carry without them being configured.
+	EscapeContexts map[string]string
 }
 
 type Element struct {
@@ -36,7 +40,9 @@ type (
 	attributeTypeBool      struct{}
 	attributeTypeRune      struct{}
 	attributeTypeInt       struct{}
-	attributeTypeNumber    struct{}
+	attributeTypeNumber    struct {
+		Precision int
+	}
 	attributeTypeString    struct{}
 	attributeTypeDelimited struct {
 		Delimiter string
@@ -85,6 +91,21 @@ func AttributeTypeNumber() *attributeTypeNumber {
 	return &attributeTypeNumber{}
 }
 
+// AttributeTypeNumberPrecision is a number rendered with at most precision
+// decimal places, for values where more digits are noise.
+func AttributeTypeNumberPrecision(precision int) *attributeTypeNumber {
+	return &attributeTypeNumber{Precision: precision}
+}
+
+// AttributeNumberPrecision returns the precision hint of a number attribute,
+// zero when there is none.
+func AttributeNumberPrecision(attributeType AttributeType) int {
+	if t, ok := attributeType.(*attributeTypeNumber); ok {
+		return t.Precision
+	}
+	return 0
+}
+
 func IsAttributeTypeNumber(attributeType AttributeType) bool {
 	_, ok := attributeType.(*attributeTypeNumber)
 	return ok
@@ -108,6 +129,15 @@ func AttributeTypeDelimited(delimiter string) *attributeTypeDelimited {
 	return &attributeTypeDelimited{Delimiter: delimiter}
 }
 
+// AttributeDelimiter returns the delimiter of a delimited attribute, an empty
+// string for the other types.
+func AttributeDelimiter(attributeType AttributeType) string {
+	if t, ok := attributeType.(*attributeTypeDelimited); ok {
+		return t.Delimiter
+	}
+	return ""
+}
+
 func AttributeTypeSpaceDelimited() *attributeTypeDelimited {
 	return AttributeTypeDelimited(" ")
 }
@@ -141,6 +171,15 @@ func IsAttributeTypeKeyValue(attributeType AttributeType) bool {
 	return ok
 }
 
+// AttributeKeyValueDelimiters returns the delimiters of a key-value
+// attribute, empty strings for the other types.
+func AttributeKeyValueDelimiters(attributeType AttributeType) (keyValueDelimiter, pairDelimiter string) {
+	if t, ok := attributeType.(*attributeTypeKeyValue); ok {
+		return t.KeyValueDelimiter, t.PairDelimiter
+	}
+	return "", ""
+}
+
 func AttributeTypeKeyValueColonSemicolon() *attributeTypeKeyValue {
 	return AttributeTypeKeyValue(":", ";")
 }
@@ -170,6 +209,47 @@ func ChoiceSuffix(choiceName string, choices []*attributeTypeChoice) string {
 	return caser.GoPascal(choiceName)
 }
 
+// Escape contexts of attribute values, used by the contextual escaping mode of
+// the renderer.
+const (
+	EscapeContextNone    = ""
+	EscapeContextURL     = "URL"
+	EscapeContextURLList = "URLList"
+	EscapeContextSrcset  = "Srcset"
+	EscapeContextSrcdoc  = "Srcdoc"
+	EscapeContextCSS     = "CSS"
+	EscapeContextJS      = "JS"
+)
+
+var urlAttributeContexts = map[string]string{
+	"action":     EscapeContextURL,
+	"cite":       EscapeContextURL,
+	"data":       EscapeContextURL,
+	"formaction": EscapeContextURL,
+	"href":       EscapeContextURL,
+	"itemid":     EscapeContextURL,
+	"itemtype":   EscapeContextURLList,
+	"ping":       EscapeContextURLList,
+	"poster":     EscapeContextURL,
+	"src":        EscapeContextURL,
+	"srcdoc":     EscapeContextSrcdoc,
+	"srcset":     EscapeContextSrcset,
+	"xlink:href": EscapeContextURL,
+}
+
+// AttributeEscapeContext returns the escape context of the attribute value,
+// derived from the attribute key and type.
+func AttributeEscapeContext(attr *Attribute) string {
+	key := strings.ToLower(attr.Key)
+	switch {
+	case len(key) > 2 && strings.HasPrefix(key, "on"):
+		return EscapeContextJS
+	case key == "style" && IsAttributeTypeKeyValue(attr.Type):
+		return EscapeContextCSS
+	}
+	return urlAttributeContexts[key]
+}
+
 func Namespaces() []*Namespace {
 	return []*Namespace{HTML, SVG, MathML}
 }
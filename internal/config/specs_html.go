@@ -2,6 +2,7 @@ package config
 
 var HTML = &Namespace{
 	Name:        "html",
+	URI:         "http://www.w3.org/1999/xhtml",
 	Description: `HTML (HyperText Markup Language) is the most basic building block of the Web. It defines the meaning and structure of web content. Other technologies besides HTML are generally used to describe a web page's appearance/presentation (CSS) or functionality/behavior (JavaScript). "Hypertext" refers to links that connect web pages to one another, either within a single website or between websites. Links are a fundamental aspect of the Web. By uploading content to the Internet and linking it to pages created by other people, you become an active participant in the World Wide Web.`,
 	Attributes: []*Attribute{
 		{
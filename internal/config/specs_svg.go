@@ -4,6 +4,13 @@ var SVG = &Namespace{
 	Name:        "svg",
 	Description: `Scalable Vector Graphics (SVG) is an XML-based markup language for describing two-dimensional based vector graphics. As such, it's a text-based, open Web standard for describing images that can be rendered cleanly at any size and are designed specifically to work well with other web standards including CSS, DOM, JavaScript, and SMIL. SVG is, essentially, to graphics what HTML is to text. SVG images and their related behaviors are defined in XML text files, which means they can be searched, indexed, scripted, and compressed. Additionally, this means they can be created and edited with any text editor or with drawing software. Compared to classic bitmapped image formats such as JPEG or PNG, SVG-format vector images can be rendered at any size without loss of quality and can be easily localized by updating the text within them, without the need of a graphical editor to do so. With proper libraries, SVG files can even be localized on-the-fly.`,
 	Prefix:      "SVG",
+	URI:         "http://www.w3.org/2000/svg",
+	// Links, images, uses and animations of SVG take URLs, in the legacy
+	// xlink:href too.
+	EscapeContexts: map[string]string{
+		"href":       EscapeContextURL,
+		"xlink:href": EscapeContextURL,
+	},
 	Attributes: []*Attribute{
 		{
 			Key:         "id",
@@ -862,7 +869,7 @@ var SVG = &Namespace{
 				{
 					Key:         "flood-opacity",
 					Description: `The flood-opacity Attribute indicates the opacity value to use across the current filter primitive subregion defined through the <feFlood> element.`,
-					Type:        AttributeTypeNumber(),
+					Type:        AttributeTypeNumberPrecision(3),
 				},
 			},
 		},
@@ -879,7 +886,7 @@ var SVG = &Namespace{
 				{
 					Key:         "flood-opacity",
 					Description: `The flood-opacity Attribute indicates the opacity value to use across the current filter primitive subregion defined through the <feFlood> element.`,
-					Type:        AttributeTypeNumber(),
+					Type:        AttributeTypeNumberPrecision(3),
 				},
 			},
 		},
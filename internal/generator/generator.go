@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -20,18 +21,15 @@ var templatesFS embed.FS
 
 var templs *template.Template
 
+const generatedHeader = "// Code generated by speckles. DO NOT EDIT.\n"
+
 func GenerateAll(ctx context.Context, outPath string, namespaces []*config.Namespace) (err error) {
 	if len(namespaces) == 0 {
 		return fmt.Errorf("no namespaces specified")
 	}
 
-	files, _ := os.ReadDir(outPath)
-	for _, file := range files {
-		if file.Name() != "builder.go" {
-			if err := os.Remove(filepath.Join(outPath, file.Name())); err != nil {
-				return fmt.Errorf("failed to clean output directory: %w", err)
-			}
-		}
+	if err := cleanGenerated(outPath); err != nil {
+		return fmt.Errorf("failed to clean output directory: %w", err)
 	}
 
 	fm := template.FuncMap{}
@@ -43,6 +41,7 @@ func GenerateAll(ctx context.Context, outPath string, namespaces []*config.Names
 	fm["attrIsString"] = config.IsAttributeTypeString
 	fm["attrIsInt"] = config.IsAttributeTypeInt
 	fm["attrIsNumber"] = config.IsAttributeTypeNumber
+	fm["attrPrecision"] = config.AttributeNumberPrecision
 	fm["attrIsDelimited"] = config.IsAttributeTypeDelimited
 	fm["attrIsKV"] = config.IsAttributeTypeKeyValue
 	fm["attrIsChoices"] = config.IsAttributeTypeChoices
@@ -52,6 +51,10 @@ func GenerateAll(ctx context.Context, outPath string, namespaces []*config.Names
 		return fmt.Errorf("failed to parse templates: %w", err)
 	}
 
+	if err := generateMetadata(ctx, outPath, namespaces); err != nil {
+		return fmt.Errorf("failed to generate metadata: %w", err)
+	}
+
 	for _, ns := range namespaces {
 		for _, element := range ns.Elements {
 			if err := generateElement(ctx, outPath, ns, element); err != nil {
@@ -63,6 +66,248 @@ func GenerateAll(ctx context.Context, outPath string, namespaces []*config.Names
 	return nil
 }
 
+// cleanGenerated removes the previously generated files from outPath, leaving
+// the hand-written ones in place.
+func cleanGenerated(outPath string) error {
+	files, err := os.ReadDir(outPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".go" {
+			continue
+		}
+		path := filepath.Join(outPath, file.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(string(b), generatedHeader) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type attributeContext struct {
+	Key     string
+	Context string
+}
+
+type elementContexts struct {
+	Tag        string
+	Attributes []attributeContext
+}
+
+type elementConstructor struct {
+	Key         string
+	Constructor string
+}
+
+// attributeType is the Go literal of the elements.attributeType of an
+// attribute, keyed by lowercase name.
+type attributeType struct {
+	Key     string
+	Literal string
+}
+
+type elementAttributeTypes struct {
+	Tag        string
+	Attributes []attributeType
+}
+
+type namespaceMetadata struct {
+	Const                 string
+	URI                   string
+	Constructors          []elementConstructor
+	GlobalAttributeTypes  []attributeType
+	ElementAttributeTypes []elementAttributeTypes
+}
+
+// attributeKey returns the key of attr, which defaults to its name.
+func attributeKey(attr *config.Attribute) string {
+	if attr.Key == "" {
+		return attr.Name
+	}
+	return attr.Key
+}
+
+// attributeTypeLiteral returns the literal describing how the parser stores the
+// value of attr. Plain string attributes only need one when their key is not
+// lowercase.
+func attributeTypeLiteral(attr *config.Attribute) (string, bool) {
+	key := attributeKey(attr)
+	var kind, extra string
+	switch t := attr.Type; {
+	case config.IsAttributeTypeBool(t):
+		kind = "valueBool"
+	case config.IsAttributeTypeChoices(t):
+		kind = "valueChoice"
+	case config.IsAttributeTypeRune(t):
+		kind = "valueRune"
+	case config.IsAttributeTypeInt(t):
+		kind = "valueInt"
+	case config.IsAttributeTypeNumber(t):
+		kind = "valueNumber"
+		if p := config.AttributeNumberPrecision(t); p > 0 {
+			extra = fmt.Sprintf(", precision: %d", p)
+		}
+	case config.IsAttributeTypeDelimited(t):
+		kind = "valueDelimited"
+		extra = fmt.Sprintf(", delimiter: %q", config.AttributeDelimiter(t))
+	case config.IsAttributeTypeKeyValue(t):
+		kind = "valueKeyValue"
+		kv, pair := config.AttributeKeyValueDelimiters(t)
+		extra = fmt.Sprintf(", keyValueDelimiter: %q, delimiter: %q", kv, pair)
+	default:
+		if key == strings.ToLower(key) {
+			return "", false
+		}
+		kind = "valueString"
+	}
+	return fmt.Sprintf("{name: %q, kind: %s%s}", key, kind, extra), true
+}
+
+// attributeTypes returns the literals of the attributes needing one, the first
+// attribute wins when several have the same lowercase key.
+func attributeTypes(attrs []*config.Attribute) []attributeType {
+	var out []attributeType
+	seen := map[string]bool{}
+	for _, attr := range attrs {
+		key := strings.ToLower(attributeKey(attr))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if literal, ok := attributeTypeLiteral(attr); ok {
+			out = append(out, attributeType{Key: key, Literal: literal})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// generateMetadata writes the lookup tables the renderer needs about the
+// configured elements and attributes. It must run before the attributes of the
+// elements are merged with the namespace attributes.
+func generateMetadata(_ context.Context, pkgPath string, namespaces []*config.Namespace) error {
+	global := map[string]string{}
+	elements := map[string]map[string]string{}
+	for _, ns := range namespaces {
+		for _, attr := range ns.Attributes {
+			if c := config.AttributeEscapeContext(attr); c != config.EscapeContextNone {
+				global[attr.Key] = c
+			}
+		}
+		for key, c := range ns.EscapeContexts {
+			global[key] = c
+		}
+		for _, element := range ns.Elements {
+			for _, attr := range element.Attributes {
+				c := config.AttributeEscapeContext(attr)
+				if c == config.EscapeContextNone {
+					continue
+				}
+				if elements[element.Tag] == nil {
+					elements[element.Tag] = map[string]string{}
+				}
+				elements[element.Tag][attr.Key] = c
+			}
+		}
+	}
+
+	toSorted := func(m map[string]string) []attributeContext {
+		out := make([]attributeContext, 0, len(m))
+		for k, c := range m {
+			out = append(out, attributeContext{Key: k, Context: c})
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+		return out
+	}
+
+	templateData := struct {
+		Namespaces      []namespaceMetadata
+		GlobalContexts  []attributeContext
+		ElementContexts []elementContexts
+	}{
+		GlobalContexts: toSorted(global),
+	}
+	for _, ns := range namespaces {
+		prefix := ns.Prefix
+		if prefix == "" {
+			prefix = "HTML"
+		}
+		data := namespaceMetadata{
+			Const:                "namespace" + prefix,
+			URI:                  ns.URI,
+			GlobalAttributeTypes: attributeTypes(ns.Attributes),
+		}
+		seen := map[string]bool{}
+		for _, element := range ns.Elements {
+			name := element.Name
+			if name == "" {
+				name = element.Tag
+			}
+			// Elements configured twice keep their first definition.
+			key := strings.ToLower(element.Tag)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			data.Constructors = append(data.Constructors, elementConstructor{
+				Key:         key,
+				Constructor: ns.Prefix + caser.GoPascal(name),
+			})
+			if types := attributeTypes(element.Attributes); len(types) > 0 {
+				data.ElementAttributeTypes = append(data.ElementAttributeTypes, elementAttributeTypes{
+					Tag:        element.Tag,
+					Attributes: types,
+				})
+			}
+		}
+		sort.Slice(data.Constructors, func(i, j int) bool { return data.Constructors[i].Key < data.Constructors[j].Key })
+		sort.Slice(data.ElementAttributeTypes, func(i, j int) bool {
+			return data.ElementAttributeTypes[i].Tag < data.ElementAttributeTypes[j].Tag
+		})
+		templateData.Namespaces = append(templateData.Namespaces, data)
+	}
+	for tag, attrs := range elements {
+		templateData.ElementContexts = append(templateData.ElementContexts, elementContexts{
+			Tag:        tag,
+			Attributes: toSorted(attrs),
+		})
+	}
+	sort.Slice(templateData.ElementContexts, func(i, j int) bool {
+		return templateData.ElementContexts[i].Tag < templateData.ElementContexts[j].Tag
+	})
+
+	return executeTemplate(filepath.Join(pkgPath, "metadata.go"), "metadata.go.tmpl", templateData)
+}
+
+func executeTemplate(path, templateName string, data any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	header := generatedHeader +
+		"// Source: generator/templates/" + templateName + "\n" +
+		"\n"
+	if _, err := f.WriteString(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	if err := templs.ExecuteTemplate(f, templateName, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
+
 func generateElement(_ context.Context, pkgPath string, ns *config.Namespace, element *config.Element) error {
 	if element.Name == "" {
 		element.Name = element.Tag
@@ -82,21 +327,6 @@ func generateElement(_ context.Context, pkgPath string, ns *config.Namespace, el
 	filename := fmt.Sprintf("%s_%s.go", prefix, suffix)
 	elementFilepath := filepath.Join(pkgPath, filename)
 
-	f, err := os.Create(elementFilepath)
-	if err != nil {
-		return fmt.Errorf("failed to create file %s: %w", elementFilepath, err)
-	}
-	defer f.Close()
-
-	templateName := "element.go.tmpl"
-
-	header := "// Code generated by speckles. DO NOT EDIT.\n" +
-		"// Source: generator/templates/" + templateName + "\n" +
-		"\n"
-	if _, err := f.WriteString(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
-	}
-
 	templateData := struct {
 		Namespace *config.Namespace
 		Element   *config.Element
@@ -105,11 +335,7 @@ func generateElement(_ context.Context, pkgPath string, ns *config.Namespace, el
 		Element:   element,
 	}
 
-	if err := templs.ExecuteTemplate(f, templateName, templateData); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
-	}
-
-	return nil
+	return executeTemplate(elementFilepath, "element.go.tmpl", templateData)
 }
 
 func processAttributes(element *config.Element, ns *config.Namespace) []*config.Attribute {
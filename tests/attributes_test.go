@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"strings"
 	"testing"
 
 	. "github.com/aprikotdev/speckles/elements"
@@ -162,3 +163,100 @@ func TestCommaDelimitedAttributes(t *testing.T) {
 		},
 	})
 }
+
+func TestAttributeValueEscaping(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<div title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"></div>`,
+			Actual:   Div().Attr("title", `"><script>alert(1)</script>`),
+		},
+		{
+			Expected: `<a href="/search?q=a&amp;b=&#34;c&#34;"></a>`,
+			Actual:   A().Href(`/search?q=a&b="c"`),
+		},
+		{
+			Expected: `<div class="a&#34; onclick=&#34;x b"></div>`,
+			Actual:   Div().Class(`a" onclick="x b`),
+		},
+		{
+			Expected: `<div style="content:&#34;&gt;&#34;"></div>`,
+			Actual:   Div().StyleAdd("content", `">"`),
+		},
+		{
+			Expected: `<a accesskey="&#34;"></a>`,
+			Actual:   A().Accesskey('"'),
+		},
+		{
+			Expected: `<p title="it's"></p>`,
+			Actual:   P().Attr("title", "it's"),
+		},
+	})
+}
+
+func TestAttributeNameValidation(t *testing.T) {
+	invalid := []ElementRenderer{
+		Div().Attr("", "x"),
+		Div().Attr("on click", "x"),
+		Div().Attr(`x"`, "x"),
+		Div().BoolAttr("a>b"),
+		Div().BoolAttr("a/b"),
+		Div().Attrs("ok", "1", "a=b", "2"),
+		Div().AttrsMap(map[string]string{"a'b": "1"}),
+		Div().Attr("a\x00b", "x"),
+		Div().Attr("a﷐", "x"),
+		Div().Attr("a\xffb", "x"),
+		Div(Span().Attr("bad name", "x")),
+	}
+	for _, e := range invalid {
+		var sb strings.Builder
+		err := e.Render(&sb)
+		assert.ErrorIs(t, err, ErrInvalidAttributeName)
+	}
+
+	run(t, []result{
+		{
+			Expected: `<div :class="{ active }" @click="open = true" data-x.y></div>`,
+			Actual:   Div().Attr("@click", "open = true").Attr(":class", "{ active }").BoolAttr("data-x.y"),
+		},
+	})
+}
+
+func TestAttributeStore(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<img alt="" src="a.png">`,
+			Actual:   Img().Src("a.png").Attr("alt", ""),
+		},
+		{
+			Expected: `<div hidden></div>`,
+			Actual:   Div().Attr("hidden", "x").BoolAttr("hidden"),
+		},
+		{
+			Expected: `<div hidden="until-found"></div>`,
+			Actual:   Div().BoolAttr("hidden").Attr("hidden", "until-found"),
+		},
+		{
+			Expected: `<div class="a b c"></div>`,
+			Actual:   Div().Attr("class", "a b").Class("c"),
+		},
+		{
+			Expected: `<div class="b"></div>`,
+			Actual:   Div().Class("a b").ClassRemove("a"),
+		},
+		{
+			Expected: `<td colspan="3" data-a="1" id="x" z="2.5"></td>`,
+			Actual:   Td().ID("x").Attr("z", "2.5").Colspan(3).Attr("data-a", "1").Attr("data-b", "1").BoolAttrRemove("data-b"),
+		},
+		{
+			Expected: `<svg><circle cx="1.5" cy="-2" r="0.001"></circle></svg>`,
+			Actual:   SVGSVG(SVGCircle().Cx(1.5).Cy(-2).R(0.001)),
+		},
+	})
+
+	runWith(t, &Renderer{XML: true}, []result{
+		{
+			Expected: `<div alt="" hidden="" xmlns="http://www.w3.org/1999/xhtml"/>`,
+			Actual:   Div().Attr("alt", "").Hidden(DivHiddenEmpty),
+		},
+	})
+}
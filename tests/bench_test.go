@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"io"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+)
+
+func benchmarkPage() ElementRenderer {
+	rows := make([]int, 20)
+	for i := range rows {
+		rows[i] = i
+	}
+	return Document(
+		HTML(
+			Head(Title().Text("Benchmark")),
+			Body(
+				Div().ID("main").Class("container fluid").Style("color: red; margin: 0").Children(
+					Input().Type(InputTypeNumber).Name("count").Placeholder("0").Attr("value", "").Disabled(),
+					Table(RangeI(rows, func(i, row int) ElementRenderer {
+						return Tr().Class("row").Attr("data-row", "r").Children(
+							Td().Colspan(2).Text("cell"),
+							Td(SVGSVG(SVGCircle().Cx(1.5).Cy(2.25).R(float64(row)))),
+						)
+					})),
+				),
+			),
+		),
+	)
+}
+
+func BenchmarkRender(b *testing.B) {
+	page := benchmarkPage()
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := page.Render(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRenderElement(b *testing.B) {
+	div := A().ID("link").Class("btn primary").Href("/path").Tabindex(1).Attr("title", "a & b").Download("file.txt")
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := div.Render(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRenderCompiled(b *testing.B) {
+	page := Static(benchmarkPage()).Fill(map[string]ElementRenderer{})
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := page.Render(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
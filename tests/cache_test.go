@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCached(t *testing.T) {
+	cache := NewLRUCache(16)
+	r := &Renderer{Cache: cache}
+	calls := 0
+	card := func(id string) ElementRenderer {
+		return Cached("card:"+id, 0, func() ElementRenderer {
+			calls++
+			return Div().Class("card").Textf("product %s, render %d", id, calls)
+		}).Tags("product:" + id)
+	}
+
+	runWith(t, r, []result{
+		{
+			Expected: `<section><div class="card">product 42, render 1</div><div class="card">product 7, render 2</div></section>`,
+			Actual:   Section(card("42"), card("7")),
+		},
+		{
+			Expected: `<section><div class="card">product 42, render 1</div><div class="card">product 7, render 2</div></section>`,
+			Actual:   Section(card("42"), card("7")),
+		},
+	})
+	assert.Equal(t, 2, calls)
+
+	cache.Invalidate("product:42")
+	runWith(t, r, []result{
+		{
+			Expected: `<section><div class="card">product 42, render 3</div><div class="card">product 7, render 2</div></section>`,
+			Actual:   Section(card("42"), card("7")),
+		},
+	})
+	assert.Equal(t, 3, calls)
+
+	// Other options render their own fragments.
+	runWith(t, &Renderer{Cache: cache, XML: true}, []result{
+		{
+			Expected: `<div class="card" xmlns="http://www.w3.org/1999/xhtml">product 7, render 4</div>`,
+			Actual:   card("7"),
+		},
+	})
+	assert.Equal(t, 3, cache.Len())
+}
+
+func TestCachedErrorIsNotStored(t *testing.T) {
+	cache := NewLRUCache(16)
+	r := &Renderer{Cache: cache}
+	var sb strings.Builder
+	err := r.Render(&sb, Div(Cached("bad", 0, func() ElementRenderer {
+		return Span().Attr("a b", "c")
+	})))
+	assert.ErrorIs(t, err, ErrInvalidAttributeName)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", []byte("1"), 0, nil)
+	cache.Set("b", []byte("2"), 0, []string{"t"})
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	// b is the least recently used.
+	cache.Set("c", []byte("3"), 0, []string{"t"})
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Invalidate("t")
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+	v, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", string(v))
+
+	cache.Set("d", []byte("4"), time.Millisecond, nil)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = cache.Get("d")
+	assert.False(t, ok)
+
+	cache.Delete("a")
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	base := Button().Class("btn").Type(ButtonTypeButton).StyleAdd("color", "red")
+	primary := base.Clone().Class("primary").StyleAdd("margin", "0").Text("OK")
+	run(t, []result{
+		{
+			Expected: `<button class="btn" style="color:red" type="button"></button>`,
+			Actual:   base,
+		},
+		{
+			Expected: `<button class="btn primary" style="color:red;margin:0" type="button">OK</button>`,
+			Actual:   primary,
+		},
+	})
+
+	tree := Div(Ul(Li().Class("item").Text("a")), Group(P()))
+	clone := tree.Clone()
+	MustQuerySelector(clone, "li").AddClass("active")
+	MustQuerySelector(clone, "p").Attr("id", "x")
+	run(t, []result{
+		{
+			Expected: `<div><ul><li class="item">a</li></ul><p></p></div>`,
+			Actual:   tree,
+		},
+		{
+			Expected: `<div><ul><li class="item active">a</li></ul><p id="x"></p></div>`,
+			Actual:   clone,
+		},
+	})
+	// The copies keep their types.
+	assert.IsType(t, &UlElement{}, clone.GetChildren()[0])
+
+	shallow := tree.CloneShallow().Children(Span())
+	MustQuerySelector(shallow, "li").Attr("title", "shared")
+	run(t, []result{
+		{
+			Expected: `<div><ul><li class="item" title="shared">a</li></ul><p></p></div>`,
+			Actual:   tree,
+		},
+		{
+			Expected: `<div><ul><li class="item" title="shared">a</li></ul><p></p><span></span></div>`,
+			Actual:   shallow,
+		},
+	})
+}
+
+func TestFreeze(t *testing.T) {
+	base := Button().Class("btn").Type(ButtonTypeButton).Freeze()
+	assert.True(t, base.Frozen())
+
+	primary := base.Class("primary").ID("ok").Text("OK")
+	danger := base.IfClass(true, "danger").IfDisabled(false).StyleAdd("color", "red")
+	assert.NotSame(t, base, primary)
+	assert.False(t, primary.Frozen())
+	assert.Same(t, base, base.IfClass(false, "unused"))
+	run(t, []result{
+		{
+			Expected: `<button class="btn" type="button"></button>`,
+			Actual:   base,
+		},
+		{
+			Expected: `<button class="btn primary" id="ok" type="button">OK</button>`,
+			Actual:   primary,
+		},
+		{
+			Expected: `<button class="btn danger" style="color:red" type="button"></button>`,
+			Actual:   danger,
+		},
+	})
+
+	el := NewElement("x-card").Freeze()
+	titled := el.Attr("title", "t").AddClass("c")
+	run(t, []result{
+		{
+			Expected: `<x-card></x-card>`,
+			Actual:   el,
+		},
+		{
+			Expected: `<x-card class="c" title="t"></x-card>`,
+			Actual:   titled,
+		},
+	})
+}
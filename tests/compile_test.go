@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+var layout = Static(Document(HTML(
+	Head(Title(Hole("title"))),
+	Body(
+		Nav(A().Href("/").Text("Home")),
+		Main(Hole("content")),
+	),
+)))
+
+func TestCompile(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<!DOCTYPE html><html><head><title>Hello</title></head><body><nav><a href="/">Home</a></nav><main><p>world</p></main></body></html>`,
+			Actual: layout.Fill(map[string]ElementRenderer{
+				"title":   Text("Hello"),
+				"content": P().Text("world"),
+			}),
+		},
+		{
+			Expected: `<!DOCTYPE html><html><head><title></title></head><body><nav><a href="/">Home</a></nav><main></main></body></html>`,
+			Actual:   layout,
+		},
+		{
+			Expected: `<div><b>x</b></div>`,
+			Actual:   FillHoles(Div(Hole("x")), map[string]ElementRenderer{"x": B().Text("x")}),
+		},
+	})
+
+	ctx := WithHoles(context.Background(), map[string]ElementRenderer{
+		"title":   Text("From context"),
+		"content": Text("body"),
+	})
+	var sb strings.Builder
+	err := (&Renderer{}).RenderContext(ctx, &sb, layout.Fill(map[string]ElementRenderer{"content": Text("map")}))
+	assert.NoError(t, err)
+	assert.Equal(t, `<!DOCTYPE html><html><head><title>From context</title></head><body><nav><a href="/">Home</a></nav><main>map</main></body></html>`, sb.String())
+}
+
+func TestCompileRenderState(t *testing.T) {
+	holes := map[string]ElementRenderer{"v": Escaped(`</script>"`)}
+	contextual := &Renderer{Contextual: true}
+	compiled, err := contextual.Compile(Script(Hole("v")))
+	assert.NoError(t, err)
+	runWith(t, contextual, []result{
+		{
+			Expected: `<script>"\u003C\u002Fscript\u003E\u0022"</script>`,
+			Actual:   compiled.Fill(holes),
+		},
+	})
+
+	// Compiled content rendered with other options renders the tree.
+	runWith(t, &Renderer{Indent: "  "}, []result{
+		{
+			Expected: "<ul>\n  <li>a</li>\n</ul>",
+			Actual:   Static(Ul(Li(Hole("v")))).Fill(map[string]ElementRenderer{"v": Text("a")}),
+		},
+	})
+
+	indent := &Renderer{Indent: "  "}
+	compiled, err = indent.Compile(Div(Ul(Hole("items"))))
+	assert.NoError(t, err)
+	runWith(t, indent, []result{
+		{
+			Expected: "<div>\n  <ul><li>a</li><li>b</li></ul>\n</div>",
+			Actual:   compiled.Fill(map[string]ElementRenderer{"items": Group(Li().Text("a"), Li().Text("b"))}),
+		},
+	})
+}
+
+func TestCompileError(t *testing.T) {
+	_, err := Compile(Div().Attr("a b", "c"))
+	assert.ErrorIs(t, err, ErrInvalidAttributeName)
+	assert.Panics(t, func() { Static(Div().Attr("a b", "c")) })
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+type CardProps struct {
+	Title string
+}
+
+var Card = NewComponent(func(props CardProps, slots Slots) ElementRenderer {
+	return Div().Class("card").Children(
+		Header(slots.Get("header", H2().Text(props.Title))),
+		Div().Class("card-body").Children(slots.Children()),
+		If(slots.Has("footer"), Footer(slots.Get("footer"))),
+	)
+})
+
+type ModalProps struct {
+	ID   string
+	Open bool
+}
+
+var Modal = NewComponent(func(props ModalProps, slots Slots) ElementRenderer {
+	return Dialog().ID(props.ID).IfOpen(props.Open).Children(
+		H2(slots.Get("title", Text("Dialog"))),
+		slots.Children(),
+		Form().Attr("method", "dialog").Children(
+			slots.Get("actions", Button().Text("Close")),
+		),
+	)
+})
+
+type LayoutProps struct {
+	Title string
+}
+
+var Layout = NewComponent(func(props LayoutProps, slots Slots) ElementRenderer {
+	return Document(HTML().Lang("en").Children(
+		Head(Title().Text(props.Title)),
+		Body(
+			Nav(slots.Get("nav")),
+			Main(slots.Children()),
+			Footer(slots.Get("footer", Text("© speckles"))),
+		),
+	))
+})
+
+func TestComponents(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<div class="card"><header><h2>Title</h2></header><div class="card-body"><p>body</p></div></div>`,
+			Actual:   Card.New(CardProps{Title: "Title"}, P().Text("body")),
+		},
+		{
+			Expected: `<div class="card wide" data-id="7" id="c7"><header><b>custom</b></header><div class="card-body">a b</div><footer>more</footer></div>`,
+			Actual: Card.New(CardProps{Title: "ignored"}).
+				Slot("header", B().Text("custom")).
+				Children(Text("a")).
+				Children(Text(" b")).
+				Slot("footer", Text("more")).
+				Class("wide card").
+				Attr("id", "c7").
+				Attr("data-id", "7"),
+		},
+		{
+			Expected: `<dialog id="confirm" open><h2>Delete?</h2><p>This cannot be undone.</p><form method="dialog"><button>Close</button></form></dialog>`,
+			Actual: Modal.New(ModalProps{ID: "confirm", Open: true}, P().Text("This cannot be undone.")).
+				Slot("title", Text("Delete?")),
+		},
+		{
+			Expected: `<section><div class="card"><header><h2>a</h2></header><div class="card-body"></div></div><div class="card"><header><h2>b</h2></header><div class="card-body"></div></div></section>`,
+			Actual: Section(Range([]string{"a", "b"}, func(title string) ElementRenderer {
+				return Card.New(CardProps{Title: title})
+			})),
+		},
+		{
+			Expected: `<!DOCTYPE html><html lang="en"><head><title>Home</title></head><body><nav><a href="/">Home</a></nav><main><h1>Welcome</h1></main><footer>© speckles</footer></body></html>`,
+			Actual: Layout.New(LayoutProps{Title: "Home"}, H1().Text("Welcome")).
+				Slot("nav", A().Href("/").Text("Home")),
+		},
+	})
+}
+
+func TestComponentErrors(t *testing.T) {
+	var sb strings.Builder
+	err := Layout.New(LayoutProps{}).Class("x").Render(&sb)
+	assert.ErrorIs(t, err, ErrNoRootElement)
+
+	err = Card.New(CardProps{}).Attr("a b", "c").Render(&sb)
+	assert.ErrorIs(t, err, ErrInvalidAttributeName)
+}
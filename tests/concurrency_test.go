@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+// The stress tests are meant to run with go test -race.
+
+var sharedIcon = SVGSVG(SVGCircle().Cx(1.5).Cy(2.25).R(1)).Freeze()
+
+var Badge = NewComponent(func(label string, _ Slots) ElementRenderer {
+	// The root is shared by every instance.
+	return sharedBadge
+})
+
+var sharedBadge = Span().Class("badge").Text("new").Freeze()
+
+func sharedPage() *HTMLElement {
+	rows := make([]int, 10)
+	return HTML(
+		Head(Title().Text("Shared"), Script().Text("go()"), Style().Text("p{}")),
+		Body(
+			Div().ID("main").Class("container").StyleAdd("color", "red").Children(
+				Input().Type(InputTypeNumber).Name("count").Disabled(),
+				Ul(RangeI(rows, func(i, _ int) ElementRenderer {
+					return Li().Class("row").Textf("item %d", i)
+				})),
+				sharedIcon,
+				Badge.New("b").Class("highlight"),
+				Badge.New("b"),
+				Cached("shared", time.Minute, func() ElementRenderer {
+					return P().Text("cached")
+				}),
+			),
+		),
+	).Freeze()
+}
+
+func stress(t *testing.T, n int, fn func(i int)) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range n {
+				fn(i*n + j)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentRender(t *testing.T) {
+	page := sharedPage()
+	renderers := []*Renderer{
+		{},
+		{Contextual: true},
+		{Minify: true},
+		{Indent: "  "},
+		{XML: true},
+		{Transformers: []Transformer{func(_ context.Context, e *Element) { e.AddClass("t") }}},
+	}
+	expected := make([]string, len(renderers))
+	for i, r := range renderers {
+		var sb strings.Builder
+		assert.NoError(t, r.Render(&sb, page))
+		expected[i] = sb.String()
+	}
+	compiled, err := Compile(Document(page))
+	assert.NoError(t, err)
+	expectedCompiled, err := RenderString(compiled)
+	assert.NoError(t, err)
+
+	stress(t, 20, func(i int) {
+		r := renderers[i%len(renderers)]
+		var sb strings.Builder
+		ctx := WithCSPHashes(WithNonce(context.Background(), fmt.Sprint(i)), NewCSPHashes())
+		if !assert.NoError(t, r.RenderContext(ctx, &sb, page)) {
+			return
+		}
+		// The nonce is the only difference with the reference output.
+		nonce := regexp.MustCompile(fmt.Sprintf(` nonce="?%d"?`, i))
+		assert.Equal(t, expected[i%len(renderers)], nonce.ReplaceAllString(sb.String(), ""))
+
+		out, err := RenderString(compiled)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCompiled, out)
+	})
+}
+
+func TestConcurrentDerive(t *testing.T) {
+	page := sharedPage()
+	expected := page.String()
+	base := Button().Class("btn").StyleAdd("color", "red").Freeze()
+
+	stress(t, 20, func(i int) {
+		variant := base.Class(fmt.Sprintf("v%d", i)).StyleAdd("margin", "0").ID("b")
+		assert.Equal(t, fmt.Sprintf(`<button class="btn v%d" id="b" style="color:red;margin:0"></button>`, i), variant.String())
+
+		// Deriving from the shared tree leaves it untouched.
+		derived := page.Children(Footer()).Lang("en")
+		MustQuerySelector(derived, "li").Attr("title", "x")
+		page.Clone().Freeze()
+		assert.Equal(t, expected, page.String())
+	})
+	assert.Equal(t, `<button class="btn" style="color:red"></button>`, base.String())
+}
+
+func TestFreezeTree(t *testing.T) {
+	page := sharedPage()
+	for _, e := range MustQuerySelectorAll(page, "*") {
+		assert.True(t, e.Frozen(), e.Tag())
+	}
+
+	li := MustQuerySelector(page, "li")
+	assert.NotSame(t, li, li.Attr("title", "x"))
+	assert.False(t, li.HasAttr("title"))
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+type userKey struct{}
+
+func currentUser(ctx context.Context) ElementRenderer {
+	user, ok := ctx.Value(userKey{}).(string)
+	if !ok {
+		return Text("guest")
+	}
+	return Text(user)
+}
+
+func TestRenderContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), userKey{}, "ada")
+	page := Div(
+		Span(DynGroupContext(currentUser)),
+		DynIfContext(true, func(ctx context.Context) ElementRenderer {
+			return P().Text("hello")
+		}),
+		DynIfContext(false, func(ctx context.Context) ElementRenderer {
+			panic("not called")
+		}),
+	)
+
+	var sb strings.Builder
+	err := page.RenderContext(ctx, &sb)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div><span>ada</span><p>hello</p></div>`, sb.String())
+
+	sb.Reset()
+	err = Group(page).RenderContext(ctx, &sb)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div><span>ada</span><p>hello</p></div>`, sb.String())
+
+	// Without a context the callbacks receive context.Background.
+	sb.Reset()
+	err = page.Render(&sb)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div><span>guest</span><p>hello</p></div>`, sb.String())
+
+	sb.Reset()
+	err = (&Renderer{Indent: "  "}).RenderContext(ctx, &sb, Ul(Li(DynGroupContext(currentUser))))
+	assert.NoError(t, err)
+	assert.Equal(t, "<ul>\n  <li>ada</li>\n</ul>", sb.String())
+}
+
+func TestRenderContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var rendered []string
+	item := func(name string) ElementRenderer {
+		return DynGroupContext(func(ctx context.Context) ElementRenderer {
+			rendered = append(rendered, name)
+			if name == "b" {
+				cancel()
+			}
+			return Li().Text(name)
+		})
+	}
+
+	var sb strings.Builder
+	err := Ul(item("a"), item("b"), item("c")).RenderContext(ctx, &sb)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, []string{"a", "b"}, rendered)
+
+	var renderErr *RenderError
+	assert.True(t, errors.As(err, &renderErr))
+	assert.Equal(t, "ul", renderErr.Path)
+}
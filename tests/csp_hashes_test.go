@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func sha256Source(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
+
+func sha384Source(s string) string {
+	sum := sha512.Sum384([]byte(s))
+	return "'sha384-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
+
+var (
+	scriptBody = regexp.MustCompile(`<script>(.*?)</script>`)
+	styleBody  = regexp.MustCompile(`<style>(.*?)</style>`)
+	styleAttr  = regexp.MustCompile(`style=(?:"(.*?)"|([^\s>]+))`)
+)
+
+// expectedHashes hashes the inline bodies and style attributes found in the
+// rendered output.
+func expectedHashes(out string) (scripts, styles []string) {
+	for _, m := range scriptBody.FindAllStringSubmatch(out, -1) {
+		scripts = append(scripts, sha256Source(m[1]))
+	}
+	for _, m := range styleBody.FindAllStringSubmatch(out, -1) {
+		styles = append(styles, sha256Source(m[1]))
+	}
+	if attrs := styleAttr.FindAllStringSubmatch(out, -1); len(attrs) > 0 {
+		styles = append(styles, "'unsafe-hashes'")
+		for _, m := range attrs {
+			styles = append(styles, sha256Source(html.UnescapeString(m[1]+m[2])))
+		}
+	}
+	return scripts, styles
+}
+
+func hashedPage() ElementRenderer {
+	return HTML(
+		Head(
+			Style().Text("body{margin:0}"),
+			Script().Src("/app.js"),
+		),
+		Body(
+			Div().StyleAdd("color", "red").StyleAdd("content", `"&"`),
+			Script().Text(`if (a < b && c) { go("<b>") }`),
+			Script().Text("track()"),
+			Script().Text("track()"),
+		),
+	)
+}
+
+func TestCSPHashes(t *testing.T) {
+	for _, r := range []*Renderer{{}, {Contextual: true}, {Minify: true}} {
+		hashes := NewCSPHashes()
+		out := renderContextString(t, r, WithCSPHashes(context.Background(), hashes), hashedPage())
+
+		scripts, styles := expectedHashes(out)
+		assert.Len(t, scripts, 3)
+		assert.Equal(t, scripts[:2], hashes.ScriptSources())
+		assert.Equal(t, styles, hashes.StyleSources())
+		assert.Equal(t,
+			"script-src "+strings.Join(scripts[:2], " ")+"; style-src "+strings.Join(styles, " "),
+			hashes.Header(),
+		)
+	}
+
+	hashes := NewCSPHashes(crypto.SHA256, crypto.SHA384)
+	renderContextString(t, &Renderer{}, WithCSPHashes(context.Background(), hashes), Script().Text("go()"))
+	assert.Equal(t, "script-src "+sha256Source("go()")+" "+sha384Source("go()"), hashes.Header())
+
+	assert.Empty(t, NewCSPHashes().Header())
+	assert.Panics(t, func() { NewCSPHashes(crypto.MD5) })
+}
+
+func TestCSPHashesCompiled(t *testing.T) {
+	compiled, err := Compile(Body(
+		Script().Text("static()"),
+		Script(Hole("script")),
+		Div().Style("color: blue"),
+	))
+	assert.NoError(t, err)
+	page := compiled.Fill(map[string]ElementRenderer{"script": Text("dynamic()")})
+
+	hashes := NewCSPHashes()
+	out := renderContextString(t, &Renderer{}, WithCSPHashes(context.Background(), hashes), page)
+	assert.Equal(t, `<body><script>static()</script><script>dynamic()</script><div style="color:blue"></div></body>`, out)
+	assert.Equal(t, []string{sha256Source("static()"), sha256Source("dynamic()")}, hashes.ScriptSources())
+	assert.Equal(t, []string{"'unsafe-hashes'", sha256Source("color:blue")}, hashes.StyleSources())
+}
+
+func TestCSPHashesCached(t *testing.T) {
+	r := &Renderer{Cache: NewLRUCache(8)}
+	calls := 0
+	fragment := Cached("hashed", time.Minute, func() ElementRenderer {
+		calls++
+		return Group(Script().Text("go()"), Style().Text("p{}"))
+	})
+
+	for range 2 {
+		hashes := NewCSPHashes()
+		renderContextString(t, r, WithCSPHashes(context.Background(), hashes), Div(fragment))
+		assert.Equal(t, "script-src "+sha256Source("go()")+"; style-src "+sha256Source("p{}"), hashes.Header())
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestCSPHashesEscapedBody(t *testing.T) {
+	// Outside of HTML raw text, the hash covers the decoded body.
+	for _, tc := range []struct {
+		r        *Renderer
+		expected string
+		actual   ElementRenderer
+	}{
+		{
+			r:        &Renderer{},
+			expected: `<svg><script>if (a &lt; b) go()</script><style>a[x=&#34;y&#34;]{}</style></svg>`,
+			actual:   SVGSVG(SVGScript(Escaped("if (a < b) go()")), SVGStyle(Escaped(`a[x="y"]{}`))),
+		},
+		{
+			r:        &Renderer{XML: true},
+			expected: `<html xmlns="http://www.w3.org/1999/xhtml"><script>if (a &lt; b) go()</script><style>a[x=&#34;y&#34;]{}</style></html>`,
+			actual:   HTML(Script(Escaped("if (a < b) go()")), Style(Escaped(`a[x="y"]{}`))),
+		},
+	} {
+		hashes := NewCSPHashes()
+		assert.Equal(t, tc.expected, renderContextString(t, tc.r, WithCSPHashes(context.Background(), hashes), tc.actual))
+		assert.Equal(t, []string{sha256Source("if (a < b) go()")}, hashes.ScriptSources())
+		assert.Equal(t, []string{sha256Source(`a[x="y"]{}`)}, hashes.StyleSources())
+	}
+
+	// HTML raw text is hashed byte for byte.
+	hashes := NewCSPHashes()
+	assert.Equal(t, `<script>a &lt; b</script>`,
+		renderContextString(t, &Renderer{}, WithCSPHashes(context.Background(), hashes), Script(Escaped("a < b"))))
+	assert.Equal(t, []string{sha256Source("a &lt; b")}, hashes.ScriptSources())
+}
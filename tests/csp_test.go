@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func renderContextString(t *testing.T, r *Renderer, ctx context.Context, root ElementRenderer) string {
+	t.Helper()
+	var sb strings.Builder
+	assert.NoError(t, r.RenderContext(ctx, &sb, root))
+	return sb.String()
+}
+
+func TestNonce(t *testing.T) {
+	r := &Renderer{}
+	ctx := WithNonce(context.Background(), "r4nd")
+
+	assert.Equal(t,
+		`<head>`+
+			`<link href="/app.css" nonce="r4nd" rel="stylesheet">`+
+			`<style nonce="r4nd">p{}</style>`+
+			`<script nonce="r4nd" src="/app.js"></script>`+
+			`<script nonce="own">go()</script>`+
+			`<meta charset="utf-8">`+
+			`</head>`,
+		renderContextString(t, r, ctx, Head(
+			Link().Href("/app.css").Rel("stylesheet"),
+			Style().Text("p{}"),
+			Script().Src("/app.js"),
+			Script().Nonce("own").Text("go()"),
+			Meta().Charset("utf-8"),
+		)),
+	)
+
+	assert.Equal(t, `<script nonce="a&#34;b"></script>`,
+		renderContextString(t, r, WithNonce(context.Background(), `a"b`), Script()))
+
+	// No nonce is stamped without one in the context.
+	run(t, []result{
+		{
+			Expected: `<script>go()</script>`,
+			Actual:   Script().Text("go()"),
+		},
+	})
+
+	nonce, ok := NonceFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "r4nd", nonce)
+	_, ok = NonceFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestRequireNonce(t *testing.T) {
+	strict := &Renderer{RequireNonce: true}
+
+	var sb strings.Builder
+	err := strict.Render(&sb, Body(Div(Script().Text("go()"))))
+	assert.ErrorIs(t, err, ErrMissingNonce)
+	assert.ErrorContains(t, err, "body>div>script")
+
+	runWith(t, strict, []result{
+		{
+			Expected: `<script src="/app.js"></script><style>p{}</style>`,
+			Actual:   Group(Script().Src("/app.js"), Style().Text("p{}")),
+		},
+		{
+			Expected: `<script nonce="own">go()</script>`,
+			Actual:   Script().Nonce("own").Text("go()"),
+		},
+	})
+
+	assert.Equal(t, `<script nonce="r4nd">go()</script>`,
+		renderContextString(t, strict, WithNonce(context.Background(), "r4nd"), Script().Text("go()")))
+}
+
+func TestNonceCompiled(t *testing.T) {
+	strict := &Renderer{RequireNonce: true}
+	compiled, err := strict.Compile(Body(Script().Text("go()"), Main(Hole("content"))))
+	assert.NoError(t, err)
+	page := compiled.Fill(map[string]ElementRenderer{"content": Style().Text("p{}")})
+
+	for _, nonce := range []string{"first", "second"} {
+		assert.Equal(t,
+			`<body><script nonce="`+nonce+`">go()</script><main><style nonce="`+nonce+`">p{}</style></main></body>`,
+			renderContextString(t, strict, WithNonce(context.Background(), nonce), page),
+		)
+	}
+
+	var sb strings.Builder
+	err = strict.Render(&sb, page)
+	assert.ErrorIs(t, err, ErrMissingNonce)
+	assert.ErrorContains(t, err, "body>script")
+}
+
+func TestNonceCached(t *testing.T) {
+	r := &Renderer{Cache: NewLRUCache(8)}
+	calls := 0
+	fragment := Cached("nonce", time.Minute, func() ElementRenderer {
+		calls++
+		return Script().Text("go()")
+	})
+
+	// The fragment is stored once and replayed with the nonce of each render.
+	for _, nonce := range []string{"first", "second", "first"} {
+		assert.Equal(t, `<script nonce="`+nonce+`">go()</script>`,
+			renderContextString(t, r, WithNonce(context.Background(), nonce), fragment))
+	}
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, `<script>go()</script>`, renderContextString(t, r, context.Background(), fragment))
+	assert.Equal(t, 2, calls)
+
+	minified := &Renderer{Cache: NewLRUCache(8), Minify: true}
+	for nonce, expected := range map[string]string{
+		"a+b=": `<script nonce="a+b=">go()</script>`,
+		"c":    `<script nonce=c>go()</script>`,
+	} {
+		assert.Equal(t, expected, renderContextString(t, minified, WithNonce(context.Background(), nonce), fragment))
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestRequireNonceCached(t *testing.T) {
+	cache := NewLRUCache(8)
+	fragment := Cached("analytics", 0, func() ElementRenderer {
+		return Script().Text("track()")
+	})
+	runWith(t, &Renderer{Cache: cache}, []result{
+		{Expected: `<div><script>track()</script></div>`, Actual: Div(fragment)},
+	})
+
+	var sb strings.Builder
+	err := (&Renderer{Cache: cache, RequireNonce: true}).Render(&sb, Div(fragment))
+	assert.ErrorIs(t, err, ErrMissingNonce)
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentNodes(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<!DOCTYPE html><html><body></body></html>`,
+			Actual:   Document(HTML(Body())),
+		},
+		{
+			Expected: `<!DOCTYPE html>`,
+			Actual:   Document(),
+		},
+		{
+			Expected: `<div><!-- note --></div>`,
+			Actual:   Div(Comment(" note ")),
+		},
+		{
+			Expected: `<!--a- -b- - -c-->`,
+			Actual:   Comment("a--b---c"),
+		},
+		{
+			Expected: `<!-- ->x- -->`,
+			Actual:   Comment("->x-"),
+		},
+		{
+			Expected: `<!--item 3-->`,
+			Actual:   Commentf("item %d", 3),
+		},
+		{
+			Expected: `<![CDATA[a < b]]>`,
+			Actual:   CDATA("a < b"),
+		},
+		{
+			Expected: `<![CDATA[x]]]]><![CDATA[>y]]>`,
+			Actual:   CDATA("x]]>y"),
+		},
+		{
+			Expected: `<?xml version="1.0" encoding="UTF-8"?>`,
+			Actual:   XMLDeclaration(),
+		},
+		{
+			Expected: `<?xml-stylesheet href="style.css"?>`,
+			Actual:   ProcessingInstruction("xml-stylesheet", `href="style.css"`),
+		},
+	})
+}
+
+func TestDocumentIndent(t *testing.T) {
+	runWith(t, &Renderer{Indent: "  "}, []result{
+		{
+			Expected: "<!DOCTYPE html>\n<html>\n  <!-- head -->\n  <head></head>\n  <body></body>\n</html>",
+			Actual:   Document(HTML(Comment(" head "), Head(), Body())),
+		},
+	})
+}
+
+func TestProcessingInstructionErrors(t *testing.T) {
+	for _, pi := range []ElementRenderer{
+		ProcessingInstruction("", "x"),
+		ProcessingInstruction("XML", "x"),
+		ProcessingInstruction("1a", "x"),
+		ProcessingInstruction("a", "?>"),
+	} {
+		var sb strings.Builder
+		err := pi.Render(&sb)
+		assert.True(t, errors.Is(err, ErrInvalidProcessingInstruction))
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+var errWriteLimit = errors.New("write limit reached")
+
+// limitWriter accepts up to limit bytes and fails every write after that.
+type limitWriter struct {
+	limit  int
+	n      int
+	writes int
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.n+len(p) > w.limit {
+		return 0, errWriteLimit
+	}
+	w.n += len(p)
+	return len(p), nil
+}
+
+func page() ElementRenderer {
+	return HTML(
+		Head(Title().Text("Title")),
+		Body(
+			Div().ID("main").Children(
+				Ul(
+					Range([]string{"one", "two", "three"}, func(s string) ElementRenderer {
+						return Li().Text(s)
+					}),
+				),
+			),
+		),
+	)
+}
+
+func TestRenderErrorPath(t *testing.T) {
+	// Fail on the text of the third list item.
+	w := &limitWriter{limit: len(`<html><head><title>Title</title></head><body><div id="main"><ul><li>one</li><li>two</li><li>`)}
+	err := page().Render(w)
+
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.ErrorIs(t, err, errWriteLimit)
+	assert.Equal(t, "html>body>div#main>ul>li[3]", renderErr.Path)
+	assert.Equal(t, "failed to render html>body>div#main>ul>li[3]: write limit reached", err.Error())
+}
+
+func TestRenderStopsAfterWriteError(t *testing.T) {
+	w := &limitWriter{limit: 3}
+	err := page().Render(w)
+
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.Equal(t, "html", renderErr.Path)
+	// "<" is written, "html" fails and nothing is attempted afterwards.
+	assert.Equal(t, 2, w.writes)
+
+	w = &limitWriter{limit: 0}
+	err = Group(Text("a"), Div()).Render(w)
+	assert.ErrorIs(t, err, errWriteLimit)
+	assert.Equal(t, 1, w.writes)
+}
+
+func TestRenderErrorFromBuilder(t *testing.T) {
+	var w limitWriter
+	w.limit = 1 << 10
+	err := Div(P(), P().Attr("bad name", "x")).Render(&w)
+
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.ErrorIs(t, err, ErrInvalidAttributeName)
+	assert.Equal(t, "div>p[2]", renderErr.Path)
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+)
+
+func TestContextualURLAttributes(t *testing.T) {
+	contextual := &Renderer{Contextual: true}
+	runWith(t, contextual, []result{
+		{
+			Expected: `<a href="https://example.com/a%20b?q=1&amp;r=%222%22">x</a>`,
+			Actual:   A().Href(`https://example.com/a b?q=1&r="2"`).Text("x"),
+		},
+		{
+			Expected: `<a href="about:invalid#zSpecklesz"></a>`,
+			Actual:   A().Href("javascript:alert(1)"),
+		},
+		{
+			Expected: `<a href="about:invalid#zSpecklesz"></a>`,
+			Actual:   A().Href(" JaVaScRiPt:alert(1)"),
+		},
+		{
+			Expected: `<a href="/relative/path:with-colon"></a>`,
+			Actual:   A().Href("/relative/path:with-colon"),
+		},
+		{
+			Expected: `<a href="mailto:bob@example.com"></a>`,
+			Actual:   A().Href("mailto:bob@example.com"),
+		},
+		{
+			Expected: `<form action="about:invalid#zSpecklesz"><button formaction="about:invalid#zSpecklesz"></button></form>`,
+			Actual:   Form().Action("data:text/html,x").Children(Button().Formaction("vbscript:x")),
+		},
+		{
+			Expected: `<img src="about:invalid#zSpecklesz" srcset="a.png 1x,about:invalid#zSpecklesz 2x">`,
+			Actual:   Img().Src("javascript:x").Srcset("a.png 1x, javascript:x 2x"),
+		},
+		{
+			Expected: `<a ping="/a,about:invalid#zSpecklesz"></a>`,
+			Actual:   A().Ping("/a,javascript:x"),
+		},
+		{
+			Expected: `<a href="javascript:void(0)"></a>`,
+			Actual:   A().RawAttr("href", "javascript:void(0)"),
+		},
+		{
+			Expected: `<svg><a xlink:href="about:invalid#zSpecklesz"></a><image href="about:invalid#zSpecklesz"></image></svg>`,
+			Actual:   SVGSVG(SVGA().Attr("xlink:href", "javascript:alert(1)"), SVGImage().Attr("href", " javascript:alert(1)")),
+		},
+		{
+			Expected: `<iframe srcdoc="&amp;lt;script&amp;gt;alert(1)&amp;lt;/script&amp;gt;"></iframe>`,
+			Actual:   Iframe().Srcdoc("<script>alert(1)</script>"),
+		},
+	})
+
+	run(t, []result{
+		{
+			Expected: `<a href="javascript:alert(1)"></a>`,
+			Actual:   A().Href("javascript:alert(1)"),
+		},
+	})
+}
+
+func TestContextualEventHandlers(t *testing.T) {
+	contextual := &Renderer{Contextual: true}
+	runWith(t, contextual, []result{
+		{
+			Expected: `<button onclick="&#34;alert(1)&#34;"></button>`,
+			Actual:   Button().Attr("onclick", "alert(1)"),
+		},
+		{
+			Expected: `<button OnClick="&#34;\u0022);alert(1);(\u0022&#34;"></button>`,
+			Actual:   Button().Attr("OnClick", `");alert(1);("`),
+		},
+		{
+			Expected: `<button onclick="toggle(&#34;menu&#34;)"></button>`,
+			Actual:   Button().RawAttr("onclick", `toggle("menu")`),
+		},
+	})
+}
+
+func TestContextualStyles(t *testing.T) {
+	contextual := &Renderer{Contextual: true}
+	runWith(t, contextual, []result{
+		{
+			Expected: `<div style="color:red;transform:translate(1px, 2px)"></div>`,
+			Actual:   Div().StyleAdd("color", "red").StyleAdd("transform", "translate(1px, 2px)"),
+		},
+		{
+			Expected: `<div style="color:zSpecklesz"></div>`,
+			Actual:   Div().StyleAdd("color", "red; background: blue"),
+		},
+		{
+			Expected: `<div style="background:zSpecklesz;width:zSpecklesz"></div>`,
+			Actual:   Div().StyleAdd("background", "url(javascript:alert(1))").StyleAdd("width", "expression(alert(1))"),
+		},
+		{
+			Expected: `<div style="background:url(/img.png)"></div>`,
+			Actual:   Div().StyleAdd("background", "url(/img.png)"),
+		},
+		{
+			Expected: `<div style="color:blue"></div>`,
+			Actual:   Div().StyleAdd("}body{", "red").StyleAdd("color", "blue"),
+		},
+		{
+			Expected: `<div style="color:red;background:zSpecklesz"></div>`,
+			Actual:   Div().Attr("style", "color: red; background:url(javascript:alert(1))"),
+		},
+		{
+			Expected: `<p style="width:zSpecklesz;margin:0"></p>`,
+			Actual:   P().Attr("style", "width:expression(alert(1));}body{;margin:0"),
+		},
+	})
+}
+
+func TestContextualEscapedContent(t *testing.T) {
+	contextual := &Renderer{Contextual: true}
+	runWith(t, contextual, []result{
+		{
+			Expected: `<script>var name = "\u003C\u002Fscript\u003E\u003Cscript\u003Ealert(1)\u003C\u002Fscript\u003E";</script>`,
+			Actual:   Script(Text("var name = "), Escaped("</script><script>alert(1)</script>"), Text(";")),
+		},
+		{
+			Expected: `<style>.user-\7B \7D \3C \2F style\3E  { color: red }</style>`,
+			Actual:   Style(Text("."), Escaped("user-{}</style>"), Text(" { color: red }")),
+		},
+		{
+			Expected: `<p>&lt;b&gt;</p>`,
+			Actual:   P().Escaped("<b>"),
+		},
+	})
+}
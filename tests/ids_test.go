@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+type FieldProps struct {
+	Label string
+	Error string
+}
+
+var Field = NewComponentContext(func(ctx context.Context, props FieldProps, slots Slots) ElementRenderer {
+	id := UseID(ctx, "field")
+	errID := UseID(ctx, "error")
+	return Div().Class("field").Children(
+		Label().For(id).Text(props.Label),
+		Input().ID(id).IfAttr(props.Error != "", "aria-describedby", errID),
+		If(props.Error != "", P().ID(errID).Text(props.Error)),
+	)
+})
+
+func TestUseID(t *testing.T) {
+	form := Form(
+		Field.New(FieldProps{Label: "Email", Error: "Required"}),
+		Range([]string{"Name", "City"}, func(label string) ElementRenderer {
+			return Field.New(FieldProps{Label: label})
+		}),
+		DynGroupContext(func(ctx context.Context) ElementRenderer {
+			return Input().ID(UseID(ctx, "field"))
+		}),
+	)
+	const expected = `<form>` +
+		`<div class="field"><label for="field-1">Email</label><input aria-describedby="error-1" id="field-1"><p id="error-1">Required</p></div>` +
+		`<div class="field"><label for="field-2">Name</label><input id="field-2"></div>` +
+		`<div class="field"><label for="field-3">City</label><input id="field-3"></div>` +
+		`<input id="field-4">` +
+		`</form>`
+
+	// Each render starts over, so the IDs are the same.
+	run(t, []result{
+		{Expected: expected, Actual: form},
+		{Expected: expected, Actual: form},
+	})
+
+	var sb strings.Builder
+	err := form.RenderContext(context.Background(), &sb)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sb.String())
+
+	assert.Equal(t, "email", UseID(context.Background(), "email"))
+}
+
+func TestUseIDCached(t *testing.T) {
+	cache := NewLRUCache(8)
+	r := &Renderer{Cache: cache}
+	calls := 0
+	field := Cached("field", 0, func() ElementRenderer {
+		calls++
+		return DynGroupContext(func(ctx context.Context) ElementRenderer {
+			id := UseID(ctx, "f")
+			return Group(Label().For(id).Text("Name"), Input().ID(id))
+		})
+	})
+	page := Form(
+		field,
+		field,
+		DynGroupContext(func(ctx context.Context) ElementRenderer {
+			return Input().ID(UseID(ctx, "f"))
+		}),
+	)
+
+	// The cached fragments allocate their IDs on every render.
+	const expected = `<form>` +
+		`<label for="f-1">Name</label><input id="f-1">` +
+		`<label for="f-2">Name</label><input id="f-2">` +
+		`<input id="f-3">` +
+		`</form>`
+	runWith(t, r, []result{
+		{Expected: expected, Actual: page},
+		{Expected: expected, Actual: page},
+	})
+	assert.Equal(t, 1, calls)
+
+	// So do the fragments nested in cached fragments.
+	outer := Cached("outer", 0, func() ElementRenderer {
+		return Div(field, DynGroupContext(func(ctx context.Context) ElementRenderer {
+			return Span().ID(UseID(ctx, "f"))
+		}))
+	})
+	runWith(t, r, []result{
+		{
+			Expected: `<div><label for="f-1">Name</label><input id="f-1"><span id="f-2"></span></div>` +
+				`<div><label for="f-3">Name</label><input id="f-3"><span id="f-4"></span></div>`,
+			Actual: Group(outer, outer),
+		},
+	})
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderIndent(t *testing.T) {
+	indented := &Renderer{Indent: "  "}
+	runWith(t, indented, []result{
+		{
+			Expected: `<html>
+  <head>
+    <meta charset="utf-8">
+    <title>Home</title>
+  </head>
+  <body>
+    <div id="main">
+      <h1>Hello <em>world</em></h1>
+      <ul>
+        <li><a href="/">Home</a></li>
+        <li>About</li>
+      </ul>
+      <p>Some <span>inline <b>text</b></span>.</p>
+    </div>
+  </body>
+</html>`,
+			Actual: HTML(
+				Head(
+					Meta().Charset("utf-8"),
+					Title().Text("Home"),
+				),
+				Body(
+					Div().ID("main").Children(
+						H1().Text("Hello ").Children(Em().Text("world")),
+						Ul(
+							Range([]string{"Home", "About"}, func(s string) ElementRenderer {
+								return Li(If(s == "Home", A().Href("/").Text(s))).IfText(s != "Home", s)
+							}),
+						),
+						P().Text("Some ").Children(Span().Text("inline ").Children(B().Text("text")), Text(".")),
+					),
+				),
+			),
+		},
+		{
+			Expected: `<div></div>`,
+			Actual:   Div(),
+		},
+		{
+			// textarea is inline-level, so the section content stays as is.
+			Expected: "<section><pre>\n  keep\n    <div>as is</div></pre><textarea>\n x</textarea></section>",
+			Actual:   Section(Pre().Text("\n  keep\n    ").Children(Div().Text("as is")), Textarea().Text("\n x")),
+		},
+		{
+			Expected: "<section>\n  <pre>\n  keep\n    <div>as is</div></pre>\n</section>",
+			Actual:   Section(Pre().Text("\n  keep\n    ").Children(Div().Text("as is"))),
+		},
+		{
+			Expected: `<span><div><p>a</p></div></span>`,
+			Actual:   Span(Div(P().Text("a"))),
+		},
+		{
+			Expected: "<div>\n  <script>if (a) {\n  b()\n}</script>\n  <style>p { color: red }</style>\n</div>",
+			Actual:   Div(Script().Text("if (a) {\n  b()\n}"), Style().Text("p { color: red }")),
+		},
+		{
+			Expected: `<p>Icon: <svg><g><path d="M0 0"></path></g></svg></p>`,
+			Actual:   P().Text("Icon: ").Children(SVGSVG(SVGG(SVGPath().D("M0 0")))),
+		},
+		{
+			Expected: "<div>\n  <p>a</p>\n  <p>b</p>\n</div>\n<footer></footer>",
+			Actual:   Group(Div(P().Text("a"), P().Text("b")), Footer()),
+		},
+	})
+}
+
+func TestRenderIndentSVGAndMathML(t *testing.T) {
+	indented := &Renderer{Indent: "\t"}
+	runWith(t, indented, []result{
+		{
+			Expected: "<svg>\n\t<g>\n\t\t<circle r=\"1\"></circle>\n\t\t<text>Hello <tspan>world</tspan></text>\n\t</g>\n</svg>",
+			Actual:   SVGSVG(SVGG(SVGCircle().R(1), SVGText().Text("Hello ").Children(SVGTspan().Text("world")))),
+		},
+		{
+			Expected: "<math>\n\t<mfrac>\n\t\t<mn>1</mn>\n\t\t<mi>x</mi>\n\t</mfrac>\n</math>",
+			Actual:   MathMLMath(MathMLMfrac(MathMLMn().Text("1"), MathMLMi().Text("x"))),
+		},
+	})
+}
+
+func TestRenderIndentMethods(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, Ul(Li().Text("a")).RenderIndent(&sb, " "))
+	assert.Equal(t, "<ul>\n <li>a</li>\n</ul>", sb.String())
+
+	sb.Reset()
+	assert.NoError(t, Group(P(), P()).RenderIndent(&sb, " "))
+	assert.Equal(t, "<p></p>\n<p></p>", sb.String())
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntrospection(t *testing.T) {
+	label := Span().Text("label")
+	input := Input().
+		Type(InputTypeNumber).
+		Class("form-control wide").
+		Style("color: red").
+		Tabindex(2).
+		Disabled().
+		Hidden(InputHiddenEmpty).
+		Attr("data-x", "<y>")
+	circle := SVGCircle().R(1.5)
+	div := Div(label, input)
+
+	assert.Equal(t, "input", input.Tag())
+	assert.Equal(t, "circle", circle.Tag())
+	assert.Equal(t, []ElementRenderer{label, input}, div.GetChildren())
+
+	// The children are a copy.
+	div.GetChildren()[0] = nil
+	assert.Equal(t, label, div.GetChildren()[0])
+
+	v, ok := input.GetAttr("data-x")
+	assert.True(t, ok)
+	assert.Equal(t, "<y>", v)
+	v, ok = input.GetAttr("tabindex")
+	assert.True(t, ok)
+	assert.Equal(t, "2", v)
+	v, ok = input.GetAttr("disabled")
+	assert.True(t, ok)
+	assert.Equal(t, "", v)
+	_, ok = input.GetAttr("title")
+	assert.False(t, ok)
+	assert.True(t, input.HasAttr("hidden"))
+	assert.False(t, input.HasAttr("title"))
+
+	r, ok := circle.AttrValue("r")
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, r)
+
+	assert.True(t, input.HasClass("wide"))
+	assert.False(t, input.HasClass("form"))
+	assert.Equal(t, []string{"form-control", "wide"}, input.ClassList())
+	assert.True(t, Div().Attr("class", "a b").HasClass("b"))
+	assert.Nil(t, Div().ClassList())
+
+	var names []string
+	var values []any
+	for name, value := range input.Attributes() {
+		names = append(names, name)
+		values = append(values, value)
+	}
+	assert.Equal(t, []string{"class", "data-x", "disabled", "hidden", "style", "tabindex", "type"}, names)
+	assert.Equal(t, []any{"form-control wide", "<y>", true, InputHiddenEmpty, "color:red", 2, InputTypeNumber}, values)
+}
+
+func TestAttrValueTypes(t *testing.T) {
+	button := Button().Type(ButtonTypeSubmit).Accesskey('s')
+	typ, _ := button.AttrValue("type")
+	key, _ := button.AttrValue("accesskey")
+	assert.Equal(t, ButtonTypeSubmit, typ)
+	assert.Equal(t, 's', key)
+
+	// The values can be passed back to the setters.
+	copied := Button().Type(typ.(ButtonTypeChoice)).Accesskey(key.(rune))
+	assert.Equal(t, button.String(), copied.String())
+
+	// So can the values of parsed trees.
+	parsed := ParseString(`<button type="submit" accesskey="s"></button>`).(*ButtonElement)
+	typ, _ = parsed.AttrValue("type")
+	key, _ = parsed.AttrValue("accesskey")
+	assert.Equal(t, ButtonTypeSubmit, typ)
+	assert.Equal(t, 's', key)
+
+	// Attr sets strings.
+	typ, _ = Button().Attr("type", "reset").AttrValue("type")
+	assert.Equal(t, "reset", typ)
+}
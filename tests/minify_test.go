@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/aprikotdev/speckles/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMinified(t *testing.T) {
+	minified := &Renderer{Minify: true}
+	runWith(t, minified, []result{
+		{
+			Expected: `<html><head><title>Home</title><body><ul class="nav main"><li><a href=/>Home</a><li>About</ul><p>One<p>Two</p>text<div>x</div>`,
+			Actual: HTML(
+				Head(Title().Text("Home")),
+				Body(
+					Ul().Class("nav main").Children(
+						Li(A().Href("/").Text("Home")),
+						Li().Text("About"),
+					),
+					P().Text("One"),
+					P().Text("Two"),
+					Text("text"),
+					Div().Text("x"),
+				),
+			),
+		},
+		{
+			Expected: `<table><thead><tr><th>a<th>b<tbody><tr><td>1<td>2<tr><td>3<td>4</table>`,
+			Actual: Table(
+				Thead(Tr(Th().Text("a"), Th().Text("b"))),
+				Tbody(
+					Range([][]string{{"1", "2"}, {"3", "4"}}, func(row []string) ElementRenderer {
+						return Tr(Range(row, func(cell string) ElementRenderer { return Td().Text(cell) }))
+					}),
+				),
+			),
+		},
+		{
+			Expected: `<select><optgroup label=A><option value=1>One<option selected value=2>Two</select>`,
+			Actual: Select(
+				Optgroup().Label("A").Children(
+					Option().Value("1").Text("One"),
+					Option().Value("2").Selected().Text("Two"),
+				),
+			),
+		},
+		{
+			Expected: `<dl><dt>Term</dt><dt>Other<dd>Definition</dl>`,
+			Actual:   Dl(Dt().Text("Term"), Text(""), Dt().Text("Other"), Dd().Text("Definition")),
+		},
+		{
+			Expected: `<a href=/x><p>kept</p></a>`,
+			Actual:   A().Href("/x").Children(P().Text("kept")),
+		},
+		{
+			Expected: `<li>fragment roots keep their end tag</li>`,
+			Actual:   Li().Text("fragment roots keep their end tag"),
+		},
+		{
+			Expected: `<input placeholder="two words" title="a=b" type=text value>`,
+			Actual:   Input().Type(InputTypeText).Placeholder("two words").Attr("title", "a=b").Attr("value", ""),
+		},
+		{
+			Expected: `<div data-x=&#34;q&#34; title=a&amp;b></div>`,
+			Actual:   Div().Attr("data-x", `"q"`).Attr("title", "a&b"),
+		},
+		{
+			Expected: `<div><p> some text with spaces <b> bold </b><pre>  keep
+  this </pre><textarea> and
+ this</textarea><script>if (a)
+  b()</script></div>`,
+			Actual: Div(
+				P().Text("  some\n text   with\tspaces ").Children(B().Escaped(" bold  ")),
+				Pre().Text("  keep\n  this "),
+				Textarea().Text(" and\n this"),
+				Script().Text("if (a)\n  b()"),
+			),
+		},
+	})
+}
+
+// TestRenderMinifiedEndTags checks every HTML element of the configuration, as
+// the last child of a div and in front of a sibling of the same kind.
+func TestRenderMinifiedEndTags(t *testing.T) {
+	omittedLast := map[string]bool{
+		"body": true, "caption": true, "colgroup": true, "dd": true, "head": true,
+		"html": true, "li": true, "optgroup": true, "option": true, "p": true,
+		"rp": true, "rt": true, "tbody": true, "td": true, "tfoot": true,
+		"th": true, "tr": true,
+	}
+	omittedBeforeSame := map[string]bool{
+		"body": true, "caption": true, "colgroup": true, "dd": true, "dt": true,
+		"head": true, "html": true, "li": true, "optgroup": true, "option": true,
+		"p": true, "rp": true, "rt": true, "tbody": true, "td": true, "th": true,
+		"tr": true,
+	}
+
+	for _, el := range config.HTML.Elements {
+		if el.NoChildren {
+			continue
+		}
+		tag := el.Tag
+		end := "</" + tag + ">"
+
+		var sb strings.Builder
+		assert.NoError(t, Div(NewElement(tag)).RenderMinified(&sb), tag)
+		if omittedLast[tag] {
+			assert.Equal(t, "<div><"+tag+"></div>", sb.String(), tag)
+		} else {
+			assert.Equal(t, "<div><"+tag+">"+end+"</div>", sb.String(), tag)
+		}
+
+		sb.Reset()
+		assert.NoError(t, Div(NewElement(tag), NewElement(tag), Text("x")).RenderMinified(&sb), tag)
+		if omittedBeforeSame[tag] {
+			assert.True(t, strings.HasPrefix(sb.String(), "<div><"+tag+"><"+tag+">"), tag)
+		} else {
+			assert.True(t, strings.HasPrefix(sb.String(), "<div><"+tag+">"+end+"<"+tag+">"), tag)
+		}
+		assert.True(t, strings.HasSuffix(sb.String(), end+"x</div>"), tag)
+	}
+}
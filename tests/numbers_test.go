@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberAttributes(t *testing.T) {
+	tenth := 0.1
+	run(t, []result{
+		{
+			Expected: `<circle cx="1000000" cy="0.0000001" r="0.30000000000000004"></circle>`,
+			Actual:   SVGCircle().Cx(1e6).Cy(1e-7).R(tenth + 0.2),
+		},
+		{
+			Expected: `<circle cx="-2.5" cy="0" r="123456789012345680000"></circle>`,
+			Actual:   SVGCircle().Cx(-2.5).Cy(math.Copysign(0, -1)).R(123456789012345678901),
+		},
+		{
+			Expected: `<meter max="100" min="0" value="0.5"></meter>`,
+			Actual:   Meter().Min(0).Max(100).Value(0.5),
+		},
+		{
+			// flood-opacity declares a precision of 3 decimal places.
+			Expected: `<feFlood flood-opacity="0.333"></feFlood>`,
+			Actual:   SVGFeFlood().FloodOpacity(1.0 / 3),
+		},
+	})
+
+	runWith(t, &Renderer{Precision: 2}, []result{
+		{
+			Expected: `<circle cx="0.3" cy="0" r="1.01"></circle>`,
+			Actual:   SVGCircle().Cx(tenth + 0.2).Cy(-0.001).R(1.005000001),
+		},
+		{
+			Expected: `<circle cx="1000000" cy="12" r="0"></circle>`,
+			Actual:   SVGCircle().Cx(1e6).Cy(11.999).R(1e-7),
+		},
+		{
+			Expected: `<feFlood flood-opacity="0.33"></feFlood>`,
+			Actual:   SVGFeFlood().FloodOpacity(1.0 / 3),
+		},
+	})
+}
+
+func TestInvalidNumber(t *testing.T) {
+	for _, e := range []ElementRenderer{
+		SVGCircle().Cx(math.NaN()),
+		SVGCircle().R(math.Inf(1)),
+	} {
+		var sb strings.Builder
+		assert.ErrorIs(t, e.Render(&sb), ErrInvalidNumber)
+	}
+}
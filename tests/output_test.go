@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputHelpers(t *testing.T) {
+	div := Div(Text("a"), Escaped("<b>"))
+	const expected = `<div>a&lt;b&gt;</div>`
+
+	assert.Equal(t, expected, div.String())
+	assert.Equal(t, expected, fmt.Sprint(div))
+	assert.Equal(t, []byte(expected), div.Bytes())
+
+	dst, err := div.AppendHTML([]byte("x"))
+	assert.NoError(t, err)
+	assert.Equal(t, "x"+expected, string(dst))
+
+	var buf bytes.Buffer
+	n, err := div.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(expected)), n)
+	assert.Equal(t, expected, buf.String())
+
+	assert.Equal(t, "<br><br>", Group(Br(), Br()).String())
+	assert.Equal(t, "a &amp; b", Escaped("a & b").String())
+	assert.Equal(t, "<!DOCTYPE html>", Document().String())
+}
+
+func TestOutputHelpersError(t *testing.T) {
+	div := Div().Attr("a b", "c")
+
+	assert.Equal(t, "", div.String())
+	assert.Nil(t, div.Bytes())
+
+	dst, err := div.AppendHTML([]byte("x"))
+	assert.True(t, errors.Is(err, ErrInvalidAttributeName))
+	assert.Equal(t, "x", string(dst))
+
+	var buf bytes.Buffer
+	n, err := div.WriteTo(&buf)
+	assert.Error(t, err)
+	assert.Zero(t, n)
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(strings.NewReader(`<!doctype html>
+<html lang="en"><head><title>A &amp; B</title></head>
+<body><p class="a  b" style="color: red; background: url('x;y')">One<p>Two<br/></body></html>`))
+	assert.NoError(t, err)
+	assert.IsType(t, &DocumentContent{}, doc)
+	run(t, []result{
+		{
+			Expected: `<!DOCTYPE html>` + "\n" +
+				`<html lang="en"><head><title>A &amp; B</title></head>` + "\n" +
+				`<body><p class="a b" style="color:red;background:url('x;y')">One</p><p>Two<br></p></body></html>`,
+			Actual: doc,
+		},
+	})
+
+	_, err = Parse(iotest.ErrReader(errors.New("read failed")))
+	assert.EqualError(t, err, "read failed")
+}
+
+func TestParseTypes(t *testing.T) {
+	tree := ParseString(`<div id=main>
+		<input Type="checkbox" disabled value="x&quot;">
+		<svg viewbox="0 0 10 10"><path d="M0 0"/><circle r="1.5" cx="bad"/></svg>
+		<x-card Foo="1">card</x-card>
+	</div>`)
+	div, ok := tree.(*DivElement)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	input := MustQuerySelector(div, "input")
+	assert.True(t, input.HasAttr("disabled"))
+	assert.Equal(t, `<input disabled type="checkbox" value="x&#34;">`, input.String())
+	assert.IsType(t, &InputElement{}, div.GetChildren()[1])
+
+	// SVG names keep their case, typed values their kind.
+	svg := MustQuerySelector(div, "svg")
+	assert.Equal(t, `<svg viewBox="0 0 10 10"><path d="M0 0"></path><circle cx="bad" r="1.5"></circle></svg>`, svg.String())
+	assert.IsType(t, &SVGPathElement{}, svg.GetChildren()[0])
+
+	// Unknown elements are kept, with lowercase attribute names.
+	card := MustQuerySelector(div, "x-card")
+	assert.Equal(t, `<x-card foo="1">card</x-card>`, card.String())
+
+	// The parsed attributes are stored the way the setters store them.
+	div.Class("x").StyleAdd("color", "red")
+	MustQuerySelector(div, "input").RemoveAttr("disabled")
+	assert.Equal(t, `<div class="x" id="main" style="color:red">`, strings.SplitN(div.String(), "\n", 2)[0])
+	assert.False(t, MustQuerySelector(div, "input").HasAttr("disabled"))
+}
+
+func TestParseStructure(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<ul><li>a</li><li>b <b>c</b></li></ul>`,
+			Actual:   ParseString(`<ul><li>a<li>b <b>c</ul>`),
+		},
+		{
+			Expected: `<dl><dt>a</dt><dd>b</dd><dt>c</dt></dl>`,
+			Actual:   ParseString(`<dl><dt>a<dd>b<dt>c</dl>`),
+		},
+		{
+			Expected: `<table><tr><td>1</td><td>2</td></tr><tr><td>3</td></tr></table>`,
+			Actual:   ParseString(`<table><tr><td>1<td>2<tr><td>3</table>`),
+		},
+		{
+			Expected: `<select><option>a</option><optgroup><option>b</option></optgroup></select>`,
+			Actual:   ParseString(`<select><option>a<optgroup><option>b</select>`),
+		},
+		{
+			Expected: `<span>ab</span>`,
+			Actual:   ParseString(`<span>a</div>b</span>`),
+		},
+		{
+			Expected: `<svg><foreignObject><p>html</p></foreignObject></svg><p>after</p>`,
+			Actual:   ParseString(`<svg><foreignObject><p>html</p></foreignObject><p>after</svg>`),
+		},
+		{
+			Expected: `<math><mi>x</mi></math> text<!-- c -->`,
+			Actual:   ParseString(`<?xml version="1.0"?><math><mi>x</mi></math> text<!-- c --></x>`),
+		},
+	})
+}
+
+func TestParseText(t *testing.T) {
+	run(t, []result{
+		{
+			Expected: `<script>if (a < b && c) {}</script><style>p>a{}</style>`,
+			Actual:   ParseString(`<script>if (a < b && c) {}</script><style>p>a{}</style>`),
+		},
+		{
+			Expected: `<textarea>a&lt;b&gt; &amp;</textarea><pre>x</pre>`,
+			Actual:   ParseString("<textarea>\na<b> &amp;</textarea><pre>\nx</pre>"),
+		},
+		{
+			Expected: `<p>1 &lt; 2 &amp;&amp; ©</p>`,
+			Actual:   ParseString(`<p>1 < 2 &amp;&amp; &copy;</p>`),
+		},
+		{
+			Expected: `<svg><text>a&lt;b</text></svg>`,
+			Actual:   ParseString(`<svg><text><![CDATA[a<b]]></text></svg>`),
+		},
+		{
+			Expected: "<p\uFFFD a\uFFFD=\"&amp;#x;\"></p\uFFFD>",
+			Actual:   ParseString("<p\x00 a\x00='&#x;'>"),
+		},
+		{
+			Expected: `<p>&amp;#x; &amp;#X;A &amp;#; A</p>`,
+			Actual:   ParseString(`<p>&#x; &#X;&#x41; &#; &#65;</p>`),
+		},
+	})
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	page := HTML(
+		Head(Title().Text("Round trip"), Script().Text("go()")),
+		Body(
+			Div().ID("main").Class("container").Class("wide").StyleAdd("color", "red").Children(
+				Input().Type(InputTypeNumber).Name("count").Disabled(),
+				SVGSVG(SVGCircle().Cx(1.5).Cy(2.25).R(1)).ViewBox("0 0 4 4"),
+				Escaped("a < b"),
+			),
+		),
+	)
+	out := page.String()
+	assert.Equal(t, out, ParseString(out).(*HTMLElement).String())
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryPage() ElementRenderer {
+	return Document(HTML(Body(
+		Nav().ID("menu").Children(Ul(
+			Li(A().Href("/").Text("Home")),
+			Li().Class("item active").Children(A().Href("/blog").Text("Blog")),
+			If(true, Li(A().Href("/about").Attr("data-kind", "page").Text("About"))),
+			Li(Span().Text("Contact")),
+		)),
+		Main(
+			Img().Src("a.png"),
+			Section(P(Img().Src("b.png")), Img().Src("c.png")),
+		),
+	)))
+}
+
+func tags(elements []*Element) []string {
+	var tags []string
+	for _, e := range elements {
+		id, _ := e.GetAttr("src")
+		if href, ok := e.GetAttr("href"); ok {
+			id = href
+		}
+		tags = append(tags, e.Tag()+id)
+	}
+	return tags
+}
+
+func TestQuerySelector(t *testing.T) {
+	page := queryPage()
+
+	for selector, expected := range map[string][]string{
+		"img":                      {"imga.png", "imgb.png", "imgc.png"},
+		"main > img":               {"imga.png"},
+		"section img":              {"imgb.png", "imgc.png"},
+		"section > img":            {"imgc.png"},
+		"#menu a":                  {"a/", "a/blog", "a/about"},
+		"li.active > a":            {"a/blog"},
+		".item.active":             {"li"},
+		"a[data-kind=page]":        {"a/about"},
+		`a[href="/blog"], span`:    {"a/blog", "span"},
+		"[data-kind]":              {"a/about"},
+		"a[href=/blog]":            {"a/blog"},
+		"[src=c.png]":              {"imgc.png"},
+		"a[ href = /about ]":       {"a/about"},
+		"li:nth-child(2n+1) a":     {"a/", "a/about"},
+		"li:nth-child(odd) a":      {"a/", "a/about"},
+		"li:nth-child(even) a":     {"a/blog"},
+		"li:nth-child(3) a":        {"a/about"},
+		"li:nth-child(-n+2) > *":   {"a/", "a/blog"},
+		"li:first-child a":         {"a/"},
+		"body > nav > ul > li > a": {"a/", "a/blog", "a/about"},
+		"ul a span":                nil,
+		"* > span":                 {"span"},
+		"html":                     {"html"},
+	} {
+		assert.Equal(t, expected, tags(MustQuerySelectorAll(page, selector)), selector)
+	}
+
+	third := MustQuerySelector(page, "li:nth-child(2)")
+	assert.True(t, third.HasClass("active"))
+	assert.Nil(t, MustQuerySelector(page, "table"))
+
+	// Post-processing: lazy load the images of the main section.
+	for _, img := range MustQuerySelectorAll(page, "section img") {
+		img.Attr("loading", "lazy")
+	}
+	assert.Len(t, MustQuerySelectorAll(page, "img[loading=lazy]"), 2)
+}
+
+func TestParseSelector(t *testing.T) {
+	for _, selector := range []string{"", "div >", "a,", "[", "[x", `[x="y]`, "[x=]", "[x=a b]", ".", "#", ":hover", ":nth-child(2x)", "a!b", "a >> b"} {
+		_, err := ParseSelector(selector)
+		assert.ErrorIs(t, err, ErrInvalidSelector, selector)
+	}
+	assert.Panics(t, func() { MustQuerySelectorAll(Div(), "!") })
+}
+
+func TestWalk(t *testing.T) {
+	var nodes []string
+	Inspect(Div(Text("a"), Group(Span(Text("b")), nil), P()), func(node ElementRenderer) bool {
+		switch n := node.(type) {
+		case interface{ Tag() string }:
+			nodes = append(nodes, n.Tag())
+		case *TextContent:
+			nodes = append(nodes, n.Text)
+		case *Grouper:
+			nodes = append(nodes, "group")
+		}
+		// Skip the children of span.
+		_, isSpan := node.(*SpanElement)
+		return !isSpan
+	})
+	assert.Equal(t, []string{"div", "a", "group", "span", "p"}, nodes)
+}
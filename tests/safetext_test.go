@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+// builtEarly is built before safe text mode is enabled, the way package-level
+// trees are built before main runs.
+var builtEarly = P().Text("<early>")
+
+type point struct{ x, y int }
+
+func (p point) Format(f fmt.State, verb rune) {
+	if f.Flag('+') {
+		fmt.Fprintf(f, "<x=%d y=%d>", p.x, p.y)
+		return
+	}
+	fmt.Fprintf(f, "(%d,%d)", p.x, p.y)
+}
+
+func TestSafeText(t *testing.T) {
+	SetSafeText(true)
+	defer SetSafeText(false)
+	assert.True(t, SafeText())
+
+	run(t, []result{
+		{
+			Expected: `<p>a &lt;b&gt; &amp; c</p>`,
+			Actual:   P(Text("a <b> & c")),
+		},
+		{
+			Expected: `<p>&lt;i&gt;</p>`,
+			Actual:   P().Text("<i>"),
+		},
+		{
+			Expected: `<p><b>&lt;script&gt;</b> has 3 items, 42.50%</p>`,
+			Actual:   P(Textf("<b>%s</b> has %d items, %5.2f%%", "<script>", 3, 42.5)),
+		},
+		{
+			Expected: `<p>Hello, <em>&#34;Bob&#34;</em></p>`,
+			Actual:   P().Textf("Hello, <em>%q</em>", "Bob"),
+		},
+		{
+			Expected: `<p><em>ok</em></p>`,
+			Actual:   P().IfTextf(true, "<em>%s</em>", "ok").IfTextf(false, "%s", "<no>"),
+		},
+		{
+			Expected: `<p><b>raw</b><i>x</i></p>`,
+			Actual:   P(Raw("<b>raw</b>")).Raw("<i>x</i>"),
+		},
+		{
+			Expected: `<p><b>&amp;</b></p>`,
+			Actual:   P(Rawf("<b>%s</b>", "&amp;")),
+		},
+		{
+			Expected: `<p>&lt;early&gt;</p>`,
+			Actual:   builtEarly,
+		},
+		{
+			Expected: `<script>if (a < b && c) {}</script><style>p > a {}</style>`,
+			Actual:   Group(Script().Text("if (a < b && c) {}"), Style().Textf("p %s a {}", ">")),
+		},
+		{
+			Expected: `<script>var n = "<\/script><img src=x onerror=alert(1)>";</script>`,
+			Actual:   Script().Textf("var n = %q;", "</script><img src=x onerror=alert(1)>"),
+		},
+		{
+			Expected: `<style><\/style><script>alert(1)<\/script></style>`,
+			Actual:   Style().Text("</style><script>alert(1)</script>"),
+		},
+		{
+			Expected: `<script>a = "<\/SCRIPT <\!-- <\/Style";</script><script></script></script>`,
+			Actual:   Group(Script().Text(`a = "</SCRIPT <!-- </Style";`), Script(Raw("</script>"))),
+		},
+		{
+			Expected: `<p>tests.point &lt;x=1 y=2&gt; (1,2) [  &lt;a&gt;]</p>`,
+			Actual:   P().Textf("%T %+v %v [%*s]", point{1, 2}, point{1, 2}, point{1, 2}, 5, "<a>"),
+		},
+	})
+
+	SetSafeText(false)
+	run(t, []result{
+		{
+			Expected: `<p><b>x</b></p>`,
+			Actual:   P(Textf("<b>%s</b>", "x")),
+		},
+	})
+}
+
+func TestSafeTextCompiled(t *testing.T) {
+	compiled := Static(P().Text("<b>"))
+	run(t, []result{{Expected: `<p><b></p>`, Actual: compiled}})
+
+	SetSafeText(true)
+	defer SetSafeText(false)
+	run(t, []result{{Expected: `<p>&lt;b&gt;</p>`, Actual: compiled}})
+}
+
+func TestSafeTextCached(t *testing.T) {
+	r := &Renderer{Cache: NewLRUCache(8)}
+	fragment := Cached("safe", time.Minute, func() ElementRenderer {
+		return P().Text("<b>")
+	})
+	runWith(t, r, []result{{Expected: `<p><b></p>`, Actual: fragment}})
+
+	SetSafeText(true)
+	defer SetSafeText(false)
+	runWith(t, r, []result{{Expected: `<p>&lt;b&gt;</p>`, Actual: fragment}})
+}
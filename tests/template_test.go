@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateContent(t *testing.T) {
+	tmpl := template.Must(template.New("list").Parse(`<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{define "name"}}<b>{{.}}</b>{{end}}`))
+
+	run(t, []result{
+		{
+			Expected: `<div><ul><li>a</li><li>&lt;b&gt;</li></ul></div>`,
+			Actual:   Div(Template(tmpl, []string{"a", "<b>"})),
+		},
+		{
+			Expected: `<p><b>&lt;x&gt;</b></p>`,
+			Actual:   P(NamedTemplate(tmpl, "name", "<x>")),
+		},
+	})
+
+	var sb strings.Builder
+	err := Div(Section(NamedTemplate(tmpl, "missing", nil))).Render(&sb)
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.Equal(t, "div>section", renderErr.Path)
+
+	for _, root := range []ElementRenderer{
+		Script(Template(tmpl, []string{"</script>"})),
+		Style(NamedTemplate(tmpl, "name", "x")),
+	} {
+		_, err = RenderString(root)
+		assert.ErrorIs(t, err, ErrTemplateInRawText)
+	}
+}
+
+func TestTemplateHTML(t *testing.T) {
+	nav := Nav(A().Href("/").Text("Home"))
+
+	h, err := TemplateHTML(nav)
+	assert.NoError(t, err)
+	assert.Equal(t, template.HTML(`<nav><a href="/">Home</a></nav>`), h)
+
+	tmpl := template.Must(template.New("page").Funcs(FuncMap()).Parse(
+		`<body>{{speckles .Nav}}<p title="{{speckles .Nav}}">{{.Title}}</p></body>`,
+	))
+	var sb strings.Builder
+	err = tmpl.Execute(&sb, map[string]any{
+		"Nav":   nav,
+		"Title": "<hi>",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `<body><nav><a href="/">Home</a></nav><p title="Home">&lt;hi&gt;</p></body>`, sb.String())
+
+	tmpl = template.Must(template.New("page").Funcs(FuncMap()).Parse(`{{speckles .}}`))
+	err = tmpl.Execute(&sb, Div().Attr("a b", "c"))
+	assert.ErrorIs(t, err, ErrInvalidAttributeName)
+}
@@ -14,11 +14,22 @@ type result struct {
 }
 
 func run(t *testing.T, results []result) {
+	for _, result := range results {
+		e := result.Expected
+
+		a, err := RenderString(result.Actual)
+		assert.NoError(t, err)
+
+		assert.Equal(t, e, a)
+	}
+}
+
+func runWith(t *testing.T, r *Renderer, results []result) {
 	for _, result := range results {
 		var sb strings.Builder
 		e := result.Expected
 
-		err := result.Actual.Render(&sb)
+		err := r.Render(&sb, result.Actual)
 		assert.NoError(t, err)
 
 		a := sb.String()
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+type nonceKey struct{}
+
+func injectNonce(ctx context.Context, e *Element) {
+	if nonce, ok := ctx.Value(nonceKey{}).(string); ok && (e.Tag() == "script" || e.Tag() == "style") {
+		e.Attr("nonce", nonce)
+	}
+}
+
+func noopener(_ context.Context, e *Element) {
+	if e.Tag() != "a" {
+		return
+	}
+	if target, _ := e.GetAttr("target"); target != "_blank" {
+		return
+	}
+	if href, _ := e.GetAttr("href"); strings.HasPrefix(href, "http") {
+		e.Attr("rel", "noopener")
+	}
+}
+
+func cdn(_ context.Context, e *Element) {
+	for _, name := range []string{"src", "href"} {
+		if v, ok := e.GetAttr(name); ok && strings.HasPrefix(v, "/assets/") {
+			e.Attr(name, "https://cdn.example.com"+v)
+		}
+	}
+}
+
+func stripTestAttributes(_ context.Context, e *Element) {
+	var names []string
+	for name := range e.Attributes() {
+		if strings.HasPrefix(name, "data-test-") {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		e.RemoveAttr(name)
+	}
+}
+
+func TestTransformers(t *testing.T) {
+	r := &Renderer{Transformers: []Transformer{injectNonce, noopener, cdn, stripTestAttributes}}
+
+	page := Div(
+		Script().Src("/assets/app.js"),
+		Style().Text("p{}"),
+		A().Href("https://example.com").Target("_blank").Text("out"),
+		A().Href("/about").Target("_blank").Text("in"),
+		Img().Src("/assets/logo.png").Attr("data-test-id", "logo").Attr("data-testing", "kept"),
+	)
+	before, err := RenderString(page)
+	assert.NoError(t, err)
+
+	var sb strings.Builder
+	ctx := context.WithValue(context.Background(), nonceKey{}, "abc")
+	assert.NoError(t, r.RenderContext(ctx, &sb, page))
+	assert.Equal(t, `<div>`+
+		`<script nonce="abc" src="https://cdn.example.com/assets/app.js"></script>`+
+		`<style nonce="abc">p{}</style>`+
+		`<a href="https://example.com" rel="noopener" target="_blank">out</a>`+
+		`<a href="/about" target="_blank">in</a>`+
+		`<img data-testing="kept" src="https://cdn.example.com/assets/logo.png">`+
+		`</div>`, sb.String())
+
+	after, err := RenderString(page)
+	assert.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	runWith(t, &Renderer{Transformers: []Transformer{
+		func(_ context.Context, e *Element) { e.AddClass("a") },
+		func(_ context.Context, e *Element) { e.AddClass("b", "a") },
+	}}, []result{
+		{
+			Expected: `<p class="x a b"><span class="a b"></span></p>`,
+			Actual:   P().Class("x").Children(Span()),
+		},
+	})
+}
+
+func TestTransformersCached(t *testing.T) {
+	cache := NewLRUCache(8)
+	fragment := Cached("transformed", time.Minute, func() ElementRenderer {
+		return P().Text("cached")
+	})
+	runWith(t, &Renderer{Cache: cache}, []result{
+		{Expected: `<p>cached</p>`, Actual: fragment},
+	})
+	runWith(t, &Renderer{Cache: cache, Transformers: []Transformer{func(_ context.Context, e *Element) { e.AddClass("t") }}}, []result{
+		{Expected: `<p class="t">cached</p>`, Actual: fragment},
+	})
+
+	// Renderers built per request share the fragments of their key.
+	calls := 0
+	counted := Cached("keyed", time.Minute, func() ElementRenderer {
+		calls++
+		return P().Text("cached")
+	})
+	for _, class := range []string{"a", "a", "b"} {
+		r := &Renderer{Cache: cache, TransformersKey: class, Transformers: []Transformer{
+			func(_ context.Context, e *Element) { e.AddClass(class) },
+		}}
+		runWith(t, r, []result{
+			{Expected: `<p class="` + class + `">cached</p>`, Actual: counted},
+		})
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestTransformersContext(t *testing.T) {
+	cache := NewLRUCache(8)
+	r := &Renderer{Cache: cache, Transformers: []Transformer{injectNonce}}
+	fragment := Cached("context", time.Minute, func() ElementRenderer {
+		return Script().Src("/app.js")
+	})
+	compiled, err := r.Compile(Div(Script().Src("/app.js")))
+	assert.NoError(t, err)
+
+	// Without a key, the transformers are applied with the context of each
+	// render.
+	for _, nonce := range []string{"a", "b"} {
+		var sb strings.Builder
+		ctx := context.WithValue(context.Background(), nonceKey{}, nonce)
+		assert.NoError(t, r.RenderContext(ctx, &sb, Group(fragment, compiled)))
+		assert.Equal(t, `<script nonce="`+nonce+`" src="/app.js"></script>`+
+			`<div><script nonce="`+nonce+`" src="/app.js"></script></div>`, sb.String())
+	}
+}
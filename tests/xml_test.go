@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/aprikotdev/speckles/elements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderXML(t *testing.T) {
+	xml := &Renderer{XML: true}
+	runWith(t, xml, []result{
+		{
+			Expected: `<svg height="200" viewBox="0 0 200 200" width="200" xmlns="http://www.w3.org/2000/svg"><circle cx="100" cy="100" r="80"/><g/></svg>`,
+			Actual: SVGSVG().Width("200").Height("200").ViewBox("0 0 200 200").Children(
+				SVGCircle().Cx(100).Cy(100).R(80),
+				SVGG(If(false, SVGRect())),
+			),
+		},
+		{
+			Expected: `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><defs><circle id="dot" r="1"/></defs><use xlink:href="#dot"/></svg>`,
+			Actual: SVGSVG(
+				SVGDefs(SVGCircle().ID("dot").R(1)),
+				SVGUse().Attr("xlink:href", "#dot"),
+			),
+		},
+		{
+			Expected: `<svg xmlns="http://www.w3.org/2000/svg"></svg>`,
+			Actual:   SVGSVG().Attr("xmlns", "http://www.w3.org/2000/svg").Children(Text("")),
+		},
+		{
+			Expected: `<math xmlns="http://www.w3.org/1998/Math/MathML"><mfrac><mn>1</mn><mn>3</mn></mfrac></math>`,
+			Actual:   MathMLMath(MathMLMfrac(MathMLMn().Text("1"), MathMLMn().Text("3"))),
+		},
+		{
+			Expected: `<div xmlns="http://www.w3.org/1999/xhtml"><input disabled="disabled" type="checkbox"/><br/><p title="a &lt; b &amp; &#34;c&#34;">1 &lt; 2</p><svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0"/></svg></div>`,
+			Actual: Div(
+				Input().Type(InputTypeCheckbox).Disabled(),
+				Br(),
+				P().Attr("title", `a < b & "c"`).Escaped("1 < 2"),
+				SVGSVG(SVGPath().D("M0 0")),
+			),
+		},
+	})
+}
+
+func TestRenderXMLDisablesMinify(t *testing.T) {
+	var sb strings.Builder
+	err := (&Renderer{XML: true, Minify: true}).Render(&sb, Ul(Li().Class("a").Text("x")))
+	assert.NoError(t, err)
+	assert.Equal(t, `<ul xmlns="http://www.w3.org/1999/xhtml"><li class="a">x</li></ul>`, sb.String())
+
+	sb.Reset()
+	assert.NoError(t, SVGG(SVGCircle()).RenderXML(&sb))
+	assert.Equal(t, `<g xmlns="http://www.w3.org/2000/svg"><circle/></g>`, sb.String())
+}
+
+func TestRenderXMLMinifyPreservesSpace(t *testing.T) {
+	var sb strings.Builder
+	err := (&Renderer{XML: true, Minify: true}).Render(&sb, Div(
+		P().Text("a   b"),
+		Pre().Text("a   b\n  c"),
+		Script().Text("// comment\ngo()"),
+		Style().Text("p {\n  color: red;\n}"),
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, `<div xmlns="http://www.w3.org/1999/xhtml"><p>a b</p><pre>a   b`+"\n"+`  c</pre>`+
+		`<script>// comment`+"\n"+`go()</script><style>p {`+"\n"+`  color: red;`+"\n"+`}</style></div>`, sb.String())
+}